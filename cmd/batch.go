@@ -7,6 +7,7 @@ import (
 	"math"
 	"os/signal"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/notify"
 	"github.com/sells-group/research-cli/internal/pipeline"
 	"github.com/sells-group/research-cli/internal/resilience"
 	"github.com/sells-group/research-cli/internal/store"
@@ -87,12 +89,16 @@ var batchCmd = &cobra.Command{
 			sfExp.SetDeferredMode(true)
 		}
 
-		batchErr := processBatch(ctx, leads, batchLimit, cfg.Batch.MaxConcurrentCompanies, env.Notion, env.Store, dlqMaxRetries, func(ctx context.Context, company model.Company) (*model.EnrichmentResult, error) {
+		start := time.Now()
+		stats, batchErr := processBatch(ctx, leads, batchLimit, cfg.Batch.MaxConcurrentCompanies, env.Notion, env.Store, dlqMaxRetries, func(ctx context.Context, company model.Company) (*model.EnrichmentResult, error) {
 			return env.Pipeline.Run(ctx, company)
 		})
 		if batchErr != nil {
 			return batchErr
 		}
+		if stats.Succeeded+stats.Failed > 0 {
+			sendRunSummary(ctx, batchSummary(start, stats))
+		}
 
 		// Flush exporters (deferred SF writes + any others).
 		if err := env.Pipeline.FlushExporters(ctx); err != nil {
@@ -317,30 +323,39 @@ func runBatchViaTemporal(ctx context.Context, cmd *cobra.Command) error {
 		companies[i] = leadToCompany(lead)
 	}
 
-	workflowID := temporalpkg.NewWorkflowID("batch-enrich")
+	run, err := startBatchEnrich(ctx, c, companies)
+	if err != nil {
+		return err
+	}
+
+	var result temporalenrich.BatchEnrichResult
+	if err := run.Get(ctx, &result); err != nil {
+		return eris.Wrap(err, "batch enrich workflow failed")
+	}
+
+	printOutputf(cmd, "Batch complete: %d succeeded, %d failed\n", result.Succeeded, result.Failed)
+	return nil
+}
+
+// startBatchEnrich starts a BatchEnrichWorkflow for companies on the
+// enrichment task queue.
+func startBatchEnrich(ctx context.Context, c client.Client, companies []model.Company) (client.WorkflowRun, error) {
 	run, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
-		ID:        workflowID,
+		ID:        temporalpkg.NewWorkflowID("batch-enrich"),
 		TaskQueue: temporalpkg.EnrichmentTaskQueue,
 	}, temporalenrich.BatchEnrichWorkflow, temporalenrich.BatchEnrichParams{
 		Companies:   companies,
 		Concurrency: cfg.Batch.MaxConcurrentCompanies,
 	})
 	if err != nil {
-		return eris.Wrap(err, "start batch enrich workflow")
+		return nil, eris.Wrap(err, "start batch enrich workflow")
 	}
 
 	zap.L().Info("batch enrich workflow started",
 		zap.String("workflow_id", run.GetID()),
 		zap.Int("companies", len(companies)),
 	)
-
-	var result temporalenrich.BatchEnrichResult
-	if err := run.Get(ctx, &result); err != nil {
-		return eris.Wrap(err, "batch enrich workflow failed")
-	}
-
-	printOutputf(cmd, "Batch complete: %d succeeded, %d failed\n", result.Succeeded, result.Failed)
-	return nil
+	return run, nil
 }
 
 func leadToCompany(page notionapi.Page) model.Company {
@@ -389,15 +404,23 @@ func leadToCompany(page notionapi.Page) model.Company {
 // enrichFunc is the callback signature for running enrichment on a company.
 type enrichFunc func(ctx context.Context, company model.Company) (*model.EnrichmentResult, error)
 
+// batchStats summarizes a processed batch.
+type batchStats struct {
+	Succeeded int64
+	Failed    int64
+	Enqueued  int64
+	Failures  []notify.Item // failed companies with their errors
+}
+
 // processBatch applies limit, then processes leads concurrently using the given enrichment function.
 // If notionClient is non-nil, failed enrichments update the Notion page status to "Failed".
 // Failed companies with transient errors are enqueued to the dead letter queue for later retry.
 func processBatch(ctx context.Context, leads []notionapi.Page, limit, concurrency int, notionClient notion.Client, st interface {
 	EnqueueDLQ(ctx context.Context, entry resilience.DLQEntry) error
-}, dlqMaxRetries int, enrich enrichFunc) error {
+}, dlqMaxRetries int, enrich enrichFunc) (batchStats, error) {
 	if len(leads) == 0 {
 		zap.L().Info("no queued leads found")
-		return nil
+		return batchStats{}, nil
 	}
 
 	// Apply limit
@@ -414,16 +437,24 @@ func processBatch(ctx context.Context, leads []notionapi.Page, limit, concurrenc
 	g.SetLimit(concurrency)
 
 	var succeeded, failed, enqueued atomic.Int64
+	var failuresMu sync.Mutex
+	var failures []notify.Item
 
 	for _, lead := range leads {
 		company := leadToCompany(lead)
 		g.Go(func() error {
 			log := zap.L().With(zap.String("company", company.URL))
 
+			start := time.Now()
 			result, err := enrich(gctx, company)
 			if err != nil {
 				failed.Add(1)
 				log.Error("enrichment failed", zap.Error(err))
+				failuresMu.Lock()
+				failures = append(failures, notify.Item{
+					Name: company.URL, Status: notify.StatusFailed, Duration: time.Since(start), Error: err.Error(),
+				})
+				failuresMu.Unlock()
 				if notionClient != nil && company.NotionPageID != "" {
 					// Use a detached context so the Notion update succeeds even
 					// if the batch context has been cancelled.
@@ -468,16 +499,35 @@ func processBatch(ctx context.Context, leads []notionapi.Page, limit, concurrenc
 		})
 	}
 
-	if err := g.Wait(); err != nil {
-		return eris.Wrap(err, "batch processing")
+	// Read the counters only once every worker has finished.
+	waitErr := g.Wait()
+	stats := batchStats{Succeeded: succeeded.Load(), Failed: failed.Load(), Enqueued: enqueued.Load(), Failures: failures}
+	if waitErr != nil {
+		return stats, eris.Wrap(waitErr, "batch processing")
 	}
 
 	zap.L().Info("batch complete",
-		zap.Int64("succeeded", succeeded.Load()),
-		zap.Int64("failed", failed.Load()),
-		zap.Int64("enqueued_dlq", enqueued.Load()),
+		zap.Int64("succeeded", stats.Succeeded),
+		zap.Int64("failed", stats.Failed),
+		zap.Int64("enqueued_dlq", stats.Enqueued),
 	)
-	return nil
+	return stats, nil
+}
+
+// batchSummary converts batch stats into a run summary for notifications.
+func batchSummary(start time.Time, stats batchStats) notify.Summary {
+	s := notify.Summary{
+		Source:    "batch",
+		StartedAt: start,
+		Duration:  time.Since(start),
+		Succeeded: int(stats.Succeeded),
+		Failed:    int(stats.Failed),
+		Items:     stats.Failures,
+	}
+	if stats.Enqueued > 0 {
+		s.Anomalies = append(s.Anomalies, fmt.Sprintf("%d companies enqueued to the dead letter queue", stats.Enqueued))
+	}
+	return s
 }
 
 // dlqBackoff computes the next retry delay using exponential backoff.
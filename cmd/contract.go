@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os/signal"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/contract"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/monitoring"
+	"github.com/sells-group/research-cli/internal/registry"
+	"github.com/sells-group/research-cli/pkg/notion"
+)
+
+var contractAlert bool
+
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Salesforce and Notion schema contract checks",
+}
+
+var contractCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Verify Salesforce fields and Notion properties the pipeline depends on",
+	Long: `Describes every Salesforce object referenced by the field registry and
+retrieves the Notion lead, field, and question databases, then verifies that
+each mapped field, picklist value, and required property still exists with the
+expected type. Exits non-zero when any violation is found.
+
+Run nightly (cron or "serve" with monitoring.contract_check_hours) so schema
+drift alerts before batch runs start failing with opaque write errors.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if cfg.Notion.Token == "" {
+			return eris.New("contract: notion.token is required")
+		}
+		notionClient := notion.NewClient(cfg.Notion.Token)
+
+		sfClient, err := initSalesforce()
+		if err != nil {
+			return eris.Wrap(err, "contract: init salesforce")
+		}
+
+		var fields *model.FieldRegistry
+		if cfg.Notion.FieldDB != "" {
+			fields, err = registry.LoadFieldRegistry(ctx, notionClient, cfg.Notion.FieldDB)
+			if err != nil {
+				zap.L().Warn("contract: field registry unavailable, checking pipeline fields only", zap.Error(err))
+			}
+		}
+
+		report, err := contract.NewChecker(sfClient, notionClient, fields, cfg.Notion, cfg.Pipeline.SFDiff).Run(ctx)
+		if err != nil {
+			return eris.Wrap(err, "contract: check")
+		}
+
+		printOutputf(cmd, "Checked %d fields/properties\n", report.Checked)
+		if report.OK() {
+			printOutputln(cmd, "No contract violations found")
+			return nil
+		}
+		for _, issue := range report.Issues {
+			printOutputf(cmd, "  %s\n", issue)
+		}
+
+		if contractAlert {
+			sent := monitoring.NewAlerter(cfg.Monitoring).SendAlerts(ctx, []monitoring.Alert{report.Alert()})
+			zap.L().Info("contract: alert sent", zap.Int("sent", sent))
+		}
+		return eris.Errorf("contract: %d violation(s) found", len(report.Issues))
+	},
+}
+
+func init() {
+	contractCheckCmd.Flags().BoolVar(&contractAlert, "alert", false, "send violations to monitoring.webhook_url")
+	contractCmd.AddCommand(contractCheckCmd)
+	rootCmd.AddCommand(contractCmd)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/datadict"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+var datadictCmd = &cobra.Command{
+	Use:   "datadict",
+	Short: "Generate a data dictionary for fed_data and geo tables",
+	Long: `Introspects the fed_data and geo schemas and writes a browsable data
+dictionary: every table with its columns and Postgres types, an estimated
+row count, and the datasets that load it with their descriptions, cadence,
+and last successful sync. Column descriptions come from datasets that
+declare them (Columns() []ColumnDoc), falling back to Postgres comments.
+
+--format picks markdown (default) or html; with --out unset the dictionary
+is written to stdout. A --format of html is inferred from an .html --out.`,
+	Example: `  research-cli datadict --out docs/data-dictionary.md
+  research-cli datadict --format html --out dictionary.html --schema fed_data`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		out, _ := cmd.Flags().GetString("out")
+		format, err := datadict.ParseFormat(datadictFormat(cmd, out))
+		if err != nil {
+			return err
+		}
+		schemas, _ := cmd.Flags().GetStringSlice("schema")
+
+		catalog, err := dataset.BuildCatalog(cfg)
+		if err != nil {
+			return eris.Wrap(err, "datadict")
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		stats, err := fedsync.NewSyncLog(pool).RunStats(ctx)
+		if err != nil {
+			return eris.Wrap(err, "datadict")
+		}
+
+		dict, err := datadict.Build(ctx, pool, schemas, datadict.Sources{
+			Datasets: dataset.NewRegistry(cfg).All(),
+			Catalog:  catalog.Datasets,
+			Stats:    stats,
+		})
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = commandOutputWriter(cmd)
+		if out != "" {
+			f, err := os.Create(out)
+			if err != nil {
+				return eris.Wrap(err, "datadict: create output")
+			}
+			defer f.Close() //nolint:errcheck
+			w = f
+		}
+		if err := datadict.Write(w, dict, format); err != nil {
+			return err
+		}
+		if out != "" {
+			printOutputf(cmd, "wrote %d tables to %s\n", len(dict.Tables), out)
+		}
+		return nil
+	},
+}
+
+func init() {
+	datadictCmd.Flags().String("format", datadict.FormatMarkdown, "output format: markdown or html")
+	datadictCmd.Flags().String("out", "", "output file (default stdout)")
+	datadictCmd.Flags().StringSlice("schema", datadict.DefaultSchemas, "schemas to document")
+	rootCmd.AddCommand(datadictCmd)
+}
+
+// datadictFormat returns --format, or html when --format is unset and out
+// ends in .html or .htm.
+func datadictFormat(cmd *cobra.Command, out string) string {
+	format, _ := cmd.Flags().GetString("format")
+	if !cmd.Flags().Changed("format") {
+		switch strings.ToLower(filepath.Ext(out)) {
+		case ".html", ".htm":
+			return datadict.FormatHTML
+		}
+	}
+	return format
+}
@@ -0,0 +1,28 @@
+//go:build !integration
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync/datadict"
+)
+
+func TestDatadictFormat(t *testing.T) {
+	format := func(out string, args ...string) string {
+		cmd := &cobra.Command{Use: "test-datadict"}
+		cmd.Flags().String("format", datadict.FormatMarkdown, "")
+		require.NoError(t, cmd.ParseFlags(args))
+		return datadictFormat(cmd, out)
+	}
+
+	assert.Equal(t, datadict.FormatMarkdown, format(""))
+	assert.Equal(t, datadict.FormatMarkdown, format("dict.md"))
+	assert.Equal(t, datadict.FormatHTML, format("dict.HTML"))
+	assert.Equal(t, datadict.FormatMarkdown, format("dict.html", "--format", "markdown"), "explicit --format wins")
+	assert.Equal(t, datadict.FormatHTML, format("", "--format", "html"))
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -31,3 +33,14 @@ func datasetCommandLong() string {
 func fedsyncPool(ctx context.Context) (*pgxpool.Pool, error) {
 	return openReadModelPool(ctx)
 }
+
+// applyFedsyncMemoryLimit sets the Go runtime's soft memory limit to mb
+// megabytes (fedsync.streaming.memory_limit_mb). Zero or less leaves the
+// limit alone, so GOMEMLIMIT still applies.
+func applyFedsyncMemoryLimit(mb int) {
+	if mb <= 0 {
+		return
+	}
+	debug.SetMemoryLimit(int64(mb) << 20)
+	zap.L().Info("fedsync memory limit set", zap.Int("memory_limit_mb", mb))
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/manifest"
+)
+
+var fedsyncBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Load explicit historical years or quarters of a dataset",
+	Long: `Load explicit historical periods of one dataset, overriding its default
+latest-period window.
+
+Supported datasets: cbp, susb, oews, qcew, econ_census (by year) and
+holdings_13f (by year or quarter). Periods are comma-separated values or
+inclusive ranges:
+
+  fedsync backfill --dataset cbp --years 2015-2021
+  fedsync backfill --dataset holdings_13f --quarters 2020Q1-2021Q2
+
+Each period is recorded in the sync log as "<dataset>:backfill", so a
+backfill never counts as the dataset's scheduled sync.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+		applyFedsyncMemoryLimit(cfg.Fedsync.Streaming.MemoryLimitMB)
+
+		name, _ := cmd.Flags().GetString("dataset")
+		periods, err := parseBackfillPeriods(cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := ensureSchema(ctx); err != nil {
+			return eris.Wrap(err, "fedsync backfill: ensure schema")
+		}
+
+		runDir := filepath.Join(cfg.Fedsync.TempDir, fmt.Sprintf("backfill-%d", time.Now().UnixNano()))
+		if err := os.MkdirAll(runDir, 0o750); err != nil {
+			return eris.Wrapf(err, "fedsync backfill: create run dir %s", runDir)
+		}
+		defer os.RemoveAll(runDir) //nolint:errcheck
+
+		f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{
+			UserAgent:  cfg.Fedsync.EDGARUserAgent,
+			MaxRetries: 3,
+			Timeout:    30 * time.Minute,
+		})
+
+		syncLog := fedsync.NewSyncLog(pool)
+		closeSyncCache, err := attachSyncLogCache(ctx, syncLog)
+		if err != nil {
+			return err
+		}
+		defer closeSyncCache()
+		engine := dataset.NewEngine(pool, f, syncLog, dataset.NewRegistry(cfg), runDir)
+		engine.SetManifest(manifest.New(cfg, nil))
+		engine.SetMirrors(dataset.MirrorsFromConfig(cfg))
+		engine.SetRetry(dataset.RetryFromConfig(cfg))
+		engine.SetRunLocks(fedsync.NewRunLocks(pool))
+
+		zap.L().Info("starting fedsync backfill",
+			zap.String("dataset", name),
+			zap.Int("periods", len(periods)),
+		)
+
+		results, err := engine.Backfill(ctx, name, periods)
+		formatBackfillResults(commandOutputWriter(cmd), results)
+		if err != nil {
+			return eris.Wrap(err, "fedsync backfill")
+		}
+		for _, r := range results {
+			if r.Err != nil {
+				return eris.Errorf("fedsync backfill: %s failed for one or more periods", name)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	fedsyncBackfillCmd.Flags().String("dataset", "", "dataset to backfill (e.g., cbp)")
+	fedsyncBackfillCmd.Flags().String("years", "", "years to load, e.g. 2015-2021 or 2017,2019")
+	fedsyncBackfillCmd.Flags().String("quarters", "", "quarters to load, e.g. 2020Q1-2021Q2 (holdings_13f)")
+	_ = fedsyncBackfillCmd.MarkFlagRequired("dataset")
+	fedsyncBackfillCmd.MarkFlagsMutuallyExclusive("years", "quarters")
+	fedsyncBackfillCmd.MarkFlagsOneRequired("years", "quarters")
+	fedsyncCmd.AddCommand(fedsyncBackfillCmd)
+}
+
+// parseBackfillPeriods parses --years or --quarters, rejecting quarters in
+// --years and years in --quarters.
+func parseBackfillPeriods(cmd *cobra.Command) ([]dataset.Period, error) {
+	years, _ := cmd.Flags().GetString("years")
+	quarters, _ := cmd.Flags().GetString("quarters")
+
+	spec, wantQuarters := years, false
+	if quarters != "" {
+		spec, wantQuarters = quarters, true
+	}
+	periods, err := dataset.ParsePeriods(spec)
+	if err != nil {
+		return nil, eris.Wrap(err, "fedsync backfill")
+	}
+	for _, p := range periods {
+		if (p.Quarter != 0) != wantQuarters {
+			if wantQuarters {
+				return nil, eris.Errorf("fedsync backfill: --quarters wants YYYYQn, got %s", p)
+			}
+			return nil, eris.Errorf("fedsync backfill: --years wants YYYY, got %s (use --quarters)", p)
+		}
+	}
+	return periods, nil
+}
+
+// formatBackfillResults writes one line per backfilled period.
+func formatBackfillResults(out io.Writer, results []dataset.BackfillResult) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PERIOD\tROWS\tELAPSED\tRESULT")
+	for _, r := range results {
+		status := "ok"
+		if r.Err != nil {
+			status = r.Err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", r.Period, r.Rows, r.Elapsed.Round(time.Second), status)
+	}
+	_ = tw.Flush()
+}
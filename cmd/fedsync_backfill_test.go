@@ -0,0 +1,53 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+func newBackfillFlagsCmd(years, quarters string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test-backfill"}
+	cmd.Flags().String("years", years, "")
+	cmd.Flags().String("quarters", quarters, "")
+	return cmd
+}
+
+func TestParseBackfillPeriods(t *testing.T) {
+	periods, err := parseBackfillPeriods(newBackfillFlagsCmd("2015-2017", ""))
+	require.NoError(t, err)
+	assert.Equal(t, []dataset.Period{{Year: 2015}, {Year: 2016}, {Year: 2017}}, periods)
+
+	periods, err = parseBackfillPeriods(newBackfillFlagsCmd("", "2020Q4-2021Q1"))
+	require.NoError(t, err)
+	assert.Equal(t, []dataset.Period{{Year: 2020, Quarter: 4}, {Year: 2021, Quarter: 1}}, periods)
+
+	_, err = parseBackfillPeriods(newBackfillFlagsCmd("2020Q1", ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use --quarters")
+
+	_, err = parseBackfillPeriods(newBackfillFlagsCmd("", "2020"))
+	assert.Error(t, err)
+}
+
+func TestFormatBackfillResults(t *testing.T) {
+	var buf bytes.Buffer
+	formatBackfillResults(&buf, []dataset.BackfillResult{
+		{Period: dataset.Period{Year: 2015}, Rows: 1200, Elapsed: 42 * time.Second},
+		{Period: dataset.Period{Year: 2016}, Err: errors.New("cbp: no data published for 2016")},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "PERIOD")
+	assert.Regexp(t, `2015\s+1200\s+42s\s+ok`, out)
+	assert.Contains(t, out, "cbp: no data published for 2016")
+}
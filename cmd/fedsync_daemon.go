@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+var fedsyncDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Sync due datasets on a loop",
+	Long: `Runs until interrupted, checking every dataset's schedule each
+fedsync.daemon.interval_mins (default 15) and syncing the ones that are due.
+
+A dataset is due when its ShouldRun() says so, or, when it has a cron
+expression under fedsync.daemon.schedules, once that schedule has fired
+since its last success. A dataset whose last run failed is retried after
+a backoff that doubles with each consecutive failure (capped at a day).
+
+Run history lives in fed_data.sync_log, so a restarted daemon resumes
+where it stopped; each check is also recorded in fed_data.daemon_state.
+Run summaries go to the notify endpoints like 'fedsync sync'.`,
+	Example: `  research-cli fedsync daemon
+  research-cli fedsync daemon --phase 1 --interval 5m`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+		applyFedsyncMemoryLimit(cfg.Fedsync.Streaming.MemoryLimitMB)
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := ensureSchema(ctx); err != nil {
+			return eris.Wrap(err, "fedsync daemon: ensure schema")
+		}
+
+		opts, err := parseSyncOpts(cmd)
+		if err != nil {
+			return err
+		}
+
+		runDir := filepath.Join(cfg.Fedsync.TempDir, fmt.Sprintf("daemon-%d", time.Now().UnixNano()))
+		if err := os.MkdirAll(runDir, 0o750); err != nil {
+			return eris.Wrapf(err, "fedsync daemon: create run dir %s", runDir)
+		}
+		defer os.RemoveAll(runDir) //nolint:errcheck
+
+		engine, closeEngine, err := newSyncEngine(ctx, pool, runDir)
+		if err != nil {
+			return err
+		}
+		defer closeEngine()
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			interval = time.Duration(cfg.Fedsync.Daemon.IntervalMins) * time.Minute
+		}
+		daemon, err := dataset.NewDaemon(engine, dataset.DaemonOpts{
+			Interval:  interval,
+			Schedules: cfg.Fedsync.Daemon.Schedules,
+			Phase:     opts.Phase,
+			Datasets:  opts.Datasets,
+			Full:      opts.Full,
+		})
+		if err != nil {
+			return err
+		}
+
+		host, _ := os.Hostname()
+		daemon.SetState(fedsync.NewDaemonState(pool, host, time.Now().UTC()))
+		daemon.SetAfterRun(func(ctx context.Context, start time.Time, outcomes []dataset.Outcome) {
+			sendRunSummary(ctx, fedsyncSummary(start, outcomes))
+		})
+		return daemon.Run(ctx)
+	},
+}
+
+func init() {
+	fedsyncDaemonCmd.Flags().String("phase", "", "restrict to phase: 1, 1b, 2, 3")
+	fedsyncDaemonCmd.Flags().String("datasets", "", "comma-separated dataset names (e.g., cbp,fpds)")
+	fedsyncDaemonCmd.Flags().Bool("full", false, "full reload instead of incremental sync")
+	fedsyncDaemonCmd.Flags().Duration("interval", 0, "time between schedule checks (default: fedsync.daemon.interval_mins)")
+	fedsyncCmd.AddCommand(fedsyncDaemonCmd)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/duckcache"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+var fedsyncExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write fed_data tables to partitioned Parquet (local or S3)",
+	Long: `Dump fed_data tables to Parquet so consumers can read synced data
+without Postgres access. Column types are inferred from the Postgres
+schema. Tables with a year column are hive-partitioned by year
+(<dest>/<table>/year=2021/...) unless --partition-by says otherwise;
+--partition-by none writes a single <dest>/<table>.parquet.
+
+Destinations are local directories or s3://bucket/prefix URLs. S3 uses
+fedsync.export.s3_* settings, falling back to the AWS_* environment
+variables. Requires a cgo-enabled build (DuckDB).`,
+	Example: `  research-cli fedsync export --dataset cbp --dest s3://sells-data/fed
+  research-cli fedsync export --table fed_data.entity_xref --dest ./export --partition-by none`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		format, _ := cmd.Flags().GetString("format")
+		if format != "parquet" {
+			return eris.Errorf("fedsync export: unsupported --format %q (parquet)", format)
+		}
+		dest, _ := cmd.Flags().GetString("dest")
+		if dest == "" {
+			dest = cfg.Fedsync.Export.Dest
+		}
+		if dest == "" {
+			return eris.New("fedsync export: --dest is required (or set fedsync.export.dest)")
+		}
+		names, _ := cmd.Flags().GetStringSlice("dataset")
+		extra, _ := cmd.Flags().GetStringSlice("table")
+		tables, err := exportTables(dataset.NewRegistry(cfg), names, extra)
+		if err != nil {
+			return err
+		}
+		partitionBy, err := parsePartitionBy(cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		s3 := duckcache.S3Options{
+			Region:          cfg.Fedsync.Export.S3Region,
+			Endpoint:        cfg.Fedsync.Export.S3Endpoint,
+			AccessKeyID:     cfg.Fedsync.Export.S3AccessKeyID,
+			SecretAccessKey: cfg.Fedsync.Export.S3SecretAccessKey,
+		}
+		for _, table := range tables {
+			res, err := duckcache.Export(ctx, pool, duckcache.ExportOptions{
+				Table:       table,
+				Dest:        dest,
+				PartitionBy: partitionBy,
+				TempDir:     cfg.Fedsync.TempDir,
+				S3:          s3,
+			})
+			if err != nil {
+				return eris.Wrapf(err, "fedsync export: %s", table)
+			}
+			partitions := "-"
+			if len(res.PartitionBy) > 0 {
+				partitions = strings.Join(res.PartitionBy, ",")
+			}
+			printOutputf(cmd, "  %-40s %10d rows  %-12s %s  %s\n", res.Table, res.Rows, partitions, res.Duration.Round(1e6), res.Dest)
+		}
+		return nil
+	},
+}
+
+func init() {
+	fedsyncExportCmd.Flags().StringSlice("dataset", nil, "datasets whose tables to export (e.g., cbp)")
+	fedsyncExportCmd.Flags().StringSlice("table", nil, "extra fed_data tables to export (e.g., fed_data.entity_xref)")
+	fedsyncExportCmd.Flags().String("format", "parquet", "output format (parquet)")
+	fedsyncExportCmd.Flags().String("dest", "", "local directory or s3://bucket/prefix (default fedsync.export.dest)")
+	fedsyncExportCmd.Flags().StringSlice("partition-by", nil, `columns to partition by, or "none" (default: year when present)`)
+	fedsyncExportCmd.MarkFlagsOneRequired("dataset", "table")
+	fedsyncCmd.AddCommand(fedsyncExportCmd)
+}
+
+// exportTables resolves dataset names to their tables and appends extra
+// tables, rejecting anything outside the fed_data schema.
+func exportTables(reg *dataset.Registry, names, extra []string) ([]string, error) {
+	var tables []string
+	for _, name := range names {
+		ds, err := reg.Get(name)
+		if err != nil {
+			return nil, eris.Wrap(err, "fedsync export")
+		}
+		tables = append(tables, ds.Table())
+	}
+	tables = append(tables, extra...)
+	for _, t := range tables {
+		if !strings.HasPrefix(t, "fed_data.") {
+			return nil, eris.Errorf("fedsync export: %q is not a fed_data table", t)
+		}
+	}
+	return tables, nil
+}
+
+// parsePartitionBy reads --partition-by: nil for the default, an empty
+// slice for "none".
+func parsePartitionBy(cmd *cobra.Command) ([]string, error) {
+	if !cmd.Flags().Changed("partition-by") {
+		return nil, nil
+	}
+	cols, _ := cmd.Flags().GetStringSlice("partition-by")
+	if len(cols) == 1 && cols[0] == "none" {
+		return []string{}, nil
+	}
+	for _, c := range cols {
+		if c == "none" {
+			return nil, eris.New(`fedsync export: --partition-by none cannot be combined with columns`)
+		}
+	}
+	return cols, nil
+}
@@ -0,0 +1,53 @@
+//go:build !integration
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+func TestExportTables(t *testing.T) {
+	reg := dataset.NewRegistry(nil)
+
+	tables, err := exportTables(reg, []string{"cbp", "qcew"}, []string{"fed_data.entity_xref"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fed_data.cbp_data", "fed_data.qcew_data", "fed_data.entity_xref"}, tables)
+
+	_, err = exportTables(reg, []string{"nope"}, nil)
+	assert.Error(t, err)
+
+	_, err = exportTables(reg, nil, []string{"public.companies"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a fed_data table")
+}
+
+func TestParsePartitionBy(t *testing.T) {
+	parse := func(args ...string) ([]string, error) {
+		cmd := &cobra.Command{Use: "test-export"}
+		cmd.Flags().StringSlice("partition-by", nil, "")
+		require.NoError(t, cmd.ParseFlags(args))
+		return parsePartitionBy(cmd)
+	}
+
+	cols, err := parse()
+	require.NoError(t, err)
+	assert.Nil(t, cols, "unset keeps the default")
+
+	cols, err = parse("--partition-by", "none")
+	require.NoError(t, err)
+	assert.NotNil(t, cols)
+	assert.Empty(t, cols)
+
+	cols, err = parse("--partition-by", "year,fips_state")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"year", "fips_state"}, cols)
+
+	_, err = parse("--partition-by", "year,none")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/readmodel"
+)
+
+var fedsyncInvestorsCmd = &cobra.Command{
+	Use:   "investors",
+	Short: "List 13F filers holding an issuer",
+	Long: `Queries the 13F investor graph (built by the investor_graph dataset) for
+filers holding a given issuer, ranked by the position's weight in each filer's book.
+
+Examples:
+  # RIAs in our pipeline with at least 5% of their book in NVIDIA
+  research-cli fedsync investors --issuer NVIDIA --min-weight 0.05 --pipeline-only
+
+  # All holders of a CUSIP for a specific quarter
+  research-cli fedsync investors --issuer 67066G104 --period 2026-06-30`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		issuer, _ := cmd.Flags().GetString("issuer")
+		periodStr, _ := cmd.Flags().GetString("period")
+		minWeight, _ := cmd.Flags().GetFloat64("min-weight")
+		riasOnly, _ := cmd.Flags().GetBool("rias-only")
+		pipelineOnly, _ := cmd.Flags().GetBool("pipeline-only")
+		limit, _ := cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		q := readmodel.IssuerHoldersQuery{
+			Issuer:       issuer,
+			MinWeight:    minWeight,
+			RIAsOnly:     riasOnly,
+			PipelineOnly: pipelineOnly,
+			Limit:        limit,
+		}
+		if periodStr != "" {
+			period, err := time.Parse("2006-01-02", periodStr)
+			if err != nil {
+				return eris.Wrap(err, "fedsync investors: parse --period (want YYYY-MM-DD)")
+			}
+			q.Period = &period
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		holders, err := readmodel.NewPostgresService(pool, cfg).Investors.IssuerHolders(ctx, q)
+		if err != nil {
+			return eris.Wrap(err, "fedsync investors")
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(holders)
+		}
+		if len(holders) == 0 {
+			fmt.Fprintln(os.Stderr, "No matching holders found.")
+			return nil
+		}
+		formatInvestorPositions(os.Stdout, holders)
+		return nil
+	},
+}
+
+func init() {
+	fedsyncInvestorsCmd.Flags().String("issuer", "", "issuer CUSIP or name fragment (required)")
+	fedsyncInvestorsCmd.Flags().String("period", "", "13F report period YYYY-MM-DD (default: latest)")
+	fedsyncInvestorsCmd.Flags().Float64("min-weight", 0, "minimum position weight in the filer's book (0-1)")
+	fedsyncInvestorsCmd.Flags().Bool("rias-only", false, "only filers cross-referenced to an ADV CRD")
+	fedsyncInvestorsCmd.Flags().Bool("pipeline-only", false, "only filers linked to a company in our pipeline")
+	fedsyncInvestorsCmd.Flags().Int("limit", 100, "max holders to display")
+	fedsyncInvestorsCmd.Flags().Bool("json", false, "output as JSON")
+	_ = fedsyncInvestorsCmd.MarkFlagRequired("issuer")
+	fedsyncCmd.AddCommand(fedsyncInvestorsCmd)
+}
+
+// formatInvestorPositions writes a tabular list of 13F positions to w.
+func formatInvestorPositions(out io.Writer, positions []readmodel.InvestorPosition) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CIK\tCRD\tFILER\tISSUER\tPERIOD\tVALUE\tWEIGHT\tRANK\tQOQ\tHELD_SINCE")
+	_, _ = fmt.Fprintln(w, "---\t---\t-----\t------\t------\t-----\t------\t----\t---\t----------")
+
+	for _, p := range positions {
+		crd := "-"
+		if p.CRDNumber != nil {
+			crd = fmt.Sprintf("%d", *p.CRDNumber)
+		}
+		qoq := "new"
+		if p.ValueChangePct != nil {
+			qoq = fmt.Sprintf("%+.1f%%", *p.ValueChangePct*100)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%.2f%%\t%d\t%s\t%s\n",
+			p.CIK,
+			crd,
+			truncate(p.FilerName, 40),
+			truncate(p.IssuerName, 30),
+			p.Period.Format("2006-01-02"),
+			p.Value,
+			p.PortfolioWeight*100,
+			p.PositionRank,
+			qoq,
+			p.FirstPeriod.Format("2006-01-02"),
+		)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,39 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sells-group/research-cli/internal/readmodel"
+)
+
+func TestFormatInvestorPositions(t *testing.T) {
+	crd := 123456
+	change := -0.125
+	positions := []readmodel.InvestorPosition{
+		{
+			CIK: "0001234567", FilerName: "Acme Wealth", CRDNumber: &crd,
+			IssuerName: "NVIDIA CORP", Period: time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC),
+			Value: 1000, PortfolioWeight: 0.12, PositionRank: 1, ValueChangePct: &change,
+			FirstPeriod: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{CIK: "0007654321", FilerName: "Beta Capital", IssuerName: "NVIDIA CORP", PositionRank: 3},
+	}
+
+	var buf bytes.Buffer
+	formatInvestorPositions(&buf, positions)
+
+	out := buf.String()
+	assert.Contains(t, out, "WEIGHT")
+	assert.Contains(t, out, "Acme Wealth")
+	assert.Contains(t, out, "123456")
+	assert.Contains(t, out, "12.00%")
+	assert.Contains(t, out, "-12.5%")
+	assert.Contains(t, out, "2024-03-31")
+	assert.Contains(t, out, "new")
+}
@@ -92,7 +92,7 @@ func TestFedsyncCmd_Metadata(t *testing.T) {
 	assert.Equal(t, "fedsync", fedsyncCmd.Use)
 	assert.NotEmpty(t, fedsyncCmd.Short)
 	assert.NotEmpty(t, fedsyncCmd.Long)
-	assert.Contains(t, fedsyncCmd.Long, "42 federal datasets")
+	assert.Contains(t, fedsyncCmd.Long, "81 federal datasets")
 }
 
 func TestFedsyncDatasetsCmd_Metadata(t *testing.T) {
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+var fedsyncRejectsCmd = &cobra.Command{
+	Use:   "rejects",
+	Short: "Report rows rejected by fedsync parsers",
+	Long: `Lists recent sync runs that rejected rows (bad CUSIP, missing CRD,
+unparsable dates, ...) with the rejection rate and most common reason.
+
+Rate is rejected / (rejected + synced). The raw records, up to 1000 per run,
+are in fed_data.rejected_rows keyed by sync_run_id.`,
+	Example: `  research-cli fedsync rejects
+  research-cli fedsync rejects --dataset holdings_13f --limit 5`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		ds, _ := cmd.Flags().GetString("dataset")
+		limit, _ := cmd.Flags().GetInt("limit")
+		summaries, err := fedsync.NewSyncLog(pool).RejectSummaries(ctx, ds, limit)
+		if err != nil {
+			return eris.Wrap(err, "fedsync rejects")
+		}
+		if len(summaries) == 0 {
+			zap.L().Info("no rejected rows recorded")
+			return nil
+		}
+
+		formatRejectSummaries(commandOutputWriter(cmd), summaries)
+		return nil
+	},
+}
+
+func init() {
+	fedsyncRejectsCmd.Flags().String("dataset", "", "only show runs of this dataset")
+	fedsyncRejectsCmd.Flags().Int("limit", 20, "maximum runs to show")
+	fedsyncCmd.AddCommand(fedsyncRejectsCmd)
+}
+
+// formatRejectSummaries writes one row per run with its rejection count,
+// rate, and top reason.
+func formatRejectSummaries(out io.Writer, summaries []fedsync.RejectSummary) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "RUN\tDATASET\tSTARTED\tROWS\tREJECTED\tRATE\tTOP REASON")
+	_, _ = fmt.Fprintln(w, "---\t-------\t-------\t----\t--------\t----\t----------")
+
+	for _, s := range summaries {
+		top := s.TopReason()
+		if top != "" {
+			top = fmt.Sprintf("%s (%d)", top, s.Reasons[top])
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%.2f%%\t%s\n",
+			s.RunID,
+			s.Dataset,
+			s.StartedAt.Format("2006-01-02 15:04"),
+			s.RowsSynced,
+			s.Rejected,
+			s.Rate()*100,
+			top,
+		)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/manifest"
+)
+
+var fedsyncResyncCmd = &cobra.Command{
+	Use:   "resync",
+	Short: "Delete and reload one period or state of a dataset",
+	Long: `Replace one slice of a dataset's table when its upstream file is
+corrected, instead of forcing a full reload. The slice's rows are deleted
+and the period is reloaded, keeping only rows inside the slice.
+
+Supported datasets: qcew (year, quarter, state), cbp and susb (year,
+state), oews and econ_census (year), holdings_13f (year or quarter).
+States are two-digit FIPS codes:
+
+  fedsync resync --dataset qcew --year 2023 --qtr 2
+  fedsync resync --dataset cbp --year 2021 --state 06
+
+Each resync is recorded in the sync log as "<dataset>:resync", so it never
+counts as the dataset's scheduled sync. The delete and reload run in one
+transaction: if the reload fails the slice keeps its old rows.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+		applyFedsyncMemoryLimit(cfg.Fedsync.Streaming.MemoryLimitMB)
+
+		name, _ := cmd.Flags().GetString("dataset")
+		slice, err := parseResyncSlice(cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		if err := ensureSchema(ctx); err != nil {
+			return eris.Wrap(err, "fedsync resync: ensure schema")
+		}
+
+		runDir := filepath.Join(cfg.Fedsync.TempDir, fmt.Sprintf("resync-%d", time.Now().UnixNano()))
+		if err := os.MkdirAll(runDir, 0o750); err != nil {
+			return eris.Wrapf(err, "fedsync resync: create run dir %s", runDir)
+		}
+		defer os.RemoveAll(runDir) //nolint:errcheck
+
+		f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{
+			UserAgent:  cfg.Fedsync.EDGARUserAgent,
+			MaxRetries: 3,
+			Timeout:    30 * time.Minute,
+		})
+
+		syncLog := fedsync.NewSyncLog(pool)
+		closeSyncCache, err := attachSyncLogCache(ctx, syncLog)
+		if err != nil {
+			return err
+		}
+		defer closeSyncCache()
+		engine := dataset.NewEngine(pool, f, syncLog, dataset.NewRegistry(cfg), runDir)
+		engine.SetManifest(manifest.New(cfg, nil))
+		engine.SetMirrors(dataset.MirrorsFromConfig(cfg))
+		engine.SetRetry(dataset.RetryFromConfig(cfg))
+		engine.SetRunLocks(fedsync.NewRunLocks(pool))
+
+		zap.L().Info("starting fedsync resync",
+			zap.String("dataset", name),
+			zap.Stringer("slice", slice),
+		)
+
+		result, err := engine.Resync(ctx, name, slice)
+		if result != nil {
+			formatResyncResult(commandOutputWriter(cmd), name, result)
+		}
+		return eris.Wrap(err, "fedsync resync")
+	},
+}
+
+func init() {
+	fedsyncResyncCmd.Flags().String("dataset", "", "dataset to resync (e.g., qcew)")
+	fedsyncResyncCmd.Flags().Int("year", 0, "data year to replace")
+	fedsyncResyncCmd.Flags().Int("qtr", 0, "quarter (1-4) to replace within --year")
+	fedsyncResyncCmd.Flags().String("state", "", "two-digit state FIPS code to replace (e.g. 06)")
+	_ = fedsyncResyncCmd.MarkFlagRequired("dataset")
+	_ = fedsyncResyncCmd.MarkFlagRequired("year")
+	fedsyncCmd.AddCommand(fedsyncResyncCmd)
+}
+
+// parseResyncSlice builds the slice from --year, --qtr, and --state.
+func parseResyncSlice(cmd *cobra.Command) (dataset.Slice, error) {
+	year, _ := cmd.Flags().GetInt("year")
+	qtr, _ := cmd.Flags().GetInt("qtr")
+	state, _ := cmd.Flags().GetString("state")
+
+	if year < 1900 || year > 2100 {
+		return dataset.Slice{}, eris.Errorf("fedsync resync: invalid --year %d", year)
+	}
+	if qtr < 0 || qtr > 4 {
+		return dataset.Slice{}, eris.Errorf("fedsync resync: --qtr must be 1-4, got %d", qtr)
+	}
+	s := dataset.Slice{Period: dataset.Period{Year: year, Quarter: qtr}, State: state}
+	if err := s.Validate(); err != nil {
+		return dataset.Slice{}, eris.Wrap(err, "fedsync resync")
+	}
+	return s, nil
+}
+
+// formatResyncResult writes a one-line summary of a resync.
+func formatResyncResult(out io.Writer, name string, r *dataset.ResyncResult) {
+	_, _ = fmt.Fprintf(out, "%s %s: deleted %d rows, loaded %d rows in %s\n",
+		name, r.Slice, r.Deleted, r.Rows, r.Elapsed.Round(time.Second))
+}
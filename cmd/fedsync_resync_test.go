@@ -0,0 +1,51 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+func newResyncFlagsCmd(year, qtr int, state string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test-resync"}
+	cmd.Flags().Int("year", year, "")
+	cmd.Flags().Int("qtr", qtr, "")
+	cmd.Flags().String("state", state, "")
+	return cmd
+}
+
+func TestParseResyncSlice(t *testing.T) {
+	s, err := parseResyncSlice(newResyncFlagsCmd(2023, 2, ""))
+	require.NoError(t, err)
+	assert.Equal(t, dataset.Slice{Period: dataset.Period{Year: 2023, Quarter: 2}}, s)
+
+	s, err = parseResyncSlice(newResyncFlagsCmd(2021, 0, "06"))
+	require.NoError(t, err)
+	assert.Equal(t, dataset.Slice{Period: dataset.Period{Year: 2021}, State: "06"}, s)
+
+	_, err = parseResyncSlice(newResyncFlagsCmd(2023, 5, ""))
+	assert.Error(t, err)
+	_, err = parseResyncSlice(newResyncFlagsCmd(0, 0, ""))
+	assert.Error(t, err)
+	_, err = parseResyncSlice(newResyncFlagsCmd(2023, 0, "CA"))
+	assert.Error(t, err)
+}
+
+func TestFormatResyncResult(t *testing.T) {
+	var buf bytes.Buffer
+	formatResyncResult(&buf, "qcew", &dataset.ResyncResult{
+		Slice:   dataset.Slice{Period: dataset.Period{Year: 2023, Quarter: 2}, State: "06"},
+		Deleted: 310,
+		Rows:    312,
+		Elapsed: 95 * time.Second,
+	})
+	assert.Equal(t, "qcew 2023Q2 state 06: deleted 310 rows, loaded 312 rows in 1m35s\n", buf.String())
+}
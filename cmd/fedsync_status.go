@@ -12,12 +12,20 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 )
 
 var fedsyncStatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show fedsync sync log",
-	Long:  "Displays the sync history for all federal datasets.",
+	Short: "Show fedsync dataset status",
+	Long: `Displays per-dataset sync status from fed_data.sync_runs: last success,
+staleness against the dataset's schedule, and failures since the last success.
+
+Freshness is "ok" when the dataset is synced for its current period, "due"
+when ShouldRun() would sync it, "overdue" when it is more than twice its
+cadence behind, and "never" when it has no successful sync.
+
+Use --history to list every run instead.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
 
@@ -28,25 +36,97 @@ var fedsyncStatusCmd = &cobra.Command{
 		defer pool.Close()
 
 		sl := fedsync.NewSyncLog(pool)
-		entries, err := sl.ListAll(ctx)
-		if err != nil {
-			return eris.Wrap(err, "fedsync status")
-		}
+		if history, _ := cmd.Flags().GetBool("history"); history {
+			entries, err := sl.ListAll(ctx)
+			if err != nil {
+				return eris.Wrap(err, "fedsync status")
+			}
+
+			if len(entries) == 0 {
+				zap.L().Info("no sync entries found, run 'fedsync sync' to start syncing datasets")
+				return nil
+			}
 
-		if len(entries) == 0 {
-			zap.L().Info("no sync entries found, run 'fedsync sync' to start syncing datasets")
+			formatStatusEntries(os.Stdout, entries)
 			return nil
 		}
 
-		formatStatusEntries(os.Stdout, entries)
+		stats, err := sl.RunStats(ctx)
+		if err != nil {
+			return eris.Wrap(err, "fedsync status")
+		}
+		formatDatasetStatus(commandOutputWriter(cmd), dataset.NewRegistry(cfg).All(), stats, time.Now().UTC())
 		return nil
 	},
 }
 
 func init() {
+	fedsyncStatusCmd.Flags().Bool("history", false, "list every sync run instead of per-dataset status")
 	fedsyncCmd.AddCommand(fedsyncStatusCmd)
 }
 
+// formatDatasetStatus writes one row per registered dataset with its last
+// success, freshness against its schedule, and trailing failure count.
+func formatDatasetStatus(out io.Writer, datasets []dataset.Dataset, stats []fedsync.DatasetRunStats, now time.Time) {
+	byName := make(map[string]fedsync.DatasetRunStats, len(stats))
+	for _, st := range stats {
+		byName[st.Dataset] = st
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "DATASET\tCADENCE\tLAST SUCCESS\tAGE\tFRESHNESS\tROWS\tBYTES\tFAILURES\tLAST ERROR")
+	_, _ = fmt.Fprintln(w, "-------\t-------\t------------\t---\t---------\t----\t-----\t--------\t----------")
+
+	for _, ds := range datasets {
+		st, ok := byName[ds.Name()]
+		var lastSuccess *time.Time
+		if ok {
+			lastSuccess = st.LastSuccess
+		}
+
+		last, age := "-", "-"
+		if lastSuccess != nil {
+			last = lastSuccess.Format("2006-01-02 15:04")
+			age = formatAge(now.Sub(*lastSuccess))
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%d\t%s\n",
+			ds.Name(),
+			ds.Cadence(),
+			last,
+			age,
+			dataset.CheckFreshness(ds, now, lastSuccess),
+			st.LastRows,
+			formatByteCount(st.LastBytes),
+			st.TrailingFailures,
+			truncate(st.LastError, 60),
+		)
+	}
+	_ = w.Flush()
+}
+
+// formatAge renders a duration in days, or hours under two days.
+func formatAge(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// formatByteCount renders n with a binary unit suffix.
+func formatByteCount(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // formatStatusEntries writes a tabular representation of sync entries to w.
 func formatStatusEntries(out io.Writer, entries []fedsync.SyncEntry) {
 	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
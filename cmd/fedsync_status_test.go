@@ -4,12 +4,15 @@ package main
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 )
 
 func TestFormatStatusEntries_Empty(t *testing.T) {
@@ -153,3 +156,59 @@ func TestFormatStatusEntries_MultipleEntries(t *testing.T) {
 	assert.Contains(t, output, "10000")
 	assert.Contains(t, output, "500")
 }
+
+func TestFormatDatasetStatus(t *testing.T) {
+	reg := dataset.NewRegistry(&config.Config{})
+	cbp, err := reg.Get("cbp")
+	assert.NoError(t, err)
+	fpds, err := reg.Get("fpds")
+	assert.NoError(t, err)
+
+	now := time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
+	lastOK := now.Add(-72 * time.Hour)
+	stats := []fedsync.DatasetRunStats{{
+		Dataset:          "fpds",
+		LastRun:          now.Add(-time.Hour),
+		LastStatus:       "failed",
+		LastSuccess:      &lastOK,
+		LastRows:         1200,
+		LastBytes:        3 << 20,
+		TrailingFailures: 2,
+		LastError:        "http 503 from api.sam.gov",
+	}}
+
+	var buf bytes.Buffer
+	formatDatasetStatus(&buf, []dataset.Dataset{cbp, fpds}, stats, now)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "FRESHNESS")
+	assert.Regexp(t, `^cbp\s+annual\s+-\s+-\s+never\s+0\s+0B\s+0`, lines[2])
+	assert.Contains(t, lines[3], "2025-06-13 12:00")
+	assert.Contains(t, lines[3], "3d")
+	assert.Contains(t, lines[3], "3.0MiB")
+	assert.Contains(t, lines[3], "http 503 from api.sam.gov")
+}
+
+func TestFormatByteCount(t *testing.T) {
+	assert.Equal(t, "512B", formatByteCount(512))
+	assert.Equal(t, "1.5KiB", formatByteCount(1536))
+	assert.Equal(t, "2.0GiB", formatByteCount(2<<30))
+}
+
+func TestFormatRejectSummaries(t *testing.T) {
+	var buf bytes.Buffer
+	formatRejectSummaries(&buf, []fedsync.RejectSummary{{
+		RunID:      42,
+		Dataset:    "holdings_13f",
+		StartedAt:  time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC),
+		RowsSynced: 990,
+		Rejected:   10,
+		Reasons:    map[string]int64{"bad_cusip": 10},
+	}})
+
+	out := buf.String()
+	assert.Contains(t, out, "TOP REASON")
+	assert.Contains(t, out, "holdings_13f")
+	assert.Contains(t, out, "1.00%")
+	assert.Contains(t, out, "bad_cusip (10)")
+}
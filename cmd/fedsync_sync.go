@@ -3,21 +3,30 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rotisserie/eris"
 	"github.com/spf13/cobra"
 	"go.temporal.io/sdk/client"
 	"go.uber.org/zap"
 
+	"github.com/sells-group/research-cli/internal/chaos"
+	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/manifest"
+	"github.com/sells-group/research-cli/internal/postsync"
 	temporalpkg "github.com/sells-group/research-cli/internal/temporal"
 	temporalfedsync "github.com/sells-group/research-cli/internal/temporal/fedsync"
 )
@@ -30,7 +39,9 @@ var fedsyncSyncCmd = &cobra.Command{
 By default, syncs all datasets whose ShouldRun() returns true.
 Use --phase to restrict to a specific phase, or --datasets for specific datasets.
 Use --force to ignore ShouldRun() scheduling logic.
-Use --full to perform a full reload instead of incremental sync.`,
+Use --full to perform a full reload instead of incremental sync.
+Use --dry-run to download and parse without writing, printing the row count
+and sample rows each table would have received.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 		defer stop()
@@ -38,10 +49,15 @@ Use --full to perform a full reload instead of incremental sync.`,
 		if err := cfg.Validate("fedsync"); err != nil {
 			return err
 		}
+		applyFedsyncMemoryLimit(cfg.Fedsync.Streaming.MemoryLimitMB)
 
 		log := zap.L().With(zap.String("command", "fedsync.sync"))
 
 		useTemporal, _ := cmd.Flags().GetBool("temporal")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if useTemporal && dryRun {
+			return eris.New("fedsync sync: --dry-run is not supported with --temporal")
+		}
 		if useTemporal {
 			return runFedsyncViaTemporal(ctx, cmd, log)
 		}
@@ -52,9 +68,12 @@ Use --full to perform a full reload instead of incremental sync.`,
 		}
 		defer pool.Close()
 
-		// Ensure schema is current via Atlas.
-		if err := ensureSchema(ctx); err != nil {
-			return eris.Wrap(err, "fedsync sync: ensure schema")
+		// Ensure schema is current via Atlas. A dry run leaves the schema
+		// alone and reads against whatever is deployed.
+		if !dryRun {
+			if err := ensureSchema(ctx); err != nil {
+				return eris.Wrap(err, "fedsync sync: ensure schema")
+			}
 		}
 
 		// Parse flags.
@@ -74,32 +93,38 @@ Use --full to perform a full reload instead of incremental sync.`,
 		}
 		defer os.RemoveAll(runDir) //nolint:errcheck
 
-		// Build fetcher.
-		f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{
-			UserAgent:  cfg.Fedsync.EDGARUserAgent,
-			MaxRetries: 3,
-			Timeout:    30 * time.Minute,
-		})
-
-		// Build engine.
-		syncLog := fedsync.NewSyncLog(pool)
-		closeSyncCache, err := attachSyncLogCache(ctx, syncLog)
+		engine, closeEngine, err := newSyncEngine(ctx, pool, runDir)
 		if err != nil {
 			return err
 		}
-		defer closeSyncCache()
-		reg := dataset.NewRegistry(cfg)
-		engine := dataset.NewEngine(pool, f, syncLog, reg, runDir)
+		defer closeEngine()
 
 		log.Info("starting fedsync",
 			zap.Any("phase", opts.Phase),
 			zap.Strings("datasets", opts.Datasets),
 			zap.Bool("force", opts.Force),
 			zap.Bool("full", opts.Full),
+			zap.Bool("dry_run", opts.DryRun),
 		)
 
-		if err := engine.Run(ctx, opts); err != nil {
-			return eris.Wrap(err, "fedsync sync")
+		start := time.Now()
+		runErr := engine.Run(ctx, opts)
+		if !opts.DryRun {
+			summary := fedsyncSummary(start, engine.Outcomes())
+			if runErr != nil {
+				summary.Anomalies = append(summary.Anomalies, "run aborted: "+runErr.Error())
+			}
+			if len(summary.Items) > 0 || runErr != nil {
+				sendRunSummary(ctx, summary)
+			}
+		}
+		if runErr != nil {
+			return eris.Wrap(runErr, "fedsync sync")
+		}
+
+		if opts.DryRun {
+			formatDryRunReport(commandOutputWriter(cmd), engine.DryRunReport())
+			return nil
 		}
 
 		zap.L().Info("sync complete")
@@ -112,11 +137,49 @@ func init() {
 	fedsyncSyncCmd.Flags().String("datasets", "", "comma-separated dataset names (e.g., cbp,fpds)")
 	fedsyncSyncCmd.Flags().Bool("force", false, "ignore ShouldRun() scheduling logic")
 	fedsyncSyncCmd.Flags().Bool("full", false, "full reload instead of incremental sync")
+	fedsyncSyncCmd.Flags().Bool("dry-run", false, "download and parse but skip writes; print row counts and sample rows per table")
 	fedsyncSyncCmd.Flags().Bool("temporal", false, "run via Temporal workflow instead of direct engine")
 	fedsyncSyncCmd.Flags().Bool("wait", true, "wait for Temporal workflow completion (only with --temporal)")
 	fedsyncCmd.AddCommand(fedsyncSyncCmd)
 }
 
+// newSyncEngine builds the engine fedsync sync and daemon run: the HTTP
+// fetcher and pool wrapped for chaos, the run manifest, mirrors, retry
+// policy, and sync bookkeeping. The returned func releases the sync log
+// cache.
+func newSyncEngine(ctx context.Context, pool *pgxpool.Pool, runDir string) (*dataset.Engine, func(), error) {
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{
+		UserAgent:  cfg.Fedsync.EDGARUserAgent,
+		MaxRetries: 3,
+		Timeout:    30 * time.Minute,
+	})
+
+	syncLog := fedsync.NewSyncLog(pool)
+	closeSyncCache, err := attachSyncLogCache(ctx, syncLog)
+	if err != nil {
+		return nil, nil, err
+	}
+	reg := dataset.NewRegistry(cfg)
+	// Sync log writes stay unwrapped so injected faults are still recorded.
+	inj := chaos.New(cfg.Chaos)
+	engine := dataset.NewEngine(chaos.WrapPool(pool, inj), chaos.WrapFetcher(f, inj), syncLog, reg, runDir)
+	engine.SetManifest(manifest.New(cfg, nil))
+	engine.SetMirrors(dataset.MirrorsFromConfig(cfg))
+	engine.SetRetry(dataset.RetryFromConfig(cfg))
+	// Watermarks, checkpoints, source file versions, and run locks are
+	// bookkeeping like the sync log, so they stay unwrapped.
+	engine.SetWatermarks(fedsync.NewWatermarks(pool))
+	engine.SetCheckpoints(fedsync.NewCheckpoints(pool))
+	engine.SetSourceFiles(fedsync.NewSourceFiles(pool))
+	engine.SetRunLocks(fedsync.NewRunLocks(pool))
+	queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize).WithMaxDepth(cfg.Geo.QueueMaxDepth)
+	engine.SetPostSync(postsync.RunnerFromConfig(pool, syncLog, queue, cfg))
+	if cfg.Fedsync.Validation.Enabled {
+		engine.SetValidation(&dataset.ValidationOpts{Block: cfg.Fedsync.Validation.Block})
+	}
+	return engine, closeSyncCache, nil
+}
+
 // runFedsyncViaTemporal starts a FedsyncRunWorkflow on Temporal.
 func runFedsyncViaTemporal(ctx context.Context, cmd *cobra.Command, log *zap.Logger) error {
 	c, err := temporalpkg.NewClient(cfg.Temporal)
@@ -183,10 +246,12 @@ func parseSyncOpts(cmd *cobra.Command) (dataset.RunOpts, error) {
 	datasetsStr, _ := cmd.Flags().GetString("datasets")
 	force, _ := cmd.Flags().GetBool("force")
 	full, _ := cmd.Flags().GetBool("full")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	opts := dataset.RunOpts{
-		Force: force,
-		Full:  full,
+		Force:  force,
+		Full:   full,
+		DryRun: dryRun,
 	}
 
 	if phaseStr != "" {
@@ -206,3 +271,60 @@ func parseSyncOpts(cmd *cobra.Command) (dataset.RunOpts, error) {
 
 	return opts, nil
 }
+
+// formatDryRunReport writes the row count and sample rows each table would
+// have received during a dry run.
+func formatDryRunReport(out io.Writer, d *db.DryRun) {
+	if d == nil {
+		return
+	}
+	tables := d.Tables()
+	if len(tables) == 0 {
+		_, _ = fmt.Fprintln(out, "Dry run: no rows would be written.")
+	} else {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TABLE\tROWS")
+		_, _ = fmt.Fprintln(w, "-----\t----")
+		for _, t := range tables {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", t.Table, t.Rows)
+		}
+		_ = w.Flush()
+
+		for _, t := range tables {
+			if len(t.Samples) == 0 {
+				continue
+			}
+			_, _ = fmt.Fprintf(out, "\n%s (%d sample rows)\n", t.Table, len(t.Samples))
+			for _, row := range t.Samples {
+				fields := make([]string, len(row))
+				for i, v := range row {
+					col := fmt.Sprintf("col%d", i+1)
+					if i < len(t.Columns) {
+						col = t.Columns[i]
+					}
+					fields[i] = col + "=" + truncate(fmt.Sprint(dryRunValue(v)), 40)
+				}
+				_, _ = fmt.Fprintf(out, "  %s\n", strings.Join(fields, ", "))
+			}
+		}
+	}
+	if n := d.Skipped(); n > 0 {
+		_, _ = fmt.Fprintf(out, "\nSkipped %d other write statements.\n", n)
+	}
+}
+
+// dryRunValue dereferences pointers so sample rows print values, not
+// addresses.
+func dryRunValue(v any) any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return "NULL"
+	}
+	if rv.Kind() == reflect.Pointer {
+		v = rv.Elem().Interface()
+	}
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return v
+}
@@ -3,12 +3,15 @@
 package main
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 )
 
@@ -20,6 +23,7 @@ func newSyncFlagsCmd() *cobra.Command {
 	cmd.Flags().String("datasets", "", "")
 	cmd.Flags().Bool("force", false, "")
 	cmd.Flags().Bool("full", false, "")
+	cmd.Flags().Bool("dry-run", false, "")
 	return cmd
 }
 
@@ -32,6 +36,7 @@ func TestParseSyncOpts_Defaults(t *testing.T) {
 	assert.Nil(t, opts.Datasets)
 	assert.False(t, opts.Force)
 	assert.False(t, opts.Full)
+	assert.False(t, opts.DryRun)
 }
 
 func TestParseSyncOpts_WithPhase(t *testing.T) {
@@ -122,3 +127,39 @@ func TestParseSyncOpts_SingleDataset(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{"cbp"}, opts.Datasets)
 }
+
+func TestParseSyncOpts_DryRun(t *testing.T) {
+	cmd := newSyncFlagsCmd()
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+
+	opts, err := parseSyncOpts(cmd)
+	require.NoError(t, err)
+	assert.True(t, opts.DryRun)
+}
+
+func TestFormatDryRunReport(t *testing.T) {
+	d := db.NewDryRun(1)
+	filed := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	d.Record("fed_data.form_d", []string{"accession_number", "filed", "amount"}, [][]any{
+		{"0001-24-000001", &filed, nil},
+		{"0001-24-000002", &filed, 5.0},
+	})
+
+	var buf bytes.Buffer
+	formatDryRunReport(&buf, d)
+	out := buf.String()
+	assert.Contains(t, out, "fed_data.form_d")
+	assert.Contains(t, out, "2\n")
+	assert.Contains(t, out, "accession_number=0001-24-000001, filed=2024-03-01T00:00:00Z, amount=NULL")
+	assert.NotContains(t, out, "0001-24-000002", "only one sample kept")
+}
+
+func TestFormatDryRunReport_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	formatDryRunReport(&buf, db.NewDryRun(3))
+	assert.Contains(t, buf.String(), "no rows would be written")
+
+	buf.Reset()
+	formatDryRunReport(&buf, nil)
+	assert.Empty(t, buf.String())
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"runtime/debug"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,14 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyFedsyncMemoryLimit(t *testing.T) {
+	prev := debug.SetMemoryLimit(-1)
+	t.Cleanup(func() { debug.SetMemoryLimit(prev) })
+
+	applyFedsyncMemoryLimit(0)
+	assert.Equal(t, prev, debug.SetMemoryLimit(-1), "zero leaves the limit alone")
+
+	applyFedsyncMemoryLimit(512)
+	assert.Equal(t, int64(512)<<20, debug.SetMemoryLimit(-1))
+}
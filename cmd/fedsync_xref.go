@@ -10,6 +10,7 @@ import (
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/manifest"
 )
 
 var fedsyncXrefCmd = &cobra.Command{
@@ -17,7 +18,8 @@ var fedsyncXrefCmd = &cobra.Command{
 	Short: "Build entity cross-reference table",
 	Long: `Runs the entity_xref dataset to build cross-reference linkages across all federal datasets.
 
-Stage 1: CRD↔CIK matching between ADV firms and EDGAR entities (3-pass).
+Stage 1: CRD↔CIK matching between ADV firms and EDGAR entities (sec_number,
+         N-CEN LEI, ticker map, and a scored probabilistic pass).
 Stage 2: Multi-dataset matching across ADV, EDGAR, BrokerCheck, Form BD, OSHA,
          EPA, FPDS, PPP, and Form D using direct CRD, direct CIK, exact name+zip,
          exact name+state, and fuzzy name+state strategies.`,
@@ -49,6 +51,7 @@ Stage 2: Multi-dataset matching across ADV, EDGAR, BrokerCheck, Form BD, OSHA,
 		defer closeSyncCache()
 		reg := dataset.NewRegistry(cfg)
 		engine := dataset.NewEngine(pool, f, syncLog, reg, cfg.Fedsync.TempDir)
+		engine.SetManifest(manifest.New(cfg, nil))
 
 		log.Info("building entity cross-reference")
 
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os/signal"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/geospatial"
+)
+
+var geoAssignCmd = &cobra.Command{
+	Use:   "assign",
+	Short: "Assign county, tract, place, and CBSA to geocoded locations",
+	Long: `Assigns county FIPS, census tract, Census place, and metro/micro CBSA to
+points in geo.locations by point-in-polygon joins against the synced TIGER
+(geo.counties, geo.census_tracts, geo.places) and CBSA (geo.cbsa) boundaries.
+By default only locations not yet assigned or re-geocoded since are updated;
+geocode run does this automatically. Use --force to reassign every location
+after a boundary sync.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		sourceTable, _ := cmd.Flags().GetString("source-table")
+		force, _ := cmd.Flags().GetBool("force")
+		limit, _ := cmd.Flags().GetInt("limit")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+		stats, err := geospatial.NewSpatialAssigner(pool, batchSize).Run(ctx, geospatial.SpatialAssignOpts{
+			SourceTable: sourceTable,
+			Force:       force,
+			Limit:       limit,
+		})
+		if stats != nil {
+			zap.L().Info("spatial assignment complete",
+				zap.Int("scanned", stats.Scanned),
+				zap.Int("counties", stats.Counties),
+				zap.Int("tracts", stats.Tracts),
+				zap.Int("places", stats.Places),
+				zap.Int("cbsas", stats.CBSAs),
+			)
+			printOutputf(cmd, "Assigned %d locations: %d in a county, %d in a tract, %d in a place, %d in a CBSA\n",
+				stats.Scanned, stats.Counties, stats.Tracts, stats.Places, stats.CBSAs)
+		}
+		return eris.Wrap(err, "geo assign")
+	},
+}
+
+func init() {
+	geoAssignCmd.Flags().String("source-table", "", "only assign locations from this source table (e.g. fed_data.adv_firms)")
+	geoAssignCmd.Flags().Bool("force", false, "reassign locations that were already assigned")
+	geoAssignCmd.Flags().Int("limit", 0, "max locations (0 = all)")
+	geoAssignCmd.Flags().Int("batch-size", 5000, "locations updated per statement")
+	geoCmd.AddCommand(geoAssignCmd)
+}
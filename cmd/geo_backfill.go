@@ -35,16 +35,19 @@ var geoBackfillCmd = &cobra.Command{
 		log := zap.L().With(zap.String("command", "geo.backfill"))
 
 		// Build PostGIS geocode client.
-		gcClient := geocode.NewClient(pool,
+		gcClient, err := newGeocodeClient(pool,
 			geocode.WithCacheEnabled(cfg.Geo.CacheEnabled),
 			geocode.WithMaxRating(cfg.Geo.MaxRating),
 		)
+		if err != nil {
+			return eris.Wrap(err, "geo backfill: geocode client")
+		}
 
 		// Build company store and geo associator.
 		cs := company.NewPostgresStore(pool)
 		var assoc *geo.Associator
 		if !skipMSA {
-			assoc = geo.NewAssociator(pool, cs)
+			assoc = geo.NewAssociator(pool, cs, geo.WithGeoSchema())
 		}
 
 		// Fetch ungeocoded addresses.
@@ -51,17 +51,21 @@ func runGeoEntityBackfill(
 	store := company.NewPostgresStore(pool)
 	var geocoder geocode.Client
 	if !skipGeocode {
-		geocoder = geocode.NewClient(pool,
+		gc, err := newGeocodeClient(pool,
 			geocode.WithCacheEnabled(cfg.Geo.CacheEnabled),
 			geocode.WithMaxRating(cfg.Geo.MaxRating),
 			geocode.WithCacheTTLDays(cfg.Geo.CacheTTLDays),
 			geocode.WithBatchConcurrency(concurrency),
 		)
+		if err != nil {
+			return eris.Wrapf(err, "%s: geocode client", commandName)
+		}
+		geocoder = gc
 	}
 
 	var assoc *igeo.Associator
 	if !skipMSA {
-		assoc = igeo.NewAssociator(pool, store)
+		assoc = igeo.NewAssociator(pool, store, igeo.WithGeoSchema())
 	}
 
 	svc := geobackfill.NewService(pool, store, geocoder, assoc, cfg)
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os/signal"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/geospatial"
+)
+
+var geoMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Compute nearest-amenity distances for geocoded company addresses",
+	Long: `Computes the distance from each geocoded company address to the nearest
+airport and hospital (geo.infrastructure), competitor POI (geo.osm_pois,
+subcategories from geo.location_metrics.competitor_subcategories), and
+interstate (geo.roads) into geo.location_metrics, which the pipeline reads
+for Salesforce fields. By default only addresses never computed, re-geocoded,
+or older than geo.location_metrics.refresh_days are updated. Use --force to
+recompute every address after an amenity layer sync.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		force, _ := cmd.Flags().GetBool("force")
+		limit, _ := cmd.Flags().GetInt("limit")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+		mc := cfg.Geo.LocationMetrics
+		stats, err := geospatial.NewMetricsComputer(pool, batchSize, mc.CompetitorSubcategories).Run(ctx, geospatial.MetricsOpts{
+			Force:       force,
+			RefreshDays: mc.RefreshDays,
+			Limit:       limit,
+		})
+		if stats != nil {
+			zap.L().Info("location metrics complete",
+				zap.Int("computed", stats.Computed),
+				zap.Int("airports", stats.Airports),
+				zap.Int("hospitals", stats.Hospitals),
+				zap.Int("competitors", stats.Competitors),
+				zap.Int("interstates", stats.Interstates),
+			)
+			printOutputf(cmd, "Computed metrics for %d addresses: %d with an airport, %d with a hospital, %d with a competitor, %d with an interstate\n",
+				stats.Computed, stats.Airports, stats.Hospitals, stats.Competitors, stats.Interstates)
+		}
+		return eris.Wrap(err, "geo metrics")
+	},
+}
+
+func init() {
+	geoMetricsCmd.Flags().Bool("force", false, "recompute addresses with current metrics")
+	geoMetricsCmd.Flags().Int("limit", 0, "max addresses (0 = all)")
+	geoMetricsCmd.Flags().Int("batch-size", 1000, "addresses computed per statement")
+	geoCmd.AddCommand(geoMetricsCmd)
+}
@@ -9,7 +9,7 @@ import (
 var geoMigrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Apply geo schema migrations",
-	Long:  "Applies declarative schema changes via Atlas to all managed schemas.",
+	Long:  "Applies versioned schema migrations via Goose to all managed schemas.",
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
 
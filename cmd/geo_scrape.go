@@ -17,6 +17,7 @@ import (
 	"github.com/sells-group/research-cli/internal/geoscraper"
 	"github.com/sells-group/research-cli/internal/geoscraper/scraper"
 	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/postsync"
 )
 
 var geoScrapeCmd = &cobra.Command{
@@ -85,9 +86,12 @@ Use --force to ignore ShouldRun() scheduling logic.`,
 		}
 		defer closeSyncCache()
 		reg := geoscraper.NewRegistry()
-		scraper.RegisterAll(reg, cfg)
-		queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize)
+		if err := scraper.RegisterAll(reg, cfg); err != nil {
+			return eris.Wrap(err, "register geo scrapers")
+		}
+		queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize).WithMaxDepth(cfg.Geo.QueueMaxDepth)
 		engine := geoscraper.NewEngine(pool, f, syncLog, reg, queue, runDir)
+		engine.SetPostSync(postsync.RunnerFromConfig(pool, syncLog, queue, cfg))
 
 		log.Info("starting geo scrape",
 			zap.Any("category", opts.Category),
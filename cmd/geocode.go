@@ -0,0 +1,190 @@
+package main
+
+import (
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+var geocodeCmd = &cobra.Command{
+	Use:   "geocode",
+	Short: "Drain the geocode queue and backfill reverse geocodes",
+	Long:  "Process addresses enqueued in geo.geocode_queue by geo scraper PostSync hooks and the fed_data bridge, and reverse geocode coordinate-only records.",
+}
+
+var geocodeRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Geocode queued addresses with the Census batch geocoder",
+	Long: `Claims pending addresses from geo.geocode_queue in batches, geocodes them
+with the Census Bureau batch geocoder, and writes coordinates, state, county,
+and tract FIPS to geo.locations. Addresses Census cannot match are retried
+through the other providers in geo.providers, cheapest first, and the
+provider and confidence are recorded per location. Still-unmatched
+addresses are marked no_match; batches that fail are retried with backoff
+until geo.geocode_max_attempts, then marked failed. New locations are then
+assigned county, tract, place, and CBSA from the synced boundaries (see
+geo assign). Runs until no items are due unless --max-batches is set.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		if batchSize <= 0 {
+			batchSize = cfg.Geo.BatchSize
+		}
+		maxBatches, _ := cmd.Flags().GetInt("max-batches")
+
+		worker := geospatial.NewGeocodeWorker(pool, geocode.NewCensusBatchClient(), batchSize, cfg.Geo.GeocodeMaxAttempts).
+			WithSpatialAssign(geospatial.NewSpatialAssigner(pool, 0))
+		if cfg.Geo.CacheEnabled {
+			worker.WithCache(cfg.Geo.CacheTTLDays)
+		}
+		fallback := slices.DeleteFunc(slices.Clone(cfg.Geo.Providers), func(name string) bool { return name == "census" })
+		if len(fallback) > 0 {
+			providers, err := geocode.BuildProviders(pool, geocodeChainConfig(fallback))
+			if err != nil {
+				return eris.Wrap(err, "geocode run")
+			}
+			worker.WithFallback(geocode.NewCascadeClient(pool, providers,
+				geocode.WithCascadeCacheEnabled(cfg.Geo.CacheEnabled),
+				geocode.WithCascadeCacheTTLDays(cfg.Geo.CacheTTLDays),
+				geocode.WithCascadeMinConfidence(cfg.Geo.MinConfidence),
+			))
+		}
+		stats, err := worker.Run(ctx, maxBatches)
+		if stats != nil {
+			zap.L().Info("geocode run complete",
+				zap.Int("batches", stats.Batches),
+				zap.Int("claimed", stats.Claimed),
+				zap.Int("matched", stats.Matched),
+				zap.Int("no_match", stats.NoMatch),
+				zap.Int("retried", stats.Retried),
+				zap.Int("failed", stats.Failed),
+				zap.Int("fallback", stats.Fallback),
+				zap.Int("cache_hits", stats.CacheHits),
+				zap.Int("deduped", stats.Deduped),
+				zap.Int("assigned", stats.Assigned),
+			)
+			printOutputf(cmd, "Geocoded %d addresses in %d batches: %d matched (%d by fallback), %d no match, %d retrying, %d failed; %d cache hits, %d duplicates, %d locations assigned\n",
+				stats.Claimed, stats.Batches, stats.Matched, stats.Fallback, stats.NoMatch, stats.Retried, stats.Failed,
+				stats.CacheHits, stats.Deduped, stats.Assigned)
+		}
+		return eris.Wrap(err, "geocode run")
+	},
+}
+
+var geocodeReverseCmd = &cobra.Command{
+	Use:   "reverse",
+	Short: "Backfill addresses for coordinate-only records by reverse geocoding",
+	Long: `Reverse geocodes rows that arrive with coordinates only and writes the
+standardized address, county FIPS, and Census place name to geo.locations
+with match_type 'reverse', keyed by (source_table, source_id = row id).
+Defaults to geo.epa_sites, geo.infrastructure, and every geo.hifld_* layer
+table. Uses the PostGIS tiger reverse geocoder, then any provider in
+geo.providers that supports reverse lookups (nominatim). Rows already in
+geo.locations are skipped; rows with no result are retried on the next run.
+New locations are then assigned county, tract, place, and CBSA.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := cfg.Validate("fedsync"); err != nil {
+			return err
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		tables, _ := cmd.Flags().GetStringSlice("table")
+		limit, _ := cmd.Flags().GetInt("limit")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		if len(tables) == 0 {
+			if tables, err = geospatial.ReverseTables(ctx, pool); err != nil {
+				return eris.Wrap(err, "geocode reverse")
+			}
+		}
+
+		gc, err := newGeocodeClient(pool, geocode.WithCacheEnabled(false))
+		if err != nil {
+			return eris.Wrap(err, "geocode reverse")
+		}
+		backfill := geospatial.NewReverseBackfill(pool, gc, batchSize)
+		for _, table := range tables {
+			stats, err := backfill.Run(ctx, table, limit)
+			if stats != nil {
+				printOutputf(cmd, "%s: reverse geocoded %d rows: %d matched, %d no result\n",
+					table, stats.Scanned, stats.Matched, stats.NoMatch)
+			}
+			if err != nil {
+				return eris.Wrap(err, "geocode reverse")
+			}
+		}
+		assigned, err := geospatial.NewSpatialAssigner(pool, 0).Run(ctx, geospatial.SpatialAssignOpts{})
+		if err != nil {
+			return eris.Wrap(err, "geocode reverse")
+		}
+		printOutputf(cmd, "Assigned boundaries to %d locations\n", assigned.Scanned)
+		return nil
+	},
+}
+
+// newGeocodeClient returns the PostGIS tiger client when geo.providers is
+// empty, otherwise a cascade over the configured providers ordered by cost.
+// opts apply only to the tiger client.
+func newGeocodeClient(pool db.Pool, opts ...geocode.Option) (geocode.Client, error) {
+	if len(cfg.Geo.Providers) == 0 {
+		return geocode.NewClient(pool, opts...), nil
+	}
+	providers, err := geocode.BuildProviders(pool, geocodeChainConfig(cfg.Geo.Providers))
+	if err != nil {
+		return nil, err
+	}
+	return geocode.NewCascadeClient(pool, providers,
+		geocode.WithCascadeCacheEnabled(cfg.Geo.CacheEnabled),
+		geocode.WithCascadeCacheTTLDays(cfg.Geo.CacheTTLDays),
+		geocode.WithCascadeMinConfidence(cfg.Geo.MinConfidence),
+	), nil
+}
+
+// geocodeChainConfig builds the provider chain config for the given names.
+func geocodeChainConfig(providers []string) geocode.ChainConfig {
+	return geocode.ChainConfig{
+		Providers:    providers,
+		RateLimits:   cfg.Geo.ProviderRPS,
+		MaxRating:    cfg.Geo.MaxRating,
+		MapboxToken:  cfg.Geo.MapboxToken,
+		GoogleAPIKey: cfg.Geo.GoogleAPIKey,
+	}
+}
+
+func init() {
+	geocodeRunCmd.Flags().Int("batch-size", 0, "addresses per Census batch request, max 10000 (default geo.batch_size)")
+	geocodeRunCmd.Flags().Int("max-batches", 0, "stop after this many batches (0 = until the queue is drained)")
+	geocodeReverseCmd.Flags().StringSlice("table", nil, "geo tables to backfill (default geo.epa_sites, geo.infrastructure, geo.hifld_*)")
+	geocodeReverseCmd.Flags().Int("limit", 0, "max rows per table (0 = all)")
+	geocodeReverseCmd.Flags().Int("batch-size", 500, "rows read and written per round trip")
+	geocodeCmd.AddCommand(geocodeRunCmd)
+	geocodeCmd.AddCommand(geocodeReverseCmd)
+	rootCmd.AddCommand(geocodeCmd)
+}
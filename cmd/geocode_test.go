@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+func TestNewGeocodeClient_TigerOnlyByDefault(t *testing.T) {
+	cfg = &config.Config{}
+
+	gc, err := newGeocodeClient(nil)
+	require.NoError(t, err)
+	_, isCascade := gc.(*geocode.CascadeClient)
+	assert.False(t, isCascade)
+}
+
+func TestNewGeocodeClient_Providers(t *testing.T) {
+	cfg = &config.Config{Geo: config.GeoConfig{
+		Providers:     []string{"google", "census"},
+		MinConfidence: 0.8,
+		GoogleAPIKey:  "key",
+	}}
+
+	gc, err := newGeocodeClient(nil)
+	require.NoError(t, err)
+	assert.IsType(t, &geocode.CascadeClient{}, gc)
+
+	cfg.Geo.Providers = []string{"census", "bing"}
+	_, err = newGeocodeClient(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown provider "bing"`)
+}
@@ -7,6 +7,19 @@ import (
 	"github.com/sells-group/research-cli/internal/migrate"
 )
 
+// migrateDBURL resolves the database migrations run against: fedsync's
+// database when set, else the store's.
+func migrateDBURL(op string) (string, error) {
+	dbURL := cfg.Fedsync.DatabaseURL
+	if dbURL == "" {
+		dbURL = cfg.Store.DatabaseURL
+	}
+	if dbURL == "" {
+		return "", eris.Errorf("%s: database URL is required (set store.database_url or fedsync.database_url)", op)
+	}
+	return dbURL, nil
+}
+
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Apply schema migrations",
@@ -14,19 +27,46 @@ var migrateCmd = &cobra.Command{
 
 Runs all pending migrations against the database. On first run against
 an existing database, automatically baselines version 1 (skipping the
-baseline SQL) so subsequent migrations apply cleanly.`,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		ctx := cmd.Context()
+baseline SQL) so subsequent migrations apply cleanly.
 
-		dbURL := cfg.Fedsync.DatabaseURL
-		if dbURL == "" {
-			dbURL = cfg.Store.DatabaseURL
+Migrations are embedded SQL files in internal/migrate/migrations covering
+the public, fed_data, and geo schemas; a new dataset ships its DDL as the
+next numbered file.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		dbURL, err := migrateDBURL("migrate")
+		if err != nil {
+			return err
 		}
-		if dbURL == "" {
-			return eris.New("migrate: database URL is required (set store.database_url or fedsync.database_url)")
+		return migrate.Apply(cmd.Context(), dbURL)
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations (same as migrate)",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		dbURL, err := migrateDBURL("migrate up")
+		if err != nil {
+			return err
 		}
+		return migrate.Apply(cmd.Context(), dbURL)
+	},
+}
 
-		return migrate.Apply(ctx, dbURL)
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back applied migrations",
+	Long: `Roll back the latest applied migration, or with --to every migration
+above the given version. The baseline (version 1) is never rolled back.`,
+	Example: `  research-cli migrate down
+  research-cli migrate down --to 45`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		dbURL, err := migrateDBURL("migrate down")
+		if err != nil {
+			return err
+		}
+		to, _ := cmd.Flags().GetInt64("to")
+		return migrate.Down(cmd.Context(), dbURL, to)
 	},
 }
 
@@ -34,17 +74,11 @@ var migrateStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current migration status",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		ctx := cmd.Context()
-
-		dbURL := cfg.Fedsync.DatabaseURL
-		if dbURL == "" {
-			dbURL = cfg.Store.DatabaseURL
-		}
-		if dbURL == "" {
-			return eris.New("migrate status: database URL is required")
+		dbURL, err := migrateDBURL("migrate status")
+		if err != nil {
+			return err
 		}
-
-		return migrate.Status(ctx, dbURL)
+		return migrate.Status(cmd.Context(), dbURL)
 	},
 }
 
@@ -53,21 +87,18 @@ var migrateBaselineCmd = &cobra.Command{
 	Short: "Record baseline version without running SQL",
 	Long:  "Marks migration version 1 as applied without executing it. Use this for existing databases.",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		ctx := cmd.Context()
-
-		dbURL := cfg.Fedsync.DatabaseURL
-		if dbURL == "" {
-			dbURL = cfg.Store.DatabaseURL
+		dbURL, err := migrateDBURL("migrate baseline")
+		if err != nil {
+			return err
 		}
-		if dbURL == "" {
-			return eris.New("migrate baseline: database URL is required")
-		}
-
-		return migrate.Baseline(ctx, dbURL)
+		return migrate.Baseline(cmd.Context(), dbURL)
 	},
 }
 
 func init() {
+	migrateDownCmd.Flags().Int64("to", -1, "roll back every migration above this version (default: latest only)")
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
 	migrateCmd.AddCommand(migrateStatusCmd)
 	migrateCmd.AddCommand(migrateBaselineCmd)
 	rootCmd.AddCommand(migrateCmd)
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/notify"
+)
+
+// sendRunSummary posts s to the configured notify endpoints. Delivery
+// failures are logged; they never fail the run. The send outlives an
+// interrupted run's context so a cancelled sync still reports.
+func sendRunSummary(ctx context.Context, s notify.Summary) {
+	d := notify.New(cfg.Notify)
+	if d == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	if err := d.Notify(ctx, s); err != nil {
+		zap.L().Warn("failed to send run summary", zap.String("source", s.Source), zap.Error(err))
+	}
+}
+
+// fedsyncSummary converts an engine run's dataset outcomes into a run
+// summary.
+func fedsyncSummary(start time.Time, outcomes []dataset.Outcome) notify.Summary {
+	items := make([]notify.Item, len(outcomes))
+	for i, o := range outcomes {
+		items[i] = notify.Item{
+			Name:      o.Dataset,
+			Status:    o.Status,
+			Rows:      o.Rows,
+			Duration:  o.Elapsed,
+			Error:     o.Error,
+			Anomalies: o.Anomalies,
+		}
+	}
+	return notify.NewSummary("fedsync", start, items)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/notify"
+)
+
+func TestFedsyncSummary(t *testing.T) {
+	s := fedsyncSummary(time.Now(), []dataset.Outcome{
+		{Dataset: "cbp", Status: "complete", Rows: 10, Anomalies: []string{"3 rows rejected"}},
+		{Dataset: "fpds", Status: "failed", Error: "boom"},
+	})
+	assert.Equal(t, "fedsync", s.Source)
+	assert.Equal(t, 1, s.Succeeded)
+	assert.Equal(t, 1, s.Failed)
+	assert.Equal(t, int64(10), s.Rows)
+	assert.Equal(t, []string{"3 rows rejected"}, s.Items[0].Anomalies)
+}
+
+func TestBatchSummary(t *testing.T) {
+	s := batchSummary(time.Now(), batchStats{
+		Succeeded: 8,
+		Failed:    2,
+		Enqueued:  1,
+		Failures:  []notify.Item{{Name: "acme.com", Status: notify.StatusFailed, Error: "timeout"}},
+	})
+	assert.Equal(t, "batch", s.Source)
+	assert.Equal(t, 8, s.Succeeded)
+	assert.Equal(t, 2, s.Failed)
+	assert.Equal(t, []string{"1 companies enqueued to the dead letter queue"}, s.Anomalies)
+	assert.Contains(t, s.Text(), "acme.com failed: timeout")
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/pipeline"
+	"github.com/sells-group/research-cli/internal/registry"
+	"github.com/sells-group/research-cli/internal/store"
+	"github.com/sells-group/research-cli/pkg/notion"
+)
+
+// exportPageSize is the number of runs fetched per ListRuns call.
+const exportPageSize = 500
+
+var (
+	pipelineExportSegment    string
+	pipelineExportFormat     string
+	pipelineExportOut        string
+	pipelineExportFields     []string
+	pipelineExportSince      time.Duration
+	pipelineExportLimit      int
+	pipelineExportConfidence bool
+)
+
+var pipelineExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export enriched companies to CSV or Excel",
+	Long: `Exports the latest completed enrichment per company as a spreadsheet for
+partners outside Salesforce. Field columns come from the field registry
+(all active fields by default, or --fields), each followed by its confidence.
+
+--segment filters rows with comma-separated key<op>value terms, e.g.
+"state=TX,score>=0.6,naics_code~238". Keys are score, name, url, state, city,
+location, salesforce_id, or any field key; operators are = != > >= < <= ~.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		format := strings.ToLower(pipelineExportFormat)
+		if format != pipeline.SheetFormatCSV && format != pipeline.SheetFormatXLSX {
+			return eris.Errorf("unknown format %q (want csv or xlsx)", pipelineExportFormat)
+		}
+		seg, err := pipeline.ParseSegment(pipelineExportSegment)
+		if err != nil {
+			return err
+		}
+
+		fields, err := loadExportFields(ctx)
+		if err != nil {
+			return err
+		}
+		cols, err := pipeline.ExportColumns(fields, pipelineExportFields)
+		if err != nil {
+			return err
+		}
+
+		st, err := initStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer st.Close() //nolint:errcheck
+
+		filter := store.RunFilter{Status: model.RunStatusComplete}
+		if pipelineExportSince > 0 {
+			filter.CreatedAfter = time.Now().Add(-pipelineExportSince)
+		}
+		runs, err := listAllRuns(ctx, st, filter)
+		if err != nil {
+			return err
+		}
+
+		rows := seg.Filter(pipeline.BuildExportRows(runs, fields))
+		if pipelineExportLimit > 0 && len(rows) > pipelineExportLimit {
+			rows = rows[:pipelineExportLimit]
+		}
+
+		out := pipelineExportOut
+		if out == "" {
+			out = "export." + format
+		}
+		f, err := os.Create(filepath.Clean(out))
+		if err != nil {
+			return eris.Wrap(err, "export: create file")
+		}
+		if err := pipeline.WriteSheet(f, format, rows, cols, pipelineExportConfidence); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return eris.Wrap(err, "export: close file")
+		}
+
+		zap.L().Info("export complete",
+			zap.String("out", out),
+			zap.Int("companies", len(rows)),
+			zap.Int("fields", len(cols)),
+		)
+		printOutputf(cmd, "Exported %d companies to %s\n", len(rows), out)
+		return nil
+	},
+}
+
+// loadExportFields loads the field registry from Notion, falling back to the
+// fixture file when Notion is not configured (as initPipeline does).
+func loadExportFields(ctx context.Context) (*model.FieldRegistry, error) {
+	if cfg.Notion.Token == "" || cfg.Notion.FieldDB == "" {
+		fields, err := registry.LoadFieldsFromFile("testdata/fields.json")
+		if err != nil {
+			return nil, eris.Wrap(err, "load field fixtures")
+		}
+		return fields, nil
+	}
+	fields, err := registry.LoadFieldRegistry(ctx, notion.NewClient(cfg.Notion.Token), cfg.Notion.FieldDB)
+	if err != nil {
+		return nil, eris.Wrap(err, "load field registry")
+	}
+	return fields, nil
+}
+
+// listAllRuns pages through ListRuns until a short page is returned.
+func listAllRuns(ctx context.Context, st store.Store, filter store.RunFilter) ([]model.Run, error) {
+	var all []model.Run
+	filter.Limit = exportPageSize
+	for {
+		runs, err := st.ListRuns(ctx, filter)
+		if err != nil {
+			return nil, eris.Wrap(err, "export: list runs")
+		}
+		all = append(all, runs...)
+		if len(runs) < exportPageSize {
+			return all, nil
+		}
+		filter.Offset += len(runs)
+	}
+}
+
+func init() {
+	pipelineExportCmd.Flags().StringVar(&pipelineExportSegment, "segment", "", `row filter, e.g. "state=TX,score>=0.6"`)
+	pipelineExportCmd.Flags().StringVar(&pipelineExportFormat, "format", pipeline.SheetFormatXLSX, "output format: csv or xlsx")
+	pipelineExportCmd.Flags().StringVar(&pipelineExportOut, "out", "", "output file (default export.<format>)")
+	pipelineExportCmd.Flags().StringSliceVar(&pipelineExportFields, "fields", nil, "field keys to include (default: all registry fields)")
+	pipelineExportCmd.Flags().DurationVar(&pipelineExportSince, "since", 0, "only runs created within this window (e.g. 720h)")
+	pipelineExportCmd.Flags().IntVar(&pipelineExportLimit, "limit", 0, "max companies to export (0 = all)")
+	pipelineExportCmd.Flags().BoolVar(&pipelineExportConfidence, "confidence", true, "include a confidence column per field")
+	pipelineCmd.AddCommand(pipelineExportCmd)
+}
@@ -0,0 +1,57 @@
+//go:build !integration
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/store"
+	storemocks "github.com/sells-group/research-cli/internal/store/mocks"
+)
+
+func TestPipelineExportCmd_Metadata(t *testing.T) {
+	assert.Equal(t, "export", pipelineExportCmd.Use)
+	assert.NotEmpty(t, pipelineExportCmd.Short)
+	assert.Equal(t, pipelineCmd, pipelineExportCmd.Parent())
+
+	for _, name := range []string{"segment", "format", "out", "fields", "since", "limit", "confidence"} {
+		require.NotNil(t, pipelineExportCmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "xlsx", pipelineExportCmd.Flags().Lookup("format").DefValue)
+}
+
+func TestPipelineExportCmd_Validation(t *testing.T) {
+	oldFormat, oldSegment := pipelineExportFormat, pipelineExportSegment
+	defer func() { pipelineExportFormat, pipelineExportSegment = oldFormat, oldSegment }()
+	cfg = &config.Config{}
+
+	pipelineExportFormat = "pdf"
+	err := pipelineExportCmd.RunE(pipelineExportCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown format")
+
+	pipelineExportFormat, pipelineExportSegment = "csv", "state"
+	err = pipelineExportCmd.RunE(pipelineExportCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid term")
+}
+
+func TestListAllRuns_Pages(t *testing.T) {
+	st := storemocks.NewMockStore(t)
+	full := make([]model.Run, exportPageSize)
+	st.EXPECT().ListRuns(mock.Anything, mock.MatchedBy(func(f store.RunFilter) bool { return f.Offset == 0 })).
+		Return(full, nil).Once()
+	st.EXPECT().ListRuns(mock.Anything, mock.MatchedBy(func(f store.RunFilter) bool { return f.Offset == exportPageSize })).
+		Return([]model.Run{{ID: "last"}}, nil).Once()
+
+	runs, err := listAllRuns(context.Background(), st, store.RunFilter{Status: model.RunStatusComplete})
+	require.NoError(t, err)
+	assert.Len(t, runs, exportPageSize+1)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/pipeline"
+	temporalpkg "github.com/sells-group/research-cli/internal/temporal"
+	temporalenrich "github.com/sells-group/research-cli/internal/temporal/enrichment"
+	"github.com/sells-group/research-cli/pkg/notion"
+)
+
+// Pipeline import modes.
+const (
+	importModeNotion = "notion" // create Queued lead pages, then enrich
+	importModeQueue  = "queue"  // enqueue enrichment directly, no Notion pages
+)
+
+var (
+	pipelineImportCSV    string
+	pipelineImportMode   string
+	pipelineImportDryRun bool
+	pipelineImportLimit  int
+	pipelineImportStart  bool
+	pipelineImportWait   bool
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Enrichment pipeline intake commands",
+}
+
+var pipelineImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Validate a company CSV and queue it for enrichment",
+	Long: `Imports a company list (conference attendees, purchased lists) for enrichment.
+
+Rows need a name and website URL; a Salesforce ID and location are optional.
+Invalid rows and duplicate domains are reported and skipped.
+
+In notion mode (default) each row becomes a Queued page in the lead database
+and enrichment starts via Temporal; with --start=false the pages wait for the
+next batch run. In queue mode companies go straight to a Temporal batch
+enrichment workflow without Notion pages.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		switch pipelineImportMode {
+		case importModeNotion:
+			if cfg.Notion.Token == "" {
+				return eris.New("notion token is required (RESEARCH_NOTION_TOKEN)")
+			}
+			if cfg.Notion.LeadDB == "" {
+				return eris.New("notion lead DB ID is required (RESEARCH_NOTION_LEAD_DB)")
+			}
+		case importModeQueue:
+			if !pipelineImportStart && !pipelineImportDryRun {
+				return eris.New("queue mode has nothing to do with --start=false")
+			}
+		default:
+			return eris.Errorf("unknown mode %q (want %s or %s)", pipelineImportMode, importModeNotion, importModeQueue)
+		}
+
+		companies, issues, err := pipeline.ParseImportCSV(pipelineImportCSV)
+		if err != nil {
+			return err
+		}
+		for _, is := range issues {
+			printOutputf(cmd, "line %d: %s: %s\n", is.Line, is.Name, is.Reason)
+		}
+		if pipelineImportLimit > 0 && len(companies) > pipelineImportLimit {
+			companies = companies[:pipelineImportLimit]
+		}
+		printOutputf(cmd, "%d valid, %d rejected\n", len(companies), len(issues))
+
+		if pipelineImportDryRun || len(companies) == 0 {
+			return nil
+		}
+
+		if pipelineImportMode == importModeNotion {
+			companies, err = createLeadPages(ctx, notion.NewClient(cfg.Notion.Token), cfg.Notion.LeadDB, companies)
+			printOutputf(cmd, "Created %d Notion lead pages\n", len(companies))
+			if err != nil {
+				return err
+			}
+			if !pipelineImportStart {
+				return nil
+			}
+		}
+
+		return startImportEnrichment(ctx, cmd, companies)
+	},
+}
+
+// createLeadPages creates a Queued lead page per company and returns the
+// companies that were created, with NotionPageID set so enrichment writes
+// back to the new pages. On error the pages created so far are returned.
+func createLeadPages(ctx context.Context, nc notion.Client, dbID string, companies []model.Company) ([]model.Company, error) {
+	created := make([]model.Company, 0, len(companies))
+	for _, c := range companies {
+		page, err := notion.CreateQueuedLead(ctx, nc, dbID, notion.Lead{
+			Name:         c.Name,
+			URL:          c.URL,
+			SalesforceID: c.SalesforceID,
+			Location:     c.Location,
+		})
+		if err != nil {
+			return created, err
+		}
+		c.NotionPageID = string(page.ID)
+		created = append(created, c)
+	}
+	return created, nil
+}
+
+// startImportEnrichment starts a batch enrichment workflow for the imported
+// companies, optionally waiting for it to finish.
+func startImportEnrichment(ctx context.Context, cmd *cobra.Command, companies []model.Company) error {
+	c, err := temporalpkg.NewClient(cfg.Temporal)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	run, err := startBatchEnrich(ctx, c, companies)
+	if err != nil {
+		return err
+	}
+	printOutputf(cmd, "Enrichment started: workflow %s (%d companies)\n", run.GetID(), len(companies))
+
+	if !pipelineImportWait {
+		return nil
+	}
+	var result temporalenrich.BatchEnrichResult
+	if err := run.Get(ctx, &result); err != nil {
+		return eris.Wrap(err, "batch enrich workflow failed")
+	}
+
+	zap.L().Info("import enrichment complete",
+		zap.Int("succeeded", result.Succeeded),
+		zap.Int("failed", result.Failed),
+	)
+	printOutputf(cmd, "Batch complete: %d succeeded, %d failed\n", result.Succeeded, result.Failed)
+	return nil
+}
+
+func init() {
+	pipelineImportCmd.Flags().StringVar(&pipelineImportCSV, "csv", "", "path to company CSV (required)")
+	pipelineImportCmd.Flags().StringVar(&pipelineImportMode, "mode", importModeNotion, "intake mode: notion or queue")
+	pipelineImportCmd.Flags().BoolVar(&pipelineImportDryRun, "dry-run", false, "validate the CSV without creating pages or jobs")
+	pipelineImportCmd.Flags().IntVar(&pipelineImportLimit, "limit", 0, "max companies to import (0 = all)")
+	pipelineImportCmd.Flags().BoolVar(&pipelineImportStart, "start", true, "start enrichment after import")
+	pipelineImportCmd.Flags().BoolVar(&pipelineImportWait, "wait", false, "wait for the enrichment workflow to finish")
+	_ = pipelineImportCmd.MarkFlagRequired("csv")
+	pipelineCmd.AddCommand(pipelineImportCmd)
+	rootCmd.AddCommand(pipelineCmd)
+}
@@ -0,0 +1,81 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	notionmocks "github.com/sells-group/research-cli/pkg/notion/mocks"
+)
+
+func TestPipelineImportCmd_Metadata(t *testing.T) {
+	assert.Equal(t, "import", pipelineImportCmd.Use)
+	assert.NotEmpty(t, pipelineImportCmd.Short)
+	assert.Equal(t, pipelineCmd, pipelineImportCmd.Parent())
+
+	for _, name := range []string{"csv", "mode", "dry-run", "limit", "start", "wait"} {
+		require.NotNil(t, pipelineImportCmd.Flags().Lookup(name), name)
+	}
+}
+
+func TestPipelineImportCmd_Validation(t *testing.T) {
+	oldMode, oldStart := pipelineImportMode, pipelineImportStart
+	defer func() { pipelineImportMode, pipelineImportStart = oldMode, oldStart }()
+
+	cfg = &config.Config{Notion: config.NotionConfig{LeadDB: "db"}}
+	pipelineImportMode = importModeNotion
+	err := pipelineImportCmd.RunE(pipelineImportCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notion token is required")
+
+	pipelineImportMode = "email"
+	err = pipelineImportCmd.RunE(pipelineImportCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown mode")
+
+	pipelineImportMode = importModeQueue
+	pipelineImportStart = false
+	err = pipelineImportCmd.RunE(pipelineImportCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to do")
+}
+
+func TestPipelineImportCmd_DryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "companies.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,url\nAcme,acme.com\nBad,\n"), 0o600))
+
+	oldCSV, oldMode, oldDry := pipelineImportCSV, pipelineImportMode, pipelineImportDryRun
+	defer func() { pipelineImportCSV, pipelineImportMode, pipelineImportDryRun = oldCSV, oldMode, oldDry }()
+	pipelineImportCSV, pipelineImportMode, pipelineImportDryRun = path, importModeQueue, true
+
+	var out bytes.Buffer
+	pipelineImportCmd.SetOut(&out)
+	defer pipelineImportCmd.SetOut(nil)
+
+	cfg = &config.Config{}
+	require.NoError(t, pipelineImportCmd.RunE(pipelineImportCmd, nil))
+	assert.Contains(t, out.String(), "line 3: Bad: missing URL")
+	assert.Contains(t, out.String(), "1 valid, 1 rejected")
+}
+
+func TestCreateLeadPages(t *testing.T) {
+	nc := notionmocks.NewMockClient(t)
+	nc.EXPECT().CreatePage(mock.Anything, mock.Anything).
+		Return(&notionapi.Page{ID: "page-1"}, nil).Once()
+
+	got, err := createLeadPages(context.Background(), nc, "db", []model.Company{{Name: "Acme", URL: "https://acme.com"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "page-1", got[0].NotionPageID)
+}
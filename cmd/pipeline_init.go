@@ -9,10 +9,12 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 
+	"github.com/sells-group/research-cli/internal/chaos"
 	"github.com/sells-group/research-cli/internal/company"
 	"github.com/sells-group/research-cli/internal/estimate"
 	"github.com/sells-group/research-cli/internal/geo"
 	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
 	"github.com/sells-group/research-cli/internal/pipeline"
 	"github.com/sells-group/research-cli/internal/registry"
 	"github.com/sells-group/research-cli/internal/scrape"
@@ -70,7 +72,7 @@ func initPipeline(ctx context.Context) (*pipelineEnv, error) {
 	}
 
 	notionClient := notion.NewClient(cfg.Notion.Token)
-	anthropicClient := anthropicpkg.NewClient(cfg.Anthropic.Key)
+	anthropicClient := chaos.WrapAnthropic(anthropicpkg.NewClient(cfg.Anthropic.Key), chaos.New(cfg.Chaos))
 	firecrawlClient := firecrawl.NewClient(cfg.Firecrawl.Key,
 		firecrawl.WithBaseURL(cfg.Firecrawl.BaseURL),
 		firecrawl.WithRateLimit(rate.Every(5*time.Second), 3), // ~12 req/min, burst 3
@@ -87,6 +89,7 @@ func initPipeline(ctx context.Context) (*pipelineEnv, error) {
 		_ = st.Close()
 		return nil, err
 	}
+	sfClient = opsmetrics.WrapSalesforce(sfClient)
 
 	// Google Places API client (optional — ultimate fallback for reviews).
 	var googleClient google.Client
@@ -202,6 +205,23 @@ func initPipeline(ctx context.Context) (*pipelineEnv, error) {
 
 	p := pipeline.New(cfg, st, chain, jinaClient, firecrawlClient, perplexityClient, anthropicClient, sfClient, notionClient, googleClient, pppClient, revenueEstimator, waterfallExec, questions, fields)
 
+	// Load the candidate question pack for shadow mode (optional).
+	if cfg.Pipeline.Shadow.Enabled && cfg.Pipeline.Shadow.QuestionsFile != "" {
+		shadowQuestions, sqErr := registry.LoadQuestionsFromFile(cfg.Pipeline.Shadow.QuestionsFile)
+		if sqErr != nil {
+			if pppClient != nil {
+				pppClient.Close()
+			}
+			_ = st.Close()
+			return nil, eris.Wrap(sqErr, "load shadow questions")
+		}
+		p.SetShadowQuestions(shadowQuestions)
+		zap.L().Info("shadow mode enabled",
+			zap.String("variant", cfg.Pipeline.Shadow.Variant),
+			zap.Int("questions", len(shadowQuestions)),
+		)
+	}
+
 	// Wire company golden record importer when using Postgres.
 	if ps, ok := st.(*store.PostgresStore); ok {
 		companyStore := company.NewPostgresStore(ps.Pool())
@@ -213,14 +233,17 @@ func initPipeline(ctx context.Context) (*pipelineEnv, error) {
 	// Wire up geocoder for Phase 7D (MSA association) if enabled.
 	if cfg.Geo.Enabled {
 		if ps, ok := st.(*store.PostgresStore); ok {
-			gc := geocode.NewClient(ps.Pool(),
+			gc, err := newGeocodeClient(ps.Pool(),
 				geocode.WithCacheEnabled(cfg.Geo.CacheEnabled),
 				geocode.WithMaxRating(cfg.Geo.MaxRating),
 			)
+			if err != nil {
+				return nil, eris.Wrap(err, "geocode client")
+			}
 			p.SetGeocoder(gc)
 
 			cStore := company.NewPostgresStore(ps.Pool())
-			assoc := geo.NewAssociator(ps.Pool(), cStore)
+			assoc := geo.NewAssociator(ps.Pool(), cStore, geo.WithGeoSchema())
 			p.SetGeoAssociator(assoc)
 			zap.L().Info("geocoding + MSA association enabled")
 		}
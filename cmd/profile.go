@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/readmodel"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile [company-id | domain]",
+	Short: "Print everything stored about a company as JSON",
+	Long: `Assembles the unified company profile served by GET /api/v1/companies/{id}/profile:
+the golden record, identifiers, geocoded addresses and MSAs, contacts, licenses,
+financials, provider sources, linked fed_data rows, the latest extracted answer
+per field, and enrichment runs, each with its source and timestamps.
+
+Examples:
+  research-cli profile 42
+  research-cli profile acme.com
+  research-cli profile --system crd --identifier 123456`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		var lookup readmodel.ProfileLookup
+		lookup.System, _ = cmd.Flags().GetString("system")
+		lookup.Identifier, _ = cmd.Flags().GetString("identifier")
+		if len(args) == 1 {
+			if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+				lookup.CompanyID = id
+			} else {
+				lookup.Domain = args[0]
+			}
+		}
+		if lookup.CompanyID == 0 && lookup.Domain == "" && (lookup.System == "" || lookup.Identifier == "") {
+			return eris.New("profile: pass a company id or domain, or --system and --identifier")
+		}
+
+		pool, err := openReadModelPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		profile, err := readmodel.NewPostgresService(pool, cfg).Profiles.CompanyProfile(ctx, lookup)
+		if err != nil {
+			return eris.Wrap(err, "profile")
+		}
+		if profile == nil {
+			return eris.New("profile: company not found")
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(profile)
+	},
+}
+
+func init() {
+	profileCmd.Flags().String("system", "", "identifier system to look up by (crd, cik, ein, uei, ...)")
+	profileCmd.Flags().String("identifier", "", "identifier value within --system")
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/duckcache"
+)
+
+var (
+	reportSnapshot string
+	reportFilter   string
+	reportOut      string
+	reportNames    []string
+	reportTables   []string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report <name>",
+	Short: "Run an analytical report against the warehouse or a DuckDB snapshot",
+	Long: `Runs a named report (msa, benchmarks, edgar_fts, physical_risk, cost_of_living)
+against the warehouse, or locally against a DuckDB snapshot file with --snapshot.
+Snapshots let analysts without warehouse access generate the same reports from a
+point-in-time copy.
+
+Examples:
+  # Take a snapshot of the tables all reports need
+  research-cli report snapshot --out reports.duckdb
+
+  # Run locally against the snapshot
+  research-cli report msa --snapshot reports.duckdb
+  research-cli report benchmarks --snapshot reports.duckdb --filter 48
+  research-cli report edgar_fts --snapshot reports.duckdb --filter "succession plan"
+  research-cli report physical_risk --snapshot reports.duckdb --filter 48
+  research-cli report cost_of_living --snapshot reports.duckdb --filter 48
+
+  # Run against the warehouse
+  research-cli report msa --filter 12420`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: duckcache.ReportNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		r, err := duckcache.LookupReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		var res *duckcache.QueryResult
+		if reportSnapshot != "" {
+			res, err = r.Run(ctx, nil, reportSnapshot, reportFilter)
+		} else {
+			pool, perr := fedsyncPool(ctx)
+			if perr != nil {
+				return perr
+			}
+			defer pool.Close()
+			res, err = r.Run(ctx, pool, "", reportFilter)
+		}
+		if err != nil {
+			return eris.Wrapf(err, "report %s", r.Name)
+		}
+
+		if len(res.Rows) == 0 {
+			printOutputln(cmd, "No rows.")
+			return nil
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, strings.Join(res.Columns, "\t"))
+		for _, row := range res.Rows {
+			_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	},
+}
+
+var reportSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Copy report tables into an embedded DuckDB file",
+	Long: `Copies the warehouse tables read by the selected reports (plus any
+--table extras) into a DuckDB file. Re-running replaces each table in place.
+Requires a cgo-enabled build.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if reportOut == "" {
+			return eris.New("report snapshot: --out is required")
+		}
+		names := reportNames
+		if len(names) == 0 {
+			names = duckcache.ReportNames()
+		}
+		tables, err := duckcache.ReportTables(names, reportTables)
+		if err != nil {
+			return eris.Wrap(err, "report snapshot")
+		}
+
+		pool, err := fedsyncPool(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		results, err := duckcache.Snapshot(ctx, pool, reportOut, tables)
+		for _, res := range results {
+			printOutputf(cmd, "  %-40s %10d rows  %s\n", res.Table, res.Rows, res.Duration.Round(1e6))
+		}
+		if err != nil {
+			return eris.Wrap(err, "report snapshot")
+		}
+		printOutputf(cmd, "Snapshot written to %s (%d tables)\n", reportOut, len(results))
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportSnapshot, "snapshot", "", "DuckDB snapshot file to query instead of the warehouse")
+	reportCmd.Flags().StringVar(&reportFilter, "filter", "", "restrict to one CBSA code (msa), state FIPS (benchmarks), or query (edgar_fts)")
+
+	reportSnapshotCmd.Flags().StringVar(&reportOut, "out", "", "DuckDB file to write (required)")
+	reportSnapshotCmd.Flags().StringSliceVar(&reportNames, "report", nil, "reports to snapshot tables for (default: all)")
+	reportSnapshotCmd.Flags().StringSliceVar(&reportTables, "table", nil, "extra schema-qualified tables to include")
+
+	reportCmd.AddCommand(reportSnapshotCmd)
+	rootCmd.AddCommand(reportCmd)
+}
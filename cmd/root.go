@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
 )
 
 var cfg *config.Config
 
+// stopMetrics shuts down the --metrics-addr listener, if one was started.
+var stopMetrics func(context.Context) error
+
 var rootCmd = &cobra.Command{
 	Use:   "research-cli",
 	Short: "Automated account enrichment pipeline",
@@ -40,13 +46,36 @@ var rootCmd = &cobra.Command{
 			cfg.Pipeline.Tier3Gate = "always"
 		}
 
+		// --chaos turns on fault injection for resilience testing.
+		if chaosOn, _ := cmd.Flags().GetBool("chaos"); chaosOn {
+			cfg.Chaos.Enabled = true
+		}
+
+		if v, _ := cmd.Flags().GetString("metrics-addr"); v != "" {
+			cfg.Monitoring.MetricsAddr = v
+		}
+
 		if err := config.InitLogger(cfg.Log); err != nil {
 			return fmt.Errorf("init logger: %w", err)
 		}
 
+		if addr := cfg.Monitoring.MetricsAddr; addr != "" {
+			stop, err := opsmetrics.Listen(addr)
+			if err != nil {
+				return fmt.Errorf("start metrics listener: %w", err)
+			}
+			stopMetrics = stop
+			zap.L().Info("serving prometheus metrics", zap.String("addr", addr))
+		}
+
 		return nil
 	},
 	PersistentPostRun: func(_ *cobra.Command, _ []string) {
+		if stopMetrics != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_ = stopMetrics(ctx)
+			cancel()
+		}
 		_ = zap.L().Sync()
 	},
 }
@@ -60,6 +89,11 @@ func init() {
 	rootCmd.PersistentFlags().String("haiku-model", "", "override Haiku model name (e.g. claude-haiku-4-5-20251001)")
 	rootCmd.PersistentFlags().String("sonnet-model", "", "override Sonnet model name (e.g. claude-sonnet-4-5-20250929)")
 	rootCmd.PersistentFlags().String("opus-model", "", "override Opus model name (e.g. claude-opus-4-6)")
+
+	rootCmd.PersistentFlags().Bool("chaos", false, "inject faults per the chaos config section (resilience testing only)")
+	_ = rootCmd.PersistentFlags().MarkHidden("chaos")
+
+	rootCmd.PersistentFlags().String("metrics-addr", "", "serve Prometheus metrics at /metrics on this address (e.g. :9090)")
 }
 
 func main() {
@@ -43,6 +43,24 @@ func TestBatchCommand_Flags(t *testing.T) {
 	assert.Equal(t, "100", flag.DefValue)
 }
 
+func TestGeocodeRunCommand_Flags(t *testing.T) {
+	assert.Equal(t, geocodeCmd, geocodeRunCmd.Parent())
+	for _, name := range []string{"batch-size", "max-batches"} {
+		flag := geocodeRunCmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "geocode run should have --%s flag", name)
+		assert.Equal(t, "0", flag.DefValue)
+	}
+}
+
+func TestGeocodeReverseCommand_Flags(t *testing.T) {
+	assert.Equal(t, geocodeCmd, geocodeReverseCmd.Parent())
+	for name, def := range map[string]string{"table": "[]", "limit": "0", "batch-size": "500"} {
+		flag := geocodeReverseCmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "geocode reverse should have --%s flag", name)
+		assert.Equal(t, def, flag.DefValue)
+	}
+}
+
 func TestServeCommand_Flags(t *testing.T) {
 	flag := serveCmd.Flags().Lookup("port")
 	require.NotNil(t, flag, "serve command should have --port flag")
@@ -62,9 +80,30 @@ func TestFedsyncCommand_HasSubcommands(t *testing.T) {
 	}
 }
 
+func TestMigrateCommand_HasSubcommands(t *testing.T) {
+	names := make(map[string]bool)
+	for _, c := range migrateCmd.Commands() {
+		names[c.Name()] = true
+	}
+	for _, name := range []string{"up", "down", "status", "baseline"} {
+		assert.True(t, names[name], "migrate should have subcommand %q", name)
+	}
+
+	flag := migrateDownCmd.Flags().Lookup("to")
+	require.NotNil(t, flag, "migrate down should have --to flag")
+	assert.Equal(t, "-1", flag.DefValue)
+}
+
 func TestFedsyncSyncCommand_Flags(t *testing.T) {
 	for _, flagName := range []string{"phase", "datasets", "force", "full"} {
 		flag := fedsyncSyncCmd.Flags().Lookup(flagName)
 		assert.NotNil(t, flag, "fedsync sync should have --%s flag", flagName)
 	}
 }
+
+func TestFedsyncDaemonCommand_Flags(t *testing.T) {
+	for _, flagName := range []string{"phase", "datasets", "full", "interval"} {
+		flag := fedsyncDaemonCmd.Flags().Lookup(flagName)
+		assert.NotNil(t, flag, "fedsync daemon should have --%s flag", flagName)
+	}
+}
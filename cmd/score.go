@@ -24,7 +24,7 @@ var scoreCmd = &cobra.Command{
 Pass 0 (ADV Score): Queries mv_firm_combined, adv_computed_metrics, and
 brochure/CRS text for keyword matches. Produces a 0-100 score based on
 configurable weights (AUM fit, growth, client quality, service fit,
-geography, industry, regulatory cleanliness, succession signals).
+geography, industry, regulatory cleanliness, succession signals, momentum).
 
 Pass 1 (Website Score): Crawls firm websites and refines ADV scores with
 website quality signals, succession language, technology mentions, and
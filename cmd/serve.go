@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/api"
+	"github.com/sells-group/research-cli/internal/contract"
 	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/enrichmentstart"
 	"github.com/sells-group/research-cli/internal/fedsync"
@@ -69,6 +70,12 @@ var serveCmd = &cobra.Command{
 			zap.L().Info("monitoring: alert checker enabled",
 				zap.String("webhook_url", cfg.Monitoring.WebhookURL),
 			)
+
+			if cfg.Monitoring.ContractCheckHours > 0 {
+				checker := contract.NewChecker(env.SF, env.Notion, env.Fields, cfg.Notion, cfg.Pipeline.SFDiff)
+				interval := time.Duration(cfg.Monitoring.ContractCheckHours) * time.Hour
+				go contract.NewMonitor(checker, alerter, interval).Run(ctx)
+			}
 		}
 
 		h := api.NewHandlers(cfg, env.Store, env.Pipeline, collector, nil)
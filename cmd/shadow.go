@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+
+	"github.com/sells-group/research-cli/internal/pipeline"
+)
+
+var shadowCmd = &cobra.Command{
+	Use:   "shadow",
+	Short: "Inspect shadow-mode results",
+	Long:  "Commands for comparing shadow question packs and models against production.",
+}
+
+// -- shadow report --
+
+var shadowReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize agreement between a shadow variant and production",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		st, err := initStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer st.Close() //nolint:errcheck
+		if err := st.Migrate(ctx); err != nil {
+			return err
+		}
+
+		variant, _ := cmd.Flags().GetString("variant")
+		if variant == "" {
+			variant = cfg.Pipeline.Shadow.Variant
+		}
+		since, _ := cmd.Flags().GetDuration("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		var after time.Time
+		if since > 0 {
+			after = time.Now().Add(-since)
+		}
+
+		results, err := st.ListShadowResults(ctx, variant, after)
+		if err != nil {
+			return eris.Wrap(err, "shadow report")
+		}
+
+		if len(results) == 0 {
+			fmt.Fprintf(os.Stderr, "No shadow results found for variant %q.\n", variant)
+			return nil
+		}
+
+		report := pipeline.BuildShadowReport(variant, results)
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+
+		printOutputf(cmd, "%s", pipeline.FormatShadowReport(report))
+		return nil
+	},
+}
+
+func init() {
+	shadowReportCmd.Flags().String("variant", "", "shadow variant name (default: pipeline.shadow.variant)")
+	shadowReportCmd.Flags().Duration("since", 7*24*time.Hour, "time window for the report (0 = all)")
+	shadowReportCmd.Flags().Bool("json", false, "output the report as JSON")
+
+	shadowCmd.AddCommand(shadowReportCmd)
+	rootCmd.AddCommand(shadowCmd)
+}
@@ -176,7 +176,8 @@ func buildFedsyncWorker(ctx context.Context, c client.Client) (worker.Worker, er
 	}
 	_ = closeSyncCache
 	reg := dataset.NewRegistry(cfg)
-	activities := temporalfedsync.NewActivities(pool, f, syncLog, reg, tempDir, cfg)
+	queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize).WithMaxDepth(cfg.Geo.QueueMaxDepth)
+	activities := temporalfedsync.NewActivities(pool, f, syncLog, reg, queue, tempDir, cfg)
 
 	w := worker.New(c, temporalpkg.FedsyncTaskQueue, worker.Options{})
 	w.RegisterWorkflow(temporalfedsync.RunWorkflow)
@@ -199,13 +200,16 @@ func buildGeoWorker(ctx context.Context, c client.Client) (worker.Worker, error)
 		return nil, err
 	}
 
-	gcClient := geocode.NewClient(pool,
+	gcClient, err := newGeocodeClient(pool,
 		geocode.WithCacheEnabled(cfg.Geo.CacheEnabled),
 		geocode.WithMaxRating(cfg.Geo.MaxRating),
 		geocode.WithCacheTTLDays(cfg.Geo.CacheTTLDays),
 	)
+	if err != nil {
+		return nil, eris.Wrap(err, "geocode client")
+	}
 	cs := company.NewPostgresStore(pool)
-	assoc := geo.NewAssociator(pool, cs)
+	assoc := geo.NewAssociator(pool, cs, geo.WithGeoSchema())
 
 	activities := temporalgeo.NewActivities(pool, cs, gcClient, assoc, cfg)
 
@@ -235,8 +239,10 @@ func buildGeoWorker(ctx context.Context, c client.Client) (worker.Worker, error)
 	}
 	_ = closeSyncCache
 	scraperReg := geoscraper.NewRegistry()
-	scraper.RegisterAll(scraperReg, cfg)
-	queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize)
+	if err := scraper.RegisterAll(scraperReg, cfg); err != nil {
+		return nil, eris.Wrap(err, "register geo scrapers")
+	}
+	queue := geospatial.NewGeocodeQueue(pool, nil, cfg.Geo.BatchSize).WithMaxDepth(cfg.Geo.QueueMaxDepth)
 	geoScraperActivities := temporalgeoscraper.NewActivities(pool, f, syncLog, scraperReg, queue, tempDir, cfg)
 
 	w := worker.New(c, temporalpkg.GeoTaskQueue, worker.Options{})
@@ -87,6 +87,7 @@ import type {
   Address,
   AddressMSA,
   Match,
+  CompanyProfile,
   DatasetStatus,
   SyncEntry,
   TableMeta,
@@ -168,6 +169,10 @@ export const api = {
     return get<{ runs: Run[] }>(`/companies/${id}/runs`);
   },
 
+  getCompanyProfile(id: number) {
+    return get<CompanyProfile>(`/companies/${id}/profile`);
+  },
+
   searchCompanies(params: Record<string, string>) {
     return get<{ companies: CompanyRecord[]; total: number }>(
       "/companies/search",
@@ -98,6 +98,24 @@ export const datasetsMeta = [
     table: "fed_data.usaspending_awards",
     description: "USAspending.gov award and subaward data",
   },
+  {
+    name: "sam_entities",
+    label: "SAM.gov Entities",
+    phase: "1",
+    cadence: "weekly",
+    table: "fed_data.sam_entities",
+    description:
+      "SAM.gov entity registrations by UEI and the federal exclusions (debarment) list",
+  },
+  {
+    name: "federal_grants",
+    label: "Federal Grants & Single Audits",
+    phase: "1",
+    cadence: "monthly",
+    table: "fed_data.grants",
+    description:
+      "USAspending grant awards and Federal Audit Clearinghouse single audits",
+  },
   {
     name: "adv_part1",
     label: "ADV Part 1A",
@@ -138,6 +156,22 @@ export const datasetsMeta = [
     table: "fed_data.edgar_entities",
     description: "EDGAR bulk company submissions and filings",
   },
+  {
+    name: "company_tickers",
+    label: "SEC Company Tickers",
+    phase: "1b",
+    cadence: "weekly",
+    table: "fed_data.cik_tickers",
+    description: "SEC ticker-to-CIK map with listing exchange",
+  },
+  {
+    name: "edgar_fulltext",
+    label: "EDGAR Full-Text Search",
+    phase: "1b",
+    cadence: "weekly",
+    table: "fed_data.edgar_fts_hits",
+    description: "EDGAR full-text search hits for configured keyword queries",
+  },
   {
     name: "entity_xref",
     label: "Entity Cross-Reference",
@@ -146,6 +180,15 @@ export const datasetsMeta = [
     table: "fed_data.entity_xref",
     description: "Cross-reference relationships across entity datasets",
   },
+  {
+    name: "investor_graph",
+    label: "13F Investor Graph",
+    phase: "1b",
+    cadence: "quarterly",
+    table: "fed_data.f13_investor_positions",
+    description:
+      "Advisor-to-issuer positions over time derived from 13F holdings",
+  },
   {
     name: "adv_part2",
     label: "ADV Part 2 Brochures",
@@ -166,9 +209,10 @@ export const datasetsMeta = [
     name: "sec_enforcement",
     label: "SEC Enforcement",
     phase: "2",
-    cadence: "monthly",
+    cadence: "weekly",
     table: "fed_data.sec_enforcement_actions",
-    description: "SEC enforcement actions and proceedings",
+    description:
+      "SEC litigation releases and administrative proceedings linked to CRD/CIK",
   },
   {
     name: "form_bd",
@@ -186,6 +230,15 @@ export const datasetsMeta = [
     table: "fed_data.osha_inspections",
     description: "OSHA injury tracking application inspection data",
   },
+  {
+    name: "osha_ita_300a",
+    label: "OSHA ITA 300A",
+    phase: "2",
+    cadence: "annual",
+    table: "fed_data.osha_ita_300a",
+    description:
+      "OSHA ITA Form 300A establishment injury summaries with hours worked and DART/TRIR rates",
+  },
   {
     name: "epa_echo",
     label: "EPA ECHO",
@@ -194,6 +247,15 @@ export const datasetsMeta = [
     table: "fed_data.epa_facilities",
     description: "EPA ECHO facility compliance and enforcement",
   },
+  {
+    name: "epa_enforcement",
+    label: "EPA Enforcement",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.epa_enforcement",
+    description:
+      "EPA ECHO formal actions, penalties, noncompliance flags, and civil cases by facility",
+  },
   {
     name: "nes",
     label: "Nonemployer Statistics",
@@ -248,7 +310,8 @@ export const datasetsMeta = [
     phase: "2",
     cadence: "annual",
     table: "fed_data.bea_regional",
-    description: "BEA regional GDP and personal income data",
+    description:
+      "BEA regional GDP, personal income, and compensation by county and MSA",
   },
   {
     name: "irs_soi_migration",
@@ -266,6 +329,190 @@ export const datasetsMeta = [
     table: "fed_data.building_permits",
     description: "Census building permits by place and county",
   },
+  {
+    name: "nppes",
+    label: "NPPES NPI Registry",
+    phase: "2",
+    cadence: "weekly",
+    table: "fed_data.npi_providers",
+    description: "CMS NPPES NPI healthcare provider registry",
+  },
+  {
+    name: "acs",
+    label: "American Community Survey",
+    phase: "2",
+    cadence: "annual",
+    table: "fed_data.acs_data",
+    description: "Census ACS 5-year demographics by county and tract",
+  },
+  {
+    name: "bfs",
+    label: "Business Formation Statistics",
+    phase: "2",
+    cadence: "weekly",
+    table: "fed_data.bfs_data",
+    description: "Census BFS business applications by state and NAICS sector",
+  },
+  {
+    name: "bds",
+    label: "Business Dynamics Statistics",
+    phase: "2",
+    cadence: "annual",
+    table: "fed_data.bds_data",
+    description:
+      "Census BDS establishment entry/exit and job flows by sector and geography",
+  },
+  {
+    name: "fmcsa",
+    label: "FMCSA Carriers",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.fmcsa_carriers",
+    description:
+      "FMCSA motor carrier census with SMS safety percentiles, keyed by USDOT number",
+  },
+  {
+    name: "faa_registry",
+    label: "FAA Aircraft",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.faa_aircraft",
+    description:
+      "FAA aircraft registry with business registrants matched to companies and ADV firms",
+  },
+  {
+    name: "emma",
+    label: "MSRB EMMA",
+    phase: "2",
+    cadence: "weekly",
+    table: "fed_data.emma_issues",
+    description:
+      "MSRB EMMA municipal issues with continuing disclosure rollups and municipal advisors linked to ADV firms",
+  },
+  {
+    name: "sos_co",
+    label: "Colorado SOS",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.sos_entities",
+    description:
+      "Colorado Secretary of State business entities with status, registered agent, and formation date",
+  },
+  {
+    name: "sos_wa",
+    label: "Washington SOS",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.sos_entities",
+    description:
+      "Washington Secretary of State business entities keyed by UBI number",
+  },
+  {
+    name: "sos_fl",
+    label: "Florida SOS",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.sos_entities",
+    description:
+      "Florida Sunbiz corporate filings from the cordata fixed-width extract",
+  },
+  {
+    name: "sos_oh",
+    label: "Ohio SOS",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.sos_entities",
+    description: "Ohio Secretary of State new business filings",
+  },
+  {
+    name: "ucc_co",
+    label: "Colorado UCC",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.ucc_filings",
+    description:
+      "Colorado UCC financing statements with debtor, secured party, and collateral type",
+  },
+  {
+    name: "ucc_fl",
+    label: "Florida UCC",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.ucc_filings",
+    description:
+      "Florida Secured Transaction Registry UCC financing statements",
+  },
+  {
+    name: "ucc_tx",
+    label: "Texas UCC",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.ucc_filings",
+    description: "Texas Secretary of State UCC financing statements",
+  },
+  {
+    name: "ucc_wa",
+    label: "Washington UCC",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.ucc_filings",
+    description:
+      "Washington UCC financing statements with debtor, secured party, and collateral type",
+  },
+  {
+    name: "courtlistener",
+    label: "Court Dockets",
+    phase: "2",
+    cadence: "weekly",
+    table: "fed_data.court_dockets",
+    description:
+      "CourtListener RECAP federal dockets naming tracked companies as defendants",
+  },
+  {
+    name: "finra_arbitration",
+    label: "FINRA Arbitration",
+    phase: "2",
+    cadence: "weekly",
+    table: "fed_data.finra_awards",
+    description:
+      "FINRA arbitration awards with OCR'd award text, amounts, and respondent firm CRDs",
+  },
+  {
+    name: "insurance_co",
+    label: "Colorado Insurance",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.insurance_producers",
+    description:
+      "Colorado DOI insurance producer and agency licenses with lines of authority",
+  },
+  {
+    name: "insurance_fl",
+    label: "Florida Insurance",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.insurance_producers",
+    description:
+      "Florida DFS insurance agent and agency licenses with lines of authority",
+  },
+  {
+    name: "insurance_tx",
+    label: "Texas Insurance",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.insurance_producers",
+    description:
+      "Texas DOI insurance agent and agency licenses with lines of authority",
+  },
+  {
+    name: "insurance_wa",
+    label: "Washington Insurance",
+    phase: "2",
+    cadence: "monthly",
+    table: "fed_data.insurance_producers",
+    description:
+      "Washington OIC insurance producer and agency licenses with lines of authority",
+  },
   {
     name: "adv_part3",
     label: "CRS Brochures",
@@ -290,6 +537,24 @@ export const datasetsMeta = [
     table: "fed_data.adv_advisor_answers",
     description: "ADV advisor answer extraction via LLM",
   },
+  {
+    name: "fund_providers",
+    label: "Fund Provider Network",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.fund_provider_network",
+    description:
+      "Private fund auditor, administrator, and prime broker network",
+  },
+  {
+    name: "opportunity",
+    label: "County Opportunity Scores",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.county_opportunity_scores",
+    description:
+      "County sourcing priority from CBP, SUSB, ACS, and Salesforce coverage",
+  },
   {
     name: "xbrl_facts",
     label: "XBRL Facts",
@@ -298,6 +563,15 @@ export const datasetsMeta = [
     table: "fed_data.xbrl_facts",
     description: "EDGAR XBRL financial fact data",
   },
+  {
+    name: "xbrl_frames",
+    label: "XBRL Frames",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.xbrl_frames",
+    description:
+      "EDGAR XBRL frames: one financial concept across all filers per calendar period",
+  },
   {
     name: "fred",
     label: "FRED Series",
@@ -322,6 +596,31 @@ export const datasetsMeta = [
     table: "fed_data.laus_data",
     description: "BLS Current Population Survey / Local Area Unemployment",
   },
+  {
+    name: "jolts",
+    label: "JOLTS",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.jolts_data",
+    description:
+      "BLS Job Openings and Labor Turnover Survey openings, hires, and quits",
+  },
+  {
+    name: "ppi",
+    label: "PPI",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.ppi_data",
+    description: "BLS Producer Price Index series",
+  },
+  {
+    name: "cpi",
+    label: "CPI",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.cpi_data",
+    description: "BLS Consumer Price Index series",
+  },
   {
     name: "m3",
     label: "M3 Manufacturers",
@@ -338,4 +637,49 @@ export const datasetsMeta = [
     table: "fed_data.lehd_lodes",
     description: "Census LEHD LODES origin-destination employment data",
   },
+  {
+    name: "lodes_od",
+    label: "LODES Tract Flows",
+    phase: "3",
+    cadence: "annual",
+    table: "fed_data.lodes_od",
+    description:
+      "LEHD LODES tract-level commuting flows and company workforce catchments",
+  },
+  {
+    name: "irs_soi",
+    label: "IRS SOI Migration",
+    phase: "3",
+    cadence: "annual",
+    table: "fed_data.soi_migration",
+    description:
+      "IRS Statistics of Income county-to-county migration and ZIP code income by AGI class",
+  },
+  {
+    name: "fcc_bdc",
+    label: "FCC Broadband",
+    phase: "3",
+    cadence: "quarterly",
+    table: "fed_data.fcc_broadband",
+    description:
+      "FCC Broadband Data Collection fixed availability by census block and county",
+  },
+  {
+    name: "eia",
+    label: "EIA Energy Prices",
+    phase: "3",
+    cadence: "monthly",
+    table: "fed_data.eia_data",
+    description:
+      "EIA state retail electricity prices by sector and selected fuel price series",
+  },
+  {
+    name: "firm_momentum",
+    label: "Firm Momentum",
+    phase: "3",
+    cadence: "weekly",
+    table: "fed_data.firm_momentum",
+    description:
+      "Per-firm AUM, headcount, disclosure, and website change trends with a momentum score",
+  },
 ] as const;
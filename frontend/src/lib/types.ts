@@ -232,6 +232,51 @@ export interface Contact {
   updated_at: string;
 }
 
+export interface ProfileFedData {
+  source: string;
+  key: string;
+  match_type: string;
+  confidence: number | null;
+  matched_at: string;
+  rows: Record<string, any>[];
+}
+
+export interface ProfileAnswer {
+  field_key: string;
+  value: string;
+  source: string;
+  confidence: number;
+  threshold_met: boolean;
+  data_as_of: string | null;
+  run_id: string;
+  extracted_at: string;
+}
+
+export interface ProfileRun {
+  id: string;
+  status: string;
+  score: number | null;
+  error: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CompanyProfile {
+  company: CompanyRecord;
+  identifiers: Identifier[] | null;
+  addresses: Address[] | null;
+  msas: AddressMSA[] | null;
+  contacts: Contact[] | null;
+  licenses: Record<string, any>[] | null;
+  financials: Record<string, any>[] | null;
+  tags: Record<string, any>[] | null;
+  sources: Record<string, any>[] | null;
+  fed_data: ProfileFedData[];
+  answers: ProfileAnswer[];
+  runs: ProfileRun[];
+  generated_at: string;
+}
+
 // Fedsync types
 export interface DatasetStatus {
   name: string;
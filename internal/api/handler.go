@@ -133,6 +133,22 @@ func (h *Handlers) requireFedsync(w http.ResponseWriter, r *http.Request) bool {
 	return true
 }
 
+func (h *Handlers) requireInvestors(w http.ResponseWriter, r *http.Request) bool {
+	if h.readModel == nil || h.readModel.Investors == nil {
+		WriteError(w, r, http.StatusServiceUnavailable, "not_configured", "investors read model not configured")
+		return false
+	}
+	return true
+}
+
+func (h *Handlers) requireProfiles(w http.ResponseWriter, r *http.Request) bool {
+	if h.readModel == nil || h.readModel.Profiles == nil {
+		WriteError(w, r, http.StatusServiceUnavailable, "not_configured", "profile read model not configured")
+		return false
+	}
+	return true
+}
+
 func (h *Handlers) requireStore(w http.ResponseWriter, r *http.Request) bool {
 	if h.store == nil {
 		WriteError(w, r, http.StatusServiceUnavailable, "not_configured", "store not configured")
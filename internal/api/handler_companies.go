@@ -224,6 +224,50 @@ func (h *Handlers) GetCompanyRuns(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetCompanyProfile handles GET /companies/{id}/profile.
+func (h *Handlers) GetCompanyProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCompanyID(w, r)
+	if !ok {
+		return
+	}
+	h.writeCompanyProfile(w, r, readmodel.ProfileLookup{CompanyID: id})
+}
+
+// LookupCompanyProfile handles GET /companies/profile.
+// Query params: domain, or system and identifier (e.g. system=crd&identifier=123456).
+func (h *Handlers) LookupCompanyProfile(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lookup := readmodel.ProfileLookup{
+		Domain:     q.Get("domain"),
+		System:     q.Get("system"),
+		Identifier: q.Get("identifier"),
+	}
+	if lookup.Domain == "" && (lookup.System == "" || lookup.Identifier == "") {
+		WriteError(w, r, http.StatusBadRequest, "missing_identity", "domain or system and identifier query parameters are required")
+		return
+	}
+	h.writeCompanyProfile(w, r, lookup)
+}
+
+func (h *Handlers) writeCompanyProfile(w http.ResponseWriter, r *http.Request, lookup readmodel.ProfileLookup) {
+	if !h.requireProfiles(w, r) {
+		return
+	}
+
+	profile, err := h.readModel.Profiles.CompanyProfile(r.Context(), lookup)
+	if err != nil {
+		zap.L().Error("get company profile failed", zap.Any("lookup", lookup), zap.Error(err))
+		WriteError(w, r, http.StatusInternalServerError, "internal", "failed to get company profile")
+		return
+	}
+	if profile == nil {
+		WriteError(w, r, http.StatusNotFound, "not_found", "company not found")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, profile)
+}
+
 // SearchCompanies handles GET /companies/search.
 func (h *Handlers) SearchCompanies(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
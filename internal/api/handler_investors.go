@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/readmodel"
+)
+
+// IssuerHolders handles GET /investors/holders.
+// Query params: issuer (CUSIP or name, required), period (YYYY-MM-DD),
+// min_weight (0-1), rias_only, pipeline_only, limit.
+func (h *Handlers) IssuerHolders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !h.requireInvestors(w, r) {
+		return
+	}
+
+	q := r.URL.Query()
+	issuer := q.Get("issuer")
+	if issuer == "" {
+		WriteError(w, r, http.StatusBadRequest, "missing_issuer", "issuer query parameter is required")
+		return
+	}
+	period, ok := parsePeriodParam(w, r)
+	if !ok {
+		return
+	}
+	minWeight, _ := strconv.ParseFloat(q.Get("min_weight"), 64)
+	riasOnly, _ := strconv.ParseBool(q.Get("rias_only"))
+	pipelineOnly, _ := strconv.ParseBool(q.Get("pipeline_only"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	holders, err := h.readModel.Investors.IssuerHolders(ctx, readmodel.IssuerHoldersQuery{
+		Issuer:       issuer,
+		Period:       period,
+		MinWeight:    minWeight,
+		RIAsOnly:     riasOnly,
+		PipelineOnly: pipelineOnly,
+		Limit:        limit,
+	})
+	if err != nil {
+		zap.L().Error("issuer holders query failed", zap.String("issuer", issuer), zap.Error(err))
+		WriteError(w, r, http.StatusInternalServerError, "internal", "failed to get issuer holders")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"issuer":  issuer,
+		"holders": holders,
+	})
+}
+
+// FilerPositions handles GET /investors/{cik}/positions.
+func (h *Handlers) FilerPositions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !h.requireInvestors(w, r) {
+		return
+	}
+
+	cik := chi.URLParam(r, "cik")
+	period, ok := parsePeriodParam(w, r)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	positions, err := h.readModel.Investors.FilerPositions(ctx, cik, period, limit)
+	if err != nil {
+		zap.L().Error("filer positions query failed", zap.String("cik", cik), zap.Error(err))
+		WriteError(w, r, http.StatusInternalServerError, "internal", "failed to get filer positions")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"cik":       cik,
+		"positions": positions,
+	})
+}
+
+// parsePeriodParam parses the optional period query parameter, writing a 400
+// and returning false when it is malformed.
+func parsePeriodParam(w http.ResponseWriter, r *http.Request) (*time.Time, bool) {
+	raw := r.URL.Query().Get("period")
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		WriteError(w, r, http.StatusBadRequest, "invalid_period", "period must be YYYY-MM-DD")
+		return nil, false
+	}
+	return &t, true
+}
@@ -140,6 +140,31 @@ func (f *fakeFedsyncReader) ListSyncEntries(context.Context) ([]fedsync.SyncEntr
 	return f.entries, nil
 }
 
+type fakeInvestorsReader struct {
+	holders   []readmodel.InvestorPosition
+	positions []readmodel.InvestorPosition
+	lastQuery readmodel.IssuerHoldersQuery
+}
+
+func (f *fakeInvestorsReader) IssuerHolders(_ context.Context, q readmodel.IssuerHoldersQuery) ([]readmodel.InvestorPosition, error) {
+	f.lastQuery = q
+	return f.holders, nil
+}
+
+func (f *fakeInvestorsReader) FilerPositions(context.Context, string, *time.Time, int) ([]readmodel.InvestorPosition, error) {
+	return f.positions, nil
+}
+
+type fakeProfilesReader struct {
+	profile    *readmodel.CompanyProfile
+	lastLookup readmodel.ProfileLookup
+}
+
+func (f *fakeProfilesReader) CompanyProfile(_ context.Context, lookup readmodel.ProfileLookup) (*readmodel.CompanyProfile, error) {
+	f.lastLookup = lookup
+	return f.profile, nil
+}
+
 func newReadModelRouter(readSvc *readmodel.Service) http.Handler {
 	cfg := &config.Config{Server: config.ServerConfig{Port: 8080}}
 	return Router(NewHandlers(cfg, nil, nil, nil, readSvc))
@@ -155,6 +180,8 @@ func TestReadModelRoutes_NotConfigured(t *testing.T) {
 		{path: "/api/v1/fedsync/statuses"},
 		{path: "/api/v1/data/tables"},
 		{path: "/api/v1/analytics/sync-trends"},
+		{path: "/api/v1/investors/holders?issuer=nvidia"},
+		{path: "/api/v1/companies/1/profile"},
 	}
 
 	for _, tc := range cases {
@@ -411,3 +438,118 @@ func TestAnalyticsRoutes_Success(t *testing.T) {
 		assert.Equal(t, "cik", body.Coverage[0].System)
 	})
 }
+
+func TestInvestorsRoutes(t *testing.T) {
+	period := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+	investors := &fakeInvestorsReader{
+		holders: []readmodel.InvestorPosition{{
+			CIK: "0001234567", FilerName: "Acme Wealth", CUSIP: "67066G104",
+			IssuerName: "NVIDIA CORP", Period: period, Value: 1000, PortfolioWeight: 0.12,
+		}},
+		positions: []readmodel.InvestorPosition{{CIK: "0001234567", CUSIP: "67066G104", Period: period}},
+	}
+	router := newReadModelRouter(&readmodel.Service{Investors: investors})
+
+	t.Run("holders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet,
+			"/api/v1/investors/holders?issuer=nvidia&period=2026-06-30&min_weight=0.05&pipeline_only=true&limit=10", nil)
+		router.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Issuer  string                       `json:"issuer"`
+			Holders []readmodel.InvestorPosition `json:"holders"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "nvidia", body.Issuer)
+		require.Len(t, body.Holders, 1)
+		assert.Equal(t, "Acme Wealth", body.Holders[0].FilerName)
+
+		q := investors.lastQuery
+		require.NotNil(t, q.Period)
+		assert.True(t, q.Period.Equal(period))
+		assert.InDelta(t, 0.05, q.MinWeight, 0.0001)
+		assert.True(t, q.PipelineOnly)
+		assert.False(t, q.RIAsOnly)
+		assert.Equal(t, 10, q.Limit)
+	})
+
+	t.Run("holders missing issuer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/investors/holders", nil)
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("holders invalid period", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/investors/holders?issuer=nvidia&period=Q2", nil)
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("filer positions", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/investors/0001234567/positions", nil)
+		router.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			CIK       string                       `json:"cik"`
+			Positions []readmodel.InvestorPosition `json:"positions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "0001234567", body.CIK)
+		require.Len(t, body.Positions, 1)
+	})
+}
+
+func TestCompanyProfileRoutes(t *testing.T) {
+	profiles := &fakeProfilesReader{profile: &readmodel.CompanyProfile{
+		Company: company.CompanyRecord{ID: 42, Name: "Acme Advisors"},
+		FedData: []readmodel.ProfileFedData{{
+			Source: "adv_firms", Key: "123456",
+			Rows: []map[string]any{{"firm_name": "ACME ADVISORS LLC"}},
+		}},
+	}}
+	router := newReadModelRouter(&readmodel.Service{Profiles: profiles})
+
+	t.Run("by id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/companies/42/profile", nil)
+		router.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body readmodel.CompanyProfile
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "Acme Advisors", body.Company.Name)
+		require.Len(t, body.FedData, 1)
+		assert.Equal(t, "ACME ADVISORS LLC", body.FedData[0].Rows[0]["firm_name"])
+		assert.Equal(t, int64(42), profiles.lastLookup.CompanyID)
+	})
+
+	t.Run("by identifier", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/companies/profile?system=crd&identifier=123456", nil)
+		router.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, readmodel.ProfileLookup{System: "crd", Identifier: "123456"}, profiles.lastLookup)
+	})
+
+	t.Run("missing identity", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/companies/profile?system=crd", nil)
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		profiles.profile = nil
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/companies/profile?domain=missing.com", nil)
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
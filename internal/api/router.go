@@ -58,12 +58,14 @@ func Router(h *Handlers) chi.Router {
 		r.Get("/companies", h.ListCompanies)
 		r.Get("/companies/search", h.SearchCompanies)
 		r.Get("/companies/geojson", h.CompaniesGeoJSON)
+		r.Get("/companies/profile", h.LookupCompanyProfile)
 		r.Get("/companies/{id}", h.GetCompanyHandler)
 		r.Get("/companies/{id}/identifiers", h.GetCompanyIdentifiers)
 		r.Get("/companies/{id}/addresses", h.GetCompanyAddresses)
 		r.Get("/companies/{id}/matches", h.GetCompanyMatches)
 		r.Get("/companies/{id}/msas", h.GetCompanyMSAs)
 		r.Get("/companies/{id}/runs", h.GetCompanyRuns)
+		r.Get("/companies/{id}/profile", h.GetCompanyProfile)
 
 		r.Get("/fedsync/statuses", h.FedsyncStatuses)
 		r.Get("/fedsync/sync-log", h.FedsyncSyncLog)
@@ -80,6 +82,9 @@ func Router(h *Handlers) chi.Router {
 		r.Get("/analytics/enrichment-stats", h.EnrichmentStatsHandler)
 		r.Get("/analytics/cost-breakdown", h.CostBreakdownHandler)
 
+		r.Get("/investors/holders", h.IssuerHolders)
+		r.Get("/investors/{cik}/positions", h.FilerPositions)
+
 		r.Get("/tiles/stats", h.TileStats)
 	})
 
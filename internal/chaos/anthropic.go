@@ -0,0 +1,92 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/sells-group/research-cli/pkg/anthropic"
+)
+
+// WrapAnthropic returns c with fault injection, or c unchanged when inj is
+// nil or does not target Anthropic. Truncated messages keep a prefix of each
+// text block and report StopReason "max_tokens", as a real cut-off would.
+func WrapAnthropic(c anthropic.Client, inj *Injector) anthropic.Client {
+	if !inj.targets(TargetAnthropic) {
+		return c
+	}
+	return &chaosAnthropic{next: c, inj: inj}
+}
+
+type chaosAnthropic struct {
+	next anthropic.Client
+	inj  *Injector
+}
+
+// CreateMessage implements anthropic.Client.
+func (c *chaosAnthropic) CreateMessage(ctx context.Context, req anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+	if err := c.inj.before(ctx, TargetAnthropic, "create_message"); err != nil {
+		return nil, err
+	}
+	resp, err := c.next.CreateMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.maybeTruncate(resp), nil
+}
+
+// CreateBatch implements anthropic.Client.
+func (c *chaosAnthropic) CreateBatch(ctx context.Context, req anthropic.BatchRequest) (*anthropic.BatchResponse, error) {
+	if err := c.inj.before(ctx, TargetAnthropic, "create_batch"); err != nil {
+		return nil, err
+	}
+	return c.next.CreateBatch(ctx, req)
+}
+
+// GetBatch implements anthropic.Client.
+func (c *chaosAnthropic) GetBatch(ctx context.Context, batchID string) (*anthropic.BatchResponse, error) {
+	if err := c.inj.before(ctx, TargetAnthropic, "get_batch"); err != nil {
+		return nil, err
+	}
+	return c.next.GetBatch(ctx, batchID)
+}
+
+// GetBatchResults implements anthropic.Client.
+func (c *chaosAnthropic) GetBatchResults(ctx context.Context, batchID string) (anthropic.BatchResultIterator, error) {
+	if err := c.inj.before(ctx, TargetAnthropic, "get_batch_results"); err != nil {
+		return nil, err
+	}
+	it, err := c.next.GetBatchResults(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return &chaosBatchIterator{BatchResultIterator: it, c: c}, nil
+}
+
+func (c *chaosAnthropic) maybeTruncate(resp *anthropic.MessageResponse) *anthropic.MessageResponse {
+	if resp == nil {
+		return nil
+	}
+	ok, keep := c.inj.truncate()
+	if !ok {
+		return resp
+	}
+	out := *resp
+	out.Content = make([]anthropic.ContentBlock, len(resp.Content))
+	for i, b := range resp.Content {
+		b.Text = b.Text[:int(float64(len(b.Text))*keep)]
+		out.Content[i] = b
+	}
+	out.StopReason = "max_tokens"
+	return &out
+}
+
+// chaosBatchIterator truncates succeeded batch messages.
+type chaosBatchIterator struct {
+	anthropic.BatchResultIterator
+	c *chaosAnthropic
+}
+
+func (it *chaosBatchIterator) Item() anthropic.BatchResultItem {
+	item := it.BatchResultIterator.Item()
+	item.Message = it.c.maybeTruncate(item.Message)
+	return item
+}
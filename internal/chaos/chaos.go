@@ -0,0 +1,108 @@
+// Package chaos wraps the fetcher, database pool, and Anthropic client with
+// configurable fault injection (errors, latency, truncation) so retry,
+// checkpoint, and quarantine logic can be exercised deterministically.
+package chaos
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+// Fault targets accepted in config.ChaosConfig.Targets.
+const (
+	TargetFetcher   = "fetcher"
+	TargetDB        = "db"
+	TargetAnthropic = "anthropic"
+)
+
+// ErrInjected is the root cause of every injected failure.
+var ErrInjected = eris.New("chaos: injected fault")
+
+// Injector decides, per call, whether to delay, fail, or truncate. All
+// randomness comes from a seeded generator so a single-threaded run with the
+// same seed injects the same faults.
+type Injector struct {
+	cfg config.ChaosConfig
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	calls map[string]int
+}
+
+// New returns an Injector for cfg, or nil when chaos is disabled. Wrap
+// functions treat a nil Injector as a no-op.
+func New(cfg config.ChaosConfig) *Injector {
+	if !cfg.Enabled {
+		return nil
+	}
+	zap.L().Warn("chaos: fault injection enabled",
+		zap.Strings("targets", cfg.Targets),
+		zap.Float64("error_rate", cfg.ErrorRate),
+		zap.Int("error_every", cfg.ErrorEvery),
+		zap.Int("latency_ms", cfg.LatencyMs),
+		zap.Float64("truncate_rate", cfg.TruncateRate),
+	)
+	return &Injector{
+		cfg:   cfg,
+		rng:   rand.New(rand.NewPCG(cfg.Seed, cfg.Seed)), // #nosec G404 -- deterministic test faults, not security
+		calls: make(map[string]int),
+	}
+}
+
+// targets reports whether faults apply to target.
+func (i *Injector) targets(target string) bool {
+	return i != nil && (len(i.cfg.Targets) == 0 || slices.Contains(i.cfg.Targets, target))
+}
+
+// before runs ahead of every wrapped call: it sleeps for the configured
+// latency and returns a transient error if this call is chosen to fail.
+func (i *Injector) before(ctx context.Context, target, op string) error {
+	if i.cfg.LatencyMs > 0 {
+		t := time.NewTimer(time.Duration(i.cfg.LatencyMs) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	i.mu.Lock()
+	i.calls[target]++
+	n := i.calls[target]
+	fail := i.cfg.ErrorEvery > 0 && n%i.cfg.ErrorEvery == 0
+	if !fail && i.cfg.ErrorRate > 0 {
+		fail = i.rng.Float64() < i.cfg.ErrorRate
+	}
+	i.mu.Unlock()
+
+	if !fail {
+		return nil
+	}
+	zap.L().Debug("chaos: injecting error", zap.String("target", target), zap.String("op", op), zap.Int("call", n))
+	return resilience.NewTransientError(eris.Wrapf(ErrInjected, "%s %s", target, op), http.StatusServiceUnavailable)
+}
+
+// truncate reports whether this response should be truncated and, if so,
+// the fraction of it to keep in [0, 1).
+func (i *Injector) truncate() (bool, float64) {
+	if i.cfg.TruncateRate <= 0 {
+		return false, 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.rng.Float64() >= i.cfg.TruncateRate {
+		return false, 0
+	}
+	return true, i.rng.Float64()
+}
@@ -0,0 +1,174 @@
+package chaos
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+	"github.com/sells-group/research-cli/internal/resilience"
+	"github.com/sells-group/research-cli/pkg/anthropic"
+	anthropicmocks "github.com/sells-group/research-cli/pkg/anthropic/mocks"
+)
+
+func TestNew_Disabled(t *testing.T) {
+	inj := New(config.ChaosConfig{ErrorRate: 1})
+	assert.Nil(t, inj)
+
+	f := fetchermocks.NewMockFetcher(t)
+	assert.Same(t, f, WrapFetcher(f, inj))
+}
+
+func TestWrap_Targets(t *testing.T) {
+	inj := New(config.ChaosConfig{Enabled: true, Targets: []string{TargetDB}})
+
+	f := fetchermocks.NewMockFetcher(t)
+	assert.Same(t, f, WrapFetcher(f, inj))
+
+	ai := anthropicmocks.NewMockClient(t)
+	assert.Same(t, ai, WrapAnthropic(ai, inj))
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	assert.NotSame(t, pool, WrapPool(pool, inj))
+}
+
+func TestInjector_ErrorEvery(t *testing.T) {
+	inj := New(config.ChaosConfig{Enabled: true, ErrorEvery: 3})
+	ctx := context.Background()
+
+	var failed []int
+	for n := 1; n <= 7; n++ {
+		if err := inj.before(ctx, TargetFetcher, "download"); err != nil {
+			failed = append(failed, n)
+			assert.ErrorIs(t, err, ErrInjected)
+			assert.True(t, resilience.IsTransient(err))
+		}
+	}
+	assert.Equal(t, []int{3, 6}, failed)
+
+	// Counters are per target.
+	assert.NoError(t, inj.before(ctx, TargetDB, "exec"))
+}
+
+func TestInjector_ErrorRateSeeded(t *testing.T) {
+	run := func() []bool {
+		inj := New(config.ChaosConfig{Enabled: true, Seed: 42, ErrorRate: 0.5})
+		var out []bool
+		for range 20 {
+			out = append(out, inj.before(context.Background(), TargetDB, "exec") != nil)
+		}
+		return out
+	}
+	first := run()
+	assert.Equal(t, first, run())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestInjector_LatencyHonorsContext(t *testing.T) {
+	inj := New(config.ChaosConfig{Enabled: true, LatencyMs: 10_000})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := inj.before(ctx, TargetFetcher, "download")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestFetcher_TruncatedDownload(t *testing.T) {
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	payload := strings.Repeat("x", 2*truncatedReadLimit)
+	f.EXPECT().Download(ctx, "http://test").Return(io.NopCloser(strings.NewReader(payload)), nil)
+
+	wrapped := WrapFetcher(f, New(config.ChaosConfig{Enabled: true, TruncateRate: 1}))
+	body, err := wrapped.Download(ctx, "http://test")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Less(t, len(data), truncatedReadLimit)
+	assert.NoError(t, body.Close())
+}
+
+func TestFetcher_TruncatedFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(ctx, "http://test", path).
+		Run(func(_ context.Context, _ string, p string) {
+			require.NoError(t, os.WriteFile(p, []byte(strings.Repeat("row\n", 100)), 0o600))
+		}).
+		Return(int64(400), nil)
+
+	wrapped := WrapFetcher(f, New(config.ChaosConfig{Enabled: true, TruncateRate: 1}))
+	n, err := wrapped.DownloadToFile(ctx, "http://test", path)
+	require.NoError(t, err)
+	assert.Less(t, n, int64(400))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, n, info.Size())
+}
+
+func TestFetcher_InjectedError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	wrapped := WrapFetcher(f, New(config.ChaosConfig{Enabled: true, ErrorRate: 1}))
+
+	_, err := wrapped.HeadETag(context.Background(), "http://test")
+	assert.ErrorIs(t, err, ErrInjected)
+}
+
+func TestPool_InjectedErrors(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	pool.ExpectExec("UPDATE t").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	wrapped := WrapPool(pool, New(config.ChaosConfig{Enabled: true, ErrorEvery: 2}))
+	ctx := context.Background()
+
+	_, err = wrapped.Exec(ctx, "UPDATE t SET x = 1")
+	require.NoError(t, err)
+
+	var v int
+	err = wrapped.QueryRow(ctx, "SELECT 1").Scan(&v)
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestAnthropic_Truncation(t *testing.T) {
+	ctx := context.Background()
+	ai := anthropicmocks.NewMockClient(t)
+	ai.EXPECT().CreateMessage(ctx, mock.Anything).Return(&anthropic.MessageResponse{
+		Content:    []anthropic.ContentBlock{{Type: "text", Text: `{"revenue": 12000000, "employees": 85}`}},
+		StopReason: "end_turn",
+	}, nil)
+
+	wrapped := WrapAnthropic(ai, New(config.ChaosConfig{Enabled: true, TruncateRate: 1}))
+	resp, err := wrapped.CreateMessage(ctx, anthropic.MessageRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "max_tokens", resp.StopReason)
+	assert.Less(t, len(resp.Content[0].Text), len(`{"revenue": 12000000, "employees": 85}`))
+}
+
+func TestAnthropic_InjectedError(t *testing.T) {
+	ai := anthropicmocks.NewMockClient(t)
+	wrapped := WrapAnthropic(ai, New(config.ChaosConfig{Enabled: true, ErrorRate: 1}))
+
+	_, err := wrapped.CreateBatch(context.Background(), anthropic.BatchRequest{})
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.True(t, resilience.IsTransient(err))
+}
@@ -0,0 +1,143 @@
+package chaos
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// truncatedReadLimit bounds how much of a streamed body is read before a
+// truncated Download reports io.ErrUnexpectedEOF.
+const truncatedReadLimit = 64 << 10
+
+// WrapFetcher returns f with fault injection, or f unchanged when inj is nil
+// or does not target the fetcher.
+func WrapFetcher(f fetcher.Fetcher, inj *Injector) fetcher.Fetcher {
+	if !inj.targets(TargetFetcher) {
+		return f
+	}
+	return &chaosFetcher{next: f, inj: inj}
+}
+
+type chaosFetcher struct {
+	next fetcher.Fetcher
+	inj  *Injector
+}
+
+// Download implements fetcher.Fetcher. A truncated body ends early with
+// io.ErrUnexpectedEOF, like a dropped connection.
+func (c *chaosFetcher) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "download"); err != nil {
+		return nil, err
+	}
+	body, err := c.next.Download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return c.maybeTruncate(body), nil
+}
+
+// DownloadToFile implements fetcher.Fetcher. A truncated file is cut short on
+// disk without an error, simulating a silently partial download that parse
+// and validation logic must catch.
+func (c *chaosFetcher) DownloadToFile(ctx context.Context, url string, path string) (int64, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "download_to_file"); err != nil {
+		return 0, err
+	}
+	n, err := c.next.DownloadToFile(ctx, url, path)
+	if err != nil {
+		return n, err
+	}
+	if ok, keep := c.inj.truncate(); ok {
+		n = int64(float64(n) * keep)
+		if err := os.Truncate(path, n); err != nil {
+			return 0, eris.Wrap(err, "chaos: truncate file")
+		}
+	}
+	return n, nil
+}
+
+// PostJSON implements fetcher.Poster.
+func (c *chaosFetcher) PostJSON(ctx context.Context, url string, body []byte) (io.ReadCloser, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "post_json"); err != nil {
+		return nil, err
+	}
+	rc, err := fetcher.PostJSON(ctx, c.next, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.maybeTruncate(rc), nil
+}
+
+// HeadETag implements fetcher.Fetcher.
+func (c *chaosFetcher) HeadETag(ctx context.Context, url string) (string, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "head_etag"); err != nil {
+		return "", err
+	}
+	return c.next.HeadETag(ctx, url)
+}
+
+// DownloadIfChanged implements fetcher.Fetcher.
+func (c *chaosFetcher) DownloadIfChanged(ctx context.Context, url string, etag string) (io.ReadCloser, string, bool, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "download_if_changed"); err != nil {
+		return nil, "", false, err
+	}
+	body, newETag, changed, err := c.next.DownloadIfChanged(ctx, url, etag)
+	if err != nil || body == nil {
+		return body, newETag, changed, err
+	}
+	return c.maybeTruncate(body), newETag, changed, nil
+}
+
+// DownloadToFileIfChanged implements fetcher.Fetcher. A truncated file is
+// re-hashed, as a partial download would have been.
+func (c *chaosFetcher) DownloadToFileIfChanged(ctx context.Context, url string, path string, prev fetcher.FileVersion) (fetcher.FileVersion, bool, error) {
+	if err := c.inj.before(ctx, TargetFetcher, "download_to_file_if_changed"); err != nil {
+		return fetcher.FileVersion{}, false, err
+	}
+	v, changed, err := c.next.DownloadToFileIfChanged(ctx, url, path, prev)
+	if err != nil || !changed {
+		return v, changed, err
+	}
+	if ok, keep := c.inj.truncate(); ok {
+		if err := os.Truncate(path, int64(float64(v.Size)*keep)); err != nil {
+			return fetcher.FileVersion{}, false, eris.Wrap(err, "chaos: truncate file")
+		}
+		if v.SHA256, v.Size, err = fetcher.HashFile(path); err != nil {
+			return fetcher.FileVersion{}, false, err
+		}
+	}
+	return v, true, nil
+}
+
+func (c *chaosFetcher) maybeTruncate(body io.ReadCloser) io.ReadCloser {
+	ok, keep := c.inj.truncate()
+	if !ok {
+		return body
+	}
+	return &truncatedBody{rc: body, remaining: int64(keep * truncatedReadLimit)}
+}
+
+// truncatedBody yields at most remaining bytes, then io.ErrUnexpectedEOF.
+type truncatedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+func (t *truncatedBody) Read(p []byte) (int, error) {
+	if t.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if int64(len(p)) > t.remaining {
+		p = p[:t.remaining]
+	}
+	n, err := t.rc.Read(p)
+	t.remaining -= int64(n)
+	return n, err
+}
+
+func (t *truncatedBody) Close() error { return t.rc.Close() }
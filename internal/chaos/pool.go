@@ -0,0 +1,72 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// WrapPool returns p with fault injection, or p unchanged when inj is nil or
+// does not target the database. Faults are injected when a call or
+// transaction starts; statements inside a transaction run unwrapped.
+func WrapPool(p db.Pool, inj *Injector) db.Pool {
+	if !inj.targets(TargetDB) {
+		return p
+	}
+	return &chaosPool{next: p, inj: inj}
+}
+
+type chaosPool struct {
+	next db.Pool
+	inj  *Injector
+}
+
+// Begin implements db.Pool.
+func (c *chaosPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	if err := c.inj.before(ctx, TargetDB, "begin"); err != nil {
+		return nil, err
+	}
+	return c.next.Begin(ctx)
+}
+
+// Exec implements db.Pool.
+func (c *chaosPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := c.inj.before(ctx, TargetDB, "exec"); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return c.next.Exec(ctx, sql, args...)
+}
+
+// Query implements db.Pool.
+func (c *chaosPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := c.inj.before(ctx, TargetDB, "query"); err != nil {
+		return nil, err
+	}
+	return c.next.Query(ctx, sql, args...)
+}
+
+// QueryRow implements db.Pool. An injected fault surfaces from Scan.
+func (c *chaosPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := c.inj.before(ctx, TargetDB, "query_row"); err != nil {
+		return errRow{err: err}
+	}
+	return c.next.QueryRow(ctx, sql, args...)
+}
+
+// CopyFrom implements db.Pool.
+func (c *chaosPool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if err := c.inj.before(ctx, TargetDB, "copy_from"); err != nil {
+		return 0, err
+	}
+	return c.next.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// errRow is a pgx.Row whose Scan returns err.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error { return r.err }
@@ -39,7 +39,30 @@ type Config struct {
 	Retry      RetryConfig      `yaml:"retry" mapstructure:"retry"`
 	Circuit    CircuitConfig    `yaml:"circuit" mapstructure:"circuit"`
 	Monitoring MonitoringConfig `yaml:"monitoring" mapstructure:"monitoring"`
+	Notify     NotifyConfig     `yaml:"notify" mapstructure:"notify"`
 	Temporal   TemporalConfig   `yaml:"temporal" mapstructure:"temporal"`
+	Chaos      ChaosConfig      `yaml:"chaos" mapstructure:"chaos"`
+	PostSync   PostSyncConfig   `yaml:"postsync" mapstructure:"postsync"`
+}
+
+// PostSyncConfig configures the hooks run after each dataset or geo scraper
+// sync. Hooks maps a dataset or scraper name to hook names (enqueue_geocode,
+// assign_msa, flood_tag, notify); a listed name replaces the hooks it
+// declares, and an empty list disables them.
+type PostSyncConfig struct {
+	Hooks map[string][]string `yaml:"hooks" mapstructure:"hooks"`
+}
+
+// ChaosConfig configures fault injection around the fetcher, database pool,
+// and Anthropic client for resilience testing. Never enable in production.
+type ChaosConfig struct {
+	Enabled      bool     `yaml:"enabled" mapstructure:"enabled"`
+	Seed         uint64   `yaml:"seed" mapstructure:"seed"`                   // RNG seed; same seed + call order = same faults
+	Targets      []string `yaml:"targets" mapstructure:"targets"`             // "fetcher", "db", "anthropic"; empty = all
+	ErrorRate    float64  `yaml:"error_rate" mapstructure:"error_rate"`       // probability [0,1] a call fails with a transient error
+	ErrorEvery   int      `yaml:"error_every" mapstructure:"error_every"`     // fail every Nth call per target; 0 = off
+	LatencyMs    int      `yaml:"latency_ms" mapstructure:"latency_ms"`       // delay added before every call
+	TruncateRate float64  `yaml:"truncate_rate" mapstructure:"truncate_rate"` // probability [0,1] a response body is truncated
 }
 
 // TemporalConfig configures the Temporal.io workflow engine connection.
@@ -56,6 +79,16 @@ type MonitoringConfig struct {
 	LookbackWindowHours  int     `yaml:"lookback_window_hours" mapstructure:"lookback_window_hours"`
 	FailureRateThreshold float64 `yaml:"failure_rate_threshold" mapstructure:"failure_rate_threshold"`
 	CostThresholdUSD     float64 `yaml:"cost_threshold_usd" mapstructure:"cost_threshold_usd"`
+	ContractCheckHours   int     `yaml:"contract_check_hours" mapstructure:"contract_check_hours"` // SF/Notion schema check interval; 0 = off
+	MetricsAddr          string  `yaml:"metrics_addr" mapstructure:"metrics_addr"`                 // CLI Prometheus /metrics listener (e.g. ":9090"); empty = off
+}
+
+// NotifyConfig configures the run summaries posted after fedsync syncs and
+// pipeline batches.
+type NotifyConfig struct {
+	SlackWebhookURL string   `yaml:"slack_webhook_url" mapstructure:"slack_webhook_url"`
+	WebhookURLs     []string `yaml:"webhook_urls" mapstructure:"webhook_urls"`   // generic endpoints receiving the summary as JSON
+	OnlyFailures    bool     `yaml:"only_failures" mapstructure:"only_failures"` // skip runs with no failures or anomalies
 }
 
 // RetryConfig configures retry behavior for API calls.
@@ -89,14 +122,47 @@ type DiscoveryConfig struct {
 
 // GeoConfig configures geocoding and MSA association.
 type GeoConfig struct {
-	Enabled      bool            `yaml:"enabled" mapstructure:"enabled"`
-	CacheEnabled bool            `yaml:"cache_enabled" mapstructure:"cache_enabled"`
-	CacheTTLDays int             `yaml:"cache_ttl_days" mapstructure:"cache_ttl_days"`
-	MaxRating    int             `yaml:"max_rating" mapstructure:"max_rating"`
-	BatchSize    int             `yaml:"batch_size" mapstructure:"batch_size"`
-	TopMSAs      int             `yaml:"top_msas" mapstructure:"top_msas"`
-	Tiles        TileConfig      `yaml:"tiles" mapstructure:"tiles"`
-	TileCache    TileCacheConfig `yaml:"tile_cache" mapstructure:"tile_cache"`
+	Enabled            bool                  `yaml:"enabled" mapstructure:"enabled"`
+	CacheEnabled       bool                  `yaml:"cache_enabled" mapstructure:"cache_enabled"`
+	CacheTTLDays       int                   `yaml:"cache_ttl_days" mapstructure:"cache_ttl_days"`
+	MaxRating          int                   `yaml:"max_rating" mapstructure:"max_rating"`
+	BatchSize          int                   `yaml:"batch_size" mapstructure:"batch_size"`
+	QueueMaxDepth      int                   `yaml:"queue_max_depth" mapstructure:"queue_max_depth"`           // pause enqueue at this many pending items (0 = no limit)
+	GeocodeMaxAttempts int                   `yaml:"geocode_max_attempts" mapstructure:"geocode_max_attempts"` // geocode worker tries per queue item before marking it failed
+	Providers          []string              `yaml:"providers" mapstructure:"providers"`                       // geocode fallback chain (tiger, census, nominatim, mapbox, google); ordered by cost; empty = tiger only
+	ProviderRPS        map[string]float64    `yaml:"provider_rps" mapstructure:"provider_rps"`                 // provider -> requests/sec; unset = geocode.DefaultRateLimits
+	MinConfidence      float64               `yaml:"min_confidence" mapstructure:"min_confidence"`             // escalate to the next provider below this match confidence (0 = first match wins)
+	MapboxToken        string                `yaml:"mapbox_token" mapstructure:"mapbox_token"`
+	GoogleAPIKey       string                `yaml:"google_api_key" mapstructure:"google_api_key"`
+	TopMSAs            int                   `yaml:"top_msas" mapstructure:"top_msas"`
+	Tiles              TileConfig            `yaml:"tiles" mapstructure:"tiles"`
+	TileCache          TileCacheConfig       `yaml:"tile_cache" mapstructure:"tile_cache"`
+	HIFLDLayers        string                `yaml:"hifld_layers" mapstructure:"hifld_layers"`       // HIFLD layer manifest path; empty = built-in
+	OSMStates          []string              `yaml:"osm_states" mapstructure:"osm_states"`           // state abbreviations for Geofabrik POI extracts; empty = all
+	OSMBBox            string                `yaml:"osm_bbox" mapstructure:"osm_bbox"`               // "south,west,north,east"; set = Overpass instead of extracts
+	SSURGOStates       []string              `yaml:"ssurgo_states" mapstructure:"ssurgo_states"`     // state abbreviations for SSURGO survey areas; empty = all
+	GTFSFeeds          map[string]string     `yaml:"gtfs_feeds" mapstructure:"gtfs_feeds"`           // feed id -> GTFS static zip URL; empty = no transit stops
+	ParcelCounties     string                `yaml:"parcel_counties" mapstructure:"parcel_counties"` // county parcel manifest path; empty = built-in
+	Isochrones         IsochroneConfig       `yaml:"isochrones" mapstructure:"isochrones"`
+	LocationMetrics    LocationMetricsConfig `yaml:"location_metrics" mapstructure:"location_metrics"`
+}
+
+// IsochroneConfig configures drive-time isochrones around target office
+// locations computed by the isochrones geo scraper.
+type IsochroneConfig struct {
+	Provider     string `yaml:"provider" mapstructure:"provider"`           // "valhalla" or "osrm"
+	URL          string `yaml:"url" mapstructure:"url"`                     // routing engine base URL; empty = scraper disabled
+	Profile      string `yaml:"profile" mapstructure:"profile"`             // Valhalla costing or OSRM profile; empty = driving
+	Minutes      []int  `yaml:"minutes" mapstructure:"minutes"`             // contour drive times
+	MaxLocations int    `yaml:"max_locations" mapstructure:"max_locations"` // locations computed per run; 0 = all
+	RefreshDays  int    `yaml:"refresh_days" mapstructure:"refresh_days"`   // recompute isochrones older than this
+}
+
+// LocationMetricsConfig configures the nearest-amenity distances computed for
+// geocoded company addresses into geo.location_metrics.
+type LocationMetricsConfig struct {
+	CompetitorSubcategories []string `yaml:"competitor_subcategories" mapstructure:"competitor_subcategories"` // geo.osm_pois subcategories counted as competitors
+	RefreshDays             int      `yaml:"refresh_days" mapstructure:"refresh_days"`                         // recompute metrics older than this
 }
 
 // TileConfig configures the tile server and basemap proxy.
@@ -131,6 +197,7 @@ type ScorerConfig struct {
 	IndustryMatchWeight    float64  `yaml:"industry_match_weight" mapstructure:"industry_match_weight"`
 	RegulatoryCleanWeight  float64  `yaml:"regulatory_clean_weight" mapstructure:"regulatory_clean_weight"`
 	SuccessionSignalWeight float64  `yaml:"succession_signal_weight" mapstructure:"succession_signal_weight"`
+	MomentumWeight         float64  `yaml:"momentum_weight" mapstructure:"momentum_weight"`
 	MinAUM                 int64    `yaml:"min_aum" mapstructure:"min_aum"`
 	MaxAUM                 int64    `yaml:"max_aum" mapstructure:"max_aum"`
 	MinEmployees           int      `yaml:"min_employees" mapstructure:"min_employees"`
@@ -155,21 +222,275 @@ type WaterfallConfig struct {
 
 // FedsyncConfig configures the federal data sync pipeline.
 type FedsyncConfig struct {
-	DatabaseURL    string    `yaml:"database_url" mapstructure:"database_url"`
-	TempDir        string    `yaml:"temp_dir" mapstructure:"temp_dir"`
-	SAMKey         string    `yaml:"sam_api_key" mapstructure:"sam_api_key"`
-	FREDKey        string    `yaml:"fred_api_key" mapstructure:"fred_api_key"`
-	BLSKey         string    `yaml:"bls_api_key" mapstructure:"bls_api_key"`
-	CensusKey      string    `yaml:"census_api_key" mapstructure:"census_api_key"`
-	FCCBDCKey      string    `yaml:"fcc_bdc_key" mapstructure:"fcc_bdc_key"`
-	EDGARUserAgent string    `yaml:"edgar_user_agent" mapstructure:"edgar_user_agent"`
-	N8NWebhook     string    `yaml:"n8n_webhook_url" mapstructure:"n8n_webhook_url"`
-	MistralKey     string    `yaml:"mistral_api_key" mapstructure:"mistral_api_key"`
-	MistralModel   string    `yaml:"mistral_ocr_model" mapstructure:"mistral_ocr_model"`
-	OCR            OCRConfig `yaml:"ocr" mapstructure:"ocr"`
-	DoclingURL     string    `yaml:"docling_url" mapstructure:"docling_url"`
-	DoclingAPIKey  string    `yaml:"docling_api_key" mapstructure:"docling_api_key"`
-	NRELKey        string    `yaml:"nrel_api_key" mapstructure:"nrel_api_key"`
+	DatabaseURL    string              `yaml:"database_url" mapstructure:"database_url"`
+	TempDir        string              `yaml:"temp_dir" mapstructure:"temp_dir"`
+	SAMKey         string              `yaml:"sam_api_key" mapstructure:"sam_api_key"`
+	FREDKey        string              `yaml:"fred_api_key" mapstructure:"fred_api_key"`
+	BLSKey         string              `yaml:"bls_api_key" mapstructure:"bls_api_key"`
+	CensusKey      string              `yaml:"census_api_key" mapstructure:"census_api_key"`
+	FCCBDCKey      string              `yaml:"fcc_bdc_key" mapstructure:"fcc_bdc_key"`
+	FCCBDCUser     string              `yaml:"fcc_bdc_username" mapstructure:"fcc_bdc_username"`
+	EDGARUserAgent string              `yaml:"edgar_user_agent" mapstructure:"edgar_user_agent"`
+	N8NWebhook     string              `yaml:"n8n_webhook_url" mapstructure:"n8n_webhook_url"`
+	MistralKey     string              `yaml:"mistral_api_key" mapstructure:"mistral_api_key"`
+	MistralModel   string              `yaml:"mistral_ocr_model" mapstructure:"mistral_ocr_model"`
+	OCR            OCRConfig           `yaml:"ocr" mapstructure:"ocr"`
+	DoclingURL     string              `yaml:"docling_url" mapstructure:"docling_url"`
+	DoclingAPIKey  string              `yaml:"docling_api_key" mapstructure:"docling_api_key"`
+	NRELKey        string              `yaml:"nrel_api_key" mapstructure:"nrel_api_key"`
+	BEAKey         string              `yaml:"bea_api_key" mapstructure:"bea_api_key"`
+	BEA            BEAConfig           `yaml:"bea" mapstructure:"bea"`
+	EIAKey         string              `yaml:"eia_api_key" mapstructure:"eia_api_key"`
+	FACKey         string              `yaml:"fac_api_key" mapstructure:"fac_api_key"`
+	EIA            EIAConfig           `yaml:"eia" mapstructure:"eia"`
+	EMMA           EMMAConfig          `yaml:"emma" mapstructure:"emma"`
+	SOS            SOSConfig           `yaml:"sos" mapstructure:"sos"`
+	UCC            UCCConfig           `yaml:"ucc" mapstructure:"ucc"`
+	CourtListener  CourtListenerConfig `yaml:"courtlistener" mapstructure:"courtlistener"`
+	FINRA          FINRAConfig         `yaml:"finra" mapstructure:"finra"`
+	Insurance      InsuranceConfig     `yaml:"insurance" mapstructure:"insurance"`
+	OSHA           OSHAConfig          `yaml:"osha" mapstructure:"osha"`
+	CBP            CBPConfig           `yaml:"cbp" mapstructure:"cbp"`
+	EDGARFTS       EDGARFTSConfig      `yaml:"edgar_fts" mapstructure:"edgar_fts"`
+	ACS            ACSConfig           `yaml:"acs" mapstructure:"acs"`
+	Opportunity    OpportunityConfig   `yaml:"opportunity" mapstructure:"opportunity"`
+	LODES          LODESConfig         `yaml:"lodes" mapstructure:"lodes"`
+	FCCBroadband   BroadbandConfig     `yaml:"fcc_broadband" mapstructure:"fcc_broadband"`
+	BLS            BLSConfig           `yaml:"bls" mapstructure:"bls"`
+	JOLTS          BLSSeriesConfig     `yaml:"jolts" mapstructure:"jolts"`
+	PPI            BLSSeriesConfig     `yaml:"ppi" mapstructure:"ppi"`
+	CPI            BLSSeriesConfig     `yaml:"cpi" mapstructure:"cpi"`
+	Mirrors        []MirrorConfig      `yaml:"mirrors" mapstructure:"mirrors"`
+	Validation     ValidationConfig    `yaml:"validation" mapstructure:"validation"`
+	Retry          SyncRetryConfig     `yaml:"retry" mapstructure:"retry"`
+	Daemon         DaemonConfig        `yaml:"daemon" mapstructure:"daemon"`
+	Export         ExportConfig        `yaml:"export" mapstructure:"export"`
+	NAICS          NAICSFilterConfig   `yaml:"naics" mapstructure:"naics"`
+	Streaming      StreamingConfig     `yaml:"streaming" mapstructure:"streaming"`
+	XBRL           XBRLConfig          `yaml:"xbrl" mapstructure:"xbrl"`
+	Xref           XrefConfig          `yaml:"xref" mapstructure:"xref"`
+}
+
+// XrefConfig tunes entity_xref. The probabilistic CRD-CIK pass scores each
+// ADV firm / EDGAR entity candidate from 0 to 1 on name similarity, state,
+// industry (SIC), and address agreement, and links a firm to its best
+// candidate when the score reaches MatchThreshold.
+type XrefConfig struct {
+	MatchThreshold float64 `yaml:"match_threshold" mapstructure:"match_threshold"`
+}
+
+// XBRLConfig controls the EDGAR XBRL datasets. xbrl_facts downloads
+// company facts with Workers concurrent requests; every data.sec.gov
+// request shares one limiter held to SEC's 10 requests per second.
+// xbrl_frames pulls each Frames concept ("taxonomy/tag/unit/instant" or
+// ".../duration", e.g. "us-gaap/Assets/USD/instant") for all filers.
+type XBRLConfig struct {
+	Workers int      `yaml:"workers" mapstructure:"workers"` // concurrent company facts downloads
+	Frames  []string `yaml:"frames" mapstructure:"frames"`   // frames API concepts
+}
+
+// StreamingConfig bounds memory for the largest streaming loaders
+// (ia_compilation, edgar_submissions, epa_echo). Each buffers at most
+// BatchRows rows per target table before upserting and blocks its parser
+// until the batch is written. MemoryLimitMB, when set, becomes the Go
+// runtime's soft memory limit for fedsync sync, backfill, resync, and
+// daemon, so the GC works harder instead of growing past the ceiling.
+type StreamingConfig struct {
+	BatchRows     int `yaml:"batch_rows" mapstructure:"batch_rows"`           // rows buffered per table between upserts
+	MemoryLimitMB int `yaml:"memory_limit_mb" mapstructure:"memory_limit_mb"` // 0 = no limit (GOMEMLIMIT still applies)
+}
+
+// NAICSFilterConfig selects which industries the NAICS-keyed statistical
+// datasets (cbp, susb, oews, qcew, econ_census) load. Rules are code
+// prefixes ("52", "6211") or ranges ("31-33"); "all" keeps every industry.
+// A dataset listed in Datasets uses its own rules instead of Include.
+type NAICSFilterConfig struct {
+	Include  []string            `yaml:"include" mapstructure:"include"`   // default rules
+	Datasets map[string][]string `yaml:"datasets" mapstructure:"datasets"` // dataset name -> rules
+}
+
+// ExportConfig controls `fedsync export`, which writes fed_data tables to
+// Parquet for consumers without Postgres access. S3 credentials left empty
+// fall back to the standard AWS_* environment variables.
+type ExportConfig struct {
+	Dest              string `yaml:"dest" mapstructure:"dest"`                                 // default destination: local dir or s3://bucket/prefix
+	S3Region          string `yaml:"s3_region" mapstructure:"s3_region"`                       // bucket region
+	S3Endpoint        string `yaml:"s3_endpoint" mapstructure:"s3_endpoint"`                   // S3-compatible host[:port] (MinIO, R2); empty for AWS
+	S3AccessKeyID     string `yaml:"s3_access_key_id" mapstructure:"s3_access_key_id"`         // overrides AWS_ACCESS_KEY_ID
+	S3SecretAccessKey string `yaml:"s3_secret_access_key" mapstructure:"s3_secret_access_key"` // overrides AWS_SECRET_ACCESS_KEY
+}
+
+// DaemonConfig controls `fedsync daemon`, which re-evaluates every
+// dataset's schedule on a loop and syncs the due ones. A dataset with a
+// cron expression in Schedules (standard five fields, e.g. "0 6 * * 1")
+// is due once per cron tick after its last success instead of following
+// its ShouldRun logic.
+type DaemonConfig struct {
+	IntervalMins int               `yaml:"interval_mins" mapstructure:"interval_mins"` // time between schedule checks
+	Schedules    map[string]string `yaml:"schedules" mapstructure:"schedules"`         // dataset name -> cron expression
+}
+
+// SyncRetryConfig controls how the engine re-runs a dataset whose sync
+// failed with a transient error (429, 5xx, connection resets). Permanent
+// errors (404s, parse failures) are never retried.
+type SyncRetryConfig struct {
+	MaxAttempts      int     `yaml:"max_attempts" mapstructure:"max_attempts"` // 1 = no retries
+	InitialBackoffMs int     `yaml:"initial_backoff_ms" mapstructure:"initial_backoff_ms"`
+	MaxBackoffMs     int     `yaml:"max_backoff_ms" mapstructure:"max_backoff_ms"`
+	Multiplier       float64 `yaml:"multiplier" mapstructure:"multiplier"`
+	JitterFraction   float64 `yaml:"jitter_fraction" mapstructure:"jitter_fraction"`
+}
+
+// ValidationConfig controls the post-sync validation rules (row-count
+// deltas, null rates, referential checks). With Block set, a dataset that
+// fails a rule is marked failed and its watermark and derived rebuilds are
+// held back.
+type ValidationConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	Block   bool `yaml:"block" mapstructure:"block"`
+}
+
+// MirrorConfig declares alternate URL prefixes for a source. When a request
+// under Prefix fails persistently, the remainder of the URL is retried under
+// each mirror in order (e.g. "https://www.sec.gov/Archives/" to a backup).
+type MirrorConfig struct {
+	Prefix string   `yaml:"prefix" mapstructure:"prefix"`
+	URLs   []string `yaml:"urls" mapstructure:"urls"`
+}
+
+// BEAConfig selects which BEA regional series and geographies to sync via the
+// BEA API. Series are "TABLE:LINECODE" pairs (e.g. "CAGDP2:1").
+type BEAConfig struct {
+	Series      []string `yaml:"series" mapstructure:"series"`
+	Geographies []string `yaml:"geographies" mapstructure:"geographies"` // COUNTY, MSA, STATE
+}
+
+// EIAConfig selects what the EIA v2 API sync pulls: state-level retail
+// electricity prices for the listed sectors (RES, COM, IND, TRA, ALL) plus
+// any additional series by legacy series ID (e.g. "NG.N3035US3.M").
+type EIAConfig struct {
+	Sectors []string `yaml:"sectors" mapstructure:"sectors"`
+	Series  []string `yaml:"series" mapstructure:"series"`
+}
+
+// EMMAConfig locates the MSRB EMMA subscription extracts. EMMA has no open
+// bulk API; issue-level primary market records and continuing disclosure
+// submissions come from MSRB data subscription files (CSV, optionally
+// zipped). IssuesURL is required; DisclosuresURL is optional.
+type EMMAConfig struct {
+	IssuesURL      string `yaml:"issues_url" mapstructure:"issues_url"`
+	DisclosuresURL string `yaml:"disclosures_url" mapstructure:"disclosures_url"`
+}
+
+// SOSConfig overrides Secretary of State bulk extract URLs by lower-case
+// state code (e.g. "wa"). States without a built-in public download must be
+// set here before their sos_<state> dataset can sync.
+type SOSConfig struct {
+	URLs map[string]string `yaml:"urls" mapstructure:"urls"`
+}
+
+// UCCConfig sets state UCC bulk extract URLs by lower-case state code
+// (e.g. "tx"). Filing offices sell or publish these extracts under
+// subscription, so each ucc_<state> dataset needs its URL set here.
+type UCCConfig struct {
+	URLs map[string]string `yaml:"urls" mapstructure:"urls"`
+}
+
+// CourtListenerConfig configures the RECAP docket sync. Dockets are kept
+// only when a party matches a public.companies name whose NAICS code starts
+// with one of NAICS (empty = all companies). LookbackDays bounds the first
+// sync; later syncs resume from the newest stored filing date.
+type CourtListenerConfig struct {
+	Token        string   `yaml:"token" mapstructure:"token"`
+	NAICS        []string `yaml:"naics" mapstructure:"naics"`
+	LookbackDays int      `yaml:"lookback_days" mapstructure:"lookback_days"`
+}
+
+// FINRAConfig locates the FINRA Arbitration Awards Online metadata export.
+// FINRA publishes no bulk feed; AwardsURL points at a CSV (optionally
+// zipped) of the award search results, one row per award with its PDF link.
+// MaxPDFs bounds how many award PDFs are downloaded and OCR'd per sync.
+type FINRAConfig struct {
+	AwardsURL string `yaml:"awards_url" mapstructure:"awards_url"`
+	MaxPDFs   int    `yaml:"max_pdfs" mapstructure:"max_pdfs"`
+}
+
+// InsuranceConfig sets state Department of Insurance producer license
+// extract URLs by lower-case state code (e.g. "tx"). NIPR sells its national
+// producer database under license, so each insurance_<state> dataset reads
+// the state's own licensee download and needs its URL set here.
+type InsuranceConfig struct {
+	URLs map[string]string `yaml:"urls" mapstructure:"urls"`
+}
+
+// OSHAConfig sets the OSHA Injury Tracking Application Form 300A summary
+// downloads by calendar year (e.g. "2024"). OSHA names each year's file
+// differently, so osha_ita_300a loads exactly the years listed here.
+type OSHAConfig struct {
+	ITAURLs map[string]string `yaml:"ita_urls" mapstructure:"ita_urls"`
+}
+
+// CBPConfig controls optional County Business Patterns extensions. ZIPLevel
+// also loads the ZIP Code Business Patterns detail files into
+// fed_data.zbp_data (roughly 3M rows per year).
+type CBPConfig struct {
+	ZIPLevel bool `yaml:"zip_level" mapstructure:"zip_level"`
+}
+
+// EDGARFTSConfig configures the EDGAR full-text search keyword monitor.
+// Each query runs against filings of the listed Forms (empty = all) since
+// its newest stored hit; LookbackDays bounds the first sync and MaxPages
+// (100 hits each) caps one query's sync.
+type EDGARFTSConfig struct {
+	Queries      []string `yaml:"queries" mapstructure:"queries"`
+	Forms        []string `yaml:"forms" mapstructure:"forms"`
+	LookbackDays int      `yaml:"lookback_days" mapstructure:"lookback_days"`
+	MaxPages     int      `yaml:"max_pages" mapstructure:"max_pages"`
+}
+
+// ACSConfig selects which American Community Survey 5-year variables to sync
+// (e.g. "B19013_001E") and which states to pull tract-level data for.
+type ACSConfig struct {
+	Variables []string `yaml:"variables" mapstructure:"variables"`
+	States    []string `yaml:"states" mapstructure:"states"` // 2-letter abbreviations; empty = all
+}
+
+// LODESConfig selects which states to sync tract-level LODES commuting flows
+// for.
+type LODESConfig struct {
+	States []string `yaml:"states" mapstructure:"states"` // 2-letter abbreviations; empty = all states and DC
+}
+
+// BroadbandConfig selects which states to sync FCC Broadband Data
+// Collection availability for.
+type BroadbandConfig struct {
+	States []string `yaml:"states" mapstructure:"states"` // 2-letter abbreviations; empty = all states, DC, and PR
+}
+
+// BLSConfig controls the shared BLS API client behind the ECI, CPS/LAUS,
+// JOLTS, PPI, and CPI datasets. DailyLimit caps the API queries made per
+// day across all of them; 0 uses the BLS limit for the account (500 with
+// bls_api_key, 25 without).
+type BLSConfig struct {
+	DailyLimit int `yaml:"daily_limit" mapstructure:"daily_limit"`
+}
+
+// BLSSeriesConfig selects which BLS series IDs a BLS time-series dataset
+// syncs (e.g. "JTS000000000000000JOL" for JOLTS total nonfarm job openings).
+type BLSSeriesConfig struct {
+	Series []string `yaml:"series" mapstructure:"series"`
+}
+
+// OpportunityConfig configures the county opportunity score table. NAICS
+// codes select the target industries (CBP/SUSB aggregate rows at the given
+// level, e.g. "5412"); empty means all industries. Weights are applied to
+// percentile ranks of each component.
+type OpportunityConfig struct {
+	NAICS            []string `yaml:"naics" mapstructure:"naics"`
+	EstabWeight      float64  `yaml:"estab_weight" mapstructure:"estab_weight"`           // target establishment count (CBP)
+	SmallFirmWeight  float64  `yaml:"small_firm_weight" mapstructure:"small_firm_weight"` // share of firms under 20 employees (SUSB)
+	WealthWeight     float64  `yaml:"wealth_weight" mapstructure:"wealth_weight"`         // median household income (ACS)
+	WhitespaceWeight float64  `yaml:"whitespace_weight" mapstructure:"whitespace_weight"` // inverse Salesforce account coverage
 }
 
 // OCRConfig configures PDF text extraction.
@@ -329,16 +650,63 @@ type QualityWeights struct {
 
 // PipelineConfig configures extraction behavior.
 type PipelineConfig struct {
-	Mode                          string         `yaml:"mode" mapstructure:"mode"`
-	ConfidenceEscalationThreshold float64        `yaml:"confidence_escalation_threshold" mapstructure:"confidence_escalation_threshold"`
-	EscalationFailRateThreshold   float64        `yaml:"escalation_fail_rate_threshold" mapstructure:"escalation_fail_rate_threshold"`
-	Tier3Gate                     string         `yaml:"tier3_gate" mapstructure:"tier3_gate"`
-	QualityScoreThreshold         float64        `yaml:"quality_score_threshold" mapstructure:"quality_score_threshold"`
-	MinCompletenessThreshold      float64        `yaml:"min_completeness_threshold" mapstructure:"min_completeness_threshold"`
-	MaxCostPerCompanyUSD          float64        `yaml:"max_cost_per_company_usd" mapstructure:"max_cost_per_company_usd"`
-	SkipConfidenceThreshold       float64        `yaml:"skip_confidence_threshold" mapstructure:"skip_confidence_threshold"`
-	AnswerReuseTTLDays            int            `yaml:"answer_reuse_ttl_days" mapstructure:"answer_reuse_ttl_days"`
-	QualityWeights                QualityWeights `yaml:"quality_weights" mapstructure:"quality_weights"`
+	Mode                          string           `yaml:"mode" mapstructure:"mode"`
+	ConfidenceEscalationThreshold float64          `yaml:"confidence_escalation_threshold" mapstructure:"confidence_escalation_threshold"`
+	EscalationFailRateThreshold   float64          `yaml:"escalation_fail_rate_threshold" mapstructure:"escalation_fail_rate_threshold"`
+	Tier3Gate                     string           `yaml:"tier3_gate" mapstructure:"tier3_gate"`
+	QualityScoreThreshold         float64          `yaml:"quality_score_threshold" mapstructure:"quality_score_threshold"`
+	MinCompletenessThreshold      float64          `yaml:"min_completeness_threshold" mapstructure:"min_completeness_threshold"`
+	MaxCostPerCompanyUSD          float64          `yaml:"max_cost_per_company_usd" mapstructure:"max_cost_per_company_usd"`
+	SkipConfidenceThreshold       float64          `yaml:"skip_confidence_threshold" mapstructure:"skip_confidence_threshold"`
+	AnswerReuseTTLDays            int              `yaml:"answer_reuse_ttl_days" mapstructure:"answer_reuse_ttl_days"`
+	QualityWeights                QualityWeights   `yaml:"quality_weights" mapstructure:"quality_weights"`
+	SFDiff                        bool             `yaml:"sf_diff" mapstructure:"sf_diff"`
+	Shadow                        ShadowConfig     `yaml:"shadow" mapstructure:"shadow"`
+	EnvRisk                       EnvRiskConfig    `yaml:"env_risk" mapstructure:"env_risk"`
+	Litigation                    LitigationConfig `yaml:"litigation" mapstructure:"litigation"`
+	Sampling                      SamplingConfig   `yaml:"sampling" mapstructure:"sampling"`
+}
+
+// SamplingConfig gates Tier 3 on a pre-score computed from Tier 1/2 answers
+// and fedsync signals, so Opus only runs for promising companies. Each
+// component scores 0.0-1.0; components without configured targets are left
+// out of the weighted average.
+type SamplingConfig struct {
+	Enabled          bool     `yaml:"enabled" mapstructure:"enabled"`
+	Tier3MinScore    float64  `yaml:"tier3_min_score" mapstructure:"tier3_min_score"`
+	MinEmployees     int      `yaml:"min_employees" mapstructure:"min_employees"`
+	MaxEmployees     int      `yaml:"max_employees" mapstructure:"max_employees"` // 0 = no upper bound
+	TargetStates     []string `yaml:"target_states" mapstructure:"target_states"`
+	ConfidenceWeight float64  `yaml:"confidence_weight" mapstructure:"confidence_weight"` // mean T1/T2 answer confidence
+	SizeWeight       float64  `yaml:"size_weight" mapstructure:"size_weight"`             // employees within range
+	GeoWeight        float64  `yaml:"geo_weight" mapstructure:"geo_weight"`               // HQ state in target states
+	FederalWeight    float64  `yaml:"federal_weight" mapstructure:"federal_weight"`       // best fedsync entity match confidence
+}
+
+// EnvRiskConfig configures the EPA/OSHA environmental and safety risk check
+// (Phase 7E). Requires the fedsync database.
+type EnvRiskConfig struct {
+	Enabled        bool    `yaml:"enabled" mapstructure:"enabled"`
+	RadiusKM       float64 `yaml:"radius_km" mapstructure:"radius_km"`             // EPA facility proximity radius
+	NameSimilarity float64 `yaml:"name_similarity" mapstructure:"name_similarity"` // trigram threshold for name matches
+	LookbackYears  int     `yaml:"lookback_years" mapstructure:"lookback_years"`   // OSHA inspection history window
+}
+
+// LitigationConfig configures the federal court docket check (Phase 7F)
+// against fed_data.court_dockets. Requires the fedsync database.
+type LitigationConfig struct {
+	Enabled       bool `yaml:"enabled" mapstructure:"enabled"`
+	LookbackYears int  `yaml:"lookback_years" mapstructure:"lookback_years"` // docket filing window
+}
+
+// ShadowConfig configures shadow mode: a candidate question pack and/or
+// model set that runs alongside production without affecting gates or exports.
+type ShadowConfig struct {
+	Enabled       bool   `yaml:"enabled" mapstructure:"enabled"`
+	Variant       string `yaml:"variant" mapstructure:"variant"`
+	QuestionsFile string `yaml:"questions_file" mapstructure:"questions_file"`
+	HaikuModel    string `yaml:"haiku_model" mapstructure:"haiku_model"`
+	SonnetModel   string `yaml:"sonnet_model" mapstructure:"sonnet_model"`
 }
 
 // BatchConfig configures batch processing.
@@ -494,6 +862,23 @@ func Load() (*Config, error) {
 	v.SetDefault("pipeline.quality_weights.completeness", 0.25)
 	v.SetDefault("pipeline.quality_weights.diversity", 0.15)
 	v.SetDefault("pipeline.quality_weights.freshness", 0.10)
+	v.SetDefault("pipeline.sf_diff", true)
+	v.SetDefault("pipeline.shadow.enabled", false)
+	v.SetDefault("pipeline.shadow.variant", "shadow")
+	v.SetDefault("pipeline.env_risk.enabled", true)
+	v.SetDefault("pipeline.env_risk.radius_km", 1.0)
+	v.SetDefault("pipeline.env_risk.name_similarity", 0.6)
+	v.SetDefault("pipeline.env_risk.lookback_years", 5)
+	v.SetDefault("pipeline.litigation.enabled", true)
+	v.SetDefault("pipeline.litigation.lookback_years", 5)
+	v.SetDefault("pipeline.sampling.enabled", false)
+	v.SetDefault("pipeline.sampling.tier3_min_score", 0.5)
+	v.SetDefault("pipeline.sampling.confidence_weight", 0.3)
+	v.SetDefault("pipeline.sampling.size_weight", 0.3)
+	v.SetDefault("pipeline.sampling.geo_weight", 0.2)
+	v.SetDefault("pipeline.sampling.federal_weight", 0.2)
+	v.SetDefault("chaos.enabled", false)
+	v.SetDefault("chaos.seed", 1)
 	v.SetDefault("jina.base_url", "https://r.jina.ai")
 	v.SetDefault("jina.search_base_url", "https://s.jina.ai")
 	v.SetDefault("firecrawl.base_url", "https://api.firecrawl.dev/v2")
@@ -539,6 +924,92 @@ func Load() (*Config, error) {
 	v.SetDefault("fedsync.ocr.pdftotext_path", "pdftotext")
 	v.SetDefault("fedsync.docling_url", "http://localhost:5001")
 	v.SetDefault("fedsync.nrel_api_key", "")
+	v.SetDefault("fedsync.bea_api_key", "")
+	v.SetDefault("fedsync.bea.series", []string{"CAGDP2:1", "CAINC1:1", "CAINC1:3", "CAINC6N:1"})
+	v.SetDefault("fedsync.bea.geographies", []string{"COUNTY", "MSA"})
+	v.SetDefault("fedsync.eia_api_key", "")
+	v.SetDefault("fedsync.fac_api_key", "")
+	v.SetDefault("fedsync.eia.sectors", []string{"ALL", "RES", "COM", "IND"})
+	v.SetDefault("fedsync.eia.series", []string{
+		"NG.N3035US3.M", "NG.N3020US3.M", // natural gas price: industrial, commercial
+		"PET.EMD_EPD2D_PTE_NUS_DPG.W", "PET.EMM_EPMR_PTE_NUS_DPG.W", // retail diesel, regular gasoline
+		"PET.EER_EPD2F_PF4_Y35NY_DPG.D", // NY Harbor No. 2 heating oil spot
+	})
+	v.SetDefault("fedsync.emma.issues_url", "")
+	v.SetDefault("fedsync.emma.disclosures_url", "")
+	v.SetDefault("fedsync.sos.urls", map[string]string{})
+	v.SetDefault("fedsync.ucc.urls", map[string]string{})
+	v.SetDefault("fedsync.courtlistener.token", "")
+	v.SetDefault("fedsync.courtlistener.naics", []string{})
+	v.SetDefault("fedsync.courtlistener.lookback_days", 90)
+	v.SetDefault("fedsync.finra.awards_url", "")
+	v.SetDefault("fedsync.finra.max_pdfs", 200)
+	v.SetDefault("fedsync.insurance.urls", map[string]string{})
+	v.SetDefault("fedsync.osha.ita_urls", map[string]string{})
+	v.SetDefault("fedsync.cbp.zip_level", false)
+	v.SetDefault("fedsync.edgar_fts.queries", []string{"succession plan", "strategic alternatives"})
+	v.SetDefault("fedsync.edgar_fts.forms", []string{})
+	v.SetDefault("fedsync.edgar_fts.lookback_days", 90)
+	v.SetDefault("fedsync.edgar_fts.max_pages", 20)
+	v.SetDefault("fedsync.validation.enabled", true)
+	v.SetDefault("fedsync.validation.block", false)
+	v.SetDefault("fedsync.retry.max_attempts", 3)
+	v.SetDefault("fedsync.retry.initial_backoff_ms", 30000)
+	v.SetDefault("fedsync.retry.max_backoff_ms", 300000)
+	v.SetDefault("fedsync.retry.multiplier", 2.0)
+	v.SetDefault("fedsync.retry.jitter_fraction", 0.25)
+	v.SetDefault("fedsync.daemon.interval_mins", 15)
+	v.SetDefault("fedsync.naics.include", []string{"all"})
+	v.SetDefault("fedsync.streaming.batch_rows", 5000)
+	v.SetDefault("fedsync.streaming.memory_limit_mb", 0)
+	v.SetDefault("fedsync.bls.daily_limit", 0)
+	v.SetDefault("fedsync.xref.match_threshold", 0.75)
+	v.SetDefault("fedsync.xbrl.workers", 4)
+	v.SetDefault("fedsync.xbrl.frames", []string{
+		"us-gaap/Assets/USD/instant",
+		"us-gaap/Liabilities/USD/instant",
+		"us-gaap/StockholdersEquity/USD/instant",
+		"us-gaap/CashAndCashEquivalentsAtCarryingValue/USD/instant",
+		"us-gaap/Revenues/USD/duration",
+		"us-gaap/NetIncomeLoss/USD/duration",
+		"us-gaap/OperatingIncomeLoss/USD/duration",
+	})
+	v.SetDefault("fedsync.export.dest", "")
+	v.SetDefault("fedsync.export.s3_region", "us-east-1")
+	v.SetDefault("fedsync.export.s3_endpoint", "")
+	v.SetDefault("fedsync.export.s3_access_key_id", "")
+	v.SetDefault("fedsync.export.s3_secret_access_key", "")
+	v.SetDefault("fedsync.acs.variables", []string{
+		"B01003_001E", "B01002_001E", // total population, median age
+		"B19013_001E", "B19301_001E", // median household income, per capita income
+		"B15003_001E", "B15003_022E", "B15003_023E", // population 25+, bachelor's, master's
+		"B25001_001E", "B25003_002E", "B25077_001E", "B25064_001E", // housing units, owner-occupied, median value, median rent
+	})
+	v.SetDefault("fedsync.acs.states", []string{})
+	v.SetDefault("fedsync.lodes.states", []string{})
+	v.SetDefault("fedsync.fcc_broadband.states", []string{})
+	v.SetDefault("fedsync.jolts.series", []string{
+		"JTS000000000000000JOL", "JTS000000000000000JOR", // total nonfarm openings level, rate
+		"JTS000000000000000HIL", "JTS000000000000000HIR", // hires level, rate
+		"JTS000000000000000QUL", "JTS000000000000000QUR", // quits level, rate
+		"JTS000000000000000LDL",                          // layoffs and discharges level
+		"JTS540099000000000JOR", "JTS540099000000000QUR", // professional and business services
+		"JTS620000000000000JOR", "JTS620000000000000QUR", // health care and social assistance
+	})
+	v.SetDefault("fedsync.ppi.series", []string{
+		"WPSFD4", "WPSFD49104", // final demand; less foods, energy, and trade (SA)
+		"PCU541211541211", "PCU5413--5413--", // CPA offices; architectural and engineering services
+		"PCU524126524126", "PCU523920523920", // P&C insurers; portfolio management
+	})
+	v.SetDefault("fedsync.cpi.series", []string{
+		"CUSR0000SA0", "CUSR0000SA0L1E", "CUSR0000SAH1", "CUSR0000SAM", // all items, core, shelter, medical care (SA)
+		"CUUR0000SA0", "CUUR0100SA0", "CUUR0200SA0", "CUUR0300SA0", "CUUR0400SA0", // all items: US, Northeast, Midwest, South, West (NSA)
+	})
+	v.SetDefault("fedsync.opportunity.naics", []string{})
+	v.SetDefault("fedsync.opportunity.estab_weight", 0.35)
+	v.SetDefault("fedsync.opportunity.small_firm_weight", 0.20)
+	v.SetDefault("fedsync.opportunity.wealth_weight", 0.25)
+	v.SetDefault("fedsync.opportunity.whitespace_weight", 0.20)
 	v.SetDefault("discovery.google_places_rate_limit", 10.0)
 	v.SetDefault("discovery.max_candidates_per_run", 10000)
 	v.SetDefault("discovery.ppp_min_approval", 150000.0)
@@ -555,8 +1026,27 @@ func Load() (*Config, error) {
 	v.SetDefault("geo.cache_enabled", true)
 	v.SetDefault("geo.max_rating", 100)
 	v.SetDefault("geo.batch_size", 1000)
+	v.SetDefault("geo.queue_max_depth", 50000)
+	v.SetDefault("geo.geocode_max_attempts", 3)
+	v.SetDefault("geo.providers", []string{})
+	v.SetDefault("geo.min_confidence", 0.0)
+	v.SetDefault("geo.mapbox_token", "")
+	v.SetDefault("geo.google_api_key", "")
 	v.SetDefault("geo.cache_ttl_days", 90)
 	v.SetDefault("geo.top_msas", 3)
+	v.SetDefault("geo.hifld_layers", "")
+	v.SetDefault("geo.parcel_counties", "")
+	v.SetDefault("geo.osm_states", []string{})
+	v.SetDefault("geo.osm_bbox", "")
+	v.SetDefault("geo.ssurgo_states", []string{})
+	v.SetDefault("geo.gtfs_feeds", map[string]string{})
+	v.SetDefault("geo.isochrones.provider", "valhalla")
+	v.SetDefault("geo.isochrones.url", "")
+	v.SetDefault("geo.isochrones.minutes", []int{15, 30, 60})
+	v.SetDefault("geo.isochrones.max_locations", 500)
+	v.SetDefault("geo.isochrones.refresh_days", 180)
+	v.SetDefault("geo.location_metrics.competitor_subcategories", []string{"accountant", "tax_advisor", "financial_advisor"})
+	v.SetDefault("geo.location_metrics.refresh_days", 90)
 	v.SetDefault("geo.tiles.port", 8081)
 	v.SetDefault("geo.tiles.basemap_url", "https://tile.openstreetmap.org")
 	v.SetDefault("geo.tiles.basemap_format", "png")
@@ -566,13 +1056,14 @@ func Load() (*Config, error) {
 	v.SetDefault("tiger.temp_dir", "/tmp/tiger")
 	v.SetDefault("tiger.concurrency", 3)
 	v.SetDefault("scorer.aum_fit_weight", 25)
-	v.SetDefault("scorer.growth_weight", 10)
+	v.SetDefault("scorer.growth_weight", 5)
 	v.SetDefault("scorer.client_quality_weight", 15)
 	v.SetDefault("scorer.service_fit_weight", 10)
 	v.SetDefault("scorer.geo_match_weight", 10)
 	v.SetDefault("scorer.industry_match_weight", 10)
 	v.SetDefault("scorer.regulatory_clean_weight", 10)
 	v.SetDefault("scorer.succession_signal_weight", 10)
+	v.SetDefault("scorer.momentum_weight", 5)
 	v.SetDefault("scorer.min_aum", 100000000)
 	v.SetDefault("scorer.max_aum", 5000000000)
 	v.SetDefault("scorer.min_employees", 3)
@@ -605,6 +1096,10 @@ func Load() (*Config, error) {
 	v.SetDefault("monitoring.lookback_window_hours", 24)
 	v.SetDefault("monitoring.failure_rate_threshold", 0.10)
 	v.SetDefault("monitoring.cost_threshold_usd", 500.0)
+	v.SetDefault("monitoring.contract_check_hours", 24)
+	v.SetDefault("notify.slack_webhook_url", "")
+	v.SetDefault("notify.webhook_urls", []string{})
+	v.SetDefault("notify.only_failures", false)
 	v.SetDefault("pricing.jina.per_mtok", 0.02)
 	v.SetDefault("pricing.perplexity.per_query", 0.005)
 	v.SetDefault("pricing.firecrawl.plan_monthly", 19.00)
@@ -0,0 +1,319 @@
+// Package contract verifies that the Salesforce org and Notion databases
+// still expose the fields, picklist values, and properties the pipeline reads
+// and writes. A schema change on either side otherwise surfaces only as
+// opaque write errors partway through a batch run.
+package contract
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/pkg/notion"
+	"github.com/sells-group/research-cli/pkg/salesforce"
+)
+
+// System names used in Issue.System.
+const (
+	SystemSalesforce = "salesforce"
+	SystemNotion     = "notion"
+)
+
+// Issue is a single contract violation.
+type Issue struct {
+	System  string `json:"system"`
+	Object  string `json:"object"` // SObject name or Notion database label
+	Field   string `json:"field,omitempty"`
+	Problem string `json:"problem"`
+}
+
+// String formats the issue as "system object.field: problem".
+func (i Issue) String() string {
+	target := i.Object
+	if i.Field != "" {
+		target += "." + i.Field
+	}
+	return fmt.Sprintf("%s %s: %s", i.System, target, i.Problem)
+}
+
+// Report is the result of a contract check.
+type Report struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Checked   int       `json:"checked"` // fields and properties verified
+	Issues    []Issue   `json:"issues"`
+}
+
+// OK reports whether the check found no issues.
+func (r *Report) OK() bool { return len(r.Issues) == 0 }
+
+func (r *Report) add(system, object, field, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{
+		System:  system,
+		Object:  object,
+		Field:   field,
+		Problem: fmt.Sprintf(format, args...),
+	})
+}
+
+// propertySpec is a Notion property the pipeline depends on.
+type propertySpec struct {
+	Name    string
+	Type    notionapi.PropertyConfigType
+	Options []string // status/select options that must exist
+}
+
+// leadProperties are read by batch (Name, URL, SalesforceID, Location) and
+// written by the Notion exporter and import command.
+var leadProperties = []propertySpec{
+	{Name: "Name", Type: notionapi.PropertyConfigTypeTitle},
+	{Name: "URL", Type: notionapi.PropertyConfigTypeURL},
+	{Name: "SalesforceID", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Location", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Status", Type: notionapi.PropertyConfigStatus, Options: []string{"Queued", "Enriched", "Manual Review", "Failed"}},
+	{Name: "Score", Type: notionapi.PropertyConfigTypeNumber},
+	{Name: "Fields Populated", Type: notionapi.PropertyConfigTypeNumber},
+	{Name: "Enrichment Cost", Type: notionapi.PropertyConfigTypeNumber},
+	{Name: "Last Enriched", Type: notionapi.PropertyConfigTypeDate},
+}
+
+// pendingChangesProperty is written by the quality gate when
+// pipeline.sf_diff is enabled.
+var pendingChangesProperty = propertySpec{Name: "Pending Changes", Type: notionapi.PropertyConfigTypeRichText}
+
+// fieldProperties are read by registry.LoadFieldRegistry.
+var fieldProperties = []propertySpec{
+	{Name: "Key", Type: notionapi.PropertyConfigTypeTitle},
+	{Name: "SFField", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "SFObject", Type: notionapi.PropertyConfigTypeSelect},
+	{Name: "DataType", Type: notionapi.PropertyConfigTypeSelect},
+	{Name: "Required", Type: notionapi.PropertyConfigTypeCheckbox},
+	{Name: "MaxLength", Type: notionapi.PropertyConfigTypeNumber},
+	{Name: "Validation", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Status", Type: notionapi.PropertyConfigStatus, Options: []string{"Active"}},
+}
+
+// questionProperties are read by registry.LoadQuestionRegistry.
+var questionProperties = []propertySpec{
+	{Name: "Question Key", Type: notionapi.PropertyConfigTypeTitle},
+	{Name: "Tier", Type: notionapi.PropertyConfigTypeSelect},
+	{Name: "Target SF Fields", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Relevant Page Types", Type: notionapi.PropertyConfigTypeMultiSelect},
+	{Name: "Instructions", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Output Schema", Type: notionapi.PropertyConfigTypeRichText},
+	{Name: "Priority", Type: notionapi.PropertyConfigTypeSelect},
+	{Name: "Status", Type: notionapi.PropertyConfigStatus, Options: []string{"Active"}},
+}
+
+// pipelineAccountFields are Account fields the Salesforce exporter writes
+// outside the field registry.
+var pipelineAccountFields = []string{"Enrichment_Report__c"}
+
+// Checker verifies the Salesforce and Notion schemas against the pipeline's
+// expectations. Either client may be nil to skip that system.
+type Checker struct {
+	sf     salesforce.Client
+	notion notion.Client
+	fields *model.FieldRegistry
+	cfg    config.NotionConfig
+	sfDiff bool
+}
+
+// NewChecker creates a Checker. fields supplies the Salesforce mappings to
+// verify; a nil registry checks only pipelineAccountFields. sfDiff requires
+// the lead database's Pending Changes property, which only the
+// pipeline.sf_diff gate writes.
+func NewChecker(sf salesforce.Client, nc notion.Client, fields *model.FieldRegistry, cfg config.NotionConfig, sfDiff bool) *Checker {
+	return &Checker{sf: sf, notion: nc, fields: fields, cfg: cfg, sfDiff: sfDiff}
+}
+
+// Run checks both systems and returns every violation found. API failures
+// (missing object, unshared database) are reported as issues rather than
+// errors so one run surfaces all problems; the error is non-nil only when
+// ctx is done.
+func (c *Checker) Run(ctx context.Context) (*Report, error) {
+	r := &Report{CheckedAt: time.Now().UTC()}
+	if c.sf != nil {
+		c.checkSalesforce(ctx, r)
+	}
+	if c.notion != nil {
+		c.checkNotion(ctx, r)
+	}
+	if err := ctx.Err(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// checkSalesforce describes each SObject referenced by the field registry and
+// verifies every mapped field exists, is updateable, fits the registry's
+// max length, and (for picklists) accepts the values the registry allows.
+func (c *Checker) checkSalesforce(ctx context.Context, r *Report) {
+	byObject := map[string][]model.FieldMapping{
+		"Account": nil,
+	}
+	for _, name := range pipelineAccountFields {
+		byObject["Account"] = append(byObject["Account"], model.FieldMapping{SFField: name})
+	}
+	if c.fields != nil {
+		for _, f := range c.fields.Fields {
+			if f.SFField == "" {
+				continue
+			}
+			obj := f.SFObject
+			if obj == "" {
+				obj = "Account"
+			}
+			byObject[obj] = append(byObject[obj], f)
+		}
+	}
+
+	objects := make([]string, 0, len(byObject))
+	for obj := range byObject {
+		objects = append(objects, obj)
+	}
+	sort.Strings(objects)
+
+	for _, obj := range objects {
+		if ctx.Err() != nil {
+			return
+		}
+		desc, err := c.sf.DescribeSObject(ctx, obj)
+		if err != nil {
+			r.add(SystemSalesforce, obj, "", "describe failed: %v", err)
+			continue
+		}
+		sfFields := make(map[string]salesforce.SObjectField, len(desc.Fields))
+		for _, f := range desc.Fields {
+			sfFields[strings.ToLower(f.Name)] = f
+		}
+		for _, m := range byObject[obj] {
+			r.Checked++
+			checkSFField(r, obj, m, sfFields)
+		}
+	}
+}
+
+func checkSFField(r *Report, obj string, m model.FieldMapping, sfFields map[string]salesforce.SObjectField) {
+	f, ok := sfFields[strings.ToLower(m.SFField)]
+	if !ok {
+		r.add(SystemSalesforce, obj, m.SFField, "field not found")
+		return
+	}
+	if !f.Updateable {
+		r.add(SystemSalesforce, obj, m.SFField, "field is not updateable")
+	}
+	if m.MaxLength > 0 && f.Length > 0 && m.MaxLength > f.Length {
+		r.add(SystemSalesforce, obj, m.SFField, "registry max length %d exceeds field length %d", m.MaxLength, f.Length)
+	}
+	if f.Type != "picklist" && f.Type != "multipicklist" {
+		return
+	}
+
+	var active []string
+	for _, v := range f.PicklistValues {
+		if v.Active {
+			active = append(active, v.Value)
+		}
+	}
+	if len(active) == 0 {
+		r.add(SystemSalesforce, obj, m.SFField, "picklist has no active values")
+		return
+	}
+	if !f.RestrictedPicklist {
+		return
+	}
+	for _, want := range enumValues(m.Validation) {
+		if !slices.ContainsFunc(active, func(v string) bool { return strings.EqualFold(v, want) }) {
+			r.add(SystemSalesforce, obj, m.SFField, "picklist value %q is missing or inactive", want)
+		}
+	}
+}
+
+// enumValues extracts the alternatives from an anchored enumeration
+// validation pattern such as "^(Low|Medium|High)$". Other patterns return
+// nil since their accepted values cannot be listed.
+func enumValues(pattern string) []string {
+	p := strings.TrimSpace(pattern)
+	p = strings.TrimPrefix(p, "(?i)")
+	if !strings.HasPrefix(p, "^(") || !strings.HasSuffix(p, ")$") {
+		return nil
+	}
+	p = strings.TrimPrefix(strings.TrimSuffix(p, ")$"), "^(")
+	p = strings.TrimPrefix(p, "?:")
+	if strings.ContainsAny(p, `()[]\*+?{}.^$`) {
+		return nil
+	}
+	var out []string
+	for _, v := range strings.Split(p, "|") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// checkNotion retrieves each configured database and verifies its properties.
+func (c *Checker) checkNotion(ctx context.Context, r *Report) {
+	leads := leadProperties
+	if c.sfDiff {
+		leads = append(slices.Clone(leads), pendingChangesProperty)
+	}
+	dbs := []struct {
+		label string
+		id    string
+		specs []propertySpec
+	}{
+		{"lead_db", c.cfg.LeadDB, leads},
+		{"field_db", c.cfg.FieldDB, fieldProperties},
+		{"question_db", c.cfg.QuestionDB, questionProperties},
+	}
+	for _, d := range dbs {
+		if d.id == "" || ctx.Err() != nil {
+			continue
+		}
+		db, err := c.notion.GetDatabase(ctx, d.id)
+		if err != nil {
+			r.add(SystemNotion, d.label, "", "retrieve failed: %v", err)
+			continue
+		}
+		for _, spec := range d.specs {
+			r.Checked++
+			checkProperty(r, d.label, spec, db.Properties)
+		}
+	}
+}
+
+func checkProperty(r *Report, label string, spec propertySpec, props notionapi.PropertyConfigs) {
+	prop, ok := props[spec.Name]
+	if !ok {
+		r.add(SystemNotion, label, spec.Name, "property not found")
+		return
+	}
+	if prop.GetType() != spec.Type {
+		r.add(SystemNotion, label, spec.Name, "property type is %s, want %s", prop.GetType(), spec.Type)
+		return
+	}
+	if len(spec.Options) == 0 {
+		return
+	}
+
+	var options []notionapi.Option
+	switch p := prop.(type) {
+	case *notionapi.StatusPropertyConfig:
+		options = p.Status.Options
+	case *notionapi.SelectPropertyConfig:
+		options = p.Select.Options
+	}
+	for _, want := range spec.Options {
+		if !slices.ContainsFunc(options, func(o notionapi.Option) bool { return o.Name == want }) {
+			r.add(SystemNotion, label, spec.Name, "option %q not found", want)
+		}
+	}
+}
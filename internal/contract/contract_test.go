@@ -0,0 +1,243 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/monitoring"
+	notionmocks "github.com/sells-group/research-cli/pkg/notion/mocks"
+	"github.com/sells-group/research-cli/pkg/salesforce"
+	sfmocks "github.com/sells-group/research-cli/pkg/salesforce/mocks"
+)
+
+func databaseFor(specs []propertySpec) *notionapi.Database {
+	props := make(notionapi.PropertyConfigs, len(specs))
+	for _, s := range specs {
+		var opts []notionapi.Option
+		for _, o := range s.Options {
+			opts = append(opts, notionapi.Option{Name: o})
+		}
+		switch s.Type {
+		case notionapi.PropertyConfigStatus:
+			props[s.Name] = &notionapi.StatusPropertyConfig{Type: s.Type, Status: notionapi.StatusConfig{Options: opts}}
+		case notionapi.PropertyConfigTypeSelect:
+			props[s.Name] = &notionapi.SelectPropertyConfig{Type: s.Type, Select: notionapi.Select{Options: opts}}
+		case notionapi.PropertyConfigTypeTitle:
+			props[s.Name] = &notionapi.TitlePropertyConfig{Type: s.Type}
+		case notionapi.PropertyConfigTypeNumber:
+			props[s.Name] = &notionapi.NumberPropertyConfig{Type: s.Type}
+		default:
+			props[s.Name] = &notionapi.RichTextPropertyConfig{Type: s.Type}
+		}
+	}
+	return &notionapi.Database{Properties: props}
+}
+
+func accountDescription() *salesforce.SObjectDescription {
+	return &salesforce.SObjectDescription{
+		Name: "Account",
+		Fields: []salesforce.SObjectField{
+			{Name: "Enrichment_Report__c", Type: "textarea", Length: 131072, Updateable: true},
+			{Name: "Description", Type: "textarea", Length: 32000, Updateable: true},
+			{Name: "Revenue_Tier__c", Type: "picklist", Updateable: true, RestrictedPicklist: true,
+				PicklistValues: []salesforce.PicklistValue{
+					{Value: "Small", Active: true},
+					{Value: "Medium", Active: true},
+					{Value: "Large", Active: false},
+				}},
+			{Name: "Id", Type: "id", Length: 18},
+		},
+	}
+}
+
+func TestChecker_AllPass(t *testing.T) {
+	sf := sfmocks.NewMockClient(t)
+	nc := notionmocks.NewMockClient(t)
+	ctx := context.Background()
+
+	sf.EXPECT().DescribeSObject(ctx, "Account").Return(accountDescription(), nil)
+	nc.EXPECT().GetDatabase(ctx, "lead").Return(databaseFor(leadProperties), nil)
+	nc.EXPECT().GetDatabase(ctx, "field").Return(databaseFor(fieldProperties), nil)
+	nc.EXPECT().GetDatabase(ctx, "question").Return(databaseFor(questionProperties), nil)
+
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "description", SFField: "Description", MaxLength: 1000},
+		{Key: "revenue_tier", SFField: "Revenue_Tier__c", Validation: "^(Small|Medium)$"},
+		{Key: "internal_only"},
+	})
+	cfg := config.NotionConfig{LeadDB: "lead", FieldDB: "field", QuestionDB: "question"}
+
+	report, err := NewChecker(sf, nc, fields, cfg, false).Run(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Issues)
+	assert.Equal(t, 3+len(leadProperties)+len(fieldProperties)+len(questionProperties), report.Checked)
+}
+
+func TestChecker_SalesforceDrift(t *testing.T) {
+	sf := sfmocks.NewMockClient(t)
+	ctx := context.Background()
+
+	sf.EXPECT().DescribeSObject(ctx, "Account").Return(accountDescription(), nil)
+	sf.EXPECT().DescribeSObject(ctx, "Contact").Return(nil, assert.AnError)
+
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "gone", SFField: "Removed__c"},
+		{Key: "id", SFField: "Id"},
+		{Key: "description", SFField: "description", MaxLength: 40000},
+		{Key: "revenue_tier", SFField: "Revenue_Tier__c", Validation: "^(Small|Large|Huge)$"},
+		{Key: "owner_email", SFField: "Email", SFObject: "Contact"},
+	})
+
+	report, err := NewChecker(sf, nil, fields, config.NotionConfig{}, false).Run(ctx)
+	require.NoError(t, err)
+
+	var got []string
+	for _, i := range report.Issues {
+		got = append(got, i.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"salesforce Account.Removed__c: field not found",
+		"salesforce Account.Id: field is not updateable",
+		"salesforce Account.description: registry max length 40000 exceeds field length 32000",
+		`salesforce Account.Revenue_Tier__c: picklist value "Large" is missing or inactive`,
+		`salesforce Account.Revenue_Tier__c: picklist value "Huge" is missing or inactive`,
+		"salesforce Contact: describe failed: " + assert.AnError.Error(),
+	}, got)
+}
+
+func TestChecker_PicklistWithoutActiveValues(t *testing.T) {
+	sf := sfmocks.NewMockClient(t)
+	ctx := context.Background()
+
+	desc := &salesforce.SObjectDescription{Fields: []salesforce.SObjectField{
+		{Name: "Enrichment_Report__c", Updateable: true},
+		{Name: "Tier__c", Type: "multipicklist", Updateable: true,
+			PicklistValues: []salesforce.PicklistValue{{Value: "A", Active: false}}},
+	}}
+	sf.EXPECT().DescribeSObject(ctx, "Account").Return(desc, nil)
+
+	fields := model.NewFieldRegistry([]model.FieldMapping{{Key: "tier", SFField: "Tier__c"}})
+	report, err := NewChecker(sf, nil, fields, config.NotionConfig{}, false).Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "picklist has no active values", report.Issues[0].Problem)
+}
+
+func TestChecker_NotionDrift(t *testing.T) {
+	nc := notionmocks.NewMockClient(t)
+	ctx := context.Background()
+
+	lead := databaseFor(leadProperties)
+	lead.Properties["Score"] = &notionapi.RichTextPropertyConfig{Type: notionapi.PropertyConfigTypeRichText}
+	lead.Properties["Status"] = &notionapi.StatusPropertyConfig{
+		Type:   notionapi.PropertyConfigStatus,
+		Status: notionapi.StatusConfig{Options: []notionapi.Option{{Name: "Queued"}, {Name: "Enriched"}, {Name: "Failed"}}},
+	}
+	nc.EXPECT().GetDatabase(ctx, "lead").Return(lead, nil)
+	nc.EXPECT().GetDatabase(ctx, "field").Return(nil, assert.AnError)
+
+	cfg := config.NotionConfig{LeadDB: "lead", FieldDB: "field"}
+	report, err := NewChecker(nil, nc, nil, cfg, true).Run(ctx)
+	require.NoError(t, err)
+
+	var got []string
+	for _, i := range report.Issues {
+		got = append(got, i.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"notion lead_db.Pending Changes: property not found",
+		"notion lead_db.Score: property type is rich_text, want number",
+		`notion lead_db.Status: option "Manual Review" not found`,
+		"notion field_db: retrieve failed: " + assert.AnError.Error(),
+	}, got)
+}
+
+func TestChecker_PendingChangesOnlyWithSFDiff(t *testing.T) {
+	nc := notionmocks.NewMockClient(t)
+	ctx := context.Background()
+
+	nc.EXPECT().GetDatabase(ctx, "lead").Return(databaseFor(leadProperties), nil).Once()
+	cfg := config.NotionConfig{LeadDB: "lead"}
+	report, err := NewChecker(nil, nc, nil, cfg, false).Run(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Issues)
+
+	nc.EXPECT().GetDatabase(ctx, "lead").Return(databaseFor(append(leadProperties, pendingChangesProperty)), nil)
+	report, err = NewChecker(nil, nc, nil, cfg, true).Run(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Issues)
+	assert.Equal(t, len(leadProperties)+1, report.Checked)
+}
+
+func TestChecker_ContextCancelled(t *testing.T) {
+	nc := notionmocks.NewMockClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewChecker(nil, nc, nil, config.NotionConfig{LeadDB: "lead"}, false).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	nc.AssertNotCalled(t, "GetDatabase", mock.Anything, mock.Anything)
+}
+
+func TestEnumValues(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"^(Low|Medium|High)$", []string{"Low", "Medium", "High"}},
+		{"(?i)^(?:Yes|No)$", []string{"Yes", "No"}},
+		{"^(Mr. Smith|Other)$", nil},
+		{`^\d{5}$`, nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, enumValues(tt.pattern), tt.pattern)
+	}
+}
+
+func TestReport_Alert(t *testing.T) {
+	r := &Report{CheckedAt: time.Unix(0, 0).UTC(), Checked: 20}
+	for i := 0; i < maxAlertIssues+2; i++ {
+		r.Issues = append(r.Issues, Issue{System: SystemNotion, Object: "lead_db", Field: "X", Problem: "property not found"})
+	}
+
+	a := r.Alert()
+	assert.Equal(t, monitoring.AlertContractDrift, a.Type)
+	assert.Contains(t, a.Message, "12 Salesforce/Notion contract issue(s) found")
+	assert.Contains(t, a.Message, "and 2 more")
+	assert.Equal(t, maxAlertIssues, strings.Count(a.Message, "\n- "))
+}
+
+func TestMonitor_SendsAlertOnDrift(t *testing.T) {
+	var received monitoring.Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	nc := notionmocks.NewMockClient(t)
+	nc.EXPECT().GetDatabase(mock.Anything, "lead").Return(&notionapi.Database{}, nil)
+
+	checker := NewChecker(nil, nc, nil, config.NotionConfig{LeadDB: "lead"}, false)
+	alerter := monitoring.NewAlerter(config.MonitoringConfig{WebhookURL: srv.URL})
+	m := NewMonitor(checker, alerter, 0)
+	assert.Equal(t, 24*time.Hour, m.interval)
+
+	m.check(context.Background(), zap.NewNop())
+	assert.Equal(t, monitoring.AlertContractDrift, received.Type)
+	assert.Contains(t, received.Message, "lead_db.Name: property not found")
+}
@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/monitoring"
+)
+
+// maxAlertIssues caps the issues listed in an alert message; the full list is
+// in Details.
+const maxAlertIssues = 10
+
+// Alert converts a failing report into a monitoring alert.
+func (r *Report) Alert() monitoring.Alert {
+	msg := fmt.Sprintf("%d Salesforce/Notion contract issue(s) found", len(r.Issues))
+	for i, issue := range r.Issues {
+		if i == maxAlertIssues {
+			msg += fmt.Sprintf("\n… and %d more", len(r.Issues)-maxAlertIssues)
+			break
+		}
+		msg += "\n- " + issue.String()
+	}
+	return monitoring.Alert{
+		Type:     monitoring.AlertContractDrift,
+		Severity: "high",
+		Message:  msg,
+		Details: map[string]any{
+			"issues":  r.Issues,
+			"checked": r.Checked,
+		},
+		Timestamp: r.CheckedAt,
+	}
+}
+
+// Monitor runs the contract check on an interval and alerts on violations.
+type Monitor struct {
+	checker  *Checker
+	alerter  *monitoring.Alerter
+	interval time.Duration
+}
+
+// NewMonitor creates a Monitor. A non-positive interval defaults to 24 hours.
+func NewMonitor(checker *Checker, alerter *monitoring.Alerter, interval time.Duration) *Monitor {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &Monitor{checker: checker, alerter: alerter, interval: interval}
+}
+
+// Run checks once immediately and then every interval. It blocks until ctx
+// is cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	log := zap.L().With(zap.String("component", "contract.monitor"))
+	log.Info("starting contract monitor", zap.Duration("interval", m.interval))
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.check(ctx, log)
+		select {
+		case <-ctx.Done():
+			log.Info("contract monitor stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context, log *zap.Logger) {
+	report, err := m.checker.Run(ctx)
+	if err != nil {
+		return
+	}
+	if report.OK() {
+		log.Info("contract: check passed", zap.Int("checked", report.Checked))
+		return
+	}
+	for _, issue := range report.Issues {
+		log.Warn("contract: violation", zap.String("issue", issue.String()))
+	}
+	sent := m.alerter.SendAlerts(ctx, []monitoring.Alert{report.Alert()})
+	log.Info("contract: check failed",
+		zap.Int("checked", report.Checked),
+		zap.Int("issues", len(report.Issues)),
+		zap.Int("alerts_sent", sent),
+	)
+}
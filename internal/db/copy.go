@@ -9,11 +9,20 @@ import (
 )
 
 // CopyFrom bulk-inserts rows into a table using PostgreSQL COPY protocol.
-// This is the fastest way to insert large volumes of data.
+// This is the fastest way to insert large volumes of data. Rows are stamped
+// with the context's Provenance, if any.
 func CopyFrom(ctx context.Context, pool Pool, table string, columns []string, rows [][]any) (int64, error) {
 	if len(rows) == 0 {
 		return 0, nil
 	}
+	if d := DryRunFromContext(ctx); d != nil {
+		d.Record(table, columns, rows)
+		return int64(len(rows)), nil
+	}
+	columns, rows, err := ProvenanceFromContext(ctx).stamp(ctx, pool, table, columns, rows)
+	if err != nil {
+		return 0, err
+	}
 
 	copySource := pgx.CopyFromRows(rows)
 	n, err := pool.CopyFrom(ctx, pgx.Identifier{table}, columns, copySource)
@@ -29,6 +38,14 @@ func CopyFromSchema(ctx context.Context, pool Pool, schema, table string, column
 	if len(rows) == 0 {
 		return 0, nil
 	}
+	if d := DryRunFromContext(ctx); d != nil {
+		d.Record(schema+"."+table, columns, rows)
+		return int64(len(rows)), nil
+	}
+	columns, rows, err := ProvenanceFromContext(ctx).stamp(ctx, pool, schema+"."+table, columns, rows)
+	if err != nil {
+		return 0, err
+	}
 
 	copySource := pgx.CopyFromRows(rows)
 	n, err := pool.CopyFrom(ctx, pgx.Identifier{schema, table}, columns, copySource)
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DryRun records the writes a sync would have made. While a DryRun is
+// attached to the context, BulkUpsert and CopyFrom count rows and keep a
+// few sample rows per table instead of writing; a pool from NewDryRunPool
+// does the same for direct COPYs and skips other write statements.
+type DryRun struct {
+	mu         sync.Mutex
+	sampleSize int
+	tables     map[string]*DryRunTable
+	skipped    int64
+}
+
+// DryRunTable summarizes the rows a dry run would have written to a table.
+type DryRunTable struct {
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+	Samples [][]any  `json:"samples,omitempty"`
+}
+
+// NewDryRun creates a DryRun that keeps up to sampleSize rows per table.
+func NewDryRun(sampleSize int) *DryRun {
+	return &DryRun{sampleSize: sampleSize, tables: make(map[string]*DryRunTable)}
+}
+
+// Record counts rows that would have been written to table.
+func (d *DryRun) Record(table string, columns []string, rows [][]any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t, ok := d.tables[table]
+	if !ok {
+		t = &DryRunTable{Table: table, Columns: slices.Clone(columns)}
+		d.tables[table] = t
+	}
+	t.Rows += int64(len(rows))
+	for _, row := range rows {
+		if len(t.Samples) >= d.sampleSize {
+			break
+		}
+		t.Samples = append(t.Samples, slices.Clone(row))
+	}
+}
+
+// Tables returns the recorded tables sorted by name.
+func (d *DryRun) Tables() []DryRunTable {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]DryRunTable, 0, len(d.tables))
+	for _, t := range d.tables {
+		out = append(out, *t)
+	}
+	slices.SortFunc(out, func(a, b DryRunTable) int { return strings.Compare(a.Table, b.Table) })
+	return out
+}
+
+// Skipped returns the number of non-COPY write statements skipped.
+func (d *DryRun) Skipped() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.skipped
+}
+
+func (d *DryRun) skip() {
+	d.mu.Lock()
+	d.skipped++
+	d.mu.Unlock()
+}
+
+// recordCopy drains a COPY source into the dry run.
+func (d *DryRun) recordCopy(table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	var rows [][]any
+	for src.Next() {
+		vals, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		rows = append(rows, vals)
+	}
+	if err := src.Err(); err != nil {
+		return 0, err
+	}
+	d.Record(strings.Join(table, "."), columns, rows)
+	return int64(len(rows)), nil
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context that routes BulkUpsert and CopyFrom writes
+// into d.
+func WithDryRun(ctx context.Context, d *DryRun) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, d)
+}
+
+// DryRunFromContext returns the DryRun attached to ctx, or nil.
+func DryRunFromContext(ctx context.Context) *DryRun {
+	d, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return d
+}
+
+// NewDryRunPool wraps p so that reads pass through while COPYs are recorded
+// in d and Exec statements are skipped. Transactions are always rolled back.
+func NewDryRunPool(p Pool, d *DryRun) Pool {
+	return &dryRunPool{next: p, d: d}
+}
+
+type dryRunPool struct {
+	next Pool
+	d    *DryRun
+}
+
+// Begin implements Pool.
+func (p *dryRunPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.next.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dryRunTx{Tx: tx, d: p.d}, nil
+}
+
+// Exec implements Pool. The statement is skipped.
+func (p *dryRunPool) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+	p.d.skip()
+	return pgconn.CommandTag{}, nil
+}
+
+// Query implements Pool.
+func (p *dryRunPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return p.next.Query(ctx, sql, args...)
+}
+
+// QueryRow implements Pool.
+func (p *dryRunPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return p.next.QueryRow(ctx, sql, args...)
+}
+
+// CopyFrom implements Pool. Rows are recorded instead of copied.
+func (p *dryRunPool) CopyFrom(_ context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return p.d.recordCopy(tableName, columnNames, rowSrc)
+}
+
+// dryRunTx skips writes inside a transaction and turns Commit into
+// Rollback.
+type dryRunTx struct {
+	pgx.Tx
+	d *DryRun
+}
+
+// Begin implements pgx.Tx.
+func (t *dryRunTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := t.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dryRunTx{Tx: tx, d: t.d}, nil
+}
+
+// Commit implements pgx.Tx by rolling back.
+func (t *dryRunTx) Commit(ctx context.Context) error {
+	return t.Tx.Rollback(ctx)
+}
+
+// Exec implements pgx.Tx. The statement is skipped.
+func (t *dryRunTx) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+	t.d.skip()
+	return pgconn.CommandTag{}, nil
+}
+
+// CopyFrom implements pgx.Tx. Rows are recorded instead of copied.
+func (t *dryRunTx) CopyFrom(_ context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return t.d.recordCopy(tableName, columnNames, rowSrc)
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun_Record(t *testing.T) {
+	d := NewDryRun(2)
+	d.Record("fed_data.b", []string{"id"}, [][]any{{1}, {2}, {3}})
+	d.Record("fed_data.a", []string{"id"}, [][]any{{9}})
+	d.Record("fed_data.b", []string{"id"}, [][]any{{4}})
+
+	tables := d.Tables()
+	require.Len(t, tables, 2)
+	assert.Equal(t, "fed_data.a", tables[0].Table)
+	assert.Equal(t, int64(1), tables[0].Rows)
+	assert.Equal(t, "fed_data.b", tables[1].Table)
+	assert.Equal(t, int64(4), tables[1].Rows)
+	assert.Equal(t, [][]any{{1}, {2}}, tables[1].Samples)
+}
+
+func TestBulkUpsert_DryRun(t *testing.T) {
+	d := NewDryRun(3)
+	ctx := WithDryRun(context.Background(), d)
+
+	// A nil pool proves nothing reaches the database.
+	n, err := BulkUpsert(ctx, nil, UpsertConfig{
+		Table:        "fed_data.test",
+		Columns:      []string{"id", "name"},
+		ConflictKeys: []string{"id"},
+	}, [][]any{{1, "a"}, {2, "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = CopyFromSchema(ctx, nil, "fed_data", "copied", []string{"id"}, [][]any{{1}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	res, err := BulkUpsertMulti(ctx, nil, []MultiUpsertEntry{
+		{Config: UpsertConfig{Table: "fed_data.multi", Columns: []string{"id"}, ConflictKeys: []string{"id"}}, Rows: [][]any{{1}, {2}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res["fed_data.multi"])
+
+	tables := d.Tables()
+	require.Len(t, tables, 3)
+	assert.Equal(t, "fed_data.copied", tables[0].Table)
+	assert.Equal(t, "fed_data.multi", tables[1].Table)
+	assert.Equal(t, "fed_data.test", tables[2].Table)
+	assert.Equal(t, []string{"id", "name"}, tables[2].Columns)
+}
+
+func TestDryRunPool(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	d := NewDryRun(3)
+	pool := NewDryRunPool(mock, d)
+	ctx := context.Background()
+
+	// Reads pass through.
+	mock.ExpectQuery("SELECT MAX").WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(7))
+	var maxID int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT MAX(id) FROM fed_data.test").Scan(&maxID))
+	assert.Equal(t, 7, maxID)
+
+	// Exec and COPY never reach the database.
+	_, err = pool.Exec(ctx, "DELETE FROM fed_data.test")
+	require.NoError(t, err)
+	n, err := pool.CopyFrom(ctx, pgx.Identifier{"fed_data", "test"}, []string{"id"}, pgx.CopyFromRows([][]any{{1}, {2}}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// Transactions skip writes and roll back on commit.
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "UPDATE fed_data.test SET id = 1")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	assert.Equal(t, int64(2), d.Skipped())
+	tables := d.Tables()
+	require.Len(t, tables, 1)
+	assert.Equal(t, "fed_data.test", tables[0].Table)
+	assert.Equal(t, int64(2), tables[0].Rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
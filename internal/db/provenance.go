@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// ProvenanceColumns are the row-level provenance columns stamped onto
+// tables that have them.
+var ProvenanceColumns = []string{"source_url", "source_file_hash", "ingested_at", "sync_run_id"}
+
+// Provenance identifies where the rows of a sync run came from. While a
+// Provenance is attached to the context, BulkUpsert, BulkUpsertMulti, and
+// CopyFrom append ProvenanceColumns to every row written to a table that
+// has a sync_run_id column. Tables without the columns are written
+// unchanged.
+type Provenance struct {
+	RunID      int64
+	IngestedAt time.Time
+
+	mu   sync.Mutex
+	url  string
+	hash string
+
+	run *provenanceRun
+}
+
+// provenanceRun is the state a Provenance shares with the copies made by
+// WithSource: which tables carry provenance columns and the hash of each
+// file downloaded so far.
+type provenanceRun struct {
+	mu     sync.Mutex
+	tables map[string]bool
+	hashes map[string]string
+}
+
+// NewProvenance creates a Provenance for the sync run runID.
+func NewProvenance(runID int64, ingestedAt time.Time) *Provenance {
+	return &Provenance{
+		RunID:      runID,
+		IngestedAt: ingestedAt,
+		run:        &provenanceRun{tables: make(map[string]bool), hashes: make(map[string]string)},
+	}
+}
+
+// SetSource records that url was downloaded with the given SHA256 ("" when
+// unknown, stored as NULL) and makes it the source stamped on rows written
+// from now on.
+func (p *Provenance) SetSource(url, hash string) {
+	p.run.mu.Lock()
+	p.run.hashes[url] = hash
+	p.run.mu.Unlock()
+
+	p.mu.Lock()
+	p.url, p.hash = url, hash
+	p.mu.Unlock()
+}
+
+// Source returns the current upstream file URL and SHA256.
+func (p *Provenance) Source() (url, hash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.url, p.hash
+}
+
+type provenanceKey struct{}
+
+// WithProvenance returns a context that stamps BulkUpsert and CopyFrom rows
+// with p.
+func WithProvenance(ctx context.Context, p *Provenance) context.Context {
+	return context.WithValue(ctx, provenanceKey{}, p)
+}
+
+// ProvenanceFromContext returns the Provenance attached to ctx, or nil.
+func ProvenanceFromContext(ctx context.Context) *Provenance {
+	p, _ := ctx.Value(provenanceKey{}).(*Provenance)
+	return p
+}
+
+// WithSource returns a context whose writes are stamped with url, and the
+// hash recorded when it was downloaded, regardless of later SetSource
+// calls. Datasets that download files concurrently, or several before
+// loading any, use it to attribute each load to its own file. Without a
+// Provenance on ctx, ctx is returned unchanged.
+func WithSource(ctx context.Context, url string) context.Context {
+	p := ProvenanceFromContext(ctx)
+	if p == nil {
+		return ctx
+	}
+	p.run.mu.Lock()
+	hash := p.run.hashes[url]
+	p.run.mu.Unlock()
+	return WithProvenance(ctx, &Provenance{
+		RunID:      p.RunID,
+		IngestedAt: p.IngestedAt,
+		url:        url,
+		hash:       hash,
+		run:        p.run,
+	})
+}
+
+// stamp appends the provenance columns and values to columns and rows when
+// table has them. Callers that write their own sync_run_id are left alone.
+// rows is copied, never modified.
+func (p *Provenance) stamp(ctx context.Context, pool Pool, table string, columns []string, rows [][]any) ([]string, [][]any, error) {
+	if p == nil || slices.Contains(columns, "sync_run_id") {
+		return columns, rows, nil
+	}
+	has, err := p.hasColumns(ctx, pool, table)
+	if err != nil || !has {
+		return columns, rows, err
+	}
+
+	url, hash := p.Source()
+	vals := []any{nullIfEmpty(url), nullIfEmpty(hash), p.IngestedAt, p.RunID}
+	out := make([][]any, len(rows))
+	for i, row := range rows {
+		r := make([]any, 0, len(row)+len(vals))
+		out[i] = append(append(r, row...), vals...)
+	}
+	return append(slices.Clone(columns), ProvenanceColumns...), out, nil
+}
+
+// stampUpsert stamps an upsert's rows and, when the target has provenance
+// columns, updates them on conflict too.
+func (p *Provenance) stampUpsert(ctx context.Context, pool Pool, cfg UpsertConfig, rows [][]any) (UpsertConfig, [][]any, error) {
+	cols, rows, err := p.stamp(ctx, pool, cfg.Table, cfg.Columns, rows)
+	if err != nil || len(cols) == len(cfg.Columns) {
+		return cfg, rows, err
+	}
+	cfg.Columns = cols
+	if cfg.UpdateCols != nil {
+		cfg.UpdateCols = append(slices.Clone(cfg.UpdateCols), ProvenanceColumns...)
+	}
+	return cfg, rows, nil
+}
+
+// hasColumns reports whether table has a sync_run_id column, caching the
+// answer for the life of the Provenance.
+func (p *Provenance) hasColumns(ctx context.Context, pool Pool, table string) (bool, error) {
+	p.run.mu.Lock()
+	defer p.run.mu.Unlock()
+	if has, ok := p.run.tables[table]; ok {
+		return has, nil
+	}
+	var has bool
+	err := pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM pg_attribute
+		     WHERE attrelid = to_regclass($1) AND attname = 'sync_run_id' AND NOT attisdropped
+		 )`,
+		table,
+	).Scan(&has)
+	if err != nil {
+		return false, eris.Wrapf(err, "db: provenance: check columns of %s", table)
+	}
+	p.run.tables[table] = has
+	return has, nil
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
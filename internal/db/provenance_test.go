@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var provenanceAt = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+func expectProvenanceColumns(mock pgxmock.PgxPoolIface, table string, has bool) {
+	mock.ExpectQuery("SELECT EXISTS .* FROM pg_attribute").
+		WithArgs(table).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(has))
+}
+
+func TestBulkUpsert_Provenance(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectProvenanceColumns(mock, "fed_data.test", true)
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_fed_data_test"},
+		[]string{"id", "name", "source_url", "source_file_hash", "ingested_at", "sync_run_id"}).WillReturnResult(1)
+	mock.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(`DO UPDATE SET "name" = EXCLUDED."name", "source_url" = EXCLUDED."source_url", .*"sync_run_id" = EXCLUDED."sync_run_id"`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	p := NewProvenance(42, provenanceAt)
+	p.SetSource("https://example.gov/a.zip", "abc")
+	rows := [][]any{{1, "a"}}
+	n, err := BulkUpsert(WithProvenance(context.Background(), p), mock, UpsertConfig{
+		Table:        "fed_data.test",
+		Columns:      []string{"id", "name"},
+		ConflictKeys: []string{"id"},
+		UpdateCols:   []string{"name"},
+	}, rows)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, [][]any{{1, "a"}}, rows, "caller's rows are not modified")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProvenance_Stamp(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// Each table is looked up once per run.
+	expectProvenanceColumns(mock, "fed_data.test", true)
+	expectProvenanceColumns(mock, "public.other", false)
+
+	p := NewProvenance(7, provenanceAt)
+	p.SetSource("https://example.gov/stream", "")
+	ctx := context.Background()
+
+	cols, rows, err := p.stamp(ctx, mock, "fed_data.test", []string{"id"}, [][]any{{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "source_url", "source_file_hash", "ingested_at", "sync_run_id"}, cols)
+	assert.Equal(t, [][]any{{1, "https://example.gov/stream", nil, provenanceAt, int64(7)}}, rows)
+
+	_, _, err = p.stamp(ctx, mock, "fed_data.test", []string{"id"}, [][]any{{2}})
+	require.NoError(t, err)
+
+	cols, rows, err = p.stamp(ctx, mock, "public.other", []string{"id"}, [][]any{{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, cols)
+	assert.Equal(t, [][]any{{1}}, rows)
+
+	cols, _, err = p.stamp(ctx, mock, "fed_data.own", []string{"id", "sync_run_id"}, [][]any{{1, 3}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "sync_run_id"}, cols, "explicit sync_run_id is kept")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	var none *Provenance
+	cols, _, err = none.stamp(ctx, mock, "fed_data.test", []string{"id"}, [][]any{{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, cols)
+}
+
+func TestProvenance_StampQueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT EXISTS").WillReturnError(errors.New("connection refused"))
+
+	_, err = CopyFrom(WithProvenance(context.Background(), NewProvenance(1, provenanceAt)), mock, "fed_data.test", []string{"id"}, [][]any{{1}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provenance: check columns of fed_data.test")
+}
+
+func TestWithSource(t *testing.T) {
+	p := NewProvenance(9, provenanceAt)
+	p.SetSource("https://example.gov/a.zip", "aaa")
+	p.SetSource("https://example.gov/b.zip", "bbb")
+
+	ctx := WithSource(WithProvenance(context.Background(), p), "https://example.gov/a.zip")
+	pinned := ProvenanceFromContext(ctx)
+	require.NotNil(t, pinned)
+	url, hash := pinned.Source()
+	assert.Equal(t, "https://example.gov/a.zip", url)
+	assert.Equal(t, "aaa", hash)
+	assert.Equal(t, int64(9), pinned.RunID)
+
+	p.SetSource("https://example.gov/c.zip", "ccc")
+	url, _ = pinned.Source()
+	assert.Equal(t, "https://example.gov/a.zip", url, "later downloads do not move a pinned source")
+
+	bare := context.Background()
+	assert.Equal(t, bare, WithSource(bare, "https://example.gov/a.zip"))
+}
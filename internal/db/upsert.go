@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rotisserie/eris"
 )
 
@@ -22,6 +24,9 @@ type UpsertConfig struct {
 // 2. COPY rows into the temp table
 // 3. INSERT INTO target SELECT ... FROM temp ON CONFLICT (keys) DO UPDATE SET ...
 // 4. Drops the temp table
+//
+// Under a dry run (WithDryRun) the rows are recorded instead of written;
+// with a Provenance attached (WithProvenance) they are stamped with it.
 func BulkUpsert(ctx context.Context, pool Pool, cfg UpsertConfig, rows [][]any) (int64, error) {
 	if len(rows) == 0 {
 		return 0, nil
@@ -34,6 +39,16 @@ func BulkUpsert(ctx context.Context, pool Pool, cfg UpsertConfig, rows [][]any)
 		return 0, eris.New("db: upsert: no conflict keys specified")
 	}
 
+	if d := DryRunFromContext(ctx); d != nil {
+		d.Record(cfg.Table, cfg.Columns, rows)
+		return int64(len(rows)), nil
+	}
+
+	cfg, rows, err := ProvenanceFromContext(ctx).stampUpsert(ctx, pool, cfg, rows)
+	if err != nil {
+		return 0, err
+	}
+
 	updateCols := cfg.UpdateCols
 	if updateCols == nil {
 		conflictSet := make(map[string]bool, len(cfg.ConflictKeys))
@@ -53,14 +68,10 @@ func BulkUpsert(ctx context.Context, pool Pool, cfg UpsertConfig, rows [][]any)
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
 
-	tempTable := fmt.Sprintf("_tmp_upsert_%s", strings.ReplaceAll(cfg.Table, ".", "_"))
+	tempTable := upsertTempTable(pool, cfg.Table)
 
 	// Create temp table with same structure as target
-	createSQL := fmt.Sprintf(
-		"CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
-		pgx.Identifier{tempTable}.Sanitize(),
-		sanitizeTable(cfg.Table),
-	)
+	createSQL := createTempTableSQL(tempTable, cfg.Table)
 	if _, err := tx.Exec(ctx, createSQL); err != nil {
 		return 0, eris.Wrapf(err, "db: upsert: create temp table for %s", cfg.Table)
 	}
@@ -137,6 +148,26 @@ func BulkUpsertMulti(ctx context.Context, pool Pool, entries []MultiUpsertEntry)
 		return results, nil
 	}
 
+	if d := DryRunFromContext(ctx); d != nil {
+		for _, e := range active {
+			d.Record(e.Config.Table, e.Config.Columns, e.Rows)
+			results[e.Config.Table] = int64(len(e.Rows))
+		}
+		return results, nil
+	}
+
+	if p := ProvenanceFromContext(ctx); p != nil {
+		stamped := make([]MultiUpsertEntry, len(active))
+		for i, e := range active {
+			cfg, rows, err := p.stampUpsert(ctx, pool, e.Config, e.Rows)
+			if err != nil {
+				return nil, err
+			}
+			stamped[i] = MultiUpsertEntry{Config: cfg, Rows: rows}
+		}
+		active = stamped
+	}
+
 	tx, err := pool.Begin(ctx)
 	if err != nil {
 		return nil, eris.Wrap(err, "db: upsert multi: begin tx")
@@ -165,13 +196,9 @@ func BulkUpsertMulti(ctx context.Context, pool Pool, entries []MultiUpsertEntry)
 			}
 		}
 
-		tempTable := fmt.Sprintf("_tmp_upsert_%s", strings.ReplaceAll(cfg.Table, ".", "_"))
+		tempTable := upsertTempTable(pool, cfg.Table)
 
-		createSQL := fmt.Sprintf(
-			"CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
-			pgx.Identifier{tempTable}.Sanitize(),
-			sanitizeTable(cfg.Table),
-		)
+		createSQL := createTempTableSQL(tempTable, cfg.Table)
 		if _, err := tx.Exec(ctx, createSQL); err != nil {
 			return nil, eris.Wrapf(err, "db: upsert multi: create temp table for %s", cfg.Table)
 		}
@@ -251,3 +278,39 @@ func quoteAndJoin(cols []string) string {
 	}
 	return strings.Join(quoted, ", ")
 }
+
+// tempTableSeq numbers staging tables created inside an outer transaction.
+var tempTableSeq atomic.Uint64
+
+// upsertTempTable names the staging table for an upsert into table. An
+// upsert normally commits its own transaction, whose ON COMMIT DROP frees
+// the name for the next call. When pool is itself a pgx.Tx, Begin is a
+// savepoint and the drop waits for the outer commit, so each call gets a
+// name of its own.
+func upsertTempTable(pool Pool, table string) string {
+	name := strings.ReplaceAll(table, ".", "_")
+	if InTx(pool) {
+		return fmt.Sprintf("_tmp_upsert_%d_%s", tempTableSeq.Add(1), name)
+	}
+	return "_tmp_upsert_" + name
+}
+
+// InTx reports whether pool is an open transaction rather than a
+// connection pool. Pools (pgxpool and pgxmock's) also satisfy pgx.Tx, so
+// they are told apart by Stat.
+func InTx(pool Pool) bool {
+	if _, ok := pool.(pgx.Tx); !ok {
+		return false
+	}
+	_, isPool := pool.(interface{ Stat() *pgxpool.Stat })
+	return !isPool
+}
+
+// createTempTableSQL creates the staging table for an upsert into table.
+func createTempTableSQL(tempTable, table string) string {
+	return fmt.Sprintf(
+		"CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
+		pgx.Identifier{tempTable}.Sanitize(),
+		sanitizeTable(table),
+	)
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5"
@@ -239,6 +240,29 @@ func TestSanitizeTable(t *testing.T) {
 	}
 }
 
+func TestCreateTempTableSQL(t *testing.T) {
+	got := createTempTableSQL("_tmp_upsert_fed_data_cbp_data", "fed_data.cbp_data")
+	assert.Equal(t, `CREATE TEMP TABLE "_tmp_upsert_fed_data_cbp_data" (LIKE "fed_data"."cbp_data" INCLUDING DEFAULTS) ON COMMIT DROP`, got)
+}
+
+// fakeTx is a pgx.Tx that is not a pool, like the one pgx.Pool.Begin returns.
+type fakeTx struct{ pgx.Tx }
+
+func TestUpsertTempTable(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	assert.Equal(t, "_tmp_upsert_fed_data_cbp_data", upsertTempTable(mock, "fed_data.cbp_data"))
+
+	tx := fakeTx{}
+	a := upsertTempTable(tx, "fed_data.cbp_data")
+	b := upsertTempTable(tx, "fed_data.cbp_data")
+	assert.NotEqual(t, a, b, "upserts inside one transaction need distinct staging tables")
+	assert.True(t, strings.HasPrefix(a, "_tmp_upsert_"))
+	assert.True(t, strings.HasSuffix(a, "_fed_data_cbp_data"))
+}
+
 func TestQuoteAndJoin(t *testing.T) {
 	result := quoteAndJoin([]string{"id", "name", "value"})
 	assert.Equal(t, `"id", "name", "value"`, result)
@@ -0,0 +1,118 @@
+// Package duckcache snapshots Postgres tables into an embedded DuckDB file so
+// report aggregations can run locally, without warehouse access, against a
+// point-in-time copy of the data.
+//
+// DuckDB requires cgo. Binaries built with CGO_ENABLED=0 (the production
+// image) include stubs that return ErrUnsupported.
+package duckcache
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rotisserie/eris"
+)
+
+// ErrUnsupported is returned by every operation in binaries built without cgo.
+var ErrUnsupported = eris.New("duckcache: DuckDB requires a cgo-enabled build")
+
+// MetaTable records what each snapshot contains.
+const MetaTable = "_snapshot_tables"
+
+// TableResult reports one table copied into a snapshot.
+type TableResult struct {
+	Table    string        `json:"table"`
+	Rows     int64         `json:"rows"`
+	Duration time.Duration `json:"duration"`
+}
+
+// QueryResult holds rows returned from a snapshot query, formatted as text.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]string
+}
+
+var tableNameRe = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// splitTable validates a table name ("schema.table" or "table") and returns
+// its schema (default "main" in DuckDB, "public" in Postgres) and name.
+func splitTable(name string) (schema, table string, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !tableNameRe.MatchString(name) {
+		return "", "", eris.Errorf("duckcache: invalid table name %q", name)
+	}
+	if schema, table, ok := strings.Cut(name, "."); ok {
+		return schema, table, nil
+	}
+	return "", name, nil
+}
+
+// column describes how a Postgres column is selected and stored in DuckDB.
+type column struct {
+	name     string
+	duckType string
+	cast     string // Postgres cast applied in the SELECT, "" for none
+}
+
+// columnFor maps a Postgres result column to its DuckDB type. Types DuckDB
+// cannot store natively (numeric, geometry, jsonb, arrays) are cast in the
+// SELECT so pgx decodes them to float64 or string.
+func columnFor(fd pgconn.FieldDescription) column {
+	c := column{name: fd.Name}
+	switch fd.DataTypeOID {
+	case pgtype.Int2OID:
+		c.duckType = "SMALLINT"
+	case pgtype.Int4OID:
+		c.duckType = "INTEGER"
+	case pgtype.Int8OID:
+		c.duckType = "BIGINT"
+	case pgtype.Float4OID:
+		c.duckType = "REAL"
+	case pgtype.Float8OID:
+		c.duckType = "DOUBLE"
+	case pgtype.NumericOID:
+		c.duckType, c.cast = "DOUBLE", "float8"
+	case pgtype.BoolOID:
+		c.duckType = "BOOLEAN"
+	case pgtype.DateOID:
+		c.duckType = "DATE"
+	case pgtype.TimestampOID, pgtype.TimestamptzOID:
+		c.duckType = "TIMESTAMP"
+	case pgtype.TextOID, pgtype.VarcharOID, pgtype.BPCharOID, pgtype.NameOID:
+		c.duckType = "VARCHAR"
+	default:
+		c.duckType, c.cast = "VARCHAR", "text"
+	}
+	return c
+}
+
+// selectList builds the Postgres SELECT list for cols.
+func selectList(cols []column) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		ident := pgx.Identifier{c.name}.Sanitize()
+		if c.cast != "" {
+			parts[i] = ident + "::" + c.cast + " AS " + ident
+		} else {
+			parts[i] = ident
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// duckValue converts a pgx-decoded value into one the DuckDB appender
+// accepts. Infinite dates and timestamps become NULL.
+func duckValue(v any) any {
+	switch x := v.(type) {
+	case pgtype.InfinityModifier:
+		return nil
+	case time.Time:
+		return x.UTC()
+	default:
+		return v
+	}
+}
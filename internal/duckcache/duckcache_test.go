@@ -0,0 +1,108 @@
+package duckcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTable(t *testing.T) {
+	schema, table, err := splitTable(" Fed_Data.CBP_Data ")
+	require.NoError(t, err)
+	assert.Equal(t, "fed_data", schema)
+	assert.Equal(t, "cbp_data", table)
+
+	schema, table, err = splitTable("companies")
+	require.NoError(t, err)
+	assert.Empty(t, schema)
+	assert.Equal(t, "companies", table)
+
+	for _, bad := range []string{"", "a.b.c", "x; DROP TABLE y", `"quoted"`, "1abc"} {
+		_, _, err := splitTable(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestColumnFor(t *testing.T) {
+	tests := []struct {
+		oid      uint32
+		duckType string
+		cast     string
+	}{
+		{pgtype.Int4OID, "INTEGER", ""},
+		{pgtype.Int8OID, "BIGINT", ""},
+		{pgtype.Float8OID, "DOUBLE", ""},
+		{pgtype.NumericOID, "DOUBLE", "float8"},
+		{pgtype.BoolOID, "BOOLEAN", ""},
+		{pgtype.DateOID, "DATE", ""},
+		{pgtype.TimestamptzOID, "TIMESTAMP", ""},
+		{pgtype.VarcharOID, "VARCHAR", ""},
+		{pgtype.JSONBOID, "VARCHAR", "text"},
+		{99999, "VARCHAR", "text"}, // e.g. PostGIS geometry
+	}
+	for _, tt := range tests {
+		c := columnFor(pgconn.FieldDescription{Name: "col", DataTypeOID: tt.oid})
+		assert.Equal(t, tt.duckType, c.duckType, "oid %d", tt.oid)
+		assert.Equal(t, tt.cast, c.cast, "oid %d", tt.oid)
+	}
+}
+
+func TestSelectList(t *testing.T) {
+	got := selectList([]column{
+		{name: "id", duckType: "BIGINT"},
+		{name: "score", duckType: "DOUBLE", cast: "float8"},
+	})
+	assert.Equal(t, `"id", "score"::float8 AS "score"`, got)
+}
+
+func TestDuckValue(t *testing.T) {
+	assert.Nil(t, duckValue(pgtype.Infinity))
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*3600))
+	assert.Equal(t, ts.UTC(), duckValue(ts))
+	assert.Equal(t, "x", duckValue("x"))
+}
+
+func TestReportTables(t *testing.T) {
+	tables, err := ReportTables([]string{"msa", "benchmarks", "msa"}, []string{"public.cbsa_areas", "fed_data.cbp_data"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"public.cbsa_areas",
+		"public.address_msa",
+		"fed_data.county_opportunity_scores",
+		"fed_data.cbp_data",
+	}, tables)
+
+	_, err = ReportTables([]string{"nope"}, nil)
+	assert.Error(t, err)
+
+	_, err = ReportTables(nil, []string{"bad name"})
+	assert.Error(t, err)
+}
+
+func TestReportNames(t *testing.T) {
+	assert.Equal(t, []string{"benchmarks", "cost_of_living", "edgar_fts", "msa", "physical_risk"}, ReportNames())
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "", formatValue(nil))
+	assert.Equal(t, "2024-03-01", formatValue(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-03-01T12:30:00Z", formatValue(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "abc", formatValue([]byte("abc")))
+	assert.Equal(t, "42", formatValue(int64(42)))
+
+	var n pgtype.Numeric
+	require.NoError(t, n.Scan("12.50"))
+	assert.Equal(t, "12.50", formatValue(n))
+}
+
+func TestReportRun_NoSource(t *testing.T) {
+	r, err := LookupReport("msa")
+	require.NoError(t, err)
+	_, err = r.Run(context.Background(), nil, "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,343 @@
+//go:build cgo
+
+package duckcache
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/marcboeker/go-duckdb"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// Snapshot copies each table from pool into the DuckDB file at path,
+// replacing any earlier copy of the same table, and records the copy in
+// MetaTable. Tables keep their Postgres schema-qualified names so report SQL
+// runs unchanged against either database.
+func Snapshot(ctx context.Context, pool db.Pool, path string, tables []string) ([]TableResult, error) {
+	if len(tables) == 0 {
+		return nil, eris.New("duckcache: no tables to snapshot")
+	}
+
+	duck, err := openSnapshot(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer duck.Close() //nolint:errcheck
+
+	results := make([]TableResult, 0, len(tables))
+	for _, name := range tables {
+		res, err := copyTable(ctx, pool, duck, name)
+		if err != nil {
+			return results, err
+		}
+		zap.L().Info("duckcache: table copied",
+			zap.String("table", res.Table),
+			zap.Int64("rows", res.Rows),
+			zap.Duration("duration", res.Duration),
+		)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// openSnapshot opens (creating if needed) the DuckDB file at path and
+// ensures MetaTable exists.
+func openSnapshot(ctx context.Context, path string) (*sql.DB, error) {
+	duck, err := sql.Open("duckdb", path)
+	if err != nil {
+		return nil, eris.Wrapf(err, "duckcache: open %s", path)
+	}
+	if _, err := duck.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+MetaTable+` (
+		table_name VARCHAR PRIMARY KEY,
+		rows BIGINT NOT NULL,
+		snapshot_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		_ = duck.Close()
+		return nil, eris.Wrap(err, "duckcache: create meta table")
+	}
+	return duck, nil
+}
+
+// Export copies a Postgres table into a staging DuckDB file and writes it
+// as Parquet under opts.Dest, hive-partitioned per opts.PartitionBy. Column
+// types follow the same Postgres-to-DuckDB mapping as Snapshot. s3://
+// destinations load DuckDB's httpfs extension, installing it on first use.
+func Export(ctx context.Context, pool db.Pool, opts ExportOptions) (ExportResult, error) {
+	start := time.Now()
+	staging, err := os.MkdirTemp(opts.TempDir, "duckcache-export-*")
+	if err != nil {
+		return ExportResult{}, eris.Wrap(err, "duckcache: create staging dir")
+	}
+	defer os.RemoveAll(staging) //nolint:errcheck
+
+	duck, err := openSnapshot(ctx, filepath.Join(staging, "export.duckdb"))
+	if err != nil {
+		return ExportResult{}, err
+	}
+	defer duck.Close() //nolint:errcheck
+
+	copied, err := copyTable(ctx, pool, duck, opts.Table)
+	if err != nil {
+		return ExportResult{Table: copied.Table}, err
+	}
+	res, err := writeParquet(ctx, duck, opts)
+	res.Rows = copied.Rows
+	res.Duration = time.Since(start)
+	return res, err
+}
+
+// writeParquet writes a table already in duck to opts.Dest.
+func writeParquet(ctx context.Context, duck *sql.DB, opts ExportOptions) (ExportResult, error) {
+	schema, table, err := splitTable(opts.Table)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	duckName := pgx.Identifier{table}.Sanitize()
+	if schema != "" {
+		duckName = pgx.Identifier{schema, table}.Sanitize()
+	}
+	res := ExportResult{Table: strings.TrimPrefix(schema+"."+table, ".")}
+
+	columns, err := duckColumns(ctx, duck, schema, table)
+	if err != nil {
+		return res, err
+	}
+	res.PartitionBy, err = exportPartitions(opts.PartitionBy, columns)
+	if err != nil {
+		return res, err
+	}
+	res.Dest = exportTarget(opts.Dest, table, len(res.PartitionBy) > 0)
+
+	if isS3(opts.Dest) {
+		for _, stmt := range []string{"INSTALL httpfs", "LOAD httpfs", s3SecretStatement(opts.S3)} {
+			if _, err := duck.ExecContext(ctx, stmt); err != nil {
+				return res, eris.Wrap(err, "duckcache: configure s3")
+			}
+		}
+	} else if err := os.MkdirAll(opts.Dest, 0o750); err != nil {
+		return res, eris.Wrapf(err, "duckcache: create %s", opts.Dest)
+	}
+
+	if _, err := duck.ExecContext(ctx, copyStatement(duckName, res.Dest, res.PartitionBy)); err != nil {
+		return res, eris.Wrapf(err, "duckcache: write parquet to %s", res.Dest)
+	}
+	return res, nil
+}
+
+// duckColumns lists a DuckDB table's columns in order.
+func duckColumns(ctx context.Context, duck *sql.DB, schema, table string) ([]string, error) {
+	if schema == "" {
+		schema = "main"
+	}
+	rows, err := duck.QueryContext(ctx,
+		`SELECT column_name FROM information_schema.columns
+		 WHERE table_schema = ? AND table_name = ? ORDER BY ordinal_position`,
+		schema, table)
+	if err != nil {
+		return nil, eris.Wrapf(err, "duckcache: describe %s.%s", schema, table)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var cols []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, eris.Wrap(err, "duckcache: scan column")
+		}
+		cols = append(cols, c)
+	}
+	return cols, eris.Wrap(rows.Err(), "duckcache: read columns")
+}
+
+// copyTable recreates one table in DuckDB and streams its rows through the
+// DuckDB appender.
+func copyTable(ctx context.Context, pool db.Pool, duck *sql.DB, name string) (TableResult, error) {
+	start := time.Now()
+	schema, table, err := splitTable(name)
+	if err != nil {
+		return TableResult{}, err
+	}
+	pgSchema := schema
+	if pgSchema == "" {
+		pgSchema = "public"
+	}
+	pgName := pgx.Identifier{pgSchema, table}.Sanitize()
+	duckName := pgx.Identifier{table}.Sanitize()
+	if schema != "" {
+		duckName = pgx.Identifier{schema, table}.Sanitize()
+	}
+	res := TableResult{Table: strings.TrimPrefix(schema+"."+table, ".")}
+
+	probe, err := pool.Query(ctx, "SELECT * FROM "+pgName+" LIMIT 0")
+	if err != nil {
+		return res, eris.Wrapf(err, "duckcache: describe %s", res.Table)
+	}
+	fds := probe.FieldDescriptions()
+	probe.Close()
+	if err := probe.Err(); err != nil {
+		return res, eris.Wrapf(err, "duckcache: describe %s", res.Table)
+	}
+
+	cols := make([]column, len(fds))
+	defs := make([]string, len(fds))
+	for i, fd := range fds {
+		cols[i] = columnFor(fd)
+		defs[i] = pgx.Identifier{cols[i].name}.Sanitize() + " " + cols[i].duckType
+	}
+
+	ddl := []string{"DROP TABLE IF EXISTS " + duckName}
+	if schema != "" {
+		ddl = append([]string{"CREATE SCHEMA IF NOT EXISTS " + pgx.Identifier{schema}.Sanitize()}, ddl...)
+	}
+	ddl = append(ddl, fmt.Sprintf("CREATE TABLE %s (%s)", duckName, strings.Join(defs, ", ")))
+	for _, stmt := range ddl {
+		if _, err := duck.ExecContext(ctx, stmt); err != nil {
+			return res, eris.Wrapf(err, "duckcache: create %s", res.Table)
+		}
+	}
+
+	rows, err := pool.Query(ctx, "SELECT "+selectList(cols)+" FROM "+pgName)
+	if err != nil {
+		return res, eris.Wrapf(err, "duckcache: query %s", res.Table)
+	}
+	defer rows.Close()
+
+	conn, err := duck.Conn(ctx)
+	if err != nil {
+		return res, eris.Wrap(err, "duckcache: duckdb conn")
+	}
+	defer conn.Close() //nolint:errcheck
+
+	err = conn.Raw(func(dc any) error {
+		dconn, ok := dc.(driver.Conn)
+		if !ok {
+			return eris.New("duckcache: unexpected driver connection type")
+		}
+		app, err := duckdb.NewAppenderFromConn(dconn, schema, table)
+		if err != nil {
+			return eris.Wrap(err, "duckcache: create appender")
+		}
+
+		vals := make([]driver.Value, len(cols))
+		for rows.Next() {
+			raw, err := rows.Values()
+			if err != nil {
+				_ = app.Close()
+				return eris.Wrap(err, "duckcache: decode row")
+			}
+			for i, v := range raw {
+				vals[i] = duckValue(v)
+			}
+			if err := app.AppendRow(vals...); err != nil {
+				_ = app.Close()
+				return eris.Wrap(err, "duckcache: append row")
+			}
+			res.Rows++
+		}
+		if err := rows.Err(); err != nil {
+			_ = app.Close()
+			return eris.Wrap(err, "duckcache: read rows")
+		}
+		return eris.Wrap(app.Close(), "duckcache: flush appender")
+	})
+	if err != nil {
+		return res, eris.Wrapf(err, "duckcache: copy %s", res.Table)
+	}
+
+	if _, err := duck.ExecContext(ctx,
+		`INSERT OR REPLACE INTO `+MetaTable+` (table_name, rows, snapshot_at) VALUES (?, ?, ?)`,
+		res.Table, res.Rows, time.Now().UTC(),
+	); err != nil {
+		return res, eris.Wrap(err, "duckcache: record snapshot")
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// Open opens a snapshot file read-only for local report queries.
+func Open(path string) (*sql.DB, error) {
+	duck, err := sql.Open("duckdb", path+"?access_mode=read_only")
+	if err != nil {
+		return nil, eris.Wrapf(err, "duckcache: open %s", path)
+	}
+	if err := duck.Ping(); err != nil {
+		_ = duck.Close()
+		return nil, eris.Wrapf(err, "duckcache: open %s", path)
+	}
+	return duck, nil
+}
+
+// Query runs query against the snapshot at path and returns every row with
+// values formatted as text (NULL as "").
+func Query(ctx context.Context, path, query string, args ...any) (*QueryResult, error) {
+	duck, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer duck.Close() //nolint:errcheck
+
+	rows, err := duck.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, eris.Wrap(err, "duckcache: query")
+	}
+	defer rows.Close() //nolint:errcheck
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, eris.Wrap(err, "duckcache: columns")
+	}
+	out := &QueryResult{Columns: cols}
+	vals := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, eris.Wrap(err, "duckcache: scan")
+		}
+		row := make([]string, len(cols))
+		for i, v := range vals {
+			if d, ok := v.(duckdb.Decimal); ok {
+				row[i] = decimalString(d)
+				continue
+			}
+			row[i] = formatValue(v)
+		}
+		out.Rows = append(out.Rows, row)
+	}
+	return out, eris.Wrap(rows.Err(), "duckcache: read rows")
+}
+
+// decimalString renders a DuckDB DECIMAL at its declared scale, matching how
+// Postgres prints NUMERIC.
+func decimalString(d duckdb.Decimal) string {
+	if d.Value == nil {
+		return ""
+	}
+	digits := new(big.Int).Abs(d.Value).String()
+	scale := int(d.Scale)
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if d.Value.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
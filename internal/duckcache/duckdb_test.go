@@ -0,0 +1,189 @@
+//go:build cgo
+
+package duckcache
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcboeker/go-duckdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedSnapshot writes report tables straight into a DuckDB file, standing in
+// for a Snapshot taken from Postgres.
+func seedSnapshot(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snap.duckdb")
+	duck, err := sql.Open("duckdb", path)
+	require.NoError(t, err)
+	defer duck.Close() //nolint:errcheck
+
+	for _, stmt := range []string{
+		`CREATE SCHEMA public`,
+		`CREATE SCHEMA fed_data`,
+		`CREATE SCHEMA geo`,
+		`CREATE TABLE public.cbsa_areas (cbsa_code VARCHAR, name VARCHAR)`,
+		`INSERT INTO public.cbsa_areas VALUES ('12420', 'Austin'), ('19100', 'Dallas')`,
+		`CREATE TABLE public.address_msa (address_id BIGINT, cbsa_code VARCHAR, is_within BOOLEAN, centroid_km DOUBLE, edge_km DOUBLE)`,
+		`INSERT INTO public.address_msa VALUES
+			(1, '12420', true, 10, 0), (2, '12420', true, 20, 0), (3, '12420', false, 60, 5),
+			(4, '19100', true, 5, 0)`,
+		`CREATE TABLE fed_data.county_opportunity_scores (state_fips VARCHAR, target_estabs BIGINT, sf_accounts INTEGER, score DOUBLE)`,
+		`INSERT INTO fed_data.county_opportunity_scores VALUES ('48', 100, 10, 80), ('48', 300, 10, 60), ('06', 50, 0, 40)`,
+		`CREATE TABLE fed_data.edgar_fts_hits (query VARCHAR, accession_number VARCHAR, cik VARCHAR, entity_name VARCHAR, form_type VARCHAR, filing_date DATE, url VARCHAR, first_seen_at TIMESTAMPTZ)`,
+		`INSERT INTO fed_data.edgar_fts_hits VALUES
+			('succession plan', '0000950170-24-000001', '0001364742', 'BlackRock', '8-K', DATE '2024-03-01', NULL, TIMESTAMPTZ '2024-03-04 00:00:00+00'),
+			('succession plan', '0000950170-24-000002', '0000320193', 'Apple', '10-K', DATE '2024-02-01', NULL, TIMESTAMPTZ '2024-03-04 00:00:00+00'),
+			('strategic alternatives', '0000950170-24-000003', '0000320193', 'Apple', '8-K', DATE '2024-01-15', NULL, TIMESTAMPTZ '2024-03-04 00:00:00+00')`,
+		`CREATE TABLE fed_data.entity_xref (crd_number INTEGER, cik VARCHAR)`,
+		`INSERT INTO fed_data.entity_xref VALUES (105247, '0001364742'), (99, '0001364742')`,
+		`CREATE TABLE geo.storm_events (event_id BIGINT, year SMALLINT, cz_type VARCHAR, county_fips VARCHAR, event_type VARCHAR, injuries INTEGER, deaths INTEGER, damage_property DOUBLE, damage_crops DOUBLE)`,
+		// Hurricanes are zone-coded; their county comes from the NWS
+		// zone-county correlation, and one in a multi-county zone without
+		// a location stays untagged.
+		`INSERT INTO geo.storm_events VALUES
+			(1, 2023, 'C', '48453', 'Hail', 2, 0, 25000, 0), (2, 2024, 'C', '48453', 'Flash Flood', 0, 1, 100000, 5000),
+			(3, 2024, 'Z', '12075', 'Hurricane (Typhoon)', 0, 0, 2000000, 0), (4, 2024, 'M', NULL, 'Marine Thunderstorm Wind', 0, 0, 0, 0),
+			(5, 2024, 'Z', NULL, 'Hurricane (Typhoon)', 0, 0, 500000, 0)`,
+		`CREATE TABLE geo.climate_normals (station_id VARCHAR, county_fips VARCHAR, tavg_f DOUBLE, prcp_in DOUBLE, snow_in DOUBLE)`,
+		`INSERT INTO geo.climate_normals VALUES ('A', '48453', 69.0, 33.0, 0.5), ('B', '48453', 70.0, 35.0, 0.1)`,
+		`CREATE TABLE geo.hud_fmr (geo_level VARCHAR, fips VARCHAR, county_fips VARCHAR, county_name VARCHAR, year INTEGER, fmr_1br INTEGER, fmr_2br INTEGER, median_family_income INTEGER, il_low_4p INTEGER)`,
+		`INSERT INTO geo.hud_fmr VALUES
+			('county', '4845399999', '48453', 'Travis County', 2026, 1400, 1700, 120000, 96000),
+			('county', '4845399999', '48453', 'Travis County', 2025, 1300, 1600, 110000, 88000),
+			('county', '4820199999', '48201', 'Harris County', 2026, 1100, 1350, 90000, 72000),
+			('county', '0100199999', '01001', 'Autauga County', 2026, 870, 1016, NULL, NULL),
+			('zip', '78701', NULL, NULL, 2026, 1880, 2250, NULL, NULL)`,
+	} {
+		_, err := duck.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+	return path
+}
+
+func TestReportRun_Snapshot(t *testing.T) {
+	path := seedSnapshot(t)
+	ctx := context.Background()
+
+	msa, err := LookupReport("msa")
+	require.NoError(t, err)
+	res, err := msa.Run(ctx, nil, path, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cbsa_code", "name", "within", "nearby", "avg_centroid_km", "avg_edge_km_nearby"}, res.Columns)
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, []string{"12420", "Austin", "2", "1", "30.0", "5.0"}, res.Rows[0])
+	assert.Equal(t, "", res.Rows[1][5])
+
+	res, err = msa.Run(ctx, nil, path, "19100")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, "Dallas", res.Rows[0][1])
+
+	bench, err := LookupReport("benchmarks")
+	require.NoError(t, err)
+	res, err = bench.Run(ctx, nil, path, "")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, []string{"48", "2", "400", "20", "0.0500", "70.00", "70.00", "80"}, res.Rows[0])
+
+	fts, err := LookupReport("edgar_fts")
+	require.NoError(t, err)
+	res, err = fts.Run(ctx, nil, path, "succession plan")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, []string{"succession plan", "2024-03-01", "8-K", "0001364742", "BlackRock", "99"}, res.Rows[0][:6])
+	assert.Equal(t, "", res.Rows[1][5], "no linked CRD")
+
+	risk, err := LookupReport("physical_risk")
+	require.NoError(t, err)
+	res, err = risk.Run(ctx, nil, path, "")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2, "events without a county are excluded")
+	assert.Equal(t, "12075", res.Rows[0][0], "ordered by damages")
+	assert.Equal(t, "1", res.Rows[0][8], "tropical")
+
+	res, err = risk.Run(ctx, nil, path, "48")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, []string{"48453", "2023", "2024", "2", "1", "1", "0", "0", "0", "1", "2", "130000", "69.5", "34.00", "0.3"}, res.Rows[0])
+
+	col, err := LookupReport("cost_of_living")
+	require.NoError(t, err)
+	res, err = col.Run(ctx, nil, path, "")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 3, "latest year county rows only")
+	assert.Equal(t, []string{"48201", "Harris County", "2026", "1100", "1350", "90000", "72000", "0.180"}, res.Rows[0])
+	assert.Equal(t, "48453", res.Rows[1][0])
+	assert.Equal(t, "0.170", res.Rows[1][7])
+	assert.Equal(t, "", res.Rows[2][7], "no income limits sorts last")
+
+	res, err = col.Run(ctx, nil, path, "48453")
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, "1400", res.Rows[0][3])
+}
+
+func TestOpen_MissingFile(t *testing.T) {
+	_, err := Query(context.Background(), filepath.Join(t.TempDir(), "missing.duckdb"), "SELECT 1")
+	assert.Error(t, err)
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		value int64
+		scale uint8
+		want  string
+	}{
+		{300, 1, "30.0"},
+		{50, 3, "0.050"},
+		{-5, 2, "-0.05"},
+		{7000, 2, "70.00"},
+		{42, 0, "42"},
+	}
+	for _, tt := range tests {
+		got := decimalString(duckdb.Decimal{Width: 18, Scale: tt.scale, Value: big.NewInt(tt.value)})
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	duck, err := sql.Open("duckdb", filepath.Join(dir, "stage.duckdb"))
+	require.NoError(t, err)
+	defer duck.Close() //nolint:errcheck
+
+	for _, stmt := range []string{
+		`CREATE SCHEMA fed_data`,
+		`CREATE TABLE fed_data.cbp_data (year SMALLINT, fips_state VARCHAR, naics VARCHAR, emp INTEGER)`,
+		`INSERT INTO fed_data.cbp_data VALUES (2021, '06', '523110', 10), (2021, '48', '523110', 20), (2022, '06', '523110', 30)`,
+	} {
+		_, err := duck.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+
+	out := filepath.Join(dir, "export")
+	res, err := writeParquet(ctx, duck, ExportOptions{Table: "fed_data.cbp_data", Dest: out})
+	require.NoError(t, err)
+	assert.Equal(t, "fed_data.cbp_data", res.Table)
+	assert.Equal(t, []string{"year"}, res.PartitionBy)
+	assert.Equal(t, out+"/cbp_data", res.Dest)
+
+	var n int64
+	var emp int64
+	err = duck.QueryRow(`SELECT count(*), sum(emp) FROM read_parquet('` + out + `/cbp_data/year=2021/*.parquet')`).Scan(&n, &emp)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, int64(30), emp)
+
+	res, err = writeParquet(ctx, duck, ExportOptions{Table: "fed_data.cbp_data", Dest: out, PartitionBy: []string{}})
+	require.NoError(t, err)
+	err = duck.QueryRow(`SELECT count(*) FROM read_parquet('` + res.Dest + `')`).Scan(&n)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
@@ -0,0 +1,113 @@
+package duckcache
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	Table string // schema-qualified Postgres table, e.g. "fed_data.cbp_data"
+	Dest  string // local directory or s3://bucket/prefix
+
+	// PartitionBy lists the columns to hive-partition by (dest/table/year=2021/...).
+	// Nil partitions by year when the table has a year column; an empty,
+	// non-nil slice writes a single file.
+	PartitionBy []string
+
+	TempDir string // where the staging DuckDB file is written; "" for the OS default
+	S3      S3Options
+}
+
+// S3Options holds credentials for s3:// destinations. Empty fields fall
+// back to DuckDB's defaults (the standard AWS_* environment variables).
+type S3Options struct {
+	Region          string
+	Endpoint        string // host[:port] of an S3-compatible store (MinIO, R2); "" for AWS
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// ExportResult reports one exported table.
+type ExportResult struct {
+	Table       string        `json:"table"`
+	Rows        int64         `json:"rows"`
+	Dest        string        `json:"dest"`
+	PartitionBy []string      `json:"partition_by,omitempty"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// isS3 reports whether dest is an S3 URL.
+func isS3(dest string) bool {
+	return strings.HasPrefix(dest, "s3://")
+}
+
+// exportPartitions resolves opts.PartitionBy against the table's columns.
+func exportPartitions(partitionBy, columns []string) ([]string, error) {
+	if partitionBy == nil {
+		if slices.Contains(columns, "year") {
+			return []string{"year"}, nil
+		}
+		return nil, nil
+	}
+	for _, c := range partitionBy {
+		if !slices.Contains(columns, c) {
+			return nil, eris.Errorf("duckcache: partition column %q not in table", c)
+		}
+	}
+	return partitionBy, nil
+}
+
+// exportTarget returns where a table's Parquet lands under dest: a
+// directory of partitions, or a single file.
+func exportTarget(dest, table string, partitioned bool) string {
+	target := strings.TrimRight(dest, "/") + "/" + table
+	if !partitioned {
+		target += ".parquet"
+	}
+	return target
+}
+
+// copyStatement builds the DuckDB COPY that writes duckName to target as
+// zstd-compressed Parquet, hive-partitioned by partitions.
+func copyStatement(duckName, target string, partitions []string) string {
+	opts := "FORMAT PARQUET, COMPRESSION ZSTD"
+	if len(partitions) > 0 {
+		cols := make([]string, len(partitions))
+		for i, p := range partitions {
+			cols[i] = pgx.Identifier{p}.Sanitize()
+		}
+		opts += ", PARTITION_BY (" + strings.Join(cols, ", ") + "), OVERWRITE_OR_IGNORE"
+	}
+	return "COPY " + duckName + " TO " + sqlString(target) + " (" + opts + ")"
+}
+
+// s3SecretStatement builds the DuckDB CREATE SECRET for o, leaving unset
+// fields to DuckDB's defaults.
+func s3SecretStatement(o S3Options) string {
+	parts := []string{"TYPE S3"}
+	add := func(key, val string) {
+		if val != "" {
+			parts = append(parts, key+" "+sqlString(val))
+		}
+	}
+	add("REGION", o.Region)
+	add("ENDPOINT", o.Endpoint)
+	add("KEY_ID", o.AccessKeyID)
+	add("SECRET", o.SecretAccessKey)
+	add("SESSION_TOKEN", o.SessionToken)
+	if o.Endpoint != "" {
+		parts = append(parts, "URL_STYLE 'path'")
+	}
+	return "CREATE OR REPLACE TEMPORARY SECRET export_s3 (" + strings.Join(parts, ", ") + ")"
+}
+
+// sqlString quotes s as a SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
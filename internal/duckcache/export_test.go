@@ -0,0 +1,56 @@
+package duckcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPartitions(t *testing.T) {
+	cols := []string{"year", "fips_state", "naics"}
+
+	got, err := exportPartitions(nil, cols)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"year"}, got, "year is the default partition")
+
+	got, err = exportPartitions(nil, []string{"cik", "period"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = exportPartitions([]string{}, cols)
+	require.NoError(t, err)
+	assert.Empty(t, got, "empty slice disables partitioning")
+
+	got, err = exportPartitions([]string{"year", "fips_state"}, cols)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"year", "fips_state"}, got)
+
+	_, err = exportPartitions([]string{"qtr"}, cols)
+	assert.Error(t, err)
+}
+
+func TestExportTarget(t *testing.T) {
+	assert.Equal(t, "s3://bucket/fed/cbp_data", exportTarget("s3://bucket/fed/", "cbp_data", true))
+	assert.Equal(t, "/tmp/out/f13_holdings.parquet", exportTarget("/tmp/out", "f13_holdings", false))
+}
+
+func TestCopyStatement(t *testing.T) {
+	assert.Equal(t,
+		`COPY "fed_data"."cbp_data" TO 's3://b/cbp_data' (FORMAT PARQUET, COMPRESSION ZSTD, PARTITION_BY ("year"), OVERWRITE_OR_IGNORE)`,
+		copyStatement(`"fed_data"."cbp_data"`, "s3://b/cbp_data", []string{"year"}))
+	assert.Equal(t,
+		`COPY "t" TO '/out/it''s.parquet' (FORMAT PARQUET, COMPRESSION ZSTD)`,
+		copyStatement(`"t"`, "/out/it's.parquet", nil))
+}
+
+func TestS3SecretStatement(t *testing.T) {
+	assert.Equal(t,
+		"CREATE OR REPLACE TEMPORARY SECRET export_s3 (TYPE S3, REGION 'us-east-1')",
+		s3SecretStatement(S3Options{Region: "us-east-1"}))
+	assert.Equal(t,
+		"CREATE OR REPLACE TEMPORARY SECRET export_s3 (TYPE S3, ENDPOINT 'minio:9000', KEY_ID 'k', SECRET 's', URL_STYLE 'path')",
+		s3SecretStatement(S3Options{Endpoint: "minio:9000", AccessKeyID: "k", SecretAccessKey: "s"}))
+	assert.True(t, isS3("s3://bucket"))
+	assert.False(t, isS3("/data/export"))
+}
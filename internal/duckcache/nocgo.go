@@ -0,0 +1,30 @@
+//go:build !cgo
+
+package duckcache
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// Snapshot is unavailable without cgo.
+func Snapshot(_ context.Context, _ db.Pool, _ string, _ []string) ([]TableResult, error) {
+	return nil, ErrUnsupported
+}
+
+// Export is unavailable without cgo.
+func Export(_ context.Context, _ db.Pool, _ ExportOptions) (ExportResult, error) {
+	return ExportResult{}, ErrUnsupported
+}
+
+// Open is unavailable without cgo.
+func Open(_ string) (*sql.DB, error) {
+	return nil, ErrUnsupported
+}
+
+// Query is unavailable without cgo.
+func Query(_ context.Context, _, _ string, _ ...any) (*QueryResult, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,233 @@
+package duckcache
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// Report is a named aggregation that runs unchanged against the warehouse or
+// a DuckDB snapshot. SQL must stay within the dialect both engines share and
+// takes a single $1 filter argument ("" for no filter). Fixed-scale numeric
+// casts keep output identical across engines.
+type Report struct {
+	Name        string
+	Description string
+	Tables      []string
+	SQL         string
+}
+
+var reports = map[string]Report{
+	"msa": {
+		Name:        "msa",
+		Description: "Company coverage per MSA (addresses within vs. near each CBSA)",
+		Tables:      []string{"public.cbsa_areas", "public.address_msa"},
+		SQL: `SELECT m.cbsa_code, c.name,
+	COUNT(*) FILTER (WHERE m.is_within) AS within,
+	COUNT(*) FILTER (WHERE NOT m.is_within) AS nearby,
+	AVG(m.centroid_km)::numeric(10,1) AS avg_centroid_km,
+	(AVG(m.edge_km) FILTER (WHERE NOT m.is_within))::numeric(10,1) AS avg_edge_km_nearby
+FROM public.address_msa m
+JOIN public.cbsa_areas c ON c.cbsa_code = m.cbsa_code
+WHERE $1 = '' OR m.cbsa_code = $1
+GROUP BY m.cbsa_code, c.name
+ORDER BY within DESC, m.cbsa_code`,
+	},
+	"benchmarks": {
+		Name:        "benchmarks",
+		Description: "State benchmarks from county opportunity scores",
+		Tables:      []string{"fed_data.county_opportunity_scores"},
+		SQL: `SELECT state_fips,
+	COUNT(*) AS counties,
+	SUM(target_estabs) AS target_estabs,
+	SUM(sf_accounts) AS sf_accounts,
+	(SUM(sf_accounts)::float8 / NULLIF(SUM(target_estabs), 0))::numeric(10,4) AS coverage_ratio,
+	AVG(score)::numeric(6,2) AS avg_score,
+	(percentile_cont(0.5) WITHIN GROUP (ORDER BY score))::numeric(6,2) AS median_score,
+	MAX(score) AS max_score
+FROM fed_data.county_opportunity_scores
+WHERE $1 = '' OR state_fips = $1
+GROUP BY state_fips
+ORDER BY avg_score DESC, state_fips`,
+	},
+	"physical_risk": {
+		Name:        "physical_risk",
+		Description: "County storm history (NOAA Storm Events) with 1991-2020 climate normals; filter by state or county FIPS",
+		Tables:      []string{"geo.storm_events", "geo.climate_normals"},
+		SQL: `SELECT s.county_fips,
+	MIN(s.year) AS first_year,
+	MAX(s.year) AS last_year,
+	COUNT(*) AS events,
+	COUNT(*) FILTER (WHERE s.event_type ILIKE '%flood%') AS floods,
+	COUNT(*) FILTER (WHERE s.event_type = 'Hail') AS hail,
+	COUNT(*) FILTER (WHERE s.event_type = 'Tornado') AS tornadoes,
+	COUNT(*) FILTER (WHERE s.event_type ILIKE '%wind%') AS wind,
+	COUNT(*) FILTER (WHERE s.event_type IN ('Hurricane (Typhoon)', 'Tropical Storm', 'Storm Surge/Tide')) AS tropical,
+	SUM(s.deaths) AS deaths,
+	SUM(s.injuries) AS injuries,
+	SUM(s.damage_property + s.damage_crops)::numeric(18,0) AS damages,
+	MAX(n.tavg_f)::numeric(6,1) AS normal_tavg_f,
+	MAX(n.prcp_in)::numeric(6,2) AS normal_prcp_in,
+	MAX(n.snow_in)::numeric(6,1) AS normal_snow_in
+FROM geo.storm_events s
+LEFT JOIN (
+	SELECT county_fips, AVG(tavg_f) AS tavg_f, AVG(prcp_in) AS prcp_in, AVG(snow_in) AS snow_in
+	FROM geo.climate_normals
+	WHERE county_fips IS NOT NULL
+	GROUP BY county_fips
+) n ON n.county_fips = s.county_fips
+WHERE s.county_fips IS NOT NULL
+	AND ($1 = '' OR s.county_fips = $1 OR LEFT(s.county_fips, 2) = $1)
+GROUP BY s.county_fips
+ORDER BY damages DESC, s.county_fips`,
+	},
+	"cost_of_living": {
+		Name:        "cost_of_living",
+		Description: "County HUD Fair Market Rents and four-person income limits for the latest fiscal year; filter by state or county FIPS",
+		Tables:      []string{"geo.hud_fmr"},
+		SQL: `SELECT county_fips,
+	MIN(county_name) AS county_name,
+	MAX(year) AS year,
+	AVG(fmr_1br)::numeric(10,0) AS fmr_1br,
+	AVG(fmr_2br)::numeric(10,0) AS fmr_2br,
+	AVG(median_family_income)::numeric(10,0) AS median_family_income,
+	AVG(il_low_4p)::numeric(10,0) AS il_low_4p,
+	(AVG(fmr_2br) * 12 / NULLIF(AVG(median_family_income), 0))::numeric(6,3) AS rent_to_income
+FROM geo.hud_fmr
+WHERE geo_level = 'county' AND county_fips IS NOT NULL
+	AND year = (SELECT MAX(year) FROM geo.hud_fmr WHERE geo_level = 'county')
+	AND ($1 = '' OR county_fips = $1 OR LEFT(county_fips, 2) = $1)
+GROUP BY county_fips
+ORDER BY rent_to_income DESC NULLS LAST, county_fips`,
+	},
+	"edgar_fts": {
+		Name:        "edgar_fts",
+		Description: "EDGAR full-text search hits per monitored query, newest first, with linked CRD",
+		Tables:      []string{"fed_data.edgar_fts_hits", "fed_data.entity_xref"},
+		SQL: `SELECT h.query, h.filing_date, h.form_type, h.cik, h.entity_name,
+	(SELECT MIN(x.crd_number) FROM fed_data.entity_xref x WHERE x.cik = h.cik) AS crd_number,
+	h.accession_number, h.url, h.first_seen_at
+FROM fed_data.edgar_fts_hits h
+WHERE $1 = '' OR h.query = $1
+ORDER BY h.filing_date DESC, h.query, h.cik, h.accession_number`,
+	},
+}
+
+// LookupReport returns the named report.
+func LookupReport(name string) (Report, error) {
+	r, ok := reports[name]
+	if !ok {
+		return Report{}, eris.Errorf("duckcache: unknown report %q (available: %v)", name, ReportNames())
+	}
+	return r, nil
+}
+
+// ReportNames lists the registered reports in sorted order.
+func ReportNames() []string {
+	names := make([]string, 0, len(reports))
+	for name := range reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReportTables returns the deduplicated tables the named reports read, in
+// first-seen order, followed by any extra tables.
+func ReportTables(names []string, extra []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var tables []string
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			tables = append(tables, t)
+		}
+	}
+	for _, name := range names {
+		r, err := LookupReport(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range r.Tables {
+			add(t)
+		}
+	}
+	for _, t := range extra {
+		if _, _, err := splitTable(t); err != nil {
+			return nil, err
+		}
+		add(t)
+	}
+	return tables, nil
+}
+
+// Run executes r against the snapshot at path, or against pool when path is
+// empty.
+func (r Report) Run(ctx context.Context, pool db.Pool, path, filter string) (*QueryResult, error) {
+	if path != "" {
+		return Query(ctx, path, r.SQL, filter)
+	}
+	if pool == nil {
+		return nil, eris.New("duckcache: no database pool or snapshot path")
+	}
+	return queryPool(ctx, pool, r.SQL, filter)
+}
+
+// queryPool runs query against Postgres and formats rows like Query.
+func queryPool(ctx context.Context, pool db.Pool, query string, args ...any) (*QueryResult, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, eris.Wrap(err, "duckcache: query warehouse")
+	}
+	defer rows.Close()
+
+	fds := rows.FieldDescriptions()
+	out := &QueryResult{Columns: make([]string, len(fds))}
+	for i, fd := range fds {
+		out.Columns[i] = fd.Name
+	}
+	for rows.Next() {
+		vals, err := rows.Values()
+		if err != nil {
+			return nil, eris.Wrap(err, "duckcache: decode row")
+		}
+		row := make([]string, len(vals))
+		for i, v := range vals {
+			row[i] = formatValue(v)
+		}
+		out.Rows = append(out.Rows, row)
+	}
+	return out, eris.Wrap(rows.Err(), "duckcache: read rows")
+}
+
+// formatValue renders a scanned value as report text (NULL as "").
+func formatValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if x.Hour() == 0 && x.Minute() == 0 && x.Second() == 0 && x.Nanosecond() == 0 {
+			return x.Format("2006-01-02")
+		}
+		return x.Format(time.RFC3339)
+	case []byte:
+		return string(x)
+	case driver.Valuer:
+		// pgtype.Numeric and friends render via their driver value.
+		dv, err := x.Value()
+		if err != nil {
+			return ""
+		}
+		return formatValue(dv)
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -91,6 +91,24 @@ func (e *Extractor) RunAdvisor(ctx context.Context, crd int) error {
 		return eris.Wrapf(err, "advextract: load funds %d", crd)
 	}
 
+	enforcement, err := e.store.LoadEnforcement(ctx, crd)
+	if err != nil {
+		return eris.Wrapf(err, "advextract: load enforcement %d", crd)
+	}
+	advisor.Enforcement = enforcement
+
+	arbitration, err := e.store.LoadArbitration(ctx, crd)
+	if err != nil {
+		return eris.Wrapf(err, "advextract: load arbitration %d", crd)
+	}
+	advisor.Arbitration = arbitration
+
+	insurance, err := e.store.LoadInsurance(ctx, crd)
+	if err != nil {
+		return eris.Wrapf(err, "advextract: load insurance %d", crd)
+	}
+	advisor.Insurance = insurance
+
 	// Assemble documents.
 	docs := AssembleDocs(advisor, brochures, crs, owners, funds)
 
@@ -99,6 +117,7 @@ func (e *Extractor) RunAdvisor(ctx context.Context, crd int) error {
 		zap.Bool("has_crs", len(crs) > 0),
 		zap.Int("owners", len(owners)),
 		zap.Int("funds", len(funds)),
+		zap.Int("enforcement_actions", len(enforcement)),
 	)
 
 	// Write section index for document coverage tracking.
@@ -141,7 +160,7 @@ func (e *Extractor) RunAdvisor(ctx context.Context, crd int) error {
 
 	// Fund-level extraction.
 	if len(docs.Funds) > 0 {
-		fundAnswers, fundErr := ExtractFunds(ctx, docs, e.client, e.store, runID, e.maxTier, e.costTracker)
+		fundAnswers, fundErr := ExtractFunds(ctx, docs, allAnswers, e.client, e.store, runID, e.maxTier, e.costTracker)
 		if fundErr != nil {
 			log.Warn("fund extraction had errors", zap.Error(fundErr))
 		}
@@ -227,30 +246,17 @@ func (e *Extractor) extractAdvisor(ctx context.Context, docs *AdvisorDocs, runID
 		t1Qs := filterByTier(llmQuestions, 1)
 		if len(t1Qs) > 0 {
 			systemText := T1SystemPrompt(docs)
-			items := buildBatchItems(t1Qs, docs, systemText, 1)
-
-			// Fire primer async.
-			primerDone := make(chan struct{})
-			var primerIn, primerOut int64
-			go func() {
-				primerIn, primerOut = firePrimer(ctx, e.client, items)
-				close(primerDone)
-			}()
-
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 1, e.client)
-			<-primerDone
-			totalInput += primerIn + inputTok
-			totalOutput += primerOut + outputTok
-
+			answers, inputTok, outputTok, err := executePlanned(ctx, e.client, t1Qs, allAnswers, docs, systemText, 1, true)
+			totalInput += inputTok
+			totalOutput += outputTok
+			for i := range answers {
+				answers[i].CRDNumber = docs.CRDNumber
+				answers[i].RunID = runID
+			}
+			allAnswers = append(allAnswers, answers...)
+			e.costTracker.RecordUsage(docs.CRDNumber, 1, inputTok, outputTok, 0, 0)
 			if err != nil {
 				log.Warn("T1 extraction failed", zap.Error(err))
-			} else {
-				for i := range answers {
-					answers[i].CRDNumber = docs.CRDNumber
-					answers[i].RunID = runID
-				}
-				allAnswers = append(allAnswers, answers...)
-				e.costTracker.RecordUsage(docs.CRDNumber, 1, inputTok, outputTok, 0, 0)
 			}
 
 			log.Info("T1 complete", zap.Int("answers", len(answers)))
@@ -270,31 +276,18 @@ func (e *Extractor) extractAdvisor(ctx context.Context, docs *AdvisorDocs, runID
 
 		if len(t2Qs) > 0 {
 			systemText := T2SystemPrompt(docs, allAnswers)
-			items := buildBatchItems(t2Qs, docs, systemText, 2)
-
-			// Fire primer async.
-			primerDone := make(chan struct{})
-			var primerIn, primerOut int64
-			go func() {
-				primerIn, primerOut = firePrimer(ctx, e.client, items)
-				close(primerDone)
-			}()
-
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 2, e.client)
-			<-primerDone
-			totalInput += primerIn + inputTok
-			totalOutput += primerOut + outputTok
-
+			answers, inputTok, outputTok, err := executePlanned(ctx, e.client, t2Qs, allAnswers, docs, systemText, 2, true)
+			totalInput += inputTok
+			totalOutput += outputTok
+			for i := range answers {
+				answers[i].CRDNumber = docs.CRDNumber
+				answers[i].RunID = runID
+			}
+			// Merge: T2 answers supersede T1 for same question key.
+			allAnswers = mergeAnswers(allAnswers, answers)
+			e.costTracker.RecordUsage(docs.CRDNumber, 2, inputTok, outputTok, 0, 0)
 			if err != nil {
 				log.Warn("T2 extraction failed", zap.Error(err))
-			} else {
-				for i := range answers {
-					answers[i].CRDNumber = docs.CRDNumber
-					answers[i].RunID = runID
-				}
-				// Merge: T2 answers supersede T1 for same question key.
-				allAnswers = mergeAnswers(allAnswers, answers)
-				e.costTracker.RecordUsage(docs.CRDNumber, 2, inputTok, outputTok, 0, 0)
 			}
 
 			log.Info("T2 complete", zap.Int("answers", len(answers)))
@@ -311,34 +304,17 @@ func (e *Extractor) extractAdvisor(ctx context.Context, docs *AdvisorDocs, runID
 		t3Qs := filterByTier(llmQuestions, 3)
 		if len(t3Qs) > 0 {
 			systemText := T3SystemPrompt(docs, allAnswers)
-			items := buildBatchItems(t3Qs, docs, systemText, 3)
-
-			// Fire primer async for large batches.
-			primerDone := make(chan struct{})
-			var primerIn, primerOut int64
-			if len(items) >= 3 {
-				go func() {
-					primerIn, primerOut = firePrimer(ctx, e.client, items)
-					close(primerDone)
-				}()
-			} else {
-				close(primerDone)
+			answers, inputTok, outputTok, err := executePlanned(ctx, e.client, t3Qs, allAnswers, docs, systemText, 3, true)
+			totalInput += inputTok
+			totalOutput += outputTok
+			for i := range answers {
+				answers[i].CRDNumber = docs.CRDNumber
+				answers[i].RunID = runID
 			}
-
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 3, e.client)
-			<-primerDone
-			totalInput += primerIn + inputTok
-			totalOutput += primerOut + outputTok
-
+			allAnswers = mergeAnswers(allAnswers, answers)
+			e.costTracker.RecordUsage(docs.CRDNumber, 3, inputTok, outputTok, 0, 0)
 			if err != nil {
 				log.Warn("T3 extraction failed", zap.Error(err))
-			} else {
-				for i := range answers {
-					answers[i].CRDNumber = docs.CRDNumber
-					answers[i].RunID = runID
-				}
-				allAnswers = mergeAnswers(allAnswers, answers)
-				e.costTracker.RecordUsage(docs.CRDNumber, 3, inputTok, outputTok, 0, 0)
 			}
 
 			log.Info("T3 complete", zap.Int("answers", len(answers)))
@@ -194,8 +194,10 @@ func executeBatchAPI(ctx context.Context, items []batchItem, tier int, client an
 	return answers, totalInput, totalOutput, nil
 }
 
-// buildBatchItems constructs batch items for a set of questions.
-func buildBatchItems(questions []Question, docs *AdvisorDocs, systemText string, tier int) []batchItem {
+// buildBatchItems constructs batch items for a set of questions. Answers in
+// known listed in a question's ContextKeys are appended to its document
+// context.
+func buildBatchItems(questions []Question, docs *AdvisorDocs, systemText string, tier int, known map[string]Answer) []batchItem {
 	model := ModelForTier(tier)
 	maxTokens := MaxTokensForTier(tier)
 	system := anthropic.BuildCachedSystemBlocks(systemText)
@@ -207,6 +209,10 @@ func buildBatchItems(questions []Question, docs *AdvisorDocs, systemText string,
 			continue // no documents available for this question
 		}
 
+		if dep := dependencyContext(q, known); dep != "" {
+			docCtx += "\n\n" + dep
+		}
+
 		userMsg := BuildUserMessage(q, docCtx)
 
 		items = append(items, batchItem{
@@ -222,6 +222,45 @@ func FormatPart1Structured(a *AdvisorRow) string {
 		}
 	}
 
+	if len(a.Enforcement) > 0 {
+		sb.WriteString("\n--- SEC Enforcement Actions ---\n")
+		for _, e := range a.Enforcement {
+			fmt.Fprintf(&sb, "  %s (%s)", e.ActionID, strings.ReplaceAll(e.ActionType, "_", " "))
+			if e.ActionDate != nil {
+				fmt.Fprintf(&sb, " %s", e.ActionDate.Format("2006-01-02"))
+			}
+			if e.Outcome != "" {
+				fmt.Fprintf(&sb, ", outcome: %s", e.Outcome)
+			}
+			if e.PenaltyAmount != nil {
+				fmt.Fprintf(&sb, ", penalty: $%s", formatDollars(*e.PenaltyAmount))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if arb := a.Arbitration; arb != nil {
+		sb.WriteString("\n--- FINRA Arbitration Awards ---\n")
+		fmt.Fprintf(&sb, "  Awards Against Firm: %d (%d in last 5 years)\n", arb.Awards, arb.Recent5y)
+		if arb.TotalAmount > 0 {
+			fmt.Fprintf(&sb, "  Total Awarded: $%s\n", formatDollars(arb.TotalAmount))
+			fmt.Fprintf(&sb, "  Largest Award: $%s\n", formatDollars(arb.LargestAmount))
+		}
+		if arb.LastAwardDate != nil {
+			fmt.Fprintf(&sb, "  Most Recent Award: %s\n", arb.LastAwardDate.Format("2006-01-02"))
+		}
+	}
+
+	if in := a.Insurance; in != nil {
+		sb.WriteString("\n--- State Insurance Licenses ---\n")
+		fmt.Fprintf(&sb, "  Licensed Insurance Producers: %d\n", in.Producers)
+		fmt.Fprintf(&sb, "  Agency Licenses: %d\n", in.AgencyLicenses)
+		fmt.Fprintf(&sb, "  States: %d\n", in.States)
+		if len(in.LinesOfAuthority) > 0 {
+			fmt.Fprintf(&sb, "  Lines of Authority: %s\n", strings.Join(in.LinesOfAuthority, ", "))
+		}
+	}
+
 	return sb.String()
 }
 
@@ -101,6 +101,57 @@ func TestFormatPart1Structured_Minimal(t *testing.T) {
 	require.NotContains(t, out, "--- Compensation Types ---")
 }
 
+func TestFormatPart1Structured_Enforcement(t *testing.T) {
+	a := testAdvisorMinimal()
+	date := time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC)
+	a.Enforcement = []EnforcementRow{{
+		ActionID:      "IA-6612",
+		ActionType:    "administrative_proceeding",
+		ActionDate:    &date,
+		Outcome:       "cease_and_desist",
+		PenaltyAmount: ptr(int64(125000)),
+	}}
+
+	out := FormatPart1Structured(a)
+	require.Contains(t, out, "--- SEC Enforcement Actions ---")
+	require.Contains(t, out, "IA-6612 (administrative proceeding) 2026-04-16, outcome: cease_and_desist, penalty: $")
+}
+
+func TestFormatPart1Structured_Arbitration(t *testing.T) {
+	a := testAdvisorMinimal()
+	date := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	a.Arbitration = &ArbitrationSummary{
+		Awards:        3,
+		Recent5y:      2,
+		TotalAmount:   1450000,
+		LargestAmount: 1200000,
+		LastAwardDate: &date,
+	}
+
+	out := FormatPart1Structured(a)
+	require.Contains(t, out, "--- FINRA Arbitration Awards ---")
+	require.Contains(t, out, "Awards Against Firm: 3 (2 in last 5 years)")
+	require.Contains(t, out, "Total Awarded: $1,450,000")
+	require.Contains(t, out, "Largest Award: $1,200,000")
+	require.Contains(t, out, "Most Recent Award: 2026-02-03")
+}
+
+func TestFormatPart1Structured_Insurance(t *testing.T) {
+	a := testAdvisorMinimal()
+	a.Insurance = &InsuranceSummary{
+		Producers:        7,
+		AgencyLicenses:   2,
+		States:           3,
+		LinesOfAuthority: []string{"accident_health", "life"},
+	}
+
+	out := FormatPart1Structured(a)
+	require.Contains(t, out, "--- State Insurance Licenses ---")
+	require.Contains(t, out, "Licensed Insurance Producers: 7")
+	require.Contains(t, out, "Agency Licenses: 2")
+	require.Contains(t, out, "Lines of Authority: accident_health, life")
+}
+
 func TestFormatPart1Structured_Full(t *testing.T) {
 	out := FormatPart1Structured(testAdvisorFull())
 
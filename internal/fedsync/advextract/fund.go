@@ -15,7 +15,9 @@ import (
 const maxFundConcurrency = 5
 
 // ExtractFunds runs fund-level extraction for all funds of an advisor.
-func ExtractFunds(ctx context.Context, docs *AdvisorDocs, client anthropic.Client, store *Store, runID int64, maxTier int, costTracker *CostTracker) ([]Answer, error) {
+// advisorAnswers seed question prerequisites and context (e.g.
+// total_fund_count); nil is fine when advisor extraction was skipped.
+func ExtractFunds(ctx context.Context, docs *AdvisorDocs, advisorAnswers []Answer, client anthropic.Client, store *Store, runID int64, maxTier int, costTracker *CostTracker) ([]Answer, error) {
 	if len(docs.Funds) == 0 {
 		return nil, nil
 	}
@@ -42,7 +44,7 @@ func ExtractFunds(ctx context.Context, docs *AdvisorDocs, client anthropic.Clien
 
 	for _, fund := range docs.Funds {
 		g.Go(func() error {
-			answers, err := extractSingleFund(gctx, docs, fund, fundQuestions, client, maxTier, costTracker)
+			answers, err := extractSingleFund(gctx, docs, fund, fundQuestions, advisorAnswers, client, maxTier, costTracker)
 			if err != nil {
 				log.Warn("fund extraction failed",
 					zap.String("fund_id", fund.FundID),
@@ -82,7 +84,7 @@ func ExtractFunds(ctx context.Context, docs *AdvisorDocs, client anthropic.Clien
 }
 
 // extractSingleFund extracts answers for one fund.
-func extractSingleFund(ctx context.Context, docs *AdvisorDocs, fund FundRow, questions []Question, client anthropic.Client, maxTier int, costTracker *CostTracker) ([]Answer, error) {
+func extractSingleFund(ctx context.Context, docs *AdvisorDocs, fund FundRow, questions []Question, advisorAnswers []Answer, client anthropic.Client, maxTier int, costTracker *CostTracker) ([]Answer, error) {
 	log := zap.L().With(
 		zap.Int("crd", docs.CRDNumber),
 		zap.String("fund_id", fund.FundID),
@@ -119,15 +121,14 @@ func extractSingleFund(ctx context.Context, docs *AdvisorDocs, fund FundRow, que
 		t1Qs := filterByTier(llmQuestions, 1)
 		if len(t1Qs) > 0 {
 			systemText := T1SystemPrompt(docs) + "\n\n" + fundCtx
-			items := buildBatchItems(t1Qs, docs, systemText, 1)
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 1, client)
+			prior := append(append([]Answer(nil), advisorAnswers...), allAnswers...)
+			answers, inputTok, outputTok, err := executePlanned(ctx, client, t1Qs, prior, docs, systemText, 1, false)
 			if err != nil {
 				log.Warn("fund T1 extraction failed", zap.Error(err))
-			} else {
-				allAnswers = append(allAnswers, answers...)
-				if costTracker != nil {
-					costTracker.RecordUsage(docs.CRDNumber, 1, inputTok, outputTok, 0, 0)
-				}
+			}
+			allAnswers = append(allAnswers, answers...)
+			if costTracker != nil {
+				costTracker.RecordUsage(docs.CRDNumber, 1, inputTok, outputTok, 0, 0)
 			}
 		}
 	}
@@ -137,15 +138,14 @@ func extractSingleFund(ctx context.Context, docs *AdvisorDocs, fund FundRow, que
 		t2Qs := filterByTier(llmQuestions, 2)
 		if len(t2Qs) > 0 {
 			systemText := T2SystemPrompt(docs, allAnswers) + "\n\n" + fundCtx
-			items := buildBatchItems(t2Qs, docs, systemText, 2)
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 2, client)
+			prior := append(append([]Answer(nil), advisorAnswers...), allAnswers...)
+			answers, inputTok, outputTok, err := executePlanned(ctx, client, t2Qs, prior, docs, systemText, 2, false)
 			if err != nil {
 				log.Warn("fund T2 extraction failed", zap.Error(err))
-			} else {
-				allAnswers = append(allAnswers, answers...)
-				if costTracker != nil {
-					costTracker.RecordUsage(docs.CRDNumber, 2, inputTok, outputTok, 0, 0)
-				}
+			}
+			allAnswers = append(allAnswers, answers...)
+			if costTracker != nil {
+				costTracker.RecordUsage(docs.CRDNumber, 2, inputTok, outputTok, 0, 0)
 			}
 		}
 	}
@@ -155,15 +155,14 @@ func extractSingleFund(ctx context.Context, docs *AdvisorDocs, fund FundRow, que
 		t3Qs := filterByTier(llmQuestions, 3)
 		if len(t3Qs) > 0 {
 			systemText := T3SystemPrompt(docs, allAnswers) + "\n\n" + fundCtx
-			items := buildBatchItems(t3Qs, docs, systemText, 3)
-			answers, inputTok, outputTok, err := executeBatch(ctx, items, 3, client)
+			prior := append(append([]Answer(nil), advisorAnswers...), allAnswers...)
+			answers, inputTok, outputTok, err := executePlanned(ctx, client, t3Qs, prior, docs, systemText, 3, false)
 			if err != nil {
 				log.Warn("fund T3 extraction failed", zap.Error(err))
-			} else {
-				allAnswers = append(allAnswers, answers...)
-				if costTracker != nil {
-					costTracker.RecordUsage(docs.CRDNumber, 3, inputTok, outputTok, 0, 0)
-				}
+			}
+			allAnswers = append(allAnswers, answers...)
+			if costTracker != nil {
+				costTracker.RecordUsage(docs.CRDNumber, 3, inputTok, outputTok, 0, 0)
 			}
 		}
 	}
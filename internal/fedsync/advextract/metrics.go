@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/sells-group/research-cli/internal/db"
 )
 
@@ -85,6 +87,9 @@ type ComputedMetrics struct {
 
 	// Regulatory risk (Gap 8.3)
 	RegulatoryRiskScore *int // 0-100
+
+	// Fund service providers: any private fund audited outside the mainstream list.
+	NonMainstreamAuditor bool
 }
 
 // ComputeRevenue applies fee schedule tiers to AUM and returns estimated annual revenue.
@@ -527,6 +532,11 @@ func computeAcquisitionReadiness(s *Scores, answers map[string]Answer) int {
 		score -= 5
 	}
 
+	// -10 if any private fund uses a non-mainstream auditor.
+	if a, ok := answers["uses_non_mainstream_auditor"]; ok && isTruthy(a.Value) {
+		score -= 10
+	}
+
 	// Clamp to 0-100.
 	if score < 0 {
 		score = 0
@@ -795,13 +805,23 @@ func ComputeRegulatoryRiskScore(ctx context.Context, pool db.Pool, crd int, drpS
 	}
 	enforcementComponent := math.Min(float64(enforcementCount)*10.0, 30.0)
 
-	// BrokerCheck disclosures: 20% weight.
+	// BrokerCheck disclosures and FINRA arbitration awards: 20% weight.
+	// Awards are also reported as disclosures, so the larger of the two
+	// counts is used, with awards of $1M or more counted twice.
 	var disclosureCount int
 	err = pool.QueryRow(ctx,
 		`SELECT COALESCE(disclosure_count, 0) FROM fed_data.brokercheck WHERE crd_number = $1`, crd).Scan(&disclosureCount)
 	if err != nil {
 		disclosureCount = 0
 	}
+	var awardCount, largeAwardCount int
+	err = pool.QueryRow(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE award_amount >= 1000000)
+		FROM fed_data.finra_awards WHERE $1 = ANY(respondent_crds)`, crd).Scan(&awardCount, &largeAwardCount)
+	if err != nil {
+		awardCount, largeAwardCount = 0, 0
+	}
+	disclosureCount = max(disclosureCount, awardCount+largeAwardCount)
 	disclosureComponent := math.Min(float64(disclosureCount)*5.0, 20.0)
 
 	// Amendment frequency: 10% weight.
@@ -937,11 +957,26 @@ func ComputeAllMetrics(ctx context.Context, pool db.Pool, crd int, advisor *Advi
 		cm.RegulatoryRiskScore = regScore
 	}
 
-	// Acquisition readiness uses all computed data (includes amendment + regulatory factors).
-	// Inject amendment data into answers for scoring.
+	// Flag private fund auditors outside the mainstream list (extracted answer + Schedule D).
+	var auditors []string
+	if a, ok := answerMap["fund_auditor"]; ok && a.Value != nil {
+		auditors = append(auditors, extractStringValue(a.Value))
+	}
+	if scheduleD, err := NewStore(pool).LoadFundAuditors(ctx, crd); err == nil {
+		auditors = append(auditors, scheduleD...)
+	} else {
+		zap.L().Warn("advextract: load Schedule D fund auditors failed", zap.Int("crd", crd), zap.Error(err))
+	}
+	cm.NonMainstreamAuditor = HasNonMainstreamAuditor(auditors)
+
+	// Acquisition readiness uses all computed data (includes amendment, regulatory,
+	// and auditor factors). Inject derived flags into answers for scoring.
 	if amendFrequent {
 		answerMap["has_frequent_amendments"] = Answer{Value: true}
 	}
+	if cm.NonMainstreamAuditor {
+		answerMap["uses_non_mainstream_auditor"] = Answer{Value: true}
+	}
 	scores.AcquisitionReadiness = computeAcquisitionReadiness(scores, answerMap)
 	cm.AcquisitionReadiness = scores.AcquisitionReadiness
 
@@ -1,7 +1,10 @@
 package advextract
 
 import (
+	"context"
 	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
 )
 
 func TestComputeRevenue(t *testing.T) {
@@ -360,3 +363,30 @@ func TestComputeRevenue_UnsortedTiers(t *testing.T) {
 		t.Errorf("expected 4375000 for unsorted tiers, got %d", rev)
 	}
 }
+
+func TestComputeRegulatoryRiskScore_ArbitrationAwards(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.ExpectQuery("sec_enforcement_actions").WithArgs(123).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("brokercheck").WithArgs(123).
+		WillReturnRows(pgxmock.NewRows([]string{"disclosure_count"}).AddRow(1))
+	mock.ExpectQuery("finra_awards").WithArgs(123).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "large"}).AddRow(2, 1))
+
+	score, err := ComputeRegulatoryRiskScore(context.Background(), mock, 123, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two awards, one of $1M+, outweigh the single disclosure: 3 * 5 = 15.
+	if score == nil || *score != 15 {
+		t.Errorf("expected regulatory risk score 15, got %v", score)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
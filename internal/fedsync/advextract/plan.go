@@ -0,0 +1,198 @@
+package advextract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/pkg/anthropic"
+)
+
+// Requirement conditions.
+const (
+	WhenTrue     = "true"     // prerequisite answered yes
+	WhenPositive = "positive" // prerequisite is a number > 0
+	WhenPresent  = "present"  // prerequisite has a non-empty value
+)
+
+// Requirement gates a question on a prior answer: the question is skipped
+// when the answer to Key is known with confidence and fails When.
+type Requirement struct {
+	Key  string
+	When string
+}
+
+// requireFunds gates fund-level questions on the adviser reporting private funds.
+var requireFunds = []Requirement{{Key: "total_fund_count", When: WhenPositive}}
+
+// met reports whether a satisfies the requirement.
+func (r Requirement) met(a Answer) bool {
+	switch r.When {
+	case WhenTrue:
+		return isTruthy(a.Value)
+	case WhenPositive:
+		return toFloat64(a.Value) > 0
+	default:
+		return hasValue(a.Value)
+	}
+}
+
+// hasValue reports whether v is a non-null, non-empty answer value.
+func hasValue(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(x) != ""
+	case []any:
+		return len(x) > 0
+	case map[string]any:
+		return len(x) > 0
+	default:
+		return true
+	}
+}
+
+// dependencyKeys returns every question key q waits on: gating
+// prerequisites plus context answers.
+func dependencyKeys(q Question) []string {
+	keys := make([]string, 0, len(q.Requires)+len(q.ContextKeys))
+	for _, r := range q.Requires {
+		keys = append(keys, r.Key)
+	}
+	return append(keys, q.ContextKeys...)
+}
+
+// planWave splits pending questions into those ready to ask now, those
+// skipped because a prerequisite answer rules them out, and those still
+// waiting on a prerequisite asked in the same pass. Missing or
+// low-confidence prerequisites never skip a question, so a failed or
+// uncertain upstream answer costs a call rather than an answer.
+func planWave(pending []Question, known map[string]Answer) (ready, skipped, waiting []Question) {
+	inPass := make(map[string]bool, len(pending))
+	for _, q := range pending {
+		inPass[q.Key] = true
+	}
+
+	for _, q := range pending {
+		blocked := false
+		for _, k := range dependencyKeys(q) {
+			if inPass[k] && k != q.Key {
+				blocked = true
+				break
+			}
+		}
+		if blocked {
+			waiting = append(waiting, q)
+			continue
+		}
+
+		skip := false
+		for _, r := range q.Requires {
+			a, ok := known[r.Key]
+			if ok && a.Confidence >= confidenceEscalationThreshold && !r.met(a) {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			skipped = append(skipped, q)
+		} else {
+			ready = append(ready, q)
+		}
+	}
+
+	// A dependency cycle would leave every question waiting; ask them as-is.
+	if len(ready) == 0 && len(skipped) == 0 {
+		return waiting, nil, nil
+	}
+	return ready, skipped, waiting
+}
+
+// dependencyContext renders the answers listed in q.ContextKeys for
+// injection into the question's prompt, or "" when none are known.
+func dependencyContext(q Question, known map[string]Answer) string {
+	var sb strings.Builder
+	for _, k := range q.ContextKeys {
+		a, ok := known[k]
+		if !ok || !hasValue(a.Value) {
+			continue
+		}
+		valJSON, _ := json.Marshal(a.Value)
+		fmt.Fprintf(&sb, "- %s: %s\n", k, string(valJSON))
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "--- Related Extracted Answers ---\n" + sb.String()
+}
+
+// answerIndex keys answers by question key; later answers win.
+func answerIndex(answers []Answer) map[string]Answer {
+	m := make(map[string]Answer, len(answers))
+	for _, a := range answers {
+		m[a.QuestionKey] = a
+	}
+	return m
+}
+
+// executePlanned asks questions for one tier in dependency order. Each wave
+// runs as a batch; answers feed the gating and prompt context of later
+// waves. prior holds answers from earlier stages. When primer is set, a cache
+// primer is fired alongside the first wave. Answers gathered before a failed
+// wave are returned with the error.
+func executePlanned(ctx context.Context, client anthropic.Client, questions []Question, prior []Answer, docs *AdvisorDocs, systemText string, tier int, primer bool) ([]Answer, int64, int64, error) {
+	log := zap.L().With(zap.Int("crd", docs.CRDNumber), zap.Int("tier", tier))
+
+	known := answerIndex(prior)
+	var out []Answer
+	var totalInput, totalOutput int64
+
+	pending := questions
+	for wave := 0; len(pending) > 0; wave++ {
+		ready, skipped, waiting := planWave(pending, known)
+		pending = waiting
+		if len(skipped) > 0 {
+			keys := make([]string, len(skipped))
+			for i, q := range skipped {
+				keys[i] = q.Key
+			}
+			log.Debug("skipping questions with unmet prerequisites", zap.Strings("questions", keys))
+		}
+
+		items := buildBatchItems(ready, docs, systemText, tier, known)
+		if len(items) == 0 {
+			continue
+		}
+
+		primerDone := make(chan struct{})
+		var primerIn, primerOut int64
+		// Tier 3 batches are small; only prime when there are several requests.
+		if primer && wave == 0 && (tier < 3 || len(items) >= 3) {
+			go func() {
+				primerIn, primerOut = firePrimer(ctx, client, items)
+				close(primerDone)
+			}()
+		} else {
+			close(primerDone)
+		}
+
+		answers, inputTok, outputTok, err := executeBatch(ctx, items, tier, client)
+		<-primerDone
+		totalInput += primerIn + inputTok
+		totalOutput += primerOut + outputTok
+		if err != nil {
+			return out, totalInput, totalOutput, err
+		}
+
+		for _, a := range answers {
+			known[a.QuestionKey] = a
+		}
+		out = append(out, answers...)
+	}
+
+	return out, totalInput, totalOutput, nil
+}
@@ -0,0 +1,136 @@
+package advextract
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/pkg/anthropic"
+	anthropicmocks "github.com/sells-group/research-cli/pkg/anthropic/mocks"
+)
+
+func questionKeys(qs []Question) []string {
+	keys := make([]string, len(qs))
+	for i, q := range qs {
+		keys[i] = q.Key
+	}
+	return keys
+}
+
+func TestAllQuestions_DependenciesValid(t *testing.T) {
+	qm := QuestionMap()
+	for _, q := range AllQuestions() {
+		for _, k := range dependencyKeys(q) {
+			dep, ok := qm[k]
+			if !assert.True(t, ok, "%s depends on unknown question %s", q.Key, k) {
+				continue
+			}
+			assert.LessOrEqual(t, dep.Tier, q.Tier, "%s depends on later-tier question %s", q.Key, k)
+			if q.Scope == ScopeAdvisor {
+				assert.Equal(t, ScopeAdvisor, dep.Scope, "advisor question %s depends on fund question %s", q.Key, k)
+			}
+		}
+		for _, r := range q.Requires {
+			assert.Contains(t, []string{WhenTrue, WhenPositive, WhenPresent}, r.When, q.Key)
+		}
+	}
+}
+
+func TestRequirement_Met(t *testing.T) {
+	assert.True(t, Requirement{When: WhenTrue}.met(Answer{Value: true}))
+	assert.True(t, Requirement{When: WhenTrue}.met(Answer{Value: "Yes"}))
+	assert.False(t, Requirement{When: WhenTrue}.met(Answer{Value: false}))
+	assert.True(t, Requirement{When: WhenPositive}.met(Answer{Value: 3}))
+	assert.False(t, Requirement{When: WhenPositive}.met(Answer{Value: 0}))
+	assert.True(t, Requirement{When: WhenPresent}.met(Answer{Value: []any{"x"}}))
+	assert.False(t, Requirement{When: WhenPresent}.met(Answer{Value: ""}))
+	assert.False(t, Requirement{When: WhenPresent}.met(Answer{Value: nil}))
+}
+
+func TestPlanWave(t *testing.T) {
+	qm := QuestionMap()
+	pending := []Question{
+		qm["charges_hourly_fees"], qm["hourly_fee_range"],
+		qm["fixed_fee_range"], qm["financial_plan_fee"], qm["performance_fee_rate"],
+	}
+	known := map[string]Answer{
+		"charges_fixed_fees":         {QuestionKey: "charges_fixed_fees", Value: false, Confidence: 0.9},
+		"charges_financial_plan_fee": {QuestionKey: "charges_financial_plan_fee", Value: false, Confidence: 0.2},
+	}
+
+	ready, skipped, waiting := planWave(pending, known)
+	// financial_plan_fee's prerequisite is too uncertain to skip on;
+	// performance_fee_rate's prerequisite is unanswered.
+	assert.Equal(t, []string{"charges_hourly_fees", "financial_plan_fee", "performance_fee_rate"}, questionKeys(ready))
+	assert.Equal(t, []string{"fixed_fee_range"}, questionKeys(skipped))
+	assert.Equal(t, []string{"hourly_fee_range"}, questionKeys(waiting))
+
+	known["charges_hourly_fees"] = Answer{QuestionKey: "charges_hourly_fees", Value: true, Confidence: 0.9}
+	ready, skipped, waiting = planWave(waiting, known)
+	assert.Equal(t, []string{"hourly_fee_range"}, questionKeys(ready))
+	assert.Empty(t, skipped)
+	assert.Empty(t, waiting)
+}
+
+func TestPlanWave_Cycle(t *testing.T) {
+	a := Question{Key: "a", ContextKeys: []string{"b"}}
+	b := Question{Key: "b", ContextKeys: []string{"a"}}
+	ready, skipped, waiting := planWave([]Question{a, b}, nil)
+	assert.Equal(t, []string{"a", "b"}, questionKeys(ready))
+	assert.Empty(t, skipped)
+	assert.Empty(t, waiting)
+}
+
+func TestDependencyContext(t *testing.T) {
+	q := QuestionMap()["max_fee_rate_pct"]
+	assert.Empty(t, dependencyContext(q, nil))
+
+	known := answerIndex([]Answer{{
+		QuestionKey: "fee_schedule_aum_tiers",
+		Value:       []any{map[string]any{"min_aum": 0, "annual_rate_pct": 1.0}},
+	}})
+	got := dependencyContext(q, known)
+	assert.Contains(t, got, "--- Related Extracted Answers ---")
+	assert.Contains(t, got, `- fee_schedule_aum_tiers: [{"annual_rate_pct":1,"min_aum":0}]`)
+}
+
+func TestExecutePlanned_SkipsAndInjects(t *testing.T) {
+	qm := QuestionMap()
+	docs := &AdvisorDocs{
+		CRDNumber:        1,
+		BrochureSections: map[string]string{SectionFees: "We charge 1% on the first $1M."},
+	}
+	prior := []Answer{{
+		QuestionKey: "fee_schedule_aum_tiers",
+		Value:       []any{map[string]any{"min_aum": 0, "annual_rate_pct": 1.0}},
+		Confidence:  0.9,
+	}}
+
+	respond := func(text string) *anthropic.MessageResponse {
+		return &anthropic.MessageResponse{
+			Content: []anthropic.ContentBlock{{Type: "text", Text: text}},
+			Usage:   anthropic.TokenUsage{InputTokens: 10, OutputTokens: 5},
+		}
+	}
+	client := anthropicmocks.NewMockClient(t)
+	client.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(req anthropic.MessageRequest) bool {
+		return strings.Contains(req.Messages[0].Content, qm["charges_hourly_fees"].Text)
+	})).Return(respond(`{"value": false, "confidence": 0.95}`), nil).Once()
+	client.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(req anthropic.MessageRequest) bool {
+		msg := req.Messages[0].Content
+		return strings.Contains(msg, qm["max_fee_rate_pct"].Text) &&
+			strings.Contains(msg, "- fee_schedule_aum_tiers: ")
+	})).Return(respond(`{"value": 1.0, "confidence": 0.9}`), nil).Once()
+
+	qs := []Question{qm["hourly_fee_range"], qm["charges_hourly_fees"], qm["max_fee_rate_pct"]}
+	answers, in, out, err := executePlanned(context.Background(), client, qs, prior, docs, "system", 1, false)
+	require.NoError(t, err)
+	require.Len(t, answers, 2)
+	assert.ElementsMatch(t, []string{"charges_hourly_fees", "max_fee_rate_pct"}, []string{answers[0].QuestionKey, answers[1].QuestionKey})
+	assert.Equal(t, int64(20), in)
+	assert.Equal(t, int64(10), out)
+}
@@ -240,6 +240,9 @@ func StructuredBypassAnswer(q Question, advisor *AdvisorRow, fund *FundRow, fund
 	case "disciplinary_history":
 		a.Value = extractDisciplinaryFlags(advisor)
 		a.Reasoning = "Extracted from ADV Part 1 Item 11 DRP flags"
+		if len(advisor.Enforcement) > 0 {
+			a.Reasoning += " and SEC enforcement actions"
+		}
 
 	case "key_regulatory_registrations":
 		a.Value = extractRegulatoryStatus(advisor)
@@ -531,12 +534,19 @@ func extractRegulatoryStatus(a *AdvisorRow) any {
 }
 
 func extractDisciplinaryFlags(a *AdvisorRow) any {
-	if a.Filing == nil {
+	if a.Filing == nil && len(a.Enforcement) == 0 {
 		return nil
 	}
 	hasAny := isTruthy(a.Filing["has_any_drp"])
 	result := map[string]any{
-		"has_disciplinary_history": hasAny,
+		"has_disciplinary_history": hasAny || len(a.Enforcement) > 0,
+	}
+	if len(a.Enforcement) > 0 {
+		ids := make([]string, 0, len(a.Enforcement))
+		for _, e := range a.Enforcement {
+			ids = append(ids, e.ActionID)
+		}
+		result["sec_enforcement_actions"] = ids
 	}
 	if hasAny {
 		drpFields := []struct{ key, label string }{
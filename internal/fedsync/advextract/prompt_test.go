@@ -340,3 +340,28 @@ func init() {
 	// Ensure json is used (for the test compilation)
 	_ = json.Marshal
 }
+
+func TestStructuredBypass_DisciplinaryEnforcementOnly(t *testing.T) {
+	advisor := &AdvisorRow{
+		CRDNumber:   12345,
+		Enforcement: []EnforcementRow{{ActionID: "IA-6612", ActionType: "administrative_proceeding"}},
+	}
+
+	q := Question{Key: "disciplinary_history", StructuredBypass: true}
+	a := StructuredBypassAnswer(q, advisor, nil, nil)
+	if a == nil {
+		t.Fatal("expected non-nil answer")
+	}
+
+	valMap, ok := a.Value.(map[string]any)
+	if !ok {
+		t.Fatalf("expected map, got %T", a.Value)
+	}
+	if valMap["has_disciplinary_history"] != true {
+		t.Error("expected has_disciplinary_history=true from enforcement actions")
+	}
+	ids, ok := valMap["sec_enforcement_actions"].([]string)
+	if !ok || len(ids) != 1 || ids[0] != "IA-6612" {
+		t.Errorf("sec_enforcement_actions = %v, want [IA-6612]", valMap["sec_enforcement_actions"])
+	}
+}
@@ -0,0 +1,224 @@
+package advextract
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// mainstreamAuditors lists normalized name fragments for auditors with a
+// meaningful private fund practice (Big Four plus the larger national firms).
+// Funds audited by anyone else are treated as a diligence risk factor.
+var mainstreamAuditors = []string{
+	"pricewaterhousecoopers", "pwc",
+	"deloitte",
+	"ernst & young", "ernst and young", "ey",
+	"kpmg",
+	"grant thornton",
+	"bdo",
+	"rsm",
+	"baker tilly",
+	"eisneramper",
+	"marcum",
+	"cohen & co", "cohen and co",
+	"withum",
+	"cohnreznick",
+	"mayer hoffman mccann",
+	"crowe",
+	"moss adams",
+	"spicer jeffries",
+	"richey may",
+	"tait, weller", "tait weller",
+}
+
+// fundProviderTypes are the provider types included in the fund provider network.
+var fundProviderTypes = []string{"auditor", "administrator", "prime_broker"}
+
+// NormalizeProviderName normalizes a service provider name for aggregation:
+// lowercased, punctuation-trimmed, and without common entity suffixes.
+func NormalizeProviderName(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), ".,")
+	s = strings.Join(strings.Fields(s), " ")
+	s = normalizeName(s)
+	for _, suffix := range []string{", l.l.p", " l.l.p", ", llp", " llp", ", lp", " lp", ", pllc", " pllc", ", pc", " p.c"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+	return strings.TrimSpace(strings.Trim(s, ".,"))
+}
+
+// IsMainstreamAuditor reports whether an auditor name matches a recognized
+// private fund audit firm. Short fragments (e.g. "ey", "bdo") must match a
+// whole word.
+func IsMainstreamAuditor(name string) bool {
+	n := NormalizeProviderName(name)
+	if n == "" {
+		return false
+	}
+	words := strings.FieldsFunc(n, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == '-' || r == '(' || r == ')'
+	})
+	for _, m := range mainstreamAuditors {
+		m = strings.ToLower(m)
+		if len(m) <= 4 {
+			for _, w := range words {
+				if w == m {
+					return true
+				}
+			}
+			continue
+		}
+		if strings.Contains(n, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNonMainstreamAuditor reports whether any disclosed auditor is outside the
+// mainstream list.
+func HasNonMainstreamAuditor(auditors []string) bool {
+	for _, a := range auditors {
+		if strings.TrimSpace(a) != "" && !IsMainstreamAuditor(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadFundAuditors returns the distinct Schedule D auditor names for an advisor's private funds.
+func (s *Store) LoadFundAuditors(ctx context.Context, crd int) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `SELECT DISTINCT provider_name
+		FROM fed_data.adv_fund_service_providers
+		WHERE crd_number = $1 AND provider_type = 'auditor'
+		ORDER BY provider_name`, crd)
+	if err != nil {
+		return nil, eris.Wrapf(err, "advextract: load fund auditors for CRD %d", crd)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, eris.Wrap(err, "advextract: scan fund auditor")
+		}
+		result = append(result, name)
+	}
+	return result, rows.Err()
+}
+
+// providerNetworkSourceSQL unions extracted provider answers with Schedule D
+// per-fund providers. Answer rows have no fund_id.
+const providerNetworkSourceSQL = `
+	SELECT provider_type, provider_name, crd_number, NULL::text AS fund_id, 'answers' AS source
+	FROM fed_data.adv_service_providers
+	WHERE provider_type = ANY($1)
+	UNION ALL
+	SELECT provider_type, provider_name, crd_number, fund_id, 'schedule_d' AS source
+	FROM fed_data.adv_fund_service_providers
+	WHERE provider_type = ANY($1)`
+
+// providerStats accumulates counts for one provider in the network.
+type providerStats struct {
+	providerType string
+	name         string
+	firms        map[int]bool
+	funds        map[string]bool
+	answerFirms  map[int]bool
+	schedFirms   map[int]bool
+}
+
+// BuildProviderNetwork aggregates fund auditor, administrator, and prime
+// broker relationships into fed_data.fund_provider_network with firm and fund
+// counts per provider. Providers no longer referenced are removed.
+func BuildProviderNetwork(ctx context.Context, pool db.Pool) (int64, error) {
+	// Postgres stores microseconds; truncate so the prune below doesn't drop this run's rows.
+	started := time.Now().Truncate(time.Microsecond)
+
+	rows, err := pool.Query(ctx, providerNetworkSourceSQL, fundProviderTypes)
+	if err != nil {
+		return 0, eris.Wrap(err, "advextract: query provider sources")
+	}
+
+	stats := make(map[string]*providerStats)
+	var order []string
+	for rows.Next() {
+		var (
+			providerType, name, source string
+			crd                        int
+			fundID                     *string
+		)
+		if err := rows.Scan(&providerType, &name, &crd, &fundID, &source); err != nil {
+			rows.Close()
+			return 0, eris.Wrap(err, "advextract: scan provider source")
+		}
+		normalized := NormalizeProviderName(name)
+		if normalized == "" {
+			continue
+		}
+
+		key := providerType + "|" + normalized
+		ps, ok := stats[key]
+		if !ok {
+			ps = &providerStats{
+				providerType: providerType,
+				name:         normalized,
+				firms:        make(map[int]bool),
+				funds:        make(map[string]bool),
+				answerFirms:  make(map[int]bool),
+				schedFirms:   make(map[int]bool),
+			}
+			stats[key] = ps
+			order = append(order, key)
+		}
+		ps.firms[crd] = true
+		if source == "answers" {
+			ps.answerFirms[crd] = true
+		} else {
+			ps.schedFirms[crd] = true
+			if fundID != nil {
+				ps.funds[*fundID] = true
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, eris.Wrap(err, "advextract: iterate provider sources")
+	}
+
+	out := make([][]any, 0, len(order))
+	for _, key := range order {
+		ps := stats[key]
+		var mainstream *bool
+		if ps.providerType == "auditor" {
+			m := IsMainstreamAuditor(ps.name)
+			mainstream = &m
+		}
+		out = append(out, []any{
+			ps.providerType, ps.name,
+			len(ps.firms), len(ps.funds), len(ps.answerFirms), len(ps.schedFirms),
+			mainstream, started,
+		})
+	}
+
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table: "fed_data.fund_provider_network",
+		Columns: []string{
+			"provider_type", "provider_name", "firm_count", "fund_count",
+			"answer_firm_count", "schedule_d_firm_count", "is_mainstream", "updated_at",
+		},
+		ConflictKeys: []string{"provider_type", "provider_name"},
+	}, out)
+	if err != nil {
+		return 0, eris.Wrap(err, "advextract: upsert provider network")
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM fed_data.fund_provider_network WHERE updated_at < $1`, started); err != nil {
+		return n, eris.Wrap(err, "advextract: prune provider network")
+	}
+	return n, nil
+}
@@ -0,0 +1,119 @@
+package advextract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeProviderName(t *testing.T) {
+	assert.Equal(t, "pricewaterhousecoopers", NormalizeProviderName("PRICEWATERHOUSECOOPERS LLP"))
+	assert.Equal(t, "ernst & young", NormalizeProviderName("  Ernst &  Young, L.L.P. "))
+	assert.Equal(t, "smith & co", NormalizeProviderName("Smith & Co, PLLC"))
+	assert.Equal(t, "", NormalizeProviderName("  "))
+}
+
+func TestIsMainstreamAuditor(t *testing.T) {
+	for _, name := range []string{
+		"PricewaterhouseCoopers LLP",
+		"Deloitte & Touche LLP",
+		"Ernst & Young LLP",
+		"EY",
+		"KPMG LLP",
+		"RSM US LLP",
+		"Cohen & Company, Ltd.",
+		"EisnerAmper LLP",
+	} {
+		assert.True(t, IsMainstreamAuditor(name), name)
+	}
+	for _, name := range []string{"", "Smith Kearney CPAs", "Rsmith Audit Group", "Obey Partners"} {
+		assert.False(t, IsMainstreamAuditor(name), name)
+	}
+}
+
+func TestHasNonMainstreamAuditor(t *testing.T) {
+	assert.False(t, HasNonMainstreamAuditor(nil))
+	assert.False(t, HasNonMainstreamAuditor([]string{"KPMG LLP", ""}))
+	assert.True(t, HasNonMainstreamAuditor([]string{"KPMG LLP", "Main Street Audit LLC"}))
+}
+
+func TestAcquisitionReadiness_NonMainstreamAuditor(t *testing.T) {
+	base := computeAcquisitionReadiness(&Scores{}, map[string]Answer{})
+	flagged := computeAcquisitionReadiness(&Scores{}, map[string]Answer{
+		"uses_non_mainstream_auditor": {Value: true},
+	})
+	assert.Equal(t, base-10, flagged)
+}
+
+func TestLoadFundAuditors(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.adv_fund_service_providers").
+		WithArgs(123).
+		WillReturnRows(pgxmock.NewRows([]string{"provider_name"}).
+			AddRow("KPMG LLP").
+			AddRow("Main Street Audit LLC"))
+
+	auditors, err := NewStore(mock).LoadFundAuditors(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"KPMG LLP", "Main Street Audit LLC"}, auditors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildProviderNetwork(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	fund1, fund2 := "805-1", "805-2"
+	mock.ExpectQuery("FROM fed_data.adv_service_providers").
+		WithArgs(fundProviderTypes).
+		WillReturnRows(pgxmock.NewRows([]string{"provider_type", "provider_name", "crd_number", "fund_id", "source"}).
+			AddRow("auditor", "kpmg", 1, nil, "answers").
+			AddRow("auditor", "KPMG LLP", 1, &fund1, "schedule_d").
+			AddRow("auditor", "KPMG LLP", 2, &fund2, "schedule_d").
+			AddRow("auditor", "Main Street Audit LLC", 3, nil, "answers").
+			AddRow("administrator", "  ", 4, nil, "answers"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(
+		pgx.Identifier{"_tmp_upsert_fed_data_fund_provider_network"},
+		[]string{
+			"provider_type", "provider_name", "firm_count", "fund_count",
+			"answer_firm_count", "schedule_d_firm_count", "is_mainstream", "updated_at",
+		},
+	).WillReturnResult(2)
+	mock.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("INSERT INTO").WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectCommit()
+	mock.ExpectExec("DELETE FROM fed_data.fund_provider_network").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	n, err := BuildProviderNetwork(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildProviderNetwork_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.adv_service_providers").
+		WithArgs(fundProviderTypes).
+		WillReturnError(errors.New("relation does not exist"))
+
+	_, err = BuildProviderNetwork(context.Background(), mock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query provider sources")
+}
@@ -11,6 +11,9 @@ type Question struct {
 	SourceSections   []string // brochure items to route to (e.g., "item_4", "item_5")
 	StructuredBypass bool     // true = answer from Part 1 data directly, no LLM
 	OutputFormat     string   // expected JSON output format hint
+
+	Requires    []Requirement // prior answers that must hold for the question to be asked
+	ContextKeys []string      // prior answers injected into the question's prompt
 }
 
 // Scope constants.
@@ -419,12 +422,14 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionFees},
 		OutputFormat: "number",
+		ContextKeys:  []string{"fee_schedule_aum_tiers"},
 	},
 	{
 		Key: "min_fee_rate_pct", Text: "What is the lowest AUM-based advisory fee rate for the largest accounts?",
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionFees},
 		OutputFormat: "number",
+		ContextKeys:  []string{"fee_schedule_aum_tiers"},
 	},
 	{
 		Key: "charges_hourly_fees", Text: "Does the firm charge hourly fees?",
@@ -437,6 +442,7 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionFees},
 		OutputFormat: "string",
+		Requires:     []Requirement{{Key: "charges_hourly_fees", When: WhenTrue}},
 	},
 	{
 		Key: "charges_fixed_fees", Text: "Does the firm charge fixed or flat fees for services?",
@@ -449,6 +455,7 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionFees},
 		OutputFormat: "string",
+		Requires:     []Requirement{{Key: "charges_fixed_fees", When: WhenTrue}},
 	},
 	{
 		Key: "charges_financial_plan_fee", Text: "Does the firm charge a separate fee for financial plans?",
@@ -461,6 +468,7 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionFees},
 		OutputFormat: "string",
+		Requires:     []Requirement{{Key: "charges_financial_plan_fee", When: WhenTrue}},
 	},
 	{
 		Key: "billing_frequency", Text: "How often are advisory fees billed (monthly, quarterly, annually)?",
@@ -532,24 +540,29 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionPerformanceFees},
 		OutputFormat: "string",
+		Requires:     []Requirement{{Key: "has_performance_fee_detail", When: WhenTrue}},
 	},
 	{
 		Key: "has_hurdle_rate", Text: "Is there a hurdle rate or preferred return?",
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionPerformanceFees},
 		OutputFormat: "boolean",
+		Requires:     []Requirement{{Key: "has_performance_fee_detail", When: WhenTrue}},
 	},
 	{
 		Key: "hurdle_rate_value", Text: "What is the hurdle rate or preferred return percentage?",
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionPerformanceFees},
 		OutputFormat: "string",
+		Requires:     []Requirement{{Key: "has_hurdle_rate", When: WhenTrue}},
+		ContextKeys:  []string{"performance_fee_rate"},
 	},
 	{
 		Key: "has_high_water_mark", Text: "Is there a high-water mark provision?",
 		Tier: 1, Category: CatFees, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionPerformanceFees},
 		OutputFormat: "boolean",
+		Requires:     []Requirement{{Key: "has_performance_fee_detail", When: WhenTrue}},
 	},
 
 	// =========================================================================
@@ -742,7 +755,7 @@ var allQuestions = []Question{
 	{
 		Key: "has_insurance_licenses", Text: "Do any firm personnel hold insurance licenses?",
 		Tier: 1, Category: CatConflicts, Scope: ScopeAdvisor,
-		SourceDocs: []string{"part2"}, SourceSections: []string{SectionAffiliations},
+		SourceDocs: []string{"part1", "part2"}, SourceSections: []string{SectionAffiliations},
 		OutputFormat: "boolean",
 	},
 	{
@@ -1208,120 +1221,140 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_target_return", Text: "What is the fund's target return? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_benchmark", Text: "What benchmark does the fund use? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_uses_leverage", Text: "Does the fund use leverage?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_max_leverage_ratio", Text: "What is the maximum leverage ratio? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     []Requirement{requireFunds[0], {Key: "fund_uses_leverage", When: WhenTrue}},
 	},
 	{
 		Key: "fund_lock_up_period", Text: "What is the fund lock-up period? Return null if none.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_redemption_notice_days", Text: "How many days notice is required for redemption? Return 0 if no restriction.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_redemption_frequency", Text: "How often can investors redeem (monthly, quarterly, annually)?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_has_gate", Text: "Does the fund have redemption gates?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_mgmt_fee_pct", Text: "What is the management fee percentage?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "number",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_performance_fee_pct", Text: "What is the performance fee/carried interest percentage? Return null if none.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "number",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_hurdle_rate", Text: "What is the fund's hurdle rate? Return null if none.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_high_water_mark", Text: "Does the fund have a high-water mark?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_min_investment", Text: "What is the minimum investment for the fund?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_auditor", Text: "Who is the fund's auditor? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_administrator", Text: "Who is the fund's administrator? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_prime_broker", Text: "Who is the fund's prime broker? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_side_letters_exist", Text: "Are there side letter arrangements granting preferential terms?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_gp_commitment_exists", Text: "Does the GP/manager have a co-investment commitment in the fund?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_concentration_limits", Text: "Does the fund have position concentration limits?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 
 	// =========================================================================
@@ -1359,7 +1392,7 @@ var allQuestions = []Question{
 		OutputFormat: "string",
 	},
 	{
-		Key: "regulatory_risk_profile", Text: "Assess the overall regulatory risk based on DRP history, custody arrangements, and cross-trading.",
+		Key: "regulatory_risk_profile", Text: "Assess the overall regulatory risk based on DRP history, SEC enforcement actions, FINRA arbitration award frequency and size, custody arrangements, and cross-trading.",
 		Tier: 2, Category: CatSynthesis, Scope: ScopeAdvisor,
 		SourceDocs: []string{"part1", "part2", "part3"}, SourceSections: []string{},
 		OutputFormat: "string",
@@ -1439,48 +1472,56 @@ var allQuestions = []Question{
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_historical_returns", Text: "Extract any disclosed historical returns as JSON: [{period, return_pct}]. Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "json",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_benchmark_comparison", Text: "What benchmark does this fund compare its performance against? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_vintage_year", Text: "What is the fund's vintage year (year of first close/inception)?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_irr_disclosed", Text: "Does the fund disclose IRR or similar return metrics? What is the disclosed IRR?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "string",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_loss_disclosure", Text: "Does the fund disclose any material investment losses or significant drawdowns?",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "boolean",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_investor_count", Text: "How many investors does this fund have? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 	{
 		Key: "fund_capital_committed", Text: "What is the total capital committed to this fund? Return null if not disclosed.",
 		Tier: 1, Category: CatFundDetail, Scope: ScopeFund,
 		SourceDocs: []string{"part2"}, SourceSections: []string{SectionInvestment, SectionAdvisoryBiz},
 		OutputFormat: "integer",
+		Requires:     requireFunds,
 	},
 
 	// =========================================================================
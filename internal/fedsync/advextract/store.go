@@ -40,6 +40,38 @@ type AdvisorRow struct {
 
 	// Structured Part 1 data (full filing row for bypass)
 	Filing map[string]any
+
+	// SEC litigation releases and administrative proceedings linked to
+	// this CRD (fed_data.sec_enforcement_actions)
+	Enforcement []EnforcementRow
+
+	// FINRA arbitration awards naming this CRD as a respondent
+	// (fed_data.finra_awards); nil when there are none
+	Arbitration *ArbitrationSummary
+
+	// Active state insurance licenses held by the firm or producers at its
+	// affiliated agency (fed_data.insurance_producers); nil when there are none
+	Insurance *InsuranceSummary
+}
+
+// ArbitrationSummary rolls up the FINRA arbitration awards against an advisor.
+// Amounts only cover awards whose PDF has been extracted.
+type ArbitrationSummary struct {
+	Awards        int
+	Recent5y      int
+	TotalAmount   int64
+	LargestAmount int64
+	LastAwardDate *time.Time
+}
+
+// EnforcementRow represents an SEC enforcement action naming the advisor.
+type EnforcementRow struct {
+	ActionID      string
+	ActionType    string
+	ActionDate    *time.Time
+	Outcome       string
+	PenaltyAmount *int64
+	Description   string
 }
 
 // BrochureRow represents an ADV Part 2 brochure.
@@ -228,6 +260,81 @@ func (s *Store) LoadFunds(ctx context.Context, crd int) ([]FundRow, error) {
 	return result, rows.Err()
 }
 
+// LoadEnforcement loads SEC enforcement actions linked to an advisor, newest first.
+func (s *Store) LoadEnforcement(ctx context.Context, crd int) ([]EnforcementRow, error) {
+	query := `SELECT action_id, action_type, action_date, COALESCE(outcome, ''), penalty_amount,
+			COALESCE(description, '')
+		FROM fed_data.sec_enforcement_actions
+		WHERE crd_number = $1
+		ORDER BY action_date DESC NULLS LAST`
+
+	rows, err := s.pool.Query(ctx, query, crd)
+	if err != nil {
+		return nil, eris.Wrapf(err, "advextract: load enforcement for CRD %d", crd)
+	}
+	defer rows.Close()
+
+	var result []EnforcementRow
+	for rows.Next() {
+		var e EnforcementRow
+		if err := rows.Scan(&e.ActionID, &e.ActionType, &e.ActionDate, &e.Outcome, &e.PenaltyAmount, &e.Description); err != nil {
+			return nil, eris.Wrap(err, "advextract: scan enforcement action")
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
+
+// LoadArbitration loads the FINRA arbitration award rollup for an advisor.
+// Returns nil when no award names the CRD as a respondent.
+func (s *Store) LoadArbitration(ctx context.Context, crd int) (*ArbitrationSummary, error) {
+	query := `SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE award_date >= CURRENT_DATE - INTERVAL '5 years'),
+			COALESCE(SUM(award_amount), 0), COALESCE(MAX(award_amount), 0), MAX(award_date)
+		FROM fed_data.finra_awards
+		WHERE $1 = ANY(respondent_crds)`
+
+	var a ArbitrationSummary
+	if err := s.pool.QueryRow(ctx, query, crd).Scan(&a.Awards, &a.Recent5y, &a.TotalAmount, &a.LargestAmount, &a.LastAwardDate); err != nil {
+		return nil, eris.Wrapf(err, "advextract: load arbitration awards for CRD %d", crd)
+	}
+	if a.Awards == 0 {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+// InsuranceSummary rolls up the active insurance producer licenses linked to
+// an advisor. Producers counts distinct individuals across states.
+type InsuranceSummary struct {
+	Producers        int
+	AgencyLicenses   int
+	States           int
+	LinesOfAuthority []string
+}
+
+// LoadInsurance loads the insurance license rollup for an advisor. Returns
+// nil when no active license is linked to the CRD.
+func (s *Store) LoadInsurance(ctx context.Context, crd int) (*InsuranceSummary, error) {
+	query := `SELECT
+			COUNT(DISTINCT COALESCE(npn, name_norm)) FILTER (WHERE entity_type = 'individual'),
+			COUNT(*) FILTER (WHERE entity_type = 'agency'),
+			COUNT(DISTINCT state),
+			ARRAY(SELECT DISTINCT unnest(lines_of_authority) FROM fed_data.insurance_producers
+				WHERE crd_number = $1 AND status IS DISTINCT FROM 'inactive' ORDER BY 1)
+		FROM fed_data.insurance_producers
+		WHERE crd_number = $1 AND status IS DISTINCT FROM 'inactive'`
+
+	var in InsuranceSummary
+	if err := s.pool.QueryRow(ctx, query, crd).Scan(&in.Producers, &in.AgencyLicenses, &in.States, &in.LinesOfAuthority); err != nil {
+		return nil, eris.Wrapf(err, "advextract: load insurance licenses for CRD %d", crd)
+	}
+	if in.Producers == 0 && in.AgencyLicenses == 0 {
+		return nil, nil
+	}
+	return &in, nil
+}
+
 // ListAdvisors returns CRD numbers matching the given filters.
 func (s *Store) ListAdvisors(ctx context.Context, opts ListOpts) ([]int, error) {
 	query := `SELECT DISTINCT f.crd_number FROM fed_data.adv_firms f`
@@ -524,11 +631,11 @@ func (s *Store) WriteComputedMetrics(ctx context.Context, m *ComputedMetrics) er
 		 hybrid_revenue_estimate, estimated_expense_ratio, estimated_operating_margin,
 		 revenue_per_employee, benchmark_aum_per_employee_pctile, benchmark_fee_rate_pctile,
 		 amendments_last_year, amendments_per_year_avg, has_frequent_amendments,
-		 regulatory_risk_score,
+		 regulatory_risk_score, non_mainstream_auditor,
 		 computed_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
 		        $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27,
-		        $28, $29, $30, $31, now())
+		        $28, $29, $30, $31, $32, now())
 		ON CONFLICT (crd_number) DO UPDATE SET
 		 revenue_estimate = EXCLUDED.revenue_estimate,
 		 blended_fee_rate_bps = EXCLUDED.blended_fee_rate_bps,
@@ -560,6 +667,7 @@ func (s *Store) WriteComputedMetrics(ctx context.Context, m *ComputedMetrics) er
 		 amendments_per_year_avg = EXCLUDED.amendments_per_year_avg,
 		 has_frequent_amendments = EXCLUDED.has_frequent_amendments,
 		 regulatory_risk_score = EXCLUDED.regulatory_risk_score,
+		 non_mainstream_auditor = EXCLUDED.non_mainstream_auditor,
 		 computed_at = now()`
 
 	_, err := s.pool.Exec(ctx, query, m.CRDNumber,
@@ -573,7 +681,7 @@ func (s *Store) WriteComputedMetrics(ctx context.Context, m *ComputedMetrics) er
 		m.HybridRevenueEstimate, m.EstimatedExpenseRatio, m.EstimatedOperatingMargin,
 		m.RevenuePerEmployee, m.BenchmarkAUMPerEmployeePctile, m.BenchmarkFeeRatePctile,
 		m.AmendmentsLastYear, m.AmendmentsPerYearAvg, m.HasFrequentAmendments,
-		m.RegulatoryRiskScore)
+		m.RegulatoryRiskScore, m.NonMainstreamAuditor)
 	return eris.Wrapf(err, "advextract: write computed metrics for CRD %d", m.CRDNumber)
 }
 
@@ -713,7 +713,7 @@ func TestWriteComputedMetrics_Success(t *testing.T) {
 			m.HybridRevenueEstimate, m.EstimatedExpenseRatio, m.EstimatedOperatingMargin,
 			m.RevenuePerEmployee, m.BenchmarkAUMPerEmployeePctile, m.BenchmarkFeeRatePctile,
 			m.AmendmentsLastYear, m.AmendmentsPerYearAvg, m.HasFrequentAmendments,
-			m.RegulatoryRiskScore,
+			m.RegulatoryRiskScore, m.NonMainstreamAuditor,
 		).WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	s := NewStore(mock)
@@ -742,7 +742,7 @@ func TestWriteComputedMetrics_Error(t *testing.T) {
 			m.HybridRevenueEstimate, m.EstimatedExpenseRatio, m.EstimatedOperatingMargin,
 			m.RevenuePerEmployee, m.BenchmarkAUMPerEmployeePctile, m.BenchmarkFeeRatePctile,
 			m.AmendmentsLastYear, m.AmendmentsPerYearAvg, m.HasFrequentAmendments,
-			m.RegulatoryRiskScore,
+			m.RegulatoryRiskScore, m.NonMainstreamAuditor,
 		).WillReturnError(fmt.Errorf("disk full"))
 
 	s := NewStore(mock)
@@ -910,3 +910,117 @@ func TestItoa(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadEnforcement_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	date := time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC)
+	penalty := int64(125000)
+	mock.ExpectQuery("SELECT").WithArgs(123).WillReturnRows(
+		pgxmock.NewRows([]string{"action_id", "action_type", "action_date", "outcome", "penalty_amount", "description"}).
+			AddRow("IA-6612", "administrative_proceeding", &date, "cease_and_desist", &penalty, "Consented to an order.").
+			AddRow("LR-26302", "litigation_release", (*time.Time)(nil), "", (*int64)(nil), ""),
+	)
+
+	s := NewStore(mock)
+	actions, err := s.LoadEnforcement(context.Background(), 123)
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "IA-6612", actions[0].ActionID)
+	assert.Equal(t, int64(125000), *actions[0].PenaltyAmount)
+	assert.Nil(t, actions[1].ActionDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadEnforcement_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT").WithArgs(123).WillReturnError(fmt.Errorf("timeout"))
+
+	s := NewStore(mock)
+	actions, err := s.LoadEnforcement(context.Background(), 123)
+	require.Error(t, err)
+	assert.Nil(t, actions)
+	assert.Contains(t, err.Error(), "load enforcement")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadArbitration_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	date := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("FROM fed_data.finra_awards").WithArgs(123).WillReturnRows(
+		pgxmock.NewRows([]string{"count", "recent", "total", "largest", "last"}).
+			AddRow(3, 2, int64(1450000), int64(1200000), &date),
+	)
+
+	s := NewStore(mock)
+	arb, err := s.LoadArbitration(context.Background(), 123)
+	require.NoError(t, err)
+	require.NotNil(t, arb)
+	assert.Equal(t, 3, arb.Awards)
+	assert.Equal(t, 2, arb.Recent5y)
+	assert.Equal(t, int64(1450000), arb.TotalAmount)
+	assert.Equal(t, int64(1200000), arb.LargestAmount)
+	assert.Equal(t, date, *arb.LastAwardDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadInsurance_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.insurance_producers").WithArgs(123).WillReturnRows(
+		pgxmock.NewRows([]string{"producers", "agencies", "states", "loa"}).
+			AddRow(7, 2, 3, []string{"accident_health", "life", "variable"}),
+	)
+
+	s := NewStore(mock)
+	in, err := s.LoadInsurance(context.Background(), 123)
+	require.NoError(t, err)
+	require.NotNil(t, in)
+	assert.Equal(t, 7, in.Producers)
+	assert.Equal(t, 2, in.AgencyLicenses)
+	assert.Equal(t, 3, in.States)
+	assert.Equal(t, []string{"accident_health", "life", "variable"}, in.LinesOfAuthority)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadInsurance_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.insurance_producers").WithArgs(123).WillReturnError(fmt.Errorf("timeout"))
+
+	s := NewStore(mock)
+	in, err := s.LoadInsurance(context.Background(), 123)
+	require.Error(t, err)
+	assert.Nil(t, in)
+	assert.Contains(t, err.Error(), "load insurance licenses")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadArbitration_NoAwards(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.finra_awards").WithArgs(123).WillReturnRows(
+		pgxmock.NewRows([]string{"count", "recent", "total", "largest", "last"}).
+			AddRow(0, 0, int64(0), int64(0), (*time.Time)(nil)),
+	)
+
+	s := NewStore(mock)
+	arb, err := s.LoadArbitration(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Nil(t, arb)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,224 @@
+// Package censusapi queries the Census Data API (api.census.gov/data). It
+// splits variable lists over the API's 50-variable limit into several calls
+// and joins the results, iterates geographies state by state, encodes
+// predicates, and paces every request through one shared rate limiter.
+package censusapi
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"golang.org/x/time/rate"
+
+	"github.com/sells-group/research-cli/internal/fedsync/transform"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	// BaseURL is the Census Data API root.
+	BaseURL = "https://api.census.gov/data"
+
+	// MaxVars is the most variables the API returns per call.
+	MaxVars = 50
+)
+
+// limiter paces requests from every Client that does not set its own.
+var limiter = rate.NewLimiter(10, 10)
+
+// Client queries the Census Data API through a fetcher.
+type Client struct {
+	Fetcher fetcher.Fetcher
+	Key     string        // API key; "" queries anonymously
+	BaseURL string        // "" = BaseURL
+	Limiter *rate.Limiter // nil = the limiter shared by all clients
+}
+
+// New returns a client that downloads through f with API key key.
+func New(f fetcher.Fetcher, key string) *Client {
+	return &Client{Fetcher: f, Key: key}
+}
+
+// Predicate is a query parameter filtering the result, e.g.
+// {"time", "from 2020"} or {"NAICS2017", "54"}.
+type Predicate struct {
+	Name  string
+	Value string
+}
+
+// Query is one Census API request.
+type Query struct {
+	// Dataset is the path below the API root, e.g. "2022/ecnbasic" or
+	// "timeseries/eits/m3".
+	Dataset string
+	// Keys are variables requested in every call when Get is split, used
+	// with the geography and predicate columns to join the calls (e.g.
+	// GEO_ID, NAICS2017). Keys count toward each call's MaxVars.
+	Keys []string
+	// Get lists the variables to return; any number is allowed.
+	Get []string
+	// For is the geography level returned, e.g. "us:*" or "county:*".
+	For string
+	// In lists enclosing geographies, e.g. "state:06".
+	In         []string
+	Predicates []Predicate
+}
+
+// IsNotFound reports whether err means the requested vintage, dataset, or
+// geography is not published (the API answers 404). A 400 means the query
+// itself is wrong, e.g. a misspelled variable, and is not treated as missing.
+func IsNotFound(err error) bool {
+	code, ok := fetcher.StatusCode(err)
+	return ok && code == 404
+}
+
+// Fetch runs q and returns the result. When Keys and Get together exceed
+// MaxVars, Get is split over several calls whose rows are joined; a row
+// missing from a later call gets empty values for that call's variables.
+func (c *Client) Fetch(ctx context.Context, q Query) (*Table, error) {
+	size := MaxVars - len(q.Keys)
+	if size < 1 {
+		return nil, eris.Errorf("censusapi: %d key variables leave no room for others", len(q.Keys))
+	}
+	vars := make([]string, 0, len(q.Get))
+	for _, v := range q.Get {
+		if !slices.Contains(q.Keys, v) {
+			vars = append(vars, v)
+		}
+	}
+	if len(vars) == 0 {
+		return c.get(ctx, q, q.Keys)
+	}
+
+	var out *Table
+	for chunk := range slices.Chunk(vars, size) {
+		t, err := c.get(ctx, q, append(slices.Clone(q.Keys), chunk...))
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = t
+			continue
+		}
+		out.join(t, chunk)
+	}
+	return out, nil
+}
+
+// ForEachState runs q once per state FIPS code, restricted with
+// "state:<fips>" added to In, and calls fn with each result. For is
+// typically a level below the state, such as "county:*" or "tract:*".
+func (c *Client) ForEachState(ctx context.Context, q Query, states []string, fn func(state string, t *Table) error) error {
+	for _, st := range states {
+		sq := q
+		sq.In = append(slices.Clone(q.In), "state:"+st)
+		t, err := c.Fetch(ctx, sq)
+		if err != nil {
+			return eris.Wrapf(err, "state %s", st)
+		}
+		if err := fn(st, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Latest fetches build(year) for each year from newest back to oldest and
+// returns the first published one. Census tables lag their reference year,
+// so the newest vintage is often not out yet. It returns year 0 and a nil
+// table when no year in the range is published.
+func (c *Client) Latest(ctx context.Context, newest, oldest int, build func(year int) Query) (int, *Table, error) {
+	for year := newest; year >= oldest; year-- {
+		t, err := c.Fetch(ctx, build(year))
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, eris.Wrapf(err, "year %d", year)
+		}
+		return year, t, nil
+	}
+	return 0, nil, nil
+}
+
+// URL returns the request URL for q returning vars.
+func (c *Client) URL(q Query, vars []string) string {
+	base := c.BaseURL
+	if base == "" {
+		base = BaseURL
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(base, "/"))
+	b.WriteString("/")
+	b.WriteString(strings.Trim(q.Dataset, "/"))
+	b.WriteString("?get=")
+	b.WriteString(escape(strings.Join(vars, ",")))
+	if q.For != "" {
+		b.WriteString("&for=")
+		b.WriteString(escape(q.For))
+	}
+	for _, in := range q.In {
+		b.WriteString("&in=")
+		b.WriteString(escape(in))
+	}
+	for _, p := range q.Predicates {
+		b.WriteString("&")
+		b.WriteString(escape(p.Name))
+		b.WriteString("=")
+		b.WriteString(escape(p.Value))
+	}
+	if c.Key != "" {
+		b.WriteString("&key=")
+		b.WriteString(url.QueryEscape(c.Key))
+	}
+	return b.String()
+}
+
+// get runs one call returning vars.
+func (c *Client) get(ctx context.Context, q Query, vars []string) (*Table, error) {
+	lim := c.Limiter
+	if lim == nil {
+		lim = limiter
+	}
+	if err := lim.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	body, err := c.Fetcher.Download(ctx, c.URL(q, vars))
+	if err != nil {
+		return nil, eris.Wrapf(err, "download %s", q.Dataset)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, eris.Wrap(err, "read response")
+	}
+	return ParseTable(data)
+}
+
+// escape query-escapes s, leaving the ':', '*', and ',' that geography
+// and variable lists are written with readable. Spaces become '+', as the
+// API expects in predicates like "time=from+2020".
+func escape(s string) string {
+	return unescaper.Replace(url.QueryEscape(s))
+}
+
+var unescaper = strings.NewReplacer("%3A", ":", "%2A", "*", "%2C", ",")
+
+// States returns the FIPS codes of the 50 states and DC, sorted. Callers
+// covering Puerto Rico add "72".
+func States() []string {
+	out := make([]string, 0, 51)
+	for abbr, fips := range transform.StateAbbrToFIPS {
+		if abbr == "PR" || abbr == "VI" {
+			continue
+		}
+		out = append(out, fips)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,194 @@
+package censusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func body(t *testing.T, rows [][]string) io.ReadCloser {
+	t.Helper()
+	data, err := json.Marshal(rows)
+	require.NoError(t, err)
+	return io.NopCloser(strings.NewReader(string(data)))
+}
+
+func testClient(f *fetchermocks.MockFetcher) *Client {
+	c := New(f, "k")
+	c.Limiter = rate.NewLimiter(rate.Inf, 1)
+	return c
+}
+
+func TestClient_URL(t *testing.T) {
+	c := New(nil, "a/b")
+	u := c.URL(Query{
+		Dataset:    "/timeseries/eits/m3",
+		For:        "county:*",
+		In:         []string{"state:06"},
+		Predicates: []Predicate{{"time", "from 2020"}, {"NAICS2017", "54&1"}},
+	}, []string{"NAME", "B01001_001E"})
+	assert.Equal(t, "https://api.census.gov/data/timeseries/eits/m3?get=NAME,B01001_001E"+
+		"&for=county:*&in=state:06&time=from+2020&NAICS2017=54%261&key=a%2Fb", u)
+
+	c = &Client{BaseURL: "http://mirror.test/"}
+	assert.Equal(t, "http://mirror.test/2022/ecnbasic?get=GEO_ID&for=us:*",
+		c.URL(Query{Dataset: "2022/ecnbasic", For: "us:*"}, []string{"GEO_ID"}))
+}
+
+func TestClient_Fetch_SplitsAndJoins(t *testing.T) {
+	var get []string
+	for i := range 60 {
+		get = append(get, fmt.Sprintf("V%02d", i))
+	}
+
+	f := fetchermocks.NewMockFetcher(t)
+	// First call: GEO_ID + V00..V48 (49 variables).
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "get=GEO_ID,V00,") && strings.Contains(u, ",V48&")
+	})).RunAndReturn(func(context.Context, string) (io.ReadCloser, error) {
+		header := append([]string{"GEO_ID"}, get[:49]...)
+		header = append(header, "state")
+		a := append([]string{"0400000US06"}, get[:49]...)
+		b := append([]string{"0400000US36"}, get[:49]...)
+		return body(t, [][]string{header, append(a, "06"), append(b, "36")}), nil
+	}).Once()
+	// Second call: GEO_ID + V49..V59; rows arrive in another order and NY
+	// is missing.
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "get=GEO_ID,V49,")
+	})).RunAndReturn(func(context.Context, string) (io.ReadCloser, error) {
+		header := append([]string{"GEO_ID"}, get[49:]...)
+		header = append(header, "state")
+		a := append([]string{"0400000US06"}, get[49:]...)
+		return body(t, [][]string{header, append(a, "06")}), nil
+	}).Once()
+
+	tbl, err := testClient(f).Fetch(context.Background(), Query{
+		Dataset: "2022/acs/acs5", Keys: []string{"GEO_ID"}, Get: append([]string{"GEO_ID"}, get...), For: "state:*",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, tbl.Len())
+	assert.Len(t, tbl.Header, 62)
+	ca, ny := tbl.Rows[0], tbl.Rows[1]
+	assert.Equal(t, "V00", tbl.Get(ca, "V00"))
+	assert.Equal(t, "V59", tbl.Get(ca, "V59"))
+	assert.Equal(t, "06", tbl.Get(ca, "state"))
+	assert.Equal(t, "V48", tbl.Get(ny, "V48"))
+	assert.Equal(t, "", tbl.Get(ny, "V59"))
+}
+
+func TestClient_Fetch_TooManyKeys(t *testing.T) {
+	keys := make([]string, MaxVars)
+	_, err := testClient(nil).Fetch(context.Background(), Query{Keys: keys, Get: []string{"X"}})
+	require.Error(t, err)
+}
+
+func TestClient_Fetch_Errors(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("timeout")).Once()
+	_, err := testClient(f).Fetch(context.Background(), Query{Dataset: "2022/nonemp", Get: []string{"A"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "download 2022/nonemp")
+
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("not json")), nil).Once()
+	_, err = testClient(f).Fetch(context.Background(), Query{Dataset: "2022/nonemp", Get: []string{"A"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse json")
+}
+
+func TestClient_Latest(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "/2024/abscs")
+	})).Return(nil, errors.New("unexpected status 404")).Once()
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "/2023/abscs")
+	})).Return(body(t, [][]string{{"NAICS2017", "us"}, {"54", "1"}}), nil).Once()
+
+	build := func(year int) Query {
+		return Query{Dataset: fmt.Sprintf("%d/abscs", year), Get: []string{"NAICS2017"}, For: "us:*"}
+	}
+	year, tbl, err := testClient(f).Latest(context.Background(), 2024, 2020, build)
+	require.NoError(t, err)
+	assert.Equal(t, 2023, year)
+	assert.Equal(t, 1, tbl.Len())
+
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("unexpected status 404")).Twice()
+	year, tbl, err = testClient(f).Latest(context.Background(), 2021, 2020, build)
+	require.NoError(t, err)
+	assert.Zero(t, year)
+	assert.Nil(t, tbl)
+
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("unexpected status 500")).Once()
+	_, _, err = testClient(f).Latest(context.Background(), 2021, 2020, build)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "year 2021")
+
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("unexpected status 400")).Once()
+	_, _, err = testClient(f).Latest(context.Background(), 2021, 2020, build)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(errors.New("download: unexpected status 404 from http://x")))
+	assert.False(t, IsNotFound(errors.New("download: unexpected status 400 from http://x")))
+	assert.False(t, IsNotFound(errors.New("connection reset")))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestClient_ForEachState(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	for _, st := range []string{"06", "36"} {
+		f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+			return strings.Contains(u, "&for=county:*&in=state:"+st+"&")
+		})).Return(body(t, [][]string{{"NAME", "state", "county"}, {"X", st, "001"}}), nil).Once()
+	}
+
+	var got []string
+	err := testClient(f).ForEachState(context.Background(),
+		Query{Dataset: "2022/cbp", Get: []string{"NAME"}, For: "county:*"},
+		[]string{"06", "36"},
+		func(state string, tbl *Table) error {
+			got = append(got, state+"/"+tbl.Get(tbl.Rows[0], "county"))
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"06/001", "36/001"}, got)
+}
+
+func TestParseTable(t *testing.T) {
+	tbl, err := ParseTable([]byte(`[["A","B"],["1","2"]]`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, tbl.Len())
+	assert.True(t, tbl.Has("B"))
+	assert.Equal(t, "2", tbl.Get(tbl.Rows[0], "B"))
+	assert.Equal(t, "", tbl.Get(tbl.Rows[0], "C"))
+
+	tbl, err = ParseTable([]byte("  "))
+	require.NoError(t, err)
+	assert.Zero(t, tbl.Len())
+
+	_, err = ParseTable([]byte("not json"))
+	require.Error(t, err)
+}
+
+func TestStates(t *testing.T) {
+	states := States()
+	assert.Len(t, states, 51)
+	assert.Equal(t, "01", states[0])
+	assert.Contains(t, states, "11")
+	assert.NotContains(t, states, "72")
+}
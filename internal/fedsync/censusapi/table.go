@@ -0,0 +1,96 @@
+package censusapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// Table is a Census API result: a header row of column names and one
+// record per geography (and predicate value, for time series).
+type Table struct {
+	Header []string
+	Rows   [][]string
+	cols   map[string]int
+}
+
+// ParseTable decodes an API response, a JSON array of string arrays with
+// the header first. An empty body, which the API sends (with 204) when
+// nothing matches, is an empty table.
+func ParseTable(data []byte) (*Table, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return newTable(nil, nil), nil
+	}
+	var raw [][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, eris.Wrap(err, "parse json")
+	}
+	if len(raw) == 0 {
+		return newTable(nil, nil), nil
+	}
+	return newTable(raw[0], raw[1:]), nil
+}
+
+func newTable(header []string, rows [][]string) *Table {
+	t := &Table{Header: header, Rows: rows, cols: make(map[string]int, len(header))}
+	for i, col := range header {
+		t.cols[col] = i
+	}
+	return t
+}
+
+// Len returns the number of records.
+func (t *Table) Len() int { return len(t.Rows) }
+
+// Has reports whether the table has column name.
+func (t *Table) Has(name string) bool {
+	_, ok := t.cols[name]
+	return ok
+}
+
+// Get returns rec's value for column name, or "" when the column is
+// missing or the record short.
+func (t *Table) Get(rec []string, name string) string {
+	i, ok := t.cols[name]
+	if !ok || i >= len(rec) {
+		return ""
+	}
+	return rec[i]
+}
+
+// join appends other's vars columns to t, matching records on every other
+// column other has (keys, geography, and predicate columns).
+func (t *Table) join(other *Table, vars []string) {
+	var keyCols []string
+	for _, col := range other.Header {
+		if !slices.Contains(vars, col) {
+			keyCols = append(keyCols, col)
+		}
+	}
+	key := func(tbl *Table, rec []string) string {
+		parts := make([]string, len(keyCols))
+		for i, col := range keyCols {
+			parts[i] = tbl.Get(rec, col)
+		}
+		return strings.Join(parts, "\x00")
+	}
+
+	index := make(map[string][]string, len(other.Rows))
+	for _, rec := range other.Rows {
+		index[key(other, rec)] = rec
+	}
+	for i, rec := range t.Rows {
+		match := index[key(t, rec)]
+		for _, v := range vars {
+			rec = append(rec, other.Get(match, v))
+		}
+		t.Rows[i] = rec
+	}
+	for _, v := range vars {
+		t.cols[v] = len(t.Header)
+		t.Header = append(t.Header, v)
+	}
+}
@@ -0,0 +1,115 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// Checkpoints provides access to fed_data.sync_checkpoints, which records
+// how far a long-running dataset sync has progressed so an interrupted run
+// can resume where it stopped.
+type Checkpoints struct {
+	pool db.Pool
+}
+
+// NewCheckpoints creates a new Checkpoints store backed by the given pool.
+func NewCheckpoints(pool db.Pool) *Checkpoints {
+	return &Checkpoints{pool: pool}
+}
+
+// For returns the checkpoint for a single dataset.
+// The run is taken to start now.
+func (c *Checkpoints) For(dataset string) *Checkpoint {
+	return &Checkpoint{pool: c.pool, dataset: dataset, started: time.Now().UTC()}
+}
+
+// Checkpoint is one dataset's persisted progress: the last key (a CIK, file
+// name, or page) whose rows are committed. A nil *Checkpoint is valid and
+// records nothing, so datasets can checkpoint unconditionally.
+type Checkpoint struct {
+	pool    db.Pool
+	dataset string
+	started time.Time
+
+	// resumedStart is when the interrupted run being resumed started, set
+	// by Load when a checkpoint is found.
+	resumedStart *time.Time
+}
+
+// Load returns the last committed key, or "" if the previous run finished
+// or none has been recorded.
+func (c *Checkpoint) Load(ctx context.Context) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	var (
+		key     string
+		started time.Time
+	)
+	err := c.pool.QueryRow(ctx,
+		`SELECT last_key, started_at FROM fed_data.sync_checkpoints WHERE dataset = $1`,
+		c.dataset,
+	).Scan(&key, &started)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", eris.Wrapf(err, "checkpoint: load %s", c.dataset)
+	}
+	c.resumedStart = &started
+	return key, nil
+}
+
+// ResumedStart returns when the interrupted run that Load found started,
+// or nil when Load found no checkpoint. A resumed run only covers what the
+// interrupted one had not reached, so its watermark must not move past
+// that start.
+func (c *Checkpoint) ResumedStart() *time.Time {
+	if c == nil {
+		return nil
+	}
+	return c.resumedStart
+}
+
+// Save records key as the last committed unit of work.
+func (c *Checkpoint) Save(ctx context.Context, key string) error {
+	if c == nil {
+		return nil
+	}
+	_, err := c.pool.Exec(ctx,
+		`INSERT INTO fed_data.sync_checkpoints (dataset, last_key, started_at, updated_at)
+		 VALUES ($1, $2, $3, now())
+		 ON CONFLICT (dataset) DO UPDATE
+		 SET last_key = EXCLUDED.last_key, updated_at = now()`,
+		c.dataset, key, c.runStart(),
+	)
+	return eris.Wrapf(err, "checkpoint: save %s", c.dataset)
+}
+
+// runStart is the start of the run the checkpoint belongs to: the
+// interrupted run's when resuming, otherwise this one's.
+func (c *Checkpoint) runStart() time.Time {
+	if c.resumedStart != nil {
+		return *c.resumedStart
+	}
+	return c.started
+}
+
+// Clear removes the checkpoint once a sync completes, so the next run
+// starts from the beginning.
+func (c *Checkpoint) Clear(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	_, err := c.pool.Exec(ctx,
+		`DELETE FROM fed_data.sync_checkpoints WHERE dataset = $1`,
+		c.dataset,
+	)
+	return eris.Wrapf(err, "checkpoint: clear %s", c.dataset)
+}
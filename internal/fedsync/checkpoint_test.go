@@ -0,0 +1,96 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint_Load(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	started := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT last_key, started_at FROM fed_data.sync_checkpoints").
+		WithArgs("xbrl_facts").
+		WillReturnRows(pgxmock.NewRows([]string{"last_key", "started_at"}).AddRow("0000320193", started))
+
+	cp := NewCheckpoints(mock).For("xbrl_facts")
+	key, err := cp.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0000320193", key)
+	require.NotNil(t, cp.ResumedStart())
+	assert.Equal(t, started, *cp.ResumedStart())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckpoint_Load_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT last_key, started_at FROM fed_data.sync_checkpoints").
+		WithArgs("xbrl_facts").
+		WillReturnError(pgx.ErrNoRows)
+
+	cp := NewCheckpoints(mock).For("xbrl_facts")
+	key, err := cp.Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.Nil(t, cp.ResumedStart())
+}
+
+func TestCheckpoint_Load_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT last_key, started_at FROM fed_data.sync_checkpoints").
+		WithArgs("xbrl_facts").
+		WillReturnError(errors.New("connection refused"))
+
+	_, err = NewCheckpoints(mock).For("xbrl_facts").Load(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checkpoint: load xbrl_facts")
+}
+
+func TestCheckpoint_SaveAndClear(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_checkpoints .* ON CONFLICT").
+		WithArgs("edgar_submissions", "CIK0000320193.json", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("DELETE FROM fed_data.sync_checkpoints").
+		WithArgs("edgar_submissions").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	cp := NewCheckpoints(mock).For("edgar_submissions")
+	require.NoError(t, cp.Save(context.Background(), "CIK0000320193.json"))
+	require.NoError(t, cp.Clear(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_checkpoints").
+		WillReturnError(errors.New("connection refused"))
+	err = cp.Save(context.Background(), "CIK0000320193.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checkpoint: save edgar_submissions")
+}
+
+func TestCheckpoint_Nil(t *testing.T) {
+	var cp *Checkpoint
+	key, err := cp.Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.NoError(t, cp.Save(context.Background(), "x"))
+	assert.NoError(t, cp.Clear(context.Background()))
+	assert.Nil(t, cp.ResumedStart())
+}
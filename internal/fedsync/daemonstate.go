@@ -0,0 +1,61 @@
+package fedsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// DaemonState provides access to fed_data.daemon_state, the heartbeat a
+// running `fedsync daemon` writes after every schedule check. A nil
+// *DaemonState is valid and records nothing.
+type DaemonState struct {
+	pool      db.Pool
+	host      string
+	startedAt time.Time
+}
+
+// DaemonTick is the outcome of one schedule check.
+type DaemonTick struct {
+	At     time.Time
+	Next   time.Time
+	Due    []string
+	Synced int
+	Failed int
+	Err    error
+}
+
+// NewDaemonState creates the heartbeat of the daemon on host, started at
+// startedAt.
+func NewDaemonState(pool db.Pool, host string, startedAt time.Time) *DaemonState {
+	return &DaemonState{pool: pool, host: host, startedAt: startedAt}
+}
+
+// Record upserts the daemon's row with tick.
+func (s *DaemonState) Record(ctx context.Context, tick DaemonTick) error {
+	if s == nil {
+		return nil
+	}
+	due := tick.Due
+	if due == nil {
+		due = []string{}
+	}
+	var errMsg *string
+	if tick.Err != nil {
+		msg := tick.Err.Error()
+		errMsg = &msg
+	}
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO fed_data.daemon_state (host, started_at, last_tick_at, next_tick_at, last_due, synced, failed, last_error)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (host) DO UPDATE SET
+		     started_at = EXCLUDED.started_at, last_tick_at = EXCLUDED.last_tick_at,
+		     next_tick_at = EXCLUDED.next_tick_at, last_due = EXCLUDED.last_due,
+		     synced = EXCLUDED.synced, failed = EXCLUDED.failed, last_error = EXCLUDED.last_error`,
+		s.host, s.startedAt, tick.At, tick.Next, due, tick.Synced, tick.Failed, errMsg,
+	)
+	return eris.Wrapf(err, "daemon state: record %s", s.host)
+}
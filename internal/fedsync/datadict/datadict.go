@@ -0,0 +1,218 @@
+// Package datadict builds the analyst data dictionary: every table in the
+// fed_data and geo schemas with its columns and Postgres types, an
+// estimated row count, and the datasets that load it, merged with their
+// catalog descriptions, declared column docs, and last successful sync.
+package datadict
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+)
+
+// DefaultSchemas are the schemas documented when none are given.
+var DefaultSchemas = []string{"fed_data", "geo"}
+
+// Column is one table column.
+type Column struct {
+	Name        string
+	Type        string // Postgres type, e.g. "character varying(10)"
+	Nullable    bool
+	Description string // dataset ColumnDoc, else the Postgres column comment
+}
+
+// Owner is a dataset that loads a table.
+type Owner struct {
+	Dataset     string
+	Label       string
+	Description string
+	Cadence     dataset.Cadence
+	LastSync    *time.Time // last successful sync; nil if never synced
+}
+
+// Table is one documented table.
+type Table struct {
+	Schema  string
+	Name    string
+	Comment string // Postgres table comment
+	// Rows is the planner's row estimate (pg_class.reltuples); -1 when the
+	// table has never been analyzed.
+	Rows    int64
+	Owners  []Owner
+	Columns []Column
+}
+
+// FullName returns the schema-qualified table name.
+func (t Table) FullName() string { return t.Schema + "." + t.Name }
+
+// LastSync returns the most recent successful sync across the table's
+// owners, or nil.
+func (t Table) LastSync() *time.Time {
+	var last *time.Time
+	for _, o := range t.Owners {
+		if o.LastSync != nil && (last == nil || o.LastSync.After(*last)) {
+			last = o.LastSync
+		}
+	}
+	return last
+}
+
+// Dictionary is the generated data dictionary.
+type Dictionary struct {
+	Generated time.Time
+	Tables    []Table
+}
+
+// Sources are the registry-side inputs merged into the introspected schema.
+type Sources struct {
+	Datasets []dataset.Dataset
+	Catalog  []dataset.CatalogEntry
+	Stats    []fedsync.DatasetRunStats
+}
+
+const tablesSQL = `SELECT n.nspname, c.relname, COALESCE(obj_description(c.oid, 'pg_class'), ''), c.reltuples::BIGINT
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND n.nspname = ANY($1)
+ORDER BY n.nspname, c.relname`
+
+const columnsSQL = `SELECT n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod),
+	NOT a.attnotnull, COALESCE(col_description(c.oid, a.attnum), '')
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND n.nspname = ANY($1)
+	AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY n.nspname, c.relname, a.attnum`
+
+// Introspect reads the tables and columns of schemas from the Postgres
+// catalog. Partitions are folded into their parent table.
+func Introspect(ctx context.Context, pool db.Pool, schemas []string) ([]Table, error) {
+	if len(schemas) == 0 {
+		schemas = DefaultSchemas
+	}
+
+	rows, err := pool.Query(ctx, tablesSQL, schemas)
+	if err != nil {
+		return nil, eris.Wrap(err, "datadict: list tables")
+	}
+	var tables []Table
+	index := make(map[string]int)
+	for rows.Next() {
+		var t Table
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Comment, &t.Rows); err != nil {
+			rows.Close()
+			return nil, eris.Wrap(err, "datadict: scan table")
+		}
+		index[t.FullName()] = len(tables)
+		tables = append(tables, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "datadict: list tables")
+	}
+
+	rows, err = pool.Query(ctx, columnsSQL, schemas)
+	if err != nil {
+		return nil, eris.Wrap(err, "datadict: list columns")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var schema, table string
+		var c Column
+		if err := rows.Scan(&schema, &table, &c.Name, &c.Type, &c.Nullable, &c.Description); err != nil {
+			return nil, eris.Wrap(err, "datadict: scan column")
+		}
+		if i, ok := index[schema+"."+table]; ok {
+			tables[i].Columns = append(tables[i].Columns, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "datadict: list columns")
+	}
+	return tables, nil
+}
+
+// Merge attaches owning datasets and their column docs to tables. A
+// dataset owns its Table() and every table its ColumnDocs name; a
+// ColumnDoc description replaces the Postgres column comment. Tables no
+// dataset owns (engine bookkeeping, derived tables) are kept unowned.
+func Merge(tables []Table, src Sources, now time.Time) *Dictionary {
+	catalog := make(map[string]dataset.CatalogEntry, len(src.Catalog))
+	for _, e := range src.Catalog {
+		catalog[e.Name] = e
+	}
+	lastSync := make(map[string]*time.Time, len(src.Stats))
+	for _, st := range src.Stats {
+		lastSync[st.Dataset] = st.LastSuccess
+	}
+
+	index := make(map[string]int, len(tables))
+	for i, t := range tables {
+		index[t.FullName()] = i
+	}
+
+	for _, ds := range src.Datasets {
+		owner := Owner{
+			Dataset:     ds.Name(),
+			Label:       catalog[ds.Name()].Label,
+			Description: catalog[ds.Name()].Description,
+			Cadence:     ds.Cadence(),
+			LastSync:    lastSync[ds.Name()],
+		}
+
+		docs := map[string]map[string]string{}
+		owned := []string{ds.Table()}
+		if d, ok := ds.(dataset.Documented); ok {
+			for _, doc := range d.Columns() {
+				table := doc.Table
+				if table == "" {
+					table = ds.Table()
+				}
+				if docs[table] == nil {
+					docs[table] = map[string]string{}
+					owned = append(owned, table)
+				}
+				docs[table][doc.Column] = doc.Description
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, name := range owned {
+			i, ok := index[name]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			t := &tables[i]
+			t.Owners = append(t.Owners, owner)
+			for j, c := range t.Columns {
+				if desc := docs[name][c.Name]; desc != "" {
+					t.Columns[j].Description = desc
+				}
+			}
+		}
+	}
+
+	for i := range tables {
+		sort.Slice(tables[i].Owners, func(a, b int) bool {
+			return tables[i].Owners[a].Dataset < tables[i].Owners[b].Dataset
+		})
+	}
+	return &Dictionary{Generated: now, Tables: tables}
+}
+
+// Build introspects schemas and merges src into the dictionary.
+func Build(ctx context.Context, pool db.Pool, schemas []string, src Sources) (*Dictionary, error) {
+	tables, err := Introspect(ctx, pool, schemas)
+	if err != nil {
+		return nil, err
+	}
+	return Merge(tables, src, time.Now().UTC()), nil
+}
@@ -0,0 +1,156 @@
+package datadict
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// stubDataset is a minimal Dataset with optional column docs.
+type stubDataset struct {
+	name, table string
+	docs        []dataset.ColumnDoc
+}
+
+func (s *stubDataset) Name() string                         { return s.name }
+func (s *stubDataset) Table() string                        { return s.table }
+func (s *stubDataset) Phase() dataset.Phase                 { return dataset.Phase1 }
+func (s *stubDataset) Cadence() dataset.Cadence             { return dataset.Annual }
+func (s *stubDataset) ShouldRun(time.Time, *time.Time) bool { return false }
+func (s *stubDataset) Columns() []dataset.ColumnDoc         { return s.docs }
+func (s *stubDataset) Sync(context.Context, db.Pool, fetcher.Fetcher, string) (*dataset.SyncResult, error) {
+	return nil, nil
+}
+
+func sampleTables() []Table {
+	return []Table{
+		{Schema: "fed_data", Name: "cbp_data", Rows: 1234567, Columns: []Column{
+			{Name: "fips_state", Type: "character(2)", Description: "pg comment"},
+			{Name: "emp", Type: "integer", Nullable: true},
+		}},
+		{Schema: "fed_data", Name: "sync_log", Rows: -1, Comment: "Engine bookkeeping"},
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM pg_class").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"nspname", "relname", "comment", "reltuples"}).
+			AddRow("fed_data", "cbp_data", "", int64(100)).
+			AddRow("geo", "counties", "County boundaries", int64(-1)))
+	mock.ExpectQuery("FROM pg_attribute").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"nspname", "relname", "attname", "type", "nullable", "comment"}).
+			AddRow("fed_data", "cbp_data", "fips_state", "character(2)", false, "").
+			AddRow("fed_data", "cbp_data", "emp", "integer", true, "Employees").
+			AddRow("geo", "counties", "geoid", "character varying(5)", false, "").
+			AddRow("geo", "other", "ignored", "text", true, ""))
+
+	tables, err := Introspect(context.Background(), mock, nil)
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "fed_data.cbp_data", tables[0].FullName())
+	assert.Equal(t, int64(100), tables[0].Rows)
+	require.Len(t, tables[0].Columns, 2)
+	assert.Equal(t, Column{Name: "emp", Type: "integer", Nullable: true, Description: "Employees"}, tables[0].Columns[1])
+	assert.Equal(t, "County boundaries", tables[1].Comment)
+	assert.Len(t, tables[1].Columns, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIntrospect_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM pg_class").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnError(assert.AnError)
+
+	_, err = Introspect(context.Background(), mock, []string{"fed_data"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "datadict: list tables")
+}
+
+func TestMerge(t *testing.T) {
+	synced := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := Sources{
+		Datasets: []dataset.Dataset{
+			&stubDataset{name: "cbp", table: "fed_data.cbp_data", docs: []dataset.ColumnDoc{
+				{Column: "fips_state", Description: "State FIPS code"},
+			}},
+			&stubDataset{name: "audit", table: "fed_data.missing", docs: []dataset.ColumnDoc{
+				{Table: "fed_data.cbp_data", Column: "emp", Description: "Mid-March employees"},
+			}},
+		},
+		Catalog: []dataset.CatalogEntry{{Name: "cbp", Label: "County Business Patterns", Description: "Establishments by county"}},
+		Stats:   []fedsync.DatasetRunStats{{Dataset: "cbp", LastSuccess: &synced}},
+	}
+
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	d := Merge(sampleTables(), src, now)
+	assert.Equal(t, now, d.Generated)
+
+	cbp := d.Tables[0]
+	require.Len(t, cbp.Owners, 2)
+	assert.Equal(t, "audit", cbp.Owners[0].Dataset)
+	assert.Nil(t, cbp.Owners[0].LastSync)
+	assert.Equal(t, "County Business Patterns", cbp.Owners[1].Label)
+	assert.Equal(t, "State FIPS code", cbp.Columns[0].Description)
+	assert.Equal(t, "Mid-March employees", cbp.Columns[1].Description)
+	require.NotNil(t, cbp.LastSync())
+	assert.True(t, cbp.LastSync().Equal(synced))
+
+	assert.Empty(t, d.Tables[1].Owners)
+	assert.Nil(t, d.Tables[1].LastSync())
+}
+
+func TestWrite_Markdown(t *testing.T) {
+	src := Sources{Datasets: []dataset.Dataset{&stubDataset{name: "cbp", table: "fed_data.cbp_data"}}}
+	d := Merge(sampleTables(), src, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, d, "md"))
+	out := buf.String()
+	assert.Contains(t, out, "| [fed_data.cbp_data](#fed_datacbp_data) | cbp | ~1,234,567 | never |")
+	assert.Contains(t, out, "## fed_data.sync_log\n\nEngine bookkeeping")
+	assert.Contains(t, out, "| fips_state | character(2) | no | pg comment |")
+	assert.Contains(t, out, "Rows: unknown")
+}
+
+func TestWrite_HTML(t *testing.T) {
+	tables := sampleTables()
+	tables[0].Columns[0].Description = "<b>FIPS</b>"
+	d := Merge(tables, Sources{}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, d, FormatHTML))
+	out := buf.String()
+	assert.Contains(t, out, `<h2 id="fed_data-cbp_data">fed_data.cbp_data</h2>`)
+	assert.Contains(t, out, "&lt;b&gt;FIPS&lt;/b&gt;")
+	assert.NotContains(t, out, "<b>FIPS</b>")
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat(" HTML ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatHTML, f)
+
+	_, err = ParseFormat("pdf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown format")
+}
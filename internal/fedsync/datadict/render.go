@@ -0,0 +1,169 @@
+package datadict
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// Output formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// ParseFormat validates an output format name, accepting "md" for
+// markdown.
+func ParseFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case FormatMarkdown, "md":
+		return FormatMarkdown, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	default:
+		return "", eris.Errorf("datadict: unknown format %q (markdown, html)", s)
+	}
+}
+
+// Write renders d to w in format (see ParseFormat).
+func Write(w io.Writer, d *Dictionary, format string) error {
+	format, err := ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	if format == FormatHTML {
+		return eris.Wrap(htmlTmpl.Execute(w, d), "datadict: render html")
+	}
+	return eris.Wrap(markdownTmpl.Execute(w, d), "datadict: render markdown")
+}
+
+var funcs = map[string]any{
+	"rows":   formatRows,
+	"synced": formatSynced,
+	"anchor": anchor,
+	"mdlink": mdAnchor,
+	"cell":   mdCell,
+	"date":   func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+}
+
+// formatRows renders a row estimate with thousands separators.
+func formatRows(n int64) string {
+	if n < 0 {
+		return "unknown"
+	}
+	s := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return "~" + b.String()
+}
+
+func formatSynced(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.UTC().Format("2006-01-02 15:04")
+}
+
+// anchor returns a fragment id for a table name.
+func anchor(name string) string {
+	return strings.ReplaceAll(name, ".", "-")
+}
+
+// mdAnchor returns the fragment GitHub-flavored Markdown generates for a
+// heading of the table name (lowercased, dots dropped).
+func mdAnchor(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), ".", "")
+}
+
+// mdCell escapes s for a Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var markdownTmpl = template.Must(template.New("markdown").Funcs(funcs).Parse(`# Data Dictionary
+
+Generated {{date .Generated}}. Row counts are planner estimates.
+
+| Table | Datasets | Rows | Last sync |
+|---|---|---|---|
+{{- range .Tables}}
+| [{{.FullName}}](#{{mdlink .FullName}}) | {{range $i, $o := .Owners}}{{if $i}}, {{end}}{{$o.Dataset}}{{end}} | {{rows .Rows}} | {{synced .LastSync}} |
+{{- end}}
+{{range .Tables}}
+## {{.FullName}}
+{{- if .Comment}}
+
+{{.Comment}}
+{{- end}}
+{{- range .Owners}}
+
+- **{{.Dataset}}**{{if .Label}} ({{.Label}}){{end}}{{if .Description}}: {{.Description}}{{end}}. Cadence {{.Cadence}}; last sync {{synced .LastSync}}.
+{{- end}}
+
+Rows: {{rows .Rows}}
+
+| Column | Type | Null | Description |
+|---|---|---|---|
+{{- range .Columns}}
+| {{.Name}} | {{cell .Type}} | {{if .Nullable}}yes{{else}}no{{end}} | {{cell .Description}} |
+{{- end}}
+{{end}}`))
+
+var htmlTmpl = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Data Dictionary</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; margin: 0.5rem 0 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.25rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.num { text-align: right; }
+code { font-size: 0.9em; }
+.muted { color: #656d76; }
+</style>
+</head>
+<body>
+<h1>Data Dictionary</h1>
+<p class="muted">Generated {{date .Generated}}. Row counts are planner estimates.</p>
+<table>
+<tr><th>Table</th><th>Datasets</th><th>Rows</th><th>Last sync</th></tr>
+{{- range .Tables}}
+<tr><td><a href="#{{anchor .FullName}}">{{.FullName}}</a></td><td>{{range $i, $o := .Owners}}{{if $i}}, {{end}}{{$o.Dataset}}{{end}}</td><td class="num">{{rows .Rows}}</td><td>{{synced .LastSync}}</td></tr>
+{{- end}}
+</table>
+{{range .Tables}}
+<h2 id="{{anchor .FullName}}">{{.FullName}}</h2>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+{{- if .Owners}}
+<ul>
+{{- range .Owners}}
+<li><strong>{{.Dataset}}</strong>{{if .Label}} ({{.Label}}){{end}}{{if .Description}}: {{.Description}}{{end}}. Cadence {{.Cadence}}; last sync {{synced .LastSync}}.</li>
+{{- end}}
+</ul>
+{{- end}}
+<p>Rows: {{rows .Rows}}</p>
+<table>
+<tr><th>Column</th><th>Type</th><th>Null</th><th>Description</th></tr>
+{{- range .Columns}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Type}}</td><td>{{if .Nullable}}yes{{else}}no{{end}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
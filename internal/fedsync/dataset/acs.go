@@ -0,0 +1,286 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/transform"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	acsBaseURL = "https://api.census.gov/data"
+
+	// acsMaxVars is the number of variables requested per call. The Census
+	// API allows 50 get fields; NAME takes one slot.
+	acsMaxVars = 49
+
+	// acsOldestYear bounds the backward search for the latest 5-year vintage.
+	acsOldestYear = 2019
+)
+
+var acsCols = []string{"year", "geo_level", "geo_id", "state_fips", "county_fips", "tract", "name", "variable", "value"}
+
+var acsConflictKeys = []string{"year", "geo_level", "geo_id", "variable"}
+
+// ACS syncs American Community Survey 5-year estimates at county and tract
+// level. The variable list and tract states are driven by fedsync.acs config.
+type ACS struct {
+	cfg     *config.Config
+	baseURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *ACS) Name() string { return "acs" }
+
+// Table implements Dataset.
+func (d *ACS) Table() string { return "fed_data.acs_data" }
+
+// Phase implements Dataset.
+func (d *ACS) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *ACS) Cadence() Cadence { return Annual }
+
+// ShouldRun implements Dataset. ACS 5-year estimates are released each December.
+func (d *ACS) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return AnnualAfter(now, lastSync, time.December)
+}
+
+// Sync fetches the latest available ACS 5-year vintage and loads it.
+func (d *ACS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	vars := d.variables()
+	if len(vars) == 0 {
+		log.Warn("no ACS variables configured, skipping")
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+	states, err := d.states()
+	if err != nil {
+		return nil, err
+	}
+
+	// Vintages lag about a year; walk backward until the county query succeeds.
+	for year := time.Now().Year() - 1; year >= acsOldestYear; year-- {
+		countyRows, err := d.fetchCounties(ctx, f, year, vars)
+		if err != nil {
+			if isCensusNotFound(err) {
+				log.Info("ACS vintage not available, trying earlier", zap.Int("year", year))
+				continue
+			}
+			return nil, err
+		}
+
+		total, err := d.upsert(ctx, pool, countyRows)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("ACS county data loaded", zap.Int("year", year), zap.Int64("rows", total))
+
+		for _, st := range states {
+			tractRows, err := d.fetchTracts(ctx, f, year, st, vars)
+			if err != nil {
+				return nil, err
+			}
+			n, err := d.upsert(ctx, pool, tractRows)
+			if err != nil {
+				return nil, err
+			}
+			total += n
+			log.Debug("ACS tract data loaded", zap.String("state", st), zap.Int64("rows", n))
+		}
+
+		return &SyncResult{
+			RowsSynced: total,
+			Metadata: map[string]any{
+				"year":      year,
+				"variables": len(vars),
+				"states":    len(states),
+			},
+		}, nil
+	}
+
+	log.Warn("ACS: no 5-year vintage available", zap.Int("oldest_year", acsOldestYear))
+	return &SyncResult{RowsSynced: 0}, nil
+}
+
+// variables returns the configured variables, upper-cased and de-duplicated.
+func (d *ACS) variables() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, v := range d.cfg.Fedsync.ACS.Variables {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+// states returns the state FIPS codes to pull tracts for. An empty config
+// means every state, DC, and Puerto Rico (ACS does not cover other territories).
+func (d *ACS) states() ([]string, error) {
+	var out []string
+	if len(d.cfg.Fedsync.ACS.States) == 0 {
+		for abbr, fips := range transform.StateAbbrToFIPS {
+			if abbr == "VI" {
+				continue
+			}
+			out = append(out, fips)
+		}
+		sort.Strings(out)
+		return out, nil
+	}
+	for _, abbr := range d.cfg.Fedsync.ACS.States {
+		fips, ok := transform.StateAbbrToFIPS[strings.ToUpper(strings.TrimSpace(abbr))]
+		if !ok {
+			return nil, eris.Errorf("acs: unknown state %q", abbr)
+		}
+		out = append(out, fips)
+	}
+	return out, nil
+}
+
+func (d *ACS) fetchCounties(ctx context.Context, f fetcher.Fetcher, year int, vars []string) ([][]any, error) {
+	var rows [][]any
+	for chunk := range slices.Chunk(vars, acsMaxVars) {
+		table, err := d.query(ctx, f, year, chunk, "for=county:*")
+		if err != nil {
+			return nil, eris.Wrapf(err, "acs: county year %d", year)
+		}
+		rows = append(rows, acsRows(table, year, "county", chunk)...)
+	}
+	return rows, nil
+}
+
+func (d *ACS) fetchTracts(ctx context.Context, f fetcher.Fetcher, year int, state string, vars []string) ([][]any, error) {
+	var rows [][]any
+	for chunk := range slices.Chunk(vars, acsMaxVars) {
+		table, err := d.query(ctx, f, year, chunk, "for=tract:*&in=state:"+state)
+		if err != nil {
+			return nil, eris.Wrapf(err, "acs: tract year %d state %s", year, state)
+		}
+		rows = append(rows, acsRows(table, year, "tract", chunk)...)
+	}
+	return rows, nil
+}
+
+// query calls the ACS 5-year endpoint and returns the raw table (header first).
+func (d *ACS) query(ctx context.Context, f fetcher.Fetcher, year int, vars []string, geo string) ([][]string, error) {
+	base := d.baseURL
+	if base == "" {
+		base = acsBaseURL
+	}
+	url := fmt.Sprintf("%s/%d/acs/acs5?get=NAME,%s&%s", base, year, strings.Join(vars, ","), geo)
+	if d.cfg.Fedsync.CensusKey != "" {
+		url += "&key=" + d.cfg.Fedsync.CensusKey
+	}
+
+	body, err := f.Download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, eris.Wrap(err, "read response")
+	}
+
+	var table [][]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, eris.Wrap(err, "parse json")
+	}
+	return table, nil
+}
+
+// acsRows pivots a Census API table into one row per geography and variable.
+// Annotated estimates (large negative sentinels for "not available") are skipped.
+func acsRows(table [][]string, year int, level string, vars []string) [][]any {
+	if len(table) < 2 {
+		return nil
+	}
+	colIdx := mapColumns(table[0])
+
+	var rows [][]any
+	for _, rec := range table[1:] {
+		state := getCol(rec, colIdx, "state")
+		county := getCol(rec, colIdx, "county")
+		if state == "" || county == "" {
+			continue
+		}
+		geoID := state + county
+		var tract any
+		if level == "tract" {
+			t := getCol(rec, colIdx, "tract")
+			if t == "" {
+				continue
+			}
+			geoID += t
+			tract = t
+		}
+		name := sanitizeUTF8(getCol(rec, colIdx, "name"))
+
+		for _, v := range vars {
+			val, ok := parseACSValue(getCol(rec, colIdx, v))
+			if !ok {
+				continue
+			}
+			rows = append(rows, []any{
+				int16(year), // #nosec G115 -- year is a calendar year, fits in int16
+				level, geoID, state, county, tract, name, v, val,
+			})
+		}
+	}
+	return rows
+}
+
+// parseACSValue parses an ACS estimate. Census encodes unavailable estimates
+// as large negative annotation values (e.g. -666666666).
+func parseACSValue(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "null" {
+		return 0, false
+	}
+	v := parseFloat64Or(s, -1e18)
+	if v <= -100000000 {
+		return 0, false
+	}
+	return v, true
+}
+
+func (d *ACS) upsert(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      acsCols,
+		ConflictKeys: acsConflictKeys,
+	}, rows)
+	if err != nil {
+		return 0, eris.Wrap(err, "acs: upsert")
+	}
+	return n, nil
+}
+
+// isCensusNotFound reports whether a Census API error means the requested
+// vintage or geography is not published. Only 404 qualifies: the API answers
+// 400 for a misspelled variable or a bad predicate, which must fail the sync
+// rather than silently fall back to an older vintage.
+func isCensusNotFound(err error) bool {
+	return strings.Contains(err.Error(), "status 404")
+}
@@ -0,0 +1,145 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func acsTestConfig(vars, states []string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Fedsync.CensusKey = "test-key"
+	cfg.Fedsync.ACS = config.ACSConfig{Variables: vars, States: states}
+	return cfg
+}
+
+func urlContains(parts ...string) any {
+	return mock.MatchedBy(func(url string) bool {
+		for _, p := range parts {
+			if !strings.Contains(url, p) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestACS_Metadata(t *testing.T) {
+	d := &ACS{}
+	assert.Equal(t, "acs", d.Name())
+	assert.Equal(t, "fed_data.acs_data", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Annual, d.Cadence())
+}
+
+func TestACS_ShouldRun(t *testing.T) {
+	d := &ACS{}
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+
+	now := time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, time.December, 20, 0, 0, 0, 0, time.UTC)
+	assert.False(t, d.ShouldRun(now, &last), "before December release")
+
+	now = time.Date(2025, time.December, 15, 0, 0, 0, 0, time.UTC)
+	assert.True(t, d.ShouldRun(now, &last))
+}
+
+func TestACS_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	ctx := context.Background()
+
+	// Latest vintage is not published yet; the previous one is.
+	f.EXPECT().Download(ctx, urlContains("for=county:*")).
+		Return(nil, errors.New("unexpected status 404")).Once()
+	f.EXPECT().Download(ctx, urlContains("get=NAME,B19013_001E,B01002_001E", "for=county:*", "key=test-key")).
+		Return(jsonBody(t, [][]string{
+			{"NAME", "B19013_001E", "B01002_001E", "state", "county"},
+			{"Travis County, Texas", "92731", "34.5", "48", "453"},
+			{"Loving County, Texas", "-666666666", "51.2", "48", "301"},
+		}), nil).Once()
+	f.EXPECT().Download(ctx, urlContains("for=tract:*&in=state:10")).
+		Return(jsonBody(t, [][]string{
+			{"NAME", "B19013_001E", "B01002_001E", "state", "county", "tract"},
+			{"Census Tract 1; Kent County; Delaware", "61000", "38.1", "10", "001", "040100"},
+		}), nil).Once()
+
+	expectBulkUpsert(pool, "fed_data.acs_data", acsCols, 3)
+	expectBulkUpsert(pool, "fed_data.acs_data", acsCols, 2)
+
+	d := &ACS{cfg: acsTestConfig([]string{"b19013_001e", "B01002_001E", "B19013_001E"}, []string{"de"})}
+	result, err := d.Sync(ctx, pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.RowsSynced)
+	assert.Equal(t, 2, result.Metadata["variables"])
+	assert.Equal(t, time.Now().Year()-2, result.Metadata["year"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestACS_Sync_NoVariables(t *testing.T) {
+	d := &ACS{cfg: acsTestConfig(nil, nil)}
+	result, err := d.Sync(context.Background(), nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.RowsSynced)
+}
+
+func TestACS_Sync_DownloadError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+	d := &ACS{cfg: acsTestConfig([]string{"B19013_001E"}, []string{"TX"})}
+	_, err := d.Sync(context.Background(), nil, f, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "acs: county")
+}
+
+func TestACS_Sync_BadRequestFails(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(nil, errors.New("download: unexpected status 400 from http://test")).Once()
+
+	d := &ACS{cfg: acsTestConfig([]string{"B19013_01E"}, []string{"TX"})}
+	_, err := d.Sync(context.Background(), nil, f, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestACS_States(t *testing.T) {
+	d := &ACS{cfg: acsTestConfig(nil, nil)}
+	all, err := d.states()
+	require.NoError(t, err)
+	assert.Len(t, all, 52, "50 states, DC, and PR")
+	assert.Equal(t, "01", all[0])
+	assert.NotContains(t, all, "78")
+
+	d = &ACS{cfg: acsTestConfig(nil, []string{"ZZ"})}
+	_, err = d.states()
+	assert.ErrorContains(t, err, "unknown state")
+}
+
+func TestACSRows(t *testing.T) {
+	table := [][]string{
+		{"NAME", "B25077_001E", "state", "county", "tract"},
+		{"Tract A", "350000", "06", "037", "101110"},
+		{"Tract B", "", "06", "037", "101122"},
+		{"Tract C", "-999999999", "06", "037", "101210"},
+	}
+	rows := acsRows(table, 2023, "tract", []string{"B25077_001E"})
+	require.Len(t, rows, 1)
+	assert.Equal(t, []any{int16(2023), "tract", "06037101110", "06", "037", "101110", "Tract A", "B25077_001E", 350000.0}, rows[0])
+
+	assert.Nil(t, acsRows([][]string{{"NAME"}}, 2023, "county", nil))
+}
@@ -27,8 +27,8 @@ type advDocSyncConfig struct {
 	conflictKeys  []string
 	batchSize     int
 	firmLimit     int
-	parseMapping  func(string) ([]docMapping, error)
-	pdfFallback   func([]string) []docMapping // nil for Part 2
+	parseMapping  func(context.Context, string) ([]docMapping, error)
+	pdfFallback   func(context.Context, []string) []docMapping // nil for Part 2
 	sectionParser func(*ocr.StructuredDocument, int, string) []sectionRow
 }
 
@@ -115,13 +115,13 @@ func syncADVDocuments(
 
 	var mappings []docMapping
 	if mappingPath != "" {
-		mappings, err = cfg.parseMapping(mappingPath)
+		mappings, err = cfg.parseMapping(ctx, mappingPath)
 		if err != nil {
 			return nil, eris.Wrapf(err, "%s: parse mapping CSV", cfg.name)
 		}
 	} else if cfg.pdfFallback != nil {
 		log.Warn("no mapping CSV found, falling back to PDF filenames")
-		mappings = cfg.pdfFallback(extractedFiles)
+		mappings = cfg.pdfFallback(ctx, extractedFiles)
 	} else {
 		return nil, eris.Errorf("%s: mapping CSV not found in ZIP", cfg.name)
 	}
@@ -1,8 +1,12 @@
 package dataset
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/ocr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -172,9 +176,32 @@ func TestCrsMappingsFromPDFsDoc(t *testing.T) {
 		"/tmp/extract/invalid.pdf",
 	}
 
-	mappings := crsMappingsFromPDFsDoc(files)
+	rejects := fedsync.NewRejects("adv_part3", 1, 10)
+	mappings := crsMappingsFromPDFsDoc(fedsync.WithRejects(context.Background(), rejects), files)
 	assert.Len(t, mappings, 2)
 	assert.Equal(t, 12345, mappings[0].CRDNumber)
 	assert.Equal(t, "crs_12345", mappings[0].DocID)
 	assert.Equal(t, 67890, mappings[1].CRDNumber)
+	assert.Equal(t, map[string]int64{"missing_crd": 1}, rejects.Counts())
+}
+
+func TestParseMappingDocs_RejectsMissingCRD(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.csv")
+	csv := "CRDNumber,BrochureID,PDFFileName,DateFiled\n" +
+		"12345,900,b_900.pdf,2025-03-31\n" +
+		",901,b_901.pdf,2025-03-31\n" +
+		"0,902,b_902.pdf,2025-03-31\n"
+	require.NoError(t, os.WriteFile(path, []byte(csv), 0o600))
+
+	for name, parse := range map[string]func(context.Context, string) ([]docMapping, error){
+		"part2": parseBrochureMappingDoc,
+		"part3": parseCRSMappingDoc,
+	} {
+		rejects := fedsync.NewRejects("adv_"+name, 1, 10)
+		mappings, err := parse(fedsync.WithRejects(context.Background(), rejects), path)
+		require.NoError(t, err, name)
+		require.Len(t, mappings, 1, name)
+		assert.Equal(t, 12345, mappings[0].CRDNumber, name)
+		assert.Equal(t, map[string]int64{"missing_crd": 2}, rejects.Counts(), name)
+	}
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
 
+	"github.com/sells-group/research-cli/internal/fedsync"
+
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -619,6 +621,15 @@ func (d *ADVPart1) syncHistorical(ctx context.Context, pool db.Pool, f fetcher.F
 		return 0, 0, 0, err2
 	}
 
+	// Pass 5: Load fund auditors, prime brokers, and administrators (Schedule D 7B1 items 23/24/26).
+	log.Info("pass 5: loading fund service providers")
+	providers, err := d.loadFundServiceProviders(ctx, pool, allFiles, latestFiling, log)
+	if err != nil {
+		log.Warn("failed to load fund service providers", zap.Error(err))
+	} else {
+		log.Info("fund service providers loaded", zap.Int64("rows", providers))
+	}
+
 	return firms, owners, funds, nil
 }
 
@@ -675,7 +686,7 @@ func (d *ADVPart1) loadPrivateFunds(ctx context.Context, pool db.Pool, files []s
 	var total int64
 	for _, path := range files {
 		base := strings.ToLower(filepath.Base(path))
-		if !strings.Contains(base, "schedule_d_7b1") {
+		if !strings.Contains(base, "schedule_d_7b1") || fundProviderType(base) != "" {
 			continue
 		}
 
@@ -689,6 +700,185 @@ func (d *ADVPart1) loadPrivateFunds(ctx context.Context, pool db.Pool, files []s
 	return total, nil
 }
 
+// fundProviderFiles maps Schedule D 7B1 item files to provider types:
+// 7B1A23 auditors, 7B1A24 prime brokers, 7B1A26 administrators.
+var fundProviderFiles = []struct {
+	marker       string
+	providerType string
+}{
+	{"schedule_d_7b1a23", "auditor"},
+	{"schedule_d_7b1a24", "prime_broker"},
+	{"schedule_d_7b1a26", "administrator"},
+}
+
+// fundProviderType returns the provider type for a Schedule D 7B1 item file, or "".
+func fundProviderType(base string) string {
+	for _, fp := range fundProviderFiles {
+		if strings.Contains(base, fp.marker) {
+			return fp.providerType
+		}
+	}
+	return ""
+}
+
+// loadFundServiceProviders streams Schedule D 7B1 provider item files, filters to
+// latest filing, and upserts per-fund service providers.
+func (d *ADVPart1) loadFundServiceProviders(ctx context.Context, pool db.Pool, files []string, latestFiling map[string]int64, log *zap.Logger) (int64, error) {
+	var total int64
+	for _, path := range files {
+		providerType := fundProviderType(strings.ToLower(filepath.Base(path)))
+		if providerType == "" {
+			continue
+		}
+
+		n, err := streamFundProviderFile(ctx, pool, path, providerType, latestFiling)
+		if err != nil {
+			return total, eris.Wrapf(err, "adv_part1: load fund providers from %s", filepath.Base(path))
+		}
+		log.Debug("loaded fund providers", zap.String("file", filepath.Base(path)), zap.Int64("rows", n))
+		total += n
+	}
+	return total, nil
+}
+
+// streamFundProviderFile loads one Schedule D 7B1 provider item file into
+// adv_fund_service_providers. The provider name is the first "name" column
+// that is not the fund name. The latest filing replaces each firm's
+// providers of providerType, so a fund that changes auditor drops the old
+// one; the delete and reload commit together.
+func streamFundProviderFile(ctx context.Context, pool db.Pool, path, providerType string, latestFiling map[string]int64) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "begin fund providers")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	crds := make([]int, 0, len(latestFiling))
+	for crd := range latestFiling {
+		crds = append(crds, parseIntOr(crd, 0))
+	}
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM fed_data.adv_fund_service_providers WHERE provider_type = $1 AND crd_number = ANY($2)`,
+		providerType, crds,
+	); err != nil {
+		return 0, eris.Wrap(err, "clear fund providers")
+	}
+
+	total, err := upsertFundProviderFile(ctx, tx, path, providerType, latestFiling)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, eris.Wrap(err, "commit fund providers")
+	}
+	return total, nil
+}
+
+// upsertFundProviderFile streams the rows of a provider item file that
+// belong to each firm's latest filing.
+func upsertFundProviderFile(ctx context.Context, pool db.Pool, path, providerType string, latestFiling map[string]int64) (int64, error) {
+	f, err := os.Open(path) // #nosec G304 -- path constructed from extracted ZIP in trusted temp directory
+	if err != nil {
+		return 0, eris.Wrap(err, "open fund provider file")
+	}
+	defer f.Close() //nolint:errcheck
+
+	reader := csv.NewReader(f)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "read header")
+	}
+	colIdx := mapColumns(header)
+
+	nameCol := -1
+	for i, col := range header {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if strings.Contains(col, "name") && !strings.Contains(col, "fund") {
+			nameCol = i
+			break
+		}
+	}
+	if nameCol < 0 {
+		return 0, eris.New("no provider name column")
+	}
+
+	filingToCRD := make(map[int64]string, len(latestFiling))
+	for crd, fid := range latestFiling {
+		filingToCRD[fid] = crd
+	}
+
+	cols := []string{"crd_number", "fund_id", "provider_type", "provider_name"}
+	conflictKeys := []string{"crd_number", "fund_id", "provider_type", "provider_name"}
+
+	seen := make(map[string]bool)
+	var batch [][]any
+	var total int64
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table: "fed_data.adv_fund_service_providers", Columns: cols, ConflictKeys: conflictKeys,
+		}, batch)
+		if err != nil {
+			return eris.Wrap(err, "upsert fund providers")
+		}
+		total += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue
+		}
+
+		filingIDStr := trimQuotes(getCol(record, colIdx, "filingid"))
+		if filingIDStr == "" {
+			filingIDStr = trimQuotes(getCol(record, colIdx, "filing_id"))
+		}
+		crd, ok := filingToCRD[parseInt64Or(filingIDStr, 0)]
+		if !ok {
+			continue
+		}
+
+		fundID := trimQuotes(getCol(record, colIdx, "fund id"))
+		if fundID == "" {
+			fundID = trimQuotes(getCol(record, colIdx, "fund_id"))
+		}
+		name := sanitizeUTF8(strings.TrimSpace(trimQuotes(safeGet(record, nameCol))))
+		if fundID == "" || name == "" {
+			continue
+		}
+
+		key := crd + "|" + fundID + "|" + strings.ToUpper(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		batch = append(batch, []any{parseIntOr(crd, 0), fundID, providerType, name})
+		if len(batch) >= advBatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
 // --- FOIA metadata helpers ---
 
 // foiaReportsMetadata represents the reports_metadata.json structure from the SEC IAPD system.
@@ -1049,6 +1239,11 @@ func streamBaseFile(ctx context.Context, pool db.Pool, path string, latestFiling
 
 		crd := trimQuotes(getColN(record, colIdx, "1e1"))
 		if crd == "" || filingID == 0 {
+			reason := "missing_crd"
+			if filingID == 0 {
+				reason = "missing_filing_id"
+			}
+			fedsync.Reject(ctx, reason, record)
 			continue
 		}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/docling"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/ocr"
 )
@@ -112,7 +113,8 @@ func matchBrochureItemKey(title string) string {
 }
 
 // parseBrochureMappingDoc reads the brochure mapping CSV and returns docMapping entries.
-func parseBrochureMappingDoc(path string) ([]docMapping, error) {
+// Rows without a CRD number are rejected.
+func parseBrochureMappingDoc(ctx context.Context, path string) ([]docMapping, error) {
 	f, err := os.Open(path) // #nosec G304 -- path from extracted ZIP in trusted temp directory
 	if err != nil {
 		return nil, eris.Wrap(err, "open mapping CSV")
@@ -142,6 +144,7 @@ func parseBrochureMappingDoc(path string) ([]docMapping, error) {
 
 		crd := parseIntOr(trimQuotes(getCol(record, colIdx, "crdnumber")), 0)
 		if crd == 0 {
+			fedsync.Reject(ctx, "missing_crd", record)
 			continue
 		}
 
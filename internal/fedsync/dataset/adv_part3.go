@@ -13,6 +13,7 @@ import (
 
 	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/ocr"
 )
@@ -138,7 +139,8 @@ func matchCRSSectionKey(title string) string {
 }
 
 // parseCRSMappingDoc reads the CRS mapping CSV and returns docMapping entries.
-func parseCRSMappingDoc(path string) ([]docMapping, error) {
+// Rows without a CRD number are rejected.
+func parseCRSMappingDoc(ctx context.Context, path string) ([]docMapping, error) {
 	f, err := os.Open(path) // #nosec G304 -- path from extracted ZIP in trusted temp directory
 	if err != nil {
 		return nil, eris.Wrap(err, "open CRS mapping CSV")
@@ -168,6 +170,7 @@ func parseCRSMappingDoc(path string) ([]docMapping, error) {
 
 		crd := parseIntOr(trimQuotes(getCol(record, colIdx, "crdnumber")), 0)
 		if crd == 0 {
+			fedsync.Reject(ctx, "missing_crd", record)
 			continue
 		}
 
@@ -202,7 +205,8 @@ func parseCRSMappingDoc(path string) ([]docMapping, error) {
 }
 
 // crsMappingsFromPDFsDoc builds CRS mappings from PDF filenames when no mapping CSV exists.
-func crsMappingsFromPDFsDoc(files []string) []docMapping {
+// PDFs whose name carries no CRD number are rejected.
+func crsMappingsFromPDFsDoc(ctx context.Context, files []string) []docMapping {
 	var result []docMapping
 	for _, fp := range files {
 		base := filepath.Base(fp)
@@ -216,6 +220,7 @@ func crsMappingsFromPDFsDoc(files []string) []docMapping {
 
 		crd := parseIntOr(numStr, 0)
 		if crd == 0 {
+			fedsync.Reject(ctx, "missing_crd", map[string]string{"file": base})
 			continue
 		}
 
@@ -2,17 +2,11 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"strings"
 	"time"
 
-	"github.com/rotisserie/eris"
-	"github.com/sells-group/research-cli/internal/db"
-	"go.uber.org/zap"
-
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/censusapi"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -38,68 +32,30 @@ func (d *ASM) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return AnnualAfter(now, lastSync, time.March)
 }
 
-// Sync fetches and loads Census Annual Survey of Manufactures data.
+// Sync fetches and loads Census Annual Survey of Manufactures data for the latest
+// published year.
 func (d *ASM) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
-	log := zap.L().With(zap.String("dataset", d.Name()))
-	log.Info("syncing ASM data")
-
-	// Try years from most recent backward until we find available data
-	// (Census data lags 1-2 years; the latest year may not be published yet)
-	for year := time.Now().Year() - 1; year >= 2020; year-- {
-		url := fmt.Sprintf("https://api.census.gov/data/%d/asm/product?get=NAICS2017,GEO_ID,VALADD,TOTVAL_SHIP,PRODWRKRS&for=us:*&key=%s",
-			year, d.cfg.Fedsync.CensusKey)
-
-		body, err := f.Download(ctx, url)
-		if err != nil {
-			if strings.Contains(err.Error(), "status 404") || strings.Contains(err.Error(), "status 400") {
-				log.Info("ASM data not available for year, trying earlier", zap.Int("year", year))
-				continue
+	return censusLatestDataset{
+		name:         d.Name(),
+		table:        d.Table(),
+		path:         "asm/product",
+		get:          []string{"NAICS2017", "GEO_ID", "VALADD", "TOTVAL_SHIP", "PRODWRKRS"},
+		geo:          "us:*",
+		cols:         []string{"year", "naics", "geo_id", "valadd", "totval_ship", "prodwrkrs"},
+		conflictKeys: []string{"year", "naics", "geo_id"},
+		row: func(year int, t *censusapi.Table, rec []string) []any {
+			geoID := t.Get(rec, "GEO_ID")
+			if geoID == "" {
+				return nil
 			}
-			return nil, eris.Wrapf(err, "asm: download census api year %d", year)
-		}
-
-		data, err := io.ReadAll(body)
-		_ = body.Close()
-		if err != nil {
-			return nil, eris.Wrap(err, "asm: read response")
-		}
-
-		var result [][]string
-		if err := json.Unmarshal(data, &result); err != nil {
-			return nil, eris.Wrap(err, "asm: parse json")
-		}
-
-		if len(result) < 2 {
-			return &SyncResult{RowsSynced: 0}, nil
-		}
-
-		var rows [][]any
-		for _, row := range result[1:] {
-			if len(row) < 5 {
-				continue
+			return []any{
+				int16(year), // #nosec G115 -- year is a calendar year (e.g. 2020-2030), fits in int16
+				t.Get(rec, "NAICS2017"),
+				geoID,
+				parseInt64Or(t.Get(rec, "VALADD"), 0),
+				parseInt64Or(t.Get(rec, "TOTVAL_SHIP"), 0),
+				parseIntOr(t.Get(rec, "PRODWRKRS"), 0),
 			}
-			rows = append(rows, []any{
-				int16(year),             // #nosec G115 -- year is a calendar year (e.g. 2020-2030), fits in int16
-				row[0],                  // naics
-				row[1],                  // geo_id
-				parseInt64Or(row[2], 0), // valadd
-				parseInt64Or(row[3], 0), // totval_ship
-				parseIntOr(row[4], 0),   // prodwrkrs
-			})
-		}
-
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table:        d.Table(),
-			Columns:      []string{"year", "naics", "geo_id", "valadd", "totval_ship", "prodwrkrs"},
-			ConflictKeys: []string{"year", "naics", "geo_id"},
-		}, rows)
-		if err != nil {
-			return nil, eris.Wrap(err, "asm: upsert")
-		}
-
-		return &SyncResult{RowsSynced: n, Metadata: map[string]any{"year": year}}, nil
-	}
-
-	log.Warn("ASM: no data available for any year from 2020 to present")
-	return &SyncResult{RowsSynced: 0}, nil
+		},
+	}.sync(ctx, pool, censusClient(d.cfg, f))
 }
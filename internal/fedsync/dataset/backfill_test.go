@@ -0,0 +1,107 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// mockPeriodDataset is a mockDataset that loads explicit periods.
+type mockPeriodDataset struct {
+	mockDataset
+	periods []Period
+	failOn  Period
+}
+
+func (m *mockPeriodDataset) SyncPeriod(_ context.Context, _ db.Pool, _ fetcher.Fetcher, _ string, p Period) (*SyncResult, error) {
+	m.periods = append(m.periods, p)
+	if p == m.failOn {
+		return nil, errors.New("not published")
+	}
+	return &SyncResult{RowsSynced: int64(p.Year)}, nil
+}
+
+func TestEngine_Backfill(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+
+	ds := &mockPeriodDataset{mockDataset: mockDataset{name: "cbp", phase: Phase1}, failOn: Period{Year: 2016}}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp:backfill").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'complete'").
+		WithArgs(int64(2015), []byte(`{"backfill_period":"2015"}`), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp:backfill").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(2)))
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'failed'").
+		WithArgs("not published", int64(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	results, err := engine.Backfill(context.Background(), "cbp", []Period{{Year: 2015}, {Year: 2016}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(2015), results[0].Rows)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Equal(t, []Period{{Year: 2015}, {Year: 2016}}, ds.periods)
+	assert.False(t, ds.synced, "Sync is not called")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEngine_Backfill_Unsupported(t *testing.T) {
+	_, syncLog := newMockSyncLog(t)
+	reg := &Registry{
+		datasets: map[string]Dataset{"fpds": &mockDataset{name: "fpds", phase: Phase1}},
+		order:    []string{"fpds"},
+	}
+
+	engine := NewEngine(nil, nil, syncLog, reg, t.TempDir())
+	_, err := engine.Backfill(context.Background(), "fpds", []Period{{Year: 2020}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support backfill")
+
+	_, err = engine.Backfill(context.Background(), "nope", []Period{{Year: 2020}})
+	assert.Error(t, err)
+}
+
+func TestEngine_Backfill_Locked(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+	ds := &mockPeriodDataset{mockDataset: mockDataset{name: "cbp", phase: Phase1}}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(false))
+	pool.ExpectRollback()
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	engine.SetRunLocks(fedsync.NewRunLocks(pool))
+	_, err := engine.Backfill(context.Background(), "cbp", []Period{{Year: 2015}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "being synced by another run")
+	assert.Empty(t, ds.periods)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestPeriodSyncers(t *testing.T) {
+	reg := NewRegistry(nil)
+	for _, name := range []string{"cbp", "susb", "oews", "qcew", "econ_census", "holdings_13f", "irs_soi_migration"} {
+		ds, err := reg.Get(name)
+		require.NoError(t, err)
+		_, ok := ds.(PeriodSyncer)
+		assert.True(t, ok, name)
+	}
+}
@@ -0,0 +1,93 @@
+package dataset
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// defaultStreamBatchRows is the per-table buffer used by streaming loaders
+// when fedsync.streaming.batch_rows is unset.
+const defaultStreamBatchRows = 5000
+
+// streamBatchRows returns the configured per-table row buffer for streaming
+// loaders, or def when cfg is nil or leaves it unset.
+func streamBatchRows(cfg *config.Config, def int) int {
+	if cfg == nil || cfg.Fedsync.Streaming.BatchRows <= 0 {
+		return def
+	}
+	return cfg.Fedsync.Streaming.BatchRows
+}
+
+// batchWriter buffers rows for one table and upserts them each time the
+// buffer fills. Add blocks while the batch is written, so producers (a
+// parser goroutine or a pool of decoders) never run more than one batch
+// ahead of Postgres. Safe for concurrent use.
+type batchWriter struct {
+	pool db.Pool
+	cfg  db.UpsertConfig
+	size int
+
+	mu    sync.Mutex
+	rows  [][]any
+	total int64
+}
+
+// newBatchWriter returns a writer that upserts into cfg.Table in batches
+// of size rows.
+func newBatchWriter(pool db.Pool, cfg db.UpsertConfig, size int) *batchWriter {
+	if size <= 0 {
+		size = defaultStreamBatchRows
+	}
+	return &batchWriter{pool: pool, cfg: cfg, size: size, rows: make([][]any, 0, size)}
+}
+
+// Add buffers rows, writing the buffer out each time it reaches the batch
+// size.
+func (w *batchWriter) Add(ctx context.Context, rows ...[]any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, row := range rows {
+		w.rows = append(w.rows, row)
+		if len(w.rows) >= w.size {
+			if err := w.flushLocked(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows.
+func (w *batchWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked(ctx)
+}
+
+// Total returns the rows written so far.
+func (w *batchWriter) Total() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.total
+}
+
+func (w *batchWriter) flushLocked(ctx context.Context) error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	n, err := db.BulkUpsert(ctx, w.pool, w.cfg, w.rows)
+	if err != nil {
+		return eris.Wrapf(err, "upsert %s", w.cfg.Table)
+	}
+	w.total += n
+	// Drop references to the written rows so they can be collected while
+	// the backing array is reused.
+	clear(w.rows)
+	w.rows = w.rows[:0]
+	return nil
+}
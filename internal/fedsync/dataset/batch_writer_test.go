@@ -0,0 +1,59 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+func TestStreamBatchRows(t *testing.T) {
+	assert.Equal(t, 2000, streamBatchRows(nil, 2000))
+	assert.Equal(t, 2000, streamBatchRows(&config.Config{}, 2000))
+
+	cfg := &config.Config{}
+	cfg.Fedsync.Streaming.BatchRows = 250
+	assert.Equal(t, 250, streamBatchRows(cfg, 2000))
+}
+
+func TestBatchWriter_FlushesAtSize(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cols := []string{"id"}
+	expectBulkUpsert(pool, "fed_data.test", cols, 2)
+	expectBulkUpsert(pool, "fed_data.test", cols, 2)
+	expectBulkUpsert(pool, "fed_data.test", cols, 1)
+
+	w := newBatchWriter(pool, db.UpsertConfig{Table: "fed_data.test", Columns: cols, ConflictKeys: cols}, 2)
+	ctx := context.Background()
+	require.NoError(t, w.Add(ctx, []any{1}, []any{2}, []any{3}))
+	require.NoError(t, w.Add(ctx, []any{4}, []any{5}))
+	assert.Len(t, w.rows, 1, "buffer holds at most one partial batch")
+
+	require.NoError(t, w.Flush(ctx))
+	require.NoError(t, w.Flush(ctx), "flushing an empty buffer is a no-op")
+	assert.Equal(t, int64(5), w.Total())
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBatchWriter_UpsertError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin().WillReturnError(errors.New("db down"))
+
+	w := newBatchWriter(pool, db.UpsertConfig{Table: "fed_data.test", Columns: []string{"id"}, ConflictKeys: []string{"id"}}, 1)
+	err = w.Add(context.Background(), []any{1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upsert fed_data.test")
+	assert.Zero(t, w.Total())
+}
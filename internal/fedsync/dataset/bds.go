@@ -0,0 +1,285 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	bdsBaseURL = "https://api.census.gov/data/timeseries/bds"
+
+	// bdsFirstYear is the first year of published BDS data.
+	bdsFirstYear = 1978
+
+	// bdsLagYears is the typical lag between the reference year and the
+	// September release (e.g. 2022 data published in 2024).
+	bdsLagYears = 2
+
+	// bdsMSAGeo is the Census API geography name for CBSAs.
+	bdsMSAGeo = "metropolitan statistical area/micropolitan statistical area"
+)
+
+// bdsVars are the BDS measures requested for each geography and sector.
+var bdsVars = []string{
+	"FIRM", "ESTAB", "EMP",
+	"ESTABS_ENTRY", "ESTABS_ENTRY_RATE", "ESTABS_EXIT", "ESTABS_EXIT_RATE",
+	"JOB_CREATION", "JOB_CREATION_RATE", "JOB_DESTRUCTION", "JOB_DESTRUCTION_RATE",
+	"NET_JOB_CREATION", "FIRMDEATH_FIRMS",
+}
+
+// bdsSectors are the NAICS sector codes pulled for every geography. "00" is
+// the all-industries total.
+var bdsSectors = []string{
+	"00", "11", "21", "22", "23", "31-33", "42", "44-45", "48-49", "51", "52",
+	"53", "54", "55", "56", "61", "62", "71", "72", "81",
+}
+
+// bdsGeoLevel describes one BDS geography query.
+type bdsGeoLevel struct {
+	level string // stored geo_level
+	query string // Census "for" clause
+}
+
+var bdsGeoLevels = []bdsGeoLevel{
+	{level: "us", query: "us:*"},
+	{level: "state", query: "state:*"},
+	{level: "county", query: "county:*"},
+	{level: "msa", query: bdsMSAGeo + ":*"},
+}
+
+var bdsCols = []string{
+	"year", "geo_level", "geo_id", "naics", "name",
+	"firms", "estabs", "emp",
+	"estabs_entry", "estabs_entry_rate", "estabs_exit", "estabs_exit_rate",
+	"job_creation", "job_creation_rate", "job_destruction", "job_destruction_rate",
+	"net_job_creation", "firm_deaths",
+}
+
+var bdsConflictKeys = []string{"year", "geo_level", "geo_id", "naics"}
+
+// BDS syncs Census Business Dynamics Statistics: establishment entry and exit
+// and job creation and destruction by NAICS sector for the nation, states,
+// counties, and metro/micro areas.
+type BDS struct {
+	cfg     *config.Config
+	baseURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *BDS) Name() string { return "bds" }
+
+// Table implements Dataset.
+func (d *BDS) Table() string { return "fed_data.bds_data" }
+
+// Phase implements Dataset.
+func (d *BDS) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *BDS) Cadence() Cadence { return Annual }
+
+// ShouldRun implements Dataset. BDS is released each September.
+func (d *BDS) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return AnnualAfter(now, lastSync, time.September)
+}
+
+// Sync loads the latest published BDS year.
+func (d *BDS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, false)
+}
+
+// SyncFull loads every BDS year from 1978.
+func (d *BDS) SyncFull(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, true)
+}
+
+func (d *BDS) sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, full bool) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	latest, err := d.latestYear(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if latest == 0 {
+		log.Warn("BDS: no published year found")
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+
+	from := latest
+	if full {
+		from = bdsFirstYear
+	}
+	log.Info("syncing BDS data", zap.Int("from_year", from), zap.Int("to_year", latest))
+
+	var total int64
+	for year := from; year <= latest; year++ {
+		n, err := d.syncYear(ctx, pool, f, year)
+		if err != nil {
+			return nil, err
+		}
+		total += n
+		log.Debug("BDS year loaded", zap.Int("year", year), zap.Int64("rows", n))
+	}
+
+	log.Info("BDS sync complete", zap.Int64("rows", total))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"from_year": from,
+			"to_year":   latest,
+		},
+	}, nil
+}
+
+// latestYear probes the national total for the newest published year.
+func (d *BDS) latestYear(ctx context.Context, f fetcher.Fetcher) (int, error) {
+	newest := time.Now().Year() - bdsLagYears
+	for year := newest; year > newest-3; year-- {
+		table, err := d.query(ctx, f, year, bdsGeoLevels[0], "00")
+		if err != nil {
+			return 0, err
+		}
+		if len(table) >= 2 {
+			return year, nil
+		}
+	}
+	return 0, nil
+}
+
+func (d *BDS) syncYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher, year int) (int64, error) {
+	var total int64
+	for _, geo := range bdsGeoLevels {
+		var rows [][]any
+		for _, sector := range bdsSectors {
+			table, err := d.query(ctx, f, year, geo, sector)
+			if err != nil {
+				return total, err
+			}
+			rows = append(rows, bdsRows(table, year, geo.level, sector)...)
+		}
+		n, err := d.upsert(ctx, pool, rows)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// query calls the BDS endpoint for one year, geography, and sector. An
+// unpublished combination (404 or an empty 204 body) returns a nil table;
+// a 400 means the query itself is wrong and is returned as an error.
+func (d *BDS) query(ctx context.Context, f fetcher.Fetcher, year int, geo bdsGeoLevel, sector string) ([][]string, error) {
+	base := d.baseURL
+	if base == "" {
+		base = bdsBaseURL
+	}
+	u := fmt.Sprintf("%s?get=NAME,%s&for=%s&YEAR=%d&NAICS=%s",
+		base, strings.Join(bdsVars, ","), strings.ReplaceAll(geo.query, " ", "%20"), year, sector)
+	if d.cfg != nil && d.cfg.Fedsync.CensusKey != "" {
+		u += "&key=" + d.cfg.Fedsync.CensusKey
+	}
+
+	body, err := f.Download(ctx, u)
+	if err != nil {
+		if isCensusNotFound(err) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "bds: download %s %s %d", geo.level, sector, year)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, eris.Wrap(err, "bds: read response")
+	}
+	if len(data) == 0 {
+		return nil, nil // 204 No Content
+	}
+
+	var table [][]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, eris.Wrapf(err, "bds: parse json %s %s %d", geo.level, sector, year)
+	}
+	return table, nil
+}
+
+// bdsRows converts a BDS API table to rows. Suppressed cells become NULL.
+func bdsRows(table [][]string, year int, level, sector string) [][]any {
+	if len(table) < 2 {
+		return nil
+	}
+	colIdx := mapColumns(table[0])
+
+	var rows [][]any
+	for _, rec := range table[1:] {
+		geoID := bdsGeoID(rec, colIdx, level)
+		if geoID == "" {
+			continue
+		}
+		row := []any{
+			int16(year), // #nosec G115 -- year is a calendar year, fits in int16
+			level, geoID, sector, sanitizeUTF8(getCol(rec, colIdx, "name")),
+		}
+		for _, v := range bdsVars {
+			row = append(row, parseBDSValue(getCol(rec, colIdx, v)))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// bdsGeoID builds the stored geography identifier: "US", the 2-digit state
+// FIPS, the 5-digit county FIPS, or the 5-digit CBSA code.
+func bdsGeoID(rec []string, colIdx map[string]int, level string) string {
+	switch level {
+	case "us":
+		return "US"
+	case "state":
+		return getCol(rec, colIdx, "state")
+	case "county":
+		state, county := getCol(rec, colIdx, "state"), getCol(rec, colIdx, "county")
+		if state == "" || county == "" {
+			return ""
+		}
+		return state + county
+	case "msa":
+		return getCol(rec, colIdx, bdsMSAGeo)
+	}
+	return ""
+}
+
+// parseBDSValue parses a BDS measure. Suppressed or withheld cells ("(D)",
+// "(X)", "N", null) return nil.
+func parseBDSValue(s string) any {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+func (d *BDS) upsert(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      bdsCols,
+		ConflictKeys: bdsConflictKeys,
+	}, rows)
+	if err != nil {
+		return 0, eris.Wrap(err, "bds: upsert")
+	}
+	return n, nil
+}
@@ -0,0 +1,136 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestBDS_Metadata(t *testing.T) {
+	d := &BDS{}
+	assert.Equal(t, "bds", d.Name())
+	assert.Equal(t, "fed_data.bds_data", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Annual, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+	assert.Implements(t, (*FullSyncer)(nil), d)
+}
+
+func TestBDS_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	latest := time.Now().Year() - bdsLagYears
+	year := fmt.Sprintf("YEAR=%d", latest-1)
+
+	header := append([]string{"NAME"}, bdsVars...)
+	row := func(name string, geo ...string) []string {
+		r := []string{name}
+		for range bdsVars {
+			r = append(r, "12")
+		}
+		return append(r, geo...)
+	}
+
+	f.EXPECT().Download(ctx, mock.Anything).RunAndReturn(func(_ context.Context, u string) (io.ReadCloser, error) {
+		switch {
+		case !strings.Contains(u, year):
+			// Newest year not yet published.
+			return io.NopCloser(strings.NewReader("")), nil
+		case strings.Contains(u, "for=us:*") && strings.Contains(u, "NAICS=00"):
+			return jsonBody(t, [][]string{append(header, "us"), row("United States", "1")}), nil
+		case strings.Contains(u, "for=state:*") && strings.Contains(u, "NAICS=23"):
+			suppressed := row("Wyoming", "56")
+			suppressed[2] = "(D)"
+			return jsonBody(t, [][]string{append(header, "state"), row("Texas", "48"), suppressed}), nil
+		case strings.Contains(u, "for=county:*") && strings.Contains(u, "NAICS=00"):
+			return jsonBody(t, [][]string{append(header, "state", "county"), row("Travis County, Texas", "48", "453")}), nil
+		case strings.Contains(u, "metropolitan%20statistical%20area") && strings.Contains(u, "NAICS=54"):
+			return jsonBody(t, [][]string{append(header, bdsMSAGeo), row("Austin-Round Rock, TX", "12420")}), nil
+		default:
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+	})
+
+	expectBulkUpsert(pool, "fed_data.bds_data", bdsCols, 1)
+	expectBulkUpsert(pool, "fed_data.bds_data", bdsCols, 2)
+	expectBulkUpsert(pool, "fed_data.bds_data", bdsCols, 1)
+	expectBulkUpsert(pool, "fed_data.bds_data", bdsCols, 1)
+
+	d := &BDS{cfg: &config.Config{}}
+	res, err := d.Sync(ctx, pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), res.RowsSynced)
+	assert.Equal(t, latest-1, res.Metadata["to_year"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBDS_Sync_NoYear(t *testing.T) {
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(ctx, urlContains("for=us:*", "NAICS=00")).
+		Return(nil, errors.New("unexpected status 404")).Times(3)
+
+	d := &BDS{cfg: &config.Config{}}
+	res, err := d.Sync(ctx, nil, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.RowsSynced)
+}
+
+func TestBDS_Sync_DownloadError(t *testing.T) {
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(ctx, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+
+	d := &BDS{cfg: &config.Config{Fedsync: config.FedsyncConfig{CensusKey: "k"}}}
+	_, err := d.Sync(ctx, nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bds: download us 00")
+}
+
+func TestBDS_Sync_BadRequestFails(t *testing.T) {
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(ctx, mock.Anything).
+		Return(nil, errors.New("download: unexpected status 400 from http://test")).Once()
+
+	d := &BDS{cfg: &config.Config{}}
+	_, err := d.Sync(ctx, nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestBDSRows(t *testing.T) {
+	header := append([]string{"NAME"}, bdsVars...)
+	rec := []string{"Travis County, Texas"}
+	for range bdsVars {
+		rec = append(rec, "(X)")
+	}
+	rec[2] = "1500"
+
+	rows := bdsRows([][]string{append(header, "state", "county"), append(rec, "48", "453")}, 2022, "county", "00")
+	require.Len(t, rows, 1)
+	assert.Equal(t, int16(2022), rows[0][0])
+	assert.Equal(t, "48453", rows[0][2])
+	assert.Equal(t, 1500.0, rows[0][6]) // estabs
+	assert.Nil(t, rows[0][5])           // firms suppressed
+
+	// Missing geography columns are skipped.
+	assert.Empty(t, bdsRows([][]string{append(header, "state"), append(rec, "48")}, 2022, "county", "00"))
+	assert.Nil(t, bdsRows([][]string{header}, 2022, "us", "00"))
+}
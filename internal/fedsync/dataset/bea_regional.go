@@ -3,7 +3,9 @@ package dataset
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -22,17 +24,23 @@ import (
 var beaTables = []string{"CAGDP1", "CAINC1", "CAINC4"}
 
 var beaCols = []string{
-	"table_name", "geo_fips", "geo_name", "line_code", "description", "unit", "year", "value",
+	"table_name", "geo_level", "geo_fips", "geo_name", "line_code", "description", "unit", "year", "value",
 }
 
-var beaConflictKeys = []string{"table_name", "geo_fips", "line_code", "year"}
+var beaConflictKeys = []string{"table_name", "geo_level", "geo_fips", "line_code", "year"}
 
-const beaBatchSize = 5000
+const (
+	beaBatchSize  = 5000
+	beaAPIBaseURL = "https://apps.bea.gov/api/data"
+)
 
-// BEARegional syncs BEA regional GDP and income data by county.
+// BEARegional syncs BEA regional GDP, income, and compensation data. With a
+// BEA API key it pulls the configured series for each configured geography
+// (county, MSA, state); without one it falls back to the bulk county ZIPs.
 type BEARegional struct {
 	cfg     *config.Config
 	baseURL string // override for testing
+	apiURL  string // override for testing
 }
 
 // Name implements Dataset.
@@ -57,6 +65,10 @@ func (d *BEARegional) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher,
 	log := zap.L().With(zap.String("dataset", d.Name()))
 	log.Info("starting bea_regional sync")
 
+	if d.cfg != nil && d.cfg.Fedsync.BEAKey != "" {
+		return d.syncAPI(ctx, pool, f, log)
+	}
+
 	var totalRows int64
 
 	for _, table := range beaTables {
@@ -179,20 +191,13 @@ func (d *BEARegional) syncTable(ctx context.Context, pool db.Pool, f fetcher.Fet
 		unit = strings.Trim(unit, `"`)
 
 		for _, yc := range yearCols {
-			valStr := strings.TrimSpace(safeGet(row, yc.idx))
-			valStr = strings.Trim(valStr, `"`)
-			if valStr == "" || valStr == "(NA)" || valStr == "(D)" || valStr == "(L)" || valStr == "(T)" {
-				continue
-			}
-			// Strip commas for numeric parsing.
-			valStr = strings.ReplaceAll(valStr, ",", "")
-			val, err := strconv.ParseFloat(valStr, 64)
-			if err != nil {
+			val, ok := parseBEAValue(safeGet(row, yc.idx))
+			if !ok {
 				continue
 			}
 
 			batch = append(batch, []any{
-				table, geoFIPS, geoName, lineCode, desc, unit, yc.year, val,
+				table, beaGeoLevelForFIPS(geoFIPS), geoFIPS, geoName, lineCode, desc, unit, yc.year, val,
 			})
 
 			if len(batch) >= beaBatchSize {
@@ -218,3 +223,197 @@ func safeGet(row []string, idx int) string {
 	}
 	return row[idx]
 }
+
+// beaSeries is one BEA regional table + line code pair.
+type beaSeries struct {
+	Table    string
+	LineCode int
+}
+
+// parseBEASeries parses "TABLE:LINECODE" entries from config.
+func parseBEASeries(specs []string) ([]beaSeries, error) {
+	out := make([]beaSeries, 0, len(specs))
+	for _, spec := range specs {
+		table, line, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		lineCode, err := strconv.Atoi(strings.TrimSpace(line))
+		if !ok || table == "" || err != nil {
+			return nil, eris.Errorf("bea_regional: invalid series %q (want TABLE:LINECODE)", spec)
+		}
+		out = append(out, beaSeries{Table: strings.ToUpper(strings.TrimSpace(table)), LineCode: lineCode})
+	}
+	return out, nil
+}
+
+// beaGeoLevel maps a BEA GeoFips selector to the geo_level stored per row.
+func beaGeoLevel(geo string) string {
+	switch strings.ToUpper(geo) {
+	case "COUNTY":
+		return "county"
+	case "MSA":
+		return "msa"
+	case "STATE":
+		return "state"
+	default:
+		return strings.ToLower(geo)
+	}
+}
+
+// beaGeoLevelForFIPS classifies bulk-file rows, which hold the nation,
+// states, and counties. County tables give the US total as "00000" and
+// state totals as "SS000".
+func beaGeoLevelForFIPS(fips string) string {
+	switch {
+	case fips == "00000" || fips == "00":
+		return "national"
+	case len(fips) == 2 || strings.HasSuffix(fips, "000"):
+		return "state"
+	default:
+		return "county"
+	}
+}
+
+// parseBEAValue parses a BEA data value, reporting false for suppressed or
+// unavailable markers such as (NA) and (D).
+func parseBEAValue(s string) (float64, bool) {
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	if s == "" || strings.HasPrefix(s, "(") {
+		return 0, false
+	}
+	val, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+	return val, true
+}
+
+type beaAPIError struct {
+	APIErrorCode        string `json:"APIErrorCode"`
+	APIErrorDescription string `json:"APIErrorDescription"`
+}
+
+type beaAPIResponse struct {
+	BEAAPI struct {
+		Error   *beaAPIError `json:"Error"`
+		Results struct {
+			Statistic     string       `json:"Statistic"`
+			UnitOfMeasure string       `json:"UnitOfMeasure"`
+			Error         *beaAPIError `json:"Error"`
+			Data          []struct {
+				GeoFips    string `json:"GeoFips"`
+				GeoName    string `json:"GeoName"`
+				TimePeriod string `json:"TimePeriod"`
+				CLUnit     string `json:"CL_UNIT"`
+				DataValue  string `json:"DataValue"`
+			} `json:"Data"`
+		} `json:"Results"`
+	} `json:"BEAAPI"`
+}
+
+// syncAPI pulls every configured series for every configured geography from
+// the BEA API.
+func (d *BEARegional) syncAPI(ctx context.Context, pool db.Pool, f fetcher.Fetcher, log *zap.Logger) (*SyncResult, error) {
+	series, err := parseBEASeries(d.cfg.Fedsync.BEA.Series)
+	if err != nil {
+		return nil, err
+	}
+	geos := d.cfg.Fedsync.BEA.Geographies
+	if len(series) == 0 || len(geos) == 0 {
+		return nil, eris.New("bea_regional: fedsync.bea.series and fedsync.bea.geographies must not be empty")
+	}
+
+	var totalRows int64
+	for _, s := range series {
+		for _, geo := range geos {
+			rows, fErr := d.fetchAPISeries(ctx, f, s, geo)
+			if fErr != nil {
+				return nil, eris.Wrapf(fErr, "bea_regional: %s line %d (%s)", s.Table, s.LineCode, geo)
+			}
+			for start := 0; start < len(rows); start += beaBatchSize {
+				end := min(start+beaBatchSize, len(rows))
+				n, uErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+					Table:        d.Table(),
+					Columns:      beaCols,
+					ConflictKeys: beaConflictKeys,
+				}, rows[start:end])
+				if uErr != nil {
+					return nil, eris.Wrap(uErr, "bea_regional: upsert")
+				}
+				totalRows += n
+			}
+			log.Info("bea series synced",
+				zap.String("table", s.Table),
+				zap.Int("line_code", s.LineCode),
+				zap.String("geo", geo),
+				zap.Int("rows", len(rows)),
+			)
+		}
+	}
+
+	log.Info("bea_regional sync complete", zap.Int64("rows", totalRows))
+	return &SyncResult{
+		RowsSynced: totalRows,
+		Metadata: map[string]any{
+			"source":      "api",
+			"series":      d.cfg.Fedsync.BEA.Series,
+			"geographies": geos,
+		},
+	}, nil
+}
+
+// fetchAPISeries fetches one series for one geography and returns upsert rows.
+func (d *BEARegional) fetchAPISeries(ctx context.Context, f fetcher.Fetcher, s beaSeries, geo string) ([][]any, error) {
+	base := d.apiURL
+	if base == "" {
+		base = beaAPIBaseURL
+	}
+	q := url.Values{}
+	q.Set("UserID", d.cfg.Fedsync.BEAKey)
+	q.Set("method", "GetData")
+	q.Set("datasetname", "Regional")
+	q.Set("TableName", s.Table)
+	q.Set("LineCode", strconv.Itoa(s.LineCode))
+	q.Set("GeoFips", geo)
+	q.Set("Year", "ALL")
+	q.Set("ResultFormat", "json")
+
+	body, err := f.Download(ctx, base+"?"+q.Encode())
+	if err != nil {
+		return nil, eris.Wrap(err, "download")
+	}
+	defer body.Close() //nolint:errcheck
+
+	var resp beaAPIResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, eris.Wrap(err, "decode response")
+	}
+	apiErr := resp.BEAAPI.Error
+	if apiErr == nil {
+		apiErr = resp.BEAAPI.Results.Error
+	}
+	if apiErr != nil {
+		return nil, eris.Errorf("api error %s: %s", apiErr.APIErrorCode, apiErr.APIErrorDescription)
+	}
+
+	level := beaGeoLevel(geo)
+	desc := sanitizeUTF8(resp.BEAAPI.Results.Statistic)
+	var rows [][]any
+	for _, row := range resp.BEAAPI.Results.Data {
+		year, yErr := strconv.Atoi(strings.TrimSpace(row.TimePeriod))
+		if yErr != nil {
+			continue
+		}
+		val, ok := parseBEAValue(row.DataValue)
+		if !ok {
+			continue
+		}
+		unit := row.CLUnit
+		if unit == "" {
+			unit = resp.BEAAPI.Results.UnitOfMeasure
+		}
+		rows = append(rows, []any{
+			s.Table, level, strings.TrimSpace(row.GeoFips), sanitizeUTF8(strings.TrimSpace(row.GeoName)),
+			s.LineCode, desc, unit, year, val,
+		})
+	}
+	return rows, nil
+}
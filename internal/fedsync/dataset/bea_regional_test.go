@@ -3,8 +3,10 @@ package dataset
 import (
 	"context"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sells-group/research-cli/internal/config"
 	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
@@ -403,3 +406,114 @@ func TestBEARegional_CommaInValue(t *testing.T) {
 	assert.Equal(t, int64(3), result.RowsSynced)
 	assert.NoError(t, pool.ExpectationsWereMet())
 }
+
+const beaAPITestResponse = `{"BEAAPI":{"Results":{
+  "Statistic":"Real GDP (thousands of chained 2017 dollars)",
+  "UnitOfMeasure":"Thousands of chained 2017 dollars",
+  "Data":[
+    {"Code":"CAGDP2-1","GeoFips":"12420","GeoName":"Austin-Round Rock-San Marcos, TX (Metropolitan Statistical Area)","TimePeriod":"2022","CL_UNIT":"Thousands of dollars","DataValue":"213,456,789"},
+    {"Code":"CAGDP2-1","GeoFips":"12420","GeoName":"Austin-Round Rock-San Marcos, TX (Metropolitan Statistical Area)","TimePeriod":"2023","CL_UNIT":"Thousands of dollars","DataValue":"(D)"},
+    {"Code":"CAGDP2-1","GeoFips":"19100","GeoName":"Dallas-Fort Worth-Arlington, TX","TimePeriod":"2023","CL_UNIT":"","DataValue":"654321"}
+  ]}}}`
+
+func beaAPIConfig(series, geos []string) *config.Config {
+	return &config.Config{Fedsync: config.FedsyncConfig{
+		BEAKey: "test-key",
+		BEA:    config.BEAConfig{Series: series, Geographies: geos},
+	}}
+}
+
+func TestBEARegional_SyncAPI(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.HasPrefix(u, "https://bea.test/api?") &&
+			strings.Contains(u, "TableName=CAGDP2") &&
+			strings.Contains(u, "LineCode=1") &&
+			strings.Contains(u, "GeoFips=MSA") &&
+			strings.Contains(u, "UserID=test-key")
+	})).Return(io.NopCloser(strings.NewReader(beaAPITestResponse)), nil).Once()
+
+	// (D) is suppressed, leaving two rows.
+	expectBulkUpsert(pool, "fed_data.bea_regional", beaCols, 2)
+
+	d := &BEARegional{cfg: beaAPIConfig([]string{"CAGDP2:1"}, []string{"MSA"}), apiURL: "https://bea.test/api"}
+	result, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, "api", result.Metadata["source"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBEARegional_SyncAPI_MultipleSeriesAndGeos(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(beaAPITestResponse)), nil
+		}).Times(4)
+
+	for range 4 {
+		expectBulkUpsert(pool, "fed_data.bea_regional", beaCols, 2)
+	}
+
+	d := &BEARegional{cfg: beaAPIConfig([]string{"CAGDP2:1", "CAINC6N:1"}, []string{"COUNTY", "MSA"}), apiURL: "https://bea.test/api"}
+	result, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), result.RowsSynced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBEARegional_SyncAPI_APIError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader(`{"BEAAPI":{"Results":{"Error":{"APIErrorCode":"40","APIErrorDescription":"The dataset requested requires parameter LineCode"}}}}`)), nil)
+
+	d := &BEARegional{cfg: beaAPIConfig([]string{"CAGDP2:1"}, []string{"COUNTY"}), apiURL: "https://bea.test/api"}
+	_, err = d.Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api error 40")
+	assert.Contains(t, err.Error(), "CAGDP2 line 1 (COUNTY)")
+}
+
+func TestBEARegional_SyncAPI_InvalidSeries(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+
+	d := &BEARegional{cfg: beaAPIConfig([]string{"CAGDP2"}, []string{"COUNTY"})}
+	_, err = d.Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid series")
+}
+
+func TestParseBEASeries(t *testing.T) {
+	series, err := parseBEASeries([]string{"cagdp2:1", " CAINC6N : 500 "})
+	require.NoError(t, err)
+	assert.Equal(t, []beaSeries{{Table: "CAGDP2", LineCode: 1}, {Table: "CAINC6N", LineCode: 500}}, series)
+
+	_, err = parseBEASeries([]string{"CAINC1:x"})
+	assert.Error(t, err)
+}
+
+func TestBEAGeoLevel(t *testing.T) {
+	assert.Equal(t, "county", beaGeoLevel("COUNTY"))
+	assert.Equal(t, "msa", beaGeoLevel("msa"))
+	assert.Equal(t, "state", beaGeoLevel("STATE"))
+	assert.Equal(t, "state", beaGeoLevelForFIPS("48"))
+	assert.Equal(t, "county", beaGeoLevelForFIPS("48453"))
+	assert.Equal(t, "state", beaGeoLevelForFIPS("48000"))
+	assert.Equal(t, "national", beaGeoLevelForFIPS("00000"))
+}
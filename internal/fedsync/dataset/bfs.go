@@ -0,0 +1,281 @@
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	bfsMonthlyURL = "https://api.census.gov/data/timeseries/eits/bfs"
+	bfsWeeklyURL  = "https://www.census.gov/econ/bfs/csv/bfs_state_apps_weekly_nsa.csv"
+
+	// bfsFirstYear is the first year of published BFS data (July 2004).
+	bfsFirstYear = 2004
+
+	// bfsLookbackYears is how far back incremental syncs re-pull, since
+	// Census revises recent months and weeks.
+	bfsLookbackYears = 2
+)
+
+var bfsCols = []string{"frequency", "period", "geo", "naics_sector", "series", "seasonally_adj", "value"}
+
+var bfsConflictKeys = []string{"frequency", "period", "geo", "naics_sector", "series", "seasonally_adj"}
+
+// BFS syncs Census Business Formation Statistics: monthly business
+// application and formation series by state and NAICS sector (Census EITS
+// API) plus weekly state business applications (Census CSV).
+type BFS struct {
+	cfg        *config.Config
+	monthlyURL string // override for testing
+	weeklyURL  string // override for testing
+}
+
+// Name implements Dataset.
+func (d *BFS) Name() string { return "bfs" }
+
+// Table implements Dataset.
+func (d *BFS) Table() string { return "fed_data.bfs_data" }
+
+// Phase implements Dataset.
+func (d *BFS) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *BFS) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *BFS) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync re-pulls the last few years of monthly and weekly series.
+func (d *BFS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, time.Now().Year()-bfsLookbackYears)
+}
+
+// SyncFull pulls the full BFS history.
+func (d *BFS) SyncFull(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, bfsFirstYear)
+}
+
+func (d *BFS) sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, fromYear int) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+	log.Info("syncing BFS data", zap.Int("from_year", fromYear))
+
+	// Monthly series are pulled one year at a time to bound response size.
+	var monthlyN int64
+	for year := fromYear; year <= time.Now().Year(); year++ {
+		monthly, err := d.fetchMonthly(ctx, f, year)
+		if err != nil {
+			return nil, err
+		}
+		n, err := d.upsert(ctx, pool, monthly)
+		if err != nil {
+			return nil, err
+		}
+		monthlyN += n
+	}
+
+	weekly, err := d.fetchWeekly(ctx, f, fromYear)
+	if err != nil {
+		return nil, err
+	}
+	weeklyN, err := d.upsert(ctx, pool, weekly)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("BFS sync complete", zap.Int64("monthly", monthlyN), zap.Int64("weekly", weeklyN))
+	return &SyncResult{
+		RowsSynced: monthlyN + weeklyN,
+		Metadata: map[string]any{
+			"from_year":    fromYear,
+			"monthly_rows": monthlyN,
+			"weekly_rows":  weeklyN,
+		},
+	}, nil
+}
+
+// fetchMonthly pulls one year of monthly series from the Census EITS time series API.
+func (d *BFS) fetchMonthly(ctx context.Context, f fetcher.Fetcher, year int) ([][]any, error) {
+	base := d.monthlyURL
+	if base == "" {
+		base = bfsMonthlyURL
+	}
+	url := fmt.Sprintf("%s?get=cell_value,data_type_code,category_code,geo_level_code,seasonally_adj&time=%d",
+		base, year)
+	if d.cfg != nil && d.cfg.Fedsync.CensusKey != "" {
+		url += "&key=" + d.cfg.Fedsync.CensusKey
+	}
+
+	body, err := f.Download(ctx, url)
+	if err != nil {
+		if isCensusNotFound(err) {
+			// The current year has no data until the first monthly release.
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "bfs: download monthly series %d", year)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, eris.Wrap(err, "bfs: read monthly response")
+	}
+	if len(data) == 0 {
+		return nil, nil // 204 No Content: no data for this year yet
+	}
+
+	var result [][]string
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, eris.Wrap(err, "bfs: parse monthly json")
+	}
+	if len(result) < 2 {
+		return nil, nil
+	}
+
+	colIdx := mapColumns(result[0])
+	var rows [][]any
+	for _, rec := range result[1:] {
+		period, err := time.Parse("2006-01", getCol(rec, colIdx, "time"))
+		if err != nil {
+			fedsync.Reject(ctx, "bad_period", rec)
+			continue
+		}
+		value, ok := parseBFSValue(getCol(rec, colIdx, "cell_value"))
+		if !ok {
+			continue
+		}
+		series := strings.TrimSpace(getCol(rec, colIdx, "data_type_code"))
+		geo := strings.TrimSpace(getCol(rec, colIdx, "geo_level_code"))
+		if series == "" || geo == "" {
+			continue
+		}
+		sector := strings.TrimSpace(getCol(rec, colIdx, "category_code"))
+		if sector == "" {
+			sector = "TOTAL"
+		}
+		rows = append(rows, []any{
+			"monthly", period, geo, sector, series,
+			getCol(rec, colIdx, "seasonally_adj") == "yes",
+			value,
+		})
+	}
+	return rows, nil
+}
+
+// fetchWeekly pulls weekly state business applications from the Census CSV.
+// Each *_NSA column (BA_NSA, HBA_NSA, ...) becomes a series ("BA_BA",
+// "BA_HBA", ...) matching the monthly data_type_code naming.
+func (d *BFS) fetchWeekly(ctx context.Context, f fetcher.Fetcher, fromYear int) ([][]any, error) {
+	url := d.weeklyURL
+	if url == "" {
+		url = bfsWeeklyURL
+	}
+
+	body, err := f.Download(ctx, url)
+	if err != nil {
+		return nil, eris.Wrap(err, "bfs: download weekly series")
+	}
+	defer body.Close() //nolint:errcheck
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "bfs: read weekly header")
+	}
+	colIdx := mapColumns(header)
+
+	type seriesCol struct {
+		idx    int
+		series string
+	}
+	var seriesCols []seriesCol
+	for i, col := range header {
+		col = strings.ToUpper(strings.TrimSpace(col))
+		if prefix, ok := strings.CutSuffix(col, "_NSA"); ok {
+			seriesCols = append(seriesCols, seriesCol{idx: i, series: "BA_" + prefix})
+		}
+	}
+	if len(seriesCols) == 0 {
+		return nil, eris.New("bfs: weekly file has no _NSA series columns")
+	}
+
+	var rows [][]any
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "bfs: read weekly csv")
+		}
+
+		year := parseIntOr(getCol(rec, colIdx, "year"), 0)
+		week := parseIntOr(getCol(rec, colIdx, "week"), 0)
+		if year < fromYear || week < 1 {
+			continue
+		}
+		geo := strings.ToUpper(strings.TrimSpace(getCol(rec, colIdx, "state")))
+		if geo == "" {
+			geo = "US"
+		}
+		period := bfsWeekEnding(year, week)
+
+		for _, sc := range seriesCols {
+			value, ok := parseBFSValue(safeGet(rec, sc.idx))
+			if !ok {
+				continue
+			}
+			rows = append(rows, []any{"weekly", period, geo, "TOTAL", sc.series, false, value})
+		}
+	}
+	return rows, nil
+}
+
+// bfsWeekEnding returns the Saturday ending BFS week n of year. Week 1 ends on
+// the first Saturday of the year.
+func bfsWeekEnding(year, week int) time.Time {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	firstSat := jan1.AddDate(0, 0, (int(time.Saturday)-int(jan1.Weekday())+7)%7)
+	return firstSat.AddDate(0, 0, 7*(week-1))
+}
+
+// parseBFSValue parses a BFS cell value. Suppressed cells ("(S)", "NA") are skipped.
+func parseBFSValue(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func (d *BFS) upsert(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      bfsCols,
+		ConflictKeys: bfsConflictKeys,
+	}, rows)
+	if err != nil {
+		return 0, eris.Wrap(err, "bfs: upsert")
+	}
+	return n, nil
+}
@@ -0,0 +1,109 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestBFS_Metadata(t *testing.T) {
+	d := &BFS{}
+	assert.Equal(t, "bfs", d.Name())
+	assert.Equal(t, "fed_data.bfs_data", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+	assert.Implements(t, (*FullSyncer)(nil), d)
+}
+
+func TestBFS_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	year := time.Now().Year()
+
+	f.EXPECT().Download(ctx, urlContains(fmt.Sprintf("time=%d", year-2), "key=k")).
+		Return(jsonBody(t, [][]string{
+			{"cell_value", "data_type_code", "category_code", "geo_level_code", "seasonally_adj", "time"},
+			{"1,234", "BA_BA", "TOTAL", "TX", "yes", fmt.Sprintf("%d-01", year-2)},
+			{"56", "BA_HBA", "NAICS54", "TX", "no", fmt.Sprintf("%d-01", year-2)},
+			{"(S)", "BA_WBA", "NAICS11", "WY", "no", fmt.Sprintf("%d-01", year-2)},
+		}), nil).Once()
+	f.EXPECT().Download(ctx, urlContains(fmt.Sprintf("time=%d", year-1))).
+		Return(io.NopCloser(strings.NewReader("")), nil).Once()
+	f.EXPECT().Download(ctx, urlContains(fmt.Sprintf("time=%d", year))).
+		Return(nil, errors.New("unexpected status 404")).Once()
+	f.EXPECT().Download(ctx, "http://test/weekly.csv").
+		Return(io.NopCloser(strings.NewReader(
+			"State,Year,Week,BA_NSA,HBA_NSA\n"+
+				fmt.Sprintf("TX,%d,2,900,300\n", year-1)+
+				"TX,2010,2,800,200\n"+
+				fmt.Sprintf("CA,%d,3,1100,\n", year-1))), nil).Once()
+
+	expectBulkUpsert(pool, "fed_data.bfs_data", bfsCols, 2)
+	expectBulkUpsert(pool, "fed_data.bfs_data", bfsCols, 3)
+
+	cfg := &config.Config{}
+	cfg.Fedsync.CensusKey = "k"
+	d := &BFS{cfg: cfg, weeklyURL: "http://test/weekly.csv"}
+	result, err := d.Sync(ctx, pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.RowsSynced)
+	assert.Equal(t, int64(2), result.Metadata["monthly_rows"])
+	assert.Equal(t, int64(3), result.Metadata["weekly_rows"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBFS_Sync_MonthlyError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+	d := &BFS{}
+	_, err := d.Sync(context.Background(), nil, f, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bfs: download monthly series")
+}
+
+func TestBFS_FetchWeekly_NoSeriesColumns(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("State,Year,Week\nTX,2024,1\n")), nil)
+
+	d := &BFS{}
+	_, err := d.fetchWeekly(context.Background(), f, 2020)
+	assert.ErrorContains(t, err, "no _NSA series columns")
+}
+
+func TestBFSWeekEnding(t *testing.T) {
+	// 2024-01-01 is a Monday; the first Saturday is January 6.
+	assert.Equal(t, time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), bfsWeekEnding(2024, 1))
+	assert.Equal(t, time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), bfsWeekEnding(2024, 2))
+	// 2022-01-01 is a Saturday.
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), bfsWeekEnding(2022, 1))
+}
+
+func TestParseBFSValue(t *testing.T) {
+	v, ok := parseBFSValue("12,345")
+	assert.True(t, ok)
+	assert.InDelta(t, 12345.0, v, 1e-9)
+
+	_, ok = parseBFSValue("(S)")
+	assert.False(t, ok)
+	_, ok = parseBFSValue("")
+	assert.False(t, ok)
+}
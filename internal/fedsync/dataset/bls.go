@@ -0,0 +1,343 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// blsSeriesURL is the BLS Public Data API v2 multi-series endpoint.
+const blsSeriesURL = "https://api.bls.gov/publicAPI/v2/timeseries/data/"
+
+// BLS API v2 limits: series per query and queries per day, for registered
+// (keyed) and anonymous callers.
+const (
+	blsMaxSeriesKeyed = 50
+	blsMaxSeriesAnon  = 25
+	blsDailyKeyed     = 500
+	blsDailyAnon      = 25
+)
+
+// blsSeriesCols and blsSeriesConflictKeys describe the common
+// (series_id, year, period, value) layout of BLS series tables.
+var (
+	blsSeriesCols         = []string{"series_id", "year", "period", "value"}
+	blsSeriesConflictKeys = []string{"series_id", "year", "period"}
+)
+
+// errBLSQuotaExhausted is returned once the day's BLS query budget is
+// spent, locally or as reported by the API.
+var errBLSQuotaExhausted = eris.New("bls: daily query quota exhausted")
+
+// blsRequest is the BLS API v2 multi-series POST body.
+type blsRequest struct {
+	SeriesID        []string `json:"seriesid"`
+	StartYear       string   `json:"startyear"`
+	EndYear         string   `json:"endyear"`
+	RegistrationKey string   `json:"registrationkey,omitempty"`
+}
+
+// blsSeriesResponse is the BLS API v2 response format.
+type blsSeriesResponse struct {
+	Status  string   `json:"status"`
+	Message []string `json:"message"`
+	Results struct {
+		Series []struct {
+			SeriesID string `json:"seriesID"`
+			Data     []struct {
+				Year   string `json:"year"`
+				Period string `json:"period"`
+				Value  string `json:"value"`
+			} `json:"data"`
+		} `json:"series"`
+	} `json:"Results"`
+}
+
+// quotaExceeded reports whether BLS refused the request because the
+// caller's daily threshold was reached.
+func (r *blsSeriesResponse) quotaExceeded() bool {
+	if r.Status != "REQUEST_NOT_PROCESSED" {
+		return false
+	}
+	for _, m := range r.Message {
+		if strings.Contains(strings.ToLower(m), "threshold") {
+			return true
+		}
+	}
+	return false
+}
+
+// blsQuotaTracker counts BLS API queries per UTC day. One tracker is shared
+// by every BLS dataset in the process so a run syncing several of them
+// stays under the account's daily limit.
+type blsQuotaTracker struct {
+	mu        sync.Mutex
+	day       string
+	used      int
+	exhausted bool
+}
+
+// blsQuota is the process-wide BLS query budget.
+var blsQuota = &blsQuotaTracker{}
+
+// reset starts a new day's count when now falls on a later day. Callers
+// hold q.mu.
+func (q *blsQuotaTracker) reset(now time.Time) {
+	if day := now.UTC().Format("2006-01-02"); day != q.day {
+		q.day, q.used, q.exhausted = day, 0, false
+	}
+}
+
+// take reserves one query against limit, reporting false when the day's
+// budget is spent.
+func (q *blsQuotaTracker) take(now time.Time, limit int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.reset(now)
+	if q.exhausted || q.used >= limit {
+		return false
+	}
+	q.used++
+	return true
+}
+
+// exhaust marks the day's budget spent, for when BLS reports the threshold
+// reached before the local count does.
+func (q *blsQuotaTracker) exhaust(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.reset(now)
+	q.exhausted = true
+}
+
+// Used returns the queries made so far today.
+func (q *blsQuotaTracker) Used(now time.Time) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.reset(now)
+	return q.used
+}
+
+// blsDailyLimit returns the configured daily query limit, or the BLS
+// account limit for whether an API key is set.
+func blsDailyLimit(cfg *config.Config) int {
+	if cfg == nil {
+		return blsDailyAnon
+	}
+	if cfg.Fedsync.BLS.DailyLimit > 0 {
+		return cfg.Fedsync.BLS.DailyLimit
+	}
+	if cfg.Fedsync.BLSKey == "" {
+		return blsDailyAnon
+	}
+	return blsDailyKeyed
+}
+
+// blsFetch describes one batched BLS download.
+type blsFetch struct {
+	fetcher    fetcher.Fetcher
+	url        string // "" = blsSeriesURL
+	apiKey     string
+	series     []string
+	startYear  int
+	endYear    int
+	dailyLimit int
+	quota      *blsQuotaTracker // nil = blsQuota
+}
+
+// fetchBLSSeries downloads the series for startYear..endYear in POSTs of up
+// to 50 series (25 without an API key) and returns (series_id, year,
+// period, value) rows and the number of queries made. Queries go through the
+// fetcher, which retries transient failures. A batch that still fails is
+// logged and the remaining batches are fetched, but the rows are returned
+// with an error so the sync is retried; once the daily quota is exhausted
+// the rows fetched so far are returned with errBLSQuotaExhausted.
+func fetchBLSSeries(ctx context.Context, req blsFetch, log *zap.Logger) ([][]any, int, error) {
+	quota := req.quota
+	if quota == nil {
+		quota = blsQuota
+	}
+	size := blsMaxSeriesKeyed
+	if req.apiKey == "" {
+		size = blsMaxSeriesAnon
+	}
+
+	var rows [][]any
+	var queries, failed int
+	var firstErr error
+	for batch := range slices.Chunk(req.series, size) {
+		if err := ctx.Err(); err != nil {
+			return rows, queries, err
+		}
+		if !quota.take(time.Now(), req.dailyLimit) {
+			return rows, queries, errBLSQuotaExhausted
+		}
+		queries++
+
+		resp, err := postBLSSeries(ctx, req.fetcher, req.url, blsRequest{
+			SeriesID:        batch,
+			StartYear:       strconv.Itoa(req.startYear),
+			EndYear:         strconv.Itoa(req.endYear),
+			RegistrationKey: req.apiKey,
+		})
+		if err != nil {
+			log.Warn("series batch failed", zap.Strings("series", batch), zap.Error(err))
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if resp.quotaExceeded() {
+			quota.exhaust(time.Now())
+			return rows, queries, errBLSQuotaExhausted
+		}
+		if resp.Status != "REQUEST_SUCCEEDED" {
+			log.Warn("series batch failed", zap.Strings("series", batch),
+				zap.String("status", resp.Status), zap.Strings("message", resp.Message))
+			failed++
+			if firstErr == nil {
+				firstErr = eris.Errorf("series query status %s: %s", resp.Status, strings.Join(resp.Message, "; "))
+			}
+			continue
+		}
+		for _, m := range resp.Message {
+			log.Debug("bls message", zap.String("message", m))
+		}
+
+		for _, s := range resp.Results.Series {
+			for _, dp := range s.Data {
+				rows = append(rows, []any{
+					s.SeriesID,
+					parseInt16Or(dp.Year, 0),
+					dp.Period,
+					parseFloat64Or(dp.Value, 0),
+				})
+			}
+		}
+	}
+	if failed > 0 {
+		return rows, queries, eris.Wrapf(firstErr, "%d of %d series batches failed", failed, queries)
+	}
+	return rows, queries, nil
+}
+
+// postBLSSeries POSTs one multi-series query through f.
+func postBLSSeries(ctx context.Context, f fetcher.Fetcher, url string, body blsRequest) (*blsSeriesResponse, error) {
+	if url == "" {
+		url = blsSeriesURL
+	}
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, eris.Wrap(err, "marshal request")
+	}
+	rc, err := fetcher.PostJSON(ctx, f, url, buf)
+	if err != nil {
+		return nil, eris.Wrap(err, "POST series")
+	}
+	defer rc.Close() //nolint:errcheck
+
+	var out blsSeriesResponse
+	if err := json.NewDecoder(rc).Decode(&out); err != nil {
+		return nil, eris.Wrap(err, "decode response")
+	}
+	return &out, nil
+}
+
+// blsSeriesDataset is the shared sync loop behind BLS time-series datasets
+// (ECI, CPS/LAUS, JOLTS, PPI, CPI): fetch the series in batched queries for
+// a trailing window of years and upsert (series_id, year, period, value)
+// rows, optionally extended with columns derived from the series ID.
+type blsSeriesDataset struct {
+	fetcher       fetcher.Fetcher
+	name          string
+	table         string
+	url           string // override for testing
+	apiKey        string
+	dailyLimit    int
+	series        []string
+	lookbackYears int
+
+	// extraCols and extra append series-ID-derived columns to each row.
+	extraCols []string
+	extra     func(seriesID string) []any
+}
+
+func (b blsSeriesDataset) sync(ctx context.Context, pool db.Pool) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", b.name))
+
+	if len(b.series) == 0 {
+		log.Warn(b.name + ": no series configured")
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+	log.Info("syncing BLS series", zap.Int("series", len(b.series)))
+
+	endYear := time.Now().Year()
+	rows, queries, fetchErr := fetchBLSSeries(ctx, blsFetch{
+		fetcher:    b.fetcher,
+		url:        b.url,
+		apiKey:     b.apiKey,
+		series:     b.series,
+		startYear:  endYear - b.lookbackYears,
+		endYear:    endYear,
+		dailyLimit: b.dailyLimit,
+	}, log)
+	cols := blsSeriesCols
+	if b.extra != nil {
+		cols = append(append([]string(nil), blsSeriesCols...), b.extraCols...)
+		for i, row := range rows {
+			rows[i] = append(row, b.extra(row[0].(string))...)
+		}
+	}
+
+	var n int64
+	if len(rows) > 0 || fetchErr == nil {
+		var err error
+		n, err = db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        b.table,
+			Columns:      cols,
+			ConflictKeys: blsSeriesConflictKeys,
+		}, rows)
+		if err != nil {
+			return nil, eris.Wrapf(err, "%s: upsert", b.name)
+		}
+	}
+	// Rows fetched before the quota ran out or a batch failed are kept, but
+	// the sync fails so the remaining series are retried on the next run.
+	if fetchErr != nil {
+		return nil, eris.Wrapf(fetchErr, "%s: loaded %d rows from %d queries", b.name, n, queries)
+	}
+
+	log.Info(b.name+" sync complete", zap.Int64("rows", n), zap.Int("queries", queries))
+	return &SyncResult{
+		RowsSynced: n,
+		Metadata:   map[string]any{"series": len(b.series), "queries": queries},
+	}, nil
+}
+
+// normalizeBLSSeries upper-cases, trims, and de-duplicates configured series
+// IDs, preserving order.
+func normalizeBLSSeries(series []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range series {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
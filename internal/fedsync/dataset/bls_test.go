@@ -0,0 +1,325 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+// blsSeriesJSON builds one series of a BLS API response from
+// (year, period, value) triples.
+func blsSeriesJSON(seriesID string, points ...string) string {
+	var data []string
+	for i := 0; i+2 < len(points); i += 3 {
+		data = append(data, `{"year":"`+points[i]+`","period":"`+points[i+1]+`","value":"`+points[i+2]+`"}`)
+	}
+	return `{"seriesID":"` + seriesID + `","data":[` + strings.Join(data, ",") + `]}`
+}
+
+// blsServer serves BLS API POSTs, answering each request with the series
+// in data that it asks for, and records the requests it receives.
+type blsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []blsRequest
+}
+
+func newBLSServer(t *testing.T, data map[string]string) *blsServer {
+	t.Helper()
+	s := &blsServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req blsRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		var series []string
+		for _, id := range req.SeriesID {
+			if d, ok := data[id]; ok {
+				series = append(series, d)
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"REQUEST_SUCCEEDED","message":[],"Results":{"series":[%s]}}`, strings.Join(series, ","))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newBLSFetcher returns an HTTP fetcher that tries each BLS query once.
+func newBLSFetcher() fetcher.Fetcher {
+	return fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 1})
+}
+
+// Requests returns the requests received so far.
+func (s *blsServer) Requests() []blsRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]blsRequest(nil), s.requests...)
+}
+
+func TestPPICPI_Metadata(t *testing.T) {
+	for _, tc := range []struct {
+		ds    Dataset
+		name  string
+		table string
+	}{
+		{&PPI{}, "ppi", "fed_data.ppi_data"},
+		{&CPI{}, "cpi", "fed_data.cpi_data"},
+	} {
+		assert.Equal(t, tc.name, tc.ds.Name())
+		assert.Equal(t, tc.table, tc.ds.Table())
+		assert.Equal(t, Phase3, tc.ds.Phase())
+		assert.Equal(t, Monthly, tc.ds.Cadence())
+		assert.True(t, tc.ds.ShouldRun(time.Now(), nil))
+	}
+}
+
+func TestPPI_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// PCU541211541211 is unknown to BLS and comes back without data.
+	srv := newBLSServer(t, map[string]string{
+		"WPSFD4": blsSeriesJSON("WPSFD4", "2026", "M08", "151.2", "2026", "M07", "150.9"),
+	})
+	expectBulkUpsert(pool, "fed_data.ppi_data", blsSeriesCols, 2)
+
+	d := &PPI{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{
+		BLSKey: "k",
+		PPI:    config.BLSSeriesConfig{Series: []string{"wpsfd4", " PCU541211541211 "}},
+	}}}
+	res, err := d.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 2, res.Metadata["series"])
+	assert.Equal(t, 1, res.Metadata["queries"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	reqs := srv.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, []string{"WPSFD4", "PCU541211541211"}, reqs[0].SeriesID)
+	assert.Equal(t, "k", reqs[0].RegistrationKey)
+	assert.Equal(t, fmt.Sprint(time.Now().Year()-3), reqs[0].StartYear)
+}
+
+func TestCPI_Sync_NoSeries(t *testing.T) {
+	d := &CPI{cfg: &config.Config{}}
+	res, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.RowsSynced)
+}
+
+func TestBLSSeriesDataset_UpsertError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	srv := newBLSServer(t, map[string]string{
+		"CUSR0000SA0": blsSeriesJSON("CUSR0000SA0", "2026", "M08", "322.1"),
+	})
+	pool.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	_, err = blsSeriesDataset{
+		fetcher:       newBLSFetcher(),
+		name:          "cpi",
+		table:         "fed_data.cpi_data",
+		url:           srv.URL,
+		dailyLimit:    blsDailyAnon,
+		series:        []string{"CUSR0000SA0"},
+		lookbackYears: 1,
+	}.sync(context.Background(), pool)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cpi: upsert")
+}
+
+func TestFetchBLSSeries_Batches(t *testing.T) {
+	data := make(map[string]string)
+	var series []string
+	for i := range 60 {
+		id := fmt.Sprintf("CUSR%07d", i)
+		series = append(series, id)
+		data[id] = blsSeriesJSON(id, "2026", "M08", "1.5")
+	}
+	srv := newBLSServer(t, data)
+
+	// 50 series per query with a key, 25 without.
+	for _, tc := range []struct {
+		key     string
+		queries int
+	}{{"k", 2}, {"", 3}} {
+		quota := &blsQuotaTracker{}
+		rows, queries, err := fetchBLSSeries(context.Background(), blsFetch{
+			fetcher: newBLSFetcher(), url: srv.URL, apiKey: tc.key, series: series,
+			startYear: 2025, endYear: 2026, dailyLimit: 100, quota: quota,
+		}, nopLog())
+		require.NoError(t, err)
+		assert.Len(t, rows, 60)
+		assert.Equal(t, tc.queries, queries)
+		assert.Equal(t, tc.queries, quota.Used(time.Now()))
+	}
+	reqs := srv.Requests()
+	require.Len(t, reqs, 5)
+	assert.Len(t, reqs[0].SeriesID, 50)
+	assert.Len(t, reqs[1].SeriesID, 10)
+	assert.Len(t, reqs[2].SeriesID, 25)
+	assert.Equal(t, "2025", reqs[0].StartYear)
+	assert.Equal(t, "2026", reqs[0].EndYear)
+}
+
+func TestFetchBLSSeries_FailedBatch(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"REQUEST_SUCCEEDED","Results":{"series":[%s]}}`,
+			blsSeriesJSON("B", "2026", "M01", "2"))
+	}))
+	defer srv.Close()
+
+	// The remaining batches are still fetched, but the failure is reported.
+	series := make([]string, 30) // two anonymous batches
+	for i := range series {
+		series[i] = fmt.Sprintf("S%02d", i)
+	}
+	rows, queries, err := fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: newBLSFetcher(), url: srv.URL, series: series, startYear: 2026, endYear: 2026,
+		dailyLimit: 10, quota: &blsQuotaTracker{},
+	}, nopLog())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 series batches failed")
+	assert.Equal(t, 2, queries)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []any{"B", int16(2026), "M01", 2.0}, rows[0])
+}
+
+func TestFetchBLSSeries_RetriesServerError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"REQUEST_SUCCEEDED","Results":{"series":[%s]}}`,
+			blsSeriesJSON("A", "2026", "M01", "1"))
+	}))
+	defer srv.Close()
+
+	rows, queries, err := fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 2}),
+		url:     srv.URL, series: []string{"A"}, startYear: 2026, endYear: 2026,
+		dailyLimit: 10, quota: &blsQuotaTracker{},
+	}, nopLog())
+	require.NoError(t, err)
+	assert.Equal(t, 1, queries, "a retried query counts once against the quota")
+	assert.Len(t, rows, 1)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestFetchBLSSeries_NoPoster(t *testing.T) {
+	_, _, err := fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: fetchermocks.NewMockFetcher(t), series: []string{"A"},
+		dailyLimit: 10, quota: &blsQuotaTracker{},
+	}, nopLog())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support POST")
+}
+
+func TestFetchBLSSeries_QuotaExhausted(t *testing.T) {
+	srv := newBLSServer(t, map[string]string{"A": blsSeriesJSON("A", "2026", "M01", "1")})
+	quota := &blsQuotaTracker{}
+
+	// The local budget allows one query; the second batch is not sent.
+	series := append([]string{"A"}, make([]string, 25)...)
+	rows, queries, err := fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: newBLSFetcher(), url: srv.URL, series: series, startYear: 2026, endYear: 2026,
+		dailyLimit: 1, quota: quota,
+	}, nopLog())
+	require.ErrorIs(t, err, errBLSQuotaExhausted)
+	assert.Equal(t, 1, queries)
+	assert.Len(t, rows, 1)
+	assert.Len(t, srv.Requests(), 1)
+
+	// The budget is shared: another dataset gets nothing today.
+	_, queries, err = fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: newBLSFetcher(), url: srv.URL, series: []string{"A"}, dailyLimit: 1, quota: quota,
+	}, nopLog())
+	require.ErrorIs(t, err, errBLSQuotaExhausted)
+	assert.Zero(t, queries)
+}
+
+func TestFetchBLSSeries_APIThreshold(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"REQUEST_NOT_PROCESSED","message":["Request could not be serviced, as the daily threshold for total number of requests allocated to the user has been reached."],"Results":{}}`))
+	}))
+	defer srv.Close()
+
+	quota := &blsQuotaTracker{}
+	_, _, err := fetchBLSSeries(context.Background(), blsFetch{
+		fetcher: newBLSFetcher(), url: srv.URL, series: []string{"A"}, dailyLimit: 500, quota: quota,
+	}, nopLog())
+	require.ErrorIs(t, err, errBLSQuotaExhausted)
+	assert.False(t, quota.take(time.Now(), 500), "API threshold exhausts the local budget")
+	assert.True(t, quota.take(time.Now().Add(24*time.Hour), 500), "budget resets the next day")
+}
+
+func TestBLSSeriesDataset_QuotaExhaustedKeepsRows(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Rows fetched before the budget ran out are loaded, but the sync fails
+	// so the rest are retried.
+	srv := newBLSServer(t, map[string]string{"A": blsSeriesJSON("A", "2026", "M01", "1")})
+	expectBulkUpsert(pool, "fed_data.cpi_data", blsSeriesCols, 1)
+
+	blsQuota = &blsQuotaTracker{}
+	t.Cleanup(func() { blsQuota = &blsQuotaTracker{} })
+	series := append([]string{"A"}, make([]string, 25)...)
+	_, err = blsSeriesDataset{
+		fetcher: newBLSFetcher(), name: "cpi", table: "fed_data.cpi_data", url: srv.URL,
+		dailyLimit: 1, series: series, lookbackYears: 1,
+	}.sync(context.Background(), pool)
+	require.ErrorIs(t, err, errBLSQuotaExhausted)
+	assert.Contains(t, err.Error(), "cpi: loaded 1 rows from 1 queries")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBLSDailyLimit(t *testing.T) {
+	assert.Equal(t, blsDailyAnon, blsDailyLimit(nil))
+	assert.Equal(t, blsDailyAnon, blsDailyLimit(&config.Config{}))
+	keyed := &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "k"}}
+	assert.Equal(t, blsDailyKeyed, blsDailyLimit(keyed))
+	keyed.Fedsync.BLS.DailyLimit = 100
+	assert.Equal(t, 100, blsDailyLimit(keyed))
+}
+
+func TestNormalizeBLSSeries(t *testing.T) {
+	assert.Equal(t, []string{"CUSR0000SA0", "WPSFD4"},
+		normalizeBLSSeries([]string{" cusr0000sa0", "", "WPSFD4", "CUSR0000SA0"}))
+	assert.Nil(t, normalizeBLSSeries(nil))
+}
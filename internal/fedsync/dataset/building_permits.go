@@ -51,6 +51,9 @@ func (d *BuildingPermits) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return AnnualAfter(now, lastSync, time.March)
 }
 
+// Mirrors implements Mirrored.
+func (d *BuildingPermits) Mirrors() []fetcher.Mirror { return censusMirrors }
+
 // Sync fetches and loads Census Building Permits Survey county data.
 func (d *BuildingPermits) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", d.Name()))
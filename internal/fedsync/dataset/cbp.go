@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/fedsync/transform"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
@@ -26,8 +28,27 @@ const (
 	cbpBatchSize = 5000
 )
 
-// CBP implements the Census County Business Patterns dataset.
-type CBP struct{}
+// zbpColumns defines the zbp_data upsert columns.
+var zbpColumns = []string{
+	"year", "zip", "naics", "state", "city", "est",
+	"n1_4", "n5_9", "n10_19", "n20_49", "n50_99", "n100_249", "n250_499", "n500_999", "n1000",
+}
+
+// zbpSizeFields lists the ZBP detail establishment size class headers in
+// zbpColumns order. Census renamed n1_4 to "n<5" in 2017; both are accepted.
+var zbpSizeFields = [][]string{
+	{"n<5", "n1_4"}, {"n5_9"}, {"n10_19"}, {"n20_49"}, {"n50_99"},
+	{"n100_249"}, {"n250_499"}, {"n500_999"}, {"n1000"},
+}
+
+// CBP implements the Census County Business Patterns dataset. When
+// fedsync.cbp.zip_level is set it also loads the ZIP Code Business Patterns
+// detail files into fed_data.zbp_data: establishment counts by NAICS and
+// employment size class for each ZIP (ZBP publishes no employment or
+// payroll below the ZIP total).
+type CBP struct {
+	cfg *config.Config
+}
 
 // Name implements Dataset.
 func (d *CBP) Name() string { return "cbp" }
@@ -35,6 +56,23 @@ func (d *CBP) Name() string { return "cbp" }
 // Table implements Dataset.
 func (d *CBP) Table() string { return "fed_data.cbp_data" }
 
+// Columns implements Documented.
+func (d *CBP) Columns() []ColumnDoc {
+	return []ColumnDoc{
+		{Column: "year", Description: "Reference year (employment as of the pay period including March 12)"},
+		{Column: "fips_state", Description: "State FIPS code"},
+		{Column: "fips_county", Description: "County FIPS code"},
+		{Column: "naics", Description: "NAICS code; 000000 for all industries"},
+		{Column: "emp", Description: "Mid-March employees (0 when suppressed; see emp_nf)"},
+		{Column: "emp_nf", Description: "Employment noise flag: G low, H medium, J high noise; D withheld"},
+		{Column: "qp1", Description: "First-quarter payroll, $1,000"},
+		{Column: "qp1_nf", Description: "First-quarter payroll noise flag"},
+		{Column: "ap", Description: "Annual payroll, $1,000"},
+		{Column: "ap_nf", Description: "Annual payroll noise flag"},
+		{Column: "est", Description: "Number of establishments"},
+	}
+}
+
 // Phase implements Dataset.
 func (d *CBP) Phase() Phase { return Phase1 }
 
@@ -46,84 +84,175 @@ func (d *CBP) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return AnnualAfter(now, lastSync, time.March)
 }
 
+// Mirrors implements Mirrored.
+func (d *CBP) Mirrors() []fetcher.Mirror { return censusMirrors }
+
 // Sync fetches and loads Census County Business Patterns data.
 func (d *CBP) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
-	log := zap.L().With(zap.String("dataset", "cbp"))
-	var totalRows atomic.Int64
-
 	currentYear := time.Now().Year() - 1 // CBP data lags by ~1 year
 
+	var totalRows, zbpRows atomic.Int64
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(3)
-
 	for year := cbpStartYear; year <= currentYear; year++ {
-		// Download county-level file.
-		g.Go(func() error {
-			yy := fmt.Sprintf("%02d", year%100)
-			url := fmt.Sprintf("https://www2.census.gov/programs-surveys/cbp/datasets/%d/cbp%sco.zip", year, yy)
-
-			log.Info("downloading CBP county data", zap.Int("year", year), zap.String("url", url))
-
-			zipPath := filepath.Join(tempDir, fmt.Sprintf("cbp%sco.zip", yy))
-			if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
-				if strings.Contains(err.Error(), "status 404") {
-					log.Info("CBP county data not yet available, skipping", zap.Int("year", year))
-					return nil
-				}
-				return eris.Wrapf(err, "cbp: download county year %d", year)
-			}
-
-			rows, err := d.processZip(gctx, pool, zipPath, year)
-			if err != nil {
-				return eris.Wrapf(err, "cbp: process county year %d", year)
-			}
-
-			totalRows.Add(rows)
-			log.Info("processed CBP county year", zap.Int("year", year), zap.Int64("rows", rows))
-
-			_ = os.Remove(zipPath)
-			return nil
-		})
-		// Download state-level file (fips_county='000', used by mv_market_size).
-		g.Go(func() error {
-			yy := fmt.Sprintf("%02d", year%100)
-			url := fmt.Sprintf("https://www2.census.gov/programs-surveys/cbp/datasets/%d/cbp%sst.zip", year, yy)
-
-			log.Info("downloading CBP state data", zap.Int("year", year), zap.String("url", url))
-
-			zipPath := filepath.Join(tempDir, fmt.Sprintf("cbp%sst.zip", yy))
-			if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
-				if strings.Contains(err.Error(), "status 404") {
-					log.Info("CBP state data not yet available, skipping", zap.Int("year", year))
-					return nil
-				}
-				return eris.Wrapf(err, "cbp: download state year %d", year)
-			}
+		d.syncYear(gctx, g, pool, f, tempDir, year, &totalRows, &zbpRows)
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
 
-			rows, err := d.processZip(gctx, pool, zipPath, year)
-			if err != nil {
-				return eris.Wrapf(err, "cbp: process state year %d", year)
-			}
+	metadata := map[string]any{"start_year": cbpStartYear, "end_year": currentYear}
+	if d.zipLevel() {
+		metadata["zbp_rows"] = zbpRows.Load()
+	}
+	return &SyncResult{
+		RowsSynced: totalRows.Load() + zbpRows.Load(),
+		Metadata:   metadata,
+	}, nil
+}
 
-			totalRows.Add(rows)
-			log.Info("processed CBP state year", zap.Int("year", year), zap.Int64("rows", rows))
+// PlanResync implements SliceResyncer: a year, optionally one state, of
+// county and state rows. ZIP-level rows are reloaded but not deleted.
+func (d *CBP) PlanResync(s Slice) (SlicePlan, error) {
+	return annualSlicePlan("cbp", "fed_data.cbp_data", "fips_state", s)
+}
 
-			_ = os.Remove(zipPath)
-			return nil
-		})
+// SyncPeriod implements PeriodSyncer, loading one CBP year (and its ZBP
+// detail when zip_level is set).
+func (d *CBP) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	year, err := requireYear("cbp", period)
+	if err != nil {
+		return nil, err
 	}
 
+	var totalRows, zbpRows atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(3)
+	if db.InTx(pool) {
+		// A resync hands us one transaction, which is not safe for
+		// concurrent use; load the files one at a time.
+		g.SetLimit(1)
+	}
+	d.syncYear(gctx, g, pool, f, tempDir, year, &totalRows, &zbpRows)
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+	if totalRows.Load() == 0 {
+		return nil, eris.Errorf("cbp: no data published for %d", year)
+	}
 
+	metadata := map[string]any{"year": year}
+	if d.zipLevel() {
+		metadata["zbp_rows"] = zbpRows.Load()
+	}
 	return &SyncResult{
-		RowsSynced: totalRows.Load(),
-		Metadata:   map[string]any{"start_year": cbpStartYear, "end_year": currentYear},
+		RowsSynced: totalRows.Load() + zbpRows.Load(),
+		Metadata:   metadata,
 	}, nil
 }
 
+// syncYear schedules the county, state, and (with zip_level) ZBP detail
+// loads for one year on g. Files Census has not published are skipped.
+func (d *CBP) syncYear(gctx context.Context, g *errgroup.Group, pool db.Pool, f fetcher.Fetcher, tempDir string, year int, totalRows, zbpRows *atomic.Int64) {
+	log := zap.L().With(zap.String("dataset", "cbp"))
+
+	// Download county-level file.
+	g.Go(func() error {
+		yy := fmt.Sprintf("%02d", year%100)
+		url := fmt.Sprintf("https://www2.census.gov/programs-surveys/cbp/datasets/%d/cbp%sco.zip", year, yy)
+
+		log.Info("downloading CBP county data", zap.Int("year", year), zap.String("url", url))
+
+		zipPath := filepath.Join(tempDir, fmt.Sprintf("cbp%sco.zip", yy))
+		if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
+			if strings.Contains(err.Error(), "status 404") {
+				log.Info("CBP county data not yet available, skipping", zap.Int("year", year))
+				return nil
+			}
+			return eris.Wrapf(err, "cbp: download county year %d", year)
+		}
+
+		rows, err := d.processZip(db.WithSource(gctx, url), pool, zipPath, year)
+		if err != nil {
+			return eris.Wrapf(err, "cbp: process county year %d", year)
+		}
+
+		totalRows.Add(rows)
+		log.Info("processed CBP county year", zap.Int("year", year), zap.Int64("rows", rows))
+
+		_ = os.Remove(zipPath)
+		return nil
+	})
+	// Download state-level file (fips_county='000', used by mv_market_size).
+	g.Go(func() error {
+		yy := fmt.Sprintf("%02d", year%100)
+		url := fmt.Sprintf("https://www2.census.gov/programs-surveys/cbp/datasets/%d/cbp%sst.zip", year, yy)
+
+		log.Info("downloading CBP state data", zap.Int("year", year), zap.String("url", url))
+
+		zipPath := filepath.Join(tempDir, fmt.Sprintf("cbp%sst.zip", yy))
+		if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
+			if strings.Contains(err.Error(), "status 404") {
+				log.Info("CBP state data not yet available, skipping", zap.Int("year", year))
+				return nil
+			}
+			return eris.Wrapf(err, "cbp: download state year %d", year)
+		}
+
+		rows, err := d.processZip(db.WithSource(gctx, url), pool, zipPath, year)
+		if err != nil {
+			return eris.Wrapf(err, "cbp: process state year %d", year)
+		}
+
+		totalRows.Add(rows)
+		log.Info("processed CBP state year", zap.Int("year", year), zap.Int64("rows", rows))
+
+		_ = os.Remove(zipPath)
+		return nil
+	})
+	if !d.zipLevel() {
+		return
+	}
+	// Download ZIP-level detail file (fed_data.zbp_data).
+	g.Go(func() error {
+		yy := fmt.Sprintf("%02d", year%100)
+		url := fmt.Sprintf("https://www2.census.gov/programs-surveys/cbp/datasets/%d/zbp%sdetail.zip", year, yy)
+
+		log.Info("downloading ZBP detail data", zap.Int("year", year), zap.String("url", url))
+
+		zipPath := filepath.Join(tempDir, fmt.Sprintf("zbp%sdetail.zip", yy))
+		if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
+			if strings.Contains(err.Error(), "status 404") {
+				log.Info("ZBP detail data not yet available, skipping", zap.Int("year", year))
+				return nil
+			}
+			return eris.Wrapf(err, "cbp: download zbp year %d", year)
+		}
+
+		rows, err := d.loadZip(db.WithSource(gctx, url), pool, zipPath, year, d.parseZBP)
+		if err != nil {
+			return eris.Wrapf(err, "cbp: process zbp year %d", year)
+		}
+
+		zbpRows.Add(rows)
+		log.Info("processed ZBP detail year", zap.Int("year", year), zap.Int64("rows", rows))
+
+		_ = os.Remove(zipPath)
+		return nil
+	})
+}
+
+// zipLevel reports whether the ZIP Code Business Patterns files are loaded.
+func (d *CBP) zipLevel() bool {
+	return d.cfg != nil && d.cfg.Fedsync.CBP.ZIPLevel
+}
+
 func (d *CBP) processZip(ctx context.Context, pool db.Pool, zipPath string, year int) (int64, error) {
+	return d.loadZip(ctx, pool, zipPath, year, d.parseCSV)
+}
+
+// loadZip passes the first CSV or TXT file in zipPath to parse.
+func (d *CBP) loadZip(ctx context.Context, pool db.Pool, zipPath string, year int, parse func(context.Context, db.Pool, io.Reader, int) (int64, error)) (int64, error) {
 	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return 0, eris.Wrap(err, "cbp: open zip")
@@ -136,7 +265,7 @@ func (d *CBP) processZip(ctx context.Context, pool db.Pool, zipPath string, year
 			if err != nil {
 				return 0, eris.Wrapf(err, "cbp: open file %s in zip", zf.Name)
 			}
-			n, err := d.parseCSV(ctx, pool, rc, year)
+			n, err := parse(ctx, pool, rc, year)
 			_ = rc.Close()
 			return n, err
 		}
@@ -156,12 +285,17 @@ func (d *CBP) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int)
 	}
 
 	colIdx := mapColumns(header)
+	filter, err := naicsFilterFor(d.cfg, "cbp")
+	if err != nil {
+		return 0, err
+	}
 
 	columns := []string{"year", "fips_state", "fips_county", "naics", "emp", "emp_nf", "qp1", "qp1_nf", "ap", "ap_nf", "est"}
 	conflictKeys := []string{"year", "fips_state", "fips_county", "naics"}
 
 	var batch [][]any
 	var totalRows int64
+	slice := sliceFromContext(ctx)
 
 	for {
 		record, err := reader.Read()
@@ -179,12 +313,15 @@ func (d *CBP) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int)
 		}
 
 		naics := trimQuotes(getCol(record, colIdx, "naics"))
-		if !transform.IsRelevantNAICS(naics) {
+		if !filter.Match(naics) {
 			continue
 		}
 		naics = transform.NormalizeNAICS(naics)
 
 		fipsState := transform.NormalizeFIPSState(trimQuotes(getCol(record, colIdx, "fipstate")))
+		if !slice.contains(0, fipsState) {
+			continue
+		}
 		fipsCounty := transform.NormalizeFIPSCounty(trimQuotes(getCol(record, colIdx, "fipscty")))
 
 		row := []any{
@@ -232,6 +369,109 @@ func (d *CBP) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int)
 	return totalRows, nil
 }
 
+// parseZBP loads a ZBP detail file into fed_data.zbp_data.
+func (d *CBP) parseZBP(ctx context.Context, pool db.Pool, r io.Reader, year int) (int64, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "cbp: read ZBP header")
+	}
+	colIdx := mapColumns(header)
+	for _, col := range []string{"zip", "naics", "est"} {
+		if _, ok := colIdx[col]; !ok {
+			return 0, eris.Errorf("cbp: ZBP file missing %s column", col)
+		}
+	}
+	filter, err := naicsFilterFor(d.cfg, "cbp")
+	if err != nil {
+		return 0, err
+	}
+
+	cfg := db.UpsertConfig{
+		Table:        "fed_data.zbp_data",
+		Columns:      zbpColumns,
+		ConflictKeys: []string{"year", "zip", "naics"},
+	}
+
+	var batch [][]any
+	var totalRows int64
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue // skip malformed rows
+		}
+
+		row := zbpRow(colIdx, record, year, filter)
+		if row == nil {
+			continue
+		}
+		batch = append(batch, row)
+
+		if len(batch) >= cbpBatchSize {
+			n, err := db.BulkUpsert(ctx, pool, cfg, batch)
+			if err != nil {
+				return totalRows, eris.Wrap(err, "cbp: zbp bulk upsert")
+			}
+			totalRows += n
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		n, err := db.BulkUpsert(ctx, pool, cfg, batch)
+		if err != nil {
+			return totalRows, eris.Wrap(err, "cbp: zbp bulk upsert final batch")
+		}
+		totalRows += n
+	}
+
+	return totalRows, nil
+}
+
+// zbpRow maps a ZBP detail record to zbpColumns. The all-industries row
+// ("------") normalizes to NAICS 000000 as in cbp_data. Returns nil for
+// records without a valid 5-digit ZIP.
+func zbpRow(colIdx map[string]int, record []string, year int, filter *transform.NAICSFilter) []any {
+	zip, err := strconv.Atoi(trimQuotes(getCol(record, colIdx, "zip")))
+	if err != nil || zip <= 0 || zip > 99999 {
+		return nil
+	}
+
+	naics := trimQuotes(getCol(record, colIdx, "naics"))
+	if !filter.Match(naics) {
+		return nil
+	}
+	naics = transform.NormalizeNAICS(naics)
+	if naics == "" {
+		return nil
+	}
+
+	row := []any{
+		int16(year), // #nosec G115 -- year is a calendar year (e.g. 2000-2030), fits in int16
+		fmt.Sprintf("%05d", zip),
+		naics,
+		nilIfEmpty(fitLen(strings.ToUpper(trimQuotes(getCol(record, colIdx, "stabbr"))), 2)),
+		nilIfEmpty(sanitizeUTF8(trimQuotes(getCol(record, colIdx, "city")))),
+		parseIntOr(trimQuotes(getCol(record, colIdx, "est")), 0),
+	}
+	for _, names := range zbpSizeFields {
+		var v string
+		for _, name := range names {
+			if v = trimQuotes(getCol(record, colIdx, name)); v != "" {
+				break
+			}
+		}
+		row = append(row, parseIntOr(v, 0))
+	}
+	return row
+}
+
 // mapColumns builds a case-insensitive column name to index map.
 func mapColumns(header []string) map[string]int {
 	m := make(map[string]int, len(header))
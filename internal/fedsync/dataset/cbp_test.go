@@ -2,6 +2,8 @@ package dataset
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,9 +12,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sells-group/research-cli/internal/config"
 	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
+const zbpDetailCSV = "zip,name,naics,est,n<5,n5_9,n10_19,n20_49,n50_99,n100_249,n250_499,n500_999,n1000,stabbr,cty_name,city\n" +
+	"501,Holtsville NY,------,12,8,2,1,1,0,0,0,0,0,NY,Suffolk,HOLTSVILLE\n" +
+	"33480,Palm Beach FL,238220,9,5,2,1,0,1,0,0,0,0,fl,Palm Beach,PALM BEACH\n" +
+	"33480,Palm Beach FL,5415//,4,N,N,N,N,N,N,N,N,N,FL,Palm Beach,PALM BEACH\n" +
+	"99999,Unknown,------,3,3,0,0,0,0,0,0,0,0,,,\n" +
+	"bad,Bad Zip,------,1,1,0,0,0,0,0,0,0,0,,,\n"
+
 func TestCBP_Metadata(t *testing.T) {
 	ds := &CBP{}
 	assert.Equal(t, "cbp", ds.Name())
@@ -92,3 +102,71 @@ func TestCBP_Sync_YearFailure(t *testing.T) {
 	_, err = ds.Sync(context.Background(), pool, f, t.TempDir())
 	assert.Error(t, err)
 }
+
+func TestZBPRow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(zbpDetailCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	colIdx := mapColumns(header)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	row := zbpRow(colIdx, records[0], 2022, nil)
+	require.Len(t, row, len(zbpColumns))
+	assert.Equal(t, int16(2022), row[0])
+	assert.Equal(t, "00501", row[1])
+	assert.Equal(t, "000000", row[2], "all-industries total")
+	assert.Equal(t, "NY", row[3])
+	assert.Equal(t, "HOLTSVILLE", row[4])
+	assert.Equal(t, 12, row[5])
+	assert.Equal(t, 8, row[6])
+
+	row = zbpRow(colIdx, records[1], 2022, nil)
+	assert.Equal(t, "238220", row[2])
+	assert.Equal(t, "FL", row[3])
+	assert.Equal(t, 1, row[10])
+
+	row = zbpRow(colIdx, records[2], 2022, nil)
+	assert.Equal(t, 4, row[5])
+	assert.Equal(t, 0, row[6], "size class not available")
+
+	assert.NotNil(t, zbpRow(colIdx, records[3], 2022, nil))
+	assert.Nil(t, zbpRow(colIdx, records[4], 2022, nil), "invalid ZIP")
+
+	legacy := mapColumns([]string{"zip", "naics", "est", "n1_4"})
+	assert.Equal(t, 7, zbpRow(legacy, []string{"10001", "------", "9", "7"}, 2016, nil)[6])
+}
+
+func TestCBP_Sync_ZIPLevel(t *testing.T) {
+	dir := t.TempDir()
+	cbpZip := createTestZip(t, dir, "cbp_empty.zip", "cbp19co.csv", "fipstate,fipscty,naics,emp,emp_nf,qp1,qp1_nf,ap,ap_nf,est\n")
+	zbpZip := createTestZip(t, dir, "zbp_detail.zip", "zbp22detail.txt", zbpDetailCSV)
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	zbpURL := "https://www2.census.gov/programs-surveys/cbp/datasets/2022/zbp22detail.zip"
+	f.EXPECT().DownloadToFile(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return !strings.Contains(url, "/zbp")
+	}), mock.Anything).
+		Run(func(_ context.Context, _ string, destPath string) {
+			copyTestFixture(t, cbpZip, destPath)
+		}).Return(int64(1000), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, zbpURL, mock.Anything).
+		Run(func(_ context.Context, _ string, destPath string) {
+			copyTestFixture(t, zbpZip, destPath)
+		}).Return(int64(1000), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("http: status 404"))
+
+	expectBulkUpsert(pool, "fed_data.zbp_data", zbpColumns, 4)
+
+	ds := &CBP{cfg: &config.Config{Fedsync: config.FedsyncConfig{CBP: config.CBPConfig{ZIPLevel: true}}}}
+	result, err := ds.Sync(context.Background(), pool, f, dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.RowsSynced)
+	assert.Equal(t, int64(4), result.Metadata["zbp_rows"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
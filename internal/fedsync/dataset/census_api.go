@@ -0,0 +1,80 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/censusapi"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// censusOldestYear bounds the backward search for the latest published
+// year of annual Census API tables.
+const censusOldestYear = 2020
+
+// censusClient returns a Census API client using the configured key.
+func censusClient(cfg *config.Config, f fetcher.Fetcher) *censusapi.Client {
+	key := ""
+	if cfg != nil {
+		key = cfg.Fedsync.CensusKey
+	}
+	return censusapi.New(f, key)
+}
+
+// censusLatestDataset is the shared sync behind annual Census API tables
+// loaded from their most recent published year (NES, ABS, ASM): walk back
+// from last year to censusOldestYear until path is published, map each
+// record with row, and upsert.
+type censusLatestDataset struct {
+	name  string
+	table string
+	path  string   // dataset below the year, e.g. "nonemp"
+	get   []string // variables requested
+	geo   string   // geography, e.g. "us:*"
+
+	cols         []string
+	conflictKeys []string
+	// row maps one record to cols; nil skips it.
+	row func(year int, t *censusapi.Table, rec []string) []any
+}
+
+func (c censusLatestDataset) sync(ctx context.Context, pool db.Pool, api *censusapi.Client) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", c.name))
+	log.Info("syncing " + c.name + " data")
+
+	year, t, err := api.Latest(ctx, time.Now().Year()-1, censusOldestYear, func(year int) censusapi.Query {
+		return censusapi.Query{Dataset: fmt.Sprintf("%d/%s", year, c.path), Get: c.get, For: c.geo}
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, c.name)
+	}
+	if t == nil {
+		log.Warn(c.name+": no data available for any year", zap.Int("oldest_year", censusOldestYear))
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+	if t.Len() == 0 {
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+
+	rows := make([][]any, 0, t.Len())
+	for _, rec := range t.Rows {
+		if row := c.row(year, t, rec); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        c.table,
+		Columns:      c.cols,
+		ConflictKeys: c.conflictKeys,
+	}, rows)
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: upsert", c.name)
+	}
+	return &SyncResult{RowsSynced: n, Metadata: map[string]any{"year": year}}, nil
+}
@@ -50,6 +50,9 @@ func (d *CensusGeo) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return QuarterlyWithLag(now, lastSync, 1)
 }
 
+// Mirrors implements Mirrored.
+func (d *CensusGeo) Mirrors() []fetcher.Mirror { return censusMirrors }
+
 // Sync downloads Census Gazetteer state and county files, then upserts into fips_codes.
 func (d *CensusGeo) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", d.Name()))
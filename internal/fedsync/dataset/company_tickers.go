@@ -0,0 +1,196 @@
+package dataset
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	companyTickersURL         = "https://www.sec.gov/files/company_tickers.json"
+	companyTickersExchangeURL = "https://www.sec.gov/files/company_tickers_exchange.json"
+	companyTickersBatchSize   = 5000
+)
+
+// cikTickerColumns defines the cik_tickers upsert columns.
+var cikTickerColumns = []string{"cik", "ticker", "title", "exchange", "updated_at"}
+
+// companyTickerJSON is one entry of company_tickers.json, which is keyed by
+// an arbitrary index ("0", "1", ...).
+type companyTickerJSON struct {
+	CIK    int64  `json:"cik_str"`
+	Ticker string `json:"ticker"`
+	Title  string `json:"title"`
+}
+
+// companyTickersExchangeJSON is company_tickers_exchange.json: a field list
+// (cik, name, ticker, exchange) and positional rows.
+type companyTickersExchangeJSON struct {
+	Fields []string `json:"fields"`
+	Data   [][]any  `json:"data"`
+}
+
+// CompanyTickers syncs the SEC's ticker-to-CIK map (company_tickers.json)
+// and its exchange listing (company_tickers_exchange.json) into
+// fed_data.cik_tickers, one row per CIK and ticker. The files cover
+// currently listed registrants only, so tickers no longer published are
+// removed after each sync.
+type CompanyTickers struct{}
+
+// Name implements Dataset.
+func (d *CompanyTickers) Name() string { return "company_tickers" }
+
+// Table implements Dataset.
+func (d *CompanyTickers) Table() string { return "fed_data.cik_tickers" }
+
+// Phase implements Dataset.
+func (d *CompanyTickers) Phase() Phase { return Phase1B }
+
+// Cadence implements Dataset.
+func (d *CompanyTickers) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *CompanyTickers) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync downloads both ticker files, merges the exchange onto each ticker,
+// and replaces the table contents.
+func (d *CompanyTickers) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	var tickers map[string]companyTickerJSON
+	if err := downloadJSON(ctx, f, companyTickersURL, &tickers); err != nil {
+		return nil, eris.Wrap(err, "company_tickers: company_tickers.json")
+	}
+	var exchange companyTickersExchangeJSON
+	if err := downloadJSON(ctx, f, companyTickersExchangeURL, &exchange); err != nil {
+		return nil, eris.Wrap(err, "company_tickers: company_tickers_exchange.json")
+	}
+
+	start := time.Now().UTC()
+	rows, err := cikTickerRows(tickers, exchange, start)
+	if err != nil {
+		return nil, eris.Wrap(err, "company_tickers: parse exchange listing")
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("company_tickers: no tickers in SEC files")
+	}
+
+	var total int64
+	for i := 0; i < len(rows); i += companyTickersBatchSize {
+		end := min(i+companyTickersBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "fed_data.cik_tickers",
+			Columns:      cikTickerColumns,
+			ConflictKeys: []string{"cik", "ticker"},
+		}, rows[i:end])
+		if err != nil {
+			return nil, eris.Wrap(err, "company_tickers: upsert")
+		}
+		total += n
+	}
+
+	tag, err := pool.Exec(ctx, `DELETE FROM fed_data.cik_tickers WHERE updated_at < $1`, start)
+	if err != nil {
+		return nil, eris.Wrap(err, "company_tickers: delete delisted tickers")
+	}
+
+	log.Info("company_tickers sync complete", zap.Int64("rows", total), zap.Int64("removed", tag.RowsAffected()))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata:   map[string]any{"removed": tag.RowsAffected()},
+	}, nil
+}
+
+// downloadJSON downloads url and decodes the body into v.
+func downloadJSON(ctx context.Context, f fetcher.Fetcher, url string, v any) error {
+	body, err := f.Download(ctx, url)
+	if err != nil {
+		return eris.Wrap(err, "download")
+	}
+	defer body.Close() //nolint:errcheck
+	return eris.Wrap(json.NewDecoder(body).Decode(v), "decode")
+}
+
+// cikTickerRows merges the two SEC ticker files into cikTickerColumns rows
+// keyed by CIK and upper-cased ticker. Tickers only in the exchange listing
+// are kept; the exchange is NULL for tickers missing from it.
+func cikTickerRows(tickers map[string]companyTickerJSON, exchange companyTickersExchangeJSON, now time.Time) ([][]any, error) {
+	type entry struct{ title, exchange string }
+	entries := make(map[[2]string]*entry)
+	add := func(cik int64, ticker, title string) *entry {
+		ticker = fitLen(strings.ToUpper(strings.TrimSpace(ticker)), 20)
+		if cik <= 0 || ticker == "" {
+			return nil
+		}
+		key := [2]string{fmt.Sprintf("%010d", cik), ticker}
+		e, ok := entries[key]
+		if !ok {
+			e = &entry{}
+			entries[key] = e
+		}
+		if e.title == "" {
+			e.title = strings.TrimSpace(title)
+		}
+		return e
+	}
+
+	for _, t := range tickers {
+		add(t.CIK, t.Ticker, t.Title)
+	}
+
+	idx := make(map[string]int, len(exchange.Fields))
+	for i, field := range exchange.Fields {
+		idx[strings.ToLower(field)] = i
+	}
+	for _, field := range []string{"cik", "ticker"} {
+		if _, ok := idx[field]; !ok && len(exchange.Data) > 0 {
+			return nil, eris.Errorf("missing %s field", field)
+		}
+	}
+	for _, rec := range exchange.Data {
+		cik, _ := jsonField(rec, idx, "cik").(float64)
+		ticker, _ := jsonField(rec, idx, "ticker").(string)
+		name, _ := jsonField(rec, idx, "name").(string)
+		if e := add(int64(cik), ticker, name); e != nil {
+			e.exchange, _ = jsonField(rec, idx, "exchange").(string)
+		}
+	}
+
+	rows := make([][]any, 0, len(entries))
+	for _, key := range slices.SortedFunc(maps.Keys(entries), func(a, b [2]string) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	}) {
+		e := entries[key]
+		rows = append(rows, []any{
+			key[0],
+			key[1],
+			nilIfEmpty(sanitizeUTF8(e.title)),
+			nilIfEmpty(strings.TrimSpace(e.exchange)),
+			now,
+		})
+	}
+	return rows, nil
+}
+
+// jsonField returns the named positional field of an exchange listing row,
+// or nil when absent.
+func jsonField(rec []any, idx map[string]int, field string) any {
+	i, ok := idx[field]
+	if !ok || i >= len(rec) {
+		return nil
+	}
+	return rec[i]
+}
@@ -0,0 +1,98 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const companyTickersJSON = `{
+"0":{"cik_str":320193,"ticker":"AAPL","title":"Apple Inc."},
+"1":{"cik_str":1364742,"ticker":"BLK","title":"BlackRock, Inc."},
+"2":{"cik_str":1364742,"ticker":"blk","title":"BlackRock, Inc."},
+"3":{"cik_str":0,"ticker":"NONE","title":"No CIK"}
+}`
+
+const companyTickersExchangeJSONFixture = `{
+"fields":["cik","name","ticker","exchange"],
+"data":[[320193,"Apple Inc.","AAPL","Nasdaq"],[1364742,"BlackRock, Inc.","BLK","NYSE"],[1067983,"Berkshire Hathaway Inc","BRK-B","NYSE"],[1067983,"Berkshire Hathaway Inc","",null]]
+}`
+
+func TestCompanyTickers_Metadata(t *testing.T) {
+	d := &CompanyTickers{}
+	assert.Equal(t, "company_tickers", d.Name())
+	assert.Equal(t, "fed_data.cik_tickers", d.Table())
+	assert.Equal(t, Phase1B, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestCIKTickerRows(t *testing.T) {
+	var tickers map[string]companyTickerJSON
+	require.NoError(t, json.Unmarshal([]byte(companyTickersJSON), &tickers))
+	var exchange companyTickersExchangeJSON
+	require.NoError(t, json.Unmarshal([]byte(companyTickersExchangeJSONFixture), &exchange))
+
+	now := time.Now()
+	rows, err := cikTickerRows(tickers, exchange, now)
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "case-insensitive duplicate and missing CIK dropped")
+
+	assert.Equal(t, []any{"0000320193", "AAPL", "Apple Inc.", "Nasdaq", now}, rows[0])
+	assert.Equal(t, []any{"0001067983", "BRK-B", "Berkshire Hathaway Inc", "NYSE", now}, rows[1], "exchange-only ticker kept")
+	assert.Equal(t, "BLK", rows[2][1])
+	assert.Equal(t, "NYSE", rows[2][3])
+
+	rows, err = cikTickerRows(tickers, companyTickersExchangeJSON{}, now)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Nil(t, rows[0][3], "no exchange listing")
+
+	_, err = cikTickerRows(nil, companyTickersExchangeJSON{Fields: []string{"name"}, Data: [][]any{{"x"}}}, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing cik field")
+}
+
+func TestCompanyTickers_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, companyTickersURL).
+		Return(io.NopCloser(strings.NewReader(companyTickersJSON)), nil)
+	f.EXPECT().Download(mock.Anything, companyTickersExchangeURL).
+		Return(io.NopCloser(strings.NewReader(companyTickersExchangeJSONFixture)), nil)
+
+	expectBulkUpsert(pool, "fed_data.cik_tickers", cikTickerColumns, 3)
+	pool.ExpectExec("DELETE FROM fed_data.cik_tickers").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	res, err := (&CompanyTickers{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["removed"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCompanyTickers_SyncDownloadError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, companyTickersURL).
+		Return(nil, errors.New("status 403"))
+
+	_, err := (&CompanyTickers{}).Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "company_tickers.json")
+}
@@ -0,0 +1,350 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	courtListenerBaseURL = "https://www.courtlistener.com"
+	courtListenerSearch  = "/api/rest/v4/search/"
+
+	// courtListenerMaxPages caps one sync's cursor walk (20 results per
+	// page) so a misconfigured window cannot run unbounded.
+	courtListenerMaxPages = 2000
+)
+
+// courtDocketColumns defines the target DB columns in upsert order.
+var courtDocketColumns = []string{
+	"docket_id", "court_id", "docket_number", "case_name", "nature_of_suit", "cause",
+	"date_filed", "date_terminated", "defendants", "defendant_norms", "parties",
+	"absolute_url", "updated_at",
+}
+
+// CourtListener syncs federal court dockets from the CourtListener RECAP
+// search API into fed_data.court_dockets. Only dockets with a defendant
+// matching a public.companies name (optionally limited to configured NAICS
+// prefixes) are kept. The first sync scans the configured lookback; later
+// syncs resume from the scan high-water mark (the latest filing date
+// scanned, matched or not), which the engine keeps in fed_data.sync_state.
+type CourtListener struct {
+	cfg        *config.Config
+	baseURL    string       // override for testing
+	httpClient *http.Client // override for testing
+}
+
+// Name implements Dataset.
+func (d *CourtListener) Name() string { return "courtlistener" }
+
+// Table implements Dataset.
+func (d *CourtListener) Table() string { return "fed_data.court_dockets" }
+
+// Phase implements Dataset.
+func (d *CourtListener) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *CourtListener) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *CourtListener) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// courtListenerPage is one page of RECAP search results.
+type courtListenerPage struct {
+	Next    *string               `json:"next"`
+	Results []courtListenerDocket `json:"results"`
+}
+
+// courtListenerDocket is a RECAP search result (one docket).
+type courtListenerDocket struct {
+	DocketID       int64    `json:"docket_id"`
+	CaseName       string   `json:"caseName"`
+	CourtID        string   `json:"court_id"`
+	DocketNumber   string   `json:"docketNumber"`
+	DateFiled      string   `json:"dateFiled"`
+	DateTerminated string   `json:"dateTerminated"`
+	SuitNature     string   `json:"suitNature"`
+	Cause          string   `json:"cause"`
+	Parties        []string `json:"party"`
+	AbsoluteURL    string   `json:"docket_absolute_url"`
+}
+
+// Sync walks RECAP search results filed within the configured lookback and
+// upserts dockets naming a known company as defendant.
+func (d *CourtListener) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, d.lookbackStart())
+}
+
+// SyncIncremental implements IncrementalSyncer, resuming one day before the
+// scan high-water mark to catch late-indexed filings.
+func (d *CourtListener) SyncIncremental(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string, since time.Time) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, since.UTC().AddDate(0, 0, -1))
+}
+
+// syncSince walks RECAP search results filed since the given date in filing
+// order. The result's Watermark is the latest filing date scanned, so a
+// page-limited walk resumes where it stopped.
+func (d *CourtListener) syncSince(ctx context.Context, pool db.Pool, since time.Time) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || d.cfg.Fedsync.CourtListener.Token == "" {
+		return nil, eris.New("courtlistener: API token required (fedsync.courtlistener.token)")
+	}
+
+	companies, err := d.loadCompanyNames(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	if len(companies) == 0 {
+		// Hold the mark so companies added later are matched against
+		// the whole window.
+		log.Warn("courtlistener: no companies to match, skipping")
+		return &SyncResult{Metadata: map[string]any{"companies": 0}, Watermark: &since}, nil
+	}
+
+	log.Info("starting courtlistener sync", zap.Time("since", since), zap.Int("companies", len(companies)))
+
+	q := url.Values{}
+	q.Set("type", "r")
+	q.Set("order_by", "dateFiled asc")
+	q.Set("filed_after", since.Format("01/02/2006"))
+	next := d.base() + courtListenerSearch + "?" + q.Encode()
+
+	now := time.Now().UTC()
+	mark := since
+	var total int64
+	var scanned, pages int
+	for next != "" && pages < courtListenerMaxPages {
+		var page courtListenerPage
+		if err := d.getJSON(ctx, next, &page); err != nil {
+			return nil, eris.Wrapf(err, "courtlistener: fetch page %d", pages+1)
+		}
+		pages++
+		scanned += len(page.Results)
+
+		var rows [][]any
+		for _, r := range page.Results {
+			if filed := parseDate(r.DateFiled); filed != nil && filed.After(mark) {
+				mark = *filed
+			}
+			if row := courtDocketRow(r, companies, now); row != nil {
+				rows = append(rows, row)
+			}
+		}
+		if len(rows) > 0 {
+			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table:        d.Table(),
+				Columns:      courtDocketColumns,
+				ConflictKeys: []string{"docket_id"},
+			}, rows)
+			if err != nil {
+				return nil, eris.Wrap(err, "courtlistener: upsert")
+			}
+			total += n
+		}
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	if next != "" {
+		log.Warn("courtlistener: page limit reached, resuming next sync", zap.Int("pages", pages))
+	}
+
+	log.Info("courtlistener sync complete",
+		zap.Int("scanned", scanned), zap.Int64("matched", total), zap.Int("pages", pages))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"since":     since.Format("2006-01-02"),
+			"scanned":   scanned,
+			"pages":     pages,
+			"companies": len(companies),
+		},
+		Watermark: &mark,
+	}, nil
+}
+
+// loadCompanyNames returns the normalized names and legal names of
+// companies whose NAICS code matches a configured prefix.
+func (d *CourtListener) loadCompanyNames(ctx context.Context, pool db.Pool) (map[string]struct{}, error) {
+	patterns := make([]string, 0, len(d.cfg.Fedsync.CourtListener.NAICS))
+	for _, p := range d.cfg.Fedsync.CourtListener.NAICS {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p+"%")
+		}
+	}
+	rows, err := pool.Query(ctx, `
+		SELECT name, COALESCE(legal_name, '')
+		FROM public.companies
+		WHERE cardinality($1::text[]) = 0 OR naics_code LIKE ANY($1)`,
+		patterns,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "courtlistener: query companies")
+	}
+	defer rows.Close()
+
+	names := make(map[string]struct{})
+	for rows.Next() {
+		var name, legal string
+		if err := rows.Scan(&name, &legal); err != nil {
+			return nil, eris.Wrap(err, "courtlistener: scan company")
+		}
+		for _, n := range []string{name, legal} {
+			if norm := resolve.NormalizeName(n); norm != "" {
+				names[norm] = struct{}{}
+			}
+		}
+	}
+	return names, eris.Wrap(rows.Err(), "courtlistener: iterate companies")
+}
+
+// lookbackStart returns the start of the configured lookback window used
+// when there is no scan high-water mark.
+func (d *CourtListener) lookbackStart() time.Time {
+	days := 0
+	if d.cfg != nil {
+		days = d.cfg.Fedsync.CourtListener.LookbackDays
+	}
+	if days <= 0 {
+		days = 90
+	}
+	return time.Now().UTC().AddDate(0, 0, -days).Truncate(24 * time.Hour)
+}
+
+func (d *CourtListener) base() string {
+	if d.baseURL != "" {
+		return d.baseURL
+	}
+	return courtListenerBaseURL
+}
+
+func (d *CourtListener) client() *http.Client {
+	if d.httpClient != nil {
+		return d.httpClient
+	}
+	return http.DefaultClient
+}
+
+// getJSON issues an authenticated GET, which needs an Authorization header
+// the shared fetcher cannot set.
+func (d *CourtListener) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return eris.Wrap(err, "create request")
+	}
+	req.Header.Set("Authorization", "Token "+d.cfg.Fedsync.CourtListener.Token)
+	req.Header.Set("User-Agent", "research-cli/1.0")
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return eris.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return eris.Wrap(json.NewDecoder(resp.Body).Decode(v), "decode response")
+}
+
+// courtDocketRow maps a search result to courtDocketColumns when one of its
+// defendants is a known company. Returns nil otherwise.
+func courtDocketRow(r courtListenerDocket, companies map[string]struct{}, now time.Time) []any {
+	if r.DocketID == 0 || r.CaseName == "" {
+		return nil
+	}
+	defendants, norms := matchDefendants(r.CaseName, r.Parties, companies)
+	if len(defendants) == 0 {
+		return nil
+	}
+
+	var absURL any
+	if r.AbsoluteURL != "" {
+		absURL = courtListenerBaseURL + r.AbsoluteURL
+	}
+	return []any{
+		r.DocketID,
+		r.CourtID,
+		nilIfEmpty(strings.TrimSpace(r.DocketNumber)),
+		sanitizeUTF8(r.CaseName),
+		nilIfEmpty(strings.TrimSpace(r.SuitNature)),
+		nilIfEmpty(strings.TrimSpace(r.Cause)),
+		dateOrNil(extractDate(r.DateFiled)),
+		dateOrNil(extractDate(r.DateTerminated)),
+		defendants,
+		norms,
+		r.Parties,
+		absURL,
+		now,
+	}
+}
+
+// matchDefendants returns the parties on the defendant side of caseName
+// whose normalized names are known companies, with their normalized names.
+// Without a party list, the defendant side itself is matched.
+func matchDefendants(caseName string, parties []string, companies map[string]struct{}) ([]string, []string) {
+	side := resolve.NormalizeName(defendantSide(caseName))
+	if side == "" {
+		return nil, nil
+	}
+	if len(parties) == 0 {
+		if _, ok := companies[side]; ok {
+			return []string{strings.TrimSpace(defendantSide(caseName))}, []string{side}
+		}
+		return nil, nil
+	}
+
+	padded := " " + side + " "
+	var names, norms []string
+	seen := make(map[string]struct{})
+	for _, p := range parties {
+		norm := resolve.NormalizeName(p)
+		if norm == "" || !strings.Contains(padded, " "+norm+" ") {
+			continue
+		}
+		if _, ok := companies[norm]; !ok {
+			continue
+		}
+		if _, dup := seen[norm]; dup {
+			continue
+		}
+		seen[norm] = struct{}{}
+		names = append(names, strings.TrimSpace(p))
+		norms = append(norms, norm)
+	}
+	return names, norms
+}
+
+// defendantSide returns the part of a case caption naming the defendant
+// ("Smith v. Acme LLC" -> "Acme LLC") or the subject of an "In re" case.
+// Returns "" when the caption has neither form.
+func defendantSide(caseName string) string {
+	lower := strings.ToLower(caseName)
+	for _, sep := range []string{" v. ", " vs. ", " v ", " vs "} {
+		if i := strings.Index(lower, sep); i >= 0 {
+			return caseName[i+len(sep):]
+		}
+	}
+	if strings.HasPrefix(lower, "in re ") {
+		return caseName[len("in re "):]
+	}
+	if strings.HasPrefix(lower, "in re: ") {
+		return caseName[len("in re: "):]
+	}
+	return ""
+}
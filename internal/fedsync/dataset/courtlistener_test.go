@@ -0,0 +1,155 @@
+package dataset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+func courtListenerTestConfig() *config.Config {
+	return &config.Config{Fedsync: config.FedsyncConfig{
+		CourtListener: config.CourtListenerConfig{Token: "secret", NAICS: []string{"238"}, LookbackDays: 30},
+	}}
+}
+
+func TestCourtListener_Metadata(t *testing.T) {
+	d := &CourtListener{}
+	assert.Equal(t, "courtlistener", d.Name())
+	assert.Equal(t, "fed_data.court_dockets", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+	assert.Implements(t, (*IncrementalSyncer)(nil), d)
+}
+
+func TestDefendantSide(t *testing.T) {
+	tests := map[string]string{
+		"Smith v. Acme Plumbing LLC":     "Acme Plumbing LLC",
+		"Jones vs Bayou HVAC, Inc.":      "Bayou HVAC, Inc.",
+		"In re Lone Star Electric Corp.": "Lone Star Electric Corp.",
+		"United States":                  "",
+	}
+	for caption, want := range tests {
+		assert.Equal(t, want, defendantSide(caption), caption)
+	}
+}
+
+func TestMatchDefendants(t *testing.T) {
+	companies := map[string]struct{}{"ACME PLUMBING": {}, "SMITH": {}}
+
+	names, norms := matchDefendants("Smith v. Acme Plumbing LLC et al",
+		[]string{"Smith", "Acme Plumbing LLC", "Acme Plumbing, L.L.C."}, companies)
+	assert.Equal(t, []string{"Acme Plumbing LLC"}, names, "plaintiff ignored, duplicate party collapsed")
+	assert.Equal(t, []string{"ACME PLUMBING"}, norms)
+
+	names, _ = matchDefendants("Doe v. Acme Plumbing Supply", []string{"Acme Plumbing Supply"}, companies)
+	assert.Empty(t, names)
+
+	names, norms = matchDefendants("Doe v. Acme Plumbing, LLC", nil, companies)
+	assert.Equal(t, []string{"Acme Plumbing, LLC"}, names)
+	assert.Equal(t, []string{"ACME PLUMBING"}, norms)
+
+	names, _ = matchDefendants("Sealed Case", []string{"Acme Plumbing"}, companies)
+	assert.Empty(t, names)
+}
+
+func TestCourtListener_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("FROM public.companies").
+		WithArgs([]string{"238%"}).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "legal_name"}).
+			AddRow("Acme Plumbing", "Acme Plumbing LLC").
+			AddRow("Bayou HVAC", ""))
+
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/api/rest/v4/search/", r.URL.Path)
+		if r.URL.Query().Get("cursor") == "" {
+			assert.Equal(t, "r", r.URL.Query().Get("type"))
+			assert.Equal(t, "03/09/2026", r.URL.Query().Get("filed_after"))
+			_, _ = w.Write([]byte(`{"next":"` + srvURL + `/api/rest/v4/search/?cursor=abc","results":[
+				{"docket_id":101,"caseName":"Smith v. Acme Plumbing LLC","court_id":"txsd","docketNumber":"4:26-cv-00101",
+				 "dateFiled":"2026-03-11","dateTerminated":null,"suitNature":"Labor: FLSA","cause":"29:201 Fair Labor Standards Act",
+				 "party":["John Smith","Acme Plumbing LLC"],"docket_absolute_url":"/docket/101/smith-v-acme-plumbing-llc/"},
+				{"docket_id":102,"caseName":"Acme Plumbing LLC v. Jones","court_id":"txsd","dateFiled":"2026-03-12",
+				 "party":["Acme Plumbing LLC","Jones"]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"next":null,"results":[
+			{"docket_id":103,"caseName":"In re Bayou HVAC","court_id":"txsb","dateFiled":"2026-03-13",
+			 "party":["Bayou HVAC"]}]}`))
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	expectBulkUpsert(pool, "fed_data.court_dockets", courtDocketColumns, 1)
+	expectBulkUpsert(pool, "fed_data.court_dockets", courtDocketColumns, 1)
+
+	d := &CourtListener{cfg: courtListenerTestConfig(), baseURL: srv.URL}
+	mark := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	res, err := d.SyncIncremental(context.Background(), pool, nil, t.TempDir(), mark)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, "2026-03-09", res.Metadata["since"])
+	assert.Equal(t, 3, res.Metadata["scanned"])
+	assert.Equal(t, 2, res.Metadata["pages"])
+	require.NotNil(t, res.Watermark)
+	assert.Equal(t, time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC), *res.Watermark, "latest filing scanned")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCourtListener_SyncErrors(t *testing.T) {
+	_, err := (&CourtListener{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.courtlistener.token")
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	pool.ExpectQuery("FROM public.companies").
+		WithArgs([]string{"238%"}).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "legal_name"}).AddRow("Acme Plumbing", ""))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err = (&CourtListener{cfg: courtListenerTestConfig(), baseURL: srv.URL}).Sync(context.Background(), pool, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "courtlistener: fetch page 1")
+	assert.Contains(t, err.Error(), "status 429")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCourtListener_SyncNoCompanies(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	pool.ExpectQuery("FROM public.companies").
+		WithArgs([]string{"238%"}).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "legal_name"}))
+
+	since := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	res, err := (&CourtListener{cfg: courtListenerTestConfig()}).SyncIncremental(context.Background(), pool, nil, t.TempDir(), since)
+	require.NoError(t, err)
+	assert.Zero(t, res.RowsSynced)
+	require.NotNil(t, res.Watermark)
+	assert.False(t, res.Watermark.After(since), "mark held")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,47 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// CPI syncs BLS Consumer Price Index series configured under fedsync.cpi.series.
+type CPI struct {
+	cfg    *config.Config
+	blsURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *CPI) Name() string { return "cpi" }
+
+// Table implements Dataset.
+func (d *CPI) Table() string { return "fed_data.cpi_data" }
+
+// Phase implements Dataset.
+func (d *CPI) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *CPI) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *CPI) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync fetches and loads the configured CPI series.
+func (d *CPI) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return blsSeriesDataset{
+		fetcher:       f,
+		name:          d.Name(),
+		table:         d.Table(),
+		url:           d.blsURL,
+		apiKey:        d.cfg.Fedsync.BLSKey,
+		dailyLimit:    blsDailyLimit(d.cfg),
+		series:        normalizeBLSSeries(d.cfg.Fedsync.CPI.Series),
+		lookbackYears: 3,
+	}.sync(ctx, pool)
+}
@@ -2,22 +2,17 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
 	"time"
 
-	"github.com/rotisserie/eris"
-	"github.com/sells-group/research-cli/internal/db"
-	"go.uber.org/zap"
-
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 // CPSLAUS syncs BLS CPS/LAUS local area unemployment data.
 type CPSLAUS struct {
-	cfg *config.Config
+	cfg    *config.Config
+	blsURL string // override for testing
 }
 
 // Name implements Dataset.
@@ -53,56 +48,14 @@ var lausSeries = []string{
 
 // Sync fetches and loads BLS CPS/LAUS unemployment data.
 func (d *CPSLAUS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
-	log := zap.L().With(zap.String("dataset", d.Name()))
-	log.Info("syncing CPS/LAUS data")
-
-	endYear := time.Now().Year()
-	startYear := endYear - 2
-
-	var allRows [][]any
-
-	for _, seriesID := range lausSeries {
-		url := fmt.Sprintf("https://api.bls.gov/publicAPI/v2/timeseries/data/%s?registrationkey=%s&startyear=%d&endyear=%d",
-			seriesID, d.cfg.Fedsync.BLSKey, startYear, endYear)
-
-		body, err := f.Download(ctx, url)
-		if err != nil {
-			log.Warn("skip series", zap.String("series", seriesID), zap.Error(err))
-			continue
-		}
-
-		data, err := io.ReadAll(body)
-		_ = body.Close()
-		if err != nil {
-			continue
-		}
-
-		var resp blsSeriesResponse
-		if err := json.Unmarshal(data, &resp); err != nil {
-			continue
-		}
-
-		for _, series := range resp.Results.Series {
-			for _, dp := range series.Data {
-				allRows = append(allRows, []any{
-					series.SeriesID,
-					parseInt16Or(dp.Year, 0),
-					dp.Period,
-					parseFloat64Or(dp.Value, 0),
-				})
-			}
-		}
-	}
-
-	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-		Table:        d.Table(),
-		Columns:      []string{"series_id", "year", "period", "value"},
-		ConflictKeys: []string{"series_id", "year", "period"},
-	}, allRows)
-	if err != nil {
-		return nil, eris.Wrap(err, "cps_laus: upsert")
-	}
-
-	log.Info("cps_laus sync complete", zap.Int64("rows", n))
-	return &SyncResult{RowsSynced: n}, nil
+	return blsSeriesDataset{
+		fetcher:       f,
+		name:          d.Name(),
+		table:         d.Table(),
+		url:           d.blsURL,
+		apiKey:        d.cfg.Fedsync.BLSKey,
+		dailyLimit:    blsDailyLimit(d.cfg),
+		series:        lausSeries,
+		lookbackYears: 2,
+	}.sync(ctx, pool)
 }
@@ -0,0 +1,195 @@
+package dataset
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+// DefaultDaemonInterval is how often the daemon checks schedules when
+// DaemonOpts.Interval is unset.
+const DefaultDaemonInterval = 15 * time.Minute
+
+// maxFailureBackoff caps how long the daemon waits before retrying a
+// dataset whose last run failed.
+const maxFailureBackoff = 24 * time.Hour
+
+// DaemonOpts configures a Daemon.
+type DaemonOpts struct {
+	Interval  time.Duration     // time between schedule checks
+	Schedules map[string]string // dataset name -> cron expression overriding ShouldRun
+	Phase     *Phase            // restrict to a phase
+	Datasets  []string          // restrict to these datasets
+	Full      bool              // full reload instead of incremental
+}
+
+// Daemon runs an Engine on a loop: every interval it checks each selected
+// dataset's schedule against its run history in fed_data.sync_runs and
+// syncs the due ones. Because that history is the daemon's only schedule
+// state, a restarted daemon picks up exactly where the last one stopped.
+type Daemon struct {
+	engine    *Engine
+	opts      DaemonOpts
+	schedules map[string]cron.Schedule
+	state     *fedsync.DaemonState
+	afterRun  func(ctx context.Context, start time.Time, outcomes []Outcome)
+	now       func() time.Time
+}
+
+// NewDaemon creates a Daemon over e. It fails on cron expressions that do
+// not parse or name unknown datasets.
+func NewDaemon(e *Engine, opts DaemonOpts) (*Daemon, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultDaemonInterval
+	}
+	schedules := make(map[string]cron.Schedule, len(opts.Schedules))
+	for name, expr := range opts.Schedules {
+		if _, err := e.reg.Get(name); err != nil {
+			return nil, eris.Wrapf(err, "daemon: schedule for %s", name)
+		}
+		sched, err := cron.ParseStandard(expr)
+		if err != nil {
+			return nil, eris.Wrapf(err, "daemon: parse schedule %q for %s", expr, name)
+		}
+		schedules[name] = sched
+	}
+	return &Daemon{
+		engine:    e,
+		opts:      opts,
+		schedules: schedules,
+		now:       func() time.Time { return time.Now().UTC() },
+	}, nil
+}
+
+// SetState records a heartbeat in fed_data.daemon_state after every check.
+func (d *Daemon) SetState(s *fedsync.DaemonState) {
+	d.state = s
+}
+
+// SetAfterRun sets a hook called after every check that synced at least
+// one dataset, with the engine's outcomes.
+func (d *Daemon) SetAfterRun(fn func(ctx context.Context, start time.Time, outcomes []Outcome)) {
+	d.afterRun = fn
+}
+
+// Run checks schedules every interval until ctx is cancelled. A failed
+// check is logged and recorded; it does not stop the daemon.
+func (d *Daemon) Run(ctx context.Context) error {
+	log := zap.L().With(zap.String("component", "fedsync.daemon"))
+	log.Info("daemon started", zap.Duration("interval", d.opts.Interval), zap.Int("cron_schedules", len(d.schedules)))
+
+	for {
+		tick, err := d.Tick(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("daemon check failed", zap.Error(err))
+		}
+		tick.Err = err
+		tick.Next = d.now().Add(d.opts.Interval)
+		if err := d.state.Record(context.WithoutCancel(ctx), tick); err != nil {
+			log.Warn("failed to record daemon state", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("daemon stopped")
+			return nil
+		case <-time.After(d.opts.Interval):
+		}
+	}
+}
+
+// Tick runs one schedule check: it syncs every due dataset and reports
+// what it found and ran.
+func (d *Daemon) Tick(ctx context.Context) (fedsync.DaemonTick, error) {
+	start := d.now()
+	tick := fedsync.DaemonTick{At: start}
+
+	due, err := d.Due(ctx, start)
+	if err != nil {
+		return tick, err
+	}
+	tick.Due = due
+	if len(due) == 0 {
+		return tick, nil
+	}
+
+	zap.L().Info("daemon: syncing due datasets", zap.Strings("datasets", due))
+	err = d.engine.Run(ctx, RunOpts{Datasets: due, Force: true, Full: d.opts.Full})
+	outcomes := d.engine.Outcomes()
+	for _, o := range outcomes {
+		if o.Status == "failed" {
+			tick.Failed++
+		} else {
+			tick.Synced++
+		}
+	}
+	if d.afterRun != nil && len(outcomes) > 0 {
+		d.afterRun(ctx, start, outcomes)
+	}
+	return tick, eris.Wrap(err, "daemon: run")
+}
+
+// Due returns the selected datasets that should sync at now, sorted by
+// name. A dataset with a cron schedule is due once the schedule has fired
+// since its last success; others follow ShouldRun. A dataset whose last
+// run failed waits out a backoff that doubles with each consecutive
+// failure, so a broken upstream is not hit every interval.
+func (d *Daemon) Due(ctx context.Context, now time.Time) ([]string, error) {
+	datasets, err := d.engine.reg.Select(d.opts.Phase, d.opts.Datasets)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := d.engine.syncLog.RunStats(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "daemon: load run history")
+	}
+	byName := make(map[string]fedsync.DatasetRunStats, len(stats))
+	for _, st := range stats {
+		byName[st.Dataset] = st
+	}
+
+	var due []string
+	for _, ds := range datasets {
+		st, seen := byName[ds.Name()]
+		if seen && st.LastStatus == "failed" && now.Sub(st.LastRun) < failureBackoff(d.opts.Interval, st.TrailingFailures) {
+			continue
+		}
+		var lastSuccess *time.Time
+		if seen {
+			lastSuccess = st.LastSuccess
+		}
+		if d.isDue(ds, now, lastSuccess) {
+			due = append(due, ds.Name())
+		}
+	}
+	sort.Strings(due)
+	return due, nil
+}
+
+// isDue applies the dataset's cron schedule, if any, else its ShouldRun.
+func (d *Daemon) isDue(ds Dataset, now time.Time, lastSuccess *time.Time) bool {
+	sched, ok := d.schedules[ds.Name()]
+	if !ok {
+		return ds.ShouldRun(now, lastSuccess)
+	}
+	if lastSuccess == nil {
+		return true
+	}
+	return !sched.Next(lastSuccess.UTC()).After(now)
+}
+
+// failureBackoff is how long to wait after the nth consecutive failure:
+// one interval, doubling per further failure, capped at a day.
+func failureBackoff(interval time.Duration, failures int) time.Duration {
+	backoff := interval
+	for i := 1; i < failures && backoff < maxFailureBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxFailureBackoff)
+}
@@ -0,0 +1,133 @@
+package dataset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+var runStatsCols = []string{"dataset", "last_run", "status", "last_success", "rows", "bytes", "failures", "error"}
+
+func daemonRegistry(ds ...*mockDataset) *Registry {
+	reg := &Registry{datasets: map[string]Dataset{}}
+	for _, d := range ds {
+		reg.datasets[d.name] = d
+		reg.order = append(reg.order, d.name)
+	}
+	return reg
+}
+
+func TestNewDaemon_InvalidSchedule(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+	reg := daemonRegistry(&mockDataset{name: "cbp"})
+	e := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+
+	_, err := NewDaemon(e, DaemonOpts{Schedules: map[string]string{"cbp": "not a cron"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `parse schedule "not a cron" for cbp`)
+
+	_, err = NewDaemon(e, DaemonOpts{Schedules: map[string]string{"nope": "0 6 * * *"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schedule for nope")
+}
+
+func TestDaemon_Due(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	lastOK := time.Date(2026, 3, 9, 5, 0, 0, 0, time.UTC)
+	recentFail := now.Add(-10 * time.Minute)
+	oldFail := now.Add(-3 * time.Hour)
+
+	reg := daemonRegistry(
+		&mockDataset{name: "cbp", shouldRun: true},          // ShouldRun, never synced
+		&mockDataset{name: "fpds", shouldRun: false},        // not due
+		&mockDataset{name: "form_d", shouldRun: false},      // cron fired since last success
+		&mockDataset{name: "qcew", shouldRun: true},         // failed 10m ago: backing off
+		&mockDataset{name: "eo_bmf", shouldRun: true},       // failed 3h ago, 2 failures: retry
+		&mockDataset{name: "holdings_13f", shouldRun: true}, // cron not yet fired
+	)
+	mock.ExpectQuery("FROM fed_data.sync_runs").
+		WillReturnRows(pgxmock.NewRows(runStatsCols).
+			AddRow("form_d", lastOK, "complete", &lastOK, int64(1), int64(0), 0, nil).
+			AddRow("qcew", recentFail, "failed", &lastOK, int64(1), int64(0), 1, nil).
+			AddRow("eo_bmf", oldFail, "failed", &lastOK, int64(1), int64(0), 2, nil).
+			AddRow("holdings_13f", lastOK, "complete", &lastOK, int64(1), int64(0), 0, nil))
+
+	e := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	d, err := NewDaemon(e, DaemonOpts{
+		Interval: 15 * time.Minute,
+		Schedules: map[string]string{
+			"form_d":       "0 6 * * *", // daily 06:00 — fired today
+			"holdings_13f": "0 6 1 * *", // monthly — next fires Apr 1
+		},
+	})
+	require.NoError(t, err)
+
+	due, err := d.Due(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cbp", "eo_bmf", "form_d"}, due)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDaemon_Tick(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+	ds := &mockDataset{name: "cbp", shouldRun: true, syncRows: 7}
+	reg := daemonRegistry(ds, &mockDataset{name: "fpds"})
+
+	mock.ExpectQuery("FROM fed_data.sync_runs").WillReturnRows(pgxmock.NewRows(runStatsCols))
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(7), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	d, err := NewDaemon(NewEngine(mock, nil, syncLog, reg, t.TempDir()), DaemonOpts{})
+	require.NoError(t, err)
+	var notified []Outcome
+	d.SetAfterRun(func(_ context.Context, _ time.Time, outcomes []Outcome) { notified = outcomes })
+
+	tick, err := d.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cbp"}, tick.Due)
+	assert.Equal(t, 1, tick.Synced)
+	assert.Zero(t, tick.Failed)
+	assert.True(t, ds.synced)
+	require.Len(t, notified, 1)
+	assert.Equal(t, "cbp", notified[0].Dataset)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDaemon_Run_RecordsStateUntilCancelled(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+	reg := daemonRegistry(&mockDataset{name: "fpds"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mock.ExpectQuery("FROM fed_data.sync_runs").WillReturnRows(pgxmock.NewRows(runStatsCols))
+	mock.ExpectExec("INSERT INTO fed_data.daemon_state").
+		WithArgs("host-a", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), []string{}, 0, 0, (*string)(nil)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	d, err := NewDaemon(NewEngine(mock, nil, syncLog, reg, t.TempDir()), DaemonOpts{Interval: time.Hour})
+	require.NoError(t, err)
+	d.SetState(fedsync.NewDaemonState(mock, "host-a", time.Now()))
+	d.SetAfterRun(func(context.Context, time.Time, []Outcome) { t.Error("nothing was due") })
+
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+	require.Eventually(t, func() bool { return mock.ExpectationsWereMet() == nil }, time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestFailureBackoff(t *testing.T) {
+	assert.Equal(t, 15*time.Minute, failureBackoff(15*time.Minute, 1))
+	assert.Equal(t, time.Hour, failureBackoff(15*time.Minute, 3))
+	assert.Equal(t, 24*time.Hour, failureBackoff(15*time.Minute, 20))
+}
@@ -2,9 +2,7 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -12,14 +10,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync/censusapi"
 	"github.com/sells-group/research-cli/internal/fedsync/transform"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
-const (
-	econCensusBatchSize = 5000
-	econCensusBaseURL   = "https://api.census.gov/data"
-)
+const econCensusBatchSize = 5000
 
 // econCensusYears are the Economic Census years available.
 var econCensusYears = []int{2017, 2022}
@@ -117,6 +113,45 @@ func (d *EconCensus) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher,
 	}, nil
 }
 
+// PlanResync implements SliceResyncer. Slices are whole census years.
+func (d *EconCensus) PlanResync(s Slice) (SlicePlan, error) {
+	return annualSlicePlan("econ_census", "fed_data.economic_census", "", s)
+}
+
+// SyncPeriod implements PeriodSyncer, loading one Economic Census year.
+// Censuses are taken in years ending in 2 and 7; the ecnbasic API covers
+// 2017 onward.
+func (d *EconCensus) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string, period Period) (*SyncResult, error) {
+	year, err := requireYear("econ_census", period)
+	if err != nil {
+		return nil, err
+	}
+	if year < econCensusYears[0] || year%5 != 2 {
+		return nil, eris.Errorf("econ_census: %d is not an Economic Census year (2017, 2022, ...)", year)
+	}
+
+	apiKey := ""
+	if d.cfg != nil {
+		apiKey = d.cfg.Fedsync.CensusKey
+	}
+	if apiKey == "" {
+		return nil, eris.New("econ_census: Census API key not configured (fedsync.census_api_key)")
+	}
+
+	rows, err := d.fetchYear(ctx, f, apiKey, year)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, eris.Errorf("econ_census: no data published for %d", year)
+	}
+	n, err := d.upsertRows(ctx, pool, rows)
+	if err != nil {
+		return nil, eris.Wrapf(err, "econ_census: upsert year %d", year)
+	}
+	return &SyncResult{RowsSynced: n, Metadata: map[string]any{"year": year}}, nil
+}
+
 func (d *EconCensus) fetchYear(ctx context.Context, f fetcher.Fetcher, apiKey string, year int) ([][]any, error) {
 	// Census API: get establishment count, receipts, payroll, employees by NAICS and geography
 	// 2022+ uses NAICS2022 variable; earlier years use NAICS2017
@@ -124,54 +159,37 @@ func (d *EconCensus) fetchYear(ctx context.Context, f fetcher.Fetcher, apiKey st
 	if year >= 2022 {
 		naicsVar = "NAICS2022"
 	}
-	url := fmt.Sprintf(
-		"%s/%d/ecnbasic?get=GEO_ID,%s,ESTAB,RCPTOT,PAYANN,EMP&for=state:*&key=%s",
-		econCensusBaseURL,
-		year,
-		naicsVar,
-		apiKey,
-	)
-
-	body, err := f.Download(ctx, url)
+	t, err := censusapi.New(f, apiKey).Fetch(ctx, censusapi.Query{
+		Dataset: fmt.Sprintf("%d/ecnbasic", year),
+		Get:     []string{"GEO_ID", naicsVar, "ESTAB", "RCPTOT", "PAYANN", "EMP"},
+		For:     "state:*",
+	})
 	if err != nil {
 		return nil, eris.Wrapf(err, "econ_census: fetch year %d", year)
 	}
-	defer body.Close() //nolint:errcheck
-
-	data, err := io.ReadAll(body)
-	if err != nil {
-		return nil, eris.Wrap(err, "econ_census: read response")
-	}
 
-	return d.parseResponse(data, year)
+	return d.parseResponse(t, year)
 }
 
-func (d *EconCensus) parseResponse(data []byte, year int) ([][]any, error) {
-	// Census API returns JSON array of arrays: [[header], [row1], [row2], ...]
-	var raw [][]string
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return nil, eris.Wrap(err, "econ_census: unmarshal JSON")
-	}
-
-	if len(raw) < 2 {
+func (d *EconCensus) parseResponse(t *censusapi.Table, year int) ([][]any, error) {
+	if t.Len() == 0 {
 		return nil, nil // no data rows
 	}
 
-	header := raw[0]
-	colIdx := make(map[string]int, len(header))
-	for i, col := range header {
-		colIdx[col] = i
+	filter, err := naicsFilterFor(d.cfg, "econ_census")
+	if err != nil {
+		return nil, err
 	}
 
 	var rows [][]any
 	seen := make(map[string]int) // conflict key → index in rows (dedup)
-	for _, record := range raw[1:] {
+	for _, record := range t.Rows {
 		// 2022+ Census API returns NAICS2022; earlier years return NAICS2017
-		naics := getColIdx(record, colIdx, "NAICS2017")
+		naics := t.Get(record, "NAICS2017")
 		if naics == "" {
-			naics = getColIdx(record, colIdx, "NAICS2022")
+			naics = t.Get(record, "NAICS2022")
 		}
-		if !transform.IsRelevantNAICS(naics) {
+		if !filter.Match(naics) {
 			continue
 		}
 		naics = transform.NormalizeNAICS(naics)
@@ -179,16 +197,16 @@ func (d *EconCensus) parseResponse(data []byte, year int) ([][]any, error) {
 			naics = naics[:6] // truncate to fit VARCHAR(6)
 		}
 
-		geoID := getColIdx(record, colIdx, "GEO_ID")
+		geoID := t.Get(record, "GEO_ID")
 
 		row := []any{
 			int16(year), // #nosec G115 -- year is a census year (e.g. 2017, 2022), fits in int16
 			geoID,
 			naics,
-			parseIntOr(getColIdx(record, colIdx, "ESTAB"), 0),
-			parseInt64Or(getColIdx(record, colIdx, "RCPTOT"), 0),
-			parseInt64Or(getColIdx(record, colIdx, "PAYANN"), 0),
-			parseIntOr(getColIdx(record, colIdx, "EMP"), 0),
+			parseIntOr(t.Get(record, "ESTAB"), 0),
+			parseInt64Or(t.Get(record, "RCPTOT"), 0),
+			parseInt64Or(t.Get(record, "PAYANN"), 0),
+			parseIntOr(t.Get(record, "EMP"), 0),
 		}
 
 		// Deduplicate by conflict key to avoid
@@ -228,12 +246,3 @@ func (d *EconCensus) upsertRows(ctx context.Context, pool db.Pool, rows [][]any)
 
 	return totalRows, nil
 }
-
-// getColIdx gets a value from a string slice by column name index.
-func getColIdx(record []string, colIdx map[string]int, name string) string {
-	idx, ok := colIdx[name]
-	if !ok || idx >= len(record) {
-		return ""
-	}
-	return record[idx]
-}
@@ -1,10 +1,18 @@
 package dataset
 
 import (
+	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync/censusapi"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
 func TestEconCensus_Metadata(t *testing.T) {
@@ -41,6 +49,14 @@ func TestEconCensus_ShouldRun(t *testing.T) {
 	assert.False(t, ds.ShouldRun(now2024, &sync2024))
 }
 
+// censusTable parses a Census API response for tests.
+func censusTable(t *testing.T, data []byte) *censusapi.Table {
+	t.Helper()
+	tbl, err := censusapi.ParseTable(data)
+	require.NoError(t, err)
+	return tbl
+}
+
 func TestEconCensus_ParseResponse(t *testing.T) {
 	ds := &EconCensus{}
 
@@ -51,7 +67,7 @@ func TestEconCensus_ParseResponse(t *testing.T) {
 		["0400000US48","541100","2200","7000000","3500000","22000","48"]
 	]`)
 
-	rows, err := ds.parseResponse(data, 2022)
+	rows, err := ds.parseResponse(censusTable(t, data), 2022)
 	assert.NoError(t, err)
 	// All NAICS codes are accepted
 	assert.Len(t, rows, 3)
@@ -79,7 +95,7 @@ func TestEconCensus_ParseResponse_NAICS2022(t *testing.T) {
 		["0400000US36","312100","800","3000000","1000000","8000","36"]
 	]`)
 
-	rows, err := ds.parseResponse(data, 2022)
+	rows, err := ds.parseResponse(censusTable(t, data), 2022)
 	assert.NoError(t, err)
 	assert.Len(t, rows, 2)
 
@@ -93,13 +109,37 @@ func TestEconCensus_ParseResponse_Empty(t *testing.T) {
 
 	// Only header, no data
 	data := []byte(`[["GEO_ID","NAICS2017","ESTAB","RCPTOT","PAYANN","EMP","state"]]`)
-	rows, err := ds.parseResponse(data, 2022)
+	rows, err := ds.parseResponse(censusTable(t, data), 2022)
 	assert.NoError(t, err)
 	assert.Empty(t, rows)
 }
 
-func TestEconCensus_ParseResponse_InvalidJSON(t *testing.T) {
+func TestEconCensus_FetchYear(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, "https://api.census.gov/data/2022/ecnbasic?get=GEO_ID,NAICS2022,ESTAB,RCPTOT,PAYANN,EMP&for=state:*&key=k").
+		Return(io.NopCloser(strings.NewReader(`[["GEO_ID","NAICS2022","ESTAB","RCPTOT","PAYANN","EMP","state"],
+			["0400000US06","523110","1500","5000000","2000000","15000","06"]]`)), nil).Once()
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader(`not json`)), nil).Once()
+
+	ds := &EconCensus{}
+	rows, err := ds.fetchYear(context.Background(), f, "k", 2022)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "523110", rows[0][2])
+
+	_, err = ds.fetchYear(context.Background(), f, "k", 2017)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse json")
+}
+
+func TestEconCensus_SyncPeriod_NotCensusYear(t *testing.T) {
 	ds := &EconCensus{}
-	_, err := ds.parseResponse([]byte(`not json`), 2022)
-	assert.Error(t, err)
+	_, err := ds.SyncPeriod(context.Background(), nil, nil, "", Period{Year: 2019})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2019 is not an Economic Census year")
+
+	_, err = ds.SyncPeriod(context.Background(), nil, nil, "", Period{Year: 2022})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Census API key not configured")
 }
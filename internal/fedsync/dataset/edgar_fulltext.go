@@ -0,0 +1,270 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	edgarFTSDefaultLookbackDays = 90
+	edgarFTSDefaultMaxPages     = 20
+)
+
+// edgarFTSColumns defines the edgar_fts_hits upsert columns. first_seen_at
+// is left to its default so re-syncs keep the date a hit first appeared.
+var edgarFTSColumns = []string{
+	"query", "accession_number", "cik", "entity_name", "form_type", "file_name",
+	"filing_date", "period_ending", "state", "sic", "url", "updated_at",
+}
+
+var (
+	eftsCIKSuffix    = regexp.MustCompile(`\s*\(CIK \d+\)\s*$`)
+	eftsTickerSuffix = regexp.MustCompile(`\s*\([A-Z0-9.\-, ]{1,30}\)\s*$`)
+)
+
+// EDGARFullText runs the configured keyword queries against the EDGAR
+// full-text search (EFTS) API and stores each matching filing in
+// fed_data.edgar_fts_hits, one row per query, accession number, and filer
+// CIK. accession_number joins to fed_data.edgar_filings and cik to
+// fed_data.edgar_entities. Each query resumes from its newest stored
+// filing date; the edgar_fts report lists hits for monitoring.
+type EDGARFullText struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *EDGARFullText) Name() string { return "edgar_fulltext" }
+
+// Table implements Dataset.
+func (d *EDGARFullText) Table() string { return "fed_data.edgar_fts_hits" }
+
+// Phase implements Dataset.
+func (d *EDGARFullText) Phase() Phase { return Phase1B }
+
+// Cadence implements Dataset.
+func (d *EDGARFullText) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *EDGARFullText) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// eftsFTSResult is one page of EFTS full-text search results.
+type eftsFTSResult struct {
+	Hits struct {
+		Total eftsTotal `json:"total"`
+		Hits  []eftsHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// eftsHit is a matching document. ID is "<accession>:<file name>".
+type eftsHit struct {
+	ID     string `json:"_id"`
+	Source struct {
+		CIKs         []string `json:"ciks"`
+		DisplayNames []string `json:"display_names"`
+		Accession    string   `json:"adsh"`
+		Form         string   `json:"form"`
+		FileDate     string   `json:"file_date"`
+		PeriodEnding string   `json:"period_ending"`
+		BizStates    []string `json:"biz_states"`
+		SICs         []string `json:"sics"`
+	} `json:"_source"`
+}
+
+// Sync runs each configured query over filings since its last stored hit.
+func (d *EDGARFullText) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || len(d.cfg.Fedsync.EDGARFTS.Queries) == 0 {
+		return nil, eris.New("edgar_fulltext: no queries configured (fedsync.edgar_fts.queries)")
+	}
+	ftsCfg := d.cfg.Fedsync.EDGARFTS
+
+	var total int64
+	perQuery := make(map[string]int64, len(ftsCfg.Queries))
+	for _, query := range ftsCfg.Queries {
+		query = strings.TrimSpace(query)
+		if query == "" {
+			continue
+		}
+		since, err := d.since(ctx, pool, query)
+		if err != nil {
+			return nil, err
+		}
+		n, err := d.syncQuery(ctx, pool, f, query, since, log)
+		if err != nil {
+			return nil, eris.Wrapf(err, "edgar_fulltext: query %q", query)
+		}
+		perQuery[query] = n
+		total += n
+		log.Info("edgar_fulltext query complete",
+			zap.String("query", query), zap.Time("since", since), zap.Int64("hits", n))
+	}
+
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata:   map[string]any{"queries": perQuery},
+	}, nil
+}
+
+// syncQuery pages through the EFTS results for one query and upserts the
+// hits. Paging stops at the configured page cap; the next sync resumes from
+// the newest stored filing date.
+func (d *EDGARFullText) syncQuery(ctx context.Context, pool db.Pool, f fetcher.Fetcher, query string, since time.Time, log *zap.Logger) (int64, error) {
+	maxPages := d.cfg.Fedsync.EDGARFTS.MaxPages
+	if maxPages <= 0 {
+		maxPages = edgarFTSDefaultMaxPages
+	}
+
+	now := time.Now().UTC()
+	var total int64
+	for page := 0; page < maxPages; page++ {
+		body, err := f.Download(ctx, d.searchURL(query, since, now, page*eftsPageSize))
+		if err != nil {
+			return total, eris.Wrapf(err, "search page %d", page+1)
+		}
+		res, err := fetcher.DecodeJSONObject[eftsFTSResult](body)
+		_ = body.Close()
+		if err != nil {
+			return total, eris.Wrapf(err, "decode page %d", page+1)
+		}
+
+		rows := edgarFTSRows(query, res.Hits.Hits, now)
+		if len(rows) > 0 {
+			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table:        d.Table(),
+				Columns:      edgarFTSColumns,
+				ConflictKeys: []string{"query", "accession_number", "cik"},
+			}, rows)
+			if err != nil {
+				return total, eris.Wrap(err, "upsert")
+			}
+			total += n
+		}
+
+		if !eftsHasMore(page*eftsPageSize, len(res.Hits.Hits), res.Hits.Total.Value) {
+			return total, nil
+		}
+	}
+	log.Warn("edgar_fulltext: page limit reached, resuming next sync",
+		zap.String("query", query), zap.Int("pages", maxPages))
+	return total, nil
+}
+
+// searchURL builds the EFTS request for one page of a query. Multi-word
+// queries are searched as phrases unless already quoted.
+func (d *EDGARFullText) searchURL(query string, since, until time.Time, from int) string {
+	if strings.ContainsAny(query, " \t") && !strings.HasPrefix(query, `"`) {
+		query = `"` + query + `"`
+	}
+	q := url.Values{}
+	q.Set("q", query)
+	q.Set("dateRange", "custom")
+	q.Set("startdt", since.Format("2006-01-02"))
+	q.Set("enddt", until.Format("2006-01-02"))
+	if forms := d.cfg.Fedsync.EDGARFTS.Forms; len(forms) > 0 {
+		q.Set("forms", strings.Join(forms, ","))
+	}
+	if from > 0 {
+		q.Set("from", strconv.Itoa(from))
+	}
+	return eftsSearchURL + "?" + q.Encode()
+}
+
+// since returns the filing date to resume a query from: one day before its
+// newest stored hit, or the configured lookback on the first sync.
+func (d *EDGARFullText) since(ctx context.Context, pool db.Pool, query string) (time.Time, error) {
+	var latest *time.Time
+	if err := pool.QueryRow(ctx,
+		`SELECT MAX(filing_date) FROM fed_data.edgar_fts_hits WHERE query = $1`, query,
+	).Scan(&latest); err != nil {
+		return time.Time{}, eris.Wrap(err, "edgar_fulltext: query latest hit")
+	}
+	if latest != nil {
+		return latest.AddDate(0, 0, -1), nil
+	}
+	days := d.cfg.Fedsync.EDGARFTS.LookbackDays
+	if days <= 0 {
+		days = edgarFTSDefaultLookbackDays
+	}
+	return time.Now().UTC().AddDate(0, 0, -days).Truncate(24 * time.Hour), nil
+}
+
+// edgarFTSRows maps EFTS hits to edgarFTSColumns, one row per filer CIK.
+// A filing matching in several documents keeps its first document.
+func edgarFTSRows(query string, hits []eftsHit, now time.Time) [][]any {
+	var rows [][]any
+	seen := make(map[string]bool)
+	for _, h := range hits {
+		src := h.Source
+		accession, fileName, _ := strings.Cut(h.ID, ":")
+		if src.Accession != "" {
+			accession = src.Accession
+		}
+		if accession == "" {
+			continue
+		}
+		for i, raw := range src.CIKs {
+			n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+			if err != nil || n <= 0 {
+				continue
+			}
+			cik := fmt.Sprintf("%010d", n)
+			key := accession + "|" + cik
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			var docURL any
+			if fileName != "" {
+				docURL = fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%d/%s/%s",
+					n, strings.ReplaceAll(accession, "-", ""), fileName)
+			}
+			rows = append(rows, []any{
+				query,
+				accession,
+				cik,
+				nilIfEmpty(eftsEntityName(indexOr(src.DisplayNames, i))),
+				nilIfEmpty(strings.TrimSpace(src.Form)),
+				nilIfEmpty(fileName),
+				dateOrNil(parseDate(src.FileDate)),
+				dateOrNil(parseDate(src.PeriodEnding)),
+				nilIfEmpty(fitLen(strings.TrimSpace(indexOr(src.BizStates, i)), 2)),
+				nilIfEmpty(strings.TrimSpace(indexOr(src.SICs, i))),
+				docURL,
+				now,
+			})
+		}
+	}
+	return rows
+}
+
+// eftsEntityName strips the "(TICKER)" and "(CIK 0000000000)" suffixes EFTS
+// appends to display names.
+func eftsEntityName(s string) string {
+	s = eftsCIKSuffix.ReplaceAllString(strings.TrimSpace(s), "")
+	s = eftsTickerSuffix.ReplaceAllString(s, "")
+	return sanitizeUTF8(strings.TrimSpace(s))
+}
+
+// indexOr returns s[i], or "" when i is out of range.
+func indexOr(s []string, i int) string {
+	if i < len(s) {
+		return s[i]
+	}
+	return ""
+}
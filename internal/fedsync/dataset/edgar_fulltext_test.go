@@ -0,0 +1,127 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const edgarFTSJSON = `{"hits":{"total":{"value":3,"relation":"eq"},"hits":[
+{"_id":"0001193125-24-012345:d123456d8k.htm","_source":{"ciks":["0001364742"],"display_names":["BlackRock Inc.  (BLK)  (CIK 0001364742)"],"adsh":"0001193125-24-012345","form":"8-K","file_date":"2024-03-01","period_ending":"2024-02-28","biz_states":["NY"],"sics":["6211"]}},
+{"_id":"0001193125-24-012345:d123456dex991.htm","_source":{"ciks":["0001364742"],"display_names":["BlackRock Inc.  (BLK)  (CIK 0001364742)"],"adsh":"0001193125-24-012345","form":"8-K","file_date":"2024-03-01"}},
+{"_id":"0000950170-24-000777:proxy.htm","_source":{"ciks":["320193","bad"],"display_names":["Apple Inc.  (AAPL)  (CIK 0000320193)"],"form":"DEF 14A","file_date":"2024-01-10"}}
+]}}`
+
+func edgarFTSConfig(queries ...string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Fedsync.EDGARFTS = config.EDGARFTSConfig{Queries: queries, LookbackDays: 30, MaxPages: 2}
+	return cfg
+}
+
+func TestEDGARFullText_Metadata(t *testing.T) {
+	d := &EDGARFullText{}
+	assert.Equal(t, "edgar_fulltext", d.Name())
+	assert.Equal(t, "fed_data.edgar_fts_hits", d.Table())
+	assert.Equal(t, Phase1B, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestEDGARFTSRows(t *testing.T) {
+	var res eftsFTSResult
+	require.NoError(t, json.Unmarshal([]byte(edgarFTSJSON), &res))
+	assert.Equal(t, 3, res.Hits.Total.Value)
+
+	now := time.Now()
+	rows := edgarFTSRows("succession plan", res.Hits.Hits, now)
+	require.Len(t, rows, 2, "exhibit of the same filing and bad CIK dropped")
+
+	row := rows[0]
+	require.Len(t, row, len(edgarFTSColumns))
+	assert.Equal(t, "succession plan", row[0])
+	assert.Equal(t, "0001193125-24-012345", row[1])
+	assert.Equal(t, "0001364742", row[2])
+	assert.Equal(t, "BlackRock Inc.", row[3])
+	assert.Equal(t, "8-K", row[4])
+	assert.Equal(t, "d123456d8k.htm", row[5])
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), row[6])
+	assert.Equal(t, "NY", row[8])
+	assert.Equal(t, "6211", row[9])
+	assert.Equal(t, "https://www.sec.gov/Archives/edgar/data/1364742/000119312524012345/d123456d8k.htm", row[10])
+
+	row = rows[1]
+	assert.Equal(t, "0000950170-24-000777", row[1], "accession from _id")
+	assert.Equal(t, "0000320193", row[2])
+	assert.Equal(t, "Apple Inc.", row[3])
+	assert.Nil(t, row[7])
+	assert.Nil(t, row[8])
+}
+
+func TestEFTSEntityName(t *testing.T) {
+	assert.Equal(t, "BlackRock Inc.", eftsEntityName("BlackRock Inc.  (BLK)  (CIK 0001364742)"))
+	assert.Equal(t, "Berkshire Hathaway Inc", eftsEntityName("Berkshire Hathaway Inc  (BRK-B, BRK-A)  (CIK 0001067983)"))
+	assert.Equal(t, "Smith Capital LLC", eftsEntityName("Smith Capital LLC  (CIK 0001999999)"))
+	assert.Empty(t, eftsEntityName(""))
+}
+
+func TestEDGARFullText_SearchURL(t *testing.T) {
+	d := &EDGARFullText{cfg: edgarFTSConfig("succession plan")}
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	u, err := url.Parse(d.searchURL("succession plan", since, until, 0))
+	require.NoError(t, err)
+	assert.Equal(t, `"succession plan"`, u.Query().Get("q"), "multi-word query searched as a phrase")
+	assert.Equal(t, "2024-01-01", u.Query().Get("startdt"))
+	assert.Equal(t, "2024-03-01", u.Query().Get("enddt"))
+	assert.False(t, u.Query().Has("forms"))
+	assert.False(t, u.Query().Has("from"))
+
+	d.cfg.Fedsync.EDGARFTS.Forms = []string{"8-K", "DEF 14A"}
+	u, err = url.Parse(d.searchURL(`"going private"`, since, until, 200))
+	require.NoError(t, err)
+	assert.Equal(t, `"going private"`, u.Query().Get("q"))
+	assert.Equal(t, "8-K,DEF 14A", u.Query().Get("forms"))
+	assert.Equal(t, "200", u.Query().Get("from"))
+}
+
+func TestEDGARFullText_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("SELECT MAX\\(filing_date\\) FROM fed_data.edgar_fts_hits").
+		WithArgs("succession plan").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+	expectBulkUpsert(pool, "fed_data.edgar_fts_hits", edgarFTSColumns, 2)
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.HasPrefix(u, eftsSearchURL) && strings.Contains(u, "succession+plan")
+	})).Return(io.NopCloser(strings.NewReader(edgarFTSJSON)), nil).Once()
+
+	d := &EDGARFullText{cfg: edgarFTSConfig("succession plan", "  ")}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, map[string]int64{"succession plan": 2}, res.Metadata["queries"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEDGARFullText_SyncNoQueries(t *testing.T) {
+	_, err := (&EDGARFullText{cfg: edgarFTSConfig()}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no queries configured")
+}
@@ -7,8 +7,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -17,21 +17,31 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 const (
 	submissionsZipURL    = "https://www.sec.gov/Archives/edgar/daily-index/bulkdata/submissions.zip"
 	submissionsBatchSize = 10000
+
+	// submissionsChunkFiles is how many submission files are decoded and
+	// committed between checkpoints.
+	submissionsChunkFiles = 20000
 )
 
 // EDGARSubmissions implements the EDGAR Submissions bulk JSON dataset.
 // Downloads the bulk submissions ZIP from SEC, parses company data and recent filings,
 // and upserts into edgar_entities and edgar_filings tables.
+// Files are committed in chunks, checkpointing the last file of each.
 type EDGARSubmissions struct {
 	cfg *config.Config
+	cp  *fedsync.Checkpoint
 }
 
+// SetCheckpoint implements Resumable.
+func (d *EDGARSubmissions) SetCheckpoint(cp *fedsync.Checkpoint) { d.cp = cp }
+
 // Name implements Dataset.
 func (d *EDGARSubmissions) Name() string { return "edgar_submissions" }
 
@@ -69,7 +79,10 @@ type submissionAddrs struct {
 }
 
 type submissionAddr struct {
+	Street1        string `json:"street1"`
+	City           string `json:"city"`
 	StateOrCountry string `json:"stateOrCountry"`
+	ZipCode        string `json:"zipCode"`
 }
 
 type recentFilings struct {
@@ -90,6 +103,22 @@ type filingList struct {
 
 // Sync fetches and loads EDGAR bulk submissions data.
 func (d *EDGARSubmissions) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	return d.load(ctx, pool, f, tempDir, nil)
+}
+
+// SyncIncremental implements IncrementalSyncer. The bulk ZIP is still
+// downloaded in full, but only filings dated on or after the watermark's
+// date, and the entities that filed them, are upserted.
+func (d *EDGARSubmissions) SyncIncremental(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, since time.Time) (*SyncResult, error) {
+	y, m, day := since.UTC().Date()
+	cutoff := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	return d.load(ctx, pool, f, tempDir, &cutoff)
+}
+
+// load parses the bulk submissions ZIP and upserts entities and filings.
+// When since is set, filings dated before it are skipped, as are entities
+// with no remaining filings.
+func (d *EDGARSubmissions) load(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, since *time.Time) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", "edgar_submissions"))
 
 	zipPath := filepath.Join(tempDir, "submissions.zip")
@@ -114,29 +143,103 @@ func (d *EDGARSubmissions) Sync(ctx context.Context, pool db.Pool, f fetcher.Fet
 
 	log.Info("extracted submission files", zap.Int("count", len(files)))
 
-	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges"}
-	entityConflict := []string{"cik"}
+	var paths []string
+	for _, fp := range files {
+		base := filepath.Base(fp)
+		if !strings.HasSuffix(base, ".json") || strings.HasPrefix(base, "filings-") {
+			continue
+		}
+		paths = append(paths, fp)
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		return strings.Compare(filepath.Base(a), filepath.Base(b))
+	})
 
-	filingCols := []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
-	filingConflict := []string{"accession_number"}
+	// Resume after the last committed file of an interrupted run.
+	resumeAfter, err := d.cp.Load(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "edgar_submissions")
+	}
+	if resumeAfter != "" {
+		i := 0
+		for i < len(paths) && filepath.Base(paths[i]) <= resumeAfter {
+			i++
+		}
+		log.Info("resuming from checkpoint", zap.String("after_file", resumeAfter), zap.Int("skipped", i))
+		paths = paths[i:]
+	}
 
-	// Parallel decode: collect parsed rows from worker pool.
-	var mu sync.Mutex
-	var entityBatch [][]any
-	var filingBatch [][]any
 	var totalEntities, totalFilings int64
+	for start := 0; start < len(paths); start += submissionsChunkFiles {
+		chunk := paths[start:min(start+submissionsChunkFiles, len(paths))]
+
+		entities, filings, err := d.loadChunk(ctx, pool, chunk, since, log)
+		if err != nil {
+			return nil, err
+		}
+		totalEntities += entities
+		totalFilings += filings
+
+		if start+len(chunk) < len(paths) {
+			if err := d.cp.Save(ctx, filepath.Base(chunk[len(chunk)-1])); err != nil {
+				return nil, eris.Wrap(err, "edgar_submissions")
+			}
+		}
+	}
+
+	if err := d.cp.Clear(ctx); err != nil {
+		return nil, eris.Wrap(err, "edgar_submissions")
+	}
+
+	log.Info("edgar_submissions sync complete",
+		zap.Int64("entities", totalEntities),
+		zap.Int64("filings", totalFilings),
+	)
+
+	meta := map[string]any{
+		"entities": totalEntities,
+		"filings":  totalFilings,
+		"files":    len(files),
+	}
+	if since != nil {
+		meta["since"] = since.Format("2006-01-02")
+	}
+	result := &SyncResult{RowsSynced: totalEntities, Metadata: meta}
+	if resumeAfter != "" {
+		meta["resumed_after"] = resumeAfter
+		// Files skipped on resume were loaded by the interrupted run, so
+		// filings that changed since it started have not been seen yet.
+		result.Watermark = d.cp.ResumedStart()
+		result.Partial = true
+	}
+	return result, nil
+}
+
+// loadChunk decodes a chunk of submission files in parallel and upserts
+// their entities and filings, returning the rows written to each table.
+// Rows go through bounded per-table buffers; a decoder that fills one
+// blocks until the batch is written, so memory stays flat however large
+// the chunk is.
+func (d *EDGARSubmissions) loadChunk(ctx context.Context, pool db.Pool, paths []string, since *time.Time, log *zap.Logger) (int64, int64, error) {
+	batchRows := streamBatchRows(d.cfg, submissionsBatchSize)
+	entities := newBatchWriter(pool, db.UpsertConfig{
+		Table:        "fed_data.edgar_entities",
+		Columns:      []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"},
+		ConflictKeys: []string{"cik"},
+	}, batchRows)
+	// Multiple companies can reference the same accession; BulkUpsert
+	// dedupes within each batch.
+	filings := newBatchWriter(pool, db.UpsertConfig{
+		Table:        "fed_data.edgar_filings",
+		Columns:      []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"},
+		ConflictKeys: []string{"accession_number"},
+	}, batchRows)
 
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(5)
 
-	for _, fp := range files {
-		if !strings.HasSuffix(fp, ".json") {
-			continue
-		}
+	for _, fp := range paths {
 		base := filepath.Base(fp)
-		if strings.HasPrefix(base, "filings-") {
-			continue
-		}
 
 		g.Go(func() error {
 			select {
@@ -161,9 +264,11 @@ func (d *EDGARSubmissions) Sync(ctx context.Context, pool db.Pool, f fetcher.Fet
 				cik = cik[:10]
 			}
 
+			biz := sub.Addresses.Business
 			entityRow := []any{
 				cik, sub.Name, sub.EntityType, sub.SIC, sub.SICDescription,
-				sub.StateOfInc, sub.Addresses.Business.StateOrCountry, sub.EIN, sub.Tickers, sub.Exchanges,
+				sub.StateOfInc, biz.StateOrCountry, sub.EIN, sub.Tickers, sub.Exchanges,
+				truncate(biz.Street1, 200), truncate(biz.City, 100), truncate(biz.ZipCode, 10),
 			}
 
 			var filingRows [][]any
@@ -175,10 +280,15 @@ func (d *EDGARSubmissions) Sync(ctx context.Context, pool db.Pool, f fetcher.Fet
 					continue
 				}
 
+				filingDate := parseDate(safeIndex(recent.FilingDate, i))
+				if since != nil && (filingDate == nil || filingDate.Before(*since)) {
+					continue
+				}
+
 				filingRows = append(filingRows, []any{
 					accession, cik,
 					safeIndex(recent.Form, i),
-					parseDate(safeIndex(recent.FilingDate, i)),
+					filingDate,
 					safeIndex(recent.PrimaryDoc, i),
 					safeIndex(recent.PrimaryDocDesc, i),
 					safeIndex(recent.Items, i),
@@ -188,75 +298,30 @@ func (d *EDGARSubmissions) Sync(ctx context.Context, pool db.Pool, f fetcher.Fet
 				})
 			}
 
-			mu.Lock()
-			entityBatch = append(entityBatch, entityRow)
-			filingBatch = append(filingBatch, filingRows...)
-			mu.Unlock()
+			if since != nil && len(filingRows) == 0 {
+				return nil
+			}
 
+			if err := entities.Add(gctx, entityRow); err != nil {
+				return eris.Wrap(err, "edgar_submissions: upsert entities")
+			}
+			if err := filings.Add(gctx, filingRows...); err != nil {
+				return eris.Wrap(err, "edgar_submissions: upsert filings")
+			}
 			return nil
 		})
 	}
 
 	if err := g.Wait(); err != nil {
-		return nil, err
-	}
-
-	// Upsert all collected entities in batches.
-	for i := 0; i < len(entityBatch); i += submissionsBatchSize {
-		end := i + submissionsBatchSize
-		if end > len(entityBatch) {
-			end = len(entityBatch)
-		}
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table: "fed_data.edgar_entities", Columns: entityCols, ConflictKeys: entityConflict,
-		}, entityBatch[i:end])
-		if err != nil {
-			return nil, eris.Wrap(err, "edgar_submissions: upsert entities")
-		}
-		totalEntities += n
+		return 0, 0, err
 	}
-
-	// Deduplicate filings by accession number (multiple companies can reference the same filing).
-	seen := make(map[string]struct{}, len(filingBatch))
-	deduped := make([][]any, 0, len(filingBatch))
-	for _, row := range filingBatch {
-		acc := row[0].(string)
-		if _, ok := seen[acc]; ok {
-			continue
-		}
-		seen[acc] = struct{}{}
-		deduped = append(deduped, row)
+	if err := entities.Flush(ctx); err != nil {
+		return 0, 0, eris.Wrap(err, "edgar_submissions: upsert entities")
 	}
-	filingBatch = deduped
-
-	// Upsert all collected filings in batches.
-	for i := 0; i < len(filingBatch); i += submissionsBatchSize {
-		end := i + submissionsBatchSize
-		if end > len(filingBatch) {
-			end = len(filingBatch)
-		}
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table: "fed_data.edgar_filings", Columns: filingCols, ConflictKeys: filingConflict,
-		}, filingBatch[i:end])
-		if err != nil {
-			return nil, eris.Wrap(err, "edgar_submissions: upsert filings")
-		}
-		totalFilings += n
+	if err := filings.Flush(ctx); err != nil {
+		return 0, 0, eris.Wrap(err, "edgar_submissions: upsert filings")
 	}
-
-	log.Info("edgar_submissions sync complete",
-		zap.Int64("entities", totalEntities),
-		zap.Int64("filings", totalFilings),
-	)
-
-	return &SyncResult{
-		RowsSynced: totalEntities,
-		Metadata: map[string]any{
-			"entities": totalEntities,
-			"filings":  totalFilings,
-			"files":    len(files),
-		},
-	}, nil
+	return entities.Total(), filings.Total(), nil
 }
 
 func (d *EDGARSubmissions) parseSubmissionFile(path string) (*submissionJSON, error) {
@@ -146,7 +146,7 @@ func makeFilings(n int) map[string]any {
 	}
 }
 
-var edgarEntityCols = []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges"}
+var edgarEntityCols = []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"}
 var edgarFilingCols = []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
 
 func TestEDGARSubmissions_Sync_ParallelDecode(t *testing.T) {
@@ -298,3 +298,34 @@ func TestEDGARSubmissions_Sync_SkipsEmptyNameEntity(t *testing.T) {
 	assert.Equal(t, int64(1), result.RowsSynced)
 	assert.NoError(t, pool.ExpectationsWereMet())
 }
+
+func TestEDGARSubmissions_Sync_FlushesMidChunk(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"CIK0000001234.json": makeSubmissionJSON(t, "1234", "Alpha Corp", "operating", "6200", 3),
+	}
+	zipPath := createTestZipMulti(t, dir, "submissions.zip", files)
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	mockDownloadToFile(t, f, zipPath)
+
+	// With a 2-row buffer the filings are written while the chunk is
+	// still being decoded, not after it.
+	expectBulkUpsertZip(pool, "fed_data.edgar_filings", edgarFilingCols, 2)
+	expectBulkUpsertZip(pool, "fed_data.edgar_entities", edgarEntityCols, 1)
+	expectBulkUpsertZip(pool, "fed_data.edgar_filings", edgarFilingCols, 1)
+
+	cfg := &config.Config{}
+	cfg.Fedsync.Streaming.BatchRows = 2
+	ds := &EDGARSubmissions{cfg: cfg}
+	result, err := ds.Sync(context.Background(), pool, f, dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsSynced)
+	assert.Equal(t, int64(3), result.Metadata["filings"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
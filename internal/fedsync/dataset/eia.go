@@ -0,0 +1,254 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+var eiaCols = []string{"series_id", "period", "state_code", "sector", "value", "units", "description"}
+
+var eiaConflictKeys = []string{"series_id", "period"}
+
+const (
+	eiaAPIBaseURL = "https://api.eia.gov/v2/"
+	eiaPageSize   = 5000 // EIA v2 maximum rows per request
+	eiaBatchSize  = 5000
+
+	// eiaLookbackYears bounds the electricity price window; fuel series are
+	// pulled in full since the legacy series route has no date filter.
+	eiaLookbackYears = 3
+)
+
+// EIA syncs U.S. Energy Information Administration data via the EIA v2 API:
+// monthly state retail electricity prices for the sectors configured under
+// fedsync.eia.sectors, plus fuel series listed by legacy ID under
+// fedsync.eia.series.
+type EIA struct {
+	cfg    *config.Config
+	apiURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *EIA) Name() string { return "eia" }
+
+// Table implements Dataset.
+func (d *EIA) Table() string { return "fed_data.eia_data" }
+
+// Phase implements Dataset.
+func (d *EIA) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *EIA) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *EIA) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// eiaResponse is the EIA v2 data response. total is a string on some routes
+// and a number on others; data values likewise.
+type eiaResponse struct {
+	Response struct {
+		Total json.Number      `json:"total"`
+		Data  []map[string]any `json:"data"`
+	} `json:"response"`
+	Error json.RawMessage `json:"error"`
+}
+
+// Sync fetches electricity prices and the configured fuel series.
+func (d *EIA) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || d.cfg.Fedsync.EIAKey == "" {
+		return nil, eris.New("eia: EIA API key required (fedsync.eia_api_key)")
+	}
+	sectors := normalizeBLSSeries(d.cfg.Fedsync.EIA.Sectors)
+	series := normalizeBLSSeries(d.cfg.Fedsync.EIA.Series)
+	log.Info("starting eia sync", zap.Strings("sectors", sectors), zap.Int("series", len(series)))
+
+	var rows [][]any
+	if len(sectors) > 0 {
+		elec, err := d.fetchElectricity(ctx, f, sectors)
+		if err != nil {
+			return nil, eris.Wrap(err, "eia: electricity retail prices")
+		}
+		log.Info("eia electricity prices fetched", zap.Int("rows", len(elec)))
+		rows = append(rows, elec...)
+	}
+
+	var skipped int
+	for _, id := range series {
+		sr, err := d.fetchSeries(ctx, f, id)
+		if err != nil {
+			log.Warn("skip series", zap.String("series", id), zap.Error(err))
+			skipped++
+			continue
+		}
+		rows = append(rows, sr...)
+	}
+
+	var totalRows int64
+	for start := 0; start < len(rows); start += eiaBatchSize {
+		end := min(start+eiaBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      eiaCols,
+			ConflictKeys: eiaConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return nil, eris.Wrap(err, "eia: upsert")
+		}
+		totalRows += n
+	}
+
+	log.Info("eia sync complete", zap.Int64("rows", totalRows), zap.Int("skipped_series", skipped))
+	return &SyncResult{
+		RowsSynced: totalRows,
+		Metadata: map[string]any{
+			"sectors":        sectors,
+			"series":         len(series),
+			"skipped_series": skipped,
+		},
+	}, nil
+}
+
+// fetchElectricity pulls monthly state retail prices for the given sectors.
+// Rows are keyed by the legacy ELEC.PRICE series ID so they line up with IDs
+// analysts already use; census-division and regional aggregates are dropped.
+func (d *EIA) fetchElectricity(ctx context.Context, f fetcher.Fetcher, sectors []string) ([][]any, error) {
+	q := url.Values{}
+	q.Set("frequency", "monthly")
+	q.Add("data[]", "price")
+	for _, s := range sectors {
+		q.Add("facets[sectorid][]", s)
+	}
+	q.Set("start", fmt.Sprintf("%d-01", time.Now().Year()-eiaLookbackYears))
+
+	var rows [][]any
+	err := d.fetchAll(ctx, f, "electricity/retail-sales/data/", q, func(rec map[string]any) {
+		state := strings.ToUpper(eiaString(rec, "stateid"))
+		sector := strings.ToUpper(eiaString(rec, "sectorid"))
+		period := eiaString(rec, "period")
+		if len(state) != 2 || sector == "" || period == "" {
+			return
+		}
+		val, ok := eiaFloat(rec["price"])
+		if !ok {
+			return
+		}
+		desc := "Average retail price of electricity : " + eiaString(rec, "stateDescription") + " : " + eiaString(rec, "sectorName")
+		rows = append(rows, []any{
+			"ELEC.PRICE." + state + "-" + sector + ".M",
+			period, state, sector, val,
+			nilIfEmpty(eiaString(rec, "price-units")),
+			sanitizeUTF8(desc),
+		})
+	})
+	return rows, err
+}
+
+// fetchSeries pulls one series through the v2 legacy series ID route.
+func (d *EIA) fetchSeries(ctx context.Context, f fetcher.Fetcher, id string) ([][]any, error) {
+	var rows [][]any
+	err := d.fetchAll(ctx, f, "seriesid/"+url.PathEscape(id), url.Values{}, func(rec map[string]any) {
+		period := eiaString(rec, "period")
+		if period == "" {
+			return
+		}
+		val, ok := eiaFloat(rec["value"])
+		if !ok {
+			// ELEC series translate to routes whose value column is price.
+			val, ok = eiaFloat(rec["price"])
+		}
+		if !ok {
+			return
+		}
+		units := eiaString(rec, "units")
+		if units == "" {
+			units = eiaString(rec, "price-units")
+		}
+		rows = append(rows, []any{
+			id, period, nil, nil, val,
+			nilIfEmpty(units),
+			nilIfEmpty(sanitizeUTF8(eiaString(rec, "series-description"))),
+		})
+	})
+	return rows, err
+}
+
+// fetchAll pages through an EIA v2 route, calling fn for each data record.
+func (d *EIA) fetchAll(ctx context.Context, f fetcher.Fetcher, route string, q url.Values, fn func(map[string]any)) error {
+	base := d.apiURL
+	if base == "" {
+		base = eiaAPIBaseURL
+	}
+	q.Set("api_key", d.cfg.Fedsync.EIAKey)
+	q.Set("length", strconv.Itoa(eiaPageSize))
+
+	for offset := 0; ; offset += eiaPageSize {
+		q.Set("offset", strconv.Itoa(offset))
+		body, err := f.Download(ctx, base+route+"?"+q.Encode())
+		if err != nil {
+			return eris.Wrap(err, "download")
+		}
+		var resp eiaResponse
+		dec := json.NewDecoder(body)
+		dec.UseNumber()
+		err = dec.Decode(&resp)
+		_ = body.Close()
+		if err != nil {
+			return eris.Wrap(err, "decode response")
+		}
+		if len(resp.Error) > 0 && string(resp.Error) != "null" {
+			return eris.Errorf("api error: %s", resp.Error)
+		}
+
+		for _, rec := range resp.Response.Data {
+			fn(rec)
+		}
+
+		total, _ := strconv.Atoi(resp.Response.Total.String())
+		if len(resp.Response.Data) < eiaPageSize || offset+eiaPageSize >= total {
+			return nil
+		}
+	}
+}
+
+// eiaString returns a record field as a trimmed string.
+func eiaString(rec map[string]any, key string) string {
+	switch v := rec[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+// eiaFloat parses an EIA data value, which may arrive as a JSON number or a
+// numeric string; nulls and non-numeric markers report false.
+func eiaFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case json.Number:
+		f, err := x.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,129 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestEIA_Metadata(t *testing.T) {
+	d := &EIA{}
+	assert.Equal(t, "eia", d.Name())
+	assert.Equal(t, "fed_data.eia_data", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestEIA_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.HasPrefix(url, "http://eia.test/electricity/retail-sales/data/?") &&
+			strings.Contains(url, "api_key=test-key") &&
+			strings.Contains(url, "facets%5Bsectorid%5D%5B%5D=COM")
+	})).Return(io.NopCloser(strings.NewReader(`{"response":{"total":"4","data":[
+		{"period":"2026-07","stateid":"TX","stateDescription":"Texas","sectorid":"COM","sectorName":"commercial","price":"9.12","price-units":"cents per kilowatt-hour"},
+		{"period":"2026-07","stateid":"ENC","stateDescription":"East North Central","sectorid":"COM","sectorName":"commercial","price":"12.5","price-units":"cents per kilowatt-hour"},
+		{"period":"2026-07","stateid":"CA","stateDescription":"California","sectorid":"COM","sectorName":"commercial","price":null,"price-units":"cents per kilowatt-hour"},
+		{"period":"2026-06","stateid":"TX","stateDescription":"Texas","sectorid":"COM","sectorName":"commercial","price":8.97,"price-units":"cents per kilowatt-hour"}]}}`)), nil).Once()
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.HasPrefix(url, "http://eia.test/seriesid/NG.N3035US3.M?")
+	})).Return(io.NopCloser(strings.NewReader(`{"response":{"total":1,"data":[
+		{"period":"2026-06","series-description":"U.S. Natural Gas Industrial Price (Dollars per Thousand Cubic Feet)","value":4.21,"units":"$/MCF"}]}}`)), nil).Once()
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.HasPrefix(url, "http://eia.test/seriesid/PET.BAD.W?")
+	})).Return(nil, errors.New("404")).Once()
+
+	expectBulkUpsert(pool, "fed_data.eia_data", eiaCols, 3)
+
+	d := &EIA{
+		apiURL: "http://eia.test/",
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{
+			EIAKey: "test-key",
+			EIA: config.EIAConfig{
+				Sectors: []string{"com", "COM"},
+				Series:  []string{"NG.N3035US3.M", "PET.BAD.W"},
+			},
+		}},
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, []string{"COM"}, res.Metadata["sectors"])
+	assert.Equal(t, 1, res.Metadata["skipped_series"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEIA_Sync_NoKey(t *testing.T) {
+	d := &EIA{cfg: &config.Config{}}
+	_, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "eia_api_key")
+}
+
+func TestEIA_Sync_APIError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader(`{"error":{"code":"API_KEY_INVALID","message":"bad key"}}`)), nil).Once()
+
+	d := &EIA{cfg: &config.Config{Fedsync: config.FedsyncConfig{
+		EIAKey: "bad",
+		EIA:    config.EIAConfig{Sectors: []string{"ALL"}},
+	}}}
+	_, err := d.Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_KEY_INVALID")
+}
+
+func TestEIA_FetchAll_Pages(t *testing.T) {
+	page := func(n int) string {
+		recs := make([]string, n)
+		for i := range recs {
+			recs[i] = `{"period":"2026-01","value":1}`
+		}
+		return `{"response":{"total":"5001","data":[` + strings.Join(recs, ",") + `]}}`
+	}
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "offset=0")
+	})).Return(io.NopCloser(strings.NewReader(page(eiaPageSize))), nil).Once()
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "offset=5000")
+	})).Return(io.NopCloser(strings.NewReader(page(1))), nil).Once()
+
+	d := &EIA{cfg: &config.Config{Fedsync: config.FedsyncConfig{EIAKey: "k"}}}
+	var n int
+	err := d.fetchAll(context.Background(), f, "seriesid/X", url.Values{}, func(map[string]any) { n++ })
+	require.NoError(t, err)
+	assert.Equal(t, eiaPageSize+1, n)
+}
+
+func TestEIAFloat(t *testing.T) {
+	v, ok := eiaFloat(json.Number("4.5"))
+	assert.True(t, ok)
+	assert.InDelta(t, 4.5, v, 1e-9)
+	v, ok = eiaFloat(" 12.25 ")
+	assert.True(t, ok)
+	assert.InDelta(t, 12.25, v, 1e-9)
+	_, ok = eiaFloat(nil)
+	assert.False(t, ok)
+	_, ok = eiaFloat("NA")
+	assert.False(t, ok)
+}
@@ -0,0 +1,341 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const emmaBatchSize = 10000
+
+// emmaColumns defines the target DB columns in upsert order. advisor_crd is
+// maintained by PostSync and left out so upserts keep existing links.
+var emmaColumns = []string{
+	"issue_id", "issuer_name", "issuer_cusip6", "state", "issue_description",
+	"security_type", "tax_status", "offering_type",
+	"dated_date", "sale_date", "closing_date", "first_maturity_date", "final_maturity_date",
+	"par_amount", "municipal_advisor", "advisor_name_norm",
+	"underwriter", "bond_counsel", "official_statement_url",
+	"cd_filing_count", "last_cd_filing_date", "last_financial_filing_date",
+	"last_event_notice_date", "failure_to_file_count",
+}
+
+// emmaDisclosures rolls up continuing disclosure submissions for one issue.
+type emmaDisclosures struct {
+	count         int
+	failures      int
+	lastFiling    *time.Time
+	lastFinancial *time.Time
+	lastEvent     *time.Time
+}
+
+// EMMA syncs MSRB EMMA municipal securities data into fed_data.emma_issues:
+// issue-level primary market records (issuer, par, dates, municipal advisor,
+// underwriter) joined with rollups of continuing disclosure submissions.
+// Municipal advisors overlap heavily with registered investment advisers, so
+// PostSync links each issue's advisor to an ADV firm by normalized name.
+type EMMA struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *EMMA) Name() string { return "emma" }
+
+// Table implements Dataset.
+func (d *EMMA) Table() string { return "fed_data.emma_issues" }
+
+// Phase implements Dataset.
+func (d *EMMA) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *EMMA) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *EMMA) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync downloads the configured EMMA extracts and upserts one row per issue.
+func (d *EMMA) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || d.cfg.Fedsync.EMMA.IssuesURL == "" {
+		return nil, eris.New("emma: issues extract URL required (fedsync.emma.issues_url)")
+	}
+
+	disclosures := map[string]*emmaDisclosures{}
+	if u := d.cfg.Fedsync.EMMA.DisclosuresURL; u != "" {
+		rc, err := downloadCSVExtract(ctx, f, u, filepath.Join(tempDir, "emma_disclosures"))
+		if err != nil {
+			return nil, eris.Wrap(err, "emma: disclosures")
+		}
+		disclosures, err = parseEMMADisclosures(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		log.Info("emma disclosures parsed", zap.Int("issues", len(disclosures)))
+	}
+
+	rc, err := downloadCSVExtract(ctx, f, d.cfg.Fedsync.EMMA.IssuesURL, filepath.Join(tempDir, "emma_issues"))
+	if err != nil {
+		return nil, eris.Wrap(err, "emma: issues")
+	}
+	defer rc.Close() //nolint:errcheck
+
+	rows, withAdvisor, err := d.loadIssues(ctx, pool, rc, disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("emma sync complete", zap.Int64("rows", rows), zap.Int64("with_advisor", withAdvisor))
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"with_advisor":            withAdvisor,
+			"issues_with_disclosures": len(disclosures),
+		},
+	}, nil
+}
+
+// loadIssues streams the issues extract and upserts rows in batches. It
+// returns the rows upserted and how many name a municipal advisor.
+func (d *EMMA) loadIssues(ctx context.Context, pool db.Pool, r io.Reader, disclosures map[string]*emmaDisclosures) (int64, int64, error) {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "emma: read issues header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	if _, ok := colIdx["issue id"]; !ok {
+		return 0, 0, eris.New("emma: issues extract missing Issue ID column")
+	}
+
+	upsert := func(batch [][]any) (int64, error) {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      emmaColumns,
+			ConflictKeys: []string{"issue_id"},
+		}, batch)
+		return n, eris.Wrap(err, "emma: upsert")
+	}
+
+	var total, withAdvisor int64
+	batch := make([][]any, 0, emmaBatchSize)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return total, withAdvisor, eris.Wrap(err, "emma: read issues")
+		}
+		row := emmaRow(record, colIdx, disclosures)
+		if row == nil {
+			continue
+		}
+		if row[emmaAdvisorNormCol] != nil {
+			withAdvisor++
+		}
+		batch = append(batch, row)
+		if len(batch) >= emmaBatchSize {
+			n, err := upsert(batch)
+			if err != nil {
+				return total, withAdvisor, err
+			}
+			total += n
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		n, err := upsert(batch)
+		if err != nil {
+			return total, withAdvisor, err
+		}
+		total += n
+	}
+	return total, withAdvisor, nil
+}
+
+// emmaAdvisorNormCol is the index of advisor_name_norm in emmaColumns.
+var emmaAdvisorNormCol = slices.Index(emmaColumns, "advisor_name_norm")
+
+// emmaRow maps an issues extract record to emmaColumns. Returns nil when the
+// record has no issue ID.
+func emmaRow(record []string, colIdx map[string]int, disclosures map[string]*emmaDisclosures) []any {
+	get := func(name string) string { return sanitizeUTF8(strings.TrimSpace(getColN(record, colIdx, name))) }
+	text := func(name string) any { return nilIfEmpty(get(name)) }
+	date := func(name string) any { return dateOrNil(parseDate(get(name))) }
+
+	issueID := strings.ToUpper(get("issue id"))
+	if issueID == "" {
+		return nil
+	}
+
+	state := strings.ToUpper(get("state"))
+	if len(state) != 2 {
+		state = ""
+	}
+	cusip6 := strings.ToUpper(get("issuer cusip6"))
+	if len(cusip6) != 6 {
+		cusip6 = ""
+	}
+
+	advisor := get("municipal advisor")
+	// Multiple advisors are ";"-separated; the first is the lead.
+	lead, _, _ := strings.Cut(advisor, ";")
+
+	par := strings.NewReplacer("$", "", ",", "").Replace(get("par amount"))
+
+	cd := disclosures[issueID]
+	if cd == nil {
+		cd = &emmaDisclosures{}
+	}
+
+	return []any{
+		issueID,
+		text("issuer name"),
+		nilIfEmpty(cusip6),
+		nilIfEmpty(state),
+		text("issue description"),
+		text("security type"),
+		text("tax status"),
+		text("offering type"),
+		date("dated date"),
+		date("sale date"),
+		date("closing date"),
+		date("first maturity date"),
+		date("final maturity date"),
+		parseFloat64OrNil(par),
+		nilIfEmpty(advisor),
+		nilIfEmpty(resolve.NormalizeName(lead)),
+		text("underwriter"),
+		text("bond counsel"),
+		text("official statement url"),
+		cd.count,
+		dateOrNil(cd.lastFiling),
+		dateOrNil(cd.lastFinancial),
+		dateOrNil(cd.lastEvent),
+		cd.failures,
+	}
+}
+
+// parseEMMADisclosures rolls up the continuing disclosure extract by issue
+// ID. Submissions are classified by category: failure-to-file notices,
+// financial/operating filings, and event notices.
+func parseEMMADisclosures(r io.Reader) (map[string]*emmaDisclosures, error) {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "emma: read disclosures header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	if _, ok := colIdx["issue id"]; !ok {
+		return nil, eris.New("emma: disclosures extract missing Issue ID column")
+	}
+
+	out := make(map[string]*emmaDisclosures)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "emma: read disclosures")
+		}
+		id := strings.ToUpper(strings.TrimSpace(getColN(record, colIdx, "issue id")))
+		if id == "" {
+			continue
+		}
+		cd := out[id]
+		if cd == nil {
+			cd = &emmaDisclosures{}
+			out[id] = cd
+		}
+		cd.count++
+
+		posted := parseDate(getColN(record, colIdx, "submission date"))
+		cd.lastFiling = laterDate(cd.lastFiling, posted)
+
+		category := strings.ToLower(getColN(record, colIdx, "disclosure category"))
+		switch {
+		case strings.Contains(category, "failure"):
+			cd.failures++
+			cd.lastEvent = laterDate(cd.lastEvent, posted)
+		case strings.Contains(category, "financial"):
+			cd.lastFinancial = laterDate(cd.lastFinancial, posted)
+		case strings.Contains(category, "event"):
+			cd.lastEvent = laterDate(cd.lastEvent, posted)
+		}
+	}
+	return out, nil
+}
+
+// laterDate returns the later of a and b, treating nil as unknown.
+func laterDate(a, b *time.Time) *time.Time {
+	if b == nil || (a != nil && !b.After(*a)) {
+		return a
+	}
+	return b
+}
+
+// dateOrNil converts a parsed date to an upsert value, nil when unknown.
+func dateOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+// PostSync implements PostSyncer by relinking municipal advisors to ADV
+// firms. Only normalized names that identify exactly one firm are linked.
+func (d *EMMA) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrap(err, "emma: begin advisor links")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `UPDATE fed_data.emma_issues SET advisor_crd = NULL WHERE advisor_crd IS NOT NULL`); err != nil {
+		return eris.Wrap(err, "emma: clear advisor links")
+	}
+	tag, err := tx.Exec(ctx, emmaAdvisorLinkSQL())
+	if err != nil {
+		return eris.Wrap(err, "emma: link advisors")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrap(err, "emma: commit advisor links")
+	}
+
+	zap.L().Info("emma advisor links rebuilt",
+		zap.String("dataset", d.Name()),
+		zap.Int64("linked", tag.RowsAffected()),
+	)
+	return nil
+}
+
+// emmaAdvisorLinkSQL sets advisor_crd where the advisor's normalized name
+// matches exactly one ADV firm.
+func emmaAdvisorLinkSQL() string {
+	return fmt.Sprintf(`UPDATE fed_data.emma_issues e
+SET advisor_crd = m.crd_number
+FROM (
+    SELECT norm, MIN(crd_number) AS crd_number
+    FROM (SELECT %s AS norm, crd_number FROM fed_data.adv_firms) f
+    GROUP BY norm
+    HAVING COUNT(DISTINCT crd_number) = 1
+) m
+WHERE e.advisor_name_norm = m.norm`, resolve.NormalizeNameSQL("firm_name"))
+}
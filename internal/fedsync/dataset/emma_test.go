@@ -0,0 +1,147 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const emmaIssuesCSV = "\ufeffIssue ID,Issuer Name,Issuer CUSIP6,State,Issue Description,Security Type,Tax Status,Offering Type,Dated Date,Sale Date,Closing Date,First Maturity Date,Final Maturity Date,Par Amount,Municipal Advisor,Underwriter,Bond Counsel,Official Statement URL\n" +
+	`ea123456,City of Austin,052396,TX,"Water and Wastewater System Revenue Bonds, Series 2025",Revenue,Tax-Exempt,Negotiated,03/01/2025,02/11/2025,03/04/2025,11/15/2025,11/15/2054,"$412,500,000.00","PFM Financial Advisors LLC; Estrada Hinojosa",Jefferies LLC,McCall Parkhurst,https://emma.msrb.org/P21881234.pdf` + "\n" +
+	`EB999,Example County,1234,Texas,General Obligation Bonds,General Obligation,Taxable,Competitive,2024-06-01,,,,,,,,,` + "\n" +
+	`,Missing ID,,,,,,,,,,,,,,,,` + "\n"
+
+const emmaDisclosuresCSV = "Issue ID,Submission Date,Disclosure Category\n" +
+	"EA123456,06/30/2025,Annual Financial Information and Operating Data\n" +
+	"EA123456,09/15/2025,Event Notice: Rating Change\n" +
+	"EA123456,03/01/2026,Failure to Provide Annual Financial Information\n" +
+	"EA123456,01/31/2026,Audited Financial Statements or ACFR\n"
+
+func TestEMMA_Metadata(t *testing.T) {
+	d := &EMMA{}
+	assert.Equal(t, "emma", d.Name())
+	assert.Equal(t, "fed_data.emma_issues", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestParseEMMADisclosures(t *testing.T) {
+	got, err := parseEMMADisclosures(strings.NewReader(emmaDisclosuresCSV))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	cd := got["EA123456"]
+	require.NotNil(t, cd)
+	assert.Equal(t, 4, cd.count)
+	assert.Equal(t, 1, cd.failures)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *cd.lastFiling)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), *cd.lastFinancial)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *cd.lastEvent)
+
+	_, err = parseEMMADisclosures(strings.NewReader("CUSIP,Date\n"))
+	assert.Error(t, err)
+}
+
+func TestEMMARow(t *testing.T) {
+	disclosures, err := parseEMMADisclosures(strings.NewReader(emmaDisclosuresCSV))
+	require.NoError(t, err)
+
+	reader := newLenientCSVReader(strings.NewReader(emmaIssuesCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	colIdx := mapColumnsTrimBOM(header)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	row := emmaRow(records[0], colIdx, disclosures)
+	require.Len(t, row, len(emmaColumns))
+	col := func(name string) any {
+		for i, c := range emmaColumns {
+			if c == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("unknown column %s", name)
+		return nil
+	}
+	assert.Equal(t, "EA123456", col("issue_id"))
+	assert.Equal(t, "052396", col("issuer_cusip6"))
+	assert.Equal(t, 412500000.0, col("par_amount"))
+	assert.Equal(t, time.Date(2054, 11, 15, 0, 0, 0, 0, time.UTC), col("final_maturity_date"))
+	assert.Equal(t, "PFM Financial Advisors LLC; Estrada Hinojosa", col("municipal_advisor"))
+	assert.Equal(t, "PFM FINANCIAL ADVISORS", col("advisor_name_norm"))
+	assert.Equal(t, 4, col("cd_filing_count"))
+	assert.Equal(t, 1, col("failure_to_file_count"))
+
+	row = emmaRow(records[1], colIdx, disclosures)
+	require.NotNil(t, row)
+	assert.Nil(t, row[2], "short CUSIP6 dropped")
+	assert.Nil(t, row[3], "non-abbreviated state dropped")
+	assert.Nil(t, row[emmaAdvisorNormCol])
+	assert.Equal(t, 0, row[len(row)-5])
+
+	assert.Nil(t, emmaRow(records[2], colIdx, disclosures))
+}
+
+func TestEMMA_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	issuesZip := createTestZip(t, t.TempDir(), "issues.zip", "issues.csv", emmaIssuesCSV)
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://emma.test/disclosures.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(emmaDisclosuresCSV))
+		}).Return(int64(len(emmaDisclosuresCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://emma.test/issues.zip", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { copyTestFixture(t, issuesZip, path) }).
+		Return(int64(1000), nil)
+
+	expectBulkUpsert(pool, "fed_data.emma_issues", emmaColumns, 2)
+
+	d := &EMMA{cfg: &config.Config{Fedsync: config.FedsyncConfig{EMMA: config.EMMAConfig{
+		IssuesURL:      "https://emma.test/issues.zip",
+		DisclosuresURL: "https://emma.test/disclosures.csv",
+	}}}}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["with_advisor"])
+	assert.Equal(t, 1, res.Metadata["issues_with_disclosures"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEMMA_Sync_NoURL(t *testing.T) {
+	_, err := (&EMMA{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.emma.issues_url")
+}
+
+func TestEMMA_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE fed_data.emma_issues SET advisor_crd = NULL").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 4))
+	pool.ExpectExec("UPDATE fed_data.emma_issues e").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+	pool.ExpectCommit()
+
+	require.NoError(t, (&EMMA{}).PostSync(context.Background(), pool, &SyncResult{}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	assert.Contains(t, emmaAdvisorLinkSQL(), "HAVING COUNT(DISTINCT crd_number) = 1")
+}
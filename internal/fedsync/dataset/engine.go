@@ -2,6 +2,8 @@ package dataset
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +14,10 @@ import (
 	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
+	"github.com/sells-group/research-cli/internal/postsync"
+	"github.com/sells-group/research-cli/internal/resilience"
 )
 
 // Engine orchestrates dataset sync runs.
@@ -21,6 +27,47 @@ type Engine struct {
 	syncLog *fedsync.SyncLog
 	reg     *Registry
 	tempDir string
+
+	// manifest, when set, is stamped into every sync_log entry's metadata.
+	manifest *model.RunManifest
+
+	// mirrors are configured fallback URLs applied to every dataset, in
+	// addition to those a Mirrored dataset declares.
+	mirrors []fetcher.Mirror
+
+	// watermarks, when set, lets IncrementalSyncer datasets resume from
+	// their high-water mark.
+	watermarks *fedsync.Watermarks
+
+	// checkpoints, when set, lets Resumable datasets pick up an
+	// interrupted sync where it stopped.
+	checkpoints *fedsync.Checkpoints
+
+	// sourceFiles, when set, lets SourceTracked datasets skip source files
+	// that have not changed since the last sync.
+	sourceFiles *fedsync.SourceFiles
+
+	// validation, when set, runs each dataset's validation rules after it
+	// syncs.
+	validation *ValidationOpts
+
+	// locks, when set, keeps concurrent fedsync processes from syncing the
+	// same dataset at once.
+	locks *fedsync.RunLocks
+
+	// retry governs re-running a sync that failed with a transient error.
+	retry resilience.RetryConfig
+
+	// postSync, when set, runs each dataset's configured post-sync hooks
+	// after its PostSync.
+	postSync *postsync.Runner
+
+	// dryRun collects the writes of the last dry run.
+	dryRun *db.DryRun
+
+	// outcomes records each dataset's result in the last run.
+	outcomeMu sync.Mutex
+	outcomes  []Outcome
 }
 
 // RunOpts configures which datasets to sync and how.
@@ -29,8 +76,12 @@ type RunOpts struct {
 	Datasets []string // restrict to specific dataset names
 	Force    bool     // ignore ShouldRun() scheduling
 	Full     bool     // full reload instead of incremental
+	DryRun   bool     // download and parse, but record writes instead of making them
 }
 
+// dryRunSamples is how many sample rows a dry run keeps per table.
+const dryRunSamples = 3
+
 // NewEngine creates a new sync engine.
 func NewEngine(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, reg *Registry, tempDir string) *Engine {
 	return &Engine{
@@ -39,11 +90,91 @@ func NewEngine(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, reg *R
 		syncLog: syncLog,
 		reg:     reg,
 		tempDir: tempDir,
+		retry:   DefaultSyncRetry(),
+	}
+}
+
+// SetManifest sets the reproducibility manifest recorded with each sync.
+func (e *Engine) SetManifest(m *model.RunManifest) {
+	e.manifest = m
+}
+
+// SetMirrors sets fallback URL prefixes applied to every dataset's fetcher.
+func (e *Engine) SetMirrors(m []fetcher.Mirror) {
+	e.mirrors = m
+}
+
+// SetWatermarks enables incremental syncs backed by fed_data.sync_state.
+func (e *Engine) SetWatermarks(w *fedsync.Watermarks) {
+	e.watermarks = w
+}
+
+// SetCheckpoints enables resumable syncs backed by fed_data.sync_checkpoints.
+func (e *Engine) SetCheckpoints(c *fedsync.Checkpoints) {
+	e.checkpoints = c
+}
+
+// SetSourceFiles enables skipping unchanged source files, backed by
+// fed_data.source_files.
+func (e *Engine) SetSourceFiles(s *fedsync.SourceFiles) {
+	e.sourceFiles = s
+}
+
+// SetValidation enables post-sync validation rules, recorded in
+// fed_data.sync_validations.
+func (e *Engine) SetValidation(v *ValidationOpts) {
+	e.validation = v
+}
+
+// SetRunLocks enables cross-process run locking: a dataset whose lock is
+// held by another run is skipped.
+func (e *Engine) SetRunLocks(l *fedsync.RunLocks) {
+	e.locks = l
+}
+
+// SetRetry replaces the retry policy for syncs that fail with a transient
+// error (429, 5xx, connection resets). MaxAttempts 1 disables retries.
+func (e *Engine) SetRetry(cfg resilience.RetryConfig) {
+	e.retry = cfg
+}
+
+// SetPostSync enables configurable post-sync hooks (enqueue_geocode,
+// assign_msa, flood_tag, notify) after each successful sync.
+func (e *Engine) SetPostSync(r *postsync.Runner) {
+	e.postSync = r
+}
+
+// DryRunReport returns the writes recorded by the last dry run, or nil if
+// the engine has not run with RunOpts.DryRun.
+func (e *Engine) DryRunReport() *db.DryRun {
+	return e.dryRun
+}
+
+// syncMetadata returns the dataset's metadata with the run manifest added.
+func (e *Engine) syncMetadata(meta map[string]any) map[string]any {
+	if e.manifest == nil {
+		return meta
+	}
+	out := make(map[string]any, len(meta)+1)
+	for k, v := range meta {
+		out[k] = v
 	}
+	out["manifest"] = e.manifest
+	return out
 }
 
 // Run iterates over the selected datasets, checks if each needs syncing,
 // and runs the sync in parallel. Results are recorded in the sync log.
+//
+// A dry run downloads and parses as usual, but every write is recorded in
+// DryRunReport instead of reaching the database; the sync log, watermarks,
+// checkpoints, post-sync hooks, and derived rebuilds are all skipped.
+//
+// With validation enabled, a dataset that fails a validation rule under
+// ValidationOpts.Block is recorded as failed and treated like a failed sync.
+// A SourceTracked dataset whose source files are all unchanged is recorded
+// as complete without validation, post-sync hooks, or derived rebuilds.
+// With run locks set, a dataset another process is syncing is skipped.
 func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 	log := zap.L().With(zap.String("component", "fedsync.engine"))
 	now := time.Now().UTC()
@@ -60,8 +191,19 @@ func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 
 	log.Info("selected datasets", zap.Int("count", len(datasets)))
 
+	e.outcomeMu.Lock()
+	e.outcomes = nil
+	e.outcomeMu.Unlock()
+
+	pool := e.pool
+	if opts.DryRun {
+		e.dryRun = db.NewDryRun(dryRunSamples)
+		pool = db.NewDryRunPool(e.pool, e.dryRun)
+		log.Info("dry run: writes will be recorded, not applied")
+	}
+
 	var synced, skipped, failed atomic.Int64
-	var entitySynced atomic.Bool
+	var entitySynced, opportunitySynced atomic.Bool
 
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(5)
@@ -90,59 +232,212 @@ func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 				}
 			}
 
+			if !opts.DryRun {
+				release, locked, err := e.lock(gctx, ds.Name())
+				if err != nil {
+					dsLog.Error("failed to take run lock", zap.Error(err))
+					e.recordOutcome(Outcome{Dataset: ds.Name(), Status: "failed", Error: err.Error()})
+					failed.Add(1)
+					return nil
+				}
+				if !locked {
+					dsLog.Info("skipping (another run holds the dataset lock)")
+					skipped.Add(1)
+					return nil
+				}
+				defer release()
+			}
+
 			dsLog.Info("starting sync")
-			syncID, err := e.syncLog.Start(gctx, ds.Name())
-			if err != nil {
-				return eris.Wrapf(err, "engine: start sync log for %s", ds.Name())
+			var syncID int64
+			if !opts.DryRun {
+				if syncID, err = e.syncLog.Start(gctx, ds.Name()); err != nil {
+					return eris.Wrapf(err, "engine: start sync log for %s", ds.Name())
+				}
 			}
 
+			// Rows are stamped with the run and the file they came from.
+			var prov *db.Provenance
+			if !opts.DryRun {
+				prov = db.NewProvenance(syncID, time.Now().UTC())
+			}
+			f := fetcher.NewMeteredFetcher(withProvenance(FetcherFor(e.fetcher, ds, e.mirrors), prov))
 			start := time.Now()
-			syncCtx, syncCancel := context.WithTimeout(gctx, 60*time.Minute)
-			var result *SyncResult
-			if opts.Full {
+			if r, ok := ds.(Resumable); ok && e.checkpoints != nil {
+				if opts.DryRun {
+					r.SetCheckpoint(nil)
+				} else {
+					r.SetCheckpoint(e.checkpoints.For(ds.Name()))
+				}
+			}
+			if st, ok := ds.(SourceTracked); ok && e.sourceFiles != nil {
+				if opts.DryRun {
+					st.SetSourceFiles(nil)
+				} else {
+					st.SetSourceFiles(e.sourceFiles.For(ds.Name(), opts.Full))
+				}
+			}
+			incr, incremental := ds.(IncrementalSyncer)
+			incremental = incremental && e.watermarks != nil
+			var since *time.Time
+			if incremental && !opts.Full {
+				if since, err = e.watermarks.Get(gctx, ds.Name()); err != nil {
+					dsLog.Warn("watermark lookup failed, running default window", zap.Error(err))
+					since = nil
+				}
+			}
+			run := ds.Sync
+			switch {
+			case opts.Full:
 				if fs, ok := ds.(FullSyncer); ok {
 					dsLog.Info("running full sync")
-					result, err = fs.SyncFull(syncCtx, e.pool, e.fetcher, e.tempDir)
-				} else {
-					result, err = ds.Sync(syncCtx, e.pool, e.fetcher, e.tempDir)
+					run = fs.SyncFull
+				}
+			case since != nil:
+				dsLog.Info("running incremental sync", zap.Time("since", *since))
+				run = func(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+					return incr.SyncIncremental(ctx, pool, f, tempDir, *since)
 				}
-			} else {
-				result, err = ds.Sync(syncCtx, e.pool, e.fetcher, e.tempDir)
 			}
-			syncCancel()
+			// Each attempt collects its own rejects so retries don't
+			// double-count.
+			var rejects *fedsync.Rejects
+			result, attempts, err := e.syncWithRetry(gctx, dsLog, func(ctx context.Context) (*SyncResult, error) {
+				if opts.DryRun {
+					ctx = db.WithDryRun(ctx, e.dryRun)
+				} else {
+					rejects = fedsync.NewRejects(ds.Name(), syncID, fedsync.DefaultRejectSamples)
+					ctx = fedsync.WithRejects(db.WithProvenance(ctx, prov), rejects)
+				}
+				return run(ctx, pool, f, e.tempDir)
+			})
 			elapsed := time.Since(start)
+			if err != nil && attempts > 1 {
+				err = eris.Wrapf(err, "failed after %d attempts", attempts)
+			}
 
-			if syncCtx.Err() == context.DeadlineExceeded {
-				dsLog.Warn("sync timed out after 60 minutes", zap.Duration("elapsed", elapsed))
+			if !opts.DryRun && (f.Bytes() > 0 || f.Requests() > 0) {
+				if logErr := e.syncLog.RecordDownloads(gctx, syncID, f.Bytes(), f.Sources()); logErr != nil {
+					dsLog.Error("failed to record downloads", zap.Error(logErr))
+				}
 			}
 
 			if err != nil {
 				dsLog.Error("sync failed", zap.Error(err), zap.Duration("elapsed", elapsed))
+				if opts.DryRun {
+					failed.Add(1)
+					return nil
+				}
 				if logErr := e.syncLog.Fail(gctx, syncID, err.Error()); logErr != nil {
 					dsLog.Error("failed to record sync failure", zap.Error(logErr))
 				}
+				recordSyncMetrics(ds, "failed", 0, f, elapsed)
+				e.recordOutcome(Outcome{Dataset: ds.Name(), Status: "failed", Elapsed: elapsed, Error: err.Error()})
 				failed.Add(1)
 				return nil // don't abort other datasets on individual failure
 			}
 
+			if opts.DryRun {
+				dsLog.Info("dry run complete",
+					zap.Int64("rows", result.RowsSynced),
+					zap.Duration("elapsed", elapsed),
+				)
+				synced.Add(1)
+				return nil
+			}
+
+			if since != nil {
+				result.Partial = true
+			}
 			fsResult := &fedsync.SyncResult{
 				RowsSynced: result.RowsSynced,
-				Metadata:   result.Metadata,
+				Metadata:   e.syncMetadata(result.Metadata),
+			}
+			if result.Partial {
+				fsResult.Metadata = withMetadata(fsResult.Metadata, "partial", true)
+			}
+			if attempts > 1 {
+				fsResult.Metadata = withMetadata(fsResult.Metadata, "attempts", attempts)
+			}
+			e.recordRejects(gctx, rejects, fsResult, dsLog)
+
+			// Nothing was loaded, so there is nothing to validate, hook,
+			// or rebuild from.
+			if result.Unchanged {
+				fsResult.Metadata = withMetadata(fsResult.Metadata, "unchanged", true)
+				if err := e.syncLog.Complete(gctx, syncID, fsResult); err != nil {
+					dsLog.Error("failed to record sync completion", zap.Error(err))
+				}
+				recordSyncMetrics(ds, "complete", 0, f, elapsed)
+				e.recordOutcome(Outcome{Dataset: ds.Name(), Status: "unchanged", Elapsed: elapsed, Anomalies: anomalies(fsResult.Metadata)})
+				dsLog.Info("sync complete, source files unchanged", zap.Duration("elapsed", elapsed))
+				synced.Add(1)
+				return nil
+			}
+
+			if failures := e.validate(gctx, ds, syncID, result, dsLog); failures > 0 {
+				fsResult.Metadata = withMetadata(fsResult.Metadata, "validation_failures", failures)
+				if e.validation.Block {
+					msg := fmt.Sprintf("validation failed: %d rule(s) failed", failures)
+					if logErr := e.syncLog.Fail(gctx, syncID, msg); logErr != nil {
+						dsLog.Error("failed to record sync failure", zap.Error(logErr))
+					}
+					recordSyncMetrics(ds, "failed", result.RowsSynced, f, elapsed)
+					e.recordOutcome(Outcome{
+						Dataset: ds.Name(), Status: "failed", Rows: result.RowsSynced, Elapsed: elapsed,
+						Error: msg, Anomalies: anomalies(fsResult.Metadata),
+					})
+					failed.Add(1)
+					return nil
+				}
 			}
 
 			if err := e.syncLog.Complete(gctx, syncID, fsResult); err != nil {
 				dsLog.Error("failed to record sync completion", zap.Error(err))
 			}
+			recordSyncMetrics(ds, "complete", result.RowsSynced, f, elapsed)
+			e.recordOutcome(Outcome{
+				Dataset: ds.Name(), Status: "complete", Rows: result.RowsSynced, Elapsed: elapsed,
+				Anomalies: anomalies(fsResult.Metadata),
+			})
 
 			dsLog.Info("sync complete",
 				zap.Int64("rows", result.RowsSynced),
 				zap.Duration("elapsed", elapsed),
 			)
+
+			if incremental {
+				mark := start.UTC()
+				if result.Watermark != nil {
+					mark = *result.Watermark
+				}
+				if err := e.watermarks.Advance(gctx, ds.Name(), mark); err != nil {
+					dsLog.Error("failed to advance watermark", zap.Error(err))
+				}
+			}
+
+			if ps, ok := ds.(PostSyncer); ok {
+				if err := ps.PostSync(gctx, e.pool, result); err != nil {
+					dsLog.Warn("post-sync hook failed", zap.Error(err))
+				}
+			}
+			if e.postSync != nil {
+				e.postSync.Run(gctx, postsync.Target{
+					Source:  "fedsync",
+					Dataset: ds.Name(),
+					Table:   ds.Table(),
+					SyncID:  syncID,
+					Rows:    result.RowsSynced,
+				}, postsync.Declared(ds))
+			}
 			synced.Add(1)
 
 			if entityBearingDatasets[ds.Name()] {
 				entitySynced.Store(true)
 			}
+			if opportunityInputDatasets[ds.Name()] {
+				opportunitySynced.Store(true)
+			}
 			return nil
 		})
 	}
@@ -157,6 +452,10 @@ func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 		zap.Int64("failed", failed.Load()),
 	)
 
+	if opts.DryRun {
+		return nil
+	}
+
 	// Auto-trigger entity cross-reference rebuild when entity-bearing
 	// datasets were synced so new records are immediately linked into
 	// the relationship web.
@@ -167,9 +466,89 @@ func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 		}
 	}
 
+	// Likewise refresh county opportunity scores when one of their inputs
+	// (CBP, SUSB, ACS) was synced.
+	if opportunitySynced.Load() && !e.inSelection(datasets, "opportunity") {
+		if ds, err := e.reg.Get("opportunity"); err == nil {
+			log.Info("auto-triggering opportunity score rebuild after input sync")
+			if err := e.runDerived(ctx, log, ds); err != nil {
+				log.Error("opportunity score auto-rebuild failed", zap.Error(err))
+			}
+		}
+	}
+
 	return nil
 }
 
+// recordSyncMetrics records a finished run in the Prometheus metrics.
+func recordSyncMetrics(ds Dataset, status string, rows int64, f *fetcher.MeteredFetcher, elapsed time.Duration) {
+	opsmetrics.RecordSync(opsmetrics.SyncRun{
+		Dataset:  ds.Name(),
+		Status:   status,
+		Rows:     rows,
+		Bytes:    f.Bytes(),
+		Requests: f.Requests(),
+		Duration: elapsed,
+	})
+}
+
+// validate runs the dataset's validation rules, records the results, and
+// returns how many rules failed. Validation is skipped when not enabled.
+func (e *Engine) validate(ctx context.Context, ds Dataset, syncID int64, result *SyncResult, log *zap.Logger) int {
+	rules := ValidationRulesFor(ds.Name())
+	if e.validation == nil || len(rules) == 0 {
+		return 0
+	}
+
+	prev, err := e.syncLog.LastSuccessRows(ctx, ds.Name())
+	if err != nil {
+		log.Warn("previous row count lookup failed, skipping row count delta", zap.Error(err))
+	}
+	results := RunValidation(ctx, e.pool, rules, result, prev)
+	if err := e.syncLog.RecordValidations(ctx, syncID, ds.Name(), results); err != nil {
+		log.Error("failed to record validation results", zap.Error(err))
+	}
+
+	var failures int
+	for _, r := range results {
+		if !r.Passed {
+			failures++
+			log.Warn("validation rule failed",
+				zap.String("rule", r.Rule),
+				zap.Float64("observed", r.Observed),
+				zap.Float64("threshold", r.Threshold),
+				zap.String("message", r.Message),
+			)
+		}
+	}
+	return failures
+}
+
+// recordRejects flushes a run's rejected rows to fed_data.rejected_rows and
+// adds the totals to its sync_log metadata. A failed flush is logged; the
+// counts are still recorded.
+func (e *Engine) recordRejects(ctx context.Context, rejects *fedsync.Rejects, res *fedsync.SyncResult, log *zap.Logger) {
+	if rejects == nil || rejects.Total() == 0 {
+		return
+	}
+	if err := rejects.Flush(ctx, e.pool); err != nil {
+		log.Error("failed to record rejected rows", zap.Error(err))
+	}
+	res.Metadata = withMetadata(res.Metadata, "rejected_rows", rejects.Total())
+	res.Metadata = withMetadata(res.Metadata, "rejected_reasons", rejects.Counts())
+	log.Warn("rows rejected", zap.Int64("rejected", rejects.Total()))
+}
+
+// withMetadata returns a copy of meta with key set to v.
+func withMetadata(meta map[string]any, key string, v any) map[string]any {
+	out := make(map[string]any, len(meta)+1)
+	for k, val := range meta {
+		out[k] = val
+	}
+	out[key] = v
+	return out
+}
+
 // entityBearingDatasets lists dataset names whose records contain firm/company/
 // entity-level data with identifiers (CRD, CIK, EIN, DUNS, UEI) or names and
 // geography. When any of these syncs successfully, the engine auto-triggers an
@@ -201,41 +580,72 @@ var entityBearingDatasets = map[string]bool{
 // xrefInSelection returns true if entity_xref is already part of the dataset
 // selection, so we don't trigger it twice.
 func (e *Engine) xrefInSelection(datasets []Dataset) bool {
+	return e.inSelection(datasets, "entity_xref")
+}
+
+// inSelection returns true if the named dataset is part of the selection.
+func (e *Engine) inSelection(datasets []Dataset, name string) bool {
 	for _, ds := range datasets {
-		if ds.Name() == "entity_xref" {
+		if ds.Name() == name {
 			return true
 		}
 	}
 	return false
 }
 
+// lock takes name's run lock. Without run locks configured it always
+// succeeds.
+func (e *Engine) lock(ctx context.Context, name string) (release func(), ok bool, err error) {
+	if e.locks == nil {
+		return func() {}, true, nil
+	}
+	return e.locks.TryLock(ctx, name)
+}
+
 // runXref runs the entity cross-reference builder and records the result
 // in the sync log.
 func (e *Engine) runXref(ctx context.Context, log *zap.Logger) error {
-	xref := &EntityXref{}
-	syncID, err := e.syncLog.Start(ctx, xref.Name())
+	return e.runDerived(ctx, log, &EntityXref{})
+}
+
+// runDerived runs an auto-triggered derived dataset and records the result
+// in the sync log.
+func (e *Engine) runDerived(ctx context.Context, log *zap.Logger, ds Dataset) error {
+	name := ds.Name()
+	release, locked, err := e.lock(ctx, name)
+	if err != nil {
+		return eris.Wrapf(err, "engine: %s", name)
+	}
+	if !locked {
+		log.Info("skipping derived rebuild, another run holds the dataset lock", zap.String("dataset", name))
+		return nil
+	}
+	defer release()
+
+	syncID, err := e.syncLog.Start(ctx, name)
 	if err != nil {
-		return eris.Wrap(err, "engine: start entity_xref sync log")
+		return eris.Wrapf(err, "engine: start %s sync log", name)
 	}
 
 	start := time.Now()
-	result, err := xref.Sync(ctx, e.pool, e.fetcher, e.tempDir)
+	result, err := ds.Sync(ctx, e.pool, FetcherFor(e.fetcher, ds, e.mirrors), e.tempDir)
 	if err != nil {
 		if logErr := e.syncLog.Fail(ctx, syncID, err.Error()); logErr != nil {
-			log.Error("failed to record entity_xref failure", zap.Error(logErr))
+			log.Error("failed to record derived sync failure", zap.String("dataset", name), zap.Error(logErr))
 		}
-		return eris.Wrap(err, "engine: entity_xref sync")
+		return eris.Wrapf(err, "engine: %s sync", name)
 	}
 
 	fsResult := &fedsync.SyncResult{
 		RowsSynced: result.RowsSynced,
-		Metadata:   result.Metadata,
+		Metadata:   e.syncMetadata(result.Metadata),
 	}
 	if err := e.syncLog.Complete(ctx, syncID, fsResult); err != nil {
-		log.Error("failed to record entity_xref completion", zap.Error(err))
+		log.Error("failed to record derived sync completion", zap.String("dataset", name), zap.Error(err))
 	}
 
-	log.Info("entity_xref auto-rebuild complete",
+	log.Info("derived dataset auto-rebuild complete",
+		zap.String("dataset", name),
 		zap.Int64("rows", result.RowsSynced),
 		zap.Duration("elapsed", time.Since(start)),
 	)
@@ -3,18 +3,23 @@ package dataset
 import (
 	"context"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/resolve"
 	"github.com/sells-group/research-cli/internal/fetcher"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+	"github.com/sells-group/research-cli/internal/model"
 )
 
 // mockDataset implements Dataset for testing.
@@ -192,6 +197,36 @@ func TestEngine_Run_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// jsonContains matches a []byte argument containing substr.
+type jsonContains string
+
+func (j jsonContains) Match(v any) bool {
+	b, ok := v.([]byte)
+	return ok && strings.Contains(string(b), string(j))
+}
+
+func TestEngine_Run_Manifest(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockDataset{name: "test_ds", phase: Phase1, shouldRun: true, syncRows: 3}
+	reg := &Registry{datasets: map[string]Dataset{"test_ds": ds}, order: []string{"test_ds"}}
+
+	mock.ExpectQuery("SELECT started_at FROM fed_data.sync_log").
+		WithArgs("test_ds").
+		WillReturnError(errors.New("no rows in result set"))
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("test_ds").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(3), jsonContains(`"manifest":{"binary_version":"v1.2.3","config_hash":"abc"`), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetManifest(&model.RunManifest{BinaryVersion: "v1.2.3", ConfigHash: "abc"})
+	require.NoError(t, engine.Run(context.Background(), RunOpts{}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestEngine_Run_Skip(t *testing.T) {
 	mock, syncLog := newMockSyncLog(t)
 	mock.MatchExpectationsInOrder(false)
@@ -488,18 +523,20 @@ func TestEngine_Run_AutoTriggerXref(t *testing.T) {
 		WithArgs("entity_xref").
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(2)))
 
-	// Stage 1: xref builder — truncate + 2 CRD-CIK passes
+	// Stage 1: xref builder — address keys, truncate + 4 CRD-CIK passes
+	expectAddressKeyRefresh(mock, 2)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
-	for range 2 {
+	for range 4 {
 		mock.ExpectExec("INSERT INTO fed_data.entity_xref").
 			WillReturnResult(pgxmock.NewResult("INSERT", 0))
 	}
 
-	// Stage 2: multi xref builder — truncate + 85 passes
+	// Stage 2: multi xref builder — address keys, truncate + 93 passes
+	expectAddressKeyRefresh(mock, 13)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
-	for range 85 {
+	for range 93 {
 		mock.ExpectExec("INSERT INTO fed_data.entity_xref_multi").
 			WillReturnResult(pgxmock.NewResult("INSERT", 0))
 	}
@@ -516,6 +553,449 @@ func TestEngine_Run_AutoTriggerXref(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// mockPostSyncDataset is a mockDataset implementing PostSyncer.
+type mockPostSyncDataset struct {
+	mockDataset
+	postSynced bool
+	postErr    error
+}
+
+func (m *mockPostSyncDataset) PostSync(_ context.Context, _ db.Pool, _ *SyncResult) error {
+	m.postSynced = true
+	return m.postErr
+}
+
+func TestEngine_Run_PostSync(t *testing.T) {
+	for _, postErr := range []error{nil, errors.New("catchment build failed")} {
+		mock, syncLog := newMockSyncLog(t)
+
+		ds := &mockPostSyncDataset{
+			mockDataset: mockDataset{name: "lodes_od", phase: Phase3, shouldRun: true, syncRows: 4},
+			postErr:     postErr,
+		}
+		reg := &Registry{datasets: map[string]Dataset{"lodes_od": ds}, order: []string{"lodes_od"}}
+
+		mock.ExpectQuery("SELECT started_at FROM fed_data.sync_log").
+			WithArgs("lodes_od").
+			WillReturnError(errors.New("no rows in result set"))
+		mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+			WithArgs("lodes_od").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+		mock.ExpectExec("UPDATE fed_data.sync_log").
+			WithArgs(int64(4), pgxmock.AnyArg(), int64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+		// A failing hook is logged, not returned.
+		assert.NoError(t, engine.Run(context.Background(), RunOpts{}))
+		assert.True(t, ds.synced)
+		assert.True(t, ds.postSynced)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}
+}
+
+type mockIncrementalDataset struct {
+	mockDataset
+	since *time.Time
+	mark  *time.Time
+}
+
+func (m *mockIncrementalDataset) SyncIncremental(_ context.Context, _ db.Pool, _ fetcher.Fetcher, _ string, since time.Time) (*SyncResult, error) {
+	m.since = &since
+	return &SyncResult{RowsSynced: m.syncRows, Watermark: m.mark}, nil
+}
+
+func TestEngine_Run_Incremental(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	mark := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	reached := time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC)
+	ds := &mockIncrementalDataset{
+		mockDataset: mockDataset{name: "form_d", phase: Phase1B, syncRows: 7},
+		mark:        &reached,
+	}
+	reg := &Registry{datasets: map[string]Dataset{"form_d": ds}, order: []string{"form_d"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("form_d").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectQuery("SELECT high_water FROM fed_data.sync_state").
+		WithArgs("form_d").
+		WillReturnRows(pgxmock.NewRows([]string{"high_water"}).AddRow(mark))
+	// Incremental runs are recorded as partial so row count checks skip them.
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(7), []byte(`{"partial":true}`), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_state").
+		WithArgs("form_d", reached).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetWatermarks(fedsync.NewWatermarks(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.False(t, ds.synced, "Sync not called when a watermark exists")
+	require.NotNil(t, ds.since)
+	assert.Equal(t, mark, *ds.since)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEngine_Run_IncrementalNoWatermark(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockIncrementalDataset{mockDataset: mockDataset{name: "fpds", phase: Phase1, syncRows: 3}}
+	reg := &Registry{datasets: map[string]Dataset{"fpds": ds}, order: []string{"fpds"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("fpds").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectQuery("SELECT high_water FROM fed_data.sync_state").
+		WithArgs("fpds").
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(3), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_state").
+		WithArgs("fpds", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetWatermarks(fedsync.NewWatermarks(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced, "default window without a watermark")
+	assert.Nil(t, ds.since)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type mockResumableDataset struct {
+	mockDataset
+	cp *fedsync.Checkpoint
+}
+
+func (m *mockResumableDataset) SetCheckpoint(cp *fedsync.Checkpoint) { m.cp = cp }
+
+func TestEngine_Run_SetsCheckpoint(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockResumableDataset{mockDataset: mockDataset{name: "xbrl_facts", phase: Phase3, syncRows: 5}}
+	reg := &Registry{datasets: map[string]Dataset{"xbrl_facts": ds}, order: []string{"xbrl_facts"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("xbrl_facts").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(5), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetCheckpoints(fedsync.NewCheckpoints(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced)
+	assert.NotNil(t, ds.cp, "engine hands Resumable datasets their checkpoint")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// mockSourceTrackedDataset reports its source files unchanged.
+type mockSourceTrackedDataset struct {
+	mockPostSyncDataset
+	src *fedsync.DatasetSources
+}
+
+func (m *mockSourceTrackedDataset) SetSourceFiles(src *fedsync.DatasetSources) { m.src = src }
+
+func (m *mockSourceTrackedDataset) Sync(_ context.Context, _ db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	m.synced = true
+	return &SyncResult{Unchanged: true}, nil
+}
+
+func TestEngine_Run_SourceFilesUnchanged(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockSourceTrackedDataset{mockPostSyncDataset: mockPostSyncDataset{mockDataset: mockDataset{name: "epa_echo", phase: Phase2}}}
+	reg := &Registry{datasets: map[string]Dataset{"epa_echo": ds}, order: []string{"epa_echo"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("epa_echo").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(0), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetSourceFiles(fedsync.NewSourceFiles(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced)
+	assert.NotNil(t, ds.src, "engine hands SourceTracked datasets their source files")
+	assert.False(t, ds.postSynced, "post-sync hooks skipped when nothing changed")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEngine_Run_SkipsLockedDataset(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockDataset{name: "cbp", phase: Phase1, shouldRun: true}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(false))
+	mock.ExpectRollback()
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetRunLocks(fedsync.NewRunLocks(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.False(t, ds.synced, "a dataset locked by another run is skipped")
+	assert.Empty(t, engine.Outcomes())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEngine_Run_HoldsLockDuringSync(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockDataset{name: "cbp", phase: Phase1, shouldRun: true, syncRows: 3}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(true))
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(3), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectRollback()
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetRunLocks(fedsync.NewRunLocks(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// mockRejectingDataset rejects one row while syncing.
+type mockRejectingDataset struct {
+	mockDataset
+}
+
+func (m *mockRejectingDataset) Sync(ctx context.Context, _ db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	m.synced = true
+	fedsync.Reject(ctx, "bad_cusip", map[string]string{"cusip": "123"})
+	return &SyncResult{RowsSynced: 5}, nil
+}
+
+func TestEngine_Run_RecordsRejects(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockRejectingDataset{mockDataset{name: "holdings_13f", phase: Phase1}}
+	reg := &Registry{datasets: map[string]Dataset{"holdings_13f": ds}, order: []string{"holdings_13f"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("holdings_13f").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(3)))
+	mock.ExpectCopyFrom(pgx.Identifier{"fed_data", "rejected_rows"},
+		[]string{"sync_run_id", "dataset", "reason", "record", "source_url", "rejected_at"}).
+		WillReturnResult(1)
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(5), pgxmock.AnyArg(), int64(3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	outcomes := engine.Outcomes()
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, "complete", outcomes[0].Status)
+	assert.Equal(t, int64(5), outcomes[0].Rows)
+	assert.Equal(t, []string{"1 rows rejected"}, outcomes[0].Anomalies)
+}
+
+// mockWritingDataset upserts its rows and deletes stale ones, like a real
+// dataset's write path.
+type mockWritingDataset struct {
+	mockDataset
+}
+
+func (m *mockWritingDataset) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	m.synced = true
+	if _, err := pool.Exec(ctx, "DELETE FROM fed_data.form_d WHERE stale"); err != nil {
+		return nil, err
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table: "fed_data.form_d", Columns: []string{"id"}, ConflictKeys: []string{"id"},
+	}, [][]any{{1}, {2}})
+	return &SyncResult{RowsSynced: n}, err
+}
+
+func TestEngine_Run_DryRun(t *testing.T) {
+	// No expectations: a dry run must not touch the sync log, watermarks,
+	// checkpoints, or the dataset's tables.
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &mockWritingDataset{mockDataset{name: "entity_ds", phase: Phase1B}}
+	reg := &Registry{datasets: map[string]Dataset{"entity_ds": ds}, order: []string{"entity_ds"}}
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetCheckpoints(fedsync.NewCheckpoints(mock))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, DryRun: true}))
+	assert.True(t, ds.synced)
+
+	report := engine.DryRunReport()
+	require.NotNil(t, report)
+	tables := report.Tables()
+	require.Len(t, tables, 1)
+	assert.Equal(t, "fed_data.form_d", tables[0].Table)
+	assert.Equal(t, int64(2), tables[0].Rows)
+	assert.Equal(t, int64(1), report.Skipped())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// mockDownloadingDataset reads one file through the engine's fetcher.
+type mockDownloadingDataset struct {
+	mockDataset
+}
+
+func (m *mockDownloadingDataset) Sync(ctx context.Context, _ db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	m.synced = true
+	rc, err := f.Download(ctx, "https://example.com/data.csv")
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close() //nolint:errcheck
+	_, err = io.Copy(io.Discard, rc)
+	return &SyncResult{RowsSynced: m.syncRows}, err
+}
+
+func TestEngine_Run_RecordsDownloads(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+
+	ds := &mockDownloadingDataset{mockDataset{name: "cbp", phase: Phase1, syncRows: 4}}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, "https://example.com/data.csv").
+		Return(io.NopCloser(strings.NewReader("a,b\n1,2\n")), nil)
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET bytes_downloaded").
+		WithArgs(int64(8), []byte(`{"https://example.com/data.csv":""}`), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'complete'").
+		WithArgs(int64(4), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(pool, f, syncLog, reg, t.TempDir())
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+	assert.True(t, ds.synced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+// expectCBPValidation expects the cbp validation rules against a previous
+// run of prevRows rows, with a passing null rate.
+func expectCBPValidation(pool pgxmock.PgxPoolIface, syncID, prevRows int64) {
+	pool.ExpectQuery("SELECT COALESCE\\(rows_synced, 0\\) FROM fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"rows_synced"}).AddRow(prevRows))
+	pool.ExpectQuery(`FILTER \(WHERE "emp" IS NULL\) FROM "fed_data"."cbp_data"`).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "nulls"}).AddRow(int64(100), int64(1)))
+	pool.ExpectExec("INSERT INTO fed_data.sync_validations").
+		WithArgs(syncID, "cbp", "row_count_delta", pgxmock.AnyArg(), pgxmock.AnyArg(), 0.5, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec("INSERT INTO fed_data.sync_validations").
+		WithArgs(syncID, "cbp", "null_rate:fed_data.cbp_data.emp", true, 0.01, 0.5, "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+}
+
+func TestEngine_Run_ValidationWarns(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+
+	ds := &mockDataset{name: "cbp", phase: Phase1, syncRows: 10}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	expectCBPValidation(pool, 1, 100)
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'complete'").
+		WithArgs(int64(10), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	engine.SetValidation(&ValidationOpts{})
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, Datasets: []string{"cbp"}}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEngine_Run_ValidationBlocks(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+
+	// A blocked input sync must not trigger the opportunity rebuild.
+	cbp := &mockDataset{name: "cbp", phase: Phase1, syncRows: 10}
+	opp := &mockDataset{name: "opportunity", phase: Phase3, syncRows: 3}
+	reg := &Registry{
+		datasets: map[string]Dataset{"cbp": cbp, "opportunity": opp},
+		order:    []string{"cbp", "opportunity"},
+	}
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	expectCBPValidation(pool, 1, 100)
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'failed'").
+		WithArgs("validation failed: 1 rule(s) failed", int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	engine.SetValidation(&ValidationOpts{Block: true})
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, Datasets: []string{"cbp"}}))
+	assert.True(t, cbp.synced)
+	assert.False(t, opp.synced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEngine_Run_AutoTriggerOpportunity(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+	mock.MatchExpectationsInOrder(false)
+
+	// An opportunity input dataset syncs; the score table is registered but not selected.
+	cbp := &mockDataset{name: "cbp", phase: Phase1, shouldRun: true, syncRows: 10}
+	opp := &mockDataset{name: "opportunity", phase: Phase3, syncRows: 3}
+	reg := &Registry{
+		datasets: map[string]Dataset{"cbp": cbp, "opportunity": opp},
+		order:    []string{"cbp", "opportunity"},
+	}
+
+	mock.ExpectQuery("SELECT started_at FROM fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnError(errors.New("no rows in result set"))
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(10), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	// Auto-trigger: opportunity scores rebuild
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("opportunity").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(2)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(3), pgxmock.AnyArg(), int64(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	err := engine.Run(context.Background(), RunOpts{Datasets: []string{"cbp"}})
+	assert.NoError(t, err)
+	assert.True(t, cbp.synced)
+	assert.True(t, opp.synced)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestEngine_Run_NoAutoTriggerWhenXrefSelected(t *testing.T) {
 	mock, syncLog := newMockSyncLog(t)
 	mock.MatchExpectationsInOrder(false)
@@ -547,17 +1027,19 @@ func TestEngine_Run_NoAutoTriggerWhenXrefSelected(t *testing.T) {
 		WithArgs("entity_xref").
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(2)))
 
-	// entity_xref.Sync: Stage 1 — truncate + 3 passes
+	// entity_xref.Sync: Stage 1 — address keys, truncate + 4 passes
+	expectAddressKeyRefresh(mock, 2)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
-	for range 2 {
+	for range 4 {
 		mock.ExpectExec("INSERT INTO fed_data.entity_xref").
 			WillReturnResult(pgxmock.NewResult("INSERT", 0))
 	}
-	// Stage 2 — truncate + 85 passes
+	// Stage 2 — address keys, truncate + 93 passes
+	expectAddressKeyRefresh(mock, 13)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
-	for range 85 {
+	for range 93 {
 		mock.ExpectExec("INSERT INTO fed_data.entity_xref_multi").
 			WillReturnResult(pgxmock.NewResult("INSERT", 0))
 	}
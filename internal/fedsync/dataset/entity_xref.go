@@ -4,23 +4,29 @@ import (
 	"context"
 	"time"
 
-	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
 
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
 	"github.com/sells-group/research-cli/internal/fedsync/resolve"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 // EntityXref implements the entity cross-reference builder dataset.
 // Performs two stages:
-//  1. CRD-CIK matching: 3-pass strategy between ADV firms and EDGAR entities
-//     (direct sec_number, SIC-based exact name, fuzzy pg_trgm)
+//  1. CRD-CIK matching: 4-pass strategy between ADV firms and EDGAR entities
+//     (direct sec_number, direct N-CEN LEI, exact name in the SEC ticker map,
+//     and a probabilistic pass scoring name similarity with state, SIC, and
+//     address agreement against fedsync.xref.match_threshold)
 //  2. Multi-dataset matching: cross-references across all entity-bearing datasets
 //     (ADV, EDGAR, BrokerCheck, Form BD, OSHA, EPA, FPDS, PPP, SBA 7(a)/504,
-//     Form D, N-CEN, Form 5500, EO BMF, FDIC, USAspending) using direct CRD,
-//     direct CIK, direct DUNS/UEI, direct EIN, direct FDIC cert,
-//     exact name+zip, and exact name+state strategies.
-type EntityXref struct{}
+//     Form D, N-CEN, Form 5500, EO BMF, FDIC, USAspending, SAM, grants,
+//     single audits, and geo.parcels owners) using direct CRD, direct CIK,
+//     direct DUNS/UEI, direct EIN, direct FDIC cert, exact name+zip, and
+//     exact name+state strategies.
+type EntityXref struct {
+	cfg *config.Config
+}
 
 // Name implements Dataset.
 func (d *EntityXref) Name() string { return "entity_xref" }
@@ -28,6 +34,27 @@ func (d *EntityXref) Name() string { return "entity_xref" }
 // Table implements Dataset.
 func (d *EntityXref) Table() string { return "fed_data.entity_xref" }
 
+// Columns implements Documented.
+func (d *EntityXref) Columns() []ColumnDoc {
+	return []ColumnDoc{
+		{Column: "crd_number", Description: "ADV firm CRD number"},
+		{Column: "cik", Description: "EDGAR CIK, zero-padded to 10 digits"},
+		{Column: "entity_name", Description: "ADV firm name"},
+		{Column: "match_type", Description: "Pass that made the link: direct_sec_number, direct_lei, ticker_exact_name, or probabilistic_name"},
+		{Column: "confidence", Description: "Link confidence, 0-1"},
+		{Column: "match_method", Description: "deterministic (identifier or exact name) or probabilistic (scored)"},
+		{Column: "match_score", Description: "Weighted score of the probabilistic pass; deterministic links repeat their confidence"},
+		{Column: "match_detail", Description: "Per-signal components (name, state, industry, address) of a probabilistic score"},
+		{Column: "match_key", Description: "Identifier value joined on (sec_number or LEI); NULL for name-based links"},
+		{Table: "fed_data.entity_xref_multi", Column: "source_dataset", Description: "Dataset of the source record"},
+		{Table: "fed_data.entity_xref_multi", Column: "source_id", Description: "Source record's key in its dataset"},
+		{Table: "fed_data.entity_xref_multi", Column: "target_dataset", Description: "Dataset of the target record"},
+		{Table: "fed_data.entity_xref_multi", Column: "target_id", Description: "Target record's key in its dataset"},
+		{Table: "fed_data.entity_xref_multi", Column: "match_type", Description: "Strategy: direct_crd, direct_cik, direct_duns, direct_uei, direct_ein, direct_fdic_cert, exact_name_zip, or exact_name_state"},
+		{Table: "fed_data.entity_xref_multi", Column: "match_key", Description: "Identifier value joined on; NULL for name-based links"},
+	}
+}
+
 // Phase implements Dataset.
 func (d *EntityXref) Phase() Phase { return Phase1B }
 
@@ -47,6 +74,9 @@ func (d *EntityXref) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher,
 	// Stage 1: CRD-CIK cross-reference (existing 3-pass matching).
 	log.Info("stage 1: building CRD-CIK cross-reference")
 	crdCIKBuilder := resolve.NewXrefBuilder(pool)
+	if d.cfg != nil {
+		crdCIKBuilder.SetMatchThreshold(d.cfg.Fedsync.Xref.MatchThreshold)
+	}
 	crdCIKMatched, err := crdCIKBuilder.Build(ctx)
 	if err != nil {
 		return nil, err
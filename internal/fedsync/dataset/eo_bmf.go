@@ -102,6 +102,7 @@ func (d *EOBMF) syncRegion(ctx context.Context, pool db.Pool, f fetcher.Fetcher,
 		return 0, eris.Wrapf(err, "eo_bmf: download %s", region)
 	}
 	defer os.Remove(csvPath) //nolint:errcheck
+	ctx = db.WithSource(ctx, url)
 
 	file, err := os.Open(csvPath) // #nosec G304 -- path constructed from downloaded IRS data in trusted temp directory
 	if err != nil {
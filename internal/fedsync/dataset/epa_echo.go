@@ -1,22 +1,31 @@
 package dataset
 
 import (
+	"archive/zip"
 	"context"
+	"io"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/rotisserie/eris"
-	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
 
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 const epaEchoURL = "https://ordsext.epa.gov/FLA/www3/state_files/national_single.zip"
 
-// EPAECHO syncs EPA ECHO facility data.
-type EPAECHO struct{}
+// EPAECHO syncs EPA ECHO facility data. The national file is republished
+// with the same contents between monthly refreshes, so an unchanged ZIP is
+// not parsed again.
+type EPAECHO struct {
+	cfg *config.Config
+	src *fedsync.DatasetSources
+}
 
 // Name implements Dataset.
 func (d *EPAECHO) Name() string { return "epa_echo" }
@@ -35,45 +44,73 @@ func (d *EPAECHO) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return MonthlySchedule(now, lastSync)
 }
 
+// SetSourceFiles implements SourceTracked.
+func (d *EPAECHO) SetSourceFiles(src *fedsync.DatasetSources) { d.src = src }
+
 // Sync fetches and loads EPA ECHO facility data.
 func (d *EPAECHO) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", d.Name()))
 	log.Info("downloading EPA ECHO data")
 
 	zipPath := filepath.Join(tempDir, "epa_echo.zip")
-	if _, err := f.DownloadToFile(ctx, epaEchoURL, zipPath); err != nil {
+	version, changed, err := downloadIfChanged(ctx, f, d.src, epaEchoURL, zipPath)
+	if err != nil {
 		return nil, eris.Wrap(err, "epa_echo: download")
 	}
+	if !changed {
+		log.Info("EPA ECHO file unchanged, skipping")
+		return &SyncResult{Unchanged: true}, nil
+	}
 
-	extractDir := filepath.Join(tempDir, "epa_extract")
-	files, err := fetcher.ExtractZIP(zipPath, extractDir)
+	totalRows, err := d.processZip(ctx, pool, zipPath)
 	if err != nil {
-		return nil, eris.Wrap(err, "epa_echo: extract zip")
+		return nil, err
 	}
 
-	// Find the first CSV file in the extracted contents
-	var csvPath string
-	for _, fp := range files {
-		if strings.HasSuffix(strings.ToLower(fp), ".csv") {
-			csvPath = fp
-			break
-		}
-	}
-	if csvPath == "" {
-		return nil, eris.New("epa_echo: no CSV found in ZIP")
+	if err := d.src.Record(ctx, epaEchoURL, version); err != nil {
+		return nil, eris.Wrap(err, "epa_echo: record source version")
 	}
 
-	csvFile, err := openFileForRead(csvPath)
+	log.Info("epa_echo sync complete", zap.Int64("rows", totalRows))
+	return &SyncResult{RowsSynced: totalRows}, nil
+}
+
+// processZip streams the first CSV in the national ZIP straight from the
+// archive, without extracting it to disk.
+func (d *EPAECHO) processZip(ctx context.Context, pool db.Pool, zipPath string) (int64, error) {
+	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return nil, eris.Wrap(err, "epa_echo: open csv")
+		return 0, eris.Wrap(err, "epa_echo: open zip")
+	}
+	defer zr.Close() //nolint:errcheck
+
+	for _, zf := range zr.File {
+		if !strings.HasSuffix(strings.ToLower(zf.Name), ".csv") {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return 0, eris.Wrapf(err, "epa_echo: open %s in zip", zf.Name)
+		}
+		n, err := d.parseCSV(ctx, pool, rc)
+		_ = rc.Close()
+		return n, err
 	}
-	defer csvFile.Close() //nolint:errcheck
+	return 0, eris.New("epa_echo: no CSV found in ZIP")
+}
 
-	rowCh, errCh := fetcher.StreamCSV(ctx, csvFile, fetcher.CSVOptions{HasHeader: false})
+// parseCSV upserts facilities in bounded batches. The CSV reader blocks
+// while a batch is written and stops as soon as a write fails.
+func (d *EPAECHO) parseCSV(ctx context.Context, pool db.Pool, r io.Reader) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rowCh, errCh := fetcher.StreamCSV(ctx, r, fetcher.CSVOptions{HasHeader: false})
 
-	var rows [][]any
-	const batchSize = 5000
-	var totalRows int64
+	w := newBatchWriter(pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      []string{"registry_id", "fac_name", "fac_city", "fac_state", "fac_zip", "fac_lat", "fac_long"},
+		ConflictKeys: []string{"registry_id"},
+	}, streamBatchRows(d.cfg, defaultStreamBatchRows))
 
 	// Read the header row to build column index (case-insensitive via mapColumns)
 	var colIdx map[string]int
@@ -96,7 +133,7 @@ func (d *EPAECHO) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tem
 			continue
 		}
 
-		rows = append(rows, []any{
+		if err := w.Add(ctx, []any{
 			regID,
 			trimQuotes(getCol(row, colIdx, "primary_name")),
 			trimQuotes(getCol(row, colIdx, "city_name")),
@@ -104,38 +141,16 @@ func (d *EPAECHO) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tem
 			trimQuotes(getCol(row, colIdx, "postal_code")),
 			parseFloat64Or(getCol(row, colIdx, "latitude83"), 0),
 			parseFloat64Or(getCol(row, colIdx, "longitude83"), 0),
-		})
-
-		if len(rows) >= batchSize {
-			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-				Table:        d.Table(),
-				Columns:      []string{"registry_id", "fac_name", "fac_city", "fac_state", "fac_zip", "fac_lat", "fac_long"},
-				ConflictKeys: []string{"registry_id"},
-			}, rows)
-			if err != nil {
-				return nil, eris.Wrap(err, "epa_echo: upsert")
-			}
-			totalRows += n
-			rows = rows[:0]
+		}); err != nil {
+			return 0, eris.Wrap(err, "epa_echo: upsert")
 		}
 	}
 
 	if err := <-errCh; err != nil {
-		return nil, eris.Wrap(err, "epa_echo: stream csv")
+		return 0, eris.Wrap(err, "epa_echo: stream csv")
 	}
-
-	if len(rows) > 0 {
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table:        d.Table(),
-			Columns:      []string{"registry_id", "fac_name", "fac_city", "fac_state", "fac_zip", "fac_lat", "fac_long"},
-			ConflictKeys: []string{"registry_id"},
-		}, rows)
-		if err != nil {
-			return nil, eris.Wrap(err, "epa_echo: upsert final")
-		}
-		totalRows += n
+	if err := w.Flush(ctx); err != nil {
+		return 0, eris.Wrap(err, "epa_echo: upsert final")
 	}
-
-	log.Info("epa_echo sync complete", zap.Int64("rows", totalRows))
-	return &SyncResult{RowsSynced: totalRows}, nil
+	return w.Total(), nil
 }
@@ -0,0 +1,387 @@
+package dataset
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	// epaExporterURL is the ECHO Exporter: one row per FRS facility with
+	// inspection, enforcement, penalty, and compliance-status summaries.
+	epaExporterURL = "https://echo.epa.gov/files/echodownloads/echo_exporter.zip"
+	// epaCasesURL is the ICIS FE&C civil enforcement case download.
+	epaCasesURL = "https://echo.epa.gov/files/echodownloads/case_downloads.zip"
+
+	epaExporterFile        = "ECHO_EXPORTER.csv"
+	epaCaseEnforcementFile = "CASE_ENFORCEMENTS.csv"
+	epaCaseFacilitiesFile  = "CASE_FACILITIES.csv"
+
+	epaEnforcementBatchSize = 5000
+	// epaMaxCaseNumbers caps the case numbers kept per facility.
+	epaMaxCaseNumbers = 20
+)
+
+// epaEnforcementColumns defines the epa_enforcement upsert columns.
+var epaEnforcementColumns = []string{
+	"registry_id", "fac_name", "fac_state", "compliance_status", "snc_flag", "qtrs_with_nc",
+	"programs_with_snc", "compliance_history_3yr",
+	"inspection_count", "last_inspection_date",
+	"informal_action_count", "last_informal_action_date",
+	"formal_action_count", "last_formal_action_date",
+	"penalty_count", "total_penalties", "last_penalty_date", "last_penalty_amount",
+	"case_count", "case_numbers", "case_penalties", "last_case_date",
+	"updated_at",
+}
+
+// epaExporterAliases lists the ECHO Exporter headers for each field.
+var epaExporterAliases = map[string][]string{
+	"registry_id":         {"registry_id"},
+	"name":                {"fac_name"},
+	"state":               {"fac_state"},
+	"compliance_status":   {"fac_compliance_status"},
+	"snc":                 {"fac_snc_flg"},
+	"qtrs_with_nc":        {"fac_qtrs_with_nc"},
+	"programs_with_snc":   {"fac_programs_with_snc"},
+	"history_3yr":         {"fac_3yr_compliance_history"},
+	"inspections":         {"fac_inspection_count"},
+	"last_inspection":     {"fac_date_last_inspection"},
+	"informal_actions":    {"fac_informal_count"},
+	"last_informal":       {"fac_date_last_informal_action"},
+	"formal_actions":      {"fac_formal_action_count"},
+	"last_formal":         {"fac_date_last_formal_action"},
+	"penalty_count":       {"fac_penalty_count"},
+	"total_penalties":     {"fac_total_penalties"},
+	"last_penalty":        {"fac_date_last_penalty"},
+	"last_penalty_amount": {"fac_last_penalty_amt"},
+}
+
+// epaCaseAliases lists the case download headers for each field.
+var epaCaseAliases = map[string][]string{
+	"case_number": {"case_number"},
+	"registry_id": {"registry_id"},
+	"penalty":     {"total_penalty_assessed_amt", "fed_penalty_assessed_amt"},
+	"date":        {"activity_status_date", "case_status_date"},
+}
+
+// epaCaseSummary aggregates the civil enforcement cases naming a facility.
+type epaCaseSummary struct {
+	numbers   []string
+	penalties float64
+	latest    *time.Time
+}
+
+// EPAEnforcement syncs EPA ECHO enforcement and compliance history into
+// fed_data.epa_enforcement, one row per FRS registry_id (joinable to
+// fed_data.epa_facilities). It combines the ECHO Exporter's formal and
+// informal action, penalty, and noncompliance summaries with the civil
+// enforcement cases naming each facility. Only facilities with an
+// enforcement action, penalty, case, or quarter of noncompliance are kept;
+// facilities that no longer qualify are removed after each sync.
+type EPAEnforcement struct{}
+
+// Name implements Dataset.
+func (d *EPAEnforcement) Name() string { return "epa_enforcement" }
+
+// Table implements Dataset.
+func (d *EPAEnforcement) Table() string { return "fed_data.epa_enforcement" }
+
+// Phase implements Dataset.
+func (d *EPAEnforcement) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *EPAEnforcement) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *EPAEnforcement) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync downloads the case and ECHO Exporter files and loads one row per
+// facility with an enforcement or compliance record.
+func (d *EPAEnforcement) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	casesZip := filepath.Join(tempDir, "epa_case_downloads.zip")
+	log.Info("downloading EPA enforcement cases")
+	if _, err := f.DownloadToFile(ctx, epaCasesURL, casesZip); err != nil {
+		return nil, eris.Wrap(err, "epa_enforcement: download cases")
+	}
+	cases, err := loadEPACases(casesZip, filepath.Join(tempDir, "epa_cases"))
+	if err != nil {
+		return nil, eris.Wrap(err, "epa_enforcement: load cases")
+	}
+	_ = os.Remove(casesZip)
+	log.Info("loaded EPA enforcement cases", zap.Int("facilities", len(cases)))
+
+	exporterZip := filepath.Join(tempDir, "echo_exporter.zip")
+	log.Info("downloading ECHO Exporter")
+	if _, err := f.DownloadToFile(ctx, epaExporterURL, exporterZip); err != nil {
+		return nil, eris.Wrap(err, "epa_enforcement: download exporter")
+	}
+	csvPath, err := fetcher.ExtractZIPFile(exporterZip, epaExporterFile, filepath.Join(tempDir, "epa_exporter"))
+	if err != nil {
+		return nil, eris.Wrap(err, "epa_enforcement: extract exporter")
+	}
+	_ = os.Remove(exporterZip)
+
+	start := time.Now().UTC()
+	total, err := d.loadExporter(ctx, pool, csvPath, cases, start)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, eris.New("epa_enforcement: exporter produced no facilities")
+	}
+
+	tag, err := pool.Exec(ctx, `DELETE FROM fed_data.epa_enforcement WHERE updated_at < $1`, start)
+	if err != nil {
+		return nil, eris.Wrap(err, "epa_enforcement: delete stale facilities")
+	}
+
+	log.Info("epa_enforcement sync complete", zap.Int64("rows", total), zap.Int64("removed", tag.RowsAffected()))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"case_facilities": len(cases),
+			"removed":         tag.RowsAffected(),
+		},
+	}, nil
+}
+
+// loadExporter streams the ECHO Exporter CSV and upserts every facility with
+// an enforcement or compliance record.
+func (d *EPAEnforcement) loadExporter(ctx context.Context, pool db.Pool, csvPath string, cases map[string]*epaCaseSummary, now time.Time) (int64, error) {
+	file, err := openFileForRead(csvPath)
+	if err != nil {
+		return 0, eris.Wrap(err, "epa_enforcement: open exporter")
+	}
+	defer file.Close() //nolint:errcheck
+
+	reader := newLenientCSVReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "epa_enforcement: read exporter header")
+	}
+	cols := resolveAliases(mapColumnsTrimBOM(header), epaExporterAliases)
+	if err := cols.require(epaExporterAliases, "registry_id", "formal_actions", "compliance_status"); err != nil {
+		return 0, eris.Wrap(err, "epa_enforcement")
+	}
+
+	var total int64
+	batch := make([][]any, 0, epaEnforcementBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      epaEnforcementColumns,
+			ConflictKeys: []string{"registry_id"},
+		}, batch)
+		if err != nil {
+			return eris.Wrap(err, "epa_enforcement: upsert")
+		}
+		total += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return total, eris.Wrap(err, "epa_enforcement: read exporter")
+		}
+		row := epaEnforcementRow(cols, record, cases, now)
+		if row == nil {
+			continue
+		}
+		batch = append(batch, row)
+		if len(batch) >= epaEnforcementBatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// epaEnforcementRow maps an ECHO Exporter record and its cases to
+// epaEnforcementColumns. Returns nil for facilities with no enforcement
+// action, penalty, case, or noncompliance.
+func epaEnforcementRow(cols aliasColumns, record []string, cases map[string]*epaCaseSummary, now time.Time) []any {
+	get := func(field string) string { return sanitizeUTF8(strings.TrimSpace(cols.get(record, field))) }
+	regID := get("registry_id")
+	if regID == "" {
+		return nil
+	}
+
+	formal := epaCount(get("formal_actions"))
+	informal := epaCount(get("informal_actions"))
+	penaltyCount := epaCount(get("penalty_count"))
+	qtrsNC := epaCount(get("qtrs_with_nc"))
+	snc := strings.EqualFold(get("snc"), "Y")
+	c := cases[regID]
+	if formal == 0 && informal == 0 && penaltyCount == 0 && qtrsNC == 0 && !snc && c == nil {
+		return nil
+	}
+
+	var programs []string
+	for _, p := range strings.FieldsFunc(get("programs_with_snc"), func(r rune) bool { return r == ' ' || r == ',' }) {
+		programs = append(programs, strings.ToUpper(p))
+	}
+
+	var caseCount, caseNumbers, casePenalties, lastCase any
+	if c != nil {
+		caseCount = len(c.numbers)
+		caseNumbers = c.numbers
+		casePenalties = c.penalties
+		lastCase = dateOrNil(c.latest)
+	}
+
+	return []any{
+		regID,
+		nilIfEmpty(get("name")),
+		nilIfEmpty(fitLen(strings.ToUpper(get("state")), 2)),
+		nilIfEmpty(get("compliance_status")),
+		snc,
+		qtrsNC,
+		programs,
+		nilIfEmpty(get("history_3yr")),
+		epaCount(get("inspections")),
+		dateOrNil(parseDate(get("last_inspection"))),
+		informal,
+		dateOrNil(parseDate(get("last_informal"))),
+		formal,
+		dateOrNil(parseDate(get("last_formal"))),
+		penaltyCount,
+		parseFloat64OrNil(get("total_penalties")),
+		dateOrNil(parseDate(get("last_penalty"))),
+		parseFloat64OrNil(get("last_penalty_amount")),
+		caseCount,
+		caseNumbers,
+		casePenalties,
+		lastCase,
+		now,
+	}
+}
+
+// loadEPACases reads the case download's enforcement and facility files
+// and aggregates case numbers and assessed penalties by registry_id.
+func loadEPACases(zipPath, destDir string) (map[string]*epaCaseSummary, error) {
+	type caseInfo struct {
+		penalty float64
+		date    *time.Time
+	}
+	byCase := make(map[string]caseInfo)
+	enfPath, err := fetcher.ExtractZIPFile(zipPath, epaCaseEnforcementFile, destDir)
+	if err != nil {
+		return nil, err
+	}
+	err = readEPACaseCSV(enfPath, func(cols aliasColumns, record []string) {
+		num := strings.TrimSpace(cols.get(record, "case_number"))
+		if num == "" {
+			return
+		}
+		info := byCase[num]
+		if v, ok := parseFloat64OrNil(cols.get(record, "penalty")).(float64); ok {
+			info.penalty += v
+		}
+		if d := parseDate(cols.get(record, "date")); d != nil && (info.date == nil || d.After(*info.date)) {
+			info.date = d
+		}
+		byCase[num] = info
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "read case enforcements")
+	}
+
+	facPath, err := fetcher.ExtractZIPFile(zipPath, epaCaseFacilitiesFile, destDir)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*epaCaseSummary)
+	err = readEPACaseCSV(facPath, func(cols aliasColumns, record []string) {
+		num := strings.TrimSpace(cols.get(record, "case_number"))
+		regID := strings.TrimSpace(cols.get(record, "registry_id"))
+		if num == "" || regID == "" {
+			return
+		}
+		s := out[regID]
+		if s == nil {
+			s = &epaCaseSummary{}
+			out[regID] = s
+		}
+		for _, n := range s.numbers {
+			if n == num {
+				return
+			}
+		}
+		info := byCase[num]
+		s.penalties += info.penalty
+		if info.date != nil && (s.latest == nil || info.date.After(*s.latest)) {
+			s.latest = info.date
+		}
+		if len(s.numbers) < epaMaxCaseNumbers {
+			s.numbers = append(s.numbers, num)
+		}
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "read case facilities")
+	}
+	return out, nil
+}
+
+// readEPACaseCSV calls fn for each record of a case download CSV.
+func readEPACaseCSV(path string, fn func(aliasColumns, []string)) error {
+	file, err := openFileForRead(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	reader := newLenientCSVReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return eris.Wrap(err, "read header")
+	}
+	cols := resolveAliases(mapColumnsTrimBOM(header), epaCaseAliases)
+	if err := cols.require(epaCaseAliases, "case_number"); err != nil {
+		return err
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return eris.Wrap(err, "read record")
+		}
+		fn(cols, record)
+	}
+}
+
+// epaCount parses an ECHO count column, treating blanks as zero.
+func epaCount(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,139 @@
+package dataset
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const epaExporterCSV = "REGISTRY_ID,FAC_NAME,FAC_STATE,FAC_INSPECTION_COUNT,FAC_DATE_LAST_INSPECTION,FAC_INFORMAL_COUNT,FAC_DATE_LAST_INFORMAL_ACTION,FAC_FORMAL_ACTION_COUNT,FAC_DATE_LAST_FORMAL_ACTION,FAC_TOTAL_PENALTIES,FAC_PENALTY_COUNT,FAC_DATE_LAST_PENALTY,FAC_LAST_PENALTY_AMT,FAC_QTRS_WITH_NC,FAC_PROGRAMS_WITH_SNC,FAC_COMPLIANCE_STATUS,FAC_SNC_FLG,FAC_3YR_COMPLIANCE_HISTORY\n" +
+	"110000002,ACME FABRICATION,tx,4,05/14/2025,1,06/01/2025,2,09/30/2025,40000,1,09/30/2025,40000,3,CAA RCRA,Significant Violation,Y,__VV_SSS___\n" +
+	"110000003,CLEAN CO,TX,2,01/10/2025,0,,0,,,0,,,0,,No Violation Identified,N,____________\n" +
+	"110000004,CASE ONLY INC,OK,,,,,,,,,,,,,No Violation Identified,N,\n"
+
+const epaCaseEnforcementsCSV = "ACTIVITY_ID,CASE_NUMBER,CASE_NAME,TOTAL_PENALTY_ASSESSED_AMT,ACTIVITY_STATUS_DATE\n" +
+	"1,06-2024-0001,ACME FABRICATION,25000,03/15/2024\n" +
+	"2,06-2025-0007,ACME FABRICATION,15000,08/01/2025\n" +
+	"3,06-2023-0100,CASE ONLY INC,,01/05/2023\n"
+
+const epaCaseFacilitiesCSV = "ACTIVITY_ID,CASE_NUMBER,REGISTRY_ID,FACILITY_NAME\n" +
+	"1,06-2024-0001,110000002,ACME FABRICATION\n" +
+	"2,06-2025-0007,110000002,ACME FABRICATION\n" +
+	"2,06-2025-0007,110000002,ACME FABRICATION\n" +
+	"3,06-2023-0100,110000004,CASE ONLY INC\n" +
+	"4,,110000009,NO CASE NUMBER\n"
+
+func epaCasesZip(t *testing.T, dir string) string {
+	t.Helper()
+	return createTestZipMulti(t, dir, "cases.zip", map[string]string{
+		epaCaseEnforcementFile: epaCaseEnforcementsCSV,
+		epaCaseFacilitiesFile:  epaCaseFacilitiesCSV,
+	})
+}
+
+func TestEPAEnforcement_Metadata(t *testing.T) {
+	d := &EPAEnforcement{}
+	assert.Equal(t, "epa_enforcement", d.Name())
+	assert.Equal(t, "fed_data.epa_enforcement", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestLoadEPACases(t *testing.T) {
+	dir := t.TempDir()
+	cases, err := loadEPACases(epaCasesZip(t, dir), filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+
+	acme := cases["110000002"]
+	assert.Equal(t, []string{"06-2024-0001", "06-2025-0007"}, acme.numbers)
+	assert.Equal(t, 40000.0, acme.penalties)
+	assert.Equal(t, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), *acme.latest)
+	assert.Zero(t, cases["110000004"].penalties)
+}
+
+func TestEPAEnforcementRow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(epaExporterCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	cols := resolveAliases(mapColumnsTrimBOM(header), epaExporterAliases)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	latest := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]*epaCaseSummary{
+		"110000002": {numbers: []string{"06-2024-0001", "06-2025-0007"}, penalties: 40000, latest: &latest},
+		"110000004": {numbers: []string{"06-2023-0100"}},
+	}
+	now := time.Now()
+
+	row := epaEnforcementRow(cols, records[0], cases, now)
+	require.Len(t, row, len(epaEnforcementColumns))
+	assert.Equal(t, "110000002", row[0])
+	assert.Equal(t, "TX", row[2])
+	assert.Equal(t, "Significant Violation", row[3])
+	assert.Equal(t, true, row[4])
+	assert.Equal(t, 3, row[5])
+	assert.Equal(t, []string{"CAA", "RCRA"}, row[6])
+	assert.Equal(t, 2, row[12])
+	assert.Equal(t, time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC), row[13])
+	assert.Equal(t, 40000.0, row[15])
+	assert.Equal(t, 2, row[18])
+	assert.Equal(t, latest, row[21])
+
+	assert.Nil(t, epaEnforcementRow(cols, records[1], cases, now), "inspected but no violations")
+
+	row = epaEnforcementRow(cols, records[2], cases, now)
+	require.NotNil(t, row, "kept for its civil case")
+	assert.Equal(t, 0, row[12])
+	assert.Nil(t, row[15])
+	assert.Equal(t, 1, row[18])
+}
+
+func TestEPAEnforcement_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	fixtures := t.TempDir()
+	casesZip := epaCasesZip(t, fixtures)
+	exporterZip := createTestZip(t, fixtures, "exporter.zip", epaExporterFile, epaExporterCSV)
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, epaCasesURL, mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			copyTestFixture(t, casesZip, path)
+		}).Return(int64(1), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, epaExporterURL, mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			copyTestFixture(t, exporterZip, path)
+		}).Return(int64(1), nil)
+
+	expectBulkUpsert(pool, "fed_data.epa_enforcement", epaEnforcementColumns, 2)
+	pool.ExpectExec("DELETE FROM fed_data.epa_enforcement").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 5))
+
+	res, err := (&EPAEnforcement{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 2, res.Metadata["case_facilities"])
+	assert.Equal(t, int64(5), res.Metadata["removed"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEPACount(t *testing.T) {
+	assert.Equal(t, 12, epaCount(" 12 "))
+	assert.Zero(t, epaCount(""))
+	assert.Zero(t, epaCount("-3"))
+}
@@ -0,0 +1,59 @@
+package dataset
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// downloadCSVExtract downloads a CSV extract to path and opens it. ZIP
+// archives are detected by content and the first .csv member is returned.
+func downloadCSVExtract(ctx context.Context, f fetcher.Fetcher, url, path string) (io.ReadCloser, error) {
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrap(err, "download")
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		// Not a ZIP; read the file as CSV.
+		file, err := os.Open(path) // #nosec G304 -- path under the sync temp dir
+		if err != nil {
+			return nil, eris.Wrap(err, "open extract")
+		}
+		return file, nil
+	}
+	for _, zf := range zr.File {
+		if strings.EqualFold(filepath.Ext(zf.Name), ".csv") {
+			rc, err := zf.Open()
+			if err != nil {
+				_ = zr.Close()
+				return nil, eris.Wrapf(err, "open %s", zf.Name)
+			}
+			return &zipMemberReader{ReadCloser: rc, zr: zr}, nil
+		}
+	}
+	_ = zr.Close()
+	return nil, eris.New("no CSV file in zip")
+}
+
+// zipMemberReader closes the archive along with the member.
+type zipMemberReader struct {
+	io.ReadCloser
+	zr *zip.ReadCloser
+}
+
+// Close closes the member and its archive.
+func (z *zipMemberReader) Close() error {
+	err := z.ReadCloser.Close()
+	if zErr := z.zr.Close(); err == nil {
+		err = zErr
+	}
+	return err
+}
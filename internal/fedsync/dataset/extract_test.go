@@ -0,0 +1,42 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestDownloadCSVExtract(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(ctx, "https://x.test/a.zip", mock.Anything).
+		RunAndReturn(mockDownloadToFileZIP(t, "data.csv", "a,b\n1,2\n")).Once()
+	rc, err := downloadCSVExtract(ctx, f, "https://x.test/a.zip", filepath.Join(dir, "a"))
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "a,b\n1,2\n", string(data))
+
+	f.EXPECT().DownloadToFile(ctx, "https://x.test/b.zip", mock.Anything).
+		RunAndReturn(mockDownloadToFileZIP(t, "readme.txt", "hi")).Once()
+	_, err = downloadCSVExtract(ctx, f, "https://x.test/b.zip", filepath.Join(dir, "b"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no CSV file in zip")
+
+	f.EXPECT().DownloadToFile(ctx, "https://x.test/c.csv", mock.Anything).
+		Return(0, errors.New("unexpected status 503")).Once()
+	_, err = downloadCSVExtract(ctx, f, "https://x.test/c.csv", filepath.Join(dir, "c"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "download")
+}
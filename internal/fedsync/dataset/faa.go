@@ -0,0 +1,435 @@
+package dataset
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	faaBatchSize = 10000
+
+	// faaRegistryURL is the FAA Releasable Aircraft Database, rebuilt nightly.
+	// The ZIP holds MASTER.txt (one row per registered N-number) and
+	// ACFTREF.txt (manufacturer/model reference keyed by MFR MDL CODE).
+	faaRegistryURL = "https://registry.faa.gov/database/ReleasableAircraft.zip"
+)
+
+// faaColumns defines the target DB columns in upsert order.
+var faaColumns = []string{
+	"n_number", "serial_number", "mfr_mdl_code", "aircraft_mfr", "aircraft_model",
+	"year_mfr", "type_aircraft", "type_engine", "num_engines", "num_seats", "weight_class",
+	"registrant_type", "registrant_name", "name_norm",
+	"street", "street2", "city", "state", "zip", "country",
+	"street_norm", "zip5",
+	"cert_issue_date", "last_action_date", "expiration_date",
+	"status_code", "fractional_owner", "mode_s_hex",
+}
+
+// faaUpsertColumns are faaColumns plus updated_at, stamped with the sync's
+// start time so aircraft missing from the master file can be pruned.
+var faaUpsertColumns = append(slices.Clone(faaColumns), "updated_at")
+
+// faaAircraftRef holds the ACFTREF.txt fields joined onto each aircraft.
+type faaAircraftRef struct {
+	mfr        string
+	model      string
+	numEngines *int
+	numSeats   *int
+	weight     string
+}
+
+// FAARegistry syncs the FAA aircraft registration master file into
+// fed_data.faa_aircraft, keyed by N-number, with manufacturer and model from
+// the aircraft reference file. After each sync, PostSync matches business
+// registrants (not individuals or government) to enrichment companies and
+// ADV firms by normalized name plus ZIP or state, a corporate aircraft
+// ownership signal for sizing.
+type FAARegistry struct {
+	url string // overrides faaRegistryURL in tests
+}
+
+// Name implements Dataset.
+func (d *FAARegistry) Name() string { return "faa_registry" }
+
+// Table implements Dataset.
+func (d *FAARegistry) Table() string { return "fed_data.faa_aircraft" }
+
+// Phase implements Dataset.
+func (d *FAARegistry) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *FAARegistry) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *FAARegistry) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync downloads the releasable aircraft ZIP and upserts MASTER.txt rows
+// joined with ACFTREF.txt. Aircraft no longer in the master file
+// (deregistered N-numbers) are then deleted, along with their matches.
+func (d *FAARegistry) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+	started := time.Now().UTC()
+
+	url := faaRegistryURL
+	if d.url != "" {
+		url = d.url
+	}
+	zipPath := filepath.Join(tempDir, "faa_releasable_aircraft.zip")
+	if _, err := f.DownloadToFile(ctx, url, zipPath); err != nil {
+		return nil, eris.Wrap(err, "faa_registry: download")
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "faa_registry: open zip")
+	}
+	defer zr.Close() //nolint:errcheck
+
+	var masterFile, refFile *zip.File
+	for _, zf := range zr.File {
+		switch strings.ToUpper(filepath.Base(zf.Name)) {
+		case "MASTER.TXT":
+			masterFile = zf
+		case "ACFTREF.TXT":
+			refFile = zf
+		}
+	}
+	if masterFile == nil {
+		return nil, eris.New("faa_registry: MASTER.txt not found in zip")
+	}
+
+	refs := map[string]faaAircraftRef{}
+	if refFile == nil {
+		log.Warn("faa_registry: ACFTREF.txt not found, loading without aircraft reference")
+	} else {
+		rc, err := refFile.Open()
+		if err != nil {
+			return nil, eris.Wrap(err, "faa_registry: open ACFTREF.txt")
+		}
+		refs, err = parseFAAAircraftRef(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rc, err := masterFile.Open()
+	if err != nil {
+		return nil, eris.Wrap(err, "faa_registry: open MASTER.txt")
+	}
+	defer rc.Close() //nolint:errcheck
+
+	rows, business, err := d.loadMaster(ctx, pool, rc, refs, started)
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, eris.New("faa_registry: MASTER.txt has no aircraft")
+	}
+
+	tag, err := pool.Exec(ctx, `DELETE FROM fed_data.faa_aircraft WHERE updated_at < $1`, started)
+	if err != nil {
+		return nil, eris.Wrap(err, "faa_registry: prune deregistered aircraft")
+	}
+
+	log.Info("faa_registry sync complete",
+		zap.Int64("rows", rows),
+		zap.Int64("business_registrants", business),
+		zap.Int64("deregistered", tag.RowsAffected()))
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"aircraft_models":      len(refs),
+			"business_registrants": business,
+			"deregistered":         tag.RowsAffected(),
+		},
+	}, nil
+}
+
+// loadMaster streams MASTER.txt and upserts aircraft in batches, stamping
+// updated_at with syncedAt. It returns the rows upserted and how many have
+// business registrants.
+func (d *FAARegistry) loadMaster(ctx context.Context, pool db.Pool, r io.Reader, refs map[string]faaAircraftRef, syncedAt time.Time) (int64, int64, error) {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "faa_registry: read master header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	if _, ok := colIdx["n-number"]; !ok {
+		return 0, 0, eris.New("faa_registry: N-NUMBER column not found in master header")
+	}
+
+	upsert := func(batch [][]any) (int64, error) {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      faaUpsertColumns,
+			ConflictKeys: []string{"n_number"},
+		}, batch)
+		return n, eris.Wrap(err, "faa_registry: bulk upsert")
+	}
+
+	var (
+		batch    [][]any
+		total    int64
+		business int64
+	)
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			continue // skip malformed rows
+		}
+
+		row := faaRow(record, colIdx, refs)
+		if row == nil {
+			continue
+		}
+		if faaBusinessRegistrant(row[faaRegistrantTypeCol]) {
+			business++
+		}
+		batch = append(batch, append(row, syncedAt))
+
+		if len(batch) >= faaBatchSize {
+			n, err := upsert(batch)
+			if err != nil {
+				return total, business, err
+			}
+			total += n
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		n, err := upsert(batch)
+		if err != nil {
+			return total, business, err
+		}
+		total += n
+	}
+	return total, business, nil
+}
+
+// faaRow maps a MASTER.txt record and its aircraft reference to faaColumns.
+// Returns nil when the record has no N-number.
+func faaRow(record []string, colIdx map[string]int, refs map[string]faaAircraftRef) []any {
+	get := func(name string) string { return sanitizeUTF8(strings.TrimSpace(getColN(record, colIdx, name))) }
+	text := func(name string) any { return nilIfEmpty(get(name)) }
+	intOrNil := func(name string) any {
+		if v, err := strconv.Atoi(get(name)); err == nil {
+			return v
+		}
+		return nil
+	}
+
+	nNumber := strings.TrimPrefix(strings.ToUpper(get("n-number")), "N")
+	if nNumber == "" {
+		return nil
+	}
+
+	mdl := get("mfr mdl code")
+	ref := refs[mdl]
+
+	var yearMfr any
+	if y, err := strconv.Atoi(get("year mfr")); err == nil && y > 1900 {
+		yearMfr = int16(y) // #nosec G115 -- manufacture year fits in int16
+	}
+	var regType any
+	if t, err := strconv.Atoi(get("type registrant")); err == nil {
+		regType = int16(t) // #nosec G115 -- single-digit code
+	}
+
+	name := get("name")
+	street := get("street")
+	zipCode := get("zip code")
+
+	return []any{
+		nNumber,
+		text("serial number"),
+		nilIfEmpty(mdl),
+		nilIfEmpty(ref.mfr),
+		nilIfEmpty(ref.model),
+		yearMfr,
+		text("type aircraft"),
+		intOrNil("type engine"),
+		ref.numEngines,
+		ref.numSeats,
+		nilIfEmpty(ref.weight),
+		regType,
+		nilIfEmpty(name),
+		nilIfEmpty(resolve.NormalizeName(name)),
+		nilIfEmpty(street),
+		text("street2"),
+		text("city"),
+		text("state"),
+		nilIfEmpty(zipCode),
+		text("country"),
+		nilIfEmpty(resolve.NormalizeStreet(street)),
+		nilIfEmpty(resolve.NormalizeZIP(zipCode)),
+		parseFAADate(get("cert issue date")),
+		parseFAADate(get("last action date")),
+		parseFAADate(get("expiration date")),
+		text("status code"),
+		get("fract owner") == "Y",
+		text("mode s code hex"),
+	}
+}
+
+// faaBusinessRegistrantTypes are the TYPE REGISTRANT codes for entities:
+// partnership, corporation, LLC, and non-citizen corporation. Individuals
+// (1), co-owners (4, 9), and government (5) are excluded from matching.
+// Keep in sync with the registrant_type filter in faaMatchSQL.
+var faaBusinessRegistrantTypes = map[int16]bool{2: true, 3: true, 7: true, 8: true}
+
+// faaRegistrantTypeCol is the index of registrant_type in faaColumns.
+var faaRegistrantTypeCol = slices.Index(faaColumns, "registrant_type")
+
+func faaBusinessRegistrant(v any) bool {
+	t, ok := v.(int16)
+	return ok && faaBusinessRegistrantTypes[t]
+}
+
+// parseFAAAircraftRef reads ACFTREF.txt into a MFR MDL CODE → reference map.
+func parseFAAAircraftRef(r io.Reader) (map[string]faaAircraftRef, error) {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "faa_registry: read acftref header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	if _, ok := colIdx["code"]; !ok {
+		return nil, eris.New("faa_registry: CODE column not found in acftref header")
+	}
+
+	get := func(record []string, name string) string { return strings.TrimSpace(getColN(record, colIdx, name)) }
+	out := make(map[string]faaAircraftRef)
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			continue
+		}
+		code := get(record, "code")
+		if code == "" {
+			continue
+		}
+		out[code] = faaAircraftRef{
+			mfr:        get(record, "mfr"),
+			model:      get(record, "model"),
+			numEngines: parseNullInt(get(record, "no-eng")),
+			numSeats:   parseNullInt(get(record, "no-seats")),
+			weight:     get(record, "ac-weight"),
+		}
+	}
+	return out, nil
+}
+
+// parseFAADate parses registry dates (YYYYMMDD). Returns nil when empty or
+// invalid.
+func parseFAADate(s string) any {
+	t, err := time.Parse("20060102", strings.TrimSpace(s))
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// PostSync implements PostSyncer by rebuilding registrant matches to
+// enrichment companies and ADV firms.
+func (d *FAARegistry) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrap(err, "faa_registry: begin matches")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fed_data.faa_aircraft_matches`); err != nil {
+		return eris.Wrap(err, "faa_registry: clear matches")
+	}
+	var total int64
+	for _, p := range faaMatchPasses() {
+		tag, err := tx.Exec(ctx, p.sql)
+		if err != nil {
+			return eris.Wrapf(err, "faa_registry: match pass %s", p.name)
+		}
+		total += tag.RowsAffected()
+		log.Info("faa_registry match pass complete", zap.String("pass", p.name), zap.Int64("matched", tag.RowsAffected()))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrap(err, "faa_registry: commit matches")
+	}
+
+	log.Info("faa_registry matches rebuilt", zap.Int64("matches", total))
+	return nil
+}
+
+// faaMatchPass is one registrant match statement.
+type faaMatchPass struct {
+	name string
+	sql  string
+}
+
+// faaMatchPasses returns the registrant match passes in descending
+// confidence order. Later passes skip aircraft/entity pairs already matched.
+func faaMatchPasses() []faaMatchPass {
+	companies := `public.companies c
+	CROSS JOIN LATERAL (VALUES (c.name), (c.legal_name)) AS n(name)`
+	companyName := resolve.NormalizeNameSQL("n.name")
+	firmName := resolve.NormalizeNameSQL("f.firm_name")
+
+	return []faaMatchPass{
+		{
+			name: "company_name_zip",
+			sql: faaMatchSQL("company", "c.id::text", "name_zip", 0.95,
+				companies, companyName, "LEFT(c.zip_code, 5) = a.zip5"),
+		},
+		{
+			name: "adv_firm_name_zip",
+			sql: faaMatchSQL("adv_firm", "f.crd_number::text", "name_zip", 0.95,
+				"fed_data.adv_firms f", firmName, "LEFT(f.zip, 5) = a.zip5"),
+		},
+		{
+			name: "company_name_state",
+			sql: faaMatchSQL("company", "c.id::text", "name_state", 0.80,
+				companies, companyName, "UPPER(c.state) = a.state"),
+		},
+		{
+			name: "adv_firm_name_state",
+			sql: faaMatchSQL("adv_firm", "f.crd_number::text", "name_state", 0.80,
+				"fed_data.adv_firms f", firmName, "UPPER(f.state) = a.state"),
+		},
+	}
+}
+
+// faaMatchSQL builds an INSERT linking business registrants to rows of from
+// whose normalized name equals name_norm and whose location satisfies cond.
+func faaMatchSQL(entityType, entityKey, matchType string, confidence float64, from, normName, cond string) string {
+	return fmt.Sprintf(`INSERT INTO fed_data.faa_aircraft_matches (n_number, entity_type, entity_key, match_type, confidence)
+SELECT DISTINCT a.n_number, '%s', %s, '%s', %.2f
+FROM fed_data.faa_aircraft a
+JOIN %s ON %s = a.name_norm AND %s
+WHERE a.registrant_type IN (2, 3, 7, 8)
+ON CONFLICT (n_number, entity_type, entity_key) DO NOTHING`,
+		entityType, entityKey, matchType, confidence, from, normName, cond)
+}
@@ -0,0 +1,219 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const faaMasterTXT = "\ufeffN-NUMBER,SERIAL NUMBER,MFR MDL CODE,ENG MFR MDL,YEAR MFR,TYPE REGISTRANT,NAME,STREET,STREET2,CITY,STATE,ZIP CODE,REGION,COUNTY,COUNTRY,LAST ACTION DATE,CERT ISSUE DATE,CERTIFICATION,TYPE AIRCRAFT,TYPE ENGINE,STATUS CODE,MODE S CODE,FRACT OWNER,AIR WORTH DATE,OTHER NAMES(1),OTHER NAMES(2),OTHER NAMES(3),OTHER NAMES(4),OTHER NAMES(5),EXPIRATION DATE,UNIQUE ID,KIT MFR, KIT MODEL,MODE S CODE HEX,\n" +
+	"100AB,525-0123     ,2072738,52036,2008,3,ACME HOLDINGS LLC                                 ,100 NORTH MAIN STREET             ,SUITE 4  ,DALLAS        ,TX,752011234 ,2,113,US,20240115,20230301,1T,5,5,V ,51442541,N,20080610,,,,,,20300331,00012345,,,A00001,\n" +
+	"22XY ,1234         ,1152020,17003,1977,1,SMITH JOHN Q                                      ,5 ELM ST                          ,         ,TULSA         ,OK,74103     ,3,143,US,20220310,20220301,1N,4,1,V ,50000000,,19770101,,,,,,20290331,00023456,,,A00002,\n" +
+	"     ,XX           ,       ,     ,    , ,NO NUMBER                                         ,                                  ,         ,              ,  ,          , ,   ,  ,        ,        ,  , , ,  ,        , ,        ,,,,,,        ,        ,,,      ,\n"
+
+const faaAcftRefTXT = "CODE,MFR,MODEL,TYPE-ACFT,TYPE-ENG,AC-CAT,BUILD-CERT-IND,NO-ENG,NO-SEATS,AC-WEIGHT,SPEED,TC-DATA-SHEET,TC-DATA-HOLDER,\n" +
+	"2072738,CESSNA                        ,525B               ,5,5,1,0,02,009,CLASS 2,0000,A1WI,TEXTRON AVIATION INC,\n" +
+	"1152020,CESSNA                        ,172N               ,4,1,1,0,01,004,CLASS 1,0122,3A12,TEXTRON AVIATION INC,\n"
+
+func TestFAARegistry_Metadata(t *testing.T) {
+	d := &FAARegistry{}
+	assert.Equal(t, "faa_registry", d.Name())
+	assert.Equal(t, "fed_data.faa_aircraft", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+}
+
+func TestFAARegistry_ShouldRun(t *testing.T) {
+	d := &FAARegistry{}
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	assert.True(t, d.ShouldRun(now, nil))
+	thisMonth := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	assert.False(t, d.ShouldRun(now, &thisMonth))
+}
+
+func TestParseFAAAircraftRef(t *testing.T) {
+	refs, err := parseFAAAircraftRef(strings.NewReader(faaAcftRefTXT))
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+
+	jet := refs["2072738"]
+	assert.Equal(t, "CESSNA", jet.mfr)
+	assert.Equal(t, "525B", jet.model)
+	require.NotNil(t, jet.numEngines)
+	assert.Equal(t, 2, *jet.numEngines)
+	assert.Equal(t, 9, *jet.numSeats)
+	assert.Equal(t, "CLASS 2", jet.weight)
+
+	_, err = parseFAAAircraftRef(strings.NewReader("FOO,BAR\n1,2\n"))
+	assert.Error(t, err)
+}
+
+func TestFAARow(t *testing.T) {
+	lines := strings.Split(faaMasterTXT, "\n")
+	reader := newLenientCSVReader(strings.NewReader(faaMasterTXT))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	colIdx := mapColumnsTrimBOM(header)
+	refs, err := parseFAAAircraftRef(strings.NewReader(faaAcftRefTXT))
+	require.NoError(t, err)
+
+	record, err := newLenientCSVReader(strings.NewReader(lines[1])).Read()
+	require.NoError(t, err)
+	row := faaRow(record, colIdx, refs)
+	require.Len(t, row, len(faaColumns))
+
+	col := func(name string) any {
+		for i, c := range faaColumns {
+			if c == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("unknown column %s", name)
+		return nil
+	}
+	assert.Equal(t, "100AB", col("n_number"))
+	assert.Equal(t, "525-0123", col("serial_number"))
+	assert.Equal(t, "CESSNA", col("aircraft_mfr"))
+	assert.Equal(t, "525B", col("aircraft_model"))
+	assert.Equal(t, int16(2008), col("year_mfr"))
+	assert.Equal(t, 5, col("type_engine"))
+	assert.Equal(t, int16(3), col("registrant_type"))
+	assert.Equal(t, "ACME HOLDINGS", col("name_norm"))
+	assert.Equal(t, "100 N MAIN ST", col("street_norm"))
+	assert.Equal(t, "75201", col("zip5"))
+	assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), col("cert_issue_date"))
+	assert.Equal(t, false, col("fractional_owner"))
+	assert.Equal(t, "A00001", col("mode_s_hex"))
+	assert.True(t, faaBusinessRegistrant(col("registrant_type")))
+
+	record, err = newLenientCSVReader(strings.NewReader(lines[2])).Read()
+	require.NoError(t, err)
+	individual := faaRow(record, colIdx, refs)
+	assert.False(t, faaBusinessRegistrant(individual[faaRegistrantTypeCol]))
+
+	record, err = newLenientCSVReader(strings.NewReader(lines[3])).Read()
+	require.NoError(t, err)
+	assert.Nil(t, faaRow(record, colIdx, refs))
+}
+
+func TestParseFAADate(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), parseFAADate("20240115"))
+	assert.Nil(t, parseFAADate("        "))
+	assert.Nil(t, parseFAADate("2024-01-15"))
+}
+
+func TestFAARegistry_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	zipPath := createTestZipMulti(t, t.TempDir(), "faa.zip", map[string]string{
+		"MASTER.txt":  faaMasterTXT,
+		"ACFTREF.txt": faaAcftRefTXT,
+		"DEREG.txt":   "N-NUMBER\n",
+	})
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://faa.test", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { copyTestFixture(t, zipPath, path) }).
+		Return(int64(1000), nil)
+
+	expectBulkUpsert(pool, "fed_data.faa_aircraft", faaUpsertColumns, 2)
+	pool.ExpectExec(`DELETE FROM fed_data.faa_aircraft WHERE updated_at < \$1`).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	res, err := (&FAARegistry{url: "https://faa.test"}).Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["business_registrants"])
+	assert.Equal(t, 2, res.Metadata["aircraft_models"])
+	assert.Equal(t, int64(3), res.Metadata["deregistered"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFAARegistry_Sync_EmptyMasterKeepsAircraft(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	header, _, _ := strings.Cut(faaMasterTXT, "\n")
+	zipPath := createTestZip(t, t.TempDir(), "faa.zip", "MASTER.txt", header+"\n")
+	f := fetchermocks.NewMockFetcher(t)
+	mockDownloadToFile(t, f, zipPath)
+
+	// No upsert and, above all, no prune.
+	_, err = (&FAARegistry{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MASTER.txt has no aircraft")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFAARegistry_Sync_MissingMaster(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	zipPath := createTestZip(t, t.TempDir(), "faa.zip", "ACFTREF.txt", faaAcftRefTXT)
+	f := fetchermocks.NewMockFetcher(t)
+	mockDownloadToFile(t, f, zipPath)
+
+	_, err = (&FAARegistry{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MASTER.txt not found")
+}
+
+func TestFAARegistry_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.faa_aircraft_matches").
+		WillReturnResult(pgxmock.NewResult("DELETE", 10))
+	for range faaMatchPasses() {
+		pool.ExpectExec("INSERT INTO fed_data.faa_aircraft_matches").
+			WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	}
+	pool.ExpectCommit()
+
+	require.NoError(t, (&FAARegistry{}).PostSync(context.Background(), pool, &SyncResult{}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFAARegistry_PostSync_PassError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.faa_aircraft_matches").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("INSERT INTO fed_data.faa_aircraft_matches").
+		WillReturnError(assert.AnError)
+	pool.ExpectRollback()
+
+	err = (&FAARegistry{}).PostSync(context.Background(), pool, &SyncResult{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "company_name_zip")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFAAMatchPasses(t *testing.T) {
+	passes := faaMatchPasses()
+	require.Len(t, passes, 4)
+	for _, p := range passes {
+		assert.Contains(t, p.sql, "a.registrant_type IN (2, 3, 7, 8)", p.name)
+		assert.Contains(t, p.sql, "ON CONFLICT (n_number, entity_type, entity_key) DO NOTHING", p.name)
+	}
+	assert.Contains(t, passes[0].sql, "'company', c.id::text, 'name_zip', 0.95")
+	assert.Contains(t, passes[3].sql, "'adv_firm', f.crd_number::text, 'name_state', 0.80")
+	assert.Contains(t, passes[1].sql, "fed_data.adv_firms f")
+}
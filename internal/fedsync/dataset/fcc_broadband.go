@@ -0,0 +1,482 @@
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/transform"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	fccBDCBaseURL   = "https://broadbandmap.fcc.gov/api/public/map"
+	fccBDCBatchSize = 5000
+
+	// fccFiberTech is the BDC technology code for fiber to the premises.
+	fccFiberTech = "50"
+)
+
+var fccBroadbandCols = []string{
+	"as_of_date", "geo_level", "geoid", "state_fips", "county_fips",
+	"locations", "locations_25_3", "locations_100_20", "locations_1000_100",
+	"fiber_locations", "providers", "max_download", "max_upload", "pct_100_20",
+}
+
+var fccBroadbandConflictKeys = []string{"as_of_date", "geo_level", "geoid"}
+
+// Per-location service flags.
+const (
+	fccServed25 uint8 = 1 << iota
+	fccServed100
+	fccServedGig
+	fccServedFiber
+)
+
+// FCCBroadband syncs FCC Broadband Data Collection fixed availability. The
+// per-location, per-provider state CSVs are aggregated to census blocks and
+// counties: broadband-serviceable locations with any fixed offer, locations
+// reaching 25/3 and 100/20 Mbps (low latency) and 1000/100 Mbps, fiber
+// coverage, and distinct providers.
+//
+// The name differs from the fcc_broadband geo scraper, which loads raw
+// coverage points into geo.broadband_coverage, so the two keep separate
+// sync_log histories.
+type FCCBroadband struct {
+	cfg        *config.Config
+	baseURL    string       // override for testing
+	httpClient *http.Client // override for testing
+}
+
+// Name implements Dataset.
+func (d *FCCBroadband) Name() string { return "fcc_bdc" }
+
+// Table implements Dataset.
+func (d *FCCBroadband) Table() string { return "fed_data.fcc_broadband" }
+
+// Phase implements Dataset.
+func (d *FCCBroadband) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *FCCBroadband) Cadence() Cadence { return Quarterly }
+
+// ShouldRun implements Dataset. BDC publishes June and December snapshots
+// about six months later, with corrected re-releases in between.
+func (d *FCCBroadband) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return QuarterlyWithLag(now, lastSync, 1)
+}
+
+// fccBDCResponse is the envelope returned by the BDC public API.
+type fccBDCResponse[T any] struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Data    []T    `json:"data"`
+}
+
+type fccAsOfDate struct {
+	DataType string `json:"data_type"`
+	AsOfDate string `json:"as_of_date"`
+}
+
+type fccAvailabilityFile struct {
+	FileID         json.Number `json:"file_id"`
+	TechnologyCode string      `json:"technology_code"`
+	StateFIPS      string      `json:"state_fips"`
+	FileName       string      `json:"file_name"`
+}
+
+// fccBlockAgg accumulates one census block. Locations map location_id to
+// fccServed* flags so a location offered by several providers or
+// technologies is counted once.
+type fccBlockAgg struct {
+	locations map[string]uint8
+	providers map[string]struct{}
+	maxDown   float64
+	maxUp     float64
+}
+
+// Sync downloads the latest fixed broadband availability files for each
+// configured state and upserts block and county aggregates.
+func (d *FCCBroadband) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || d.cfg.Fedsync.FCCBDCKey == "" || d.cfg.Fedsync.FCCBDCUser == "" {
+		return nil, eris.New("fcc_bdc: FCC BDC credentials required (fedsync.fcc_bdc_username, fedsync.fcc_bdc_key)")
+	}
+	states, err := d.states()
+	if err != nil {
+		return nil, err
+	}
+
+	asOf, err := d.latestAsOfDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if asOf == "" {
+		log.Warn("fcc_bdc: no availability as-of date published")
+		return &SyncResult{RowsSynced: 0}, nil
+	}
+
+	files, err := d.listFiles(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	byState := make(map[string][]fccAvailabilityFile)
+	for _, file := range files {
+		if states[file.StateFIPS] {
+			byState[file.StateFIPS] = append(byState[file.StateFIPS], file)
+		}
+	}
+	stateList := make([]string, 0, len(byState))
+	for st := range byState {
+		stateList = append(stateList, st)
+	}
+	sort.Strings(stateList)
+	log.Info("syncing FCC BDC availability", zap.String("as_of_date", asOf), zap.Int("states", len(stateList)))
+
+	// States are processed one at a time; the largest hold millions of
+	// locations in memory while aggregating.
+	var total int64
+	for _, st := range stateList {
+		n, err := d.syncState(ctx, pool, tempDir, asOf, st, byState[st])
+		if err != nil {
+			return nil, err
+		}
+		total += n
+		log.Info("fcc_bdc state synced", zap.String("state_fips", st), zap.Int64("rows", n))
+	}
+
+	log.Info("fcc_bdc sync complete", zap.Int64("rows", total))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"as_of_date": asOf,
+			"states":     len(stateList),
+			"files":      len(files),
+		},
+	}, nil
+}
+
+// states returns the set of state FIPS codes to sync: configured
+// abbreviations, or every state, DC, and Puerto Rico when none are set.
+func (d *FCCBroadband) states() (map[string]bool, error) {
+	out := make(map[string]bool)
+	if len(d.cfg.Fedsync.FCCBroadband.States) == 0 {
+		for abbr, fips := range transform.StateAbbrToFIPS {
+			if abbr != "VI" {
+				out[fips] = true
+			}
+		}
+		return out, nil
+	}
+	for _, abbr := range d.cfg.Fedsync.FCCBroadband.States {
+		fips, ok := transform.StateAbbrToFIPS[strings.ToUpper(strings.TrimSpace(abbr))]
+		if !ok {
+			return nil, eris.Errorf("fcc_bdc: unknown state %q", abbr)
+		}
+		out[fips] = true
+	}
+	return out, nil
+}
+
+// latestAsOfDate returns the newest availability snapshot date.
+func (d *FCCBroadband) latestAsOfDate(ctx context.Context) (string, error) {
+	var resp fccBDCResponse[fccAsOfDate]
+	if err := d.getJSON(ctx, "/listAsOfDates", &resp); err != nil {
+		return "", eris.Wrap(err, "fcc_bdc: list as-of dates")
+	}
+	var latest string
+	for _, dt := range resp.Data {
+		if dt.DataType == "availability" && dt.AsOfDate > latest {
+			latest = dt.AsOfDate
+		}
+	}
+	if len(latest) > 10 {
+		latest = latest[:10] // "2024-06-30T00:00:00" -> "2024-06-30"
+	}
+	return latest, nil
+}
+
+// listFiles returns the state-level fixed broadband files for asOf.
+func (d *FCCBroadband) listFiles(ctx context.Context, asOf string) ([]fccAvailabilityFile, error) {
+	var resp fccBDCResponse[fccAvailabilityFile]
+	path := "/downloads/listAvailabilityData/" + asOf + "?category=State&subcategory=Fixed%20Broadband"
+	if err := d.getJSON(ctx, path, &resp); err != nil {
+		return nil, eris.Wrapf(err, "fcc_bdc: list files %s", asOf)
+	}
+	return resp.Data, nil
+}
+
+func (d *FCCBroadband) syncState(ctx context.Context, pool db.Pool, tempDir, asOf, state string, files []fccAvailabilityFile) (int64, error) {
+	blocks := make(map[string]*fccBlockAgg)
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		zipPath := filepath.Join(tempDir, fmt.Sprintf("fcc_bdc_%s.zip", file.FileID))
+		if err := d.download(ctx, "/downloads/downloadFile/availability/"+file.FileID.String(), zipPath); err != nil {
+			return 0, eris.Wrapf(err, "fcc_bdc: download %s", file.FileName)
+		}
+		err := d.aggregateZIP(zipPath, filepath.Join(tempDir, "fcc_bdc_"+file.FileID.String()), blocks)
+		_ = os.Remove(zipPath)
+		if err != nil {
+			return 0, eris.Wrapf(err, "fcc_bdc: parse %s", file.FileName)
+		}
+	}
+
+	rows := fccBroadbandRows(asOf, blocks)
+	var total int64
+	for start := 0; start < len(rows); start += fccBDCBatchSize {
+		end := min(start+fccBDCBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      fccBroadbandCols,
+			ConflictKeys: fccBroadbandConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrapf(err, "fcc_bdc: upsert %s", state)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// aggregateZIP extracts a BDC file archive and adds every CSV inside to
+// blocks.
+func (d *FCCBroadband) aggregateZIP(zipPath, extractDir string, blocks map[string]*fccBlockAgg) error {
+	files, err := fetcher.ExtractZIP(zipPath, extractDir)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(extractDir) //nolint:errcheck
+
+	for _, fp := range files {
+		if !strings.HasSuffix(strings.ToLower(fp), ".csv") {
+			continue
+		}
+		file, err := os.Open(fp) // #nosec G304 -- path from controlled temp dir
+		if err != nil {
+			return err
+		}
+		err = aggregateFCCAvailability(file, blocks)
+		_ = file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregateFCCAvailability adds a BDC fixed availability CSV (one row per
+// location, provider, and technology) into per-block aggregates.
+func aggregateFCCAvailability(r io.Reader, blocks map[string]*fccBlockAgg) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	colIdx := mapColumns(header)
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		block := getCol(row, colIdx, "block_geoid")
+		locationID := getCol(row, colIdx, "location_id")
+		if len(block) != 15 || locationID == "" {
+			continue
+		}
+		down, _ := strconv.ParseFloat(getCol(row, colIdx, "max_advertised_download_speed"), 64)
+		up, _ := strconv.ParseFloat(getCol(row, colIdx, "max_advertised_upload_speed"), 64)
+		lowLatency := getCol(row, colIdx, "low_latency") == "1"
+
+		var flags uint8
+		if lowLatency && down >= 25 && up >= 3 {
+			flags |= fccServed25
+		}
+		if lowLatency && down >= 100 && up >= 20 {
+			flags |= fccServed100
+		}
+		if down >= 1000 && up >= 100 {
+			flags |= fccServedGig
+		}
+		if getCol(row, colIdx, "technology") == fccFiberTech {
+			flags |= fccServedFiber
+		}
+
+		agg := blocks[block]
+		if agg == nil {
+			agg = &fccBlockAgg{locations: make(map[string]uint8), providers: make(map[string]struct{})}
+			blocks[block] = agg
+		}
+		agg.locations[strings.Clone(locationID)] |= flags
+		if p := getCol(row, colIdx, "provider_id"); p != "" {
+			if _, ok := agg.providers[p]; !ok {
+				agg.providers[strings.Clone(p)] = struct{}{}
+			}
+		}
+		agg.maxDown = max(agg.maxDown, down)
+		agg.maxUp = max(agg.maxUp, up)
+	}
+}
+
+// fccAreaSummary holds the stored measures for one block or county.
+type fccAreaSummary struct {
+	locations, n25, n100, nGig, nFiber int
+	providers                          map[string]struct{}
+	maxDown, maxUp                     float64
+}
+
+func (s *fccAreaSummary) add(o *fccAreaSummary) {
+	s.locations += o.locations
+	s.n25 += o.n25
+	s.n100 += o.n100
+	s.nGig += o.nGig
+	s.nFiber += o.nFiber
+	for p := range o.providers {
+		s.providers[p] = struct{}{}
+	}
+	s.maxDown = max(s.maxDown, o.maxDown)
+	s.maxUp = max(s.maxUp, o.maxUp)
+}
+
+func (s *fccAreaSummary) row(asOf, level, geoid string) []any {
+	var pct any
+	if s.locations > 0 {
+		pct = float64(s.n100) / float64(s.locations)
+	}
+	return []any{
+		asOf, level, geoid, geoid[:2], geoid[:5],
+		s.locations, s.n25, s.n100, s.nGig, s.nFiber, len(s.providers),
+		s.maxDown, s.maxUp, pct,
+	}
+}
+
+// summarize counts a block's locations by service flag.
+func (agg *fccBlockAgg) summarize() *fccAreaSummary {
+	s := &fccAreaSummary{
+		locations: len(agg.locations),
+		providers: agg.providers,
+		maxDown:   agg.maxDown,
+		maxUp:     agg.maxUp,
+	}
+	for _, flags := range agg.locations {
+		if flags&fccServed25 != 0 {
+			s.n25++
+		}
+		if flags&fccServed100 != 0 {
+			s.n100++
+		}
+		if flags&fccServedGig != 0 {
+			s.nGig++
+		}
+		if flags&fccServedFiber != 0 {
+			s.nFiber++
+		}
+	}
+	return s
+}
+
+// fccBroadbandRows converts block aggregates to block rows plus one row per
+// county. A location lies in exactly one block, so county location counts
+// are sums; providers are a distinct union.
+func fccBroadbandRows(asOf string, blocks map[string]*fccBlockAgg) [][]any {
+	counties := make(map[string]*fccAreaSummary)
+	var rows [][]any
+	for geoid, agg := range blocks {
+		s := agg.summarize()
+		rows = append(rows, s.row(asOf, "block", geoid))
+
+		c := counties[geoid[:5]]
+		if c == nil {
+			c = &fccAreaSummary{providers: make(map[string]struct{})}
+			counties[geoid[:5]] = c
+		}
+		c.add(s)
+	}
+	for geoid, c := range counties {
+		rows = append(rows, c.row(asOf, "county", geoid))
+	}
+	return rows
+}
+
+func (d *FCCBroadband) client() *http.Client {
+	if d.httpClient != nil {
+		return d.httpClient
+	}
+	return http.DefaultClient
+}
+
+// request issues an authenticated GET against the BDC public API, which
+// requires username and hash_value headers the shared fetcher cannot set.
+func (d *FCCBroadband) request(ctx context.Context, path string) (*http.Response, error) {
+	base := d.baseURL
+	if base == "" {
+		base = fccBDCBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "create request")
+	}
+	req.Header.Set("username", d.cfg.Fedsync.FCCBDCUser)
+	req.Header.Set("hash_value", d.cfg.Fedsync.FCCBDCKey)
+	req.Header.Set("User-Agent", "research-cli/1.0")
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, eris.Errorf("unexpected status %d from %s", resp.StatusCode, path)
+	}
+	return resp, nil
+}
+
+func (d *FCCBroadband) getJSON(ctx context.Context, path string, v any) error {
+	resp, err := d.request(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (d *FCCBroadband) download(ctx context.Context, path, dest string) error {
+	resp, err := d.request(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	out, err := os.Create(dest) // #nosec G304 -- path from controlled temp dir
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		_ = out.Close()
+		return err
+	}
+	// Close flushes the file; a failure here means a truncated download.
+	return out.Close()
+}
@@ -0,0 +1,135 @@
+package dataset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+const fccTestCSV = `frn,provider_id,brand_name,location_id,technology,max_advertised_download_speed,max_advertised_upload_speed,low_latency,business_residential_code,state_usps,block_geoid,h3_res8_id
+0001,130001,FiberCo,1001,50,1000,1000,1,X,TX,484530001001000,88
+0002,130002,CableCo,1001,40,300,20,1,X,TX,484530001001000,88
+0002,130002,CableCo,1002,40,100,10,1,R,TX,484530001001000,88
+0003,130003,SatCo,1003,60,100,20,0,X,TX,484530002002000,88
+0003,130003,SatCo,1004,60,100,20,0,X,TX,bad,88
+`
+
+func fccTestConfig() *config.Config {
+	return &config.Config{Fedsync: config.FedsyncConfig{
+		FCCBDCUser:   "user@example.com",
+		FCCBDCKey:    "token",
+		FCCBroadband: config.BroadbandConfig{States: []string{"tx"}},
+	}}
+}
+
+func TestFCCBroadband_Metadata(t *testing.T) {
+	d := &FCCBroadband{}
+	assert.Equal(t, "fcc_bdc", d.Name())
+	assert.Equal(t, "fed_data.fcc_broadband", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Quarterly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestFCCBroadband_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	zipPath := filepath.Join(t.TempDir(), "bdc.zip")
+	createMultiZIP(t, zipPath, map[string][]byte{"bdc_48_fixed.csv": []byte(fccTestCSV)})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("username") != "user@example.com" || r.Header.Get("hash_value") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/listAsOfDates":
+			_, _ = w.Write([]byte(`{"status":"successful","data":[
+				{"data_type":"availability","as_of_date":"2024-06-30T00:00:00"},
+				{"data_type":"availability","as_of_date":"2023-12-31T00:00:00"},
+				{"data_type":"challenge","as_of_date":"2025-01-31T00:00:00"}]}`))
+		case r.URL.Path == "/downloads/listAvailabilityData/2024-06-30":
+			assert.Equal(t, "Fixed Broadband", r.URL.Query().Get("subcategory"))
+			_, _ = w.Write([]byte(`{"status":"successful","data":[
+				{"file_id":101,"state_fips":"48","technology_code":"50","file_name":"bdc_48_fiber"},
+				{"file_id":202,"state_fips":"06","technology_code":"50","file_name":"bdc_06_fiber"}]}`))
+		case r.URL.Path == "/downloads/downloadFile/availability/101":
+			http.ServeFile(w, r, zipPath)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	// Two blocks plus their shared county.
+	expectBulkUpsert(pool, "fed_data.fcc_broadband", fccBroadbandCols, 3)
+
+	d := &FCCBroadband{cfg: fccTestConfig(), baseURL: srv.URL}
+	res, err := d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, "2024-06-30", res.Metadata["as_of_date"])
+	assert.Equal(t, 1, res.Metadata["states"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFCCBroadband_Sync_Errors(t *testing.T) {
+	_, err := (&FCCBroadband{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials required")
+
+	cfg := fccTestConfig()
+	cfg.Fedsync.FCCBroadband.States = []string{"ZZ"}
+	_, err = (&FCCBroadband{cfg: cfg}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown state "ZZ"`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	_, err = (&FCCBroadband{cfg: fccTestConfig(), baseURL: srv.URL}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fcc_bdc: list as-of dates")
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestFCCBroadbandRows(t *testing.T) {
+	blocks := make(map[string]*fccBlockAgg)
+	require.NoError(t, aggregateFCCAvailability(strings.NewReader(fccTestCSV), blocks))
+	require.Len(t, blocks, 2)
+
+	rows := fccBroadbandRows("2024-06-30", blocks)
+	byGeo := make(map[string][]any)
+	for _, r := range rows {
+		byGeo[r[2].(string)] = r
+	}
+	require.Len(t, byGeo, 3)
+
+	// Location 1001 has fiber and cable offers but counts once.
+	block := byGeo["484530001001000"]
+	assert.Equal(t, "block", block[1])
+	assert.Equal(t, "48453", block[4])
+	assert.Equal(t, []any{2, 2, 1, 1, 1, 2}, block[5:11]) // locations, 25/3, 100/20, gig, fiber, providers
+	assert.Equal(t, 1000.0, block[11])
+	assert.Equal(t, 0.5, block[13])
+
+	// Satellite without low latency does not count as served at 25/3.
+	county := byGeo["48453"]
+	assert.Equal(t, "county", county[1])
+	assert.Equal(t, []any{3, 2, 1, 1, 1, 3}, county[5:11])
+	assert.InDelta(t, 1.0/3, county[13], 1e-9)
+}
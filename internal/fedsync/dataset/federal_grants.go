@@ -0,0 +1,446 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	grantsSearchURL = "https://api.usaspending.gov/api/v2/search/spending_by_award/"
+	grantsPageSize  = 100 // spending_by_award maximum
+
+	// grantsLookbackDays bounds the first sync; later syncs resume from the
+	// newest stored last_modified_date.
+	grantsLookbackDays = 35
+
+	facAPIURL   = "https://api.fac.gov"
+	facPageSize = 5000
+
+	// facLookbackYears bounds the first single audit sync by FAC acceptance
+	// date; later syncs resume from the newest stored acceptance.
+	facLookbackYears = 3
+)
+
+// grantAwardTypes are the USAspending assistance type codes for grants:
+// block (02), formula (03), project (04), and cooperative agreement (05).
+var grantAwardTypes = []string{"02", "03", "04", "05"}
+
+// grantSearchFields are the spending_by_award result fields requested.
+var grantSearchFields = []string{
+	"Award ID", "Recipient Name", "Recipient UEI", "Recipient Location",
+	"Award Amount", "Total Outlays", "Description", "Award Type",
+	"Start Date", "End Date", "Last Modified Date",
+	"Awarding Agency", "Awarding Sub Agency", "Funding Agency", "Assistance Listings",
+}
+
+// grantColumns defines the grants upsert columns.
+var grantColumns = []string{
+	"award_id", "fain", "award_type",
+	"recipient_uei", "recipient_name", "recipient_city", "recipient_state", "recipient_zip",
+	"awarding_agency", "awarding_sub_agency", "funding_agency",
+	"cfda_number", "cfda_title",
+	"award_amount", "total_outlays",
+	"start_date", "end_date", "last_modified_date",
+	"description", "updated_at",
+}
+
+// singleAuditColumns defines the single_audits upsert columns.
+var singleAuditColumns = []string{
+	"report_id", "auditee_uei", "auditee_ein", "auditee_name",
+	"auditee_city", "auditee_state", "auditee_zip", "entity_type",
+	"audit_year", "fy_end_date", "fac_accepted_date",
+	"auditor_firm_name", "auditor_ein", "auditor_city", "auditor_state",
+	"total_amount_expended", "is_going_concern", "is_material_weakness", "is_low_risk_auditee",
+	"updated_at",
+}
+
+// facGeneralFields are the FAC general view columns selected.
+var facGeneralFields = []string{
+	"report_id", "auditee_uei", "auditee_ein", "auditee_name",
+	"auditee_city", "auditee_state", "auditee_zip", "entity_type",
+	"audit_year", "fy_end_date", "fac_accepted_date",
+	"auditor_firm_name", "auditor_ein", "auditor_city", "auditor_state",
+	"total_amount_expended", "is_going_concern_included",
+	"is_internal_control_material_weakness_disclosed", "is_low_risk_auditee",
+}
+
+// FederalGrants syncs federal grant awards from the USAspending award
+// search API into fed_data.grants and Single Audit submissions from the
+// Federal Audit Clearinghouse (FAC) into fed_data.single_audits. Grant
+// recipients and auditees join on UEI; single audits also name the audit
+// firm, so the pair maps grant-dependent nonprofits to their auditors.
+// Single audits require an api.data.gov key (fedsync.fac_api_key) and are
+// skipped without one.
+type FederalGrants struct {
+	cfg *config.Config
+
+	searchURL  string       // override for testing
+	httpClient *http.Client // override for testing
+	facURL     string       // override for testing
+}
+
+// Name implements Dataset.
+func (d *FederalGrants) Name() string { return "federal_grants" }
+
+// Table implements Dataset.
+func (d *FederalGrants) Table() string { return "fed_data.grants" }
+
+// Phase implements Dataset.
+func (d *FederalGrants) Phase() Phase { return Phase1 }
+
+// Cadence implements Dataset.
+func (d *FederalGrants) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *FederalGrants) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// grantSearchRequest is the spending_by_award POST body.
+type grantSearchRequest struct {
+	Filters grantSearchFilters `json:"filters"`
+	Fields  []string           `json:"fields"`
+	Page    int                `json:"page"`
+	Limit   int                `json:"limit"`
+	Sort    string             `json:"sort"`
+	Order   string             `json:"order"`
+}
+
+// grantSearchFilters restricts the search to grants modified in a window.
+type grantSearchFilters struct {
+	AwardTypeCodes []string          `json:"award_type_codes"`
+	TimePeriod     []grantTimePeriod `json:"time_period"`
+}
+
+// grantTimePeriod is a spending_by_award date window.
+type grantTimePeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	DateType  string `json:"date_type"`
+}
+
+// grantSearchResponse is one page of spending_by_award results. Result keys
+// are the requested display field names, so results decode as maps.
+type grantSearchResponse struct {
+	Results      []map[string]any `json:"results"`
+	PageMetadata struct {
+		HasNext bool `json:"hasNext"`
+	} `json:"page_metadata"`
+}
+
+// Sync loads grants modified since the last sync, then single audits
+// accepted since the last sync.
+func (d *FederalGrants) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	since, err := d.resumeDate(ctx, pool, "SELECT MAX(last_modified_date) FROM fed_data.grants",
+		time.Now().UTC().AddDate(0, 0, -grantsLookbackDays))
+	if err != nil {
+		return nil, eris.Wrap(err, "federal_grants: latest grant")
+	}
+	grants, err := d.syncGrants(ctx, pool, since)
+	if err != nil {
+		return nil, eris.Wrap(err, "federal_grants: grants")
+	}
+	log.Info("grants synced", zap.Time("since", since), zap.Int64("rows", grants))
+
+	meta := map[string]any{"grants": grants, "grants_since": since.Format("2006-01-02")}
+	if d.cfg == nil || d.cfg.Fedsync.FACKey == "" {
+		log.Warn("single audits skipped: FAC API key not set (fedsync.fac_api_key)")
+		meta["single_audits"] = "skipped"
+		return &SyncResult{RowsSynced: grants, Metadata: meta}, nil
+	}
+
+	since, err = d.resumeDate(ctx, pool, "SELECT MAX(fac_accepted_date) FROM fed_data.single_audits",
+		time.Now().UTC().AddDate(-facLookbackYears, 0, 0))
+	if err != nil {
+		return nil, eris.Wrap(err, "federal_grants: latest single audit")
+	}
+	audits, err := d.syncSingleAudits(ctx, pool, f, since)
+	if err != nil {
+		return nil, eris.Wrap(err, "federal_grants: single audits")
+	}
+	log.Info("single audits synced", zap.Time("since", since), zap.Int64("rows", audits))
+
+	meta["single_audits"] = audits
+	meta["single_audits_since"] = since.Format("2006-01-02")
+	return &SyncResult{RowsSynced: grants + audits, Metadata: meta}, nil
+}
+
+// resumeDate returns one day before the date query yields, or def when the
+// table is empty.
+func (d *FederalGrants) resumeDate(ctx context.Context, pool db.Pool, query string, def time.Time) (time.Time, error) {
+	var latest *time.Time
+	if err := pool.QueryRow(ctx, query).Scan(&latest); err != nil {
+		return time.Time{}, err
+	}
+	if latest == nil {
+		return def.Truncate(24 * time.Hour), nil
+	}
+	return latest.AddDate(0, 0, -1), nil
+}
+
+// syncGrants pages through grants last modified on or after since.
+func (d *FederalGrants) syncGrants(ctx context.Context, pool db.Pool, since time.Time) (int64, error) {
+	now := time.Now().UTC()
+	req := grantSearchRequest{
+		Filters: grantSearchFilters{
+			AwardTypeCodes: grantAwardTypes,
+			TimePeriod: []grantTimePeriod{{
+				StartDate: since.Format("2006-01-02"),
+				EndDate:   now.Format("2006-01-02"),
+				DateType:  "last_modified_date",
+			}},
+		},
+		Fields: grantSearchFields,
+		Limit:  grantsPageSize,
+		Sort:   "Last Modified Date",
+		Order:  "asc",
+	}
+
+	var total int64
+	for req.Page = 1; ; req.Page++ {
+		res, err := d.searchGrants(ctx, req)
+		if err != nil {
+			return total, eris.Wrapf(err, "page %d", req.Page)
+		}
+		if rows := grantRows(res.Results, now); len(rows) > 0 {
+			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table:        "fed_data.grants",
+				Columns:      grantColumns,
+				ConflictKeys: []string{"award_id"},
+			}, rows)
+			if err != nil {
+				return total, eris.Wrap(err, "upsert")
+			}
+			total += n
+		}
+		if !res.PageMetadata.HasNext || len(res.Results) == 0 {
+			return total, nil
+		}
+	}
+}
+
+// searchGrants POSTs one spending_by_award request.
+func (d *FederalGrants) searchGrants(ctx context.Context, body grantSearchRequest) (*grantSearchResponse, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, eris.Wrap(err, "marshal request")
+	}
+	u := d.searchURL
+	if u == "" {
+		u = grantsSearchURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(buf))
+	if err != nil {
+		return nil, eris.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := d.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "POST award search")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, eris.Errorf("award search returned status %d", resp.StatusCode)
+	}
+
+	var res grantSearchResponse
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return nil, eris.Wrap(err, "decode response")
+	}
+	return &res, nil
+}
+
+// grantRows maps spending_by_award results to grantColumns. Results are
+// keyed by generated_internal_id, the award's stable USAspending ID.
+func grantRows(results []map[string]any, now time.Time) [][]any {
+	rows := make([][]any, 0, len(results))
+	seen := make(map[string]bool, len(results))
+	for _, r := range results {
+		id := eiaString(r, "generated_internal_id")
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		loc, _ := r["Recipient Location"].(map[string]any)
+		var cfdaNumber, cfdaTitle string
+		if listings, _ := r["Assistance Listings"].([]any); len(listings) > 0 {
+			if l, ok := listings[0].(map[string]any); ok {
+				cfdaNumber = eiaString(l, "cfda_number")
+				cfdaTitle = eiaString(l, "cfda_program_title")
+			}
+		}
+
+		rows = append(rows, []any{
+			id,
+			nilIfEmpty(eiaString(r, "Award ID")),
+			nilIfEmpty(eiaString(r, "Award Type")),
+			nilIfEmpty(strings.ToUpper(eiaString(r, "Recipient UEI"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "Recipient Name"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(loc, "city_name"))),
+			nilIfEmpty(fitLen(eiaString(loc, "state_code"), 2)),
+			nilIfEmpty(fitLen(eiaString(loc, "zip5"), 5)),
+			nilIfEmpty(eiaString(r, "Awarding Agency")),
+			nilIfEmpty(eiaString(r, "Awarding Sub Agency")),
+			nilIfEmpty(eiaString(r, "Funding Agency")),
+			nilIfEmpty(fitLen(cfdaNumber, 10)),
+			nilIfEmpty(sanitizeUTF8(cfdaTitle)),
+			grantAmount(r["Award Amount"]),
+			grantAmount(r["Total Outlays"]),
+			dateOrNil(parseDate(eiaString(r, "Start Date"))),
+			dateOrNil(parseDate(eiaString(r, "End Date"))),
+			dateOrNil(parseDate(eiaString(r, "Last Modified Date"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "Description"))),
+			now,
+		})
+	}
+	return rows
+}
+
+// grantAmount returns a dollar amount, or nil when absent.
+func grantAmount(v any) any {
+	if f, ok := eiaFloat(v); ok {
+		return f
+	}
+	return nil
+}
+
+// syncSingleAudits pages through the FAC general view for audits accepted
+// on or after since.
+func (d *FederalGrants) syncSingleAudits(ctx context.Context, pool db.Pool, f fetcher.Fetcher, since time.Time) (int64, error) {
+	base := d.facURL
+	if base == "" {
+		base = facAPIURL
+	}
+	now := time.Now().UTC()
+
+	var total int64
+	for offset := 0; ; offset += facPageSize {
+		q := url.Values{}
+		q.Set("api_key", d.cfg.Fedsync.FACKey)
+		q.Set("select", strings.Join(facGeneralFields, ","))
+		q.Set("fac_accepted_date", "gte."+since.Format("2006-01-02"))
+		q.Set("order", "report_id")
+		q.Set("limit", strconv.Itoa(facPageSize))
+		q.Set("offset", strconv.Itoa(offset))
+
+		body, err := f.Download(ctx, base+"/general?"+q.Encode())
+		if err != nil {
+			return total, eris.Wrapf(err, "download offset %d", offset)
+		}
+		var recs []map[string]any
+		dec := json.NewDecoder(body)
+		dec.UseNumber()
+		err = dec.Decode(&recs)
+		_ = body.Close()
+		if err != nil {
+			return total, eris.Wrapf(err, "decode offset %d", offset)
+		}
+
+		if rows := singleAuditRows(recs, now); len(rows) > 0 {
+			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table:        "fed_data.single_audits",
+				Columns:      singleAuditColumns,
+				ConflictKeys: []string{"report_id"},
+			}, rows)
+			if err != nil {
+				return total, eris.Wrap(err, "upsert")
+			}
+			total += n
+		}
+		if len(recs) < facPageSize {
+			return total, nil
+		}
+	}
+}
+
+// singleAuditRows maps FAC general records to singleAuditColumns.
+func singleAuditRows(recs []map[string]any, now time.Time) [][]any {
+	rows := make([][]any, 0, len(recs))
+	for _, r := range recs {
+		id := eiaString(r, "report_id")
+		if id == "" {
+			continue
+		}
+		var year any
+		if y, err := strconv.Atoi(eiaString(r, "audit_year")); err == nil && y > 0 {
+			year = y
+		}
+		rows = append(rows, []any{
+			id,
+			nilIfEmpty(strings.ToUpper(eiaString(r, "auditee_uei"))),
+			nilIfEmpty(facEIN(eiaString(r, "auditee_ein"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "auditee_name"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "auditee_city"))),
+			nilIfEmpty(fitLen(strings.ToUpper(eiaString(r, "auditee_state")), 2)),
+			nilIfEmpty(fitLen(eiaString(r, "auditee_zip"), 10)),
+			nilIfEmpty(eiaString(r, "entity_type")),
+			year,
+			dateOrNil(parseDate(eiaString(r, "fy_end_date"))),
+			dateOrNil(parseDate(eiaString(r, "fac_accepted_date"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "auditor_firm_name"))),
+			nilIfEmpty(facEIN(eiaString(r, "auditor_ein"))),
+			nilIfEmpty(sanitizeUTF8(eiaString(r, "auditor_city"))),
+			nilIfEmpty(fitLen(strings.ToUpper(eiaString(r, "auditor_state")), 2)),
+			grantAmount(r["total_amount_expended"]),
+			facYesNo(r["is_going_concern_included"]),
+			facYesNo(r["is_internal_control_material_weakness_disclosed"]),
+			facYesNo(r["is_low_risk_auditee"]),
+			now,
+		})
+	}
+	return rows
+}
+
+// facEIN normalizes an EIN to nine digits, or "" when malformed.
+func facEIN(s string) string {
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) != 9 {
+		return ""
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+	return s
+}
+
+// facYesNo maps FAC "Yes"/"No" flags (or JSON booleans) to a bool, or nil
+// when unanswered.
+func facYesNo(v any) any {
+	switch x := v.(type) {
+	case bool:
+		return x
+	case string:
+		switch strings.ToLower(strings.TrimSpace(x)) {
+		case "yes", "y", "true":
+			return true
+		case "no", "n", "false":
+			return false
+		}
+	}
+	return nil
+}
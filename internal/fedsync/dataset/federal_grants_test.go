@@ -0,0 +1,191 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const grantSearchJSON = `{"results":[
+{"internal_id":1,"generated_internal_id":"ASST_NON_H79SM085001_7522","Award ID":"H79SM085001","Recipient Name":"Palm Beach Community Health Inc","Recipient UEI":"abc123def456","Recipient Location":{"city_name":"West Palm Beach","state_code":"FL","zip5":"33401"},"Award Amount":1250000.5,"Total Outlays":null,"Description":"BEHAVIORAL HEALTH","Award Type":"PROJECT GRANT (B)","Start Date":"2023-09-30","End Date":"2026-09-29","Last Modified Date":"2024-03-02","Awarding Agency":"Department of Health and Human Services","Awarding Sub Agency":"SAMHSA","Funding Agency":"Department of Health and Human Services","Assistance Listings":[{"cfda_number":"93.243","cfda_program_title":"Substance Abuse and Mental Health Services Projects"}]},
+{"internal_id":1,"generated_internal_id":"ASST_NON_H79SM085001_7522","Award ID":"H79SM085001"},
+{"internal_id":2,"generated_internal_id":"","Award ID":"NOID"},
+{"internal_id":3,"generated_internal_id":"ASST_AGG_XX_1234","Award ID":"XX","Award Amount":"500","Recipient Location":null,"Assistance Listings":[]}
+],"page_metadata":{"page":1,"hasNext":false}}`
+
+const facGeneralJSON = `[
+{"report_id":"2023-06-GSAFAC-0000012345","auditee_uei":"ABC123DEF456","auditee_ein":"65-0123456","auditee_name":"Palm Beach Community Health Inc","auditee_city":"West Palm Beach","auditee_state":"FL","auditee_zip":"33401","entity_type":"non-profit","audit_year":"2023","fy_end_date":"2023-06-30","fac_accepted_date":"2024-02-15","auditor_firm_name":"Smith & Jones CPAs","auditor_ein":"591234567","auditor_city":"Miami","auditor_state":"FL","total_amount_expended":3400000,"is_going_concern_included":"No","is_internal_control_material_weakness_disclosed":"Yes","is_low_risk_auditee":""},
+{"report_id":"","auditee_name":"Missing ID"}
+]`
+
+func federalGrantsConfig(facKey string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Fedsync.FACKey = facKey
+	return cfg
+}
+
+func TestFederalGrants_Metadata(t *testing.T) {
+	d := &FederalGrants{}
+	assert.Equal(t, "federal_grants", d.Name())
+	assert.Equal(t, "fed_data.grants", d.Table())
+	assert.Equal(t, Phase1, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestGrantRows(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(grantSearchJSON))
+	dec.UseNumber()
+	var res grantSearchResponse
+	require.NoError(t, dec.Decode(&res))
+
+	now := time.Now()
+	rows := grantRows(res.Results, now)
+	require.Len(t, rows, 2, "duplicate and missing IDs dropped")
+
+	row := rows[0]
+	require.Len(t, row, len(grantColumns))
+	assert.Equal(t, "ASST_NON_H79SM085001_7522", row[0])
+	assert.Equal(t, "H79SM085001", row[1])
+	assert.Equal(t, "PROJECT GRANT (B)", row[2])
+	assert.Equal(t, "ABC123DEF456", row[3])
+	assert.Equal(t, "West Palm Beach", row[5])
+	assert.Equal(t, "FL", row[6])
+	assert.Equal(t, "33401", row[7])
+	assert.Equal(t, "SAMHSA", row[9])
+	assert.Equal(t, "93.243", row[11])
+	assert.Equal(t, 1250000.5, row[13])
+	assert.Nil(t, row[14])
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), row[17])
+
+	row = rows[1]
+	assert.Equal(t, 500.0, row[13], "string amount")
+	assert.Nil(t, row[6])
+	assert.Nil(t, row[11])
+}
+
+func TestSingleAuditRows(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(facGeneralJSON))
+	dec.UseNumber()
+	var recs []map[string]any
+	require.NoError(t, dec.Decode(&recs))
+
+	rows := singleAuditRows(recs, time.Now())
+	require.Len(t, rows, 1)
+	row := rows[0]
+	require.Len(t, row, len(singleAuditColumns))
+	assert.Equal(t, "2023-06-GSAFAC-0000012345", row[0])
+	assert.Equal(t, "650123456", row[2])
+	assert.Equal(t, 2023, row[8])
+	assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), row[10])
+	assert.Equal(t, "Smith & Jones CPAs", row[11])
+	assert.Equal(t, "591234567", row[12])
+	assert.Equal(t, 3400000.0, row[15])
+	assert.Equal(t, false, row[16])
+	assert.Equal(t, true, row[17])
+	assert.Nil(t, row[18])
+}
+
+func TestFACEIN(t *testing.T) {
+	assert.Equal(t, "650123456", facEIN("65-0123456"))
+	assert.Empty(t, facEIN("12345"))
+	assert.Empty(t, facEIN("GSA_MIGRAT"))
+}
+
+// grantSearchServer serves grantSearchJSON and records the request body.
+func grantSearchServer(t *testing.T, got *grantSearchRequest) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(got))
+		_, _ = w.Write([]byte(grantSearchJSON))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFederalGrants_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var req grantSearchRequest
+	srv := grantSearchServer(t, &req)
+
+	pool.ExpectQuery("SELECT MAX\\(last_modified_date\\) FROM fed_data.grants").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+	expectBulkUpsert(pool, "fed_data.grants", grantColumns, 2)
+	latest := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	pool.ExpectQuery("SELECT MAX\\(fac_accepted_date\\) FROM fed_data.single_audits").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(&latest))
+	expectBulkUpsert(pool, "fed_data.single_audits", singleAuditColumns, 1)
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.HasPrefix(u, "https://fac.test/general?") &&
+			strings.Contains(u, "api_key=k") && strings.Contains(u, "fac_accepted_date=gte.2024-01-31")
+	})).Return(io.NopCloser(strings.NewReader(facGeneralJSON)), nil).Once()
+
+	d := &FederalGrants{cfg: federalGrantsConfig("k"), searchURL: srv.URL, facURL: "https://fac.test"}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["single_audits"])
+	assert.Equal(t, "2024-01-31", res.Metadata["single_audits_since"])
+	assert.Equal(t, grantAwardTypes, req.Filters.AwardTypeCodes)
+	assert.Equal(t, "last_modified_date", req.Filters.TimePeriod[0].DateType)
+	assert.Equal(t, 1, req.Page)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFederalGrants_SyncWithoutFACKey(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var req grantSearchRequest
+	srv := grantSearchServer(t, &req)
+
+	pool.ExpectQuery("SELECT MAX\\(last_modified_date\\) FROM fed_data.grants").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+	expectBulkUpsert(pool, "fed_data.grants", grantColumns, 2)
+
+	d := &FederalGrants{cfg: federalGrantsConfig(""), searchURL: srv.URL}
+	res, err := d.Sync(context.Background(), pool, fetchermocks.NewMockFetcher(t), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, "skipped", res.Metadata["single_audits"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFederalGrants_SyncSearchError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	pool.ExpectQuery("SELECT MAX\\(last_modified_date\\) FROM fed_data.grants").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+
+	d := &FederalGrants{cfg: federalGrantsConfig(""), searchURL: srv.URL}
+	_, err = d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
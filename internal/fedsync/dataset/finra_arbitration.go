@@ -0,0 +1,357 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/ocr"
+)
+
+const finraAwardsBatchSize = 5000
+
+// finraAwardsCols defines the metadata upsert columns. award_text and
+// award_amount come from the award PDF and respondent_crds is maintained by
+// PostSync, so upserts leave them untouched.
+var finraAwardsCols = []string{
+	"case_id", "forum", "document_type", "award_date",
+	"claimants", "respondents", "respondent_norms", "pdf_url", "updated_at",
+}
+
+// finraAwardPayRe finds the amount each "shall pay" directive in an award
+// orders paid, e.g. "Respondent is liable for and shall pay to Claimant
+// compensatory damages in the amount of $125,000.00".
+var finraAwardPayRe = regexp.MustCompile(`(?is)shall\s+pay[^$]{0,200}?\$\s?([\d,]+(?:\.\d{1,2})?)`)
+
+// FINRAArbitration syncs FINRA Arbitration Awards Online into
+// fed_data.finra_awards. Award metadata comes from the configured search
+// export; award PDFs are OCR'd to capture the text and the amount awarded.
+// PostSync links respondents to broker-dealer and adviser CRDs so award
+// frequency and size per firm can feed the regulatory-risk assessment.
+type FINRAArbitration struct {
+	cfg *config.Config
+	ext ocr.Extractor // overrides the configured extractor in tests
+}
+
+// Name implements Dataset.
+func (d *FINRAArbitration) Name() string { return "finra_arbitration" }
+
+// Table implements Dataset.
+func (d *FINRAArbitration) Table() string { return "fed_data.finra_awards" }
+
+// Phase implements Dataset.
+func (d *FINRAArbitration) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *FINRAArbitration) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *FINRAArbitration) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync downloads the awards export, upserts award metadata, then OCRs up to
+// fedsync.finra.max_pdfs award PDFs that have no text yet.
+func (d *FINRAArbitration) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	if d.cfg == nil || d.cfg.Fedsync.FINRA.AwardsURL == "" {
+		return nil, eris.New("finra_arbitration: awards export URL required (fedsync.finra.awards_url)")
+	}
+
+	rc, err := downloadCSVExtract(ctx, f, d.cfg.Fedsync.FINRA.AwardsURL, filepath.Join(tempDir, "finra_awards"))
+	if err != nil {
+		return nil, eris.Wrap(err, "finra_arbitration: awards export")
+	}
+	rows, err := d.loadAwards(ctx, pool, rc)
+	_ = rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("finra awards upserted", zap.Int64("rows", rows))
+
+	extracted, failed, err := d.extractAwards(ctx, pool, f, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("finra_arbitration sync complete",
+		zap.Int64("rows", rows),
+		zap.Int("pdfs_extracted", extracted),
+		zap.Int("pdfs_failed", failed),
+	)
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"pdfs_extracted": extracted,
+			"pdfs_failed":    failed,
+		},
+	}, nil
+}
+
+// loadAwards streams the awards export and upserts rows in batches.
+func (d *FINRAArbitration) loadAwards(ctx context.Context, pool db.Pool, r io.Reader) (int64, error) {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "finra_arbitration: read header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	if _, ok := colIdx["case id"]; !ok {
+		return 0, eris.New("finra_arbitration: awards export missing Case ID column")
+	}
+
+	upsert := func(batch [][]any) (int64, error) {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      finraAwardsCols,
+			ConflictKeys: []string{"case_id"},
+		}, batch)
+		return n, eris.Wrap(err, "finra_arbitration: upsert")
+	}
+
+	now := time.Now().UTC()
+	seen := make(map[string]bool)
+	var total int64
+	batch := make([][]any, 0, finraAwardsBatchSize)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return total, eris.Wrap(err, "finra_arbitration: read awards")
+		}
+		row := finraAwardRow(record, colIdx, now)
+		if row == nil || seen[row[0].(string)] {
+			continue
+		}
+		seen[row[0].(string)] = true
+		batch = append(batch, row)
+		if len(batch) >= finraAwardsBatchSize {
+			n, err := upsert(batch)
+			if err != nil {
+				return total, err
+			}
+			total += n
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		n, err := upsert(batch)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// finraAwardRow maps an export record to finraAwardsCols. Returns nil when
+// the record has no case ID or names no respondent.
+func finraAwardRow(record []string, colIdx map[string]int, now time.Time) []any {
+	get := func(names ...string) string {
+		for _, name := range names {
+			if v := sanitizeUTF8(strings.TrimSpace(getColN(record, colIdx, name))); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	caseID := strings.ToUpper(get("case id"))
+	respondents := splitFINRAParties(get("respondents", "respondent"))
+	if caseID == "" || len(respondents) == 0 {
+		return nil
+	}
+
+	norms := make([]string, 0, len(respondents))
+	for _, r := range respondents {
+		if n := resolve.NormalizeName(r); n != "" {
+			norms = append(norms, n)
+		}
+	}
+
+	return []any{
+		caseID,
+		nilIfEmpty(get("forum")),
+		nilIfEmpty(get("document type")),
+		dateOrNil(parseDate(get("date of award", "award date"))),
+		splitFINRAParties(get("claimants", "claimant")),
+		respondents,
+		norms,
+		nilIfEmpty(get("document link", "award url", "pdf url")),
+		now,
+	}
+}
+
+// splitFINRAParties splits a ";"-separated party list, dropping blanks.
+func splitFINRAParties(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ";") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// extractAwards downloads and OCRs award PDFs that have no text yet, newest
+// first, storing the text and awarded amount. Per-award download and OCR
+// failures are logged and counted so one bad PDF does not fail the sync.
+func (d *FINRAArbitration) extractAwards(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (int, int, error) {
+	limit := d.cfg.Fedsync.FINRA.MaxPDFs
+	if limit <= 0 {
+		return 0, 0, nil
+	}
+
+	pending, err := pendingFINRAAwards(ctx, pool, limit)
+	if err != nil || len(pending) == 0 {
+		return 0, 0, err
+	}
+
+	ext := d.ext
+	if ext == nil {
+		if ext, _, err = buildExtractors(d.cfg); err != nil {
+			return 0, 0, eris.Wrap(err, "finra_arbitration: create extractor")
+		}
+	}
+
+	log := zap.L().With(zap.String("dataset", d.Name()))
+	var extracted, failed int
+	for i, p := range pending {
+		// Case IDs come from the feed; name files by position so a crafted
+		// ID cannot point the download outside tempDir.
+		pdfPath := filepath.Join(tempDir, fmt.Sprintf("finra_award_%d.pdf", i))
+		if _, err := f.DownloadToFile(ctx, p.pdfURL, pdfPath); err != nil {
+			log.Warn("finra award download failed", zap.String("case_id", p.caseID), zap.Error(err))
+			failed++
+			continue
+		}
+		text, err := ext.ExtractText(ctx, pdfPath)
+		if err != nil {
+			log.Warn("finra award ocr failed", zap.String("case_id", p.caseID), zap.Error(err))
+			failed++
+			continue
+		}
+		text = sanitizeUTF8(text)
+		if _, err := pool.Exec(ctx, `UPDATE fed_data.finra_awards SET award_text = $2, award_amount = $3 WHERE case_id = $1`,
+			p.caseID, text, parseFINRAAwardAmount(text)); err != nil {
+			return extracted, failed, eris.Wrapf(err, "finra_arbitration: store award %s", p.caseID)
+		}
+		extracted++
+	}
+	return extracted, failed, nil
+}
+
+// finraPendingAward is an award whose PDF has not been extracted.
+type finraPendingAward struct {
+	caseID string
+	pdfURL string
+}
+
+func pendingFINRAAwards(ctx context.Context, pool db.Pool, limit int) ([]finraPendingAward, error) {
+	rows, err := pool.Query(ctx, `SELECT case_id, pdf_url FROM fed_data.finra_awards
+WHERE award_text IS NULL AND pdf_url IS NOT NULL
+ORDER BY award_date DESC NULLS LAST
+LIMIT $1`, limit)
+	if err != nil {
+		return nil, eris.Wrap(err, "finra_arbitration: query pending awards")
+	}
+	defer rows.Close()
+
+	var out []finraPendingAward
+	for rows.Next() {
+		var p finraPendingAward
+		if err := rows.Scan(&p.caseID, &p.pdfURL); err != nil {
+			return nil, eris.Wrap(err, "finra_arbitration: scan pending award")
+		}
+		out = append(out, p)
+	}
+	return out, eris.Wrap(rows.Err(), "finra_arbitration: iterate pending awards")
+}
+
+// parseFINRAAwardAmount sums the dollar amounts the award orders paid,
+// rounded to whole dollars. Returns nil when no payment is ordered, which
+// covers denied claims.
+func parseFINRAAwardAmount(text string) any {
+	var total float64
+	var found bool
+	for _, m := range finraAwardPayRe.FindAllStringSubmatch(text, -1) {
+		v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		total += v
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return int64(math.Round(total))
+}
+
+// PostSync implements PostSyncer by relinking respondents to CRD numbers.
+// A normalized respondent name is linked when it identifies exactly one
+// firm across adv_firms and form_bd.
+func (d *FINRAArbitration) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrap(err, "finra_arbitration: begin respondent links")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `UPDATE fed_data.finra_awards SET respondent_crds = NULL WHERE respondent_crds IS NOT NULL`); err != nil {
+		return eris.Wrap(err, "finra_arbitration: clear respondent links")
+	}
+	tag, err := tx.Exec(ctx, finraRespondentLinkSQL())
+	if err != nil {
+		return eris.Wrap(err, "finra_arbitration: link respondents")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrap(err, "finra_arbitration: commit respondent links")
+	}
+
+	zap.L().Info("finra_arbitration respondent links rebuilt",
+		zap.String("dataset", d.Name()),
+		zap.Int64("linked", tag.RowsAffected()),
+	)
+	return nil
+}
+
+// finraRespondentLinkSQL sets respondent_crds to the CRDs of respondents
+// whose normalized name matches exactly one broker-dealer or adviser.
+func finraRespondentLinkSQL() string {
+	return fmt.Sprintf(`UPDATE fed_data.finra_awards a
+SET respondent_crds = m.crds
+FROM (
+    SELECT w.case_id, array_agg(DISTINCT f.crd_number ORDER BY f.crd_number) AS crds
+    FROM fed_data.finra_awards w
+    CROSS JOIN LATERAL unnest(w.respondent_norms) AS r(norm)
+    JOIN (
+        SELECT norm, MIN(crd_number) AS crd_number
+        FROM (
+            SELECT %s AS norm, crd_number FROM fed_data.adv_firms
+            UNION ALL
+            SELECT %s AS norm, crd_number FROM fed_data.form_bd
+        ) n
+        GROUP BY norm
+        HAVING COUNT(DISTINCT crd_number) = 1
+    ) f ON f.norm = r.norm
+    GROUP BY w.case_id
+) m
+WHERE a.case_id = m.case_id`, resolve.NormalizeNameSQL("firm_name"), resolve.NormalizeNameSQL("firm_name"))
+}
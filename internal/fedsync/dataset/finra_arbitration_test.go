@@ -0,0 +1,168 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+	ocrmocks "github.com/sells-group/research-cli/internal/ocr/mocks"
+)
+
+const finraAwardsCSV = "\ufeffCase ID,Document Type,Forum,Date of Award,Claimant(s),Respondent(s),Document Link\n" +
+	`21-01234,Award,FINRA,02/03/2026,John Smith; Jane Smith,"Acme Securities, LLC; Robert Jones",https://finra.test/21-01234.pdf` + "\n" +
+	`22-00042,Award,FINRA,2025-11-20,Mary Lee,Beta Capital Markets Inc.,` + "\n" +
+	`21-01234,Award,FINRA,02/03/2026,John Smith,"Acme Securities, LLC",https://finra.test/21-01234.pdf` + "\n" +
+	`23-00001,Award,FINRA,01/05/2026,Claimant Only,,https://finra.test/23-00001.pdf` + "\n"
+
+const finraAwardText = `AWARD
+1. Respondent Acme Securities, LLC is liable for and shall pay to Claimants
+compensatory damages in the amount of $125,000.00.
+2. Respondent Acme Securities, LLC shall pay to Claimants costs in the amount of $1,500.50.
+3. Any and all claims for relief not specifically addressed herein are denied.`
+
+func TestFINRAArbitration_Metadata(t *testing.T) {
+	d := &FINRAArbitration{}
+	assert.Equal(t, "finra_arbitration", d.Name())
+	assert.Equal(t, "fed_data.finra_awards", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestFINRAAwardRow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(finraAwardsCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	colIdx := mapColumnsTrimBOM(header)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	row := finraAwardRow(records[0], colIdx, now)
+	require.Len(t, row, len(finraAwardsCols))
+	assert.Equal(t, "21-01234", row[0])
+	assert.Equal(t, "FINRA", row[1])
+	assert.Equal(t, "Award", row[2])
+	assert.Equal(t, time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC), row[3])
+	assert.Equal(t, []string{"John Smith", "Jane Smith"}, row[4])
+	assert.Equal(t, []string{"Acme Securities, LLC", "Robert Jones"}, row[5])
+	assert.Equal(t, []string{"ACME SECURITIES", "ROBERT JONES"}, row[6])
+	assert.Equal(t, "https://finra.test/21-01234.pdf", row[7])
+
+	row = finraAwardRow(records[1], colIdx, now)
+	require.NotNil(t, row)
+	assert.Nil(t, row[7], "missing PDF link")
+
+	assert.Nil(t, finraAwardRow(records[3], colIdx, now), "no respondent")
+}
+
+func TestParseFINRAAwardAmount(t *testing.T) {
+	assert.Equal(t, int64(126501), parseFINRAAwardAmount(finraAwardText))
+	assert.Nil(t, parseFINRAAwardAmount("Claimants' claims are denied in their entirety."))
+}
+
+func TestFINRAArbitration_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://finra.test/awards.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(finraAwardsCSV))
+		}).Return(int64(len(finraAwardsCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://finra.test/21-01234.pdf", mock.Anything).
+		Return(int64(1000), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://finra.test/20-00999.pdf", mock.Anything).
+		Return(int64(0), errors.New("404"))
+
+	ext := ocrmocks.NewMockExtractor(t)
+	ext.EXPECT().ExtractText(mock.Anything, mock.Anything).Return(finraAwardText, nil)
+
+	expectBulkUpsert(pool, "fed_data.finra_awards", finraAwardsCols, 2)
+	pool.ExpectQuery("SELECT case_id, pdf_url FROM fed_data.finra_awards").WithArgs(5).
+		WillReturnRows(pgxmock.NewRows([]string{"case_id", "pdf_url"}).
+			AddRow("21-01234", "https://finra.test/21-01234.pdf").
+			AddRow("20-00999", "https://finra.test/20-00999.pdf"))
+	pool.ExpectExec("UPDATE fed_data.finra_awards SET award_text").
+		WithArgs("21-01234", finraAwardText, int64(126501)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	d := &FINRAArbitration{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{FINRA: config.FINRAConfig{
+			AwardsURL: "https://finra.test/awards.csv",
+			MaxPDFs:   5,
+		}}},
+		ext: ext,
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 1, res.Metadata["pdfs_extracted"])
+	assert.Equal(t, 1, res.Metadata["pdfs_failed"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFINRAArbitration_ExtractAwards_PathInTempDir(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	tempDir := t.TempDir()
+	pool.ExpectQuery("SELECT case_id, pdf_url FROM fed_data.finra_awards").WithArgs(1).
+		WillReturnRows(pgxmock.NewRows([]string{"case_id", "pdf_url"}).
+			AddRow("../../etc/cron.d/x", "https://finra.test/x.pdf"))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://finra.test/x.pdf", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, path string) (int64, error) {
+			assert.Equal(t, tempDir, filepath.Dir(path))
+			return 0, errors.New("404")
+		})
+
+	d := &FINRAArbitration{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{FINRA: config.FINRAConfig{MaxPDFs: 1}}},
+		ext: ocrmocks.NewMockExtractor(t),
+	}
+	extracted, failed, err := d.extractAwards(context.Background(), pool, f, tempDir)
+	require.NoError(t, err)
+	assert.Zero(t, extracted)
+	assert.Equal(t, 1, failed)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFINRAArbitration_Sync_NoURL(t *testing.T) {
+	_, err := (&FINRAArbitration{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.finra.awards_url")
+}
+
+func TestFINRAArbitration_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE fed_data.finra_awards SET respondent_crds = NULL").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 4))
+	pool.ExpectExec("UPDATE fed_data.finra_awards a").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+	pool.ExpectCommit()
+
+	require.NoError(t, (&FINRAArbitration{}).PostSync(context.Background(), pool, &SyncResult{}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	sql := finraRespondentLinkSQL()
+	assert.Contains(t, sql, "fed_data.form_bd")
+	assert.Contains(t, sql, "HAVING COUNT(DISTINCT crd_number) = 1")
+}
@@ -0,0 +1,324 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// Momentum component weights. Components without data are left out and the
+// remaining weights renormalized.
+const (
+	momentumAUMWeight        = 0.40
+	momentumEmployeesWeight  = 0.30
+	momentumWebsiteWeight    = 0.15
+	momentumDisclosureWeight = 0.15
+)
+
+// firmMomentumColumns defines the fed_data.firm_momentum upsert columns.
+var firmMomentumColumns = []string{
+	"crd_number", "aum", "aum_change_pct", "employees", "employees_change_pct",
+	"disclosures_12m", "disclosures_prior_12m", "website_changes_12m",
+	"website_last_changed_at", "momentum", "computed_at",
+}
+
+// urlHostSQL reduces a URL column to its lower-cased host without "www.".
+const urlHostSQL = `lower(regexp_replace(regexp_replace(%s, '^(https?://)?(www\.)?', '', 'i'), '[/:?#].*$', ''))`
+
+// firmWebsiteHashSQL records a firm's crawled website content hash when it
+// differs from the last recorded hash. The latest crawl per host comes from
+// public.crawl_cache, matched to ADV firms by website host.
+var firmWebsiteHashSQL = `
+WITH firms AS (
+	SELECT crd_number, ` + fmt.Sprintf(urlHostSQL, "website") + ` AS host
+	FROM fed_data.adv_firms
+	WHERE COALESCE(website, '') <> ''
+),
+crawls AS (
+	SELECT DISTINCT ON (host) host, content_hash, crawled_at
+	FROM (
+		SELECT ` + fmt.Sprintf(urlHostSQL, "company_url") + ` AS host, md5(pages::text) AS content_hash, crawled_at
+		FROM public.crawl_cache
+	) c
+	ORDER BY host, crawled_at DESC
+),
+latest AS (
+	SELECT DISTINCT ON (crd_number) crd_number, content_hash, crawled_at
+	FROM fed_data.firm_website_hashes
+	ORDER BY crd_number, crawled_at DESC
+)
+INSERT INTO fed_data.firm_website_hashes (crd_number, content_hash, crawled_at)
+SELECT f.crd_number, c.content_hash, c.crawled_at
+FROM firms f
+JOIN crawls c ON c.host = f.host
+LEFT JOIN latest l ON l.crd_number = f.crd_number
+WHERE l.crd_number IS NULL
+   OR (c.crawled_at > l.crawled_at AND c.content_hash <> l.content_hash)
+ON CONFLICT (crd_number, crawled_at) DO NOTHING`
+
+// firmTrendsSQL rebuilds yearly trend points. AUM and headcount come from
+// each year's last ADV filing; disclosures and website changes are counted
+// per year. prior_value is the firm's previous point for the metric.
+const firmTrendsSQL = `
+WITH yearly AS (
+	SELECT DISTINCT ON (crd_number, date_trunc('year', filing_date))
+		crd_number,
+		date_trunc('year', filing_date)::date AS period,
+		COALESCE(aum_total, aum) AS aum,
+		COALESCE(total_employees, num_employees) AS employees
+	FROM fed_data.adv_filings
+	ORDER BY crd_number, date_trunc('year', filing_date), filing_date DESC
+),
+website_changes AS (
+	SELECT crd_number, crawled_at
+	FROM (
+		SELECT crd_number, crawled_at,
+			ROW_NUMBER() OVER (PARTITION BY crd_number ORDER BY crawled_at) AS n
+		FROM fed_data.firm_website_hashes
+	) h
+	WHERE n > 1
+),
+points AS (
+	SELECT crd_number, 'aum' AS metric, period, aum::numeric AS value FROM yearly WHERE aum IS NOT NULL
+	UNION ALL
+	SELECT crd_number, 'employees', period, employees::numeric FROM yearly WHERE employees IS NOT NULL
+	UNION ALL
+	SELECT crd_number, 'disclosures', date_trunc('year', event_date)::date, COUNT(*)::numeric
+	FROM fed_data.adv_disclosures
+	WHERE event_date IS NOT NULL
+	GROUP BY crd_number, date_trunc('year', event_date)
+	UNION ALL
+	SELECT crd_number, 'website_changes', date_trunc('year', crawled_at)::date, COUNT(*)::numeric
+	FROM website_changes
+	GROUP BY crd_number, date_trunc('year', crawled_at)
+)
+INSERT INTO fed_data.firm_trends (crd_number, metric, period, value, prior_value, change_pct, updated_at)
+SELECT crd_number, metric, period, value, prior_value,
+	CASE WHEN prior_value > 0 THEN ((value - prior_value) / prior_value * 100)::double precision END,
+	now()
+FROM (
+	SELECT p.*, LAG(value) OVER (PARTITION BY crd_number, metric ORDER BY period) AS prior_value
+	FROM points p
+) t`
+
+// firmMomentumInputsSQL gathers each firm's latest AUM and headcount change,
+// disclosure counts for the trailing and prior 12 months, and website change
+// activity as of $1.
+const firmMomentumInputsSQL = `
+WITH latest AS (
+	SELECT DISTINCT ON (crd_number, metric) crd_number, metric, value, change_pct
+	FROM fed_data.firm_trends
+	WHERE metric IN ('aum', 'employees')
+	ORDER BY crd_number, metric, period DESC
+),
+disc AS (
+	SELECT crd_number,
+		COUNT(*) FILTER (WHERE event_date > $1::date - 365) AS last_12m,
+		COUNT(*) FILTER (WHERE event_date <= $1::date - 365) AS prior_12m
+	FROM fed_data.adv_disclosures
+	WHERE event_date > $1::date - 730
+	GROUP BY crd_number
+),
+web AS (
+	SELECT crd_number,
+		COUNT(*) FILTER (WHERE n > 1 AND crawled_at > $1::date - 365) AS changes_12m,
+		MAX(crawled_at) FILTER (WHERE n > 1) AS last_changed
+	FROM (
+		SELECT crd_number, crawled_at,
+			ROW_NUMBER() OVER (PARTITION BY crd_number ORDER BY crawled_at) AS n
+		FROM fed_data.firm_website_hashes
+	) h
+	GROUP BY crd_number
+),
+firms AS (
+	SELECT crd_number FROM latest
+	UNION SELECT crd_number FROM disc
+	UNION SELECT crd_number FROM web
+)
+SELECT f.crd_number,
+	a.value::bigint, a.change_pct,
+	e.value::integer, e.change_pct,
+	COALESCE(d.last_12m, 0)::integer, COALESCE(d.prior_12m, 0)::integer,
+	w.crd_number IS NOT NULL, COALESCE(w.changes_12m, 0)::integer, w.last_changed
+FROM firms f
+LEFT JOIN latest a ON a.crd_number = f.crd_number AND a.metric = 'aum'
+LEFT JOIN latest e ON e.crd_number = f.crd_number AND e.metric = 'employees'
+LEFT JOIN disc d ON d.crd_number = f.crd_number
+LEFT JOIN web w ON w.crd_number = f.crd_number`
+
+// momentumInputs are one firm's rate-of-change measures.
+type momentumInputs struct {
+	CRDNumber           int
+	AUM                 *int64
+	AUMChangePct        *float64
+	Employees           *int
+	EmployeesChangePct  *float64
+	Disclosures12m      int
+	DisclosuresPrior12m int
+	WebsiteTracked      bool
+	WebsiteChanges12m   int
+	WebsiteLastChanged  *time.Time
+}
+
+// FirmMomentum materializes per-firm trend tables from ADV filing history,
+// disclosure events, and website crawl content hashes, then scores each
+// firm's momentum for the target scorer and the Salesforce momentum field.
+type FirmMomentum struct{}
+
+// Name implements Dataset.
+func (d *FirmMomentum) Name() string { return "firm_momentum" }
+
+// Table implements Dataset.
+func (d *FirmMomentum) Table() string { return "fed_data.firm_momentum" }
+
+// Phase implements Dataset.
+func (d *FirmMomentum) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *FirmMomentum) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *FirmMomentum) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync records website changes, rebuilds fed_data.firm_trends, and upserts
+// a momentum row per firm.
+func (d *FirmMomentum) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	hashTag, err := tx.Exec(ctx, firmWebsiteHashSQL)
+	if err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: record website hashes")
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM fed_data.firm_trends`); err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: clear trends")
+	}
+	trendTag, err := tx.Exec(ctx, firmTrendsSQL)
+	if err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: build trends")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: commit trends")
+	}
+
+	now := time.Now().UTC()
+	inputs, err := loadMomentumInputs(ctx, pool, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]any, 0, len(inputs))
+	var scored int
+	for _, in := range inputs {
+		m := computeMomentum(in)
+		var momentum any
+		if m != nil {
+			momentum = *m
+			scored++
+		}
+		rows = append(rows, []any{
+			in.CRDNumber, in.AUM, in.AUMChangePct, in.Employees, in.EmployeesChangePct,
+			in.Disclosures12m, in.DisclosuresPrior12m, in.WebsiteChanges12m,
+			in.WebsiteLastChanged, momentum, now,
+		})
+	}
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      firmMomentumColumns,
+		ConflictKeys: []string{"crd_number"},
+	}, rows)
+	if err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: upsert")
+	}
+
+	log.Info("firm momentum built",
+		zap.Int64("trend_points", trendTag.RowsAffected()),
+		zap.Int64("website_hashes", hashTag.RowsAffected()),
+		zap.Int64("firms", n),
+		zap.Int("scored", scored),
+	)
+	return &SyncResult{
+		RowsSynced: n,
+		Metadata: map[string]any{
+			"trend_points":   trendTag.RowsAffected(),
+			"website_hashes": hashTag.RowsAffected(),
+			"scored":         scored,
+		},
+	}, nil
+}
+
+func loadMomentumInputs(ctx context.Context, pool db.Pool, now time.Time) ([]momentumInputs, error) {
+	rows, err := pool.Query(ctx, firmMomentumInputsSQL, now)
+	if err != nil {
+		return nil, eris.Wrap(err, "firm_momentum: query inputs")
+	}
+	defer rows.Close()
+
+	var out []momentumInputs
+	for rows.Next() {
+		var in momentumInputs
+		if err := rows.Scan(
+			&in.CRDNumber, &in.AUM, &in.AUMChangePct, &in.Employees, &in.EmployeesChangePct,
+			&in.Disclosures12m, &in.DisclosuresPrior12m,
+			&in.WebsiteTracked, &in.WebsiteChanges12m, &in.WebsiteLastChanged,
+		); err != nil {
+			return nil, eris.Wrap(err, "firm_momentum: scan inputs")
+		}
+		out = append(out, in)
+	}
+	return out, eris.Wrap(rows.Err(), "firm_momentum: iterate inputs")
+}
+
+// computeMomentum blends the firm's changes into a 0-1 score where 0.5 is
+// flat. It returns nil when the firm has no growth or website data, since
+// disclosure counts alone say little about momentum.
+func computeMomentum(in momentumInputs) *float64 {
+	var sum, weights float64
+	add := func(signal, weight float64) {
+		sum += signal * weight
+		weights += weight
+	}
+	if in.AUMChangePct != nil {
+		add(changeSignal(*in.AUMChangePct), momentumAUMWeight)
+	}
+	if in.EmployeesChangePct != nil {
+		add(changeSignal(*in.EmployeesChangePct), momentumEmployeesWeight)
+	}
+	if in.WebsiteTracked {
+		// An unchanged site reads as stale; steady updates as active.
+		add(math.Min(0.4+0.15*float64(in.WebsiteChanges12m), 0.85), momentumWebsiteWeight)
+	}
+	if weights == 0 {
+		return nil
+	}
+	// New disclosures against the prior year pull momentum down.
+	delta := float64(in.Disclosures12m - in.DisclosuresPrior12m)
+	add(clamp01(0.5-0.2*delta), momentumDisclosureWeight)
+
+	m := math.Round(sum/weights*1000) / 1000
+	return &m
+}
+
+// changeSignal maps a percent change onto 0-1: -20% or worse is 0, flat is
+// 0.5, +20% or better is 1.
+func changeSignal(pct float64) float64 {
+	return clamp01(0.5 + pct/40)
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,89 @@
+package dataset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirmMomentum_Metadata(t *testing.T) {
+	d := &FirmMomentum{}
+	assert.Equal(t, "firm_momentum", d.Name())
+	assert.Equal(t, "fed_data.firm_momentum", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestComputeMomentum(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+
+	assert.Nil(t, computeMomentum(momentumInputs{Disclosures12m: 3}), "disclosures alone are not scored")
+
+	flat := computeMomentum(momentumInputs{AUMChangePct: pct(0), EmployeesChangePct: pct(0)})
+	require.NotNil(t, flat)
+	assert.InDelta(t, 0.5, *flat, 0.001)
+
+	growing := computeMomentum(momentumInputs{AUMChangePct: pct(25), EmployeesChangePct: pct(10)})
+	require.NotNil(t, growing)
+	assert.Greater(t, *growing, 0.7)
+
+	shrinking := computeMomentum(momentumInputs{AUMChangePct: pct(-30), Disclosures12m: 2})
+	require.NotNil(t, shrinking)
+	assert.Less(t, *shrinking, 0.2)
+
+	stale := computeMomentum(momentumInputs{WebsiteTracked: true})
+	active := computeMomentum(momentumInputs{WebsiteTracked: true, WebsiteChanges12m: 3})
+	require.NotNil(t, stale)
+	require.NotNil(t, active)
+	assert.Less(t, *stale, 0.5)
+	assert.Greater(t, *active, 0.5)
+}
+
+func TestChangeSignal(t *testing.T) {
+	assert.Equal(t, 0.0, changeSignal(-50))
+	assert.Equal(t, 0.5, changeSignal(0))
+	assert.Equal(t, 0.75, changeSignal(10))
+	assert.Equal(t, 1.0, changeSignal(80))
+}
+
+func TestFirmMomentum_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("INSERT INTO fed_data.firm_website_hashes").
+		WillReturnResult(pgxmock.NewResult("INSERT", 4))
+	pool.ExpectExec("DELETE FROM fed_data.firm_trends").
+		WillReturnResult(pgxmock.NewResult("DELETE", 100))
+	pool.ExpectExec("INSERT INTO fed_data.firm_trends").
+		WillReturnResult(pgxmock.NewResult("INSERT", 120))
+	pool.ExpectCommit()
+
+	aum, aumPct := int64(500_000_000), 12.5
+	emp := 40
+	pool.ExpectQuery("FROM fed_data.firm_trends").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"crd_number", "aum", "aum_change_pct", "employees", "employees_change_pct",
+			"disclosures_12m", "disclosures_prior_12m", "website_tracked", "website_changes_12m", "website_last_changed_at",
+		}).
+			AddRow(1001, &aum, &aumPct, &emp, nil, 0, 0, false, 0, nil).
+			AddRow(1002, nil, nil, nil, nil, 1, 0, false, 0, nil))
+	expectBulkUpsert(pool, "fed_data.firm_momentum", firmMomentumColumns, 2)
+	pool.ExpectRollback()
+
+	d := &FirmMomentum{}
+	res, err := d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, int64(120), res.Metadata["trend_points"])
+	assert.Equal(t, int64(4), res.Metadata["website_hashes"])
+	assert.Equal(t, 1, res.Metadata["scored"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
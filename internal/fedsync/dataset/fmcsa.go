@@ -0,0 +1,384 @@
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	fmcsaBatchSize = 10000
+
+	// Motor Carrier Census (Company Census File) and SMS carrier results,
+	// both published as Socrata CSV exports on data.transportation.gov.
+	fmcsaCensusURL = "https://data.transportation.gov/api/views/az4n-8mr2/rows.csv?accessType=DOWNLOAD"
+	fmcsaSMSURL    = "https://data.transportation.gov/api/views/4y6x-dmck/rows.csv?accessType=DOWNLOAD"
+)
+
+// fmcsaColumns defines the target DB columns in upsert order.
+var fmcsaColumns = []string{
+	"dot_number", "legal_name", "dba_name", "name_norm",
+	"carrier_operation", "hm_flag", "pc_flag",
+	"phy_street", "phy_city", "phy_state", "phy_zip", "phy_country",
+	"street_norm", "zip5",
+	"mailing_street", "mailing_city", "mailing_state", "mailing_zip",
+	"telephone", "email",
+	"mcs150_date", "mcs150_mileage", "mcs150_mileage_year", "add_date",
+	"power_units", "drivers",
+	"inspections", "driver_oos_inspections", "vehicle_oos_inspections",
+	"unsafe_driving_pct", "hos_pct", "driver_fitness_pct", "drugs_alcohol_pct", "vehicle_maint_pct",
+	"basic_alerts",
+}
+
+// fmcsaSafetyColumns are the fmcsaColumns filled from the SMS results.
+var fmcsaSafetyColumns = []string{
+	"inspections", "driver_oos_inspections", "vehicle_oos_inspections",
+	"unsafe_driving_pct", "hos_pct", "driver_fitness_pct", "drugs_alcohol_pct", "vehicle_maint_pct",
+	"basic_alerts",
+}
+
+// fmcsaBasicAlertCols are the SMS BASIC alert flag columns counted into
+// basic_alerts.
+var fmcsaBasicAlertCols = []string{
+	"unsafe_driv_basic_alert", "hos_driv_basic_alert", "driv_fit_basic_alert",
+	"contr_subst_basic_alert", "veh_maint_basic_alert",
+}
+
+// fmcsaSafety holds the SMS measures joined onto a census row.
+type fmcsaSafety struct {
+	inspections    *int
+	driverOOS      *int
+	vehicleOOS     *int
+	unsafeDriving  *float64
+	hos            *float64
+	driverFitness  *float64
+	drugsAlcohol   *float64
+	vehicleMaint   *float64
+	basicAlerts    int16
+	hasBasicAlerts bool
+}
+
+// FMCSA syncs the FMCSA Motor Carrier Census joined with SMS safety
+// percentiles into fed_data.fmcsa_carriers, keyed by USDOT number. Carrier
+// names and physical addresses are stored normalized (name_norm,
+// street_norm, zip5) for entity matching.
+type FMCSA struct {
+	censusURL string // overrides fmcsaCensusURL in tests
+	smsURL    string // overrides fmcsaSMSURL in tests
+}
+
+// Name implements Dataset.
+func (d *FMCSA) Name() string { return "fmcsa" }
+
+// Table implements Dataset.
+func (d *FMCSA) Table() string { return "fed_data.fmcsa_carriers" }
+
+// Phase implements Dataset.
+func (d *FMCSA) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *FMCSA) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *FMCSA) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync downloads the SMS results and the carrier census, then upserts census
+// rows with their safety measures. A failed SMS download is logged and the
+// census is loaded without touching existing carriers' safety columns.
+func (d *FMCSA) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	censusURL, smsURL := fmcsaCensusURL, fmcsaSMSURL
+	if d.censusURL != "" {
+		censusURL = d.censusURL
+	}
+	if d.smsURL != "" {
+		smsURL = d.smsURL
+	}
+
+	safety := map[int]fmcsaSafety{}
+	smsLoaded := false
+	smsPath := filepath.Join(tempDir, "fmcsa_sms.csv")
+	if _, err := f.DownloadToFile(ctx, smsURL, smsPath); err != nil {
+		if ctx.Err() != nil {
+			return nil, eris.Wrap(err, "fmcsa: download sms")
+		}
+		log.Warn("fmcsa: sms download failed, keeping existing safety measures", zap.Error(err))
+	} else {
+		smsFile, err := openFileForRead(smsPath)
+		if err != nil {
+			return nil, eris.Wrap(err, "fmcsa: open sms")
+		}
+		safety, err = parseFMCSASafety(smsFile)
+		_ = smsFile.Close()
+		if err != nil {
+			return nil, eris.Wrap(err, "fmcsa: parse sms")
+		}
+		smsLoaded = true
+		log.Info("loaded SMS safety measures", zap.Int("carriers", len(safety)))
+	}
+
+	censusPath := filepath.Join(tempDir, "fmcsa_census.csv")
+	if _, err := f.DownloadToFile(ctx, censusURL, censusPath); err != nil {
+		return nil, eris.Wrap(err, "fmcsa: download census")
+	}
+	censusFile, err := openFileForRead(censusPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "fmcsa: open census")
+	}
+	defer censusFile.Close() //nolint:errcheck
+
+	rows, matched, err := d.loadCensus(ctx, pool, censusFile, safety, smsLoaded)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("fmcsa sync complete", zap.Int64("rows", rows), zap.Int64("with_safety", matched))
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"sms_loaded":   smsLoaded,
+			"sms_carriers": len(safety),
+			"with_safety":  matched,
+		},
+	}, nil
+}
+
+// loadCensus streams the census CSV and upserts carriers in batches. It
+// returns the rows upserted and how many had SMS measures. Unless
+// smsLoaded, existing carriers keep their safety columns.
+func (d *FMCSA) loadCensus(ctx context.Context, pool db.Pool, r io.Reader, safety map[int]fmcsaSafety, smsLoaded bool) (int64, int64, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "fmcsa: read census header")
+	}
+	colIdx := mapColumnsNormalized(header)
+	if _, ok := colIdx["dot_number"]; !ok {
+		return 0, 0, eris.New("fmcsa: DOT_NUMBER column not found in census header")
+	}
+
+	var updateCols []string
+	if !smsLoaded {
+		for _, c := range fmcsaColumns[1:] {
+			if !slices.Contains(fmcsaSafetyColumns, c) {
+				updateCols = append(updateCols, c)
+			}
+		}
+	}
+	upsert := func(batch [][]any) (int64, error) {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      fmcsaColumns,
+			ConflictKeys: []string{"dot_number"},
+			UpdateCols:   updateCols,
+		}, batch)
+		return n, eris.Wrap(err, "fmcsa: bulk upsert")
+	}
+
+	var (
+		batch   [][]any
+		total   int64
+		matched int64
+	)
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			continue // skip malformed rows
+		}
+
+		dot, err := strconv.Atoi(strings.TrimSpace(getColN(record, colIdx, "dot_number")))
+		if err != nil || dot <= 0 {
+			continue
+		}
+		s, ok := safety[dot]
+		if ok {
+			matched++
+		}
+		batch = append(batch, fmcsaRow(dot, record, colIdx, s))
+
+		if len(batch) >= fmcsaBatchSize {
+			n, err := upsert(batch)
+			if err != nil {
+				return total, matched, err
+			}
+			total += n
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		n, err := upsert(batch)
+		if err != nil {
+			return total, matched, err
+		}
+		total += n
+	}
+	return total, matched, nil
+}
+
+// fmcsaRow maps a census record and its SMS measures to fmcsaColumns.
+func fmcsaRow(dot int, record []string, colIdx map[string]int, s fmcsaSafety) []any {
+	get := func(name string) string { return sanitizeUTF8(trimQuotes(getColN(record, colIdx, name))) }
+	text := func(name string) any {
+		if v := get(name); v != "" {
+			return v
+		}
+		return nil
+	}
+	intOrNil := func(name string) any {
+		if v, err := strconv.Atoi(get(name)); err == nil {
+			return v
+		}
+		return nil
+	}
+
+	legal := get("legal_name")
+	street := get("phy_street")
+	zip := get("phy_zip")
+
+	var basicAlerts any
+	if s.hasBasicAlerts {
+		basicAlerts = s.basicAlerts
+	}
+
+	return []any{
+		dot,
+		legal,
+		text("dba_name"),
+		resolve.NormalizeName(legal),
+		text("carrier_operation"),
+		parseBoolYN(get("hm_flag")),
+		parseBoolYN(get("pc_flag")),
+		text("phy_street"),
+		text("phy_city"),
+		text("phy_state"),
+		text("phy_zip"),
+		text("phy_country"),
+		nilIfEmpty(resolve.NormalizeStreet(street)),
+		nilIfEmpty(resolve.NormalizeZIP(zip)),
+		text("mailing_street"),
+		text("mailing_city"),
+		text("mailing_state"),
+		text("mailing_zip"),
+		text("telephone"),
+		text("email_address"),
+		parseFMCSADate(get("mcs150_date")),
+		intOrNil("mcs150_mileage"),
+		intOrNil("mcs150_mileage_year"),
+		parseFMCSADate(get("add_date")),
+		intOrNil("nbr_power_unit"),
+		intOrNil("driver_total"),
+		s.inspections,
+		s.driverOOS,
+		s.vehicleOOS,
+		s.unsafeDriving,
+		s.hos,
+		s.driverFitness,
+		s.drugsAlcohol,
+		s.vehicleMaint,
+		basicAlerts,
+	}
+}
+
+// parseFMCSASafety reads the SMS results CSV into a DOT number → measures
+// map.
+func parseFMCSASafety(r io.Reader) (map[int]fmcsaSafety, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "fmcsa: read sms header")
+	}
+	colIdx := mapColumnsNormalized(header)
+	if _, ok := colIdx["dot_number"]; !ok {
+		return nil, eris.New("fmcsa: DOT_NUMBER column not found in sms header")
+	}
+
+	intPtr := func(record []string, name string) *int {
+		v, err := strconv.Atoi(strings.TrimSpace(getColN(record, colIdx, name)))
+		if err != nil {
+			return nil
+		}
+		return &v
+	}
+	pct := func(record []string, name string) *float64 {
+		return parseNullFloat(getColN(record, colIdx, name))
+	}
+
+	out := make(map[int]fmcsaSafety)
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			continue
+		}
+		dot, err := strconv.Atoi(strings.TrimSpace(getColN(record, colIdx, "dot_number")))
+		if err != nil || dot <= 0 {
+			continue
+		}
+
+		s := fmcsaSafety{
+			inspections:   intPtr(record, "insp_total"),
+			driverOOS:     intPtr(record, "driver_oos_insp_total"),
+			vehicleOOS:    intPtr(record, "vehicle_oos_insp_total"),
+			unsafeDriving: pct(record, "unsafe_driv_pct"),
+			hos:           pct(record, "hos_driv_pct"),
+			driverFitness: pct(record, "driv_fit_pct"),
+			drugsAlcohol:  pct(record, "contr_subst_pct"),
+			vehicleMaint:  pct(record, "veh_maint_pct"),
+		}
+		for _, col := range fmcsaBasicAlertCols {
+			if _, ok := colIdx[col]; !ok {
+				continue
+			}
+			s.hasBasicAlerts = true
+			if parseBoolYN(getColN(record, colIdx, col)) {
+				s.basicAlerts++
+			}
+		}
+		out[dot] = s
+	}
+	return out, nil
+}
+
+// parseFMCSADate parses census dates, which appear as YYYYMMDD, ISO, or
+// DD-MON-YY depending on the export. Returns nil when empty or invalid.
+func parseFMCSADate(s string) any {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	if i := strings.IndexByte(s, ' '); i > 0 {
+		s = s[:i] // drop trailing time component
+	}
+	for _, layout := range []string{"20060102", "2006-01-02", "02-Jan-06", "01/02/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const fmcsaCensusCSV = `DOT_NUMBER,LEGAL_NAME,DBA_NAME,CARRIER_OPERATION,HM_FLAG,PC_FLAG,PHY_STREET,PHY_CITY,PHY_STATE,PHY_ZIP,PHY_COUNTRY,MAILING_STREET,MAILING_CITY,MAILING_STATE,MAILING_ZIP,TELEPHONE,FAX,EMAIL_ADDRESS,MCS150_DATE,MCS150_MILEAGE,MCS150_MILEAGE_YEAR,ADD_DATE,OIC_STATE,NBR_POWER_UNIT,DRIVER_TOTAL
+1234567,"Acme Freight Lines, Inc.",Acme Freight,A,N,N,"100 North Industrial Parkway, Suite 2",Dallas,TX,75201-1234,US,PO Box 9,Dallas,TX,75202,(214) 555-0100,,ops@acme.example,20240115,1250000,2023,19990301,TX,42,45
+7654321,Solo Hauling LLC,,C,Y,N,5 Elm Street,Tulsa,OK,74103,US,,,,,,,,15-MAR-22,,,,OK,1,1
+notanumber,Bad Row,,A,N,N,,,,,,,,,,,,,,,,,,,
+`
+
+const fmcsaSMSCSV = `DOT_NUMBER,INSP_TOTAL,DRIVER_INSP_TOTAL,DRIVER_OOS_INSP_TOTAL,VEHICLE_INSP_TOTAL,VEHICLE_OOS_INSP_TOTAL,UNSAFE_DRIV_PCT,HOS_DRIV_PCT,DRIV_FIT_PCT,CONTR_SUBST_PCT,VEH_MAINT_PCT,UNSAFE_DRIV_BASIC_ALERT,HOS_DRIV_BASIC_ALERT,DRIV_FIT_BASIC_ALERT,CONTR_SUBST_BASIC_ALERT,VEH_MAINT_BASIC_ALERT
+1234567,80,60,3,50,9,72.5,40.1,,,81.0,Y,N,N,N,Y
+`
+
+func TestFMCSA_Metadata(t *testing.T) {
+	d := &FMCSA{}
+	assert.Equal(t, "fmcsa", d.Name())
+	assert.Equal(t, "fed_data.fmcsa_carriers", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+}
+
+func TestFMCSA_ShouldRun(t *testing.T) {
+	d := &FMCSA{}
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	assert.True(t, d.ShouldRun(now, nil))
+	thisMonth := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	assert.False(t, d.ShouldRun(now, &thisMonth))
+	lastMonth := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	assert.True(t, d.ShouldRun(now, &lastMonth))
+}
+
+func TestParseFMCSASafety(t *testing.T) {
+	safety, err := parseFMCSASafety(strings.NewReader(fmcsaSMSCSV))
+	require.NoError(t, err)
+	require.Len(t, safety, 1)
+
+	s := safety[1234567]
+	require.NotNil(t, s.inspections)
+	assert.Equal(t, 80, *s.inspections)
+	assert.Equal(t, 3, *s.driverOOS)
+	assert.Equal(t, 9, *s.vehicleOOS)
+	assert.InDelta(t, 72.5, *s.unsafeDriving, 0.001)
+	assert.Nil(t, s.driverFitness)
+	assert.Equal(t, int16(2), s.basicAlerts)
+	assert.True(t, s.hasBasicAlerts)
+
+	_, err = parseFMCSASafety(strings.NewReader("FOO,BAR\n1,2\n"))
+	assert.Error(t, err)
+}
+
+func TestFMCSARow(t *testing.T) {
+	header := strings.SplitN(fmcsaCensusCSV, "\n", 2)[0]
+	colIdx := mapColumnsNormalized(strings.Split(header, ","))
+	record := []string{
+		"1234567", "Acme Freight Lines, Inc.", "Acme Freight", "A", "N", "N",
+		"100 North Industrial Parkway, Suite 2", "Dallas", "TX", "75201-1234", "US",
+		"PO Box 9", "Dallas", "TX", "75202", "(214) 555-0100", "", "ops@acme.example",
+		"20240115", "1250000", "2023", "19990301", "TX", "42", "45",
+	}
+	safety, err := parseFMCSASafety(strings.NewReader(fmcsaSMSCSV))
+	require.NoError(t, err)
+
+	row := fmcsaRow(1234567, record, colIdx, safety[1234567])
+	require.Len(t, row, len(fmcsaColumns))
+
+	col := func(name string) any {
+		for i, c := range fmcsaColumns {
+			if c == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("unknown column %s", name)
+		return nil
+	}
+	assert.Equal(t, 1234567, col("dot_number"))
+	assert.Equal(t, "ACME FREIGHT LINES", col("name_norm"))
+	assert.Equal(t, "100 N INDUSTRIAL PKWY STE 2", col("street_norm"))
+	assert.Equal(t, "75201", col("zip5"))
+	assert.Equal(t, false, col("hm_flag"))
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), col("mcs150_date"))
+	assert.Equal(t, 1250000, col("mcs150_mileage"))
+	assert.Equal(t, 42, col("power_units"))
+	assert.Equal(t, int16(2), col("basic_alerts"))
+
+	bare := fmcsaRow(7654321, record, colIdx, fmcsaSafety{})
+	for i, c := range fmcsaColumns {
+		if c == "basic_alerts" {
+			assert.Nil(t, bare[i])
+		}
+	}
+}
+
+func TestParseFMCSADate(t *testing.T) {
+	want := time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, want, parseFMCSADate("20220315"))
+	assert.Equal(t, want, parseFMCSADate("2022-03-15"))
+	assert.Equal(t, want, parseFMCSADate("15-MAR-22"))
+	assert.Equal(t, want, parseFMCSADate("03/15/2022 12:00:00 AM"))
+	assert.Nil(t, parseFMCSADate(""))
+	assert.Nil(t, parseFMCSADate("garbage"))
+}
+
+func TestFMCSA_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://sms.test", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { writeTestFixture(t, path, []byte(fmcsaSMSCSV)) }).
+		Return(int64(len(fmcsaSMSCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://census.test", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { writeTestFixture(t, path, []byte(fmcsaCensusCSV)) }).
+		Return(int64(len(fmcsaCensusCSV)), nil)
+
+	expectBulkUpsert(pool, "fed_data.fmcsa_carriers", fmcsaColumns, 2)
+
+	d := &FMCSA{censusURL: "https://census.test", smsURL: "https://sms.test"}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["with_safety"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFMCSA_Sync_SMSUnavailable(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, fmcsaSMSURL, mock.Anything).
+		Return(int64(0), assert.AnError)
+	f.EXPECT().DownloadToFile(mock.Anything, fmcsaCensusURL, mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { writeTestFixture(t, path, []byte(fmcsaCensusCSV)) }).
+		Return(int64(len(fmcsaCensusCSV)), nil)
+
+	// Existing carriers keep their safety columns: the update stops at drivers.
+	pool.ExpectBegin()
+	pool.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	pool.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_fed_data_fmcsa_carriers"}, fmcsaColumns).WillReturnResult(2)
+	pool.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec(`INSERT INTO .* DO UPDATE SET "legal_name" = EXCLUDED\."legal_name", .*"drivers" = EXCLUDED\."drivers"$`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	pool.ExpectCommit()
+
+	res, err := (&FMCSA{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 0, res.Metadata["sms_carriers"])
+	assert.Equal(t, false, res.Metadata["sms_loaded"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestFMCSA_Sync_CensusDownloadError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, fmcsaSMSURL, mock.Anything).
+		Run(func(_ context.Context, _ string, path string) { writeTestFixture(t, path, []byte(fmcsaSMSCSV)) }).
+		Return(int64(len(fmcsaSMSCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, fmcsaCensusURL, mock.Anything).
+		Return(int64(0), assert.AnError)
+
+	_, err = (&FMCSA{}).Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fmcsa: download census")
+}
@@ -525,7 +525,7 @@ func (d *Form5500) syncYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher
 			return totalRows, eris.Wrapf(err, "form_5500: download %s year %d", dl.label, year)
 		}
 
-		rows, err := d.processZip(ctx, pool, zipPath, dl.zipType)
+		rows, err := d.processZip(db.WithSource(ctx, url), pool, zipPath, dl.zipType)
 		if err != nil {
 			return totalRows, eris.Wrapf(err, "form_5500: process %s year %d", dl.label, year)
 		}
@@ -85,98 +85,118 @@ type formDOffering struct {
 	TotalSold     int64  `xml:"offeringSalesAmounts>totalAmountSold"`
 }
 
-// Sync fetches and loads SEC Form D offering data.
+// Sync fetches and loads SEC Form D offering data filed in the last 2 days
+// (to handle weekends).
 func (d *FormD) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, f, tempDir, time.Now().UTC().AddDate(0, 0, -2))
+}
+
+// SyncIncremental implements IncrementalSyncer, loading Form D filings filed
+// on or after the watermark's date.
+func (d *FormD) SyncIncremental(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, since time.Time) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, f, tempDir, since.UTC())
+}
+
+// syncSince pages through Form D filings filed from start through today.
+func (d *FormD) syncSince(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, start time.Time) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", "form_d"))
 
-	// Search for Form D filings from the last 2 days to handle weekends.
 	now := time.Now().UTC()
-	startDate := now.AddDate(0, 0, -2).Format("2006-01-02")
+	startDate := start.Format("2006-01-02")
 	endDate := now.Format("2006-01-02")
 
-	searchURL := fmt.Sprintf(
-		"%s?q=*&dateRange=custom&startdt=%s&enddt=%s&forms=D&from=0&size=200",
-		formDSearchURL, startDate, endDate,
-	)
-
 	log.Info("searching for Form D filings",
 		zap.String("start_date", startDate),
 		zap.String("end_date", endDate),
 	)
 
-	body, err := f.Download(ctx, searchURL)
-	if err != nil {
-		return nil, eris.Wrap(err, "form_d: search EFTS")
-	}
-
-	result, err := fetcher.DecodeJSONObject[formDSearchResult](body)
-	_ = body.Close()
-	if err != nil {
-		return nil, eris.Wrap(err, "form_d: decode search results")
-	}
-
-	log.Info("found Form D filings", zap.Int("total", result.Hits.Total.Value))
-
 	columns := []string{"accession_number", "cik", "entity_name", "entity_type", "year_of_inc", "state_of_inc", "industry_group", "revenue_range", "total_offering", "total_sold", "filing_date"}
 	conflictKeys := []string{"accession_number"}
 
 	var batch [][]any
 	var totalRows int64
+	var found int
 
-	for _, hit := range result.Hits.Hits {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		src := hit.Source
-		cik := strings.TrimLeft(src.CIK, "0")
-		accession := src.AccessionNumber
-		accessionClean := strings.ReplaceAll(accession, "-", "")
-
-		// Download Form D XML.
-		xmlURL := fmt.Sprintf(
-			"https://www.sec.gov/Archives/edgar/data/%s/%s/primary_doc.xml",
-			cik, accessionClean,
+	for from := 0; ; from += eftsPageSize {
+		searchURL := fmt.Sprintf(
+			"%s?q=*&dateRange=custom&startdt=%s&enddt=%s&forms=D&from=%d&size=%d",
+			formDSearchURL, startDate, endDate, from, eftsPageSize,
 		)
 
-		xmlPath := filepath.Join(tempDir, fmt.Sprintf("form_d_%s.xml", accessionClean))
-		if _, dlErr := f.DownloadToFile(ctx, xmlURL, xmlPath); dlErr != nil {
-			// Fall back to search metadata if XML download fails.
-			filingDate := parseDate(src.FilingDate)
-			row := []any{accession, cik, src.EntityName, "", "", "", "", "", int64(0), int64(0), filingDate}
-			batch = append(batch, row)
-			continue
+		body, err := f.Download(ctx, searchURL)
+		if err != nil {
+			return nil, eris.Wrap(err, "form_d: search EFTS")
 		}
 
-		xmlFile, err := os.Open(xmlPath) // #nosec G304 -- path constructed from downloaded EDGAR filing in trusted temp directory
+		result, err := fetcher.DecodeJSONObject[formDSearchResult](body)
+		_ = body.Close()
 		if err != nil {
-			_ = os.Remove(xmlPath)
-			continue
+			return nil, eris.Wrap(err, "form_d: decode search results")
+		}
+		found = result.Hits.Total.Value
+		if from == 0 {
+			log.Info("found Form D filings", zap.Int("total", found))
 		}
 
-		row, err := d.parseFormDXML(xmlFile, accession, cik, src.FilingDate)
-		_ = xmlFile.Close()
-		_ = os.Remove(xmlPath)
+		for _, hit := range result.Hits.Hits {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
 
-		if err != nil {
-			log.Warn("form_d: parse XML failed", zap.String("accession", accession), zap.Error(err))
-			filingDate := parseDate(src.FilingDate)
-			row = []any{accession, cik, src.EntityName, "", "", "", "", "", int64(0), int64(0), filingDate}
-		}
+			src := hit.Source
+			cik := strings.TrimLeft(src.CIK, "0")
+			accession := src.AccessionNumber
+			accessionClean := strings.ReplaceAll(accession, "-", "")
+
+			// Download Form D XML.
+			xmlURL := fmt.Sprintf(
+				"https://www.sec.gov/Archives/edgar/data/%s/%s/primary_doc.xml",
+				cik, accessionClean,
+			)
+
+			xmlPath := filepath.Join(tempDir, fmt.Sprintf("form_d_%s.xml", accessionClean))
+			if _, dlErr := f.DownloadToFile(ctx, xmlURL, xmlPath); dlErr != nil {
+				// Fall back to search metadata if XML download fails.
+				filingDate := parseDate(src.FilingDate)
+				row := []any{accession, cik, src.EntityName, "", "", "", "", "", int64(0), int64(0), filingDate}
+				batch = append(batch, row)
+				continue
+			}
+
+			xmlFile, err := os.Open(xmlPath) // #nosec G304 -- path constructed from downloaded EDGAR filing in trusted temp directory
+			if err != nil {
+				_ = os.Remove(xmlPath)
+				continue
+			}
+
+			row, err := d.parseFormDXML(xmlFile, accession, cik, src.FilingDate)
+			_ = xmlFile.Close()
+			_ = os.Remove(xmlPath)
+
+			if err != nil {
+				log.Warn("form_d: parse XML failed", zap.String("accession", accession), zap.Error(err))
+				filingDate := parseDate(src.FilingDate)
+				row = []any{accession, cik, src.EntityName, "", "", "", "", "", int64(0), int64(0), filingDate}
+			}
 
-		batch = append(batch, row)
+			batch = append(batch, row)
 
-		if len(batch) >= formDBatchSize {
-			n, upsertErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-				Table: "fed_data.form_d", Columns: columns, ConflictKeys: conflictKeys,
-			}, batch)
-			if upsertErr != nil {
-				return nil, eris.Wrap(upsertErr, "form_d: upsert")
+			if len(batch) >= formDBatchSize {
+				n, upsertErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+					Table: "fed_data.form_d", Columns: columns, ConflictKeys: conflictKeys,
+				}, batch)
+				if upsertErr != nil {
+					return nil, eris.Wrap(upsertErr, "form_d: upsert")
+				}
+				totalRows += n
+				batch = batch[:0]
 			}
-			totalRows += n
-			batch = batch[:0]
+		}
+
+		if !eftsHasMore(from, len(result.Hits.Hits), found) {
+			break
 		}
 	}
 
@@ -192,12 +212,19 @@ func (d *FormD) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempD
 
 	log.Info("form_d sync complete", zap.Int64("rows", totalRows))
 
-	return &SyncResult{
+	result := &SyncResult{
 		RowsSynced: totalRows,
 		Metadata: map[string]any{
-			"filings_found": result.Hits.Total.Value,
+			"filings_found": found,
 		},
-	}, nil
+	}
+	if found > eftsMaxResults {
+		// Filings past the EFTS result window were never fetched.
+		log.Warn("form_d: search truncated, watermark held at window start",
+			zap.Int("found", found), zap.Int("max_results", eftsMaxResults))
+		result.Watermark = &start
+	}
+	return result, nil
 }
 
 func (d *FormD) parseFormDXML(r io.Reader, accession, cik, filingDateStr string) ([]any, error) {
@@ -45,8 +45,19 @@ func (d *FPDS) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return DailySchedule(now, lastSync)
 }
 
-// Sync fetches and loads SAM.gov FPDS contract data.
+// Sync fetches and loads SAM.gov FPDS contract data posted in the last 30 days.
 func (d *FPDS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, f, time.Now().AddDate(0, 0, -30))
+}
+
+// SyncIncremental implements IncrementalSyncer, loading contracts posted on
+// or after the watermark's date.
+func (d *FPDS) SyncIncremental(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string, since time.Time) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, f, since)
+}
+
+// syncSince pages through contracts posted from postedFrom through today.
+func (d *FPDS) syncSince(ctx context.Context, pool db.Pool, f fetcher.Fetcher, postedFrom time.Time) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", "fpds"))
 
 	apiKey := ""
@@ -76,7 +87,7 @@ func (d *FPDS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ stri
 			naicsFilter,
 			fpdsPageSize,
 			offset,
-			time.Now().AddDate(0, 0, -30).Format("01/02/2006"),
+			postedFrom.Format("01/02/2006"),
 			time.Now().Format("01/02/2006"),
 		)
 
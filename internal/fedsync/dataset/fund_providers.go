@@ -0,0 +1,47 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/advextract"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// FundProviderNetwork rebuilds the private fund auditor/administrator/prime
+// broker network from extracted ADV answers and Schedule D 7.B.(1) data.
+type FundProviderNetwork struct{}
+
+// Name implements Dataset.
+func (d *FundProviderNetwork) Name() string { return "fund_providers" }
+
+// Table implements Dataset.
+func (d *FundProviderNetwork) Table() string { return "fed_data.fund_provider_network" }
+
+// Phase implements Dataset.
+func (d *FundProviderNetwork) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *FundProviderNetwork) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *FundProviderNetwork) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync rebuilds the provider network table.
+func (d *FundProviderNetwork) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	n, err := advextract.BuildProviderNetwork(ctx, pool)
+	if err != nil {
+		return nil, eris.Wrap(err, "fund_providers: build")
+	}
+
+	log.Info("fund provider network built", zap.Int64("providers", n))
+	return &SyncResult{RowsSynced: n}, nil
+}
@@ -0,0 +1,70 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundProviderNetwork_Metadata(t *testing.T) {
+	d := &FundProviderNetwork{}
+	assert.Equal(t, "fund_providers", d.Name())
+	assert.Equal(t, "fed_data.fund_provider_network", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestFundProviderNetwork_SyncError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("FROM fed_data.adv_service_providers").WillReturnError(errors.New("boom"))
+
+	d := &FundProviderNetwork{}
+	_, err = d.Sync(context.Background(), pool, nil, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fund_providers: build")
+}
+
+func TestFundProviderType(t *testing.T) {
+	assert.Equal(t, "auditor", fundProviderType("ia_schedule_d_7b1a23_20250101.csv"))
+	assert.Equal(t, "prime_broker", fundProviderType("ia_schedule_d_7b1a24_20250101.csv"))
+	assert.Equal(t, "administrator", fundProviderType("ia_schedule_d_7b1a26_20250101.csv"))
+	assert.Empty(t, fundProviderType("ia_schedule_d_7b1_20250101.csv"))
+}
+
+func TestStreamFundProviderFile(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	path := filepath.Join(t.TempDir(), "IA_Schedule_D_7B1A23.csv")
+	require.NoError(t, os.WriteFile(path, []byte("FilingID,Fund ID,Fund Name,Auditing Firm Name,City\n"+
+		"100,805-1,Alpha Fund,KPMG LLP,New York\n"+
+		"100,805-1,Alpha Fund,kpmg llp,New York\n"+
+		"100,805-2,Beta Fund,Main Street Audit LLC,Dallas\n"+
+		"99,805-3,Stale Fund,KPMG LLP,Boston\n"+
+		"100,,Gamma Fund,KPMG LLP,Boston\n"), 0o644))
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.adv_fund_service_providers").
+		WithArgs("auditor", []int{123}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	expectBulkUpsert(pool, "fed_data.adv_fund_service_providers",
+		[]string{"crd_number", "fund_id", "provider_type", "provider_name"}, 2)
+	pool.ExpectCommit()
+
+	n, err := streamFundProviderFile(context.Background(), nestedStagingPool{pool}, path, "auditor", map[string]int64{"123": 100})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -3,10 +3,12 @@ package dataset
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,16 +17,45 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 const (
 	eftsSearchURL     = "https://efts.sec.gov/LATEST/search-index"
 	holdingsBatchSize = 5000
+
+	// eftsPageSize is the fixed number of hits EFTS returns per page, and
+	// eftsMaxResults the deepest result it will page to.
+	eftsPageSize   = 100
+	eftsMaxResults = 10000
 )
 
+// f13CoverColumns defines the f13_filers columns loaded from the cover page.
+var f13CoverColumns = []string{
+	"cik", "company_name", "street1", "street2", "city", "state", "zip",
+	"report_type", "file_number", "crd_number", "is_amendment",
+	"signer_name", "signer_title", "signer_phone", "signature_date",
+	"other_managers_count", "updated_at",
+}
+
+// f13OtherManagerColumns defines the f13_other_managers upsert columns.
+var f13OtherManagerColumns = []string{
+	"cik", "period", "role", "seq", "manager_cik", "manager_name", "file_number", "crd_number",
+}
+
 // Holdings13F implements the SEC 13F Holdings dataset.
-// Downloads 13F XML filings from EDGAR full-text search, parses holdings, and upserts.
+// Downloads 13F XML filings from EDGAR full-text search, parses the cover
+// page (manager address, signer, and other managers) and holdings, and
+// upserts. Other managers are stored in fed_data.f13_other_managers with
+// role "included" (managers whose holdings this report includes) or
+// "reporting" (managers reporting on this filer's behalf in a notice or
+// combination report).
+//
+// 13F-HR/A amendments are loaded alongside originals. Every filing is
+// recorded in fed_data.f13_filings; a restatement supersedes the earlier
+// filings for its CIK and period and replaces their holdings, while a NEW
+// HOLDINGS amendment adds to the current filing.
 type Holdings13F struct {
 	cfg *config.Config
 }
@@ -35,6 +66,23 @@ func (d *Holdings13F) Name() string { return "holdings_13f" }
 // Table implements Dataset.
 func (d *Holdings13F) Table() string { return "fed_data.f13_holdings" }
 
+// Columns implements Documented.
+func (d *Holdings13F) Columns() []ColumnDoc {
+	return []ColumnDoc{
+		{Column: "cik", Description: "Filer CIK"},
+		{Column: "period", Description: "Report period (quarter end)"},
+		{Column: "cusip", Description: "Security CUSIP"},
+		{Column: "value", Description: "Market value in dollars as reported"},
+		{Column: "shares", Description: "Shares or principal amount held"},
+		{Column: "sh_prn_type", Description: "SH (shares) or PRN (principal amount)"},
+		{Column: "put_call", Description: "PUT or CALL for option positions"},
+		{Column: "accession_number", Description: "Filing the holding was loaded from; NULL for holdings loaded before lineage was tracked"},
+		{Table: "fed_data.f13_filings", Column: "amendment_type", Description: "NULL for originals; RESTATEMENT or NEW HOLDINGS for 13F-HR/A"},
+		{Table: "fed_data.f13_filings", Column: "superseded_by", Description: "Accession of the later filing that replaced this one"},
+		{Table: "fed_data.f13_filers", Column: "total_value", Description: "Value of the filer's effective holdings for its latest period"},
+	}
+}
+
 // Phase implements Dataset.
 func (d *Holdings13F) Phase() Phase { return Phase1B }
 
@@ -57,6 +105,50 @@ type f13Holding struct {
 	PutCall    string `xml:"putCall"`
 }
 
+// f13Cover is the cover page, signature block, and summary page of a 13F
+// primary_doc.xml. Tags match by local name, so the com: address namespace
+// needs no special handling.
+type f13Cover struct {
+	XMLName       xml.Name
+	IsAmendment   string `xml:"formData>coverPage>isAmendment"`
+	AmendmentNo   string `xml:"formData>coverPage>amendmentNo"`
+	AmendmentType string `xml:"formData>coverPage>amendmentInfo>amendmentType"`
+	Manager       struct {
+		Name    string `xml:"name"`
+		Street1 string `xml:"address>street1"`
+		Street2 string `xml:"address>street2"`
+		City    string `xml:"address>city"`
+		State   string `xml:"address>stateOrCountry"`
+		Zip     string `xml:"address>zipCode"`
+	} `xml:"formData>coverPage>filingManager"`
+	ReportType string            `xml:"formData>coverPage>reportType"`
+	FileNumber string            `xml:"formData>coverPage>form13FFileNumber"`
+	CRDNumber  string            `xml:"formData>coverPage>crdNumber"`
+	Reporting  []f13OtherManager `xml:"formData>coverPage>otherManagersInfo>otherManager"`
+	Signature  f13Signature      `xml:"formData>signatureBlock"`
+	OtherCount string            `xml:"formData>summaryPage>otherIncludedManagersCount"`
+	Included   []struct {
+		Seq     string          `xml:"sequenceNumber"`
+		Manager f13OtherManager `xml:"otherManager"`
+	} `xml:"formData>summaryPage>otherManagers2Info>otherManager2"`
+}
+
+// f13OtherManager is another manager named on a 13F cover or summary page.
+type f13OtherManager struct {
+	CIK        string `xml:"cik"`
+	Name       string `xml:"name"`
+	FileNumber string `xml:"form13FFileNumber"`
+	CRDNumber  string `xml:"crdNumber"`
+}
+
+// f13Signature is the 13F signature block.
+type f13Signature struct {
+	Name  string `xml:"name"`
+	Title string `xml:"title"`
+	Phone string `xml:"phone"`
+	Date  string `xml:"signatureDate"`
+}
+
 // eftsSearchResult is the response from the EDGAR full-text search API.
 type eftsSearchResult struct {
 	Hits struct {
@@ -92,16 +184,92 @@ func (t *eftsTotal) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &t.Value)
 }
 
-// Sync fetches and loads SEC 13F holdings data.
+// eftsHasMore reports whether another EFTS page follows the page of n hits
+// starting at from, out of total. EFTS serves at most eftsMaxResults hits
+// per query.
+func eftsHasMore(from, n, total int) bool {
+	next := from + eftsPageSize
+	return n > 0 && from+n < total && next < eftsMaxResults
+}
+
+// Sync fetches and loads SEC 13F holdings data filed since the most recent
+// quarter-end for which data should be available.
 func (d *Holdings13F) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
-	log := zap.L().With(zap.String("dataset", "holdings_13f"))
+	qEnd := mostRecentQuarterEnd(time.Now().UTC().AddDate(0, 0, -45))
+	return d.syncSince(ctx, pool, f, tempDir, qEnd.AddDate(0, 0, 1))
+}
+
+// PlanResync implements SliceResyncer: the holdings reported for a quarter,
+// or for every quarter of a year. Filers are upserted, not deleted.
+func (d *Holdings13F) PlanResync(s Slice) (SlicePlan, error) {
+	if s.State != "" {
+		return SlicePlan{}, eris.New("holdings_13f: has no state column; resync by year or quarter only")
+	}
+	qs := s.Period.Quarters()
+	return SlicePlan{
+		Delete: "DELETE FROM fed_data.f13_holdings WHERE period BETWEEN $1 AND $2",
+		Args:   []any{qs[0].QuarterEnd(), qs[len(qs)-1].QuarterEnd()},
+		Load:   s.Period,
+	}, nil
+}
+
+// SyncPeriod implements PeriodSyncer. For each quarter in period it loads
+// the 13F-HR filings filed in the following quarter, which is when holdings
+// as of that quarter-end are reported (due within 45 days).
+func (d *Holdings13F) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	now := time.Now().UTC()
+	var total int64
+	var found int
+	for _, q := range period.Quarters() {
+		qEnd := q.QuarterEnd()
+		if !qEnd.Before(now) {
+			return nil, eris.Errorf("holdings_13f: quarter %s has not ended", q)
+		}
+		end := q.next().QuarterEnd()
+		if end.After(now) {
+			end = now
+		}
+		res, err := d.syncWindow(ctx, pool, f, tempDir, qEnd.AddDate(0, 0, 1), end, qEnd.Format("2006-01-02"))
+		if err != nil {
+			return nil, eris.Wrapf(err, "holdings_13f: backfill %s", q)
+		}
+		total += res.RowsSynced
+		if n, ok := res.Metadata["filings_found"].(int); ok {
+			found += n
+		}
+	}
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata:   map[string]any{"period": period.String(), "filings_found": found},
+	}, nil
+}
+
+// SyncIncremental implements IncrementalSyncer, loading 13F filings filed on
+// or after the watermark's date, including late filings and amendments for
+// earlier quarters.
+func (d *Holdings13F) SyncIncremental(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, since time.Time) (*SyncResult, error) {
+	return d.syncSince(ctx, pool, f, tempDir, since.UTC())
+}
 
-	// Determine the most recent quarter-end for which data should be available.
+// syncSince pages through 13F-HR and 13F-HR/A filings filed from start
+// through today. The result's Watermark holds the next run back to the
+// earliest filing that was not loaded (see syncWindow).
+func (d *Holdings13F) syncSince(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, start time.Time) (*SyncResult, error) {
 	now := time.Now().UTC()
-	qEnd := mostRecentQuarterEnd(now.AddDate(0, 0, -45))
-	period := qEnd.Format("2006-01-02")
-	startDate := qEnd.AddDate(0, 0, 1).Format("2006-01-02")
-	endDate := now.Format("2006-01-02")
+	period := mostRecentQuarterEnd(now.AddDate(0, 0, -45)).Format("2006-01-02")
+	return d.syncWindow(ctx, pool, f, tempDir, start, now, period)
+}
+
+// syncWindow pages through 13F-HR and 13F-HR/A filings filed from start through end.
+// period labels the quarter-end being loaded in logs and metadata. When a
+// filing fails to load, Watermark is set to its filing date so an
+// incremental run retries from there; when EFTS truncates the results,
+// Watermark stays at start.
+func (d *Holdings13F) syncWindow(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, start, end time.Time, period string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", "holdings_13f"))
+
+	startDate := start.Format("2006-01-02")
+	endDate := end.Format("2006-01-02")
 
 	log.Info("searching for 13F filings",
 		zap.String("period", period),
@@ -109,100 +277,149 @@ func (d *Holdings13F) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher,
 		zap.String("end_date", endDate),
 	)
 
-	// Search for 13F-HR filings via EFTS.
-	searchURL := fmt.Sprintf(
-		"%s?q=*&dateRange=custom&startdt=%s&enddt=%s&forms=13F-HR&from=0&size=200",
-		eftsSearchURL, startDate, endDate,
-	)
-
-	body, err := f.Download(ctx, searchURL)
-	if err != nil {
-		return nil, eris.Wrap(err, "holdings_13f: search EFTS")
-	}
-
-	searchResult, err := fetcher.DecodeJSONObject[eftsSearchResult](body)
-	_ = body.Close()
-	if err != nil {
-		return nil, eris.Wrap(err, "holdings_13f: decode search results")
+	var totalRows int64
+	var found, superseded, failed int
+	var retryFrom *time.Time
+	markFailed := func(filingDate *time.Time) {
+		failed++
+		if filingDate == nil {
+			filingDate = &start
+		}
+		if retryFrom == nil || filingDate.Before(*retryFrom) {
+			retryFrom = filingDate
+		}
 	}
 
-	log.Info("found 13F filings", zap.Int("total", searchResult.Hits.Total.Value))
+	for from := 0; ; from += eftsPageSize {
+		// Search for 13F-HR filings and their amendments via EFTS.
+		searchURL := fmt.Sprintf(
+			"%s?q=*&dateRange=custom&startdt=%s&enddt=%s&forms=13F-HR,13F-HR/A&from=%d&size=%d",
+			eftsSearchURL, startDate, endDate, from, eftsPageSize,
+		)
 
-	var totalRows int64
+		body, err := f.Download(ctx, searchURL)
+		if err != nil {
+			return nil, eris.Wrap(err, "holdings_13f: search EFTS")
+		}
 
-	for _, hit := range searchResult.Hits.Hits {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+		searchResult, err := fetcher.DecodeJSONObject[eftsSearchResult](body)
+		_ = body.Close()
+		if err != nil {
+			return nil, eris.Wrap(err, "holdings_13f: decode search results")
+		}
+		found = searchResult.Hits.Total.Value
+		if from == 0 {
+			log.Info("found 13F filings", zap.Int("total", found))
 		}
 
-		src := hit.Source
-		cik := strings.TrimLeft(src.CIK, "0")
-		accession := strings.ReplaceAll(src.AccessionNumber, "-", "")
+		for _, hit := range searchResult.Hits.Hits {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
 
-		periodDate := parseDate(src.PeriodOfReport)
-		filingDate := parseDate(src.FilingDate)
+			src := hit.Source
+			cik := strings.TrimLeft(src.CIK, "0")
+			accession := strings.ReplaceAll(src.AccessionNumber, "-", "")
 
-		// Upsert filer record
-		filerCols := []string{"cik", "company_name", "form_type", "filing_date", "period_of_report", "total_value"}
-		filerRow := []any{cik, src.CompanyName, src.FormType, filingDate, periodDate, int64(0)}
-		if _, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table: "fed_data.f13_filers", Columns: filerCols, ConflictKeys: []string{"cik"},
-		}, [][]any{filerRow}); err != nil {
-			log.Warn("holdings_13f: upsert filer failed", zap.String("cik", cik), zap.Error(err))
-			continue
-		}
+			periodDate := parseDate(src.PeriodOfReport)
+			filingDate := parseDate(src.FilingDate)
 
-		// Download the 13F holdings XML.
-		holdingsURL := fmt.Sprintf(
-			"https://www.sec.gov/Archives/edgar/data/%s/%s/primary_doc.xml",
-			cik, accession,
-		)
+			// Upsert filer record
+			filerCols := []string{"cik", "company_name", "form_type", "filing_date", "period_of_report", "total_value"}
+			filerRow := []any{cik, src.CompanyName, src.FormType, filingDate, periodDate, int64(0)}
+			if _, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table: "fed_data.f13_filers", Columns: filerCols, ConflictKeys: []string{"cik"},
+			}, [][]any{filerRow}); err != nil {
+				log.Warn("holdings_13f: upsert filer failed", zap.String("cik", cik), zap.Error(err))
+				markFailed(filingDate)
+				continue
+			}
 
-		rows, err := d.downloadAndParseHoldings(ctx, f, pool, holdingsURL, cik, periodDate, tempDir, log)
-		if err != nil {
-			log.Warn("holdings_13f: parse holdings failed",
-				zap.String("cik", cik),
-				zap.String("accession", src.AccessionNumber),
-				zap.Error(err),
+			// Download the 13F holdings XML.
+			holdingsURL := fmt.Sprintf(
+				"https://www.sec.gov/Archives/edgar/data/%s/%s/primary_doc.xml",
+				cik, accession,
 			)
-			continue
-		}
 
-		// Update filer total_value
-		totalValue := d.sumHoldingsValue(rows)
-		if _, err := pool.Exec(ctx,
-			"UPDATE fed_data.f13_filers SET total_value = $1 WHERE cik = $2",
-			totalValue, cik,
-		); err != nil {
-			log.Warn("holdings_13f: update filer total_value", zap.Error(err))
+			filing := &f13Filing{
+				Accession:  src.AccessionNumber,
+				CIK:        cik,
+				Period:     periodDate,
+				FormType:   src.FormType,
+				FilingDate: filingDate,
+			}
+			rows, err := d.downloadAndParseHoldings(ctx, f, pool, holdingsURL, filing, src.CompanyName, tempDir, log)
+			if err != nil {
+				log.Warn("holdings_13f: parse holdings failed",
+					zap.String("cik", cik),
+					zap.String("accession", src.AccessionNumber),
+					zap.Error(err),
+				)
+				markFailed(filingDate)
+				continue
+			}
+			if filing.SupersededBy != "" {
+				log.Debug("holdings_13f: filing already superseded, skipping holdings",
+					zap.String("accession", src.AccessionNumber),
+					zap.String("superseded_by", filing.SupersededBy),
+				)
+				superseded++
+				continue
+			}
+
+			if err := recordF13Totals(ctx, pool, filing, len(rows), d.sumHoldingsValue(rows)); err != nil {
+				log.Warn("holdings_13f: record totals", zap.Error(err))
+			}
+
+			totalRows += int64(len(rows))
 		}
 
-		totalRows += int64(len(rows))
+		if !eftsHasMore(from, len(searchResult.Hits.Hits), found) {
+			break
+		}
 	}
 
-	log.Info("holdings_13f sync complete", zap.Int64("holdings", totalRows))
+	log.Info("holdings_13f sync complete", zap.Int64("holdings", totalRows), zap.Int("failed", failed))
 
-	return &SyncResult{
+	result := &SyncResult{
 		RowsSynced: totalRows,
 		Metadata: map[string]any{
 			"period":        period,
-			"filings_found": searchResult.Hits.Total.Value,
+			"filings_found": found,
+			"superseded":    superseded,
+			"failed":        failed,
 		},
-	}, nil
+	}
+	switch {
+	case found > eftsMaxResults:
+		// Filings past the EFTS result window were never fetched.
+		log.Warn("holdings_13f: search truncated, watermark held at window start",
+			zap.Int("found", found), zap.Int("max_results", eftsMaxResults))
+		result.Watermark = &start
+	case retryFrom != nil:
+		mark := retryFrom.UTC()
+		result.Watermark = &mark
+	}
+	return result, nil
 }
 
+// downloadAndParseHoldings downloads a filing's primary_doc.xml, loads its
+// cover page, settles its amendment lineage, and upserts its holdings.
+// Returns no rows, with filing.SupersededBy set, when a later filing
+// already replaced this one.
 func (d *Holdings13F) downloadAndParseHoldings(
 	ctx context.Context,
 	f fetcher.Fetcher,
 	pool db.Pool,
 	url string,
-	cik string,
-	period *time.Time,
+	filing *f13Filing,
+	name string,
 	tempDir string,
 	log *zap.Logger,
 ) ([][]any, error) {
+	cik, period := filing.CIK, filing.Period
 	xmlPath := filepath.Join(tempDir, fmt.Sprintf("13f_%s.xml", cik))
 	if _, err := f.DownloadToFile(ctx, url, xmlPath); err != nil {
 		return nil, eris.Wrapf(err, "download 13F holdings for %s", cik)
@@ -215,7 +432,135 @@ func (d *Holdings13F) downloadAndParseHoldings(
 	}
 	defer file.Close() //nolint:errcheck
 
-	return d.parseHoldingsXML(ctx, pool, file, cik, period, log)
+	// The cover page is best effort: a malformed cover should not drop the
+	// holdings.
+	cover, err := parseF13Cover(file)
+	if err != nil {
+		log.Warn("holdings_13f: parse cover page failed", zap.String("cik", cik), zap.Error(err))
+	} else if cover != nil {
+		if err := d.upsertCover(ctx, pool, cover, cik, name, period); err != nil {
+			log.Warn("holdings_13f: upsert cover page failed", zap.String("cik", cik), zap.Error(err))
+		}
+	}
+	filing.applyCover(cover)
+	if err := claimF13Filing(ctx, pool, filing); err != nil {
+		return nil, err
+	}
+	if filing.SupersededBy != "" {
+		return nil, nil
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, eris.Wrap(err, "rewind 13F XML")
+	}
+
+	return d.parseHoldingsXML(ctx, pool, file, cik, period, filing.Accession, log)
+}
+
+// parseF13Cover decodes the cover page of a 13F primary_doc.xml. Returns
+// nil when the document is not an edgarSubmission (e.g. a bare information
+// table).
+func parseF13Cover(r io.Reader) (*f13Cover, error) {
+	var cover f13Cover
+	if err := xml.NewDecoder(r).Decode(&cover); err != nil {
+		return nil, eris.Wrap(err, "decode 13F cover page")
+	}
+	if cover.XMLName.Local != "edgarSubmission" {
+		return nil, nil
+	}
+	return &cover, nil
+}
+
+// upsertCover loads the cover page into f13_filers and replaces the filing
+// period's other managers. name is the EFTS entity name, used when the
+// cover omits the manager name.
+func (d *Holdings13F) upsertCover(ctx context.Context, pool db.Pool, cover *f13Cover, cik, name string, period *time.Time) error {
+	now := time.Now()
+	if n := strings.TrimSpace(cover.Manager.Name); n != "" {
+		name = n
+	}
+	filer := []any{
+		cik,
+		fitLen(sanitizeUTF8(name), 200),
+		nilIfEmpty(strings.TrimSpace(cover.Manager.Street1)),
+		nilIfEmpty(strings.TrimSpace(cover.Manager.Street2)),
+		nilIfEmpty(strings.TrimSpace(cover.Manager.City)),
+		nilIfEmpty(fitLen(strings.ToUpper(strings.TrimSpace(cover.Manager.State)), 2)),
+		nilIfEmpty(fitLen(strings.TrimSpace(cover.Manager.Zip), 10)),
+		nilIfEmpty(strings.TrimSpace(cover.ReportType)),
+		nilIfEmpty(strings.TrimSpace(cover.FileNumber)),
+		nilIfEmpty(strings.TrimSpace(cover.CRDNumber)),
+		strings.EqualFold(strings.TrimSpace(cover.IsAmendment), "true"),
+		nilIfEmpty(strings.TrimSpace(cover.Signature.Name)),
+		nilIfEmpty(strings.TrimSpace(cover.Signature.Title)),
+		nilIfEmpty(strings.TrimSpace(cover.Signature.Phone)),
+		parseDate(cover.Signature.Date),
+		parseInt64OrNil(strings.TrimSpace(cover.OtherCount)),
+		now,
+	}
+	if _, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table: "fed_data.f13_filers", Columns: f13CoverColumns, ConflictKeys: []string{"cik"},
+	}, [][]any{filer}); err != nil {
+		return eris.Wrap(err, "upsert filer cover")
+	}
+
+	if period == nil {
+		return nil
+	}
+	if _, err := pool.Exec(ctx,
+		"DELETE FROM fed_data.f13_other_managers WHERE cik = $1 AND period = $2",
+		cik, *period,
+	); err != nil {
+		return eris.Wrap(err, "clear other managers")
+	}
+	rows := f13OtherManagerRows(cover, cik, *period)
+	if len(rows) == 0 {
+		return nil
+	}
+	if _, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table: "fed_data.f13_other_managers", Columns: f13OtherManagerColumns,
+		ConflictKeys: []string{"cik", "period", "role", "seq"},
+	}, rows); err != nil {
+		return eris.Wrap(err, "upsert other managers")
+	}
+	return nil
+}
+
+// f13OtherManagerRows maps the cover's reporting managers and the summary
+// page's included managers to f13OtherManagerColumns. Included managers keep
+// their filed sequence number; reporting managers are numbered in order.
+func f13OtherManagerRows(cover *f13Cover, cik string, period time.Time) [][]any {
+	var rows [][]any
+	add := func(role string, seq int, m f13OtherManager) {
+		name := strings.TrimSpace(m.Name)
+		if name == "" && strings.TrimSpace(m.FileNumber) == "" {
+			return
+		}
+		var managerCIK any
+		if n, err := strconv.ParseInt(strings.TrimSpace(m.CIK), 10, 64); err == nil && n > 0 {
+			managerCIK = strconv.FormatInt(n, 10) // unpadded, like f13_filers.cik
+		}
+		rows = append(rows, []any{
+			cik,
+			period,
+			role,
+			seq,
+			managerCIK,
+			nilIfEmpty(sanitizeUTF8(name)),
+			nilIfEmpty(strings.TrimSpace(m.FileNumber)),
+			nilIfEmpty(strings.TrimSpace(m.CRDNumber)),
+		})
+	}
+	for i, m := range cover.Reporting {
+		add("reporting", i+1, m)
+	}
+	for i, info := range cover.Included {
+		seq, err := strconv.Atoi(strings.TrimSpace(info.Seq))
+		if err != nil || seq < 1 {
+			seq = i + 1
+		}
+		add("included", seq, info.Manager)
+	}
+	return rows
 }
 
 func (d *Holdings13F) parseHoldingsXML(
@@ -224,11 +569,12 @@ func (d *Holdings13F) parseHoldingsXML(
 	r io.Reader,
 	cik string,
 	period *time.Time,
+	accession string,
 	_ *zap.Logger,
 ) ([][]any, error) {
 	holdingCh, errCh := fetcher.StreamXML[f13Holding](ctx, r, "infoTable")
 
-	columns := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call"}
+	columns := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call", "accession_number"}
 	conflictKeys := []string{"cik", "period", "cusip"}
 
 	var batch [][]any
@@ -237,6 +583,7 @@ func (d *Holdings13F) parseHoldingsXML(
 	for h := range holdingCh {
 		cusip := strings.TrimSpace(h.CUSIP)
 		if len(cusip) < 9 {
+			fedsync.Reject(ctx, "bad_cusip", map[string]any{"cik": cik, "holding": h})
 			continue
 		}
 
@@ -250,6 +597,7 @@ func (d *Holdings13F) parseHoldingsXML(
 			h.Shares,
 			strings.TrimSpace(h.ShPrnType),
 			strings.TrimSpace(h.PutCall),
+			nilIfEmpty(accession),
 		}
 		batch = append(batch, row)
 		allRows = append(allRows, row)
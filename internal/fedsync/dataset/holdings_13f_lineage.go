@@ -0,0 +1,162 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// 13F-HR/A amendment types, from the cover page's amendmentInfo.
+const (
+	f13Restatement = "RESTATEMENT"
+	f13NewHoldings = "NEW HOLDINGS"
+)
+
+// f13Filing is one 13F-HR or 13F-HR/A filing being loaded, with the
+// lineage recorded in fed_data.f13_filings.
+type f13Filing struct {
+	Accession     string // dashed, as EFTS reports it
+	CIK           string
+	Period        *time.Time
+	FormType      string
+	FilingDate    *time.Time
+	AmendmentNo   int
+	AmendmentType string // "" for an original
+	SupersededBy  string // set when a later filing already replaced this one
+}
+
+// tracked reports whether the filing can take part in lineage: it needs an
+// accession number and a report period to be compared with other filings.
+func (fl *f13Filing) tracked() bool {
+	return fl != nil && fl.Accession != "" && fl.Period != nil
+}
+
+// replaces reports whether the filing replaces the period's holdings (an
+// original or a restatement) rather than adding to them (NEW HOLDINGS).
+func (fl *f13Filing) replaces() bool {
+	return fl.AmendmentType != f13NewHoldings
+}
+
+// applyCover sets the amendment number and type from the cover page. A
+// 13F-HR/A whose cover is missing or omits the amendment type is treated as
+// a restatement, the safer reading: it replaces the period rather than
+// being added on top of it.
+func (fl *f13Filing) applyCover(cover *f13Cover) {
+	amended := strings.HasSuffix(strings.ToUpper(strings.TrimSpace(fl.FormType)), "/A")
+	var typ string
+	if cover != nil {
+		amended = amended || strings.EqualFold(strings.TrimSpace(cover.IsAmendment), "true")
+		if n, err := strconv.Atoi(strings.TrimSpace(cover.AmendmentNo)); err == nil && n > 0 {
+			fl.AmendmentNo = n
+		}
+		typ = strings.ToUpper(strings.Join(strings.Fields(cover.AmendmentType), " "))
+	}
+	switch {
+	case !amended:
+		fl.AmendmentType = ""
+	case typ == f13NewHoldings:
+		fl.AmendmentType = f13NewHoldings
+	default:
+		fl.AmendmentType = f13Restatement
+	}
+}
+
+// claimF13Filing records fl in f13_filings and settles its lineage before
+// its holdings are loaded. When an original or restatement filed after fl
+// is already loaded for the same CIK and period, fl is recorded as
+// superseded by it and SupersededBy is set; its holdings must not be
+// loaded. Otherwise, if fl replaces the period, earlier filings are marked
+// superseded by fl and their holdings, along with any loaded before lineage
+// was tracked, are deleted.
+func claimF13Filing(ctx context.Context, pool db.Pool, fl *f13Filing) error {
+	if !fl.tracked() {
+		return nil
+	}
+	var amendType any
+	if fl.AmendmentType != "" {
+		amendType = fl.AmendmentType
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO fed_data.f13_filings
+		(accession_number, cik, period, form_type, filing_date, amendment_no, amendment_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (accession_number) DO UPDATE SET
+			form_type = EXCLUDED.form_type, filing_date = EXCLUDED.filing_date,
+			amendment_no = EXCLUDED.amendment_no, amendment_type = EXCLUDED.amendment_type,
+			loaded_at = now()`,
+		fl.Accession, fl.CIK, *fl.Period, fl.FormType, fl.FilingDate, fl.AmendmentNo, amendType,
+	); err != nil {
+		return eris.Wrapf(err, "record 13F filing %s", fl.Accession)
+	}
+
+	var later string
+	err := pool.QueryRow(ctx, `SELECT accession_number FROM fed_data.f13_filings
+		WHERE cik = $1 AND period = $2 AND accession_number <> $3
+			AND amendment_type IS DISTINCT FROM 'NEW HOLDINGS'
+			AND (filing_date, amendment_no) > ($4, $5)
+		ORDER BY filing_date DESC, amendment_no DESC
+		LIMIT 1`,
+		fl.CIK, *fl.Period, fl.Accession, fl.FilingDate, fl.AmendmentNo,
+	).Scan(&later)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return eris.Wrapf(err, "find filing superseding %s", fl.Accession)
+	default:
+		if _, err := pool.Exec(ctx,
+			"UPDATE fed_data.f13_filings SET superseded_by = $2 WHERE accession_number = $1",
+			fl.Accession, later,
+		); err != nil {
+			return eris.Wrapf(err, "mark 13F filing %s superseded", fl.Accession)
+		}
+		fl.SupersededBy = later
+		return nil
+	}
+	if !fl.replaces() {
+		return nil
+	}
+
+	if _, err := pool.Exec(ctx, `UPDATE fed_data.f13_filings SET superseded_by = $3
+		WHERE cik = $1 AND period = $2 AND accession_number <> $3 AND superseded_by IS NULL
+			AND (filing_date, amendment_no) <= ($4, $5)`,
+		fl.CIK, *fl.Period, fl.Accession, fl.FilingDate, fl.AmendmentNo,
+	); err != nil {
+		return eris.Wrapf(err, "supersede filings replaced by %s", fl.Accession)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM fed_data.f13_holdings
+		WHERE cik = $1 AND period = $2 AND (accession_number IS NULL OR accession_number IN (
+			SELECT accession_number FROM fed_data.f13_filings WHERE superseded_by = $3))`,
+		fl.CIK, *fl.Period, fl.Accession,
+	); err != nil {
+		return eris.Wrapf(err, "delete holdings replaced by %s", fl.Accession)
+	}
+	return nil
+}
+
+// recordF13Totals stores the filing's holding count and value on its
+// f13_filings row and recomputes the filer's total_value from the period's
+// effective holdings, so amendments never double count.
+func recordF13Totals(ctx context.Context, pool db.Pool, fl *f13Filing, holdings int, value int64) error {
+	if !fl.tracked() {
+		_, err := pool.Exec(ctx, "UPDATE fed_data.f13_filers SET total_value = $1 WHERE cik = $2", value, fl.CIK)
+		return eris.Wrap(err, "update filer total_value")
+	}
+	if _, err := pool.Exec(ctx,
+		"UPDATE fed_data.f13_filings SET holdings = $2, total_value = $3 WHERE accession_number = $1",
+		fl.Accession, holdings, value,
+	); err != nil {
+		return eris.Wrapf(err, "record totals for 13F filing %s", fl.Accession)
+	}
+	_, err := pool.Exec(ctx, `UPDATE fed_data.f13_filers SET total_value = (
+			SELECT COALESCE(SUM(value), 0) FROM fed_data.f13_holdings WHERE cik = $1 AND period = $2)
+		WHERE cik = $1`,
+		fl.CIK, *fl.Period,
+	)
+	return eris.Wrap(err, "update filer total_value")
+}
@@ -1,12 +1,79 @@
 package dataset
 
 import (
+	"context"
+	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
+const f13CoverXML = `<?xml version="1.0" encoding="UTF-8"?>
+<edgarSubmission xmlns="http://www.sec.gov/edgar/thirteenffiler" xmlns:com="http://www.sec.gov/edgar/common">
+  <headerData><submissionType>13F-HR</submissionType></headerData>
+  <formData>
+    <coverPage>
+      <reportCalendarOrQuarter>03-31-2025</reportCalendarOrQuarter>
+      <isAmendment>false</isAmendment>
+      <filingManager>
+        <name>Example Capital Management LLC</name>
+        <address>
+          <com:street1>100 Main Street</com:street1>
+          <com:street2>Suite 400</com:street2>
+          <com:city>Omaha</com:city>
+          <com:stateOrCountry>ne</com:stateOrCountry>
+          <com:zipCode>68131</com:zipCode>
+        </address>
+      </filingManager>
+      <reportType>13F COMBINATION REPORT</reportType>
+      <form13FFileNumber>028-12345</form13FFileNumber>
+      <crdNumber>000123456</crdNumber>
+      <otherManagersInfo>
+        <otherManager>
+          <cik>0000999999</cik>
+          <form13FFileNumber>028-99999</form13FFileNumber>
+          <name>Parent Advisors LP</name>
+        </otherManager>
+      </otherManagersInfo>
+    </coverPage>
+    <signatureBlock>
+      <name>Jane Doe</name>
+      <title>Chief Compliance Officer</title>
+      <phone>402-555-0100</phone>
+      <signature>/s/ Jane Doe</signature>
+      <city>Omaha</city>
+      <stateOrCountry>NE</stateOrCountry>
+      <signatureDate>05-14-2025</signatureDate>
+    </signatureBlock>
+    <summaryPage>
+      <otherIncludedManagersCount>2</otherIncludedManagersCount>
+      <otherManagers2Info>
+        <otherManager2>
+          <sequenceNumber>1</sequenceNumber>
+          <otherManager><cik>1234</cik><form13FFileNumber>028-11111</form13FFileNumber><name>Sub Adviser One LLC</name></otherManager>
+        </otherManager2>
+        <otherManager2>
+          <sequenceNumber>2</sequenceNumber>
+          <otherManager><form13FFileNumber>028-22222</form13FFileNumber><name>Sub Adviser Two LLC</name></otherManager>
+        </otherManager2>
+        <otherManager2>
+          <sequenceNumber>3</sequenceNumber>
+          <otherManager></otherManager>
+        </otherManager2>
+      </otherManagers2Info>
+    </summaryPage>
+  </formData>
+</edgarSubmission>`
+
 func TestHoldings13F_Name(t *testing.T) {
 	d := &Holdings13F{}
 	assert.Equal(t, "holdings_13f", d.Name())
@@ -81,3 +148,268 @@ func TestHoldings13F_SumHoldingsValue_Empty(t *testing.T) {
 	d := &Holdings13F{}
 	assert.Equal(t, int64(0), d.sumHoldingsValue(nil))
 }
+
+func TestParseF13Cover(t *testing.T) {
+	cover, err := parseF13Cover(strings.NewReader(f13CoverXML))
+	require.NoError(t, err)
+	assert.Equal(t, "Example Capital Management LLC", cover.Manager.Name)
+	assert.Equal(t, "100 Main Street", cover.Manager.Street1)
+	assert.Equal(t, "Omaha", cover.Manager.City)
+	assert.Equal(t, "68131", cover.Manager.Zip)
+	assert.Equal(t, "028-12345", cover.FileNumber)
+	assert.Equal(t, "Jane Doe", cover.Signature.Name)
+	assert.Equal(t, "05-14-2025", cover.Signature.Date)
+	assert.Len(t, cover.Reporting, 1)
+	assert.Len(t, cover.Included, 3)
+
+	_, err = parseF13Cover(strings.NewReader("<edgarSubmission>"))
+	assert.Error(t, err)
+
+	cover, err = parseF13Cover(strings.NewReader("<informationTable><infoTable/></informationTable>"))
+	require.NoError(t, err)
+	assert.Nil(t, cover, "not a cover page")
+}
+
+func TestF13OtherManagerRows(t *testing.T) {
+	cover, err := parseF13Cover(strings.NewReader(f13CoverXML))
+	require.NoError(t, err)
+
+	period := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	rows := f13OtherManagerRows(cover, "1001", period)
+	require.Len(t, rows, 3, "empty manager dropped")
+	assert.Equal(t, []any{"1001", period, "reporting", 1, "999999", "Parent Advisors LP", "028-99999", nil}, rows[0])
+	assert.Equal(t, "included", rows[1][2])
+	assert.Equal(t, "1234", rows[1][4])
+	assert.Equal(t, 2, rows[2][3])
+	assert.Nil(t, rows[2][4], "no CIK")
+}
+
+func TestHoldings13F_UpsertCover(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cover, err := parseF13Cover(strings.NewReader(f13CoverXML))
+	require.NoError(t, err)
+	period := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	expectBulkUpsert(pool, "fed_data.f13_filers", f13CoverColumns, 1)
+	pool.ExpectExec("DELETE FROM fed_data.f13_other_managers").
+		WithArgs("1001", period).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	expectBulkUpsert(pool, "fed_data.f13_other_managers", f13OtherManagerColumns, 3)
+
+	d := &Holdings13F{}
+	require.NoError(t, d.upsertCover(context.Background(), pool, cover, "1001", "EXAMPLE CAPITAL", &period))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestHoldings13F_UpsertCover_NoPeriod(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	expectBulkUpsert(pool, "fed_data.f13_filers", f13CoverColumns, 1)
+
+	d := &Holdings13F{}
+	require.NoError(t, d.upsertCover(context.Background(), pool, &f13Cover{}, "1001", "EXAMPLE CAPITAL", nil))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEFTSHasMore(t *testing.T) {
+	assert.True(t, eftsHasMore(0, 100, 250))
+	assert.True(t, eftsHasMore(100, 100, 250))
+	assert.False(t, eftsHasMore(200, 50, 250))
+	assert.False(t, eftsHasMore(0, 0, 250), "empty page")
+	assert.False(t, eftsHasMore(9900, 100, 50000), "result window limit")
+}
+
+func TestHoldings13F_SyncPeriod(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "startdt=2020-07-01&enddt=2020-09-30&forms=13F-HR")
+	})).Return(io.NopCloser(strings.NewReader(`{"hits":{"total":{"value":0},"hits":[]}}`)), nil).Once()
+
+	d := &Holdings13F{}
+	res, err := d.SyncPeriod(context.Background(), nil, f, t.TempDir(), Period{Year: 2020, Quarter: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.RowsSynced)
+	assert.Equal(t, "2020Q2", res.Metadata["period"])
+
+	_, err = d.SyncPeriod(context.Background(), nil, f, t.TempDir(), Period{Year: time.Now().Year() + 1, Quarter: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not ended")
+}
+
+func TestF13Filing_ApplyCover(t *testing.T) {
+	amendCover := func(no, typ string) *f13Cover {
+		return &f13Cover{IsAmendment: "true", AmendmentNo: no, AmendmentType: typ}
+	}
+	tests := []struct {
+		name     string
+		formType string
+		cover    *f13Cover
+		wantNo   int
+		wantType string
+	}{
+		{"original", "13F-HR", &f13Cover{IsAmendment: "false"}, 0, ""},
+		{"restatement", "13F-HR/A", amendCover("2", "RESTATEMENT"), 2, f13Restatement},
+		{"new holdings", "13F-HR/A", amendCover("1", "new  holdings"), 1, f13NewHoldings},
+		{"amendment without type", "13F-HR/A", amendCover("", ""), 0, f13Restatement},
+		{"amendment without cover", "13F-HR/A", nil, 0, f13Restatement},
+		{"cover flags amendment", "13F-HR", amendCover("3", "NEW HOLDINGS"), 3, f13NewHoldings},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fl := &f13Filing{FormType: tt.formType}
+			fl.applyCover(tt.cover)
+			assert.Equal(t, tt.wantNo, fl.AmendmentNo)
+			assert.Equal(t, tt.wantType, fl.AmendmentType)
+		})
+	}
+}
+
+func TestParseF13Cover_Amendment(t *testing.T) {
+	doc := strings.Replace(f13CoverXML, "<isAmendment>false</isAmendment>",
+		"<isAmendment>true</isAmendment><amendmentNo>1</amendmentNo>"+
+			"<amendmentInfo><amendmentType>NEW HOLDINGS</amendmentType></amendmentInfo>", 1)
+	cover, err := parseF13Cover(strings.NewReader(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "1", cover.AmendmentNo)
+	assert.Equal(t, "NEW HOLDINGS", cover.AmendmentType)
+}
+
+func testF13Filing(accession, amendType string, filed time.Time, amendNo int) *f13Filing {
+	period := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	formType := "13F-HR"
+	if amendType != "" {
+		formType = "13F-HR/A"
+	}
+	return &f13Filing{
+		Accession: accession, CIK: "1001", Period: &period, FormType: formType,
+		FilingDate: &filed, AmendmentNo: amendNo, AmendmentType: amendType,
+	}
+}
+
+func TestClaimF13Filing_Untracked(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.NoError(t, claimF13Filing(context.Background(), pool, &f13Filing{CIK: "1001"}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestClaimF13Filing_RestatementSupersedesEarlier(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	fl := testF13Filing("0001001-25-000002", f13Restatement, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 1)
+	pool.ExpectExec("INSERT INTO fed_data.f13_filings").
+		WithArgs(fl.Accession, "1001", *fl.Period, "13F-HR/A", fl.FilingDate, 1, f13Restatement).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectQuery("SELECT accession_number FROM fed_data.f13_filings").
+		WithArgs("1001", *fl.Period, fl.Accession, fl.FilingDate, 1).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectExec("UPDATE fed_data.f13_filings SET superseded_by = \\$3").
+		WithArgs("1001", *fl.Period, fl.Accession, fl.FilingDate, 1).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec("DELETE FROM fed_data.f13_holdings").
+		WithArgs("1001", *fl.Period, fl.Accession).
+		WillReturnResult(pgxmock.NewResult("DELETE", 42))
+
+	require.NoError(t, claimF13Filing(context.Background(), pool, fl))
+	assert.Empty(t, fl.SupersededBy)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestClaimF13Filing_SupersededByLater(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// The original is reloaded after its restatement was already loaded.
+	fl := testF13Filing("0001001-25-000001", "", time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC), 0)
+	pool.ExpectExec("INSERT INTO fed_data.f13_filings").
+		WithArgs(fl.Accession, "1001", *fl.Period, "13F-HR", fl.FilingDate, 0, nil).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectQuery("SELECT accession_number FROM fed_data.f13_filings").
+		WithArgs("1001", *fl.Period, fl.Accession, fl.FilingDate, 0).
+		WillReturnRows(pgxmock.NewRows([]string{"accession_number"}).AddRow("0001001-25-000002"))
+	pool.ExpectExec("UPDATE fed_data.f13_filings SET superseded_by = \\$2").
+		WithArgs(fl.Accession, "0001001-25-000002").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, claimF13Filing(context.Background(), pool, fl))
+	assert.Equal(t, "0001001-25-000002", fl.SupersededBy)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestClaimF13Filing_NewHoldingsAddsToCurrent(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// A NEW HOLDINGS amendment supersedes nothing and deletes nothing.
+	fl := testF13Filing("0001001-25-000003", f13NewHoldings, time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC), 2)
+	pool.ExpectExec("INSERT INTO fed_data.f13_filings").
+		WithArgs(fl.Accession, "1001", *fl.Period, "13F-HR/A", fl.FilingDate, 2, f13NewHoldings).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectQuery("SELECT accession_number FROM fed_data.f13_filings").
+		WithArgs("1001", *fl.Period, fl.Accession, fl.FilingDate, 2).
+		WillReturnError(pgx.ErrNoRows)
+
+	require.NoError(t, claimF13Filing(context.Background(), pool, fl))
+	assert.Empty(t, fl.SupersededBy)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestRecordF13Totals_Untracked(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectExec("UPDATE fed_data.f13_filers SET total_value = \\$1").
+		WithArgs(int64(5000), "1001").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, recordF13Totals(context.Background(), pool, &f13Filing{CIK: "1001"}, 1, 5000))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestHoldings13F_SyncIncremental_HoldsWatermarkAtFailedFiling(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	pool.ExpectBegin().WillReturnError(errors.New("connection reset"))
+	pool.ExpectBegin().WillReturnError(errors.New("connection reset"))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "startdt=2025-05-01&")
+	})).Return(io.NopCloser(strings.NewReader(`{"hits":{"total":{"value":2},"hits":[
+		{"_source":{"entity_cik":"0001001","file_date":"2025-05-09","accession_no":"0001001-25-000002"}},
+		{"_source":{"entity_cik":"0001002","file_date":"2025-05-06","accession_no":"0001002-25-000001"}}
+	]}}`)), nil).Once()
+
+	since := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	res, err := (&Holdings13F{}).SyncIncremental(context.Background(), pool, f, t.TempDir(), since)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Metadata["failed"])
+	require.NotNil(t, res.Watermark)
+	assert.Equal(t, time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC), *res.Watermark)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestHoldings13F_SyncIncremental_TruncatedHoldsWatermark(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader(`{"hits":{"total":{"value":12000},"hits":[]}}`)), nil).Once()
+
+	since := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	res, err := (&Holdings13F{}).SyncIncremental(context.Background(), nil, f, t.TempDir(), since)
+	require.NoError(t, err)
+	require.NotNil(t, res.Watermark)
+	assert.Equal(t, since, *res.Watermark)
+}
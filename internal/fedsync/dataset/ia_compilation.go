@@ -196,16 +196,26 @@ func (d *IACompilation) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetche
 	return result, nil
 }
 
+// parseAndLoad streams firms from the feed and upserts them in bounded
+// batches. The decoder blocks while a batch is written, and stops as soon
+// as a write fails.
 func (d *IACompilation) parseAndLoad(ctx context.Context, pool db.Pool, r io.Reader, log *zap.Logger) (*SyncResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	firmCh, errCh := fetcher.StreamXML[iaFirm](ctx, r, "Firm")
 
-	firmCols := []string{"crd_number", "firm_name", "sec_number", "city", "state", "country", "website"}
-	firmConflict := []string{"crd_number"}
-	filingCols := []string{"crd_number", "filing_date", "aum", "num_accounts", "legal_name", "num_employees", "total_employees", "sec_registered"}
-	filingConflict := []string{"crd_number", "filing_date"}
-
-	var firmBatch, filingBatch [][]any
-	var totalFirms, totalFilings int64
+	batchRows := streamBatchRows(d.cfg, iaBatchSize)
+	firms := newBatchWriter(pool, db.UpsertConfig{
+		Table:        "fed_data.adv_firms",
+		Columns:      []string{"crd_number", "firm_name", "sec_number", "city", "state", "country", "website"},
+		ConflictKeys: []string{"crd_number"},
+	}, batchRows)
+	filings := newBatchWriter(pool, db.UpsertConfig{
+		Table:        "fed_data.adv_filings",
+		Columns:      []string{"crd_number", "filing_date", "aum", "num_accounts", "legal_name", "num_employees", "total_employees", "sec_registered"},
+		ConflictKeys: []string{"crd_number", "filing_date"},
+		UpdateCols:   []string{"aum", "num_accounts", "legal_name", "num_employees", "total_employees", "sec_registered"},
+	}, batchRows)
 
 	for firm := range firmCh {
 		if firm.Info.CRDNumber == 0 {
@@ -222,7 +232,7 @@ func (d *IACompilation) parseAndLoad(ctx context.Context, pool db.Pool, r io.Rea
 			website = strings.TrimSpace(firm.FormInfo.Part1A.Item1.WebAddrs[0])
 		}
 
-		firmRow := []any{
+		if err := firms.Add(ctx, []any{
 			firm.Info.CRDNumber,
 			strings.TrimSpace(firm.Info.FirmName),
 			strings.TrimSpace(firm.Info.SECNumber),
@@ -230,12 +240,13 @@ func (d *IACompilation) parseAndLoad(ctx context.Context, pool db.Pool, r io.Rea
 			state,
 			strings.TrimSpace(firm.MainAddr.Country),
 			website,
+		}); err != nil {
+			return nil, eris.Wrap(err, "ia_compilation: upsert firms")
 		}
-		firmBatch = append(firmBatch, firmRow)
 
 		filingDate := parseDate(firm.Filing.Date)
 		if filingDate != nil {
-			filingRow := []any{
+			if err := filings.Add(ctx, []any{
 				firm.Info.CRDNumber,
 				filingDate,
 				firm.FormInfo.Part1A.Item5F.AUM,
@@ -244,33 +255,9 @@ func (d *IACompilation) parseAndLoad(ctx context.Context, pool db.Pool, r io.Rea
 				firm.FormInfo.Part1A.Item5A.TotalEmployees,
 				firm.FormInfo.Part1A.Item5A.TotalEmployees,
 				true, // all firms in IA_FIRM_SEC_Feed are SEC-registered
-			}
-			filingBatch = append(filingBatch, filingRow)
-		}
-
-		if len(firmBatch) >= iaBatchSize {
-			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-				Table: "fed_data.adv_firms", Columns: firmCols, ConflictKeys: firmConflict,
-			}, firmBatch)
-			if err != nil {
-				return nil, eris.Wrap(err, "ia_compilation: upsert firms")
-			}
-			totalFirms += n
-			firmBatch = firmBatch[:0]
-		}
-
-		if len(filingBatch) >= iaBatchSize {
-			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-				Table:        "fed_data.adv_filings",
-				Columns:      filingCols,
-				ConflictKeys: filingConflict,
-				UpdateCols:   []string{"aum", "num_accounts", "legal_name", "num_employees", "total_employees", "sec_registered"},
-			}, filingBatch)
-			if err != nil {
+			}); err != nil {
 				return nil, eris.Wrap(err, "ia_compilation: upsert filings")
 			}
-			totalFilings += n
-			filingBatch = filingBatch[:0]
 		}
 	}
 
@@ -278,33 +265,17 @@ func (d *IACompilation) parseAndLoad(ctx context.Context, pool db.Pool, r io.Rea
 		return nil, eris.Wrap(err, "ia_compilation: parse XML")
 	}
 
-	if len(firmBatch) > 0 {
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table: "fed_data.adv_firms", Columns: firmCols, ConflictKeys: firmConflict,
-		}, firmBatch)
-		if err != nil {
-			return nil, eris.Wrap(err, "ia_compilation: upsert firms final")
-		}
-		totalFirms += n
+	if err := firms.Flush(ctx); err != nil {
+		return nil, eris.Wrap(err, "ia_compilation: upsert firms final")
 	}
-
-	if len(filingBatch) > 0 {
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table:        "fed_data.adv_filings",
-			Columns:      filingCols,
-			ConflictKeys: filingConflict,
-			UpdateCols:   []string{"aum", "num_accounts", "legal_name", "num_employees", "total_employees", "sec_registered"},
-		}, filingBatch)
-		if err != nil {
-			return nil, eris.Wrap(err, "ia_compilation: upsert filings final")
-		}
-		totalFilings += n
+	if err := filings.Flush(ctx); err != nil {
+		return nil, eris.Wrap(err, "ia_compilation: upsert filings final")
 	}
 
-	log.Info("ia_compilation sync complete", zap.Int64("firms", totalFirms), zap.Int64("filings", totalFilings))
+	log.Info("ia_compilation sync complete", zap.Int64("firms", firms.Total()), zap.Int64("filings", filings.Total()))
 
 	return &SyncResult{
-		RowsSynced: totalFirms,
+		RowsSynced: firms.Total(),
 	}, nil
 }
 
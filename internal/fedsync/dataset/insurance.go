@@ -0,0 +1,506 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const insuranceBatchSize = 10000
+
+// Producer entity types.
+const (
+	insuranceIndividual = "individual"
+	insuranceAgency     = "agency"
+)
+
+// Normalized lines of authority assigned by normalizeLinesOfAuthority.
+const (
+	insuranceLOALife          = "life"
+	insuranceLOAHealth        = "accident_health"
+	insuranceLOAProperty      = "property"
+	insuranceLOACasualty      = "casualty"
+	insuranceLOAVariable      = "variable"
+	insuranceLOAPersonalLines = "personal_lines"
+	insuranceLOATitle         = "title"
+	insuranceLOASurplusLines  = "surplus_lines"
+	insuranceLOAOther         = "other"
+)
+
+// insuranceColumns defines the target DB columns in upsert order.
+// crd_number is maintained by PostSync and left out so upserts keep
+// existing links.
+var insuranceColumns = []string{
+	"state", "license_number", "npn", "entity_type", "name", "name_norm",
+	"agency_name", "agency_norm", "city", "address_state", "zip",
+	"resident", "status", "lines_of_authority", "issue_date", "expiration_date",
+	"updated_at",
+}
+
+// insuranceLOACol is the index of lines_of_authority in insuranceColumns.
+var insuranceLOACol = slices.Index(insuranceColumns, "lines_of_authority")
+
+// insuranceCommonAliases lists the headers accepted for each field across
+// state extracts (normalized by normalizeCol). State adapters add their own.
+var insuranceCommonAliases = map[string][]string{
+	"license_number": {"license number", "license_number", "license no", "license #", "license id"},
+	"npn":            {"npn", "national producer number"},
+	"entity_type":    {"license type", "licensee type", "entity type", "type"},
+	"name":           {"licensee name", "name", "full name", "business name", "first name+middle name+last name"},
+	"agency":         {"agency name", "affiliated agency", "business entity", "employer", "firm name"},
+	"city":           {"city", "business city", "mailing city"},
+	"address_state":  {"state", "business state", "mailing state"},
+	"zip":            {"zip", "zip code", "business zip", "mailing zip"},
+	"residency":      {"residency", "resident", "residency status", "resident type"},
+	"status":         {"license status", "status"},
+	"loa":            {"lines of authority", "line of authority", "loa", "qualification", "qualifications"},
+	"issue_date":     {"issue date", "original issue date", "license issue date", "first active date"},
+	"expiration":     {"expiration date", "license expiration date", "expires"},
+}
+
+// insuranceAdapters returns the state extracts registered as
+// insurance_<state> datasets, in registration order.
+func insuranceAdapters() []*insuranceCSVAdapter {
+	return []*insuranceCSVAdapter{
+		{state: "CO", aliases: map[string][]string{
+			"license_number": {"credential number"},
+			"status":         {"credential status"},
+			"loa":            {"specialty"},
+		}},
+		{state: "FL", aliases: map[string][]string{
+			"license_number": {"license id number"},
+			"loa":            {"license type description", "appointment type"},
+		}},
+		{state: "TX", aliases: map[string][]string{
+			"license_number": {"lic_number", "agent_lic_num"},
+			"name":           {"agent_name", "agency_name"},
+			"entity_type":    {"lic_type"},
+			"loa":            {"qual_desc"},
+		}},
+		{state: "WA", aliases: map[string][]string{
+			"license_number": {"wa license number"},
+		}},
+	}
+}
+
+// insuranceProducer is one license record read from an extract. A license
+// with several lines of authority may span multiple records.
+type insuranceProducer struct {
+	LicenseNumber string
+	NPN           string
+	EntityTypeRaw string
+	Name          string
+	Agency        string
+	City          string
+	AddressState  string
+	Zip           string
+	ResidencyRaw  string
+	StatusRaw     string
+	LOARaw        string
+	Issued        *time.Time
+	Expires       *time.Time
+}
+
+// insuranceCSVAdapter parses a state's producer license extract by header
+// name. State aliases are tried before the common ones.
+type insuranceCSVAdapter struct {
+	state   string
+	aliases map[string][]string
+}
+
+// fieldAliases merges the state's aliases ahead of insuranceCommonAliases.
+func (a *insuranceCSVAdapter) fieldAliases() map[string][]string {
+	out := maps.Clone(insuranceCommonAliases)
+	for field, names := range a.aliases {
+		out[field] = append(slices.Clone(names), insuranceCommonAliases[field]...)
+	}
+	return out
+}
+
+// Parse streams license records from one extract file to emit.
+func (a *insuranceCSVAdapter) Parse(r io.Reader, emit func(insuranceProducer) error) error {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return eris.Wrap(err, "read header")
+	}
+	aliases := a.fieldAliases()
+	cols := resolveAliases(mapColumnsTrimBOM(header), aliases)
+	if err := cols.require(aliases, "license_number", "name"); err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return eris.Wrap(err, "read record")
+		}
+		if err := emit(insuranceProducer{
+			LicenseNumber: cols.get(record, "license_number"),
+			NPN:           cols.get(record, "npn"),
+			EntityTypeRaw: cols.get(record, "entity_type"),
+			Name:          cols.get(record, "name"),
+			Agency:        cols.get(record, "agency"),
+			City:          cols.get(record, "city"),
+			AddressState:  cols.get(record, "address_state"),
+			Zip:           cols.get(record, "zip"),
+			ResidencyRaw:  cols.get(record, "residency"),
+			StatusRaw:     cols.get(record, "status"),
+			LOARaw:        cols.get(record, "loa"),
+			Issued:        extractDate(cols.get(record, "issue_date")),
+			Expires:       extractDate(cols.get(record, "expiration")),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// InsuranceProducers syncs one state's insurance producer license extract
+// into fed_data.insurance_producers. Each state is a separate dataset
+// (insurance_tx, insurance_fl, ...) sharing the table, so states sync and
+// fail independently. Agency and affiliated-agency names are linked to
+// adviser and broker-dealer CRDs so license counts can approximate the
+// insurance business of hybrid RIAs.
+type InsuranceProducers struct {
+	cfg     *config.Config
+	adapter *insuranceCSVAdapter
+}
+
+// Name implements Dataset.
+func (d *InsuranceProducers) Name() string {
+	return "insurance_" + strings.ToLower(d.adapter.state)
+}
+
+// Table implements Dataset.
+func (d *InsuranceProducers) Table() string { return "fed_data.insurance_producers" }
+
+// Phase implements Dataset.
+func (d *InsuranceProducers) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *InsuranceProducers) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *InsuranceProducers) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// sourceURL returns the configured extract URL.
+func (d *InsuranceProducers) sourceURL() string {
+	if d.cfg == nil {
+		return ""
+	}
+	return d.cfg.Fedsync.Insurance.URLs[strings.ToLower(d.adapter.state)]
+}
+
+// Sync downloads the state extract and upserts one row per license.
+func (d *InsuranceProducers) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	url := d.sourceURL()
+	if url == "" {
+		return nil, eris.Errorf("%s: source URL required (fedsync.insurance.urls.%s)", d.Name(), strings.ToLower(d.adapter.state))
+	}
+
+	path := filepath.Join(tempDir, d.Name())
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrapf(err, "%s: download", d.Name())
+	}
+
+	state := strings.ToUpper(d.adapter.state)
+	entityTypes := make(map[string]int)
+	var total int64
+	batch := make([][]any, 0, insuranceBatchSize)
+	inBatch := make(map[string]int)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      insuranceColumns,
+			ConflictKeys: []string{"state", "license_number"},
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "%s: upsert", d.Name())
+		}
+		total += n
+		batch = batch[:0]
+		clear(inBatch)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	emit := func(p insuranceProducer) error {
+		row := insuranceRow(state, p, now)
+		if row == nil {
+			return nil
+		}
+		// Extracts list one record per line of authority; fold repeats of a
+		// license into the row already in the batch.
+		key := row[1].(string)
+		if i, dup := inBatch[key]; dup {
+			batch[i][insuranceLOACol] = mergeLinesOfAuthority(batch[i][insuranceLOACol].([]string), row[insuranceLOACol].([]string))
+			return nil
+		}
+		inBatch[key] = len(batch)
+		entityTypes[row[3].(string)]++
+		batch = append(batch, row)
+		if len(batch) >= insuranceBatchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	err := forEachExtractFile(path, []string{".csv", ".txt"}, func(r io.Reader) error {
+		return d.adapter.Parse(r, emit)
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: parse", d.Name())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Info("insurance producer sync complete", zap.Int64("rows", total), zap.Any("entity_types", entityTypes))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"state":        state,
+			"entity_types": entityTypes,
+		},
+	}, nil
+}
+
+// insuranceRow maps a license record to insuranceColumns. Returns nil when
+// the record has no license number or name.
+func insuranceRow(state string, p insuranceProducer, now time.Time) []any {
+	clean := func(s string) string { return sanitizeUTF8(strings.TrimSpace(s)) }
+	license := strings.ToUpper(clean(p.LicenseNumber))
+	name := clean(p.Name)
+	if license == "" || name == "" {
+		return nil
+	}
+
+	npn := clean(p.NPN)
+	if len(npn) > 12 {
+		npn = ""
+	}
+	addressState := strings.ToUpper(clean(p.AddressState))
+	if len(addressState) != 2 {
+		addressState = ""
+	}
+	zip := clean(p.Zip)
+	if len(zip) > 10 {
+		zip = zip[:10]
+	}
+	agency := clean(p.Agency)
+	entityType := classifyProducerEntity(p.EntityTypeRaw, name)
+	if entityType == insuranceAgency {
+		agency = ""
+	}
+
+	row := make([]any, len(insuranceColumns))
+	row[0] = state
+	row[1] = license
+	row[2] = nilIfEmpty(npn)
+	row[3] = entityType
+	row[4] = name
+	row[5] = nilIfEmpty(resolve.NormalizeName(name))
+	row[6] = nilIfEmpty(agency)
+	row[7] = nilIfEmpty(resolve.NormalizeName(agency))
+	row[8] = nilIfEmpty(clean(p.City))
+	row[9] = nilIfEmpty(addressState)
+	row[10] = nilIfEmpty(zip)
+	row[11] = parseResidency(p.ResidencyRaw)
+	row[12] = nilIfEmpty(normalizeLicenseStatus(p.StatusRaw))
+	row[insuranceLOACol] = normalizeLinesOfAuthority(p.LOARaw)
+	row[14] = dateOrNil(p.Issued)
+	row[15] = dateOrNil(p.Expires)
+	row[16] = now
+	return row
+}
+
+// insuranceAgencyTypeMarkers identify business-entity licensees by
+// license type wording.
+var insuranceAgencyTypeMarkers = []string{"agency", "entity", "firm", "business", "organization"}
+
+// insuranceAgencyNameWords identify business-entity licensees by name when
+// the extract has no license type.
+var insuranceAgencyNameWords = map[string]bool{
+	"agency": true, "llc": true, "inc": true, "corp": true, "corporation": true,
+	"company": true, "co": true, "group": true, "services": true, "insurance": true,
+	"lp": true, "llp": true, "ltd": true,
+}
+
+// classifyProducerEntity returns insuranceAgency when the license type (or,
+// without one, the name) marks a business entity, else insuranceIndividual.
+func classifyProducerEntity(raw, name string) string {
+	if s := strings.ToLower(strings.TrimSpace(raw)); s != "" {
+		for _, m := range insuranceAgencyTypeMarkers {
+			if strings.Contains(s, m) {
+				return insuranceAgency
+			}
+		}
+		return insuranceIndividual
+	}
+	for _, w := range strings.Fields(strings.NewReplacer(",", " ", ".", " ").Replace(strings.ToLower(name))) {
+		if insuranceAgencyNameWords[w] {
+			return insuranceAgency
+		}
+	}
+	return insuranceIndividual
+}
+
+// parseResidency maps residency wording to true (resident), false
+// (nonresident), or nil when unknown.
+func parseResidency(raw string) any {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case s == "":
+		return nil
+	case strings.Contains(s, "non"), s == "n", s == "no", s == "false":
+		return false
+	case strings.Contains(s, "resident"), s == "y", s == "yes", s == "true", s == "r":
+		return true
+	default:
+		return nil
+	}
+}
+
+// normalizeLicenseStatus maps a license status to active or inactive.
+// Returns "" when unrecognized.
+func normalizeLicenseStatus(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case s == "":
+		return ""
+	case strings.Contains(s, "inactive"), strings.Contains(s, "expired"), strings.Contains(s, "cancel"),
+		strings.Contains(s, "revoked"), strings.Contains(s, "suspend"), strings.Contains(s, "surrender"),
+		strings.Contains(s, "terminat"):
+		return "inactive"
+	case strings.Contains(s, "active"), s == "a", strings.Contains(s, "current"), strings.Contains(s, "valid"):
+		return "active"
+	default:
+		return ""
+	}
+}
+
+// insuranceLOAKeywords maps line-of-authority wording to classes. A single
+// qualification such as "Property & Casualty" may map to several.
+var insuranceLOAKeywords = []struct {
+	class    string
+	keywords []string
+}{
+	{insuranceLOALife, []string{"life", "annuit"}},
+	{insuranceLOAHealth, []string{"accident", "health", "sickness", "disability"}},
+	{insuranceLOAPersonalLines, []string{"personal lines"}},
+	{insuranceLOAProperty, []string{"property", "fire"}},
+	{insuranceLOACasualty, []string{"casualty"}},
+	{insuranceLOATitle, []string{"title"}},
+	{insuranceLOASurplusLines, []string{"surplus lines", "excess lines"}},
+}
+
+// normalizeLinesOfAuthority splits a lines-of-authority value on ";", ",",
+// or "|" and classifies each qualification, returning sorted unique classes.
+// Variable life and annuity qualifications are classed as variable only.
+func normalizeLinesOfAuthority(raw string) []string {
+	set := make(map[string]bool)
+	for _, part := range strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool { return r == ';' || r == ',' || r == '|' }) {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+			continue
+		case strings.Contains(part, "variable"):
+			set[insuranceLOAVariable] = true
+			continue
+		}
+		matched := false
+		for _, c := range insuranceLOAKeywords {
+			if slices.ContainsFunc(c.keywords, func(kw string) bool { return strings.Contains(part, kw) }) {
+				set[c.class] = true
+				matched = true
+			}
+		}
+		if !matched {
+			set[insuranceLOAOther] = true
+		}
+	}
+	out := slices.Collect(maps.Keys(set))
+	slices.Sort(out)
+	return out
+}
+
+// mergeLinesOfAuthority returns the sorted union of two class lists.
+func mergeLinesOfAuthority(a, b []string) []string {
+	out := slices.Concat(a, b)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// PostSync implements PostSyncer by relinking producers to CRD numbers.
+// Agencies are linked by their own name and individuals by their
+// affiliated agency, when the normalized name identifies exactly one firm
+// across adv_firms and form_bd. The table is shared by every state, so the
+// links are rebuilt for all states at once.
+func (d *InsuranceProducers) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrapf(err, "%s: begin firm links", d.Name())
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `UPDATE fed_data.insurance_producers SET crd_number = NULL WHERE crd_number IS NOT NULL`); err != nil {
+		return eris.Wrapf(err, "%s: clear firm links", d.Name())
+	}
+	tag, err := tx.Exec(ctx, insuranceFirmLinkSQL())
+	if err != nil {
+		return eris.Wrapf(err, "%s: link firms", d.Name())
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrapf(err, "%s: commit firm links", d.Name())
+	}
+
+	zap.L().Info("insurance producer firm links rebuilt",
+		zap.String("dataset", d.Name()),
+		zap.Int64("linked", tag.RowsAffected()),
+	)
+	return nil
+}
+
+// insuranceFirmLinkSQL sets crd_number where an agency's name, or an
+// individual's affiliated agency, matches exactly one adviser or
+// broker-dealer.
+func insuranceFirmLinkSQL() string {
+	return fmt.Sprintf(`UPDATE fed_data.insurance_producers p
+SET crd_number = m.crd_number
+FROM (
+    SELECT norm, MIN(crd_number) AS crd_number
+    FROM (
+        SELECT %s AS norm, crd_number FROM fed_data.adv_firms
+        UNION ALL
+        SELECT %s AS norm, crd_number FROM fed_data.form_bd
+    ) n
+    GROUP BY norm
+    HAVING COUNT(DISTINCT crd_number) = 1
+) m
+WHERE m.norm = CASE WHEN p.entity_type = 'agency' THEN p.name_norm ELSE p.agency_norm END`,
+		resolve.NormalizeNameSQL("firm_name"), resolve.NormalizeNameSQL("firm_name"))
+}
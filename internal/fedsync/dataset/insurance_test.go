@@ -0,0 +1,165 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const insuranceTexasCSV = "\ufeffLIC_NUMBER,NPN,LIC_TYPE,AGENT_NAME,Agency Name,City,State,Zip,Residency,License Status,QUAL_DESC,Issue Date,Expiration Date\n" +
+	"1234567,8812345,General Lines Agent,Jane Q Advisor,Summit Wealth Partners LLC,Austin,TX,78701,Resident,Active,Life Accident and Health,2015-03-01,2027-02-28\n" +
+	"1234567,8812345,General Lines Agent,Jane Q Advisor,Summit Wealth Partners LLC,Austin,TX,78701,Resident,Active,Variable Life and Variable Annuity,2015-03-01,2027-02-28\n" +
+	"2345678,,General Lines Agency,Summit Insurance Services LLC,,Austin,Texas,78701-1234-56,Non-Resident,Expired,Property; Casualty,01/15/2020,\n" +
+	",9999,General Lines Agent,No License,,,,,,,,,\n"
+
+func TestInsuranceProducers_Metadata(t *testing.T) {
+	names := make([]string, 0, len(insuranceAdapters()))
+	for _, a := range insuranceAdapters() {
+		d := &InsuranceProducers{adapter: a}
+		names = append(names, d.Name())
+		assert.Equal(t, "fed_data.insurance_producers", d.Table())
+		assert.Equal(t, Phase2, d.Phase())
+		assert.Equal(t, Monthly, d.Cadence())
+		assert.True(t, d.ShouldRun(time.Now(), nil))
+	}
+	assert.Equal(t, []string{"insurance_co", "insurance_fl", "insurance_tx", "insurance_wa"}, names)
+}
+
+func TestNormalizeLinesOfAuthority(t *testing.T) {
+	tests := map[string][]string{
+		"Life Accident and Health":           {insuranceLOAHealth, insuranceLOALife},
+		"Variable Life and Variable Annuity": {insuranceLOAVariable},
+		"Property; Casualty":                 {insuranceLOACasualty, insuranceLOAProperty},
+		"Personal Lines | Title":             {insuranceLOAPersonalLines, insuranceLOATitle},
+		"Surplus Lines, Bail Bonds":          {insuranceLOAOther, insuranceLOASurplusLines},
+		"":                                   nil,
+	}
+	for raw, want := range tests {
+		assert.Equal(t, want, normalizeLinesOfAuthority(raw), raw)
+	}
+}
+
+func TestClassifyProducerEntity(t *testing.T) {
+	assert.Equal(t, insuranceAgency, classifyProducerEntity("General Lines Agency", "Jane Doe"))
+	assert.Equal(t, insuranceAgency, classifyProducerEntity("Business Entity", "Acme"))
+	assert.Equal(t, insuranceIndividual, classifyProducerEntity("General Lines Agent", "Acme Insurance LLC"))
+	assert.Equal(t, insuranceAgency, classifyProducerEntity("", "Acme Insurance, Inc."))
+	assert.Equal(t, insuranceIndividual, classifyProducerEntity("", "Jane Q. Advisor"))
+}
+
+func TestNormalizeLicenseStatus(t *testing.T) {
+	tests := map[string]string{
+		"Active":    "active",
+		"Inactive":  "inactive",
+		"Expired":   "inactive",
+		"Cancelled": "inactive",
+		"A":         "active",
+		"":          "",
+		"Pending":   "",
+	}
+	for raw, want := range tests {
+		assert.Equal(t, want, normalizeLicenseStatus(raw), raw)
+	}
+}
+
+func TestInsuranceRow(t *testing.T) {
+	tx := insuranceAdapters()[2]
+	var got []insuranceProducer
+	require.NoError(t, tx.Parse(strings.NewReader(insuranceTexasCSV), func(p insuranceProducer) error {
+		got = append(got, p)
+		return nil
+	}))
+	require.Len(t, got, 4)
+
+	now := time.Now()
+	row := insuranceRow("TX", got[0], now)
+	require.Len(t, row, len(insuranceColumns))
+	assert.Equal(t, "1234567", row[1])
+	assert.Equal(t, "8812345", row[2])
+	assert.Equal(t, insuranceIndividual, row[3])
+	assert.Equal(t, "Summit Wealth Partners LLC", row[6])
+	assert.Equal(t, "SUMMIT WEALTH PARTNERS", row[7])
+	assert.Equal(t, true, row[11])
+	assert.Equal(t, "active", row[12])
+	assert.Equal(t, []string{insuranceLOAHealth, insuranceLOALife}, row[insuranceLOACol])
+
+	row = insuranceRow("TX", got[2], now)
+	require.NotNil(t, row)
+	assert.Equal(t, insuranceAgency, row[3])
+	assert.Nil(t, row[6], "agencies have no affiliated agency")
+	assert.Nil(t, row[9], "non-abbreviated state dropped")
+	assert.Equal(t, "78701-1234", row[10])
+	assert.Equal(t, false, row[11])
+	assert.Equal(t, "inactive", row[12])
+
+	assert.Nil(t, insuranceRow("TX", got[3], now))
+
+	err := tx.Parse(strings.NewReader("city,zip\nAustin,78701\n"), func(insuranceProducer) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing license_number column")
+}
+
+func TestInsuranceProducers_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://tdi.test/agents.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(insuranceTexasCSV))
+		}).Return(int64(len(insuranceTexasCSV)), nil)
+
+	// The second line of authority folds into the first license's row.
+	expectBulkUpsert(pool, "fed_data.insurance_producers", insuranceColumns, 2)
+
+	d := &InsuranceProducers{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{Insurance: config.InsuranceConfig{
+			URLs: map[string]string{"tx": "https://tdi.test/agents.csv"},
+		}}},
+		adapter: insuranceAdapters()[2],
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, "TX", res.Metadata["state"])
+	assert.Equal(t, map[string]int{insuranceIndividual: 1, insuranceAgency: 1}, res.Metadata["entity_types"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestInsuranceProducers_SyncNoURL(t *testing.T) {
+	d := &InsuranceProducers{cfg: &config.Config{}, adapter: insuranceAdapters()[0]}
+	_, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.insurance.urls.co")
+}
+
+func TestInsuranceProducers_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE fed_data.insurance_producers SET crd_number = NULL").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 4))
+	pool.ExpectExec("UPDATE fed_data.insurance_producers p").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+	pool.ExpectCommit()
+
+	d := &InsuranceProducers{adapter: insuranceAdapters()[2]}
+	require.NoError(t, d.PostSync(context.Background(), pool, &SyncResult{}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	sql := insuranceFirmLinkSQL()
+	assert.Contains(t, sql, "fed_data.form_bd")
+	assert.Contains(t, sql, "p.agency_norm")
+}
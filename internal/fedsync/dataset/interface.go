@@ -7,6 +7,7 @@ import (
 	"github.com/rotisserie/eris"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -69,6 +70,19 @@ const (
 type SyncResult struct {
 	RowsSynced int64          `json:"rows_synced"`
 	Metadata   map[string]any `json:"metadata,omitempty"`
+
+	// Watermark, when set by an IncrementalSyncer, is the high-water mark
+	// the sync reached. Unset, the engine records the sync's start time.
+	Watermark *time.Time `json:"-"`
+
+	// Unchanged, when set by a SourceTracked dataset, means every source
+	// file matched its recorded version and nothing was loaded.
+	Unchanged bool `json:"-"`
+
+	// Partial means the run loaded only part of the dataset: an incremental
+	// window, or the rest of an interrupted run. Its row count is not
+	// comparable to a full run's. The engine sets it for incremental runs.
+	Partial bool `json:"-"`
 }
 
 // FullSyncer is an optional interface that datasets can implement to support
@@ -79,6 +93,80 @@ type FullSyncer interface {
 	SyncFull(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error)
 }
 
+// IncrementalSyncer is an optional interface for datasets that can fetch
+// only records newer than a high-water mark. When the engine holds a
+// watermark for the dataset (fed_data.sync_state), SyncIncremental is called
+// with it instead of Sync; without one, Sync runs its default window. After
+// any successful sync the engine advances the watermark.
+type IncrementalSyncer interface {
+	SyncIncremental(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, since time.Time) (*SyncResult, error)
+}
+
+// PeriodSyncer is an optional interface for datasets published by year or
+// quarter that can load one explicit historical period instead of their
+// default latest-period window. `fedsync backfill` calls SyncPeriod once
+// per requested period; datasets reject periods they do not publish.
+type PeriodSyncer interface {
+	SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error)
+}
+
+// SliceResyncer is an optional interface for PeriodSyncer datasets that can
+// replace one slice of their table (a period, optionally one state) without
+// a full reload. `fedsync resync` runs the plan's DELETE, then reloads
+// plan.Load through SyncPeriod with the slice on the context; loaders skip
+// rows outside it. PlanResync rejects slices the table cannot select.
+type SliceResyncer interface {
+	PeriodSyncer
+	PlanResync(s Slice) (SlicePlan, error)
+}
+
+// Resumable is an optional interface for long-running datasets that persist
+// progress (fed_data.sync_checkpoints) as they go. The engine hands the
+// dataset its checkpoint before each sync; an interrupted run resumes after
+// the last committed key, and a completed run clears the checkpoint.
+type Resumable interface {
+	SetCheckpoint(cp *fedsync.Checkpoint)
+}
+
+// SourceTracked is an optional interface for datasets that load whole
+// files which rarely change. The engine hands the dataset its recorded
+// source file versions (fed_data.source_files) before each sync; a file the
+// server reports unchanged (304) or that hashes the same as last time is
+// not parsed again. --full ignores the recorded versions.
+type SourceTracked interface {
+	SetSourceFiles(src *fedsync.DatasetSources)
+}
+
+// PostSyncer is an optional interface for datasets that derive additional
+// tables from freshly synced data (e.g. LODES workforce catchments). The
+// engine calls PostSync after a successful sync; a PostSync error is logged
+// but does not fail the sync.
+type PostSyncer interface {
+	PostSync(ctx context.Context, pool db.Pool, result *SyncResult) error
+}
+
+// Mirrored is an optional interface for datasets whose source files are
+// also published at alternate URLs. The engine wraps the fetcher so that a
+// primary that keeps failing (5xx, network errors) falls back to the mirrors.
+type Mirrored interface {
+	Mirrors() []fetcher.Mirror
+}
+
+// ColumnDoc describes one column a dataset loads, for the data dictionary.
+type ColumnDoc struct {
+	Table       string // schema-qualified; "" = the dataset's Table()
+	Column      string
+	Description string
+}
+
+// Documented is an optional interface for datasets that describe the
+// columns they load. `research-cli datadict` shows these descriptions
+// ahead of Postgres column comments. Columns may cover secondary tables by
+// setting ColumnDoc.Table.
+type Documented interface {
+	Columns() []ColumnDoc
+}
+
 // Dataset defines the interface each federal dataset must implement.
 type Dataset interface {
 	// Name returns the unique identifier for this dataset (e.g., "cbp", "adv_part1").
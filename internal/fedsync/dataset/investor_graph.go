@@ -0,0 +1,130 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// investorGraphEpoch is the rebuild start used for a full rebuild or when the
+// positions table is still empty.
+var investorGraphEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// investorGraphSQL derives advisor → issuer positions from 13F holdings for
+// every period on or after $1. Window functions run over the full history so
+// prior values and first-seen periods stay correct on incremental rebuilds.
+// Option positions (put/call) are excluded from weights.
+const investorGraphSQL = `
+WITH xref AS (
+	SELECT DISTINCT ON (cik) cik, crd_number
+	FROM fed_data.entity_xref
+	WHERE cik IS NOT NULL AND crd_number IS NOT NULL
+	ORDER BY cik, confidence DESC NULLS LAST
+),
+history AS (
+	SELECT
+		h.cik, h.period, h.cusip, h.issuer_name, h.value, h.shares,
+		LAG(h.value) OVER (PARTITION BY h.cik, h.cusip ORDER BY h.period) AS prior_value,
+		MIN(h.period) OVER (PARTITION BY h.cik, h.cusip) AS first_period,
+		SUM(h.value) OVER (PARTITION BY h.cik, h.period) AS book_value,
+		RANK() OVER (PARTITION BY h.cik, h.period ORDER BY h.value DESC NULLS LAST) AS position_rank
+	FROM fed_data.f13_holdings h
+	WHERE COALESCE(h.put_call, '') = ''
+)
+INSERT INTO fed_data.f13_investor_positions (
+	cik, period, cusip, filer_name, crd_number, issuer_name, value, shares,
+	portfolio_weight, position_rank, prior_value, value_change_pct, first_period, updated_at
+)
+SELECT
+	h.cik, h.period, h.cusip, f.company_name, x.crd_number, h.issuer_name, h.value, h.shares,
+	CASE WHEN h.book_value > 0 THEN h.value::double precision / h.book_value END,
+	h.position_rank,
+	h.prior_value,
+	CASE WHEN h.prior_value > 0 THEN (h.value - h.prior_value)::double precision / h.prior_value END,
+	h.first_period,
+	now()
+FROM history h
+LEFT JOIN fed_data.f13_filers f ON f.cik = h.cik
+LEFT JOIN xref x ON x.cik = h.cik
+WHERE h.period >= $1`
+
+// InvestorGraph derives the advisor → issuer relationship table from 13F
+// holdings and the CRD↔CIK cross-reference. Incremental runs rebuild the most
+// recent built period (to pick up late filings and amendments) and any newer
+// ones; SyncFull rebuilds every period.
+type InvestorGraph struct{}
+
+// Name implements Dataset.
+func (d *InvestorGraph) Name() string { return "investor_graph" }
+
+// Table implements Dataset.
+func (d *InvestorGraph) Table() string { return "fed_data.f13_investor_positions" }
+
+// Phase implements Dataset.
+func (d *InvestorGraph) Phase() Phase { return Phase1B }
+
+// Cadence implements Dataset.
+func (d *InvestorGraph) Cadence() Cadence { return Quarterly }
+
+// ShouldRun implements Dataset.
+func (d *InvestorGraph) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return QuarterlyAfterDelay(now, lastSync, 45)
+}
+
+// Sync rebuilds positions for the latest built period onward.
+func (d *InvestorGraph) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.build(ctx, pool, false)
+}
+
+// SyncFull rebuilds positions for every 13F period.
+func (d *InvestorGraph) SyncFull(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return d.build(ctx, pool, true)
+}
+
+func (d *InvestorGraph) build(ctx context.Context, pool db.Pool, full bool) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	from := investorGraphEpoch
+	if !full {
+		var latest *time.Time
+		if err := pool.QueryRow(ctx, `SELECT MAX(period) FROM fed_data.f13_investor_positions`).Scan(&latest); err != nil {
+			return nil, eris.Wrap(err, "investor_graph: find latest period")
+		}
+		if latest != nil {
+			from = *latest
+		}
+	}
+	log.Info("building investor graph", zap.Time("from_period", from), zap.Bool("full", full))
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "investor_graph: begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fed_data.f13_investor_positions WHERE period >= $1`, from); err != nil {
+		return nil, eris.Wrap(err, "investor_graph: clear periods")
+	}
+	tag, err := tx.Exec(ctx, investorGraphSQL, from)
+	if err != nil {
+		return nil, eris.Wrap(err, "investor_graph: insert positions")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, eris.Wrap(err, "investor_graph: commit")
+	}
+
+	rows := tag.RowsAffected()
+	log.Info("investor graph built", zap.Int64("rows", rows))
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"from_period": from.Format("2006-01-02"),
+			"full":        full,
+		},
+	}, nil
+}
@@ -0,0 +1,113 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvestorGraph_Metadata(t *testing.T) {
+	d := &InvestorGraph{}
+	assert.Equal(t, "investor_graph", d.Name())
+	assert.Equal(t, "fed_data.f13_investor_positions", d.Table())
+	assert.Equal(t, Phase1B, d.Phase())
+	assert.Equal(t, Quarterly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestInvestorGraph_Sync_RebuildsFromLatestPeriod(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	latest := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+	pool.ExpectQuery("SELECT MAX\\(period\\) FROM fed_data.f13_investor_positions").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(&latest))
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.f13_investor_positions WHERE period >=").
+		WithArgs(latest).
+		WillReturnResult(pgxmock.NewResult("DELETE", 120))
+	pool.ExpectExec("INSERT INTO fed_data.f13_investor_positions").
+		WithArgs(latest).
+		WillReturnResult(pgxmock.NewResult("INSERT", 250))
+	pool.ExpectCommit()
+	pool.ExpectRollback()
+
+	d := &InvestorGraph{}
+	result, err := d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(250), result.RowsSynced)
+	assert.Equal(t, "2026-06-30", result.Metadata["from_period"])
+	assert.Equal(t, false, result.Metadata["full"])
+}
+
+func TestInvestorGraph_Sync_EmptyTableBuildsAll(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("SELECT MAX\\(period\\)").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("INSERT INTO fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnResult(pgxmock.NewResult("INSERT", 10))
+	pool.ExpectCommit()
+	pool.ExpectRollback()
+
+	d := &InvestorGraph{}
+	result, err := d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.RowsSynced)
+}
+
+func TestInvestorGraph_SyncFull(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnResult(pgxmock.NewResult("DELETE", 500))
+	pool.ExpectExec("INSERT INTO fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnResult(pgxmock.NewResult("INSERT", 500))
+	pool.ExpectCommit()
+	pool.ExpectRollback()
+
+	d := &InvestorGraph{}
+	result, err := d.SyncFull(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), result.RowsSynced)
+	assert.Equal(t, true, result.Metadata["full"])
+}
+
+func TestInvestorGraph_InsertError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("INSERT INTO fed_data.f13_investor_positions").
+		WithArgs(investorGraphEpoch).
+		WillReturnError(errors.New("division by zero"))
+	pool.ExpectRollback()
+
+	d := &InvestorGraph{}
+	_, err = d.SyncFull(context.Background(), pool, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "investor_graph: insert positions")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
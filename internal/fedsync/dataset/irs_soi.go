@@ -0,0 +1,226 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	// soiBaseURL is the IRS Statistics of Income public file root.
+	soiBaseURL   = "https://www.irs.gov/pub/irs-soi"
+	soiStartYear = 2019
+	soiBatchSize = 5000
+)
+
+// soiZipIncomeColumns defines the soi_zip_income upsert columns.
+var soiZipIncomeColumns = []string{
+	"tax_year", "zipcode", "agi_stub", "state",
+	"returns", "individuals", "agi", "wages", "dividends", "capital_gains", "business_income", "total_income",
+	"updated_at",
+}
+
+// soiZipIncomeFields maps the zpallagi amount columns (after agi_stub and
+// state) to their headers.
+var soiZipIncomeFields = []string{"n1", "n2", "a00100", "a00200", "a00600", "a01000", "a00900", "a02650"}
+
+// IRSSOI syncs IRS Statistics of Income individual income by ZIP code and
+// AGI size class (fed_data.soi_zip_income), keyed by tax year. Amounts are
+// in thousands of dollars. Every year published since soiStartYear is
+// loaded. County migration flows from the same SOI release are loaded by
+// IRSSOIMigration.
+type IRSSOI struct {
+	baseURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *IRSSOI) Name() string { return "irs_soi" }
+
+// Table implements Dataset.
+func (d *IRSSOI) Table() string { return "fed_data.soi_zip_income" }
+
+// Phase implements Dataset.
+func (d *IRSSOI) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *IRSSOI) Cadence() Cadence { return Annual }
+
+// ShouldRun implements Dataset. SOI publishes each tax year's ZIP file
+// roughly two years after the year ends, usually in spring.
+func (d *IRSSOI) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return AnnualAfter(now, lastSync, time.June)
+}
+
+// Sync downloads and loads the ZIP income file for each published tax
+// year, skipping years not yet available.
+func (d *IRSSOI) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+	base := d.baseURL
+	if base == "" {
+		base = soiBaseURL
+	}
+
+	var zipIncome int64
+	var zipYears []int
+	for year := soiStartYear; year < time.Now().Year(); year++ {
+		zipURL := fmt.Sprintf("%s/%02dzpallagi.csv", base, year%100)
+		n, ok, err := d.loadFile(ctx, f, zipURL, tempDir, func(path string) (int64, error) {
+			return d.loadZipIncome(ctx, pool, path, year)
+		})
+		if err != nil {
+			return nil, eris.Wrapf(err, "irs_soi: zip income %d", year)
+		}
+		if ok {
+			zipIncome += n
+			zipYears = append(zipYears, year)
+			log.Info("loaded SOI ZIP income", zap.Int("tax_year", year), zap.Int64("rows", n))
+		} else {
+			log.Info("SOI ZIP income not yet available, skipping", zap.Int("tax_year", year))
+		}
+	}
+	if len(zipYears) == 0 {
+		return nil, eris.New("irs_soi: no SOI files available")
+	}
+
+	return &SyncResult{
+		RowsSynced: zipIncome,
+		Metadata: map[string]any{
+			"zip_income_years": zipYears,
+		},
+	}, nil
+}
+
+// loadFile downloads url and passes the local path to load. ok is false
+// when the file is not published yet (404).
+func (d *IRSSOI) loadFile(ctx context.Context, f fetcher.Fetcher, url, tempDir string, load func(string) (int64, error)) (int64, bool, error) {
+	path := filepath.Join(tempDir, filepath.Base(url))
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return 0, false, nil
+		}
+		return 0, false, eris.Wrapf(err, "download %s", filepath.Base(url))
+	}
+	defer os.Remove(path) //nolint:errcheck
+	n, err := load(path)
+	return n, true, err
+}
+
+// loadZipIncome upserts a zpallagi file.
+func (d *IRSSOI) loadZipIncome(ctx context.Context, pool db.Pool, path string, year int) (int64, error) {
+	now := time.Now()
+	return soiLoadCSV(ctx, pool, path, "fed_data.soi_zip_income", soiZipIncomeColumns,
+		[]string{"tax_year", "zipcode", "agi_stub"},
+		[]string{"zipcode", "agi_stub", "n1", "a00100"},
+		func(colIdx map[string]int, record []string) []any {
+			return soiZipIncomeRow(colIdx, record, year, now)
+		})
+}
+
+// soiLoadCSV streams an SOI CSV, mapping each record with toRow and
+// upserting in batches. required lists headers that must be present.
+func soiLoadCSV(ctx context.Context, pool db.Pool, path, table string, cols, keys, required []string, toRow func(map[string]int, []string) []any) (int64, error) {
+	file, err := openFileForRead(path)
+	if err != nil {
+		return 0, eris.Wrap(err, "open")
+	}
+	defer file.Close() //nolint:errcheck
+
+	reader := newLenientCSVReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "read header")
+	}
+	colIdx := mapColumnsTrimBOM(header)
+	for _, h := range required {
+		if _, ok := colIdx[h]; !ok {
+			return 0, eris.Errorf("missing %s column", h)
+		}
+	}
+
+	var total int64
+	batch := make([][]any, 0, soiBatchSize)
+	seen := make(map[string]bool)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{Table: table, Columns: cols, ConflictKeys: keys}, batch)
+		if err != nil {
+			return eris.Wrap(err, "upsert")
+		}
+		total += n
+		batch = batch[:0]
+		clear(seen)
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return total, eris.Wrap(err, "read record")
+		}
+		row := toRow(colIdx, record)
+		if row == nil {
+			continue
+		}
+		key := fmt.Sprint(row[1], "|", row[2])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		batch = append(batch, row)
+		if len(batch) >= soiBatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, flush()
+}
+
+// soiZipIncomeRow maps a zpallagi record to soiZipIncomeColumns. Returns
+// nil for state totals (00000) and the residual "other" ZIP (99999).
+func soiZipIncomeRow(colIdx map[string]int, record []string, year int, now time.Time) []any {
+	n, err := strconv.Atoi(strings.TrimSpace(getColN(record, colIdx, "zipcode")))
+	if err != nil || n <= 0 || n >= 99999 {
+		return nil
+	}
+	stub, err := strconv.Atoi(strings.TrimSpace(getColN(record, colIdx, "agi_stub")))
+	if err != nil || stub < 1 {
+		return nil
+	}
+	row := []any{
+		year,
+		fmt.Sprintf("%05d", n),
+		stub,
+		nilIfEmpty(fitLen(strings.ToUpper(strings.TrimSpace(getColN(record, colIdx, "state"))), 2)),
+	}
+	for _, field := range soiZipIncomeFields {
+		row = append(row, soiValue(getColN(record, colIdx, field)))
+	}
+	return append(row, now)
+}
+
+// soiValue parses an SOI count or amount. SOI marks suppressed cells with
+// -1, which load as NULL.
+func soiValue(s string) any {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v < 0 {
+		return nil
+	}
+	return int64(v)
+}
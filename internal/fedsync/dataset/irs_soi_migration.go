@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -20,6 +21,7 @@ var irsMigrationCols = []string{
 	"year", "direction",
 	"state_fips_origin", "county_fips_origin",
 	"state_fips_dest", "county_fips_dest",
+	"counterpart_state", "counterpart_name",
 	"num_returns", "num_exemptions", "adjusted_gross_income",
 }
 
@@ -31,7 +33,12 @@ var irsMigrationConflictKeys = []string{
 
 const irsBatchSize = 5000
 
-// IRSSOIMigration syncs IRS Statistics of Income county-to-county migration data.
+// IRSSOIMigration syncs IRS Statistics of Income county-to-county migration
+// data from the county inflow and outflow files, keyed by the later filing
+// year of the pair (countyinflow2122 loads as 2022). counterpart_state and
+// counterpart_name describe the other county of the flow: the origin for
+// inflows, the destination for outflows. Sync loads the latest published
+// pair; SyncPeriod backfills earlier years.
 type IRSSOIMigration struct {
 	baseURL string // override for testing
 }
@@ -111,6 +118,36 @@ func (d *IRSSOIMigration) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetc
 	return &SyncResult{RowsSynced: totalRows}, nil
 }
 
+// SyncPeriod implements PeriodSyncer, loading the inflow and outflow files
+// for one filing year.
+func (d *IRSSOIMigration) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	year, err := requireYear(d.Name(), period)
+	if err != nil {
+		return nil, err
+	}
+	log := zap.L().With(zap.String("dataset", d.Name()), zap.Int("year", year))
+	base := d.baseURL
+	if base == "" {
+		base = soiBaseURL
+	}
+
+	var totalRows int64
+	for _, direction := range []string{"inflow", "outflow"} {
+		url := fmt.Sprintf("%s/county%s%02d%02d.csv", base, direction, (year-1)%100, year%100)
+		csvPath := filepath.Join(tempDir, fmt.Sprintf("irs_%s_%d.csv", direction, year))
+		if _, err := f.DownloadToFile(ctx, url, csvPath); err != nil {
+			return nil, eris.Wrapf(err, "irs_soi_migration: download %s %d", direction, year)
+		}
+		n, err := d.parseFile(ctx, pool, csvPath, direction, year, log)
+		_ = os.Remove(csvPath)
+		if err != nil {
+			return nil, eris.Wrapf(err, "irs_soi_migration: parse %s %d", direction, year)
+		}
+		totalRows += n
+	}
+	return &SyncResult{RowsSynced: totalRows, Metadata: map[string]any{"year": year}}, nil
+}
+
 func (d *IRSSOIMigration) parseFile(ctx context.Context, pool db.Pool, csvPath, direction string, year int, log *zap.Logger) (int64, error) {
 	file, err := os.Open(csvPath) // #nosec G304 -- path from controlled temp dir
 	if err != nil {
@@ -170,15 +207,22 @@ func (d *IRSSOIMigration) parseFile(ctx context.Context, pool db.Pool, csvPath,
 			continue
 		}
 
-		numReturns := parseIntOr(getCol(row, colIdx, "return_num"), 0)
-		numExemptions := parseIntOr(getCol(row, colIdx, "exmpt_num"), 0)
-		agi := parseInt64Or(getCol(row, colIdx, "adjusted_gross_income"), 0)
+		// The other county of the flow is named in y1_* columns for
+		// inflows and y2_* columns for outflows.
+		side := "y1"
+		if direction == "outflow" {
+			side = "y2"
+		}
 
 		batch = append(batch, []any{
 			year, direction,
 			originState, originCounty,
 			destState, destCounty,
-			numReturns, numExemptions, agi,
+			nilIfEmpty(fitLen(strings.ToUpper(strings.TrimSpace(getCol(row, colIdx, side+"_state"))), 2)),
+			nilIfEmpty(sanitizeUTF8(strings.TrimSpace(getCol(row, colIdx, side+"_countyname")))),
+			soiValue(irsMigrationCol(row, colIdx, "n1", "return_num")),
+			soiValue(irsMigrationCol(row, colIdx, "n2", "exmpt_num")),
+			soiValue(irsMigrationCol(row, colIdx, "agi", "adjusted_gross_income")),
 		})
 
 		if len(batch) >= irsBatchSize {
@@ -198,3 +242,15 @@ func (d *IRSSOIMigration) parseFile(ctx context.Context, pool db.Pool, csvPath,
 	)
 	return totalRows, nil
 }
+
+// irsMigrationCol returns the value of the first of names present in the
+// header. Current SOI files use n1/n2/agi; older ones spelled the columns
+// out.
+func irsMigrationCol(row []string, colIdx map[string]int, names ...string) string {
+	for _, name := range names {
+		if _, ok := colIdx[name]; ok {
+			return getCol(row, colIdx, name)
+		}
+	}
+	return ""
+}
@@ -391,3 +391,45 @@ func TestIRSSOIMigration_ProdURL_OutflowFails(t *testing.T) {
 	assert.Contains(t, syncErr.Error(), "tried pairs")
 	assert.Contains(t, syncErr.Error(), "outflow")
 }
+
+func TestIRSSOIMigration_SyncPeriod(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Current SOI files name the counts n1/n2/agi and mark suppressed
+	// cells -1.
+	inflow := "y2_statefips,y2_countyfips,y1_statefips,y1_countyfips,y1_state,y1_countyname,n1,n2,agi\n" +
+		"12,099,97,000,FL,Palm Beach County Total Migration-US,28000,51000,4100000\n" +
+		"12,099,36,061,NY,New York County,1450,2300,1250000\n" +
+		"12,099,44,001,RI,Bristol County,-1,-1,-1\n"
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://soi.test/countyinflow2122.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(inflow))
+		}).Return(int64(len(inflow)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://soi.test/countyoutflow2122.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(outflowCSV))
+		}).Return(int64(len(outflowCSV)), nil)
+
+	expectBulkUpsert(pool, "fed_data.irs_soi_migration", irsMigrationCols, 2)
+	expectBulkUpsert(pool, "fed_data.irs_soi_migration", irsMigrationCols, 1)
+
+	ds := &IRSSOIMigration{baseURL: "https://soi.test"}
+	res, err := ds.SyncPeriod(context.Background(), pool, f, t.TempDir(), Period{Year: 2022})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	_, err = ds.SyncPeriod(context.Background(), pool, f, t.TempDir(), Period{Year: 2022, Quarter: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backfill by year")
+}
+
+func TestIRSMigrationCol(t *testing.T) {
+	row := []string{"1450", "2300"}
+	assert.Equal(t, "1450", irsMigrationCol(row, map[string]int{"n1": 0}, "n1", "return_num"))
+	assert.Equal(t, "2300", irsMigrationCol(row, map[string]int{"return_num": 1}, "n1", "return_num"))
+	assert.Empty(t, irsMigrationCol(row, map[string]int{}, "n1", "return_num"))
+}
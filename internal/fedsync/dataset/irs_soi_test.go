@@ -0,0 +1,99 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const soiZipCSV = "STATEFIPS,STATE,zipcode,agi_stub,N1,N2,A00100,A00200,A00600,A01000,A00900,A02650\n" +
+	"12,FL,0,6,100,200,5000,3000,400,900,200,5200\n" +
+	"12,FL,33480,6,2450,5100,3210000,410000,220000,1500000,98000,3300000\n" +
+	"12,FL,33480,1,800,900,9000,7000,10,0,-1,9500\n" +
+	"25,MA,2108,6,1200,2300,950000,500000,60000,210000,40000,980000\n" +
+	"12,FL,99999,6,50,60,7000,100,1,1,1,7100\n"
+
+func TestIRSSOI_Metadata(t *testing.T) {
+	d := &IRSSOI{}
+	assert.Equal(t, "irs_soi", d.Name())
+	assert.Equal(t, "fed_data.soi_zip_income", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Annual, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestSOIZipIncomeRow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(soiZipCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	colIdx := mapColumnsTrimBOM(header)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.Nil(t, soiZipIncomeRow(colIdx, records[0], 2022, now), "state total")
+	assert.Nil(t, soiZipIncomeRow(colIdx, records[4], 2022, now), "other ZIPs")
+
+	row := soiZipIncomeRow(colIdx, records[1], 2022, now)
+	require.Len(t, row, len(soiZipIncomeColumns))
+	assert.Equal(t, "33480", row[1])
+	assert.Equal(t, 6, row[2])
+	assert.Equal(t, "FL", row[3])
+	assert.Equal(t, int64(2450), row[4])
+	assert.Equal(t, int64(3210000), row[6])
+	assert.Equal(t, int64(1500000), row[9])
+
+	assert.Equal(t, "02108", soiZipIncomeRow(colIdx, records[3], 2022, now)[1])
+	assert.Nil(t, soiZipIncomeRow(colIdx, records[2], 2022, now)[10], "suppressed")
+}
+
+func TestIRSSOI_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://soi.test/22zpallagi.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(soiZipCSV))
+		}).Return(int64(len(soiZipCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("http: status 404"))
+
+	expectBulkUpsert(pool, "fed_data.soi_zip_income", soiZipIncomeColumns, 3)
+
+	res, err := (&IRSSOI{baseURL: "https://soi.test"}).Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, []int{2022}, res.Metadata["zip_income_years"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestIRSSOI_SyncNothingAvailable(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("http: status 404"))
+
+	_, err := (&IRSSOI{baseURL: "https://soi.test"}).Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no SOI files available")
+}
+
+func TestIRSSOI_SyncDownloadError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("connection reset"))
+
+	_, err := (&IRSSOI{baseURL: "https://soi.test"}).Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "irs_soi: zip income 2019")
+}
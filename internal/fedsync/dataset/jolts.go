@@ -0,0 +1,67 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// joltsExtraCols are the series-ID components stored alongside each value.
+var joltsExtraCols = []string{
+	"seasonal", "industry_code", "state_code", "size_class", "data_element", "rate_level",
+}
+
+// JOLTS syncs BLS Job Openings and Labor Turnover Survey series (openings,
+// hires, quits, layoffs) configured under fedsync.jolts.series.
+type JOLTS struct {
+	cfg    *config.Config
+	blsURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *JOLTS) Name() string { return "jolts" }
+
+// Table implements Dataset.
+func (d *JOLTS) Table() string { return "fed_data.jolts_data" }
+
+// Phase implements Dataset.
+func (d *JOLTS) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *JOLTS) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *JOLTS) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync fetches and loads the configured JOLTS series.
+func (d *JOLTS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return blsSeriesDataset{
+		fetcher:       f,
+		name:          d.Name(),
+		table:         d.Table(),
+		url:           d.blsURL,
+		apiKey:        d.cfg.Fedsync.BLSKey,
+		dailyLimit:    blsDailyLimit(d.cfg),
+		series:        normalizeBLSSeries(d.cfg.Fedsync.JOLTS.Series),
+		lookbackYears: 2,
+		extraCols:     joltsExtraCols,
+		extra:         joltsSeriesParts,
+	}.sync(ctx, pool)
+}
+
+// joltsSeriesParts splits a 21-character JOLTS series ID
+// (JT + seasonal + industry(6) + state(2) + area(5) + size class(2) +
+// data element(2) + rate/level(1)) into its stored components. Malformed
+// IDs yield NULLs.
+func joltsSeriesParts(id string) []any {
+	if len(id) != 21 || !strings.HasPrefix(id, "JT") {
+		return []any{nil, nil, nil, nil, nil, nil}
+	}
+	return []any{id[2:3], id[3:9], id[9:11], id[16:18], id[18:20], id[20:21]}
+}
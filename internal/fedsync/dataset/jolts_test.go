@@ -0,0 +1,60 @@
+package dataset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+func TestJOLTS_Metadata(t *testing.T) {
+	d := &JOLTS{}
+	assert.Equal(t, "jolts", d.Name())
+	assert.Equal(t, "fed_data.jolts_data", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestJOLTS_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// JTS000000000000000QUR comes back without data.
+	srv := newBLSServer(t, map[string]string{
+		"JTS000000000000000JOL": blsSeriesJSON("JTS000000000000000JOL", "2026", "M07", "7437", "2026", "M06", "7357"),
+	})
+
+	expectBulkUpsert(pool, "fed_data.jolts_data", append(append([]string(nil), blsSeriesCols...), joltsExtraCols...), 2)
+
+	// Duplicate and lower-case IDs are normalized.
+	d := &JOLTS{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{
+		BLSKey: "test-key",
+		JOLTS:  config.BLSSeriesConfig{Series: []string{"jts000000000000000jol", "JTS000000000000000JOL", "JTS000000000000000QUR"}},
+	}}}
+	res, err := d.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 2, res.Metadata["series"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+	require.Len(t, srv.Requests(), 1)
+	assert.Equal(t, []string{"JTS000000000000000JOL", "JTS000000000000000QUR"}, srv.Requests()[0].SeriesID)
+}
+
+func TestJOLTS_Sync_NoSeries(t *testing.T) {
+	d := &JOLTS{cfg: &config.Config{}}
+	res, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.RowsSynced)
+}
+
+func TestJoltsSeriesParts(t *testing.T) {
+	assert.Equal(t, []any{"S", "540099", "00", "00", "JO", "R"}, joltsSeriesParts("JTS540099000000000JOR"))
+	assert.Equal(t, []any{nil, nil, nil, nil, nil, nil}, joltsSeriesParts("CIU1010000000000A"))
+}
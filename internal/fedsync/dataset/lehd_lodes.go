@@ -30,8 +30,11 @@ var lodesConflictKeys = []string{"year", "state", "w_county_fips", "h_county_fip
 
 const lodesBatchSize = 5000
 
-// lodesCountyAgg holds aggregated job counts for a county-to-county pair.
-type lodesCountyAgg struct {
+// lodesBaseURL is the LODES version 8 download root.
+const lodesBaseURL = "https://lehd.ces.census.gov/data/lodes/LODES8"
+
+// lodesJobAgg holds aggregated job counts for a workplace/residence pair.
+type lodesJobAgg struct {
 	S000 int
 	SA01 int
 	SA02 int
@@ -88,23 +91,9 @@ func (d *LEHDLODES) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, t
 	}
 
 	if d.baseURL == "" {
-		// Probe with first state to find latest available year.
-		probeState := states[0]
-		var found bool
-		for offset := 2; offset <= 5; offset++ {
-			tryYear := time.Now().Year() - offset
-			probeURL := fmt.Sprintf("https://lehd.ces.census.gov/data/lodes/LODES8/%s/od/%s_od_main_JT00_%d.csv.gz",
-				probeState, probeState, tryYear)
-			probePath := filepath.Join(tempDir, "lodes_probe.csv.gz")
-			if _, err := f.DownloadToFile(ctx, probeURL, probePath); err == nil {
-				year = tryYear
-				log.Info("found LODES data year", zap.Int("year", year), zap.String("probe_state", probeState))
-				_ = os.Remove(probePath)
-				found = true
-				break
-			}
-		}
-		if !found {
+		if found := lodesLatestYear(ctx, f, tempDir, lodesBaseURL, states[0], log); found != 0 {
+			year = found
+		} else {
 			log.Warn("lehd_lodes: could not find available year via probe, using default", zap.Int("year", year))
 		}
 	}
@@ -137,8 +126,7 @@ func (d *LEHDLODES) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, t
 func (d *LEHDLODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir, state string, year int, log *zap.Logger) (int64, error) {
 	url := d.baseURL
 	if url == "" {
-		url = fmt.Sprintf("https://lehd.ces.census.gov/data/lodes/LODES8/%s/od/%s_od_main_JT00_%d.csv.gz",
-			state, state, year)
+		url = lodesODURL(lodesBaseURL, state, "main", year)
 	}
 
 	gzPath := filepath.Join(tempDir, fmt.Sprintf("lodes_%s.csv.gz", state))
@@ -150,6 +138,7 @@ func (d *LEHDLODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetch
 		}
 		return 0, eris.Wrapf(err, "lehd_lodes: download %s", state)
 	}
+	ctx = db.WithSource(ctx, url)
 
 	gzFile, err := os.Open(gzPath) // #nosec G304 -- path from controlled temp dir
 	if err != nil {
@@ -171,7 +160,7 @@ func (d *LEHDLODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetch
 	colIdx := mapColumns(header)
 
 	// Aggregate block-level OD data to county level.
-	agg := make(map[string]*lodesCountyAgg)
+	agg := make(map[string]*lodesJobAgg)
 
 	for {
 		row, rErr := reader.Read()
@@ -191,7 +180,7 @@ func (d *LEHDLODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetch
 		key := wGeo[:5] + "|" + hGeo[:5]
 		entry := agg[key]
 		if entry == nil {
-			entry = &lodesCountyAgg{}
+			entry = &lodesJobAgg{}
 			agg[key] = entry
 		}
 
@@ -250,3 +239,24 @@ func (d *LEHDLODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetch
 	log.Info("lehd_lodes state synced", zap.String("state", state), zap.Int64("rows", totalRows))
 	return totalRows, nil
 }
+
+// lodesODURL returns the URL of a state's OD file. part is "main" (workers
+// living in the state) or "aux" (workers living out of state).
+func lodesODURL(base, state, part string, year int) string {
+	return fmt.Sprintf("%s/%s/od/%s_od_%s_JT00_%d.csv.gz", base, state, state, part, year)
+}
+
+// lodesLatestYear probes a state's OD main file to find the latest published
+// year. LODES lags 2-4 years. Returns 0 if no year in range is available.
+func lodesLatestYear(ctx context.Context, f fetcher.Fetcher, tempDir, base, state string, log *zap.Logger) int {
+	for offset := 2; offset <= 5; offset++ {
+		tryYear := time.Now().Year() - offset
+		probePath := filepath.Join(tempDir, "lodes_probe.csv.gz")
+		if _, err := f.DownloadToFile(ctx, lodesODURL(base, state, "main", tryYear), probePath); err == nil {
+			log.Info("found LODES data year", zap.Int("year", tryYear), zap.String("probe_state", state))
+			_ = os.Remove(probePath)
+			return tryYear
+		}
+	}
+	return 0
+}
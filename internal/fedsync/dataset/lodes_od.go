@@ -0,0 +1,319 @@
+package dataset
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+var lodesODCols = []string{
+	"year", "state", "w_tract", "h_tract",
+	"total_jobs", "jobs_age_29_or_younger", "jobs_age_30_to_54", "jobs_age_55_plus",
+	"jobs_earn_1250_or_less", "jobs_earn_1251_to_3333", "jobs_earn_3334_or_more",
+}
+
+var lodesODConflictKeys = []string{"year", "w_tract", "h_tract"}
+
+// lodesCatchmentSQL rebuilds workforce catchments for geocoded company
+// addresses. Each address is placed in a census tract via the TIGER block
+// group polygons, then every home tract sending workers to that tract in the
+// latest LODES year is aggregated into a labor-shed summary.
+const lodesCatchmentSQL = `
+WITH sites AS (
+	SELECT a.id AS address_id, a.company_id, left(bg.geoid, 11) AS work_tract
+	FROM public.company_addresses a
+	JOIN geo.block_groups bg ON ST_Contains(bg.geom, a.geom)
+	WHERE a.geom IS NOT NULL
+),
+flows AS (
+	SELECT s.address_id, s.company_id, s.work_tract, o.year, o.h_tract, o.total_jobs,
+		o.jobs_earn_1250_or_less, o.jobs_earn_1251_to_3333, o.jobs_earn_3334_or_more
+	FROM sites s
+	JOIN fed_data.lodes_od o ON o.w_tract = s.work_tract
+	WHERE o.year = (SELECT MAX(year) FROM fed_data.lodes_od)
+),
+home_counties AS (
+	SELECT address_id, left(h_tract, 5) AS county_fips, SUM(total_jobs) AS workers,
+		row_number() OVER (PARTITION BY address_id ORDER BY SUM(total_jobs) DESC, left(h_tract, 5)) AS rn
+	FROM flows
+	GROUP BY 1, 2
+),
+top_counties AS (
+	SELECT address_id,
+		jsonb_agg(jsonb_build_object('county_fips', county_fips, 'workers', workers) ORDER BY rn) AS top_home_counties
+	FROM home_counties
+	WHERE rn <= 5
+	GROUP BY 1
+)
+INSERT INTO fed_data.lodes_catchments (
+	address_id, company_id, year, work_tract, total_workers, home_tracts, home_counties,
+	same_county_share, low_wage_share, high_wage_share, top_home_counties, updated_at
+)
+SELECT
+	f.address_id, f.company_id, f.year, f.work_tract,
+	SUM(f.total_jobs),
+	COUNT(DISTINCT f.h_tract),
+	COUNT(DISTINCT left(f.h_tract, 5)),
+	SUM(f.total_jobs) FILTER (WHERE left(f.h_tract, 5) = left(f.work_tract, 5))::float8 / NULLIF(SUM(f.total_jobs), 0),
+	SUM(f.jobs_earn_1250_or_less)::float8 / NULLIF(SUM(f.total_jobs), 0),
+	SUM(f.jobs_earn_3334_or_more)::float8 / NULLIF(SUM(f.total_jobs), 0),
+	t.top_home_counties,
+	now()
+FROM flows f
+JOIN top_counties t ON t.address_id = f.address_id
+GROUP BY f.address_id, f.company_id, f.year, f.work_tract, t.top_home_counties`
+
+// LODES syncs LEHD Origin-Destination Employment Statistics aggregated from
+// block to tract level, including out-of-state commuters (aux files). After
+// each sync, PostSync rebuilds workforce catchments around geocoded company
+// locations in fed_data.lodes_catchments.
+type LODES struct {
+	cfg     *config.Config
+	baseURL string // override for testing
+	year    int    // override for testing; 0 probes for the latest year
+}
+
+// Name implements Dataset.
+func (d *LODES) Name() string { return "lodes_od" }
+
+// Table implements Dataset.
+func (d *LODES) Table() string { return "fed_data.lodes_od" }
+
+// Phase implements Dataset.
+func (d *LODES) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *LODES) Cadence() Cadence { return Annual }
+
+// ShouldRun implements Dataset.
+func (d *LODES) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return AnnualAfter(now, lastSync, time.June)
+}
+
+// Sync downloads OD main and aux files for each configured state, aggregates
+// to tract pairs, and upserts.
+func (d *LODES) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	states, err := d.states()
+	if err != nil {
+		return nil, err
+	}
+	base := d.baseURL
+	if base == "" {
+		base = lodesBaseURL
+	}
+
+	year := d.year
+	if year == 0 {
+		year = lodesLatestYear(ctx, f, tempDir, base, states[0], log)
+		if year == 0 {
+			log.Warn("lodes_od: no published year found")
+			return &SyncResult{RowsSynced: 0}, nil
+		}
+	}
+	log.Info("syncing LODES OD data", zap.Int("year", year), zap.Int("states", len(states)))
+
+	var totalRows atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(3)
+	for _, st := range states {
+		g.Go(func() error {
+			n, err := d.syncState(gctx, pool, f, tempDir, base, st, year, log)
+			if err != nil {
+				return err
+			}
+			totalRows.Add(n)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	total := totalRows.Load()
+	log.Info("lodes_od sync complete", zap.Int64("rows", total))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"year":   year,
+			"states": len(states),
+		},
+	}, nil
+}
+
+// PostSync implements PostSyncer by rebuilding company workforce catchments.
+func (d *LODES) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrap(err, "lodes_od: begin catchments")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fed_data.lodes_catchments`); err != nil {
+		return eris.Wrap(err, "lodes_od: clear catchments")
+	}
+	tag, err := tx.Exec(ctx, lodesCatchmentSQL)
+	if err != nil {
+		return eris.Wrap(err, "lodes_od: build catchments")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrap(err, "lodes_od: commit catchments")
+	}
+
+	log.Info("lodes_od catchments rebuilt", zap.Int64("addresses", tag.RowsAffected()))
+	return nil
+}
+
+// states returns lowercase state abbreviations from config, or every state
+// and DC when none are configured.
+func (d *LODES) states() ([]string, error) {
+	if d.cfg == nil || len(d.cfg.Fedsync.LODES.States) == 0 {
+		return lodesStates, nil
+	}
+	valid := make(map[string]bool, len(lodesStates))
+	for _, st := range lodesStates {
+		valid[st] = true
+	}
+	var out []string
+	for _, st := range d.cfg.Fedsync.LODES.States {
+		st = strings.ToLower(strings.TrimSpace(st))
+		if !valid[st] {
+			return nil, eris.Errorf("lodes_od: unknown state %q", st)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (d *LODES) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir, base, state string, year int, log *zap.Logger) (int64, error) {
+	agg := make(map[string]*lodesJobAgg)
+	for _, part := range []string{"main", "aux"} {
+		gzPath := filepath.Join(tempDir, fmt.Sprintf("lodes_od_%s_%s.csv.gz", state, part))
+		if _, err := f.DownloadToFile(ctx, lodesODURL(base, state, part, year), gzPath); err != nil {
+			// Some states lag a year behind; skip them.
+			if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+				log.Warn("lodes_od: file not available, skipping",
+					zap.String("state", state), zap.String("part", part))
+				continue
+			}
+			return 0, eris.Wrapf(err, "lodes_od: download %s %s", state, part)
+		}
+		err := aggregateLODESTracts(gzPath, agg)
+		_ = os.Remove(gzPath)
+		if err != nil {
+			return 0, eris.Wrapf(err, "lodes_od: parse %s %s", state, part)
+		}
+	}
+
+	// Tracts aggregate the main and aux files; rows cite the main file.
+	ctx = db.WithSource(ctx, lodesODURL(base, state, "main", year))
+	var batch [][]any
+	var total int64
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      lodesODCols,
+			ConflictKeys: lodesODConflictKeys,
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "lodes_od: upsert %s", state)
+		}
+		total += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for key, e := range agg {
+		wTract, hTract, _ := strings.Cut(key, "|")
+		batch = append(batch, []any{
+			int16(year), // #nosec G115 -- year is a calendar year, fits in int16
+			state, wTract, hTract,
+			e.S000, e.SA01, e.SA02, e.SA03, e.SE01, e.SE02, e.SE03,
+		})
+		if len(batch) >= lodesBatchSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	log.Info("lodes_od state synced", zap.String("state", state), zap.Int64("rows", total))
+	return total, nil
+}
+
+// aggregateLODESTracts adds a gzipped OD file's block-pair job counts into
+// agg, keyed by "work_tract|home_tract" (11-digit tract GEOIDs).
+func aggregateLODESTracts(gzPath string, agg map[string]*lodesJobAgg) error {
+	file, err := os.Open(gzPath) // #nosec G304 -- path from controlled temp dir
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close() //nolint:errcheck
+
+	reader := csv.NewReader(gz)
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	colIdx := mapColumns(header)
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wGeo := getCol(row, colIdx, "w_geocode")
+		hGeo := getCol(row, colIdx, "h_geocode")
+		if len(wGeo) < 11 || len(hGeo) < 11 {
+			continue
+		}
+
+		key := wGeo[:11] + "|" + hGeo[:11]
+		e := agg[key]
+		if e == nil {
+			e = &lodesJobAgg{}
+			agg[key] = e
+		}
+		e.S000 += parseIntOr(getCol(row, colIdx, "s000"), 0)
+		e.SA01 += parseIntOr(getCol(row, colIdx, "sa01"), 0)
+		e.SA02 += parseIntOr(getCol(row, colIdx, "sa02"), 0)
+		e.SA03 += parseIntOr(getCol(row, colIdx, "sa03"), 0)
+		e.SE01 += parseIntOr(getCol(row, colIdx, "se01"), 0)
+		e.SE02 += parseIntOr(getCol(row, colIdx, "se02"), 0)
+		e.SE03 += parseIntOr(getCol(row, colIdx, "se03"), 0)
+	}
+}
@@ -0,0 +1,134 @@
+package dataset
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+// writeGzipCSV writes content gzip-compressed to path.
+func writeGzipCSV(t *testing.T, path, content string) {
+	t.Helper()
+	file, err := os.Create(path) // #nosec G304 -- test temp path
+	require.NoError(t, err)
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestLODES_Metadata(t *testing.T) {
+	d := &LODES{}
+	assert.Equal(t, "lodes_od", d.Name())
+	assert.Equal(t, "fed_data.lodes_od", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Annual, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+	assert.Implements(t, (*PostSyncer)(nil), d)
+}
+
+func TestLODES_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+
+	// Two blocks in the same work/home tract pair collapse into one row.
+	f.EXPECT().DownloadToFile(mock.Anything, "http://test/tx/od/tx_od_main_JT00_2022.csv.gz", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeGzipCSV(t, path, "w_geocode,h_geocode,S000,SA01,SA02,SA03,SE01,SE02,SE03,SI01,SI02,SI03,createdate\n"+
+				"484530011001000,484530012002001,5,1,3,1,1,2,2,0,1,4,20240101\n"+
+				"484530011001002,484530012002005,3,1,1,1,0,1,2,0,0,3,20240101\n"+
+				"484530011001000,480219501001000,2,0,2,0,0,0,2,0,0,2,20240101\n"+
+				"bad,row,1,0,0,0,0,0,0,0,0,0,20240101\n")
+		}).
+		Return(int64(100), nil).Once()
+	f.EXPECT().DownloadToFile(mock.Anything, "http://test/tx/od/tx_od_aux_JT00_2022.csv.gz", mock.Anything).
+		Return(int64(0), errors.New("HTTP 404 not found")).Once()
+
+	expectBulkUpsert(pool, "fed_data.lodes_od", lodesODCols, 2)
+
+	d := &LODES{
+		cfg:     &config.Config{Fedsync: config.FedsyncConfig{LODES: config.LODESConfig{States: []string{"TX"}}}},
+		baseURL: "http://test",
+		year:    2022,
+	}
+	res, err := d.Sync(ctx, pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 2022, res.Metadata["year"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLODES_Sync_UnknownState(t *testing.T) {
+	d := &LODES{cfg: &config.Config{Fedsync: config.FedsyncConfig{LODES: config.LODESConfig{States: []string{"ZZ"}}}}}
+	_, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown state "zz"`)
+}
+
+func TestAggregateLODESTracts(t *testing.T) {
+	path := t.TempDir() + "/od.csv.gz"
+	writeGzipCSV(t, path, "w_geocode,h_geocode,S000,SA01,SA02,SA03,SE01,SE02,SE03\n"+
+		"484530011001000,484530012002001,5,1,3,1,1,2,2\n"+
+		"484530011001002,484530012002005,3,1,1,1,0,1,2\n")
+
+	agg := make(map[string]*lodesJobAgg)
+	require.NoError(t, aggregateLODESTracts(path, agg))
+	require.Len(t, agg, 1)
+	e := agg["48453001100|48453001200"]
+	require.NotNil(t, e)
+	assert.Equal(t, 8, e.S000)
+	assert.Equal(t, 4, e.SE03)
+}
+
+func TestLODES_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.lodes_catchments").
+		WillReturnResult(pgxmock.NewResult("DELETE", 10))
+	pool.ExpectExec("INSERT INTO fed_data.lodes_catchments").
+		WillReturnResult(pgxmock.NewResult("INSERT", 12))
+	pool.ExpectCommit()
+	pool.ExpectRollback()
+
+	d := &LODES{}
+	require.NoError(t, d.PostSync(context.Background(), pool, &SyncResult{RowsSynced: 2}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLODES_PostSync_Error(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.lodes_catchments").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("INSERT INTO fed_data.lodes_catchments").
+		WillReturnError(errors.New("function st_contains does not exist"))
+	pool.ExpectRollback()
+
+	d := &LODES{}
+	err = d.PostSync(context.Background(), pool, nil)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "lodes_od: build catchments"))
+}
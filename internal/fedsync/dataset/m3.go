@@ -2,9 +2,7 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -12,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync/censusapi"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -53,45 +52,30 @@ func (d *M3) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string
 
 	// Census consolidated M3 endpoint requires: time, seasonally_adj, for=us:*
 	// Fetch all data types and category codes in a single request.
-	url := fmt.Sprintf(
-		"https://api.census.gov/data/timeseries/eits/m3?get=cell_value,time_slot_id,category_code,data_type_code&for=us:*&time=from+2020&seasonally_adj=yes&key=%s",
-		d.cfg.Fedsync.CensusKey)
-
-	body, err := f.Download(ctx, url)
+	t, err := censusClient(d.cfg, f).Fetch(ctx, censusapi.Query{
+		Dataset: "timeseries/eits/m3",
+		Get:     []string{"cell_value", "time_slot_id", "category_code", "data_type_code"},
+		For:     "us:*",
+		Predicates: []censusapi.Predicate{
+			{Name: "time", Value: "from 2020"},
+			{Name: "seasonally_adj", Value: "yes"},
+		},
+	})
 	if err != nil {
-		return nil, eris.Wrap(err, "m3: download")
+		return nil, eris.Wrap(err, "m3")
 	}
-
-	data, err := io.ReadAll(body)
-	_ = body.Close()
-	if err != nil {
-		return nil, eris.Wrap(err, "m3: read response")
-	}
-
-	var result [][]string
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, eris.Wrap(err, "m3: parse json")
-	}
-
-	if len(result) < 2 {
+	if t.Len() == 0 {
 		return &SyncResult{RowsSynced: 0}, nil
 	}
 
-	// Build column index from header
-	header := result[0]
-	colIdx := make(map[string]int, len(header))
-	for i, col := range header {
-		colIdx[col] = i
-	}
-
 	var allRows [][]any
 	seen := make(map[string]int)
 
-	for _, row := range result[1:] {
-		cellValue := getColIdx(row, colIdx, "cell_value")
-		timeStr := getColIdx(row, colIdx, "time")
-		catCode := getColIdx(row, colIdx, "category_code")
-		dtCode := getColIdx(row, colIdx, "data_type_code")
+	for _, rec := range t.Rows {
+		cellValue := t.Get(rec, "cell_value")
+		timeStr := t.Get(rec, "time")
+		catCode := t.Get(rec, "category_code")
+		dtCode := t.Get(rec, "data_type_code")
 
 		// Only keep core data types (VS, NO, TI, UO)
 		dataType, ok := m3DataTypes[dtCode]
@@ -48,36 +48,75 @@ var datasetMetadata = map[string]Metadata{
 	"eo_bmf":            {Label: "IRS Exempt Orgs", Description: "IRS Exempt Organizations Business Master File"},
 	"census_geo":        {Label: "Census Geography", Description: "Census CBSA/MSA geographic definitions"},
 	"usaspending":       {Label: "USAspending", Description: "USAspending.gov award and subaward data"},
+	"sam_entities":      {Label: "SAM.gov Entities", Description: "SAM.gov entity registrations by UEI and the federal exclusions (debarment) list"},
+	"federal_grants":    {Label: "Federal Grants & Single Audits", Description: "USAspending grant awards and Federal Audit Clearinghouse single audits"},
 	"adv_part1":         {Label: "ADV Part 1A", Description: "SEC ADV Part 1A investment adviser registrations"},
 	"ia_compilation":    {Label: "IARD Daily", Description: "IARD investment adviser representative compilation"},
 	"holdings_13f":      {Label: "13F Holdings", Description: "SEC 13F institutional investment manager holdings"},
 	"form_d":            {Label: "Form D", Description: "EDGAR Form D private placement notices"},
 	"edgar_submissions": {Label: "EDGAR Submissions", Description: "EDGAR bulk company submissions and filings"},
+	"company_tickers":   {Label: "SEC Company Tickers", Description: "SEC ticker-to-CIK map with listing exchange"},
+	"edgar_fulltext":    {Label: "EDGAR Full-Text Search", Description: "EDGAR full-text search hits for configured keyword queries"},
 	"entity_xref":       {Label: "Entity Cross-Reference", Description: "Cross-reference relationships across entity datasets"},
+	"investor_graph":    {Label: "13F Investor Graph", Description: "Advisor-to-issuer positions over time derived from 13F holdings"},
 	"adv_part2":         {Label: "ADV Part 2 Brochures", Description: "SEC ADV Part 2A brochure PDF extraction"},
 	"brokercheck":       {Label: "BrokerCheck", Description: "FINRA BrokerCheck broker-dealer registrations"},
-	"sec_enforcement":   {Label: "SEC Enforcement", Description: "SEC enforcement actions and proceedings"},
+	"sec_enforcement":   {Label: "SEC Enforcement", Description: "SEC litigation releases and administrative proceedings linked to CRD/CIK"},
 	"form_bd":           {Label: "Form BD", Description: "FINRA Form BD broker-dealer registrations"},
 	"osha_ita":          {Label: "OSHA ITA", Description: "OSHA injury tracking application inspection data"},
+	"osha_ita_300a":     {Label: "OSHA ITA 300A", Description: "OSHA ITA Form 300A establishment injury summaries with hours worked and DART/TRIR rates"},
 	"epa_echo":          {Label: "EPA ECHO", Description: "EPA ECHO facility compliance and enforcement"},
+	"epa_enforcement":   {Label: "EPA Enforcement", Description: "EPA ECHO formal actions, penalties, noncompliance flags, and civil cases by facility"},
 	"nes":               {Label: "Nonemployer Statistics", Description: "Census Nonemployer Statistics"},
 	"asm":               {Label: "Annual Survey of Manufactures", Description: "Census Annual Survey of Manufactures"},
 	"eci":               {Label: "Employment Cost Index", Description: "BLS Employment Cost Index compensation trends"},
 	"fdic_bankfind":     {Label: "FDIC BankFind", Description: "FDIC BankFind financial institution data"},
 	"ncen":              {Label: "N-CEN", Description: "SEC Form N-CEN registered fund census filings"},
 	"ncua_call_reports": {Label: "NCUA Call Reports", Description: "NCUA quarterly credit union call reports"},
-	"bea_regional":      {Label: "BEA Regional", Description: "BEA regional GDP and personal income data"},
+	"bea_regional":      {Label: "BEA Regional", Description: "BEA regional GDP, personal income, and compensation by county and MSA"},
 	"irs_soi_migration": {Label: "IRS SOI Migration", Description: "IRS SOI county-to-county migration flows"},
 	"building_permits":  {Label: "Building Permits", Description: "Census building permits by place and county"},
+	"nppes":             {Label: "NPPES NPI Registry", Description: "CMS NPPES NPI healthcare provider registry"},
+	"acs":               {Label: "American Community Survey", Description: "Census ACS 5-year demographics by county and tract"},
+	"bfs":               {Label: "Business Formation Statistics", Description: "Census BFS business applications by state and NAICS sector"},
+	"bds":               {Label: "Business Dynamics Statistics", Description: "Census BDS establishment entry/exit and job flows by sector and geography"},
 	"adv_part3":         {Label: "CRS Brochures", Description: "SEC ADV Part 3 CRS relationship summary PDFs"},
 	"adv_enrichment":    {Label: "ADV Enrichment", Description: "ADV brochure structured section extraction"},
 	"adv_extract":       {Label: "ADV Extract", Description: "ADV advisor answer extraction via LLM"},
+	"fund_providers":    {Label: "Fund Provider Network", Description: "Private fund auditor, administrator, and prime broker network"},
+	"opportunity":       {Label: "County Opportunity Scores", Description: "County sourcing priority from CBP, SUSB, ACS, and Salesforce coverage"},
 	"xbrl_facts":        {Label: "XBRL Facts", Description: "EDGAR XBRL financial fact data"},
+	"xbrl_frames":       {Label: "XBRL Frames", Description: "EDGAR XBRL frames: one financial concept across all filers per calendar period"},
 	"fred":              {Label: "FRED Series", Description: "Federal Reserve FRED economic data series"},
 	"abs":               {Label: "Annual Business Survey", Description: "Census Annual Business Survey"},
 	"cps_laus":          {Label: "CPS/LAUS", Description: "BLS Current Population Survey / Local Area Unemployment"},
+	"jolts":             {Label: "JOLTS", Description: "BLS Job Openings and Labor Turnover Survey openings, hires, and quits"},
+	"ppi":               {Label: "PPI", Description: "BLS Producer Price Index series"},
+	"cpi":               {Label: "CPI", Description: "BLS Consumer Price Index series"},
 	"m3":                {Label: "M3 Manufacturers", Description: "Census M3 manufacturers shipments/inventories/orders"},
 	"lehd_lodes":        {Label: "LEHD LODES", Description: "Census LEHD LODES origin-destination employment data"},
+	"lodes_od":          {Label: "LODES Tract Flows", Description: "LEHD LODES tract-level commuting flows and company workforce catchments"},
+	"irs_soi":           {Label: "IRS SOI ZIP Income", Description: "IRS Statistics of Income individual income by ZIP code and AGI class"},
+	"fcc_bdc":           {Label: "FCC Broadband", Description: "FCC Broadband Data Collection fixed availability by census block and county"},
+	"eia":               {Label: "EIA Energy Prices", Description: "EIA state retail electricity prices by sector and selected fuel price series"},
+	"fmcsa":             {Label: "FMCSA Carriers", Description: "FMCSA motor carrier census with SMS safety percentiles, keyed by USDOT number"},
+	"faa_registry":      {Label: "FAA Aircraft", Description: "FAA aircraft registry with business registrants matched to companies and ADV firms"},
+	"emma":              {Label: "MSRB EMMA", Description: "MSRB EMMA municipal issues with continuing disclosure rollups and municipal advisors linked to ADV firms"},
+	"firm_momentum":     {Label: "Firm Momentum", Description: "Per-firm AUM, headcount, disclosure, and website change trends with a momentum score"},
+	"sos_co":            {Label: "Colorado SOS", Description: "Colorado Secretary of State business entities with status, registered agent, and formation date"},
+	"sos_wa":            {Label: "Washington SOS", Description: "Washington Secretary of State business entities keyed by UBI number"},
+	"sos_fl":            {Label: "Florida SOS", Description: "Florida Sunbiz corporate filings from the cordata fixed-width extract"},
+	"sos_oh":            {Label: "Ohio SOS", Description: "Ohio Secretary of State new business filings"},
+	"ucc_co":            {Label: "Colorado UCC", Description: "Colorado UCC financing statements with debtor, secured party, and collateral type"},
+	"ucc_fl":            {Label: "Florida UCC", Description: "Florida Secured Transaction Registry UCC financing statements"},
+	"ucc_tx":            {Label: "Texas UCC", Description: "Texas Secretary of State UCC financing statements"},
+	"ucc_wa":            {Label: "Washington UCC", Description: "Washington UCC financing statements with debtor, secured party, and collateral type"},
+	"courtlistener":     {Label: "Court Dockets", Description: "CourtListener RECAP federal dockets naming tracked companies as defendants"},
+	"finra_arbitration": {Label: "FINRA Arbitration", Description: "FINRA arbitration awards with OCR'd award text, amounts, and respondent firm CRDs"},
+	"insurance_co":      {Label: "Colorado Insurance", Description: "Colorado DOI insurance producer and agency licenses with lines of authority"},
+	"insurance_fl":      {Label: "Florida Insurance", Description: "Florida DFS insurance agent and agency licenses with lines of authority"},
+	"insurance_tx":      {Label: "Texas Insurance", Description: "Texas DOI insurance agent and agency licenses with lines of authority"},
+	"insurance_wa":      {Label: "Washington Insurance", Description: "Washington OIC insurance producer and agency licenses with lines of authority"},
 }
 
 // BuildCatalog returns a live dataset catalog merged with frontend metadata.
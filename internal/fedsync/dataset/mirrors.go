@@ -0,0 +1,39 @@
+package dataset
+
+import (
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// censusMirrors are the alternate hosts for files published on
+// www2.census.gov. ftp2.census.gov serves the same tree over HTTPS and FTP.
+var censusMirrors = []fetcher.Mirror{{
+	Prefix: "https://www2.census.gov/",
+	URLs:   []string{"https://ftp2.census.gov/", "ftp://ftp2.census.gov/"},
+}}
+
+// MirrorsFromConfig converts the fedsync.mirrors config entries to fetcher
+// mirrors.
+func MirrorsFromConfig(cfg *config.Config) []fetcher.Mirror {
+	if cfg == nil {
+		return nil
+	}
+	var out []fetcher.Mirror
+	for _, m := range cfg.Fedsync.Mirrors {
+		out = append(out, fetcher.Mirror{Prefix: m.Prefix, URLs: m.URLs})
+	}
+	return out
+}
+
+// FetcherFor returns f wrapped with mirror failover for ds. Configured
+// mirrors are tried before those the dataset declares via Mirrored. When
+// neither exists f is returned unchanged.
+func FetcherFor(f fetcher.Fetcher, ds Dataset, mirrors []fetcher.Mirror) fetcher.Fetcher {
+	if md, ok := ds.(Mirrored); ok {
+		mirrors = append(append([]fetcher.Mirror(nil), mirrors...), md.Mirrors()...)
+	}
+	if len(mirrors) == 0 {
+		return f
+	}
+	return fetcher.NewMirrorFetcher(f, mirrors)
+}
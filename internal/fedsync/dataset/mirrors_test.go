@@ -0,0 +1,51 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestMirrorsFromConfig(t *testing.T) {
+	assert.Nil(t, MirrorsFromConfig(nil))
+
+	cfg := &config.Config{Fedsync: config.FedsyncConfig{Mirrors: []config.MirrorConfig{
+		{Prefix: "https://www.sec.gov/Archives/", URLs: []string{"https://backup.example.com/Archives/"}},
+	}}}
+	assert.Equal(t, []fetcher.Mirror{
+		{Prefix: "https://www.sec.gov/Archives/", URLs: []string{"https://backup.example.com/Archives/"}},
+	}, MirrorsFromConfig(cfg))
+}
+
+func TestFetcherFor(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+
+	// No declared or configured mirrors: the fetcher is used as-is.
+	assert.Same(t, f, FetcherFor(f, &FRED{}, nil))
+	assert.Implements(t, (*Mirrored)(nil), &CBP{})
+	assert.IsType(t, &fetcher.MirrorFetcher{}, FetcherFor(f, &CBP{}, nil))
+}
+
+func TestFetcherFor_CensusFailover(t *testing.T) {
+	ctx := context.Background()
+	f := fetchermocks.NewMockFetcher(t)
+	primary := "https://www2.census.gov/econ/bps/County/co2024a.txt"
+	mirror := "https://ftp2.census.gov/econ/bps/County/co2024a.txt"
+
+	f.EXPECT().Download(ctx, primary).Return(nil, errors.New("all retries exhausted: http 503 from www2")).Once()
+	f.EXPECT().Download(ctx, mirror).Return(io.NopCloser(strings.NewReader("ok")), nil).Once()
+
+	body, err := FetcherFor(f, &BuildingPermits{}, nil).Download(ctx, primary)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "ok", string(data))
+}
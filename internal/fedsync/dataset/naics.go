@@ -0,0 +1,23 @@
+package dataset
+
+import (
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync/transform"
+)
+
+// naicsFilterFor returns the NAICS allowlist configured for dataset name:
+// its fedsync.naics.datasets entry, else fedsync.naics.include. A nil cfg
+// keeps every industry.
+func naicsFilterFor(cfg *config.Config, name string) (*transform.NAICSFilter, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	rules, ok := cfg.Fedsync.NAICS.Datasets[name]
+	if !ok {
+		rules = cfg.Fedsync.NAICS.Include
+	}
+	f, err := transform.ParseNAICSFilter(rules)
+	return f, eris.Wrapf(err, "%s: fedsync.naics", name)
+}
@@ -0,0 +1,64 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+func TestNAICSFilterFor(t *testing.T) {
+	f, err := naicsFilterFor(nil, "cbp")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	cfg := &config.Config{}
+	cfg.Fedsync.NAICS.Include = []string{"52"}
+	cfg.Fedsync.NAICS.Datasets = map[string][]string{"qcew": {"all"}, "oews": {"5x"}}
+
+	f, err = naicsFilterFor(cfg, "cbp")
+	require.NoError(t, err)
+	assert.True(t, f.Match("523110"))
+	assert.False(t, f.Match("621111"))
+
+	f, err = naicsFilterFor(cfg, "qcew")
+	require.NoError(t, err)
+	assert.Nil(t, f, "per-dataset override")
+
+	_, err = naicsFilterFor(cfg, "oews")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "oews: fedsync.naics")
+}
+
+func TestEconCensus_ParseResponse_NAICSFilter(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Fedsync.NAICS.Include = []string{"62", "48-49"}
+	ds := &EconCensus{cfg: cfg}
+
+	data := []byte(`[
+		["GEO_ID","NAICS2017","ESTAB","RCPTOT","PAYANN","EMP","state"],
+		["0400000US06","523110","1500","5000000","2000000","15000","06"],
+		["0400000US36","621111","800","3000000","1000000","8000","36"],
+		["0400000US48","484110","2200","7000000","3500000","22000","48"]
+	]`)
+
+	rows, err := ds.parseResponse(censusTable(t, data), 2022)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "621111", rows[0][2])
+	assert.Equal(t, "484110", rows[1][2])
+}
+
+func TestQCEW_IsRelevantFile_NAICSFilter(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Fedsync.NAICS.Include = []string{"5231"}
+	f, err := naicsFilterFor(cfg, "qcew")
+	require.NoError(t, err)
+
+	ds := &QCEW{cfg: cfg}
+	assert.True(t, ds.isRelevantFile("2023.q1-q4 52 naics 52.csv", f))
+	assert.True(t, ds.isRelevantFile("path/to/10 total all.csv", f))
+	assert.False(t, ds.isRelevantFile("2023.q1-q4 62 naics 62.csv", f))
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -315,16 +316,19 @@ func (d *NCUACallReports) processFOICU(ctx context.Context, pool db.Pool, foicuP
 
 		cuNumStr := get("CU_NUMBER")
 		if cuNumStr == "" {
+			fedsync.Reject(ctx, "missing_cu_number", record)
 			continue
 		}
 		cuNum, parseErr := strconv.Atoi(cuNumStr)
 		if parseErr != nil {
+			fedsync.Reject(ctx, "bad_cu_number", record)
 			continue
 		}
 
 		cycleDateStr := get("CYCLE_DATE")
 		cycleDate := parseNCUADate(cycleDateStr)
 		if cycleDate.IsZero() {
+			fedsync.Reject(ctx, "bad_cycle_date", record)
 			continue
 		}
 
@@ -0,0 +1,387 @@
+package dataset
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	nppesFilesURL     = "https://download.cms.gov/nppes/NPI_Files.html"
+	nppesDownloadBase = "https://download.cms.gov/nppes/"
+	nppesBatchSize    = 10000
+	nppesMaxTaxonomy  = 15
+)
+
+var (
+	// nppesMonthlyRe matches the monthly full replacement file, e.g.
+	// NPPES_Data_Dissemination_October_2026_V2.zip.
+	nppesMonthlyRe = regexp.MustCompile(`NPPES_Data_Dissemination_([A-Za-z]+)_(\d{4})(?:_V2)?\.zip`)
+
+	// nppesWeeklyRe matches the weekly incremental file, e.g.
+	// NPPES_Data_Dissemination_100526_101126_Weekly_V2.zip.
+	nppesWeeklyRe = regexp.MustCompile(`NPPES_Data_Dissemination_(\d{6})_(\d{6})_Weekly(?:_V2)?\.zip`)
+)
+
+// nppesColumns defines the target DB columns in upsert order.
+var nppesColumns = []string{
+	"npi", "entity_type", "org_name", "last_name", "first_name", "middle_name",
+	"credential", "other_org_name",
+	"practice_address1", "practice_address2", "practice_city", "practice_state",
+	"practice_zip", "practice_country", "practice_phone",
+	"primary_taxonomy", "taxonomy_codes", "sole_proprietor",
+	"enumeration_date", "last_update_date", "deactivation_date", "reactivation_date",
+	"source_file",
+}
+
+// nppesFile describes one downloadable NPPES dissemination ZIP.
+type nppesFile struct {
+	Name   string
+	Date   time.Time // release month (monthly) or period start (weekly)
+	Weekly bool
+}
+
+// NPPES implements the CMS National Plan and Provider Enumeration System
+// NPI registry. The monthly dissemination file is a full replacement of
+// fed_data.npi_providers; weekly files are applied as deltas on top of it.
+type NPPES struct {
+	// filesURL is the NPPES download index page. Defaults to nppesFilesURL.
+	filesURL string
+}
+
+// Name implements Dataset.
+func (d *NPPES) Name() string { return "nppes" }
+
+// Table implements Dataset.
+func (d *NPPES) Table() string { return "fed_data.npi_providers" }
+
+// Phase implements Dataset.
+func (d *NPPES) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *NPPES) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *NPPES) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync loads the latest monthly full file if it has not been applied yet,
+// then applies every newer weekly delta that has not been loaded.
+func (d *NPPES) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, tempDir, false)
+}
+
+// SyncFull reloads the latest monthly full file regardless of load history,
+// then applies newer weekly deltas.
+func (d *NPPES) SyncFull(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	return d.sync(ctx, pool, f, tempDir, true)
+}
+
+func (d *NPPES) sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, force bool) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", "nppes"))
+
+	monthly, weekly, err := d.discoverFiles(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := d.loadedFiles(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalRows int64
+	var applied []string
+
+	if force || !loaded[monthly.Name] {
+		log.Info("loading NPPES monthly full replacement", zap.String("file", monthly.Name))
+		n, err := d.applyFile(ctx, pool, f, tempDir, monthly)
+		if err != nil {
+			return nil, err
+		}
+		totalRows += n
+		applied = append(applied, monthly.Name)
+	}
+
+	for _, wf := range weekly {
+		if !wf.Date.After(monthly.Date) || loaded[wf.Name] {
+			continue
+		}
+		log.Info("applying NPPES weekly delta", zap.String("file", wf.Name))
+		n, err := d.applyFile(ctx, pool, f, tempDir, wf)
+		if err != nil {
+			return nil, err
+		}
+		totalRows += n
+		applied = append(applied, wf.Name)
+	}
+
+	return &SyncResult{
+		RowsSynced: totalRows,
+		Metadata: map[string]any{
+			"monthly_file":  monthly.Name,
+			"files_applied": applied,
+		},
+	}, nil
+}
+
+// discoverFiles scrapes the NPPES download page for the most recent monthly
+// file and all listed weekly files (sorted oldest first).
+func (d *NPPES) discoverFiles(ctx context.Context, f fetcher.Fetcher) (nppesFile, []nppesFile, error) {
+	indexURL := d.filesURL
+	if indexURL == "" {
+		indexURL = nppesFilesURL
+	}
+
+	body, err := f.Download(ctx, indexURL)
+	if err != nil {
+		return nppesFile{}, nil, eris.Wrap(err, "nppes: fetch file index")
+	}
+	defer body.Close() //nolint:errcheck
+
+	page, err := io.ReadAll(body)
+	if err != nil {
+		return nppesFile{}, nil, eris.Wrap(err, "nppes: read file index")
+	}
+
+	monthly, weekly := parseNPPESIndex(string(page))
+	if monthly == nil {
+		return nppesFile{}, nil, eris.New("nppes: no monthly file found in index")
+	}
+	return *monthly, weekly, nil
+}
+
+// parseNPPESIndex extracts dissemination file names from the index HTML.
+func parseNPPESIndex(page string) (*nppesFile, []nppesFile) {
+	var monthly *nppesFile
+	for _, m := range nppesMonthlyRe.FindAllStringSubmatch(page, -1) {
+		t, err := time.Parse("January 2006", m[1]+" "+m[2])
+		if err != nil {
+			continue
+		}
+		if monthly == nil || t.After(monthly.Date) {
+			monthly = &nppesFile{Name: m[0], Date: t}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var weekly []nppesFile
+	for _, m := range nppesWeeklyRe.FindAllStringSubmatch(page, -1) {
+		if seen[m[0]] {
+			continue
+		}
+		t, err := time.Parse("010206", m[1])
+		if err != nil {
+			continue
+		}
+		seen[m[0]] = true
+		weekly = append(weekly, nppesFile{Name: m[0], Date: t, Weekly: true})
+	}
+	slices.SortFunc(weekly, func(a, b nppesFile) int { return a.Date.Compare(b.Date) })
+
+	return monthly, weekly
+}
+
+// loadedFiles returns the set of dissemination files already applied.
+func (d *NPPES) loadedFiles(ctx context.Context, pool db.Pool) (map[string]bool, error) {
+	rows, err := pool.Query(ctx, "SELECT file_name FROM fed_data.npi_file_loads")
+	if err != nil {
+		return nil, eris.Wrap(err, "nppes: query loaded files")
+	}
+	defer rows.Close()
+
+	loaded := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, eris.Wrap(err, "nppes: scan loaded file")
+		}
+		loaded[name] = true
+	}
+	return loaded, rows.Err()
+}
+
+// applyFile downloads one dissemination ZIP and loads it. A monthly file
+// replaces the table: providers absent from it are deleted afterwards.
+func (d *NPPES) applyFile(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, nf nppesFile) (int64, error) {
+	zipPath := filepath.Join(tempDir, nf.Name)
+	if _, err := f.DownloadToFile(ctx, nppesDownloadBase+nf.Name, zipPath); err != nil {
+		return 0, eris.Wrapf(err, "nppes: download %s", nf.Name)
+	}
+	defer os.Remove(zipPath) //nolint:errcheck
+
+	n, err := d.processZip(ctx, pool, zipPath, nf.Name)
+	if err != nil {
+		return n, eris.Wrapf(err, "nppes: process %s", nf.Name)
+	}
+
+	if !nf.Weekly {
+		tag, err := pool.Exec(ctx, "DELETE FROM fed_data.npi_providers WHERE source_file <> $1", nf.Name)
+		if err != nil {
+			return n, eris.Wrap(err, "nppes: remove providers absent from full file")
+		}
+		zap.L().Info("nppes: removed stale providers", zap.Int64("rows", tag.RowsAffected()))
+	}
+
+	fileType := "monthly"
+	if nf.Weekly {
+		fileType = "weekly"
+	}
+	_, err = pool.Exec(ctx,
+		`INSERT INTO fed_data.npi_file_loads (file_name, file_type, file_date, rows_loaded, loaded_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (file_name) DO UPDATE SET rows_loaded = EXCLUDED.rows_loaded, loaded_at = now()`,
+		nf.Name, fileType, nf.Date, n)
+	if err != nil {
+		return n, eris.Wrapf(err, "nppes: record load of %s", nf.Name)
+	}
+	return n, nil
+}
+
+func (d *NPPES) processZip(ctx context.Context, pool db.Pool, zipPath, sourceFile string) (int64, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return 0, eris.Wrap(err, "nppes: open zip")
+	}
+	defer zr.Close() //nolint:errcheck
+
+	for _, zf := range zr.File {
+		name := strings.ToLower(filepath.Base(zf.Name))
+		if !strings.HasPrefix(name, "npidata_pfile") || strings.Contains(name, "fileheader") {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return 0, eris.Wrapf(err, "nppes: open file %s in zip", zf.Name)
+		}
+		n, err := d.parseCSV(ctx, pool, rc, sourceFile)
+		_ = rc.Close()
+		return n, err
+	}
+
+	return 0, eris.New("nppes: no npidata file found in zip")
+}
+
+func (d *NPPES) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, sourceFile string) (int64, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, eris.Wrap(err, "nppes: read CSV header")
+	}
+	colIdx := mapColumns(header)
+
+	upsertCfg := db.UpsertConfig{
+		Table:        "fed_data.npi_providers",
+		Columns:      nppesColumns,
+		ConflictKeys: []string{"npi"},
+	}
+
+	var batch [][]any
+	var totalRows int64
+
+	flush := func() error {
+		n, err := db.BulkUpsert(ctx, pool, upsertCfg, batch)
+		if err != nil {
+			return eris.Wrap(err, "nppes: bulk upsert")
+		}
+		totalRows += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue // skip malformed rows
+		}
+
+		npi := parseInt64Or(getCol(record, colIdx, "npi"), 0)
+		if npi == 0 {
+			continue
+		}
+
+		primary, codes := nppesTaxonomies(record, colIdx)
+
+		batch = append(batch, []any{
+			npi,
+			parseInt16Or(getCol(record, colIdx, "entity type code"), 0),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider organization name (legal business name)"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider last name (legal name)"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider first name"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider middle name"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider credential text"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider other organization name"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider first line business practice location address"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider second line business practice location address"))),
+			sanitizeUTF8(strings.TrimSpace(getCol(record, colIdx, "provider business practice location address city name"))),
+			strings.TrimSpace(getCol(record, colIdx, "provider business practice location address state name")),
+			strings.TrimSpace(getCol(record, colIdx, "provider business practice location address postal code")),
+			strings.TrimSpace(getCol(record, colIdx, "provider business practice location address country code (if outside u.s.)")),
+			strings.TrimSpace(getCol(record, colIdx, "provider business practice location address telephone number")),
+			primary,
+			codes,
+			parseBoolYN(getCol(record, colIdx, "is sole proprietor")),
+			parseDate(getCol(record, colIdx, "provider enumeration date")),
+			parseDate(getCol(record, colIdx, "last update date")),
+			parseDate(getCol(record, colIdx, "npi deactivation date")),
+			parseDate(getCol(record, colIdx, "npi reactivation date")),
+			sourceFile,
+		})
+
+		if len(batch) >= nppesBatchSize {
+			if err := flush(); err != nil {
+				return totalRows, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return totalRows, err
+		}
+	}
+
+	return totalRows, nil
+}
+
+// nppesTaxonomies returns the primary taxonomy code and all listed codes.
+// When no code is flagged primary, the first listed code is used.
+func nppesTaxonomies(record []string, colIdx map[string]int) (string, []string) {
+	var primary string
+	codes := make([]string, 0, 2)
+	for i := 1; i <= nppesMaxTaxonomy; i++ {
+		code := strings.TrimSpace(getCol(record, colIdx, fmt.Sprintf("healthcare provider taxonomy code_%d", i)))
+		if code == "" {
+			continue
+		}
+		codes = append(codes, code)
+		if primary == "" && parseBoolYN(getCol(record, colIdx, fmt.Sprintf("healthcare provider primary taxonomy switch_%d", i))) {
+			primary = code
+		}
+	}
+	if primary == "" && len(codes) > 0 {
+		primary = codes[0]
+	}
+	return primary, codes
+}
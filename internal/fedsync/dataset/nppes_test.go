@@ -0,0 +1,194 @@
+package dataset
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const nppesCSVHeader = `"NPI","Entity Type Code","Provider Organization Name (Legal Business Name)","Provider Last Name (Legal Name)","Provider First Name","Provider Middle Name","Provider Credential Text","Provider Other Organization Name","Provider First Line Business Practice Location Address","Provider Second Line Business Practice Location Address","Provider Business Practice Location Address City Name","Provider Business Practice Location Address State Name","Provider Business Practice Location Address Postal Code","Provider Business Practice Location Address Country Code (If outside U.S.)","Provider Business Practice Location Address Telephone Number","Provider Enumeration Date","Last Update Date","NPI Deactivation Date","NPI Reactivation Date","Is Sole Proprietor","Healthcare Provider Taxonomy Code_1","Healthcare Provider Primary Taxonomy Switch_1","Healthcare Provider Taxonomy Code_2","Healthcare Provider Primary Taxonomy Switch_2"` + "\n"
+
+const nppesTestIndex = `<html><body>
+<a href="NPPES_Data_Dissemination_September_2026_V2.zip">September</a>
+<a href="NPPES_Data_Dissemination_October_2026_V2.zip">October</a>
+<a href="NPPES_Data_Dissemination_092826_100426_Weekly_V2.zip">Weekly</a>
+<a href="NPPES_Data_Dissemination_101226_101826_Weekly_V2.zip">Weekly</a>
+<a href="NPPES_Data_Dissemination_100526_101126_Weekly_V2.zip">Weekly</a>
+</body></html>`
+
+func TestNPPES_Metadata(t *testing.T) {
+	ds := &NPPES{}
+	assert.Equal(t, "nppes", ds.Name())
+	assert.Equal(t, "fed_data.npi_providers", ds.Table())
+	assert.Equal(t, Phase2, ds.Phase())
+	assert.Equal(t, Weekly, ds.Cadence())
+}
+
+func TestNPPES_ShouldRun(t *testing.T) {
+	ds := &NPPES{}
+	now := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, ds.ShouldRun(now, nil))
+
+	recent := now.Add(-24 * time.Hour)
+	assert.False(t, ds.ShouldRun(now, &recent))
+
+	old := now.AddDate(0, 0, -14)
+	assert.True(t, ds.ShouldRun(now, &old))
+}
+
+func TestParseNPPESIndex(t *testing.T) {
+	monthly, weekly := parseNPPESIndex(nppesTestIndex)
+	require.NotNil(t, monthly)
+	assert.Equal(t, "NPPES_Data_Dissemination_October_2026_V2.zip", monthly.Name)
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), monthly.Date)
+
+	require.Len(t, weekly, 3)
+	assert.Equal(t, "NPPES_Data_Dissemination_092826_100426_Weekly_V2.zip", weekly[0].Name)
+	assert.Equal(t, "NPPES_Data_Dissemination_101226_101826_Weekly_V2.zip", weekly[2].Name)
+	assert.True(t, weekly[0].Weekly)
+}
+
+func TestParseNPPESIndex_NoMonthly(t *testing.T) {
+	monthly, weekly := parseNPPESIndex("<html></html>")
+	assert.Nil(t, monthly)
+	assert.Empty(t, weekly)
+}
+
+func TestNPPESTaxonomies(t *testing.T) {
+	colIdx := mapColumns(strings.Split(strings.ReplaceAll(strings.TrimSpace(nppesCSVHeader), `"`, ""), ","))
+
+	record := make([]string, len(colIdx))
+	record[colIdx["healthcare provider taxonomy code_1"]] = "207Q00000X"
+	record[colIdx["healthcare provider taxonomy code_2"]] = "208D00000X"
+	record[colIdx["healthcare provider primary taxonomy switch_2"]] = "Y"
+
+	primary, codes := nppesTaxonomies(record, colIdx)
+	assert.Equal(t, "208D00000X", primary)
+	assert.Equal(t, []string{"207Q00000X", "208D00000X"}, codes)
+
+	record[colIdx["healthcare provider primary taxonomy switch_2"]] = "N"
+	primary, _ = nppesTaxonomies(record, colIdx)
+	assert.Equal(t, "207Q00000X", primary, "falls back to first code")
+}
+
+func TestNPPES_ParseCSV(t *testing.T) {
+	csvContent := nppesCSVHeader +
+		`"1234567893","1","","SMITH","JANE","A","MD","","100 MAIN ST","STE 200","AUSTIN","TX","787011234","US","5125551234","05/23/2005","07/08/2026","","","N","207Q00000X","Y","",""` + "\n" +
+		`"1588667638","2","ACME FAMILY CLINIC","","","","","ACME HEALTH","200 OAK AVE","","DALLAS","TX","75201","US","2145550000","06/01/2010","09/15/2026","","","","261QP2300X","Y","",""` + "\n" +
+		`"","1","","NO NPI","ROW","","","","","","","","","","","","","","","","","","",""` + "\n"
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	expectBulkUpsertZip(pool, "fed_data.npi_providers", nppesColumns, 2)
+
+	ds := &NPPES{}
+	rows, err := ds.parseCSV(context.Background(), pool, strings.NewReader(csvContent), "weekly.zip")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestNPPES_Sync_AppliesWeeklyDeltas(t *testing.T) {
+	dir := t.TempDir()
+	csvContent := nppesCSVHeader +
+		`"1234567893","1","","SMITH","JANE","A","MD","","100 MAIN ST","","AUSTIN","TX","78701","US","","05/23/2005","10/14/2026","","","N","207Q00000X","Y","",""` + "\n"
+	zipPath := createTestZip(t, dir, "weekly.zip", "npidata_pfile_20261012-20261018.csv", csvContent)
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Monthly file and first weekly already applied; the September weekly
+	// predates the monthly file and must be ignored.
+	pool.ExpectQuery("SELECT file_name FROM fed_data.npi_file_loads").
+		WillReturnRows(pgxmock.NewRows([]string{"file_name"}).
+			AddRow("NPPES_Data_Dissemination_October_2026_V2.zip").
+			AddRow("NPPES_Data_Dissemination_100526_101126_Weekly_V2.zip"))
+	expectBulkUpsertZip(pool, "fed_data.npi_providers", nppesColumns, 1)
+	pool.ExpectExec("INSERT INTO fed_data.npi_file_loads").
+		WithArgs("NPPES_Data_Dissemination_101226_101826_Weekly_V2.zip", "weekly", pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, nppesFilesURL).
+		Return(io.NopCloser(strings.NewReader(nppesTestIndex)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, nppesDownloadBase+"NPPES_Data_Dissemination_101226_101826_Weekly_V2.zip", mock.Anything).
+		Run(func(_ context.Context, _ string, destPath string) {
+			copyTestFixture(t, zipPath, destPath)
+		}).
+		Return(int64(0), nil)
+
+	ds := &NPPES{}
+	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsSynced)
+	assert.Equal(t, []string{"NPPES_Data_Dissemination_101226_101826_Weekly_V2.zip"}, result.Metadata["files_applied"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestNPPES_Sync_FullReplacement(t *testing.T) {
+	dir := t.TempDir()
+	csvContent := nppesCSVHeader +
+		`"1234567893","1","","SMITH","JANE","A","MD","","100 MAIN ST","","AUSTIN","TX","78701","US","","05/23/2005","10/01/2026","","","N","207Q00000X","Y","",""` + "\n"
+	zipPath := createTestZipMulti(t, dir, "monthly.zip", map[string]string{
+		"npidata_pfile_20050523-20261012.csv":            csvContent,
+		"npidata_pfile_20050523-20261012_fileheader.csv": nppesCSVHeader,
+	})
+
+	index := `<a href="NPPES_Data_Dissemination_October_2026_V2.zip">October</a>`
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("SELECT file_name FROM fed_data.npi_file_loads").
+		WillReturnRows(pgxmock.NewRows([]string{"file_name"}))
+	expectBulkUpsertZip(pool, "fed_data.npi_providers", nppesColumns, 1)
+	pool.ExpectExec("DELETE FROM fed_data.npi_providers WHERE source_file").
+		WithArgs("NPPES_Data_Dissemination_October_2026_V2.zip").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	pool.ExpectExec("INSERT INTO fed_data.npi_file_loads").
+		WithArgs("NPPES_Data_Dissemination_October_2026_V2.zip", "monthly", pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).
+		Return(io.NopCloser(strings.NewReader(index)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, destPath string) {
+			copyTestFixture(t, zipPath, destPath)
+		}).
+		Return(int64(0), nil)
+
+	ds := &NPPES{}
+	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.RowsSynced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestNPPES_Sync_IndexError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	ds := &NPPES{}
+	_, err = ds.Sync(context.Background(), pool, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nppes: fetch file index")
+}
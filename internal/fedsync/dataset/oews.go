@@ -12,11 +12,12 @@ import (
 	"time"
 
 	"github.com/rotisserie/eris"
+	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/db"
 	"github.com/tealeg/xlsx/v2"
 	"go.uber.org/zap"
 
-	"github.com/sells-group/research-cli/internal/fedsync/transform"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
@@ -26,7 +27,12 @@ const (
 )
 
 // OEWS implements the BLS Occupational Employment and Wage Statistics dataset.
-type OEWS struct{}
+// Published years are re-downloaded on every sync but only parsed again
+// when their ZIP has changed.
+type OEWS struct {
+	cfg *config.Config
+	src *fedsync.DatasetSources
+}
 
 // Name implements Dataset.
 func (d *OEWS) Name() string { return "oews" }
@@ -45,10 +51,14 @@ func (d *OEWS) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return AnnualAfter(now, lastSync, time.April)
 }
 
+// SetSourceFiles implements SourceTracked.
+func (d *OEWS) SetSourceFiles(src *fedsync.DatasetSources) { d.src = src }
+
 // Sync fetches and loads BLS OEWS occupation and wage data.
 func (d *OEWS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", "oews"))
 	var totalRows int64
+	unchanged := true
 
 	currentYear := time.Now().Year() - 1
 
@@ -59,43 +69,86 @@ func (d *OEWS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDi
 		default:
 		}
 
-		yy := fmt.Sprintf("%02d", year%100)
-		url := fmt.Sprintf("https://www.bls.gov/oes/special-requests/oesm%snat.zip", yy)
-		log.Info("downloading OEWS data", zap.Int("year", year), zap.String("url", url))
-
-		zipPath := filepath.Join(tempDir, fmt.Sprintf("oews_%d.zip", year))
-		if _, err := f.DownloadToFile(ctx, url, zipPath); err != nil {
-			if strings.Contains(err.Error(), "status 404") {
-				log.Info("OEWS data not yet available, skipping", zap.Int("year", year))
-				continue
-			}
-			return nil, eris.Wrapf(err, "oews: download year %d", year)
-		}
-
-		rows, err := d.processZip(ctx, pool, zipPath, year)
+		rows, ok, changed, err := d.syncYear(ctx, pool, f, tempDir, year, log)
 		if err != nil {
-			// BLS returns HTML error pages with 200 status for future years —
-			// the zip.OpenReader fails with "not a valid zip file".
-			if strings.Contains(err.Error(), "not a valid zip") {
-				log.Info("OEWS data not valid zip (likely not yet available), skipping", zap.Int("year", year))
-				_ = os.Remove(zipPath)
-				continue
-			}
-			return nil, eris.Wrapf(err, "oews: process year %d", year)
+			return nil, err
 		}
-
 		totalRows += rows
-		log.Info("processed OEWS year", zap.Int("year", year), zap.Int64("rows", rows))
-
-		_ = os.Remove(zipPath)
+		// An unpublished year loads nothing, so it does not count as a change.
+		unchanged = unchanged && !(ok && changed)
 	}
 
 	return &SyncResult{
 		RowsSynced: totalRows,
+		Unchanged:  unchanged,
 		Metadata:   map[string]any{"start_year": oewsStartYear, "end_year": currentYear},
 	}, nil
 }
 
+// PlanResync implements SliceResyncer. OEWS loads the national file, so
+// slices are whole years.
+func (d *OEWS) PlanResync(s Slice) (SlicePlan, error) {
+	return annualSlicePlan("oews", "fed_data.oews_data", "", s)
+}
+
+// SyncPeriod implements PeriodSyncer, loading one OEWS (May) year.
+func (d *OEWS) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	year, err := requireYear("oews", period)
+	if err != nil {
+		return nil, err
+	}
+	rows, ok, _, err := d.syncYear(ctx, pool, f, tempDir, year, zap.L().With(zap.String("dataset", "oews")))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, eris.Errorf("oews: no data published for %d", year)
+	}
+	return &SyncResult{RowsSynced: rows, Metadata: map[string]any{"year": year}}, nil
+}
+
+// syncYear downloads and loads one OEWS year. ok is false when BLS has not
+// published the year; changed is false when the year's ZIP matches the
+// recorded version and was not parsed.
+func (d *OEWS) syncYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, year int, log *zap.Logger) (rows int64, ok, changed bool, err error) {
+	yy := fmt.Sprintf("%02d", year%100)
+	url := fmt.Sprintf("https://www.bls.gov/oes/special-requests/oesm%snat.zip", yy)
+	log.Info("downloading OEWS data", zap.Int("year", year), zap.String("url", url))
+
+	zipPath := filepath.Join(tempDir, fmt.Sprintf("oews_%d.zip", year))
+	version, changed, err := downloadIfChanged(ctx, f, d.src, url, zipPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			log.Info("OEWS data not yet available, skipping", zap.Int("year", year))
+			return 0, false, false, nil
+		}
+		return 0, false, false, eris.Wrapf(err, "oews: download year %d", year)
+	}
+	if !changed {
+		log.Info("OEWS file unchanged, skipping", zap.Int("year", year))
+		return 0, true, false, nil
+	}
+
+	rows, err = d.processZip(ctx, pool, zipPath, year)
+	if err != nil {
+		// BLS returns HTML error pages with 200 status for future years —
+		// the zip.OpenReader fails with "not a valid zip file".
+		if strings.Contains(err.Error(), "not a valid zip") {
+			log.Info("OEWS data not valid zip (likely not yet available), skipping", zap.Int("year", year))
+			_ = os.Remove(zipPath)
+			return 0, false, true, nil
+		}
+		return 0, false, true, eris.Wrapf(err, "oews: process year %d", year)
+	}
+	if err := d.src.Record(ctx, url, version); err != nil {
+		return 0, false, true, eris.Wrapf(err, "oews: record source version for %d", year)
+	}
+
+	log.Info("processed OEWS year", zap.Int("year", year), zap.Int64("rows", rows))
+	_ = os.Remove(zipPath)
+	return rows, true, true, nil
+}
+
 func (d *OEWS) processZip(ctx context.Context, pool db.Pool, zipPath string, year int) (int64, error) {
 	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
@@ -144,6 +197,11 @@ func (d *OEWS) processZip(ctx context.Context, pool db.Pool, zipPath string, yea
 }
 
 func (d *OEWS) parseXLSX(ctx context.Context, pool db.Pool, zf *zip.File, year int) (int64, error) {
+	filter, err := naicsFilterFor(d.cfg, "oews")
+	if err != nil {
+		return 0, err
+	}
+
 	// Extract XLSX to temp file — tealeg/xlsx needs a file path.
 	rc, err := zf.Open()
 	if err != nil {
@@ -214,7 +272,7 @@ func (d *OEWS) parseXLSX(ctx context.Context, pool db.Pool, zf *zip.File, year i
 		if naics == "" {
 			naics = trimQuotes(getCol(record, colIdx, "i_group"))
 		}
-		if !transform.IsRelevantNAICS(naics) {
+		if !filter.Match(naics) {
 			continue
 		}
 
@@ -289,6 +347,10 @@ func (d *OEWS) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 	}
 
 	colIdx := mapColumns(header)
+	filter, err := naicsFilterFor(d.cfg, "oews")
+	if err != nil {
+		return 0, err
+	}
 
 	columns := []string{"area_code", "area_type", "naics", "occ_code", "year", "tot_emp", "h_mean", "a_mean", "h_median", "a_median"}
 	conflictKeys := []string{"area_code", "naics", "occ_code", "year"}
@@ -311,7 +373,7 @@ func (d *OEWS) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 		if naics == "" {
 			naics = trimQuotes(getCol(record, colIdx, "i_group"))
 		}
-		if !transform.IsRelevantNAICS(naics) {
+		if !filter.Match(naics) {
 			continue
 		}
 
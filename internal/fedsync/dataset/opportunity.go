@@ -0,0 +1,178 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/transform"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// opportunityInputDatasets lists the datasets feeding county opportunity
+// scores. When any of them syncs, the engine rebuilds the score table.
+var opportunityInputDatasets = map[string]bool{
+	"cbp":  true,
+	"susb": true,
+	"acs":  true,
+}
+
+// countyOpportunitySQL scores every county with target-industry
+// establishments. Inputs use the latest loaded year of each source; SUSB is
+// state-level, so counties inherit their state's small-firm share. Each
+// component is percentile-ranked across counties and weighted ($2-$5);
+// whitespace is the inverse of Salesforce account coverage per establishment.
+const countyOpportunitySQL = `
+WITH cbp AS (
+	SELECT fips_state || fips_county AS county_fips, fips_state AS state_fips, year,
+		SUM(est)::bigint AS target_estabs, SUM(emp)::bigint AS target_emp
+	FROM fed_data.cbp_data
+	WHERE year = (SELECT MAX(year) FROM fed_data.cbp_data) AND naics = ANY($1) AND fips_county <> '999'
+	GROUP BY 1, 2, 3
+),
+susb AS (
+	SELECT fips_state,
+		SUM(firm) FILTER (WHERE entrsizedscr LIKE '%<20%')::float8 /
+			NULLIF(SUM(firm) FILTER (WHERE entrsizedscr ILIKE '%total%'), 0) AS small_firm_share
+	FROM fed_data.susb_data
+	WHERE year = (SELECT MAX(year) FROM fed_data.susb_data) AND naics = ANY($1)
+	GROUP BY 1
+),
+acs AS (
+	SELECT geo_id,
+		MAX(value) FILTER (WHERE variable = 'B19013_001E') AS median_hh_income,
+		MAX(value) FILTER (WHERE variable = 'B01003_001E') AS population,
+		MAX(name) AS name
+	FROM fed_data.acs_data
+	WHERE geo_level = 'county'
+	  AND year = (SELECT MAX(year) FROM fed_data.acs_data WHERE geo_level = 'county')
+	GROUP BY 1
+),
+sf AS (
+	SELECT a.county_fips, COUNT(DISTINCT a.company_id) AS sf_accounts
+	FROM public.company_addresses a
+	JOIN public.company_identifiers ci ON ci.company_id = a.company_id AND ci.system = 'salesforce'
+	WHERE a.is_primary AND a.county_fips IS NOT NULL
+	GROUP BY 1
+),
+base AS (
+	SELECT c.county_fips, c.state_fips, a.name, c.year AS cbp_year,
+		c.target_estabs, c.target_emp, s.small_firm_share,
+		a.median_hh_income, a.population,
+		COALESCE(f.sf_accounts, 0) AS sf_accounts,
+		COALESCE(f.sf_accounts, 0)::float8 / c.target_estabs AS coverage_ratio
+	FROM cbp c
+	LEFT JOIN susb s ON s.fips_state = c.state_fips
+	LEFT JOIN acs a ON a.geo_id = c.county_fips
+	LEFT JOIN sf f ON f.county_fips = c.county_fips
+	WHERE c.target_estabs > 0
+),
+ranked AS (
+	SELECT *,
+		percent_rank() OVER (ORDER BY target_estabs) AS estab_pct,
+		percent_rank() OVER (ORDER BY COALESCE(small_firm_share, 0)) AS small_firm_pct,
+		percent_rank() OVER (ORDER BY COALESCE(median_hh_income, 0)) AS wealth_pct,
+		1 - percent_rank() OVER (ORDER BY coverage_ratio) AS whitespace_pct
+	FROM base
+)
+INSERT INTO fed_data.county_opportunity_scores (
+	county_fips, state_fips, name, cbp_year, target_estabs, target_emp, small_firm_share,
+	median_hh_income, population, sf_accounts, coverage_ratio,
+	estab_pct, small_firm_pct, wealth_pct, whitespace_pct, score, updated_at
+)
+SELECT
+	county_fips, state_fips, name, cbp_year, target_estabs, target_emp, small_firm_share,
+	median_hh_income, population, sf_accounts, coverage_ratio,
+	estab_pct, small_firm_pct, wealth_pct, whitespace_pct,
+	round((100 * ($2 * estab_pct + $3 * small_firm_pct + $4 * wealth_pct + $5 * whitespace_pct))::numeric, 2),
+	now()
+FROM ranked`
+
+// CountyOpportunity materializes county market opportunity scores from CBP
+// establishment counts, SUSB size distributions, ACS income, and existing
+// Salesforce account coverage. The table is fully rebuilt on each run and
+// after any input dataset syncs.
+type CountyOpportunity struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *CountyOpportunity) Name() string { return "opportunity" }
+
+// Table implements Dataset.
+func (d *CountyOpportunity) Table() string { return "fed_data.county_opportunity_scores" }
+
+// Phase implements Dataset.
+func (d *CountyOpportunity) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *CountyOpportunity) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset. Monthly refreshes pick up Salesforce coverage
+// changes between input dataset syncs.
+func (d *CountyOpportunity) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync rebuilds the county opportunity score table.
+func (d *CountyOpportunity) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+	oc := d.cfg.Fedsync.Opportunity
+	naics := opportunityNAICS(oc.NAICS)
+	log.Info("building county opportunity scores", zap.Strings("naics", naics))
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "county_opportunity: begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fed_data.county_opportunity_scores`); err != nil {
+		return nil, eris.Wrap(err, "county_opportunity: clear scores")
+	}
+	tag, err := tx.Exec(ctx, countyOpportunitySQL, naics,
+		oc.EstabWeight, oc.SmallFirmWeight, oc.WealthWeight, oc.WhitespaceWeight)
+	if err != nil {
+		return nil, eris.Wrap(err, "county_opportunity: insert scores")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, eris.Wrap(err, "county_opportunity: commit")
+	}
+
+	rows := tag.RowsAffected()
+	log.Info("county opportunity scores built", zap.Int64("counties", rows))
+	return &SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"naics": naics,
+		},
+	}, nil
+}
+
+// naicsAllIndustries is the normalized all-industries total row ("------" in
+// CBP, "--" in SUSB).
+const naicsAllIndustries = "000000"
+
+// opportunityNAICS normalizes configured NAICS codes to the 6-character form
+// stored by CBP and SUSB ("5412" → "541200"). Empty selects the all-industries
+// total row.
+func opportunityNAICS(codes []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, c := range codes {
+		n := transform.NormalizeNAICS(c)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	if len(out) == 0 {
+		out = []string{naicsAllIndustries}
+	}
+	return out
+}
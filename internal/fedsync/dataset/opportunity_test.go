@@ -0,0 +1,77 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+func testOpportunityConfig(naics ...string) *config.Config {
+	return &config.Config{Fedsync: config.FedsyncConfig{Opportunity: config.OpportunityConfig{
+		NAICS:            naics,
+		EstabWeight:      0.35,
+		SmallFirmWeight:  0.2,
+		WealthWeight:     0.25,
+		WhitespaceWeight: 0.2,
+	}}}
+}
+
+func TestCountyOpportunity_Metadata(t *testing.T) {
+	d := &CountyOpportunity{}
+	assert.Equal(t, "opportunity", d.Name())
+	assert.Equal(t, "fed_data.county_opportunity_scores", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestCountyOpportunity_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.county_opportunity_scores").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3100))
+	pool.ExpectExec("INSERT INTO fed_data.county_opportunity_scores").
+		WithArgs([]string{"541200", "524210"}, 0.35, 0.2, 0.25, 0.2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 3140))
+	pool.ExpectCommit()
+	pool.ExpectRollback()
+
+	d := &CountyOpportunity{cfg: testOpportunityConfig("5412", "524210", "5412")}
+	result, err := d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3140), result.RowsSynced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestCountyOpportunity_Sync_InsertError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.county_opportunity_scores").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectExec("INSERT INTO fed_data.county_opportunity_scores").
+		WillReturnError(errors.New("relation does not exist"))
+	pool.ExpectRollback()
+
+	d := &CountyOpportunity{cfg: testOpportunityConfig()}
+	_, err = d.Sync(context.Background(), pool, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "county_opportunity: insert scores")
+}
+
+func TestOpportunityNAICS(t *testing.T) {
+	assert.Equal(t, []string{naicsAllIndustries}, opportunityNAICS(nil))
+	assert.Equal(t, []string{"540000", "541211"}, opportunityNAICS([]string{"54", " 541211", "54----"}))
+}
@@ -0,0 +1,267 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const osha300ABatchSize = 5000
+
+// osha300AColumns defines the osha_ita_300a upsert columns. dart_rate and
+// trir are generated by the table.
+var osha300AColumns = []string{
+	"year_filing_for", "establishment_id", "establishment_name", "establishment_name_norm",
+	"company_name", "ein", "street_address", "city", "state", "zip",
+	"naics_code", "industry_description", "establishment_type", "size",
+	"annual_average_employees", "total_hours_worked",
+	"total_deaths", "total_dafw_cases", "total_djtr_cases", "total_other_cases",
+	"total_dafw_days", "total_djtr_days", "total_injuries", "total_skin_disorders",
+	"total_respiratory_conditions", "total_poisonings", "total_hearing_loss", "total_other_illnesses",
+	"updated_at",
+}
+
+// osha300ACountFields are the integer summary columns, in osha300AColumns
+// order from annual_average_employees.
+var osha300ACountFields = []string{
+	"annual_average_employees", "total_hours_worked",
+	"total_deaths", "total_dafw_cases", "total_djtr_cases", "total_other_cases",
+	"total_dafw_days", "total_djtr_days", "total_injuries", "total_skin_disorders",
+	"total_respiratory_conditions", "total_poisonings", "total_hearing_loss", "total_other_illnesses",
+}
+
+// osha300AAliases lists the 300A summary CSV headers for each field. Older
+// files have no establishment_id, so the submission id stands in.
+var osha300AAliases = func() map[string][]string {
+	m := map[string][]string{
+		"year":                 {"year_filing_for"},
+		"establishment_id":     {"establishment_id", "id"},
+		"establishment_name":   {"establishment_name"},
+		"company_name":         {"company_name"},
+		"ein":                  {"ein"},
+		"street_address":       {"street_address"},
+		"city":                 {"city"},
+		"state":                {"state"},
+		"zip":                  {"zip", "zip_code"},
+		"naics_code":           {"naics_code"},
+		"industry_description": {"industry_description"},
+		"establishment_type":   {"establishment_type"},
+		"size":                 {"size"},
+	}
+	for _, f := range osha300ACountFields {
+		m[f] = []string{f}
+	}
+	return m
+}()
+
+// OSHAITAEstablishments syncs OSHA Injury Tracking Application Form 300A
+// summaries (establishment, NAICS, hours worked, injury and illness counts)
+// into fed_data.osha_ita_300a. The table derives DART and TRIR rates per
+// establishment from the counts and hours worked.
+type OSHAITAEstablishments struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *OSHAITAEstablishments) Name() string { return "osha_ita_300a" }
+
+// Table implements Dataset.
+func (d *OSHAITAEstablishments) Table() string { return "fed_data.osha_ita_300a" }
+
+// Phase implements Dataset.
+func (d *OSHAITAEstablishments) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *OSHAITAEstablishments) Cadence() Cadence { return Annual }
+
+// ShouldRun implements Dataset. Form 300A summaries are due March 2 and
+// OSHA publishes the prior calendar year's submissions by mid-year.
+func (d *OSHAITAEstablishments) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return AnnualAfter(now, lastSync, time.July)
+}
+
+// Sync downloads and loads each configured year's 300A summary file.
+func (d *OSHAITAEstablishments) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	var urls map[string]string
+	if d.cfg != nil {
+		urls = d.cfg.Fedsync.OSHA.ITAURLs
+	}
+	if len(urls) == 0 {
+		return nil, eris.New("osha_ita_300a: no ITA 300A downloads configured (fedsync.osha.ita_urls)")
+	}
+	byYear := make(map[int]string, len(urls))
+	for key, url := range urls {
+		year, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, eris.Errorf("osha_ita_300a: invalid year %q in fedsync.osha.ita_urls", key)
+		}
+		byYear[year] = url
+	}
+	years := slices.Sorted(maps.Keys(byYear))
+
+	var total int64
+	for _, year := range years {
+		url := byYear[year]
+		path := filepath.Join(tempDir, fmt.Sprintf("osha_ita_300a_%d", year))
+		log.Info("downloading OSHA ITA 300A summaries", zap.Int("year", year), zap.String("url", url))
+		if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+			return nil, eris.Wrapf(err, "osha_ita_300a: download year %d", year)
+		}
+		n, err := d.load(ctx, pool, path, year)
+		if err != nil {
+			return nil, eris.Wrapf(err, "osha_ita_300a: load year %d", year)
+		}
+		log.Info("loaded OSHA ITA 300A summaries", zap.Int("year", year), zap.Int64("rows", n))
+		total += n
+	}
+
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata:   map[string]any{"years": years},
+	}, nil
+}
+
+// load parses a 300A summary extract and upserts its establishments.
+// defaultYear applies to files without a year_filing_for column.
+func (d *OSHAITAEstablishments) load(ctx context.Context, pool db.Pool, path string, defaultYear int) (int64, error) {
+	var total int64
+	batch := make([][]any, 0, osha300ABatchSize)
+	seen := make(map[string]bool)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      osha300AColumns,
+			ConflictKeys: []string{"year_filing_for", "establishment_id"},
+		}, batch)
+		if err != nil {
+			return eris.Wrap(err, "upsert")
+		}
+		total += n
+		batch = batch[:0]
+		clear(seen)
+		return nil
+	}
+
+	now := time.Now()
+	err := forEachExtractFile(path, []string{".csv"}, func(r io.Reader) error {
+		reader := newLenientCSVReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			return eris.Wrap(err, "read header")
+		}
+		cols := resolveAliases(mapColumnsTrimBOM(header), osha300AAliases)
+		if err := cols.require(osha300AAliases, "establishment_id", "establishment_name", "total_hours_worked"); err != nil {
+			return err
+		}
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return eris.Wrap(err, "read record")
+			}
+			row := osha300ARow(cols, record, defaultYear, now)
+			if row == nil {
+				continue
+			}
+			key := fmt.Sprintf("%v|%v", row[0], row[1])
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			batch = append(batch, row)
+			if len(batch) >= osha300ABatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	})
+	if err != nil {
+		return total, err
+	}
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// osha300ARow maps a 300A summary record to osha300AColumns. Returns nil
+// when the record has no establishment id or name.
+func osha300ARow(cols aliasColumns, record []string, defaultYear int, now time.Time) []any {
+	get := func(field string) string { return sanitizeUTF8(strings.TrimSpace(cols.get(record, field))) }
+	id := get("establishment_id")
+	name := get("establishment_name")
+	if id == "" || name == "" || len(id) > 40 {
+		return nil
+	}
+
+	year := defaultYear
+	if y, err := strconv.Atoi(get("year")); err == nil && y > 2000 {
+		year = y
+	}
+	ein := strings.ReplaceAll(get("ein"), "-", "")
+	if len(ein) != 9 {
+		ein = ""
+	}
+	zip := get("zip")
+	if len(zip) > 10 {
+		zip = zip[:10]
+	}
+	naics := get("naics_code")
+	if len(naics) > 6 {
+		naics = ""
+	}
+
+	row := []any{
+		year,
+		id,
+		name,
+		nilIfEmpty(resolve.NormalizeName(name)),
+		nilIfEmpty(get("company_name")),
+		nilIfEmpty(ein),
+		nilIfEmpty(get("street_address")),
+		nilIfEmpty(get("city")),
+		nilIfEmpty(fitLen(strings.ToUpper(get("state")), 2)),
+		nilIfEmpty(zip),
+		nilIfEmpty(naics),
+		nilIfEmpty(get("industry_description")),
+		osha300ACount(get("establishment_type")),
+		osha300ACount(get("size")),
+	}
+	for _, field := range osha300ACountFields {
+		row = append(row, osha300ACount(get(field)))
+	}
+	return append(row, now)
+}
+
+// osha300ACount parses a summary count, which some years export with a
+// trailing ".0". Returns nil for blank, invalid, or negative values.
+func osha300ACount(s string) any {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return int64(math.Round(v))
+}
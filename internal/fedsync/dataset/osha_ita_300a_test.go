@@ -0,0 +1,113 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const osha300ACSV = "id,establishment_id,establishment_name,ein,company_name,street_address,city,state,zip,naics_code,industry_description,establishment_type,size,annual_average_employees,total_hours_worked,no_injuries_illnesses,total_deaths,total_dafw_cases,total_djtr_cases,total_other_cases,total_dafw_days,total_djtr_days,total_injuries,total_skin_disorders,total_respiratory_conditions,total_poisonings,total_hearing_loss,total_other_illnesses,year_filing_for\n" +
+	"901,E-1001,Acme Plumbing Warehouse,12-3456789,\"Acme Plumbing, LLC\",1 Main St,Houston,tx,77002,238220,Plumbing Contractors,1,21,85,170000.0,1,0,2,1,3,45,20,6,0,0,0,0,0,2024\n" +
+	"902,E-1001,Acme Plumbing Warehouse,123456789,\"Acme Plumbing, LLC\",1 Main St,Houston,TX,77002,238220,Plumbing Contractors,1,21,85,170000,1,0,2,1,3,45,20,6,0,0,0,0,0,2024\n" +
+	",,No Establishment Id,,,,,,,,,,,,,,,,,,,,,,,,,,2024\n" +
+	"904,E-2002,Beta Roofing,,,,Dallas,Texas,,,,,,,n/a,,,,,,,,,,,,,,\n"
+
+func TestOSHAITAEstablishments_Metadata(t *testing.T) {
+	d := &OSHAITAEstablishments{}
+	assert.Equal(t, "osha_ita_300a", d.Name())
+	assert.Equal(t, "fed_data.osha_ita_300a", d.Table())
+	assert.Equal(t, Phase2, d.Phase())
+	assert.Equal(t, Annual, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+
+	last := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, d.ShouldRun(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), &last))
+	assert.True(t, d.ShouldRun(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC), &last))
+}
+
+func TestOSHA300ARow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(osha300ACSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	cols := resolveAliases(mapColumnsTrimBOM(header), osha300AAliases)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	now := time.Now()
+	row := osha300ARow(cols, records[0], 2023, now)
+	require.Len(t, row, len(osha300AColumns))
+	assert.Equal(t, 2024, row[0], "year_filing_for overrides the configured year")
+	assert.Equal(t, "E-1001", row[1])
+	assert.Equal(t, "ACME PLUMBING WAREHOUSE", row[3])
+	assert.Equal(t, "Acme Plumbing, LLC", row[4])
+	assert.Equal(t, "123456789", row[5])
+	assert.Equal(t, "TX", row[8])
+	assert.Equal(t, "238220", row[10])
+	assert.Equal(t, int64(85), row[14])
+	assert.Equal(t, int64(170000), row[15])
+	assert.Equal(t, int64(2), row[17])
+	assert.Equal(t, int64(1), row[18])
+	assert.Equal(t, int64(3), row[19])
+
+	assert.Nil(t, osha300ARow(cols, records[2], 2024, now), "no establishment id")
+
+	row = osha300ARow(cols, records[3], 2023, now)
+	require.NotNil(t, row)
+	assert.Equal(t, 2023, row[0])
+	assert.Nil(t, row[8], "non-abbreviated state dropped")
+	assert.Nil(t, row[15], "invalid hours")
+}
+
+func TestOSHA300ACount(t *testing.T) {
+	assert.Equal(t, int64(12), osha300ACount("12"))
+	assert.Equal(t, int64(1250000), osha300ACount("1,250,000.0"))
+	assert.Nil(t, osha300ACount(""))
+	assert.Nil(t, osha300ACount("-1"))
+	assert.Nil(t, osha300ACount("NaN"))
+}
+
+func TestOSHAITAEstablishments_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://osha.test/ita-2024.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(osha300ACSV))
+		}).Return(int64(len(osha300ACSV)), nil)
+
+	// The duplicate establishment row folds into one.
+	expectBulkUpsert(pool, "fed_data.osha_ita_300a", osha300AColumns, 2)
+
+	d := &OSHAITAEstablishments{cfg: &config.Config{Fedsync: config.FedsyncConfig{OSHA: config.OSHAConfig{
+		ITAURLs: map[string]string{"2024": "https://osha.test/ita-2024.csv"},
+	}}}}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, []int{2024}, res.Metadata["years"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestOSHAITAEstablishments_SyncConfigErrors(t *testing.T) {
+	_, err := (&OSHAITAEstablishments{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.osha.ita_urls")
+
+	d := &OSHAITAEstablishments{cfg: &config.Config{Fedsync: config.FedsyncConfig{OSHA: config.OSHAConfig{
+		ITAURLs: map[string]string{"latest": "https://osha.test/ita.csv"},
+	}}}}
+	_, err = d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid year "latest"`)
+}
@@ -0,0 +1,46 @@
+package dataset
+
+import (
+	"fmt"
+	"time"
+)
+
+// Outcome is one dataset's result in an engine run.
+type Outcome struct {
+	Dataset   string
+	Status    string // "complete", "failed", or "unchanged"
+	Rows      int64
+	Elapsed   time.Duration
+	Error     string
+	Anomalies []string // retries, validation failures, rejected rows
+}
+
+// Outcomes returns the result of every dataset the last Run synced, in
+// completion order. Datasets skipped as not due and dry runs are not
+// recorded.
+func (e *Engine) Outcomes() []Outcome {
+	e.outcomeMu.Lock()
+	defer e.outcomeMu.Unlock()
+	return append([]Outcome(nil), e.outcomes...)
+}
+
+func (e *Engine) recordOutcome(o Outcome) {
+	e.outcomeMu.Lock()
+	e.outcomes = append(e.outcomes, o)
+	e.outcomeMu.Unlock()
+}
+
+// anomalies describes the noteworthy parts of a run's sync_log metadata.
+func anomalies(meta map[string]any) []string {
+	var out []string
+	if n, ok := meta["attempts"]; ok {
+		out = append(out, fmt.Sprintf("succeeded after %v attempts", n))
+	}
+	if n, ok := meta["validation_failures"]; ok {
+		out = append(out, fmt.Sprintf("%v validation rule(s) failed", n))
+	}
+	if n, ok := meta["rejected_rows"]; ok {
+		out = append(out, fmt.Sprintf("%v rows rejected", n))
+	}
+	return out
+}
@@ -1,6 +1,8 @@
 package dataset
 
 import (
+	"encoding/csv"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -100,6 +102,24 @@ func mapColumnsNormalized(header []string) map[string]int {
 	return m
 }
 
+// mapColumnsTrimBOM is mapColumnsNormalized for files whose first header
+// cell carries a UTF-8 byte-order mark.
+func mapColumnsTrimBOM(header []string) map[string]int {
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	return mapColumnsNormalized(header)
+}
+
+// newLenientCSVReader returns a CSV reader that tolerates stray quotes and
+// ragged rows, as in space-padded text files with trailing commas.
+func newLenientCSVReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	return reader
+}
+
 // getColN gets a column value by normalized name.
 func getColN(record []string, colIdx map[string]int, name string) string {
 	idx, ok := colIdx[normalizeCol(name)]
@@ -1,9 +1,11 @@
 package dataset
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseIntOr(t *testing.T) {
@@ -181,6 +183,19 @@ func TestMapColumns_Empty(t *testing.T) {
 	assert.Empty(t, m)
 }
 
+func TestMapColumnsTrimBOM(t *testing.T) {
+	m := mapColumnsTrimBOM([]string{"\ufeffN-NUMBER", "Name"})
+	assert.Equal(t, 0, m["n-number"])
+	assert.Equal(t, 1, m["name"])
+	assert.Empty(t, mapColumnsTrimBOM(nil))
+}
+
+func TestNewLenientCSVReader(t *testing.T) {
+	records, err := newLenientCSVReader(strings.NewReader("A,B,\n1 \"x\" ,2,3,4\n")).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B", ""}, {"1 \"x\" ", "2", "3", "4"}}, records)
+}
+
 func TestGetCol(t *testing.T) {
 	colIdx := map[string]int{"name": 0, "age": 1, "city": 2}
 	row := []string{"Alice", "30", "NYC"}
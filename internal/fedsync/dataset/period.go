@@ -0,0 +1,125 @@
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// Period is a data year, or one quarter of it when Quarter is 1-4.
+type Period struct {
+	Year    int
+	Quarter int // 0 = the whole year
+}
+
+// String formats the period as "2019" or "2019Q2".
+func (p Period) String() string {
+	if p.Quarter == 0 {
+		return strconv.Itoa(p.Year)
+	}
+	return fmt.Sprintf("%dQ%d", p.Year, p.Quarter)
+}
+
+// Quarters returns the quarters the period covers: itself, or Q1-Q4 of a
+// whole year.
+func (p Period) Quarters() []Period {
+	if p.Quarter != 0 {
+		return []Period{p}
+	}
+	return []Period{{p.Year, 1}, {p.Year, 2}, {p.Year, 3}, {p.Year, 4}}
+}
+
+// QuarterEnd returns the last day of a quarter period.
+func (p Period) QuarterEnd() time.Time {
+	return time.Date(p.Year, time.Month(p.Quarter*3)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// next returns the period after p at the same granularity.
+func (p Period) next() Period {
+	if p.Quarter == 0 {
+		return Period{Year: p.Year + 1}
+	}
+	if p.Quarter == 4 {
+		return Period{Year: p.Year + 1, Quarter: 1}
+	}
+	return Period{Year: p.Year, Quarter: p.Quarter + 1}
+}
+
+// before reports whether p sorts before o. Both must share a granularity.
+func (p Period) before(o Period) bool {
+	return p.Year < o.Year || (p.Year == o.Year && p.Quarter < o.Quarter)
+}
+
+// maxPeriods bounds one ParsePeriods spec.
+const maxPeriods = 200
+
+// ParsePeriods parses a comma-separated list of periods and inclusive
+// ranges: years ("2015-2021", "2019") or quarters ("2020Q1-2021Q2").
+// A range's endpoints must both be years or both be quarters.
+func ParsePeriods(spec string) ([]Period, error) {
+	var out []Period
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := parsePeriod(from)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parsePeriod(to); err != nil {
+				return nil, err
+			}
+		}
+		if (start.Quarter == 0) != (end.Quarter == 0) {
+			return nil, eris.Errorf("period range %q mixes years and quarters", part)
+		}
+		if end.before(start) {
+			return nil, eris.Errorf("period range %q ends before it starts", part)
+		}
+		for p := start; !end.before(p); p = p.next() {
+			if len(out) == maxPeriods {
+				return nil, eris.Errorf("more than %d periods in %q", maxPeriods, spec)
+			}
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return nil, eris.New("no periods given")
+	}
+	return out, nil
+}
+
+// parsePeriod parses "2019" or "2019Q2".
+func parsePeriod(s string) (Period, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	yearStr, qStr, hasQ := strings.Cut(s, "Q")
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < 1900 || year > 2100 {
+		return Period{}, eris.Errorf("invalid period %q (want YYYY or YYYYQn)", s)
+	}
+	p := Period{Year: year}
+	if hasQ {
+		q, err := strconv.Atoi(qStr)
+		if err != nil || q < 1 || q > 4 {
+			return Period{}, eris.Errorf("invalid quarter in period %q", s)
+		}
+		p.Quarter = q
+	}
+	return p, nil
+}
+
+// requireYear returns the period's year, or an error naming the dataset
+// when the period is a quarter.
+func requireYear(dataset string, p Period) (int, error) {
+	if p.Quarter != 0 {
+		return 0, eris.Errorf("%s: publishes annual files; backfill by year, not %s", dataset, p)
+	}
+	return p.Year, nil
+}
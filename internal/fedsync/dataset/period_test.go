@@ -0,0 +1,57 @@
+package dataset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriods(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"2019", []string{"2019"}},
+		{"2015-2018", []string{"2015", "2016", "2017", "2018"}},
+		{"2017, 2019-2020", []string{"2017", "2019", "2020"}},
+		{"2020Q3-2021Q2", []string{"2020Q3", "2020Q4", "2021Q1", "2021Q2"}},
+		{"2022q4", []string{"2022Q4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			periods, err := ParsePeriods(tt.spec)
+			require.NoError(t, err)
+			got := make([]string, len(periods))
+			for i, p := range periods {
+				got[i] = p.String()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePeriods_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "abc", "2019Q5", "2021-2019", "2019-2020Q1", "1800", "1900-2100"} {
+		_, err := ParsePeriods(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestPeriod_Quarters(t *testing.T) {
+	assert.Len(t, Period{Year: 2020}.Quarters(), 4)
+	assert.Equal(t, []Period{{2020, 2}}, Period{Year: 2020, Quarter: 2}.Quarters())
+	assert.Equal(t, time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC), Period{Year: 2020, Quarter: 2}.QuarterEnd())
+	assert.Equal(t, time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC), Period{Year: 2020, Quarter: 4}.QuarterEnd())
+}
+
+func TestRequireYear(t *testing.T) {
+	y, err := requireYear("cbp", Period{Year: 2019})
+	require.NoError(t, err)
+	assert.Equal(t, 2019, y)
+
+	_, err = requireYear("cbp", Period{Year: 2019, Quarter: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cbp: publishes annual files")
+}
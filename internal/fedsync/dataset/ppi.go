@@ -0,0 +1,47 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// PPI syncs BLS Producer Price Index series configured under fedsync.ppi.series.
+type PPI struct {
+	cfg    *config.Config
+	blsURL string // override for testing
+}
+
+// Name implements Dataset.
+func (d *PPI) Name() string { return "ppi" }
+
+// Table implements Dataset.
+func (d *PPI) Table() string { return "fed_data.ppi_data" }
+
+// Phase implements Dataset.
+func (d *PPI) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *PPI) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *PPI) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync fetches and loads the configured PPI series.
+func (d *PPI) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
+	return blsSeriesDataset{
+		fetcher:       f,
+		name:          d.Name(),
+		table:         d.Table(),
+		url:           d.blsURL,
+		apiKey:        d.cfg.Fedsync.BLSKey,
+		dailyLimit:    blsDailyLimit(d.cfg),
+		series:        normalizeBLSSeries(d.cfg.Fedsync.PPI.Series),
+		lookbackYears: 3,
+	}.sync(ctx, pool)
+}
@@ -72,7 +72,7 @@ func (d *PPP) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir
 				return eris.Wrapf(err, "ppp: download %s", res.Name)
 			}
 
-			rows, err := d.processCSV(gctx, pool, csvPath)
+			rows, err := d.processCSV(db.WithSource(gctx, res.URL), pool, csvPath)
 			if err != nil {
 				return eris.Wrapf(err, "ppp: process %s", res.Name)
 			}
@@ -0,0 +1,82 @@
+package dataset
+
+import (
+	"context"
+	"io"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// provenanceFetcher wraps a Fetcher and makes each downloaded URL the
+// current source of prov, so rows loaded after a download are stamped with
+// the file they came from. Files saved to disk are hashed; streamed bodies
+// carry only their URL. Datasets that download several files before loading
+// any attribute each load with db.WithSource instead.
+type provenanceFetcher struct {
+	next fetcher.Fetcher
+	prov *db.Provenance
+}
+
+// withProvenance wraps f so downloads update prov. A nil prov returns f.
+func withProvenance(f fetcher.Fetcher, prov *db.Provenance) fetcher.Fetcher {
+	if prov == nil {
+		return f
+	}
+	return &provenanceFetcher{next: f, prov: prov}
+}
+
+// Download implements fetcher.Fetcher.
+func (p *provenanceFetcher) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	rc, err := p.next.Download(ctx, url)
+	if err == nil {
+		p.prov.SetSource(url, "")
+	}
+	return rc, err
+}
+
+// DownloadToFile implements fetcher.Fetcher.
+func (p *provenanceFetcher) DownloadToFile(ctx context.Context, url string, path string) (int64, error) {
+	n, err := p.next.DownloadToFile(ctx, url, path)
+	if err != nil {
+		return n, err
+	}
+	sum, _, err := fetcher.HashFile(path)
+	if err != nil {
+		return n, err
+	}
+	p.prov.SetSource(url, sum)
+	return n, nil
+}
+
+// PostJSON implements fetcher.Poster.
+func (p *provenanceFetcher) PostJSON(ctx context.Context, url string, body []byte) (io.ReadCloser, error) {
+	rc, err := fetcher.PostJSON(ctx, p.next, url, body)
+	if err == nil {
+		p.prov.SetSource(url, "")
+	}
+	return rc, err
+}
+
+// HeadETag implements fetcher.Fetcher.
+func (p *provenanceFetcher) HeadETag(ctx context.Context, url string) (string, error) {
+	return p.next.HeadETag(ctx, url)
+}
+
+// DownloadIfChanged implements fetcher.Fetcher.
+func (p *provenanceFetcher) DownloadIfChanged(ctx context.Context, url string, etag string) (io.ReadCloser, string, bool, error) {
+	rc, newETag, changed, err := p.next.DownloadIfChanged(ctx, url, etag)
+	if err == nil && changed {
+		p.prov.SetSource(url, "")
+	}
+	return rc, newETag, changed, err
+}
+
+// DownloadToFileIfChanged implements fetcher.Fetcher.
+func (p *provenanceFetcher) DownloadToFileIfChanged(ctx context.Context, url string, path string, prev fetcher.FileVersion) (fetcher.FileVersion, bool, error) {
+	v, changed, err := p.next.DownloadToFileIfChanged(ctx, url, path, prev)
+	if err == nil && changed {
+		p.prov.SetSource(url, v.SHA256)
+	}
+	return v, changed, err
+}
@@ -0,0 +1,62 @@
+package dataset
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+func TestProvenanceFetcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.zip")
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://example.gov/a.zip", path).
+		RunAndReturn(func(_ context.Context, _ string, p string) (int64, error) {
+			return 5, os.WriteFile(p, []byte("hello"), 0o600)
+		})
+	f.EXPECT().Download(mock.Anything, "https://example.gov/api").
+		Return(io.NopCloser(strings.NewReader("{}")), nil)
+	f.EXPECT().DownloadToFileIfChanged(mock.Anything, "https://example.gov/b.zip", path, fetcher.FileVersion{}).
+		Return(fetcher.FileVersion{SHA256: "bbb"}, true, nil)
+
+	prov := db.NewProvenance(1, time.Now())
+	pf := withProvenance(f, prov)
+	ctx := context.Background()
+
+	_, err := pf.DownloadToFile(ctx, "https://example.gov/a.zip", path)
+	require.NoError(t, err)
+	url, hash := prov.Source()
+	assert.Equal(t, "https://example.gov/a.zip", url)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", hash)
+
+	_, err = pf.Download(ctx, "https://example.gov/api")
+	require.NoError(t, err)
+	url, hash = prov.Source()
+	assert.Equal(t, "https://example.gov/api", url)
+	assert.Empty(t, hash, "streamed bodies are not hashed")
+
+	_, _, err = pf.DownloadToFileIfChanged(ctx, "https://example.gov/b.zip", path, fetcher.FileVersion{})
+	require.NoError(t, err)
+	_, hash = prov.Source()
+	assert.Equal(t, "bbb", hash)
+
+	// The hash of an earlier file is still known when a load is pinned to it.
+	_, hash = db.ProvenanceFromContext(db.WithSource(db.WithProvenance(ctx, prov), "https://example.gov/a.zip")).Source()
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", hash)
+}
+
+func TestWithProvenance_Nil(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	assert.Same(t, f, withProvenance(f, nil))
+}
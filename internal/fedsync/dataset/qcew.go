@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/rotisserie/eris"
+	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -28,7 +29,9 @@ const (
 )
 
 // QCEW implements the BLS Quarterly Census of Employment and Wages dataset.
-type QCEW struct{}
+type QCEW struct {
+	cfg *config.Config
+}
 
 // Name implements Dataset.
 func (d *QCEW) Name() string { return "qcew" }
@@ -59,28 +62,9 @@ func (d *QCEW) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDi
 
 	for year := qcewStartYear; year <= currentYear; year++ {
 		g.Go(func() error {
-			url := fmt.Sprintf("https://data.bls.gov/cew/data/files/%d/csv/%d_qtrly_by_industry.zip", year, year)
-			log.Info("downloading QCEW data", zap.Int("year", year), zap.String("url", url))
-
-			zipPath := filepath.Join(tempDir, fmt.Sprintf("qcew_%d.zip", year))
-			if _, err := f.DownloadToFile(gctx, url, zipPath); err != nil {
-				if strings.Contains(err.Error(), "status 404") {
-					log.Info("QCEW data not yet available, skipping", zap.Int("year", year))
-					return nil
-				}
-				return eris.Wrapf(err, "qcew: download year %d", year)
-			}
-
-			rows, err := d.processZip(gctx, pool, zipPath, year)
-			if err != nil {
-				return eris.Wrapf(err, "qcew: process year %d", year)
-			}
-
+			rows, _, err := d.syncYear(gctx, pool, f, tempDir, year, log)
 			totalRows.Add(rows)
-			log.Info("processed QCEW year", zap.Int("year", year), zap.Int64("rows", rows))
-
-			_ = os.Remove(zipPath)
-			return nil
+			return err
 		})
 	}
 
@@ -94,12 +78,75 @@ func (d *QCEW) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDi
 	}, nil
 }
 
+// PlanResync implements SliceResyncer: a year or quarter, optionally the
+// counties and statewide rows of one state (area_fips prefix). The whole
+// year's file is reloaded, keeping only the slice's rows.
+func (d *QCEW) PlanResync(s Slice) (SlicePlan, error) {
+	query := "DELETE FROM fed_data.qcew_data WHERE year = $1"
+	args := []any{s.Period.Year}
+	if s.Period.Quarter != 0 {
+		args = append(args, s.Period.Quarter)
+		query += fmt.Sprintf(" AND qtr = $%d", len(args))
+	}
+	if s.State != "" {
+		args = append(args, s.State)
+		query += fmt.Sprintf(" AND LEFT(area_fips, 2) = $%d", len(args))
+	}
+	return SlicePlan{Delete: query, Args: args, Load: Period{Year: s.Period.Year}}, nil
+}
+
+// SyncPeriod implements PeriodSyncer, loading one QCEW year. BLS publishes
+// a year's quarters in a single file, so periods are whole years.
+func (d *QCEW) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	year, err := requireYear("qcew", period)
+	if err != nil {
+		return nil, err
+	}
+	rows, ok, err := d.syncYear(ctx, pool, f, tempDir, year, zap.L().With(zap.String("dataset", "qcew")))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, eris.Errorf("qcew: no data published for %d", year)
+	}
+	return &SyncResult{RowsSynced: rows, Metadata: map[string]any{"year": year}}, nil
+}
+
+// syncYear downloads and loads one QCEW year. ok is false when BLS has not
+// published the year.
+func (d *QCEW) syncYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, year int, log *zap.Logger) (rows int64, ok bool, err error) {
+	url := fmt.Sprintf("https://data.bls.gov/cew/data/files/%d/csv/%d_qtrly_by_industry.zip", year, year)
+	log.Info("downloading QCEW data", zap.Int("year", year), zap.String("url", url))
+
+	zipPath := filepath.Join(tempDir, fmt.Sprintf("qcew_%d.zip", year))
+	if _, err := f.DownloadToFile(ctx, url, zipPath); err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			log.Info("QCEW data not yet available, skipping", zap.Int("year", year))
+			return 0, false, nil
+		}
+		return 0, false, eris.Wrapf(err, "qcew: download year %d", year)
+	}
+
+	rows, err = d.processZip(db.WithSource(ctx, url), pool, zipPath, year)
+	if err != nil {
+		return 0, false, eris.Wrapf(err, "qcew: process year %d", year)
+	}
+
+	log.Info("processed QCEW year", zap.Int("year", year), zap.Int64("rows", rows))
+	_ = os.Remove(zipPath)
+	return rows, true, nil
+}
+
 func (d *QCEW) processZip(ctx context.Context, pool db.Pool, zipPath string, year int) (int64, error) {
 	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return 0, eris.Wrap(err, "qcew: open zip")
 	}
 	defer zr.Close() //nolint:errcheck
+	filter, err := naicsFilterFor(d.cfg, "qcew")
+	if err != nil {
+		return 0, err
+	}
 
 	var totalRows int64
 
@@ -109,7 +156,7 @@ func (d *QCEW) processZip(ctx context.Context, pool db.Pool, zipPath string, yea
 		if !strings.HasSuffix(name, ".csv") {
 			continue
 		}
-		if !d.isRelevantFile(name) {
+		if !d.isRelevantFile(name, filter) {
 			continue
 		}
 
@@ -128,18 +175,18 @@ func (d *QCEW) processZip(ctx context.Context, pool db.Pool, zipPath string, yea
 	return totalRows, nil
 }
 
-// isRelevantFile checks if a QCEW CSV file is for a relevant industry sector.
-// Files are named like "2023.q1-q4.by_industry/2023.q1-q4 52 NAICS 52.csv".
-func (d *QCEW) isRelevantFile(name string) bool {
+// isRelevantFile checks if a QCEW CSV file is for a sector filter keeps.
+// Files are named like "2023.q1-q4.by_industry/2023.q1-q4 52 NAICS 52.csv";
+// the all-industry total file is always kept.
+func (d *QCEW) isRelevantFile(name string, filter *transform.NAICSFilter) bool {
+	if strings.Contains(name, "10 total") {
+		return true
+	}
 	for _, prefix := range transform.NAICSPrefixes {
 		if strings.Contains(name, " "+prefix+" ") || strings.Contains(name, " "+prefix+".") {
-			return true
+			return filter.Overlaps(prefix)
 		}
 	}
-	// Also accept aggregate/total files
-	if strings.Contains(name, "10 total") {
-		return true
-	}
 	return false
 }
 
@@ -154,6 +201,10 @@ func (d *QCEW) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 	}
 
 	colIdx := mapColumns(header)
+	filter, err := naicsFilterFor(d.cfg, "qcew")
+	if err != nil {
+		return 0, err
+	}
 
 	columns := []string{"area_fips", "own_code", "industry_code", "year", "qtr", "month1_emplvl", "month2_emplvl", "month3_emplvl", "total_qtrly_wages", "avg_wkly_wage", "qtrly_estabs"}
 	conflictKeys := []string{"area_fips", "own_code", "industry_code", "year", "qtr"}
@@ -161,6 +212,7 @@ func (d *QCEW) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 	var batch [][]any
 	var totalRows int64
 	seen := make(map[string]int) // conflict key → batch index (dedup within batch)
+	slice := sliceFromContext(ctx)
 
 	for {
 		record, err := reader.Read()
@@ -172,7 +224,8 @@ func (d *QCEW) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 		}
 
 		industryCode := trimQuotes(getCol(record, colIdx, "industry_code"))
-		if !transform.IsRelevantNAICS(industryCode) {
+		// Files hold sector-level rows, so keep sectors containing kept codes.
+		if industryCode != "10" && !filter.Overlaps(industryCode) {
 			continue
 		}
 
@@ -184,6 +237,9 @@ func (d *QCEW) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 		if qtr == 0 {
 			continue
 		}
+		if !slice.contains(int(qtr), areaFips[:min(2, len(areaFips))]) {
+			continue
+		}
 
 		row := []any{
 			areaFips,
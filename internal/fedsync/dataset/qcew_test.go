@@ -2,6 +2,7 @@ package dataset
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -54,11 +55,11 @@ func TestQCEW_ShouldRun(t *testing.T) {
 func TestQCEW_IsRelevantFile(t *testing.T) {
 	ds := &QCEW{}
 
-	assert.True(t, ds.isRelevantFile("2023.q1-q4 52 NAICS 52.csv"))
-	assert.True(t, ds.isRelevantFile("2023.q1-q4 54 NAICS 54.csv"))
-	assert.True(t, ds.isRelevantFile("path/to/10 total all.csv"))
-	assert.True(t, ds.isRelevantFile("2023.q1-q4 31 NAICS 31.csv"))
-	assert.False(t, ds.isRelevantFile("readme.txt"))
+	assert.True(t, ds.isRelevantFile("2023.q1-q4 52 NAICS 52.csv", nil))
+	assert.True(t, ds.isRelevantFile("2023.q1-q4 54 NAICS 54.csv", nil))
+	assert.True(t, ds.isRelevantFile("path/to/10 total all.csv", nil))
+	assert.True(t, ds.isRelevantFile("2023.q1-q4 31 NAICS 31.csv", nil))
+	assert.False(t, ds.isRelevantFile("readme.txt", nil))
 }
 
 func TestQCEW_Sync_NoRelevantFiles(t *testing.T) {
@@ -104,3 +105,17 @@ func TestQCEW_Sync_DownloadFailure(t *testing.T) {
 	_, err = ds.Sync(context.Background(), pool, f, t.TempDir())
 	assert.Error(t, err)
 }
+
+func TestQCEW_SyncPeriod(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://data.bls.gov/cew/data/files/2016/csv/2016_qtrly_by_industry.zip", mock.Anything).
+		Return(int64(0), errors.New("status 404"))
+
+	ds := &QCEW{}
+	_, err := ds.SyncPeriod(context.Background(), nil, f, t.TempDir(), Period{Year: 2016})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no data published for 2016")
+
+	_, err = ds.SyncPeriod(context.Background(), nil, f, t.TempDir(), Period{Year: 2016, Quarter: 2})
+	assert.Error(t, err, "quarters are not separate files")
+}
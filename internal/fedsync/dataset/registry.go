@@ -19,10 +19,10 @@ func NewRegistry(cfg *config.Config) *Registry {
 	}
 
 	// Phase 1: Market Intelligence
-	r.Register(&CBP{})
-	r.Register(&SUSB{})
-	r.Register(&QCEW{})
-	r.Register(&OEWS{})
+	r.Register(&CBP{cfg: cfg})
+	r.Register(&SUSB{cfg: cfg})
+	r.Register(&QCEW{cfg: cfg})
+	r.Register(&OEWS{cfg: cfg})
 	r.Register(&FPDS{cfg: cfg})
 	r.Register(&EconCensus{cfg: cfg})
 	r.Register(&PPP{})
@@ -31,6 +31,8 @@ func NewRegistry(cfg *config.Config) *Registry {
 	r.Register(&EOBMF{})
 	r.Register(&CensusGeo{})
 	r.Register(&USAspending{cfg: cfg})
+	r.Register(&SAMEntities{cfg: cfg})
+	r.Register(&FederalGrants{cfg: cfg})
 
 	// Phase 1B: Buyer Intelligence (SEC/EDGAR)
 	r.Register(&ADVPart1{})
@@ -38,7 +40,10 @@ func NewRegistry(cfg *config.Config) *Registry {
 	r.Register(&Holdings13F{cfg: cfg})
 	r.Register(&FormD{cfg: cfg})
 	r.Register(&EDGARSubmissions{cfg: cfg})
-	r.Register(&EntityXref{})
+	r.Register(&CompanyTickers{})
+	r.Register(&EDGARFullText{cfg: cfg})
+	r.Register(&EntityXref{cfg: cfg})
+	r.Register(&InvestorGraph{})
 
 	// Phase 2: Extended Intelligence
 	r.Register(&ADVPart2{cfg: cfg})
@@ -46,7 +51,9 @@ func NewRegistry(cfg *config.Config) *Registry {
 	r.Register(&SECEnforcement{})
 	r.Register(&FormBD{cfg: cfg})
 	r.Register(&OSHITA{})
-	r.Register(&EPAECHO{})
+	r.Register(&OSHAITAEstablishments{cfg: cfg})
+	r.Register(&EPAECHO{cfg: cfg})
+	r.Register(&EPAEnforcement{})
 	r.Register(&NES{cfg: cfg})
 	r.Register(&ASM{cfg: cfg})
 	r.Register(&ECI{cfg: cfg})
@@ -56,17 +63,46 @@ func NewRegistry(cfg *config.Config) *Registry {
 	r.Register(&BEARegional{cfg: cfg})
 	r.Register(&IRSSOIMigration{})
 	r.Register(&BuildingPermits{cfg: cfg})
+	r.Register(&NPPES{})
+	r.Register(&ACS{cfg: cfg})
+	r.Register(&BFS{cfg: cfg})
+	r.Register(&BDS{cfg: cfg})
+	r.Register(&FMCSA{})
+	r.Register(&FAARegistry{})
+	r.Register(&EMMA{cfg: cfg})
+	for _, a := range sosAdapters() {
+		r.Register(&SOSRegistry{cfg: cfg, adapter: a})
+	}
+	for _, a := range uccAdapters() {
+		r.Register(&UCCFilings{cfg: cfg, adapter: a})
+	}
+	r.Register(&CourtListener{cfg: cfg})
+	r.Register(&FINRAArbitration{cfg: cfg})
+	for _, a := range insuranceAdapters() {
+		r.Register(&InsuranceProducers{cfg: cfg, adapter: a})
+	}
 
 	// Phase 3: On-Demand
 	r.Register(&ADVPart3{cfg: cfg})
 	r.Register(&ADVEnrichment{cfg: cfg})
 	r.Register(&ADVExtract{cfg: cfg})
+	r.Register(&FundProviderNetwork{})
+	r.Register(&CountyOpportunity{cfg: cfg})
 	r.Register(&XBRLFacts{cfg: cfg})
+	r.Register(&XBRLFrames{cfg: cfg})
 	r.Register(&FRED{cfg: cfg})
 	r.Register(&ABS{cfg: cfg})
 	r.Register(&CPSLAUS{cfg: cfg})
+	r.Register(&JOLTS{cfg: cfg})
+	r.Register(&PPI{cfg: cfg})
+	r.Register(&CPI{cfg: cfg})
 	r.Register(&M3{cfg: cfg})
 	r.Register(&LEHDLODES{})
+	r.Register(&LODES{cfg: cfg})
+	r.Register(&IRSSOI{})
+	r.Register(&FCCBroadband{cfg: cfg})
+	r.Register(&EIA{cfg: cfg})
+	r.Register(&FirmMomentum{})
 
 	return r
 }
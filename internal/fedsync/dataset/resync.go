@@ -0,0 +1,223 @@
+package dataset
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+// Slice selects part of a period dataset's table: one period, optionally
+// narrowed to a single state.
+type Slice struct {
+	Period Period
+	State  string // two-digit state FIPS code; "" = every state
+}
+
+// String formats the slice as "2023Q2" or "2023Q2 state 06".
+func (s Slice) String() string {
+	if s.State == "" {
+		return s.Period.String()
+	}
+	return s.Period.String() + " state " + s.State
+}
+
+// Validate checks that State, when set, is a two-digit FIPS code.
+func (s Slice) Validate() error {
+	if s.State == "" {
+		return nil
+	}
+	if len(s.State) != 2 || s.State[0] < '0' || s.State[0] > '9' || s.State[1] < '0' || s.State[1] > '9' {
+		return eris.Errorf("slice: state must be a two-digit FIPS code (e.g. 06), got %q", s.State)
+	}
+	return nil
+}
+
+// contains reports whether a row of quarter (0 = unknown or annual) and
+// state (two-digit FIPS, "" = none) falls in the slice. A nil slice
+// contains every row.
+func (s *Slice) contains(quarter int, state string) bool {
+	if s == nil {
+		return true
+	}
+	if s.Period.Quarter != 0 && quarter != s.Period.Quarter {
+		return false
+	}
+	return s.State == "" || state == s.State
+}
+
+// SlicePlan is how a SliceResyncer replaces one slice: the DELETE that
+// clears it and the period SyncPeriod reloads it from.
+type SlicePlan struct {
+	Delete string
+	Args   []any
+	Load   Period
+}
+
+type sliceKey struct{}
+
+// withSlice returns a context telling loaders to keep only rows in s.
+func withSlice(ctx context.Context, s Slice) context.Context {
+	return context.WithValue(ctx, sliceKey{}, &s)
+}
+
+// sliceFromContext returns the slice being resynced, or nil for a regular
+// sync or backfill.
+func sliceFromContext(ctx context.Context) *Slice {
+	s, _ := ctx.Value(sliceKey{}).(*Slice)
+	return s
+}
+
+// ResyncResult is the outcome of a slice resync.
+type ResyncResult struct {
+	Slice   Slice
+	Deleted int64
+	Rows    int64
+	Elapsed time.Duration
+}
+
+// ResyncLogName is the sync log dataset name resyncs of name are recorded
+// under, so a partial reload never counts as the dataset's scheduled sync.
+func ResyncLogName(name string) string {
+	return name + ":resync"
+}
+
+// Resync replaces one slice of a SliceResyncer dataset with a fresh load
+// from upstream, for when a single period's published file is corrected.
+// The delete and reload run in one transaction, so the slice keeps its old
+// rows unless the reload succeeds. The reload is retried like a regular
+// sync; each attempt runs in a savepoint so a failed attempt is undone
+// without aborting the transaction.
+func (e *Engine) Resync(ctx context.Context, name string, s Slice) (*ResyncResult, error) {
+	ds, err := e.reg.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := ds.(SliceResyncer)
+	if !ok {
+		return nil, eris.Errorf("engine: %s does not support resync by slice", name)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	plan, err := rs.PlanResync(s)
+	if err != nil {
+		return nil, err
+	}
+
+	release, locked, err := e.lock(ctx, name)
+	if err != nil {
+		return nil, eris.Wrapf(err, "engine: resync %s", name)
+	}
+	if !locked {
+		return nil, eris.Errorf("engine: %s is being synced by another run", name)
+	}
+	defer release()
+
+	log := zap.L().With(zap.String("component", "fedsync.resync"), zap.String("dataset", name), zap.Stringer("slice", s))
+	logName := ResyncLogName(name)
+	syncID, err := e.syncLog.Start(ctx, logName)
+	if err != nil {
+		return nil, eris.Wrapf(err, "engine: start resync log for %s %s", name, s)
+	}
+
+	start := time.Now()
+	result := &ResyncResult{Slice: s}
+	fail := func(err error) (*ResyncResult, error) {
+		result.Elapsed = time.Since(start)
+		log.Error("resync failed", zap.Error(err))
+		if logErr := e.syncLog.Fail(ctx, syncID, err.Error()); logErr != nil {
+			log.Error("failed to record resync failure", zap.Error(logErr))
+		}
+		return result, err
+	}
+
+	tx, err := e.pool.Begin(ctx)
+	if err != nil {
+		return fail(eris.Wrapf(err, "engine: begin resync of %s slice %s", name, s))
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	tag, err := tx.Exec(ctx, plan.Delete, plan.Args...)
+	if err != nil {
+		return fail(eris.Wrapf(err, "engine: delete %s slice %s", name, s))
+	}
+	result.Deleted = tag.RowsAffected()
+	log.Info("deleted slice, reloading", zap.Int64("deleted", result.Deleted), zap.Stringer("load_period", plan.Load))
+
+	prov := db.NewProvenance(syncID, start.UTC())
+	f := withProvenance(FetcherFor(e.fetcher, ds, e.mirrors), prov)
+	var rejects *fedsync.Rejects
+	res, attempts, err := e.syncWithRetry(ctx, log, func(ctx context.Context) (*SyncResult, error) {
+		rejects = fedsync.NewRejects(logName, syncID, fedsync.DefaultRejectSamples)
+		ctx = withSlice(fedsync.WithRejects(db.WithProvenance(ctx, prov), rejects), s)
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, eris.Wrap(err, "begin savepoint")
+		}
+		res, err := rs.SyncPeriod(ctx, sp, f, e.tempDir, plan.Load)
+		if err != nil {
+			_ = sp.Rollback(ctx)
+			return nil, err
+		}
+		if err := sp.Commit(ctx); err != nil {
+			return nil, eris.Wrap(err, "release savepoint")
+		}
+		return res, nil
+	})
+	if err != nil {
+		if attempts > 1 {
+			err = eris.Wrapf(err, "failed after %d attempts", attempts)
+		}
+		result.Deleted = 0
+		return fail(eris.Wrapf(err, "engine: reload %s slice %s (slice left unchanged)", name, s))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		result.Deleted = 0
+		return fail(eris.Wrapf(err, "engine: commit resync of %s slice %s (slice left unchanged)", name, s))
+	}
+
+	result.Rows = res.RowsSynced
+	result.Elapsed = time.Since(start)
+	meta := withMetadata(res.Metadata, "resync_slice", s.String())
+	meta = withMetadata(meta, "deleted_rows", result.Deleted)
+	fsResult := &fedsync.SyncResult{
+		RowsSynced: res.RowsSynced,
+		Metadata:   e.syncMetadata(meta),
+	}
+	e.recordRejects(ctx, rejects, fsResult, log)
+	if err := e.syncLog.Complete(ctx, syncID, fsResult); err != nil {
+		log.Error("failed to record resync completion", zap.Error(err))
+	}
+	log.Info("resync complete",
+		zap.Int64("deleted", result.Deleted),
+		zap.Int64("rows", result.Rows),
+		zap.Duration("elapsed", result.Elapsed),
+	)
+	return result, nil
+}
+
+// annualSlicePlan plans a resync for a table loaded by year, keyed by a
+// year column and, when stateCol is set, a two-digit state FIPS column.
+func annualSlicePlan(dataset, table, stateCol string, s Slice) (SlicePlan, error) {
+	year, err := requireYear(dataset, s.Period)
+	if err != nil {
+		return SlicePlan{}, err
+	}
+	load := Period{Year: year}
+	if s.State == "" {
+		return SlicePlan{Delete: "DELETE FROM " + table + " WHERE year = $1", Args: []any{year}, Load: load}, nil
+	}
+	if stateCol == "" {
+		return SlicePlan{}, eris.Errorf("%s: has no state column; resync by year only", dataset)
+	}
+	return SlicePlan{
+		Delete: "DELETE FROM " + table + " WHERE year = $1 AND " + stateCol + " = $2",
+		Args:   []any{year, s.State},
+		Load:   load,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// mockSliceDataset is a mockPeriodDataset that plans slice resyncs and
+// records the slice its loader saw.
+type mockSliceDataset struct {
+	mockPeriodDataset
+	seen *Slice
+}
+
+func (m *mockSliceDataset) PlanResync(s Slice) (SlicePlan, error) {
+	return SlicePlan{Delete: "DELETE FROM fed_data.cbp_data WHERE year = $1 AND fips_state = $2", Args: []any{s.Period.Year, s.State}, Load: Period{Year: s.Period.Year}}, nil
+}
+
+func (m *mockSliceDataset) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, p Period) (*SyncResult, error) {
+	m.seen = sliceFromContext(ctx)
+	return m.mockPeriodDataset.SyncPeriod(ctx, pool, f, tempDir, p)
+}
+
+func TestEngine_Resync(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+	ds := &mockSliceDataset{mockPeriodDataset: mockPeriodDataset{mockDataset: mockDataset{name: "cbp", phase: Phase1}}}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+	s := Slice{Period: Period{Year: 2021}, State: "06"}
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp:resync").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(4)))
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.cbp_data WHERE year = \\$1 AND fips_state = \\$2").
+		WithArgs(2021, "06").
+		WillReturnResult(pgxmock.NewResult("DELETE", 7))
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+	pool.ExpectCommit()
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'complete'").
+		WithArgs(int64(2021), []byte(`{"deleted_rows":7,"resync_slice":"2021 state 06"}`), int64(4)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	res, err := engine.Resync(context.Background(), "cbp", s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), res.Deleted)
+	assert.Equal(t, int64(2021), res.Rows)
+	assert.Equal(t, []Period{{Year: 2021}}, ds.periods)
+	require.NotNil(t, ds.seen)
+	assert.Equal(t, s, *ds.seen)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEngine_Resync_ReloadFails(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+	ds := &mockSliceDataset{mockPeriodDataset: mockPeriodDataset{mockDataset: mockDataset{name: "cbp", phase: Phase1}, failOn: Period{Year: 2021}}}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	pool.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp:resync").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	pool.ExpectBegin()
+	pool.ExpectExec("DELETE FROM fed_data.cbp_data").
+		WithArgs(2021, "").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+	pool.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'failed'").
+		WithArgs(pgxmock.AnyArg(), int64(5)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectRollback()
+
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+	res, err := engine.Resync(context.Background(), "cbp", Slice{Period: Period{Year: 2021}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slice left unchanged")
+	assert.Zero(t, res.Deleted)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEngine_Resync_Rejected(t *testing.T) {
+	pool, syncLog := newMockSyncLog(t)
+	reg := &Registry{
+		datasets: map[string]Dataset{
+			"fpds": &mockDataset{name: "fpds", phase: Phase1},
+			"cbp":  &mockSliceDataset{mockPeriodDataset: mockPeriodDataset{mockDataset: mockDataset{name: "cbp", phase: Phase1}}},
+		},
+		order: []string{"cbp", "fpds"},
+	}
+	engine := NewEngine(pool, nil, syncLog, reg, t.TempDir())
+
+	_, err := engine.Resync(context.Background(), "fpds", Slice{Period: Period{Year: 2021}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support resync")
+
+	_, err = engine.Resync(context.Background(), "cbp", Slice{Period: Period{Year: 2021}, State: "CA"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "two-digit FIPS")
+
+	pool.ExpectBegin()
+	pool.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(false))
+	pool.ExpectRollback()
+	engine.SetRunLocks(fedsync.NewRunLocks(pool))
+	_, err = engine.Resync(context.Background(), "cbp", Slice{Period: Period{Year: 2021}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "being synced by another run")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestSliceResyncers(t *testing.T) {
+	reg := NewRegistry(nil)
+	for _, name := range []string{"cbp", "susb", "oews", "qcew", "econ_census", "holdings_13f"} {
+		ds, err := reg.Get(name)
+		require.NoError(t, err)
+		_, ok := ds.(SliceResyncer)
+		assert.True(t, ok, name)
+	}
+}
+
+func TestPlanResync(t *testing.T) {
+	plan, err := (&QCEW{}).PlanResync(Slice{Period: Period{Year: 2023, Quarter: 2}, State: "06"})
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM fed_data.qcew_data WHERE year = $1 AND qtr = $2 AND LEFT(area_fips, 2) = $3", plan.Delete)
+	assert.Equal(t, []any{2023, 2, "06"}, plan.Args)
+	assert.Equal(t, Period{Year: 2023}, plan.Load)
+
+	plan, err = (&SUSB{}).PlanResync(Slice{Period: Period{Year: 2021}, State: "48"})
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM fed_data.susb_data WHERE year = $1 AND fips_state = $2", plan.Delete)
+
+	plan, err = (&Holdings13F{}).PlanResync(Slice{Period: Period{Year: 2020, Quarter: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, Period{Year: 2020, Quarter: 3}, plan.Load)
+	assert.Equal(t, []any{Period{2020, 3}.QuarterEnd(), Period{2020, 3}.QuarterEnd()}, plan.Args)
+
+	_, err = (&CBP{}).PlanResync(Slice{Period: Period{Year: 2021, Quarter: 1}})
+	assert.Error(t, err, "annual tables reject quarters")
+	_, err = (&OEWS{}).PlanResync(Slice{Period: Period{Year: 2022}, State: "06"})
+	assert.Error(t, err, "oews has no state column")
+	_, err = (&Holdings13F{}).PlanResync(Slice{Period: Period{Year: 2022}, State: "06"})
+	assert.Error(t, err)
+}
+
+func TestQCEW_ParseCSV_Slice(t *testing.T) {
+	csvData := "area_fips,own_code,industry_code,qtr,month1_emplvl\n" +
+		"01001,5,52,1,10\n" +
+		"01001,5,52,2,11\n" +
+		"06037,5,52,1,12\n"
+
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Nothing in the file is in the slice, so nothing is written.
+	ctx := withSlice(context.Background(), Slice{Period: Period{Year: 2023, Quarter: 2}, State: "06"})
+	n, err := (&QCEW{}).parseCSV(ctx, pool, strings.NewReader(csvData), 2023)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	expectBulkUpsert(pool, "fed_data.qcew_data", []string{"area_fips", "own_code", "industry_code", "year", "qtr", "month1_emplvl", "month2_emplvl", "month3_emplvl", "total_qtrly_wages", "avg_wkly_wage", "qtrly_estabs"}, 1)
+	ctx = withSlice(context.Background(), Slice{Period: Period{Year: 2023, Quarter: 1}, State: "06"})
+	n, err = (&QCEW{}).parseCSV(ctx, pool, strings.NewReader(csvData), 2023)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+// syncTimeout caps a single sync attempt.
+const syncTimeout = 60 * time.Minute
+
+// DefaultSyncRetry is the engine's retry policy unless SetRetry replaces
+// it: up to three attempts, 30s and then 60s apart (±25%).
+func DefaultSyncRetry() resilience.RetryConfig {
+	return resilience.RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+		Multiplier:     2.0,
+		JitterFraction: 0.25,
+	}
+}
+
+// RetryFromConfig converts the fedsync.retry config to the engine's retry
+// policy.
+func RetryFromConfig(cfg *config.Config) resilience.RetryConfig {
+	r := cfg.Fedsync.Retry
+	return resilience.FromRetryConfig(r.MaxAttempts, r.InitialBackoffMs, r.MaxBackoffMs, r.Multiplier, r.JitterFraction)
+}
+
+// IsTransientSyncError reports whether a failed sync is worth running
+// again: rate limiting (429), request timeouts (408), server errors (5xx),
+// and network resets or timeouts. Anything else — 404s and other client
+// errors, parse failures, cancellation — is permanent.
+func IsTransientSyncError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if code, ok := fetcher.StatusCode(err); ok {
+		return code >= 500 || resilience.IsTransientHTTPStatus(code)
+	}
+	return resilience.IsTransient(err)
+}
+
+// syncWithRetry runs fn under syncTimeout, re-running it with jittered
+// exponential backoff while it fails with a transient error. It returns
+// the result, the number of attempts made, and the last error. Resumable
+// datasets pick up from their checkpoint on each retry.
+func (e *Engine) syncWithRetry(ctx context.Context, log *zap.Logger, fn func(ctx context.Context) (*SyncResult, error)) (*SyncResult, int, error) {
+	var attempts int
+	var timedOut bool
+	cfg := e.retry
+	cfg.ShouldRetry = func(err error) bool {
+		// A timed-out attempt is not retried, whatever error the dataset
+		// surfaced for it.
+		return !timedOut && IsTransientSyncError(err)
+	}
+	cfg.OnRetry = func(attempt int, err error) {
+		log.Warn("transient sync failure, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", cfg.MaxAttempts),
+			zap.Error(err),
+		)
+	}
+
+	res, err := resilience.DoVal(ctx, cfg, func(ctx context.Context) (*SyncResult, error) {
+		attempts++
+		attemptCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+		defer cancel()
+		start := time.Now()
+		res, err := fn(attemptCtx)
+		timedOut = attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		if timedOut {
+			log.Warn("sync timed out", zap.Duration("timeout", syncTimeout), zap.Duration("elapsed", time.Since(start)))
+		}
+		return res, err
+	})
+	return res, attempts, err
+}
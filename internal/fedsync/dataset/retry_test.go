@@ -0,0 +1,144 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+// flakyDataset fails with its queued errors, one per call, then succeeds.
+type flakyDataset struct {
+	mockDataset
+	errs  []error
+	calls int
+}
+
+func (d *flakyDataset) Sync(_ context.Context, _ db.Pool, _ fetcher.Fetcher, _ string) (*SyncResult, error) {
+	d.calls++
+	if len(d.errs) > 0 {
+		err := d.errs[0]
+		d.errs = d.errs[1:]
+		return nil, err
+	}
+	return &SyncResult{RowsSynced: d.syncRows}, nil
+}
+
+func fastRetry(attempts int) resilience.RetryConfig {
+	return resilience.RetryConfig{MaxAttempts: attempts, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+}
+
+func TestRetryFromConfig(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Fedsync.Retry = config.SyncRetryConfig{MaxAttempts: 4, InitialBackoffMs: 1000, MaxBackoffMs: 8000, Multiplier: 3, JitterFraction: 0.1}
+	r := RetryFromConfig(cfg)
+	assert.Equal(t, 4, r.MaxAttempts)
+	assert.Equal(t, time.Second, r.InitialBackoff)
+	assert.Equal(t, 8*time.Second, r.MaxBackoff)
+	assert.Equal(t, 3.0, r.Multiplier)
+	assert.Equal(t, 0.1, r.JitterFraction)
+}
+
+func TestIsTransientSyncError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("download: all retries exhausted: http 503 from https://api.census.gov/data"), true},
+		{errors.New("all retries exhausted: http 429 from x"), true},
+		{errors.New("census api: unexpected status 502 from x"), true},
+		{errors.New("download: unexpected status 408 from x"), true},
+		{fmt.Errorf("read body: %w", syscall.ECONNRESET), true},
+		{errors.New("read tcp: connection reset by peer"), true},
+		{errors.New("download: unexpected status 404 from x"), false},
+		{errors.New("download: unexpected status 400 from x"), false},
+		{errors.New("parse csv: record on line 3: wrong number of fields"), false},
+		{fmt.Errorf("sync: %w", context.Canceled), false},
+		{fmt.Errorf("sync: %w", context.DeadlineExceeded), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsTransientSyncError(tt.err), "%v", tt.err)
+	}
+}
+
+func TestEngine_Run_RetriesTransientFailure(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &flakyDataset{
+		mockDataset: mockDataset{name: "cbp", phase: Phase1, syncRows: 42},
+		errs:        []error{errors.New("all retries exhausted: http 503 from https://api.census.gov")},
+	}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log").
+		WithArgs(int64(42), pgxmock.AnyArg(), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetRetry(fastRetry(3))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, Datasets: []string{"cbp"}}))
+	assert.Equal(t, 2, ds.calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEngine_Run_RetriesExhausted(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	transient := errors.New("http 502 from x")
+	ds := &flakyDataset{
+		mockDataset: mockDataset{name: "cbp", phase: Phase1},
+		errs:        []error{transient, transient, transient},
+	}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'failed'").
+		WithArgs("failed after 2 attempts: http 502 from x", int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetRetry(fastRetry(2))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, Datasets: []string{"cbp"}}))
+	assert.Equal(t, 2, ds.calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEngine_Run_PermanentFailureNotRetried(t *testing.T) {
+	mock, syncLog := newMockSyncLog(t)
+
+	ds := &flakyDataset{
+		mockDataset: mockDataset{name: "cbp", phase: Phase1},
+		errs:        []error{errors.New("download: unexpected status 404 from x")},
+	}
+	reg := &Registry{datasets: map[string]Dataset{"cbp": ds}, order: []string{"cbp"}}
+
+	mock.ExpectQuery("INSERT INTO fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec("UPDATE fed_data.sync_log\\s+SET status = 'failed'").
+		WithArgs("download: unexpected status 404 from x", int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	engine := NewEngine(mock, nil, syncLog, reg, t.TempDir())
+	engine.SetRetry(fastRetry(3))
+	require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true, Datasets: []string{"cbp"}}))
+	assert.Equal(t, 1, ds.calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,434 @@
+package dataset
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const (
+	samBatchSize = 10000
+	// samExtractsURL is the SAM.gov Extracts Download API.
+	samExtractsURL = "https://api.sam.gov/data-services/v1/extracts"
+)
+
+// samEntityColumns defines the sam_entities upsert columns.
+var samEntityColumns = []string{
+	"uei", "cage_code", "registration_status", "purpose", "legal_name", "legal_name_norm",
+	"dba_name", "address_line1", "city", "state", "zip", "country",
+	"entity_structure", "state_of_incorporation", "primary_naics", "naics_codes", "business_types",
+	"entity_url", "registration_date", "expiration_date", "last_update_date", "entity_start_date",
+	"extract_date", "updated_at",
+}
+
+// samExclusionColumns defines the sam_exclusions upsert columns.
+var samExclusionColumns = []string{
+	"sam_number", "classification", "name", "name_norm", "uei", "cage_code",
+	"city", "state", "country", "exclusion_program", "excluding_agency", "exclusion_type",
+	"active_date", "termination_date", "comments", "updated_at",
+}
+
+// Field positions in the pipe-delimited public entity extract (V2).
+const (
+	samFieldUEI               = 0
+	samFieldCAGE              = 3
+	samFieldExtractCode       = 5
+	samFieldPurpose           = 6
+	samFieldRegistrationDate  = 7
+	samFieldExpirationDate    = 8
+	samFieldLastUpdateDate    = 9
+	samFieldLegalName         = 11
+	samFieldDBAName           = 12
+	samFieldAddressLine1      = 15
+	samFieldCity              = 17
+	samFieldState             = 18
+	samFieldZip               = 19
+	samFieldCountry           = 21
+	samFieldEntityStartDate   = 23
+	samFieldURL               = 25
+	samFieldEntityStructure   = 26
+	samFieldStateOfIncorp     = 27
+	samFieldBusinessTypes     = 30
+	samFieldPrimaryNAICS      = 31
+	samFieldNAICSCodes        = 33
+	samEntityMinFields        = samFieldNAICSCodes + 1
+	samEntityRecordTerminator = "!end"
+)
+
+// samExclusionAliases lists the exclusions extract headers for each field
+// (normalized by normalizeCol).
+var samExclusionAliases = map[string][]string{
+	"sam_number":     {"sam number"},
+	"classification": {"classification"},
+	"name":           {"name", "prefix+first+middle+last+suffix"},
+	"uei":            {"unique entity id", "uei"},
+	"cage":           {"cage"},
+	"city":           {"city"},
+	"state":          {"state / province", "state/province", "state"},
+	"country":        {"country"},
+	"program":        {"exclusion program"},
+	"agency":         {"excluding agency"},
+	"type":           {"exclusion type"},
+	"active_date":    {"active date"},
+	"termination":    {"termination date"},
+	"comments":       {"additional comments"},
+}
+
+// SAMEntities syncs SAM.gov entity registrations into fed_data.sam_entities
+// and the exclusions list into fed_data.sam_exclusions, both keyed by UEI.
+// Exclusions are reloaded on every run; the monthly entity extract is only
+// reloaded when a new month's file is published. The pipeline checks
+// exclusions for every company before the quality gate.
+type SAMEntities struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *SAMEntities) Name() string { return "sam_entities" }
+
+// Table implements Dataset.
+func (d *SAMEntities) Table() string { return "fed_data.sam_entities" }
+
+// Phase implements Dataset.
+func (d *SAMEntities) Phase() Phase { return Phase1 }
+
+// Cadence implements Dataset. Exclusions change daily, so the dataset runs
+// weekly even though entity registrations are extracted monthly.
+func (d *SAMEntities) Cadence() Cadence { return Weekly }
+
+// ShouldRun implements Dataset.
+func (d *SAMEntities) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return WeeklySchedule(now, lastSync)
+}
+
+// Sync loads the exclusions extract, then the current month's entity
+// extract when it has not been loaded yet.
+func (d *SAMEntities) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	apiKey := ""
+	if d.cfg != nil {
+		apiKey = d.cfg.Fedsync.SAMKey
+	}
+	if apiKey == "" {
+		return nil, eris.New("sam_entities: SAM API key not configured (fedsync.sam_api_key)")
+	}
+
+	exclusions, removed, err := d.syncExclusions(ctx, pool, f, apiKey, tempDir)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("sam exclusions synced", zap.Int64("rows", exclusions), zap.Int64("removed", removed))
+
+	month := time.Now().UTC()
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var loaded *time.Time
+	if err := pool.QueryRow(ctx, `SELECT MAX(extract_date) FROM fed_data.sam_entities`).Scan(&loaded); err != nil {
+		return nil, eris.Wrap(err, "sam_entities: query loaded extract month")
+	}
+
+	var entities int64
+	if loaded == nil || loaded.Before(month) {
+		entities, err = d.syncEntities(ctx, pool, f, apiKey, month, tempDir)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("sam entity extract loaded", zap.Time("month", month), zap.Int64("rows", entities))
+	} else {
+		log.Info("sam entity extract already loaded", zap.Time("month", month))
+	}
+
+	return &SyncResult{
+		RowsSynced: entities + exclusions,
+		Metadata: map[string]any{
+			"entities":           entities,
+			"exclusions":         exclusions,
+			"exclusions_removed": removed,
+		},
+	}, nil
+}
+
+// samExtractURL builds an Extracts Download API URL. date is MM/YYYY for
+// monthly files; the latest daily file is returned when it is empty.
+func samExtractURL(apiKey, fileType, frequency, date string) string {
+	q := url.Values{}
+	q.Set("api_key", apiKey)
+	q.Set("fileType", fileType)
+	q.Set("sensitivity", "PUBLIC")
+	if frequency != "" {
+		q.Set("frequency", frequency)
+	}
+	if date != "" {
+		q.Set("date", date)
+	}
+	return samExtractsURL + "?" + q.Encode()
+}
+
+// syncExclusions loads the full exclusions extract and deletes records no
+// longer listed. Returns the rows upserted and removed.
+func (d *SAMEntities) syncExclusions(ctx context.Context, pool db.Pool, f fetcher.Fetcher, apiKey, tempDir string) (int64, int64, error) {
+	path := filepath.Join(tempDir, "sam_exclusions")
+	if _, err := f.DownloadToFile(ctx, samExtractURL(apiKey, "EXCLUSION", "", ""), path); err != nil {
+		return 0, 0, eris.Wrap(err, "sam_entities: download exclusions")
+	}
+
+	start := time.Now().UTC()
+	var total int64
+	batch := make([][]any, 0, samBatchSize)
+	seen := make(map[string]bool)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "fed_data.sam_exclusions",
+			Columns:      samExclusionColumns,
+			ConflictKeys: []string{"sam_number"},
+		}, batch)
+		if err != nil {
+			return eris.Wrap(err, "sam_entities: upsert exclusions")
+		}
+		total += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err := forEachExtractFile(path, []string{".csv"}, func(r io.Reader) error {
+		reader := newLenientCSVReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			return eris.Wrap(err, "read exclusions header")
+		}
+		cols := resolveAliases(mapColumnsTrimBOM(header), samExclusionAliases)
+		if err := cols.require(samExclusionAliases, "sam_number", "name"); err != nil {
+			return err
+		}
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return eris.Wrap(err, "read exclusions")
+			}
+			row := samExclusionRow(cols, record, start)
+			if row == nil || seen[row[0].(string)] {
+				continue
+			}
+			seen[row[0].(string)] = true
+			batch = append(batch, row)
+			if len(batch) >= samBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	})
+	if err != nil {
+		return total, 0, eris.Wrap(err, "sam_entities: parse exclusions")
+	}
+	if err := flush(); err != nil {
+		return total, 0, err
+	}
+	if total == 0 {
+		return 0, 0, eris.New("sam_entities: exclusions extract is empty")
+	}
+
+	tag, err := pool.Exec(ctx, `DELETE FROM fed_data.sam_exclusions WHERE updated_at < $1`, start)
+	if err != nil {
+		return total, 0, eris.Wrap(err, "sam_entities: delete lifted exclusions")
+	}
+	return total, tag.RowsAffected(), nil
+}
+
+// samExclusionRow maps an exclusions record to samExclusionColumns.
+// Returns nil when the record has no SAM number or name.
+func samExclusionRow(cols aliasColumns, record []string, now time.Time) []any {
+	get := func(field string) string { return sanitizeUTF8(strings.TrimSpace(cols.get(record, field))) }
+	samNumber := strings.ToUpper(get("sam_number"))
+	name := get("name")
+	if samNumber == "" || name == "" {
+		return nil
+	}
+	state := strings.ToUpper(get("state"))
+	if len(state) != 2 {
+		state = ""
+	}
+	country := strings.ToUpper(get("country"))
+	if len(country) > 3 {
+		country = ""
+	}
+	return []any{
+		samNumber,
+		nilIfEmpty(get("classification")),
+		name,
+		nilIfEmpty(resolve.NormalizeName(name)),
+		nilIfEmpty(strings.ToUpper(get("uei"))),
+		nilIfEmpty(strings.ToUpper(get("cage"))),
+		nilIfEmpty(get("city")),
+		nilIfEmpty(state),
+		nilIfEmpty(country),
+		nilIfEmpty(get("program")),
+		nilIfEmpty(get("agency")),
+		nilIfEmpty(get("type")),
+		dateOrNil(extractDate(get("active_date"))),
+		dateOrNil(extractDate(get("termination"))), // "Indefinite" parses to nil
+		nilIfEmpty(truncate(get("comments"), 4000)),
+		now,
+	}
+}
+
+// syncEntities loads the public entity extract for month.
+func (d *SAMEntities) syncEntities(ctx context.Context, pool db.Pool, f fetcher.Fetcher, apiKey string, month time.Time, tempDir string) (int64, error) {
+	path := filepath.Join(tempDir, "sam_entities")
+	u := samExtractURL(apiKey, "ENTITY", "MONTHLY", month.Format("01/2006"))
+	if _, err := f.DownloadToFile(ctx, u, path); err != nil {
+		return 0, eris.Wrap(err, "sam_entities: download entity extract")
+	}
+
+	var total int64
+	batch := make([][]any, 0, samBatchSize)
+	seen := make(map[string]bool)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      samEntityColumns,
+			ConflictKeys: []string{"uei"},
+		}, batch)
+		if err != nil {
+			return eris.Wrap(err, "sam_entities: upsert entities")
+		}
+		total += n
+		batch = batch[:0]
+		clear(seen)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	err := forEachExtractFile(path, []string{".dat", ".txt"}, func(r io.Reader) error {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			row := samEntityRow(scanner.Text(), month, now)
+			if row == nil || seen[row[0].(string)] {
+				continue
+			}
+			seen[row[0].(string)] = true
+			batch = append(batch, row)
+			if len(batch) >= samBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return eris.Wrap(scanner.Err(), "read entity extract")
+	})
+	if err != nil {
+		return total, eris.Wrap(err, "sam_entities: parse entity extract")
+	}
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// samEntityRow maps a pipe-delimited entity extract line to
+// samEntityColumns. Returns nil for the BOF/EOF marker lines and records
+// without a UEI or legal name.
+func samEntityRow(line string, month, now time.Time) []any {
+	line = strings.TrimSuffix(strings.TrimRight(line, "\r"), samEntityRecordTerminator)
+	if strings.HasPrefix(line, "BOF ") || strings.HasPrefix(line, "EOF ") {
+		return nil
+	}
+	fields := strings.Split(line, "|")
+	if len(fields) < samEntityMinFields {
+		return nil
+	}
+	get := func(i int) string { return sanitizeUTF8(strings.TrimSpace(fields[i])) }
+
+	uei := strings.ToUpper(get(samFieldUEI))
+	name := get(samFieldLegalName)
+	if len(uei) != 12 || name == "" {
+		return nil
+	}
+
+	status := ""
+	switch get(samFieldExtractCode) {
+	case "A":
+		status = "active"
+	case "E":
+		status = "expired"
+	}
+	zip := get(samFieldZip)
+	if len(zip) > 10 {
+		zip = zip[:10]
+	}
+	naics := get(samFieldPrimaryNAICS)
+	if len(naics) != 6 {
+		naics = ""
+	}
+
+	return []any{
+		uei,
+		nilIfEmpty(strings.ToUpper(get(samFieldCAGE))),
+		nilIfEmpty(status),
+		nilIfEmpty(get(samFieldPurpose)),
+		name,
+		nilIfEmpty(resolve.NormalizeName(name)),
+		nilIfEmpty(get(samFieldDBAName)),
+		nilIfEmpty(get(samFieldAddressLine1)),
+		nilIfEmpty(get(samFieldCity)),
+		nilIfEmpty(fitLen(strings.ToUpper(get(samFieldState)), 2)),
+		nilIfEmpty(zip),
+		nilIfEmpty(fitLen(strings.ToUpper(get(samFieldCountry)), 3)),
+		nilIfEmpty(fitLen(get(samFieldEntityStructure), 2)),
+		nilIfEmpty(fitLen(strings.ToUpper(get(samFieldStateOfIncorp)), 2)),
+		nilIfEmpty(naics),
+		samCodeList(get(samFieldNAICSCodes), 6),
+		samCodeList(get(samFieldBusinessTypes), 2),
+		nilIfEmpty(get(samFieldURL)),
+		parseFAADate(get(samFieldRegistrationDate)),
+		parseFAADate(get(samFieldExpirationDate)),
+		parseFAADate(get(samFieldLastUpdateDate)),
+		parseFAADate(get(samFieldEntityStartDate)),
+		month,
+		now,
+	}
+}
+
+// samCodeList splits a "~"-separated code list, keeping the first n
+// characters of each entry to drop suffix flags (e.g. "541611Y" → "541611").
+func samCodeList(s string, n int) []string {
+	var out []string
+	for _, code := range strings.Split(s, "~") {
+		code = strings.TrimSpace(code)
+		if len(code) < n {
+			continue
+		}
+		out = append(out, code[:n])
+	}
+	return out
+}
+
+// fitLen returns s when it is at most n bytes long, else "".
+func fitLen(s string, n int) string {
+	if len(s) > n {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,210 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const samExclusionsCSV = "\ufeffClassification,Name,Prefix,First,Middle,Last,Suffix,Address 1,City,State / Province,Country,Zip Code,Open Data Flag,Blank (Deprecated),Unique Entity ID,Exclusion Program,Excluding Agency,CT Code,Exclusion Type,Additional Comments,Active Date,Termination Date,Record Status,Cross-Reference,SAM Number,CAGE,NPI\n" +
+	`Firm,"Acme Federal Services, LLC",,,,,,1 Main St,Arlington,VA,USA,22201,,,ABCDEF123456,Reciprocal,HHS,,Prohibition/Restriction,Fraud,03/15/2024,Indefinite,,,s4mr3abc123,1ABC2,` + "\n" +
+	`Individual,,Mr.,John,Q,Public,,,Reston,Virginia,USA,,,,,Procurement,GSA,,Ineligible (Proceedings Pending),,2025-01-10,2027-01-10,,,S4MR3DEF456,,` + "\n" +
+	`Firm,No Sam Number LLC,,,,,,,,,,,,,,,,,,,,,,,,,` + "\n"
+
+// samEntityLine builds a pipe-delimited entity extract line with the given
+// fields set.
+func samEntityLine(set map[int]string) string {
+	fields := make([]string, samEntityMinFields+8)
+	for i, v := range set {
+		fields[i] = v
+	}
+	return strings.Join(fields, "|") + samEntityRecordTerminator
+}
+
+var samEntityFixture = strings.Join([]string{
+	"BOF PUBLIC V2 00000000 20260301 0000002 0000001",
+	samEntityLine(map[int]string{
+		samFieldUEI:              "abcdef123456",
+		samFieldCAGE:             "1abc2",
+		samFieldExtractCode:      "A",
+		samFieldPurpose:          "Z2",
+		samFieldRegistrationDate: "20200115",
+		samFieldExpirationDate:   "20270114",
+		samFieldLastUpdateDate:   "20260110",
+		samFieldLegalName:        "Acme Federal Services, LLC",
+		samFieldDBAName:          "Acme Federal",
+		samFieldAddressLine1:     "1 Main St",
+		samFieldCity:             "Arlington",
+		samFieldState:            "va",
+		samFieldZip:              "22201",
+		samFieldCountry:          "USA",
+		samFieldEntityStartDate:  "20150601",
+		samFieldURL:              "www.acmefed.test",
+		samFieldEntityStructure:  "2L",
+		samFieldStateOfIncorp:    "VA",
+		samFieldBusinessTypes:    "2X~23~A8",
+		samFieldPrimaryNAICS:     "541611",
+		samFieldNAICSCodes:       "541611Y~541512N~54",
+	}),
+	samEntityLine(map[int]string{samFieldUEI: "SHORT", samFieldLegalName: "Bad UEI Inc"}),
+	"EOF PUBLIC V2 00000000 20260301 0000002 0000001",
+}, "\n") + "\n"
+
+func TestSAMEntities_Metadata(t *testing.T) {
+	d := &SAMEntities{}
+	assert.Equal(t, "sam_entities", d.Name())
+	assert.Equal(t, "fed_data.sam_entities", d.Table())
+	assert.Equal(t, Phase1, d.Phase())
+	assert.Equal(t, Weekly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+}
+
+func TestSAMExtractURL(t *testing.T) {
+	u := samExtractURL("key", "ENTITY", "MONTHLY", "03/2026")
+	assert.Contains(t, u, "api_key=key")
+	assert.Contains(t, u, "fileType=ENTITY")
+	assert.Contains(t, u, "sensitivity=PUBLIC")
+	assert.Contains(t, u, "date=03%2F2026")
+
+	u = samExtractURL("key", "EXCLUSION", "", "")
+	assert.NotContains(t, u, "frequency")
+	assert.NotContains(t, u, "date")
+}
+
+func TestSAMEntityRow(t *testing.T) {
+	month := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	lines := strings.Split(strings.TrimSpace(samEntityFixture), "\n")
+
+	assert.Nil(t, samEntityRow(lines[0], month, now), "BOF marker")
+	assert.Nil(t, samEntityRow(lines[2], month, now), "malformed UEI")
+	assert.Nil(t, samEntityRow(lines[3], month, now), "EOF marker")
+	assert.Nil(t, samEntityRow("ABCDEF123456|too|few", month, now))
+
+	row := samEntityRow(lines[1], month, now)
+	require.Len(t, row, len(samEntityColumns))
+	assert.Equal(t, "ABCDEF123456", row[0])
+	assert.Equal(t, "1ABC2", row[1])
+	assert.Equal(t, "active", row[2])
+	assert.Equal(t, "Acme Federal Services, LLC", row[4])
+	assert.Equal(t, "ACME FEDERAL SERVICES", row[5])
+	assert.Equal(t, "VA", row[9])
+	assert.Equal(t, "541611", row[14])
+	assert.Equal(t, []string{"541611", "541512"}, row[15])
+	assert.Equal(t, []string{"2X", "23", "A8"}, row[16])
+	assert.Equal(t, time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), row[18])
+	assert.Equal(t, month, row[22])
+}
+
+func TestSAMExclusionRow(t *testing.T) {
+	reader := newLenientCSVReader(strings.NewReader(samExclusionsCSV))
+	header, err := reader.Read()
+	require.NoError(t, err)
+	cols := resolveAliases(mapColumnsTrimBOM(header), samExclusionAliases)
+	require.NoError(t, cols.require(samExclusionAliases, "sam_number", "name"))
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	now := time.Now()
+	row := samExclusionRow(cols, records[0], now)
+	require.Len(t, row, len(samExclusionColumns))
+	assert.Equal(t, "S4MR3ABC123", row[0])
+	assert.Equal(t, "Firm", row[1])
+	assert.Equal(t, "ACME FEDERAL SERVICES", row[3])
+	assert.Equal(t, "ABCDEF123456", row[4])
+	assert.Equal(t, "VA", row[7])
+	assert.Equal(t, "HHS", row[10])
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), row[12])
+	assert.Nil(t, row[13], "indefinite exclusion")
+
+	row = samExclusionRow(cols, records[1], now)
+	require.NotNil(t, row)
+	assert.Equal(t, "Mr. John Q Public", row[2], "individual name joined from parts")
+	assert.Nil(t, row[7], "non-abbreviated state dropped")
+	assert.Equal(t, time.Date(2027, 1, 10, 0, 0, 0, 0, time.UTC), row[13])
+
+	assert.Nil(t, samExclusionRow(cols, records[2], now), "no SAM number")
+}
+
+func TestSAMCodeList(t *testing.T) {
+	assert.Equal(t, []string{"541611", "541512"}, samCodeList("541611Y~541512N", 6))
+	assert.Nil(t, samCodeList("", 6))
+}
+
+func TestSAMEntities_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "fileType=EXCLUSION")
+	}), mock.Anything).Run(func(_ context.Context, _ string, path string) {
+		writeTestFixture(t, path, []byte(samExclusionsCSV))
+	}).Return(int64(len(samExclusionsCSV)), nil)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.MatchedBy(func(u string) bool {
+		return strings.Contains(u, "fileType=ENTITY") && strings.Contains(u, "frequency=MONTHLY")
+	}), mock.Anything).Run(func(_ context.Context, _ string, path string) {
+		writeTestFixture(t, path, []byte(samEntityFixture))
+	}).Return(int64(len(samEntityFixture)), nil)
+
+	expectBulkUpsert(pool, "fed_data.sam_exclusions", samExclusionColumns, 2)
+	pool.ExpectExec("DELETE FROM fed_data.sam_exclusions").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	pool.ExpectQuery("SELECT MAX\\(extract_date\\) FROM fed_data.sam_entities").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+	expectBulkUpsert(pool, "fed_data.sam_entities", samEntityColumns, 1)
+
+	d := &SAMEntities{cfg: &config.Config{Fedsync: config.FedsyncConfig{SAMKey: "key"}}}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, int64(1), res.Metadata["entities"])
+	assert.Equal(t, int64(2), res.Metadata["exclusions"])
+	assert.Equal(t, int64(3), res.Metadata["exclusions_removed"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestSAMEntities_SyncCurrentMonthLoaded(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(samExclusionsCSV))
+		}).Return(int64(len(samExclusionsCSV)), nil).Once()
+
+	now := time.Now().UTC()
+	loaded := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	expectBulkUpsert(pool, "fed_data.sam_exclusions", samExclusionColumns, 2)
+	pool.ExpectExec("DELETE FROM fed_data.sam_exclusions").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	pool.ExpectQuery("SELECT MAX\\(extract_date\\) FROM fed_data.sam_entities").
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(&loaded))
+
+	d := &SAMEntities{cfg: &config.Config{Fedsync: config.FedsyncConfig{SAMKey: "key"}}}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, int64(0), res.Metadata["entities"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestSAMEntities_SyncNoAPIKey(t *testing.T) {
+	_, err := (&SAMEntities{cfg: &config.Config{}}).Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.sam_api_key")
+}
@@ -84,6 +84,49 @@ func QuarterlyAfterDelay(now time.Time, lastSync *time.Time, delayDays int) bool
 	return lastSync.Before(available)
 }
 
+// Freshness describes where a dataset stands against its sync schedule.
+type Freshness string
+
+// FreshnessOK and following constants are the Freshness states.
+const (
+	FreshnessOK      Freshness = "ok"      // synced this period
+	FreshnessDue     Freshness = "due"     // ShouldRun is true
+	FreshnessOverdue Freshness = "overdue" // due and more than 2x its cadence behind
+	FreshnessNever   Freshness = "never"   // no successful sync on record
+)
+
+// CheckFreshness classifies a dataset given its last successful sync.
+func CheckFreshness(ds Dataset, now time.Time, lastSuccess *time.Time) Freshness {
+	switch {
+	case lastSuccess == nil:
+		return FreshnessNever
+	case !ds.ShouldRun(now, lastSuccess):
+		return FreshnessOK
+	case SignificantlyOverdue(ds.Cadence(), now.Sub(*lastSuccess)):
+		return FreshnessOverdue
+	default:
+		return FreshnessDue
+	}
+}
+
+// SignificantlyOverdue reports whether elapsed exceeds twice the cadence.
+func SignificantlyOverdue(c Cadence, elapsed time.Duration) bool {
+	switch c {
+	case Daily:
+		return elapsed > 48*time.Hour
+	case Weekly:
+		return elapsed > 14*24*time.Hour
+	case Monthly:
+		return elapsed > 60*24*time.Hour
+	case Quarterly:
+		return elapsed > 180*24*time.Hour
+	case Annual:
+		return elapsed > 730*24*time.Hour
+	default:
+		return false
+	}
+}
+
 // mostRecentQuarterEnd returns the last day of the most recent completed quarter.
 func mostRecentQuarterEnd(t time.Time) time.Time {
 	year := t.Year()
@@ -162,3 +162,28 @@ func TestQuarterlyAfterDelay(t *testing.T) {
 func ptr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestCheckFreshness(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 14, 0, 0, 0, time.UTC)
+	ds := &EDGARFullText{} // weekly
+
+	assert.Equal(t, FreshnessNever, CheckFreshness(ds, now, nil))
+
+	thisWeek := time.Date(2024, time.March, 11, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, FreshnessOK, CheckFreshness(ds, now, &thisWeek))
+
+	lastWeek := time.Date(2024, time.March, 8, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, FreshnessDue, CheckFreshness(ds, now, &lastWeek))
+
+	lastMonth := time.Date(2024, time.February, 20, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, FreshnessOverdue, CheckFreshness(ds, now, &lastMonth))
+}
+
+func TestSignificantlyOverdue(t *testing.T) {
+	day := 24 * time.Hour
+	assert.False(t, SignificantlyOverdue(Daily, 47*time.Hour))
+	assert.True(t, SignificantlyOverdue(Daily, 49*time.Hour))
+	assert.True(t, SignificantlyOverdue(Monthly, 61*day))
+	assert.False(t, SignificantlyOverdue(Annual, 400*day))
+	assert.False(t, SignificantlyOverdue(Cadence("unknown"), 1000*day))
+}
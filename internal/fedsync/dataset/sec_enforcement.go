@@ -2,8 +2,11 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,15 +14,45 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 const (
-	// SEC EDGAR litigation releases API endpoint.
-	secEnforcementURL = "https://efts.sec.gov/LATEST/search-index?q=%%22enforcement+action%%22&dateRange=custom&startdt=%s&enddt=%s&forms=LIT_REL"
+	secEnforcementLitigation = "litigation_release"
+	secEnforcementAdmin      = "administrative_proceeding"
 )
 
-// SECEnforcement implements the Dataset interface for SEC enforcement actions.
+// secEnforcementFeeds are the SEC litigation release and administrative
+// proceeding (including ALJ orders) index feeds, keyed by action type.
+var secEnforcementFeeds = []struct{ actionType, url string }{
+	{secEnforcementLitigation, "https://www.sec.gov/enforcement-litigation/litigation-releases/rss"},
+	{secEnforcementAdmin, "https://www.sec.gov/enforcement-litigation/administrative-proceedings/rss"},
+}
+
+// secEnforcementCols defines the upsert columns. crd_number and cik are
+// maintained by PostSync and left out so upserts keep existing links.
+var secEnforcementCols = []string{
+	"action_id", "action_type", "respondent_name", "respondent_norm", "action_date",
+	"description", "outcome", "penalty_amount", "url", "synced_at",
+}
+
+var (
+	// secReleaseTitleRe splits "LR-26123: Respondent" and
+	// "IA-6543 - Respondent" into release number and respondent.
+	secReleaseTitleRe = regexp.MustCompile(`^([A-Za-z0-9]{1,5}-\d+)\s*[:\-–]\s*(.+)$`)
+	// secPenaltyRe finds the civil penalty amount in a release summary.
+	secPenaltyRe = regexp.MustCompile(`(?i)civil (?:money )?penalt(?:y|ies)[^$]{0,40}\$\s?([\d,]+(?:\.\d+)?)(\s*million)?`)
+	// secRespondentPrefixRe strips caption boilerplate ahead of the respondent.
+	secRespondentPrefixRe = regexp.MustCompile(`(?i)^(?:(?:u\.s\.\s+)?securities and exchange commission|sec)\s+v\.?\s+|^in the matter of\s+`)
+	// secEtAlRe strips a trailing "et al." from the respondent.
+	secEtAlRe = regexp.MustCompile(`(?i),?\s+et\s+al\.?$`)
+)
+
+// SECEnforcement syncs SEC litigation releases and administrative
+// proceedings into fed_data.sec_enforcement_actions. PostSync links each
+// respondent to a CRD/CIK through entity_xref so the advisor extraction
+// pipeline can flag firms with enforcement history.
 type SECEnforcement struct{}
 
 // Name implements Dataset.
@@ -31,112 +64,263 @@ func (d *SECEnforcement) Table() string { return "fed_data.sec_enforcement_actio
 // Phase implements Dataset.
 func (d *SECEnforcement) Phase() Phase { return Phase2 }
 
-// Cadence implements Dataset.
-func (d *SECEnforcement) Cadence() Cadence { return Monthly }
+// Cadence implements Dataset. The index feeds only carry recent releases, so
+// they are polled weekly.
+func (d *SECEnforcement) Cadence() Cadence { return Weekly }
 
 // ShouldRun implements Dataset.
 func (d *SECEnforcement) ShouldRun(now time.Time, lastSync *time.Time) bool {
-	return MonthlySchedule(now, lastSync)
-}
-
-// enforcementSearchResult represents the EFTS search API response.
-type enforcementSearchResult struct {
-	Hits struct {
-		Hits []struct {
-			ID     string `json:"_id"`
-			Source struct {
-				DisplayNames []string `json:"display_names"`
-				FileDate     string   `json:"file_date"`
-				FormType     string   `json:"form_type"`
-				DisplayDesc  string   `json:"display_description"`
-				FileNum      string   `json:"file_num"`
-			} `json:"_source"`
-		} `json:"hits"`
-		Total struct {
-			Value int `json:"value"`
-		} `json:"total"`
-	} `json:"hits"`
-}
-
-// Sync fetches recent SEC enforcement actions and upserts into the database.
+	return WeeklySchedule(now, lastSync)
+}
+
+// secEnforcementRSS is an RSS 2.0 index feed.
+type secEnforcementRSS struct {
+	Items []secEnforcementItem `xml:"channel>item"`
+}
+
+// secEnforcementItem is one release in an index feed.
+type secEnforcementItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+}
+
+// Sync fetches the enforcement index feeds and upserts their releases.
 func (d *SECEnforcement) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
-	log := zap.L().With(zap.String("dataset", "sec_enforcement"))
+	log := zap.L().With(zap.String("dataset", d.Name()))
 
-	// Fetch enforcement actions from the last 90 days.
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, -3, 0)
+	now := time.Now().UTC()
+	seen := make(map[string]bool)
+	counts := make(map[string]int)
+	var rows [][]any
+	for _, feed := range secEnforcementFeeds {
+		items, err := fetchSECEnforcementFeed(ctx, f, feed.url)
+		if err != nil {
+			return nil, eris.Wrapf(err, "sec_enforcement: fetch %s feed", feed.actionType)
+		}
+		for _, item := range items {
+			row := secEnforcementRow(feed.actionType, item, now)
+			if row == nil || seen[row[0].(string)] {
+				continue
+			}
+			seen[row[0].(string)] = true
+			counts[feed.actionType]++
+			rows = append(rows, row)
+		}
+		log.Info("parsed enforcement feed", zap.String("type", feed.actionType), zap.Int("items", len(items)))
+	}
+
+	if len(rows) == 0 {
+		log.Info("no new enforcement actions found")
+		return &SyncResult{RowsSynced: 0}, nil
+	}
 
-	url := fmt.Sprintf(secEnforcementURL,
-		startDate.Format("2006-01-02"),
-		endDate.Format("2006-01-02"))
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      secEnforcementCols,
+		ConflictKeys: []string{"action_id"},
+	}, rows)
+	if err != nil {
+		return nil, eris.Wrap(err, "sec_enforcement: upsert actions")
+	}
 
-	log.Info("fetching SEC enforcement actions",
-		zap.String("start", startDate.Format("2006-01-02")),
-		zap.String("end", endDate.Format("2006-01-02")))
+	log.Info("sec_enforcement sync complete", zap.Int64("rows", n))
+	return &SyncResult{
+		RowsSynced: n,
+		Metadata: map[string]any{
+			"litigation_releases":        counts[secEnforcementLitigation],
+			"administrative_proceedings": counts[secEnforcementAdmin],
+		},
+	}, nil
+}
 
+func fetchSECEnforcementFeed(ctx context.Context, f fetcher.Fetcher, url string) ([]secEnforcementItem, error) {
 	rc, err := f.Download(ctx, url)
 	if err != nil {
-		return nil, eris.Wrap(err, "sec_enforcement: fetch EFTS search")
+		return nil, err
 	}
 	defer rc.Close() //nolint:errcheck
 
-	var result enforcementSearchResult
-	if err := json.NewDecoder(rc).Decode(&result); err != nil {
-		return nil, eris.Wrap(err, "sec_enforcement: parse EFTS response")
+	var feed secEnforcementRSS
+	if err := xml.NewDecoder(rc).Decode(&feed); err != nil {
+		return nil, eris.Wrap(err, "parse feed")
 	}
+	return feed.Items, nil
+}
 
-	log.Info("parsed enforcement results", zap.Int("hits", result.Hits.Total.Value))
+// secEnforcementRow maps a feed item to secEnforcementCols. Returns nil when
+// no release number can be determined.
+func secEnforcementRow(actionType string, item secEnforcementItem, now time.Time) []any {
+	title := strings.TrimSpace(item.Title)
+	link := strings.TrimSpace(item.Link)
 
-	cols := []string{
-		"action_id", "action_type", "respondent_name",
-		"crd_number", "cik", "action_date", "description",
-		"outcome", "penalty_amount", "url",
+	var actionID, respondent string
+	if m := secReleaseTitleRe.FindStringSubmatch(title); m != nil {
+		actionID, respondent = strings.ToUpper(m[1]), m[2]
+	} else {
+		// Fall back to the release page name (".../lr-26123", ".../ia-6543.htm").
+		base := strings.TrimSuffix(strings.TrimSuffix(path.Base(link), ".htm"), ".pdf")
+		if base == "." || base == "/" || base == "" {
+			return nil
+		}
+		actionID, respondent = strings.ToUpper(base), title
+	}
+	if len(actionID) > 50 {
+		return nil
 	}
-	conflictKeys := []string{"action_id"}
 
-	var rows [][]any
-	for _, hit := range result.Hits.Hits {
-		src := hit.Source
+	respondent = secRespondent(respondent)
+	desc := strings.TrimSpace(sanitizeUTF8(item.Description))
 
-		actionDate := parseDate(src.FileDate)
-		respondent := ""
-		if len(src.DisplayNames) > 0 {
-			respondent = strings.Join(src.DisplayNames, "; ")
-		}
+	var outcome any
+	if o := classifySECOutcome(desc); o != "" {
+		outcome = o
+	}
+	var penalty any
+	if p := parseSECPenalty(desc); p > 0 {
+		penalty = p
+	}
+	var url any
+	if link != "" {
+		url = truncate(link, 500)
+	}
+
+	return []any{
+		actionID,
+		actionType,
+		nilIfEmpty(truncate(respondent, 300)),
+		nilIfEmpty(resolve.NormalizeName(respondent)),
+		parseSECPubDate(item.PubDate),
+		nilIfEmpty(desc),
+		outcome,
+		penalty,
+		url,
+		now,
+	}
+}
+
+// secRespondent strips caption boilerplate ("SEC v.", "In the Matter of",
+// "et al.") from a release title.
+func secRespondent(s string) string {
+	s = strings.TrimSpace(sanitizeUTF8(s))
+	s = secRespondentPrefixRe.ReplaceAllString(s, "")
+	s = secEtAlRe.ReplaceAllString(s, "")
+	return strings.TrimSpace(s)
+}
 
-		row := []any{
-			hit.ID,
-			src.FormType,
-			respondent,
-			nil, // crd_number — resolved via entity_xref later
-			nil, // cik
-			actionDate,
-			src.DisplayDesc,
-			nil, // outcome — not in search results
-			nil, // penalty_amount — not in search results
-			fmt.Sprintf("https://www.sec.gov/cgi-bin/browse-edgar?action=getcompany&filenum=%s", src.FileNum),
+// classifySECOutcome maps release wording to a coarse outcome.
+func classifySECOutcome(desc string) string {
+	lower := strings.ToLower(desc)
+	switch {
+	case strings.Contains(lower, "final judgment"):
+		return "final_judgment"
+	case strings.Contains(lower, "cease-and-desist") || strings.Contains(lower, "cease and desist"):
+		return "cease_and_desist"
+	case strings.Contains(lower, "barred") || strings.Contains(lower, "industry bar"):
+		return "bar"
+	case strings.Contains(lower, "agreed to settle") || strings.Contains(lower, "consented"):
+		return "settled"
+	case strings.Contains(lower, "filed a complaint") || strings.Contains(lower, "charged"):
+		return "charged"
+	}
+	return ""
+}
+
+// parseSECPenalty returns the civil penalty in whole dollars, or 0.
+func parseSECPenalty(desc string) int64 {
+	m := secPenaltyRe.FindStringSubmatch(desc)
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	if m[2] != "" {
+		v *= 1_000_000
+	}
+	return int64(v)
+}
+
+// parseSECPubDate parses an RSS pubDate into a date.
+func parseSECPubDate(s string) any {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 		}
-		rows = append(rows, row)
 	}
+	return nil
+}
 
-	if len(rows) == 0 {
-		log.Info("no new enforcement actions found")
-		return &SyncResult{RowsSynced: 0}, nil
+// truncate clips s to at most n bytes for VARCHAR columns.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
 	}
+	return strings.ToValidUTF8(s[:n], "")
+}
 
-	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-		Table:        "fed_data.sec_enforcement_actions",
-		Columns:      cols,
-		ConflictKeys: conflictKeys,
-	}, rows)
+// PostSync implements PostSyncer by relinking respondents to CRD and CIK
+// numbers. A normalized respondent name is linked when it identifies exactly
+// one entity in entity_xref; remaining respondents fall back to a unique ADV
+// firm name for the CRD.
+func (d *SECEnforcement) PostSync(ctx context.Context, pool db.Pool, _ *SyncResult) error {
+	tx, err := pool.Begin(ctx)
 	if err != nil {
-		return nil, eris.Wrap(err, "sec_enforcement: upsert actions")
+		return eris.Wrap(err, "sec_enforcement: begin respondent links")
 	}
+	defer tx.Rollback(ctx) //nolint:errcheck
 
-	log.Info("sec_enforcement sync complete", zap.Int64("rows", n))
+	if _, err := tx.Exec(ctx, `UPDATE fed_data.sec_enforcement_actions SET crd_number = NULL, cik = NULL
+WHERE respondent_norm IS NOT NULL AND (crd_number IS NOT NULL OR cik IS NOT NULL)`); err != nil {
+		return eris.Wrap(err, "sec_enforcement: clear respondent links")
+	}
+	xref, err := tx.Exec(ctx, secEnforcementXrefLinkSQL())
+	if err != nil {
+		return eris.Wrap(err, "sec_enforcement: link respondents via entity_xref")
+	}
+	adv, err := tx.Exec(ctx, secEnforcementADVLinkSQL())
+	if err != nil {
+		return eris.Wrap(err, "sec_enforcement: link respondents via adv_firms")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return eris.Wrap(err, "sec_enforcement: commit respondent links")
+	}
 
-	return &SyncResult{
-		RowsSynced: n,
-		Metadata:   map[string]any{"total_hits": result.Hits.Total.Value, "synced": n},
-	}, nil
+	zap.L().Info("sec_enforcement respondent links rebuilt",
+		zap.String("dataset", d.Name()),
+		zap.Int64("xref_linked", xref.RowsAffected()),
+		zap.Int64("adv_linked", adv.RowsAffected()),
+	)
+	return nil
+}
+
+// secEnforcementXrefLinkSQL sets crd_number and cik where the respondent's
+// normalized name matches exactly one CRD and at most one CIK in entity_xref.
+func secEnforcementXrefLinkSQL() string {
+	return fmt.Sprintf(`UPDATE fed_data.sec_enforcement_actions s
+SET crd_number = m.crd_number, cik = m.cik
+FROM (
+    SELECT norm, MIN(crd_number) AS crd_number, MIN(cik) AS cik
+    FROM (SELECT %s AS norm, crd_number, cik FROM fed_data.entity_xref WHERE crd_number IS NOT NULL) x
+    GROUP BY norm
+    HAVING COUNT(DISTINCT crd_number) = 1 AND COUNT(DISTINCT cik) <= 1
+) m
+WHERE s.respondent_norm = m.norm`, resolve.NormalizeNameSQL("entity_name"))
+}
+
+// secEnforcementADVLinkSQL sets crd_number for still-unlinked respondents
+// whose normalized name matches exactly one ADV firm.
+func secEnforcementADVLinkSQL() string {
+	return fmt.Sprintf(`UPDATE fed_data.sec_enforcement_actions s
+SET crd_number = m.crd_number
+FROM (
+    SELECT norm, MIN(crd_number) AS crd_number
+    FROM (SELECT %s AS norm, crd_number FROM fed_data.adv_firms) f
+    GROUP BY norm
+    HAVING COUNT(DISTINCT crd_number) = 1
+) m
+WHERE s.respondent_norm = m.norm AND s.crd_number IS NULL`, resolve.NormalizeNameSQL("firm_name"))
 }
@@ -2,26 +2,57 @@ package dataset
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"io"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
+const secLitReleasesRSS = `<?xml version="1.0" encoding="utf-8"?>
+<rss version="2.0"><channel><title>Litigation Releases</title>
+<item>
+  <title>LR-26301: SEC v. Harbor Point Advisors LLC, et al.</title>
+  <link>https://www.sec.gov/enforcement-litigation/litigation-releases/lr-26301</link>
+  <description>The SEC obtained a final judgment ordering Harbor Point Advisors LLC to pay a civil penalty of $1.5 million.</description>
+  <pubDate>Tue, 14 Apr 2026 10:00:00 -0400</pubDate>
+</item>
+<item>
+  <title>Securities and Exchange Commission v. John Roe</title>
+  <link>https://www.sec.gov/litigation/litreleases/2026/lr26302.htm</link>
+  <description>The SEC filed a complaint charging John Roe with insider trading.</description>
+  <pubDate>Wed, 15 Apr 2026 10:00:00 -0400</pubDate>
+</item>
+<item><title>No release</title><link></link></item>
+</channel></rss>`
+
+const secAdminRSS = `<?xml version="1.0" encoding="utf-8"?>
+<rss version="2.0"><channel><title>Administrative Proceedings</title>
+<item>
+  <title>IA-6612: In the Matter of Summit Ridge Wealth Management, Inc.</title>
+  <link>https://www.sec.gov/enforcement-litigation/administrative-proceedings/ia-6612</link>
+  <description>Summit Ridge consented to a cease-and-desist order and a civil money penalty of $125,000.</description>
+  <pubDate>Thu, 16 Apr 2026 09:00:00 -0400</pubDate>
+</item>
+<item>
+  <title>LR-26301: SEC v. Harbor Point Advisors LLC, et al.</title>
+  <link>https://www.sec.gov/enforcement-litigation/litigation-releases/lr-26301</link>
+</item>
+</channel></rss>`
+
 func TestSECEnforcement_Metadata(t *testing.T) {
 	d := &SECEnforcement{}
 
 	assert.Equal(t, "sec_enforcement", d.Name())
 	assert.Equal(t, "fed_data.sec_enforcement_actions", d.Table())
 	assert.Equal(t, Phase2, d.Phase())
-	assert.Equal(t, Monthly, d.Cadence())
+	assert.Equal(t, Weekly, d.Cadence())
 }
 
 func TestSECEnforcement_ShouldRun(t *testing.T) {
@@ -31,70 +62,116 @@ func TestSECEnforcement_ShouldRun(t *testing.T) {
 		assert.True(t, d.ShouldRun(time.Now(), nil))
 	})
 
-	t.Run("synced this month", func(t *testing.T) {
-		now := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	t.Run("synced this week", func(t *testing.T) {
+		now := time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)
 		last := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
 		assert.False(t, d.ShouldRun(now, &last))
 	})
 
-	t.Run("synced last month", func(t *testing.T) {
+	t.Run("synced last week", func(t *testing.T) {
 		now := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
-		last := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+		last := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
 		assert.True(t, d.ShouldRun(now, &last))
 	})
 }
 
-func TestSECEnforcement_Sync_EmptyResponse(t *testing.T) {
-	resp := map[string]any{
-		"hits": map[string]any{
-			"hits":  []any{},
-			"total": map[string]any{"value": 0},
-		},
-	}
-
-	mf := fetchermocks.NewMockFetcher(t)
-	mf.On("Download", mock.Anything, mock.AnythingOfType("string")).
-		Return(jsonBody(t, resp), nil).Once()
-
-	d := &SECEnforcement{}
-	result, err := d.Sync(context.Background(), nil, mf, t.TempDir())
-	require.NoError(t, err)
-	assert.Equal(t, int64(0), result.RowsSynced)
+func TestSECEnforcementRow(t *testing.T) {
+	now := time.Now()
+	row := secEnforcementRow(secEnforcementLitigation, secEnforcementItem{
+		Title:       "LR-26301: SEC v. Harbor Point Advisors LLC, et al.",
+		Link:        "https://www.sec.gov/enforcement-litigation/litigation-releases/lr-26301",
+		Description: "Final judgment with a civil penalty of $1.5 million.",
+		PubDate:     "Tue, 14 Apr 2026 10:00:00 -0400",
+	}, now)
+	require.Len(t, row, len(secEnforcementCols))
+	assert.Equal(t, "LR-26301", row[0])
+	assert.Equal(t, "Harbor Point Advisors LLC", row[2])
+	assert.Equal(t, "HARBOR POINT ADVISORS", row[3])
+	assert.Equal(t, time.Date(2026, 4, 14, 0, 0, 0, 0, time.UTC), row[4])
+	assert.Equal(t, "final_judgment", row[6])
+	assert.Equal(t, int64(1_500_000), row[7])
+
+	// Release number recovered from the page name.
+	row = secEnforcementRow(secEnforcementLitigation, secEnforcementItem{
+		Title: "Securities and Exchange Commission v. John Roe",
+		Link:  "https://www.sec.gov/litigation/litreleases/2026/lr26302.htm",
+	}, now)
+	assert.Equal(t, "LR26302", row[0])
+	assert.Equal(t, "John Roe", row[2])
+	assert.Nil(t, row[4])
+	assert.Nil(t, row[7])
+
+	assert.Nil(t, secEnforcementRow(secEnforcementAdmin, secEnforcementItem{Title: "No release"}, now))
 }
 
-func TestSECEnforcement_Sync_ParsesResults(t *testing.T) {
-	// Build a mock EFTS response with one hit.
-	resp := map[string]any{
-		"hits": map[string]any{
-			"hits": []any{
-				map[string]any{
-					"_id": "LR-12345",
-					"_source": map[string]any{
-						"display_names":       []string{"Test Advisor LLC"},
-						"file_date":           "2026-01-15",
-						"form_type":           "LIT_REL",
-						"display_description": "SEC charges advisory firm with fraud",
-						"file_num":            "3-12345",
-					},
-				},
-			},
-			"total": map[string]any{"value": 1},
-		},
+func TestParseSECPenalty(t *testing.T) {
+	tests := map[string]int64{
+		"ordered to pay a civil penalty of $250,000":             250_000,
+		"civil money penalties totaling $3 million":              3_000_000,
+		"disgorgement of $80,000 and a civil penalty of $40,000": 40_000,
+		"disgorgement of $80,000":                                0,
+		"":                                                       0,
+	}
+	for desc, want := range tests {
+		assert.Equal(t, want, parseSECPenalty(desc), desc)
 	}
+}
 
-	// Validate mock response is parseable into the struct.
-	data, err := json.Marshal(resp)
+func TestSECEnforcement_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
 	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(context.Background(), secEnforcementFeeds[0].url).
+		Return(io.NopCloser(strings.NewReader(secLitReleasesRSS)), nil)
+	f.EXPECT().Download(context.Background(), secEnforcementFeeds[1].url).
+		Return(io.NopCloser(strings.NewReader(secAdminRSS)), nil)
 
-	var result enforcementSearchResult
-	err = json.NewDecoder(io.NopCloser(strings.NewReader(string(data)))).Decode(&result)
+	// Two litigation releases plus one proceeding; the repeated release is dropped.
+	expectBulkUpsert(pool, "fed_data.sec_enforcement_actions", secEnforcementCols, 3)
+
+	res, err := (&SECEnforcement{}).Sync(context.Background(), pool, f, t.TempDir())
 	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, 2, res.Metadata["litigation_releases"])
+	assert.Equal(t, 1, res.Metadata["administrative_proceedings"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
 
-	assert.Equal(t, 1, result.Hits.Total.Value)
-	assert.Equal(t, "LR-12345", result.Hits.Hits[0].ID)
-	assert.Equal(t, "Test Advisor LLC", result.Hits.Hits[0].Source.DisplayNames[0])
-	assert.Equal(t, "2026-01-15", result.Hits.Hits[0].Source.FileDate)
-	assert.Equal(t, "LIT_REL", result.Hits.Hits[0].Source.FormType)
-	assert.Equal(t, "SEC charges advisory firm with fraud", result.Hits.Hits[0].Source.DisplayDesc)
-	assert.Equal(t, "3-12345", result.Hits.Hits[0].Source.FileNum)
+func TestSECEnforcement_Sync_Errors(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(context.Background(), secEnforcementFeeds[0].url).
+		Return(nil, errors.New("503 service unavailable"))
+	_, err := (&SECEnforcement{}).Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sec_enforcement: fetch litigation_release feed")
+
+	f = fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(context.Background(), secEnforcementFeeds[0].url).
+		Return(io.NopCloser(strings.NewReader("<rss><channel>")), nil)
+	_, err = (&SECEnforcement{}).Sync(context.Background(), nil, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse feed")
+}
+
+func TestSECEnforcement_PostSync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("UPDATE fed_data.sec_enforcement_actions SET crd_number = NULL").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	pool.ExpectExec("FROM fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	pool.ExpectExec("FROM fed_data.adv_firms").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectCommit()
+
+	require.NoError(t, (&SECEnforcement{}).PostSync(context.Background(), pool, &SyncResult{}))
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	assert.Contains(t, secEnforcementXrefLinkSQL(), "HAVING COUNT(DISTINCT crd_number) = 1")
+	assert.Contains(t, secEnforcementADVLinkSQL(), "s.crd_number IS NULL")
 }
@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,6 +18,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
@@ -262,7 +265,7 @@ func TestEDGARSubmissions_Sync_MultipleEntities(t *testing.T) {
 			return 2000, nil
 		})
 
-	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges"}
+	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"}
 	filingCols := []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
 
 	expectBulkUpsert(pool, "fed_data.edgar_entities", entityCols, 2)
@@ -277,6 +280,90 @@ func TestEDGARSubmissions_Sync_MultipleEntities(t *testing.T) {
 	assert.NoError(t, pool.ExpectationsWereMet())
 }
 
+func TestEDGARSubmissions_SyncIncremental(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+	pool.MatchExpectationsInOrder(false)
+
+	f := fetchermocks.NewMockFetcher(t)
+	tempDir := t.TempDir()
+
+	sub1 := `{"cik":"111","name":"Corp A","filings":{"recent":{"accessionNumber":["ACC-1"],"filingDate":["2024-01-01"],"form":["10-K"],"primaryDocument":["d.htm"],"primaryDocDescription":["AR"],"items":[""],"size":[100],"isXBRL":[0],"isInlineXBRL":[0]}}}`
+	sub2 := `{"cik":"222","name":"Corp B","filings":{"recent":{"accessionNumber":["ACC-2","ACC-3"],"filingDate":["2024-02-01","2023-12-01"],"form":["10-Q","8-K"],"primaryDocument":["q.htm","e.htm"],"primaryDocDescription":["QR","CR"],"items":["",""],"size":[200,50],"isXBRL":[1,0],"isInlineXBRL":[1,0]}}}`
+
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, path string) (int64, error) {
+			createMultiZIP(t, path, map[string][]byte{
+				"CIK0000000111.json": []byte(sub1),
+				"CIK0000000222.json": []byte(sub2),
+			})
+			return 2000, nil
+		})
+
+	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"}
+	filingCols := []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
+
+	// Only Corp B's ACC-2 is on or after the watermark date.
+	expectBulkUpsert(pool, "fed_data.edgar_entities", entityCols, 1)
+	expectBulkUpsert(pool, "fed_data.edgar_filings", filingCols, 1)
+
+	ds := &EDGARSubmissions{cfg: &config.Config{}}
+	since := time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC)
+	result, err := ds.SyncIncremental(context.Background(), pool, f, tempDir, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Metadata["entities"])
+	assert.Equal(t, int64(1), result.Metadata["filings"])
+	assert.Equal(t, "2024-01-15", result.Metadata["since"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestEDGARSubmissions_Sync_ResumesFromCheckpoint(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	tempDir := t.TempDir()
+
+	sub1 := `{"cik":"111","name":"Corp A","filings":{"recent":{"accessionNumber":["ACC-1"],"filingDate":["2024-01-01"],"form":["10-K"],"primaryDocument":["d.htm"],"primaryDocDescription":["AR"],"items":[""],"size":[100],"isXBRL":[0],"isInlineXBRL":[0]}}}`
+	sub2 := `{"cik":"222","name":"Corp B","filings":{"recent":{"accessionNumber":["ACC-2"],"filingDate":["2024-02-01"],"form":["10-Q"],"primaryDocument":["q.htm"],"primaryDocDescription":["QR"],"items":[""],"size":[200],"isXBRL":[1],"isInlineXBRL":[1]}}}`
+
+	f.EXPECT().DownloadToFile(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, path string) (int64, error) {
+			createMultiZIP(t, path, map[string][]byte{
+				"CIK0000000111.json": []byte(sub1),
+				"CIK0000000222.json": []byte(sub2),
+			})
+			return 2000, nil
+		})
+
+	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"}
+	filingCols := []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
+
+	// The previous run committed CIK0000000111.json; only Corp B is loaded.
+	interrupted := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	pool.ExpectQuery("SELECT last_key, started_at FROM fed_data.sync_checkpoints").
+		WithArgs("edgar_submissions").
+		WillReturnRows(pgxmock.NewRows([]string{"last_key", "started_at"}).AddRow("CIK0000000111.json", interrupted))
+	expectBulkUpsert(pool, "fed_data.edgar_entities", entityCols, 1)
+	expectBulkUpsert(pool, "fed_data.edgar_filings", filingCols, 1)
+	pool.ExpectExec("DELETE FROM fed_data.sync_checkpoints").
+		WithArgs("edgar_submissions").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	ds := &EDGARSubmissions{cfg: &config.Config{}}
+	ds.SetCheckpoint(fedsync.NewCheckpoints(pool).For("edgar_submissions"))
+	result, err := ds.Sync(context.Background(), pool, f, tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Metadata["entities"])
+	assert.Equal(t, "CIK0000000111.json", result.Metadata["resumed_after"])
+	require.NotNil(t, result.Watermark, "resumed run keeps the interrupted run's start")
+	assert.Equal(t, interrupted, *result.Watermark)
+	assert.True(t, result.Partial, "a resumed run's row count skips the files already loaded")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
 func TestEDGARSubmissions_ParseSubmissionFile_Success(t *testing.T) {
 	tempDir := t.TempDir()
 	jsonData := `{"cik":"9999999","name":"Test Corp","filings":{"recent":{"accessionNumber":[],"filingDate":[],"form":[],"primaryDocument":[],"primaryDocDescription":[],"items":[],"size":[],"isXBRL":[],"isInlineXBRL":[]}}}`
@@ -355,11 +442,28 @@ func TestHoldings13F_Sync_Full(t *testing.T) {
 			return int64(len(holdingsXML)), os.WriteFile(path, []byte(holdingsXML), 0o644)
 		})
 
-	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call"}
+	period := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	pool.ExpectExec("INSERT INTO fed_data.f13_filings").
+		WithArgs("0001234567-24-000001", "1234567", period, "13F-HR", pgxmock.AnyArg(), 0, nil).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectQuery("SELECT accession_number FROM fed_data.f13_filings").
+		WithArgs("1234567", period, "0001234567-24-000001", pgxmock.AnyArg(), 0).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectExec("UPDATE fed_data.f13_filings SET superseded_by").
+		WithArgs("1234567", period, "0001234567-24-000001", pgxmock.AnyArg(), 0).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	pool.ExpectExec("DELETE FROM fed_data.f13_holdings").
+		WithArgs("1234567", period, "0001234567-24-000001").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call", "accession_number"}
 	expectBulkUpsert(pool, "fed_data.f13_holdings", holdingsCols, 1)
 
+	pool.ExpectExec("UPDATE fed_data.f13_filings SET holdings").
+		WithArgs("0001234567-24-000001", 1, int64(150000000)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 	pool.ExpectExec("UPDATE fed_data.f13_filers SET total_value").
-		WithArgs(int64(150000000), "1234567").
+		WithArgs("1234567", period).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 	ds := &Holdings13F{cfg: &config.Config{}}
@@ -431,11 +535,11 @@ func TestHoldings13F_DownloadAndParseHoldings_Success(t *testing.T) {
 			return int64(len(holdingsXML)), os.WriteFile(path, []byte(holdingsXML), 0o644)
 		})
 
-	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call"}
+	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call", "accession_number"}
 	expectBulkUpsert(pool, "fed_data.f13_holdings", holdingsCols, 1)
 
 	ds := &Holdings13F{}
-	rows, err := ds.downloadAndParseHoldings(context.Background(), f, pool, "https://example.com/13f.xml", "9876543", nil, tempDir, nopLog())
+	rows, err := ds.downloadAndParseHoldings(context.Background(), f, pool, "https://example.com/13f.xml", &f13Filing{CIK: "9876543"}, "", tempDir, nopLog())
 	require.NoError(t, err)
 	assert.Len(t, rows, 1)
 	assert.Equal(t, "02079K107", rows[0][2])
@@ -452,7 +556,7 @@ func TestHoldings13F_DownloadAndParseHoldings_DownloadError(t *testing.T) {
 		Return(int64(0), errors.New("404 not found"))
 
 	ds := &Holdings13F{}
-	_, err = ds.downloadAndParseHoldings(context.Background(), f, pool, "https://example.com/13f.xml", "123", nil, t.TempDir(), nopLog())
+	_, err = ds.downloadAndParseHoldings(context.Background(), f, pool, "https://example.com/13f.xml", &f13Filing{CIK: "123"}, "", t.TempDir(), nopLog())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "download 13F holdings")
 }
@@ -531,6 +635,22 @@ func TestFormD_Sync_Full(t *testing.T) {
 	assert.NoError(t, pool.ExpectationsWereMet())
 }
 
+func TestFormD_SyncIncremental_TruncatedHoldsWatermark(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "forms=D")
+	})).Return(jsonBody(t, map[string]any{
+		"hits": map[string]any{"total": eftsMaxResults + 1, "hits": []map[string]any{}},
+	}), nil).Once()
+
+	since := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	ds := &FormD{cfg: &config.Config{}}
+	result, err := ds.SyncIncremental(context.Background(), nil, f, t.TempDir(), since)
+	require.NoError(t, err)
+	require.NotNil(t, result.Watermark)
+	assert.Equal(t, since, *result.Watermark)
+}
+
 func TestFormD_Sync_SearchError(t *testing.T) {
 	pool, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -958,6 +1078,7 @@ func TestEntityXref_Sync_Pass1Error(t *testing.T) {
 
 	f := fetchermocks.NewMockFetcher(t)
 
+	expectAddressKeyRefresh(pool, 2)
 	pool.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
@@ -976,6 +1097,7 @@ func TestEntityXref_Sync_Pass2Error(t *testing.T) {
 
 	f := fetchermocks.NewMockFetcher(t)
 
+	expectAddressKeyRefresh(pool, 2)
 	pool.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
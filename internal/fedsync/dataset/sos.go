@@ -0,0 +1,305 @@
+package dataset
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const sosBatchSize = 10000
+
+// Normalized Secretary of State entity statuses.
+const (
+	sosStatusActive     = "active"
+	sosStatusInactive   = "inactive"
+	sosStatusDissolved  = "dissolved"
+	sosStatusWithdrawn  = "withdrawn"
+	sosStatusDelinquent = "delinquent"
+	sosStatusUnknown    = "unknown"
+)
+
+// sosColumns defines the target DB columns in upsert order. first_seen_at
+// is left to its insert default so upserts keep the original value.
+var sosColumns = []string{
+	"state", "entity_id", "entity_name", "name_norm", "entity_type",
+	"status", "status_raw", "jurisdiction", "registered_agent",
+	"principal_street", "principal_city", "principal_state", "principal_zip",
+	"formation_date", "dissolution_date", "updated_at",
+}
+
+// sosEntity is one business entity record normalized by a state adapter.
+type sosEntity struct {
+	ID           string
+	Name         string
+	Type         string
+	StatusRaw    string
+	Jurisdiction string
+	Agent        string
+	Street       string
+	City         string
+	State        string
+	Zip          string
+	Formed       *time.Time
+	Dissolved    *time.Time
+}
+
+// sosAdapter parses one state's bulk entity extract. Adding a state means
+// writing an adapter and listing it in sosAdapters.
+type sosAdapter interface {
+	// State returns the two-letter state code.
+	State() string
+	// DefaultURL returns the public extract URL, or "" when the state
+	// requires fedsync.sos.urls to be configured.
+	DefaultURL() string
+	// Extensions lists the file extensions read from a ZIP extract.
+	Extensions() []string
+	// Parse streams entities from one extract file to emit.
+	Parse(r io.Reader, emit func(sosEntity) error) error
+}
+
+// SOSRegistry syncs one state's Secretary of State business entity extract
+// into fed_data.sos_entities. Each state is a separate dataset (sos_co,
+// sos_wa, ...) sharing the table, so states sync and fail independently.
+type SOSRegistry struct {
+	cfg     *config.Config
+	adapter sosAdapter
+}
+
+// Name implements Dataset.
+func (d *SOSRegistry) Name() string { return "sos_" + strings.ToLower(d.adapter.State()) }
+
+// Table implements Dataset.
+func (d *SOSRegistry) Table() string { return "fed_data.sos_entities" }
+
+// Phase implements Dataset.
+func (d *SOSRegistry) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *SOSRegistry) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *SOSRegistry) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// sourceURL returns the configured extract URL, falling back to the
+// adapter's default.
+func (d *SOSRegistry) sourceURL() string {
+	if d.cfg != nil {
+		if u := d.cfg.Fedsync.SOS.URLs[strings.ToLower(d.adapter.State())]; u != "" {
+			return u
+		}
+	}
+	return d.adapter.DefaultURL()
+}
+
+// Sync downloads the state extract and upserts one row per entity.
+func (d *SOSRegistry) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	url := d.sourceURL()
+	if url == "" {
+		return nil, eris.Errorf("%s: source URL required (fedsync.sos.urls.%s)", d.Name(), strings.ToLower(d.adapter.State()))
+	}
+
+	path := filepath.Join(tempDir, d.Name())
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrapf(err, "%s: download", d.Name())
+	}
+
+	state := strings.ToUpper(d.adapter.State())
+	statuses := make(map[string]int)
+	var total int64
+	batch := make([][]any, 0, sosBatchSize)
+	seen := make(map[string]struct{})
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      sosColumns,
+			ConflictKeys: []string{"state", "entity_id"},
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "%s: upsert", d.Name())
+		}
+		total += n
+		batch = batch[:0]
+		clear(seen)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	emit := func(e sosEntity) error {
+		row := sosRow(state, e, now)
+		if row == nil {
+			return nil
+		}
+		// A batch cannot touch the same key twice in one upsert.
+		if _, dup := seen[e.ID]; dup {
+			return nil
+		}
+		seen[e.ID] = struct{}{}
+		statuses[row[sosStatusCol].(string)]++
+		batch = append(batch, row)
+		if len(batch) >= sosBatchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	err := forEachExtractFile(path, d.adapter.Extensions(), func(r io.Reader) error {
+		return d.adapter.Parse(r, emit)
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: parse", d.Name())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Info("sos sync complete", zap.Int64("rows", total), zap.Any("statuses", statuses))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"state":    state,
+			"statuses": statuses,
+		},
+	}, nil
+}
+
+// sosStatusCol is the index of status in sosColumns.
+var sosStatusCol = slices.Index(sosColumns, "status")
+
+// sosRow maps an entity to sosColumns. Returns nil when the entity has no
+// ID or name.
+func sosRow(state string, e sosEntity, now time.Time) []any {
+	clean := func(s string) string { return sanitizeUTF8(strings.TrimSpace(s)) }
+	e.ID = strings.ToUpper(clean(e.ID))
+	e.Name = clean(e.Name)
+	if e.ID == "" || e.Name == "" {
+		return nil
+	}
+
+	principalState := strings.ToUpper(clean(e.State))
+	if len(principalState) != 2 {
+		principalState = ""
+	}
+	zip := clean(e.Zip)
+	if len(zip) > 10 {
+		zip = zip[:10]
+	}
+
+	row := make([]any, len(sosColumns))
+	row[0] = state
+	row[1] = e.ID
+	row[2] = e.Name
+	row[3] = nilIfEmpty(resolve.NormalizeName(e.Name))
+	row[4] = nilIfEmpty(clean(e.Type))
+	row[sosStatusCol] = normalizeSOSStatus(e.StatusRaw)
+	row[6] = nilIfEmpty(clean(e.StatusRaw))
+	row[7] = nilIfEmpty(clean(e.Jurisdiction))
+	row[8] = nilIfEmpty(clean(e.Agent))
+	row[9] = nilIfEmpty(clean(e.Street))
+	row[10] = nilIfEmpty(clean(e.City))
+	row[11] = nilIfEmpty(principalState)
+	row[12] = nilIfEmpty(zip)
+	row[13] = dateOrNil(e.Formed)
+	row[14] = dateOrNil(e.Dissolved)
+	row[15] = now
+	return row
+}
+
+// normalizeSOSStatus maps a state's status wording onto the shared
+// vocabulary. Negative statuses are checked first since "Inactive" and
+// "Not in Good Standing" contain their positive counterparts.
+func normalizeSOSStatus(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case s == "a":
+		return sosStatusActive
+	case s == "i":
+		return sosStatusInactive
+	case strings.Contains(s, "withdraw"):
+		return sosStatusWithdrawn
+	case strings.Contains(s, "dissol"), strings.Contains(s, "terminat"),
+		strings.Contains(s, "cancel"), strings.Contains(s, "revoked"),
+		strings.Contains(s, "merged"), strings.Contains(s, "dead"):
+		return sosStatusDissolved
+	case strings.Contains(s, "delinquent"), strings.Contains(s, "noncompliant"),
+		strings.Contains(s, "not in good standing"), strings.Contains(s, "suspended"):
+		return sosStatusDelinquent
+	case strings.Contains(s, "inactive"), strings.Contains(s, "expired"):
+		return sosStatusInactive
+	case strings.Contains(s, "active"), strings.Contains(s, "good standing"),
+		strings.Contains(s, "exists"), strings.Contains(s, "current"):
+		return sosStatusActive
+	default:
+		return sosStatusUnknown
+	}
+}
+
+// extractDate parses bulk extract dates, accepting ISO timestamps (Socrata
+// exports "2019-03-13T00:00:00.000") by their date part.
+func extractDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if t := parseDate(s); t != nil {
+		return t
+	}
+	if len(s) > 10 && s[10] == 'T' {
+		return parseDate(s[:10])
+	}
+	return nil
+}
+
+// forEachExtractFile calls fn for each ZIP member with one of exts, in
+// archive order, or once for the whole file when path is not a ZIP.
+func forEachExtractFile(path string, exts []string, fn func(io.Reader) error) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		// Not a ZIP; read the file directly.
+		file, err := os.Open(path) // #nosec G304 -- path under the sync temp dir
+		if err != nil {
+			return eris.Wrap(err, "open extract")
+		}
+		defer file.Close() //nolint:errcheck
+		return fn(file)
+	}
+	defer zr.Close() //nolint:errcheck
+
+	var matched int
+	for _, zf := range zr.File {
+		if !slices.ContainsFunc(exts, func(ext string) bool { return strings.EqualFold(filepath.Ext(zf.Name), ext) }) {
+			continue
+		}
+		matched++
+		rc, err := zf.Open()
+		if err != nil {
+			return eris.Wrapf(err, "open %s", zf.Name)
+		}
+		err = fn(rc)
+		_ = rc.Close()
+		if err != nil {
+			return eris.Wrapf(err, "read %s", zf.Name)
+		}
+	}
+	if matched == 0 {
+		return eris.Errorf("no %s file in zip", strings.Join(exts, "/"))
+	}
+	return nil
+}
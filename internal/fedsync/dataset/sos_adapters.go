@@ -0,0 +1,269 @@
+package dataset
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// sosAdapters returns the state adapters registered as sos_<state>
+// datasets, in registration order.
+func sosAdapters() []sosAdapter {
+	return []sosAdapter{
+		coloradoSOS(),
+		washingtonSOS(),
+		&floridaSOS{},
+		ohioSOS(),
+	}
+}
+
+// aliasColumns maps each field to the column indexes of its aliases present
+// in a CSV header, in alias order. An alias joining headers with "+" spans
+// several columns.
+type aliasColumns map[string][][]int
+
+// resolveAliases matches field aliases (normalized by normalizeCol) against
+// a header index.
+func resolveAliases(colIdx map[string]int, aliases map[string][]string) aliasColumns {
+	cols := make(aliasColumns, len(aliases))
+	for field, names := range aliases {
+		for _, alias := range names {
+			var idx []int
+			for _, name := range strings.Split(alias, "+") {
+				if i, ok := colIdx[name]; ok {
+					idx = append(idx, i)
+				}
+			}
+			if len(idx) > 0 {
+				cols[field] = append(cols[field], idx)
+			}
+		}
+	}
+	return cols
+}
+
+// require returns an error naming the first missing field.
+func (c aliasColumns) require(aliases map[string][]string, fields ...string) error {
+	for _, field := range fields {
+		if _, ok := c[field]; !ok {
+			return eris.Errorf("extract missing %s column (accepted headers: %s)",
+				field, strings.Join(aliases[field], ", "))
+		}
+	}
+	return nil
+}
+
+// get returns the field's value in record from the first alias with a value.
+// A multi-column alias joins its non-empty columns with spaces.
+func (c aliasColumns) get(record []string, field string) string {
+	for _, idx := range c[field] {
+		var parts []string
+		for _, i := range idx {
+			if i < len(record) {
+				if v := strings.TrimSpace(record[i]); v != "" {
+					parts = append(parts, v)
+				}
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, " ")
+		}
+	}
+	return ""
+}
+
+// sosCSVAdapter parses delimited extracts by header name. Each entity field
+// lists accepted headers (normalized by normalizeCol); per row the first
+// alias with a value wins.
+type sosCSVAdapter struct {
+	state         string
+	defaultURL    string
+	aliases       map[string][]string
+	defaultStatus string // used when the extract has no status column
+}
+
+// State implements sosAdapter.
+func (a *sosCSVAdapter) State() string { return a.state }
+
+// DefaultURL implements sosAdapter.
+func (a *sosCSVAdapter) DefaultURL() string { return a.defaultURL }
+
+// Extensions implements sosAdapter.
+func (a *sosCSVAdapter) Extensions() []string { return []string{".csv"} }
+
+// Parse implements sosAdapter.
+func (a *sosCSVAdapter) Parse(r io.Reader, emit func(sosEntity) error) error {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return eris.Wrap(err, "read header")
+	}
+	cols := resolveAliases(mapColumnsTrimBOM(header), a.aliases)
+	if err := cols.require(a.aliases, "id", "name"); err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return eris.Wrap(err, "read record")
+		}
+
+		status := cols.get(record, "status")
+		if _, ok := cols["status"]; !ok {
+			status = a.defaultStatus
+		}
+		if err := emit(sosEntity{
+			ID:           cols.get(record, "id"),
+			Name:         cols.get(record, "name"),
+			Type:         cols.get(record, "type"),
+			StatusRaw:    status,
+			Jurisdiction: cols.get(record, "jurisdiction"),
+			Agent:        cols.get(record, "agent"),
+			Street:       cols.get(record, "street"),
+			City:         cols.get(record, "city"),
+			State:        cols.get(record, "state"),
+			Zip:          cols.get(record, "zip"),
+			Formed:       extractDate(cols.get(record, "formed")),
+			Dissolved:    extractDate(cols.get(record, "dissolved")),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// coloradoSOS reads the Colorado Information Marketplace business entity
+// export (Socrata). The agent is an organization name or a person's
+// first and last name.
+func coloradoSOS() *sosCSVAdapter {
+	return &sosCSVAdapter{
+		state:      "CO",
+		defaultURL: "https://data.colorado.gov/api/views/4ykn-tg5h/rows.csv?accessType=DOWNLOAD",
+		aliases: map[string][]string{
+			"id":           {"entityid"},
+			"name":         {"entityname"},
+			"type":         {"entitytype"},
+			"status":       {"entitystatus"},
+			"jurisdiction": {"jurisdictonofformation", "jurisdictionofformation"},
+			"agent":        {"agentorganizationname", "agentfirstname+agentmiddlename+agentlastname"},
+			"street":       {"principaladdress1"},
+			"city":         {"principalcity"},
+			"state":        {"principalstate"},
+			"zip":          {"principalzipcode"},
+			"formed":       {"entityformdate"},
+		},
+	}
+}
+
+// washingtonSOS reads the Washington Corporations and Charities Filing
+// System business search export, keyed by UBI number.
+func washingtonSOS() *sosCSVAdapter {
+	return &sosCSVAdapter{
+		state: "WA",
+		aliases: map[string][]string{
+			"id":           {"ubi#", "ubi number", "ubi"},
+			"name":         {"business name", "entity name"},
+			"type":         {"business type", "entity type"},
+			"status":       {"status", "business status"},
+			"jurisdiction": {"jurisdiction", "state of incorporation"},
+			"agent":        {"registered agent name", "registered agent"},
+			"street":       {"principal office street address", "principal street address", "principal address"},
+			"city":         {"principal office city", "principal city"},
+			"state":        {"principal office state", "principal state"},
+			"zip":          {"principal office zip", "principal zip"},
+			"formed":       {"date of incorporation", "formation date", "formation/ registration date"},
+			"dissolved":    {"inactive date", "dissolution date"},
+		},
+	}
+}
+
+// ohioSOS reads the Ohio Secretary of State new business filings report.
+// The report only lists newly formed entities, so rows default to active.
+func ohioSOS() *sosCSVAdapter {
+	return &sosCSVAdapter{
+		state:         "OH",
+		defaultStatus: "Active",
+		aliases: map[string][]string{
+			"id":           {"charter num", "charter number", "document number"},
+			"name":         {"business name", "entity name"},
+			"type":         {"filing type", "entity type"},
+			"status":       {"status"},
+			"jurisdiction": {"jurisdiction"},
+			"agent":        {"agent name", "agent contact"},
+			"street":       {"address", "business address"},
+			"city":         {"city", "business location"},
+			"state":        {"state"},
+			"zip":          {"zip", "zip code"},
+			"formed":       {"effective date", "filing date", "tran date"},
+		},
+	}
+}
+
+// floridaSOS reads the Sunbiz corporate data file (cordata*.txt), a
+// fixed-width layout of 1440-byte records.
+type floridaSOS struct{}
+
+// Sunbiz cordata field offsets (0-based, end exclusive).
+var (
+	flDocNumber    = [2]int{0, 12}
+	flName         = [2]int{12, 204}
+	flStatus       = [2]int{204, 205}
+	flFilingType   = [2]int{205, 220}
+	flStreet       = [2]int{220, 262}
+	flCity         = [2]int{304, 332}
+	flState        = [2]int{332, 334}
+	flZip          = [2]int{334, 344}
+	flFileDate     = [2]int{472, 480}
+	flStateCountry = [2]int{503, 505}
+	flAgentName    = [2]int{544, 586}
+)
+
+// State implements sosAdapter.
+func (a *floridaSOS) State() string { return "FL" }
+
+// DefaultURL implements sosAdapter. Sunbiz publishes cordata over SFTP, so
+// the extract must be mirrored and configured.
+func (a *floridaSOS) DefaultURL() string { return "" }
+
+// Extensions implements sosAdapter.
+func (a *floridaSOS) Extensions() []string { return []string{".txt"} }
+
+// Parse implements sosAdapter.
+func (a *floridaSOS) Parse(r io.Reader, emit func(sosEntity) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 64*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		field := func(span [2]int) string {
+			if span[0] >= len(line) {
+				return ""
+			}
+			return strings.TrimSpace(line[span[0]:min(span[1], len(line))])
+		}
+		e := sosEntity{
+			ID:           field(flDocNumber),
+			Name:         field(flName),
+			Type:         field(flFilingType),
+			StatusRaw:    field(flStatus),
+			Jurisdiction: field(flStateCountry),
+			Agent:        field(flAgentName),
+			Street:       field(flStreet),
+			City:         field(flCity),
+			State:        field(flState),
+			Zip:          field(flZip),
+		}
+		if t, err := time.Parse("01022006", field(flFileDate)); err == nil {
+			e.Formed = &t
+		}
+		if err := emit(e); err != nil {
+			return err
+		}
+	}
+	return eris.Wrap(scanner.Err(), "scan records")
+}
@@ -0,0 +1,224 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const sosColoradoCSV = "\ufeffentityid,entityname,principaladdress1,principalcity,principalstate,principalzipcode,entitystatus,jurisdictonofformation,entitytype,agentfirstname,agentmiddlename,agentlastname,agentorganizationname,entityformdate\n" +
+	"20191234567,Front Range HVAC LLC,100 Main St,Denver,CO,80202,Good Standing,CO,DLLC,,,,Registered Agents Inc,2019-03-13T00:00:00.000\n" +
+	"19871000001,Summit Plumbing Co,1 Elm Ave,Boulder,CO,80301-1234,Administratively Dissolved,CO,DPC,Jane,Q,Doe,,03/02/1987\n" +
+	"20191234567,Front Range HVAC LLC,100 Main St,Denver,CO,80202,Good Standing,CO,DLLC,,,,Registered Agents Inc,2019-03-13T00:00:00.000\n" +
+	",Missing ID,,,,,,,,,,,,\n"
+
+// sosFloridaLine builds a cordata record with fields at their offsets.
+func sosFloridaLine(fields map[[2]int]string) string {
+	line := []byte(strings.Repeat(" ", 1440))
+	for span, v := range fields {
+		copy(line[span[0]:span[1]], v)
+	}
+	return string(line)
+}
+
+func TestSOSRegistry_Metadata(t *testing.T) {
+	names := make([]string, 0, len(sosAdapters()))
+	for _, a := range sosAdapters() {
+		d := &SOSRegistry{adapter: a}
+		names = append(names, d.Name())
+		assert.Equal(t, "fed_data.sos_entities", d.Table())
+		assert.Equal(t, Phase2, d.Phase())
+		assert.Equal(t, Monthly, d.Cadence())
+		assert.True(t, d.ShouldRun(time.Now(), nil))
+	}
+	assert.Equal(t, []string{"sos_co", "sos_wa", "sos_fl", "sos_oh"}, names)
+}
+
+func TestNormalizeSOSStatus(t *testing.T) {
+	tests := map[string]string{
+		"Good Standing":              sosStatusActive,
+		"Active":                     sosStatusActive,
+		"Exists":                     sosStatusActive,
+		"A":                          sosStatusActive,
+		"I":                          sosStatusInactive,
+		"Inactive":                   sosStatusInactive,
+		"Delinquent":                 sosStatusDelinquent,
+		"Noncompliant":               sosStatusDelinquent,
+		"Not in Good Standing":       sosStatusDelinquent,
+		"Administratively Dissolved": sosStatusDissolved,
+		"Terminated":                 sosStatusDissolved,
+		"Cancelled":                  sosStatusDissolved,
+		"Withdrawn":                  sosStatusWithdrawn,
+		"":                           sosStatusUnknown,
+		"Pending":                    sosStatusUnknown,
+	}
+	for raw, want := range tests {
+		assert.Equal(t, want, normalizeSOSStatus(raw), raw)
+	}
+}
+
+func TestSOSCSVAdapter_Colorado(t *testing.T) {
+	var got []sosEntity
+	err := coloradoSOS().Parse(strings.NewReader(sosColoradoCSV), func(e sosEntity) error {
+		got = append(got, e)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "20191234567", got[0].ID)
+	assert.Equal(t, "Registered Agents Inc", got[0].Agent)
+	assert.Equal(t, "Good Standing", got[0].StatusRaw)
+	require.NotNil(t, got[0].Formed)
+	assert.Equal(t, time.Date(2019, 3, 13, 0, 0, 0, 0, time.UTC), *got[0].Formed)
+
+	assert.Equal(t, "Jane Q Doe", got[1].Agent, "person agent falls back to joined name columns")
+	assert.Equal(t, time.Date(1987, 3, 2, 0, 0, 0, 0, time.UTC), *got[1].Formed)
+
+	err = coloradoSOS().Parse(strings.NewReader("name,city\nAcme,Denver\n"), func(sosEntity) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing id column")
+}
+
+func TestSOSCSVAdapter_OhioDefaultStatus(t *testing.T) {
+	csv := "CHARTER NUM,BUSINESS NAME,FILING TYPE,EFFECTIVE DATE,CITY,STATE,AGENT NAME\n" +
+		"5123456,Buckeye Roofing LLC,DOMESTIC LIMITED LIABILITY COMPANY,10/01/2026,COLUMBUS,OH,JOHN SMITH\n"
+	var got []sosEntity
+	require.NoError(t, ohioSOS().Parse(strings.NewReader(csv), func(e sosEntity) error {
+		got = append(got, e)
+		return nil
+	}))
+	require.Len(t, got, 1)
+	assert.Equal(t, "Active", got[0].StatusRaw)
+	assert.Equal(t, "JOHN SMITH", got[0].Agent)
+}
+
+func TestFloridaSOS_Parse(t *testing.T) {
+	data := sosFloridaLine(map[[2]int]string{
+		flDocNumber:    "L26000123456",
+		flName:         "GULF COAST POOLS LLC",
+		flStatus:       "A",
+		flFilingType:   "FLAL",
+		flStreet:       "200 BAY ST",
+		flCity:         "TAMPA",
+		flState:        "FL",
+		flZip:          "33602",
+		flFileDate:     "09152026",
+		flStateCountry: "FL",
+		flAgentName:    "SUNSHINE AGENTS INC",
+	}) + "\n" + "P12\n"
+
+	var got []sosEntity
+	require.NoError(t, (&floridaSOS{}).Parse(strings.NewReader(data), func(e sosEntity) error {
+		got = append(got, e)
+		return nil
+	}))
+	require.Len(t, got, 2)
+	assert.Equal(t, "L26000123456", got[0].ID)
+	assert.Equal(t, "GULF COAST POOLS LLC", got[0].Name)
+	assert.Equal(t, "A", got[0].StatusRaw)
+	assert.Equal(t, "SUNSHINE AGENTS INC", got[0].Agent)
+	assert.Equal(t, "33602", got[0].Zip)
+	assert.Equal(t, time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC), *got[0].Formed)
+
+	assert.Equal(t, "P12", got[1].ID, "short records parse without panicking")
+	assert.Empty(t, got[1].Name)
+}
+
+func TestSOSRow(t *testing.T) {
+	now := time.Now()
+	row := sosRow("CO", sosEntity{
+		ID:        " abc123 ",
+		Name:      "Front Range HVAC, L.L.C.",
+		StatusRaw: "Delinquent",
+		State:     "Colorado",
+		Zip:       "80202-1234-99",
+	}, now)
+	require.Len(t, row, len(sosColumns))
+	assert.Equal(t, "CO", row[0])
+	assert.Equal(t, "ABC123", row[1])
+	assert.NotNil(t, row[3])
+	assert.Equal(t, sosStatusDelinquent, row[sosStatusCol])
+	assert.Nil(t, row[11], "non-abbreviated state dropped")
+	assert.Equal(t, "80202-1234", row[12])
+	assert.Equal(t, now, row[len(row)-1])
+
+	assert.Nil(t, sosRow("CO", sosEntity{ID: "1"}, now))
+}
+
+func TestSOSRegistry_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://sos.test/co.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(sosColoradoCSV))
+		}).Return(int64(len(sosColoradoCSV)), nil)
+
+	// The duplicate entity row is dropped within the batch.
+	expectBulkUpsert(pool, "fed_data.sos_entities", sosColumns, 2)
+
+	d := &SOSRegistry{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{SOS: config.SOSConfig{
+			URLs: map[string]string{"co": "https://sos.test/co.csv"},
+		}}},
+		adapter: coloradoSOS(),
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, "CO", res.Metadata["state"])
+	assert.Equal(t, map[string]int{sosStatusActive: 1, sosStatusDissolved: 1}, res.Metadata["statuses"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestSOSRegistry_SyncFloridaZip(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	record := sosFloridaLine(map[[2]int]string{
+		flDocNumber: "L26000123456",
+		flName:      "GULF COAST POOLS LLC",
+		flStatus:    "I",
+	})
+	zipPath := createTestZipMulti(t, t.TempDir(), "cordata.zip", map[string]string{
+		"cordata0.txt": record + "\n",
+		"README.pdf":   "not data",
+	})
+	f := fetchermocks.NewMockFetcher(t)
+	mockDownloadToFile(t, f, zipPath)
+	expectBulkUpsert(pool, "fed_data.sos_entities", sosColumns, 1)
+
+	d := &SOSRegistry{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{SOS: config.SOSConfig{
+			URLs: map[string]string{"fl": "https://sos.test/cordata.zip"},
+		}}},
+		adapter: &floridaSOS{},
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.RowsSynced)
+	assert.Equal(t, map[string]int{sosStatusInactive: 1}, res.Metadata["statuses"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestSOSRegistry_SyncNoURL(t *testing.T) {
+	d := &SOSRegistry{cfg: &config.Config{}, adapter: washingtonSOS()}
+	_, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.sos.urls.wa")
+
+	assert.Equal(t, coloradoSOS().DefaultURL(), (&SOSRegistry{cfg: &config.Config{}, adapter: coloradoSOS()}).sourceURL())
+}
@@ -0,0 +1,38 @@
+package dataset
+
+import (
+	"context"
+	"os"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// downloadIfChanged downloads url to path unless src shows it unchanged:
+// the server answers 304 to the recorded ETag or Last-Modified, or the new
+// file hashes the same as the recorded one. An unchanged file's check is
+// recorded right away. A changed file's version is returned for the caller
+// to Record once its rows are loaded, so a failed load is retried on the
+// next sync. With a nil src the file is always downloaded.
+func downloadIfChanged(ctx context.Context, f fetcher.Fetcher, src *fedsync.DatasetSources, url, path string) (fetcher.FileVersion, bool, error) {
+	if src == nil {
+		_, err := f.DownloadToFile(ctx, url, path)
+		return fetcher.FileVersion{}, err == nil, err
+	}
+
+	prev, err := src.Get(ctx, url)
+	if err != nil {
+		return prev, false, err
+	}
+	v, changed, err := f.DownloadToFileIfChanged(ctx, url, path, prev)
+	if err != nil {
+		return v, false, err
+	}
+	if changed && !v.SameContent(prev) {
+		return v, true, nil
+	}
+	if changed {
+		_ = os.Remove(path)
+	}
+	return v, false, src.Record(ctx, url, v)
+}
@@ -0,0 +1,97 @@
+package dataset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const sourcesTestURL = "https://example.gov/file.zip"
+
+func expectSourceVersion(pm pgxmock.PgxPoolIface, sum string) {
+	etag, size := `"v1"`, int64(5)
+	pm.ExpectQuery("SELECT etag, last_modified, sha256, size_bytes FROM fed_data.source_files").
+		WithArgs("epa_echo", sourcesTestURL).
+		WillReturnRows(pgxmock.NewRows([]string{"etag", "last_modified", "sha256", "size_bytes"}).
+			AddRow(&etag, (*string)(nil), &sum, &size))
+}
+
+func TestDownloadIfChanged_NotModified(t *testing.T) {
+	pm, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pm.Close()
+
+	prev := fetcher.FileVersion{ETag: `"v1"`, SHA256: "abc", Size: 5}
+	expectSourceVersion(pm, "abc")
+	pm.ExpectExec("INSERT INTO fed_data.source_files").
+		WithArgs("epa_echo", sourcesTestURL, `"v1"`, "", "abc", int64(5)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFileIfChanged(mock.Anything, sourcesTestURL, "path", prev).Return(prev, false, nil)
+
+	src := fedsync.NewSourceFiles(pm).For("epa_echo", false)
+	_, changed, err := downloadIfChanged(context.Background(), f, src, sourcesTestURL, "path")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.NoError(t, pm.ExpectationsWereMet())
+}
+
+func TestDownloadIfChanged_SameHash(t *testing.T) {
+	pm, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pm.Close()
+
+	// The server ignored the validators but sent the same bytes.
+	expectSourceVersion(pm, "abc")
+	pm.ExpectExec("INSERT INTO fed_data.source_files").
+		WithArgs("epa_echo", sourcesTestURL, `"v2"`, "", "abc", int64(5)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFileIfChanged(mock.Anything, sourcesTestURL, "path", mock.Anything).
+		Return(fetcher.FileVersion{ETag: `"v2"`, SHA256: "abc", Size: 5}, true, nil)
+
+	src := fedsync.NewSourceFiles(pm).For("epa_echo", false)
+	_, changed, err := downloadIfChanged(context.Background(), f, src, sourcesTestURL, "path")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.NoError(t, pm.ExpectationsWereMet())
+}
+
+func TestDownloadIfChanged_Changed(t *testing.T) {
+	pm, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pm.Close()
+
+	// A changed file is not recorded until the caller has loaded it.
+	expectSourceVersion(pm, "abc")
+
+	next := fetcher.FileVersion{ETag: `"v2"`, SHA256: "def", Size: 9}
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFileIfChanged(mock.Anything, sourcesTestURL, "path", mock.Anything).Return(next, true, nil)
+
+	src := fedsync.NewSourceFiles(pm).For("epa_echo", false)
+	v, changed, err := downloadIfChanged(context.Background(), f, src, sourcesTestURL, "path")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, next, v)
+	assert.NoError(t, pm.ExpectationsWereMet())
+}
+
+func TestDownloadIfChanged_Untracked(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, sourcesTestURL, "path").Return(int64(5), nil)
+
+	_, changed, err := downloadIfChanged(context.Background(), f, nil, sourcesTestURL, "path")
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
@@ -12,19 +12,19 @@ import (
 func TestBuildSummary(t *testing.T) {
 	summary := BuildSummary(nil)
 
-	require.Equal(t, 42, summary.Total)
+	require.Equal(t, 81, summary.Total)
 	require.Equal(t, []Count{
-		{Key: "1", Count: 12},
-		{Key: "1b", Count: 6},
-		{Key: "2", Count: 15},
-		{Key: "3", Count: 9},
+		{Key: "1", Count: 14},
+		{Key: "1b", Count: 9},
+		{Key: "2", Count: 38},
+		{Key: "3", Count: 20},
 	}, summary.ByPhase)
 	require.Equal(t, []Count{
 		{Key: "daily", Count: 4},
-		{Key: "weekly", Count: 2},
-		{Key: "monthly", Count: 15},
-		{Key: "quarterly", Count: 7},
-		{Key: "annual", Count: 14},
+		{Key: "weekly", Count: 12},
+		{Key: "monthly", Count: 37},
+		{Key: "quarterly", Count: 9},
+		{Key: "annual", Count: 19},
 	}, summary.ByCadence)
 }
 
@@ -44,8 +44,8 @@ func TestRenderMarkdownSummary_MatchesDocs(t *testing.T) {
 func TestBuildCatalog(t *testing.T) {
 	catalog, err := BuildCatalog(nil)
 	require.NoError(t, err)
-	require.Equal(t, 42, catalog.Total)
-	require.Len(t, catalog.Datasets, 42)
+	require.Equal(t, 81, catalog.Total)
+	require.Len(t, catalog.Datasets, 81)
 	require.Equal(t, "County Business Patterns", catalog.Datasets[0].Label)
 	require.NotEmpty(t, catalog.Datasets[0].Description)
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/rotisserie/eris"
+	"github.com/sells-group/research-cli/internal/config"
 	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
 
@@ -24,7 +25,9 @@ const (
 )
 
 // SUSB implements the Census Statistics of US Businesses dataset.
-type SUSB struct{}
+type SUSB struct {
+	cfg *config.Config
+}
 
 // Name implements Dataset.
 func (d *SUSB) Name() string { return "susb" }
@@ -43,6 +46,9 @@ func (d *SUSB) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return AnnualAfter(now, lastSync, time.March)
 }
 
+// Mirrors implements Mirrored.
+func (d *SUSB) Mirrors() []fetcher.Mirror { return censusMirrors }
+
 // Sync fetches and loads Census SUSB business data.
 func (d *SUSB) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", "susb"))
@@ -57,33 +63,11 @@ func (d *SUSB) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDi
 		default:
 		}
 
-		// Census now publishes SUSB as plain TXT files (not ZIPs).
-		url := fmt.Sprintf("https://www2.census.gov/programs-surveys/susb/datasets/%d/us_state_6digitnaics_%d.txt", year, year)
-		log.Info("downloading SUSB data", zap.Int("year", year), zap.String("url", url))
-
-		txtPath := filepath.Join(tempDir, fmt.Sprintf("susb_%d.txt", year))
-		if _, err := f.DownloadToFile(ctx, url, txtPath); err != nil {
-			if strings.Contains(err.Error(), "status 404") {
-				log.Info("SUSB data not yet available, skipping", zap.Int("year", year))
-				continue
-			}
-			return nil, eris.Wrapf(err, "susb: download year %d", year)
-		}
-
-		file, err := os.Open(txtPath) // #nosec G304 -- path constructed from downloaded Census data in trusted temp directory
-		if err != nil {
-			return nil, eris.Wrapf(err, "susb: open year %d", year)
-		}
-		rows, err := d.parseCSV(ctx, pool, file, year)
-		_ = file.Close()
+		rows, _, err := d.syncYear(ctx, pool, f, tempDir, year, log)
 		if err != nil {
-			return nil, eris.Wrapf(err, "susb: process year %d", year)
+			return nil, err
 		}
-
 		totalRows += rows
-		log.Info("processed SUSB year", zap.Int("year", year), zap.Int64("rows", rows))
-
-		_ = os.Remove(txtPath)
 	}
 
 	return &SyncResult{
@@ -92,6 +76,58 @@ func (d *SUSB) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDi
 	}, nil
 }
 
+// PlanResync implements SliceResyncer: a year, optionally one state.
+func (d *SUSB) PlanResync(s Slice) (SlicePlan, error) {
+	return annualSlicePlan("susb", "fed_data.susb_data", "fips_state", s)
+}
+
+// SyncPeriod implements PeriodSyncer, loading one SUSB year.
+func (d *SUSB) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, period Period) (*SyncResult, error) {
+	year, err := requireYear("susb", period)
+	if err != nil {
+		return nil, err
+	}
+	rows, ok, err := d.syncYear(ctx, pool, f, tempDir, year, zap.L().With(zap.String("dataset", "susb")))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, eris.Errorf("susb: no data published for %d", year)
+	}
+	return &SyncResult{RowsSynced: rows, Metadata: map[string]any{"year": year}}, nil
+}
+
+// syncYear downloads and loads one SUSB year. ok is false when Census has
+// not published the year.
+func (d *SUSB) syncYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, year int, log *zap.Logger) (rows int64, ok bool, err error) {
+	// Census now publishes SUSB as plain TXT files (not ZIPs).
+	url := fmt.Sprintf("https://www2.census.gov/programs-surveys/susb/datasets/%d/us_state_6digitnaics_%d.txt", year, year)
+	log.Info("downloading SUSB data", zap.Int("year", year), zap.String("url", url))
+
+	txtPath := filepath.Join(tempDir, fmt.Sprintf("susb_%d.txt", year))
+	if _, err := f.DownloadToFile(ctx, url, txtPath); err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			log.Info("SUSB data not yet available, skipping", zap.Int("year", year))
+			return 0, false, nil
+		}
+		return 0, false, eris.Wrapf(err, "susb: download year %d", year)
+	}
+
+	file, err := os.Open(txtPath) // #nosec G304 -- path constructed from downloaded Census data in trusted temp directory
+	if err != nil {
+		return 0, false, eris.Wrapf(err, "susb: open year %d", year)
+	}
+	rows, err = d.parseCSV(ctx, pool, file, year)
+	_ = file.Close()
+	if err != nil {
+		return 0, false, eris.Wrapf(err, "susb: process year %d", year)
+	}
+
+	log.Info("processed SUSB year", zap.Int("year", year), zap.Int64("rows", rows))
+	_ = os.Remove(txtPath)
+	return rows, true, nil
+}
+
 func (d *SUSB) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int) (int64, error) {
 	reader := csv.NewReader(r)
 	reader.LazyQuotes = true
@@ -103,6 +139,10 @@ func (d *SUSB) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 	}
 
 	colIdx := mapColumns(header)
+	filter, err := naicsFilterFor(d.cfg, "susb")
+	if err != nil {
+		return 0, err
+	}
 
 	columns := []string{"year", "fips_state", "naics", "entrsizedscr", "firm", "estb", "empl", "payr"}
 	conflictKeys := []string{"year", "fips_state", "naics", "entrsizedscr"}
@@ -110,6 +150,7 @@ func (d *SUSB) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 	var batch [][]any
 	var totalRows int64
 	seen := make(map[string]int) // conflict key → batch index (dedup within batch)
+	slice := sliceFromContext(ctx)
 
 	for {
 		record, err := reader.Read()
@@ -121,12 +162,15 @@ func (d *SUSB) parseCSV(ctx context.Context, pool db.Pool, r io.Reader, year int
 		}
 
 		naics := trimQuotes(getCol(record, colIdx, "naics"))
-		if !transform.IsRelevantNAICS(naics) {
+		if !filter.Match(naics) {
 			continue
 		}
 		naics = transform.NormalizeNAICS(naics)
 
 		fipsState := transform.NormalizeFIPSState(trimQuotes(getCol(record, colIdx, "state")))
+		if !slice.contains(0, fipsState) {
+			continue
+		}
 		entrSize := trimQuotes(getCol(record, colIdx, "entrsizedscr"))
 
 		row := []any{
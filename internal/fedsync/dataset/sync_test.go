@@ -7,8 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
 )
 
@@ -48,6 +52,41 @@ func mockDownloadToFileZIP(t *testing.T, innerName, content string) func(context
 	}
 }
 
+// nestedStagingPool wraps a mock pool for code that upserts inside its own
+// transaction. Staging tables created there carry a per-call sequence
+// number; the wrapped CopyFrom strips it so expectBulkUpsert still matches.
+type nestedStagingPool struct{ pgxmock.PgxPoolIface }
+
+// Begin wraps the mock transaction.
+func (p nestedStagingPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.PgxPoolIface.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nestedStagingTx{tx}, nil
+}
+
+type nestedStagingTx struct{ pgx.Tx }
+
+var stagingSeqRe = regexp.MustCompile(`^_tmp_upsert_\d+_`)
+
+// Begin wraps the savepoint.
+func (t nestedStagingTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := t.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nestedStagingTx{tx}, nil
+}
+
+// CopyFrom drops the staging table's sequence number.
+func (t nestedStagingTx) CopyFrom(ctx context.Context, table pgx.Identifier, cols []string, src pgx.CopyFromSource) (int64, error) {
+	if len(table) == 1 {
+		table = pgx.Identifier{stagingSeqRe.ReplaceAllString(table[0], "_tmp_upsert_")}
+	}
+	return t.Tx.CopyFrom(ctx, table, cols, src)
+}
+
 // expectBulkUpsert sets up pgxmock expectations for a db.BulkUpsert call.
 // BulkUpsert does: Begin -> CREATE TEMP TABLE -> COPY -> DELETE (dedup) -> INSERT ON CONFLICT -> Commit.
 func expectBulkUpsert(m pgxmock.PgxPoolIface, table string, cols []string, n int64) {
@@ -301,46 +340,17 @@ func TestECI_Sync(t *testing.T) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	f := fetchermocks.NewMockFetcher(t)
-
-	blsResp := blsSeriesResponse{
-		Status: "REQUEST_SUCCEEDED",
-	}
-	blsResp.Results.Series = []struct {
-		SeriesID string `json:"seriesID"`
-		Data     []struct {
-			Year   string `json:"year"`
-			Period string `json:"period"`
-			Value  string `json:"value"`
-		} `json:"data"`
-	}{
-		{
-			SeriesID: "CIU1010000000000A",
-			Data: []struct {
-				Year   string `json:"year"`
-				Period string `json:"period"`
-				Value  string `json:"value"`
-			}{
-				{Year: "2024", Period: "Q01", Value: "154.2"},
-				{Year: "2024", Period: "Q02", Value: "155.1"},
-			},
-		},
-	}
-
-	// ECI fetches per series (5 total). Return data for first, errors for rest.
-	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
-		return strings.Contains(url, "CIU1010000000000A")
-	})).Return(jsonBody(t, blsResp), nil)
-
-	for i := 0; i < 4; i++ {
-		f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("skip")).Maybe()
-	}
+	// All 5 ECI series go in one query; only the first returns data.
+	srv := newBLSServer(t, map[string]string{
+		"CIU1010000000000A": blsSeriesJSON("CIU1010000000000A", "2024", "Q01", "154.2", "2024", "Q02", "155.1"),
+	})
 
 	expectBulkUpsert(pool, "fed_data.eci_data", eciCols, 2)
 
-	ds := &ECI{cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "test-key"}}}
-	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
+	ds := &ECI{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "test-key"}}}
+	result, err := ds.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
 	require.NoError(t, err)
+	assert.Len(t, srv.Requests(), 1)
 	assert.Equal(t, int64(2), result.RowsSynced)
 }
 
@@ -484,43 +494,18 @@ func TestCPSLAUS_Sync(t *testing.T) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	f := fetchermocks.NewMockFetcher(t)
-
-	blsResp := blsSeriesResponse{Status: "REQUEST_SUCCEEDED"}
-	blsResp.Results.Series = []struct {
-		SeriesID string `json:"seriesID"`
-		Data     []struct {
-			Year   string `json:"year"`
-			Period string `json:"period"`
-			Value  string `json:"value"`
-		} `json:"data"`
-	}{
-		{
-			SeriesID: "LASST060000000000003",
-			Data: []struct {
-				Year   string `json:"year"`
-				Period string `json:"period"`
-				Value  string `json:"value"`
-			}{
-				{Year: "2024", Period: "M06", Value: "4.2"},
-			},
-		},
-	}
-
-	// CPSLAUS iterates over 10 series. Return data for first, errors for rest.
-	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
-		return strings.Contains(url, "LASST060000000000003")
-	})).Return(jsonBody(t, blsResp), nil)
-
-	for i := 0; i < 9; i++ {
-		f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("skip")).Maybe()
-	}
+	// All 10 LAUS series go in one query; only the first returns data.
+	srv := newBLSServer(t, map[string]string{
+		"LASST060000000000003": blsSeriesJSON("LASST060000000000003", "2024", "M06", "4.2"),
+	})
 
 	expectBulkUpsert(pool, "fed_data.laus_data", lausCols, 1)
 
-	ds := &CPSLAUS{cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "test-key"}}}
-	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
+	ds := &CPSLAUS{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "test-key"}}}
+	result, err := ds.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
 	require.NoError(t, err)
+	require.Len(t, srv.Requests(), 1)
+	assert.Equal(t, lausSeries, srv.Requests()[0].SeriesID)
 	assert.Equal(t, int64(1), result.RowsSynced)
 }
 
@@ -921,6 +906,38 @@ func TestXBRLFacts_Sync_QueryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "query CIKs")
 }
 
+func TestXBRLFacts_Sync_ResumesFromCheckpoint(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+
+	cikRows := pgxmock.NewRows([]string{"cik"}).
+		AddRow("1234567").
+		AddRow("9876543")
+	pool.ExpectQuery("SELECT DISTINCT cik FROM fed_data.entity_xref .* ORDER BY cik").WillReturnRows(cikRows)
+	pool.ExpectQuery("SELECT last_key, started_at FROM fed_data.sync_checkpoints").
+		WithArgs("xbrl_facts").
+		WillReturnRows(pgxmock.NewRows([]string{"last_key", "started_at"}).AddRow("1234567", time.Now()))
+
+	// Only the CIK after the checkpoint is fetched.
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "CIK0009876543")
+	})).Return(nil, errors.New("not found")).Once()
+
+	pool.ExpectExec("DELETE FROM fed_data.sync_checkpoints").
+		WithArgs("xbrl_facts").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	ds := &XBRLFacts{cfg: &config.Config{}}
+	ds.SetCheckpoint(fedsync.NewCheckpoints(pool).For("xbrl_facts"))
+	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.RowsSynced)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
 // --- Entity Xref: Sync ---
 
 func TestEntityXref_Sync(t *testing.T) {
@@ -930,19 +947,27 @@ func TestEntityXref_Sync(t *testing.T) {
 
 	f := fetchermocks.NewMockFetcher(t)
 
-	// Stage 1: XrefBuilder.Build() — CRD-CIK cross-reference (2 passes)
+	// Stage 1: XrefBuilder.Build() — CRD-CIK cross-reference (4 passes)
+	// after refreshing the ADV and EDGAR address keys.
+	expectAddressKeyRefresh(pool, 2)
 	pool.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("INSERT", 50))
+	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
 	pool.ExpectExec("INSERT INTO fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("INSERT", 30))
 
 	// Stage 2: MultiXrefBuilder.Build() — multi-dataset cross-reference
+	// after refreshing the keys of all 13 address key sources.
+	expectAddressKeyRefresh(pool, 13)
 	pool.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
-	// 85 match passes, each returning 2 rows.
-	for range 85 {
+	// 93 match passes, each returning 2 rows.
+	for range 93 {
 		pool.ExpectExec("INSERT INTO fed_data.entity_xref_multi").
 			WillReturnResult(pgxmock.NewResult("INSERT", 2))
 	}
@@ -950,10 +975,10 @@ func TestEntityXref_Sync(t *testing.T) {
 	ds := &EntityXref{}
 	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
 	require.NoError(t, err)
-	// 80 from CRD-CIK + 170 from multi (85 passes × 2 rows)
-	assert.Equal(t, int64(250), result.RowsSynced)
+	// 80 from CRD-CIK + 186 from multi (93 passes × 2 rows)
+	assert.Equal(t, int64(266), result.RowsSynced)
 	assert.Equal(t, int64(80), result.Metadata["crd_cik_matched"])
-	assert.Equal(t, int64(170), result.Metadata["multi_matched"])
+	assert.Equal(t, int64(186), result.Metadata["multi_matched"])
 }
 
 func TestEntityXref_Sync_TruncateError(t *testing.T) {
@@ -963,6 +988,7 @@ func TestEntityXref_Sync_TruncateError(t *testing.T) {
 
 	f := fetchermocks.NewMockFetcher(t)
 
+	expectAddressKeyRefresh(pool, 2)
 	pool.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnError(errors.New("permission denied"))
 
@@ -972,6 +998,15 @@ func TestEntityXref_Sync_TruncateError(t *testing.T) {
 	assert.Contains(t, err.Error(), "truncate")
 }
 
+// expectAddressKeyRefresh expects n resolve.RefreshAddressKeys source
+// queries, each finding no stale keys.
+func expectAddressKeyRefresh(m pgxmock.PgxPoolIface, n int) {
+	for range n {
+		m.ExpectQuery("LEFT JOIN fed_data.address_keys k").
+			WillReturnRows(pgxmock.NewRows([]string{"source_id", "street", "zip"}))
+	}
+}
+
 // --- Holdings 13F: parseHoldingsXML ---
 
 func TestHoldings13F_ParseHoldingsXML(t *testing.T) {
@@ -1017,14 +1052,14 @@ func TestHoldings13F_ParseHoldingsXML(t *testing.T) {
 
 	r := strings.NewReader(xmlData)
 
-	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call"}
+	holdingsCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call", "accession_number"}
 	expectBulkUpsert(pool, "fed_data.f13_holdings", holdingsCols, 2)
 
 	ds := &Holdings13F{}
 	period := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 	log := zap.NewNop()
 
-	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, log)
+	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, "0001234567-25-000001", log)
 	require.NoError(t, err)
 	// 2 rows (bad CUSIP filtered out)
 	assert.Len(t, rows, 2)
@@ -1057,7 +1092,7 @@ func TestHoldings13F_ParseHoldingsXML_Empty(t *testing.T) {
 	period := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 	log := zap.NewNop()
 
-	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, log)
+	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, "0001234567-25-000001", log)
 	require.NoError(t, err)
 	assert.Empty(t, rows)
 }
@@ -1197,20 +1232,6 @@ func TestNewRegistry_AllDatasets(t *testing.T) {
 	}
 }
 
-// --- getColIdx ---
-
-func TestGetColIdx(t *testing.T) {
-	colIdx := map[string]int{"A": 0, "B": 1}
-	record := []string{"val_a", "val_b"}
-
-	assert.Equal(t, "val_a", getColIdx(record, colIdx, "A"))
-	assert.Equal(t, "val_b", getColIdx(record, colIdx, "B"))
-	assert.Equal(t, "", getColIdx(record, colIdx, "C"))
-
-	colIdx["D"] = 99
-	assert.Equal(t, "", getColIdx(record, colIdx, "D"))
-}
-
 // =====================================================================
 // Additional coverage tests — schedule edge cases
 // =====================================================================
@@ -1496,17 +1517,17 @@ func TestCPSLAUS_Sync_AllSeriesFail(t *testing.T) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	f := fetchermocks.NewMockFetcher(t)
-
-	f.EXPECT().Download(mock.Anything, mock.Anything).
-		Return(nil, errors.New("skip")).Times(len(lausSeries))
-
-	expectBulkUpsert(pool, "fed_data.laus_data", lausCols, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
 
-	ds := &CPSLAUS{cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "key"}}}
-	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
-	require.NoError(t, err)
-	assert.Equal(t, int64(0), result.RowsSynced)
+	// Nothing is loaded and the sync fails so the series are retried.
+	ds := &CPSLAUS{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "key"}}}
+	_, err = ds.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "series batches failed")
+	assert.NoError(t, pool.ExpectationsWereMet())
 }
 
 // =====================================================================
@@ -1518,17 +1539,17 @@ func TestECI_Sync_AllSeriesFail(t *testing.T) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	f := fetchermocks.NewMockFetcher(t)
-
-	f.EXPECT().Download(mock.Anything, mock.Anything).
-		Return(nil, errors.New("skip")).Times(len(eciSeries))
-
-	expectBulkUpsert(pool, "fed_data.eci_data", eciCols, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
 
-	ds := &ECI{cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "key"}}}
-	result, err := ds.Sync(context.Background(), pool, f, t.TempDir())
-	require.NoError(t, err)
-	assert.Equal(t, int64(0), result.RowsSynced)
+	// Nothing is loaded and the sync fails so the series are retried.
+	ds := &ECI{blsURL: srv.URL, cfg: &config.Config{Fedsync: config.FedsyncConfig{BLSKey: "key"}}}
+	_, err = ds.Sync(context.Background(), pool, newBLSFetcher(), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "series batches failed")
+	assert.NoError(t, pool.ExpectationsWereMet())
 }
 
 // =====================================================================
@@ -2020,7 +2041,7 @@ func TestHoldings13F_ParseHoldingsXML_UpsertError(t *testing.T) {
 	period := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 	log := zap.NewNop()
 
-	_, err = ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, log)
+	_, err = ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, "0001234567-25-000001", log)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "upsert")
 }
@@ -2137,7 +2158,7 @@ func TestEDGARSubmissions_Sync_MidBatchFlush(t *testing.T) {
 		},
 	)
 
-	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges"}
+	entityCols := []string{"cik", "entity_name", "entity_type", "sic", "sic_description", "state_of_inc", "state_of_business", "ein", "tickers", "exchanges", "business_street", "business_city", "business_zip"}
 	filingCols := []string{"accession_number", "cik", "form_type", "filing_date", "primary_doc", "primary_doc_desc", "items", "size", "is_xbrl", "is_inline_xbrl"}
 
 	pool.MatchExpectationsInOrder(false)
@@ -2372,14 +2393,14 @@ func TestHoldings13F_ParseHoldingsXML_MidBatchFlush(t *testing.T) {
 
 	r := strings.NewReader(sb.String())
 
-	holdCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call"}
+	holdCols := []string{"cik", "period", "cusip", "issuer_name", "class_title", "value", "shares", "sh_prn_type", "put_call", "accession_number"}
 	expectBulkUpsert(pool, "fed_data.f13_holdings", holdCols, 5000)
 	expectBulkUpsert(pool, "fed_data.f13_holdings", holdCols, 2)
 
 	ds := &Holdings13F{}
 	period := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 	log := zap.NewNop()
-	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, log)
+	rows, err := ds.parseHoldingsXML(context.Background(), pool, r, "1234567", &period, "0001234567-25-000001", log)
 	require.NoError(t, err)
 	assert.Len(t, rows, 5002)
 	assert.NoError(t, pool.ExpectationsWereMet())
@@ -0,0 +1,377 @@
+package dataset
+
+import (
+	"context"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const uccBatchSize = 10000
+
+// Normalized UCC filing types.
+const (
+	uccFilingInitial      = "initial"
+	uccFilingAmendment    = "amendment"
+	uccFilingContinuation = "continuation"
+	uccFilingTermination  = "termination"
+	uccFilingAssignment   = "assignment"
+)
+
+// Collateral classes assigned by classifyCollateral.
+const (
+	uccCollateralAllAssets   = "all_assets"
+	uccCollateralEquipment   = "equipment"
+	uccCollateralInventory   = "inventory"
+	uccCollateralReceivables = "receivables"
+	uccCollateralVehicles    = "vehicles"
+	uccCollateralFixtures    = "fixtures"
+	uccCollateralFarm        = "farm_products"
+	uccCollateralSecurities  = "securities"
+	uccCollateralOther       = "other"
+)
+
+// uccColumns defines the target DB columns in upsert order.
+var uccColumns = []string{
+	"state", "filing_number", "debtor_name", "debtor_name_norm",
+	"debtor_city", "debtor_state", "debtor_zip",
+	"secured_party_name", "secured_party_norm",
+	"filing_type", "filing_date", "lapse_date", "original_filing_number",
+	"collateral_type", "collateral_description", "updated_at",
+}
+
+// uccCollateralCol is the index of collateral_type in uccColumns.
+var uccCollateralCol = slices.Index(uccColumns, "collateral_type")
+
+// uccCommonAliases lists the headers accepted for each field across state
+// extracts (normalized by normalizeCol). State adapters add their own.
+var uccCommonAliases = map[string][]string{
+	"filing_number":  {"filing number", "file number", "filing_number", "file_number", "document number"},
+	"debtor":         {"debtor name", "debtor organization name", "debtor_name", "debtor", "debtor first name+debtor middle name+debtor last name"},
+	"debtor_city":    {"debtor city", "debtor_city"},
+	"debtor_state":   {"debtor state", "debtor_state"},
+	"debtor_zip":     {"debtor zip", "debtor zip code", "debtor_zip"},
+	"secured_party":  {"secured party name", "secured party organization name", "secured_party_name", "secured party"},
+	"filing_type":    {"filing type", "record type", "filing_type", "document type"},
+	"filing_date":    {"filing date", "file date", "filing_date", "date filed"},
+	"lapse_date":     {"lapse date", "lapse_date", "expiration date"},
+	"original":       {"original filing number", "initial filing number", "original_filing_number", "original file number"},
+	"collateral":     {"collateral type", "collateral_type", "collateral code"},
+	"collateral_txt": {"collateral description", "collateral", "collateral_description"},
+}
+
+// uccAdapters returns the state extracts registered as ucc_<state>
+// datasets, in registration order.
+func uccAdapters() []*uccCSVAdapter {
+	return []*uccCSVAdapter{
+		{state: "CO"},
+		{state: "FL", aliases: map[string][]string{
+			"filing_number": {"doc number", "doc_number"},
+			"original":      {"orig doc number"},
+		}},
+		{state: "TX", aliases: map[string][]string{
+			"filing_number": {"file_num"},
+			"debtor":        {"debtor_org_name"},
+			"secured_party": {"sp_org_name"},
+		}},
+		{state: "WA", aliases: map[string][]string{
+			"debtor":        {"debtor organization", "debtor individual"},
+			"secured_party": {"secured party organization", "secured party individual"},
+		}},
+	}
+}
+
+// uccFiling is one financing statement record (one debtor) read from an
+// extract.
+type uccFiling struct {
+	Number        string
+	Debtor        string
+	DebtorCity    string
+	DebtorState   string
+	DebtorZip     string
+	SecuredParty  string
+	TypeRaw       string
+	Filed         *time.Time
+	Lapses        *time.Time
+	Original      string
+	CollateralRaw string
+	Collateral    string
+}
+
+// uccCSVAdapter parses a state's delimited UCC extract by header name.
+// State aliases are tried before the common ones.
+type uccCSVAdapter struct {
+	state   string
+	aliases map[string][]string
+}
+
+// fieldAliases merges the state's aliases ahead of uccCommonAliases.
+func (a *uccCSVAdapter) fieldAliases() map[string][]string {
+	out := maps.Clone(uccCommonAliases)
+	for field, names := range a.aliases {
+		out[field] = append(slices.Clone(names), uccCommonAliases[field]...)
+	}
+	return out
+}
+
+// Parse streams filings from one extract file to emit.
+func (a *uccCSVAdapter) Parse(r io.Reader, emit func(uccFiling) error) error {
+	reader := newLenientCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return eris.Wrap(err, "read header")
+	}
+	aliases := a.fieldAliases()
+	cols := resolveAliases(mapColumnsTrimBOM(header), aliases)
+	if err := cols.require(aliases, "filing_number", "debtor"); err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return eris.Wrap(err, "read record")
+		}
+		if err := emit(uccFiling{
+			Number:        cols.get(record, "filing_number"),
+			Debtor:        cols.get(record, "debtor"),
+			DebtorCity:    cols.get(record, "debtor_city"),
+			DebtorState:   cols.get(record, "debtor_state"),
+			DebtorZip:     cols.get(record, "debtor_zip"),
+			SecuredParty:  cols.get(record, "secured_party"),
+			TypeRaw:       cols.get(record, "filing_type"),
+			Filed:         extractDate(cols.get(record, "filing_date")),
+			Lapses:        extractDate(cols.get(record, "lapse_date")),
+			Original:      cols.get(record, "original"),
+			CollateralRaw: cols.get(record, "collateral"),
+			Collateral:    cols.get(record, "collateral_txt"),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// UCCFilings syncs one state's UCC financing statement extract into
+// fed_data.ucc_filings. Each state is a separate dataset (ucc_co, ucc_tx,
+// ...) sharing the table, so states sync and fail independently.
+type UCCFilings struct {
+	cfg     *config.Config
+	adapter *uccCSVAdapter
+}
+
+// Name implements Dataset.
+func (d *UCCFilings) Name() string { return "ucc_" + strings.ToLower(d.adapter.state) }
+
+// Table implements Dataset.
+func (d *UCCFilings) Table() string { return "fed_data.ucc_filings" }
+
+// Phase implements Dataset.
+func (d *UCCFilings) Phase() Phase { return Phase2 }
+
+// Cadence implements Dataset.
+func (d *UCCFilings) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *UCCFilings) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// sourceURL returns the configured extract URL.
+func (d *UCCFilings) sourceURL() string {
+	if d.cfg == nil {
+		return ""
+	}
+	return d.cfg.Fedsync.UCC.URLs[strings.ToLower(d.adapter.state)]
+}
+
+// Sync downloads the state extract and upserts one row per filing debtor.
+func (d *UCCFilings) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()))
+
+	url := d.sourceURL()
+	if url == "" {
+		return nil, eris.Errorf("%s: source URL required (fedsync.ucc.urls.%s)", d.Name(), strings.ToLower(d.adapter.state))
+	}
+
+	path := filepath.Join(tempDir, d.Name())
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrapf(err, "%s: download", d.Name())
+	}
+
+	state := strings.ToUpper(d.adapter.state)
+	collateral := make(map[string]int)
+	var total int64
+	batch := make([][]any, 0, uccBatchSize)
+	seen := make(map[[2]string]struct{})
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        d.Table(),
+			Columns:      uccColumns,
+			ConflictKeys: []string{"state", "filing_number", "debtor_name"},
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "%s: upsert", d.Name())
+		}
+		total += n
+		batch = batch[:0]
+		clear(seen)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	emit := func(u uccFiling) error {
+		row := uccRow(state, u, now)
+		if row == nil {
+			return nil
+		}
+		// A batch cannot touch the same key twice in one upsert.
+		key := [2]string{row[1].(string), row[2].(string)}
+		if _, dup := seen[key]; dup {
+			return nil
+		}
+		seen[key] = struct{}{}
+		collateral[row[uccCollateralCol].(string)]++
+		batch = append(batch, row)
+		if len(batch) >= uccBatchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	err := forEachExtractFile(path, []string{".csv", ".txt"}, func(r io.Reader) error {
+		return d.adapter.Parse(r, emit)
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: parse", d.Name())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Info("ucc sync complete", zap.Int64("rows", total), zap.Any("collateral", collateral))
+	return &SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"state":      state,
+			"collateral": collateral,
+		},
+	}, nil
+}
+
+// uccRow maps a filing to uccColumns. Returns nil when the filing has no
+// number or debtor.
+func uccRow(state string, u uccFiling, now time.Time) []any {
+	clean := func(s string) string { return sanitizeUTF8(strings.TrimSpace(s)) }
+	number := strings.ToUpper(clean(u.Number))
+	debtor := clean(u.Debtor)
+	if number == "" || debtor == "" {
+		return nil
+	}
+
+	debtorState := strings.ToUpper(clean(u.DebtorState))
+	if len(debtorState) != 2 {
+		debtorState = ""
+	}
+	zip := clean(u.DebtorZip)
+	if len(zip) > 10 {
+		zip = zip[:10]
+	}
+	securedParty := clean(u.SecuredParty)
+	description := clean(u.Collateral)
+
+	row := make([]any, len(uccColumns))
+	row[0] = state
+	row[1] = number
+	row[2] = debtor
+	row[3] = nilIfEmpty(resolve.NormalizeName(debtor))
+	row[4] = nilIfEmpty(clean(u.DebtorCity))
+	row[5] = nilIfEmpty(debtorState)
+	row[6] = nilIfEmpty(zip)
+	row[7] = nilIfEmpty(securedParty)
+	row[8] = nilIfEmpty(resolve.NormalizeName(securedParty))
+	row[9] = nilIfEmpty(normalizeUCCFilingType(u.TypeRaw))
+	row[10] = dateOrNil(u.Filed)
+	row[11] = dateOrNil(u.Lapses)
+	row[12] = nilIfEmpty(strings.ToUpper(clean(u.Original)))
+	row[uccCollateralCol] = classifyCollateral(u.CollateralRaw, description)
+	row[14] = nilIfEmpty(description)
+	row[15] = now
+	return row
+}
+
+// normalizeUCCFilingType maps a filing office's record type wording (or
+// UCC form code) onto the shared vocabulary. Returns "" when unrecognized.
+func normalizeUCCFilingType(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case s == "":
+		return ""
+	case strings.Contains(s, "terminat"), s == "ucc3t":
+		return uccFilingTermination
+	case strings.Contains(s, "continu"), s == "ucc3c":
+		return uccFilingContinuation
+	case strings.Contains(s, "assign"):
+		return uccFilingAssignment
+	case strings.Contains(s, "amend"), s == "ucc3", s == "ucc-3":
+		return uccFilingAmendment
+	case strings.Contains(s, "initial"), strings.Contains(s, "original"),
+		strings.Contains(s, "financing statement"), s == "ucc1", s == "ucc-1":
+		return uccFilingInitial
+	default:
+		return ""
+	}
+}
+
+// uccCollateralKeywords maps collateral wording to classes in precedence
+// order; blanket liens are checked first since they often enumerate the
+// specific classes too.
+var uccCollateralKeywords = []struct {
+	class    string
+	keywords []string
+}{
+	{uccCollateralAllAssets, []string{"all assets", "all of debtor's assets", "all personal property", "all of the debtor's assets", "blanket"}},
+	{uccCollateralVehicles, []string{"vehicle", "truck", "trailer", "vin "}},
+	{uccCollateralFarm, []string{"crops", "livestock", "farm products", "cattle"}},
+	{uccCollateralFixtures, []string{"fixture"}},
+	{uccCollateralSecurities, []string{"securities", "investment property", "stock"}},
+	{uccCollateralReceivables, []string{"accounts receivable", "receivables", "accounts", "chattel paper"}},
+	{uccCollateralInventory, []string{"inventory"}},
+	{uccCollateralEquipment, []string{"equipment", "machinery", "copier", "forklift"}},
+}
+
+// classifyCollateral assigns a collateral class from the extract's type
+// code when it names one, else from keywords in the description.
+func classifyCollateral(raw, description string) string {
+	for _, text := range []string{raw, description} {
+		s := strings.ToLower(text)
+		if s == "" {
+			continue
+		}
+		for _, c := range uccCollateralKeywords {
+			for _, kw := range c.keywords {
+				if strings.Contains(s, kw) {
+					return c.class
+				}
+			}
+		}
+	}
+	return uccCollateralOther
+}
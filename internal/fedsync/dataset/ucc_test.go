@@ -0,0 +1,150 @@
+package dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+const uccTexasCSV = "\ufeffFILE_NUM,Filing Type,Filing Date,Lapse Date,DEBTOR_ORG_NAME,Debtor City,Debtor State,Debtor Zip,SP_ORG_NAME,Collateral Description\n" +
+	"26-0012345678,UCC1,2026-01-15,2031-01-15,Lone Star HVAC LLC,Austin,TX,78701,Frost Bank,All assets of the debtor now owned or hereafter acquired\n" +
+	"26-0012345678,UCC1,2026-01-15,2031-01-15,Lone Star Holdings Inc,Austin,TX,78701,Frost Bank,All assets of the debtor now owned or hereafter acquired\n" +
+	"26-0012345678,UCC1,2026-01-15,2031-01-15,Lone Star HVAC LLC,Austin,TX,78701,Frost Bank,All assets of the debtor now owned or hereafter acquired\n" +
+	"26-0099999999,Amendment,03/02/2026,,Bayou Plumbing Co,Houston,Texas,77002-1234-55,Caterpillar Financial,One (1) CAT 320 excavator and related equipment\n" +
+	",UCC1,2026-01-15,,No Number Co,,,,,\n"
+
+func TestUCCFilings_Metadata(t *testing.T) {
+	names := make([]string, 0, len(uccAdapters()))
+	for _, a := range uccAdapters() {
+		d := &UCCFilings{adapter: a}
+		names = append(names, d.Name())
+		assert.Equal(t, "fed_data.ucc_filings", d.Table())
+		assert.Equal(t, Phase2, d.Phase())
+		assert.Equal(t, Monthly, d.Cadence())
+		assert.True(t, d.ShouldRun(time.Now(), nil))
+	}
+	assert.Equal(t, []string{"ucc_co", "ucc_fl", "ucc_tx", "ucc_wa"}, names)
+}
+
+func TestNormalizeUCCFilingType(t *testing.T) {
+	tests := map[string]string{
+		"UCC1":                        uccFilingInitial,
+		"Initial Financing Statement": uccFilingInitial,
+		"UCC3":                        uccFilingAmendment,
+		"Amendment":                   uccFilingAmendment,
+		"Continuation":                uccFilingContinuation,
+		"Termination":                 uccFilingTermination,
+		"Assignment":                  uccFilingAssignment,
+		"":                            "",
+		"Lien":                        "",
+	}
+	for raw, want := range tests {
+		assert.Equal(t, want, normalizeUCCFilingType(raw), raw)
+	}
+}
+
+func TestClassifyCollateral(t *testing.T) {
+	tests := []struct {
+		raw, description, want string
+	}{
+		{"", "All assets of debtor including inventory and equipment", uccCollateralAllAssets},
+		{"", "2024 Ford F-150 VIN 1FTFW1E5", uccCollateralVehicles},
+		{"", "All inventory now owned", uccCollateralInventory},
+		{"", "Accounts receivable and chattel paper", uccCollateralReceivables},
+		{"", "One Konica copier", uccCollateralEquipment},
+		{"", "All crops grown on the premises", uccCollateralFarm},
+		{"Equipment", "All assets", uccCollateralEquipment},
+		{"", "", uccCollateralOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, classifyCollateral(tt.raw, tt.description), tt.description)
+	}
+}
+
+func TestUCCCSVAdapter_Parse(t *testing.T) {
+	tx := uccAdapters()[2]
+	var got []uccFiling
+	require.NoError(t, tx.Parse(strings.NewReader(uccTexasCSV), func(u uccFiling) error {
+		got = append(got, u)
+		return nil
+	}))
+	require.Len(t, got, 5)
+	assert.Equal(t, "26-0012345678", got[0].Number)
+	assert.Equal(t, "Lone Star HVAC LLC", got[0].Debtor)
+	assert.Equal(t, "Frost Bank", got[0].SecuredParty)
+	require.NotNil(t, got[0].Lapses)
+	assert.Equal(t, time.Date(2031, 1, 15, 0, 0, 0, 0, time.UTC), *got[0].Lapses)
+
+	err := tx.Parse(strings.NewReader("name,city\nAcme,Austin\n"), func(uccFiling) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing filing_number column")
+}
+
+func TestUCCRow(t *testing.T) {
+	now := time.Now()
+	row := uccRow("TX", uccFiling{
+		Number:       " 26-0099999999 ",
+		Debtor:       "Bayou Plumbing Co",
+		DebtorState:  "Texas",
+		DebtorZip:    "77002-1234-55",
+		SecuredParty: "Caterpillar Financial",
+		TypeRaw:      "Amendment",
+		Original:     "26-0012345678",
+		Collateral:   "One CAT 320 excavator and related equipment",
+	}, now)
+	require.Len(t, row, len(uccColumns))
+	assert.Equal(t, "26-0099999999", row[1])
+	assert.NotNil(t, row[3])
+	assert.Nil(t, row[5], "non-abbreviated state dropped")
+	assert.Equal(t, "77002-1234", row[6])
+	assert.Equal(t, uccFilingAmendment, row[9])
+	assert.Equal(t, "26-0012345678", row[12])
+	assert.Equal(t, uccCollateralEquipment, row[uccCollateralCol])
+	assert.Equal(t, now, row[len(row)-1])
+
+	assert.Nil(t, uccRow("TX", uccFiling{Number: "1"}, now))
+}
+
+func TestUCCFilings_Sync(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().DownloadToFile(mock.Anything, "https://ucc.test/tx.csv", mock.Anything).
+		Run(func(_ context.Context, _ string, path string) {
+			writeTestFixture(t, path, []byte(uccTexasCSV))
+		}).Return(int64(len(uccTexasCSV)), nil)
+
+	// Both debtors on the first filing are kept; the repeated row is dropped.
+	expectBulkUpsert(pool, "fed_data.ucc_filings", uccColumns, 3)
+
+	d := &UCCFilings{
+		cfg: &config.Config{Fedsync: config.FedsyncConfig{UCC: config.UCCConfig{
+			URLs: map[string]string{"tx": "https://ucc.test/tx.csv"},
+		}}},
+		adapter: uccAdapters()[2],
+	}
+	res, err := d.Sync(context.Background(), pool, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res.RowsSynced)
+	assert.Equal(t, "TX", res.Metadata["state"])
+	assert.Equal(t, map[string]int{uccCollateralAllAssets: 2, uccCollateralEquipment: 1}, res.Metadata["collateral"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestUCCFilings_SyncNoURL(t *testing.T) {
+	d := &UCCFilings{cfg: &config.Config{}, adapter: uccAdapters()[0]}
+	_, err := d.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fedsync.ucc.urls.co")
+}
@@ -0,0 +1,152 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+// RuleKind identifies a post-sync validation check.
+type RuleKind string
+
+// RowCountDelta and following constants are the supported rule kinds.
+const (
+	// RowCountDelta fails when RowsSynced drops by more than Threshold (a
+	// fraction) from the previous successful full run, which is how a
+	// silently truncated download shows up. Partial runs are not checked.
+	RowCountDelta RuleKind = "row_count_delta"
+	// NullRate fails when more than Threshold (a fraction) of Table's rows
+	// have a NULL Column.
+	NullRate RuleKind = "null_rate"
+	// Reference fails when more than Threshold rows of Table have a Column
+	// value missing from RefTable.RefColumn.
+	Reference RuleKind = "reference"
+)
+
+// ValidationRule is one check the engine runs after a dataset syncs.
+type ValidationRule struct {
+	Kind      RuleKind
+	Table     string
+	Column    string
+	RefTable  string
+	RefColumn string
+	Threshold float64
+}
+
+// Name identifies the rule in fed_data.sync_validations.
+func (r ValidationRule) Name() string {
+	switch r.Kind {
+	case NullRate:
+		return fmt.Sprintf("%s:%s.%s", r.Kind, r.Table, r.Column)
+	case Reference:
+		return fmt.Sprintf("%s:%s.%s->%s.%s", r.Kind, r.Table, r.Column, r.RefTable, r.RefColumn)
+	default:
+		return string(r.Kind)
+	}
+}
+
+// ValidationOpts enables post-sync validation in the Engine. With Block set,
+// a failed rule marks the sync failed, so its watermark, post-sync hook, and
+// the entity_xref and opportunity rebuilds it would trigger are held back.
+type ValidationOpts struct {
+	Block bool
+}
+
+// validationRules lists the checks run after each dataset syncs. Datasets
+// without an entry are not validated.
+var validationRules = map[string][]ValidationRule{
+	"adv_part1": {
+		{Kind: RowCountDelta, Threshold: 0.5},
+		{Kind: NullRate, Table: "fed_data.adv_firms", Column: "state", Threshold: 0.2},
+		{Kind: Reference, Table: "fed_data.adv_filings", Column: "crd_number", RefTable: "fed_data.adv_firms", RefColumn: "crd_number"},
+	},
+	"cbp": {
+		{Kind: RowCountDelta, Threshold: 0.5},
+		{Kind: NullRate, Table: "fed_data.cbp_data", Column: "emp", Threshold: 0.5},
+	},
+	"edgar_submissions": {
+		{Kind: RowCountDelta, Threshold: 0.5},
+		{Kind: Reference, Table: "fed_data.edgar_filings", Column: "cik", RefTable: "fed_data.edgar_entities", RefColumn: "cik"},
+	},
+	"eo_bmf": {
+		{Kind: RowCountDelta, Threshold: 0.5},
+		{Kind: NullRate, Table: "fed_data.eo_bmf", Column: "state", Threshold: 0.05},
+	},
+}
+
+// ValidationRulesFor returns the rules run after the named dataset syncs.
+func ValidationRulesFor(name string) []ValidationRule {
+	return validationRules[name]
+}
+
+// RunValidation evaluates rules against the freshly synced tables. prevRows
+// is the previous successful full run's row count, or nil if there was
+// none. A rule whose query errors is reported as failed.
+func RunValidation(ctx context.Context, pool db.Pool, rules []ValidationRule, result *SyncResult, prevRows *int64) []fedsync.ValidationResult {
+	out := make([]fedsync.ValidationResult, 0, len(rules))
+	for _, r := range rules {
+		res := fedsync.ValidationResult{Rule: r.Name(), Threshold: r.Threshold, Passed: true}
+		switch r.Kind {
+		case RowCountDelta:
+			if result.Partial {
+				res.Message = "partial run"
+				break
+			}
+			if prevRows == nil || *prevRows <= 0 {
+				res.Message = "no previous run"
+				break
+			}
+			res.Observed = 1 - float64(result.RowsSynced)/float64(*prevRows)
+			if res.Observed > r.Threshold {
+				res.Passed = false
+				res.Message = fmt.Sprintf("rows dropped from %d to %d", *prevRows, result.RowsSynced)
+			}
+		case NullRate:
+			var total, nulls int64
+			if err := pool.QueryRow(ctx, fmt.Sprintf(
+				`SELECT COUNT(*), COUNT(*) FILTER (WHERE %s IS NULL) FROM %s`,
+				pgx.Identifier{r.Column}.Sanitize(), qualified(r.Table),
+			)).Scan(&total, &nulls); err != nil {
+				res.Passed, res.Message = false, err.Error()
+				break
+			}
+			if total > 0 {
+				res.Observed = float64(nulls) / float64(total)
+			}
+			if res.Observed > r.Threshold {
+				res.Passed = false
+				res.Message = fmt.Sprintf("%d of %d rows NULL", nulls, total)
+			}
+		case Reference:
+			var orphans int64
+			if err := pool.QueryRow(ctx, fmt.Sprintf(
+				`SELECT COUNT(*) FROM %s a WHERE a.%s IS NOT NULL
+				 AND NOT EXISTS (SELECT 1 FROM %s r WHERE r.%s = a.%s)`,
+				qualified(r.Table), pgx.Identifier{r.Column}.Sanitize(),
+				qualified(r.RefTable), pgx.Identifier{r.RefColumn}.Sanitize(), pgx.Identifier{r.Column}.Sanitize(),
+			)).Scan(&orphans); err != nil {
+				res.Passed, res.Message = false, err.Error()
+				break
+			}
+			res.Observed = float64(orphans)
+			if res.Observed > r.Threshold {
+				res.Passed = false
+				res.Message = fmt.Sprintf("%d rows reference missing %s", orphans, r.RefTable)
+			}
+		default:
+			res.Passed, res.Message = false, "unknown rule kind"
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// qualified quotes a schema-qualified table name.
+func qualified(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
@@ -0,0 +1,82 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationRule_Name(t *testing.T) {
+	assert.Equal(t, "row_count_delta", ValidationRule{Kind: RowCountDelta}.Name())
+	assert.Equal(t, "null_rate:fed_data.adv_firms.state",
+		ValidationRule{Kind: NullRate, Table: "fed_data.adv_firms", Column: "state"}.Name())
+	assert.Equal(t, "reference:fed_data.adv_filings.crd_number->fed_data.adv_firms.crd_number",
+		ValidationRule{Kind: Reference, Table: "fed_data.adv_filings", Column: "crd_number",
+			RefTable: "fed_data.adv_firms", RefColumn: "crd_number"}.Name())
+}
+
+func TestValidationRulesFor(t *testing.T) {
+	reg := NewRegistry(nil)
+	for name, rules := range validationRules {
+		_, err := reg.Get(name)
+		assert.NoError(t, err, "rules for unregistered dataset %s", name)
+		assert.NotEmpty(t, rules)
+	}
+	assert.Empty(t, ValidationRulesFor("test_ds"))
+}
+
+func TestRunValidation_RowCountDelta(t *testing.T) {
+	rules := []ValidationRule{{Kind: RowCountDelta, Threshold: 0.5}}
+	prev := int64(1000)
+
+	res := RunValidation(context.Background(), nil, rules, &SyncResult{RowsSynced: 600}, &prev)
+	require.Len(t, res, 1)
+	assert.True(t, res[0].Passed)
+	assert.InDelta(t, 0.4, res[0].Observed, 1e-9)
+
+	res = RunValidation(context.Background(), nil, rules, &SyncResult{RowsSynced: 100}, &prev)
+	assert.False(t, res[0].Passed)
+	assert.Equal(t, "rows dropped from 1000 to 100", res[0].Message)
+
+	res = RunValidation(context.Background(), nil, rules, &SyncResult{RowsSynced: 0}, nil)
+	assert.True(t, res[0].Passed, "first run has nothing to compare against")
+
+	res = RunValidation(context.Background(), nil, rules, &SyncResult{RowsSynced: 100, Partial: true}, &prev)
+	assert.True(t, res[0].Passed, "incremental and resumed runs are not compared")
+	assert.Equal(t, "partial run", res[0].Message)
+}
+
+func TestRunValidation_NullRateAndReference(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery(`SELECT COUNT\(\*\), COUNT\(\*\) FILTER \(WHERE "state" IS NULL\) FROM "fed_data"."adv_firms"`).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "nulls"}).AddRow(int64(100), int64(30)))
+	pool.ExpectQuery(`SELECT COUNT\(\*\) FROM "fed_data"."adv_filings" a WHERE a."crd_number" IS NOT NULL\s+AND NOT EXISTS \(SELECT 1 FROM "fed_data"."adv_firms" r WHERE r."crd_number" = a."crd_number"\)`).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(0)))
+	pool.ExpectQuery(`FROM "fed_data"."edgar_filings"`).
+		WillReturnError(errors.New("relation does not exist"))
+
+	res := RunValidation(context.Background(), pool, []ValidationRule{
+		{Kind: NullRate, Table: "fed_data.adv_firms", Column: "state", Threshold: 0.2},
+		{Kind: Reference, Table: "fed_data.adv_filings", Column: "crd_number", RefTable: "fed_data.adv_firms", RefColumn: "crd_number"},
+		{Kind: Reference, Table: "fed_data.edgar_filings", Column: "cik", RefTable: "fed_data.edgar_entities", RefColumn: "cik"},
+	}, &SyncResult{}, nil)
+	require.Len(t, res, 3)
+
+	assert.False(t, res[0].Passed)
+	assert.InDelta(t, 0.3, res[0].Observed, 1e-9)
+	assert.Equal(t, "30 of 100 rows NULL", res[0].Message)
+
+	assert.True(t, res[1].Passed)
+	assert.Zero(t, res[1].Observed)
+
+	assert.False(t, res[2].Passed, "query errors fail the rule")
+	assert.Contains(t, res[2].Message, "relation does not exist")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
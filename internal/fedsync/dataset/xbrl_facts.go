@@ -3,20 +3,41 @@ package dataset
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/rotisserie/eris"
 	"github.com/sells-group/research-cli/internal/db"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 
 	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/xbrl"
 	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
+const (
+	xbrlFactsBatchSize = 5000
+
+	// xbrlFactsChunkCIKs is how many CIKs are fetched and committed
+	// between checkpoints.
+	xbrlFactsChunkCIKs = 200
+
+	defaultXBRLWorkers = 4
+)
+
+// secAPILimiter holds every data.sec.gov request from the XBRL datasets to
+// SEC's fair-access limit of 10 requests per second, however many workers
+// are fetching.
+var secAPILimiter = rate.NewLimiter(10, 1)
+
 // XBRLFacts syncs EDGAR Company Facts JSON-LD → XBRL financial data.
+// Progress is checkpointed per chunk of CIKs.
 type XBRLFacts struct {
 	cfg *config.Config
+	cp  *fedsync.Checkpoint
 }
 
 // Name implements Dataset.
@@ -36,14 +57,19 @@ func (d *XBRLFacts) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return DailySchedule(now, lastSync)
 }
 
-// Sync fetches and loads EDGAR XBRL company facts data.
+// SetCheckpoint implements Resumable.
+func (d *XBRLFacts) SetCheckpoint(cp *fedsync.Checkpoint) { d.cp = cp }
+
+// Sync fetches and loads EDGAR XBRL company facts data. CIKs are fetched
+// in chunks by a bounded worker pool; each chunk is flushed and
+// checkpointed before the next starts.
 func (d *XBRLFacts) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*SyncResult, error) {
 	log := zap.L().With(zap.String("dataset", d.Name()))
 	log.Info("syncing XBRL company facts")
 
 	// Get CIKs from entity_xref that have linked EDGAR entities.
 	cikRows, err := pool.Query(ctx,
-		"SELECT DISTINCT cik FROM fed_data.entity_xref WHERE cik IS NOT NULL AND cik != '' LIMIT 1000")
+		"SELECT DISTINCT cik FROM fed_data.entity_xref WHERE cik IS NOT NULL AND cik != '' ORDER BY cik LIMIT 1000")
 	if err != nil {
 		return nil, eris.Wrap(err, "xbrl_facts: query CIKs")
 	}
@@ -58,76 +84,105 @@ func (d *XBRLFacts) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _
 		ciks = append(ciks, cik)
 	}
 
-	log.Info("fetching company facts", zap.Int("cik_count", len(ciks)))
+	resumeAfter, err := d.cp.Load(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "xbrl_facts")
+	}
+	if i := slices.Index(ciks, resumeAfter); resumeAfter != "" && i >= 0 {
+		log.Info("resuming from checkpoint", zap.String("after_cik", resumeAfter), zap.Int("skipped", i+1))
+		ciks = ciks[i+1:]
+	}
 
-	var totalRows int64
-	const batchSize = 5000
+	workers := d.workers()
+	log.Info("fetching company facts", zap.Int("cik_count", len(ciks)), zap.Int("workers", workers))
 
-	var rows [][]any
+	w := newBatchWriter(pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      []string{"cik", "fact_name", "period_end", "value", "unit", "form", "fy", "accession"},
+		ConflictKeys: []string{"cik", "fact_name", "period_end"},
+	}, streamBatchRows(d.cfg, xbrlFactsBatchSize))
 
-	for _, cik := range ciks {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+	for start := 0; start < len(ciks); start += xbrlFactsChunkCIKs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
-
-		// Pad CIK to 10 digits for URL.
-		paddedCIK := fmt.Sprintf("%010s", cik)
-		url := fmt.Sprintf("https://data.sec.gov/api/xbrl/companyfacts/CIK%s.json", paddedCIK)
-
-		body, err := f.Download(ctx, url)
-		if err != nil {
-			log.Debug("skip CIK", zap.String("cik", cik), zap.Error(err))
-			continue
+		chunk := ciks[start:min(start+xbrlFactsChunkCIKs, len(ciks))]
+
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(workers)
+		for _, cik := range chunk {
+			g.Go(func() error {
+				rows, err := d.fetchFacts(gctx, f, cik)
+				if err != nil {
+					if gctx.Err() != nil {
+						return gctx.Err()
+					}
+					log.Debug("skip CIK", zap.String("cik", cik), zap.Error(err))
+					return nil
+				}
+				return eris.Wrap(w.Add(gctx, rows...), "xbrl_facts: upsert")
+			})
 		}
-
-		facts, err := xbrl.ParseCompanyFacts(body)
-		_ = body.Close()
-		if err != nil {
-			log.Debug("skip malformed facts", zap.String("cik", cik), zap.Error(err))
-			continue
+		if err := g.Wait(); err != nil {
+			return nil, err
 		}
-
-		extracted := xbrl.ExtractTargetFacts(facts, xbrl.TargetFacts)
-		for _, ef := range extracted {
-			rows = append(rows, []any{
-				cik,
-				ef.FactName,
-				ef.Period,
-				ef.Value,
-				ef.Unit,
-				ef.Form,
-				int16(ef.FY), // #nosec G115 -- fiscal year value (e.g. 2020-2030), fits in int16
-				ef.Filed,
-			})
+		if err := w.Flush(ctx); err != nil {
+			return nil, eris.Wrap(err, "xbrl_facts: upsert")
 		}
-
-		if len(rows) >= batchSize {
-			n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-				Table:        d.Table(),
-				Columns:      []string{"cik", "fact_name", "period_end", "value", "unit", "form", "fy", "accession"},
-				ConflictKeys: []string{"cik", "fact_name", "period_end"},
-			}, rows)
-			if err != nil {
-				return nil, eris.Wrap(err, "xbrl_facts: upsert")
+		if start+len(chunk) < len(ciks) {
+			if err := d.cp.Save(ctx, chunk[len(chunk)-1]); err != nil {
+				return nil, eris.Wrap(err, "xbrl_facts")
 			}
-			totalRows += n
-			rows = rows[:0]
 		}
 	}
 
-	if len(rows) > 0 {
-		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table:        d.Table(),
-			Columns:      []string{"cik", "fact_name", "period_end", "value", "unit", "form", "fy", "accession"},
-			ConflictKeys: []string{"cik", "fact_name", "period_end"},
-		}, rows)
-		if err != nil {
-			return nil, eris.Wrap(err, "xbrl_facts: upsert final")
-		}
-		totalRows += n
+	if err := d.cp.Clear(ctx); err != nil {
+		return nil, eris.Wrap(err, "xbrl_facts")
+	}
+
+	return &SyncResult{RowsSynced: w.Total()}, nil
+}
+
+// fetchFacts downloads one CIK's company facts and returns its target
+// facts as xbrl_facts rows.
+func (d *XBRLFacts) fetchFacts(ctx context.Context, f fetcher.Fetcher, cik string) ([][]any, error) {
+	if err := secAPILimiter.Wait(ctx); err != nil {
+		return nil, err
 	}
 
-	return &SyncResult{RowsSynced: totalRows}, nil
+	// Pad CIK to 10 digits for URL.
+	url := fmt.Sprintf("https://data.sec.gov/api/xbrl/companyfacts/CIK%010s.json", cik)
+	body, err := f.Download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	facts, err := xbrl.ParseCompanyFacts(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	extracted := xbrl.ExtractTargetFacts(facts, xbrl.TargetFacts)
+	rows := make([][]any, 0, len(extracted))
+	for _, ef := range extracted {
+		rows = append(rows, []any{
+			cik,
+			ef.FactName,
+			ef.Period,
+			ef.Value,
+			ef.Unit,
+			ef.Form,
+			int16(ef.FY), // #nosec G115 -- fiscal year value (e.g. 2020-2030), fits in int16
+			ef.Filed,
+		})
+	}
+	return rows, nil
+}
+
+// workers returns fedsync.xbrl.workers, or the default when unset.
+func (d *XBRLFacts) workers() int {
+	if d.cfg == nil || d.cfg.Fedsync.XBRL.Workers <= 0 {
+		return defaultXBRLWorkers
+	}
+	return d.cfg.Fedsync.XBRL.Workers
 }
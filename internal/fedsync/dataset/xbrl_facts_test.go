@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/sells-group/research-cli/internal/config"
 )
 
 func TestXBRLFacts_Metadata(t *testing.T) {
@@ -34,3 +36,11 @@ func TestXBRLFacts_ShouldRun(t *testing.T) {
 		assert.True(t, d.ShouldRun(now, &last))
 	})
 }
+
+func TestXBRLFacts_Workers(t *testing.T) {
+	assert.Equal(t, defaultXBRLWorkers, (&XBRLFacts{}).workers())
+
+	cfg := &config.Config{}
+	cfg.Fedsync.XBRL.Workers = 8
+	assert.Equal(t, 8, (&XBRLFacts{cfg: cfg}).workers())
+}
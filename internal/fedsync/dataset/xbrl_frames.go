@@ -0,0 +1,164 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/fedsync/xbrl"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const xbrlFramesURL = "https://data.sec.gov/api/xbrl/frames/%s/%s/%s/%s.json"
+
+// XBRLFrames syncs the EDGAR XBRL frames API: point-in-time cross-sections
+// of one concept across every filer (e.g. Assets for all filers at the end
+// of 2024) for each concept in fedsync.xbrl.frames.
+type XBRLFrames struct {
+	cfg *config.Config
+}
+
+// Name implements Dataset.
+func (d *XBRLFrames) Name() string { return "xbrl_frames" }
+
+// Table implements Dataset.
+func (d *XBRLFrames) Table() string { return "fed_data.xbrl_frames" }
+
+// Phase implements Dataset.
+func (d *XBRLFrames) Phase() Phase { return Phase3 }
+
+// Cadence implements Dataset.
+func (d *XBRLFrames) Cadence() Cadence { return Monthly }
+
+// ShouldRun implements Dataset.
+func (d *XBRLFrames) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return MonthlySchedule(now, lastSync)
+}
+
+// Sync loads the previous calendar year's frames. Filers keep adding to a
+// year's frames through annual report season, so each monthly run reloads
+// it.
+func (d *XBRLFrames) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*SyncResult, error) {
+	return d.SyncPeriod(ctx, pool, f, tempDir, Period{Year: time.Now().UTC().Year() - 1})
+}
+
+// SyncPeriod implements PeriodSyncer. A year loads annual frames (CY2024,
+// or the year-end instant CY2024Q4I); a quarter loads quarterly frames
+// (CY2024Q2, CY2024Q2I). Frames SEC has not published are skipped.
+func (d *XBRLFrames) SyncPeriod(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string, period Period) (*SyncResult, error) {
+	log := zap.L().With(zap.String("dataset", d.Name()), zap.Stringer("period", period))
+
+	end := Period{Year: period.Year, Quarter: 4}
+	if period.Quarter != 0 {
+		end = period
+	}
+	if !end.QuarterEnd().Before(time.Now().UTC()) {
+		return nil, eris.Errorf("xbrl_frames: period %s has not ended", period)
+	}
+
+	concepts, err := d.concepts()
+	if err != nil {
+		return nil, err
+	}
+
+	w := newBatchWriter(pool, db.UpsertConfig{
+		Table:        d.Table(),
+		Columns:      []string{"taxonomy", "tag", "unit", "frame", "cik", "entity_name", "loc", "period_start", "period_end", "value", "accession"},
+		ConflictKeys: []string{"taxonomy", "tag", "unit", "frame", "cik"},
+	}, streamBatchRows(d.cfg, defaultStreamBatchRows))
+
+	var loaded, missing int
+	for _, c := range concepts {
+		frame := c.Period(period.Year, period.Quarter)
+		n, err := d.loadFrame(ctx, f, w, c, frame)
+		if code, ok := fetcher.StatusCode(err); ok && code == http.StatusNotFound {
+			log.Info("frame not published, skipping", zap.Stringer("concept", c), zap.String("frame", frame))
+			missing++
+			continue
+		}
+		if err != nil {
+			return nil, eris.Wrapf(err, "xbrl_frames: %s %s", c, frame)
+		}
+		log.Debug("loaded frame", zap.Stringer("concept", c), zap.String("frame", frame), zap.Int("facts", n))
+		loaded++
+	}
+	if err := w.Flush(ctx); err != nil {
+		return nil, eris.Wrap(err, "xbrl_frames: upsert")
+	}
+
+	log.Info("xbrl_frames sync complete", zap.Int("frames", loaded), zap.Int("missing", missing), zap.Int64("rows", w.Total()))
+	return &SyncResult{
+		RowsSynced: w.Total(),
+		Metadata: map[string]any{
+			"period":         period.String(),
+			"frames":         loaded,
+			"missing_frames": missing,
+		},
+	}, nil
+}
+
+// loadFrame downloads one frame and buffers its facts, returning how many
+// it kept.
+func (d *XBRLFrames) loadFrame(ctx context.Context, f fetcher.Fetcher, w *batchWriter, c xbrl.FrameConcept, frame string) (int, error) {
+	if err := secAPILimiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	body, err := f.Download(ctx, fmt.Sprintf(xbrlFramesURL, c.Taxonomy, c.Tag, c.Unit, frame))
+	if err != nil {
+		return 0, err
+	}
+	fr, err := xbrl.ParseFrame(body)
+	_ = body.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, fact := range fr.Data {
+		periodEnd := parseDate(fact.End)
+		if fact.CIK == 0 || periodEnd == nil {
+			fedsync.Reject(ctx, "missing_cik_or_end", fact)
+			continue
+		}
+		if err := w.Add(ctx, []any{
+			c.Taxonomy,
+			c.Tag,
+			c.Unit,
+			frame,
+			fmt.Sprintf("%010d", fact.CIK),
+			fact.EntityName,
+			fact.Loc,
+			parseDate(fact.Start),
+			periodEnd,
+			fact.Val,
+			fact.Accn,
+		}); err != nil {
+			return n, eris.Wrap(err, "upsert")
+		}
+		n++
+	}
+	return n, nil
+}
+
+// concepts parses fedsync.xbrl.frames.
+func (d *XBRLFrames) concepts() ([]xbrl.FrameConcept, error) {
+	if d.cfg == nil || len(d.cfg.Fedsync.XBRL.Frames) == 0 {
+		return nil, eris.New("xbrl_frames: no concepts configured (fedsync.xbrl.frames)")
+	}
+	out := make([]xbrl.FrameConcept, 0, len(d.cfg.Fedsync.XBRL.Frames))
+	for _, s := range d.cfg.Fedsync.XBRL.Frames {
+		c, err := xbrl.ParseFrameConcept(s)
+		if err != nil {
+			return nil, eris.Wrap(err, "xbrl_frames: fedsync.xbrl.frames")
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
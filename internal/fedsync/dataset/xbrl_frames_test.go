@@ -0,0 +1,119 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	fetchermocks "github.com/sells-group/research-cli/internal/fetcher/mocks"
+)
+
+var xbrlFrameCols = []string{"taxonomy", "tag", "unit", "frame", "cik", "entity_name", "loc", "period_start", "period_end", "value", "accession"}
+
+func xbrlFramesConfig(frames ...string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Fedsync.XBRL.Frames = frames
+	return cfg
+}
+
+func TestXBRLFrames_Metadata(t *testing.T) {
+	d := &XBRLFrames{}
+	assert.Equal(t, "xbrl_frames", d.Name())
+	assert.Equal(t, "fed_data.xbrl_frames", d.Table())
+	assert.Equal(t, Phase3, d.Phase())
+	assert.Equal(t, Monthly, d.Cadence())
+	assert.True(t, d.ShouldRun(time.Now(), nil))
+
+	var _ PeriodSyncer = d
+}
+
+func TestXBRLFrames_SyncPeriod(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, "https://data.sec.gov/api/xbrl/frames/us-gaap/Assets/USD/CY2024Q4I.json").
+		Return(jsonBody(t, map[string]any{
+			"taxonomy": "us-gaap", "tag": "Assets", "ccp": "CY2024Q4I", "uom": "USD",
+			"data": []map[string]any{
+				{"accn": "0000320193-25-000008", "cik": 320193, "entityName": "Apple Inc.", "loc": "US-CA", "end": "2024-12-28", "val": 344085000000},
+				{"accn": "0000000000-25-000001", "cik": 0, "entityName": "No CIK", "end": "2024-12-31", "val": 1},
+			},
+		}), nil)
+	f.EXPECT().Download(mock.Anything, "https://data.sec.gov/api/xbrl/frames/us-gaap/Revenues/USD/CY2024.json").
+		Return(nil, errors.New("unexpected status 404 from data.sec.gov"))
+
+	expectBulkUpsert(pool, "fed_data.xbrl_frames", xbrlFrameCols, 1)
+
+	ds := &XBRLFrames{cfg: xbrlFramesConfig("us-gaap/Assets/USD/instant", "us-gaap/Revenues/USD/duration")}
+	res, err := ds.SyncPeriod(context.Background(), pool, f, t.TempDir(), Period{Year: 2024})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.RowsSynced)
+	assert.Equal(t, 1, res.Metadata["frames"])
+	assert.Equal(t, 1, res.Metadata["missing_frames"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestXBRLFrames_SyncPeriod_Quarter(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.MatchedBy(func(url string) bool {
+		return strings.HasSuffix(url, "/us-gaap/NetIncomeLoss/USD/CY2024Q2.json")
+	})).Return(jsonBody(t, map[string]any{
+		"data": []map[string]any{
+			{"accn": "0001", "cik": 1750, "entityName": "AAR CORP.", "start": "2024-04-01", "end": "2024-06-30", "val": 1000},
+		},
+	}), nil)
+
+	expectBulkUpsert(pool, "fed_data.xbrl_frames", xbrlFrameCols, 1)
+
+	ds := &XBRLFrames{cfg: xbrlFramesConfig("us-gaap/NetIncomeLoss/USD/duration")}
+	res, err := ds.SyncPeriod(context.Background(), pool, f, t.TempDir(), Period{Year: 2024, Quarter: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.RowsSynced)
+	assert.Equal(t, "2024Q2", res.Metadata["period"])
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestXBRLFrames_SyncPeriod_DownloadError(t *testing.T) {
+	f := fetchermocks.NewMockFetcher(t)
+	f.EXPECT().Download(mock.Anything, mock.Anything).Return(nil, errors.New("http 503 from data.sec.gov"))
+
+	ds := &XBRLFrames{cfg: xbrlFramesConfig("us-gaap/Assets/USD/instant")}
+	_, err := ds.SyncPeriod(context.Background(), nil, f, t.TempDir(), Period{Year: 2024})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "us-gaap/Assets/USD/instant CY2024Q4I")
+}
+
+func TestXBRLFrames_SyncPeriod_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *config.Config
+		period Period
+		want   string
+	}{
+		{"unended period", xbrlFramesConfig("us-gaap/Assets/USD/instant"), Period{Year: time.Now().Year() + 1}, "has not ended"},
+		{"no concepts", &config.Config{}, Period{Year: 2024}, "no concepts configured"},
+		{"bad concept", xbrlFramesConfig("us-gaap/Assets"), Period{Year: 2024}, "fedsync.xbrl.frames"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &XBRLFrames{cfg: tt.cfg}
+			_, err := ds.SyncPeriod(context.Background(), nil, fetchermocks.NewMockFetcher(t), t.TempDir(), tt.period)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package fedsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// HookResult is the outcome of one post-sync hook, stored in
+// fed_data.sync_hooks.
+type HookResult struct {
+	Hook     string        `json:"hook"`
+	Status   string        `json:"status"` // "complete" or "failed"
+	Rows     int64         `json:"rows"`
+	Duration time.Duration `json:"duration_ns"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// RecordHooks stores the post-sync hook results of a sync run.
+func (s *SyncLog) RecordHooks(ctx context.Context, syncID int64, dataset string, results []HookResult) error {
+	for _, r := range results {
+		if _, err := s.pool.Exec(ctx,
+			`INSERT INTO fed_data.sync_hooks
+			 (sync_id, dataset, hook, status, rows_affected, duration_ms, error)
+			 VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`,
+			syncID, dataset, r.Hook, r.Status, r.Rows, r.Duration.Milliseconds(), r.Error,
+		); err != nil {
+			return eris.Wrapf(err, "synclog: record hook %s for sync %d", r.Hook, syncID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncLog_RecordHooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_hooks").
+		WithArgs(int64(7), "fema_flood", "flood_tag", "complete", int64(120), int64(1500), "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_hooks").
+		WithArgs(int64(7), "fema_flood", "notify", "failed", int64(0), int64(0), "webhook: 500").
+		WillReturnError(errors.New("connection refused"))
+
+	err = NewSyncLog(mock).RecordHooks(context.Background(), 7, "fema_flood", []HookResult{
+		{Hook: "flood_tag", Status: "complete", Rows: 120, Duration: 1500 * time.Millisecond},
+		{Hook: "notify", Status: "failed", Error: "webhook: 500"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record hook notify for sync 7")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
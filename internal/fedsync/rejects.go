@@ -0,0 +1,210 @@
+package fedsync
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// DefaultRejectSamples caps the raw records a run keeps in
+// fed_data.rejected_rows; every rejection is still counted.
+const DefaultRejectSamples = 1000
+
+// Rejects collects the rows a sync's parsers skipped (bad CUSIP, zero CRD,
+// unparsable date, ...) with the reason and the raw record. While Rejects
+// is attached to the context, Reject records into it; the engine flushes
+// the samples to fed_data.rejected_rows and the per-reason totals to the
+// run's sync_log metadata.
+type Rejects struct {
+	dataset string
+	runID   int64
+	max     int
+
+	mu      sync.Mutex
+	counts  map[string]int64
+	samples []RejectedRow
+}
+
+// RejectedRow is one rejected record.
+type RejectedRow struct {
+	Reason    string          `json:"reason"`
+	Record    json.RawMessage `json:"record"`
+	SourceURL string          `json:"source_url,omitempty"`
+	At        time.Time       `json:"rejected_at"`
+}
+
+// NewRejects creates a collector for sync run runID of dataset that keeps
+// up to maxSamples raw records.
+func NewRejects(dataset string, runID int64, maxSamples int) *Rejects {
+	return &Rejects{dataset: dataset, runID: runID, max: maxSamples, counts: make(map[string]int64)}
+}
+
+// Add counts a rejection and, while under the sample cap, keeps record
+// (marshaled to JSON) with the source file it came from.
+func (r *Rejects) Add(reason string, record any, sourceURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[reason]++
+	if len(r.samples) >= r.max {
+		return
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		raw = json.RawMessage(strconv.Quote(err.Error()))
+	}
+	r.samples = append(r.samples, RejectedRow{Reason: reason, Record: raw, SourceURL: sourceURL, At: time.Now().UTC()})
+}
+
+// Total returns how many rows were rejected.
+func (r *Rejects) Total() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, c := range r.counts {
+		n += c
+	}
+	return n
+}
+
+// Counts returns rejections per reason.
+func (r *Rejects) Counts() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.counts)
+}
+
+// Samples returns the kept raw records.
+func (r *Rejects) Samples() []RejectedRow {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RejectedRow(nil), r.samples...)
+}
+
+// Flush writes the kept records to fed_data.rejected_rows and clears them.
+func (r *Rejects) Flush(ctx context.Context, pool db.Pool) error {
+	r.mu.Lock()
+	samples := r.samples
+	r.samples = nil
+	r.mu.Unlock()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	rows := make([][]any, len(samples))
+	for i, s := range samples {
+		var src any
+		if s.SourceURL != "" {
+			src = s.SourceURL
+		}
+		rows[i] = []any{r.runID, r.dataset, s.Reason, s.Record, src, s.At}
+	}
+	_, err := db.CopyFromSchema(ctx, pool, "fed_data", "rejected_rows",
+		[]string{"sync_run_id", "dataset", "reason", "record", "source_url", "rejected_at"}, rows)
+	return eris.Wrapf(err, "rejects: flush %s run %d", r.dataset, r.runID)
+}
+
+type rejectsKey struct{}
+
+// WithRejects returns a context whose Reject calls record into r.
+func WithRejects(ctx context.Context, r *Rejects) context.Context {
+	return context.WithValue(ctx, rejectsKey{}, r)
+}
+
+// RejectsFromContext returns the Rejects attached to ctx, or nil.
+func RejectsFromContext(ctx context.Context) *Rejects {
+	r, _ := ctx.Value(rejectsKey{}).(*Rejects)
+	return r
+}
+
+// Reject records that a parser skipped record for reason. The source file
+// is taken from the context's db.Provenance. Without Rejects on ctx it does
+// nothing, so parsers call it unconditionally.
+func Reject(ctx context.Context, reason string, record any) {
+	r := RejectsFromContext(ctx)
+	if r == nil {
+		return
+	}
+	var src string
+	if p := db.ProvenanceFromContext(ctx); p != nil {
+		src, _ = p.Source()
+	}
+	r.Add(reason, record, src)
+}
+
+// RejectSummary is one completed run's rejections.
+type RejectSummary struct {
+	RunID      int64            `json:"run_id"`
+	Dataset    string           `json:"dataset"`
+	StartedAt  time.Time        `json:"started_at"`
+	RowsSynced int64            `json:"rows_synced"`
+	Rejected   int64            `json:"rejected"`
+	Reasons    map[string]int64 `json:"reasons"`
+}
+
+// Rate returns the share of parsed rows that were rejected.
+func (s RejectSummary) Rate() float64 {
+	if s.Rejected == 0 {
+		return 0
+	}
+	return float64(s.Rejected) / float64(s.Rejected+s.RowsSynced)
+}
+
+// TopReason returns the most frequent rejection reason, ties broken by
+// name.
+func (s RejectSummary) TopReason() string {
+	reasons := make([]string, 0, len(s.Reasons))
+	for r := range s.Reasons {
+		reasons = append(reasons, r)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if s.Reasons[reasons[i]] != s.Reasons[reasons[j]] {
+			return s.Reasons[reasons[i]] > s.Reasons[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	if len(reasons) == 0 {
+		return ""
+	}
+	return reasons[0]
+}
+
+// RejectSummaries returns the latest completed runs that rejected rows,
+// newest first, optionally for one dataset ("" for all).
+func (s *SyncLog) RejectSummaries(ctx context.Context, dataset string, limit int) ([]RejectSummary, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, dataset, started_at, COALESCE(rows_synced, 0),
+		        (metadata->>'rejected_rows')::bigint, metadata->'rejected_reasons'
+		 FROM fed_data.sync_log
+		 WHERE status = 'complete' AND metadata ? 'rejected_rows'
+		   AND ($1 = '' OR dataset = $1)
+		 ORDER BY started_at DESC
+		 LIMIT $2`,
+		dataset, limit,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "synclog: reject summaries")
+	}
+	defer rows.Close()
+
+	var out []RejectSummary
+	for rows.Next() {
+		var rs RejectSummary
+		var reasons []byte
+		if err := rows.Scan(&rs.RunID, &rs.Dataset, &rs.StartedAt, &rs.RowsSynced, &rs.Rejected, &reasons); err != nil {
+			return nil, eris.Wrap(err, "synclog: scan reject summary")
+		}
+		if reasons != nil {
+			_ = json.Unmarshal(reasons, &rs.Reasons)
+		}
+		out = append(out, rs)
+	}
+	return out, rows.Err()
+}
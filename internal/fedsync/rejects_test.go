@@ -0,0 +1,133 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+var rejectCols = []string{"sync_run_id", "dataset", "reason", "record", "source_url", "rejected_at"}
+
+func TestRejects_AddCapsSamples(t *testing.T) {
+	r := NewRejects("holdings_13f", 7, 2)
+	r.Add("bad_cusip", map[string]string{"cusip": "123"}, "")
+	r.Add("bad_cusip", map[string]string{"cusip": "45"}, "")
+	r.Add("missing_crd", []string{"a", "b"}, "")
+
+	assert.Equal(t, int64(3), r.Total())
+	assert.Equal(t, map[string]int64{"bad_cusip": 2, "missing_crd": 1}, r.Counts())
+	samples := r.Samples()
+	require.Len(t, samples, 2)
+	assert.JSONEq(t, `{"cusip":"123"}`, string(samples[0].Record))
+}
+
+func TestReject_NoRejectsOnContext(t *testing.T) {
+	assert.NotPanics(t, func() { Reject(context.Background(), "bad_cusip", nil) })
+}
+
+func TestReject_UsesProvenanceSource(t *testing.T) {
+	r := NewRejects("bfs", 1, 10)
+	prov := db.NewProvenance(1, time.Now())
+	prov.SetSource("https://example.gov/bfs.json", "")
+	ctx := WithRejects(db.WithProvenance(context.Background(), prov), r)
+
+	Reject(ctx, "bad_period", []string{"2025-13"})
+
+	samples := r.Samples()
+	require.Len(t, samples, 1)
+	assert.Equal(t, "https://example.gov/bfs.json", samples[0].SourceURL)
+	assert.Equal(t, "bad_period", samples[0].Reason)
+}
+
+func TestRejects_Flush(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectCopyFrom(pgx.Identifier{"fed_data", "rejected_rows"}, rejectCols).WillReturnResult(2)
+
+	r := NewRejects("ncua_call_reports", 9, 10)
+	r.Add("bad_cu_number", []string{"x"}, "")
+	r.Add("bad_cycle_date", []string{"1"}, "https://example.gov/call.zip")
+	require.NoError(t, r.Flush(context.Background(), mock))
+
+	assert.Empty(t, r.Samples())
+	assert.Equal(t, int64(2), r.Total())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRejects_Flush_Empty(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	require.NoError(t, NewRejects("bfs", 1, 10).Flush(context.Background(), mock))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRejects_Flush_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectCopyFrom(pgx.Identifier{"fed_data", "rejected_rows"}, rejectCols).WillReturnError(errors.New("copy failed"))
+
+	r := NewRejects("bfs", 1, 10)
+	r.Add("bad_period", nil, "")
+	err = r.Flush(context.Background(), mock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rejects: flush bfs run 1")
+}
+
+func TestRejectSummary_RateAndTopReason(t *testing.T) {
+	s := RejectSummary{
+		RowsSynced: 90,
+		Rejected:   10,
+		Reasons:    map[string]int64{"missing_crd": 4, "missing_filing_id": 4, "bad_date": 2},
+	}
+	assert.InDelta(t, 0.1, s.Rate(), 1e-9)
+	assert.Equal(t, "missing_crd", s.TopReason())
+
+	assert.Zero(t, RejectSummary{RowsSynced: 5}.Rate())
+	assert.Empty(t, RejectSummary{}.TopReason())
+}
+
+func TestSyncLog_RejectSummaries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	started := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("FROM fed_data.sync_log").
+		WithArgs("holdings_13f", 5).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "dataset", "started_at", "rows_synced", "rejected_rows", "rejected_reasons"}).
+			AddRow(int64(42), "holdings_13f", started, int64(1000), int64(12), []byte(`{"bad_cusip":12}`)))
+
+	got, err := NewSyncLog(mock).RejectSummaries(context.Background(), "holdings_13f", 5)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(42), got[0].RunID)
+	assert.Equal(t, int64(12), got[0].Rejected)
+	assert.Equal(t, map[string]int64{"bad_cusip": 12}, got[0].Reasons)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncLog_RejectSummaries_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.sync_log").WithArgs("", 20).WillReturnError(errors.New("boom"))
+
+	_, err = NewSyncLog(mock).RejectSummaries(context.Background(), "", 20)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "synclog: reject summaries")
+}
@@ -0,0 +1,187 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// addressKeyBatchSize is the number of address keys upserted per batch.
+const addressKeyBatchSize = 50000
+
+// addressKeySource is a fed_data table whose address the xref passes
+// compare through fed_data.address_keys.
+type addressKeySource struct {
+	table  string
+	keys   []string // primary key columns, joined with ':' into source_id
+	street string   // street column; "" for ZIP-only sources
+	zip    string   // ZIP or ZIP+4 column
+}
+
+// addressKeySources lists every table an xref pass compares by street or
+// ZIP. Each pass's table must be listed here (see addressKeySourceFor).
+var addressKeySources = []addressKeySource{
+	{table: "adv_firms", keys: []string{"crd_number"}, street: "street1", zip: "zip"},
+	{table: "edgar_entities", keys: []string{"cik"}, street: "business_street", zip: "business_zip"},
+	{table: "eo_bmf", keys: []string{"ein"}, zip: "zip"},
+	{table: "epa_facilities", keys: []string{"registry_id"}, zip: "fac_zip"},
+	{table: "fdic_institutions", keys: []string{"cert"}, zip: "zip"},
+	{table: "form_5500", keys: []string{"ack_id"}, zip: "spons_dfe_mail_us_zip"},
+	{table: "fpds_contracts", keys: []string{"contract_id"}, zip: "vendor_zip"},
+	{table: "ncen_registrants", keys: []string{"accession_number"}, zip: "zip"},
+	{table: "ncua_call_reports", keys: []string{"cu_number", "cycle_date"}, zip: "zip_code"},
+	{table: "osha_inspections", keys: []string{"activity_nr"}, zip: "site_zip"},
+	{table: "ppp_loans", keys: []string{"loannumber"}, zip: "borrowerzip"},
+	{table: "sba_loans", keys: []string{"program", "l2locid"}, zip: "borrzip"},
+	{table: "usaspending_awards", keys: []string{"award_id"}, zip: "recipient_zip"},
+}
+
+// addressKeySourceFor returns the address key source for table. It panics
+// when table is not in addressKeySources, since pass SQL is static.
+func addressKeySourceFor(table string) addressKeySource {
+	for _, s := range addressKeySources {
+		if s.table == table {
+			return s
+		}
+	}
+	panic(fmt.Sprintf("resolve: %s has no address key source", table))
+}
+
+// idSQL returns the SQL expression for the source_id of alias's row.
+func (s addressKeySource) idSQL(alias string) string {
+	parts := make([]string, len(s.keys))
+	for i, k := range s.keys {
+		parts[i] = fmt.Sprintf("%s.%s::TEXT", alias, k)
+	}
+	return strings.Join(parts, " || ':' || ")
+}
+
+// joinSQL returns a join of fed_data.address_keys as keyAlias to the
+// source row aliased alias.
+func (s addressKeySource) joinSQL(join, keyAlias, alias string) string {
+	return fmt.Sprintf("%[1]s fed_data.address_keys %[2]s ON %[2]s.source_table = '%[3]s' AND %[2]s.source_id = %[4]s",
+		join, keyAlias, s.table, s.idSQL(alias))
+}
+
+// staleSQL returns the query for the rows of s whose address key is
+// missing or was computed from different street or ZIP values.
+func (s addressKeySource) staleSQL() string {
+	street := "NULL::TEXT"
+	if s.street != "" {
+		street = "s." + s.street + "::TEXT"
+	}
+	return fmt.Sprintf(`
+SELECT %[1]s, %[2]s, s.%[3]s::TEXT
+FROM fed_data.%[4]s s
+%[5]s
+WHERE k.source_id IS NULL
+   OR k.street_raw IS DISTINCT FROM %[2]s
+   OR k.zip_raw IS DISTINCT FROM s.%[3]s::TEXT`,
+		s.idSQL("s"), street, s.zip, s.table, s.joinSQL("LEFT JOIN", "k", "s"))
+}
+
+// RefreshAddressKeys recomputes the fed_data.address_keys rows of tables
+// (all address key sources when none are given) that are missing or stale,
+// normalizing street and ZIP with pkg/address. Returns the number of keys
+// written.
+func RefreshAddressKeys(ctx context.Context, pool db.Pool, tables ...string) (int64, error) {
+	sources := addressKeySources
+	if len(tables) > 0 {
+		sources = make([]addressKeySource, len(tables))
+		for i, t := range tables {
+			sources[i] = addressKeySourceFor(t)
+		}
+	}
+
+	var total int64
+	for _, s := range sources {
+		n, err := refreshAddressKeys(ctx, pool, s)
+		if err != nil {
+			return total, err
+		}
+		if n > 0 {
+			zap.L().Info("address keys refreshed", zap.String("table", s.table), zap.Int64("keys", n))
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// refreshAddressKeys recomputes the stale address keys of one source,
+// upserting them in batches as the rows stream in.
+func refreshAddressKeys(ctx context.Context, pool db.Pool, s addressKeySource) (int64, error) {
+	rows, err := pool.Query(ctx, s.staleSQL())
+	if err != nil {
+		return 0, eris.Wrapf(err, "resolve: query stale address keys for %s", s.table)
+	}
+	defer rows.Close()
+
+	var total int64
+	batch := make([][]any, 0, addressKeyBatchSize)
+	flush := func() error {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "fed_data.address_keys",
+			Columns:      []string{"source_table", "source_id", "street_raw", "zip_raw", "street_norm", "zip5"},
+			ConflictKeys: []string{"source_table", "source_id"},
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "resolve: upsert address keys for %s", s.table)
+		}
+		total += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var id string
+		var street, zip *string
+		if err := rows.Scan(&id, &street, &zip); err != nil {
+			return total, eris.Wrapf(err, "resolve: scan address key for %s", s.table)
+		}
+		var streetNorm, zip5 string
+		if street != nil {
+			streetNorm = NormalizeStreet(*street)
+		}
+		if zip != nil {
+			zip5 = NormalizeZIP(*zip)
+		}
+		batch = append(batch, []any{s.table, id, street, zip, nullIfEmpty(streetNorm), nullIfEmpty(zip5)})
+		if len(batch) == addressKeyBatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return total, eris.Wrapf(err, "resolve: iterate address keys for %s", s.table)
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// nullIfEmpty returns nil for "" so empty keys are stored as NULL.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// zipKeySource returns the address key source for a name+zip pass's table,
+// panicking when its ZIP column is not the one the keys are computed from.
+func zipKeySource(table, zipCol string) addressKeySource {
+	s := addressKeySourceFor(table)
+	if s.zip != zipCol {
+		panic(fmt.Sprintf("resolve: address keys for %s use %s, not %s", table, s.zip, zipCol))
+	}
+	return s
+}
@@ -0,0 +1,139 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// expectAddressKeyRefresh expects RefreshAddressKeys to find no stale keys
+// in tables (all address key sources when none are given).
+func expectAddressKeyRefresh(mock pgxmock.PgxPoolIface, tables ...string) {
+	if len(tables) == 0 {
+		for _, s := range addressKeySources {
+			tables = append(tables, s.table)
+		}
+	}
+	for _, t := range tables {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM fed_data." + t + " s")).
+			WillReturnRows(pgxmock.NewRows([]string{"source_id", "street", "zip"}))
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestAddressKeySource_StaleSQL(t *testing.T) {
+	sql := addressKeySourceFor("adv_firms").staleSQL()
+	assert.Contains(t, sql, "SELECT s.crd_number::TEXT, s.street1::TEXT, s.zip::TEXT")
+	assert.Contains(t, sql, "LEFT JOIN fed_data.address_keys k ON k.source_table = 'adv_firms' AND k.source_id = s.crd_number::TEXT")
+	assert.Contains(t, sql, "k.street_raw IS DISTINCT FROM s.street1::TEXT")
+	assert.Contains(t, sql, "k.zip_raw IS DISTINCT FROM s.zip::TEXT")
+
+	sql = addressKeySourceFor("sba_loans").staleSQL()
+	assert.Contains(t, sql, "SELECT s.program::TEXT || ':' || s.l2locid::TEXT, NULL::TEXT, s.borrzip::TEXT")
+}
+
+func TestAddressKeySourceFor_Unknown(t *testing.T) {
+	assert.Panics(t, func() { addressKeySourceFor("no_such_table") })
+	assert.Panics(t, func() { zipKeySource("ppp_loans", "zip") })
+}
+
+func TestRefreshAddressKeys_Normalizes(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM fed_data.adv_firms s")).
+		WillReturnRows(pgxmock.NewRows([]string{"source_id", "street", "zip"}).
+			AddRow("100", strPtr("123 North Main Street, Suite 4"), strPtr("02110-1234")).
+			AddRow("200", (*string)(nil), strPtr("n/a")))
+
+	d := db.NewDryRun(10)
+	n, err := RefreshAddressKeys(db.WithDryRun(context.Background(), d), mock, "adv_firms")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	tables := d.Tables()
+	require.Len(t, tables, 1)
+	assert.Equal(t, "fed_data.address_keys", tables[0].Table)
+	require.Len(t, tables[0].Samples, 2)
+	assert.Equal(t, []any{"adv_firms", "100", strPtr("123 North Main Street, Suite 4"), strPtr("02110-1234"), NormalizeStreet("123 North Main Street, Suite 4"), "02110"}, tables[0].Samples[0])
+	assert.Equal(t, []any{"adv_firms", "200", (*string)(nil), strPtr("n/a"), nil, nil}, tables[0].Samples[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshAddressKeys_Upserts(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM fed_data.ppp_loans s")).
+		WillReturnRows(pgxmock.NewRows([]string{"source_id", "street", "zip"}).
+			AddRow("9001", (*string)(nil), strPtr("75201")))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(
+		pgx.Identifier{"_tmp_upsert_fed_data_address_keys"},
+		[]string{"source_table", "source_id", "street_raw", "zip_raw", "street_norm", "zip5"},
+	).WillReturnResult(1)
+	mock.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("INSERT INTO").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	n, err := RefreshAddressKeys(context.Background(), mock, "ppp_loans")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshAddressKeys_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM fed_data.adv_firms s")).
+		WillReturnError(fmt.Errorf("relation does not exist"))
+
+	_, err = RefreshAddressKeys(context.Background(), mock, "adv_firms", "edgar_entities")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stale address keys for adv_firms")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestXrefBuilder_Build_AddressKeyError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM fed_data.adv_firms s")).
+		WillReturnError(fmt.Errorf("connection reset"))
+
+	_, err = NewXrefBuilder(mock).Build(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "xref: refresh address keys")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddressKeySources_UsedByPasses(t *testing.T) {
+	// Every name+zip pass compares ZIP5 keys, never raw ZIPs, and every
+	// address key source is compared by some pass.
+	all := AllPassSQL() + Pass4ProbabilisticSQL(DefaultMatchThreshold)
+	for _, p := range allPasses() {
+		if strings.Contains(p.sql, "'exact_name_zip'") {
+			assert.Contains(t, p.sql, "kb.zip5", "pass %s", p.name)
+			assert.NotContains(t, p.sql, "LEFT(b.", "pass %s", p.name)
+		}
+	}
+	for _, s := range addressKeySources {
+		assert.Contains(t, all, fmt.Sprintf("source_table = '%s'", s.table), "address key source %s is unused", s.table)
+	}
+}
@@ -1,18 +1,26 @@
 // Package resolve performs entity resolution across federal datasets.
 package resolve
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Pass1DirectSQL returns the SQL for pass 1: direct CRD-CIK matching.
 // Matches ADV firms to EDGAR entities where the ADV sec_number corresponds
 // to an EDGAR CIK (with leading-zero padding).
 func Pass1DirectSQL() string {
 	return `
-INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence)
+INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence, match_method, match_score, match_key)
 SELECT
     a.crd_number,
     e.cik,
     a.firm_name,
     'direct_sec_number',
-    1.00
+    1.00,
+    'deterministic',
+    1.00,
+    a.sec_number
 FROM fed_data.adv_firms a
 JOIN fed_data.edgar_entities e
     ON LPAD(REPLACE(a.sec_number, '-', ''), 10, '0') = e.cik
@@ -22,26 +30,167 @@ ON CONFLICT (crd_number, cik) WHERE crd_number IS NOT NULL AND cik IS NOT NULL
 DO NOTHING`
 }
 
-// Pass2SICSQL returns the SQL for pass 2: SIC code based exact name matching.
-// Matches ADV firms to EDGAR entities that have investment advisor SIC codes
-// (6211 = Security Brokers/Dealers, 6282 = Investment Advice) by exact name.
-func Pass2SICSQL() string {
+// Pass2LEISQL returns the SQL for pass 2: direct Legal Entity Identifier
+// matching. N-CEN reports each fund adviser's CRD and LEI and each
+// registrant's CIK and LEI; an adviser whose LEI is also a registrant's
+// links that CRD to the registrant's EDGAR CIK. The LEI is recorded in
+// match_key. Firms already linked by sec_number are skipped.
+func Pass2LEISQL() string {
 	return `
-INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence)
-SELECT
+INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence, match_method, match_score, match_key)
+SELECT DISTINCT ON (a.crd_number, e.cik)
     a.crd_number,
     e.cik,
     a.firm_name,
-    'sic_exact_name',
-    0.95
-FROM fed_data.adv_firms a
-JOIN fed_data.edgar_entities e
-    ON UPPER(TRIM(a.firm_name)) = UPPER(TRIM(e.entity_name))
-WHERE e.sic IN ('6211', '6282')
+    'direct_lei',
+    1.00,
+    'deterministic',
+    1.00,
+    UPPER(TRIM(v.adviser_lei))
+FROM fed_data.ncen_advisers v
+JOIN fed_data.adv_firms a ON v.adviser_crd::INTEGER = a.crd_number
+JOIN fed_data.ncen_registrants r ON UPPER(TRIM(r.lei)) = UPPER(TRIM(v.adviser_lei))
+JOIN fed_data.edgar_entities e ON e.cik = r.cik
+WHERE v.adviser_crd ~ '^\d+$'
+  AND NULLIF(TRIM(v.adviser_lei), '') IS NOT NULL
   AND NOT EXISTS (
       SELECT 1 FROM fed_data.entity_xref x
-      WHERE x.crd_number = a.crd_number AND x.cik = e.cik
+      WHERE x.crd_number = a.crd_number
   )
+ORDER BY a.crd_number, e.cik
+ON CONFLICT (crd_number, cik) WHERE crd_number IS NOT NULL AND cik IS NOT NULL
+DO NOTHING`
+}
+
+// Pass3TickerSQL returns the SQL for pass 3: exact name matching against the
+// SEC ticker map (fed_data.cik_tickers). Its titles cover currently listed
+// registrants only, so a name that maps to a single CIK there is a stronger
+// signal than an exact name match across all EDGAR entities. Firms already
+// linked by an identifier are skipped.
+func Pass3TickerSQL() string {
+	return `
+INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence, match_method, match_score)
+SELECT
+    a.crd_number,
+    t.cik,
+    a.firm_name,
+    'ticker_exact_name',
+    0.97,
+    'deterministic',
+    0.97
+FROM fed_data.adv_firms a
+JOIN (
+    SELECT UPPER(TRIM(title)) AS title_norm, MIN(cik) AS cik
+    FROM fed_data.cik_tickers
+    WHERE title IS NOT NULL
+    GROUP BY UPPER(TRIM(title))
+    HAVING COUNT(DISTINCT cik) = 1
+) t ON UPPER(TRIM(a.firm_name)) = t.title_norm
+WHERE NOT EXISTS (
+    SELECT 1 FROM fed_data.entity_xref x
+    WHERE x.crd_number = a.crd_number
+)
 ON CONFLICT (crd_number, cik) WHERE crd_number IS NOT NULL AND cik IS NOT NULL
 DO NOTHING`
 }
+
+// DefaultMatchThreshold is the lowest score at which the probabilistic pass
+// links a firm, used when no threshold is configured. An exact name at an
+// investment-advice SIC code scores exactly this with no state or address
+// agreement.
+const DefaultMatchThreshold = 0.75
+
+// Weights of the probabilistic pass's signals, each scored from 0 to 1.
+// They sum to 1, so the match score is also between 0 and 1.
+const (
+	weightName     = 0.60 // trigram similarity of normalized names
+	weightState    = 0.15 // ADV state vs EDGAR business (1) or incorporation (0.5) state
+	weightIndustry = 0.15 // EDGAR SIC: 6211/6282 (1), other 62xx/67xx (0.5)
+	weightAddress  = 0.10 // normalized ZIP5 (0.5) plus street token overlap (0.5)
+)
+
+// Pass4ProbabilisticSQL returns the SQL for pass 4: scored name matching.
+// Candidates are EDGAR entities whose names are trigram-similar to an ADV
+// firm's not linked by an earlier pass. Each candidate is scored as a
+// weighted sum of name similarity and state, industry, and address
+// agreement; a firm is linked to its highest-scoring candidate when the
+// score reaches threshold. Addresses are compared through the normalized
+// keys in fed_data.address_keys (see RefreshAddressKeys). The score and its
+// components are stored in match_score and match_detail for review.
+func Pass4ProbabilisticSQL(threshold float64) string {
+	return fmt.Sprintf(`
+INSERT INTO fed_data.entity_xref (crd_number, cik, entity_name, match_type, confidence, match_method, match_score, match_detail)
+SELECT DISTINCT ON (s.crd_number)
+    s.crd_number,
+    s.cik,
+    s.firm_name,
+    'probabilistic_name',
+    LEAST(ROUND(s.score, 2), 0.99),
+    'probabilistic',
+    ROUND(s.score, 3),
+    jsonb_build_object(
+        'name', ROUND(s.name_sim, 3),
+        'state', s.state_agree,
+        'industry', s.industry_agree,
+        'address', ROUND(s.address_agree, 3)
+    )
+FROM (
+    SELECT c.*,
+        %[1]v * c.name_sim + %[2]v * c.state_agree + %[3]v * c.industry_agree + %[4]v * c.address_agree AS score
+    FROM (
+        SELECT
+            a.crd_number,
+            a.firm_name,
+            e.cik,
+            similarity(%[5]s, %[6]s)::NUMERIC AS name_sim,
+            CASE
+                WHEN NULLIF(TRIM(a.state), '') IS NULL THEN 0.0
+                WHEN UPPER(TRIM(a.state)) = UPPER(TRIM(e.state_of_business)) THEN 1.0
+                WHEN UPPER(TRIM(a.state)) = UPPER(TRIM(e.state_of_inc)) THEN 0.5
+                ELSE 0.0
+            END AS state_agree,
+            CASE
+                WHEN e.sic IN ('6211', '6282') THEN 1.0
+                WHEN LEFT(e.sic, 2) IN ('62', '67') THEN 0.5
+                ELSE 0.0
+            END AS industry_agree,
+            CASE
+                WHEN ka.zip5 = ke.zip5 THEN 0.5
+                ELSE 0.0
+            END + 0.5 * %[7]s AS address_agree
+        FROM fed_data.adv_firms a
+        JOIN fed_data.edgar_entities e ON a.firm_name %% e.entity_name
+        %[9]s
+        %[10]s
+        WHERE NOT EXISTS (
+            SELECT 1 FROM fed_data.entity_xref x
+            WHERE x.crd_number = a.crd_number
+        )
+    ) c
+) s
+WHERE s.score >= %[8]v
+ORDER BY s.crd_number, s.score DESC, s.cik
+ON CONFLICT (crd_number, cik) WHERE crd_number IS NOT NULL AND cik IS NOT NULL
+DO NOTHING`,
+		weightName, weightState, weightIndustry, weightAddress,
+		NormalizeNameSQL("a.firm_name"), NormalizeNameSQL("e.entity_name"),
+		tokenOverlapSQL("COALESCE(ka.street_norm, '')", "COALESCE(ke.street_norm, '')"), threshold,
+		addressKeySourceFor("adv_firms").joinSQL("LEFT JOIN", "ka", "a"),
+		addressKeySourceFor("edgar_entities").joinSQL("LEFT JOIN", "ke", "e"))
+}
+
+// tokenOverlapSQL returns a SQL expression for the Jaccard overlap (0 to 1)
+// of the space-separated tokens of SQL expressions a and b; 0 when both
+// are empty.
+func tokenOverlapSQL(a, b string) string {
+	tokens := func(expr string) string {
+		return `(SELECT DISTINCT t FROM regexp_split_to_table(` + expr + `, '\s+') AS t WHERE t <> '')`
+	}
+	return strings.Join([]string{
+		`COALESCE((`,
+		`    SELECT (COUNT(*) FILTER (WHERE ta.t IS NOT NULL AND tb.t IS NOT NULL))::NUMERIC / NULLIF(COUNT(*), 0)`,
+		`    FROM ` + tokens(a) + ` ta`,
+		`    FULL JOIN ` + tokens(b) + ` tb ON ta.t = tb.t`,
+		`), 0)`,
+	}, "\n")
+}
@@ -12,8 +12,9 @@ import (
 )
 
 // MultiXrefBuilder builds cross-references across all entity-bearing federal
-// datasets using multiple match strategies: direct CRD, direct CIK, exact
-// name+zip, and exact name+state.
+// datasets using multiple match strategies: direct CRD, direct CIK, direct
+// DUNS/UEI, exact name+zip, and exact name+state. Identifier passes run
+// before any name pass.
 type MultiXrefBuilder struct {
 	pool db.Pool
 }
@@ -34,6 +35,11 @@ type passSpec struct {
 func (m *MultiXrefBuilder) Build(ctx context.Context) (int64, map[string]int64, error) {
 	log := zap.L().With(zap.String("component", "multi_xref_builder"))
 
+	// Normalize the ZIPs the name+zip passes compare.
+	if _, err := RefreshAddressKeys(ctx, m.pool); err != nil {
+		return 0, nil, eris.Wrap(err, "multi_xref: refresh address keys")
+	}
+
 	if _, err := m.pool.Exec(ctx, "TRUNCATE TABLE fed_data.entity_xref_multi"); err != nil {
 		return 0, nil, eris.Wrap(err, "multi_xref: truncate entity_xref_multi")
 	}
@@ -114,6 +120,22 @@ func allPasses() []passSpec {
 			name: "uei_usa_fpds",
 			sql:  directUEISQL(),
 		},
+		{
+			name: "uei_sam_fpds",
+			sql:  samUEISQL("fpds_contracts", "contract_id", "vendor_uei"),
+		},
+		{
+			name: "uei_sam_usa",
+			sql:  samUEISQL("usaspending_awards", "award_id", "recipient_uei"),
+		},
+		{
+			name: "uei_sam_grants",
+			sql:  samUEISQL("grants", "award_id", "recipient_uei"),
+		},
+		{
+			name: "uei_sam_single_audits",
+			sql:  samUEISQL("single_audits", "report_id", "auditee_uei"),
+		},
 
 		// --- Pass group 4: Direct EIN linkage (confidence 0.95) ---
 		{
@@ -753,6 +775,26 @@ func allPasses() []passSpec {
 				"state", 0.88, normName,
 			),
 		},
+
+		// --- Pass group 7: County parcel owners (geo.parcels, confidence 0.80-0.85) ---
+		// Owners of record are often holding entities, so these rank below
+		// the operational name passes.
+		{
+			name: "name_zip_parcels_ppp",
+			sql:  parcelOwnerSQL("ppp_loans", "loannumber", "borrowername", "borrowerzip", "zip", 0.85, normName),
+		},
+		{
+			name: "name_zip_parcels_sba",
+			sql:  parcelOwnerSQL("sba_loans", "l2locid", "borrname", "borrzip", "zip", 0.85, normName),
+		},
+		{
+			name: "name_state_parcels_adv",
+			sql:  parcelOwnerSQL("adv_firms", "crd_number", "firm_name", "state", "state", 0.80, normName),
+		},
+		{
+			name: "name_state_parcels_edgar",
+			sql:  parcelOwnerSQL("edgar_entities", "cik", "entity_name", "state_of_business", "state", 0.80, normName),
+		},
 	}
 }
 
@@ -829,10 +871,15 @@ func exactNameGeoSQL(
 ) string {
 	matchType := fmt.Sprintf("exact_name_%s", geoType)
 
-	// Use LEFT(x,5) for zip matching to handle zip+4 codes.
+	// ZIPs are compared as the normalized ZIP5 in fed_data.address_keys,
+	// which handles ZIP+4 and formatting differences.
 	geoJoin := fmt.Sprintf("a.%s = b.%s", srcGeo, tgtGeo)
+	var keyJoins string
 	if geoType == "zip" {
-		geoJoin = fmt.Sprintf("LEFT(a.%s, 5) = LEFT(b.%s, 5)", srcGeo, tgtGeo)
+		src := zipKeySource(srcTable, srcGeo)
+		tgt := zipKeySource(tgtTable, tgtGeo)
+		keyJoins = "\n" + src.joinSQL("JOIN", "ka", "a") + "\n" + tgt.joinSQL("JOIN", "kb", "b")
+		geoJoin = "kb.zip5 = ka.zip5"
 	}
 
 	return fmt.Sprintf(`
@@ -846,7 +893,7 @@ SELECT DISTINCT ON (a.%[3]s::TEXT, b.%[7]s::TEXT)
     a.%[4]s,
     '%[10]s',
     %[11]v
-FROM fed_data.%[1]s a
+FROM fed_data.%[1]s a%[15]s
 JOIN fed_data.%[5]s b
     ON %[9]s = %[12]s
     AND %[13]s
@@ -874,6 +921,7 @@ ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`,
 		normFn("b."+tgtName), // 12
 		geoJoin,              // 13
 		srcGeo,               // 14
+		keyJoins,             // 15
 	)
 }
 
@@ -938,7 +986,7 @@ ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`,
 func directDUNSSQL() string {
 	return `
 INSERT INTO fed_data.entity_xref_multi
-    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence)
+    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence, match_key)
 SELECT DISTINCT ON (a.recipient_duns)
     'usaspending_awards',
     a.award_id,
@@ -946,7 +994,8 @@ SELECT DISTINCT ON (a.recipient_duns)
     b.contract_id,
     a.recipient_name,
     'direct_duns',
-    1.00
+    1.00,
+    a.recipient_duns
 FROM fed_data.usaspending_awards a
 JOIN fed_data.fpds_contracts b ON a.recipient_duns = b.vendor_duns
 WHERE a.recipient_duns IS NOT NULL AND a.recipient_duns != ''
@@ -959,7 +1008,7 @@ ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`
 func directUEISQL() string {
 	return `
 INSERT INTO fed_data.entity_xref_multi
-    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence)
+    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence, match_key)
 SELECT DISTINCT ON (a.recipient_uei)
     'usaspending_awards',
     a.award_id,
@@ -967,7 +1016,8 @@ SELECT DISTINCT ON (a.recipient_uei)
     b.contract_id,
     a.recipient_name,
     'direct_uei',
-    1.00
+    1.00,
+    a.recipient_uei
 FROM fed_data.usaspending_awards a
 JOIN fed_data.fpds_contracts b ON a.recipient_uei = b.vendor_uei
 WHERE a.recipient_uei IS NOT NULL AND a.recipient_uei != ''
@@ -976,6 +1026,31 @@ ORDER BY a.recipient_uei
 ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`
 }
 
+// samUEISQL generates SQL for SAM.gov entity → tgtTable direct UEI
+// matching, linking each registration to one target row per UEI. The UEI
+// is recorded in match_key.
+func samUEISQL(tgtTable, tgtPK, tgtUEI string) string {
+	return fmt.Sprintf(`
+INSERT INTO fed_data.entity_xref_multi
+    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence, match_key)
+SELECT DISTINCT ON (a.uei)
+    'sam_entities',
+    a.uei,
+    '%[1]s',
+    b.%[2]s::TEXT,
+    a.legal_name,
+    'direct_uei',
+    1.00,
+    a.uei
+FROM fed_data.sam_entities a
+JOIN fed_data.%[1]s b ON a.uei = b.%[3]s
+WHERE b.%[3]s IS NOT NULL AND b.%[3]s != ''
+ORDER BY a.uei, b.%[2]s
+ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`,
+		tgtTable, tgtPK, tgtUEI,
+	)
+}
+
 // cikNCENEdgarSQL generates SQL for N-CEN registrant → EDGAR direct CIK matching.
 // Uses DISTINCT ON to pick the latest filing per CIK.
 func cikNCENEdgarSQL() string {
@@ -1085,3 +1160,54 @@ ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`,
 		normFn("f.vendor_name"),
 	)
 }
+
+// parcelOwnerSQL generates SQL for geo.parcels owner → federal dataset
+// exact normalized-name matching on the owner's mailing ZIP or state.
+// Parcels are keyed by "<county_fips>:<parcel_id>".
+func parcelOwnerSQL(tgtTable, tgtPK, tgtName, tgtGeo, geoType string, confidence float64, normFn func(string) string) string {
+	srcGeo := "p.owner_state"
+	geoJoin := fmt.Sprintf("p.owner_state = b.%s", tgtGeo)
+	var keyJoin string
+	if geoType == "zip" {
+		srcGeo = "p.owner_zip"
+		keyJoin = "\n" + zipKeySource(tgtTable, tgtGeo).joinSQL("JOIN", "kb", "b")
+		geoJoin = "p.owner_zip = kb.zip5"
+	}
+
+	return fmt.Sprintf(`
+INSERT INTO fed_data.entity_xref_multi
+    (source_dataset, source_id, target_dataset, target_id, entity_name, match_type, confidence)
+SELECT DISTINCT ON (p.county_fips || ':' || p.parcel_id, b.%[2]s::TEXT)
+    'parcels',
+    p.county_fips || ':' || p.parcel_id,
+    '%[1]s',
+    b.%[2]s::TEXT,
+    LEFT(p.owner_name, 300),
+    'exact_name_%[4]s',
+    %[5]v
+FROM geo.parcels p
+JOIN fed_data.%[1]s b%[10]s
+    ON %[6]s = %[7]s
+    AND %[8]s
+WHERE p.owner_name IS NOT NULL AND p.owner_name != ''
+  AND b.%[3]s IS NOT NULL AND b.%[3]s != ''
+  AND %[9]s IS NOT NULL AND %[9]s != ''
+  AND NOT EXISTS (
+      SELECT 1 FROM fed_data.entity_xref_multi x
+      WHERE x.source_dataset = 'parcels' AND x.source_id = p.county_fips || ':' || p.parcel_id
+        AND x.target_dataset = '%[1]s' AND x.target_id = b.%[2]s::TEXT
+  )
+ORDER BY p.county_fips || ':' || p.parcel_id, b.%[2]s::TEXT
+ON CONFLICT (source_dataset, source_id, target_dataset, target_id) DO NOTHING`,
+		tgtTable,               // 1
+		tgtPK,                  // 2
+		tgtName,                // 3
+		geoType,                // 4
+		confidence,             // 5
+		normFn("p.owner_name"), // 6
+		normFn("b."+tgtName),   // 7
+		geoJoin,                // 8
+		srcGeo,                 // 9
+		keyJoin,                // 10
+	)
+}
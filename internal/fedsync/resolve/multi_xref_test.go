@@ -65,8 +65,10 @@ func TestExactNameGeoSQL_Zip(t *testing.T) {
 	assert.Contains(t, sql, "'ppp_loans'")
 	assert.Contains(t, sql, "'exact_name_zip'")
 	assert.Contains(t, sql, "0.92")
-	assert.Contains(t, sql, "LEFT(a.vendor_zip, 5)")
-	assert.Contains(t, sql, "LEFT(b.borrowerzip, 5)")
+	assert.Contains(t, sql, "JOIN fed_data.address_keys ka ON ka.source_table = 'fpds_contracts' AND ka.source_id = a.contract_id::TEXT")
+	assert.Contains(t, sql, "JOIN fed_data.address_keys kb ON kb.source_table = 'ppp_loans' AND kb.source_id = b.loannumber::TEXT")
+	assert.Contains(t, sql, "kb.zip5 = ka.zip5")
+	assert.NotContains(t, sql, "LEFT(")
 	assert.Contains(t, sql, "UPPER")
 	assert.Contains(t, sql, "ON CONFLICT")
 }
@@ -99,7 +101,7 @@ func TestExactNameGeoSQL_NotExists(t *testing.T) {
 
 func TestAllPasses_Count(t *testing.T) {
 	passes := allPasses()
-	assert.Len(t, passes, 85)
+	assert.Len(t, passes, 93)
 }
 
 func TestAllPasses_UniqueNames(t *testing.T) {
@@ -167,10 +169,11 @@ func TestMultiXrefBuilder_Build_Success(t *testing.T) {
 	defer mock.Close()
 
 	// Truncate
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 
-	// 85 passes, each returns some rows.
+	// 93 passes, each returns some rows.
 	passes := allPasses()
 	for range passes {
 		mock.ExpectExec("INSERT INTO fed_data.entity_xref_multi").
@@ -180,8 +183,8 @@ func TestMultiXrefBuilder_Build_Success(t *testing.T) {
 	builder := NewMultiXrefBuilder(mock)
 	total, counts, err := builder.Build(context.Background())
 	assert.NoError(t, err)
-	assert.Equal(t, int64(85*10), total)
-	assert.Len(t, counts, 85)
+	assert.Equal(t, int64(93*10), total)
+	assert.Len(t, counts, 93)
 	for _, c := range counts {
 		assert.Equal(t, int64(10), c)
 	}
@@ -193,6 +196,7 @@ func TestMultiXrefBuilder_Build_TruncateError(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnError(fmt.Errorf("permission denied"))
 
@@ -209,6 +213,7 @@ func TestMultiXrefBuilder_Build_PassError(t *testing.T) {
 	defer mock.Close()
 
 	// Truncate succeeds
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	// First pass fails
@@ -227,6 +232,7 @@ func TestMultiXrefBuilder_Build_MiddlePassError(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	// First 3 passes succeed
@@ -252,6 +258,7 @@ func TestMultiXrefBuilder_Build_ZeroMatches(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	for range allPasses() {
@@ -293,6 +300,23 @@ func TestAllPassSQL(t *testing.T) {
 	assert.Contains(t, sql, "fed_data.ncua_call_reports")
 }
 
+func TestParcelOwnerSQL_Content(t *testing.T) {
+	sql := parcelOwnerSQL("ppp_loans", "loannumber", "borrowername", "borrowerzip", "zip", 0.85, NormalizeNameSQL)
+	assert.Contains(t, sql, "INSERT INTO fed_data.entity_xref_multi")
+	assert.Contains(t, sql, "FROM geo.parcels p")
+	assert.Contains(t, sql, "JOIN fed_data.ppp_loans b")
+	assert.Contains(t, sql, "JOIN fed_data.address_keys kb ON kb.source_table = 'ppp_loans' AND kb.source_id = b.loannumber::TEXT")
+	assert.Contains(t, sql, "p.owner_zip = kb.zip5")
+	assert.Contains(t, sql, "'exact_name_zip'")
+	assert.Contains(t, sql, "0.85")
+	assert.Contains(t, sql, "p.county_fips || ':' || p.parcel_id")
+
+	sql = parcelOwnerSQL("adv_firms", "crd_number", "firm_name", "state", "state", 0.80, NormalizeNameSQL)
+	assert.Contains(t, sql, "p.owner_state = b.state")
+	assert.Contains(t, sql, "'exact_name_state'")
+	assert.Contains(t, sql, "ON CONFLICT")
+}
+
 func TestDirectFDICSBASQL_Content(t *testing.T) {
 	sql := directFDICSBASQL()
 	assert.Contains(t, sql, "INSERT INTO fed_data.entity_xref_multi")
@@ -380,14 +404,40 @@ func TestDirectUEISQL_Content(t *testing.T) {
 	assert.Contains(t, sql, "'fpds_contracts'")
 	assert.Contains(t, sql, "'direct_uei'")
 	assert.Contains(t, sql, "recipient_uei = b.vendor_uei")
+	assert.Contains(t, sql, "a.recipient_uei\nFROM")
 	assert.Contains(t, sql, "ON CONFLICT")
 }
 
+func TestSAMUEISQL_Content(t *testing.T) {
+	sql := samUEISQL("grants", "award_id", "recipient_uei")
+	assert.Contains(t, sql, "'sam_entities'")
+	assert.Contains(t, sql, "'grants'")
+	assert.Contains(t, sql, "'direct_uei'")
+	assert.Contains(t, sql, "match_key")
+	assert.Contains(t, sql, "JOIN fed_data.grants b ON a.uei = b.recipient_uei")
+	assert.Contains(t, sql, "DISTINCT ON (a.uei)")
+	assert.NotContains(t, sql, "%!")
+}
+
+func TestAllPasses_IdentifiersBeforeNames(t *testing.T) {
+	lastIdentifier, firstName := -1, -1
+	for i, p := range allPasses() {
+		switch {
+		case strings.Contains(p.sql, "'direct_"):
+			lastIdentifier = i
+		case firstName < 0:
+			firstName = i
+		}
+	}
+	assert.Less(t, lastIdentifier, firstName, "identifier passes must run before name passes")
+}
+
 func TestMultiXrefBuilder_Build_VaryingCounts(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock)
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref_multi").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 
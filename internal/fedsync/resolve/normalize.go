@@ -3,6 +3,8 @@ package resolve
 import (
 	"regexp"
 	"strings"
+
+	"github.com/sells-group/research-cli/pkg/address"
 )
 
 // legalSuffixes lists common legal entity suffixes to strip during name normalization.
@@ -77,3 +79,19 @@ func NormalizeNameSQL(col string) string {
         '\s+', ' ', 'g')
     ))`
 }
+
+// NormalizeStreet standardizes a street address line for matching by
+// uppercasing, stripping punctuation, and abbreviating suffixes,
+// directionals, and unit designators ("123 North Main Street, Suite 4" →
+// "123 N MAIN ST STE 4"). "P O BOX" and "P.O. BOX" both become "PO BOX".
+// See address.NormalizeStreet.
+func NormalizeStreet(street string) string {
+	return address.NormalizeStreet(street)
+}
+
+// NormalizeZIP returns the 5-digit ZIP from a ZIP or ZIP+4 value
+// ("12345-6789", "123456789"), or "" when it has fewer than 5 digits.
+func NormalizeZIP(zip string) string {
+	zip5, _ := address.SplitZIP(zip)
+	return zip5
+}
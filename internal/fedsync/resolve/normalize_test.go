@@ -97,3 +97,26 @@ func TestNormalizeNameSQL_ContainsLegalSuffixPattern(t *testing.T) {
 	assert.Contains(t, sql, "INC")
 	assert.Contains(t, sql, "CORP")
 }
+
+func TestNormalizeStreet(t *testing.T) {
+	tests := map[string]string{
+		"":                               "",
+		"123 North Main Street, Suite 4": "123 N MAIN ST STE 4",
+		"  45 w. elm ave. ":              "45 W ELM AVE",
+		"P.O. Box 100":                   "PO BOX 100",
+		"P O BOX 100":                    "PO BOX 100",
+		"9 Industrial Parkway #12":       "9 INDUSTRIAL PKWY UNIT 12",
+		"1 Southwest Commerce Boulevard": "1 SW COMMERCE BLVD",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizeStreet(in), in)
+	}
+}
+
+func TestNormalizeZIP(t *testing.T) {
+	assert.Equal(t, "12345", NormalizeZIP("12345"))
+	assert.Equal(t, "12345", NormalizeZIP("12345-6789"))
+	assert.Equal(t, "02134", NormalizeZIP("021346789"))
+	assert.Equal(t, "", NormalizeZIP("1234"))
+	assert.Equal(t, "", NormalizeZIP(""))
+}
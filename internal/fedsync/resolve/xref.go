@@ -10,21 +10,36 @@ import (
 )
 
 // XrefBuilder builds the CRD-CIK cross-reference table by performing
-// a 2-pass matching strategy between ADV firms and EDGAR entities.
+// a 4-pass matching strategy between ADV firms and EDGAR entities:
+// identifier passes (sec_number, LEI) first, then name passes.
 type XrefBuilder struct {
-	pool db.Pool
+	pool      db.Pool
+	threshold float64
 }
 
 // NewXrefBuilder creates a new XrefBuilder.
 func NewXrefBuilder(pool db.Pool) *XrefBuilder {
-	return &XrefBuilder{pool: pool}
+	return &XrefBuilder{pool: pool, threshold: DefaultMatchThreshold}
 }
 
-// Build executes the 2-pass matching and rebuilds the entity_xref table.
+// SetMatchThreshold sets the lowest score, between 0 and 1, at which the
+// probabilistic pass links a firm. Values outside (0, 1] keep the default.
+func (x *XrefBuilder) SetMatchThreshold(threshold float64) {
+	if threshold > 0 && threshold <= 1 {
+		x.threshold = threshold
+	}
+}
+
+// Build executes the 4-pass matching and rebuilds the entity_xref table.
 // Returns the total number of cross-references created.
 func (x *XrefBuilder) Build(ctx context.Context) (int64, error) {
 	log := zap.L().With(zap.String("component", "xref_builder"))
 
+	// Normalize firm and entity addresses for the probabilistic pass.
+	if _, err := RefreshAddressKeys(ctx, x.pool, "adv_firms", "edgar_entities"); err != nil {
+		return 0, eris.Wrap(err, "xref: refresh address keys")
+	}
+
 	// Truncate existing xref table for a clean rebuild.
 	if _, err := x.pool.Exec(ctx, "TRUNCATE TABLE fed_data.entity_xref"); err != nil {
 		return 0, eris.Wrap(err, "xref: truncate entity_xref")
@@ -41,15 +56,33 @@ func (x *XrefBuilder) Build(ctx context.Context) (int64, error) {
 	total += n
 	log.Info("xref pass 1 complete", zap.Int64("matched", n))
 
-	// Pass 2: Direct matches from EDGAR SIC codes for investment advisors.
-	log.Info("xref pass 2: EDGAR SIC code matches")
-	n, err = x.pass2SIC(ctx)
+	// Pass 2: Direct CRD-CIK matches on LEI from N-CEN.
+	log.Info("xref pass 2: direct CRD-CIK from N-CEN LEI")
+	n, err = x.pass2LEI(ctx)
 	if err != nil {
-		return total, eris.Wrap(err, "xref: pass 2 (SIC code)")
+		return total, eris.Wrap(err, "xref: pass 2 (LEI)")
 	}
 	total += n
 	log.Info("xref pass 2 complete", zap.Int64("matched", n))
 
+	// Pass 3: Exact name matches against the SEC ticker map.
+	log.Info("xref pass 3: SEC ticker map exact name")
+	n, err = x.pass3Ticker(ctx)
+	if err != nil {
+		return total, eris.Wrap(err, "xref: pass 3 (ticker map)")
+	}
+	total += n
+	log.Info("xref pass 3 complete", zap.Int64("matched", n))
+
+	// Pass 4: Scored name, state, industry, and address matching.
+	log.Info("xref pass 4: probabilistic name match", zap.Float64("threshold", x.threshold))
+	n, err = x.pass4Probabilistic(ctx)
+	if err != nil {
+		return total, eris.Wrap(err, "xref: pass 4 (probabilistic)")
+	}
+	total += n
+	log.Info("xref pass 4 complete", zap.Int64("matched", n))
+
 	return total, nil
 }
 
@@ -63,12 +96,33 @@ func (x *XrefBuilder) pass1Direct(ctx context.Context) (int64, error) {
 	return tag.RowsAffected(), nil
 }
 
-// pass2SIC matches firms by exact name where EDGAR entities have financial services SIC codes.
-func (x *XrefBuilder) pass2SIC(ctx context.Context) (int64, error) {
-	sql := Pass2SICSQL()
+// pass2LEI matches firms to EDGAR registrants sharing their N-CEN LEI.
+func (x *XrefBuilder) pass2LEI(ctx context.Context) (int64, error) {
+	sql := Pass2LEISQL()
 	tag, err := x.pool.Exec(ctx, sql)
 	if err != nil {
 		return 0, eris.Wrap(err, "xref: execute pass 2")
 	}
 	return tag.RowsAffected(), nil
 }
+
+// pass3Ticker matches firms by exact name to listed registrants in the SEC ticker map.
+func (x *XrefBuilder) pass3Ticker(ctx context.Context) (int64, error) {
+	sql := Pass3TickerSQL()
+	tag, err := x.pool.Exec(ctx, sql)
+	if err != nil {
+		return 0, eris.Wrap(err, "xref: execute pass 3")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// pass4Probabilistic links remaining firms to their best-scoring EDGAR
+// entity at or above the match threshold.
+func (x *XrefBuilder) pass4Probabilistic(ctx context.Context) (int64, error) {
+	sql := Pass4ProbabilisticSQL(x.threshold)
+	tag, err := x.pool.Exec(ctx, sql)
+	if err != nil {
+		return 0, eris.Wrap(err, "xref: execute pass 4")
+	}
+	return tag.RowsAffected(), nil
+}
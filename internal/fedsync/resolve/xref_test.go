@@ -28,14 +28,48 @@ func TestPass1DirectSQL(t *testing.T) {
 	assert.Contains(t, sql, "ON CONFLICT")
 }
 
-func TestPass2SICSQL(t *testing.T) {
-	sql := Pass2SICSQL()
+func TestPass2LEISQL(t *testing.T) {
+	sql := Pass2LEISQL()
 	assert.Contains(t, sql, "INSERT INTO fed_data.entity_xref")
-	assert.Contains(t, sql, "sic_exact_name")
-	assert.Contains(t, sql, "'6211'")
-	assert.Contains(t, sql, "'6282'")
-	assert.Contains(t, sql, "UPPER(TRIM(a.firm_name))")
-	assert.Contains(t, sql, "ON CONFLICT")
+	assert.Contains(t, sql, "'direct_lei'")
+	assert.Contains(t, sql, "match_key")
+	assert.Contains(t, sql, "UPPER(TRIM(r.lei)) = UPPER(TRIM(v.adviser_lei))")
+	assert.Contains(t, sql, "fed_data.ncen_advisers")
+	assert.Contains(t, sql, "fed_data.edgar_entities")
+	assert.Contains(t, sql, "NOT EXISTS")
+}
+
+func TestPass1DirectSQL_RecordsSECNumber(t *testing.T) {
+	sql := Pass1DirectSQL()
+	assert.Contains(t, sql, "match_key")
+	assert.Contains(t, sql, "a.sec_number\nFROM")
+}
+
+func TestPass4ProbabilisticSQL(t *testing.T) {
+	sql := Pass4ProbabilisticSQL(0.8)
+	assert.Contains(t, sql, "INSERT INTO fed_data.entity_xref")
+	assert.Contains(t, sql, "'probabilistic_name'")
+	assert.Contains(t, sql, "'probabilistic'")
+	assert.Contains(t, sql, "match_detail")
+	assert.Contains(t, sql, "a.firm_name % e.entity_name")
+	assert.Contains(t, sql, "similarity(")
+	assert.Contains(t, sql, "'6211', '6282'")
+	assert.Contains(t, sql, "e.state_of_business")
+	assert.Contains(t, sql, "LEFT JOIN fed_data.address_keys ka ON ka.source_table = 'adv_firms' AND ka.source_id = a.crd_number::TEXT")
+	assert.Contains(t, sql, "LEFT JOIN fed_data.address_keys ke ON ke.source_table = 'edgar_entities' AND ke.source_id = e.cik::TEXT")
+	assert.Contains(t, sql, "WHEN ka.zip5 = ke.zip5 THEN 0.5")
+	assert.Contains(t, sql, "regexp_split_to_table(COALESCE(ka.street_norm, '')")
+	assert.Contains(t, sql, "0.6 * c.name_sim + 0.15 * c.state_agree + 0.15 * c.industry_agree + 0.1 * c.address_agree")
+	assert.Contains(t, sql, "WHERE s.score >= 0.8")
+	assert.Contains(t, sql, "DISTINCT ON (s.crd_number)")
+	assert.NotContains(t, sql, "%!")
+}
+
+func TestProbabilisticWeightsSumToOne(t *testing.T) {
+	assert.InDelta(t, 1.0, weightName+weightState+weightIndustry+weightAddress, 1e-9)
+	// An exact name at an investment-advice SIC code alone reaches the
+	// default threshold.
+	assert.InDelta(t, DefaultMatchThreshold, weightName+weightIndustry, 1e-9)
 }
 
 func TestPass1DirectSQL_NotEmpty(t *testing.T) {
@@ -43,8 +77,8 @@ func TestPass1DirectSQL_NotEmpty(t *testing.T) {
 	assert.NotEmpty(t, strings.TrimSpace(sql))
 }
 
-func TestPass2SICSQL_NotEmpty(t *testing.T) {
-	sql := Pass2SICSQL()
+func TestPass4ProbabilisticSQL_NotEmpty(t *testing.T) {
+	sql := Pass4ProbabilisticSQL(DefaultMatchThreshold)
 	assert.NotEmpty(t, strings.TrimSpace(sql))
 }
 
@@ -54,7 +88,9 @@ func TestAllSQLHaveConflictClause(t *testing.T) {
 		sql  string
 	}{
 		{"pass1", Pass1DirectSQL()},
-		{"pass2", Pass2SICSQL()},
+		{"pass2", Pass2LEISQL()},
+		{"pass3", Pass3TickerSQL()},
+		{"pass4", Pass4ProbabilisticSQL(DefaultMatchThreshold)},
 	}
 	for _, q := range queries {
 		assert.Contains(t, q.sql, "ON CONFLICT", "query %s should have ON CONFLICT clause", q.name)
@@ -68,10 +104,15 @@ func TestPass1DirectSQL_MatchType(t *testing.T) {
 	assert.Contains(t, sql, "1.00")
 }
 
-func TestPass2SICSQL_MatchType(t *testing.T) {
-	sql := Pass2SICSQL()
-	assert.Contains(t, sql, "'sic_exact_name'")
-	assert.Contains(t, sql, "0.95")
+func TestXrefBuilder_SetMatchThreshold(t *testing.T) {
+	xb := NewXrefBuilder(nil)
+	assert.Equal(t, DefaultMatchThreshold, xb.threshold)
+	xb.SetMatchThreshold(0.9)
+	assert.Equal(t, 0.9, xb.threshold)
+	xb.SetMatchThreshold(0)
+	assert.Equal(t, 0.9, xb.threshold)
+	xb.SetMatchThreshold(1.5)
+	assert.Equal(t, 0.9, xb.threshold)
 }
 
 // --- XrefBuilder pgxmock tests ---
@@ -90,19 +131,26 @@ func TestXrefBuilder_Build_Success(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	// Pass 1: direct CRD-CIK
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("INSERT", 50))
-	// Pass 2: SIC code
+	// Pass 2: LEI
+	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	// Pass 3: ticker map
+	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("INSERT", 5))
+	// Pass 4: probabilistic
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("INSERT", 30))
 
 	xb := NewXrefBuilder(mock)
 	total, err := xb.Build(context.Background())
 	assert.NoError(t, err)
-	assert.Equal(t, int64(80), total)
+	assert.Equal(t, int64(88), total)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -111,6 +159,7 @@ func TestXrefBuilder_Build_TruncateError(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnError(fmt.Errorf("permission denied"))
 
@@ -126,6 +175,7 @@ func TestXrefBuilder_Build_Pass1Error(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
@@ -143,6 +193,7 @@ func TestXrefBuilder_Build_Pass2Error(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
 	// Pass 1 succeeds
@@ -150,26 +201,75 @@ func TestXrefBuilder_Build_Pass2Error(t *testing.T) {
 		WillReturnResult(pgxmock.NewResult("INSERT", 10))
 	// Pass 2 fails
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
-		WillReturnError(fmt.Errorf("sic column missing"))
+		WillReturnError(fmt.Errorf("ncen_advisers does not exist"))
 
 	xb := NewXrefBuilder(mock)
 	_, err = xb.Build(context.Background())
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "pass 2")
+	assert.Contains(t, err.Error(), "pass 2 (LEI)")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestXrefBuilder_Build_ZeroMatches(t *testing.T) {
+func TestXrefBuilder_Build_Pass3Error(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
 	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
 		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
+	// Passes 1 and 2 succeed
+	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("INSERT", 10))
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
-		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	// Pass 3 fails
+	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+		WillReturnError(fmt.Errorf("cik_tickers does not exist"))
+
+	xb := NewXrefBuilder(mock)
+	_, err = xb.Build(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pass 3 (ticker map)")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestXrefBuilder_Build_Pass4Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
+	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
+	// Passes 1-3 succeed
+	for range 3 {
+		mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	}
+	// Pass 4 fails
 	mock.ExpectExec("INSERT INTO fed_data.entity_xref").
-		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+		WillReturnError(fmt.Errorf("function similarity does not exist"))
+
+	xb := NewXrefBuilder(mock)
+	_, err = xb.Build(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pass 4")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestXrefBuilder_Build_ZeroMatches(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectAddressKeyRefresh(mock, "adv_firms", "edgar_entities")
+	mock.ExpectExec("TRUNCATE TABLE fed_data.entity_xref").
+		WillReturnResult(pgxmock.NewResult("TRUNCATE", 0))
+	for range 4 {
+		mock.ExpectExec("INSERT INTO fed_data.entity_xref").
+			WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	}
 
 	xb := NewXrefBuilder(mock)
 	total, err := xb.Build(context.Background())
@@ -0,0 +1,48 @@
+package fedsync
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// RunLocks serializes dataset syncs across processes and hosts with
+// Postgres advisory locks keyed by dataset name, so two fedsync
+// invocations never load the same dataset (and its temp upsert tables)
+// at once.
+//
+// Each lock is a transaction-scoped advisory lock held by an otherwise
+// idle transaction for the length of the sync: it works through any
+// db.Pool, and Postgres releases it if the holder's connection dies.
+type RunLocks struct {
+	pool db.Pool
+}
+
+// NewRunLocks creates run locks backed by the given pool.
+func NewRunLocks(pool db.Pool) *RunLocks {
+	return &RunLocks{pool: pool}
+}
+
+// TryLock takes dataset's run lock without waiting. ok is false when
+// another run holds it. On success the caller must call release once the
+// sync is done.
+func (l *RunLocks) TryLock(ctx context.Context, dataset string) (release func(), ok bool, err error) {
+	tx, err := l.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, eris.Wrapf(err, "run lock: begin %s", dataset)
+	}
+	if err := tx.QueryRow(ctx,
+		`SELECT pg_try_advisory_xact_lock(hashtext('fedsync'), hashtext($1))`,
+		dataset,
+	).Scan(&ok); err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return nil, false, eris.Wrapf(err, "run lock: acquire %s", dataset)
+	}
+	if !ok {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return nil, false, nil
+	}
+	return func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }, true, nil
+}
@@ -0,0 +1,59 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLocks_TryLock(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(true))
+	mock.ExpectRollback()
+
+	release, ok, err := NewRunLocks(mock).TryLock(context.Background(), "cbp")
+	require.NoError(t, err)
+	require.True(t, ok)
+	release()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunLocks_TryLock_Held(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("pg_try_advisory_xact_lock").
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"ok"}).AddRow(false))
+	mock.ExpectRollback()
+
+	release, ok, err := NewRunLocks(mock).TryLock(context.Background(), "cbp")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, release)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunLocks_TryLock_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectBegin().WillReturnError(errors.New("conn refused"))
+
+	_, _, err = NewRunLocks(mock).TryLock(context.Background(), "cbp")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run lock: begin cbp")
+}
@@ -0,0 +1,99 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// SourceFiles provides access to fed_data.source_files, which records the
+// last downloaded version (ETag, Last-Modified, SHA256) of each source file
+// so unchanged files can be skipped.
+type SourceFiles struct {
+	pool db.Pool
+}
+
+// NewSourceFiles creates a new SourceFiles store backed by the given pool.
+func NewSourceFiles(pool db.Pool) *SourceFiles {
+	return &SourceFiles{pool: pool}
+}
+
+// For returns the source file versions of a single dataset. With reload
+// set, stored versions are ignored so every file is downloaded and parsed
+// again; new versions are still recorded.
+func (s *SourceFiles) For(dataset string, reload bool) *DatasetSources {
+	return &DatasetSources{pool: s.pool, dataset: dataset, reload: reload}
+}
+
+// DatasetSources is one dataset's recorded source file versions. A nil
+// *DatasetSources is valid: it knows no versions and records nothing.
+type DatasetSources struct {
+	pool    db.Pool
+	dataset string
+	reload  bool
+}
+
+// Get returns the recorded version of url, or the zero version if none is
+// recorded (or the dataset is reloading).
+func (d *DatasetSources) Get(ctx context.Context, url string) (fetcher.FileVersion, error) {
+	var v fetcher.FileVersion
+	if d == nil || d.reload {
+		return v, nil
+	}
+	var etag, lastMod, sum *string
+	var size *int64
+	err := d.pool.QueryRow(ctx,
+		`SELECT etag, last_modified, sha256, size_bytes
+		 FROM fed_data.source_files WHERE dataset = $1 AND url = $2`,
+		d.dataset, url,
+	).Scan(&etag, &lastMod, &sum, &size)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return v, nil
+	}
+	if err != nil {
+		return v, eris.Wrapf(err, "source files: get %s %s", d.dataset, url)
+	}
+	if etag != nil {
+		v.ETag = *etag
+	}
+	if lastMod != nil {
+		v.LastModified = *lastMod
+	}
+	if sum != nil {
+		v.SHA256 = *sum
+	}
+	if size != nil {
+		v.Size = *size
+	}
+	return v, nil
+}
+
+// Record stores v as the current version of url. changed_at only moves
+// when the content hash differs from the recorded one.
+func (d *DatasetSources) Record(ctx context.Context, url string, v fetcher.FileVersion) error {
+	if d == nil {
+		return nil
+	}
+	_, err := d.pool.Exec(ctx,
+		`INSERT INTO fed_data.source_files
+		     (dataset, url, etag, last_modified, sha256, size_bytes, checked_at, changed_at)
+		 VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, now(), now())
+		 ON CONFLICT (dataset, url) DO UPDATE
+		 SET etag = EXCLUDED.etag,
+		     last_modified = EXCLUDED.last_modified,
+		     sha256 = COALESCE(EXCLUDED.sha256, fed_data.source_files.sha256),
+		     size_bytes = EXCLUDED.size_bytes,
+		     checked_at = now(),
+		     changed_at = CASE
+		         WHEN EXCLUDED.sha256 IS NOT NULL
+		              AND EXCLUDED.sha256 IS DISTINCT FROM fed_data.source_files.sha256
+		         THEN now() ELSE fed_data.source_files.changed_at END`,
+		d.dataset, url, v.ETag, v.LastModified, v.SHA256, v.Size,
+	)
+	return eris.Wrapf(err, "source files: record %s %s", d.dataset, url)
+}
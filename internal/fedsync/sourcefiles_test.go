@@ -0,0 +1,101 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+const echoURL = "https://example.gov/national_single.zip"
+
+func TestSourceFiles_Get(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	etag, sum := `"v1"`, "abc"
+	size := int64(42)
+	mock.ExpectQuery("SELECT etag, last_modified, sha256, size_bytes FROM fed_data.source_files").
+		WithArgs("epa_echo", echoURL).
+		WillReturnRows(pgxmock.NewRows([]string{"etag", "last_modified", "sha256", "size_bytes"}).
+			AddRow(&etag, (*string)(nil), &sum, &size))
+
+	got, err := NewSourceFiles(mock).For("epa_echo", false).Get(context.Background(), echoURL)
+	require.NoError(t, err)
+	assert.Equal(t, fetcher.FileVersion{ETag: etag, SHA256: sum, Size: size}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceFiles_Get_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT etag, last_modified, sha256, size_bytes FROM fed_data.source_files").
+		WithArgs("epa_echo", echoURL).
+		WillReturnError(pgx.ErrNoRows)
+
+	got, err := NewSourceFiles(mock).For("epa_echo", false).Get(context.Background(), echoURL)
+	require.NoError(t, err)
+	assert.Equal(t, fetcher.FileVersion{}, got)
+}
+
+func TestSourceFiles_Get_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT etag, last_modified, sha256, size_bytes FROM fed_data.source_files").
+		WillReturnError(errors.New("connection refused"))
+
+	_, err = NewSourceFiles(mock).For("epa_echo", false).Get(context.Background(), echoURL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "source files: get epa_echo")
+}
+
+func TestSourceFiles_Get_Reload(t *testing.T) {
+	// No expectations: a reloading dataset never reads stored versions.
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	got, err := NewSourceFiles(mock).For("epa_echo", true).Get(context.Background(), echoURL)
+	require.NoError(t, err)
+	assert.Equal(t, fetcher.FileVersion{}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceFiles_Record(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	v := fetcher.FileVersion{ETag: `"v2"`, SHA256: "def", Size: 7}
+	mock.ExpectExec("INSERT INTO fed_data.source_files .* ON CONFLICT").
+		WithArgs("epa_echo", echoURL, v.ETag, "", v.SHA256, v.Size).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, NewSourceFiles(mock).For("epa_echo", true).Record(context.Background(), echoURL, v))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec("INSERT INTO fed_data.source_files").
+		WillReturnError(errors.New("connection refused"))
+	err = NewSourceFiles(mock).For("epa_echo", false).Record(context.Background(), echoURL, v)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "source files: record epa_echo")
+}
+
+func TestDatasetSources_Nil(t *testing.T) {
+	var d *DatasetSources
+	got, err := d.Get(context.Background(), echoURL)
+	require.NoError(t, err)
+	assert.Equal(t, fetcher.FileVersion{}, got)
+	assert.NoError(t, d.Record(context.Background(), echoURL, fetcher.FileVersion{SHA256: "x"}))
+}
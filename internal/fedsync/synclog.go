@@ -38,6 +38,18 @@ type SyncSummary struct {
 	Running  int `json:"running"`
 }
 
+// DatasetRunStats summarizes a dataset's run history in fed_data.sync_runs.
+type DatasetRunStats struct {
+	Dataset          string     `json:"dataset"`
+	LastRun          time.Time  `json:"last_run"`
+	LastStatus       string     `json:"last_status"`
+	LastSuccess      *time.Time `json:"last_success,omitempty"`
+	LastRows         int64      `json:"last_rows"`
+	LastBytes        int64      `json:"last_bytes"`
+	TrailingFailures int        `json:"trailing_failures"`
+	LastError        string     `json:"last_error,omitempty"`
+}
+
 // SyncLog provides read/write access to the fed_data.sync_log table.
 type SyncLog struct {
 	pool  db.Pool
@@ -176,6 +188,72 @@ func (s *SyncLog) Fail(ctx context.Context, syncID int64, errMsg string) error {
 	return nil
 }
 
+// RecordDownloads stores the bytes a run downloaded and the source URLs it
+// read, keyed to their ETag when known.
+func (s *SyncLog) RecordDownloads(ctx context.Context, syncID int64, bytes int64, sources map[string]string) error {
+	var sourcesJSON []byte
+	if len(sources) > 0 {
+		var err error
+		sourcesJSON, err = json.Marshal(sources)
+		if err != nil {
+			return eris.Wrap(err, "synclog: marshal source versions")
+		}
+	}
+	_, err := s.pool.Exec(ctx,
+		`UPDATE fed_data.sync_log
+		 SET bytes_downloaded = $1, source_versions = $2
+		 WHERE id = $3`,
+		bytes, sourcesJSON, syncID,
+	)
+	return eris.Wrapf(err, "synclog: record downloads for sync %d", syncID)
+}
+
+// RunStats returns per-dataset run history from fed_data.sync_runs: the
+// latest run, the latest success with its rows and bytes, and how many
+// runs have failed since that success.
+func (s *SyncLog) RunStats(ctx context.Context) ([]DatasetRunStats, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH last_ok AS (
+			SELECT DISTINCT ON (dataset) dataset, started_at, rows_synced, bytes_downloaded
+			FROM fed_data.sync_runs
+			WHERE status = 'complete'
+			ORDER BY dataset, started_at DESC
+		), last_run AS (
+			SELECT DISTINCT ON (dataset) dataset, started_at, status
+			FROM fed_data.sync_runs
+			ORDER BY dataset, started_at DESC
+		)
+		SELECT lr.dataset, lr.started_at, lr.status,
+			ok.started_at, COALESCE(ok.rows_synced, 0), COALESCE(ok.bytes_downloaded, 0),
+			COUNT(r.id) FILTER (WHERE r.status = 'failed'),
+			(ARRAY_AGG(r.error ORDER BY r.started_at DESC) FILTER (WHERE r.status = 'failed'))[1]
+		FROM last_run lr
+		LEFT JOIN last_ok ok ON ok.dataset = lr.dataset
+		LEFT JOIN fed_data.sync_runs r ON r.dataset = lr.dataset
+			AND r.started_at > COALESCE(ok.started_at, '-infinity'::timestamptz)
+		GROUP BY lr.dataset, lr.started_at, lr.status, ok.started_at, ok.rows_synced, ok.bytes_downloaded
+		ORDER BY lr.dataset`)
+	if err != nil {
+		return nil, eris.Wrap(err, "synclog: run stats")
+	}
+	defer rows.Close()
+
+	var stats []DatasetRunStats
+	for rows.Next() {
+		var st DatasetRunStats
+		var lastErr *string
+		if err := rows.Scan(&st.Dataset, &st.LastRun, &st.LastStatus, &st.LastSuccess,
+			&st.LastRows, &st.LastBytes, &st.TrailingFailures, &lastErr); err != nil {
+			return nil, eris.Wrap(err, "synclog: scan run stats")
+		}
+		if lastErr != nil {
+			st.LastError = *lastErr
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
+
 // ListAll returns all sync log entries ordered by most recent first.
 func (s *SyncLog) ListAll(ctx context.Context) ([]SyncEntry, error) {
 	rows, err := s.pool.Query(ctx,
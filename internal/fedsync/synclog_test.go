@@ -337,3 +337,60 @@ func TestSyncLog_SummarizeSince(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+// --- RecordDownloads / RunStats ---
+
+func TestSyncLog_RecordDownloads(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("UPDATE fed_data.sync_log\\s+SET bytes_downloaded").
+		WithArgs(int64(2048), []byte(`{"https://example.com/a.zip":"\"abc\""}`), int64(7)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	sl := NewSyncLog(mock)
+	err = sl.RecordDownloads(context.Background(), 7, 2048, map[string]string{"https://example.com/a.zip": `"abc"`})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncLog_RunStats(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	lastRun := time.Date(2025, 6, 16, 6, 0, 0, 0, time.UTC)
+	lastOK := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	errMsg := "http 503"
+	mock.ExpectQuery("FROM fed_data.sync_runs").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"dataset", "last_run", "status", "last_success", "rows", "bytes", "failures", "error",
+		}).
+			AddRow("cbp", lastRun, "failed", &lastOK, int64(500), int64(1<<20), 2, &errMsg).
+			AddRow("fpds", lastRun, "complete", &lastRun, int64(10), int64(0), 0, nil))
+
+	stats, err := NewSyncLog(mock).RunStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "cbp", stats[0].Dataset)
+	assert.Equal(t, "failed", stats[0].LastStatus)
+	assert.Equal(t, lastOK, *stats[0].LastSuccess)
+	assert.Equal(t, int64(1<<20), stats[0].LastBytes)
+	assert.Equal(t, 2, stats[0].TrailingFailures)
+	assert.Equal(t, "http 503", stats[0].LastError)
+	assert.Empty(t, stats[1].LastError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncLog_RunStats_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM fed_data.sync_runs").WillReturnError(fmt.Errorf("relation does not exist"))
+
+	_, err = NewSyncLog(mock).RunStats(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "synclog: run stats")
+}
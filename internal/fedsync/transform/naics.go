@@ -4,7 +4,8 @@ import (
 	"strings"
 )
 
-// NAICSPrefixes defines the 2-digit NAICS sectors used by QCEW file filtering.
+// NAICSPrefixes lists the 2-digit NAICS sectors (plus QCEW's "10" total),
+// used to name per-sector source files and API sector filters.
 var NAICSPrefixes = []string{
 	"10", // Total (aggregate)
 	"11", // Agriculture, Forestry, Fishing and Hunting
@@ -33,16 +34,6 @@ var NAICSPrefixes = []string{
 	"92", // Public Administration
 }
 
-// IsRelevantNAICS returns true for any non-empty NAICS code.
-// All sectors are relevant for revenue estimation and market sizing.
-func IsRelevantNAICS(code string) bool {
-	code = strings.TrimSpace(code)
-	if code == "" || code == "-" {
-		return true
-	}
-	return true
-}
-
 // NormalizeNAICS normalizes a NAICS code to 6 digits by padding with zeros.
 // Returns the original if it's longer than 6 digits or empty.
 func NormalizeNAICS(code string) string {
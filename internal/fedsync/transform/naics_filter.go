@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// NAICSFilter decides which NAICS codes a dataset keeps. Rules are code
+// prefixes ("52", "5231") or inclusive ranges of equal-length prefixes
+// ("31-33", "5411-5415"). A nil filter, or one parsed from "all" or an
+// empty list, keeps every code.
+type NAICSFilter struct {
+	prefixes []string
+	ranges   [][2]string
+}
+
+// ParseNAICSFilter parses allowlist rules. "all" (alone) or no rules
+// yields a nil filter that keeps every code.
+func ParseNAICSFilter(rules []string) (*NAICSFilter, error) {
+	f := &NAICSFilter{}
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		switch {
+		case rule == "":
+			continue
+		case strings.EqualFold(rule, "all"):
+			if len(rules) > 1 {
+				return nil, eris.New("naics filter: \"all\" cannot be combined with other rules")
+			}
+			return nil, nil
+		}
+		lo, hi, isRange := strings.Cut(rule, "-")
+		if !isNAICSPrefix(lo) || (isRange && !isNAICSPrefix(hi)) {
+			return nil, eris.Errorf("naics filter: invalid rule %q (want 2-6 digits or a range like 31-33)", rule)
+		}
+		if !isRange {
+			f.prefixes = append(f.prefixes, lo)
+			continue
+		}
+		if len(lo) != len(hi) || lo > hi {
+			return nil, eris.Errorf("naics filter: invalid range %q (bounds must be the same length, low to high)", rule)
+		}
+		f.ranges = append(f.ranges, [2]string{lo, hi})
+	}
+	if len(f.prefixes) == 0 && len(f.ranges) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// Match reports whether code is kept. Totals (empty or dash-only codes,
+// e.g. CBP's "------") are always kept; sector spans like "31-33" match on
+// their leading digits.
+func (f *NAICSFilter) Match(code string) bool {
+	if f == nil {
+		return true
+	}
+	code = leadingDigits(strings.TrimSpace(code))
+	if code == "" {
+		return true
+	}
+	for _, p := range f.prefixes {
+		if strings.HasPrefix(code, p) {
+			return true
+		}
+	}
+	for _, r := range f.ranges {
+		if len(code) >= len(r[0]) && code[:len(r[0])] >= r[0] && code[:len(r[0])] <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps reports whether code is kept or is an aggregate (e.g. sector
+// "52") containing kept codes, for sources that only publish aggregates.
+func (f *NAICSFilter) Overlaps(code string) bool {
+	if f.Match(code) {
+		return true
+	}
+	code = leadingDigits(strings.TrimSpace(code))
+	for _, p := range f.prefixes {
+		if strings.HasPrefix(p, code) {
+			return true
+		}
+	}
+	for _, r := range f.ranges {
+		n := min(len(code), len(r[0]))
+		if code[:n] >= r[0][:n] && code[:n] <= r[1][:n] {
+			return true
+		}
+	}
+	return false
+}
+
+// isNAICSPrefix reports whether s is 2-6 digits.
+func isNAICSPrefix(s string) bool {
+	return len(s) >= 2 && len(s) <= 6 && leadingDigits(s) == s
+}
+
+// leadingDigits returns the run of ASCII digits at the start of s.
+func leadingDigits(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return s[:i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNAICSFilter(t *testing.T) {
+	for _, rules := range [][]string{nil, {}, {"all"}, {"ALL"}, {""}} {
+		f, err := ParseNAICSFilter(rules)
+		require.NoError(t, err, rules)
+		assert.Nil(t, f, "%v keeps everything", rules)
+	}
+
+	for _, bad := range [][]string{{"5"}, {"5231100"}, {"52a"}, {"45-44"}, {"52-5415"}, {"all", "52"}} {
+		_, err := ParseNAICSFilter(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestNAICSFilter_Match(t *testing.T) {
+	f, err := ParseNAICSFilter([]string{"52", "5411", "62-62", "48-49"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"523110", true},
+		{"52----", true},
+		{"541110", true},
+		{"541211", false},
+		{"621111", true},
+		{"484110", true},
+		{"493110", true},
+		{"48-49", true}, // QCEW sector span
+		{"311111", false},
+		{"31-33", false},
+		{"------", true}, // all-industry total
+		{"", true},
+		{"5", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, f.Match(tt.code), "code %q", tt.code)
+	}
+}
+
+func TestNAICSFilter_Overlaps(t *testing.T) {
+	f, err := ParseNAICSFilter([]string{"5231", "31-33"})
+	require.NoError(t, err)
+	assert.True(t, f.Overlaps("52"), "sector containing 5231")
+	assert.True(t, f.Overlaps("523"))
+	assert.True(t, f.Overlaps("523110"))
+	assert.False(t, f.Overlaps("5241"))
+	assert.True(t, f.Overlaps("32"))
+	assert.True(t, f.Overlaps("31-33"))
+	assert.False(t, f.Overlaps("54"))
+
+	var all *NAICSFilter
+	assert.True(t, all.Overlaps("54"))
+}
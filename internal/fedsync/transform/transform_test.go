@@ -6,7 +6,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestIsRelevantNAICS(t *testing.T) {
+func TestNAICSFilter_NilKeepsAll(t *testing.T) {
+	var f *NAICSFilter
 	tests := []struct {
 		code     string
 		relevant bool
@@ -20,7 +21,7 @@ func TestIsRelevantNAICS(t *testing.T) {
 		{"52", true},     // Sector-level
 	}
 	for _, tt := range tests {
-		assert.Equal(t, tt.relevant, IsRelevantNAICS(tt.code), "code: %q", tt.code)
+		assert.Equal(t, tt.relevant, f.Match(tt.code), "code: %q", tt.code)
 	}
 }
 
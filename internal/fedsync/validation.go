@@ -0,0 +1,53 @@
+package fedsync
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+)
+
+// ValidationResult is the outcome of one post-sync validation rule, stored
+// in fed_data.sync_validations.
+type ValidationResult struct {
+	Rule      string  `json:"rule"`
+	Passed    bool    `json:"passed"`
+	Observed  float64 `json:"observed"`
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message,omitempty"`
+}
+
+// LastSuccessRows returns rows_synced of the dataset's most recent
+// successful full sync, or nil if it has never completed one. Runs recorded
+// with metadata "partial" (incremental or resumed) are skipped.
+func (s *SyncLog) LastSuccessRows(ctx context.Context, dataset string) (*int64, error) {
+	var n int64
+	err := s.pool.QueryRow(ctx,
+		`SELECT COALESCE(rows_synced, 0) FROM fed_data.sync_log
+		 WHERE dataset = $1 AND status = 'complete'
+		   AND NOT COALESCE((metadata->>'partial')::boolean, false)
+		 ORDER BY started_at DESC LIMIT 1`,
+		dataset,
+	).Scan(&n)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "synclog: last success rows for %s", dataset)
+	}
+	return &n, nil
+}
+
+// RecordValidations stores the validation results of a sync run.
+func (s *SyncLog) RecordValidations(ctx context.Context, syncID int64, dataset string, results []ValidationResult) error {
+	for _, r := range results {
+		if _, err := s.pool.Exec(ctx,
+			`INSERT INTO fed_data.sync_validations
+			 (sync_id, dataset, rule, passed, observed, threshold, message)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			syncID, dataset, r.Rule, r.Passed, r.Observed, r.Threshold, r.Message,
+		); err != nil {
+			return eris.Wrapf(err, "synclog: record validation %s for sync %d", r.Rule, syncID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncLog_LastSuccessRows(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT COALESCE\(rows_synced, 0\) FROM fed_data.sync_log\s+WHERE dataset = \$1 AND status = 'complete'\s+AND NOT COALESCE\(\(metadata->>'partial'\)::boolean, false\)`).
+		WithArgs("cbp").
+		WillReturnRows(pgxmock.NewRows([]string{"rows_synced"}).AddRow(int64(1200)))
+
+	n, err := NewSyncLog(mock).LastSuccessRows(context.Background(), "cbp")
+	require.NoError(t, err)
+	require.NotNil(t, n)
+	assert.Equal(t, int64(1200), *n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncLog_LastSuccessRows_NeverSynced(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT COALESCE\\(rows_synced, 0\\) FROM fed_data.sync_log").
+		WithArgs("cbp").
+		WillReturnError(errors.New("no rows in result set"))
+
+	n, err := NewSyncLog(mock).LastSuccessRows(context.Background(), "cbp")
+	require.NoError(t, err)
+	assert.Nil(t, n)
+}
+
+func TestSyncLog_RecordValidations(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_validations").
+		WithArgs(int64(7), "cbp", "row_count_delta", true, 0.1, 0.5, "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_validations").
+		WithArgs(int64(7), "cbp", "null_rate:fed_data.cbp_data.emp", false, 0.8, 0.5, "80 of 100 rows NULL").
+		WillReturnError(errors.New("connection refused"))
+
+	err = NewSyncLog(mock).RecordValidations(context.Background(), 7, "cbp", []ValidationResult{
+		{Rule: "row_count_delta", Passed: true, Observed: 0.1, Threshold: 0.5},
+		{Rule: "null_rate:fed_data.cbp_data.emp", Observed: 0.8, Threshold: 0.5, Message: "80 of 100 rows NULL"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record validation null_rate:fed_data.cbp_data.emp for sync 7")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,54 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// Watermarks provides read/write access to the fed_data.sync_state table,
+// which holds the high-water mark each incremental dataset has synced
+// through.
+type Watermarks struct {
+	pool db.Pool
+}
+
+// NewWatermarks creates a new Watermarks store backed by the given pool.
+func NewWatermarks(pool db.Pool) *Watermarks {
+	return &Watermarks{pool: pool}
+}
+
+// Get returns the dataset's high-water mark, or nil if it has none.
+func (w *Watermarks) Get(ctx context.Context, dataset string) (*time.Time, error) {
+	var t time.Time
+	err := w.pool.QueryRow(ctx,
+		`SELECT high_water FROM fed_data.sync_state WHERE dataset = $1`,
+		dataset,
+	).Scan(&t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrapf(err, "watermark: get %s", dataset)
+	}
+	return &t, nil
+}
+
+// Advance records mark as the dataset's high-water mark. The mark never
+// moves backwards; an older mark leaves the stored one in place.
+func (w *Watermarks) Advance(ctx context.Context, dataset string, mark time.Time) error {
+	_, err := w.pool.Exec(ctx,
+		`INSERT INTO fed_data.sync_state (dataset, high_water, updated_at)
+		 VALUES ($1, $2, now())
+		 ON CONFLICT (dataset) DO UPDATE
+		 SET high_water = GREATEST(fed_data.sync_state.high_water, EXCLUDED.high_water),
+		     updated_at = now()`,
+		dataset, mark,
+	)
+	return eris.Wrapf(err, "watermark: advance %s", dataset)
+}
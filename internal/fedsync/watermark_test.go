@@ -0,0 +1,78 @@
+package fedsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatermarks_Get(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mark := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT high_water FROM fed_data.sync_state").
+		WithArgs("form_d").
+		WillReturnRows(pgxmock.NewRows([]string{"high_water"}).AddRow(mark))
+
+	got, err := NewWatermarks(mock).Get(context.Background(), "form_d")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, mark, *got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatermarks_Get_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT high_water FROM fed_data.sync_state").
+		WithArgs("form_d").
+		WillReturnError(pgx.ErrNoRows)
+
+	got, err := NewWatermarks(mock).Get(context.Background(), "form_d")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestWatermarks_Get_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT high_water FROM fed_data.sync_state").
+		WithArgs("form_d").
+		WillReturnError(errors.New("connection refused"))
+
+	_, err = NewWatermarks(mock).Get(context.Background(), "form_d")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "watermark: get form_d")
+}
+
+func TestWatermarks_Advance(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mark := time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO fed_data.sync_state .* GREATEST").
+		WithArgs("fpds", mark).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, NewWatermarks(mock).Advance(context.Background(), "fpds", mark))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_state").
+		WillReturnError(errors.New("connection refused"))
+	err = NewWatermarks(mock).Advance(context.Background(), "fpds", mark)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "watermark: advance fpds")
+}
@@ -0,0 +1,94 @@
+package xbrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// Frame is an EDGAR frames API response: one concept's latest value for
+// every filer in a calendar period.
+type Frame struct {
+	Taxonomy string      `json:"taxonomy"`
+	Tag      string      `json:"tag"`
+	CCP      string      `json:"ccp"` // calendar period, e.g. "CY2024Q4I"
+	UOM      string      `json:"uom"`
+	Label    string      `json:"label"`
+	Data     []FrameFact `json:"data"`
+}
+
+// FrameFact is one filer's value in a frame.
+type FrameFact struct {
+	Accn       string `json:"accn"`
+	CIK        int    `json:"cik"`
+	EntityName string `json:"entityName"`
+	Loc        string `json:"loc"`
+	Start      string `json:"start,omitempty"` // duration frames only
+	End        string `json:"end"`
+	Val        any    `json:"val"`
+}
+
+// ParseFrame parses an EDGAR frames API response from a reader.
+func ParseFrame(r io.Reader) (*Frame, error) {
+	var f Frame
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, eris.Wrap(err, "xbrl: parse frame")
+	}
+	return &f, nil
+}
+
+// FrameConcept is a concept queried through the frames API. Instant
+// concepts (balance sheet items) are reported at a point in time; the rest
+// cover a duration (income and cash flow items).
+type FrameConcept struct {
+	Taxonomy string
+	Tag      string
+	Unit     string
+	Instant  bool
+}
+
+// ParseFrameConcept parses "taxonomy/tag/unit/instant" or
+// "taxonomy/tag/unit/duration", e.g. "us-gaap/Assets/USD/instant".
+func ParseFrameConcept(s string) (FrameConcept, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return FrameConcept{}, eris.Errorf("xbrl: invalid frame concept %q (want taxonomy/tag/unit/instant|duration)", s)
+	}
+	c := FrameConcept{Taxonomy: parts[0], Tag: parts[1], Unit: parts[2]}
+	switch parts[3] {
+	case "instant":
+		c.Instant = true
+	case "duration":
+	default:
+		return FrameConcept{}, eris.Errorf("xbrl: frame concept %q must end in /instant or /duration", s)
+	}
+	return c, nil
+}
+
+// Period returns the frames API period code for a calendar year (quarter
+// 0) or quarter: CY2024 or CY2024Q2 for durations, and the instant at the
+// end of the period (CY2024Q4I, CY2024Q2I) for instant concepts.
+func (c FrameConcept) Period(year, quarter int) string {
+	switch {
+	case c.Instant && quarter == 0:
+		return fmt.Sprintf("CY%dQ4I", year)
+	case c.Instant:
+		return fmt.Sprintf("CY%dQ%dI", year, quarter)
+	case quarter == 0:
+		return fmt.Sprintf("CY%d", year)
+	default:
+		return fmt.Sprintf("CY%dQ%d", year, quarter)
+	}
+}
+
+// String formats the concept as ParseFrameConcept accepts it.
+func (c FrameConcept) String() string {
+	kind := "duration"
+	if c.Instant {
+		kind = "instant"
+	}
+	return c.Taxonomy + "/" + c.Tag + "/" + c.Unit + "/" + kind
+}
@@ -0,0 +1,67 @@
+package xbrl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleFrame = `{
+  "taxonomy": "us-gaap",
+  "tag": "Assets",
+  "ccp": "CY2024Q4I",
+  "uom": "USD",
+  "label": "Assets",
+  "pts": 2,
+  "data": [
+    {"accn": "0001104659-25-012345", "cik": 1750, "entityName": "AAR CORP.", "loc": "US-IL", "end": "2024-11-30", "val": 2870800000},
+    {"accn": "0000320193-25-000008", "cik": 320193, "entityName": "Apple Inc.", "loc": "US-CA", "end": "2024-12-28", "val": 344085000000}
+  ]
+}`
+
+func TestParseFrame(t *testing.T) {
+	f, err := ParseFrame(strings.NewReader(sampleFrame))
+	require.NoError(t, err)
+	assert.Equal(t, "us-gaap", f.Taxonomy)
+	assert.Equal(t, "CY2024Q4I", f.CCP)
+	assert.Equal(t, "USD", f.UOM)
+	require.Len(t, f.Data, 2)
+	assert.Equal(t, 320193, f.Data[1].CIK)
+	assert.Equal(t, "Apple Inc.", f.Data[1].EntityName)
+	assert.Equal(t, "2024-12-28", f.Data[1].End)
+	assert.Empty(t, f.Data[1].Start)
+}
+
+func TestParseFrame_Invalid(t *testing.T) {
+	_, err := ParseFrame(strings.NewReader("{not json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse frame")
+}
+
+func TestParseFrameConcept(t *testing.T) {
+	c, err := ParseFrameConcept("us-gaap/Assets/USD/instant")
+	require.NoError(t, err)
+	assert.Equal(t, FrameConcept{Taxonomy: "us-gaap", Tag: "Assets", Unit: "USD", Instant: true}, c)
+	assert.Equal(t, "us-gaap/Assets/USD/instant", c.String())
+
+	c, err = ParseFrameConcept(" us-gaap/Revenues/USD/duration ")
+	require.NoError(t, err)
+	assert.False(t, c.Instant)
+
+	for _, bad := range []string{"", "us-gaap/Assets/USD", "us-gaap//USD/instant", "us-gaap/Assets/USD/annual"} {
+		_, err := ParseFrameConcept(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFrameConcept_Period(t *testing.T) {
+	instant := FrameConcept{Taxonomy: "us-gaap", Tag: "Assets", Unit: "USD", Instant: true}
+	duration := FrameConcept{Taxonomy: "us-gaap", Tag: "Revenues", Unit: "USD"}
+
+	assert.Equal(t, "CY2024Q4I", instant.Period(2024, 0))
+	assert.Equal(t, "CY2024Q2I", instant.Period(2024, 2))
+	assert.Equal(t, "CY2024", duration.Period(2024, 0))
+	assert.Equal(t, "CY2024Q2", duration.Period(2024, 2))
+}
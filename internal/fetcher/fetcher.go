@@ -3,6 +3,8 @@ package fetcher
 import (
 	"context"
 	"io"
+
+	"github.com/rotisserie/eris"
 )
 
 // Fetcher defines the interface for downloading remote data.
@@ -19,4 +21,27 @@ type Fetcher interface {
 	// DownloadIfChanged fetches the URL only if the ETag has changed.
 	// Returns (body, newETag, changed, error). If not changed, body is nil and changed is false.
 	DownloadIfChanged(ctx context.Context, url string, etag string) (io.ReadCloser, string, bool, error)
+
+	// DownloadToFileIfChanged writes the URL to path unless the server
+	// reports it unchanged since prev (If-None-Match / If-Modified-Since).
+	// Returns the new version with its SHA256 and size, and whether the file
+	// was downloaded. If not changed, nothing is written and prev is returned.
+	DownloadToFileIfChanged(ctx context.Context, url string, path string, prev FileVersion) (FileVersion, bool, error)
+}
+
+// Poster is implemented by fetchers that can send a JSON POST, for APIs that
+// take their query as a request body. Wrapping fetchers forward it when the
+// fetcher they wrap supports it.
+type Poster interface {
+	// PostJSON POSTs body as application/json and returns the response body.
+	PostJSON(ctx context.Context, url string, body []byte) (io.ReadCloser, error)
+}
+
+// PostJSON POSTs body through f, or fails when f cannot POST.
+func PostJSON(ctx context.Context, f Fetcher, url string, body []byte) (io.ReadCloser, error) {
+	p, ok := f.(Poster)
+	if !ok {
+		return nil, eris.Errorf("fetcher %T does not support POST", f)
+	}
+	return p.PostJSON(ctx, url, body)
 }
@@ -1,6 +1,7 @@
 package fetcher
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math"
@@ -183,6 +184,13 @@ func (f *HTTPFetcher) doWithRetry(ctx context.Context, req *http.Request) (*http
 		}
 
 		cloned := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, eris.Wrap(err, "rewind request body")
+			}
+			cloned.Body = body
+		}
 		resp, err := f.client.Do(cloned) // #nosec G704 -- URL constructed from configured API base URL
 		if err != nil {
 			lastErr = err
@@ -272,6 +280,29 @@ func (f *HTTPFetcher) Download(ctx context.Context, rawURL string) (io.ReadClose
 	return resp.Body, nil
 }
 
+// PostJSON implements Poster. Requests are retried and rate limited like
+// downloads.
+func (f *HTTPFetcher) PostJSON(ctx context.Context, rawURL string, body []byte) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, eris.Wrap(err, "create request")
+	}
+	req.Header.Set("User-Agent", f.opts.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, eris.Wrap(err, "post")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, eris.Errorf("post: unexpected status %d from %s", resp.StatusCode, rawURL)
+	}
+
+	return resp.Body, nil
+}
+
 // DownloadToFile fetches the URL and writes it to the given path.
 func (f *HTTPFetcher) DownloadToFile(ctx context.Context, rawURL string, path string) (int64, error) {
 	body, err := f.Download(ctx, rawURL)
@@ -350,3 +381,38 @@ func (f *HTTPFetcher) DownloadIfChanged(ctx context.Context, rawURL string, etag
 	newETag := resp.Header.Get("ETag")
 	return resp.Body, newETag, true, nil
 }
+
+// DownloadToFileIfChanged writes the URL to path unless the server answers
+// 304 to prev's ETag or Last-Modified.
+func (f *HTTPFetcher) DownloadToFileIfChanged(ctx context.Context, rawURL string, path string, prev FileVersion) (FileVersion, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return FileVersion{}, false, eris.Wrap(err, "create request")
+	}
+	req.Header.Set("User-Agent", f.opts.UserAgent)
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := f.doWithRetry(ctx, req)
+	if err != nil {
+		return FileVersion{}, false, eris.Wrap(err, "download if changed")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusNotModified {
+		return prev, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return FileVersion{}, false, eris.Errorf("download if changed: unexpected status %d from %s", resp.StatusCode, rawURL)
+	}
+
+	v := FileVersion{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	if v.Size, v.SHA256, err = writeFileHashed(path, resp.Body); err != nil {
+		return FileVersion{}, false, err
+	}
+	return v, true, nil
+}
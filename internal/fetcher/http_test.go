@@ -42,6 +42,32 @@ func TestDownload(t *testing.T) {
 	assert.Equal(t, "hello world", string(data))
 }
 
+func TestPostJSON_RetriesWithBody(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"q":1}`, string(body))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher()
+	body, err := PostJSON(context.Background(), f, srv.URL, []byte(`{"q":1}`))
+	require.NoError(t, err)
+	defer body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
 func TestDownloadToFile(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("file content here")) //nolint:errcheck
@@ -112,6 +138,57 @@ func TestDownloadIfChanged_Changed(t *testing.T) {
 	assert.Equal(t, "new content", string(data))
 }
 
+func TestDownloadToFileIfChanged(t *testing.T) {
+	const lastMod = "Wed, 01 Oct 2025 00:00:00 GMT"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == lastMod {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastMod)
+		_, _ = w.Write([]byte("hello")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher()
+	path := filepath.Join(t.TempDir(), "file.zip")
+
+	v, changed, err := f.DownloadToFileIfChanged(context.Background(), srv.URL+"/file.zip", path, FileVersion{})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, FileVersion{
+		ETag:         `"v1"`,
+		LastModified: lastMod,
+		SHA256:       "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		Size:         5,
+	}, v)
+	data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	require.NoError(t, os.Remove(path))
+	got, changed, err := f.DownloadToFileIfChanged(context.Background(), srv.URL+"/file.zip", path, v)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, v, got)
+	assert.NoFileExists(t, path)
+
+	// Last-Modified alone is enough for a conditional request.
+	_, changed, err = f.DownloadToFileIfChanged(context.Background(), srv.URL+"/file.zip", path, FileVersion{LastModified: lastMod})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestDownloadToFileIfChanged_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, _, err := newTestFetcher().DownloadToFileIfChanged(context.Background(), srv.URL+"/missing", filepath.Join(t.TempDir(), "x"), FileVersion{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 404")
+}
+
 func TestRetryOnServerError(t *testing.T) {
 	var attempts atomic.Int32
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
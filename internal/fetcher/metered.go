@@ -0,0 +1,139 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// meteredMaxSources caps the source URLs a MeteredFetcher remembers, so
+// per-entity APIs (one request per CIK) don't bloat the run record.
+const meteredMaxSources = 100
+
+// MeteredFetcher wraps a Fetcher and counts the bytes it downloads and the
+// source URLs it reads, with their ETag when the server reported one.
+type MeteredFetcher struct {
+	next  Fetcher
+	bytes atomic.Int64
+
+	mu       sync.Mutex
+	sources  map[string]string
+	requests int64
+}
+
+// NewMeteredFetcher creates a MeteredFetcher around f.
+func NewMeteredFetcher(f Fetcher) *MeteredFetcher {
+	return &MeteredFetcher{next: f, sources: make(map[string]string)}
+}
+
+// Bytes returns the total bytes downloaded so far.
+func (m *MeteredFetcher) Bytes() int64 {
+	return m.bytes.Load()
+}
+
+// Sources returns the URLs read (up to meteredMaxSources) mapped to their
+// ETag, or "" when none was reported.
+func (m *MeteredFetcher) Sources() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]string, len(m.sources))
+	for u, v := range m.sources {
+		out[u] = v
+	}
+	return out
+}
+
+// Requests returns the number of successful downloads, including those past
+// the Sources cap.
+func (m *MeteredFetcher) Requests() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests
+}
+
+func (m *MeteredFetcher) record(url, etag string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if _, ok := m.sources[url]; ok || len(m.sources) < meteredMaxSources {
+		m.sources[url] = etag
+	}
+}
+
+// Download implements Fetcher.
+func (m *MeteredFetcher) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	rc, err := m.next.Download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	m.record(url, "")
+	return &countingReadCloser{ReadCloser: rc, n: &m.bytes}, nil
+}
+
+// DownloadToFile implements Fetcher.
+func (m *MeteredFetcher) DownloadToFile(ctx context.Context, url string, path string) (int64, error) {
+	n, err := m.next.DownloadToFile(ctx, url, path)
+	if err != nil {
+		return n, err
+	}
+	m.bytes.Add(n)
+	m.record(url, "")
+	return n, nil
+}
+
+// PostJSON implements Poster.
+func (m *MeteredFetcher) PostJSON(ctx context.Context, url string, body []byte) (io.ReadCloser, error) {
+	rc, err := PostJSON(ctx, m.next, url, body)
+	if err != nil {
+		return nil, err
+	}
+	m.record(url, "")
+	return &countingReadCloser{ReadCloser: rc, n: &m.bytes}, nil
+}
+
+// HeadETag implements Fetcher.
+func (m *MeteredFetcher) HeadETag(ctx context.Context, url string) (string, error) {
+	return m.next.HeadETag(ctx, url)
+}
+
+// DownloadIfChanged implements Fetcher. Unchanged sources are recorded with
+// the ETag they still carry.
+func (m *MeteredFetcher) DownloadIfChanged(ctx context.Context, url string, etag string) (io.ReadCloser, string, bool, error) {
+	rc, newETag, changed, err := m.next.DownloadIfChanged(ctx, url, etag)
+	if err != nil {
+		return rc, newETag, changed, err
+	}
+	if !changed {
+		m.record(url, etag)
+		return rc, newETag, changed, nil
+	}
+	m.record(url, newETag)
+	return &countingReadCloser{ReadCloser: rc, n: &m.bytes}, newETag, changed, nil
+}
+
+// DownloadToFileIfChanged implements Fetcher. Unchanged sources are
+// recorded with the ETag they still carry.
+func (m *MeteredFetcher) DownloadToFileIfChanged(ctx context.Context, url string, path string, prev FileVersion) (FileVersion, bool, error) {
+	v, changed, err := m.next.DownloadToFileIfChanged(ctx, url, path, prev)
+	if err != nil {
+		return v, changed, err
+	}
+	if changed {
+		m.bytes.Add(v.Size)
+	}
+	m.record(url, v.ETag)
+	return v, changed, nil
+}
+
+// countingReadCloser adds every byte read to n.
+type countingReadCloser struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,71 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeteredFetcher(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{"https://src/missing": errors.New("http 404")}}
+	m := NewMeteredFetcher(stub)
+	ctx := context.Background()
+
+	rc, err := m.Download(ctx, "https://src/a.json")
+	require.NoError(t, err)
+	_, err = io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+
+	n, err := m.DownloadToFile(ctx, "https://src/b.zip", "unused")
+	require.NoError(t, err)
+
+	rc, etag, changed, err := m.DownloadIfChanged(ctx, "https://src/c.csv", "old")
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Equal(t, "etag", etag)
+	_, _ = io.ReadAll(rc)
+
+	_, err = m.Download(ctx, "https://src/missing")
+	require.Error(t, err)
+
+	assert.Equal(t, int64(len("https://src/a.json"))+n+int64(len("https://src/c.csv")), m.Bytes())
+	assert.Equal(t, int64(3), m.Requests())
+	assert.Equal(t, map[string]string{
+		"https://src/a.json": "",
+		"https://src/b.zip":  "",
+		"https://src/c.csv":  "etag",
+	}, m.Sources())
+}
+
+func TestMeteredFetcher_DownloadToFileIfChanged(t *testing.T) {
+	m := NewMeteredFetcher(&stubFetcher{})
+	ctx := context.Background()
+
+	v, changed, err := m.DownloadToFileIfChanged(ctx, "https://src/a.zip", "unused", FileVersion{})
+	require.NoError(t, err)
+	require.True(t, changed)
+	_, changed, err = m.DownloadToFileIfChanged(ctx, "https://src/a.zip", "unused", v)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	assert.Equal(t, v.Size, m.Bytes(), "unchanged file adds no bytes")
+	assert.Equal(t, int64(2), m.Requests())
+	assert.Equal(t, map[string]string{"https://src/a.zip": "etag-https://src/a.zip"}, m.Sources())
+}
+
+func TestMeteredFetcher_SourceCap(t *testing.T) {
+	m := NewMeteredFetcher(&stubFetcher{})
+	for i := range meteredMaxSources + 10 {
+		rc, err := m.Download(context.Background(), fmt.Sprintf("https://src/%d", i))
+		require.NoError(t, err)
+		_ = rc.Close()
+	}
+	assert.Len(t, m.Sources(), meteredMaxSources)
+	assert.Equal(t, int64(meteredMaxSources+10), m.Requests())
+}
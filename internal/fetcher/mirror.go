@@ -0,0 +1,226 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+)
+
+// Mirror maps a primary URL prefix to alternate prefixes serving the same
+// files (e.g. "https://www2.census.gov/" to "ftp://ftp2.census.gov/").
+type Mirror struct {
+	Prefix string
+	URLs   []string
+}
+
+// MirrorFetcher wraps a Fetcher and retries a request against mirror URLs
+// when the primary fails persistently (retries exhausted, 5xx, network
+// errors). Client errors such as 404 are returned as-is since they mean the
+// file is not published rather than that the source is down.
+//
+// ftp:// mirrors are fetched with an FTPFetcher and are only tried for
+// Download, DownloadToFile, and DownloadToFileIfChanged; ETag requests skip
+// them.
+type MirrorFetcher struct {
+	primary Fetcher
+	ftp     *FTPFetcher
+	mirrors []Mirror
+}
+
+// NewMirrorFetcher creates a MirrorFetcher. Mirrors with the same prefix are
+// merged; the longest matching prefix wins.
+func NewMirrorFetcher(f Fetcher, mirrors []Mirror) *MirrorFetcher {
+	byPrefix := make(map[string][]string)
+	var order []string
+	for _, m := range mirrors {
+		if m.Prefix == "" || len(m.URLs) == 0 {
+			continue
+		}
+		if _, ok := byPrefix[m.Prefix]; !ok {
+			order = append(order, m.Prefix)
+		}
+		byPrefix[m.Prefix] = append(byPrefix[m.Prefix], m.URLs...)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return len(order[i]) > len(order[j]) })
+
+	mf := &MirrorFetcher{primary: f, ftp: NewFTPFetcher(FTPOptions{})}
+	for _, p := range order {
+		mf.mirrors = append(mf.mirrors, Mirror{Prefix: p, URLs: byPrefix[p]})
+	}
+	return mf
+}
+
+// candidates returns rawURL followed by its mirror URLs.
+func (m *MirrorFetcher) candidates(rawURL string) []string {
+	for _, mir := range m.mirrors {
+		if !strings.HasPrefix(rawURL, mir.Prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(rawURL, mir.Prefix)
+		out := []string{rawURL}
+		for _, alt := range mir.URLs {
+			out = append(out, alt+rest)
+		}
+		return out
+	}
+	return []string{rawURL}
+}
+
+func isFTPURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "ftp://")
+}
+
+// Download implements Fetcher.
+func (m *MirrorFetcher) Download(ctx context.Context, rawURL string) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	err := m.try(ctx, rawURL, true, func(u string) error {
+		var err error
+		if isFTPURL(u) {
+			rc, err = m.ftp.Download(ctx, u)
+		} else {
+			rc, err = m.primary.Download(ctx, u)
+		}
+		return err
+	})
+	return rc, err
+}
+
+// DownloadToFile implements Fetcher.
+func (m *MirrorFetcher) DownloadToFile(ctx context.Context, rawURL string, path string) (int64, error) {
+	var n int64
+	err := m.try(ctx, rawURL, true, func(u string) error {
+		var err error
+		if isFTPURL(u) {
+			n, err = m.ftp.DownloadToFile(ctx, u, path)
+		} else {
+			n, err = m.primary.DownloadToFile(ctx, u, path)
+		}
+		return err
+	})
+	return n, err
+}
+
+// PostJSON implements Poster. Mirrors serve files, not APIs, so queries go
+// to the primary only.
+func (m *MirrorFetcher) PostJSON(ctx context.Context, rawURL string, body []byte) (io.ReadCloser, error) {
+	return PostJSON(ctx, m.primary, rawURL, body)
+}
+
+// HeadETag implements Fetcher.
+func (m *MirrorFetcher) HeadETag(ctx context.Context, rawURL string) (string, error) {
+	var etag string
+	err := m.try(ctx, rawURL, false, func(u string) error {
+		var err error
+		etag, err = m.primary.HeadETag(ctx, u)
+		return err
+	})
+	return etag, err
+}
+
+// DownloadIfChanged implements Fetcher. Mirrors rarely share ETags with the
+// primary, so a mirror response is usually reported as changed.
+func (m *MirrorFetcher) DownloadIfChanged(ctx context.Context, rawURL string, etag string) (io.ReadCloser, string, bool, error) {
+	var (
+		rc      io.ReadCloser
+		newETag string
+		changed bool
+	)
+	err := m.try(ctx, rawURL, false, func(u string) error {
+		var err error
+		rc, newETag, changed, err = m.primary.DownloadIfChanged(ctx, u, etag)
+		return err
+	})
+	return rc, newETag, changed, err
+}
+
+// DownloadToFileIfChanged implements Fetcher. ftp:// mirrors have no
+// validators, so a file fetched from one is always downloaded and hashed.
+func (m *MirrorFetcher) DownloadToFileIfChanged(ctx context.Context, rawURL string, path string, prev FileVersion) (FileVersion, bool, error) {
+	var (
+		v       FileVersion
+		changed bool
+	)
+	err := m.try(ctx, rawURL, true, func(u string) error {
+		var err error
+		if !isFTPURL(u) {
+			v, changed, err = m.primary.DownloadToFileIfChanged(ctx, u, path, prev)
+			return err
+		}
+		if _, err = m.ftp.DownloadToFile(ctx, u, path); err != nil {
+			return err
+		}
+		v = FileVersion{}
+		v.SHA256, v.Size, err = HashFile(path)
+		changed = true
+		return err
+	})
+	return v, changed, err
+}
+
+// try calls fn for rawURL and, when the primary fails persistently, for
+// each mirror in turn. A mirror's own error never replaces the primary's, so
+// callers see the same error (e.g. "status 503") they would without mirrors.
+func (m *MirrorFetcher) try(ctx context.Context, rawURL string, allowFTP bool, fn func(u string) error) error {
+	err := fn(rawURL)
+	if err == nil || !ShouldFailover(ctx, err) {
+		return err
+	}
+
+	tried := 0
+	for _, u := range m.candidates(rawURL)[1:] {
+		if isFTPURL(u) && !allowFTP {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		tried++
+		zap.L().Warn("fetch failed, trying mirror",
+			zap.String("url", rawURL), zap.String("mirror", u), zap.Error(err))
+		mirrorErr := fn(u)
+		if mirrorErr == nil {
+			zap.L().Info("mirror fetch succeeded", zap.String("url", rawURL), zap.String("mirror", u))
+			return nil
+		}
+		zap.L().Warn("mirror fetch failed", zap.String("mirror", u), zap.Error(mirrorErr))
+	}
+	if tried == 0 {
+		return err
+	}
+	return eris.Wrapf(err, "primary and %d mirrors failed", tried)
+}
+
+var statusCodeRe = regexp.MustCompile(`(?:status|http) (\d{3})`)
+
+// StatusCode extracts the HTTP status from a fetch error message such as
+// "http 503 from ..." or "unexpected status 404 from ...".
+func StatusCode(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+	match := statusCodeRe.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	code, _ := strconv.Atoi(match[1])
+	return code, true
+}
+
+// ShouldFailover reports whether err indicates the source is unavailable
+// rather than that the request itself was wrong. Cancellation and 4xx
+// responses (other than 408 and 429) are not retried against mirrors.
+func ShouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if code, ok := StatusCode(err); ok && code >= 400 && code < 500 {
+		return code == 408 || code == 429
+	}
+	return true
+}
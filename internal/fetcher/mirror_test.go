@@ -0,0 +1,153 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFetcher returns canned errors per URL and records calls.
+type stubFetcher struct {
+	errs  map[string]error
+	calls []string
+}
+
+func (s *stubFetcher) Download(_ context.Context, u string) (io.ReadCloser, error) {
+	s.calls = append(s.calls, u)
+	if err := s.errs[u]; err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(u)), nil
+}
+
+func (s *stubFetcher) DownloadToFile(_ context.Context, u string, _ string) (int64, error) {
+	s.calls = append(s.calls, u)
+	return int64(len(u)), s.errs[u]
+}
+
+func (s *stubFetcher) HeadETag(_ context.Context, u string) (string, error) {
+	s.calls = append(s.calls, u)
+	return "etag-" + u, s.errs[u]
+}
+
+func (s *stubFetcher) DownloadIfChanged(ctx context.Context, u string, _ string) (io.ReadCloser, string, bool, error) {
+	rc, err := s.Download(ctx, u)
+	return rc, "etag", err == nil, err
+}
+
+func (s *stubFetcher) DownloadToFileIfChanged(_ context.Context, u string, _ string, prev FileVersion) (FileVersion, bool, error) {
+	s.calls = append(s.calls, u)
+	if err := s.errs[u]; err != nil {
+		return FileVersion{}, false, err
+	}
+	if prev.ETag == "etag-"+u {
+		return prev, false, nil
+	}
+	return FileVersion{ETag: "etag-" + u, SHA256: "sha-" + u, Size: int64(len(u))}, true, nil
+}
+
+func TestMirrorFetcher_Failover(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{
+		"https://primary/a/file.zip": errors.New("all retries exhausted: http 503 from https://primary/a/file.zip"),
+		"https://backup1/file.zip":   errors.New("dial tcp: connection refused"),
+	}}
+	mf := NewMirrorFetcher(stub, []Mirror{
+		{Prefix: "https://primary/", URLs: []string{"https://other/"}},
+		{Prefix: "https://primary/a/", URLs: []string{"https://backup1/", "https://backup2/"}},
+	})
+
+	body, err := mf.Download(context.Background(), "https://primary/a/file.zip")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "https://backup2/file.zip", string(data))
+	// Longest prefix wins; the shorter prefix's mirror is not tried.
+	assert.Equal(t, []string{
+		"https://primary/a/file.zip", "https://backup1/file.zip", "https://backup2/file.zip",
+	}, stub.calls)
+}
+
+func TestMirrorFetcher_NoFailoverOnNotFound(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{
+		"https://primary/file": errors.New("download: unexpected status 404 from https://primary/file"),
+	}}
+	mf := NewMirrorFetcher(stub, []Mirror{{Prefix: "https://primary/", URLs: []string{"https://backup/"}}})
+
+	_, err := mf.DownloadToFile(context.Background(), "https://primary/file", "/tmp/x")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+	assert.Equal(t, []string{"https://primary/file"}, stub.calls)
+}
+
+func TestMirrorFetcher_AllFail(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{
+		"https://primary/file": errors.New("http 502 from https://primary/file"),
+		"https://backup/file":  errors.New("download: unexpected status 404 from https://backup/file"),
+	}}
+	mf := NewMirrorFetcher(stub, []Mirror{{Prefix: "https://primary/", URLs: []string{"https://backup/", "ftp://backup/"}}})
+
+	// ETag requests skip FTP mirrors; the primary's error is reported.
+	_, err := mf.HeadETag(context.Background(), "https://primary/file")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "primary and 1 mirrors failed")
+	assert.Contains(t, err.Error(), "http 502")
+	assert.Equal(t, []string{"https://primary/file", "https://backup/file"}, stub.calls)
+}
+
+func TestMirrorFetcher_Unmatched(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{
+		"https://elsewhere/file": errors.New("http 503 from https://elsewhere/file"),
+	}}
+	mf := NewMirrorFetcher(stub, []Mirror{{Prefix: "https://primary/", URLs: []string{"https://backup/"}}})
+
+	_, _, _, err := mf.DownloadIfChanged(context.Background(), "https://elsewhere/file", "")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "mirrors failed")
+	assert.Len(t, stub.calls, 1)
+}
+
+func TestMirrorFetcher_DownloadToFileIfChanged(t *testing.T) {
+	stub := &stubFetcher{errs: map[string]error{
+		"https://primary/file.zip": errors.New("all retries exhausted: http 503 from https://primary/file.zip"),
+	}}
+	mf := NewMirrorFetcher(stub, []Mirror{{Prefix: "https://primary/", URLs: []string{"https://backup/"}}})
+
+	v, changed, err := mf.DownloadToFileIfChanged(context.Background(), "https://primary/file.zip", "unused", FileVersion{})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "sha-https://backup/file.zip", v.SHA256)
+	assert.Equal(t, []string{"https://primary/file.zip", "https://backup/file.zip"}, stub.calls)
+}
+
+func TestStatusCode(t *testing.T) {
+	code, ok := StatusCode(errors.New("all retries exhausted: http 503 from x"))
+	assert.True(t, ok)
+	assert.Equal(t, 503, code)
+
+	code, ok = StatusCode(errors.New("download: unexpected status 404 from x"))
+	assert.True(t, ok)
+	assert.Equal(t, 404, code)
+
+	_, ok = StatusCode(errors.New("parse csv: wrong number of fields"))
+	assert.False(t, ok)
+	_, ok = StatusCode(nil)
+	assert.False(t, ok)
+}
+
+func TestShouldFailover(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, ShouldFailover(ctx, nil))
+	assert.True(t, ShouldFailover(ctx, errors.New("all retries exhausted: http 500 from x")))
+	assert.True(t, ShouldFailover(ctx, errors.New("http 429 from x")))
+	assert.True(t, ShouldFailover(ctx, errors.New("read: connection reset by peer")))
+	assert.False(t, ShouldFailover(ctx, errors.New("download: unexpected status 400 from x")))
+	assert.False(t, ShouldFailover(ctx, errors.New("download: unexpected status 404 from x")))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, ShouldFailover(canceled, errors.New("http 503 from x")))
+}
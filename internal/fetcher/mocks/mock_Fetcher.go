@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	fetcher "github.com/sells-group/research-cli/internal/fetcher"
+
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -214,6 +216,72 @@ func (_c *MockFetcher_DownloadToFile_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DownloadToFileIfChanged provides a mock function with given fields: ctx, url, path, prev
+func (_m *MockFetcher) DownloadToFileIfChanged(ctx context.Context, url string, path string, prev fetcher.FileVersion) (fetcher.FileVersion, bool, error) {
+	ret := _m.Called(ctx, url, path, prev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadToFileIfChanged")
+	}
+
+	var r0 fetcher.FileVersion
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fetcher.FileVersion) (fetcher.FileVersion, bool, error)); ok {
+		return rf(ctx, url, path, prev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fetcher.FileVersion) fetcher.FileVersion); ok {
+		r0 = rf(ctx, url, path, prev)
+	} else {
+		r0 = ret.Get(0).(fetcher.FileVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, fetcher.FileVersion) bool); ok {
+		r1 = rf(ctx, url, path, prev)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, fetcher.FileVersion) error); ok {
+		r2 = rf(ctx, url, path, prev)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockFetcher_DownloadToFileIfChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadToFileIfChanged'
+type MockFetcher_DownloadToFileIfChanged_Call struct {
+	*mock.Call
+}
+
+// DownloadToFileIfChanged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - path string
+//   - prev fetcher.FileVersion
+func (_e *MockFetcher_Expecter) DownloadToFileIfChanged(ctx interface{}, url interface{}, path interface{}, prev interface{}) *MockFetcher_DownloadToFileIfChanged_Call {
+	return &MockFetcher_DownloadToFileIfChanged_Call{Call: _e.mock.On("DownloadToFileIfChanged", ctx, url, path, prev)}
+}
+
+func (_c *MockFetcher_DownloadToFileIfChanged_Call) Run(run func(ctx context.Context, url string, path string, prev fetcher.FileVersion)) *MockFetcher_DownloadToFileIfChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(fetcher.FileVersion))
+	})
+	return _c
+}
+
+func (_c *MockFetcher_DownloadToFileIfChanged_Call) Return(_a0 fetcher.FileVersion, _a1 bool, _a2 error) *MockFetcher_DownloadToFileIfChanged_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockFetcher_DownloadToFileIfChanged_Call) RunAndReturn(run func(context.Context, string, string, fetcher.FileVersion) (fetcher.FileVersion, bool, error)) *MockFetcher_DownloadToFileIfChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HeadETag provides a mock function with given fields: ctx, url
 func (_m *MockFetcher) HeadETag(ctx context.Context, url string) (string, error) {
 	ret := _m.Called(ctx, url)
@@ -0,0 +1,58 @@
+package fetcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/rotisserie/eris"
+)
+
+// FileVersion identifies one downloaded copy of a source file: the HTTP
+// validators the server sent with it and the SHA256 and size of its body.
+type FileVersion struct {
+	ETag         string
+	LastModified string
+	SHA256       string
+	Size         int64
+}
+
+// SameContent reports whether v and o hash to the same body. Versions
+// without a hash are never the same.
+func (v FileVersion) SameContent(o FileVersion) bool {
+	return v.SHA256 != "" && v.SHA256 == o.SHA256
+}
+
+// writeFileHashed copies r to path, returning the bytes written and their
+// hex SHA256.
+func writeFileHashed(path string, r io.Reader) (int64, string, error) {
+	file, err := os.Create(path) // #nosec G304 -- path from function parameter in internal package
+	if err != nil {
+		return 0, "", eris.Wrap(err, "create file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(file, h), r)
+	if err != nil {
+		return n, "", eris.Wrap(err, "write file")
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HashFile returns the hex SHA256 and size of the file at path.
+func HashFile(path string) (string, int64, error) {
+	file, err := os.Open(path) // #nosec G304 -- path from function parameter in internal package
+	if err != nil {
+		return "", 0, eris.Wrap(err, "open file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	h := sha256.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return "", n, eris.Wrap(err, "hash file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
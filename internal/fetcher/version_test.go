@@ -0,0 +1,28 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileVersion_SameContent(t *testing.T) {
+	assert.True(t, FileVersion{SHA256: "abc"}.SameContent(FileVersion{SHA256: "abc", ETag: "x"}))
+	assert.False(t, FileVersion{SHA256: "abc"}.SameContent(FileVersion{SHA256: "def"}))
+	assert.False(t, FileVersion{}.SameContent(FileVersion{}))
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	sum, n, err := HashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+	assert.Equal(t, int64(5), n)
+
+	_, _, err = HashFile(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
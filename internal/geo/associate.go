@@ -26,7 +26,10 @@ type MSARelation struct {
 // AssociatorOption configures an Associator.
 type AssociatorOption func(*Associator)
 
-// WithGeoSchema configures the Associator to query geo.cbsa instead of public.cbsa_areas.
+// WithGeoSchema configures the Associator to query geo.cbsa (loaded by the
+// cbsa_delineations geo scraper) instead of public.cbsa_areas. Until
+// geo.cbsa is synced it returns no rows, and the Associator falls back to
+// public.cbsa_areas.
 func WithGeoSchema() AssociatorOption {
 	return func(a *Associator) {
 		a.useGeoSchema = true
@@ -56,12 +59,51 @@ func (a *Associator) AssociateAddress(ctx context.Context, addressID int64, lat,
 		topN = 3
 	}
 
-	// PostGIS query: find top N nearest CBSAs with distances.
-	cbsaTable := "public.cbsa_areas"
+	tables := []string{"public.cbsa_areas"}
 	if a.useGeoSchema {
-		cbsaTable = "geo.cbsa"
+		tables = []string{"geo.cbsa", "public.cbsa_areas"}
 	}
 
+	var relations []MSARelation
+	for _, table := range tables {
+		var err error
+		relations, err = a.nearestMSAs(ctx, table, lat, lon, topN)
+		if err != nil {
+			return nil, err
+		}
+		if len(relations) > 0 {
+			break
+		}
+	}
+
+	// Persist associations via the company store.
+	for _, r := range relations {
+		am := &company.AddressMSA{
+			AddressID:      addressID,
+			CBSACode:       r.CBSACode,
+			MSAName:        r.MSAName,
+			IsWithin:       r.IsWithin,
+			DistanceKM:     r.DistanceKM,
+			CentroidKM:     r.CentroidKM,
+			EdgeKM:         r.EdgeKM,
+			Classification: r.Classification,
+			ComputedAt:     time.Now(),
+		}
+		if err := a.companyStore.UpsertAddressMSA(ctx, am); err != nil {
+			zap.L().Warn("geo: failed to upsert address MSA",
+				zap.Int64("address_id", addressID),
+				zap.String("cbsa_code", r.CBSACode),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return relations, nil
+}
+
+// nearestMSAs returns the top N metropolitan and micropolitan areas in
+// cbsaTable nearest the point, with distances and classification.
+func (a *Associator) nearestMSAs(ctx context.Context, cbsaTable string, lat, lon float64, topN int) ([]MSARelation, error) {
 	query := fmt.Sprintf(`
 		SELECT
 			cb.cbsa_code,
@@ -97,28 +139,5 @@ func (a *Associator) AssociateAddress(ctx context.Context, addressID int64, lat,
 	if err := rows.Err(); err != nil {
 		return nil, eris.Wrap(err, "geo: iterate MSA rows")
 	}
-
-	// Persist associations via the company store.
-	for _, r := range relations {
-		am := &company.AddressMSA{
-			AddressID:      addressID,
-			CBSACode:       r.CBSACode,
-			MSAName:        r.MSAName,
-			IsWithin:       r.IsWithin,
-			DistanceKM:     r.DistanceKM,
-			CentroidKM:     r.CentroidKM,
-			EdgeKM:         r.EdgeKM,
-			Classification: r.Classification,
-			ComputedAt:     time.Now(),
-		}
-		if err := a.companyStore.UpsertAddressMSA(ctx, am); err != nil {
-			zap.L().Warn("geo: failed to upsert address MSA",
-				zap.Int64("address_id", addressID),
-				zap.String("cbsa_code", r.CBSACode),
-				zap.Error(err),
-			)
-		}
-	}
-
 	return relations, nil
 }
@@ -212,3 +212,50 @@ func TestAssociateAddress_DefaultUsesPublicSchema(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestAssociateAddress_GeoSchemaFallsBack(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	cs := &mockCompanyStore{}
+	assoc := NewAssociator(mock, cs, WithGeoSchema())
+
+	// geo.cbsa not yet synced: no rows, so public.cbsa_areas is queried.
+	mock.ExpectQuery("FROM geo.cbsa").
+		WithArgs(-97.7431, 30.2672, 3).
+		WillReturnRows(
+			pgxmock.NewRows([]string{"cbsa_code", "name", "is_within", "distance_km", "centroid_km", "edge_km"}),
+		)
+	mock.ExpectQuery("FROM public.cbsa_areas").
+		WithArgs(-97.7431, 30.2672, 3).
+		WillReturnRows(
+			pgxmock.NewRows([]string{"cbsa_code", "name", "is_within", "distance_km", "centroid_km", "edge_km"}).
+				AddRow("12420", "Austin-Round Rock-Georgetown, TX", true, 0.0, 8.1, 0.0),
+		)
+
+	relations, err := assoc.AssociateAddress(context.Background(), 10, 30.2672, -97.7431, 3)
+	require.NoError(t, err)
+	require.Len(t, relations, 1)
+	assert.Equal(t, "12420", relations[0].CBSACode)
+	require.Len(t, cs.upserted, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssociateAddress_GeoSchemaQueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	assoc := NewAssociator(mock, &mockCompanyStore{}, WithGeoSchema())
+
+	mock.ExpectQuery("FROM geo.cbsa").
+		WithArgs(-97.7431, 30.2672, 3).
+		WillReturnError(assert.AnError)
+
+	_, err = assoc.AssociateAddress(context.Background(), 10, 30.2672, -97.7431, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "associate address query")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -33,3 +33,32 @@ func Classify(isWithin bool, centroidKM, edgeKM float64) string {
 	}
 	return ClassRural
 }
+
+// urbanCoreDensity is the tract population density (people per square
+// mile) at which a metropolitan core tract counts as urban core even far
+// from the MSA centroid, as in secondary downtowns of polycentric metros.
+const urbanCoreDensity = 4000.0
+
+// RefineWithRUCA refines a centroid-distance classification with the
+// tract's primary RUCA code and population density:
+//   - 1 (metropolitan core): urban_core if the distance heuristic says so
+//     or density >= 4,000/sq mi, otherwise suburban
+//   - 2-3 (metropolitan commuting): exurban
+//   - 4-10 (micropolitan, small town, rural): rural
+//
+// Codes outside 1-10 (99 = no population, 0 = unknown) keep class.
+func RefineWithRUCA(class string, primaryRUCA int, density float64) string {
+	switch {
+	case primaryRUCA == 1:
+		if class == ClassUrbanCore || density >= urbanCoreDensity {
+			return ClassUrbanCore
+		}
+		return ClassSuburban
+	case primaryRUCA == 2 || primaryRUCA == 3:
+		return ClassExurban
+	case primaryRUCA >= 4 && primaryRUCA <= 10:
+		return ClassRural
+	default:
+		return class
+	}
+}
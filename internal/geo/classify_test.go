@@ -86,3 +86,29 @@ func TestClassify(t *testing.T) {
 		})
 	}
 }
+
+func TestRefineWithRUCA(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    string
+		ruca     int
+		density  float64
+		expected string
+	}{
+		{"metro core keeps urban_core", ClassUrbanCore, 1, 1200, ClassUrbanCore},
+		{"dense metro core far from centroid", ClassSuburban, 1, 6500, ClassUrbanCore},
+		{"metro core outside MSA boundary", ClassExurban, 1, 900, ClassSuburban},
+		{"metro core without MSA association", "", 1, 0, ClassSuburban},
+		{"metro commuting inside MSA", ClassSuburban, 2, 150, ClassExurban},
+		{"metro low commuting", ClassRural, 3, 40, ClassExurban},
+		{"micropolitan core near MSA", ClassExurban, 4, 2100, ClassRural},
+		{"rural", ClassExurban, 10, 3, ClassRural},
+		{"no population keeps heuristic", ClassSuburban, 99, 0, ClassSuburban},
+		{"unknown keeps heuristic", ClassUrbanCore, 0, 0, ClassUrbanCore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RefineWithRUCA(tt.class, tt.ruca, tt.density))
+		})
+	}
+}
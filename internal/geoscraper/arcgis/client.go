@@ -1,14 +1,17 @@
-// Package arcgis provides a client for querying Esri ArcGIS FeatureServer REST
-// endpoints with automatic pagination. It is used by HIFLD, FEMA, EPA, and
-// other government scrapers that publish data via ArcGIS Online.
+// Package arcgis provides a client for querying Esri ArcGIS FeatureServer and
+// MapServer REST endpoints with automatic pagination, layer metadata
+// detection, and retries of transient service errors. It is used by HIFLD,
+// FEMA, EPA, and other government scrapers that publish data via ArcGIS.
 package arcgis
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,17 +19,28 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/resilience"
 )
 
 const defaultPageSize = 2000
 
 // QueryConfig configures an ArcGIS FeatureServer query.
 type QueryConfig struct {
-	BaseURL   string   // e.g., ".../FeatureServer/0/query"
+	BaseURL   string   // e.g., ".../FeatureServer/0/query"; a bare FeatureServer/MapServer layer URL gets /query appended
 	Where     string   // SQL WHERE clause (default "1=1")
 	OutFields []string // fields to return (default ["*"])
 	PageSize  int      // records per request (default 2000)
 	OutSR     int      // output spatial reference WKID (e.g., 4326 for WGS84); 0 = default to 4326
+
+	// AutoPageSize reads the layer's metadata before querying to respect
+	// its maxRecordCount and page by object id when resultOffset is
+	// unsupported. Costs one extra request.
+	AutoPageSize bool
+
+	// Retry controls retries of transient service errors, which ArcGIS
+	// returns with an HTTP 200 status the fetcher cannot see. The zero
+	// value uses resilience defaults (3 attempts).
+	Retry resilience.RetryConfig
 }
 
 // Feature represents a single ArcGIS feature with attributes and geometry.
@@ -139,10 +153,101 @@ func (g *Geometry) RingsToEWKT() string {
 	return sb.String()
 }
 
+// EWKT encodes the geometry as an SRID 4326 EWKT string for PostGIS:
+// POINT for points, MULTILINESTRING for polylines, and MULTIPOLYGON for
+// polygons. Polygon rings follow the Esri convention — clockwise rings are
+// exteriors and counter-clockwise rings are holes of the preceding exterior
+// — unlike RingsToEWKT, which makes every ring its own polygon. Returns ""
+// for an empty geometry.
+func (g *Geometry) EWKT() string {
+	var sb strings.Builder
+	switch {
+	case g.X != nil && g.Y != nil:
+		sb.WriteString("SRID=4326;POINT(")
+		writeCoord(&sb, [2]float64{*g.X, *g.Y})
+		sb.WriteString(")")
+	case len(g.Paths) > 0:
+		sb.WriteString("SRID=4326;MULTILINESTRING(")
+		for i, path := range g.Paths {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			writeCoords(&sb, path)
+		}
+		sb.WriteString(")")
+	case len(g.Rings) > 0:
+		sb.WriteString("SRID=4326;MULTIPOLYGON(")
+		for i, ring := range g.Rings {
+			switch {
+			case i == 0:
+				sb.WriteString("(")
+			case ringArea(ring) <= 0: // clockwise: a new exterior
+				sb.WriteString("),(")
+			default:
+				sb.WriteString(",")
+			}
+			writeCoords(&sb, ring)
+		}
+		sb.WriteString("))")
+	}
+	return sb.String()
+}
+
+// ringArea returns the signed shoelace area of a ring: negative for
+// clockwise rings.
+func ringArea(ring [][2]float64) float64 {
+	var a float64
+	for i := 0; i+1 < len(ring); i++ {
+		a += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return a / 2
+}
+
+func writeCoords(sb *strings.Builder, coords [][2]float64) {
+	sb.WriteString("(")
+	for i, c := range coords {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writeCoord(sb, c)
+	}
+	sb.WriteString(")")
+}
+
+func writeCoord(sb *strings.Builder, c [2]float64) {
+	sb.WriteString(strconv.FormatFloat(c[0], 'f', -1, 64))
+	sb.WriteString(" ")
+	sb.WriteString(strconv.FormatFloat(c[1], 'f', -1, 64))
+}
+
 // Response is the Esri JSON envelope returned by FeatureServer queries.
+// Services report failures in Error with an HTTP 200 status.
 type Response struct {
-	Features              []Feature `json:"features"`
-	ExceededTransferLimit bool      `json:"exceededTransferLimit"`
+	Features              []Feature     `json:"features"`
+	ExceededTransferLimit bool          `json:"exceededTransferLimit"`
+	Error                 *ServiceError `json:"error,omitempty"`
+}
+
+// ServiceError is the error object an ArcGIS service returns in place of
+// results (e.g., {"error":{"code":500,"message":"Error performing query operation"}}).
+type ServiceError struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details"`
+}
+
+func (e *ServiceError) Error() string {
+	msg := fmt.Sprintf("arcgis service error %d: %s", e.Code, e.Message)
+	if len(e.Details) > 0 {
+		msg += " (" + strings.Join(e.Details, "; ") + ")"
+	}
+	return msg
+}
+
+// Transient reports whether the error is a server-side failure worth
+// retrying (timeouts, overload, and 5xx codes).
+func (e *ServiceError) Transient() bool {
+	return resilience.IsTransientHTTPStatus(e.Code)
 }
 
 // PageCallback is invoked for each page of features during QueryAll pagination.
@@ -150,31 +255,59 @@ type PageCallback func(features []Feature) error
 
 // QueryAll pages through all features matching the query configuration, invoking
 // the callback for each page. Pagination stops when ExceededTransferLimit is false
-// or the callback returns an error.
+// or the callback returns an error. Pages that fail with a transient service
+// error are retried per cfg.Retry.
+//
+// With AutoPageSize, the layer's metadata is read first: pages are capped at
+// the layer's maxRecordCount, and layers that do not support resultOffset
+// are paged by object id instead.
 func QueryAll(ctx context.Context, f fetcher.Fetcher, cfg QueryConfig, callback PageCallback) error {
-	log := zap.L().With(zap.String("component", "arcgis"))
-
-	pageSize := cfg.PageSize
-	if pageSize <= 0 {
-		pageSize = defaultPageSize
+	q := query{
+		url:       QueryURL(cfg.BaseURL),
+		where:     cfg.Where,
+		outFields: "*",
+		pageSize:  cfg.PageSize,
+		outSR:     cfg.OutSR,
+		retry:     cfg.Retry,
 	}
-
-	where := cfg.Where
-	if where == "" {
-		where = "1=1"
+	if q.pageSize <= 0 {
+		q.pageSize = defaultPageSize
+	}
+	if q.where == "" {
+		q.where = "1=1"
 	}
-
-	outFields := "*"
 	if len(cfg.OutFields) > 0 {
-		for i, field := range cfg.OutFields {
-			if i > 0 {
-				outFields += ","
-			} else {
-				outFields = ""
-			}
-			outFields += field
+		q.outFields = strings.Join(cfg.OutFields, ",")
+	}
+
+	if cfg.AutoPageSize {
+		info, err := DescribeLayer(ctx, f, cfg.BaseURL)
+		if err != nil {
+			return err
+		}
+		if info.MaxRecordCount > 0 && info.MaxRecordCount < q.pageSize {
+			q.pageSize = info.MaxRecordCount
+		}
+		if !info.SupportsPagination && info.ObjectIDField != "" {
+			return q.byObjectID(ctx, f, info.ObjectIDField, callback)
 		}
 	}
+	return q.byOffset(ctx, f, callback)
+}
+
+// query is a resolved QueryConfig.
+type query struct {
+	url       string
+	where     string
+	outFields string
+	pageSize  int
+	outSR     int
+	retry     resilience.RetryConfig
+}
+
+// byOffset pages with resultOffset/resultRecordCount.
+func (q query) byOffset(ctx context.Context, f fetcher.Fetcher, callback PageCallback) error {
+	log := zap.L().With(zap.String("component", "arcgis"))
 
 	offset := 0
 	for {
@@ -184,27 +317,16 @@ func QueryAll(ctx context.Context, f fetcher.Fetcher, cfg QueryConfig, callback
 		default:
 		}
 
-		u, err := buildURL(cfg.BaseURL, where, outFields, pageSize, offset, cfg.OutSR)
+		u, err := buildURL(q.url, q.where, q.outFields, q.pageSize, offset, q.outSR, "")
 		if err != nil {
 			return eris.Wrap(err, "arcgis: build query URL")
 		}
 
 		log.Debug("fetching page", zap.String("url", u), zap.Int("offset", offset))
 
-		body, err := f.Download(ctx, u)
+		resp, err := fetchPage(ctx, f, u, q.retry)
 		if err != nil {
-			return eris.Wrapf(err, "arcgis: download page at offset %d", offset)
-		}
-
-		data, err := io.ReadAll(body)
-		_ = body.Close()
-		if err != nil {
-			return eris.Wrapf(err, "arcgis: read response at offset %d", offset)
-		}
-
-		var resp Response
-		if err := json.Unmarshal(data, &resp); err != nil {
-			return eris.Wrapf(err, "arcgis: decode response at offset %d", offset)
+			return eris.Wrapf(err, "arcgis: page at offset %d", offset)
 		}
 
 		if len(resp.Features) > 0 {
@@ -223,8 +345,120 @@ func QueryAll(ctx context.Context, f fetcher.Fetcher, cfg QueryConfig, callback
 	return nil
 }
 
-// buildURL constructs the ArcGIS query URL with pagination parameters.
-func buildURL(baseURL, where, outFields string, pageSize, offset, outSR int) (string, error) {
+// byObjectID pages by ascending object id (oidField > last id) for layers
+// that ignore resultOffset, such as pre-10.3 ArcGIS Server MapServers.
+func (q query) byObjectID(ctx context.Context, f fetcher.Fetcher, oidField string, callback PageCallback) error {
+	log := zap.L().With(zap.String("component", "arcgis"))
+
+	outFields := q.outFields
+	if outFields != "*" && !slices.Contains(strings.Split(outFields, ","), oidField) {
+		outFields += "," + oidField
+	}
+
+	last := int64(-1)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		where := q.where
+		if last >= 0 {
+			where = fmt.Sprintf("(%s) AND %s > %d", q.where, oidField, last)
+		}
+		u, err := buildURL(q.url, where, outFields, q.pageSize, -1, q.outSR, oidField+" ASC")
+		if err != nil {
+			return eris.Wrap(err, "arcgis: build query URL")
+		}
+
+		log.Debug("fetching page", zap.String("url", u), zap.Int64("after_oid", last))
+
+		resp, err := fetchPage(ctx, f, u, q.retry)
+		if err != nil {
+			return eris.Wrapf(err, "arcgis: page after %s %d", oidField, last)
+		}
+		if len(resp.Features) == 0 {
+			return nil
+		}
+		if err := callback(resp.Features); err != nil {
+			return eris.Wrap(err, "arcgis: callback error")
+		}
+
+		next, ok := maxObjectID(resp.Features, oidField)
+		if !ok || next <= last {
+			return eris.Errorf("arcgis: page after %s %d did not advance the object id", oidField, last)
+		}
+		last = next
+
+		if !resp.ExceededTransferLimit && len(resp.Features) < q.pageSize {
+			return nil
+		}
+	}
+}
+
+// maxObjectID returns the largest object id in features.
+func maxObjectID(features []Feature, oidField string) (int64, bool) {
+	maxID, found := int64(0), false
+	for _, feat := range features {
+		v, ok := feat.Attributes[oidField].(float64)
+		if !ok {
+			continue
+		}
+		if id := int64(v); !found || id > maxID {
+			maxID, found = id, true
+		}
+	}
+	return maxID, found
+}
+
+// fetchPage downloads and decodes one query page, retrying transient
+// service errors.
+func fetchPage(ctx context.Context, f fetcher.Fetcher, u string, retry resilience.RetryConfig) (*Response, error) {
+	retry.ShouldRetry = func(err error) bool {
+		var se *ServiceError
+		return errors.As(err, &se) && se.Transient()
+	}
+	if retry.OnRetry == nil {
+		retry.OnRetry = func(attempt int, err error) {
+			zap.L().Warn("arcgis: retrying page", zap.Int("attempt", attempt), zap.Error(err))
+		}
+	}
+
+	return resilience.DoVal(ctx, retry, func(ctx context.Context) (*Response, error) {
+		var resp Response
+		if err := getJSON(ctx, f, u, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return &resp, nil
+	})
+}
+
+// getJSON downloads u and decodes its JSON body into v.
+func getJSON(ctx context.Context, f fetcher.Fetcher, u string, v any) error {
+	body, err := f.Download(ctx, u)
+	if err != nil {
+		return eris.Wrap(err, "download page")
+	}
+
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return eris.Wrap(err, "read response")
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return eris.Wrap(err, "decode response")
+	}
+	return nil
+}
+
+// buildURL constructs the ArcGIS query URL with pagination parameters. A
+// negative offset omits resultOffset; orderBy sets orderByFields.
+func buildURL(baseURL, where, outFields string, pageSize, offset, outSR int, orderBy string) (string, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return "", eris.Wrapf(err, "parse base URL %q", baseURL)
@@ -236,7 +470,12 @@ func buildURL(baseURL, where, outFields string, pageSize, offset, outSR int) (st
 	q.Set("returnGeometry", "true")
 	q.Set("f", "json")
 	q.Set("resultRecordCount", strconv.Itoa(pageSize))
-	q.Set("resultOffset", strconv.Itoa(offset))
+	if offset >= 0 {
+		q.Set("resultOffset", strconv.Itoa(offset))
+	}
+	if orderBy != "" {
+		q.Set("orderByFields", orderBy)
+	}
 	if outSR <= 0 {
 		outSR = 4326 // default to WGS84 geographic coordinates
 	}
@@ -6,11 +6,13 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/resilience"
 )
 
 func newTestFetcher() fetcher.Fetcher {
@@ -394,3 +396,200 @@ func TestQueryAll_PolygonResponse(t *testing.T) {
 	ewkt := collected[0].Geometry.RingsToEWKT()
 	assert.Contains(t, ewkt, "SRID=4326;MULTIPOLYGON")
 }
+
+func TestQueryAll_ServiceErrorRetried(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			_, _ = w.Write([]byte(`{"error":{"code":504,"message":"Your request has timed out.","details":[]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"features":[{"attributes":{"ID":1}}],"exceededTransferLimit":false}`))
+	}))
+	defer srv.Close()
+
+	var total int
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL: srv.URL + "/query",
+		Retry:   resilience.RetryConfig{InitialBackoff: time.Millisecond},
+	}, func(features []Feature) error {
+		total += len(features)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 2, calls)
+}
+
+func TestQueryAll_ServiceErrorPermanent(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"Cannot perform query. Invalid query parameters.","details":["'where' parameter is invalid"]}}`))
+	}))
+	defer srv.Close()
+
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL: srv.URL + "/query",
+		Retry:   resilience.RetryConfig{InitialBackoff: time.Millisecond},
+	}, func(_ []Feature) error {
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "arcgis service error 400")
+	assert.Contains(t, err.Error(), "'where' parameter is invalid")
+	assert.Equal(t, 1, calls)
+
+	var se *ServiceError
+	require.ErrorAs(t, err, &se)
+	assert.False(t, se.Transient())
+}
+
+func TestQueryAll_BareLayerURL(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"features":[],"exceededTransferLimit":false}`))
+	}))
+	defer srv.Close()
+
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL: srv.URL + "/X/MapServer/4",
+	}, func(_ []Feature) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/X/MapServer/4/query", gotPath)
+}
+
+func TestQueryAll_AutoPageSize(t *testing.T) {
+	var pageSizes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/X/FeatureServer/0" {
+			_, _ = w.Write([]byte(`{"maxRecordCount":2,"objectIdField":"OBJECTID","advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		pageSizes = append(pageSizes, r.URL.Query().Get("resultRecordCount"))
+		if r.URL.Query().Get("resultOffset") == "0" {
+			_, _ = w.Write([]byte(`{"features":[{"attributes":{"OBJECTID":1}},{"attributes":{"OBJECTID":2}}],"exceededTransferLimit":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"features":[{"attributes":{"OBJECTID":3}}],"exceededTransferLimit":false}`))
+	}))
+	defer srv.Close()
+
+	var total int
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL:      srv.URL + "/X/FeatureServer/0/query",
+		AutoPageSize: true,
+	}, func(features []Feature) error {
+		total += len(features)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"2", "2"}, pageSizes)
+}
+
+func TestQueryAll_ObjectIDPaging(t *testing.T) {
+	var wheres []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/X/MapServer/1" {
+			_, _ = w.Write([]byte(`{"maxRecordCount":2,"objectIdField":"FID"}`))
+			return
+		}
+		q := r.URL.Query()
+		assert.Empty(t, q.Get("resultOffset"))
+		assert.Equal(t, "FID ASC", q.Get("orderByFields"))
+		assert.Equal(t, "NAME,FID", q.Get("outFields"))
+		wheres = append(wheres, q.Get("where"))
+		switch len(wheres) {
+		case 1:
+			_, _ = w.Write([]byte(`{"features":[{"attributes":{"FID":10}},{"attributes":{"FID":11}}],"exceededTransferLimit":true}`))
+		default:
+			_, _ = w.Write([]byte(`{"features":[{"attributes":{"FID":12}}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	var total int
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL:      srv.URL + "/X/MapServer/1/query",
+		Where:        "STATE='TX'",
+		OutFields:    []string{"NAME"},
+		AutoPageSize: true,
+	}, func(features []Feature) error {
+		total += len(features)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"STATE='TX'", "(STATE='TX') AND FID > 11"}, wheres)
+}
+
+func TestQueryAll_ObjectIDPagingStalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/X/MapServer/1" {
+			_, _ = w.Write([]byte(`{"objectIdField":"FID"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"features":[{"attributes":{"NAME":"no id"}}],"exceededTransferLimit":true}`))
+	}))
+	defer srv.Close()
+
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL:      srv.URL + "/X/MapServer/1/query",
+		AutoPageSize: true,
+	}, func(_ []Feature) error {
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not advance")
+}
+
+func TestQueryAll_AutoPageSizeDescribeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer srv.Close()
+
+	err := QueryAll(context.Background(), newTestFetcher(), QueryConfig{
+		BaseURL:      srv.URL + "/X/FeatureServer/0/query",
+		AutoPageSize: true,
+	}, func(_ []Feature) error {
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "describe layer")
+}
+
+func TestGeometry_EWKT_Point(t *testing.T) {
+	x, y := -95.5, 30.25
+	g := Geometry{X: &x, Y: &y}
+	assert.Equal(t, "SRID=4326;POINT(-95.5 30.25)", g.EWKT())
+}
+
+func TestGeometry_EWKT_Polyline(t *testing.T) {
+	g := Geometry{Paths: [][][2]float64{
+		{{-95, 30}, {-95.1, 30.1}},
+		{{-96, 31}, {-96.1, 31.1}},
+	}}
+	assert.Equal(t, "SRID=4326;MULTILINESTRING((-95 30,-95.1 30.1),(-96 31,-96.1 31.1))", g.EWKT())
+}
+
+func TestGeometry_EWKT_PolygonWithHole(t *testing.T) {
+	g := Geometry{Rings: [][][2]float64{
+		// Clockwise exterior, counter-clockwise hole, clockwise second exterior.
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}},
+		{{20, 20}, {20, 21}, {21, 21}, {21, 20}, {20, 20}},
+	}}
+	assert.Equal(t,
+		"SRID=4326;MULTIPOLYGON(((0 0,0 10,10 10,10 0,0 0),(2 2,4 2,4 4,2 4,2 2)),((20 20,20 21,21 21,21 20,20 20)))",
+		g.EWKT())
+}
+
+func TestGeometry_EWKT_Empty(t *testing.T) {
+	assert.Empty(t, (&Geometry{}).EWKT())
+}
@@ -0,0 +1,107 @@
+package arcgis
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+)
+
+// layerPath matches a FeatureServer or MapServer layer path without its
+// /query operation.
+var layerPath = regexp.MustCompile(`/(FeatureServer|MapServer)/\d+/?$`)
+
+// QueryURL returns the query endpoint for rawURL, appending /query to a
+// bare FeatureServer or MapServer layer URL. Other URLs are returned as-is.
+func QueryURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !layerPath.MatchString(u.Path) {
+		return rawURL
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/query"
+	return u.String()
+}
+
+// LayerURL returns the layer resource for a query endpoint, dropping the
+// /query operation and any query string.
+func LayerURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/query")
+	u.RawQuery = ""
+	return u.String()
+}
+
+// LayerInfo is the subset of a layer's metadata (GET <layer>?f=json) that
+// drives paging.
+type LayerInfo struct {
+	Name               string
+	Type               string // "Feature Layer", "Table", ...
+	GeometryType       string // "esriGeometryPoint", "esriGeometryPolygon", ...
+	MaxRecordCount     int    // server cap on features per page; 0 when unreported
+	SupportsPagination bool   // resultOffset/resultRecordCount are honored
+	ObjectIDField      string
+}
+
+// layerResponse is the layer metadata JSON.
+type layerResponse struct {
+	Name                      string `json:"name"`
+	Type                      string `json:"type"`
+	GeometryType              string `json:"geometryType"`
+	MaxRecordCount            int    `json:"maxRecordCount"`
+	ObjectIDField             string `json:"objectIdField"`
+	AdvancedQueryCapabilities *struct {
+		SupportsPagination bool `json:"supportsPagination"`
+	} `json:"advancedQueryCapabilities"`
+	Fields []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"fields"`
+	Error *ServiceError `json:"error,omitempty"`
+}
+
+// DescribeLayer fetches the metadata of the layer behind rawURL (a layer or
+// query URL). Servers that predate advancedQueryCapabilities report no
+// pagination support; the object id field falls back to the layer's
+// esriFieldTypeOID field.
+func DescribeLayer(ctx context.Context, f fetcher.Fetcher, rawURL string) (*LayerInfo, error) {
+	u, err := url.Parse(LayerURL(rawURL))
+	if err != nil {
+		return nil, eris.Wrapf(err, "arcgis: parse layer URL %q", rawURL)
+	}
+	u.RawQuery = url.Values{"f": {"json"}}.Encode()
+
+	var resp layerResponse
+	if err := getJSON(ctx, f, u.String(), &resp); err != nil {
+		return nil, eris.Wrap(err, "arcgis: describe layer")
+	}
+	if resp.Error != nil {
+		return nil, eris.Wrap(resp.Error, "arcgis: describe layer")
+	}
+
+	info := &LayerInfo{
+		Name:           resp.Name,
+		Type:           resp.Type,
+		GeometryType:   resp.GeometryType,
+		MaxRecordCount: resp.MaxRecordCount,
+		ObjectIDField:  resp.ObjectIDField,
+	}
+	if resp.AdvancedQueryCapabilities != nil {
+		info.SupportsPagination = resp.AdvancedQueryCapabilities.SupportsPagination
+	}
+	if info.ObjectIDField == "" {
+		for _, fld := range resp.Fields {
+			if fld.Type == "esriFieldTypeOID" {
+				info.ObjectIDField = fld.Name
+				break
+			}
+		}
+	}
+	return info, nil
+}
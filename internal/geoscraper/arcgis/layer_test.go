@@ -0,0 +1,86 @@
+package arcgis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://example.com/arcgis/rest/services/X/FeatureServer/0", "https://example.com/arcgis/rest/services/X/FeatureServer/0/query"},
+		{"https://example.com/arcgis/rest/services/X/MapServer/12/", "https://example.com/arcgis/rest/services/X/MapServer/12/query"},
+		{"https://example.com/arcgis/rest/services/X/FeatureServer/0/query", "https://example.com/arcgis/rest/services/X/FeatureServer/0/query"},
+		{"http://127.0.0.1:1/nonexistent", "http://127.0.0.1:1/nonexistent"},
+		{"://bad-url", "://bad-url"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, QueryURL(tt.in), tt.in)
+	}
+}
+
+func TestLayerURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/X/MapServer/3",
+		LayerURL("https://example.com/X/MapServer/3/query?where=1%3D1"))
+	assert.Equal(t, "https://example.com/X/FeatureServer/0",
+		LayerURL("https://example.com/X/FeatureServer/0"))
+}
+
+func TestDescribeLayer(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = w.Write([]byte(`{
+			"name": "Hospitals", "type": "Feature Layer", "geometryType": "esriGeometryPoint",
+			"maxRecordCount": 1000, "objectIdField": "FID",
+			"advancedQueryCapabilities": {"supportsPagination": true}
+		}`))
+	}))
+	defer srv.Close()
+
+	info, err := DescribeLayer(context.Background(), newTestFetcher(), srv.URL+"/X/FeatureServer/0/query")
+	require.NoError(t, err)
+	assert.Equal(t, "/X/FeatureServer/0", gotPath)
+	assert.Equal(t, "f=json", gotQuery)
+	assert.Equal(t, &LayerInfo{
+		Name:               "Hospitals",
+		Type:               "Feature Layer",
+		GeometryType:       "esriGeometryPoint",
+		MaxRecordCount:     1000,
+		SupportsPagination: true,
+		ObjectIDField:      "FID",
+	}, info)
+}
+
+func TestDescribeLayer_LegacyServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"name": "Parcels", "maxRecordCount": 500,
+			"fields": [{"name": "PIN", "type": "esriFieldTypeString"}, {"name": "OBJECTID_1", "type": "esriFieldTypeOID"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	info, err := DescribeLayer(context.Background(), newTestFetcher(), srv.URL+"/X/MapServer/2")
+	require.NoError(t, err)
+	assert.False(t, info.SupportsPagination)
+	assert.Equal(t, "OBJECTID_1", info.ObjectIDField)
+	assert.Equal(t, 500, info.MaxRecordCount)
+}
+
+func TestDescribeLayer_ServiceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error": {"code": 499, "message": "Token Required", "details": []}}`))
+	}))
+	defer srv.Close()
+
+	_, err := DescribeLayer(context.Background(), newTestFetcher(), srv.URL+"/X/FeatureServer/0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Token Required")
+}
@@ -1,5 +1,8 @@
 // Package geoscraper provides a framework for ingesting geospatial data from
 // national and state-level sources (HIFLD, FEMA, EPA, TIGER, Census, FCC, NRCS, OSM).
 // It mirrors the fedsync Dataset/Engine/Registry pattern, populating geo.* tables
-// and enqueuing addresses for geocoding via PostSync hooks.
+// and running post-sync hooks from the postsync registry: enqueue_geocode for
+// address producers plus any hooks a scraper declares or config assigns.
+// Scrapers that implement PostSyncer run their own follow-up work after a
+// successful sync.
 package geoscraper
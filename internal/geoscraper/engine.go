@@ -13,16 +13,18 @@ import (
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/postsync"
 )
 
 // Engine orchestrates geo scraper runs.
 type Engine struct {
-	pool    db.Pool
-	fetcher fetcher.Fetcher
-	syncLog *fedsync.SyncLog
-	reg     *Registry
-	queue   *geospatial.GeocodeQueue
-	tempDir string
+	pool     db.Pool
+	fetcher  fetcher.Fetcher
+	syncLog  *fedsync.SyncLog
+	reg      *Registry
+	queue    *geospatial.GeocodeQueue
+	tempDir  string
+	postSync *postsync.Runner
 }
 
 // RunOpts configures which scrapers to run and how.
@@ -36,15 +38,23 @@ type RunOpts struct {
 // NewEngine creates a new geo scraper engine.
 func NewEngine(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, reg *Registry, queue *geospatial.GeocodeQueue, tempDir string) *Engine {
 	return &Engine{
-		pool:    pool,
-		fetcher: f,
-		syncLog: syncLog,
-		reg:     reg,
-		queue:   queue,
-		tempDir: tempDir,
+		pool:     pool,
+		fetcher:  f,
+		syncLog:  syncLog,
+		reg:      reg,
+		queue:    queue,
+		tempDir:  tempDir,
+		postSync: postsync.NewRunner(pool, postsync.Builtins(queue, nil), syncLog, nil),
 	}
 }
 
+// SetPostSync replaces the post-sync hook runner. The default runner has
+// no notifier and no hook config, so scrapers run only the hooks they
+// declare.
+func (e *Engine) SetPostSync(r *postsync.Runner) {
+	e.postSync = r
+}
+
 // Run iterates over selected scrapers, checks scheduling, and runs syncs in parallel.
 func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 	log := zap.L().With(zap.String("component", "geoscraper.engine"))
@@ -127,12 +137,16 @@ func (e *Engine) Run(ctx context.Context, opts RunOpts) error {
 				sLog.Error("failed to record sync completion", zap.Error(err))
 			}
 
-			// PostSync: enqueue addresses for geocoding if applicable.
-			if e.queue != nil {
-				if ap, ok := s.(AddressProducer); ok && ap.HasAddresses() {
-					if psErr := PostSyncGeocode(gctx, e.pool, e.queue, s.Table(), result); psErr != nil {
-						sLog.Warn("postsync geocode failed", zap.Error(psErr))
-					}
+			e.postSync.Run(gctx, postsync.Target{
+				Source:  "geoscraper",
+				Dataset: s.Name(),
+				Table:   s.Table(),
+				SyncID:  syncID,
+				Rows:    result.RowsSynced,
+			}, DefaultHooks(s, e.queue != nil))
+			if ps, ok := s.(PostSyncer); ok {
+				if psErr := ps.PostSync(gctx, e.pool, result); psErr != nil {
+					sLog.Warn("postsync failed", zap.Error(psErr))
 				}
 			}
 
@@ -230,10 +230,15 @@ func TestEngine_Run_PostSyncWithAddressProducer(t *testing.T) {
 		WithArgs(int64(42), pgxmock.AnyArg(), int64(1)).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
-	// PostSyncGeocode: query for ungeocoded rows — return empty (no work).
+	// enqueue_geocode hook: query for ungeocoded rows — return empty (no work).
 	mock.ExpectQuery(`SELECT source_id, address FROM`).
 		WillReturnRows(pgxmock.NewRows([]string{"source_id", "address"}))
 
+	// Hook execution recorded against the sync.
+	mock.ExpectExec(`INSERT INTO fed_data\.sync_hooks`).
+		WithArgs(int64(1), "poi_scraper", "enqueue_geocode", "complete", int64(0), pgxmock.AnyArg(), "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
 	err := engine.Run(context.Background(), RunOpts{Force: true})
 	require.NoError(t, err)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -306,3 +311,37 @@ func TestEngine_Run_ShouldRunTrue(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+// postSyncScraper implements PostSyncer.
+type postSyncScraper struct {
+	mockScraper
+	err    error
+	called bool
+}
+
+func (p *postSyncScraper) PostSync(_ context.Context, _ db.Pool, _ *SyncResult) error {
+	p.called = true
+	return p.err
+}
+
+func TestEngine_Run_PostSyncer(t *testing.T) {
+	for _, psErr := range []error{nil, errors.New("tag failed")} {
+		s := &postSyncScraper{mockScraper: mockScraper{
+			name: "flood_scraper", table: "geo.flood_zones", category: National, run: true,
+		}, err: psErr}
+		engine, mock := setupEngine(t, s)
+
+		mock.ExpectQuery(`INSERT INTO fed_data\.sync_log`).
+			WithArgs("flood_scraper").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+		mock.ExpectExec(`UPDATE fed_data\.sync_log`).
+			WithArgs(int64(42), pgxmock.AnyArg(), int64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// A PostSync error is logged, not returned.
+		require.NoError(t, engine.Run(context.Background(), RunOpts{Force: true}))
+		assert.True(t, s.called)
+		require.NoError(t, mock.ExpectationsWereMet())
+		mock.Close()
+	}
+}
@@ -102,3 +102,10 @@ type AddressProducer interface {
 	// HasAddresses returns true if the scraper's target table contains an address column.
 	HasAddresses() bool
 }
+
+// PostSyncer is an optional interface scrapers implement to run follow-up
+// work after a successful sync, such as tagging rows in other tables with
+// the newly loaded layer. Errors are logged and do not fail the sync.
+type PostSyncer interface {
+	PostSync(ctx context.Context, pool db.Pool, result *SyncResult) error
+}
@@ -0,0 +1,445 @@
+// Package osmpbf reads OpenStreetMap PBF extracts such as the Geofabrik
+// per-state downloads. It decodes nodes (plain and dense) and ways and
+// hands them to callbacks one at a time so state-sized files stream without
+// being held in memory. Relations and element metadata are skipped.
+package osmpbf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/rotisserie/eris"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	maxBlobHeaderSize = 64 * 1024
+	maxBlobSize       = 32 * 1024 * 1024
+)
+
+// supportedFeatures are the header required_features this reader handles.
+var supportedFeatures = map[string]bool{
+	"OsmSchema-V0.6": true,
+	"DenseNodes":     true,
+}
+
+// Node is an OSM node. Tags is nil for untagged nodes.
+type Node struct {
+	ID   int64
+	Lat  float64
+	Lon  float64
+	Tags map[string]string
+}
+
+// Way is an OSM way with the ids of its member nodes in order.
+type Way struct {
+	ID   int64
+	Refs []int64
+	Tags map[string]string
+}
+
+// Handler receives decoded elements. A nil callback skips decoding that
+// element type entirely; a callback error stops the scan and is returned.
+type Handler struct {
+	Node func(Node) error
+	Way  func(Way) error
+}
+
+// Scan reads a PBF stream and calls h for every node and way in file order.
+func Scan(ctx context.Context, r io.Reader, h Handler) error {
+	var sizeBuf [4]byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(r, sizeBuf[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return eris.Wrap(err, "osmpbf: read blob header size")
+		}
+		size := binary.BigEndian.Uint32(sizeBuf[:])
+		if size > maxBlobHeaderSize {
+			return eris.Errorf("osmpbf: blob header of %d bytes exceeds limit", size)
+		}
+		headerBuf := make([]byte, size)
+		if _, err := io.ReadFull(r, headerBuf); err != nil {
+			return eris.Wrap(err, "osmpbf: read blob header")
+		}
+		blobType, dataSize, err := parseBlobHeader(headerBuf)
+		if err != nil {
+			return err
+		}
+		if dataSize > maxBlobSize {
+			return eris.Errorf("osmpbf: blob of %d bytes exceeds limit", dataSize)
+		}
+		blobBuf := make([]byte, dataSize)
+		if _, err := io.ReadFull(r, blobBuf); err != nil {
+			return eris.Wrap(err, "osmpbf: read blob")
+		}
+
+		switch blobType {
+		case "OSMHeader":
+			data, err := decodeBlob(blobBuf)
+			if err != nil {
+				return err
+			}
+			if err := checkHeader(data); err != nil {
+				return err
+			}
+		case "OSMData":
+			if h.Node == nil && h.Way == nil {
+				continue
+			}
+			data, err := decodeBlob(blobBuf)
+			if err != nil {
+				return err
+			}
+			if err := decodePrimitiveBlock(data, h); err != nil {
+				return err
+			}
+		default:
+			// Unknown blob types are skippable by spec.
+		}
+	}
+}
+
+// fields iterates the top-level fields of a protobuf message.
+func fields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return eris.Wrap(protowire.ParseError(n), "osmpbf: decode tag")
+		}
+		b = b[n:]
+		var (
+			v []byte
+			x uint64
+		)
+		switch typ {
+		case protowire.VarintType:
+			x, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return eris.Wrap(protowire.ParseError(n), "osmpbf: decode field")
+		}
+		b = b[n:]
+		if err := fn(num, typ, v, x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseBlobHeader(b []byte) (blobType string, dataSize uint64, err error) {
+	err = fields(b, func(num protowire.Number, _ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			blobType = string(v)
+		case 3:
+			dataSize = x
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return blobType, dataSize, nil
+}
+
+// decodeBlob returns the uncompressed payload of a Blob. Only raw and zlib
+// payloads are supported; Geofabrik writes zlib.
+func decodeBlob(b []byte) ([]byte, error) {
+	var (
+		raw, zdata []byte
+		rawSize    uint64
+		other      bool
+	)
+	err := fields(b, func(num protowire.Number, _ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			raw = v
+		case 2:
+			rawSize = x
+		case 3:
+			zdata = v
+		case 4, 5, 6, 7:
+			other = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case raw != nil:
+		return raw, nil
+	case zdata != nil:
+		if rawSize > maxBlobSize {
+			return nil, eris.Errorf("osmpbf: blob of %d bytes exceeds limit", rawSize)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(zdata))
+		if err != nil {
+			return nil, eris.Wrap(err, "osmpbf: open zlib blob")
+		}
+		defer zr.Close() //nolint:errcheck
+		out := bytes.NewBuffer(make([]byte, 0, rawSize))
+		if _, err := io.Copy(out, io.LimitReader(zr, maxBlobSize+1)); err != nil {
+			return nil, eris.Wrap(err, "osmpbf: inflate blob")
+		}
+		return out.Bytes(), nil
+	case other:
+		return nil, eris.New("osmpbf: unsupported blob compression")
+	default:
+		return nil, nil
+	}
+}
+
+// checkHeader rejects files that require features this reader lacks, such
+// as history extracts.
+func checkHeader(b []byte) error {
+	return fields(b, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) error {
+		if num == 4 && !supportedFeatures[string(v)] {
+			return eris.Errorf("osmpbf: unsupported required feature %q", string(v))
+		}
+		return nil
+	})
+}
+
+// block holds the string table and coordinate scaling of a PrimitiveBlock.
+type block struct {
+	strings     [][]byte
+	granularity int64
+	latOffset   int64
+	lonOffset   int64
+}
+
+func (bl *block) coord(offset, v int64) float64 {
+	return 1e-9 * float64(offset+bl.granularity*v)
+}
+
+func (bl *block) str(i uint64) (string, error) {
+	if i >= uint64(len(bl.strings)) {
+		return "", eris.Errorf("osmpbf: string index %d out of range", i)
+	}
+	return string(bl.strings[i]), nil
+}
+
+func decodePrimitiveBlock(b []byte, h Handler) error {
+	bl := &block{granularity: 100}
+	var groups [][]byte
+	err := fields(b, func(num protowire.Number, _ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			return fields(v, func(num protowire.Number, _ protowire.Type, s []byte, _ uint64) error {
+				if num == 1 {
+					bl.strings = append(bl.strings, s)
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, v)
+		case 17:
+			bl.granularity = int64(int32(x))
+		case 19:
+			bl.latOffset = int64(x)
+		case 20:
+			bl.lonOffset = int64(x)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		err := fields(g, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) error {
+			switch {
+			case num == 1 && h.Node != nil:
+				return bl.decodeNode(v, h.Node)
+			case num == 2 && h.Node != nil:
+				return bl.decodeDense(v, h.Node)
+			case num == 3 && h.Way != nil:
+				return bl.decodeWay(v, h.Way)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bl *block) decodeNode(b []byte, fn func(Node) error) error {
+	var (
+		n          Node
+		keys, vals []uint64
+		lat, lon   int64
+	)
+	err := fields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			n.ID = protowire.DecodeZigZag(x)
+		case 2:
+			return appendVarints(&keys, typ, v, x)
+		case 3:
+			return appendVarints(&vals, typ, v, x)
+		case 8:
+			lat = protowire.DecodeZigZag(x)
+		case 9:
+			lon = protowire.DecodeZigZag(x)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if n.Tags, err = bl.tags(keys, vals); err != nil {
+		return err
+	}
+	n.Lat = bl.coord(bl.latOffset, lat)
+	n.Lon = bl.coord(bl.lonOffset, lon)
+	return fn(n)
+}
+
+func (bl *block) decodeDense(b []byte, fn func(Node) error) error {
+	var ids, lats, lons, keysVals []uint64
+	err := fields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			return appendVarints(&ids, typ, v, x)
+		case 8:
+			return appendVarints(&lats, typ, v, x)
+		case 9:
+			return appendVarints(&lons, typ, v, x)
+		case 10:
+			return appendVarints(&keysVals, typ, v, x)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(lats) != len(ids) || len(lons) != len(ids) {
+		return eris.New("osmpbf: dense node arrays differ in length")
+	}
+
+	var id, lat, lon int64
+	kv := 0
+	for i := range ids {
+		id += protowire.DecodeZigZag(ids[i])
+		lat += protowire.DecodeZigZag(lats[i])
+		lon += protowire.DecodeZigZag(lons[i])
+		n := Node{ID: id, Lat: bl.coord(bl.latOffset, lat), Lon: bl.coord(bl.lonOffset, lon)}
+
+		// keys_vals holds key,value string indexes per node, each node's
+		// run terminated by 0. It is empty when no node in the block has tags.
+		for kv < len(keysVals) && keysVals[kv] != 0 {
+			if kv+1 >= len(keysVals) {
+				return eris.New("osmpbf: dense keys_vals truncated")
+			}
+			k, err := bl.str(keysVals[kv])
+			if err != nil {
+				return err
+			}
+			v, err := bl.str(keysVals[kv+1])
+			if err != nil {
+				return err
+			}
+			if n.Tags == nil {
+				n.Tags = make(map[string]string)
+			}
+			n.Tags[k] = v
+			kv += 2
+		}
+		kv++
+
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bl *block) decodeWay(b []byte, fn func(Way) error) error {
+	var (
+		w          Way
+		keys, vals []uint64
+		refs       []uint64
+	)
+	err := fields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			w.ID = int64(x)
+		case 2:
+			return appendVarints(&keys, typ, v, x)
+		case 3:
+			return appendVarints(&vals, typ, v, x)
+		case 8:
+			return appendVarints(&refs, typ, v, x)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if w.Tags, err = bl.tags(keys, vals); err != nil {
+		return err
+	}
+	w.Refs = make([]int64, len(refs))
+	var ref int64
+	for i, d := range refs {
+		ref += protowire.DecodeZigZag(d)
+		w.Refs[i] = ref
+	}
+	return fn(w)
+}
+
+func (bl *block) tags(keys, vals []uint64) (map[string]string, error) {
+	if len(keys) != len(vals) {
+		return nil, eris.New("osmpbf: tag keys and values differ in length")
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string]string, len(keys))
+	for i := range keys {
+		k, err := bl.str(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		v, err := bl.str(vals[i])
+		if err != nil {
+			return nil, err
+		}
+		tags[k] = v
+	}
+	return tags, nil
+}
+
+// appendVarints appends a repeated varint field, which encoders may write
+// packed (one length-delimited run) or as individual varints.
+func appendVarints(dst *[]uint64, typ protowire.Type, v []byte, x uint64) error {
+	if typ == protowire.VarintType {
+		*dst = append(*dst, x)
+		return nil
+	}
+	for len(v) > 0 {
+		x, n := protowire.ConsumeVarint(v)
+		if n < 0 {
+			return eris.Wrap(protowire.ParseError(n), "osmpbf: decode packed field")
+		}
+		*dst = append(*dst, x)
+		v = v[n:]
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package osmpbf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var testNodes = []Node{
+	{ID: 100, Lat: 30.2672, Lon: -97.7431, Tags: map[string]string{"office": "lawyer", "name": "Smith & Jones"}},
+	{ID: 101, Lat: 30.2680, Lon: -97.7440},
+	{ID: 105, Lat: 30.2690, Lon: -97.7420, Tags: map[string]string{"amenity": "bank"}},
+	{ID: 90, Lat: -33.8688, Lon: 151.2093},
+}
+
+var testWays = []Way{
+	{ID: 7, Refs: []int64{101, 105, 90, 101}, Tags: map[string]string{"building": "office", "office": "company"}},
+	{ID: 8, Refs: []int64{100}},
+}
+
+func writeTestPBF(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testNodes, testWays))
+	return buf.Bytes()
+}
+
+func TestScan_RoundTrip(t *testing.T) {
+	var nodes []Node
+	var ways []Way
+	err := Scan(context.Background(), bytes.NewReader(writeTestPBF(t)), Handler{
+		Node: func(n Node) error { nodes = append(nodes, n); return nil },
+		Way:  func(w Way) error { ways = append(ways, w); return nil },
+	})
+	require.NoError(t, err)
+
+	require.Len(t, nodes, len(testNodes))
+	for i, want := range testNodes {
+		assert.Equal(t, want.ID, nodes[i].ID)
+		assert.InDelta(t, want.Lat, nodes[i].Lat, 1e-7)
+		assert.InDelta(t, want.Lon, nodes[i].Lon, 1e-7)
+		assert.Equal(t, want.Tags, nodes[i].Tags)
+	}
+	assert.Nil(t, nodes[1].Tags, "untagged node")
+	assert.Equal(t, testWays, ways)
+}
+
+func TestScan_NilHandlersSkip(t *testing.T) {
+	var ways int
+	err := Scan(context.Background(), bytes.NewReader(writeTestPBF(t)), Handler{
+		Way: func(Way) error { ways++; return nil },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, ways)
+
+	require.NoError(t, Scan(context.Background(), bytes.NewReader(writeTestPBF(t)), Handler{}))
+}
+
+func TestScan_CallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	err := Scan(context.Background(), bytes.NewReader(writeTestPBF(t)), Handler{
+		Node: func(Node) error { return stop },
+	})
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestScan_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Scan(ctx, bytes.NewReader(writeTestPBF(t)), Handler{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScan_Truncated(t *testing.T) {
+	data := writeTestPBF(t)
+	err := Scan(context.Background(), bytes.NewReader(data[:len(data)-10]), Handler{
+		Node: func(Node) error { return nil },
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read blob")
+}
+
+// rawBlob frames an uncompressed blob with its header.
+func rawBlob(blobType string, data []byte) []byte {
+	blob := appendMessage(nil, 1, data)
+	header := protowire.AppendTag(nil, 1, protowire.BytesType)
+	header = protowire.AppendString(header, blobType)
+	header = protowire.AppendTag(header, 3, protowire.VarintType)
+	header = protowire.AppendVarint(header, uint64(len(blob)))
+
+	out := binary.BigEndian.AppendUint32(nil, uint32(len(header)))
+	out = append(out, header...)
+	return append(out, blob...)
+}
+
+func TestScan_PlainNodes(t *testing.T) {
+	var strTable []byte
+	for _, s := range []string{"", "office", "accountant"} {
+		strTable = protowire.AppendTag(strTable, 1, protowire.BytesType)
+		strTable = protowire.AppendString(strTable, s)
+	}
+
+	node := protowire.AppendTag(nil, 1, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(42))
+	// Unpacked keys and vals.
+	node = protowire.AppendTag(node, 2, protowire.VarintType)
+	node = protowire.AppendVarint(node, 1)
+	node = protowire.AppendTag(node, 3, protowire.VarintType)
+	node = protowire.AppendVarint(node, 2)
+	node = protowire.AppendTag(node, 8, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(400))
+	node = protowire.AppendTag(node, 9, protowire.VarintType)
+	node = protowire.AppendVarint(node, protowire.EncodeZigZag(-800))
+
+	block := appendMessage(nil, 1, strTable)
+	block = appendMessage(block, 2, appendMessage(nil, 1, node))
+	block = protowire.AppendTag(block, 17, protowire.VarintType)
+	block = protowire.AppendVarint(block, 1000)
+	block = protowire.AppendTag(block, 19, protowire.VarintType)
+	block = protowire.AppendVarint(block, uint64(int64(1_000_000_000)))
+
+	var nodes []Node
+	err := Scan(context.Background(), bytes.NewReader(rawBlob("OSMData", block)), Handler{
+		Node: func(n Node) error { nodes = append(nodes, n); return nil },
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, int64(42), nodes[0].ID)
+	assert.InDelta(t, 1.0004, nodes[0].Lat, 1e-9)
+	assert.InDelta(t, -0.0008, nodes[0].Lon, 1e-9)
+	assert.Equal(t, map[string]string{"office": "accountant"}, nodes[0].Tags)
+}
+
+func TestScan_UnsupportedFeature(t *testing.T) {
+	header := protowire.AppendTag(nil, 4, protowire.BytesType)
+	header = protowire.AppendString(header, "HistoricalInformation")
+
+	err := Scan(context.Background(), bytes.NewReader(rawBlob("OSMHeader", header)), Handler{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported required feature "HistoricalInformation"`)
+}
+
+func TestScan_UnsupportedCompression(t *testing.T) {
+	blob := appendMessage(nil, 4, []byte("lzma"))
+	header := protowire.AppendTag(nil, 1, protowire.BytesType)
+	header = protowire.AppendString(header, "OSMData")
+	header = protowire.AppendTag(header, 3, protowire.VarintType)
+	header = protowire.AppendVarint(header, uint64(len(blob)))
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(header)))
+	data = append(append(data, header...), blob...)
+
+	err := Scan(context.Background(), bytes.NewReader(data), Handler{Node: func(Node) error { return nil }})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported blob compression")
+}
+
+func TestScan_StringIndexOutOfRange(t *testing.T) {
+	way := protowire.AppendTag(nil, 1, protowire.VarintType)
+	way = protowire.AppendVarint(way, 1)
+	way = appendMessage(way, 2, protowire.AppendVarint(nil, 5))
+	way = appendMessage(way, 3, protowire.AppendVarint(nil, 6))
+	block := appendMessage(nil, 2, appendMessage(nil, 3, way))
+
+	err := Scan(context.Background(), bytes.NewReader(rawBlob("OSMData", block)), Handler{
+		Way: func(Way) error { return nil },
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "string index 5 out of range")
+}
+
+func TestScan_Empty(t *testing.T) {
+	require.NoError(t, Scan(context.Background(), bytes.NewReader(nil), Handler{}))
+}
@@ -0,0 +1,160 @@
+package osmpbf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/rotisserie/eris"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Write encodes nodes and ways as a PBF file with a header block and one
+// zlib-compressed data block, using dense nodes at the default 100
+// nanodegree granularity. It suits small extracts and test fixtures; large
+// inputs would exceed the per-block size limit.
+func Write(w io.Writer, nodes []Node, ways []Way) error {
+	var header []byte
+	for _, f := range []string{"OsmSchema-V0.6", "DenseNodes"} {
+		header = protowire.AppendTag(header, 4, protowire.BytesType)
+		header = protowire.AppendString(header, f)
+	}
+	if err := writeBlob(w, "OSMHeader", header); err != nil {
+		return err
+	}
+
+	st := newStringTable()
+	var groups []byte
+	if len(nodes) > 0 {
+		groups = appendMessage(groups, 2, appendMessage(nil, 2, encodeDense(nodes, st)))
+	}
+	if len(ways) > 0 {
+		var g []byte
+		for _, way := range ways {
+			g = appendMessage(g, 3, encodeWay(way, st))
+		}
+		groups = appendMessage(groups, 2, g)
+	}
+
+	var strTable []byte
+	for _, s := range st.list {
+		strTable = protowire.AppendTag(strTable, 1, protowire.BytesType)
+		strTable = protowire.AppendString(strTable, s)
+	}
+	data := appendMessage(nil, 1, strTable)
+	data = append(data, groups...)
+	return writeBlob(w, "OSMData", data)
+}
+
+// stringTable interns tag strings; index 0 is reserved as the empty
+// delimiter string.
+type stringTable struct {
+	list  []string
+	index map[string]uint64
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{list: []string{""}, index: map[string]uint64{"": 0}}
+}
+
+func (st *stringTable) id(s string) uint64 {
+	if i, ok := st.index[s]; ok {
+		return i
+	}
+	i := uint64(len(st.list))
+	st.list = append(st.list, s)
+	st.index[s] = i
+	return i
+}
+
+// sortedKeys returns tag keys in a stable order so output is deterministic.
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func encodeDense(nodes []Node, st *stringTable) []byte {
+	var ids, lats, lons, keysVals []byte
+	var prevID, prevLat, prevLon int64
+	for _, n := range nodes {
+		lat := int64(math.Round(n.Lat * 1e7))
+		lon := int64(math.Round(n.Lon * 1e7))
+		ids = protowire.AppendVarint(ids, protowire.EncodeZigZag(n.ID-prevID))
+		lats = protowire.AppendVarint(lats, protowire.EncodeZigZag(lat-prevLat))
+		lons = protowire.AppendVarint(lons, protowire.EncodeZigZag(lon-prevLon))
+		prevID, prevLat, prevLon = n.ID, lat, lon
+		for _, k := range sortedKeys(n.Tags) {
+			keysVals = protowire.AppendVarint(keysVals, st.id(k))
+			keysVals = protowire.AppendVarint(keysVals, st.id(n.Tags[k]))
+		}
+		keysVals = protowire.AppendVarint(keysVals, 0)
+	}
+
+	var b []byte
+	b = appendMessage(b, 1, ids)
+	b = appendMessage(b, 8, lats)
+	b = appendMessage(b, 9, lons)
+	b = appendMessage(b, 10, keysVals)
+	return b
+}
+
+func encodeWay(w Way, st *stringTable) []byte {
+	var keys, vals, refs []byte
+	for _, k := range sortedKeys(w.Tags) {
+		keys = protowire.AppendVarint(keys, st.id(k))
+		vals = protowire.AppendVarint(vals, st.id(w.Tags[k]))
+	}
+	var prev int64
+	for _, ref := range w.Refs {
+		refs = protowire.AppendVarint(refs, protowire.EncodeZigZag(ref-prev))
+		prev = ref
+	}
+
+	b := protowire.AppendTag(nil, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(w.ID))
+	b = appendMessage(b, 2, keys)
+	b = appendMessage(b, 3, vals)
+	b = appendMessage(b, 8, refs)
+	return b
+}
+
+func appendMessage(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func writeBlob(w io.Writer, blobType string, data []byte) error {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return eris.Wrap(err, "osmpbf: compress blob")
+	}
+	if err := zw.Close(); err != nil {
+		return eris.Wrap(err, "osmpbf: compress blob")
+	}
+
+	blob := protowire.AppendTag(nil, 2, protowire.VarintType)
+	blob = protowire.AppendVarint(blob, uint64(len(data)))
+	blob = appendMessage(blob, 3, z.Bytes())
+
+	header := protowire.AppendTag(nil, 1, protowire.BytesType)
+	header = protowire.AppendString(header, blobType)
+	header = protowire.AppendTag(header, 3, protowire.VarintType)
+	header = protowire.AppendVarint(header, uint64(len(blob)))
+
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(header)))
+	for _, part := range [][]byte{size[:], header, blob} {
+		if _, err := w.Write(part); err != nil {
+			return eris.Wrap(err, "osmpbf: write blob")
+		}
+	}
+	return nil
+}
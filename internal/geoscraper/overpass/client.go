@@ -23,6 +23,14 @@ type Element struct {
 	Lat  float64           `json:"lat"`
 	Lon  float64           `json:"lon"`
 	Tags map[string]string `json:"tags"`
+	// Center is set for ways and relations queried with "out center".
+	Center *Point `json:"center,omitempty"`
+}
+
+// Point is a latitude/longitude pair.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
 
 // Response is the Overpass API response envelope.
@@ -120,3 +120,18 @@ func TestQuery_PostsFormData(t *testing.T) {
 	assert.Equal(t, "application/x-www-form-urlencoded", receivedContentType)
 	assert.Contains(t, receivedBody, "data=")
 }
+
+func TestQuery_WayCenter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"elements": [{"type": "way", "id": 9, "center": {"lat": 30.5, "lon": -97.5}, "tags": {"office": "it"}}]}`))
+	}))
+	defer srv.Close()
+
+	elems, err := Query(context.Background(), srv.URL, "[out:json];way(9);out center;")
+	require.NoError(t, err)
+	require.Len(t, elems, 1)
+	require.NotNil(t, elems[0].Center)
+	assert.InDelta(t, 30.5, elems[0].Center.Lat, 1e-9)
+	assert.InDelta(t, -97.5, elems[0].Center.Lon, 1e-9)
+	assert.Zero(t, elems[0].Lat)
+}
@@ -10,24 +10,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/postsync"
 )
 
-func TestSanitizeTableParts(t *testing.T) {
-	tests := []struct {
-		input string
-		want  []string
-	}{
-		{"geo.poi", []string{"geo", "poi"}},
-		{"geo.infrastructure", []string{"geo", "infrastructure"}},
-		{"simple_table", []string{"simple_table"}},
-	}
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			assert.Equal(t, tt.want, sanitizeTableParts(tt.input))
-		})
-	}
-}
-
 func TestPostSyncGeocode_QueryError(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -82,8 +67,8 @@ func TestPostSyncGeocode_EnqueueBatchError(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"source_id", "address"}).
 			AddRow("src1", "123 Main St"))
 
-	// EnqueueBatch calls pool.Begin which will fail.
-	mock.ExpectBegin().WillReturnError(errors.New("begin failed"))
+	mock.ExpectExec(`INSERT INTO geo\.geocode_queue`).
+		WillReturnError(errors.New("insert failed"))
 
 	queue := geospatial.NewGeocodeQueue(mock, nil, 100)
 	err = PostSyncGeocode(context.Background(), mock, queue, "geo.poi", &SyncResult{RowsSynced: 1})
@@ -91,7 +76,7 @@ func TestPostSyncGeocode_EnqueueBatchError(t *testing.T) {
 	assert.Contains(t, err.Error(), "postsync: enqueue batch")
 }
 
-func TestPostSyncGeocode_SmallBatchProcessesImmediately(t *testing.T) {
+func TestPostSyncGeocode_SmallBatchWithoutGeocoder(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
@@ -100,19 +85,41 @@ func TestPostSyncGeocode_SmallBatchProcessesImmediately(t *testing.T) {
 		WillReturnRows(pgxmock.NewRows([]string{"source_id", "address"}).
 			AddRow("src1", "123 Main St"))
 
-	// EnqueueBatch: Begin + Exec + Commit.
-	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO geo\.geocode_queue`).
-		WithArgs("geo.poi", "src1", "123 Main St").
+		WithArgs("geo.poi", []string{"src1"}, []string{"123 Main St"}, pgxmock.AnyArg(), pgxmock.AnyArg()).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectCommit()
 
-	// ProcessBatch starts Begin, but we return an error to keep the test simple.
-	// This covers the "small batch → ProcessBatch attempted" code path.
-	mock.ExpectBegin().WillReturnError(errors.New("process batch unavailable"))
+	// The queue has no geocoder, so the immediate ProcessBatch claims
+	// nothing and the rows are left for the geocode worker.
 
 	queue := geospatial.NewGeocodeQueue(mock, nil, 100)
 	err = PostSyncGeocode(context.Background(), mock, queue, "geo.poi", &SyncResult{RowsSynced: 1})
 	require.NoError(t, err) // ProcessBatch error is logged, not returned
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPostSyncGeocode_QueueFull(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT source_id, address FROM`).
+		WillReturnRows(pgxmock.NewRows([]string{"source_id", "address"}).
+			AddRow("src1", "123 Main St"))
+
+	// Queue is at max depth: nothing is enqueued and no immediate batch runs.
+	mock.ExpectQuery(`SELECT count\(\*\) FROM geo\.geocode_queue`).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(5))
+
+	queue := geospatial.NewGeocodeQueue(mock, nil, 100).WithMaxDepth(5)
+	err = PostSyncGeocode(context.Background(), mock, queue, "geo.poi", &SyncResult{RowsSynced: 1})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultHooks(t *testing.T) {
+	ap := &mockAddressProducer{}
+	assert.Equal(t, []string{postsync.EnqueueGeocode}, DefaultHooks(ap, true))
+	assert.Empty(t, DefaultHooks(ap, false))
+	assert.Empty(t, DefaultHooks(&mockScraper{}, true))
+}
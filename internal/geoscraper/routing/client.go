@@ -0,0 +1,80 @@
+// Package routing provides drive-time isochrone clients for self-hosted or
+// public routing engines. Valhalla computes isochrone polygons natively;
+// OSRM has no isochrone service, so its client approximates one from a
+// radial grid of table-service durations.
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// Providers supported by New.
+const (
+	Valhalla = "valhalla"
+	OSRM     = "osrm"
+)
+
+// maxErrorBody caps how much of an error response is quoted in errors.
+const maxErrorBody = 512
+
+// Isochrone is the area reachable from an origin within Minutes of
+// driving. GeoJSON is a Polygon or MultiPolygon geometry in WGS84.
+type Isochrone struct {
+	Minutes int
+	GeoJSON string
+}
+
+// Client computes drive-time isochrones around a point.
+type Client interface {
+	// Provider returns the routing engine name.
+	Provider() string
+	// Isochrones returns one isochrone per entry of minutes, in the same
+	// order.
+	Isochrones(ctx context.Context, lat, lng float64, minutes []int) ([]Isochrone, error)
+}
+
+// New returns the client for provider (valhalla or osrm) at endpoint.
+// profile is the engine's costing or profile name; empty uses the driving
+// default.
+func New(provider, endpoint, profile string) (Client, error) {
+	if endpoint == "" {
+		return nil, eris.Errorf("routing: no endpoint for %s", provider)
+	}
+	switch provider {
+	case Valhalla, "":
+		return NewValhalla(endpoint, profile), nil
+	case OSRM:
+		return NewOSRM(endpoint, profile), nil
+	default:
+		return nil, eris.Errorf("routing: unknown provider %q", provider)
+	}
+}
+
+// newHTTPClient returns the HTTP client shared by the routing clients.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 60 * time.Second}
+}
+
+// doJSON sends req and decodes a 200 response into out.
+func doJSON(client *http.Client, req *http.Request, provider string, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return eris.Wrapf(err, "%s: execute request", provider)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return eris.Errorf("%s: HTTP %d: %s", provider, resp.StatusCode, string(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return eris.Wrapf(err, "%s: decode response", provider)
+	}
+	return nil
+}
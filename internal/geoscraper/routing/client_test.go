@@ -0,0 +1,118 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	c, err := New("", "http://valhalla:8002", "")
+	require.NoError(t, err)
+	assert.Equal(t, Valhalla, c.Provider())
+
+	c, err = New(OSRM, "http://osrm:5000", "")
+	require.NoError(t, err)
+	assert.Equal(t, OSRM, c.Provider())
+
+	_, err = New(Valhalla, "", "")
+	require.Error(t, err)
+	_, err = New("graphhopper", "http://gh", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown provider "graphhopper"`)
+}
+
+func TestValhalla_Isochrones(t *testing.T) {
+	var got valhallaRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/isochrone", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = fmt.Fprint(w, `{"type":"FeatureCollection","features":[
+			{"type":"Feature","properties":{"contour":30},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}},
+			{"type":"Feature","properties":{"contour":15},"geometry":{"type":"Polygon","coordinates":[[[0,0],[0.5,0],[0.5,0.5],[0,0]]]}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	isos, err := NewValhalla(srv.URL+"/", "").Isochrones(context.Background(), 32.78, -96.8, []int{15, 30})
+	require.NoError(t, err)
+	require.Len(t, isos, 2)
+	assert.Equal(t, 15, isos[0].Minutes)
+	assert.Contains(t, isos[0].GeoJSON, "0.5")
+	assert.Equal(t, 30, isos[1].Minutes)
+
+	assert.Equal(t, "auto", got.Costing)
+	assert.True(t, got.Polygons)
+	assert.Equal(t, []valhallaContour{{Time: 15}, {Time: 30}}, got.Contours)
+	assert.Equal(t, []valhallaLocation{{Lat: 32.78, Lon: -96.8}}, got.Locations)
+
+	_, err = NewValhalla(srv.URL, "").Isochrones(context.Background(), 32.78, -96.8, []int{60})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no 60-minute contour")
+}
+
+func TestValhalla_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "no suitable edges near location", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := NewValhalla(srv.URL, "").Isochrones(context.Background(), 0, 0, []int{15})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valhalla: HTTP 400")
+}
+
+func TestOSRM_Isochrones(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.True(t, strings.HasPrefix(r.URL.Path, "/table/v1/driving/"))
+		assert.Equal(t, "0", r.URL.Query().Get("sources"))
+		coords := strings.Split(strings.TrimPrefix(r.URL.Path, "/table/v1/driving/"), ";")
+		// Every destination is reachable in 10 minutes.
+		row := make([]*float64, len(coords))
+		for i := range row {
+			d := 600.0
+			row[i] = &d
+		}
+		_ = json.NewEncoder(w).Encode(osrmTableResponse{Code: "Ok", Durations: [][]*float64{row}})
+	}))
+	defer srv.Close()
+
+	isos, err := NewOSRM(srv.URL, "").Isochrones(context.Background(), 32.78, -96.8, []int{15})
+	require.NoError(t, err)
+	require.Len(t, isos, 1)
+	assert.Equal(t, 2, requests) // 192 destinations in chunks of 98
+
+	var poly geoJSONPolygon
+	require.NoError(t, json.Unmarshal([]byte(isos[0].GeoJSON), &poly))
+	assert.Equal(t, "Polygon", poly.Type)
+	require.Len(t, poly.Coordinates[0], osrmBearings+1)
+	assert.Equal(t, poly.Coordinates[0][0], poly.Coordinates[0][osrmBearings])
+	// Bearing 0 reaches the outermost ring: 27.5 km north.
+	assert.InDelta(t, 32.78+27.5/111.19, poly.Coordinates[0][0][1], 0.001)
+}
+
+func TestOSRM_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"code":"TooBig","message":"Too many table coordinates"}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewOSRM(srv.URL, "").Isochrones(context.Background(), 32.78, -96.8, []int{15})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "osrm: TooBig")
+}
+
+func TestDestination(t *testing.T) {
+	p := destination(0, 0, 90, 111.19)
+	assert.InDelta(t, 1.0, p[0], 0.001)
+	assert.InDelta(t, 0.0, p[1], 0.001)
+}
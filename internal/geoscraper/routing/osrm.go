@@ -0,0 +1,150 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// OSRM isochrone approximation: destinations are placed on osrmBearings
+// rays at osrmRings evenly spaced distances out to the farthest point
+// reachable at osrmMaxSpeedKMH, and each ray's vertex is the farthest
+// destination the table service reaches in time.
+const (
+	osrmBearings    = 24
+	osrmRings       = 8
+	osrmMaxSpeedKMH = 110.0
+	// osrmTableLimit is the destinations per /table request, below the
+	// default osrm-routed --max-table-size of 100 locations.
+	osrmTableLimit = 98
+	earthRadiusKM  = 6371.0
+)
+
+// OSRMClient approximates isochrones with the OSRM /table service.
+type OSRMClient struct {
+	endpoint string
+	profile  string
+	client   *http.Client
+}
+
+// NewOSRM creates an OSRMClient for the service at endpoint (e.g.
+// "http://localhost:5000"). profile defaults to "driving".
+func NewOSRM(endpoint, profile string) *OSRMClient {
+	if profile == "" {
+		profile = "driving"
+	}
+	return &OSRMClient{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		profile:  profile,
+		client:   newHTTPClient(),
+	}
+}
+
+// Provider implements Client.
+func (c *OSRMClient) Provider() string { return OSRM }
+
+type osrmTableResponse struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Durations [][]*float64 `json:"durations"`
+}
+
+type geoJSONPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// Isochrones implements Client.
+func (c *OSRMClient) Isochrones(ctx context.Context, lat, lng float64, minutes []int) ([]Isochrone, error) {
+	out := make([]Isochrone, 0, len(minutes))
+	for _, m := range minutes {
+		radiusKM := osrmMaxSpeedKMH * float64(m) / 60
+		grid := make([][2]float64, 0, osrmBearings*osrmRings)
+		for b := range osrmBearings {
+			for r := 1; r <= osrmRings; r++ {
+				grid = append(grid, destination(lat, lng, float64(b)*360/osrmBearings, radiusKM*float64(r)/osrmRings))
+			}
+		}
+		durations, err := c.durations(ctx, lat, lng, grid)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := float64(m * 60)
+		ring := make([][2]float64, 0, osrmBearings+1)
+		for b := range osrmBearings {
+			// Unreachable rays collapse to half the first ring.
+			dist := radiusKM / osrmRings / 2
+			for r := osrmRings; r >= 1; r-- {
+				if d := durations[b*osrmRings+r-1]; d != nil && *d <= limit {
+					dist = radiusKM * float64(r) / osrmRings
+					break
+				}
+			}
+			ring = append(ring, destination(lat, lng, float64(b)*360/osrmBearings, dist))
+		}
+		ring = append(ring, ring[0])
+
+		geom, err := json.Marshal(geoJSONPolygon{Type: "Polygon", Coordinates: [][][2]float64{ring}})
+		if err != nil {
+			return nil, eris.Wrap(err, "osrm: encode polygon")
+		}
+		out = append(out, Isochrone{Minutes: m, GeoJSON: string(geom)})
+	}
+	return out, nil
+}
+
+// durations returns the driving time in seconds from (lat, lng) to each
+// destination ([lng, lat]), nil where OSRM finds no route.
+func (c *OSRMClient) durations(ctx context.Context, lat, lng float64, dests [][2]float64) ([]*float64, error) {
+	out := make([]*float64, 0, len(dests))
+	for start := 0; start < len(dests); start += osrmTableLimit {
+		chunk := dests[start:min(start+osrmTableLimit, len(dests))]
+		coords := make([]string, 0, len(chunk)+1)
+		coords = append(coords, fmt.Sprintf("%.6f,%.6f", lng, lat))
+		for _, d := range chunk {
+			coords = append(coords, fmt.Sprintf("%.6f,%.6f", d[0], d[1]))
+		}
+		url := fmt.Sprintf("%s/table/v1/%s/%s?sources=0&annotations=duration",
+			c.endpoint, c.profile, strings.Join(coords, ";"))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, eris.Wrap(err, "osrm: build request")
+		}
+
+		var resp osrmTableResponse
+		if err := doJSON(c.client, req, OSRM, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Code != "Ok" {
+			return nil, eris.Errorf("osrm: %s: %s", resp.Code, resp.Message)
+		}
+		if len(resp.Durations) == 0 || len(resp.Durations[0]) != len(chunk)+1 {
+			return nil, eris.New("osrm: unexpected table size")
+		}
+		out = append(out, resp.Durations[0][1:]...)
+	}
+	return out, nil
+}
+
+// destination returns the [lng, lat] point distKM from (lat, lng) along
+// bearingDeg on a spherical earth.
+func destination(lat, lng, bearingDeg, distKM float64) [2]float64 {
+	phi1 := lat * math.Pi / 180
+	lambda1 := lng * math.Pi / 180
+	theta := bearingDeg * math.Pi / 180
+	delta := distKM / earthRadiusKM
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+	return [2]float64{
+		math.Round(lambda2*180/math.Pi*1e6) / 1e6,
+		math.Round(phi2*180/math.Pi*1e6) / 1e6,
+	}
+}
@@ -0,0 +1,106 @@
+package routing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// ValhallaClient calls the Valhalla /isochrone service.
+type ValhallaClient struct {
+	endpoint string
+	costing  string
+	client   *http.Client
+}
+
+// NewValhalla creates a ValhallaClient for the service at endpoint (e.g.
+// "http://localhost:8002"). costing defaults to "auto".
+func NewValhalla(endpoint, costing string) *ValhallaClient {
+	if costing == "" {
+		costing = "auto"
+	}
+	return &ValhallaClient{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		costing:  costing,
+		client:   newHTTPClient(),
+	}
+}
+
+// Provider implements Client.
+func (c *ValhallaClient) Provider() string { return Valhalla }
+
+type valhallaLocation struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+type valhallaContour struct {
+	Time int `json:"time"`
+}
+
+type valhallaRequest struct {
+	Locations []valhallaLocation `json:"locations"`
+	Costing   string             `json:"costing"`
+	Contours  []valhallaContour  `json:"contours"`
+	Polygons  bool               `json:"polygons"`
+}
+
+// valhallaResponse is a GeoJSON FeatureCollection with one feature per
+// contour; properties.contour is the contour time in minutes.
+type valhallaResponse struct {
+	Features []struct {
+		Properties struct {
+			Contour float64 `json:"contour"`
+		} `json:"properties"`
+		Geometry json.RawMessage `json:"geometry"`
+	} `json:"features"`
+}
+
+// Isochrones implements Client.
+func (c *ValhallaClient) Isochrones(ctx context.Context, lat, lng float64, minutes []int) ([]Isochrone, error) {
+	if len(minutes) == 0 {
+		return nil, nil
+	}
+	body := valhallaRequest{
+		Locations: []valhallaLocation{{Lat: lat, Lon: lng}},
+		Costing:   c.costing,
+		Polygons:  true,
+	}
+	for _, m := range minutes {
+		body.Contours = append(body.Contours, valhallaContour{Time: m})
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, eris.Wrap(err, "valhalla: encode request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/isochrone", bytes.NewReader(payload))
+	if err != nil {
+		return nil, eris.Wrap(err, "valhalla: build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp valhallaResponse
+	if err := doJSON(c.client, req, Valhalla, &resp); err != nil {
+		return nil, err
+	}
+
+	byMinutes := make(map[int]string, len(resp.Features))
+	for _, f := range resp.Features {
+		if len(f.Geometry) > 0 {
+			byMinutes[int(f.Properties.Contour)] = string(f.Geometry)
+		}
+	}
+	out := make([]Isochrone, 0, len(minutes))
+	for _, m := range minutes {
+		geom, ok := byMinutes[m]
+		if !ok {
+			return nil, eris.Errorf("valhalla: no %d-minute contour in response", m)
+		}
+		out = append(out, Isochrone{Minutes: m, GeoJSON: geom})
+	}
+	return out, nil
+}
@@ -0,0 +1,253 @@
+package scraper
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/tealeg/xlsx/v2"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// cbsaDelineationVintage is the OMB delineation loaded; TIGER/Line CBSA
+// boundaries from tigerYear follow it.
+const cbsaDelineationVintage = 2023
+
+// cbsaDelineationURL is Census list 1 (CBSAs, metropolitan divisions, and
+// CSAs by county).
+const cbsaDelineationURL = "https://www2.census.gov/programs-surveys/metro-micro/geographies/reference-files/2023/delineation-files/list1_2023.xlsx"
+
+// cbsaCountyCols are the columns written to geo.cbsa_counties.
+var cbsaCountyCols = []string{
+	"county_fips", "state_fips", "cbsa_code", "cbsa_title", "metro_micro",
+	"metro_division_code", "metro_division_title", "csa_code", "csa_title",
+	"county_name", "state_name", "central_outlying", "vintage", "source",
+}
+
+var cbsaCountyConflictKeys = []string{"county_fips"}
+
+// CBSADelineations loads CBSA boundary polygons from TIGER/Line into
+// geo.cbsa and the OMB county delineation into geo.cbsa_counties, then
+// stamps each CBSA with its metro/micro type, CSA, and county count. It
+// is the source of the geo.cbsa rows the MSA associator reads.
+type CBSADelineations struct {
+	downloadBaseURL string // TIGER override for testing; empty uses census.gov
+	delineationURL  string // override for testing; empty uses cbsaDelineationURL
+	year            int    // TIGER vintage override for testing; 0 uses tigerYear
+}
+
+// Name implements GeoScraper.
+func (s *CBSADelineations) Name() string { return "cbsa_delineations" }
+
+// Table implements GeoScraper.
+func (s *CBSADelineations) Table() string { return "geo.cbsa" }
+
+// Category implements GeoScraper.
+func (s *CBSADelineations) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *CBSADelineations) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper.
+func (s *CBSADelineations) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.October)
+}
+
+// Sync implements GeoScraper.
+func (s *CBSADelineations) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting CBSA delineations sync")
+
+	tb := &TIGERBoundaries{downloadBaseURL: s.downloadBaseURL, year: s.year}
+	year := tb.effectiveYear()
+	boundaries, err := tb.syncNational(ctx, pool, cbsaDef(), year, tempDir)
+	if err != nil {
+		return nil, eris.Wrap(err, "cbsa_delineations: boundaries")
+	}
+	if err := recordVintage(ctx, pool, layerCBSA, year, boundaries); err != nil {
+		return nil, eris.Wrap(err, "cbsa_delineations")
+	}
+	log.Info("CBSA boundaries loaded", zap.Int64("rows", boundaries), zap.Int("vintage", year))
+
+	counties, err := s.syncDelineation(ctx, pool, f, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("CBSA delineations sync complete",
+		zap.Int64("boundaries", boundaries),
+		zap.Int64("counties", counties),
+	)
+	return &geoscraper.SyncResult{
+		RowsSynced: boundaries + counties,
+		Metadata: map[string]any{
+			"boundaries":          boundaries,
+			"counties":            counties,
+			"delineation_vintage": cbsaDelineationVintage,
+		},
+	}, nil
+}
+
+// syncDelineation loads the OMB delineation into geo.cbsa_counties, drops
+// counties from older vintages, and refreshes the geo.cbsa summary.
+func (s *CBSADelineations) syncDelineation(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (int64, error) {
+	url := s.delineationURL
+	if url == "" {
+		url = cbsaDelineationURL
+	}
+
+	xlsxPath := filepath.Join(tempDir, "cbsa_list1.xlsx")
+	if _, err := f.DownloadToFile(ctx, url, xlsxPath); err != nil {
+		return 0, eris.Wrap(err, "cbsa_delineations: download delineation")
+	}
+
+	xlFile, err := xlsx.OpenFile(xlsxPath)
+	if err != nil {
+		return 0, eris.Wrap(err, "cbsa_delineations: open xlsx")
+	}
+	rows, err := parseCBSADelineation(xlFile)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += hifldBatchSize {
+		end := min(start+hifldBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.cbsa_counties",
+			Columns:      cbsaCountyCols,
+			ConflictKeys: cbsaCountyConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrap(err, "cbsa_delineations: upsert batch")
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, pruneCBSACountiesSQL, int16(cbsaDelineationVintage)); err != nil {
+		return 0, eris.Wrap(err, "cbsa_delineations: prune counties")
+	}
+	if _, err := pool.Exec(ctx, summarizeCBSASQL); err != nil {
+		return 0, eris.Wrap(err, "cbsa_delineations: summarize cbsa")
+	}
+	return total, nil
+}
+
+const pruneCBSACountiesSQL = `DELETE FROM geo.cbsa_counties WHERE vintage <> $1`
+
+// summarizeCBSASQL stamps each geo.cbsa row with its delineation type, CSA,
+// and member county count.
+const summarizeCBSASQL = `UPDATE geo.cbsa c SET
+	metro_micro = d.metro_micro,
+	csa_code = d.csa_code,
+	csa_name = d.csa_title,
+	county_count = d.counties,
+	delineation_vintage = d.vintage
+FROM (
+	SELECT cbsa_code,
+	       MAX(metro_micro) AS metro_micro,
+	       MAX(csa_code) AS csa_code,
+	       MAX(csa_title) AS csa_title,
+	       COUNT(*) AS counties,
+	       MAX(vintage) AS vintage
+	FROM geo.cbsa_counties
+	GROUP BY cbsa_code
+) d
+WHERE c.cbsa_code = d.cbsa_code`
+
+// parseCBSADelineation extracts geo.cbsa_counties rows from the list 1
+// workbook. The header row follows title rows and data rows are followed by
+// notes, so the header is located by its "CBSA Code" cell and rows without
+// a five-digit CBSA code are skipped.
+func parseCBSADelineation(xlFile *xlsx.File) ([][]any, error) {
+	if len(xlFile.Sheets) == 0 {
+		return nil, eris.New("cbsa_delineations: no sheets in xlsx")
+	}
+	sheet := xlFile.Sheets[0]
+
+	headerIdx := -1
+	for i, row := range sheet.Rows {
+		if xlsxString(row, 0) == "CBSA Code" {
+			headerIdx = i
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil, eris.New("cbsa_delineations: header row not found")
+	}
+	cols := xlsxColIndex(sheet.Rows[headerIdx])
+	col := func(name string) int {
+		if i, ok := cols[name]; ok {
+			return i
+		}
+		return -1
+	}
+	var (
+		cbsaCode    = col("CBSA Code")
+		divCode     = col("Metropolitan Division Code")
+		csaCode     = col("CSA Code")
+		cbsaTitle   = col("CBSA Title")
+		metroMicro  = col("Metropolitan/Micropolitan Statistical Area")
+		divTitle    = col("Metropolitan Division Title")
+		csaTitle    = col("CSA Title")
+		countyName  = col("County/County Equivalent")
+		stateName   = col("State Name")
+		stateFIPS   = col("FIPS State Code")
+		countyCode  = col("FIPS County Code")
+		centralFlag = col("Central/Outlying County")
+	)
+	if stateFIPS < 0 || countyCode < 0 {
+		return nil, eris.New("cbsa_delineations: FIPS columns not found")
+	}
+
+	var rows [][]any
+	for _, row := range sheet.Rows[headerIdx+1:] {
+		code := xlsxString(row, cbsaCode)
+		if len(code) != 5 {
+			continue
+		}
+		fips := countyFIPS(xlsxString(row, stateFIPS), xlsxString(row, countyCode))
+		if fips == "" || strings.HasSuffix(fips, "000") {
+			continue
+		}
+		rows = append(rows, []any{
+			fips,
+			fips[:2],
+			code,
+			nilIfEmpty(xlsxString(row, cbsaTitle)),
+			nilIfEmpty(cbsaType(xlsxString(row, metroMicro))),
+			nilIfEmpty(xlsxString(row, divCode)),
+			nilIfEmpty(xlsxString(row, divTitle)),
+			nilIfEmpty(xlsxString(row, csaCode)),
+			nilIfEmpty(xlsxString(row, csaTitle)),
+			nilIfEmpty(xlsxString(row, countyName)),
+			nilIfEmpty(xlsxString(row, stateName)),
+			nilIfEmpty(strings.ToLower(xlsxString(row, centralFlag))),
+			int16(cbsaDelineationVintage),
+			"omb",
+		})
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("cbsa_delineations: no county rows in xlsx")
+	}
+	return rows, nil
+}
+
+// cbsaType maps the delineation's area type to "metro" or "micro".
+func cbsaType(s string) string {
+	switch {
+	case strings.HasPrefix(s, "Metropolitan"):
+		return "metro"
+	case strings.HasPrefix(s, "Micropolitan"):
+		return "micro"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jonas-p/go-shp"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+var cbsaDelineationHeader = []string{
+	"CBSA Code", "Metropolitan Division Code", "CSA Code", "CBSA Title",
+	"Metropolitan/Micropolitan Statistical Area", "Metropolitan Division Title", "CSA Title",
+	"County/County Equivalent", "State Name", "FIPS State Code", "FIPS County Code", "Central/Outlying County",
+}
+
+// buildCBSADelineationXLSX lays rows out like Census list 1: title rows,
+// the header, county rows, then notes.
+func buildCBSADelineationXLSX(t *testing.T, rows [][]string) []byte {
+	t.Helper()
+	all := [][]string{
+		{"July 2023"},
+		cbsaDelineationHeader,
+	}
+	all = append(all, rows...)
+	all = append(all, []string{"Note: The 2023 delineations reflect OMB Bulletin 23-01."})
+	return buildFMRXLSX(t, []string{"List 1. Core Based Statistical Areas (CBSAs), Metropolitan Divisions, and Combined Statistical Areas (CSAs)"}, all)
+}
+
+var cbsaDelineationRows = [][]string{
+	{"12420", "", "", "Austin-Round Rock-San Marcos, TX", "Metropolitan Statistical Area", "", "", "Travis County", "Texas", "48", "453", "Central"},
+	{"12420", "", "", "Austin-Round Rock-San Marcos, TX", "Metropolitan Statistical Area", "", "", "Bastrop County", "Texas", "48", "21", "Outlying"},
+	{"10100", "", "", "Aberdeen, SD", "Micropolitan Statistical Area", "", "", "Brown County", "South Dakota", "46", "13", "Central"},
+}
+
+func TestCBSADelineations_Metadata(t *testing.T) {
+	s := &CBSADelineations{}
+	assert.Equal(t, "cbsa_delineations", s.Name())
+	assert.Equal(t, "geo.cbsa", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestCBSADelineations_Sync(t *testing.T) {
+	zipPath := createTestBoundaryShapefile(t, shp.POLYGON, cbsaProduct.Columns, 2)
+	xlsxData := buildCBSADelineationXLSX(t, cbsaDelineationRows)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".xlsx") {
+			_, _ = w.Write(xlsxData)
+			return
+		}
+		data, err := os.ReadFile(zipPath)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_cbsa", cbsaCols, 2)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 2)
+	expectRecordVintage(mock, layerCBSA, 2)
+	expectBoundaryUpsert(mock, "geo_cbsa_counties", cbsaCountyCols, 3)
+	mock.ExpectExec("DELETE FROM geo.cbsa_counties").
+		WithArgs(int16(cbsaDelineationVintage)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("UPDATE geo.cbsa c SET").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	s := &CBSADelineations{downloadBaseURL: srv.URL, delineationURL: srv.URL + "/list1_2023.xlsx", year: 2024}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.RowsSynced)
+	assert.Equal(t, int64(3), result.Metadata["counties"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCBSADelineations_BoundaryError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &CBSADelineations{downloadBaseURL: srv.URL, year: 2024}
+	_, err = s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cbsa_delineations: boundaries")
+}
+
+func TestParseCBSADelineation(t *testing.T) {
+	rows := append([][]string{
+		// Statewide and malformed rows are skipped.
+		{"99999", "", "", "Bad", "Metropolitan Statistical Area", "", "", "", "Texas", "48", "", ""},
+		{"", "", "", "", "", "", "", "", "", "", "", ""},
+		{"35620", "35614", "408", "New York-Newark-Jersey City, NY-NJ", "Metropolitan Statistical Area",
+			"New York-Jersey City-White Plains, NY-NJ", "New York-Newark, NY-NJ-CT-PA", "Bronx County", "New York", "36", "5", "Central"},
+	}, cbsaDelineationRows...)
+	xlFile, err := xlsx.OpenBinary(buildCBSADelineationXLSX(t, rows))
+	require.NoError(t, err)
+
+	got, err := parseCBSADelineation(xlFile)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	ny := got[0]
+	require.Len(t, ny, len(cbsaCountyCols))
+	assert.Equal(t, "36005", ny[0])
+	assert.Equal(t, "36", ny[1])
+	assert.Equal(t, "35620", ny[2])
+	assert.Equal(t, "metro", ny[4])
+	assert.Equal(t, "35614", ny[5])
+	assert.Equal(t, "408", ny[7])
+	assert.Equal(t, "New York-Newark, NY-NJ-CT-PA", ny[8])
+	assert.Equal(t, "central", ny[11])
+	assert.Equal(t, int16(2023), ny[12])
+
+	aberdeen := got[3]
+	assert.Equal(t, "46013", aberdeen[0])
+	assert.Equal(t, "micro", aberdeen[4])
+	assert.Nil(t, aberdeen[7], "no CSA")
+}
+
+func TestParseCBSADelineation_NoHeader(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, []string{"fips", "state"}, [][]string{{"48453", "48"}}))
+	require.NoError(t, err)
+
+	_, err = parseCBSADelineation(xlFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "header row not found")
+}
@@ -26,6 +26,7 @@ var sldExclude = map[string]bool{
 	"CBSA_Name":  true,
 	"NatWalkInd": true,
 	"D3B":        true,
+	"D4C":        true,
 	"D1C":        true,
 	"D1A":        true,
 	"TotEmp":     true,
@@ -34,14 +35,17 @@ var sldExclude = map[string]bool{
 
 var sldCols = []string{
 	"geoid", "state_fips", "county_fips", "cbsa_name",
-	"walkability_index", "transit_freq", "emp_density", "hh_density",
+	"walkability_index", "intersection_density", "transit_freq", "emp_density", "hh_density",
 	"tot_emp", "auto_own_0_pct",
 	"source", "source_id", "properties",
 }
 
 var sldConflictKeys = []string{"geoid"}
 
-// EPASmartLocation scrapes the EPA Smart Location Database.
+// EPASmartLocation scrapes the EPA Smart Location Database into geo.sld:
+// national walkability index (NatWalkInd), street intersection density
+// (D3B), peak transit frequency (D4C), and employment and household density
+// (D1C, D1A) per census block group.
 type EPASmartLocation struct {
 	baseURL string // override for testing; empty uses default EPA endpoint
 }
@@ -50,7 +54,7 @@ type EPASmartLocation struct {
 func (s *EPASmartLocation) Name() string { return "epa_smart_location" }
 
 // Table implements GeoScraper.
-func (s *EPASmartLocation) Table() string { return "geo.smart_location" }
+func (s *EPASmartLocation) Table() string { return "geo.sld" }
 
 // Category implements GeoScraper.
 func (s *EPASmartLocation) Category() geoscraper.Category { return geoscraper.National }
@@ -144,6 +148,7 @@ func (s *EPASmartLocation) Sync(ctx context.Context, pool db.Pool, f fetcher.Fet
 			csvString(row, cols["CBSA_Name"]),
 			csvFloat64(row, cols["NatWalkInd"]),
 			csvFloat64(row, cols["D3B"]),
+			csvFloat64(row, cols["D4C"]),
 			csvFloat64(row, cols["D1C"]),
 			csvFloat64(row, cols["D1A"]),
 			totEmp,
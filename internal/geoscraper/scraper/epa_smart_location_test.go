@@ -19,7 +19,7 @@ import (
 func expectSLDUpsert(mock pgxmock.PgxPoolIface, rows int64) {
 	mock.ExpectBegin()
 	mock.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
-	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_geo_smart_location"}, sldCols).WillReturnResult(rows)
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_geo_sld"}, sldCols).WillReturnResult(rows)
 	mock.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
 	mock.ExpectExec("INSERT INTO").WillReturnResult(pgxmock.NewResult("INSERT", rows))
 	mock.ExpectCommit()
@@ -28,7 +28,7 @@ func expectSLDUpsert(mock pgxmock.PgxPoolIface, rows int64) {
 func TestEPASmartLocation_Metadata(t *testing.T) {
 	s := &EPASmartLocation{}
 	assert.Equal(t, "epa_smart_location", s.Name())
-	assert.Equal(t, "geo.smart_location", s.Table())
+	assert.Equal(t, "geo.sld", s.Table())
 	assert.Equal(t, geoscraper.National, s.Category())
 	assert.Equal(t, geoscraper.Annual, s.Cadence())
 }
@@ -72,8 +72,8 @@ func TestEPASmartLocation_Sync(t *testing.T) {
 }
 
 func TestEPASmartLocation_EmptyGEOID(t *testing.T) {
-	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D1C,D1A,TotEmp,AutoOwn0\n" +
-		",48,113,Dallas,15.2,42.5,8.3,4.1,5200,0.12\n")
+	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D4C,D1C,D1A,TotEmp,AutoOwn0\n" +
+		",48,113,Dallas,15.2,42.5,3.1,8.3,4.1,5200,0.12\n")
 
 	tmpDir := t.TempDir()
 	zipData := buildMultiZIP(t, tmpDir, map[string][]byte{
@@ -140,7 +140,7 @@ func TestEPASmartLocation_UpsertError(t *testing.T) {
 }
 
 func TestEPASmartLocation_EmptyCSV(t *testing.T) {
-	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D1C,D1A,TotEmp,AutoOwn0\n")
+	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D4C,D1C,D1A,TotEmp,AutoOwn0\n")
 
 	tmpDir := t.TempDir()
 	zipData := buildMultiZIP(t, tmpDir, map[string][]byte{
@@ -241,8 +241,8 @@ func TestFindSLDCSV_NotFound(t *testing.T) {
 }
 
 func TestEPASmartLocation_ReadRowError(t *testing.T) {
-	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D1C,D1A,TotEmp,AutoOwn0\n" +
-		"481130101001,\"Broken Quote,113,Dallas,15.2,42.5,8.3,4.1,5200,0.12\n")
+	csvData := []byte("GEOID20,STATEFP,COUNTYFP,CBSA_Name,NatWalkInd,D3B,D4C,D1C,D1A,TotEmp,AutoOwn0\n" +
+		"481130101001,\"Broken Quote,113,Dallas,15.2,42.5,3.1,8.3,4.1,5200,0.12\n")
 
 	tmpDir := t.TempDir()
 	zipData := buildMultiZIP(t, tmpDir, map[string][]byte{
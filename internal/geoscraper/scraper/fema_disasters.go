@@ -0,0 +1,342 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// openFEMABaseURL is the OpenFEMA API root.
+const openFEMABaseURL = "https://www.fema.gov/api/open"
+
+// openFEMASource is the source identifier for OpenFEMA rows.
+const openFEMASource = "openfema"
+
+// openFEMAPageSize is the $top page size; OpenFEMA caps pages at 10,000.
+const openFEMAPageSize = 10000
+
+// disasterSince is the earliest declaration date synced. Older declarations
+// carry no public assistance detail and are not useful for exposure.
+const disasterSince = "1998-01-01"
+
+// disasterCols are the columns written to geo.fema_disasters.
+var disasterCols = []string{
+	"disaster_number", "declaration_string", "declaration_type", "declaration_date",
+	"state", "state_fips", "county_fips", "designated_area", "place_code",
+	"incident_type", "title", "incident_begin", "incident_end", "closeout_date",
+	"ih_declared", "ia_declared", "pa_declared", "hm_declared",
+	"pa_projects", "pa_federal_obligated",
+	"source", "source_id",
+}
+
+var disasterConflictKeys = []string{"source", "source_id"}
+
+// disasterDeclaration is one DisasterDeclarationsSummaries record: a
+// designated area (county, statewide, or tribal area) of a declaration.
+type disasterDeclaration struct {
+	ID                string `json:"id"`
+	DisasterNumber    int    `json:"disasterNumber"`
+	DeclarationString string `json:"femaDeclarationString"`
+	DeclarationType   string `json:"declarationType"`
+	DeclarationDate   string `json:"declarationDate"`
+	State             string `json:"state"`
+	FIPSStateCode     string `json:"fipsStateCode"`
+	FIPSCountyCode    string `json:"fipsCountyCode"`
+	PlaceCode         string `json:"placeCode"`
+	DesignatedArea    string `json:"designatedArea"`
+	IncidentType      string `json:"incidentType"`
+	DeclarationTitle  string `json:"declarationTitle"`
+	IncidentBeginDate string `json:"incidentBeginDate"`
+	IncidentEndDate   string `json:"incidentEndDate"`
+	CloseoutDate      string `json:"disasterCloseoutDate"`
+	IHProgramDeclared bool   `json:"ihProgramDeclared"`
+	IAProgramDeclared bool   `json:"iaProgramDeclared"`
+	PAProgramDeclared bool   `json:"paProgramDeclared"`
+	HMProgramDeclared bool   `json:"hmProgramDeclared"`
+}
+
+// paProject is one PublicAssistanceFundedProjectsDetails record, reduced
+// to the fields aggregated per disaster and county.
+type paProject struct {
+	DisasterNumber        int     `json:"disasterNumber"`
+	StateNumberCode       string  `json:"stateNumberCode"`
+	CountyCode            string  `json:"countyCode"`
+	FederalShareObligated float64 `json:"federalShareObligated"`
+}
+
+// paKey identifies a disaster's public assistance within one county.
+type paKey struct {
+	disaster   int
+	countyFIPS string
+}
+
+// paTotals is the public assistance obligated in one disaster and county.
+type paTotals struct {
+	projects int
+	federal  float64
+	attached bool
+}
+
+// FEMADisasters syncs OpenFEMA disaster declarations by county, with each
+// county's public assistance project count and federal obligation, into
+// geo.fema_disasters.
+type FEMADisasters struct {
+	baseURL string // override for testing; empty uses openFEMABaseURL
+}
+
+// Name implements GeoScraper.
+func (s *FEMADisasters) Name() string { return "fema_disasters" }
+
+// Table implements GeoScraper.
+func (s *FEMADisasters) Table() string { return "geo.fema_disasters" }
+
+// Category implements GeoScraper.
+func (s *FEMADisasters) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *FEMADisasters) Cadence() geoscraper.Cadence { return geoscraper.Monthly }
+
+// ShouldRun implements GeoScraper.
+func (s *FEMADisasters) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.MonthlySchedule(now, lastSync)
+}
+
+// Sync implements GeoScraper.
+func (s *FEMADisasters) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting FEMA disasters sync")
+
+	pa, err := s.fetchPublicAssistance(ctx, f)
+	if err != nil {
+		return nil, eris.Wrap(err, "fema_disasters: public assistance")
+	}
+	log.Info("aggregated public assistance", zap.Int("disaster_counties", len(pa)))
+
+	var totalRows int64
+	var batch [][]any
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, uErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        s.Table(),
+			Columns:      disasterCols,
+			ConflictKeys: disasterConflictKeys,
+		}, batch)
+		if uErr != nil {
+			return eris.Wrap(uErr, "fema_disasters: upsert batch")
+		}
+		totalRows += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err = s.paginate(ctx, f, "v2/DisasterDeclarationsSummaries", url.Values{
+		"$filter":  {fmt.Sprintf("declarationDate ge '%s'", disasterSince)},
+		"$orderby": {"id"},
+	}, "DisasterDeclarationsSummaries", func(raw json.RawMessage) error {
+		var d disasterDeclaration
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return eris.Wrap(err, "decode declaration")
+		}
+		row := newDisasterRow(d, pa)
+		if row == nil {
+			return nil
+		}
+		batch = append(batch, row)
+		if len(batch) >= femaBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "fema_disasters: declarations")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Info("FEMA disasters sync complete", zap.Int64("rows", totalRows))
+	return &geoscraper.SyncResult{RowsSynced: totalRows}, nil
+}
+
+// fetchPublicAssistance sums obligated public assistance projects by
+// disaster and county FIPS. Statewide projects key on county "000".
+func (s *FEMADisasters) fetchPublicAssistance(ctx context.Context, f fetcher.Fetcher) (map[paKey]*paTotals, error) {
+	totals := make(map[paKey]*paTotals)
+	err := s.paginate(ctx, f, "v1/PublicAssistanceFundedProjectsDetails", url.Values{
+		"$select":  {"disasterNumber,stateNumberCode,countyCode,federalShareObligated"},
+		"$filter":  {fmt.Sprintf("declarationDate ge '%s'", disasterSince)},
+		"$orderby": {"id"},
+	}, "PublicAssistanceFundedProjectsDetails", func(raw json.RawMessage) error {
+		var p paProject
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return eris.Wrap(err, "decode project")
+		}
+		fips := countyFIPS(p.StateNumberCode, p.CountyCode)
+		if p.DisasterNumber == 0 || fips == "" {
+			return nil
+		}
+		k := paKey{disaster: p.DisasterNumber, countyFIPS: fips}
+		t := totals[k]
+		if t == nil {
+			t = &paTotals{}
+			totals[k] = t
+		}
+		t.projects++
+		t.federal += p.FederalShareObligated
+		return nil
+	})
+	return totals, err
+}
+
+// paginate pages through an OpenFEMA entity with $skip/$top, calling fn
+// for each record in the response array named key.
+func (s *FEMADisasters) paginate(ctx context.Context, f fetcher.Fetcher, entity string, params url.Values, key string, fn func(json.RawMessage) error) error {
+	base := s.baseURL
+	if base == "" {
+		base = openFEMABaseURL
+	}
+
+	for skip := 0; ; skip += openFEMAPageSize {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		q.Set("$top", fmt.Sprint(openFEMAPageSize))
+		q.Set("$skip", fmt.Sprint(skip))
+
+		records, err := fetchOpenFEMAPage(ctx, f, base+"/"+entity+"?"+q.Encode(), key)
+		if err != nil {
+			return eris.Wrapf(err, "%s skip %d", entity, skip)
+		}
+		for _, r := range records {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		if len(records) < openFEMAPageSize {
+			return nil
+		}
+	}
+}
+
+// fetchOpenFEMAPage downloads one OpenFEMA page and returns the records in
+// its key array.
+func fetchOpenFEMAPage(ctx context.Context, f fetcher.Fetcher, reqURL, key string) ([]json.RawMessage, error) {
+	body, err := f.Download(ctx, reqURL)
+	if err != nil {
+		return nil, eris.Wrap(err, "download")
+	}
+	defer body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, eris.Wrap(err, "read body")
+	}
+
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, eris.Wrap(err, "decode response")
+	}
+	var records []json.RawMessage
+	if raw, ok := resp[key]; ok {
+		if err := json.Unmarshal(raw, &records); err != nil {
+			return nil, eris.Wrapf(err, "decode %s", key)
+		}
+	}
+	return records, nil
+}
+
+// newDisasterRow builds a geo.fema_disasters row, attaching the county's
+// public assistance totals to the first designation of each disaster and
+// county. Returns nil for records without a disaster number or state.
+func newDisasterRow(d disasterDeclaration, pa map[paKey]*paTotals) []any {
+	fips := countyFIPS(d.FIPSStateCode, d.FIPSCountyCode)
+	if d.DisasterNumber == 0 || fips == "" {
+		return nil
+	}
+
+	var projects, federal any
+	if t := pa[paKey{disaster: d.DisasterNumber, countyFIPS: fips}]; t != nil && !t.attached {
+		t.attached = true
+		projects, federal = t.projects, t.federal
+	}
+
+	sourceID := d.ID
+	if sourceID == "" {
+		sourceID = fmt.Sprintf("%s/%s/%s", d.DeclarationString, fips, d.PlaceCode)
+	}
+
+	return []any{
+		d.DisasterNumber,
+		nilIfEmpty(d.DeclarationString),
+		nilIfEmpty(d.DeclarationType),
+		openFEMADate(d.DeclarationDate),
+		nilIfEmpty(d.State),
+		fips[:2],
+		fips,
+		nilIfEmpty(d.DesignatedArea),
+		nilIfEmpty(d.PlaceCode),
+		nilIfEmpty(d.IncidentType),
+		nilIfEmpty(d.DeclarationTitle),
+		openFEMADate(d.IncidentBeginDate),
+		openFEMADate(d.IncidentEndDate),
+		openFEMADate(d.CloseoutDate),
+		d.IHProgramDeclared,
+		d.IAProgramDeclared,
+		d.PAProgramDeclared,
+		d.HMProgramDeclared,
+		projects,
+		federal,
+		openFEMASource,
+		sourceID,
+	}
+}
+
+// countyFIPS joins OpenFEMA state and county codes into a five-digit county
+// FIPS, zero-padding codes the API returns unpadded. Statewide areas use
+// county "000". Returns "" when the state code is missing.
+func countyFIPS(state, county string) string {
+	state = strings.TrimSpace(state)
+	county = strings.TrimSpace(county)
+	if state == "" || len(state) > 2 || len(county) > 3 {
+		return ""
+	}
+	if county == "" {
+		county = "000"
+	}
+	return strings.Repeat("0", 2-len(state)) + state + strings.Repeat("0", 3-len(county)) + county
+}
+
+// openFEMADate parses an OpenFEMA ISO-8601 timestamp, returning nil for
+// empty or unparseable values.
+func openFEMADate(s string) any {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,138 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+const testDeclarations = `{"metadata": {"skip": 0, "top": 10000},
+"DisasterDeclarationsSummaries": [
+	{"id": "d1", "disasterNumber": 4781, "femaDeclarationString": "DR-4781-TX", "declarationType": "DR",
+	 "declarationDate": "2024-05-17T00:00:00.000Z", "state": "TX", "fipsStateCode": "48", "fipsCountyCode": "201",
+	 "placeCode": "99201", "designatedArea": "Harris (County)", "incidentType": "Severe Storm",
+	 "declarationTitle": "SEVERE STORMS", "incidentBeginDate": "2024-04-26T00:00:00.000Z",
+	 "incidentEndDate": "2024-06-05T00:00:00.000Z", "ihProgramDeclared": true, "paProgramDeclared": true},
+	{"id": "d2", "disasterNumber": 4781, "femaDeclarationString": "DR-4781-TX", "declarationType": "DR",
+	 "declarationDate": "2024-05-17T00:00:00.000Z", "state": "TX", "fipsStateCode": "48", "fipsCountyCode": "0",
+	 "designatedArea": "Statewide", "incidentType": "Severe Storm", "hmProgramDeclared": true},
+	{"id": "bad", "disasterNumber": 0, "fipsStateCode": "48", "fipsCountyCode": "201"}
+]}`
+
+const testPAProjects = `{"PublicAssistanceFundedProjectsDetails": [
+	{"disasterNumber": 4781, "stateNumberCode": "48", "countyCode": "201", "federalShareObligated": 1000.5},
+	{"disasterNumber": 4781, "stateNumberCode": "48", "countyCode": "201", "federalShareObligated": 499.5},
+	{"disasterNumber": 4781, "stateNumberCode": "48", "countyCode": "0", "federalShareObligated": 250}
+]}`
+
+func TestFEMADisasters_Metadata(t *testing.T) {
+	s := &FEMADisasters{}
+	assert.Equal(t, "fema_disasters", s.Name())
+	assert.Equal(t, "geo.fema_disasters", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Monthly, s.Cadence())
+	assert.True(t, s.ShouldRun(fixedNow(), nil))
+}
+
+func TestFEMADisasters_Sync(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "10000", r.URL.Query().Get("$top"))
+		assert.Contains(t, r.URL.Query().Get("$filter"), "declarationDate ge '1998-01-01'")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "PublicAssistance") {
+			_, _ = w.Write([]byte(testPAProjects))
+			return
+		}
+		_, _ = w.Write([]byte(testDeclarations))
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_fema_disasters", disasterCols, 2)
+
+	s := &FEMADisasters{baseURL: srv.URL}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, []string{"/v1/PublicAssistanceFundedProjectsDetails", "/v2/DisasterDeclarationsSummaries"}, paths)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFEMADisasters_DownloadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &FEMADisasters{baseURL: srv.URL}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fema_disasters: public assistance")
+}
+
+func TestNewDisasterRow(t *testing.T) {
+	pa := map[paKey]*paTotals{
+		{disaster: 4781, countyFIPS: "48201"}: {projects: 2, federal: 1500},
+	}
+	d := disasterDeclaration{
+		ID: "d1", DisasterNumber: 4781, DeclarationString: "DR-4781-TX", DeclarationType: "DR",
+		DeclarationDate: "2024-05-17T00:00:00.000Z", State: "TX", FIPSStateCode: "48", FIPSCountyCode: "201",
+		IncidentType: "Severe Storm", PAProgramDeclared: true,
+	}
+
+	row := newDisasterRow(d, pa)
+	require.Len(t, row, len(disasterCols))
+	assert.Equal(t, 4781, row[0])
+	assert.Equal(t, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), row[3])
+	assert.Equal(t, "48", row[5])    // state_fips
+	assert.Equal(t, "48201", row[6]) // county_fips
+	assert.Nil(t, row[12])           // incident_end
+	assert.Equal(t, true, row[16])   // pa_declared
+	assert.Equal(t, 2, row[18])      // pa_projects
+	assert.InDelta(t, 1500.0, row[19], 0.001)
+	assert.Equal(t, "d1", row[21])
+
+	// A second designation of the same county does not repeat the totals.
+	d.ID = "d1-tribal"
+	row = newDisasterRow(d, pa)
+	assert.Nil(t, row[18])
+	assert.Nil(t, row[19])
+
+	assert.Nil(t, newDisasterRow(disasterDeclaration{DisasterNumber: 1}, pa), "no state")
+}
+
+func TestCountyFIPS(t *testing.T) {
+	assert.Equal(t, "48201", countyFIPS("48", "201"))
+	assert.Equal(t, "06001", countyFIPS("6", "1"))
+	assert.Equal(t, "48000", countyFIPS("48", "0"))
+	assert.Equal(t, "48000", countyFIPS("48", ""))
+	assert.Equal(t, "", countyFIPS("", "201"))
+	assert.Equal(t, "", countyFIPS("480", "201"))
+}
+
+func TestOpenFEMADate(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), openFEMADate("2024-05-17T00:00:00.000Z"))
+	assert.Nil(t, openFEMADate(""))
+	assert.Nil(t, openFEMADate("05/17/2024"))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/geoscraper"
 	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
 )
 
 // FEMAFloodZones scrapes flood hazard areas from FEMA's NFHL ArcGIS FeatureServer.
@@ -36,6 +37,10 @@ func (f *FEMAFloodZones) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return dataset.MonthlySchedule(now, lastSync)
 }
 
+// PostSyncHooks implements postsync.Declarer: geocoded company addresses
+// are tagged with the flood zone they fall in.
+func (f *FEMAFloodZones) PostSyncHooks() []string { return []string{postsync.FloodTag} }
+
 // Sync implements GeoScraper.
 func (f *FEMAFloodZones) Sync(ctx context.Context, pool db.Pool, ft fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
 	log := zap.L().With(zap.String("scraper", f.Name()))
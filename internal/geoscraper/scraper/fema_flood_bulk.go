@@ -16,6 +16,7 @@ import (
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/postsync"
 	"github.com/sells-group/research-cli/internal/tiger"
 )
 
@@ -60,6 +61,10 @@ func (f *FEMAFloodBulk) ShouldRun(now time.Time, lastSync *time.Time) bool {
 	return dataset.AnnualAfter(now, lastSync, time.January)
 }
 
+// PostSyncHooks implements postsync.Declarer: geocoded company addresses
+// are tagged with the flood zone they fall in.
+func (f *FEMAFloodBulk) PostSyncHooks() []string { return []string{postsync.FloodTag} }
+
 // Sync implements GeoScraper.
 func (f *FEMAFloodBulk) Sync(ctx context.Context, pool db.Pool, ft fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
 	log := zap.L().With(zap.String("scraper", f.Name()))
@@ -18,6 +18,7 @@ import (
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/geoscraper"
 	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
 )
 
 func TestFEMAFlood_Metadata(t *testing.T) {
@@ -28,6 +29,14 @@ func TestFEMAFlood_Metadata(t *testing.T) {
 	assert.Equal(t, geoscraper.Monthly, s.Cadence())
 }
 
+func TestFEMAFlood_PostSyncHooks(t *testing.T) {
+	var _ postsync.Declarer = &FEMAFloodZones{}
+	var _ postsync.Declarer = &FEMAFloodBulk{}
+
+	assert.Equal(t, []string{postsync.FloodTag}, (&FEMAFloodZones{}).PostSyncHooks())
+	assert.Equal(t, []string{postsync.FloodTag}, (&FEMAFloodBulk{}).PostSyncHooks())
+}
+
 func TestFEMAFlood_ShouldRun(t *testing.T) {
 	s := &FEMAFloodZones{}
 	now := fixedNow()
@@ -0,0 +1,425 @@
+package scraper
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// gtfsSource is the source identifier for GTFS feeds.
+const gtfsSource = "gtfs"
+
+// gtfsBatchSize is the number of rows per BulkUpsert batch.
+const gtfsBatchSize = 5000
+
+// Weekday peak window, in seconds after midnight of the service day.
+const (
+	gtfsPeakStart = 7 * 3600
+	gtfsPeakEnd   = 9 * 3600
+)
+
+// transitStopCols are the columns written to geo.transit_stops.
+var transitStopCols = []string{
+	"feed_id", "stop_id", "name", "route_count", "route_types",
+	"daily_departures", "peak_departures", "peak_headway_min",
+	"latitude", "longitude", "source", "synced_at",
+}
+
+var transitStopConflictKeys = []string{"feed_id", "stop_id"}
+
+// gtfsFeedIDPattern limits feed ids to names safe for file paths.
+var gtfsFeedIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// GTFS loads transit stops with weekday service levels from configured
+// GTFS static feeds into geo.transit_stops. Service levels count trips
+// running on a representative Wednesday within the feed's calendar, so
+// office locations can be scored for transit access. calendar_dates.txt
+// exceptions are not applied to calendar.txt services; feeds without
+// calendar.txt take their service from the dates calendar_dates.txt adds.
+type GTFS struct {
+	feeds map[string]string // feed id -> static GTFS zip URL
+}
+
+// Name implements GeoScraper.
+func (s *GTFS) Name() string { return "gtfs" }
+
+// Table implements GeoScraper.
+func (s *GTFS) Table() string { return "geo.transit_stops" }
+
+// Category implements GeoScraper.
+func (s *GTFS) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *GTFS) Cadence() geoscraper.Cadence { return geoscraper.Monthly }
+
+// ShouldRun implements GeoScraper. Nothing runs until feeds are
+// configured.
+func (s *GTFS) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return len(s.feeds) > 0 && dataset.MonthlySchedule(now, lastSync)
+}
+
+// Sync implements GeoScraper.
+func (s *GTFS) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	if len(s.feeds) == 0 {
+		return nil, eris.New("gtfs: no feeds configured (geo.gtfs_feeds)")
+	}
+
+	ids := make([]string, 0, len(s.feeds))
+	for id := range s.feeds {
+		if !gtfsFeedIDPattern.MatchString(id) {
+			return nil, eris.Errorf("gtfs: invalid feed id %q", id)
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	log.Info("starting GTFS sync", zap.Int("feeds", len(ids)))
+	now := time.Now().UTC()
+	var total int64
+	stops := make(map[string]any, len(ids))
+	for _, id := range ids {
+		n, err := s.syncFeed(ctx, pool, f, tempDir, id, now)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("GTFS feed loaded", zap.String("feed", id), zap.Int64("stops", n))
+		stops[id] = n
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.transit_stops WHERE NOT (feed_id = ANY($1))`, ids); err != nil {
+		return nil, eris.Wrap(err, "gtfs: prune feeds")
+	}
+
+	log.Info("GTFS sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{RowsSynced: total, Metadata: map[string]any{"stops": stops}}, nil
+}
+
+// syncFeed downloads one feed, replaces its stops, and returns the number
+// loaded.
+func (s *GTFS) syncFeed(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir, id string, now time.Time) (int64, error) {
+	zipPath := filepath.Join(tempDir, "gtfs_"+id+".zip")
+	if _, err := f.DownloadToFile(ctx, s.feeds[id], zipPath); err != nil {
+		return 0, eris.Wrapf(err, "gtfs: download feed %s", id)
+	}
+	rows, err := parseGTFSFeed(zipPath, id, now)
+	if err != nil {
+		return 0, eris.Wrapf(err, "gtfs: parse feed %s", id)
+	}
+	if len(rows) == 0 {
+		return 0, eris.Errorf("gtfs: feed %s has no stops", id)
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += gtfsBatchSize {
+		end := min(start+gtfsBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.transit_stops",
+			Columns:      transitStopCols,
+			ConflictKeys: transitStopConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrapf(err, "gtfs: upsert feed %s", id)
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.transit_stops WHERE feed_id = $1 AND synced_at < $2`, id, now); err != nil {
+		return 0, eris.Wrapf(err, "gtfs: prune feed %s", id)
+	}
+	return total, nil
+}
+
+// gtfsStopService is the weekday service at one stop.
+type gtfsStopService struct {
+	routes map[string]bool
+	daily  int
+	peak   int
+}
+
+// parseGTFSFeed reads a GTFS zip into geo.transit_stops rows. Stops and
+// platforms are kept (stations and entrances are not); stops without
+// weekday service load with zero departures.
+func parseGTFSFeed(zipPath, feedID string, now time.Time) ([][]any, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "open zip")
+	}
+	defer zr.Close() //nolint:errcheck
+
+	routeTypes := make(map[string]int16)
+	err = readGTFSFile(&zr.Reader, "routes.txt", true, func(col map[string]int, rec []string) {
+		if t, err := strconv.ParseInt(csvString(rec, colIdx(col, "route_type")), 10, 16); err == nil {
+			routeTypes[csvString(rec, colIdx(col, "route_id"))] = int16(t)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := gtfsWeekdayServices(&zr.Reader, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tripRoutes := make(map[string]string)
+	err = readGTFSFile(&zr.Reader, "trips.txt", true, func(col map[string]int, rec []string) {
+		if services != nil && !services[csvString(rec, colIdx(col, "service_id"))] {
+			return
+		}
+		tripRoutes[csvString(rec, colIdx(col, "trip_id"))] = csvString(rec, colIdx(col, "route_id"))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service := make(map[string]*gtfsStopService)
+	err = readGTFSFile(&zr.Reader, "stop_times.txt", true, func(col map[string]int, rec []string) {
+		route, ok := tripRoutes[csvString(rec, colIdx(col, "trip_id"))]
+		if !ok {
+			return
+		}
+		stopID := csvString(rec, colIdx(col, "stop_id"))
+		sv := service[stopID]
+		if sv == nil {
+			sv = &gtfsStopService{routes: make(map[string]bool)}
+			service[stopID] = sv
+		}
+		sv.routes[route] = true
+		sv.daily++
+		dep, ok := parseGTFSTime(csvString(rec, colIdx(col, "departure_time")))
+		if !ok {
+			dep, ok = parseGTFSTime(csvString(rec, colIdx(col, "arrival_time")))
+		}
+		if ok && dep >= gtfsPeakStart && dep < gtfsPeakEnd {
+			sv.peak++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]any
+	err = readGTFSFile(&zr.Reader, "stops.txt", true, func(col map[string]int, rec []string) {
+		if lt := csvString(rec, colIdx(col, "location_type")); lt != "" && lt != "0" {
+			return
+		}
+		stopID := csvString(rec, colIdx(col, "stop_id"))
+		lat := parseFloatOrNil(csvString(rec, colIdx(col, "stop_lat")))
+		lon := parseFloatOrNil(csvString(rec, colIdx(col, "stop_lon")))
+		if stopID == "" || lat == nil || lon == nil || (*lat == 0 && *lon == 0) {
+			return
+		}
+		rows = append(rows, newTransitStopRow(feedID, stopID, csvString(rec, colIdx(col, "stop_name")),
+			service[stopID], routeTypes, *lat, *lon, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// newTransitStopRow builds a geo.transit_stops row. Peak headway is the
+// average minutes between departures in the peak window, nil without peak
+// service.
+func newTransitStopRow(feedID, stopID, name string, sv *gtfsStopService, routeTypes map[string]int16, lat, lon float64, now time.Time) []any {
+	var (
+		routeCount, daily, peak int
+		types                   []int16
+		headway                 *float64
+	)
+	if sv != nil {
+		routeCount, daily, peak = len(sv.routes), sv.daily, sv.peak
+		for route := range sv.routes {
+			if t, ok := routeTypes[route]; ok && !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		slices.Sort(types)
+	}
+	if peak > 0 {
+		h := math.Round(float64(gtfsPeakEnd-gtfsPeakStart)/60/float64(peak)*10) / 10
+		headway = &h
+	}
+	return []any{
+		feedID, stopID, nilIfEmpty(name), routeCount, types,
+		daily, peak, headway, lat, lon, gtfsSource, now,
+	}
+}
+
+// gtfsWeekdayServices returns the service ids running on Wednesdays whose
+// date range covers now, falling back to every Wednesday service when the
+// feed's calendar has expired or not yet started. Feeds without
+// calendar.txt rows use gtfsDateServices.
+func gtfsWeekdayServices(zr *zip.Reader, now time.Time) (map[string]bool, error) {
+	today := now.Format("20060102")
+	var (
+		found   bool
+		current = make(map[string]bool)
+		all     = make(map[string]bool)
+	)
+	err := readGTFSFile(zr, "calendar.txt", false, func(col map[string]int, rec []string) {
+		found = true
+		if csvString(rec, colIdx(col, "wednesday")) != "1" {
+			return
+		}
+		id := csvString(rec, colIdx(col, "service_id"))
+		all[id] = true
+		start, end := csvString(rec, colIdx(col, "start_date")), csvString(rec, colIdx(col, "end_date"))
+		if (start == "" || start <= today) && (end == "" || end >= today) {
+			current[id] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return gtfsDateServices(zr, now)
+	}
+	if len(current) > 0 {
+		return current, nil
+	}
+	return all, nil
+}
+
+// gtfsDateServices returns the service ids calendar_dates.txt adds
+// (exception_type 1) on a representative weekday: the upcoming Monday to
+// Friday date with the most services, or the busiest past weekday when
+// the feed has no upcoming dates. Returns nil (all services) when the feed
+// has no calendar_dates.txt rows either.
+func gtfsDateServices(zr *zip.Reader, now time.Time) (map[string]bool, error) {
+	today := now.Format("20060102")
+	var found bool
+	byDate := make(map[string]map[string]bool)
+	err := readGTFSFile(zr, "calendar_dates.txt", false, func(col map[string]int, rec []string) {
+		found = true
+		if csvString(rec, colIdx(col, "exception_type")) != "1" {
+			return
+		}
+		date := csvString(rec, colIdx(col, "date"))
+		d, err := time.Parse("20060102", date)
+		if err != nil || d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			return
+		}
+		if byDate[date] == nil {
+			byDate[date] = make(map[string]bool)
+		}
+		byDate[date][csvString(rec, colIdx(col, "service_id"))] = true
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var best, bestPast string
+	for date, ids := range byDate {
+		pick := &best
+		if date < today {
+			pick = &bestPast
+		}
+		if *pick == "" || len(ids) > len(byDate[*pick]) || (len(ids) == len(byDate[*pick]) && date < *pick) {
+			*pick = date
+		}
+	}
+	if best == "" {
+		best = bestPast
+	}
+	if best == "" {
+		return map[string]bool{}, nil
+	}
+	return byDate[best], nil
+}
+
+// readGTFSFile streams the rows of a feed file to fn with the header's
+// column index. Files may sit in a subdirectory of the zip. A missing
+// optional file is not an error.
+func readGTFSFile(zr *zip.Reader, name string, required bool, fn func(col map[string]int, rec []string)) error {
+	var file *zip.File
+	for _, zf := range zr.File {
+		if path.Base(zf.Name) == name {
+			file = zf
+			break
+		}
+	}
+	if file == nil {
+		if required {
+			return eris.Errorf("missing %s", name)
+		}
+		return nil
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return eris.Wrapf(err, "open %s", name)
+	}
+	defer rc.Close() //nolint:errcheck
+
+	r := csv.NewReader(rc)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return eris.Wrapf(err, "read %s header", name)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	col := csvColIndex(header)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return eris.Wrapf(err, "read %s", name)
+		}
+		fn(col, rec)
+	}
+}
+
+// colIdx returns the index of a column, or -1 when the file lacks it.
+func colIdx(col map[string]int, name string) int {
+	if i, ok := col[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// parseGTFSTime parses a GTFS H:MM:SS time into seconds after midnight of
+// the service day. Hours may exceed 23 for trips past midnight.
+func parseGTFSTime(s string) (int, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	var secs int
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		secs = secs*60 + n
+	}
+	return secs, true
+}
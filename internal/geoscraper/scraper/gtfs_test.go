@@ -0,0 +1,245 @@
+package scraper
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// testGTFSFeed is a two-route feed: a bus with weekday and weekend
+// service and a rail line stopping at Union Station.
+var testGTFSFeed = map[string]string{
+	"feed/routes.txt": "\ufeffroute_id,route_short_name,route_type\nR1,1,3\nRL,Red,1\n",
+	"feed/calendar.txt": "service_id,monday,tuesday,wednesday,thursday,friday,saturday,sunday,start_date,end_date\n" +
+		"WK,1,1,1,1,1,0,0,20200101,20991231\n" +
+		"SA,0,0,0,0,0,1,0,20200101,20991231\n",
+	"feed/trips.txt": "route_id,service_id,trip_id\nR1,WK,T1\nR1,WK,T2\nR1,SA,T3\nRL,WK,T4\n",
+	"feed/stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+		"T1,07:10:00,07:10:00,S1,1\n" +
+		"T1,07:20:00,07:20:00,S2,2\n" +
+		"T2,17:30:00,,S1,1\n" +
+		"T3,07:15:00,07:15:00,S1,1\n" +
+		"T4,8:05:00,8:05:00,S2,1\n" +
+		"T4,25:10:00,25:10:00,S2,2\n",
+	"feed/stops.txt": "stop_id,stop_name,stop_lat,stop_lon,location_type\n" +
+		"S1,Main & 1st,30.2672,-97.7431,0\n" +
+		"S2,Union Station Platform,30.2700,-97.7400,\n" +
+		"ST,Union Station,30.2700,-97.7400,1\n" +
+		"S3,Unserved,30.3000,-97.7000,0\n" +
+		"BAD,No Location,,,0\n",
+}
+
+func buildGTFSZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func writeGTFSZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feed.zip")
+	require.NoError(t, os.WriteFile(path, buildGTFSZip(t, files), 0o600))
+	return path
+}
+
+func TestGTFS_Metadata(t *testing.T) {
+	s := &GTFS{feeds: map[string]string{"metro": "http://example.com/gtfs.zip"}}
+	assert.Equal(t, "gtfs", s.Name())
+	assert.Equal(t, "geo.transit_stops", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Monthly, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+	assert.False(t, (&GTFS{}).ShouldRun(now, nil), "no feeds configured")
+}
+
+func TestGTFS_Sync(t *testing.T) {
+	feed := buildGTFSZip(t, testGTFSFeed)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_transit_stops", transitStopCols, 3)
+	mock.ExpectExec("DELETE FROM geo.transit_stops WHERE feed_id").
+		WithArgs("metro", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(`DELETE FROM geo.transit_stops WHERE NOT \(feed_id = ANY`).
+		WithArgs([]string{"metro"}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	s := &GTFS{feeds: map[string]string{"metro": srv.URL + "/gtfs.zip"}}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, map[string]any{"metro": int64(3)}, result.Metadata["stops"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGTFS_Sync_Errors(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+
+	_, err = (&GTFS{}).Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no feeds configured")
+
+	_, err = (&GTFS{feeds: map[string]string{"../etc": "http://x"}}).Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid feed id")
+}
+
+func TestParseGTFSFeed(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	rows, err := parseGTFSFeed(writeGTFSZip(t, testGTFSFeed), "metro", now)
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "stations and stops without location are skipped")
+
+	byStop := make(map[string][]any)
+	for _, row := range rows {
+		require.Len(t, row, len(transitStopCols))
+		byStop[row[1].(string)] = row
+	}
+
+	// S1: two weekday bus trips, one in the peak; the Saturday trip is ignored.
+	s1 := byStop["S1"]
+	assert.Equal(t, "metro", s1[0])
+	assert.Equal(t, "Main & 1st", s1[2])
+	assert.Equal(t, 1, s1[3])
+	assert.Equal(t, []int16{3}, s1[4])
+	assert.Equal(t, 2, s1[5])
+	assert.Equal(t, 1, s1[6])
+	assert.InDelta(t, 120, *s1[7].(*float64), 1e-9)
+	assert.Equal(t, gtfsSource, s1[10])
+	assert.Equal(t, now, s1[11])
+
+	// S2: bus and rail, both peak; the after-midnight stop is off-peak.
+	s2 := byStop["S2"]
+	assert.Equal(t, 2, s2[3])
+	assert.Equal(t, []int16{1, 3}, s2[4])
+	assert.Equal(t, 3, s2[5])
+	assert.Equal(t, 2, s2[6])
+	assert.InDelta(t, 60, *s2[7].(*float64), 1e-9)
+
+	s3 := byStop["S3"]
+	assert.Equal(t, 0, s3[5])
+	assert.Nil(t, s3[7])
+}
+
+func TestParseGTFSFeed_MissingFile(t *testing.T) {
+	files := map[string]string{"routes.txt": testGTFSFeed["feed/routes.txt"]}
+	_, err := parseGTFSFeed(writeGTFSZip(t, files), "metro", fixedNow())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing trips.txt")
+}
+
+func TestGTFSWeekdayServices(t *testing.T) {
+	files := map[string]string{"calendar.txt": "service_id,wednesday,start_date,end_date\n" +
+		"OLD,1,20200101,20201231\n" +
+		"CUR,1,20260101,20261231\n" +
+		"SUN,0,20260101,20261231\n"}
+	zr, err := zip.OpenReader(writeGTFSZip(t, files))
+	require.NoError(t, err)
+	defer zr.Close() //nolint:errcheck
+
+	got, err := gtfsWeekdayServices(&zr.Reader, fixedNow())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"CUR": true}, got)
+
+	// An expired calendar falls back to every Wednesday service.
+	got, err = gtfsWeekdayServices(&zr.Reader, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"OLD": true, "CUR": true}, got)
+}
+
+func TestGTFSWeekdayServices_CalendarDatesOnly(t *testing.T) {
+	// fixedNow is Sunday 2026-03-01. Weekends and removals never count.
+	files := map[string]string{"calendar_dates.txt": "service_id,date,exception_type\n" +
+		"WKDY,20260302,1\n" +
+		"WKDY,20260303,1\n" +
+		"EXTRA,20260303,1\n" +
+		"WKND,20260307,1\n" +
+		"WKND,20260308,1\n" +
+		"CUT,20260304,2\n" +
+		"OLD,20250106,1\n"}
+	zr, err := zip.OpenReader(writeGTFSZip(t, files))
+	require.NoError(t, err)
+	defer zr.Close() //nolint:errcheck
+
+	got, err := gtfsWeekdayServices(&zr.Reader, fixedNow())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"WKDY": true, "EXTRA": true}, got)
+
+	// Past the last date, the busiest past weekday is used.
+	got, err = gtfsWeekdayServices(&zr.Reader, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"WKDY": true, "EXTRA": true}, got)
+
+	// A feed with only weekend service has no weekday services.
+	files = map[string]string{"calendar_dates.txt": "service_id,date,exception_type\nWKND,20260307,1\n"}
+	zr2, err := zip.OpenReader(writeGTFSZip(t, files))
+	require.NoError(t, err)
+	defer zr2.Close() //nolint:errcheck
+	got, err = gtfsWeekdayServices(&zr2.Reader, fixedNow())
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NotNil(t, got)
+
+	// No calendar files at all counts every service.
+	zr3, err := zip.OpenReader(writeGTFSZip(t, map[string]string{"stops.txt": "stop_id\n"}))
+	require.NoError(t, err)
+	defer zr3.Close() //nolint:errcheck
+	got, err = gtfsWeekdayServices(&zr3.Reader, fixedNow())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestParseGTFSTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"07:30:00", 27000, true},
+		{"7:30:00", 27000, true},
+		{"25:10:00", 90600, true},
+		{"", 0, false},
+		{"07:30", 0, false},
+		{"aa:00:00", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseGTFSTime(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
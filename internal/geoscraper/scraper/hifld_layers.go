@@ -0,0 +1,301 @@
+package scraper
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+)
+
+// defaultHIFLDLayers is the built-in HIFLD layer manifest.
+//
+//go:embed hifld_layers.yaml
+var defaultHIFLDLayers []byte
+
+// HIFLDLayer is one manifest entry: an ArcGIS layer loaded into its own
+// geo.hifld_<name> table.
+type HIFLDLayer struct {
+	Name    string             `yaml:"name"`
+	URL     string             `yaml:"url"`
+	Cadence geoscraper.Cadence `yaml:"cadence"`
+	Where   string             `yaml:"where"`
+	ID      string             `yaml:"id"`
+	Fields  map[string]string  `yaml:"fields"`
+}
+
+// Table returns the layer's target table.
+func (l HIFLDLayer) Table() string { return "geo.hifld_" + l.Name }
+
+// hifldLayerFieldCols are the mappable columns of a geo.hifld_* table, in
+// row order.
+var hifldLayerFieldCols = []string{"name", "address", "city", "state", "zip"}
+
+// hifldLayerCols are the columns written to geo.hifld_* tables.
+var hifldLayerCols = append(append([]string{}, hifldLayerFieldCols...),
+	"latitude", "longitude", "source", "source_id", "properties")
+
+var hifldLayerName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,40}$`)
+
+// ParseHIFLDLayers parses and validates a HIFLD layer manifest, filling
+// defaults (quarterly cadence, OBJECTID source ids).
+func ParseHIFLDLayers(data []byte) ([]HIFLDLayer, error) {
+	var m struct {
+		Layers []HIFLDLayer `yaml:"layers"`
+	}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, eris.Wrap(err, "hifld layers: parse manifest")
+	}
+
+	seen := make(map[string]bool, len(m.Layers))
+	for i := range m.Layers {
+		l := &m.Layers[i]
+		if !hifldLayerName.MatchString(l.Name) {
+			return nil, eris.Errorf("hifld layers: invalid layer name %q (lowercase letters, digits, underscores)", l.Name)
+		}
+		if seen[l.Name] {
+			return nil, eris.Errorf("hifld layers: duplicate layer %q", l.Name)
+		}
+		seen[l.Name] = true
+		if !strings.HasPrefix(l.URL, "https://") && !strings.HasPrefix(l.URL, "http://") {
+			return nil, eris.Errorf("hifld layers: %s: url must be an http(s) ArcGIS query endpoint", l.Name)
+		}
+		switch l.Cadence {
+		case "":
+			l.Cadence = geoscraper.Quarterly
+		case geoscraper.Annual, geoscraper.Quarterly, geoscraper.Monthly:
+		default:
+			return nil, eris.Errorf("hifld layers: %s: unsupported cadence %q (annual, quarterly, monthly)", l.Name, l.Cadence)
+		}
+		if l.ID == "" {
+			l.ID = "OBJECTID"
+		}
+		for col := range l.Fields {
+			if !slices.Contains(hifldLayerFieldCols, col) {
+				return nil, eris.Errorf("hifld layers: %s: unknown field column %q (%s)", l.Name, col, strings.Join(hifldLayerFieldCols, ", "))
+			}
+		}
+	}
+	return m.Layers, nil
+}
+
+// LoadHIFLDLayers reads the manifest at path, or the built-in manifest
+// when path is empty.
+func LoadHIFLDLayers(path string) ([]HIFLDLayer, error) {
+	if path == "" {
+		return ParseHIFLDLayers(defaultHIFLDLayers)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- manifest path comes from trusted config
+	if err != nil {
+		return nil, eris.Wrapf(err, "hifld layers: read manifest %s", path)
+	}
+	return ParseHIFLDLayers(data)
+}
+
+// HIFLDLayerScraper loads one manifest layer from its ArcGIS endpoint.
+type HIFLDLayerScraper struct {
+	layer   HIFLDLayer
+	baseURL string // override for testing; empty uses layer.URL
+}
+
+// NewHIFLDLayerScraper returns a scraper for layer.
+func NewHIFLDLayerScraper(layer HIFLDLayer) *HIFLDLayerScraper {
+	return &HIFLDLayerScraper{layer: layer}
+}
+
+// Name implements GeoScraper.
+func (h *HIFLDLayerScraper) Name() string { return "hifld_layer_" + h.layer.Name }
+
+// Table implements GeoScraper.
+func (h *HIFLDLayerScraper) Table() string { return h.layer.Table() }
+
+// Category implements GeoScraper.
+func (h *HIFLDLayerScraper) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (h *HIFLDLayerScraper) Cadence() geoscraper.Cadence { return h.layer.Cadence }
+
+// ShouldRun implements GeoScraper.
+func (h *HIFLDLayerScraper) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	switch h.layer.Cadence {
+	case geoscraper.Annual:
+		return hifldAnnualShouldRun(now, lastSync)
+	case geoscraper.Monthly:
+		return dataset.MonthlySchedule(now, lastSync)
+	default:
+		return hifldShouldRun(now, lastSync)
+	}
+}
+
+// Sync implements GeoScraper.
+func (h *HIFLDLayerScraper) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", h.Name()))
+	log.Info("starting HIFLD layer sync", zap.String("table", h.Table()))
+
+	if err := ensureHIFLDLayerTable(ctx, pool, h.Table()); err != nil {
+		return nil, eris.Wrapf(err, "%s", h.Name())
+	}
+
+	exclude := map[string]bool{"OBJECTID": true, h.layer.ID: true}
+	for _, attr := range h.layer.Fields {
+		exclude[attr] = true
+	}
+
+	var totalRows int64
+	var batch [][]any
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        h.Table(),
+			Columns:      hifldLayerCols,
+			ConflictKeys: infraConflictKeys,
+		}, batch)
+		if err != nil {
+			return eris.Wrapf(err, "%s: upsert batch", h.Name())
+		}
+		totalRows += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err := arcgis.QueryAll(ctx, f, arcgis.QueryConfig{
+		BaseURL:      hifldURL(h.baseURL, h.layer.URL),
+		Where:        h.layer.Where,
+		AutoPageSize: true,
+	}, func(features []arcgis.Feature) error {
+		for _, feat := range features {
+			row := h.newRow(feat, exclude)
+			if row == nil {
+				log.Warn("skipping feature with null geometry or id",
+					zap.Any("objectid", feat.Attributes["OBJECTID"]))
+				continue
+			}
+			batch = append(batch, row)
+			if len(batch) >= hifldBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: query arcgis", h.Name())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Info("HIFLD layer sync complete", zap.Int64("rows", totalRows))
+	return &geoscraper.SyncResult{RowsSynced: totalRows}, nil
+}
+
+// newRow builds a geo.hifld_* row, or nil when the feature has no
+// geometry or source id.
+func (h *HIFLDLayerScraper) newRow(feat arcgis.Feature, exclude map[string]bool) []any {
+	if feat.Geometry == nil {
+		return nil
+	}
+	id := hifldAttrString(feat.Attributes, h.layer.ID)
+	if id == "" {
+		return nil
+	}
+
+	row := make([]any, 0, len(hifldLayerCols))
+	for _, col := range hifldLayerFieldCols {
+		row = append(row, nilIfEmpty(hifldAttrString(feat.Attributes, h.layer.Fields[col])))
+	}
+	lat, lon := feat.Geometry.Centroid()
+	return append(row,
+		lat, lon,
+		hifldSource,
+		id,
+		hifldProperties(feat.Attributes, exclude),
+	)
+}
+
+// hifldAttrString returns an attribute as trimmed text, formatting numeric
+// values (ZIP codes and ids are often numeric in ArcGIS).
+func hifldAttrString(attrs map[string]any, key string) string {
+	if key == "" || attrs[key] == nil {
+		return ""
+	}
+	switch v := attrs[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// hifldLayerDDL creates a geo.hifld_* table and its indexes. Layers share
+// one shape so a manifest entry needs no migration.
+const hifldLayerDDL = `CREATE TABLE IF NOT EXISTS %[1]s (
+	id          BIGSERIAL PRIMARY KEY,
+	name        TEXT,
+	address     TEXT,
+	city        TEXT,
+	state       TEXT,
+	zip         TEXT,
+	latitude    DOUBLE PRECISION,
+	longitude   DOUBLE PRECISION,
+	geom        GEOMETRY(Point, 4326) GENERATED ALWAYS AS
+	            (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)) STORED,
+	source      TEXT NOT NULL DEFAULT 'hifld',
+	source_id   TEXT NOT NULL,
+	properties  JSONB DEFAULT '{}'::jsonb,
+	created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (source, source_id)
+);
+CREATE INDEX IF NOT EXISTS %[2]s ON %[1]s USING GIST (geom);
+CREATE INDEX IF NOT EXISTS %[3]s ON %[1]s (state)`
+
+// ensureHIFLDLayerTable creates table if it does not exist.
+func ensureHIFLDLayerTable(ctx context.Context, pool db.Pool, table string) error {
+	name := strings.TrimPrefix(table, "geo.")
+	ddl := fmt.Sprintf(hifldLayerDDL,
+		pgx.Identifier{"geo", name}.Sanitize(),
+		pgx.Identifier{"idx_" + name + "_geom"}.Sanitize(),
+		pgx.Identifier{"idx_" + name + "_state"}.Sanitize(),
+	)
+	if _, err := pool.Exec(ctx, ddl); err != nil {
+		return eris.Wrapf(err, "ensure table %s", table)
+	}
+	return nil
+}
+
+// RegisterHIFLDLayers registers one scraper per layer in the HIFLD layer
+// manifest (geo.hifld_layers, or the built-in manifest when unset). A
+// manifest that cannot be read or parsed registers nothing and is returned
+// as an error.
+func RegisterHIFLDLayers(reg *geoscraper.Registry, path string) error {
+	layers, err := LoadHIFLDLayers(path)
+	if err != nil {
+		return err
+	}
+	for _, l := range layers {
+		reg.Register(NewHIFLDLayerScraper(l))
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+)
+
+func TestParseHIFLDLayers_Default(t *testing.T) {
+	layers, err := LoadHIFLDLayers("")
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+
+	names := make([]string, len(layers))
+	for i, l := range layers {
+		names[i] = l.Name
+		assert.NotEmpty(t, l.ID, l.Name)
+		assert.NotEmpty(t, l.Fields["name"], l.Name)
+	}
+	assert.Equal(t, []string{"banks", "cell_towers"}, names)
+	assert.Equal(t, "geo.hifld_banks", layers[0].Table())
+	assert.Equal(t, geoscraper.Quarterly, layers[0].Cadence)
+}
+
+func TestParseHIFLDLayers_Defaults(t *testing.T) {
+	layers, err := ParseHIFLDLayers([]byte(`
+layers:
+  - name: ports
+    url: https://example.com/FeatureServer/0/query
+`))
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	assert.Equal(t, geoscraper.Quarterly, layers[0].Cadence)
+	assert.Equal(t, "OBJECTID", layers[0].ID)
+}
+
+func TestParseHIFLDLayers_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{"yaml", "layers: [", "parse manifest"},
+		{"name", "layers:\n  - name: Bad-Name\n    url: https://example.com/query\n", "invalid layer name"},
+		{"duplicate", "layers:\n  - name: a\n    url: https://example.com/query\n  - name: a\n    url: https://example.com/query\n", "duplicate layer"},
+		{"url", "layers:\n  - name: a\n    url: ftp://example.com/query\n", "http(s)"},
+		{"cadence", "layers:\n  - name: a\n    url: https://example.com/query\n    cadence: weekly\n", "unsupported cadence"},
+		{"field", "layers:\n  - name: a\n    url: https://example.com/query\n    fields:\n      county: CNTY\n", "unknown field column"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHIFLDLayers([]byte(tt.manifest))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadHIFLDLayers_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("layers:\n  - name: ports\n    url: https://example.com/query\n"), 0o600))
+
+	layers, err := LoadHIFLDLayers(path)
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+	assert.Equal(t, "ports", layers[0].Name)
+
+	_, err = LoadHIFLDLayers(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestRegisterHIFLDLayers(t *testing.T) {
+	reg := geoscraper.NewRegistry()
+	require.NoError(t, RegisterHIFLDLayers(reg, ""))
+	assert.Len(t, reg.AllNames(), 2)
+	_, err := reg.Get("hifld_layer_cell_towers")
+	assert.NoError(t, err)
+
+	// Layers fed into geo.infrastructure by the hifld_* scrapers are not
+	// loaded again.
+	for _, name := range []string{"hospitals", "schools", "fire_stations"} {
+		_, err := reg.Get("hifld_layer_" + name)
+		assert.Error(t, err, name)
+	}
+
+	reg = geoscraper.NewRegistry()
+	err = RegisterHIFLDLayers(reg, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read manifest")
+	assert.Empty(t, reg.AllNames())
+}
+
+func TestHIFLDLayerScraper_Metadata(t *testing.T) {
+	s := NewHIFLDLayerScraper(HIFLDLayer{Name: "banks", Cadence: geoscraper.Quarterly})
+	assert.Equal(t, "hifld_layer_banks", s.Name())
+	assert.Equal(t, "geo.hifld_banks", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Quarterly, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	recent := now.Add(-1)
+	assert.False(t, s.ShouldRun(now, &recent))
+}
+
+func TestHIFLDLayerScraper_Sync(t *testing.T) {
+	data := []byte(`{
+		"features": [
+			{"attributes": {"OBJECTID": 1, "ID": 1234567, "NAME": " General ", "CITY": "Austin", "STATE": "TX", "ZIP": 78701, "BEDS": 40}, "geometry": {"x": -97.74, "y": 30.27}},
+			{"attributes": {"OBJECTID": 2, "ID": "H2", "NAME": "County"}, "geometry": {"x": -95.0, "y": 30.0}},
+			{"attributes": {"OBJECTID": 3, "ID": "H3", "NAME": "No Geometry"}, "geometry": null},
+			{"attributes": {"OBJECTID": 4, "NAME": "No Id"}, "geometry": {"x": -95.0, "y": 30.0}}
+		],
+		"exceededTransferLimit": false
+	}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/Hospitals/FeatureServer/0" {
+			_, _ = w.Write([]byte(`{"maxRecordCount":2000,"objectIdField":"OBJECTID","advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	expectBoundaryUpsert(mock, "geo_hifld_clinics", hifldLayerCols, 2)
+
+	s := NewHIFLDLayerScraper(HIFLDLayer{
+		Name:    "clinics",
+		Cadence: geoscraper.Annual,
+		ID:      "ID",
+		Fields:  map[string]string{"name": "NAME", "city": "CITY", "state": "STATE", "zip": "ZIP"},
+	})
+	s.baseURL = srv.URL + "/Hospitals/FeatureServer/0/query"
+
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHIFLDLayerScraper_EnsureTableError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS").WillReturnError(assert.AnError)
+
+	s := NewHIFLDLayerScraper(HIFLDLayer{Name: "banks", URL: "http://127.0.0.1:1/query"})
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ensure table geo.hifld_banks")
+}
+
+func TestHIFLDLayerScraper_NewRow(t *testing.T) {
+	s := NewHIFLDLayerScraper(HIFLDLayer{
+		Name:   "hospitals",
+		ID:     "ID",
+		Fields: map[string]string{"name": "NAME", "zip": "ZIP"},
+	})
+	exclude := map[string]bool{"OBJECTID": true, "ID": true, "NAME": true, "ZIP": true}
+	x, y := -71.1, 42.3
+
+	row := s.newRow(arcgis.Feature{
+		Attributes: map[string]any{"OBJECTID": 1.0, "ID": 1234567.0, "NAME": " General ", "ZIP": 2134.0},
+		Geometry:   &arcgis.Geometry{X: &x, Y: &y},
+	}, exclude)
+	require.Len(t, row, len(hifldLayerCols))
+	assert.Equal(t, "General", row[0])
+	assert.Nil(t, row[1], "unmapped address is null")
+	assert.Equal(t, "2134", row[4])
+	assert.InDelta(t, 42.3, row[5], 1e-9)
+	assert.InDelta(t, -71.1, row[6], 1e-9)
+	assert.Equal(t, "hifld", row[7])
+	assert.Equal(t, "1234567", row[8])
+
+	assert.Nil(t, s.newRow(arcgis.Feature{Attributes: map[string]any{"ID": "x"}}, exclude))
+	assert.Nil(t, s.newRow(arcgis.Feature{
+		Attributes: map[string]any{"NAME": "No Id"},
+		Geometry:   &arcgis.Geometry{X: &x, Y: &y},
+	}, exclude))
+}
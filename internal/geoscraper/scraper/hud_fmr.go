@@ -20,35 +20,65 @@ import (
 
 // fmrExclude lists XLSX columns stored in dedicated DB columns.
 var fmrExclude = map[string]bool{
-	"fips":       true,
-	"state":      true,
-	"countyname": true,
-	"fmr_0":      true,
-	"fmr_1":      true,
-	"fmr_2":      true,
-	"fmr_3":      true,
-	"fmr_4":      true,
-	"year":       true,
+	"fips":          true,
+	"state":         true,
+	"countyname":    true,
+	"fmr_0":         true,
+	"fmr_1":         true,
+	"fmr_2":         true,
+	"fmr_3":         true,
+	"fmr_4":         true,
+	"year":          true,
+	"hud_area_code": true,
+	"hud_area_name": true,
 }
 
 var fmrCols = []string{
-	"fips", "state_fips", "county_name", "year",
+	"geo_level", "fips", "state_fips", "county_fips", "county_name", "hud_area_code", "area_name", "year",
 	"fmr_0br", "fmr_1br", "fmr_2br", "fmr_3br", "fmr_4br",
+	"median_family_income", "il_extremely_low_4p", "il_very_low_4p", "il_low_4p",
 	"source", "source_id", "properties",
 }
 
-var fmrConflictKeys = []string{"fips", "year"}
+var fmrConflictKeys = []string{"geo_level", "fips", "year"}
 
-// HUDFMR scrapes Fair Market Rent data from HUD.
+// HUD fiscal year loaded, and its FMR, Small Area FMR, and Section 8
+// income limits downloads.
+const (
+	hudFMRYear         = 2026
+	hudFMRURL          = "https://www.huduser.gov/portal/datasets/fmr/fmr2026/FY26_FMRs.xlsx"
+	hudSAFMRURL        = "https://www.huduser.gov/portal/datasets/fmr/fmr2026/fy2026_safmrs.xlsx"
+	hudIncomeLimitsURL = "https://www.huduser.gov/portal/datasets/il/il26/Section8-FY26.xlsx"
+)
+
+// Geography levels stored in geo.hud_fmr.geo_level.
+const (
+	hudLevelCounty = "county"
+	hudLevelZIP    = "zip"
+)
+
+const hudFMRSource = "hud_fmr"
+
+// hudIncomeLimitsSize is the number of income limit values per area:
+// median family income and the 30%, 50%, and 80% limits.
+const hudIncomeLimitsSize = 4
+
+// HUDFMR scrapes HUD Fair Market Rents into geo.hud_fmr: county (and New
+// England town) FMRs joined with Section 8 income limits for a
+// four-person household, plus ZIP-level Small Area FMRs. County rows keep
+// HUD's ten-digit FIPS code (county FIPS followed by the county
+// subdivision, 99999 for a whole county).
 type HUDFMR struct {
-	baseURL string // override for testing; empty uses default HUD endpoint
+	baseURL         string // override for testing; empty uses hudFMRURL
+	safmrURL        string // override for testing; empty uses hudSAFMRURL
+	incomeLimitsURL string // override for testing; empty uses hudIncomeLimitsURL
 }
 
 // Name implements GeoScraper.
 func (s *HUDFMR) Name() string { return "hud_fmr" }
 
 // Table implements GeoScraper.
-func (s *HUDFMR) Table() string { return "geo.fair_market_rents" }
+func (s *HUDFMR) Table() string { return "geo.hud_fmr" }
 
 // Category implements GeoScraper.
 func (s *HUDFMR) Category() geoscraper.Category { return geoscraper.National }
@@ -112,32 +142,19 @@ func (s *HUDFMR) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, temp
 	log := zap.L().With(zap.String("scraper", s.Name()))
 	log.Info("starting hud_fmr sync")
 
-	url := s.baseURL
-	if url == "" {
-		url = "https://www.huduser.gov/portal/datasets/fmr/fmr2026/FY26_FMRs.xlsx"
-	}
-
-	xlsxPath := filepath.Join(tempDir, "fmr.xlsx")
-	if _, err := f.DownloadToFile(ctx, url, xlsxPath); err != nil {
-		return nil, eris.Wrap(err, "hud_fmr: download")
-	}
-
-	xlFile, err := xlsx.OpenFile(xlsxPath)
+	sheet, err := hudDownloadSheet(ctx, f, usgsURL(s.baseURL, hudFMRURL), filepath.Join(tempDir, "fmr.xlsx"), "")
 	if err != nil {
-		return nil, eris.Wrap(err, "hud_fmr: open xlsx")
-	}
-
-	if len(xlFile.Sheets) == 0 {
-		return nil, eris.New("hud_fmr: no sheets in xlsx")
+		return nil, err
 	}
-	sheet := xlFile.Sheets[0]
-
 	if len(sheet.Rows) < 2 {
 		return nil, eris.New("hud_fmr: no data rows in xlsx")
 	}
 
-	headerRow := sheet.Rows[0]
-	cols := xlsxColIndex(headerRow)
+	ilSheet, err := hudDownloadSheet(ctx, f, usgsURL(s.incomeLimitsURL, hudIncomeLimitsURL), filepath.Join(tempDir, "income_limits.xlsx"), "income limits ")
+	if err != nil {
+		return nil, err
+	}
+	limits := parseHUDIncomeLimits(ilSheet)
 
 	var totalRows int64
 	var batch [][]any
@@ -159,6 +176,9 @@ func (s *HUDFMR) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, temp
 		return nil
 	}
 
+	headerRow := sheet.Rows[0]
+	cols := xlsxColIndex(headerRow)
+	var counties int
 	for i := 1; i < len(sheet.Rows); i++ {
 		row := sheet.Rows[i]
 
@@ -172,24 +192,32 @@ func (s *HUDFMR) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, temp
 		if stateFIPS == "" && len(fips) >= 2 {
 			stateFIPS = fips[:2]
 		}
+		var countyFIPS any
+		if len(fips) >= 5 {
+			countyFIPS = fips[:5]
+		}
 
-		year := 2026 // Current FMR year from the dataset
-		sourceID := fips + "_" + strconv.Itoa(year)
-
+		il := limits[fips]
 		batch = append(batch, []any{
+			hudLevelCounty,
 			fips,
 			stateFIPS,
+			countyFIPS,
 			xlsxString(row, cols["countyname"]),
-			year,
+			nilIfEmpty(xlsxString(row, colIdx(cols, "hud_area_code"))),
+			nilIfEmpty(xlsxString(row, colIdx(cols, "hud_area_name"))),
+			hudFMRYear,
 			csvFMRInt(xlsxString(row, cols["fmr_0"])),
 			csvFMRInt(xlsxString(row, cols["fmr_1"])),
 			csvFMRInt(xlsxString(row, cols["fmr_2"])),
 			csvFMRInt(xlsxString(row, cols["fmr_3"])),
 			csvFMRInt(xlsxString(row, cols["fmr_4"])),
-			"hud_fmr",
-			sourceID,
+			il[0], il[1], il[2], il[3],
+			hudFMRSource,
+			fips + "_" + strconv.Itoa(hudFMRYear),
 			xlsxProperties(row, headerRow, fmrExclude),
 		})
+		counties++
 
 		if len(batch) >= hifldBatchSize {
 			if err := flush(); err != nil {
@@ -198,10 +226,142 @@ func (s *HUDFMR) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, temp
 		}
 	}
 
+	safmrSheet, err := hudDownloadSheet(ctx, f, usgsURL(s.safmrURL, hudSAFMRURL), filepath.Join(tempDir, "safmr.xlsx"), "safmr ")
+	if err != nil {
+		return nil, err
+	}
+	zips := 0
+	for _, row := range parseHUDSAFMR(safmrSheet) {
+		batch = append(batch, row)
+		zips++
+		if len(batch) >= hifldBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if err := flush(); err != nil {
 		return nil, err
 	}
 
-	log.Info("hud_fmr sync complete", zap.Int64("rows", totalRows))
-	return &geoscraper.SyncResult{RowsSynced: totalRows}, nil
+	log.Info("hud_fmr sync complete", zap.Int64("rows", totalRows), zap.Int("counties", counties), zap.Int("zips", zips))
+	return &geoscraper.SyncResult{
+		RowsSynced: totalRows,
+		Metadata:   map[string]any{"counties": counties, "zips": zips, "income_limits": len(limits), "year": hudFMRYear},
+	}, nil
+}
+
+// hudDownloadSheet downloads a HUD workbook and returns its first sheet.
+// label prefixes error messages for the secondary workbooks.
+func hudDownloadSheet(ctx context.Context, f fetcher.Fetcher, url, path, label string) (*xlsx.Sheet, error) {
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrapf(err, "hud_fmr: %sdownload", label)
+	}
+	xlFile, err := xlsx.OpenFile(path)
+	if err != nil {
+		return nil, eris.Wrapf(err, "hud_fmr: %sopen xlsx", label)
+	}
+	if len(xlFile.Sheets) == 0 {
+		return nil, eris.Errorf("hud_fmr: %sno sheets in xlsx", label)
+	}
+	return xlFile.Sheets[0], nil
+}
+
+// parseHUDIncomeLimits maps HUD's ten-digit FIPS code to the area median
+// family income and the extremely low (30%), very low (50%), and low (80%)
+// income limits for a four-person household. Missing values are nil.
+func parseHUDIncomeLimits(sheet *xlsx.Sheet) map[string][hudIncomeLimitsSize]any {
+	limits := make(map[string][hudIncomeLimitsSize]any)
+	if len(sheet.Rows) == 0 {
+		return limits
+	}
+	cols := hudHeaderIndex(sheet.Rows[0])
+	medianCol := -1
+	for name, i := range cols {
+		if strings.HasPrefix(name, "median") {
+			medianCol = i
+		}
+	}
+	fipsCol := colIdx(cols, "fips")
+	if fipsCol < 0 {
+		fipsCol = colIdx(cols, "fips2010")
+	}
+	valueCols := []int{medianCol, colIdx(cols, "eli_4"), colIdx(cols, "l50_4"), colIdx(cols, "l80_4")}
+	for _, row := range sheet.Rows[1:] {
+		fips := xlsxString(row, fipsCol)
+		if fips == "" {
+			continue
+		}
+		var vals [hudIncomeLimitsSize]any
+		for i, c := range valueCols {
+			if n := csvFMRInt(xlsxString(row, c)); n > 0 {
+				vals[i] = n
+			}
+		}
+		limits[fips] = vals
+	}
+	return limits
+}
+
+// parseHUDSAFMR builds ZIP rows from the Small Area FMR workbook. A ZIP
+// spanning several HUD areas keeps its first row.
+func parseHUDSAFMR(sheet *xlsx.Sheet) [][]any {
+	if len(sheet.Rows) == 0 {
+		return nil
+	}
+	header := sheet.Rows[0]
+	cols := hudHeaderIndex(header)
+	var (
+		zipCol  = colIdx(cols, "zip code")
+		areaCol = colIdx(cols, "hud area code")
+		nameCol = colIdx(cols, "hud metro fair market rent area name")
+		fmrCols [5]int
+	)
+	for br := range fmrCols {
+		fmrCols[br] = colIdx(cols, "safmr "+strconv.Itoa(br)+"br")
+	}
+
+	seen := make(map[string]bool)
+	var rows [][]any
+	for _, row := range sheet.Rows[1:] {
+		zip := xlsxString(row, zipCol)
+		if len(zip) == 4 {
+			zip = "0" + zip // leading zero lost to a numeric cell
+		}
+		if len(zip) != 5 || !isDigits(zip) || seen[zip] {
+			continue
+		}
+		seen[zip] = true
+		rows = append(rows, []any{
+			hudLevelZIP,
+			zip,
+			nil,
+			nil,
+			nil,
+			nilIfEmpty(xlsxString(row, areaCol)),
+			nilIfEmpty(xlsxString(row, nameCol)),
+			hudFMRYear,
+			csvFMRInt(xlsxString(row, fmrCols[0])),
+			csvFMRInt(xlsxString(row, fmrCols[1])),
+			csvFMRInt(xlsxString(row, fmrCols[2])),
+			csvFMRInt(xlsxString(row, fmrCols[3])),
+			csvFMRInt(xlsxString(row, fmrCols[4])),
+			nil, nil, nil, nil,
+			hudFMRSource,
+			"zip_" + zip + "_" + strconv.Itoa(hudFMRYear),
+			json.RawMessage("{}"),
+		})
+	}
+	return rows
+}
+
+// hudHeaderIndex indexes a HUD header row by lowercased name with runs of
+// whitespace (HUD headers wrap onto several lines) collapsed to a space.
+func hudHeaderIndex(row *xlsx.Row) map[string]int {
+	m := make(map[string]int)
+	for i, cell := range row.Cells {
+		m[strings.ToLower(strings.Join(strings.Fields(cell.String()), " "))] = i
+	}
+	return m
 }
@@ -43,7 +43,7 @@ func buildFMRXLSX(t *testing.T, header []string, rows [][]string) []byte {
 func expectFMRUpsert(mock pgxmock.PgxPoolIface, rows int64) {
 	mock.ExpectBegin()
 	mock.ExpectExec("CREATE TEMP TABLE").WillReturnResult(pgxmock.NewResult("CREATE", 0))
-	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_geo_fair_market_rents"}, fmrCols).WillReturnResult(rows)
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_upsert_geo_hud_fmr"}, fmrCols).WillReturnResult(rows)
 	mock.ExpectExec("DELETE FROM").WillReturnResult(pgxmock.NewResult("DELETE", 0))
 	mock.ExpectExec("INSERT INTO").WillReturnResult(pgxmock.NewResult("INSERT", rows))
 	mock.ExpectCommit()
@@ -54,7 +54,7 @@ var fmrHeader = []string{"stusps", "state", "hud_area_code", "countyname", "coun
 func TestHUDFMR_Metadata(t *testing.T) {
 	s := &HUDFMR{}
 	assert.Equal(t, "hud_fmr", s.Name())
-	assert.Equal(t, "geo.fair_market_rents", s.Table())
+	assert.Equal(t, "geo.hud_fmr", s.Table())
 	assert.Equal(t, geoscraper.National, s.Category())
 	assert.Equal(t, geoscraper.Annual, s.Cadence())
 }
@@ -69,48 +69,79 @@ func TestHUDFMR_ShouldRun(t *testing.T) {
 	assert.False(t, s.ShouldRun(now, &recent))
 }
 
+var (
+	ilHeader    = []string{"fips2010", "State_Alpha", "County_Name", "median2026", "l50_4", "ELI_4", "l80_4"}
+	safmrHeader = []string{"ZIP\nCode", "HUD Area Code", "HUD Metro Fair Market Rent Area Name", "SAFMR\n0BR", "SAFMR\n1BR", "SAFMR\n2BR", "SAFMR\n3BR", "SAFMR\n4BR"}
+)
+
+// hudTestServer serves the FMR, income limits, and SAFMR workbooks by path.
+func hudTestServer(t *testing.T, fmr, il, safmr []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/il.xlsx":
+			_, _ = w.Write(il)
+		case "/safmr.xlsx":
+			_, _ = w.Write(safmr)
+		default:
+			_, _ = w.Write(fmr)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func hudTestScraper(srv *httptest.Server) *HUDFMR {
+	return &HUDFMR{
+		baseURL:         srv.URL + "/fmr.xlsx",
+		incomeLimitsURL: srv.URL + "/il.xlsx",
+		safmrURL:        srv.URL + "/safmr.xlsx",
+	}
+}
+
 func TestHUDFMR_Sync(t *testing.T) {
-	xlsxData := buildFMRXLSX(t, fmrHeader, [][]string{
+	fmr := buildFMRXLSX(t, fmrHeader, [][]string{
 		{"AL", "01", "METRO33860M33860", "Autauga County", "", "1", "Montgomery, AL MSA", "0100199999", "58761", "860", "870", "1016", "1304", "1537"},
 		{"TX", "48", "METRO12420M12420", "Travis County", "", "1", "Austin, TX MSA", "4845399999", "1290188", "1200", "1400", "1700", "2100", "2400"},
 	})
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write(xlsxData)
-	}))
-	defer srv.Close()
+	il := buildFMRXLSX(t, ilHeader, [][]string{
+		{"4845399999", "TX", "Travis County", "$126,000", "$63,000", "$37,800", "$100,800"},
+	})
+	safmr := buildFMRXLSX(t, safmrHeader, [][]string{
+		{"78701", "METRO12420M12420", "Austin, TX MSA", "$1,650", "$1,880", "$2,250", "$2,900", "$3,400"},
+	})
+	srv := hudTestServer(t, fmr, il, safmr)
 
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
-	expectFMRUpsert(mock, 2)
+	expectFMRUpsert(mock, 3)
 
-	s := &HUDFMR{baseURL: srv.URL}
 	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
-	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	result, err := hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
 	require.NoError(t, err)
-	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, 2, result.Metadata["counties"])
+	assert.Equal(t, 1, result.Metadata["zips"])
+	assert.Equal(t, 1, result.Metadata["income_limits"])
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestHUDFMR_EmptyFIPS(t *testing.T) {
-	xlsxData := buildFMRXLSX(t, fmrHeader, [][]string{
+	fmr := buildFMRXLSX(t, fmrHeader, [][]string{
 		{"TX", "48", "METRO", "Travis County", "", "1", "Austin", "", "100", "1200", "1400", "1700", "2100", "2400"},
 	})
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write(xlsxData)
-	}))
-	defer srv.Close()
+	il := buildFMRXLSX(t, ilHeader, nil)
+	safmr := buildFMRXLSX(t, safmrHeader, nil)
+	srv := hudTestServer(t, fmr, il, safmr)
 
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
-	s := &HUDFMR{baseURL: srv.URL}
 	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
-	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	result, err := hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), result.RowsSynced)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -132,14 +163,10 @@ func TestHUDFMR_DownloadError(t *testing.T) {
 }
 
 func TestHUDFMR_UpsertError(t *testing.T) {
-	xlsxData := buildFMRXLSX(t, fmrHeader, [][]string{
+	fmr := buildFMRXLSX(t, fmrHeader, [][]string{
 		{"AL", "01", "METRO", "Autauga County", "", "1", "Montgomery", "0100199999", "58761", "860", "870", "1016", "1304", "1537"},
 	})
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write(xlsxData)
-	}))
-	defer srv.Close()
+	srv := hudTestServer(t, fmr, buildFMRXLSX(t, ilHeader, nil), buildFMRXLSX(t, safmrHeader, nil))
 
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -147,49 +174,94 @@ func TestHUDFMR_UpsertError(t *testing.T) {
 
 	mock.ExpectBegin().WillReturnError(assert.AnError)
 
-	s := &HUDFMR{baseURL: srv.URL}
 	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
-	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	_, err = hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "upsert")
 }
 
 func TestHUDFMR_HeaderOnly(t *testing.T) {
-	xlsxData := buildFMRXLSX(t, fmrHeader, nil)
-
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write(xlsxData)
-	}))
-	defer srv.Close()
+	srv := hudTestServer(t, buildFMRXLSX(t, fmrHeader, nil), nil, nil)
 
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
-	s := &HUDFMR{baseURL: srv.URL}
 	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
-	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	_, err = hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no data rows")
 }
 
 func TestHUDFMR_InvalidXLSX(t *testing.T) {
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		_, _ = w.Write([]byte("not an xlsx file"))
-	}))
-	defer srv.Close()
+	srv := hudTestServer(t, []byte("not an xlsx file"), nil, nil)
 
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
-	s := &HUDFMR{baseURL: srv.URL}
 	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
-	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	_, err = hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "open xlsx")
 }
 
+func TestHUDFMR_InvalidIncomeLimits(t *testing.T) {
+	fmr := buildFMRXLSX(t, fmrHeader, [][]string{
+		{"AL", "01", "METRO", "Autauga County", "", "1", "Montgomery", "0100199999", "58761", "860", "870", "1016", "1304", "1537"},
+	})
+	srv := hudTestServer(t, fmr, []byte("not an xlsx file"), nil)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = hudTestScraper(srv).Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "income limits open xlsx")
+}
+
+func TestParseHUDIncomeLimits(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, ilHeader, [][]string{
+		{"4845399999", "TX", "Travis County", "126000", "63000", "37800", "100800"},
+		{"0100199999", "AL", "Autauga County", "82000", "", "", ""},
+		{"", "TX", "Blank", "1", "1", "1", "1"},
+	}))
+	require.NoError(t, err)
+
+	limits := parseHUDIncomeLimits(xlFile.Sheets[0])
+	require.Len(t, limits, 2)
+	assert.Equal(t, [hudIncomeLimitsSize]any{126000, 37800, 63000, 100800}, limits["4845399999"])
+	assert.Equal(t, [hudIncomeLimitsSize]any{82000, nil, nil, nil}, limits["0100199999"])
+}
+
+func TestParseHUDSAFMR(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, safmrHeader, [][]string{
+		{"78701", "METRO12420M12420", "Austin, TX MSA", "$1,650", "$1,880", "$2,250", "$2,900", "$3,400"},
+		{"78701", "METRO99999M99999", "Other", "1", "1", "1", "1", "1"},
+		{"1001", "METRO44140M44140", "Springfield, MA MSA", "$1,100", "$1,200", "$1,500", "$1,900", "$2,100"},
+		{"ABCDE", "", "", "", "", "", "", ""},
+	}))
+	require.NoError(t, err)
+
+	rows := parseHUDSAFMR(xlFile.Sheets[0])
+	require.Len(t, rows, 2, "duplicate and invalid ZIPs are skipped")
+
+	row := rows[0]
+	require.Len(t, row, len(fmrCols))
+	assert.Equal(t, hudLevelZIP, row[0])
+	assert.Equal(t, "78701", row[1])
+	assert.Equal(t, "METRO12420M12420", row[5])
+	assert.Equal(t, "Austin, TX MSA", row[6])
+	assert.Equal(t, 1650, row[8])
+	assert.Equal(t, 2250, row[10])
+	assert.Equal(t, 3400, row[12])
+	assert.Equal(t, "zip_78701_2026", row[18])
+
+	assert.Equal(t, "01001", rows[1][1], "leading zero restored")
+}
+
 func TestCsvFMRInt(t *testing.T) {
 	tests := []struct {
 		input string
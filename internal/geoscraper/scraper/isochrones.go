@@ -0,0 +1,255 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/routing"
+)
+
+// isochroneSourceTable is the table whose rows isochrones are computed
+// around.
+const isochroneSourceTable = "public.company_addresses"
+
+// isochroneConcurrency caps concurrent routing requests.
+const isochroneConcurrency = 4
+
+// isochroneTargetsSQL selects geocoded primary company addresses missing a
+// current isochrone for any configured contour ($3): never computed, older
+// than $1 days, or computed from a different point. $2 limits the batch
+// (0 = all).
+const isochroneTargetsSQL = `
+SELECT a.id::text, ST_Y(a.geom), ST_X(a.geom)
+FROM public.company_addresses a
+WHERE a.is_primary AND a.geom IS NOT NULL
+	AND (
+		SELECT count(*) FROM geo.isochrones i
+		WHERE i.source_table = 'public.company_addresses' AND i.source_id = a.id::text
+			AND i.minutes = ANY($3)
+			AND i.computed_at > now() - make_interval(days => $1)
+			AND ST_Equals(i.origin, a.geom)
+	) < cardinality($3)
+ORDER BY a.id
+LIMIT NULLIF($2, 0)`
+
+// upsertIsochronesSQL writes one location's contours and clears their
+// stats for recomputation.
+const upsertIsochronesSQL = `
+INSERT INTO geo.isochrones (source_table, source_id, minutes, provider, origin, geom, computed_at)
+SELECT $1, $2, t.minutes, $3, ST_SetSRID(ST_MakePoint($5, $4), 4326),
+	ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_SetSRID(ST_GeomFromGeoJSON(t.geojson), 4326)), 3)),
+	now()
+FROM unnest($6::smallint[], $7::text[]) AS t(minutes, geojson)
+ON CONFLICT (source_table, source_id, minutes) DO UPDATE SET
+	provider = EXCLUDED.provider,
+	origin = EXCLUDED.origin,
+	geom = EXCLUDED.geom,
+	computed_at = EXCLUDED.computed_at,
+	population = NULL,
+	establishments = NULL,
+	employees = NULL,
+	stats_at = NULL`
+
+// isochroneStatsSQL sets population from the latest ACS tract totals
+// (B01003_001E) and establishments and employees from the latest CBP
+// county all-industry totals for isochrones without stats. Each tract or
+// county counts in proportion to the share of its area inside the
+// isochrone.
+const isochroneStatsSQL = `
+WITH years AS (
+	SELECT (SELECT MAX(year) FROM fed_data.acs_data WHERE geo_level = 'tract') AS acs_year,
+		(SELECT MAX(year) FROM fed_data.cbp_data) AS cbp_year
+),
+stats AS (
+	SELECT i.id, pop.population, biz.establishments, biz.employees
+	FROM geo.isochrones i
+	LEFT JOIN LATERAL (
+		SELECT SUM(a.value * ST_Area(ST_Intersection(t.geom, i.geom)) / NULLIF(ST_Area(t.geom), 0)) AS population
+		FROM geo.census_tracts t
+		JOIN fed_data.acs_data a ON a.geo_level = 'tract' AND a.geo_id = t.geoid
+			AND a.variable = 'B01003_001E' AND a.year = (SELECT acs_year FROM years)
+		WHERE ST_Intersects(t.geom, i.geom)
+	) pop ON true
+	LEFT JOIN LATERAL (
+		SELECT SUM(c.est * f.share) AS establishments, SUM(c.emp * f.share) AS employees
+		FROM (
+			SELECT co.geoid, ST_Area(ST_Intersection(co.geom, i.geom)) / NULLIF(ST_Area(co.geom), 0) AS share
+			FROM geo.counties co
+			WHERE ST_Intersects(co.geom, i.geom)
+		) f
+		JOIN fed_data.cbp_data c ON c.fips_state || c.fips_county = f.geoid
+			AND c.naics = '000000' AND c.year = (SELECT cbp_year FROM years)
+	) biz ON true
+	WHERE i.stats_at IS NULL
+)
+UPDATE geo.isochrones i SET
+	population = round(s.population)::bigint,
+	establishments = round(s.establishments)::bigint,
+	employees = round(s.employees)::bigint,
+	acs_year = (SELECT acs_year FROM years),
+	cbp_year = (SELECT cbp_year FROM years),
+	stats_at = now()
+FROM stats s
+WHERE i.id = s.id`
+
+// Isochrones computes drive-time isochrones around geocoded primary
+// company addresses through a Valhalla or OSRM routing engine and stores
+// them in geo.isochrones with the ACS population and CBP establishments
+// and employees inside each. Nothing runs until geo.isochrones.url is set.
+type Isochrones struct {
+	cfg    config.IsochroneConfig
+	client routing.Client // override for testing; nil builds from cfg
+}
+
+// Name implements GeoScraper.
+func (s *Isochrones) Name() string { return "isochrones" }
+
+// Table implements GeoScraper.
+func (s *Isochrones) Table() string { return "geo.isochrones" }
+
+// Category implements GeoScraper.
+func (s *Isochrones) Category() geoscraper.Category { return geoscraper.OnDemand }
+
+// Cadence implements GeoScraper.
+func (s *Isochrones) Cadence() geoscraper.Cadence { return geoscraper.Monthly }
+
+// ShouldRun implements GeoScraper. Nothing runs until a routing engine is
+// configured.
+func (s *Isochrones) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return (s.client != nil || s.cfg.URL != "") && dataset.MonthlySchedule(now, lastSync)
+}
+
+type isochroneTarget struct {
+	id       string
+	lat, lng float64
+}
+
+// Sync implements GeoScraper. Locations the routing engine cannot route
+// from are logged and skipped; the run fails only if every location fails.
+func (s *Isochrones) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+
+	client := s.client
+	if client == nil {
+		var err error
+		if client, err = routing.New(s.cfg.Provider, s.cfg.URL, s.cfg.Profile); err != nil {
+			return nil, eris.Wrap(err, "isochrones")
+		}
+	}
+	minutes := s.cfg.Minutes
+	if len(minutes) == 0 {
+		minutes = []int{15, 30, 60}
+	}
+
+	targets, err := s.targets(ctx, pool, minutes)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("computing isochrones", zap.Int("locations", len(targets)), zap.Ints("minutes", minutes))
+
+	var (
+		mu       sync.Mutex
+		rows     int64
+		failures int
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(isochroneConcurrency)
+	for _, t := range targets {
+		g.Go(func() error {
+			isos, err := client.Isochrones(gctx, t.lat, t.lng, minutes)
+			if err != nil {
+				if gctx.Err() != nil {
+					return eris.Wrap(gctx.Err(), "isochrones: canceled")
+				}
+				log.Warn("isochrones: routing failed", zap.String("source_id", t.id), zap.Error(err))
+				mu.Lock()
+				failures++
+				mu.Unlock()
+				return nil
+			}
+			n, err := s.store(gctx, pool, client.Provider(), t, isos)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			rows += n
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if len(targets) > 0 && failures == len(targets) {
+		return nil, eris.Errorf("isochrones: routing failed for all %d locations", failures)
+	}
+
+	tag, err := pool.Exec(ctx, isochroneStatsSQL)
+	if err != nil {
+		return nil, eris.Wrap(err, "isochrones: compute stats")
+	}
+
+	log.Info("isochrones sync complete", zap.Int64("rows", rows), zap.Int("failed", failures),
+		zap.Int64("stats", tag.RowsAffected()))
+	return &geoscraper.SyncResult{
+		RowsSynced: rows,
+		Metadata: map[string]any{
+			"provider":  client.Provider(),
+			"locations": len(targets),
+			"failed":    failures,
+			"minutes":   minutes,
+		},
+	}, nil
+}
+
+// targets returns the locations needing isochrones.
+func (s *Isochrones) targets(ctx context.Context, pool db.Pool, minutes []int) ([]isochroneTarget, error) {
+	refresh := s.cfg.RefreshDays
+	if refresh <= 0 {
+		refresh = 180
+	}
+	rows, err := pool.Query(ctx, isochroneTargetsSQL, refresh, s.cfg.MaxLocations, minutes)
+	if err != nil {
+		return nil, eris.Wrap(err, "isochrones: query targets")
+	}
+	defer rows.Close()
+
+	var out []isochroneTarget
+	for rows.Next() {
+		var t isochroneTarget
+		if err := rows.Scan(&t.id, &t.lat, &t.lng); err != nil {
+			return nil, eris.Wrap(err, "isochrones: scan target")
+		}
+		out = append(out, t)
+	}
+	return out, eris.Wrap(rows.Err(), "isochrones: iterate targets")
+}
+
+// store upserts a location's isochrones and returns the rows written.
+func (s *Isochrones) store(ctx context.Context, pool db.Pool, provider string, t isochroneTarget, isos []routing.Isochrone) (int64, error) {
+	if len(isos) == 0 {
+		return 0, nil
+	}
+	minutes := make([]int16, len(isos))
+	geoms := make([]string, len(isos))
+	for i, iso := range isos {
+		minutes[i] = int16(iso.Minutes) //nolint:gosec // contour minutes are small config values
+		geoms[i] = iso.GeoJSON
+	}
+	tag, err := pool.Exec(ctx, upsertIsochronesSQL,
+		isochroneSourceTable, t.id, provider, t.lat, t.lng, minutes, geoms)
+	if err != nil {
+		return 0, eris.Wrapf(err, "isochrones: store %s", t.id)
+	}
+	return tag.RowsAffected(), nil
+}
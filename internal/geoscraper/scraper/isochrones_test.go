@@ -0,0 +1,104 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/routing"
+)
+
+type fakeRouting struct {
+	fail map[float64]bool
+}
+
+func (f *fakeRouting) Provider() string { return routing.Valhalla }
+
+func (f *fakeRouting) Isochrones(_ context.Context, lat, _ float64, minutes []int) ([]routing.Isochrone, error) {
+	if f.fail[lat] {
+		return nil, errors.New("no suitable edges near location")
+	}
+	out := make([]routing.Isochrone, len(minutes))
+	for i, m := range minutes {
+		out[i] = routing.Isochrone{Minutes: m, GeoJSON: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`}
+	}
+	return out, nil
+}
+
+var isochroneTargetCols = []string{"id", "lat", "lng"}
+
+func TestIsochrones_Metadata(t *testing.T) {
+	s := &Isochrones{}
+	assert.Equal(t, "isochrones", s.Name())
+	assert.Equal(t, "geo.isochrones", s.Table())
+	assert.Equal(t, geoscraper.OnDemand, s.Category())
+	assert.Equal(t, geoscraper.Monthly, s.Cadence())
+
+	assert.False(t, s.ShouldRun(time.Now(), nil))
+	s.cfg.URL = "http://valhalla:8002"
+	assert.True(t, s.ShouldRun(time.Now(), nil))
+}
+
+func TestIsochrones_Sync(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM public\.company_addresses a`).
+		WithArgs(90, 10, []int{15, 30}).
+		WillReturnRows(pgxmock.NewRows(isochroneTargetCols).
+			AddRow("11", 32.78, -96.8).
+			AddRow("12", 0.0, 0.0))
+	mock.ExpectExec(`INSERT INTO geo\.isochrones`).
+		WithArgs(isochroneSourceTable, "11", routing.Valhalla, 32.78, -96.8,
+			[]int16{15, 30}, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectExec(`UPDATE geo\.isochrones i SET`).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	s := &Isochrones{
+		cfg:    config.IsochroneConfig{Minutes: []int{15, 30}, MaxLocations: 10, RefreshDays: 90},
+		client: &fakeRouting{fail: map[float64]bool{0: true}},
+	}
+	res, err := s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.RowsSynced)
+	assert.Equal(t, 1, res.Metadata["failed"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsochrones_Sync_AllFail(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM public\.company_addresses a`).
+		WithArgs(180, 0, []int{15, 30, 60}).
+		WillReturnRows(pgxmock.NewRows(isochroneTargetCols).AddRow("12", 0.0, 0.0))
+
+	s := &Isochrones{client: &fakeRouting{fail: map[float64]bool{0: true}}}
+	_, err = s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "routing failed for all 1 locations")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsochrones_Sync_NoEndpoint(t *testing.T) {
+	_, err := (&Isochrones{}).Sync(context.Background(), nil, nil, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "routing: no endpoint")
+}
+
+func TestIsochroneStatsSQL(t *testing.T) {
+	assert.Contains(t, isochroneStatsSQL, "a.variable = 'B01003_001E'")
+	assert.Contains(t, isochroneStatsSQL, "c.naics = '000000'")
+	assert.Contains(t, isochroneStatsSQL, "WHERE i.stats_at IS NULL")
+	assert.Contains(t, upsertIsochronesSQL, "stats_at = NULL")
+}
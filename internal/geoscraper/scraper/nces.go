@@ -0,0 +1,305 @@
+package scraper
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
+)
+
+// ncesSource is the source identifier for NCES scrapers.
+const ncesSource = "nces"
+
+// NCES EDGE FeatureServer endpoints: composite school district boundaries
+// and Common Core of Data public school characteristics.
+const (
+	schoolDistrictsBaseURL = "https://services1.arcgis.com/Ua5sjt3LWTPigjyD/arcgis/rest/services/School_District_Boundaries_Current/FeatureServer/0/query"
+	schoolCharsBaseURL     = "https://services1.arcgis.com/Ua5sjt3LWTPigjyD/arcgis/rest/services/Public_School_Characteristics_Current/FeatureServer/0/query"
+)
+
+// schoolDistrictCols are the columns written to the temp table for district
+// loads. geom_wkt is TEXT in the temp table; converted to geometry via
+// ST_GeomFromEWKT.
+var schoolDistrictCols = []string{
+	"geoid", "name", "state_fips", "district_type", "lowest_grade", "highest_grade",
+	"school_year", "schools", "enrollment", "teachers", "student_teacher_ratio",
+	"frl_pct", "geom_wkt", "properties",
+}
+
+// schoolDistrictTypes maps TIGER MAF/TIGER feature class codes to district
+// types.
+var schoolDistrictTypes = map[string]string{
+	"G5400": "elementary",
+	"G5410": "secondary",
+	"G5420": "unified",
+}
+
+// districtSchools is the roll-up of public school characteristics for one
+// district.
+type districtSchools struct {
+	schools    int
+	enrollment int
+	teachers   float64
+	frl        int
+}
+
+// NCES loads NCES EDGE school district boundaries into geo.school_districts
+// with enrollment, staffing, and free/reduced lunch totals rolled up from
+// the public school characteristics. The table is replaced as a whole.
+// After a sync it assigns geocoded company addresses to the district
+// containing them.
+type NCES struct {
+	districtsURL string // override for testing
+	schoolsURL   string // override for testing
+}
+
+// Name implements GeoScraper.
+func (s *NCES) Name() string { return "nces" }
+
+// Table implements GeoScraper.
+func (s *NCES) Table() string { return "geo.school_districts" }
+
+// Category implements GeoScraper.
+func (s *NCES) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *NCES) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper. EDGE publishes the new school year's
+// boundaries and CCD files in the fall.
+func (s *NCES) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.October)
+}
+
+// PostSyncHooks implements postsync.Declarer: geocoded company addresses
+// are assigned to the school district they fall in.
+func (s *NCES) PostSyncHooks() []string { return []string{postsync.SchoolDistrictTag} }
+
+// Sync implements GeoScraper.
+func (s *NCES) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting NCES school districts sync")
+
+	schools, err := fetchDistrictSchools(ctx, f, usgsURL(s.schoolsURL, schoolCharsBaseURL))
+	if err != nil {
+		return nil, err
+	}
+	log.Info("school characteristics loaded", zap.Int("districts", len(schools)))
+
+	rows, err := fetchSchoolDistricts(ctx, f, usgsURL(s.districtsURL, schoolDistrictsBaseURL), schools)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := replaceSchoolDistricts(ctx, pool, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("NCES school districts sync complete", zap.Int64("rows", n))
+	return &geoscraper.SyncResult{
+		RowsSynced: n,
+		Metadata:   map[string]any{"districts": n, "districts_with_schools": len(schools)},
+	}, nil
+}
+
+// fetchDistrictSchools queries every public school and rolls its
+// enrollment, teachers, and free/reduced lunch counts up by LEA id.
+// Suppressed or missing counts (negative in the CCD) are left out of the
+// totals.
+func fetchDistrictSchools(ctx context.Context, f fetcher.Fetcher, baseURL string) (map[string]*districtSchools, error) {
+	byLEA := make(map[string]*districtSchools)
+	err := arcgis.QueryAll(ctx, f, arcgis.QueryConfig{
+		BaseURL:      baseURL,
+		OutFields:    []string{"LEAID", "TOTAL", "FTE", "TOTFRL"},
+		AutoPageSize: true,
+	}, func(features []arcgis.Feature) error {
+		for _, feat := range features {
+			lea := padFIPS(hifldAttrString(feat.Attributes, "LEAID"), 7)
+			if lea == "" {
+				continue
+			}
+			d := byLEA[lea]
+			if d == nil {
+				d = &districtSchools{}
+				byLEA[lea] = d
+			}
+			d.schools++
+			if v := hifldFloat64(feat.Attributes, "TOTAL"); v > 0 {
+				d.enrollment += int(v)
+			}
+			if v := hifldFloat64(feat.Attributes, "FTE"); v > 0 {
+				d.teachers += v
+			}
+			if v := hifldFloat64(feat.Attributes, "TOTFRL"); v > 0 {
+				d.frl += int(v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "nces: query school characteristics")
+	}
+	return byLEA, nil
+}
+
+// fetchSchoolDistricts queries every district polygon and builds temp
+// table rows, joining the school roll-up by LEA id. Features without an id
+// or geometry are skipped.
+func fetchSchoolDistricts(ctx context.Context, f fetcher.Fetcher, baseURL string, schools map[string]*districtSchools) ([][]any, error) {
+	var (
+		rows    [][]any
+		skipped int
+	)
+	err := arcgis.QueryAll(ctx, f, arcgis.QueryConfig{
+		BaseURL:      baseURL,
+		OutFields:    []string{"*"},
+		AutoPageSize: true,
+	}, func(features []arcgis.Feature) error {
+		for _, feat := range features {
+			row, ok := newSchoolDistrictRow(feat, schools)
+			if !ok {
+				skipped++
+				continue
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "nces: query school districts")
+	}
+	if skipped > 0 {
+		zap.L().Debug("skipped school district features", zap.Int("skipped", skipped))
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("nces: no school district polygons")
+	}
+	return rows, nil
+}
+
+// newSchoolDistrictRow builds a temp table row from a district feature.
+func newSchoolDistrictRow(feat arcgis.Feature, schools map[string]*districtSchools) ([]any, bool) {
+	if feat.Geometry == nil || len(feat.Geometry.Rings) == 0 {
+		return nil, false
+	}
+	attrs := feat.Attributes
+	geoid := padFIPS(hifldAttrString(attrs, "GEOID"), 7)
+	name := hifldAttrString(attrs, "NAME")
+	if len(geoid) != 7 || name == "" {
+		return nil, false
+	}
+
+	var (
+		count      int
+		enrollment *int
+		teachers   *float64
+		ratio      *float64
+		frlPct     *float64
+	)
+	if d := schools[geoid]; d != nil {
+		count = d.schools
+		if d.enrollment > 0 {
+			enrollment = &d.enrollment
+		}
+		if d.teachers > 0 {
+			t := math.Round(d.teachers*100) / 100
+			teachers = &t
+		}
+		if d.enrollment > 0 && d.teachers > 0 {
+			r := math.Round(float64(d.enrollment)/d.teachers*100) / 100
+			ratio = &r
+		}
+		if d.enrollment > 0 && d.frl > 0 {
+			p := math.Round(float64(d.frl)/float64(d.enrollment)*1000) / 10
+			frlPct = &p
+		}
+	}
+
+	exclude := map[string]bool{
+		"OBJECTID": true, "GEOID": true, "NAME": true, "STATEFP": true,
+		"MTFCC": true, "LOGRADE": true, "HIGRADE": true, "SCHOOLYEAR": true,
+		"Shape__Area": true, "Shape__Length": true,
+	}
+	return []any{
+		geoid,
+		name,
+		nilIfEmpty(padFIPS(hifldAttrString(attrs, "STATEFP"), 2)),
+		nilIfEmpty(schoolDistrictTypes[strings.ToUpper(hifldAttrString(attrs, "MTFCC"))]),
+		nilIfEmpty(hifldAttrString(attrs, "LOGRADE")),
+		nilIfEmpty(hifldAttrString(attrs, "HIGRADE")),
+		nilIfEmpty(hifldAttrString(attrs, "SCHOOLYEAR")),
+		count,
+		enrollment,
+		teachers,
+		ratio,
+		frlPct,
+		feat.Geometry.EWKT(),
+		hifldProperties(attrs, exclude),
+	}, true
+}
+
+// replaceSchoolDistricts swaps every district in a single transaction,
+// converting EWKT geometry via ST_GeomFromEWKT.
+func replaceSchoolDistricts(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "nces: begin tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	createSQL := `CREATE TEMP TABLE _tmp_school_districts (
+		geoid                 TEXT,
+		name                  TEXT,
+		state_fips            TEXT,
+		district_type         TEXT,
+		lowest_grade          TEXT,
+		highest_grade         TEXT,
+		school_year           TEXT,
+		schools               INTEGER,
+		enrollment            INTEGER,
+		teachers              DOUBLE PRECISION,
+		student_teacher_ratio DOUBLE PRECISION,
+		frl_pct               DOUBLE PRECISION,
+		geom_wkt              TEXT,
+		properties            JSONB
+	) ON COMMIT DROP`
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return 0, eris.Wrap(err, "nces: create temp table")
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"_tmp_school_districts"}, schoolDistrictCols, pgx.CopyFromRows(rows)); err != nil {
+		return 0, eris.Wrap(err, "nces: COPY into temp table")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM geo.school_districts`); err != nil {
+		return 0, eris.Wrap(err, "nces: delete districts")
+	}
+
+	tag, err := tx.Exec(ctx, `INSERT INTO geo.school_districts (geoid, name, state_fips, district_type, lowest_grade, highest_grade,
+			school_year, schools, enrollment, teachers, student_teacher_ratio, frl_pct, geom, source, properties)
+		SELECT DISTINCT ON (geoid) geoid, name, state_fips, district_type, lowest_grade, highest_grade,
+			school_year, schools, enrollment, teachers, student_teacher_ratio, frl_pct,
+			ST_Multi(ST_GeomFromEWKT(geom_wkt)), '`+ncesSource+`', properties
+		FROM _tmp_school_districts
+		ORDER BY geoid`)
+	if err != nil {
+		return 0, eris.Wrap(err, "nces: insert districts")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, eris.Wrap(err, "nces: commit tx")
+	}
+	return tag.RowsAffected(), nil
+}
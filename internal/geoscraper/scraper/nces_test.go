@@ -0,0 +1,153 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
+)
+
+const (
+	testSchoolDistricts = `{"features": [
+		{"attributes": {"OBJECTID": 1, "GEOID": "4823640", "NAME": "Austin Independent School District", "STATEFP": "48", "MTFCC": "G5420", "LOGRADE": "PK", "HIGRADE": "12", "SCHOOLYEAR": "2023-2024", "UNSDLEA": "23640"}, "geometry": {"rings": [[[-97.9, 30.1], [-97.6, 30.1], [-97.6, 30.4], [-97.9, 30.1]]]}},
+		{"attributes": {"OBJECTID": 2, "GEOID": 600001, "NAME": "Example Elementary District", "STATEFP": 6, "MTFCC": "G5400"}, "geometry": {"rings": [[[-122.5, 37.7], [-122.3, 37.7], [-122.3, 37.9], [-122.5, 37.7]]]}},
+		{"attributes": {"OBJECTID": 3, "GEOID": "", "NAME": "No Id"}, "geometry": {"rings": [[[-122.5, 37.7], [-122.3, 37.7], [-122.3, 37.9], [-122.5, 37.7]]]}},
+		{"attributes": {"OBJECTID": 4, "GEOID": "4800001", "NAME": "No Geometry"}, "geometry": null}
+	], "exceededTransferLimit": false}`
+	testSchoolChars = `{"features": [
+		{"attributes": {"LEAID": "4823640", "TOTAL": 1200, "FTE": 80.5, "TOTFRL": 600}},
+		{"attributes": {"LEAID": "4823640", "TOTAL": 800, "FTE": 49.5, "TOTFRL": -1}},
+		{"attributes": {"LEAID": 600001, "TOTAL": -9, "FTE": null, "TOTFRL": null}},
+		{"attributes": {"LEAID": null, "TOTAL": 50}}
+	], "exceededTransferLimit": false}`
+)
+
+// newNCESServer serves layer metadata and query responses for the district
+// and school layers under /districts and /schools.
+func newNCESServer(t *testing.T, districts, schools string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/FeatureServer/0") {
+			_, _ = w.Write([]byte(`{"maxRecordCount":2000,"objectIdField":"OBJECTID","advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/schools/") {
+			_, _ = w.Write([]byte(schools))
+			return
+		}
+		_, _ = w.Write([]byte(districts))
+	}))
+}
+
+func TestNCES_Metadata(t *testing.T) {
+	s := &NCES{}
+	assert.Equal(t, "nces", s.Name())
+	assert.Equal(t, "geo.school_districts", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+
+	assert.Equal(t, []string{postsync.SchoolDistrictTag}, s.PostSyncHooks())
+}
+
+func TestNCES_Sync(t *testing.T) {
+	srv := newNCESServer(t, testSchoolDistricts, testSchoolChars)
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_school_districts").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_school_districts"}, schoolDistrictCols).WillReturnResult(2)
+	mock.ExpectExec("DELETE FROM geo.school_districts").WillReturnResult(pgxmock.NewResult("DELETE", 5))
+	mock.ExpectExec(`INSERT INTO geo.school_districts \(`).WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectCommit()
+
+	s := &NCES{
+		districtsURL: srv.URL + "/districts/FeatureServer/0/query",
+		schoolsURL:   srv.URL + "/schools/FeatureServer/0/query",
+	}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, 2, result.Metadata["districts_with_schools"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNCES_Sync_NoDistricts(t *testing.T) {
+	srv := newNCESServer(t, `{"features": []}`, testSchoolChars)
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &NCES{
+		districtsURL: srv.URL + "/districts/FeatureServer/0/query",
+		schoolsURL:   srv.URL + "/schools/FeatureServer/0/query",
+	}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no school district polygons")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewSchoolDistrictRow(t *testing.T) {
+	geom := &arcgis.Geometry{Rings: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}
+	schools := map[string]*districtSchools{
+		"4823640": {schools: 2, enrollment: 2000, teachers: 130, frl: 600},
+	}
+
+	row, ok := newSchoolDistrictRow(arcgis.Feature{Attributes: map[string]any{
+		"OBJECTID": 1.0, "GEOID": "4823640", "NAME": "Austin ISD", "STATEFP": "48",
+		"MTFCC": "G5420", "LOGRADE": "PK", "HIGRADE": "12", "UNSDLEA": "23640",
+	}, Geometry: geom}, schools)
+	require.True(t, ok)
+	require.Len(t, row, len(schoolDistrictCols))
+	assert.Equal(t, "4823640", row[0])
+	assert.Equal(t, "48", row[2])
+	assert.Equal(t, "unified", row[3])
+	assert.Equal(t, "PK", row[4])
+	assert.Equal(t, 2, row[7])
+	assert.Equal(t, 2000, *row[8].(*int))
+	assert.InDelta(t, 130, *row[9].(*float64), 1e-9)
+	assert.InDelta(t, 15.38, *row[10].(*float64), 1e-9)
+	assert.InDelta(t, 30, *row[11].(*float64), 1e-9)
+	assert.JSONEq(t, `{"UNSDLEA":"23640"}`, string(row[13].([]byte)))
+
+	// Numeric ids are zero-padded; districts without schools carry no totals.
+	row, ok = newSchoolDistrictRow(arcgis.Feature{Attributes: map[string]any{
+		"GEOID": 600001.0, "NAME": "Example", "STATEFP": 6.0, "MTFCC": "G5410",
+	}, Geometry: geom}, schools)
+	require.True(t, ok)
+	assert.Equal(t, "0600001", row[0])
+	assert.Equal(t, "06", row[2])
+	assert.Equal(t, "secondary", row[3])
+	assert.Equal(t, 0, row[7])
+	assert.Nil(t, row[8])
+	assert.Nil(t, row[11])
+
+	_, ok = newSchoolDistrictRow(arcgis.Feature{Attributes: map[string]any{"GEOID": "4823640"}, Geometry: geom}, schools)
+	assert.False(t, ok, "missing name")
+	_, ok = newSchoolDistrictRow(arcgis.Feature{Attributes: map[string]any{"GEOID": "4823640", "NAME": "x"}}, schools)
+	assert.False(t, ok, "missing geometry")
+}
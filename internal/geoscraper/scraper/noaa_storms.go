@@ -0,0 +1,766 @@
+package scraper
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// noaaSource is the source identifier for NOAA scrapers.
+const noaaSource = "noaa"
+
+// stormEventsBaseURL is the NCEI directory of yearly Storm Events CSVs.
+const stormEventsBaseURL = "https://www.ncei.noaa.gov/pub/data/swdi/stormevents/csvfiles/"
+
+// nwsZoneCountyPageURL is the NWS page linking the current zone-county
+// correlation file (bpDDmmYY.dbx), republished a few times a year.
+const nwsZoneCountyPageURL = "https://www.weather.gov/gis/ZoneCounty"
+
+// climateNormalsURL is the NCEI 1991-2020 annual/seasonal normals archive,
+// one CSV per station.
+const climateNormalsURL = "https://www.ncei.noaa.gov/data/normals-annualseasonal/1991-2020/archive/us-climate-normals_1991-2020_v1.0.1_annualseasonal_multivariate_by-station_c20230404.tar.gz"
+
+// climateNormalsPeriod is the normals period loaded.
+const climateNormalsPeriod = "1991-2020"
+
+// stormEventYears is how many of the most recent yearly files are kept.
+const stormEventYears = 10
+
+// stormBatchSize is the number of rows per BulkUpsert batch.
+const stormBatchSize = 5000
+
+// stormDetailsFile matches yearly details files, capturing the event year
+// and the creation date NCEI stamps on each republication.
+var stormDetailsFile = regexp.MustCompile(`StormEvents_details-ftp_v1\.0_d(\d{4})_c(\d{8})\.csv\.gz`)
+
+// stormEventCols are the columns written to geo.storm_events.
+var stormEventCols = []string{
+	"event_id", "episode_id", "year", "begin_date", "state", "state_fips",
+	"cz_type", "cz_fips", "cz_name", "county_fips", "event_type",
+	"injuries", "deaths", "damage_property", "damage_crops",
+	"magnitude", "magnitude_type", "latitude", "longitude", "source",
+}
+
+var stormEventConflictKeys = []string{"event_id"}
+
+// nwsZoneCountyFile matches zone-county correlation file links, capturing
+// the day, two-letter month, and two-digit year of the release.
+var nwsZoneCountyFile = regexp.MustCompile(`[^"'\s<>]*bp(\d{2})([a-z]{2})(\d{2})\.dbx`)
+
+// nwsZoneMonths maps the NWS two-letter month codes used in zone-county
+// file names.
+var nwsZoneMonths = map[string]time.Month{
+	"ja": time.January, "fe": time.February, "mr": time.March, "ap": time.April,
+	"my": time.May, "jn": time.June, "jl": time.July, "au": time.August,
+	"se": time.September, "oc": time.October, "no": time.November, "de": time.December,
+}
+
+// nwsZoneCountyCols are the columns written to geo.nws_zone_counties.
+var nwsZoneCountyCols = []string{"state_fips", "zone", "county_fips", "state_zone", "zone_name", "synced_at"}
+
+var nwsZoneCountyConflictKeys = []string{"state_fips", "zone", "county_fips"}
+
+// climateNormalCols are the columns written to geo.climate_normals.
+var climateNormalCols = []string{
+	"station_id", "name", "elevation_m",
+	"tavg_f", "tmax_f", "tmin_f", "prcp_in", "snow_in", "heating_dd", "cooling_dd",
+	"period", "latitude", "longitude", "source",
+}
+
+var climateNormalConflictKeys = []string{"station_id"}
+
+// NOAAStorms loads NOAA Storm Events (by county, event type, and damages)
+// into geo.storm_events and 1991-2020 station climate normals into
+// geo.climate_normals. Storm Events years whose file has not been
+// republished since the last load are skipped, and years outside the
+// most recent stormEventYears are pruned. Zone-coded events, which
+// include most hurricane, flood, and winter events, are tagged with a
+// county through the NWS zone-county correlation in geo.nws_zone_counties.
+type NOAAStorms struct {
+	stormsBaseURL string // override for testing; empty uses stormEventsBaseURL
+	zonesPageURL  string // override for testing; empty uses nwsZoneCountyPageURL
+	normalsURL    string // override for testing; empty uses climateNormalsURL
+}
+
+// Name implements GeoScraper.
+func (s *NOAAStorms) Name() string { return "noaa_storms" }
+
+// Table implements GeoScraper.
+func (s *NOAAStorms) Table() string { return "geo.storm_events" }
+
+// Category implements GeoScraper.
+func (s *NOAAStorms) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *NOAAStorms) Cadence() geoscraper.Cadence { return geoscraper.Monthly }
+
+// ShouldRun implements GeoScraper.
+func (s *NOAAStorms) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.MonthlySchedule(now, lastSync)
+}
+
+// Sync implements GeoScraper.
+func (s *NOAAStorms) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting NOAA storms sync")
+
+	events, loaded, unchanged, err := s.syncStormEvents(ctx, pool, f, tempDir)
+	if err != nil {
+		return nil, err
+	}
+	zones, tagged, err := s.syncZoneCounties(ctx, pool, f, tempDir)
+	if err != nil {
+		return nil, err
+	}
+	stations, err := s.syncClimateNormals(ctx, pool, f, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("NOAA storms sync complete",
+		zap.Int64("storm_events", events),
+		zap.Int64("zones_tagged", tagged),
+		zap.Int64("stations", stations),
+	)
+	return &geoscraper.SyncResult{
+		RowsSynced: events + stations,
+		Metadata: map[string]any{
+			"storm_events":    events,
+			"years_loaded":    loaded,
+			"years_unchanged": unchanged,
+			"zone_counties":   zones,
+			"zones_tagged":    tagged,
+			"stations":        stations,
+		},
+	}, nil
+}
+
+// syncStormEvents loads every changed yearly file in the window and prunes
+// years that have left it.
+func (s *NOAAStorms) syncStormEvents(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (int64, int, int, error) {
+	base := s.stormsBaseURL
+	if base == "" {
+		base = stormEventsBaseURL
+	}
+	files, err := listStormEventFiles(ctx, f, base)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	years := make([]int, 0, len(files))
+	for y := range files {
+		years = append(years, y)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+	if len(years) > stormEventYears {
+		years = years[:stormEventYears]
+	}
+
+	prev, err := loadedStormEventFiles(ctx, pool)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var (
+		total     int64
+		loaded    int
+		unchanged int
+	)
+	for _, year := range years {
+		name := files[year]
+		if prev[year] == name {
+			unchanged++
+			continue
+		}
+		n, err := s.loadStormEventYear(ctx, pool, f, base, year, name, tempDir)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		zap.L().Info("storm events year loaded", zap.Int("year", year), zap.Int64("events", n))
+		total += n
+		loaded++
+	}
+
+	oldest := int16(years[len(years)-1])
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.storm_events WHERE year < $1`, oldest); err != nil {
+		return 0, 0, 0, eris.Wrap(err, "noaa_storms: prune events")
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.storm_event_files WHERE year < $1`, oldest); err != nil {
+		return 0, 0, 0, eris.Wrap(err, "noaa_storms: prune files")
+	}
+	return total, loaded, unchanged, nil
+}
+
+// listStormEventFiles reads the NCEI directory listing and returns the
+// newest details file for each year.
+func listStormEventFiles(ctx context.Context, f fetcher.Fetcher, base string) (map[int]string, error) {
+	rc, err := f.Download(ctx, base)
+	if err != nil {
+		return nil, eris.Wrap(err, "noaa_storms: list storm event files")
+	}
+	defer rc.Close() //nolint:errcheck
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, eris.Wrap(err, "noaa_storms: read file listing")
+	}
+
+	files := make(map[int]string)
+	for _, m := range stormDetailsFile.FindAllStringSubmatch(string(body), -1) {
+		year, _ := strconv.Atoi(m[1])
+		// File names differ only in the creation date, so the
+		// lexically greatest is the newest.
+		if m[0] > files[year] {
+			files[year] = m[0]
+		}
+	}
+	if len(files) == 0 {
+		return nil, eris.New("noaa_storms: no storm event files in listing")
+	}
+	return files, nil
+}
+
+// loadedStormEventFiles returns the file loaded for each year.
+func loadedStormEventFiles(ctx context.Context, pool db.Pool) (map[int]string, error) {
+	rows, err := pool.Query(ctx, `SELECT year, file_name FROM geo.storm_event_files`)
+	if err != nil {
+		return nil, eris.Wrap(err, "noaa_storms: query loaded files")
+	}
+	defer rows.Close()
+
+	loaded := make(map[int]string)
+	for rows.Next() {
+		var (
+			year int16
+			name string
+		)
+		if err := rows.Scan(&year, &name); err != nil {
+			return nil, eris.Wrap(err, "noaa_storms: scan loaded file")
+		}
+		loaded[int(year)] = name
+	}
+	return loaded, eris.Wrap(rows.Err(), "noaa_storms: read loaded files")
+}
+
+// loadStormEventYear downloads and upserts one yearly details file, then
+// records it in geo.storm_event_files.
+func (s *NOAAStorms) loadStormEventYear(ctx context.Context, pool db.Pool, f fetcher.Fetcher, base string, year int, name, tempDir string) (int64, error) {
+	gzPath := filepath.Join(tempDir, name)
+	if _, err := f.DownloadToFile(ctx, strings.TrimSuffix(base, "/")+"/"+name, gzPath); err != nil {
+		return 0, eris.Wrapf(err, "noaa_storms: download %d", year)
+	}
+	rows, err := parseStormEventsFile(gzPath)
+	if err != nil {
+		return 0, eris.Wrapf(err, "noaa_storms: parse %d", year)
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += stormBatchSize {
+		end := min(start+stormBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.storm_events",
+			Columns:      stormEventCols,
+			ConflictKeys: stormEventConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrapf(err, "noaa_storms: upsert %d", year)
+		}
+		total += n
+	}
+
+	_, err = pool.Exec(ctx, `INSERT INTO geo.storm_event_files (year, file_name, events, loaded_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (year) DO UPDATE SET
+			file_name = EXCLUDED.file_name,
+			events    = EXCLUDED.events,
+			loaded_at = now()`,
+		int16(year), name, len(rows))
+	if err != nil {
+		return 0, eris.Wrapf(err, "noaa_storms: record file %d", year)
+	}
+	return total, nil
+}
+
+// parseStormEventsFile reads a gzipped Storm Events details CSV into
+// geo.storm_events rows. Rows without an event id or type are skipped.
+// Only county-coded events get county_fips here; zone-coded events are
+// tagged after load by syncZoneCounties.
+func parseStormEventsFile(path string) ([][]any, error) {
+	file, err := os.Open(path) //nolint:gosec // path is under the sync temp dir
+	if err != nil {
+		return nil, eris.Wrap(err, "open file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, eris.Wrap(err, "open gzip")
+	}
+	defer gz.Close() //nolint:errcheck
+
+	r := csv.NewReader(gz)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "read header")
+	}
+	idx := csvColIndex(header)
+	get := func(rec []string, name string) string {
+		if i, ok := idx[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var rows [][]any
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "read row")
+		}
+		eventID, err := strconv.ParseInt(get(rec, "EVENT_ID"), 10, 64)
+		eventType := get(rec, "EVENT_TYPE")
+		if err != nil || eventType == "" {
+			continue
+		}
+		year, _ := strconv.Atoi(get(rec, "YEAR"))
+
+		stateFIPS := padFIPS(get(rec, "STATE_FIPS"), 2)
+		czType := get(rec, "CZ_TYPE")
+		czFIPS := padFIPS(get(rec, "CZ_FIPS"), 3)
+		var county any
+		if czType == "C" && stateFIPS != "" && czFIPS != "" {
+			county = stateFIPS + czFIPS
+		}
+
+		var episode any
+		if v, err := strconv.ParseInt(get(rec, "EPISODE_ID"), 10, 64); err == nil {
+			episode = v
+		}
+
+		rows = append(rows, []any{
+			eventID,
+			episode,
+			int16(year),
+			stormBeginDate(get(rec, "BEGIN_YEARMONTH"), get(rec, "BEGIN_DAY")),
+			nilIfEmpty(get(rec, "STATE")),
+			nilIfEmpty(stateFIPS),
+			nilIfEmpty(czType),
+			nilIfEmpty(czFIPS),
+			nilIfEmpty(get(rec, "CZ_NAME")),
+			county,
+			eventType,
+			atoiOrZero(get(rec, "INJURIES_DIRECT")) + atoiOrZero(get(rec, "INJURIES_INDIRECT")),
+			atoiOrZero(get(rec, "DEATHS_DIRECT")) + atoiOrZero(get(rec, "DEATHS_INDIRECT")),
+			parseStormDamage(get(rec, "DAMAGE_PROPERTY")),
+			parseStormDamage(get(rec, "DAMAGE_CROPS")),
+			parseFloatOrNil(get(rec, "MAGNITUDE")),
+			nilIfEmpty(get(rec, "MAGNITUDE_TYPE")),
+			parseFloatOrNil(get(rec, "BEGIN_LAT")),
+			parseFloatOrNil(get(rec, "BEGIN_LON")),
+			noaaSource,
+		})
+	}
+	return rows, nil
+}
+
+// syncZoneCounties loads the current NWS zone-county correlation file into
+// geo.nws_zone_counties and tags zone-coded storm events with a county. It
+// returns the correlation rows loaded and the events tagged.
+func (s *NOAAStorms) syncZoneCounties(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (int64, int64, error) {
+	page := s.zonesPageURL
+	if page == "" {
+		page = nwsZoneCountyPageURL
+	}
+	fileURL, err := findZoneCountyFile(ctx, f, page)
+	if err != nil {
+		return 0, 0, err
+	}
+	path := filepath.Join(tempDir, "nws_zone_counties.dbx")
+	if _, err := f.DownloadToFile(ctx, fileURL, path); err != nil {
+		return 0, 0, eris.Wrap(err, "noaa_storms: download zone counties")
+	}
+	file, err := os.Open(path) //nolint:gosec // path is under the sync temp dir
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "noaa_storms: open zone counties")
+	}
+	defer file.Close() //nolint:errcheck
+
+	now := time.Now().UTC()
+	rows, err := parseZoneCounties(file, now)
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "noaa_storms: parse zone counties")
+	}
+	if len(rows) == 0 {
+		return 0, 0, eris.New("noaa_storms: no zone counties")
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += stormBatchSize {
+		end := min(start+stormBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.nws_zone_counties",
+			Columns:      nwsZoneCountyCols,
+			ConflictKeys: nwsZoneCountyConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, 0, eris.Wrap(err, "noaa_storms: upsert zone counties")
+		}
+		total += n
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.nws_zone_counties WHERE synced_at < $1`, now); err != nil {
+		return 0, 0, eris.Wrap(err, "noaa_storms: prune zone counties")
+	}
+
+	tag, err := pool.Exec(ctx, tagStormEventZoneCountiesSQL)
+	if err != nil {
+		return 0, 0, eris.Wrap(err, "noaa_storms: tag zone event counties")
+	}
+	return total, tag.RowsAffected(), nil
+}
+
+// tagStormEventZoneCountiesSQL sets the county of each zone-coded event.
+// A zone covering one county maps to it directly; for a zone spanning
+// several, the county containing the event's begin point wins. Events in
+// multi-county zones without a location are left untagged rather than
+// counted against every county the zone touches.
+const tagStormEventZoneCountiesSQL = `WITH candidates AS (
+	SELECT e.event_id, z.county_fips,
+		COUNT(*) OVER (PARTITION BY e.event_id) AS zone_counties,
+		c.geoid IS NOT NULL AS contains_point
+	FROM geo.storm_events e
+	JOIN geo.nws_zone_counties z ON z.state_fips = e.state_fips AND z.zone = e.cz_fips
+	LEFT JOIN geo.counties c ON c.geoid = z.county_fips AND ST_Contains(c.geom, e.geom)
+	WHERE e.cz_type = 'Z'
+), matched AS (
+	SELECT DISTINCT ON (event_id) event_id, county_fips
+	FROM candidates
+	WHERE zone_counties = 1 OR contains_point
+	ORDER BY event_id, contains_point DESC
+)
+UPDATE geo.storm_events e
+SET county_fips = m.county_fips
+FROM matched m
+WHERE e.event_id = m.event_id
+	AND e.county_fips IS DISTINCT FROM m.county_fips`
+
+// findZoneCountyFile reads the NWS zone-county page and returns the URL of
+// the newest correlation file it links.
+func findZoneCountyFile(ctx context.Context, f fetcher.Fetcher, page string) (string, error) {
+	rc, err := f.Download(ctx, page)
+	if err != nil {
+		return "", eris.Wrap(err, "noaa_storms: fetch zone county page")
+	}
+	defer rc.Close() //nolint:errcheck
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		return "", eris.Wrap(err, "noaa_storms: read zone county page")
+	}
+	base, err := url.Parse(page)
+	if err != nil {
+		return "", eris.Wrap(err, "noaa_storms: parse zone county page url")
+	}
+
+	var (
+		newest time.Time
+		link   string
+	)
+	for _, m := range nwsZoneCountyFile.FindAllStringSubmatch(string(body), -1) {
+		day, _ := strconv.Atoi(m[1])
+		month, ok := nwsZoneMonths[m[2]]
+		year, _ := strconv.Atoi(m[3])
+		if !ok {
+			continue
+		}
+		released := time.Date(2000+year, month, day, 0, 0, 0, 0, time.UTC)
+		if link == "" || released.After(newest) {
+			newest, link = released, m[0]
+		}
+	}
+	if link == "" {
+		return "", eris.New("noaa_storms: no zone county file on page")
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", eris.Wrapf(err, "noaa_storms: parse zone county link %s", link)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// parseZoneCounties reads the pipe-delimited, headerless NWS zone-county
+// file (STATE|ZONE|CWA|NAME|STATE_ZONE|COUNTY|FIPS|...) into
+// geo.nws_zone_counties rows. Rows without a numeric zone and county FIPS
+// are skipped, as are repeats of a zone-county pair.
+func parseZoneCounties(r io.Reader, now time.Time) ([][]any, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = '|'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	seen := make(map[string]bool)
+	var rows [][]any
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "read row")
+		}
+		zone := padFIPS(csvString(rec, 1), 3)
+		county := csvString(rec, 6)
+		if zone == "" || len(county) != 5 || !isDigits(county) {
+			continue
+		}
+		key := county[:2] + zone + county
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, []any{
+			county[:2],
+			zone,
+			county,
+			nilIfEmpty(csvString(rec, 4)),
+			nilIfEmpty(csvString(rec, 3)),
+			now,
+		})
+	}
+	return rows, nil
+}
+
+// parseStormDamage converts a Storm Events damage string such as "10.00K",
+// "1.5M", or "2B" to dollars. Blank or malformed values are zero.
+func parseStormDamage(s string) float64 {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0
+	}
+	mult := 1.0
+	switch s[len(s)-1] {
+	case 'K':
+		mult = 1e3
+	case 'M':
+		mult = 1e6
+	case 'B':
+		mult = 1e9
+	case 'H':
+		mult = 1e2
+	}
+	if mult != 1 {
+		s = s[:len(s)-1]
+		if s == "" {
+			return mult // bare unit means one of it
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v * mult
+}
+
+// stormBeginDate builds the event begin date from BEGIN_YEARMONTH (YYYYMM)
+// and BEGIN_DAY, or nil when either is malformed.
+func stormBeginDate(yearMonth, day string) any {
+	if len(yearMonth) != 6 {
+		return nil
+	}
+	y, err1 := strconv.Atoi(yearMonth[:4])
+	m, err2 := strconv.Atoi(yearMonth[4:])
+	d, err3 := strconv.Atoi(day)
+	if err1 != nil || err2 != nil || err3 != nil || m < 1 || m > 12 || d < 1 || d > 31 {
+		return nil
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+}
+
+// syncClimateNormals loads station normals from the NCEI archive and tags
+// each station with its county.
+func (s *NOAAStorms) syncClimateNormals(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (int64, error) {
+	url := s.normalsURL
+	if url == "" {
+		url = climateNormalsURL
+	}
+	archivePath := filepath.Join(tempDir, "climate_normals.tar.gz")
+	if _, err := f.DownloadToFile(ctx, url, archivePath); err != nil {
+		return 0, eris.Wrap(err, "noaa_storms: download climate normals")
+	}
+	rows, err := parseClimateNormalsArchive(archivePath)
+	if err != nil {
+		return 0, eris.Wrap(err, "noaa_storms: parse climate normals")
+	}
+	if len(rows) == 0 {
+		return 0, eris.New("noaa_storms: no climate normal stations")
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += stormBatchSize {
+		end := min(start+stormBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.climate_normals",
+			Columns:      climateNormalCols,
+			ConflictKeys: climateNormalConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrap(err, "noaa_storms: upsert climate normals")
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, tagClimateNormalCountiesSQL); err != nil {
+		return 0, eris.Wrap(err, "noaa_storms: tag station counties")
+	}
+	return total, nil
+}
+
+// tagClimateNormalCountiesSQL sets each station's county from the county
+// polygon containing it.
+const tagClimateNormalCountiesSQL = `UPDATE geo.climate_normals n
+SET county_fips = c.geoid
+FROM geo.counties c
+WHERE ST_Contains(c.geom, n.geom)
+	AND n.county_fips IS DISTINCT FROM c.geoid`
+
+// parseClimateNormalsArchive reads every station CSV in the normals
+// tar.gz into geo.climate_normals rows.
+func parseClimateNormalsArchive(path string) ([][]any, error) {
+	file, err := os.Open(path) //nolint:gosec // path is under the sync temp dir
+	if err != nil {
+		return nil, eris.Wrap(err, "open file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, eris.Wrap(err, "open gzip")
+	}
+	defer gz.Close() //nolint:errcheck
+
+	var rows [][]any
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "read archive")
+		}
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(strings.ToLower(hdr.Name), ".csv") {
+			continue
+		}
+		row, err := parseClimateNormalStation(tr)
+		if err != nil {
+			return nil, eris.Wrapf(err, "station file %s", hdr.Name)
+		}
+		if row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// parseClimateNormalStation reads a single-station normals CSV. Returns nil
+// when the station has no id or location.
+func parseClimateNormalStation(r io.Reader) ([]any, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, eris.Wrap(err, "read header")
+	}
+	rec, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, eris.Wrap(err, "read row")
+	}
+	idx := csvColIndex(header)
+	get := func(name string) string {
+		if i, ok := idx[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	station := get("STATION")
+	lat := parseFloatOrNil(get("LATITUDE"))
+	lon := parseFloatOrNil(get("LONGITUDE"))
+	if station == "" || lat == nil || lon == nil {
+		return nil, nil
+	}
+	return []any{
+		station,
+		nilIfEmpty(get("NAME")),
+		parseFloatOrNil(get("ELEVATION")),
+		parseNormal(get("ANN-TAVG-NORMAL")),
+		parseNormal(get("ANN-TMAX-NORMAL")),
+		parseNormal(get("ANN-TMIN-NORMAL")),
+		parseNormal(get("ANN-PRCP-NORMAL")),
+		parseNormal(get("ANN-SNOW-NORMAL")),
+		parseNormal(get("ANN-HTDD-NORMAL")),
+		parseNormal(get("ANN-CLDD-NORMAL")),
+		climateNormalsPeriod,
+		*lat,
+		*lon,
+		noaaSource,
+	}, nil
+}
+
+// parseNormal parses a normals value. NCEI marks trace amounts as -7777,
+// which load as zero; other negative sentinels (-9999, -8888, -6666) mean
+// missing.
+func parseNormal(s string) *float64 {
+	v := parseFloatOrNil(s)
+	if v == nil || *v > -6666 {
+		return v
+	}
+	if *v == -7777 {
+		zero := 0.0
+		return &zero
+	}
+	return nil
+}
+
+// padFIPS left-pads a numeric FIPS code to width, or returns "" when it is
+// not numeric or too long.
+func padFIPS(s string, width int) string {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return ""
+	}
+	out := fmt.Sprintf("%0*d", width, n)
+	if len(out) > width {
+		return ""
+	}
+	return out
+}
+
+// atoiOrZero parses an integer, returning zero when malformed.
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,279 @@
+package scraper
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+const (
+	testStormListing = `<html><body>
+<a href="StormEvents_details-ftp_v1.0_d2023_c20240216.csv.gz">StormEvents_details-ftp_v1.0_d2023_c20240216.csv.gz</a>
+<a href="StormEvents_details-ftp_v1.0_d2024_c20250101.csv.gz">StormEvents_details-ftp_v1.0_d2024_c20250101.csv.gz</a>
+<a href="StormEvents_details-ftp_v1.0_d2024_c20250401.csv.gz">StormEvents_details-ftp_v1.0_d2024_c20250401.csv.gz</a>
+<a href="StormEvents_fatalities-ftp_v1.0_d2024_c20250401.csv.gz">StormEvents_fatalities-ftp_v1.0_d2024_c20250401.csv.gz</a>
+</body></html>`
+	testStormDetails = `BEGIN_YEARMONTH,BEGIN_DAY,EPISODE_ID,EVENT_ID,STATE,STATE_FIPS,YEAR,EVENT_TYPE,CZ_TYPE,CZ_FIPS,CZ_NAME,INJURIES_DIRECT,INJURIES_INDIRECT,DEATHS_DIRECT,DEATHS_INDIRECT,DAMAGE_PROPERTY,DAMAGE_CROPS,MAGNITUDE,MAGNITUDE_TYPE,BEGIN_LAT,BEGIN_LON
+202405,28,190001,1160001,TEXAS,48,2024,Hail,C,453,TRAVIS,1,0,0,0,25.00K,1.5M,1.75,,30.27,-97.74
+202408,3,190002,1160002,FLORIDA,12,2024,Hurricane (Typhoon),Z,52,COASTAL LEVY,0,0,1,0,2B,,,,,
+,,,,,,2024,,,,,,,,,,,,,,
+`
+	testZonePage = `<html><body>
+<a href="/source/gis/Shapefiles/County/bp05mr24.dbx">bp05mr24.dbx</a>
+<a href="/source/gis/Shapefiles/County/bp18mr25.dbx">bp18mr25.dbx</a>
+<a href="https://example.com/County/bp10se24.dbx">bp10se24.dbx</a>
+</body></html>`
+	testZoneCounties = `FL|052|TAE|Coastal Levy|FL052|Levy|12075|E|nc|29.2530|-82.8500
+FL|052|TAE|Coastal Levy|FL052|Levy|12075|E|nc|29.2530|-82.8500
+TX|192|EWX|Travis|TX192|Travis|48453|C|sc|30.3340|-97.7820
+GM|750|KEY|Marine Zone||||E||24.5|-81.9
+`
+	testNormalsStation = `"STATION","DATE","LATITUDE","LONGITUDE","ELEVATION","NAME","ANN-TAVG-NORMAL","ANN-TMAX-NORMAL","ANN-TMIN-NORMAL","ANN-PRCP-NORMAL","ANN-SNOW-NORMAL","ANN-HTDD-NORMAL","ANN-CLDD-NORMAL"
+"USW00013958","ANN","30.1831","-97.6799","146.3","AUSTIN BERGSTROM AP, TX US","69.8","80.6","58.9","33.75","-7777","1521","3342"
+`
+)
+
+func gzipBytes(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// buildNormalsArchive packs station CSVs into a tar.gz like the NCEI
+// by-station archive.
+func buildNormalsArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func newNOAAServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	details := gzipBytes(t, testStormDetails)
+	normals := buildNormalsArchive(t, map[string]string{
+		"USW00013958.csv": testNormalsStation,
+		"README.txt":      "not a station",
+	})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/storms/":
+			_, _ = w.Write([]byte(testStormListing))
+		case strings.HasPrefix(r.URL.Path, "/storms/StormEvents_details"):
+			_, _ = w.Write(details)
+		case r.URL.Path == "/gis/ZoneCounty":
+			_, _ = w.Write([]byte(testZonePage))
+		case r.URL.Path == "/source/gis/Shapefiles/County/bp18mr25.dbx":
+			_, _ = w.Write([]byte(testZoneCounties))
+		case r.URL.Path == "/normals.tar.gz":
+			_, _ = w.Write(normals)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestNOAAStorms_Metadata(t *testing.T) {
+	s := &NOAAStorms{}
+	assert.Equal(t, "noaa_storms", s.Name())
+	assert.Equal(t, "geo.storm_events", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Monthly, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestNOAAStorms_Sync(t *testing.T) {
+	srv := newNOAAServer(t)
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// 2023 is current; 2024 was republished.
+	mock.ExpectQuery("SELECT year, file_name FROM geo.storm_event_files").
+		WillReturnRows(pgxmock.NewRows([]string{"year", "file_name"}).
+			AddRow(int16(2023), "StormEvents_details-ftp_v1.0_d2023_c20240216.csv.gz").
+			AddRow(int16(2024), "StormEvents_details-ftp_v1.0_d2024_c20250101.csv.gz"))
+	expectBoundaryUpsert(mock, "geo_storm_events", stormEventCols, 2)
+	mock.ExpectExec("INSERT INTO geo.storm_event_files").
+		WithArgs(int16(2024), "StormEvents_details-ftp_v1.0_d2024_c20250401.csv.gz", 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("DELETE FROM geo.storm_events WHERE year").
+		WithArgs(int16(2023)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("DELETE FROM geo.storm_event_files WHERE year").
+		WithArgs(int16(2023)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	expectBoundaryUpsert(mock, "geo_nws_zone_counties", nwsZoneCountyCols, 2)
+	mock.ExpectExec("DELETE FROM geo.nws_zone_counties WHERE synced_at").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec("UPDATE geo.storm_events e").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	expectBoundaryUpsert(mock, "geo_climate_normals", climateNormalCols, 1)
+	mock.ExpectExec("UPDATE geo.climate_normals n").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	s := &NOAAStorms{
+		stormsBaseURL: srv.URL + "/storms/",
+		zonesPageURL:  srv.URL + "/gis/ZoneCounty",
+		normalsURL:    srv.URL + "/normals.tar.gz",
+	}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, 1, result.Metadata["years_loaded"])
+	assert.Equal(t, 1, result.Metadata["years_unchanged"])
+	assert.Equal(t, int64(1), result.Metadata["zones_tagged"])
+	assert.Equal(t, int64(1), result.Metadata["stations"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNOAAStorms_Sync_EmptyListing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &NOAAStorms{stormsBaseURL: srv.URL + "/storms/"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no storm event files in listing")
+}
+
+func TestParseStormEventsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "details.csv.gz")
+	require.NoError(t, os.WriteFile(path, gzipBytes(t, testStormDetails), 0o600))
+
+	rows, err := parseStormEventsFile(path)
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "row without event id is skipped")
+
+	hail := rows[0]
+	require.Len(t, hail, len(stormEventCols))
+	assert.Equal(t, int64(1160001), hail[0])
+	assert.Equal(t, int64(190001), hail[1])
+	assert.Equal(t, int16(2024), hail[2])
+	assert.Equal(t, time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC), hail[3])
+	assert.Equal(t, "48", hail[5])
+	assert.Equal(t, "453", hail[7])
+	assert.Equal(t, "48453", hail[9])
+	assert.Equal(t, "Hail", hail[10])
+	assert.Equal(t, 1, hail[11])
+	assert.InDelta(t, 25000, hail[13], 1e-6)
+	assert.InDelta(t, 1.5e6, hail[14], 1e-6)
+	assert.InDelta(t, 30.27, *hail[17].(*float64), 1e-9)
+
+	// Zone-coded events get their county after load.
+	hurricane := rows[1]
+	assert.Equal(t, "Z", hurricane[6])
+	assert.Equal(t, "052", hurricane[7])
+	assert.Nil(t, hurricane[9])
+	assert.Equal(t, 1, hurricane[12])
+	assert.InDelta(t, 2e9, hurricane[13], 1e-6)
+	assert.Nil(t, hurricane[17])
+}
+
+func TestFindZoneCountyFile(t *testing.T) {
+	srv := newNOAAServer(t)
+	defer srv.Close()
+
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	got, err := findZoneCountyFile(context.Background(), f, srv.URL+"/gis/ZoneCounty")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/source/gis/Shapefiles/County/bp18mr25.dbx", got, "newest release, resolved against the page")
+}
+
+func TestParseZoneCounties(t *testing.T) {
+	now := fixedNow()
+	rows, err := parseZoneCounties(strings.NewReader(testZoneCounties), now)
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "repeated pair and marine zone are skipped")
+	assert.Equal(t, []any{"12", "052", "12075", "FL052", "Coastal Levy", now}, rows[0])
+	assert.Equal(t, "48453", rows[1][2])
+}
+
+func TestParseStormDamage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"10.00K", 10000},
+		{"1.5M", 1.5e6},
+		{"2B", 2e9},
+		{"K", 1000},
+		{"0", 0},
+		{"", 0},
+		{"n/a", 0},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, parseStormDamage(tt.in), 1e-6, tt.in)
+	}
+}
+
+func TestParseClimateNormalsArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "normals.tar.gz")
+	archive := buildNormalsArchive(t, map[string]string{
+		"USW00013958.csv": testNormalsStation,
+		"NOLOC.csv":       "\"STATION\",\"LATITUDE\",\"LONGITUDE\"\n\"USC00000001\",\"\",\"\"\n",
+	})
+	require.NoError(t, os.WriteFile(path, archive, 0o600))
+
+	rows, err := parseClimateNormalsArchive(path)
+	require.NoError(t, err)
+	require.Len(t, rows, 1, "station without location is skipped")
+
+	row := rows[0]
+	require.Len(t, row, len(climateNormalCols))
+	assert.Equal(t, "USW00013958", row[0])
+	assert.Equal(t, "AUSTIN BERGSTROM AP, TX US", row[1])
+	assert.InDelta(t, 69.8, *row[3].(*float64), 1e-9)
+	assert.InDelta(t, 0, *row[7].(*float64), 1e-9, "trace snowfall loads as zero")
+	assert.Equal(t, climateNormalsPeriod, row[10])
+	assert.InDelta(t, 30.1831, row[11], 1e-9)
+}
+
+func TestParseNormal(t *testing.T) {
+	assert.InDelta(t, 12.5, *parseNormal("12.5"), 1e-9)
+	assert.InDelta(t, -3.2, *parseNormal("-3.2"), 1e-9)
+	assert.InDelta(t, 0, *parseNormal("-7777"), 1e-9)
+	assert.Nil(t, parseNormal("-9999"))
+	assert.Nil(t, parseNormal(""))
+}
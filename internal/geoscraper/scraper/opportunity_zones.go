@@ -0,0 +1,276 @@
+package scraper
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/tealeg/xlsx/v2"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// CDFI Fund downloads: the designated Qualified Opportunity Zone list and
+// the NMTC low-income community eligibility by 2016-2020 ACS tract.
+const (
+	qozTractsURL  = "https://www.cdfifund.gov/sites/cdfi/files/documents/designated-qozs.12.14.18.xlsx"
+	nmtcTractsURL = "https://www.cdfifund.gov/sites/cdfi/files/2023-08/NMTC_2016-2020_ACS_LIC_Sept1_2023.xlsx"
+)
+
+// cdfiURL returns override if set, otherwise defaultURL.
+func cdfiURL(override, defaultURL string) string {
+	if override != "" {
+		return override
+	}
+	return defaultURL
+}
+
+// Programs stored in geo.incentive_tracts.program.
+const (
+	incentiveQOZ  = "qoz"
+	incentiveNMTC = "nmtc"
+)
+
+// incentiveTractCols are the columns written to geo.incentive_tracts.
+var incentiveTractCols = []string{
+	"program", "geoid", "state_fips", "county_fips", "category",
+	"poverty_rate", "mfi_pct", "tract_vintage", "source", "properties", "synced_at",
+}
+
+var incentiveTractConflictKeys = []string{"program", "geoid"}
+
+// incentiveProgram is one CDFI Fund tract list.
+type incentiveProgram struct {
+	program      string
+	url          string
+	tractVintage int16
+	parse        func(sheet *xlsx.Sheet, vintage int16, now time.Time) ([][]any, error)
+}
+
+// OpportunityZones loads designated Qualified Opportunity Zone tracts and
+// NMTC-eligible low-income community tracts from the CDFI Fund into
+// geo.incentive_tracts. QOZ designations use 2010 tract codes, NMTC
+// eligibility 2020 codes; the pipeline reaches the 2010 codes through
+// geo.tract_relationships and flags companies located in either.
+type OpportunityZones struct {
+	qozURL  string // override for testing; empty uses qozTractsURL
+	nmtcURL string // override for testing; empty uses nmtcTractsURL
+}
+
+// Name implements GeoScraper.
+func (s *OpportunityZones) Name() string { return "opportunity_zones" }
+
+// Table implements GeoScraper.
+func (s *OpportunityZones) Table() string { return "geo.incentive_tracts" }
+
+// Category implements GeoScraper.
+func (s *OpportunityZones) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *OpportunityZones) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper. NMTC eligibility is refreshed with
+// each ACS five-year release; QOZ designations are fixed through 2028.
+func (s *OpportunityZones) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.September)
+}
+
+func (s *OpportunityZones) programs() []incentiveProgram {
+	return []incentiveProgram{
+		{program: incentiveQOZ, url: cdfiURL(s.qozURL, qozTractsURL), tractVintage: 2010, parse: parseQOZSheet},
+		{program: incentiveNMTC, url: cdfiURL(s.nmtcURL, nmtcTractsURL), tractVintage: 2020, parse: parseNMTCSheet},
+	}
+}
+
+// Sync implements GeoScraper.
+func (s *OpportunityZones) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting opportunity zone and NMTC tract sync")
+
+	now := time.Now().UTC()
+	var total int64
+	counts := make(map[string]any)
+	for _, p := range s.programs() {
+		n, err := syncIncentiveProgram(ctx, pool, f, tempDir, p, now)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("incentive tracts loaded", zap.String("program", p.program), zap.Int64("tracts", n))
+		counts[p.program] = n
+		total += n
+	}
+
+	log.Info("opportunity zone and NMTC tract sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{RowsSynced: total, Metadata: counts}, nil
+}
+
+// syncIncentiveProgram downloads one program's workbook and replaces its
+// tracts.
+func syncIncentiveProgram(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string, p incentiveProgram, now time.Time) (int64, error) {
+	xlsxPath := filepath.Join(tempDir, p.program+"_tracts.xlsx")
+	if _, err := f.DownloadToFile(ctx, p.url, xlsxPath); err != nil {
+		return 0, eris.Wrapf(err, "opportunity_zones: download %s", p.program)
+	}
+	xlFile, err := xlsx.OpenFile(xlsxPath)
+	if err != nil {
+		return 0, eris.Wrapf(err, "opportunity_zones: open %s xlsx", p.program)
+	}
+
+	// The tract list is on the first sheet with a recognizable header.
+	var rows [][]any
+	err = eris.Errorf("opportunity_zones: %s workbook has no sheets", p.program)
+	for _, sheet := range xlFile.Sheets {
+		if rows, err = p.parse(sheet, p.tractVintage, now); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += hifldBatchSize {
+		end := min(start+hifldBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.incentive_tracts",
+			Columns:      incentiveTractCols,
+			ConflictKeys: incentiveTractConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return 0, eris.Wrapf(err, "opportunity_zones: upsert %s", p.program)
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.incentive_tracts WHERE program = $1 AND synced_at < $2`, p.program, now); err != nil {
+		return 0, eris.Wrapf(err, "opportunity_zones: prune %s", p.program)
+	}
+	return total, nil
+}
+
+// parseQOZSheet extracts designated QOZ tracts. category is the tract
+// type, "Low-Income Community" or "Non-LIC Contiguous".
+func parseQOZSheet(sheet *xlsx.Sheet, vintage int16, now time.Time) ([][]any, error) {
+	headerIdx, header := incentiveHeader(sheet)
+	if header == nil {
+		return nil, eris.New("opportunity_zones: qoz header row not found")
+	}
+	geoidCol := incentiveGeoidCol(header)
+	typeCol := xlsxFindCol(header, "tract type")
+
+	exclude := map[string]bool{xlsxString(header, geoidCol): true, xlsxString(header, typeCol): true}
+	var rows [][]any
+	for _, row := range sheet.Rows[headerIdx+1:] {
+		geoid, ok := incentiveGeoid(xlsxString(row, geoidCol))
+		if !ok {
+			continue
+		}
+		rows = append(rows, newIncentiveTractRow(incentiveQOZ, geoid,
+			xlsxString(row, typeCol), nil, nil, vintage, xlsxProperties(row, header, exclude), now))
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("opportunity_zones: no qoz tracts")
+	}
+	return rows, nil
+}
+
+// parseNMTCSheet extracts tracts qualifying as NMTC low-income
+// communities. category is "severe_distress" for tracts that also meet
+// the severe distress criteria, otherwise "lic".
+func parseNMTCSheet(sheet *xlsx.Sheet, vintage int16, now time.Time) ([][]any, error) {
+	headerIdx, header := incentiveHeader(sheet)
+	if header == nil {
+		return nil, eris.New("opportunity_zones: nmtc header row not found")
+	}
+	var (
+		geoidCol    = incentiveGeoidCol(header)
+		qualifyCol  = xlsxFindCol(header, "qualify for nmtc")
+		severeCol   = xlsxFindCol(header, "severe distress")
+		povertyCol  = xlsxFindCol(header, "poverty rate")
+		mfiCol      = xlsxFindCol(header, "median family income")
+		excludeCols = []int{geoidCol, qualifyCol, severeCol, povertyCol, mfiCol}
+	)
+	if qualifyCol < 0 {
+		return nil, eris.New("opportunity_zones: nmtc sheet has no qualification column")
+	}
+	exclude := make(map[string]bool)
+	for _, i := range excludeCols {
+		if i >= 0 {
+			exclude[xlsxString(header, i)] = true
+		}
+	}
+
+	var rows [][]any
+	for _, row := range sheet.Rows[headerIdx+1:] {
+		geoid, ok := incentiveGeoid(xlsxString(row, geoidCol))
+		if !ok || !strings.EqualFold(xlsxString(row, qualifyCol), "yes") {
+			continue
+		}
+		category := "lic"
+		if strings.EqualFold(xlsxString(row, severeCol), "yes") {
+			category = "severe_distress"
+		}
+		rows = append(rows, newIncentiveTractRow(incentiveNMTC, geoid, category,
+			parseFloatOrNil(xlsxString(row, povertyCol)), parseFloatOrNil(xlsxString(row, mfiCol)),
+			vintage, xlsxProperties(row, header, exclude), now))
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("opportunity_zones: no nmtc tracts")
+	}
+	return rows, nil
+}
+
+func newIncentiveTractRow(program, geoid, category string, poverty, mfi *float64, vintage int16, props []byte, now time.Time) []any {
+	return []any{
+		program, geoid, geoid[:2], geoid[:5], nilIfEmpty(category),
+		poverty, mfi, vintage, "cdfi", props, now,
+	}
+}
+
+// incentiveHeader finds the header row, the first row naming a census
+// tract column. CDFI workbooks put title rows above it.
+func incentiveHeader(sheet *xlsx.Sheet) (int, *xlsx.Row) {
+	for i, row := range sheet.Rows {
+		if incentiveGeoidCol(row) >= 0 {
+			return i, row
+		}
+	}
+	return -1, nil
+}
+
+// incentiveGeoidCol returns the tract code column: a GEOID or FIPS code
+// column, else the QOZ list's "Census Tract Number".
+func incentiveGeoidCol(header *xlsx.Row) int {
+	for _, want := range []string{"geoid", "fips code", "census tract number"} {
+		if i := xlsxFindCol(header, want); i >= 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// xlsxFindCol returns the first column whose header contains substr,
+// ignoring case, or -1.
+func xlsxFindCol(header *xlsx.Row, substr string) int {
+	for i := range header.Cells {
+		if strings.Contains(strings.ToLower(xlsxString(header, i)), substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// incentiveGeoid normalizes an 11-digit tract code, restoring a leading
+// zero lost to a numeric cell.
+func incentiveGeoid(s string) (string, bool) {
+	if len(s) == 10 {
+		s = "0" + s
+	}
+	return s, len(s) == 11 && isDigits(s)
+}
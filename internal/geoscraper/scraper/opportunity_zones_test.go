@@ -0,0 +1,133 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+var (
+	testQOZHeader = []string{"State", "County", "Census Tract Number", "Tract Type", "ACS Data Source"}
+	testQOZRows   = [][]string{
+		{"Alabama", "Autauga", "1001020700", "Low-Income Community", "2011-2015 5-year ACS"},
+		{"Texas", "Travis", "48453000804", "Non-LIC Contiguous", "2011-2015 5-year ACS"},
+		{"Total", "", "", "", ""},
+	}
+	testNMTCHeader = []string{
+		"2020 Census Tract Number FIPS code. GEOID",
+		"Does Census Tract Qualify For NMTC Low-Income Community (LIC) on Poverty or Income Criteria?",
+		"Census Tract Poverty Rate % (2016-2020 ACS)",
+		"Percentage of Benchmarked Median Family Income (%)",
+		"Does Census Tract Qualify for Severe Distress Criteria (poverty rate >30% or MFI <60%)?",
+		"County Name",
+	}
+	testNMTCRows = [][]string{
+		{"48453000804", "Yes", "34.5", "52.1", "Yes", "Travis"},
+		{"6037101110", "Yes", "21.0", "75.0", "No", "Los Angeles"},
+		{"48453001100", "No", "8.2", "140.3", "No", "Travis"},
+	}
+)
+
+func TestOpportunityZones_Metadata(t *testing.T) {
+	s := &OpportunityZones{}
+	assert.Equal(t, "opportunity_zones", s.Name())
+	assert.Equal(t, "geo.incentive_tracts", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestOpportunityZones_Sync(t *testing.T) {
+	qoz := buildFMRXLSX(t, testQOZHeader, testQOZRows)
+	nmtc := buildFMRXLSX(t, testNMTCHeader, testNMTCRows)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/qoz.xlsx" {
+			_, _ = w.Write(qoz)
+			return
+		}
+		_, _ = w.Write(nmtc)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_incentive_tracts", incentiveTractCols, 2)
+	mock.ExpectExec("DELETE FROM geo.incentive_tracts WHERE program").
+		WithArgs(incentiveQOZ, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	expectBoundaryUpsert(mock, "geo_incentive_tracts", incentiveTractCols, 2)
+	mock.ExpectExec("DELETE FROM geo.incentive_tracts WHERE program").
+		WithArgs(incentiveNMTC, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	s := &OpportunityZones{qozURL: srv.URL + "/qoz.xlsx", nmtcURL: srv.URL + "/nmtc.xlsx"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.RowsSynced)
+	assert.Equal(t, int64(2), result.Metadata[incentiveQOZ])
+	assert.Equal(t, int64(2), result.Metadata[incentiveNMTC])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseQOZSheet(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, testQOZHeader, testQOZRows))
+	require.NoError(t, err)
+	now := fixedNow()
+
+	rows, err := parseQOZSheet(xlFile.Sheets[0], 2010, now)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	row := rows[0]
+	require.Len(t, row, len(incentiveTractCols))
+	assert.Equal(t, incentiveQOZ, row[0])
+	assert.Equal(t, "01001020700", row[1], "leading zero restored")
+	assert.Equal(t, "01", row[2])
+	assert.Equal(t, "01001", row[3])
+	assert.Equal(t, "Low-Income Community", row[4])
+	assert.Equal(t, int16(2010), row[7])
+	assert.JSONEq(t, `{"State":"Alabama","County":"Autauga","ACS Data Source":"2011-2015 5-year ACS"}`, string(row[9].([]byte)))
+	assert.Equal(t, now, row[10])
+}
+
+func TestParseNMTCSheet(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, testNMTCHeader, testNMTCRows))
+	require.NoError(t, err)
+
+	rows, err := parseNMTCSheet(xlFile.Sheets[0], 2020, fixedNow())
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "non-qualifying tracts are skipped")
+
+	assert.Equal(t, "48453000804", rows[0][1])
+	assert.Equal(t, "severe_distress", rows[0][4])
+	assert.InDelta(t, 34.5, *rows[0][5].(*float64), 1e-9)
+	assert.InDelta(t, 52.1, *rows[0][6].(*float64), 1e-9)
+	assert.JSONEq(t, `{"County Name":"Travis"}`, string(rows[0][9].([]byte)))
+
+	assert.Equal(t, "06037101110", rows[1][1])
+	assert.Equal(t, "lic", rows[1][4])
+}
+
+func TestParseNMTCSheet_NoHeader(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, []string{"a", "b"}, [][]string{{"1", "2"}}))
+	require.NoError(t, err)
+	_, err = parseNMTCSheet(xlFile.Sheets[0], 2020, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nmtc header row not found")
+}
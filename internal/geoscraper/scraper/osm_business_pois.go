@@ -0,0 +1,477 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/osmpbf"
+	"github.com/sells-group/research-cli/internal/geoscraper/overpass"
+	"github.com/sells-group/research-cli/internal/tiger"
+)
+
+// geofabrikBaseURL hosts the per-state US extracts.
+const geofabrikBaseURL = "https://download.geofabrik.de/north-america/us"
+
+// geofabrikRegions maps state abbreviations to Geofabrik extract names.
+var geofabrikRegions = map[string]string{
+	"AL": "alabama", "AK": "alaska", "AZ": "arizona", "AR": "arkansas",
+	"CA": "california", "CO": "colorado", "CT": "connecticut", "DE": "delaware",
+	"DC": "district-of-columbia", "FL": "florida", "GA": "georgia", "HI": "hawaii",
+	"ID": "idaho", "IL": "illinois", "IN": "indiana", "IA": "iowa",
+	"KS": "kansas", "KY": "kentucky", "LA": "louisiana", "ME": "maine",
+	"MD": "maryland", "MA": "massachusetts", "MI": "michigan", "MN": "minnesota",
+	"MS": "mississippi", "MO": "missouri", "MT": "montana", "NE": "nebraska",
+	"NV": "nevada", "NH": "new-hampshire", "NJ": "new-jersey", "NM": "new-mexico",
+	"NY": "new-york", "NC": "north-carolina", "ND": "north-dakota", "OH": "ohio",
+	"OK": "oklahoma", "OR": "oregon", "PA": "pennsylvania", "RI": "rhode-island",
+	"SC": "south-carolina", "SD": "south-dakota", "TN": "tennessee", "TX": "texas",
+	"UT": "utah", "VT": "vermont", "VA": "virginia", "WA": "washington",
+	"WV": "west-virginia", "WI": "wisconsin", "WY": "wyoming",
+}
+
+// osmPOICols are the columns written to geo.osm_pois.
+var osmPOICols = []string{
+	"osm_type", "osm_id", "name", "brand", "category", "subcategory",
+	"latitude", "longitude", "state_fips", "tags", "source", "synced_at",
+}
+
+var osmPOIConflictKeys = []string{"osm_type", "osm_id"}
+
+// Business tag values kept beyond the office=*, craft=*, and healthcare=*
+// keys, which are kept whole.
+var (
+	osmFinancialAmenities = map[string]bool{
+		"bank": true, "bureau_de_change": true, "money_transfer": true, "payment_centre": true,
+	}
+	osmHealthcareAmenities = map[string]bool{
+		"clinic": true, "dentist": true, "doctors": true, "veterinary": true,
+	}
+	osmServiceShops = map[string]bool{
+		"car_repair": true, "computer": true, "copyshop": true, "dry_cleaning": true,
+		"funeral_directors": true, "hairdresser": true, "insurance": true,
+		"laundry": true, "storage_rental": true, "travel_agency": true,
+	}
+)
+
+// categorizeBusinessOSM maps OSM tags to a business category and
+// subcategory, reporting false for features outside the business set.
+func categorizeBusinessOSM(tags map[string]string) (category, subcategory string, ok bool) {
+	if v, found := tags["office"]; found && v != "no" {
+		if v == "yes" {
+			v = "general"
+		}
+		return "office", v, true
+	}
+	if v := tags["amenity"]; v != "" {
+		switch {
+		case osmFinancialAmenities[v]:
+			return "financial", v, true
+		case osmHealthcareAmenities[v]:
+			return "healthcare", v, true
+		case v == "coworking_space":
+			return "office", "coworking", true
+		}
+	}
+	if v := tags["healthcare"]; v != "" && v != "no" {
+		return "healthcare", v, true
+	}
+	if v := tags["craft"]; v != "" && v != "no" {
+		return "trades", v, true
+	}
+	if v := tags["shop"]; osmServiceShops[v] {
+		return "services", v, true
+	}
+	return "", "", false
+}
+
+// OSMBusinessPOIs loads business-relevant OpenStreetMap POIs (offices,
+// banks, professional and trade services) into geo.osm_pois for
+// competitor-density metrics. By default it streams the Geofabrik PBF
+// extract for each configured state; with a bounding box it queries
+// Overpass instead, which suits small areas. It is separate from OSMPOI,
+// which loads civic amenities into geo.poi.
+type OSMBusinessPOIs struct {
+	states          []string // state abbreviations; empty = all states and DC
+	bbox            string   // "south,west,north,east"; set = Overpass
+	downloadBaseURL string   // override for testing; empty uses geofabrikBaseURL
+	endpointURL     string   // Overpass override for testing
+}
+
+// Name implements GeoScraper.
+func (s *OSMBusinessPOIs) Name() string { return "osm_business_pois" }
+
+// Table implements GeoScraper.
+func (s *OSMBusinessPOIs) Table() string { return "geo.osm_pois" }
+
+// Category implements GeoScraper.
+func (s *OSMBusinessPOIs) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *OSMBusinessPOIs) Cadence() geoscraper.Cadence { return geoscraper.Monthly }
+
+// ShouldRun implements GeoScraper.
+func (s *OSMBusinessPOIs) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.MonthlySchedule(now, lastSync)
+}
+
+// Sync implements GeoScraper.
+func (s *OSMBusinessPOIs) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	if s.bbox != "" {
+		return s.syncOverpass(ctx, pool)
+	}
+
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	states, err := s.extractStates()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("starting OSM business POI sync", zap.Int("states", len(states)))
+
+	syncedAt := time.Now().UTC()
+	var (
+		total  int64
+		failed []string
+	)
+	for _, abbr := range states {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, err := s.syncState(ctx, pool, f, tempDir, abbr, syncedAt)
+		if err != nil {
+			log.Warn("state extract failed, skipping", zap.String("state", abbr), zap.Error(err))
+			failed = append(failed, abbr)
+			continue
+		}
+		log.Info("state extract loaded", zap.String("state", abbr), zap.Int64("rows", n))
+		total += n
+	}
+	if len(failed) == len(states) {
+		return nil, eris.Errorf("osm_business_pois: all %d state extracts failed", len(states))
+	}
+
+	log.Info("OSM business POI sync complete", zap.Int64("rows", total), zap.Strings("failed", failed))
+	return &geoscraper.SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"states":        len(states) - len(failed),
+			"failed_states": failed,
+		},
+	}, nil
+}
+
+// extractStates returns the configured state abbreviations, or every state
+// with a Geofabrik extract.
+func (s *OSMBusinessPOIs) extractStates() ([]string, error) {
+	if len(s.states) == 0 {
+		all := make([]string, 0, len(geofabrikRegions))
+		for abbr := range geofabrikRegions {
+			all = append(all, abbr)
+		}
+		slices.Sort(all)
+		return all, nil
+	}
+	states := make([]string, 0, len(s.states))
+	for _, st := range s.states {
+		abbr := strings.ToUpper(strings.TrimSpace(st))
+		if _, ok := geofabrikRegions[abbr]; !ok {
+			return nil, eris.Errorf("osm_business_pois: no Geofabrik extract for state %q", st)
+		}
+		states = append(states, abbr)
+	}
+	return states, nil
+}
+
+// syncState downloads one state extract, loads its POIs, and removes rows
+// from that state not present in this extract.
+func (s *OSMBusinessPOIs) syncState(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir, abbr string, syncedAt time.Time) (int64, error) {
+	base := s.downloadBaseURL
+	if base == "" {
+		base = geofabrikBaseURL
+	}
+	region := geofabrikRegions[abbr]
+	fips := tiger.FIPSCodes[abbr]
+
+	pbfPath := filepath.Join(tempDir, region+"-latest.osm.pbf")
+	if _, err := f.DownloadToFile(ctx, fmt.Sprintf("%s/%s-latest.osm.pbf", base, region), pbfPath); err != nil {
+		return 0, eris.Wrapf(err, "osm_business_pois: download %s", region)
+	}
+	defer os.Remove(pbfPath) //nolint:errcheck
+
+	n, err := s.loadExtract(ctx, pool, pbfPath, fips, syncedAt)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := pool.Exec(ctx, pruneOSMPOIsSQL, fips, syncedAt); err != nil {
+		return 0, eris.Wrapf(err, "osm_business_pois: prune %s", abbr)
+	}
+	return n, nil
+}
+
+const pruneOSMPOIsSQL = `DELETE FROM geo.osm_pois WHERE state_fips = $1 AND synced_at < $2`
+
+// osmWay is a business way awaiting node coordinates.
+type osmWay struct {
+	id          int64
+	refs        []int64
+	tags        map[string]string
+	category    string
+	subcategory string
+}
+
+// loadExtract streams a PBF extract and upserts its business POIs. Tagged
+// nodes load in the first pass. Ways follow their nodes in PBF order, so
+// business ways are collected in the first pass and placed at the centroid
+// of their nodes after a second pass reads those coordinates.
+func (s *OSMBusinessPOIs) loadExtract(ctx context.Context, pool db.Pool, path, stateFIPS string, syncedAt time.Time) (int64, error) {
+	up := &osmPOIUpserter{pool: pool, table: s.Table()}
+
+	var ways []osmWay
+	needed := make(map[int64][2]float64)
+	err := scanPBF(ctx, path, osmpbf.Handler{
+		Node: func(n osmpbf.Node) error {
+			if n.Tags == nil {
+				return nil
+			}
+			category, sub, ok := categorizeBusinessOSM(n.Tags)
+			if !ok {
+				return nil
+			}
+			return up.add(ctx, newOSMPOIRow("node", n.ID, n.Lat, n.Lon, n.Tags, category, sub, stateFIPS, syncedAt))
+		},
+		Way: func(w osmpbf.Way) error {
+			category, sub, ok := categorizeBusinessOSM(w.Tags)
+			if !ok || len(w.Refs) == 0 {
+				return nil
+			}
+			refs := w.Refs
+			if len(refs) > 1 && refs[0] == refs[len(refs)-1] {
+				refs = refs[:len(refs)-1] // closed ring repeats its first node
+			}
+			for _, ref := range refs {
+				needed[ref] = [2]float64{}
+			}
+			ways = append(ways, osmWay{id: w.ID, refs: refs, tags: w.Tags, category: category, subcategory: sub})
+			return nil
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ways) > 0 {
+		err := scanPBF(ctx, path, osmpbf.Handler{
+			Node: func(n osmpbf.Node) error {
+				if _, ok := needed[n.ID]; ok {
+					needed[n.ID] = [2]float64{n.Lat, n.Lon}
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return 0, err
+		}
+		for _, w := range ways {
+			lat, lon, ok := wayCentroid(w.refs, needed)
+			if !ok {
+				continue
+			}
+			if err := up.add(ctx, newOSMPOIRow("way", w.id, lat, lon, w.tags, w.category, w.subcategory, stateFIPS, syncedAt)); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := up.flush(ctx); err != nil {
+		return 0, err
+	}
+	return up.total, nil
+}
+
+func scanPBF(ctx context.Context, path string, h osmpbf.Handler) error {
+	file, err := os.Open(path) // #nosec G304 -- path is built from tempDir
+	if err != nil {
+		return eris.Wrap(err, "osm_business_pois: open extract")
+	}
+	defer file.Close() //nolint:errcheck
+	if err := osmpbf.Scan(ctx, file, h); err != nil {
+		return eris.Wrap(err, "osm_business_pois: read extract")
+	}
+	return nil
+}
+
+// wayCentroid averages the coordinates of a way's nodes, ignoring nodes the
+// extract clipped away. Averaging vertices is close enough to the area
+// centroid for building-sized features.
+func wayCentroid(refs []int64, coords map[int64][2]float64) (lat, lon float64, ok bool) {
+	var n int
+	for _, ref := range refs {
+		c := coords[ref]
+		if c == [2]float64{} {
+			continue
+		}
+		lat += c[0]
+		lon += c[1]
+		n++
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	return lat / float64(n), lon / float64(n), true
+}
+
+// syncOverpass loads business POIs within the configured bounding box from
+// Overpass. Rows carry no state, so nothing is pruned.
+func (s *OSMBusinessPOIs) syncOverpass(ctx context.Context, pool db.Pool) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	south, west, north, east, err := parseOSMBBox(s.bbox)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("starting OSM business POI sync from Overpass", zap.String("bbox", s.bbox))
+
+	elems, err := overpass.Query(ctx, s.endpointURL, buildBusinessPOIQuery(south, west, north, east))
+	if err != nil {
+		return nil, eris.Wrap(err, "osm_business_pois: overpass query")
+	}
+
+	syncedAt := time.Now().UTC()
+	up := &osmPOIUpserter{pool: pool, table: s.Table()}
+	for _, elem := range elems {
+		category, sub, ok := categorizeBusinessOSM(elem.Tags)
+		if !ok {
+			continue
+		}
+		lat, lon := elem.Lat, elem.Lon
+		if elem.Center != nil {
+			lat, lon = elem.Center.Lat, elem.Center.Lon
+		}
+		if lat == 0 && lon == 0 {
+			continue
+		}
+		if err := up.add(ctx, newOSMPOIRow(elem.Type, elem.ID, lat, lon, elem.Tags, category, sub, "", syncedAt)); err != nil {
+			return nil, err
+		}
+	}
+	if err := up.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Info("OSM business POI sync complete", zap.Int64("rows", up.total))
+	return &geoscraper.SyncResult{RowsSynced: up.total}, nil
+}
+
+// parseOSMBBox parses a "south,west,north,east" bounding box.
+func parseOSMBBox(s string) (south, west, north, east float64, err error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return 0, 0, 0, 0, eris.Errorf("osm_business_pois: bbox %q must be south,west,north,east", s)
+	}
+	var v [4]float64
+	for i, p := range parts {
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(p), 64); err != nil {
+			return 0, 0, 0, 0, eris.Wrapf(err, "osm_business_pois: parse bbox %q", s)
+		}
+	}
+	if v[0] >= v[2] || v[1] >= v[3] {
+		return 0, 0, 0, 0, eris.Errorf("osm_business_pois: bbox %q is empty", s)
+	}
+	return v[0], v[1], v[2], v[3], nil
+}
+
+// buildBusinessPOIQuery builds an Overpass QL query for the business tag
+// set within a bounding box. Ways are returned with their center point.
+func buildBusinessPOIQuery(south, west, north, east float64) string {
+	bbox := fmt.Sprintf("%.6f,%.6f,%.6f,%.6f", south, west, north, east)
+	amenities := append(sortedSet(osmFinancialAmenities), sortedSet(osmHealthcareAmenities)...)
+	amenities = append(amenities, "coworking_space")
+	filters := []string{
+		`["office"]`,
+		fmt.Sprintf(`["amenity"~"^(%s)$"]`, strings.Join(amenities, "|")),
+		`["healthcare"]`,
+		`["craft"]`,
+		fmt.Sprintf(`["shop"~"^(%s)$"]`, strings.Join(sortedSet(osmServiceShops), "|")),
+	}
+	var b strings.Builder
+	b.WriteString("[out:json][timeout:180];\n(\n")
+	for _, f := range filters {
+		fmt.Fprintf(&b, "  nw%s(%s);\n", f, bbox)
+	}
+	b.WriteString(");\nout center tags;")
+	return b.String()
+}
+
+func sortedSet(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// newOSMPOIRow builds a geo.osm_pois row. All tags, including name, are
+// kept in the tags column.
+func newOSMPOIRow(osmType string, id int64, lat, lon float64, tags map[string]string, category, subcategory, stateFIPS string, syncedAt time.Time) []any {
+	tagsJSON, _ := json.Marshal(tags)
+	return []any{
+		osmType,
+		id,
+		nilIfEmpty(tags["name"]),
+		nilIfEmpty(tags["brand"]),
+		category,
+		nilIfEmpty(subcategory),
+		lat,
+		lon,
+		nilIfEmpty(stateFIPS),
+		tagsJSON,
+		osmSource,
+		syncedAt,
+	}
+}
+
+// osmPOIUpserter batches rows into geo.osm_pois.
+type osmPOIUpserter struct {
+	pool  db.Pool
+	table string
+	batch [][]any
+	total int64
+}
+
+func (u *osmPOIUpserter) add(ctx context.Context, row []any) error {
+	u.batch = append(u.batch, row)
+	if len(u.batch) >= osmBatchSize {
+		return u.flush(ctx)
+	}
+	return nil
+}
+
+func (u *osmPOIUpserter) flush(ctx context.Context) error {
+	if len(u.batch) == 0 {
+		return nil
+	}
+	n, err := db.BulkUpsert(ctx, u.pool, db.UpsertConfig{
+		Table:        u.table,
+		Columns:      osmPOICols,
+		ConflictKeys: osmPOIConflictKeys,
+	}, u.batch)
+	if err != nil {
+		return eris.Wrap(err, "osm_business_pois: upsert batch")
+	}
+	u.total += n
+	u.batch = u.batch[:0]
+	return nil
+}
@@ -0,0 +1,233 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/osmpbf"
+)
+
+// buildOSMExtract encodes a small extract: two business nodes, an
+// untagged vertex set, one business way, and one non-business way.
+func buildOSMExtract(t *testing.T) []byte {
+	t.Helper()
+	nodes := []osmpbf.Node{
+		{ID: 1, Lat: 30.2672, Lon: -97.7431, Tags: map[string]string{"office": "lawyer", "name": "Smith & Jones"}},
+		{ID: 2, Lat: 30.2700, Lon: -97.7400, Tags: map[string]string{"amenity": "bank", "brand": "Frost"}},
+		{ID: 3, Lat: 30.2800, Lon: -97.7500, Tags: map[string]string{"amenity": "school"}},
+		{ID: 10, Lat: 30.0, Lon: -97.0},
+		{ID: 11, Lat: 30.0, Lon: -97.2},
+		{ID: 12, Lat: 30.2, Lon: -97.2},
+		{ID: 13, Lat: 30.2, Lon: -97.0},
+	}
+	ways := []osmpbf.Way{
+		{ID: 500, Refs: []int64{10, 11, 12, 13, 10}, Tags: map[string]string{"building": "office", "office": "accountant"}},
+		{ID: 501, Refs: []int64{10, 11}, Tags: map[string]string{"highway": "residential"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, osmpbf.Write(&buf, nodes, ways))
+	return buf.Bytes()
+}
+
+func TestOSMBusinessPOIs_Metadata(t *testing.T) {
+	s := &OSMBusinessPOIs{}
+	assert.Equal(t, "osm_business_pois", s.Name())
+	assert.Equal(t, "geo.osm_pois", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Monthly, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestOSMBusinessPOIs_Sync(t *testing.T) {
+	extract := buildOSMExtract(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/texas-latest.osm.pbf" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(extract)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_osm_pois", osmPOICols, 3)
+	mock.ExpectExec("DELETE FROM geo.osm_pois").
+		WithArgs("48", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 4))
+
+	s := &OSMBusinessPOIs{states: []string{"tx", "RI"}, downloadBaseURL: srv.URL}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, 1, result.Metadata["states"])
+	assert.Equal(t, []string{"RI"}, result.Metadata["failed_states"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOSMBusinessPOIs_Sync_AllFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &OSMBusinessPOIs{states: []string{"TX"}, downloadBaseURL: srv.URL}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all 1 state extracts failed")
+}
+
+func TestOSMBusinessPOIs_LoadExtract(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "texas-latest.osm.pbf")
+	require.NoError(t, os.WriteFile(path, buildOSMExtract(t), 0o600))
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	expectBoundaryUpsert(mock, "geo_osm_pois", osmPOICols, 3)
+
+	s := &OSMBusinessPOIs{}
+	n, err := s.loadExtract(context.Background(), mock, path, "48", fixedNow())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOSMBusinessPOIs_UnknownState(t *testing.T) {
+	s := &OSMBusinessPOIs{states: []string{"PR"}}
+	_, err := s.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no Geofabrik extract for state "PR"`)
+}
+
+func TestOSMBusinessPOIs_ExtractStates_Default(t *testing.T) {
+	states, err := (&OSMBusinessPOIs{}).extractStates()
+	require.NoError(t, err)
+	assert.Len(t, states, 51)
+	assert.Equal(t, "AK", states[0])
+}
+
+func TestOSMBusinessPOIs_SyncOverpass(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Contains(t, r.PostForm.Get("data"), "out center tags;")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elements": [
+			{"type": "node", "id": 1, "lat": 30.27, "lon": -97.74, "tags": {"office": "insurance", "name": "Acme"}},
+			{"type": "way", "id": 2, "center": {"lat": 30.28, "lon": -97.75}, "tags": {"craft": "electrician"}},
+			{"type": "node", "id": 3, "lat": 30.29, "lon": -97.76, "tags": {"amenity": "cafe"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	expectBoundaryUpsert(mock, "geo_osm_pois", osmPOICols, 2)
+
+	s := &OSMBusinessPOIs{bbox: "30.2,-97.8,30.3,-97.7", endpointURL: srv.URL}
+	result, err := s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOSMBusinessPOIs_SyncOverpass_BadBBox(t *testing.T) {
+	s := &OSMBusinessPOIs{bbox: "30.3,-97.8,30.2,-97.7"}
+	_, err := s.Sync(context.Background(), nil, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is empty")
+}
+
+func TestParseOSMBBox(t *testing.T) {
+	south, west, north, east, err := parseOSMBBox(" 30.2, -97.8,30.3,-97.7")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{30.2, -97.8, 30.3, -97.7}, []float64{south, west, north, east})
+
+	_, _, _, _, err = parseOSMBBox("30.2,-97.8,30.3")
+	assert.ErrorContains(t, err, "must be south,west,north,east")
+
+	_, _, _, _, err = parseOSMBBox("a,b,c,d")
+	assert.ErrorContains(t, err, "parse bbox")
+}
+
+func TestCategorizeBusinessOSM(t *testing.T) {
+	tests := []struct {
+		tags     map[string]string
+		category string
+		sub      string
+		ok       bool
+	}{
+		{map[string]string{"office": "lawyer"}, "office", "lawyer", true},
+		{map[string]string{"office": "yes"}, "office", "general", true},
+		{map[string]string{"office": "no", "shop": "bakery"}, "", "", false},
+		{map[string]string{"amenity": "bank"}, "financial", "bank", true},
+		{map[string]string{"amenity": "dentist"}, "healthcare", "dentist", true},
+		{map[string]string{"amenity": "coworking_space"}, "office", "coworking", true},
+		{map[string]string{"healthcare": "physiotherapist"}, "healthcare", "physiotherapist", true},
+		{map[string]string{"craft": "hvac"}, "trades", "hvac", true},
+		{map[string]string{"shop": "car_repair"}, "services", "car_repair", true},
+		{map[string]string{"shop": "supermarket"}, "", "", false},
+		{map[string]string{"amenity": "school"}, "", "", false},
+	}
+	for _, tt := range tests {
+		cat, sub, ok := categorizeBusinessOSM(tt.tags)
+		assert.Equal(t, tt.ok, ok, "tags=%v", tt.tags)
+		assert.Equal(t, tt.category, cat, "tags=%v", tt.tags)
+		assert.Equal(t, tt.sub, sub, "tags=%v", tt.tags)
+	}
+}
+
+func TestWayCentroid(t *testing.T) {
+	coords := map[int64][2]float64{1: {30, -97}, 2: {32, -99}, 3: {}}
+	lat, lon, ok := wayCentroid([]int64{1, 2, 3}, coords)
+	require.True(t, ok)
+	assert.InDelta(t, 31.0, lat, 1e-9)
+	assert.InDelta(t, -98.0, lon, 1e-9)
+
+	_, _, ok = wayCentroid([]int64{3, 4}, coords)
+	assert.False(t, ok)
+}
+
+func TestBuildBusinessPOIQuery(t *testing.T) {
+	q := buildBusinessPOIQuery(30.2, -97.8, 30.3, -97.7)
+	assert.Contains(t, q, `nw["office"](30.200000,-97.800000,30.300000,-97.700000);`)
+	assert.Contains(t, q, `["amenity"~"^(bank|bureau_de_change|money_transfer|payment_centre|clinic|dentist|doctors|veterinary|coworking_space)$"]`)
+	assert.Contains(t, q, `nw["craft"]`)
+	assert.True(t, strings.HasSuffix(q, "out center tags;"))
+}
+
+func TestNewOSMPOIRow(t *testing.T) {
+	row := newOSMPOIRow("node", 42, 30.1, -97.2, map[string]string{"office": "it", "name": "Acme"}, "office", "it", "", fixedNow())
+	require.Len(t, row, len(osmPOICols))
+	assert.Equal(t, "node", row[0])
+	assert.Equal(t, int64(42), row[1])
+	assert.Equal(t, "Acme", row[2])
+	assert.Nil(t, row[3], "no brand")
+	assert.Nil(t, row[8], "no state")
+	assert.JSONEq(t, `{"office":"it","name":"Acme"}`, string(row[9].([]byte)))
+	assert.Equal(t, osmSource, row[10])
+}
@@ -0,0 +1,439 @@
+package scraper
+
+import (
+	"context"
+	_ "embed"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+)
+
+// defaultParcelCounties is the built-in county parcel manifest.
+//
+//go:embed parcel_counties.yaml
+var defaultParcelCounties []byte
+
+// ParcelCounty is one manifest entry: a county's ArcGIS parcel layer and
+// how its attributes map onto geo.parcels.
+type ParcelCounty struct {
+	Name       string             `yaml:"name"`
+	CountyFIPS string             `yaml:"county_fips"`
+	URL        string             `yaml:"url"`
+	Cadence    geoscraper.Cadence `yaml:"cadence"`
+	Where      string             `yaml:"where"`
+	Fields     map[string]string  `yaml:"fields"`
+	LandUse    map[string]string  `yaml:"land_use"`
+}
+
+// parcelFieldCols are the manifest field columns. owner_csz is not stored;
+// it is split into owner_city, owner_state, and owner_zip.
+var parcelFieldCols = []string{
+	"parcel_id", "owner_name", "owner_address", "owner_city", "owner_state", "owner_zip", "owner_csz",
+	"site_address", "site_city", "site_zip", "land_use_code",
+	"assessed_value", "land_value", "improvement_value", "acres",
+}
+
+// parcelLandUses are the normalized geo.parcels.land_use classes.
+var parcelLandUses = []string{"residential", "commercial", "industrial", "agricultural", "exempt", "vacant", "other"}
+
+// parcelCols are the columns written to the parcel temp table. geom_wkt
+// is converted to geo.parcels.geom via ST_GeomFromEWKT.
+var parcelCols = []string{
+	"county_fips", "state_fips", "parcel_id",
+	"owner_name", "owner_address", "owner_city", "owner_state", "owner_zip",
+	"site_address", "site_city", "site_zip", "land_use_code", "land_use",
+	"assessed_value", "land_value", "improvement_value", "acres",
+	"geom_wkt", "adapter", "properties", "synced_at",
+}
+
+var (
+	parcelCountyName = regexp.MustCompile(`^[a-z]{2}_[a-z0-9_]{1,40}$`)
+	parcelCSZ        = regexp.MustCompile(`^(.*?)[\s,]+([A-Z]{2})\s+(\d{5})(?:-?\d{4})?$`)
+	parcelZIP        = regexp.MustCompile(`\d{5}`)
+)
+
+// ParseParcelCounties parses and validates a county parcel manifest,
+// filling the default annual cadence.
+func ParseParcelCounties(data []byte) ([]ParcelCounty, error) {
+	var m struct {
+		Counties []ParcelCounty `yaml:"counties"`
+	}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, eris.Wrap(err, "parcels: parse manifest")
+	}
+
+	seen := make(map[string]bool, len(m.Counties))
+	for i := range m.Counties {
+		c := &m.Counties[i]
+		if !parcelCountyName.MatchString(c.Name) {
+			return nil, eris.Errorf("parcels: invalid county name %q (<state>_<county>, lowercase letters, digits, underscores)", c.Name)
+		}
+		if seen[c.Name] {
+			return nil, eris.Errorf("parcels: duplicate county %q", c.Name)
+		}
+		seen[c.Name] = true
+		if len(c.CountyFIPS) != 5 || !isDigits(c.CountyFIPS) {
+			return nil, eris.Errorf("parcels: %s: county_fips must be five digits", c.Name)
+		}
+		if !strings.HasPrefix(c.URL, "https://") && !strings.HasPrefix(c.URL, "http://") {
+			return nil, eris.Errorf("parcels: %s: url must be an http(s) ArcGIS query endpoint", c.Name)
+		}
+		switch c.Cadence {
+		case "":
+			c.Cadence = geoscraper.Annual
+		case geoscraper.Annual, geoscraper.Quarterly, geoscraper.Monthly:
+		default:
+			return nil, eris.Errorf("parcels: %s: unsupported cadence %q (annual, quarterly, monthly)", c.Name, c.Cadence)
+		}
+		for col := range c.Fields {
+			if !slices.Contains(parcelFieldCols, col) {
+				return nil, eris.Errorf("parcels: %s: unknown field column %q (%s)", c.Name, col, strings.Join(parcelFieldCols, ", "))
+			}
+		}
+		if c.Fields["parcel_id"] == "" || c.Fields["owner_name"] == "" {
+			return nil, eris.Errorf("parcels: %s: parcel_id and owner_name fields are required", c.Name)
+		}
+		for code, class := range c.LandUse {
+			if !slices.Contains(parcelLandUses, class) {
+				return nil, eris.Errorf("parcels: %s: land use %q maps to unknown class %q (%s)", c.Name, code, class, strings.Join(parcelLandUses, ", "))
+			}
+		}
+	}
+	return m.Counties, nil
+}
+
+// LoadParcelCounties reads the manifest at path, or the built-in manifest
+// when path is empty.
+func LoadParcelCounties(path string) ([]ParcelCounty, error) {
+	if path == "" {
+		return ParseParcelCounties(defaultParcelCounties)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- manifest path comes from trusted config
+	if err != nil {
+		return nil, eris.Wrapf(err, "parcels: read manifest %s", path)
+	}
+	return ParseParcelCounties(data)
+}
+
+// ParcelScraper loads one county's parcels from its ArcGIS parcel layer
+// into geo.parcels. Owner names are matched to federal entities by the
+// entity_xref multi-dataset passes.
+type ParcelScraper struct {
+	county  ParcelCounty
+	baseURL string // override for testing; empty uses county.URL
+}
+
+// NewParcelScraper returns a scraper for county.
+func NewParcelScraper(county ParcelCounty) *ParcelScraper {
+	return &ParcelScraper{county: county}
+}
+
+// Name implements GeoScraper.
+func (s *ParcelScraper) Name() string { return "parcels_" + s.county.Name }
+
+// Table implements GeoScraper.
+func (s *ParcelScraper) Table() string { return "geo.parcels" }
+
+// Category implements GeoScraper.
+func (s *ParcelScraper) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *ParcelScraper) Cadence() geoscraper.Cadence { return s.county.Cadence }
+
+// ShouldRun implements GeoScraper. Annual counties run after the January
+// assessment date.
+func (s *ParcelScraper) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	switch s.county.Cadence {
+	case geoscraper.Quarterly:
+		return hifldShouldRun(now, lastSync)
+	case geoscraper.Monthly:
+		return dataset.MonthlySchedule(now, lastSync)
+	default:
+		return dataset.AnnualAfter(now, lastSync, time.January)
+	}
+}
+
+// Sync implements GeoScraper.
+func (s *ParcelScraper) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting parcel sync", zap.String("county_fips", s.county.CountyFIPS))
+
+	exclude := map[string]bool{"OBJECTID": true}
+	for _, attr := range s.county.Fields {
+		exclude[attr] = true
+	}
+
+	now := time.Now().UTC()
+	seen := make(map[string]bool)
+	var (
+		totalRows int64
+		skipped   int
+		batch     [][]any
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := upsertParcels(ctx, pool, batch)
+		if err != nil {
+			return eris.Wrapf(err, "%s: upsert batch", s.Name())
+		}
+		totalRows += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err := arcgis.QueryAll(ctx, f, arcgis.QueryConfig{
+		BaseURL:      hifldURL(s.baseURL, s.county.URL),
+		Where:        s.county.Where,
+		AutoPageSize: true,
+	}, func(features []arcgis.Feature) error {
+		for _, feat := range features {
+			row := s.newRow(feat, exclude, now)
+			// Multipart parcels can repeat a parcel id; the first part wins.
+			if row == nil || seen[row[2].(string)] {
+				skipped++
+				continue
+			}
+			seen[row[2].(string)] = true
+			batch = append(batch, row)
+			if len(batch) >= hifldBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: query arcgis", s.Name())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if totalRows == 0 {
+		// Keep the previous load rather than pruning every parcel.
+		return nil, eris.Errorf("%s: no parcels returned", s.Name())
+	}
+
+	tag, err := pool.Exec(ctx, `DELETE FROM geo.parcels WHERE county_fips = $1 AND synced_at < $2`, s.county.CountyFIPS, now)
+	if err != nil {
+		return nil, eris.Wrapf(err, "%s: prune parcels", s.Name())
+	}
+
+	log.Info("parcel sync complete",
+		zap.Int64("rows", totalRows), zap.Int("skipped", skipped), zap.Int64("pruned", tag.RowsAffected()))
+	return &geoscraper.SyncResult{
+		RowsSynced: totalRows,
+		Metadata:   map[string]any{"county_fips": s.county.CountyFIPS, "skipped": skipped, "pruned": tag.RowsAffected()},
+	}, nil
+}
+
+// newRow builds a parcel temp table row, or nil when the feature has no
+// polygon or parcel id.
+func (s *ParcelScraper) newRow(feat arcgis.Feature, exclude map[string]bool, now time.Time) []any {
+	if feat.Geometry == nil || len(feat.Geometry.Rings) == 0 {
+		return nil
+	}
+	attr := func(col string) string {
+		return strings.Join(strings.Fields(hifldAttrString(feat.Attributes, s.county.Fields[col])), " ")
+	}
+	parcelID := attr("parcel_id")
+	if parcelID == "" {
+		return nil
+	}
+
+	ownerCity, ownerState, ownerZIP := attr("owner_city"), strings.ToUpper(attr("owner_state")), attr("owner_zip")
+	if csz := strings.ToUpper(attr("owner_csz")); csz != "" {
+		if m := parcelCSZ.FindStringSubmatch(csz); m != nil {
+			ownerCity, ownerState, ownerZIP = strings.TrimSpace(m[1]), m[2], m[3]
+		}
+	}
+	landUseCode := attr("land_use_code")
+
+	return []any{
+		s.county.CountyFIPS,
+		s.county.CountyFIPS[:2],
+		parcelID,
+		nilIfEmpty(strings.ToUpper(attr("owner_name"))),
+		nilIfEmpty(attr("owner_address")),
+		nilIfEmpty(ownerCity),
+		nilIfEmpty(ownerState),
+		nilIfEmpty(normalizeParcelZIP(ownerZIP)),
+		nilIfEmpty(attr("site_address")),
+		nilIfEmpty(attr("site_city")),
+		nilIfEmpty(normalizeParcelZIP(attr("site_zip"))),
+		nilIfEmpty(landUseCode),
+		nilIfEmpty(s.landUse(landUseCode)),
+		parcelAmount(attr("assessed_value")),
+		parcelAmount(attr("land_value")),
+		parcelAmount(attr("improvement_value")),
+		parseFloatOrNil(attr("acres")),
+		feat.Geometry.EWKT(),
+		s.county.Name,
+		hifldProperties(feat.Attributes, exclude),
+		now,
+	}
+}
+
+// landUse maps a county land use code to its class: the manifest mapping
+// first, then keywords in the code's description.
+func (s *ParcelScraper) landUse(code string) string {
+	if code == "" {
+		return ""
+	}
+	if class, ok := s.county.LandUse[code]; ok {
+		return class
+	}
+	upper := strings.ToUpper(code)
+	for _, k := range parcelLandUseKeywords {
+		for _, kw := range k.keywords {
+			if strings.Contains(upper, kw) {
+				return k.class
+			}
+		}
+	}
+	return "other"
+}
+
+// parcelLandUseKeywords classify land use descriptions, checked in order
+// so "VACANT COMMERCIAL" is vacant and "EXEMPT RESIDENTIAL" exempt.
+var parcelLandUseKeywords = []struct {
+	class    string
+	keywords []string
+}{
+	{"vacant", []string{"VACANT", "UNDEVELOPED"}},
+	{"exempt", []string{"EXEMPT", "GOVERNMENT", "CHURCH", "RELIGIOUS", "SCHOOL", "PUBLIC"}},
+	{"agricultural", []string{"AGRI", "FARM", "TIMBER", "FOREST", "RANCH"}},
+	{"industrial", []string{"INDUSTRIAL", "MANUFACTUR", "WAREHOUSE", "UTILITY"}},
+	{"commercial", []string{"COMMERCIAL", "OFFICE", "RETAIL", "HOTEL", "STORE", "RESTAURANT"}},
+	{"residential", []string{"RESIDENTIAL", "SINGLE FAMILY", "MULTI FAMILY", "MULTIFAMILY", "CONDO", "APARTMENT", "MOBILE HOME", "DWELLING"}},
+}
+
+// normalizeParcelZIP returns the first five-digit group of s, or "". A
+// four-digit value is a ZIP that lost its leading zero to a numeric field.
+func normalizeParcelZIP(s string) string {
+	if len(s) == 4 && isDigits(s) {
+		return "0" + s
+	}
+	return parcelZIP.FindString(s)
+}
+
+// parcelAmount parses a dollar value, returning nil for missing or zero
+// values (unassessed parcels).
+func parcelAmount(s string) *float64 {
+	v := parseFloatOrNil(strings.NewReplacer("$", "", ",", "").Replace(s))
+	if v == nil || *v <= 0 {
+		return nil
+	}
+	return v
+}
+
+// upsertParcels upserts rows through a temp table in one transaction,
+// converting EWKT geometry via ST_GeomFromEWKT.
+func upsertParcels(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "parcels: begin tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	createSQL := `CREATE TEMP TABLE _tmp_parcels (
+		county_fips        TEXT,
+		state_fips         TEXT,
+		parcel_id          TEXT,
+		owner_name         TEXT,
+		owner_address      TEXT,
+		owner_city         TEXT,
+		owner_state        TEXT,
+		owner_zip          TEXT,
+		site_address       TEXT,
+		site_city          TEXT,
+		site_zip           TEXT,
+		land_use_code      TEXT,
+		land_use           TEXT,
+		assessed_value     DOUBLE PRECISION,
+		land_value         DOUBLE PRECISION,
+		improvement_value  DOUBLE PRECISION,
+		acres              DOUBLE PRECISION,
+		geom_wkt           TEXT,
+		adapter            TEXT,
+		properties         JSONB,
+		synced_at          TIMESTAMPTZ
+	) ON COMMIT DROP`
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return 0, eris.Wrap(err, "parcels: create temp table")
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"_tmp_parcels"}, parcelCols, pgx.CopyFromRows(rows)); err != nil {
+		return 0, eris.Wrap(err, "parcels: COPY into temp table")
+	}
+
+	tag, err := tx.Exec(ctx, `INSERT INTO geo.parcels (county_fips, state_fips, parcel_id,
+			owner_name, owner_address, owner_city, owner_state, owner_zip,
+			site_address, site_city, site_zip, land_use_code, land_use,
+			assessed_value, land_value, improvement_value, acres,
+			geom, adapter, properties, synced_at)
+		SELECT county_fips, state_fips, parcel_id,
+			owner_name, owner_address, owner_city, owner_state, owner_zip,
+			site_address, site_city, site_zip, land_use_code, land_use,
+			assessed_value, land_value, improvement_value, acres,
+			ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_GeomFromEWKT(geom_wkt)), 3)), adapter, properties, synced_at
+		FROM _tmp_parcels
+		ON CONFLICT (county_fips, parcel_id) DO UPDATE SET
+			owner_name        = EXCLUDED.owner_name,
+			owner_address     = EXCLUDED.owner_address,
+			owner_city        = EXCLUDED.owner_city,
+			owner_state       = EXCLUDED.owner_state,
+			owner_zip         = EXCLUDED.owner_zip,
+			site_address      = EXCLUDED.site_address,
+			site_city         = EXCLUDED.site_city,
+			site_zip          = EXCLUDED.site_zip,
+			land_use_code     = EXCLUDED.land_use_code,
+			land_use          = EXCLUDED.land_use,
+			assessed_value    = EXCLUDED.assessed_value,
+			land_value        = EXCLUDED.land_value,
+			improvement_value = EXCLUDED.improvement_value,
+			acres             = EXCLUDED.acres,
+			geom              = EXCLUDED.geom,
+			adapter           = EXCLUDED.adapter,
+			properties        = EXCLUDED.properties,
+			synced_at         = EXCLUDED.synced_at`)
+	if err != nil {
+		return 0, eris.Wrap(err, "parcels: INSERT ON CONFLICT")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, eris.Wrap(err, "parcels: commit tx")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// RegisterParcelCounties registers one scraper per county in the parcel
+// manifest (geo.parcel_counties, or the built-in manifest when unset). A
+// manifest that cannot be read or parsed registers nothing and is returned
+// as an error.
+func RegisterParcelCounties(reg *geoscraper.Registry, path string) error {
+	counties, err := LoadParcelCounties(path)
+	if err != nil {
+		return err
+	}
+	for _, c := range counties {
+		reg.Register(NewParcelScraper(c))
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+)
+
+var testParcelCounty = ParcelCounty{
+	Name:       "nc_wake",
+	CountyFIPS: "37183",
+	Cadence:    geoscraper.Annual,
+	Fields: map[string]string{
+		"parcel_id":      "PIN_NUM",
+		"owner_name":     "OWNER",
+		"owner_address":  "ADDR1",
+		"owner_csz":      "ADDR2",
+		"site_address":   "SITE_ADDRESS",
+		"land_use_code":  "TYPE_USE_DECODE",
+		"assessed_value": "TOTAL_VALUE_ASSD",
+		"acres":          "DEED_ACRES",
+	},
+	LandUse: map[string]string{"SPECIAL": "industrial"},
+}
+
+// testParcelRing is a clockwise unit square.
+var testParcelRing = [][][2]float64{{{-78.6, 35.7}, {-78.6, 35.8}, {-78.5, 35.8}, {-78.5, 35.7}, {-78.6, 35.7}}}
+
+func expectParcelUpsert(mock pgxmock.PgxPoolIface, rows int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_parcels").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_parcels"}, parcelCols).WillReturnResult(rows)
+	mock.ExpectExec("INSERT INTO geo.parcels").WillReturnResult(pgxmock.NewResult("INSERT", rows))
+	mock.ExpectCommit()
+}
+
+func TestParseParcelCounties_Default(t *testing.T) {
+	counties, err := LoadParcelCounties("")
+	require.NoError(t, err)
+	require.Len(t, counties, 2)
+	for _, c := range counties {
+		assert.Equal(t, geoscraper.Annual, c.Cadence, c.Name)
+		assert.NotEmpty(t, c.Fields["parcel_id"], c.Name)
+		assert.NotEmpty(t, c.Fields["owner_name"], c.Name)
+	}
+	assert.Equal(t, "nc_wake", counties[0].Name)
+	assert.Equal(t, "37183", counties[0].CountyFIPS)
+}
+
+func TestParseParcelCounties_Invalid(t *testing.T) {
+	const entry = "  - name: tx_travis\n    county_fips: \"48453\"\n    url: https://example.com/query\n"
+	const base = "counties:\n" + entry
+	const fields = "    fields:\n      parcel_id: PID\n      owner_name: OWNER\n"
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{"yaml", "counties: [", "parse manifest"},
+		{"name", "counties:\n  - name: Travis\n", "invalid county name"},
+		{"duplicate", base + fields + entry + fields, "duplicate county"},
+		{"fips", "counties:\n  - name: tx_travis\n    county_fips: \"453\"\n", "five digits"},
+		{"url", "counties:\n  - name: tx_travis\n    county_fips: \"48453\"\n    url: ftp://example.com\n", "http(s)"},
+		{"cadence", base + "    cadence: weekly\n" + fields, "unsupported cadence"},
+		{"field", base + fields + "      zoning: ZONE\n", "unknown field column"},
+		{"required", base + "    fields:\n      parcel_id: PID\n", "are required"},
+		{"land use", base + fields + "    land_use:\n      A1: houses\n", "unknown class"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseParcelCounties([]byte(tt.manifest))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadParcelCounties_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "parcels.yaml")
+	manifest := "counties:\n  - name: tx_travis\n    county_fips: \"48453\"\n    url: https://example.com/query\n    cadence: quarterly\n" +
+		"    fields:\n      parcel_id: PID\n      owner_name: OWNER\n"
+	require.NoError(t, os.WriteFile(path, []byte(manifest), 0o600))
+
+	counties, err := LoadParcelCounties(path)
+	require.NoError(t, err)
+	require.Len(t, counties, 1)
+	assert.Equal(t, geoscraper.Quarterly, counties[0].Cadence)
+
+	reg := geoscraper.NewRegistry()
+	require.NoError(t, RegisterParcelCounties(reg, path))
+	_, err = reg.Get("parcels_tx_travis")
+	assert.NoError(t, err)
+
+	reg = geoscraper.NewRegistry()
+	err = RegisterParcelCounties(reg, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read manifest")
+	assert.Empty(t, reg.AllNames())
+}
+
+func TestParcelScraper_Metadata(t *testing.T) {
+	s := NewParcelScraper(testParcelCounty)
+	assert.Equal(t, "parcels_nc_wake", s.Name())
+	assert.Equal(t, "geo.parcels", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestParcelScraper_Sync(t *testing.T) {
+	data := []byte(`{
+		"features": [
+			{"attributes": {"OBJECTID": 1, "PIN_NUM": "1703 12 3456", "OWNER": "Acme Holdings LLC", "ADDR2": "RALEIGH NC 27601-1234", "TYPE_USE_DECODE": "COMMERCIAL", "TOTAL_VALUE_ASSD": 1250000}, "geometry": {"rings": [[[-78.6,35.7],[-78.6,35.8],[-78.5,35.8],[-78.6,35.7]]]}},
+			{"attributes": {"OBJECTID": 2, "PIN_NUM": "1703 12 3456", "OWNER": "Acme Holdings LLC"}, "geometry": {"rings": [[[-78.4,35.7],[-78.4,35.8],[-78.3,35.8],[-78.4,35.7]]]}},
+			{"attributes": {"OBJECTID": 3, "PIN_NUM": "0798 01 0001", "OWNER": "Jane Doe"}, "geometry": {"rings": [[[-78.6,35.7],[-78.6,35.8],[-78.5,35.8],[-78.6,35.7]]]}},
+			{"attributes": {"OBJECTID": 4, "PIN_NUM": "NOGEOM", "OWNER": "Nobody"}, "geometry": null}
+		],
+		"exceededTransferLimit": false
+	}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/Parcels/MapServer/0" {
+			_, _ = w.Write([]byte(`{"maxRecordCount":1000,"objectIdField":"OBJECTID","advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectParcelUpsert(mock, 2)
+	mock.ExpectExec("DELETE FROM geo.parcels WHERE county_fips").
+		WithArgs("37183", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 5))
+
+	s := NewParcelScraper(testParcelCounty)
+	s.baseURL = srv.URL + "/Parcels/MapServer/0/query"
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, 2, result.Metadata["skipped"], "repeated parcel id and missing geometry")
+	assert.Equal(t, int64(5), result.Metadata["pruned"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParcelScraper_Sync_Empty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/Parcels/MapServer/0" {
+			_, _ = w.Write([]byte(`{"maxRecordCount":1000,"advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"features": [], "exceededTransferLimit": false}`))
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := NewParcelScraper(testParcelCounty)
+	s.baseURL = srv.URL + "/Parcels/MapServer/0/query"
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no parcels returned")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParcelScraper_NewRow(t *testing.T) {
+	s := NewParcelScraper(testParcelCounty)
+	now := fixedNow()
+	exclude := map[string]bool{"PIN_NUM": true, "OWNER": true, "ADDR1": true, "ADDR2": true, "TYPE_USE_DECODE": true, "TOTAL_VALUE_ASSD": true, "DEED_ACRES": true}
+
+	row := s.newRow(arcgis.Feature{
+		Attributes: map[string]any{
+			"PIN_NUM":          "1703 12  3456",
+			"OWNER":            "Acme  Holdings LLC",
+			"ADDR1":            "100 Main St",
+			"ADDR2":            "Raleigh, NC 27601-1234",
+			"TYPE_USE_DECODE":  "VACANT COMMERCIAL",
+			"TOTAL_VALUE_ASSD": "$1,250,000",
+			"DEED_ACRES":       2.5,
+			"ZONING":           "CX-3",
+		},
+		Geometry: &arcgis.Geometry{Rings: testParcelRing},
+	}, exclude, now)
+	require.Len(t, row, len(parcelCols))
+	assert.Equal(t, "37183", row[0])
+	assert.Equal(t, "37", row[1])
+	assert.Equal(t, "1703 12 3456", row[2], "whitespace collapsed")
+	assert.Equal(t, "ACME HOLDINGS LLC", row[3])
+	assert.Equal(t, "100 Main St", row[4])
+	assert.Equal(t, "RALEIGH", row[5])
+	assert.Equal(t, "NC", row[6])
+	assert.Equal(t, "27601", row[7])
+	assert.Equal(t, "VACANT COMMERCIAL", row[11])
+	assert.Equal(t, "vacant", row[12])
+	assert.InDelta(t, 1250000, *row[13].(*float64), 1e-9)
+	assert.Nil(t, row[14], "unmapped land value")
+	assert.InDelta(t, 2.5, *row[16].(*float64), 1e-9)
+	assert.Contains(t, row[17], "SRID=4326;MULTIPOLYGON")
+	assert.Equal(t, "nc_wake", row[18])
+	assert.JSONEq(t, `{"ZONING":"CX-3"}`, string(row[19].([]byte)))
+	assert.Equal(t, now, row[20])
+
+	assert.Nil(t, s.newRow(arcgis.Feature{Attributes: map[string]any{"PIN_NUM": "1"}}, exclude, now))
+	assert.Nil(t, s.newRow(arcgis.Feature{
+		Attributes: map[string]any{"OWNER": "No Id"},
+		Geometry:   &arcgis.Geometry{Rings: testParcelRing},
+	}, exclude, now))
+}
+
+func TestParcelScraper_LandUse(t *testing.T) {
+	s := NewParcelScraper(testParcelCounty)
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"", ""},
+		{"SPECIAL", "industrial"},
+		{"Single Family Residential", "residential"},
+		{"OFFICE BUILDING", "commercial"},
+		{"Light Manufacturing", "industrial"},
+		{"FARM - PRESENT USE", "agricultural"},
+		{"EXEMPT RESIDENTIAL", "exempt"},
+		{"Vacant Land", "vacant"},
+		{"MISC", "other"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, s.landUse(tt.code), tt.code)
+	}
+}
+
+func TestNormalizeParcelZIP(t *testing.T) {
+	assert.Equal(t, "27601", normalizeParcelZIP("27601-1234"))
+	assert.Equal(t, "02134", normalizeParcelZIP("2134"))
+	assert.Equal(t, "27601", normalizeParcelZIP("276011234"))
+	assert.Equal(t, "", normalizeParcelZIP("N/A"))
+}
+
+func TestParcelAmount(t *testing.T) {
+	assert.InDelta(t, 1250000, *parcelAmount("$1,250,000"), 1e-9)
+	assert.Nil(t, parcelAmount("0"))
+	assert.Nil(t, parcelAmount(""))
+}
@@ -22,10 +22,21 @@ func RegisterHIFLD(reg *geoscraper.Registry) {
 	reg.Register(&HIFLDBridges{})
 }
 
+// RegisterHIFLDManifest registers the manifest-driven HIFLD layer scrapers
+// (geo.hifld_* tables) from geo.hifld_layers or the built-in manifest.
+func RegisterHIFLDManifest(reg *geoscraper.Registry, cfg *config.Config) error {
+	var path string
+	if cfg != nil {
+		path = cfg.Geo.HIFLDLayers
+	}
+	return RegisterHIFLDLayers(reg, path)
+}
+
 // RegisterFEMA registers all FEMA scrapers.
 func RegisterFEMA(reg *geoscraper.Registry) {
 	reg.Register(&FEMAFloodZones{})
 	reg.Register(&FEMAFloodBulk{})
+	reg.Register(&FEMADisasters{})
 }
 
 // RegisterEPA registers all EPA scrapers.
@@ -60,17 +71,24 @@ func RegisterNWI(reg *geoscraper.Registry) {
 }
 
 // RegisterNRCS registers all NRCS scrapers.
-func RegisterNRCS(reg *geoscraper.Registry) {
+func RegisterNRCS(reg *geoscraper.Registry, cfg *config.Config) {
 	reg.Register(&NRCSSoils{})
+	ssurgo := &SSURGO{}
+	if cfg != nil {
+		ssurgo.states = cfg.Geo.SSURGOStates
+	}
+	reg.Register(ssurgo)
 }
 
 // RegisterTIGER registers all TIGER/Line scrapers.
 func RegisterTIGER(reg *geoscraper.Registry) {
 	reg.Register(&TIGERBoundaries{})
+	reg.Register(&CBSADelineations{})
 	reg.Register(&TIGERRoads{})
 	reg.Register(&TIGERBlockGroups{})
 	reg.Register(&TIGERCousub{})
 	reg.Register(&TIGERWater{})
+	reg.Register(&TIGERTractRelationships{})
 }
 
 // RegisterUSGS registers all USGS/USGS-adjacent scrapers.
@@ -80,6 +98,7 @@ func RegisterUSGS(reg *geoscraper.Registry) {
 	reg.Register(&USGSWaterways{})
 	reg.Register(&USGSCoalMines{})
 	reg.Register(&USGSEarthquakes{})
+	reg.Register(&USGSHazards{})
 }
 
 // RegisterBulkGDB registers GDB-based bulk scrapers that replace ArcGIS equivalents.
@@ -89,8 +108,14 @@ func RegisterBulkGDB(reg *geoscraper.Registry) {
 }
 
 // RegisterOSM registers all OpenStreetMap scrapers.
-func RegisterOSM(reg *geoscraper.Registry) {
+func RegisterOSM(reg *geoscraper.Registry, cfg *config.Config) {
 	reg.Register(&OSMPOI{})
+	business := &OSMBusinessPOIs{}
+	if cfg != nil {
+		business.states = cfg.Geo.OSMStates
+		business.bbox = cfg.Geo.OSMBBox
+	}
+	reg.Register(business)
 }
 
 // RegisterBulkCSV registers CSV-based scrapers that replace ArcGIS equivalents.
@@ -158,18 +183,75 @@ func RegisterBLM(reg *geoscraper.Registry) {
 	reg.Register(&BLMMineralLeases{})
 }
 
-// RegisterAll registers all geo scraper implementations.
-func RegisterAll(reg *geoscraper.Registry, cfg *config.Config) {
+// RegisterNOAA registers all NOAA scrapers.
+func RegisterNOAA(reg *geoscraper.Registry) {
+	reg.Register(&NOAAStorms{})
+}
+
+// RegisterNCES registers all NCES scrapers.
+func RegisterNCES(reg *geoscraper.Registry) {
+	reg.Register(&NCES{})
+}
+
+// RegisterGTFS registers the GTFS transit feed scraper.
+func RegisterGTFS(reg *geoscraper.Registry, cfg *config.Config) {
+	gtfs := &GTFS{}
+	if cfg != nil {
+		gtfs.feeds = cfg.Geo.GTFSFeeds
+	}
+	reg.Register(gtfs)
+}
+
+// RegisterCDFI registers all CDFI Fund scrapers.
+func RegisterCDFI(reg *geoscraper.Registry) {
+	reg.Register(&OpportunityZones{})
+}
+
+// RegisterUSDA registers all USDA scrapers.
+func RegisterUSDA(reg *geoscraper.Registry) {
+	reg.Register(&USDARUCA{})
+}
+
+// RegisterParcels registers the per-county parcel scrapers from
+// geo.parcel_counties or the built-in manifest.
+func RegisterParcels(reg *geoscraper.Registry, cfg *config.Config) error {
+	var path string
+	if cfg != nil {
+		path = cfg.Geo.ParcelCounties
+	}
+	return RegisterParcelCounties(reg, path)
+}
+
+// RegisterIsochrones registers the drive-time isochrone scraper.
+func RegisterIsochrones(reg *geoscraper.Registry, cfg *config.Config) {
+	iso := &Isochrones{}
+	if cfg != nil {
+		iso.cfg = cfg.Geo.Isochrones
+	}
+	reg.Register(iso)
+}
+
+// RegisterUSFS registers all US Forest Service scrapers.
+func RegisterUSFS(reg *geoscraper.Registry) {
+	reg.Register(&WildfireRisk{})
+}
+
+// RegisterAll registers all geo scraper implementations. It fails when a
+// configured scraper manifest cannot be loaded.
+func RegisterAll(reg *geoscraper.Registry, cfg *config.Config) error {
 	RegisterHIFLD(reg)
+	if err := RegisterHIFLDManifest(reg, cfg); err != nil {
+		return err
+	}
 	RegisterFEMA(reg)
 	RegisterEPA(reg)
 	RegisterCensus(reg, cfg)
 	RegisterFCC(reg, cfg)
 	RegisterNWI(reg)
-	RegisterNRCS(reg)
+	RegisterNRCS(reg, cfg)
 	RegisterUSGS(reg)
 	RegisterTIGER(reg)
-	RegisterOSM(reg)
+	RegisterOSM(reg, cfg)
 	RegisterBulkCSV(reg, cfg)
 	RegisterNTAD(reg)
 	RegisterEIA(reg)
@@ -180,4 +262,15 @@ func RegisterAll(reg *geoscraper.Registry, cfg *config.Config) {
 	RegisterImports(reg)
 	RegisterBulkGDB(reg)
 	RegisterBLM(reg)
+	RegisterUSFS(reg)
+	RegisterNOAA(reg)
+	RegisterNCES(reg)
+	RegisterGTFS(reg, cfg)
+	RegisterCDFI(reg)
+	RegisterUSDA(reg)
+	if err := RegisterParcels(reg, cfg); err != nil {
+		return err
+	}
+	RegisterIsochrones(reg, cfg)
+	return nil
 }
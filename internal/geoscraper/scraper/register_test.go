@@ -1,6 +1,7 @@
 package scraper
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,10 +34,10 @@ func TestRegisterHIFLD(t *testing.T) {
 
 func TestRegisterAll(t *testing.T) {
 	reg := geoscraper.NewRegistry()
-	RegisterAll(reg, nil)
+	require.NoError(t, RegisterAll(reg, nil))
 
 	names := reg.AllNames()
-	require.Len(t, names, 61) // 13 HIFLD + 2 FEMA + 3 EPA + 1 Census + 2 FCC + 1 NWI + 1 NRCS + 5 USGS + 5 TIGER + 1 OSM + 5 BulkCSV + 7 NTAD + 1 EIA + 1 CDC + 1 FDIC + 2 HUD + 1 EPA SLD + 5 Imports + 2 BulkGDB + 2 BLM
+	require.Len(t, names, 78) // 13 HIFLD + 2 HIFLD layers + 3 FEMA + 3 EPA + 1 Census + 2 FCC + 1 NWI + 2 NRCS + 6 USGS + 7 TIGER + 2 OSM + 5 BulkCSV + 7 NTAD + 1 EIA + 1 CDC + 1 FDIC + 2 HUD + 1 EPA SLD + 5 Imports + 2 BulkGDB + 2 BLM + 1 USFS + 1 NOAA + 1 NCES + 1 GTFS + 1 CDFI + 1 USDA + 2 parcel counties + 1 isochrones
 
 	// All should be National or OnDemand category.
 	for _, s := range reg.All() {
@@ -52,15 +53,24 @@ func TestRegisterAll_WithConfig(t *testing.T) {
 	cfg.Fedsync.FCCBDCKey = "test-fcc-key"
 
 	reg := geoscraper.NewRegistry()
-	RegisterAll(reg, cfg)
+	require.NoError(t, RegisterAll(reg, cfg))
 
 	names := reg.AllNames()
-	require.Len(t, names, 61)
+	require.Len(t, names, 78)
+}
+
+func TestRegisterAll_BadParcelManifest(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Geo.ParcelCounties = filepath.Join(t.TempDir(), "missing.yaml")
+
+	err := RegisterAll(geoscraper.NewRegistry(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parcels: read manifest")
 }
 
 func TestRegisterAll_NoDuplicates(t *testing.T) {
 	reg := geoscraper.NewRegistry()
-	RegisterAll(reg, nil)
+	require.NoError(t, RegisterAll(reg, nil))
 
 	seen := make(map[string]bool)
 	for _, name := range reg.AllNames() {
@@ -76,15 +86,20 @@ var (
 	_ geoscraper.GeoScraper = (*HIFLDTransmissionLines)(nil)
 	_ geoscraper.GeoScraper = (*HIFLDPipelines)(nil)
 	_ geoscraper.GeoScraper = (*FEMAFloodZones)(nil)
+	_ geoscraper.GeoScraper = (*FEMADisasters)(nil)
+	_ geoscraper.GeoScraper = (*HIFLDLayerScraper)(nil)
+	_ geoscraper.GeoScraper = (*CBSADelineations)(nil)
 	_ geoscraper.GeoScraper = (*EPASites)(nil)
 	_ geoscraper.GeoScraper = (*CensusDemographics)(nil)
 	_ geoscraper.GeoScraper = (*FCCTowers)(nil)
 	_ geoscraper.GeoScraper = (*FCCBroadband)(nil)
 	_ geoscraper.GeoScraper = (*NWIWetlands)(nil)
 	_ geoscraper.GeoScraper = (*NRCSSoils)(nil)
+	_ geoscraper.GeoScraper = (*SSURGO)(nil)
 	_ geoscraper.GeoScraper = (*TIGERBoundaries)(nil)
 	_ geoscraper.GeoScraper = (*TIGERRoads)(nil)
 	_ geoscraper.GeoScraper = (*OSMPOI)(nil)
+	_ geoscraper.GeoScraper = (*OSMBusinessPOIs)(nil)
 	_ geoscraper.GeoScraper = (*HIFLDSchools)(nil)
 	_ geoscraper.GeoScraper = (*HIFLDFireEMS)(nil)
 	_ geoscraper.GeoScraper = (*HIFLDHospitals)(nil)
@@ -99,6 +114,12 @@ var (
 	_ geoscraper.GeoScraper = (*USGSWaterways)(nil)
 	_ geoscraper.GeoScraper = (*USGSCoalMines)(nil)
 	_ geoscraper.GeoScraper = (*USGSEarthquakes)(nil)
+	_ geoscraper.GeoScraper = (*USGSHazards)(nil)
+	_ geoscraper.GeoScraper = (*WildfireRisk)(nil)
+	_ geoscraper.GeoScraper = (*NOAAStorms)(nil)
+	_ geoscraper.GeoScraper = (*NCES)(nil)
+	_ geoscraper.GeoScraper = (*GTFS)(nil)
+	_ geoscraper.GeoScraper = (*OpportunityZones)(nil)
 	_ geoscraper.GeoScraper = (*EPAWastewater)(nil)
 	_ geoscraper.GeoScraper = (*EPABrownfields)(nil)
 	_ geoscraper.GeoScraper = (*FHWABridges)(nil)
@@ -0,0 +1,385 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/sda"
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+// ssurgoAreaSymbol matches a soil survey area symbol (state abbreviation
+// plus three characters, e.g. "TX453"). Symbols are interpolated into SDA
+// queries, so anything else is rejected.
+var ssurgoAreaSymbol = regexp.MustCompile(`^[A-Z]{2}[0-9A-Z]{3}$`)
+
+// ssurgoPageSize is the number of polygons requested per SDA query. SDA
+// caps response size, so large survey areas are fetched in pages.
+const ssurgoPageSize = 2000
+
+// ssurgoStateAbbr matches a two-letter state prefix.
+var ssurgoStateAbbr = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ssurgoCols are the columns written to the temp table for survey area
+// loads. geom_wkt is TEXT in the temp table; converted to geometry via
+// ST_GeomFromEWKT.
+var ssurgoCols = []string{
+	"areasymbol", "mukey", "polygon_key", "musym", "muname", "mukind",
+	"farmland_class", "drainage_class", "hydrologic_group", "hydric_percent",
+	"slope_percent", "capability_class", "available_water_cm", "geom_wkt", "source",
+}
+
+// ssurgoArea is a soil survey area from the SDA catalog.
+type ssurgoArea struct {
+	symbol  string
+	name    string
+	version string // sacatalog.saverest, the published survey version
+}
+
+// SSURGO loads SSURGO soil map unit polygons and their key attributes
+// (farmland class, drainage, hydrologic group, capability class) into
+// geo.ssurgo from NRCS Soil Data Access, one survey area at a time. Areas
+// whose published version matches the one recorded in geo.ssurgo_areas
+// are skipped. Polygons are paged by polygon key, and transient SDA errors
+// are retried. It complements NRCSSoils, whose national general soil map
+// is too coarse for parcel-level questions.
+type SSURGO struct {
+	states      []string               // state abbreviations; empty = every survey area
+	endpointURL string                 // override for testing; empty uses sda.DefaultEndpoint
+	pageSize    int                    // override for testing; zero uses ssurgoPageSize
+	retry       resilience.RetryConfig // zero value uses resilience defaults
+}
+
+// Name implements GeoScraper.
+func (s *SSURGO) Name() string { return "ssurgo" }
+
+// Table implements GeoScraper.
+func (s *SSURGO) Table() string { return "geo.ssurgo" }
+
+// Category implements GeoScraper.
+func (s *SSURGO) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *SSURGO) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper. SSURGO is refreshed each October.
+func (s *SSURGO) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.October)
+}
+
+// Sync implements GeoScraper.
+func (s *SSURGO) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+
+	areas, err := s.listAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := loadedSSURGOVersions(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("starting SSURGO sync", zap.Int("areas", len(areas)), zap.Int("loaded", len(loaded)))
+
+	var (
+		total   int64
+		synced  int
+		skipped int
+		failed  []string
+	)
+	for _, area := range areas {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if loaded[area.symbol] == area.version {
+			skipped++
+			continue
+		}
+		n, err := s.syncArea(ctx, pool, area)
+		if err != nil {
+			log.Warn("survey area failed, skipping", zap.String("area", area.symbol), zap.Error(err))
+			failed = append(failed, area.symbol)
+			continue
+		}
+		log.Debug("survey area loaded", zap.String("area", area.symbol), zap.Int64("polygons", n))
+		total += n
+		synced++
+	}
+	// Loaded areas are recorded, so the next run retries only the failures.
+	if len(failed) > 0 {
+		return nil, eris.Errorf("ssurgo: %d of %d changed survey areas failed: %s",
+			len(failed), len(failed)+synced, strings.Join(failed, ", "))
+	}
+
+	log.Info("SSURGO sync complete",
+		zap.Int64("polygons", total),
+		zap.Int("areas", synced),
+		zap.Int("unchanged", skipped),
+	)
+	return &geoscraper.SyncResult{
+		RowsSynced: total,
+		Metadata: map[string]any{
+			"areas":     synced,
+			"unchanged": skipped,
+		},
+	}, nil
+}
+
+// listAreas returns the survey areas in the SDA catalog, limited to the
+// configured states.
+func (s *SSURGO) listAreas(ctx context.Context) ([]ssurgoArea, error) {
+	query := `SELECT areasymbol, areaname, CONVERT(varchar(19), saverest, 120) AS saverest
+FROM sacatalog
+WHERE areasymbol <> 'US'`
+	if len(s.states) > 0 {
+		quoted := make([]string, 0, len(s.states))
+		for _, st := range s.states {
+			abbr := strings.ToUpper(strings.TrimSpace(st))
+			if !ssurgoStateAbbr.MatchString(abbr) {
+				return nil, eris.Errorf("ssurgo: invalid state %q", st)
+			}
+			quoted = append(quoted, "'"+abbr+"'")
+		}
+		query += fmt.Sprintf(" AND LEFT(areasymbol, 2) IN (%s)", strings.Join(quoted, ", "))
+	}
+	query += "\nORDER BY areasymbol"
+
+	rows, err := s.query(ctx, query)
+	if err != nil {
+		return nil, eris.Wrap(err, "ssurgo: list survey areas")
+	}
+	areas := make([]ssurgoArea, 0, len(rows))
+	for _, r := range rows {
+		if !ssurgoAreaSymbol.MatchString(r["areasymbol"]) || r["saverest"] == "" {
+			continue
+		}
+		areas = append(areas, ssurgoArea{symbol: r["areasymbol"], name: r["areaname"], version: r["saverest"]})
+	}
+	if len(areas) == 0 {
+		return nil, eris.New("ssurgo: no survey areas in catalog")
+	}
+	return areas, nil
+}
+
+// loadedSSURGOVersions returns the survey version loaded for each area.
+func loadedSSURGOVersions(ctx context.Context, pool db.Pool) (map[string]string, error) {
+	rows, err := pool.Query(ctx, `SELECT areasymbol, survey_version FROM geo.ssurgo_areas`)
+	if err != nil {
+		return nil, eris.Wrap(err, "ssurgo: query loaded areas")
+	}
+	defer rows.Close()
+
+	loaded := make(map[string]string)
+	for rows.Next() {
+		var symbol, version string
+		if err := rows.Scan(&symbol, &version); err != nil {
+			return nil, eris.Wrap(err, "ssurgo: scan loaded area")
+		}
+		loaded[symbol] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "ssurgo: iterate loaded areas")
+	}
+	return loaded, nil
+}
+
+// syncArea fetches one survey area's map units and polygons and replaces
+// the area in geo.ssurgo.
+func (s *SSURGO) syncArea(ctx context.Context, pool db.Pool, area ssurgoArea) (int64, error) {
+	mapUnits, err := s.query(ctx, fmt.Sprintf(ssurgoMapUnitQuery, area.symbol))
+	if err != nil {
+		return 0, eris.Wrapf(err, "ssurgo: map units for %s", area.symbol)
+	}
+	units := make(map[string]sda.Row, len(mapUnits))
+	for _, mu := range mapUnits {
+		units[mu["mukey"]] = mu
+	}
+
+	pageSize := s.pageSize
+	if pageSize <= 0 {
+		pageSize = ssurgoPageSize
+	}
+	var (
+		rows  [][]any
+		after int64
+	)
+	for {
+		polygons, err := s.query(ctx, fmt.Sprintf(ssurgoPolygonQuery, pageSize, area.symbol, after))
+		if err != nil {
+			return 0, eris.Wrapf(err, "ssurgo: polygons for %s after key %d", area.symbol, after)
+		}
+		last := after
+		for _, p := range polygons {
+			if key, err := strconv.ParseInt(p["mupolygonkey"], 10, 64); err == nil && key > last {
+				last = key
+			}
+			row, ok := newSSURGORow(area.symbol, p, units[p["mukey"]])
+			if ok {
+				rows = append(rows, row)
+			}
+		}
+		if len(polygons) < pageSize {
+			break
+		}
+		if last == after {
+			return 0, eris.Errorf("ssurgo: polygon keys for %s did not advance past %d", area.symbol, after)
+		}
+		after = last
+	}
+	if len(rows) == 0 {
+		return 0, eris.Errorf("ssurgo: no polygons for %s", area.symbol)
+	}
+	return replaceSSURGOArea(ctx, pool, area, len(units), rows)
+}
+
+const ssurgoMapUnitQuery = `SELECT mu.mukey, mu.musym, mu.muname, mu.mukind, mu.farmlndcl,
+	ma.drclassdcd, ma.hydgrpdcd, ma.hydclprs, ma.slopegraddcp, ma.niccdcd, ma.aws0150wta
+FROM legend l
+INNER JOIN mapunit mu ON mu.lkey = l.lkey
+LEFT JOIN muaggatt ma ON ma.mukey = mu.mukey
+WHERE l.areasymbol = '%s'`
+
+// ssurgoPolygonQuery selects one page of an area's polygons: the page
+// size, area symbol, and last polygon key of the previous page.
+const ssurgoPolygonQuery = `SELECT TOP %d mupolygonkey, mukey, mupolygongeo.STAsText() AS wkt
+FROM mupolygon
+WHERE areasymbol = '%s' AND mupolygonkey > %d
+ORDER BY mupolygonkey`
+
+// query runs an SDA query, retrying transient failures.
+func (s *SSURGO) query(ctx context.Context, query string) ([]sda.Row, error) {
+	retry := s.retry
+	if retry.OnRetry == nil {
+		retry.OnRetry = func(attempt int, err error) {
+			zap.L().Warn("ssurgo: retrying SDA query", zap.Int("attempt", attempt), zap.Error(err))
+		}
+	}
+	return resilience.DoVal(ctx, retry, func(ctx context.Context) ([]sda.Row, error) {
+		return sda.Query(ctx, s.endpointURL, query)
+	})
+}
+
+// newSSURGORow builds a temp table row from a polygon and its map unit.
+// Returns nil, false if the polygon has no key or geometry.
+func newSSURGORow(areaSymbol string, polygon, mu sda.Row) ([]any, bool) {
+	key, wkt := polygon["mupolygonkey"], polygon["wkt"]
+	if key == "" || wkt == "" {
+		return nil, false
+	}
+	var hydric any
+	if v, err := strconv.ParseInt(mu["hydclprs"], 10, 16); err == nil {
+		hydric = int16(v)
+	}
+	return []any{
+		areaSymbol,
+		polygon["mukey"],
+		key,
+		nilIfEmpty(mu["musym"]),
+		nilIfEmpty(mu["muname"]),
+		nilIfEmpty(mu["mukind"]),
+		nilIfEmpty(mu["farmlndcl"]),
+		nilIfEmpty(mu["drclassdcd"]),
+		nilIfEmpty(mu["hydgrpdcd"]),
+		hydric,
+		parseFloatOrNil(mu["slopegraddcp"]),
+		nilIfEmpty(mu["niccdcd"]),
+		parseFloatOrNil(mu["aws0150wta"]),
+		"SRID=4326;" + wkt,
+		nrcsSource,
+	}, true
+}
+
+// replaceSSURGOArea swaps an area's polygons in one transaction and records
+// the loaded survey version, converting EWKT geometry via ST_GeomFromEWKT.
+func replaceSSURGOArea(ctx context.Context, pool db.Pool, area ssurgoArea, mapUnits int, rows [][]any) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "ssurgo: begin tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	createSQL := `CREATE TEMP TABLE _tmp_ssurgo (
+		areasymbol         TEXT,
+		mukey              TEXT,
+		polygon_key        TEXT,
+		musym              TEXT,
+		muname             TEXT,
+		mukind             TEXT,
+		farmland_class     TEXT,
+		drainage_class     TEXT,
+		hydrologic_group   TEXT,
+		hydric_percent     SMALLINT,
+		slope_percent      DOUBLE PRECISION,
+		capability_class   TEXT,
+		available_water_cm DOUBLE PRECISION,
+		geom_wkt           TEXT,
+		source             TEXT
+	) ON COMMIT DROP`
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return 0, eris.Wrap(err, "ssurgo: create temp table")
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"_tmp_ssurgo"}, ssurgoCols, pgx.CopyFromRows(rows)); err != nil {
+		return 0, eris.Wrap(err, "ssurgo: COPY into temp table")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM geo.ssurgo WHERE areasymbol = $1`, area.symbol); err != nil {
+		return 0, eris.Wrap(err, "ssurgo: delete area")
+	}
+
+	// A polygon key seen under another area moves to this one.
+	tag, err := tx.Exec(ctx, `INSERT INTO geo.ssurgo (areasymbol, mukey, polygon_key, musym, muname, mukind,
+			farmland_class, drainage_class, hydrologic_group, hydric_percent, slope_percent,
+			capability_class, available_water_cm, geom, source)
+		SELECT areasymbol, mukey, polygon_key, musym, muname, mukind,
+			farmland_class, drainage_class, hydrologic_group, hydric_percent, slope_percent,
+			capability_class, available_water_cm, ST_Multi(ST_GeomFromEWKT(geom_wkt)), source
+		FROM _tmp_ssurgo
+		ON CONFLICT (polygon_key) DO UPDATE SET
+			areasymbol         = EXCLUDED.areasymbol,
+			mukey              = EXCLUDED.mukey,
+			musym              = EXCLUDED.musym,
+			muname             = EXCLUDED.muname,
+			mukind             = EXCLUDED.mukind,
+			farmland_class     = EXCLUDED.farmland_class,
+			drainage_class     = EXCLUDED.drainage_class,
+			hydrologic_group   = EXCLUDED.hydrologic_group,
+			hydric_percent     = EXCLUDED.hydric_percent,
+			slope_percent      = EXCLUDED.slope_percent,
+			capability_class   = EXCLUDED.capability_class,
+			available_water_cm = EXCLUDED.available_water_cm,
+			geom               = EXCLUDED.geom,
+			updated_at         = now()`)
+	if err != nil {
+		return 0, eris.Wrap(err, "ssurgo: INSERT ON CONFLICT")
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO geo.ssurgo_areas (areasymbol, areaname, survey_version, map_units, polygons, loaded_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (areasymbol) DO UPDATE SET
+			areaname       = EXCLUDED.areaname,
+			survey_version = EXCLUDED.survey_version,
+			map_units      = EXCLUDED.map_units,
+			polygons       = EXCLUDED.polygons,
+			loaded_at      = EXCLUDED.loaded_at`,
+		area.symbol, nilIfEmpty(area.name), area.version, mapUnits, len(rows)); err != nil {
+		return 0, eris.Wrap(err, "ssurgo: record area")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, eris.Wrap(err, "ssurgo: commit tx")
+	}
+	return tag.RowsAffected(), nil
+}
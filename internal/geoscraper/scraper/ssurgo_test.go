@@ -0,0 +1,313 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/sda"
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+const (
+	testSSURGOCatalog = `{"Table": [
+		["areasymbol", "areaname", "saverest"],
+		["TX021", "Bastrop County, Texas", "2024-09-03 00:00:00"],
+		["TX453", "Travis County, Texas", "2024-09-05 00:00:00"],
+		["bad'; --", "Injected", "2024-09-05 00:00:00"]
+	]}`
+	testSSURGOMapUnits = `{"Table": [
+		["mukey", "musym", "muname", "mukind", "farmlndcl", "drclassdcd", "hydgrpdcd", "hydclprs", "slopegraddcp", "niccdcd", "aws0150wta"],
+		["1001", "HoA", "Houston Black clay, 0 to 1 percent slopes", "Consociation", "All areas are prime farmland", "Moderately well drained", "D", "0", "1", "2", "22.5"],
+		["1002", "W", "Water", "Consociation", "Not prime farmland", null, null, null, null, null, null]
+	]}`
+	testSSURGOPolygons = `{"Table": [
+		["mupolygonkey", "mukey", "wkt"],
+		["9001", "1001", "POLYGON ((-97.7 30.2, -97.6 30.2, -97.6 30.3, -97.7 30.2))"],
+		["9002", "1002", "POLYGON ((-97.8 30.2, -97.7 30.2, -97.7 30.3, -97.8 30.2))"],
+		["9003", "1001", null]
+	]}`
+)
+
+// newSDAServer answers SDA queries by the table they select from. Tables
+// missing from responses fail with HTTP 500.
+func newSDAServer(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		for table, body := range responses {
+			if strings.Contains(req.Query, "FROM "+table) {
+				_, _ = w.Write([]byte(body))
+				return
+			}
+		}
+		http.Error(w, "query failed", http.StatusInternalServerError)
+	}))
+}
+
+func TestSSURGO_Metadata(t *testing.T) {
+	s := &SSURGO{}
+	assert.Equal(t, "ssurgo", s.Name())
+	assert.Equal(t, "geo.ssurgo", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestSSURGO_Sync(t *testing.T) {
+	srv := newSDAServer(t, map[string]string{
+		"sacatalog": testSSURGOCatalog,
+		"legend":    testSSURGOMapUnits,
+		"mupolygon": testSSURGOPolygons,
+	})
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// TX021 is current and skipped; TX453 changed.
+	mock.ExpectQuery("SELECT areasymbol, survey_version FROM geo.ssurgo_areas").
+		WillReturnRows(pgxmock.NewRows([]string{"areasymbol", "survey_version"}).
+			AddRow("TX021", "2024-09-03 00:00:00").
+			AddRow("TX453", "2023-09-01 00:00:00"))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_ssurgo").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_ssurgo"}, ssurgoCols).WillReturnResult(2)
+	mock.ExpectExec("DELETE FROM geo.ssurgo WHERE areasymbol").
+		WithArgs("TX453").
+		WillReturnResult(pgxmock.NewResult("DELETE", 5))
+	mock.ExpectExec(`INSERT INTO geo.ssurgo \(`).WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectExec("INSERT INTO geo.ssurgo_areas").
+		WithArgs("TX453", "Travis County, Texas", "2024-09-05 00:00:00", 2, 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	s := &SSURGO{states: []string{"tx"}, endpointURL: srv.URL}
+	result, err := s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	assert.Equal(t, 1, result.Metadata["areas"])
+	assert.Equal(t, 1, result.Metadata["unchanged"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSSURGO_Sync_AllFailed(t *testing.T) {
+	srv := newSDAServer(t, map[string]string{
+		"sacatalog": testSSURGOCatalog,
+		"legend":    testSSURGOMapUnits,
+	})
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectQuery("SELECT areasymbol, survey_version FROM geo.ssurgo_areas").
+		WillReturnRows(pgxmock.NewRows([]string{"areasymbol", "survey_version"}))
+
+	s := &SSURGO{endpointURL: srv.URL, retry: resilience.RetryConfig{MaxAttempts: 1}}
+	_, err = s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 2 changed survey areas failed: TX021, TX453")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSSURGO_Sync_OneAreaFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case strings.Contains(req.Query, "FROM sacatalog"):
+			_, _ = w.Write([]byte(testSSURGOCatalog))
+		case strings.Contains(req.Query, "'TX021'"):
+			http.Error(w, "Invalid query", http.StatusBadRequest)
+		case strings.Contains(req.Query, "FROM legend"):
+			_, _ = w.Write([]byte(testSSURGOMapUnits))
+		default:
+			_, _ = w.Write([]byte(testSSURGOPolygons))
+		}
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectQuery("SELECT areasymbol, survey_version FROM geo.ssurgo_areas").
+		WillReturnRows(pgxmock.NewRows([]string{"areasymbol", "survey_version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_ssurgo").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_ssurgo"}, ssurgoCols).WillReturnResult(2)
+	mock.ExpectExec("DELETE FROM geo.ssurgo WHERE areasymbol").
+		WithArgs("TX453").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(`INSERT INTO geo.ssurgo \(`).WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectExec("INSERT INTO geo.ssurgo_areas").
+		WithArgs("TX453", "Travis County, Texas", "2024-09-05 00:00:00", 2, 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	// TX453 is loaded and recorded, but the sync still fails for TX021.
+	s := &SSURGO{endpointURL: srv.URL}
+	_, err = s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 changed survey areas failed: TX021")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSSURGO_SyncArea_PagesAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case strings.Contains(req.Query, "FROM legend"):
+			_, _ = w.Write([]byte(testSSURGOMapUnits))
+		case calls.Add(1) == 1:
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		case strings.Contains(req.Query, "mupolygonkey > 0"):
+			pages = append(pages, req.Query)
+			_, _ = w.Write([]byte(`{"Table": [["mupolygonkey", "mukey", "wkt"],
+				["9001", "1001", "POLYGON ((0 0, 1 0, 1 1, 0 0))"],
+				["9002", "1002", "POLYGON ((1 0, 2 0, 2 1, 1 0))"]]}`))
+		case strings.Contains(req.Query, "mupolygonkey > 9002"):
+			pages = append(pages, req.Query)
+			_, _ = w.Write([]byte(`{"Table": [["mupolygonkey", "mukey", "wkt"],
+				["9004", "1001", "POLYGON ((2 0, 3 0, 3 1, 2 0))"]]}`))
+		default:
+			http.Error(w, "unexpected page", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_ssurgo").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_ssurgo"}, ssurgoCols).WillReturnResult(3)
+	mock.ExpectExec("DELETE FROM geo.ssurgo WHERE areasymbol").
+		WithArgs("TX453").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(`INSERT INTO geo.ssurgo \(`).WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	mock.ExpectExec("INSERT INTO geo.ssurgo_areas").
+		WithArgs("TX453", "Travis County, Texas", "2024-09-05 00:00:00", 2, 3).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	s := &SSURGO{
+		endpointURL: srv.URL,
+		pageSize:    2,
+		retry:       resilience.RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+	}
+	area := ssurgoArea{symbol: "TX453", name: "Travis County, Texas", version: "2024-09-05 00:00:00"}
+	n, err := s.syncArea(context.Background(), mock, area)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.Len(t, pages, 2)
+	assert.Contains(t, pages[0], "SELECT TOP 2 mupolygonkey")
+	assert.Contains(t, pages[0], "ORDER BY mupolygonkey")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSSURGO_Sync_LoadedQueryError(t *testing.T) {
+	srv := newSDAServer(t, map[string]string{"sacatalog": testSSURGOCatalog})
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectQuery("SELECT areasymbol, survey_version FROM geo.ssurgo_areas").
+		WillReturnError(assert.AnError)
+
+	s := &SSURGO{endpointURL: srv.URL}
+	_, err = s.Sync(context.Background(), mock, nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ssurgo: query loaded areas")
+}
+
+func TestSSURGO_ListAreas_StateFilter(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		query = req.Query
+		_, _ = w.Write([]byte(testSSURGOCatalog))
+	}))
+	defer srv.Close()
+
+	s := &SSURGO{states: []string{"TX", " ok "}, endpointURL: srv.URL}
+	areas, err := s.listAreas(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, query, "AND LEFT(areasymbol, 2) IN ('TX', 'OK')")
+	require.Len(t, areas, 2, "malformed symbols are dropped")
+	assert.Equal(t, ssurgoArea{symbol: "TX021", name: "Bastrop County, Texas", version: "2024-09-03 00:00:00"}, areas[0])
+}
+
+func TestSSURGO_ListAreas_InvalidState(t *testing.T) {
+	s := &SSURGO{states: []string{"TX'"}}
+	_, err := s.listAreas(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid state "TX'"`)
+}
+
+func TestSSURGO_ListAreas_Empty(t *testing.T) {
+	srv := newSDAServer(t, map[string]string{"sacatalog": `{}`})
+	defer srv.Close()
+
+	_, err := (&SSURGO{endpointURL: srv.URL}).listAreas(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no survey areas in catalog")
+}
+
+func TestNewSSURGORow(t *testing.T) {
+	mu := sda.Row{
+		"musym": "HoA", "muname": "Houston Black clay", "farmlndcl": "All areas are prime farmland",
+		"drclassdcd": "Moderately well drained", "hydgrpdcd": "D", "hydclprs": "5",
+		"slopegraddcp": "1.5", "niccdcd": "2", "aws0150wta": "22.5",
+	}
+	row, ok := newSSURGORow("TX453", sda.Row{"mupolygonkey": "9001", "mukey": "1001", "wkt": "POLYGON ((0 0, 1 0, 1 1, 0 0))"}, mu)
+	require.True(t, ok)
+	require.Len(t, row, len(ssurgoCols))
+	assert.Equal(t, "TX453", row[0])
+	assert.Equal(t, "1001", row[1])
+	assert.Equal(t, "9001", row[2])
+	assert.Equal(t, "All areas are prime farmland", row[6])
+	assert.Equal(t, int16(5), row[9])
+	assert.InDelta(t, 1.5, *row[10].(*float64), 1e-9)
+	assert.Equal(t, "SRID=4326;POLYGON ((0 0, 1 0, 1 1, 0 0))", row[13])
+	assert.Equal(t, nrcsSource, row[14])
+
+	// Map unit attributes may be missing entirely.
+	row, ok = newSSURGORow("TX453", sda.Row{"mupolygonkey": "9002", "mukey": "1003", "wkt": "POLYGON ((0 0, 1 0, 1 1, 0 0))"}, nil)
+	require.True(t, ok)
+	assert.Nil(t, row[6])
+	assert.Nil(t, row[9])
+	assert.Nil(t, row[10])
+
+	_, ok = newSSURGORow("TX453", sda.Row{"mupolygonkey": "9003", "mukey": "1001"}, mu)
+	assert.False(t, ok, "missing geometry")
+}
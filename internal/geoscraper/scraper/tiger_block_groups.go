@@ -30,7 +30,9 @@ var blockGroupCols = []string{
 	"source", "source_id", "properties",
 }
 
-// TIGERBlockGroups scrapes Census block group boundaries from TIGER/Line shapefiles.
+// TIGERBlockGroups scrapes Census block group boundaries from TIGER/Line
+// shapefiles into geo.block_groups and the block_group layer of
+// geo.boundaries.
 type TIGERBlockGroups struct {
 	downloadBaseURL string   // override for testing; empty uses census.gov
 	year            int      // override for testing; 0 uses tigerYear
@@ -65,6 +67,7 @@ func (t *TIGERBlockGroups) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fet
 	}
 
 	var totalRows int64
+	var skipped int
 
 	states := tiger.AllStateFIPS()
 	if len(t.stateFIPS) > 0 {
@@ -79,18 +82,26 @@ func (t *TIGERBlockGroups) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fet
 		}
 
 		url := t.buildURL(year, fips)
-		n, err := t.downloadAndLoad(ctx, pool, url, tempDir)
+		n, err := t.downloadAndLoad(ctx, pool, url, year, tempDir)
 		if err != nil {
 			if strings.Contains(err.Error(), "upsert") {
 				return nil, err
 			}
 			log.Warn("block group download failed, skipping state",
 				zap.String("fips", fips), zap.Error(err))
+			skipped++
 			continue
 		}
 		totalRows += n
 	}
 
+	if skipped > 0 {
+		log.Warn("block group layer incomplete, vintage not recorded",
+			zap.Int("vintage", year), zap.Int("states_skipped", skipped))
+	} else if err := recordVintage(ctx, pool, layerBlockGroup, year, totalRows); err != nil {
+		return nil, eris.Wrap(err, "tiger_block_groups")
+	}
+
 	log.Info("TIGER block groups sync complete", zap.Int64("rows", totalRows))
 	return &geoscraper.SyncResult{RowsSynced: totalRows}, nil
 }
@@ -101,7 +112,7 @@ func (t *TIGERBlockGroups) buildURL(year int, fips string) string {
 	return tigerURL(t.downloadBaseURL, year, suffix)
 }
 
-func (t *TIGERBlockGroups) downloadAndLoad(ctx context.Context, pool db.Pool, url, tempDir string) (int64, error) {
+func (t *TIGERBlockGroups) downloadAndLoad(ctx context.Context, pool db.Pool, url string, year int, tempDir string) (int64, error) {
 	shpPath, err := tiger.Download(ctx, url, tempDir)
 	if err != nil {
 		return 0, eris.Wrap(err, "download block groups")
@@ -112,9 +123,10 @@ func (t *TIGERBlockGroups) downloadAndLoad(ctx context.Context, pool db.Pool, ur
 		return 0, eris.Wrap(err, "parse block groups shapefile")
 	}
 	result = filterToProductColumns(result, blockGroupProduct)
+	fields := layerFieldsFor(blockGroupProduct)
 
 	var totalRows int64
-	var batch [][]any
+	var batch, layerBatch [][]any
 
 	flush := func() error {
 		if len(batch) == 0 {
@@ -130,12 +142,16 @@ func (t *TIGERBlockGroups) downloadAndLoad(ctx context.Context, pool db.Pool, ur
 		}
 		totalRows += n
 		batch = batch[:0]
+		if _, uErr := upsertBoundaryLayer(ctx, pool, layerBatch); uErr != nil {
+			return uErr
+		}
+		layerBatch = layerBatch[:0]
 		return nil
 	}
 
 	for _, raw := range result.Rows {
-		row := newBlockGroupRow(raw)
-		batch = append(batch, row)
+		batch = append(batch, newBlockGroupRow(raw))
+		layerBatch = append(layerBatch, fields.row(layerBlockGroup, year, raw))
 		if len(batch) >= tigerBatchSize {
 			if err := flush(); err != nil {
 				return 0, err
@@ -87,10 +87,12 @@ func TestTIGERBlockGroups_Sync(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	// 51 states × 2 rows each = 51 upsert calls.
+	// 2 states × 2 rows each, into geo.block_groups and geo.boundaries.
 	for range 2 {
 		expectBlockGroupUpsert(mock, 2)
+		expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 2)
 	}
+	expectRecordVintage(mock, layerBlockGroup, 4)
 
 	s := &TIGERBlockGroups{downloadBaseURL: srv.URL, year: 2024, stateFIPS: []string{"48", "06"}}
 	result, err := s.Sync(context.Background(), mock, nil, t.TempDir())
@@ -15,8 +15,12 @@ import (
 	"github.com/sells-group/research-cli/internal/tiger"
 )
 
-// TIGERBoundaries scrapes county, place, ZCTA, CBSA, census tract, and
-// congressional district boundaries from Census TIGER/Line shapefiles.
+// TIGERBoundaries scrapes state, county, place, ZCTA, census tract, and
+// congressional district boundaries from Census TIGER/Line shapefiles
+// (CBSAs are loaded with their delineations by CBSADelineations).
+// County, place, and tract features land in their per-layer tables and, with
+// states, in the vintage-keyed geo.boundaries; each fully loaded layer's
+// vintage is recorded in geo.boundary_vintages.
 type TIGERBoundaries struct {
 	downloadBaseURL string // override for testing; empty uses census.gov
 	year            int    // override for testing; 0 uses tigerYear
@@ -54,7 +58,7 @@ func (t *TIGERBoundaries) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetc
 		default:
 		}
 
-		n, err := t.syncBoundary(ctx, pool, def, year, tempDir)
+		n, skipped, err := t.syncBoundary(ctx, pool, def, year, tempDir)
 		if err != nil {
 			return nil, eris.Wrapf(err, "tiger_boundaries: sync %s", def.name)
 		}
@@ -63,6 +67,21 @@ func (t *TIGERBoundaries) Sync(ctx context.Context, pool db.Pool, _ fetcher.Fetc
 			zap.String("boundary", def.name),
 			zap.Int64("rows", n),
 		)
+
+		if def.layer == "" {
+			continue
+		}
+		if skipped > 0 {
+			log.Warn("boundary layer incomplete, vintage not recorded",
+				zap.String("layer", def.layer),
+				zap.Int("vintage", year),
+				zap.Int("states_skipped", skipped),
+			)
+			continue
+		}
+		if err := recordVintage(ctx, pool, def.layer, year, n); err != nil {
+			return nil, eris.Wrapf(err, "tiger_boundaries: sync %s", def.name)
+		}
 	}
 
 	log.Info("TIGER boundaries sync complete", zap.Int64("rows", totalRows))
@@ -76,42 +95,47 @@ func (t *TIGERBoundaries) effectiveYear() int {
 	return tigerYear
 }
 
-func (t *TIGERBoundaries) syncBoundary(ctx context.Context, pool db.Pool, def boundaryDef, year int, tempDir string) (int64, error) {
+// syncBoundary loads one boundary type and returns its row count and the
+// number of per-state files that were skipped.
+func (t *TIGERBoundaries) syncBoundary(ctx context.Context, pool db.Pool, def boundaryDef, year int, tempDir string) (int64, int, error) {
 	if def.national {
-		return t.syncNational(ctx, pool, def, year, tempDir)
+		n, err := t.syncNational(ctx, pool, def, year, tempDir)
+		return n, 0, err
 	}
 	return t.syncPerState(ctx, pool, def, year, tempDir)
 }
 
 func (t *TIGERBoundaries) syncNational(ctx context.Context, pool db.Pool, def boundaryDef, year int, tempDir string) (int64, error) {
 	url := t.buildURL(def, year, "")
-	return t.downloadAndLoad(ctx, pool, def, url, tempDir)
+	return t.downloadAndLoad(ctx, pool, def, url, year, tempDir)
 }
 
-func (t *TIGERBoundaries) syncPerState(ctx context.Context, pool db.Pool, def boundaryDef, year int, tempDir string) (int64, error) {
+func (t *TIGERBoundaries) syncPerState(ctx context.Context, pool db.Pool, def boundaryDef, year int, tempDir string) (int64, int, error) {
 	log := zap.L().With(zap.String("scraper", t.Name()), zap.String("boundary", def.name))
 	var totalRows int64
+	var skipped int
 
 	for _, fips := range tiger.AllStateFIPS() {
 		select {
 		case <-ctx.Done():
-			return 0, ctx.Err()
+			return 0, 0, ctx.Err()
 		default:
 		}
 
 		url := t.buildURL(def, year, fips)
-		n, err := t.downloadAndLoad(ctx, pool, def, url, tempDir)
+		n, err := t.downloadAndLoad(ctx, pool, def, url, year, tempDir)
 		if err != nil {
 			log.Warn("per-state download failed, skipping",
 				zap.String("fips", fips),
 				zap.Error(err),
 			)
+			skipped++
 			continue
 		}
 		totalRows += n
 	}
 
-	return totalRows, nil
+	return totalRows, skipped, nil
 }
 
 func (t *TIGERBoundaries) buildURL(def boundaryDef, year int, fips string) string {
@@ -125,7 +149,10 @@ func (t *TIGERBoundaries) buildURL(def boundaryDef, year int, fips string) strin
 	return tigerURL(t.downloadBaseURL, year, suffix)
 }
 
-func (t *TIGERBoundaries) downloadAndLoad(ctx context.Context, pool db.Pool, def boundaryDef, url, tempDir string) (int64, error) {
+// downloadAndLoad loads one shapefile into def.table and, for layered
+// types, geo.boundaries at vintage year. The row count is def.table's when
+// set, else geo.boundaries'.
+func (t *TIGERBoundaries) downloadAndLoad(ctx context.Context, pool db.Pool, def boundaryDef, url string, year int, tempDir string) (int64, error) {
 	shpPath, err := tiger.Download(ctx, url, tempDir)
 	if err != nil {
 		return 0, eris.Wrapf(err, "download %s", def.name)
@@ -138,30 +165,45 @@ func (t *TIGERBoundaries) downloadAndLoad(ctx context.Context, pool db.Pool, def
 
 	result = filterToProductColumns(result, def.product)
 
+	fields := layerFieldsFor(def.product)
+
 	var totalRows int64
-	var batch [][]any
+	var batch, layerBatch [][]any
 
 	flush := func() error {
-		if len(batch) == 0 {
-			return nil
+		if len(batch) > 0 {
+			n, uErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+				Table:        def.table,
+				Columns:      def.columns,
+				ConflictKeys: []string{def.conflictKey},
+			}, batch)
+			if uErr != nil {
+				return eris.Wrapf(uErr, "%s: upsert batch", def.name)
+			}
+			totalRows += n
+			batch = batch[:0]
 		}
-		n, uErr := db.BulkUpsert(ctx, pool, db.UpsertConfig{
-			Table:        def.table,
-			Columns:      def.columns,
-			ConflictKeys: []string{def.conflictKey},
-		}, batch)
-		if uErr != nil {
-			return eris.Wrapf(uErr, "%s: upsert batch", def.name)
+		if len(layerBatch) > 0 {
+			n, uErr := upsertBoundaryLayer(ctx, pool, layerBatch)
+			if uErr != nil {
+				return uErr
+			}
+			if def.table == "" {
+				totalRows += n
+			}
+			layerBatch = layerBatch[:0]
 		}
-		totalRows += n
-		batch = batch[:0]
 		return nil
 	}
 
 	for _, raw := range result.Rows {
-		row := def.buildRow(raw)
-		batch = append(batch, row)
-		if len(batch) >= tigerBatchSize {
+		if def.table != "" {
+			batch = append(batch, def.buildRow(raw))
+		}
+		if def.layer != "" {
+			layerBatch = append(layerBatch, fields.row(def.layer, year, raw))
+		}
+		if len(batch) >= tigerBatchSize || len(layerBatch) >= tigerBatchSize {
 			if err := flush(); err != nil {
 				return 0, err
 			}
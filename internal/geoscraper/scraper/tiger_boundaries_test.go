@@ -230,21 +230,29 @@ func TestTIGERBoundaries_Sync(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	// 6 boundary types: counties(3), places(3), zcta(3), cbsa(3), tracts(3×51 states), congressional(3).
+	// 6 boundary types: states(3), counties(3), places(3), zcta(3), tracts(3×51 states), congressional(3).
 	// With test override base URL, per-state tracts will all get the same file.
-	// Each boundary type gets one upsert with 3 rows.
+	// Each boundary type gets one upsert with 3 rows; layered types also
+	// upsert geo.boundaries and record their vintage.
+	// states (geo.boundaries only)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 3)
+	expectRecordVintage(mock, layerState, 3)
 	// counties
 	expectBoundaryUpsert(mock, "geo_counties", countyCols, 3)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 3)
+	expectRecordVintage(mock, layerCounty, 3)
 	// places
 	expectBoundaryUpsert(mock, "geo_places", placeCols, 3)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 3)
+	expectRecordVintage(mock, layerPlace, 3)
 	// zcta
 	expectBoundaryUpsert(mock, "geo_zcta", zctaCols, 3)
-	// cbsa
-	expectBoundaryUpsert(mock, "geo_cbsa", cbsaCols, 3)
 	// census_tracts (per-state: 51 states × 3 rows = 51 upserts)
 	for range 51 {
 		expectBoundaryUpsert(mock, "geo_census_tracts", censusTractCols, 3)
+		expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 3)
 	}
+	expectRecordVintage(mock, layerTract, 153)
 	// congressional_districts
 	expectBoundaryUpsert(mock, "geo_congressional_districts", congressionalDistrictCols, 3)
 
@@ -288,11 +296,16 @@ func TestTIGERBoundaries_StateDownloadError(t *testing.T) {
 	defer mock.Close()
 
 	// National boundary types get upserted, per-state tracts all fail → skipped.
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 2)
+	expectRecordVintage(mock, layerState, 2)
 	expectBoundaryUpsert(mock, "geo_counties", countyCols, 2)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 2)
+	expectRecordVintage(mock, layerCounty, 2)
 	expectBoundaryUpsert(mock, "geo_places", placeCols, 2)
+	expectBoundaryUpsert(mock, "geo_boundaries", boundaryLayerCols, 2)
+	expectRecordVintage(mock, layerPlace, 2)
 	expectBoundaryUpsert(mock, "geo_zcta", zctaCols, 2)
-	expectBoundaryUpsert(mock, "geo_cbsa", cbsaCols, 2)
-	// No tract upserts (all states fail)
+	// No tract upserts (all states fail) and no tract vintage.
 	expectBoundaryUpsert(mock, "geo_congressional_districts", congressionalDistrictCols, 2)
 
 	s := &TIGERBoundaries{downloadBaseURL: srv.URL, year: 2024}
@@ -300,6 +313,7 @@ func TestTIGERBoundaries_StateDownloadError(t *testing.T) {
 	require.NoError(t, err)
 	// 5 national × 2 = 10, no tracts.
 	assert.Equal(t, int64(10), result.RowsSynced)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestTIGERBoundaries_DownloadError(t *testing.T) {
@@ -431,6 +445,12 @@ func expectBoundaryUpsert(mock pgxmock.PgxPoolIface, tempTable string, cols []st
 	mock.ExpectCommit()
 }
 
+func expectRecordVintage(mock pgxmock.PgxPoolIface, layer string, rows int64) {
+	mock.ExpectExec("INSERT INTO geo.boundary_vintages").
+		WithArgs(layer, int16(2024), rows).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+}
+
 // createTestBoundaryShapefile creates a polygon shapefile with n features and the
 // given column names, zips it, and returns the path to the ZIP file.
 func createTestBoundaryShapefile(t *testing.T, shpType shp.ShapeType, columns []string, n int) string {
@@ -67,7 +67,8 @@ const tigerBaseURL = "https://www2.census.gov/geo/tiger/TIGER%d"
 // boundaryDef defines a single TIGER boundary type for the boundaries scraper.
 type boundaryDef struct {
 	name        string // for logging
-	table       string // target table (e.g., "geo.counties")
+	table       string // target table (e.g., "geo.counties"); empty loads only geo.boundaries
+	layer       string // geo.boundaries layer; empty skips the unified table
 	product     tiger.Product
 	conflictKey string // single natural key column for ON CONFLICT
 	national    bool   // true = single national file, false = per-state
@@ -84,18 +85,39 @@ func tigerURL(baseOverride string, year int, pathSuffix string) string {
 	return base + "/" + pathSuffix
 }
 
-// boundaryDefs returns the six boundary definitions for the TIGER boundaries scraper.
+// boundaryDefs returns the boundary definitions for the TIGER boundaries
+// scraper. CBSAs are loaded by CBSADelineations.
 func boundaryDefs() []boundaryDef {
 	return []boundaryDef{
+		stateDef(),
 		countyDef(),
 		placeDef(),
 		zctaDef(),
-		cbsaDef(),
 		censusTractDef(),
 		congressionalDistrictDef(),
 	}
 }
 
+// --- State ---
+
+var stateProduct = tiger.Product{
+	Name:     "STATE",
+	Table:    "state",
+	Columns:  []string{"region", "division", "statefp", "statens", "geoid", "stusps", "name", "lsad", "mtfcc", "funcstat", "aland", "awater", "intptlat", "intptlon"},
+	GeomType: "MULTIPOLYGON",
+}
+
+// stateDef loads states into geo.boundaries only; there is no per-layer
+// state table.
+func stateDef() boundaryDef {
+	return boundaryDef{
+		name:     "states",
+		product:  stateProduct,
+		layer:    layerState,
+		national: true,
+	}
+}
+
 // --- County ---
 
 var countyProduct = tiger.Product{
@@ -115,6 +137,7 @@ func countyDef() boundaryDef {
 	return boundaryDef{
 		name:        "counties",
 		table:       "geo.counties",
+		layer:       layerCounty,
 		product:     countyProduct,
 		conflictKey: "geoid",
 		national:    true,
@@ -167,6 +190,7 @@ func placeDef() boundaryDef {
 	return boundaryDef{
 		name:        "places",
 		table:       "geo.places",
+		layer:       layerPlace,
 		product:     placeProduct,
 		conflictKey: "geoid",
 		national:    true,
@@ -276,6 +300,7 @@ func cbsaDef() boundaryDef {
 	return boundaryDef{
 		name:        "cbsa",
 		table:       "geo.cbsa",
+		layer:       layerCBSA,
 		product:     cbsaProduct,
 		conflictKey: "cbsa_code",
 		national:    true,
@@ -325,6 +350,7 @@ func censusTractDef() boundaryDef {
 	return boundaryDef{
 		name:        "census_tracts",
 		table:       "geo.census_tracts",
+		layer:       layerTract,
 		product:     censusTractProduct,
 		conflictKey: "geoid",
 		national:    false,
@@ -496,7 +522,7 @@ func wkbToWGS84(v any) []byte {
 
 // strVal safely extracts a string from a parsed shapefile row.
 func strVal(raw []any, idx int) string {
-	if idx >= len(raw) || raw[idx] == nil {
+	if idx < 0 || idx >= len(raw) || raw[idx] == nil {
 		return ""
 	}
 	s, ok := raw[idx].(string)
@@ -0,0 +1,116 @@
+package scraper
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/tiger"
+)
+
+// geo.boundaries layers loaded from TIGER/Line.
+const (
+	layerState      = "state"
+	layerCounty     = "county"
+	layerTract      = "tract"
+	layerBlockGroup = "block_group"
+	layerPlace      = "place"
+	layerCBSA       = "cbsa"
+)
+
+// boundaryLayerTable is the unified, vintage-keyed boundary table.
+const boundaryLayerTable = "geo.boundaries"
+
+// boundaryLayerCols are the columns written to geo.boundaries.
+var boundaryLayerCols = []string{
+	"layer", "geoid", "vintage", "state_fips", "name",
+	"aland", "awater", "geom", "latitude", "longitude", "source",
+}
+
+var boundaryLayerKeys = []string{"layer", "geoid", "vintage"}
+
+// layerFields locates the geo.boundaries fields in a product's filtered
+// row (product.Columns followed by the geometry). Fields a product lacks
+// are -1.
+type layerFields struct {
+	geoid, state, name, nameLSAD, aland, awater, lat, lon, geom int
+}
+
+func layerFieldsFor(p tiger.Product) layerFields {
+	idx := make(map[string]int, len(p.Columns))
+	for i, c := range p.Columns {
+		idx[c] = i
+	}
+	find := func(names ...string) int {
+		for _, n := range names {
+			if i, ok := idx[n]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	return layerFields{
+		geoid:    find("geoid", "geoid20", "cbsafp"),
+		state:    find("statefp", "statefp20"),
+		name:     find("name", "name20"),
+		nameLSAD: find("namelsad", "namelsad20"),
+		aland:    find("aland", "aland20"),
+		awater:   find("awater", "awater20"),
+		lat:      find("intptlat", "intptlat20"),
+		lon:      find("intptlon", "intptlon20"),
+		geom:     len(p.Columns),
+	}
+}
+
+// row builds a geo.boundaries row from a filtered shapefile row. Names
+// fall back to the legal/statistical name (e.g., "Block Group 1") for
+// layers without a NAME field; geometries are rewritten to SRID 4326.
+func (f layerFields) row(layer string, vintage int, raw []any) []any {
+	name := strVal(raw, f.name)
+	if name == "" {
+		name = strVal(raw, f.nameLSAD)
+	}
+	lat, lon := parseLatLon(raw, f.lat, f.lon)
+	var geom []byte
+	if f.geom < len(raw) {
+		geom = wkbToWGS84(raw[f.geom])
+	}
+	return []any{
+		layer,
+		strVal(raw, f.geoid),
+		int16(vintage), // #nosec G115 -- TIGER vintages are four-digit years
+		strVal(raw, f.state),
+		name,
+		parseInt64Val(raw, f.aland),
+		parseInt64Val(raw, f.awater),
+		geom,
+		lat, lon,
+		tigerGeoSource,
+	}
+}
+
+// upsertBoundaryLayer writes a batch of geo.boundaries rows.
+func upsertBoundaryLayer(ctx context.Context, pool db.Pool, rows [][]any) (int64, error) {
+	n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+		Table:        boundaryLayerTable,
+		Columns:      boundaryLayerCols,
+		ConflictKeys: boundaryLayerKeys,
+	}, rows)
+	return n, eris.Wrap(err, "boundaries: upsert batch")
+}
+
+const recordVintageSQL = `INSERT INTO geo.boundary_vintages (layer, vintage, row_count, loaded_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (layer, vintage) DO UPDATE SET row_count = EXCLUDED.row_count, loaded_at = EXCLUDED.loaded_at`
+
+// recordVintage marks a layer's vintage as fully loaded, making it the
+// layer's current vintage in geo.current_boundaries. Layers that loaded no
+// rows are not recorded, so a failed release never replaces a good one.
+func recordVintage(ctx context.Context, pool db.Pool, layer string, vintage int, rows int64) error {
+	if rows == 0 {
+		return nil
+	}
+	_, err := pool.Exec(ctx, recordVintageSQL, layer, int16(vintage), rows) // #nosec G115 -- TIGER vintages are four-digit years
+	return eris.Wrapf(err, "boundaries: record %s vintage %d", layer, vintage)
+}
@@ -0,0 +1,74 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayerFields_County(t *testing.T) {
+	// EWKB NDR MultiPolygon header with SRID flag and SRID 4269.
+	wkb := []byte{0x01, 0x06, 0x00, 0x00, 0x20, 0xAD, 0x10, 0x00, 0x00}
+	raw := []any{
+		"48", "453", "48453", "Travis", "Travis County", "06", "G4020", "A",
+		"2546422", "18993", "30.3340", "-97.7715", wkb,
+	}
+
+	row := layerFieldsFor(countyProduct).row(layerCounty, 2024, raw)
+	require.Len(t, row, len(boundaryLayerCols))
+	assert.Equal(t, layerCounty, row[0])
+	assert.Equal(t, "48453", row[1]) // geoid
+	assert.Equal(t, int16(2024), row[2])
+	assert.Equal(t, "48", row[3])     // state_fips
+	assert.Equal(t, "Travis", row[4]) // name
+	assert.Equal(t, int64(2546422), *row[5].(*int64))
+	assert.Equal(t, int64(18993), *row[6].(*int64))
+	assert.Equal(t, []byte{0x01, 0x06, 0x00, 0x00, 0x20, 0xE6, 0x10, 0x00, 0x00}, row[7], "SRID rewritten to 4326")
+	assert.InDelta(t, 30.334, row[8], 0.001)
+	assert.InDelta(t, -97.772, row[9], 0.001)
+	assert.Equal(t, tigerGeoSource, row[10])
+}
+
+func TestLayerFields_BlockGroupNameFallback(t *testing.T) {
+	raw := []any{
+		"48", "453", "002100", "1", "484530021001", "Block Group 1", "G5030", "S",
+		"1000000", "", "30.29", "-97.74", nil,
+	}
+
+	row := layerFieldsFor(blockGroupProduct).row(layerBlockGroup, 2024, raw)
+	assert.Equal(t, "484530021001", row[1])
+	assert.Equal(t, "Block Group 1", row[4], "falls back to namelsad")
+	assert.Nil(t, row[6], "empty awater")
+	assert.Nil(t, row[7], "missing geometry")
+}
+
+func TestLayerFields_MissingFields(t *testing.T) {
+	// CBSAs key on CBSAFP and carry no state.
+	f := layerFieldsFor(cbsaProduct)
+	assert.Equal(t, 0, f.geoid)
+	assert.Equal(t, -1, f.state)
+	assert.Equal(t, len(cbsaProduct.Columns), f.geom)
+}
+
+func TestRecordVintage(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectRecordVintage(mock, layerState, 56)
+	require.NoError(t, recordVintage(context.Background(), mock, layerState, 2024, 56))
+
+	// Empty loads are not recorded.
+	require.NoError(t, recordVintage(context.Background(), mock, layerState, 2024, 0))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectExec("INSERT INTO geo.boundary_vintages").
+		WithArgs(layerTract, int16(2024), int64(1)).
+		WillReturnError(assert.AnError)
+	err = recordVintage(context.Background(), mock, layerTract, 2024, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record tract vintage 2024")
+}
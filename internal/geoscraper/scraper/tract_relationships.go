@@ -0,0 +1,170 @@
+package scraper
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// tractRelationshipsURL is the Census 2020-to-2010 tract relationship file,
+// pipe-delimited with a header row.
+const tractRelationshipsURL = "https://www2.census.gov/geo/docs/maps-data/data/rel2020/tract/tab20_tract20_tract10_natl.txt"
+
+// tractRelationshipCols are the columns written to geo.tract_relationships.
+var tractRelationshipCols = []string{"tract_2020", "tract_2010", "arealand_part", "arealand_2020", "synced_at"}
+
+var tractRelationshipConflictKeys = []string{"tract_2020", "tract_2010"}
+
+// TIGERTractRelationships loads the Census 2020-to-2010 tract relationship
+// file into geo.tract_relationships, so lookups against tract lists still
+// keyed by 2010 geoids (QOZ designations, RUCA codes) can start from the
+// 2020 tracts in geo.census_tracts.
+type TIGERTractRelationships struct {
+	downloadURL string // override for testing; empty uses tractRelationshipsURL
+}
+
+// Name implements GeoScraper.
+func (s *TIGERTractRelationships) Name() string { return "tract_relationships" }
+
+// Table implements GeoScraper.
+func (s *TIGERTractRelationships) Table() string { return "geo.tract_relationships" }
+
+// Category implements GeoScraper.
+func (s *TIGERTractRelationships) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *TIGERTractRelationships) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper. The file is fixed for the decade; the
+// annual check picks up Census corrections.
+func (s *TIGERTractRelationships) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.January)
+}
+
+// Sync implements GeoScraper.
+func (s *TIGERTractRelationships) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting tract relationship sync")
+
+	url := s.downloadURL
+	if url == "" {
+		url = tractRelationshipsURL
+	}
+	path := filepath.Join(tempDir, "tract_relationships.txt")
+	if _, err := f.DownloadToFile(ctx, url, path); err != nil {
+		return nil, eris.Wrap(err, "tract_relationships: download")
+	}
+	file, err := os.Open(path) // #nosec G304 -- path built from tempDir
+	if err != nil {
+		return nil, eris.Wrap(err, "tract_relationships: open file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	now := time.Now().UTC()
+	var total int64
+	flush := func(rows [][]any) error {
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        s.Table(),
+			Columns:      tractRelationshipCols,
+			ConflictKeys: tractRelationshipConflictKeys,
+		}, rows)
+		if err != nil {
+			return eris.Wrap(err, "tract_relationships: upsert batch")
+		}
+		total += n
+		return nil
+	}
+	if err := parseTractRelationships(file, now, hifldBatchSize, flush); err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, eris.New("tract_relationships: no relationships")
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.tract_relationships WHERE synced_at < $1`, now); err != nil {
+		return nil, eris.Wrap(err, "tract_relationships: prune")
+	}
+
+	log.Info("tract relationship sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{RowsSynced: total}, nil
+}
+
+// parseTractRelationships reads the pipe-delimited relationship file and
+// passes rows to flush in batches of batchSize. Rows without both tract
+// geoids are skipped.
+func parseTractRelationships(r io.Reader, now time.Time, batchSize int, flush func([][]any) error) error {
+	cr := csv.NewReader(r)
+	cr.Comma = '|'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return eris.Wrap(err, "tract_relationships: read header")
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	idx := csvColIndex(header)
+	col := func(name string) int {
+		if i, ok := idx[name]; ok {
+			return i
+		}
+		return -1
+	}
+	var (
+		tract20Col  = col("GEOID_TRACT_20")
+		tract10Col  = col("GEOID_TRACT_10")
+		partCol     = col("AREALAND_PART")
+		area2020Col = col("AREALAND_TRACT_20")
+	)
+	if tract20Col < 0 || tract10Col < 0 {
+		return eris.New("tract_relationships: header has no tract geoid columns")
+	}
+
+	batch := make([][]any, 0, batchSize)
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return eris.Wrap(err, "tract_relationships: read row")
+		}
+		tract20, tract10 := csvString(rec, tract20Col), csvString(rec, tract10Col)
+		if len(tract20) != 11 || len(tract10) != 11 || !isDigits(tract20) || !isDigits(tract10) {
+			continue
+		}
+		part, _ := strconv.ParseInt(csvString(rec, partCol), 10, 64)
+		var area2020 *int64
+		if v, err := strconv.ParseInt(csvString(rec, area2020Col), 10, 64); err == nil {
+			area2020 = &v
+		}
+		batch = append(batch, []any{tract20, tract10, part, area2020, now})
+		if len(batch) == batchSize {
+			if err := flush(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		return flush(batch)
+	}
+	return nil
+}
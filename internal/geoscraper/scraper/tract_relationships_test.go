@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+const testTractRelationships = "\ufeffOID_TRACT_20|GEOID_TRACT_20|NAMELSAD_TRACT_20|AREALAND_TRACT_20|AREAWATER_TRACT_20|MTFCC_TRACT_20|FUNCSTAT_TRACT_20|OID_TRACT_10|GEOID_TRACT_10|NAMELSAD_TRACT_10|AREALAND_TRACT_10|AREAWATER_TRACT_10|MTFCC_TRACT_10|FUNCSTAT_TRACT_10|AREALAND_PART|AREAWATER_PART\n" +
+	"207...|01001020100|Census Tract 201|9825304|28435|G5020|S|207...|01001020100|Census Tract 201|9827271|28435|G5020|S|9825304|28435\n" +
+	"207...|48113019205|Census Tract 192.05|1520000|0|G5020|S|207...|48113019201|Census Tract 192.01|3100000|0|G5020|S|1400000|0\n" +
+	"207...|48113019205|Census Tract 192.05|1520000|0|G5020|S|207...|48113019202|Census Tract 192.02|2900000|0|G5020|S|120000|0\n" +
+	"207...||||||||48113019300|Census Tract 193|100|0|G5020|S|0|0\n"
+
+func TestTIGERTractRelationships_Metadata(t *testing.T) {
+	s := &TIGERTractRelationships{}
+	assert.Equal(t, "tract_relationships", s.Name())
+	assert.Equal(t, "geo.tract_relationships", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestTIGERTractRelationships_Sync(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testTractRelationships))
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_tract_relationships", tractRelationshipCols, 3)
+	mock.ExpectExec("DELETE FROM geo.tract_relationships WHERE synced_at").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	s := &TIGERTractRelationships{downloadURL: srv.URL + "/rel.txt"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseTractRelationships(t *testing.T) {
+	now := time.Now()
+	var batches [][][]any
+	err := parseTractRelationships(strings.NewReader(testTractRelationships), now, 2, func(rows [][]any) error {
+		batches = append(batches, append([][]any(nil), rows...))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A 2010 tract with no 2020 counterpart is skipped.
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[1], 1)
+	assert.Equal(t, []any{"01001020100", "01001020100", int64(9825304), ptrInt64(9825304), now}, batches[0][0])
+	assert.Equal(t, "48113019205", batches[0][1][0])
+	assert.Equal(t, "48113019201", batches[0][1][1])
+	assert.Equal(t, int64(1400000), batches[0][1][2])
+	assert.Equal(t, "48113019202", batches[1][0][1])
+}
+
+func TestParseTractRelationships_NoHeader(t *testing.T) {
+	err := parseTractRelationships(strings.NewReader("a|b\n1|2\n"), time.Now(), 10, func([][]any) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no tract geoid columns")
+}
+
+func ptrInt64(v int64) *int64 { return &v }
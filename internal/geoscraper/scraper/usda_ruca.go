@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/tealeg/xlsx/v2"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// rucaURL is the USDA ERS 2010 tract RUCA workbook (revised 7/3/2019).
+const rucaURL = "https://www.ers.usda.gov/webdocs/DataFiles/53241/ruca2010revised.xlsx"
+
+// rucaTractVintage is the census tract vintage of the RUCA geoids.
+const rucaTractVintage = 2010
+
+// rucaCols are the columns written to geo.ruca.
+var rucaCols = []string{
+	"geoid", "state_fips", "county_fips", "primary_ruca", "secondary_ruca",
+	"population", "land_area_sqmi", "pop_density", "tract_vintage", "source", "synced_at",
+}
+
+var rucaConflictKeys = []string{"geoid"}
+
+// USDARUCA loads USDA ERS Rural-Urban Commuting Area codes by census tract
+// into geo.ruca. The codes use 2010 tract geoids; the pipeline reaches them
+// from the 2020 tracts in geo.census_tracts through geo.tract_relationships
+// to refine the urban classification.
+type USDARUCA struct {
+	downloadURL string // override for testing; empty uses rucaURL
+}
+
+// Name implements GeoScraper.
+func (s *USDARUCA) Name() string { return "usda_ruca" }
+
+// Table implements GeoScraper.
+func (s *USDARUCA) Table() string { return "geo.ruca" }
+
+// Category implements GeoScraper.
+func (s *USDARUCA) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *USDARUCA) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper. RUCA codes change only with a new
+// decennial release; the annual check picks up revisions.
+func (s *USDARUCA) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.January)
+}
+
+// Sync implements GeoScraper.
+func (s *USDARUCA) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting RUCA sync")
+
+	url := s.downloadURL
+	if url == "" {
+		url = rucaURL
+	}
+	xlsxPath := filepath.Join(tempDir, "ruca.xlsx")
+	if _, err := f.DownloadToFile(ctx, url, xlsxPath); err != nil {
+		return nil, eris.Wrap(err, "usda_ruca: download")
+	}
+	xlFile, err := xlsx.OpenFile(xlsxPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "usda_ruca: open xlsx")
+	}
+
+	// The data is on the first sheet with a tract header; the workbook
+	// also carries code descriptions and errata sheets.
+	now := time.Now().UTC()
+	var rows [][]any
+	err = eris.New("usda_ruca: workbook has no sheets")
+	for _, sheet := range xlFile.Sheets {
+		if rows, err = parseRUCASheet(sheet, now); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += hifldBatchSize {
+		end := min(start+hifldBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        s.Table(),
+			Columns:      rucaCols,
+			ConflictKeys: rucaConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return nil, eris.Wrap(err, "usda_ruca: upsert batch")
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, `DELETE FROM geo.ruca WHERE synced_at < $1`, now); err != nil {
+		return nil, eris.Wrap(err, "usda_ruca: prune")
+	}
+
+	log.Info("RUCA sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{
+		RowsSynced: total,
+		Metadata:   map[string]any{"tract_vintage": rucaTractVintage},
+	}, nil
+}
+
+// parseRUCASheet extracts tract RUCA codes. The header row is the first
+// naming a tract FIPS column; title rows may sit above it.
+func parseRUCASheet(sheet *xlsx.Sheet, now time.Time) ([][]any, error) {
+	headerIdx := -1
+	var header *xlsx.Row
+	for i, row := range sheet.Rows {
+		if xlsxFindCol(row, "tract fips") >= 0 {
+			headerIdx, header = i, row
+			break
+		}
+	}
+	if header == nil {
+		return nil, eris.New("usda_ruca: header row not found")
+	}
+	var (
+		geoidCol     = xlsxFindCol(header, "tract fips")
+		primaryCol   = xlsxFindCol(header, "primary ruca")
+		secondaryCol = xlsxFindCol(header, "secondary ruca")
+		popCol       = xlsxFindCol(header, "tract population")
+		areaCol      = xlsxFindCol(header, "land area")
+		densityCol   = xlsxFindCol(header, "population density")
+	)
+	if primaryCol < 0 {
+		return nil, eris.New("usda_ruca: sheet has no primary RUCA column")
+	}
+
+	var rows [][]any
+	for _, row := range sheet.Rows[headerIdx+1:] {
+		geoid, ok := incentiveGeoid(xlsxString(row, geoidCol))
+		if !ok {
+			continue
+		}
+		primary := parseFloatOrNil(xlsxString(row, primaryCol))
+		if primary == nil {
+			continue
+		}
+		var pop *int
+		if p := parseFloatOrNil(xlsxString(row, popCol)); p != nil {
+			n := int(*p)
+			pop = &n
+		}
+		rows = append(rows, []any{
+			geoid, geoid[:2], geoid[:5], int16(*primary),
+			parseFloatOrNil(xlsxString(row, secondaryCol)), pop,
+			parseFloatOrNil(xlsxString(row, areaCol)), parseFloatOrNil(xlsxString(row, densityCol)),
+			int16(rucaTractVintage), "usda_ers", now,
+		})
+	}
+	if len(rows) == 0 {
+		return nil, eris.New("usda_ruca: no tracts")
+	}
+	return rows, nil
+}
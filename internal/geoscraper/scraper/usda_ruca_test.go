@@ -0,0 +1,99 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+var (
+	testRUCAHeader = []string{
+		"State-County FIPS Code", "Select State", "Select County",
+		"State-County-Tract FIPS Code (lookup by address at http://www.ffiec.gov/Geocode/)",
+		"Primary RUCA Code 2010", "Secondary RUCA Code, 2010 (see errata)",
+		"Tract Population, 2010", "Land Area (square miles), 2010", "Population Density (per square mile), 2010",
+	}
+	testRUCARows = [][]string{
+		{"01001", "AL", "Autauga County", "1001020100", "2", "2.0", "1912", "3.79", "504.6"},
+		{"48113", "TX", "Dallas County", "48113002100", "1", "1.0", "4211", "0.62", "6791.9"},
+		{"48113", "TX", "Dallas County", "48113980000", "", "", "0", "1.2", "0"},
+	}
+)
+
+func TestUSDARUCA_Metadata(t *testing.T) {
+	s := &USDARUCA{}
+	assert.Equal(t, "usda_ruca", s.Name())
+	assert.Equal(t, "geo.ruca", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestUSDARUCA_Sync(t *testing.T) {
+	data := buildFMRXLSX(t, testRUCAHeader, testRUCARows)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_ruca", rucaCols, 2)
+	mock.ExpectExec("DELETE FROM geo.ruca WHERE synced_at").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	s := &USDARUCA{downloadURL: srv.URL + "/ruca.xlsx"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.RowsSynced)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseRUCASheet(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, testRUCAHeader, testRUCARows))
+	require.NoError(t, err)
+
+	now := time.Now()
+	rows, err := parseRUCASheet(xlFile.Sheets[0], now)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	// Leading zero restored; tract without a code skipped.
+	assert.Equal(t, "01001020100", rows[0][0])
+	assert.Equal(t, "01", rows[0][1])
+	assert.Equal(t, "01001", rows[0][2])
+	assert.Equal(t, int16(2), rows[0][3])
+	assert.Equal(t, 2.0, *rows[0][4].(*float64))
+	assert.Equal(t, 1912, *rows[0][5].(*int))
+
+	assert.Equal(t, "48113002100", rows[1][0])
+	assert.Equal(t, int16(1), rows[1][3])
+	assert.InDelta(t, 6791.9, *rows[1][7].(*float64), 0.01)
+	assert.Equal(t, int16(2010), rows[1][8])
+	assert.Equal(t, "usda_ers", rows[1][9])
+}
+
+func TestParseRUCASheet_NoHeader(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, []string{"Code", "Description"}, [][]string{{"1", "Metropolitan core"}}))
+	require.NoError(t, err)
+	_, err = parseRUCASheet(xlFile.Sheets[0], time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "header row not found")
+}
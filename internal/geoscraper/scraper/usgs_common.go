@@ -18,6 +18,12 @@ const nhdBaseURL = "https://hydro.nationalmap.gov/arcgis/rest/services/nhd/MapSe
 // coalMinesBaseURL is the EIA/MSHA coal mines FeatureServer endpoint.
 const coalMinesBaseURL = "https://services2.arcgis.com/FiaPA4ga0iQKduv3/arcgis/rest/services/Surface_and_Underground_Coal_Mines_in_the_US/FeatureServer/0/query"
 
+// seismicDesignBaseURL is the USGS seismic design category FeatureServer endpoint.
+const seismicDesignBaseURL = "https://services.arcgis.com/v01gqwM5QqNysAAi/arcgis/rest/services/Seismic_Design_Categories/FeatureServer/0/query"
+
+// landslideBaseURL is the USGS landslide incidence and susceptibility FeatureServer endpoint.
+const landslideBaseURL = "https://services.arcgis.com/v01gqwM5QqNysAAi/arcgis/rest/services/Landslide_Incidence_and_Susceptibility/FeatureServer/0/query"
+
 // earthquakeBaseURL is the USGS FDSN Event Web Service endpoint.
 const earthquakeBaseURL = "https://earthquake.usgs.gov/fdsnws/event/1/query"
 
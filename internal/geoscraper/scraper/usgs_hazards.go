@@ -0,0 +1,278 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
+)
+
+// Hazard identifiers stored in geo.usgs_hazards.hazard.
+const (
+	hazardSeismic   = "seismic_design_category"
+	hazardLandslide = "landslide_susceptibility"
+)
+
+// hazardCols are the columns written to the temp table for hazard loads.
+// geom_wkt is TEXT in the temp table; converted to geometry via
+// ST_GeomFromEWKT.
+var hazardCols = []string{
+	"hazard", "hazard_class", "severity", "label", "geom_wkt",
+	"source", "source_id", "properties",
+}
+
+// hazardLayer is one USGS hazard polygon product and how its class field
+// maps to a normalized class and severity.
+type hazardLayer struct {
+	hazard     string
+	baseURL    string
+	classField string
+	classify   func(raw string) (class, label string, severity int16, ok bool)
+}
+
+// usgsHazardLayers returns the hazard layers, with base URLs taken from
+// overrides when set.
+func usgsHazardLayers(overrides map[string]string) []hazardLayer {
+	layers := []hazardLayer{
+		{hazard: hazardSeismic, baseURL: seismicDesignBaseURL, classField: "SDC", classify: classifySeismicDesignCategory},
+		{hazard: hazardLandslide, baseURL: landslideBaseURL, classField: "LANDSLIDE", classify: classifyLandslide},
+	}
+	for i := range layers {
+		layers[i].baseURL = usgsURL(overrides[layers[i].hazard], layers[i].baseURL)
+	}
+	return layers
+}
+
+// classifySeismicDesignCategory normalizes an ASCE 7 / IBC seismic design
+// category (A-F, with IBC's D0-D2 subdivisions kept) and ranks it A=1
+// through F=6.
+func classifySeismicDesignCategory(raw string) (class, label string, severity int16, ok bool) {
+	class = strings.ToUpper(strings.TrimSpace(raw))
+	class = strings.TrimPrefix(class, "SDC ")
+	if class == "" || class[0] < 'A' || class[0] > 'F' {
+		return "", "", 0, false
+	}
+	if len(class) > 1 && !(class[0] == 'D' && len(class) == 2 && class[1] >= '0' && class[1] <= '2') {
+		return "", "", 0, false
+	}
+	return class, "Seismic Design Category " + class, int16(class[0]-'A') + 1, true
+}
+
+// classifyLandslide maps a USGS landslide overview code to low, moderate,
+// or high. Single-letter codes give incidence (L, M, H); two-letter codes
+// give susceptibility then incidence (ML, HL, HM). The leading letter is
+// the worse of the two, so it sets the class.
+func classifyLandslide(raw string) (class, label string, severity int16, ok bool) {
+	code := strings.ToUpper(strings.TrimSpace(raw))
+	if code == "" || len(code) > 2 {
+		return "", "", 0, false
+	}
+	switch code[0] {
+	case 'H':
+		class, severity = "high", 3
+	case 'M':
+		class, severity = "moderate", 2
+	case 'L':
+		class, severity = "low", 1
+	default:
+		return "", "", 0, false
+	}
+	if len(code) == 2 {
+		label = fmt.Sprintf("%s susceptibility, %s incidence", landslideLevel(code[0]), landslideLevel(code[1]))
+	} else {
+		label = fmt.Sprintf("%s incidence", landslideLevel(code[0]))
+	}
+	return class, label, severity, true
+}
+
+func landslideLevel(c byte) string {
+	switch c {
+	case 'H':
+		return "High"
+	case 'M':
+		return "Moderate"
+	default:
+		return "Low"
+	}
+}
+
+// USGSHazards loads USGS seismic design category and landslide
+// susceptibility polygons into geo.usgs_hazards. Each hazard is replaced
+// as a whole. After a sync it tags geocoded company addresses with the
+// hazard classes they fall in, as the FEMA flood scrapers do for flood
+// zones.
+type USGSHazards struct {
+	baseURLs map[string]string // hazard -> layer URL override for testing
+}
+
+// Name implements GeoScraper.
+func (s *USGSHazards) Name() string { return "usgs_hazards" }
+
+// Table implements GeoScraper.
+func (s *USGSHazards) Table() string { return "geo.usgs_hazards" }
+
+// Category implements GeoScraper.
+func (s *USGSHazards) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *USGSHazards) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper.
+func (s *USGSHazards) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.January)
+}
+
+// PostSyncHooks implements postsync.Declarer: geocoded company addresses
+// are tagged with their seismic design category and landslide
+// susceptibility.
+func (s *USGSHazards) PostSyncHooks() []string { return []string{postsync.HazardTag} }
+
+// Sync implements GeoScraper.
+func (s *USGSHazards) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, _ string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting USGS hazards sync")
+
+	var total int64
+	counts := make(map[string]any)
+	for _, layer := range usgsHazardLayers(s.baseURLs) {
+		rows, err := fetchHazardLayer(ctx, f, layer)
+		if err != nil {
+			return nil, err
+		}
+		n, err := replaceHazard(ctx, pool, layer.hazard, rows)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("hazard layer loaded", zap.String("hazard", layer.hazard), zap.Int64("rows", n))
+		counts[layer.hazard] = n
+		total += n
+	}
+
+	log.Info("USGS hazards sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{RowsSynced: total, Metadata: counts}, nil
+}
+
+// fetchHazardLayer queries every polygon of a hazard layer and builds temp
+// table rows. Features with an unrecognized class or no geometry are
+// skipped.
+func fetchHazardLayer(ctx context.Context, f fetcher.Fetcher, layer hazardLayer) ([][]any, error) {
+	var (
+		rows    [][]any
+		skipped int
+	)
+	err := arcgis.QueryAll(ctx, f, arcgis.QueryConfig{
+		BaseURL:      layer.baseURL,
+		OutFields:    []string{"*"},
+		AutoPageSize: true,
+	}, func(features []arcgis.Feature) error {
+		for _, feat := range features {
+			row, ok := newHazardRow(layer, feat)
+			if !ok {
+				skipped++
+				continue
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "usgs_hazards: query %s", layer.hazard)
+	}
+	if skipped > 0 {
+		zap.L().Debug("skipped hazard features", zap.String("hazard", layer.hazard), zap.Int("skipped", skipped))
+	}
+	if len(rows) == 0 {
+		return nil, eris.Errorf("usgs_hazards: no %s polygons", layer.hazard)
+	}
+	return rows, nil
+}
+
+// newHazardRow builds a temp table row from a hazard feature.
+func newHazardRow(layer hazardLayer, feat arcgis.Feature) ([]any, bool) {
+	if feat.Geometry == nil || len(feat.Geometry.Rings) == 0 {
+		return nil, false
+	}
+	raw := hifldAttrString(feat.Attributes, layer.classField)
+	class, label, severity, ok := layer.classify(raw)
+	if !ok {
+		return nil, false
+	}
+	oid := hifldAttrString(feat.Attributes, "OBJECTID")
+	if oid == "" {
+		return nil, false
+	}
+	props, _ := json.Marshal(map[string]any{layer.classField: raw})
+	return []any{
+		layer.hazard,
+		class,
+		severity,
+		label,
+		feat.Geometry.EWKT(),
+		usgsSource,
+		layer.hazard + "/" + oid,
+		props,
+	}, true
+}
+
+// replaceHazard swaps every polygon of one hazard in a single transaction,
+// converting EWKT geometry via ST_GeomFromEWKT.
+func replaceHazard(ctx context.Context, pool db.Pool, hazard string, rows [][]any) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: begin tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is no-op
+
+	createSQL := `CREATE TEMP TABLE _tmp_usgs_hazards (
+		hazard       TEXT,
+		hazard_class TEXT,
+		severity     SMALLINT,
+		label        TEXT,
+		geom_wkt     TEXT,
+		source       TEXT,
+		source_id    TEXT,
+		properties   JSONB
+	) ON COMMIT DROP`
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: create temp table")
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"_tmp_usgs_hazards"}, hazardCols, pgx.CopyFromRows(rows)); err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: COPY into temp table")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM geo.usgs_hazards WHERE hazard = $1`, hazard); err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: delete hazard")
+	}
+
+	tag, err := tx.Exec(ctx, `INSERT INTO geo.usgs_hazards (hazard, hazard_class, severity, label, geom, source, source_id, properties)
+		SELECT hazard, hazard_class, severity, label, ST_Multi(ST_GeomFromEWKT(geom_wkt)), source, source_id, properties
+		FROM _tmp_usgs_hazards
+		ON CONFLICT (source, source_id) DO UPDATE SET
+			hazard_class = EXCLUDED.hazard_class,
+			severity     = EXCLUDED.severity,
+			label        = EXCLUDED.label,
+			geom         = EXCLUDED.geom,
+			properties   = EXCLUDED.properties,
+			updated_at   = now()`)
+	if err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: INSERT ON CONFLICT")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: commit tx")
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,184 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+	"github.com/sells-group/research-cli/internal/geoscraper/arcgis"
+	"github.com/sells-group/research-cli/internal/postsync"
+)
+
+const (
+	testSDCFeatures = `{"features": [
+		{"attributes": {"OBJECTID": 1, "SDC": "d1"}, "geometry": {"rings": [[[-122.5, 37.7], [-122.3, 37.7], [-122.3, 37.9], [-122.5, 37.7]]]}},
+		{"attributes": {"OBJECTID": 2, "SDC": "E"}, "geometry": {"rings": [[[-122.6, 37.7], [-122.5, 37.7], [-122.5, 37.9], [-122.6, 37.7]]]}},
+		{"attributes": {"OBJECTID": 3, "SDC": "Z"}, "geometry": {"rings": [[[-122.6, 37.7], [-122.5, 37.7], [-122.5, 37.9], [-122.6, 37.7]]]}},
+		{"attributes": {"OBJECTID": 4, "SDC": "A"}, "geometry": null}
+	], "exceededTransferLimit": false}`
+	testLandslideFeatures = `{"features": [
+		{"attributes": {"OBJECTID": 7, "LANDSLIDE": "HL"}, "geometry": {"rings": [[[-105.5, 39.7], [-105.3, 39.7], [-105.3, 39.9], [-105.5, 39.7]]]}}
+	], "exceededTransferLimit": false}`
+)
+
+// newHazardServer serves layer metadata and query responses for the two
+// hazard layers under /sdc and /landslide.
+func newHazardServer(t *testing.T, sdc, landslide string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/FeatureServer/0") {
+			_, _ = w.Write([]byte(`{"maxRecordCount":2000,"objectIdField":"OBJECTID","advancedQueryCapabilities":{"supportsPagination":true}}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/sdc/") {
+			_, _ = w.Write([]byte(sdc))
+			return
+		}
+		_, _ = w.Write([]byte(landslide))
+	}))
+}
+
+func expectHazardReplace(mock pgxmock.PgxPoolIface, hazard string, rows int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TEMP TABLE _tmp_usgs_hazards").WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mock.ExpectCopyFrom(pgx.Identifier{"_tmp_usgs_hazards"}, hazardCols).WillReturnResult(rows)
+	mock.ExpectExec("DELETE FROM geo.usgs_hazards WHERE hazard").
+		WithArgs(hazard).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	mock.ExpectExec(`INSERT INTO geo.usgs_hazards \(`).WillReturnResult(pgxmock.NewResult("INSERT", rows))
+	mock.ExpectCommit()
+}
+
+func TestUSGSHazards_Metadata(t *testing.T) {
+	s := &USGSHazards{}
+	assert.Equal(t, "usgs_hazards", s.Name())
+	assert.Equal(t, "geo.usgs_hazards", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+
+	assert.Equal(t, []string{postsync.HazardTag}, s.PostSyncHooks())
+}
+
+func TestUSGSHazards_Sync(t *testing.T) {
+	srv := newHazardServer(t, testSDCFeatures, testLandslideFeatures)
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectHazardReplace(mock, hazardSeismic, 2)
+	expectHazardReplace(mock, hazardLandslide, 1)
+
+	s := &USGSHazards{baseURLs: map[string]string{
+		hazardSeismic:   srv.URL + "/sdc/FeatureServer/0/query",
+		hazardLandslide: srv.URL + "/landslide/FeatureServer/0/query",
+	}}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, int64(2), result.Metadata[hazardSeismic])
+	assert.Equal(t, int64(1), result.Metadata[hazardLandslide])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUSGSHazards_Sync_EmptyLayer(t *testing.T) {
+	srv := newHazardServer(t, `{"features": []}`, testLandslideFeatures)
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &USGSHazards{baseURLs: map[string]string{
+		hazardSeismic:   srv.URL + "/sdc/FeatureServer/0/query",
+		hazardLandslide: srv.URL + "/landslide/FeatureServer/0/query",
+	}}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no seismic_design_category polygons")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClassifySeismicDesignCategory(t *testing.T) {
+	tests := []struct {
+		raw      string
+		class    string
+		severity int16
+		ok       bool
+	}{
+		{"A", "A", 1, true},
+		{" d2 ", "D2", 4, true},
+		{"SDC E", "E", 5, true},
+		{"F", "F", 6, true},
+		{"D3", "", 0, false},
+		{"G", "", 0, false},
+		{"", "", 0, false},
+	}
+	for _, tt := range tests {
+		class, label, severity, ok := classifySeismicDesignCategory(tt.raw)
+		assert.Equal(t, tt.ok, ok, tt.raw)
+		assert.Equal(t, tt.class, class, tt.raw)
+		assert.Equal(t, tt.severity, severity, tt.raw)
+		if ok {
+			assert.Equal(t, "Seismic Design Category "+tt.class, label)
+		}
+	}
+}
+
+func TestClassifyLandslide(t *testing.T) {
+	tests := []struct {
+		raw      string
+		class    string
+		label    string
+		severity int16
+		ok       bool
+	}{
+		{"H", "high", "High incidence", 3, true},
+		{"ml", "moderate", "Moderate susceptibility, Low incidence", 2, true},
+		{"L", "low", "Low incidence", 1, true},
+		{"X", "", "", 0, false},
+		{"HML", "", "", 0, false},
+	}
+	for _, tt := range tests {
+		class, label, severity, ok := classifyLandslide(tt.raw)
+		assert.Equal(t, tt.ok, ok, tt.raw)
+		assert.Equal(t, tt.class, class, tt.raw)
+		assert.Equal(t, tt.label, label, tt.raw)
+		assert.Equal(t, tt.severity, severity, tt.raw)
+	}
+}
+
+func TestNewHazardRow(t *testing.T) {
+	layer := usgsHazardLayers(nil)[0]
+	geom := &arcgis.Geometry{Rings: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}
+
+	row, ok := newHazardRow(layer, arcgis.Feature{Attributes: map[string]any{"OBJECTID": 12.0, "SDC": "C"}, Geometry: geom})
+	require.True(t, ok)
+	require.Len(t, row, len(hazardCols))
+	assert.Equal(t, hazardSeismic, row[0])
+	assert.Equal(t, "C", row[1])
+	assert.Equal(t, int16(3), row[2])
+	assert.Equal(t, usgsSource, row[5])
+	assert.Equal(t, "seismic_design_category/12", row[6])
+	assert.JSONEq(t, `{"SDC":"C"}`, string(row[7].([]byte)))
+
+	_, ok = newHazardRow(layer, arcgis.Feature{Attributes: map[string]any{"SDC": "C"}, Geometry: geom})
+	assert.False(t, ok, "missing OBJECTID")
+}
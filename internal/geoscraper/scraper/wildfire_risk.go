@@ -0,0 +1,260 @@
+package scraper
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/tealeg/xlsx/v2"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+// wildfireRiskVintage is the Wildfire Risk to Communities release loaded.
+const wildfireRiskVintage = 2024
+
+// wildfireRiskURL is the Wildfire Risk to Communities tabular download,
+// which carries the raster products summarized by county and census tract.
+const wildfireRiskURL = "https://wildfirerisk.org/wp-content/uploads/2024/05/wrc_download_202405.xlsx"
+
+// Geography levels stored in geo.wildfire_risk.geo_level.
+const (
+	wildfireLevelCounty = "county"
+	wildfireLevelTract  = "tract"
+)
+
+// wildfireRiskCols are the columns written to geo.wildfire_risk.
+var wildfireRiskCols = []string{
+	"geo_level", "geoid", "state_fips", "name",
+	"risk_to_homes", "risk_to_homes_pctile",
+	"wildfire_likelihood", "wildfire_likelihood_pctile",
+	"vintage", "source", "properties",
+}
+
+var wildfireRiskConflictKeys = []string{"geo_level", "geoid"}
+
+// wildfireRiskHeaders lists the accepted header names for each metric,
+// covering the spelled-out and abbreviated forms used across releases.
+var wildfireRiskHeaders = map[string][]string{
+	"name":                       {"NAME", "Name", "County", "Tract"},
+	"risk_to_homes":              {"Risk to Homes", "RPS", "Risk to Potential Structures"},
+	"risk_to_homes_pctile":       {"Risk to Homes (National Percentile)", "RPS National Percentile", "RPS_PCTL_NATIONAL"},
+	"wildfire_likelihood":        {"Wildfire Likelihood", "BP", "Burn Probability"},
+	"wildfire_likelihood_pctile": {"Wildfire Likelihood (National Percentile)", "BP National Percentile", "BP_PCTL_NATIONAL"},
+}
+
+// WildfireRisk loads the USFS Wildfire Risk to Communities county and
+// census tract summaries into geo.wildfire_risk. The pipeline reads the
+// tract (or, failing that, county) risk to homes percentile as the
+// company's wildfire score.
+type WildfireRisk struct {
+	downloadURL string // override for testing; empty uses wildfireRiskURL
+}
+
+// Name implements GeoScraper.
+func (s *WildfireRisk) Name() string { return "wildfire_risk" }
+
+// Table implements GeoScraper.
+func (s *WildfireRisk) Table() string { return "geo.wildfire_risk" }
+
+// Category implements GeoScraper.
+func (s *WildfireRisk) Category() geoscraper.Category { return geoscraper.National }
+
+// Cadence implements GeoScraper.
+func (s *WildfireRisk) Cadence() geoscraper.Cadence { return geoscraper.Annual }
+
+// ShouldRun implements GeoScraper.
+func (s *WildfireRisk) ShouldRun(now time.Time, lastSync *time.Time) bool {
+	return dataset.AnnualAfter(now, lastSync, time.June)
+}
+
+// Sync implements GeoScraper.
+func (s *WildfireRisk) Sync(ctx context.Context, pool db.Pool, f fetcher.Fetcher, tempDir string) (*geoscraper.SyncResult, error) {
+	log := zap.L().With(zap.String("scraper", s.Name()))
+	log.Info("starting wildfire risk sync")
+
+	url := s.downloadURL
+	if url == "" {
+		url = wildfireRiskURL
+	}
+	xlsxPath := filepath.Join(tempDir, "wildfire_risk.xlsx")
+	if _, err := f.DownloadToFile(ctx, url, xlsxPath); err != nil {
+		return nil, eris.Wrap(err, "wildfire_risk: download")
+	}
+	xlFile, err := xlsx.OpenFile(xlsxPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "wildfire_risk: open xlsx")
+	}
+
+	counts := make(map[string]any)
+	var rows [][]any
+	for _, lvl := range []struct{ level, key string }{
+		{wildfireLevelCounty, "counties"},
+		{wildfireLevelTract, "tracts"},
+	} {
+		sheet := wildfireRiskSheet(xlFile, lvl.level)
+		if sheet == nil {
+			return nil, eris.Errorf("wildfire_risk: no %s sheet in xlsx", lvl.level)
+		}
+		levelRows, err := parseWildfireRiskSheet(sheet, lvl.level)
+		if err != nil {
+			return nil, err
+		}
+		counts[lvl.key] = len(levelRows)
+		rows = append(rows, levelRows...)
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += hifldBatchSize {
+		end := min(start+hifldBatchSize, len(rows))
+		n, err := db.BulkUpsert(ctx, pool, db.UpsertConfig{
+			Table:        "geo.wildfire_risk",
+			Columns:      wildfireRiskCols,
+			ConflictKeys: wildfireRiskConflictKeys,
+		}, rows[start:end])
+		if err != nil {
+			return nil, eris.Wrap(err, "wildfire_risk: upsert batch")
+		}
+		total += n
+	}
+
+	if _, err := pool.Exec(ctx, pruneWildfireRiskSQL, int16(wildfireRiskVintage)); err != nil {
+		return nil, eris.Wrap(err, "wildfire_risk: prune")
+	}
+
+	counts["vintage"] = wildfireRiskVintage
+	log.Info("wildfire risk sync complete", zap.Int64("rows", total))
+	return &geoscraper.SyncResult{RowsSynced: total, Metadata: counts}, nil
+}
+
+const pruneWildfireRiskSQL = `DELETE FROM geo.wildfire_risk WHERE vintage <> $1`
+
+// wildfireRiskSheet finds the summary sheet for a geography level by name,
+// e.g. "Counties" or "Census Tracts".
+func wildfireRiskSheet(xlFile *xlsx.File, level string) *xlsx.Sheet {
+	want := "count"
+	if level == wildfireLevelTract {
+		want = "tract"
+	}
+	for _, sheet := range xlFile.Sheets {
+		if strings.Contains(strings.ToLower(sheet.Name), want) {
+			return sheet
+		}
+	}
+	return nil
+}
+
+// parseWildfireRiskSheet extracts geo.wildfire_risk rows from a county or
+// tract sheet. The header row is located by its GEOID cell. Percentiles
+// published as fractions are scaled to 0-100, and rows whose GEOID is not
+// a county (5) or tract (11) code are skipped.
+func parseWildfireRiskSheet(sheet *xlsx.Sheet, level string) ([][]any, error) {
+	headerIdx := -1
+	for i, row := range sheet.Rows {
+		if _, ok := xlsxColIndex(row)["GEOID"]; ok {
+			headerIdx = i
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil, eris.Errorf("wildfire_risk: %s header row not found", level)
+	}
+	header := sheet.Rows[headerIdx]
+	cols := xlsxColIndex(header)
+	col := func(metric string) int {
+		for _, name := range wildfireRiskHeaders[metric] {
+			if i, ok := cols[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	var (
+		geoidCol   = cols["GEOID"]
+		nameCol    = col("name")
+		riskCol    = col("risk_to_homes")
+		riskPctCol = col("risk_to_homes_pctile")
+		likeCol    = col("wildfire_likelihood")
+		likePctCol = col("wildfire_likelihood_pctile")
+	)
+	if riskCol < 0 && riskPctCol < 0 {
+		return nil, eris.Errorf("wildfire_risk: %s sheet has no risk to homes column", level)
+	}
+
+	geoidLen := 5
+	if level == wildfireLevelTract {
+		geoidLen = 11
+	}
+	exclude := map[string]bool{"GEOID": true}
+	for _, i := range []int{nameCol, riskCol, riskPctCol, likeCol, likePctCol} {
+		if i >= 0 {
+			exclude[xlsxString(header, i)] = true
+		}
+	}
+
+	var (
+		rows       [][]any
+		fractional = true
+	)
+	for _, row := range sheet.Rows[headerIdx+1:] {
+		geoid := xlsxString(row, geoidCol)
+		if len(geoid) == geoidLen-1 {
+			geoid = "0" + geoid // leading zero lost to a numeric cell
+		}
+		if len(geoid) != geoidLen || !isDigits(geoid) {
+			continue
+		}
+		riskPct := parseFloatOrNil(xlsxString(row, riskPctCol))
+		likePct := parseFloatOrNil(xlsxString(row, likePctCol))
+		for _, p := range []*float64{riskPct, likePct} {
+			if p != nil && *p > 1 {
+				fractional = false
+			}
+		}
+		rows = append(rows, []any{
+			level,
+			geoid,
+			geoid[:2],
+			nilIfEmpty(xlsxString(row, nameCol)),
+			parseFloatOrNil(xlsxString(row, riskCol)),
+			riskPct,
+			parseFloatOrNil(xlsxString(row, likeCol)),
+			likePct,
+			int16(wildfireRiskVintage),
+			"usfs",
+			xlsxProperties(row, header, exclude),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, eris.Errorf("wildfire_risk: no %s rows", level)
+	}
+	if fractional {
+		for _, row := range rows {
+			for _, i := range []int{5, 7} {
+				if p, ok := row[i].(*float64); ok && p != nil {
+					*p *= 100
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// isDigits reports whether s is non-empty and all ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
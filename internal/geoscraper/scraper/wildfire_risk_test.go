@@ -0,0 +1,154 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geoscraper"
+)
+
+var wildfireRiskHeader = []string{
+	"GEOID", "NAME", "Risk to Homes", "Risk to Homes (National Percentile)",
+	"Wildfire Likelihood", "Wildfire Likelihood (National Percentile)", "Exposure Type",
+}
+
+// buildWildfireRiskXLSX lays out a workbook with a notes sheet followed by
+// one sheet per named geography, each with a title row above the header.
+func buildWildfireRiskXLSX(t *testing.T, sheets map[string][][]string) []byte {
+	t.Helper()
+	f := xlsx.NewFile()
+	_, err := f.AddSheet("Read Me")
+	require.NoError(t, err)
+	for _, name := range []string{"Counties", "Census Tracts"} {
+		rows, ok := sheets[name]
+		if !ok {
+			continue
+		}
+		sheet, err := f.AddSheet(name)
+		require.NoError(t, err)
+		sheet.AddRow().AddCell().SetString("Wildfire Risk to Communities")
+		for _, r := range append([][]string{wildfireRiskHeader}, rows...) {
+			row := sheet.AddRow()
+			for _, v := range r {
+				row.AddCell().SetString(v)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, f.Write(&buf))
+	return buf.Bytes()
+}
+
+var wildfireRiskSheets = map[string][][]string{
+	"Counties": {
+		{"6037", "Los Angeles County", "0.0081", "0.97", "0.0042", "0.91", "Direct"},
+		{"48453", "Travis County", "0.0012", "0.62", "0.0008", "0.55", "Indirect"},
+		{"", "Total", "", "", "", "", ""},
+	},
+	"Census Tracts": {
+		{"06037101110", "Census Tract 1011.10", "0.0095", "0.98", "0.0051", "0.93", "Direct"},
+	},
+}
+
+func TestWildfireRisk_Metadata(t *testing.T) {
+	s := &WildfireRisk{}
+	assert.Equal(t, "wildfire_risk", s.Name())
+	assert.Equal(t, "geo.wildfire_risk", s.Table())
+	assert.Equal(t, geoscraper.National, s.Category())
+	assert.Equal(t, geoscraper.Annual, s.Cadence())
+
+	now := fixedNow()
+	assert.True(t, s.ShouldRun(now, nil))
+	assert.False(t, s.ShouldRun(now, &now))
+}
+
+func TestWildfireRisk_Sync(t *testing.T) {
+	data := buildWildfireRiskXLSX(t, wildfireRiskSheets)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectBoundaryUpsert(mock, "geo_wildfire_risk", wildfireRiskCols, 3)
+	mock.ExpectExec("DELETE FROM geo.wildfire_risk WHERE vintage").
+		WithArgs(int16(wildfireRiskVintage)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	s := &WildfireRisk{downloadURL: srv.URL + "/wrc.xlsx"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	result, err := s.Sync(context.Background(), mock, f, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.RowsSynced)
+	assert.Equal(t, 2, result.Metadata["counties"])
+	assert.Equal(t, 1, result.Metadata["tracts"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWildfireRisk_Sync_MissingSheet(t *testing.T) {
+	data := buildWildfireRiskXLSX(t, map[string][][]string{"Counties": wildfireRiskSheets["Counties"]})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	s := &WildfireRisk{downloadURL: srv.URL + "/wrc.xlsx"}
+	f := fetcher.NewHTTPFetcher(fetcher.HTTPOptions{MaxRetries: 0})
+	_, err = s.Sync(context.Background(), mock, f, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no tract sheet")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseWildfireRiskSheet(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildWildfireRiskXLSX(t, wildfireRiskSheets))
+	require.NoError(t, err)
+
+	rows, err := parseWildfireRiskSheet(wildfireRiskSheet(xlFile, wildfireLevelCounty), wildfireLevelCounty)
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "total row is skipped")
+
+	la := rows[0]
+	require.Len(t, la, len(wildfireRiskCols))
+	assert.Equal(t, "county", la[0])
+	assert.Equal(t, "06037", la[1], "leading zero restored")
+	assert.Equal(t, "06", la[2])
+	assert.Equal(t, "Los Angeles County", la[3])
+	assert.InDelta(t, 0.0081, *la[4].(*float64), 1e-9)
+	assert.InDelta(t, 97, *la[5].(*float64), 1e-9, "fractional percentile scaled")
+	assert.InDelta(t, 91, *la[7].(*float64), 1e-9)
+	assert.JSONEq(t, `{"Exposure Type":"Direct"}`, string(la[10].(json.RawMessage)))
+
+	// Percentiles already on a 0-100 scale are kept.
+	sheet := buildWildfireRiskXLSX(t, map[string][][]string{"Counties": {{"48453", "Travis County", "0.0012", "62", "0.0008", "55", ""}}})
+	xlFile, err = xlsx.OpenBinary(sheet)
+	require.NoError(t, err)
+	rows, err = parseWildfireRiskSheet(wildfireRiskSheet(xlFile, wildfireLevelCounty), wildfireLevelCounty)
+	require.NoError(t, err)
+	assert.InDelta(t, 62, *rows[0][5].(*float64), 1e-9)
+}
+
+func TestParseWildfireRiskSheet_NoHeader(t *testing.T) {
+	xlFile, err := xlsx.OpenBinary(buildFMRXLSX(t, []string{"fips", "risk"}, [][]string{{"48453", "0.5"}}))
+	require.NoError(t, err)
+	_, err = parseWildfireRiskSheet(xlFile.Sheets[0], wildfireLevelCounty)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "county header row not found")
+}
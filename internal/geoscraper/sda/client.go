@@ -0,0 +1,98 @@
+// Package sda provides a client for the USDA NRCS Soil Data Access (SDA)
+// tabular service, which answers T-SQL queries against the current SSURGO
+// soil survey database.
+package sda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+// DefaultEndpoint is the public SDA tabular endpoint.
+const DefaultEndpoint = "https://sdmdataaccess.sc.egov.usda.gov/Tabular/post.rest"
+
+// maxErrorBody caps how much of an error response is quoted in errors.
+const maxErrorBody = 512
+
+// Row is one result row keyed by column name. NULL values are absent.
+type Row map[string]string
+
+// request is the SDA POST body.
+type request struct {
+	Query  string `json:"query"`
+	Format string `json:"format"`
+}
+
+// response is the JSON+COLUMNNAME envelope: the first row of Table holds
+// the column names. Queries returning no rows yield an empty object.
+type response struct {
+	Table [][]*string `json:"Table"`
+}
+
+// Query runs a T-SQL query against SDA and returns the result rows.
+// Overload and server errors (429, 5xx) are returned as
+// resilience.TransientError so callers can retry them.
+func Query(ctx context.Context, endpoint, query string) ([]Row, error) {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	body, err := json.Marshal(request{Query: query, Format: "JSON+COLUMNNAME"})
+	if err != nil {
+		return nil, eris.Wrap(err, "sda: encode request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, eris.Wrap(err, "sda: build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "sda: execute request")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		err := eris.Errorf("sda: HTTP %d: %s", resp.StatusCode, string(msg))
+		if resilience.IsTransientHTTPStatus(resp.StatusCode) {
+			return nil, resilience.NewTransientError(err, resp.StatusCode)
+		}
+		return nil, err
+	}
+
+	var result response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, eris.Wrap(err, "sda: decode response")
+	}
+	if len(result.Table) == 0 {
+		return nil, nil
+	}
+
+	header := result.Table[0]
+	cols := make([]string, len(header))
+	for i, c := range header {
+		if c != nil {
+			cols[i] = *c
+		}
+	}
+	rows := make([]Row, 0, len(result.Table)-1)
+	for _, values := range result.Table[1:] {
+		row := make(Row, len(cols))
+		for i, v := range values {
+			if i < len(cols) && v != nil {
+				row[cols[i]] = *v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
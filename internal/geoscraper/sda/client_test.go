@@ -0,0 +1,88 @@
+package sda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/resilience"
+)
+
+func TestQuery_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "SELECT areasymbol, areaname FROM sacatalog", req.Query)
+		assert.Equal(t, "JSON+COLUMNNAME", req.Format)
+
+		_, _ = w.Write([]byte(`{"Table": [
+			["areasymbol", "areaname"],
+			["TX453", "Travis County, Texas"],
+			["TX021", null]
+		]}`))
+	}))
+	defer srv.Close()
+
+	rows, err := Query(context.Background(), srv.URL, "SELECT areasymbol, areaname FROM sacatalog")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, Row{"areasymbol": "TX453", "areaname": "Travis County, Texas"}, rows[0])
+	assert.Equal(t, Row{"areasymbol": "TX021"}, rows[1])
+}
+
+func TestQuery_NoRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rows, err := Query(context.Background(), srv.URL, "SELECT 1 WHERE 1 = 0")
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestQuery_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Invalid query: Incorrect syntax near 'FORM'.", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := Query(context.Background(), srv.URL, "SELECT * FORM mapunit")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sda: HTTP 400")
+	assert.Contains(t, err.Error(), "Incorrect syntax")
+	assert.False(t, resilience.IsTransient(err), "bad queries are not retried")
+}
+
+func TestQuery_ServerErrorIsTransient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := Query(context.Background(), srv.URL, "SELECT 1")
+	require.Error(t, err)
+	assert.True(t, resilience.IsTransient(err))
+}
+
+func TestQuery_BadJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html>maintenance</html>"))
+	}))
+	defer srv.Close()
+
+	_, err := Query(context.Background(), srv.URL, "SELECT 1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sda: decode response")
+}
+
+func TestQuery_DefaultEndpoint(t *testing.T) {
+	assert.Equal(t, "https://sdmdataaccess.sc.egov.usda.gov/Tabular/post.rest", DefaultEndpoint)
+}
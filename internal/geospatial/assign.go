@@ -0,0 +1,172 @@
+package geospatial
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// assignBoundariesSQL finds the county, tract, place, and metro/micro CBSA
+// containing each location of a keyset batch.
+const assignBoundariesSQL = `
+	LEFT JOIN LATERAL (
+		SELECT c.geoid, c.state_fips FROM geo.counties c
+		WHERE ST_Contains(c.geom, l.geom) LIMIT 1
+	) co ON true
+	LEFT JOIN LATERAL (
+		SELECT t.geoid FROM geo.census_tracts t
+		WHERE ST_Contains(t.geom, l.geom) LIMIT 1
+	) tr ON true
+	LEFT JOIN LATERAL (
+		SELECT p.geoid, p.name FROM geo.places p
+		WHERE ST_Contains(p.geom, l.geom) LIMIT 1
+	) pl ON true
+	LEFT JOIN LATERAL (
+		SELECT m.cbsa_code, m.name FROM geo.cbsa m
+		WHERE m.lsad IN ('M1', 'M2') AND ST_Contains(m.geom, l.geom) LIMIT 1
+	) cb ON true`
+
+// assignBatchSQL assigns one batch of locations after id $1 and reports
+// how many were scanned, the last id, and how many matched each boundary.
+// $2 reassigns already assigned locations, $3 restricts to a source table,
+// and $4 is the batch size. Boundaries with no match keep the existing
+// value, so a missing boundary layer never clears codes from the geocoder.
+const assignBatchSQL = `
+WITH assigned AS (
+	SELECT l.id, co.geoid AS county_fips, co.state_fips, tr.geoid AS tract_geoid,
+		pl.geoid AS place_geoid, pl.name AS place_name, cb.cbsa_code
+	FROM (
+		SELECT id, geom FROM geo.locations
+		WHERE id > $1
+			AND geom IS NOT NULL
+			AND ($2 OR spatial_assigned_at IS NULL)
+			AND ($3 = '' OR source_table = $3)
+		ORDER BY id
+		LIMIT $4
+	) l` + assignBoundariesSQL + `
+), updated AS (
+	UPDATE geo.locations l SET
+		county_fips = COALESCE(a.county_fips, l.county_fips),
+		state_fips = COALESCE(a.state_fips, l.state_fips),
+		tract_geoid = COALESCE(a.tract_geoid, l.tract_geoid),
+		place_geoid = COALESCE(a.place_geoid, l.place_geoid),
+		place_name = COALESCE(a.place_name, l.place_name),
+		cbsa_code = COALESCE(a.cbsa_code, l.cbsa_code),
+		spatial_assigned_at = now()
+	FROM assigned a
+	WHERE l.id = a.id
+	RETURNING l.id, a.county_fips IS NOT NULL AS county, a.tract_geoid IS NOT NULL AS tract,
+		a.place_geoid IS NOT NULL AS place, a.cbsa_code IS NOT NULL AS cbsa
+)
+SELECT count(*), COALESCE(max(id), 0),
+	count(*) FILTER (WHERE county), count(*) FILTER (WHERE tract),
+	count(*) FILTER (WHERE place), count(*) FILTER (WHERE cbsa)
+FROM updated`
+
+// assignPointSQL looks up the boundaries containing a single point ($1
+// longitude, $2 latitude).
+const assignPointSQL = `
+SELECT co.geoid, tr.geoid, pl.geoid, pl.name, cb.cbsa_code, cb.name
+FROM (SELECT ST_SetSRID(ST_MakePoint($1, $2), 4326) AS geom) l` + assignBoundariesSQL
+
+// SpatialAssignOpts configures a SpatialAssigner run.
+type SpatialAssignOpts struct {
+	SourceTable string // restrict to one source table; empty = all
+	Force       bool   // reassign locations that were already assigned
+	Limit       int    // max locations (0 = all)
+}
+
+// SpatialAssignStats counts the locations a run assigned and how many
+// matched each boundary layer.
+type SpatialAssignStats struct {
+	Scanned  int `json:"scanned"`
+	Counties int `json:"counties"`
+	Tracts   int `json:"tracts"`
+	Places   int `json:"places"`
+	CBSAs    int `json:"cbsas"`
+}
+
+// SpatialAssigner bulk-assigns county FIPS, tract, place, and metro/micro
+// CBSA to geocoded points in geo.locations by point-in-polygon joins
+// against the synced TIGER and CBSA boundaries. Incremental runs pick up
+// locations never assigned or re-geocoded since their last assignment.
+type SpatialAssigner struct {
+	pool      db.Pool
+	batchSize int
+}
+
+// NewSpatialAssigner creates a SpatialAssigner. batchSize is the number of
+// locations updated per statement (default 5000).
+func NewSpatialAssigner(pool db.Pool, batchSize int) *SpatialAssigner {
+	if batchSize <= 0 {
+		batchSize = 5000
+	}
+	return &SpatialAssigner{pool: pool, batchSize: batchSize}
+}
+
+// Run assigns boundaries to pending locations, or every location when
+// opts.Force is set, in keyset batches until none remain or opts.Limit is
+// reached.
+func (a *SpatialAssigner) Run(ctx context.Context, opts SpatialAssignOpts) (*SpatialAssignStats, error) {
+	stats := &SpatialAssignStats{}
+	var lastID int64
+	for opts.Limit <= 0 || stats.Scanned < opts.Limit {
+		if err := ctx.Err(); err != nil {
+			return stats, eris.Wrap(err, "spatial assign: canceled")
+		}
+		n := a.batchSize
+		if opts.Limit > 0 {
+			n = min(n, opts.Limit-stats.Scanned)
+		}
+		var scanned, counties, tracts, places, cbsas int
+		err := a.pool.QueryRow(ctx, assignBatchSQL, lastID, opts.Force, opts.SourceTable, n).
+			Scan(&scanned, &lastID, &counties, &tracts, &places, &cbsas)
+		if err != nil {
+			return stats, eris.Wrap(err, "spatial assign: update batch")
+		}
+		if scanned == 0 {
+			break
+		}
+		stats.Scanned += scanned
+		stats.Counties += counties
+		stats.Tracts += tracts
+		stats.Places += places
+		stats.CBSAs += cbsas
+		zap.L().Debug("spatial assign: batch complete",
+			zap.Int("scanned", scanned), zap.Int64("last_id", lastID))
+	}
+	return stats, nil
+}
+
+// PointAssignment holds the boundaries containing a point. Fields are empty
+// when no synced boundary contains it.
+type PointAssignment struct {
+	CountyFIPS string `json:"county_fips,omitempty"`
+	TractGEOID string `json:"tract_geoid,omitempty"`
+	PlaceGEOID string `json:"place_geoid,omitempty"`
+	PlaceName  string `json:"place_name,omitempty"`
+	CBSACode   string `json:"cbsa_code,omitempty"`
+	CBSAName   string `json:"cbsa_name,omitempty"`
+}
+
+// AssignPoint returns the county, tract, place, and metro/micro CBSA
+// containing (lat, lng) with the same joins SpatialAssigner uses.
+func AssignPoint(ctx context.Context, pool db.Pool, lat, lng float64) (*PointAssignment, error) {
+	var county, tract, placeGEOID, placeName, cbsa, cbsaName *string
+	err := pool.QueryRow(ctx, assignPointSQL, lng, lat).
+		Scan(&county, &tract, &placeGEOID, &placeName, &cbsa, &cbsaName)
+	if err != nil {
+		return nil, eris.Wrap(err, "spatial assign: lookup point")
+	}
+	return &PointAssignment{
+		CountyFIPS: derefString(county),
+		TractGEOID: derefString(tract),
+		PlaceGEOID: derefString(placeGEOID),
+		PlaceName:  derefString(placeName),
+		CBSACode:   derefString(cbsa),
+		CBSAName:   derefString(cbsaName),
+	}, nil
+}
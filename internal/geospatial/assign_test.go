@@ -0,0 +1,92 @@
+package geospatial
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var assignCols = []string{"scanned", "last_id", "counties", "tracts", "places", "cbsas"}
+
+func TestSpatialAssigner_Run(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(0), false, "", 2).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(2, int64(14), 2, 2, 1, 1))
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(14), false, "", 2).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(1, int64(20), 1, 0, 0, 0))
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(20), false, "", 2).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(0, int64(0), 0, 0, 0, 0))
+
+	stats, err := NewSpatialAssigner(mock, 2).Run(context.Background(), SpatialAssignOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, &SpatialAssignStats{Scanned: 3, Counties: 3, Tracts: 2, Places: 1, CBSAs: 1}, stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpatialAssigner_Run_ForceLimit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(0), true, "fed_data.adv_firms", 3).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(3, int64(9), 3, 3, 0, 2))
+
+	stats, err := NewSpatialAssigner(mock, 10).Run(context.Background(), SpatialAssignOpts{
+		SourceTable: "fed_data.adv_firms", Force: true, Limit: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Scanned)
+	assert.Equal(t, 2, stats.CBSAs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpatialAssigner_Run_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(0), false, "", 5000).
+		WillReturnError(errors.New("relation geo.counties does not exist"))
+
+	_, err = NewSpatialAssigner(mock, 0).Run(context.Background(), SpatialAssignOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spatial assign: update batch")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignPoint(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	county, tract, cbsa, cbsaName := "48113", "48113007101", "19100", "Dallas-Fort Worth-Arlington, TX"
+	mock.ExpectQuery(`SELECT co\.geoid, tr\.geoid, pl\.geoid, pl\.name, cb\.cbsa_code, cb\.name`).
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows([]string{"county", "tract", "place_geoid", "place_name", "cbsa", "cbsa_name"}).
+			AddRow(&county, &tract, (*string)(nil), (*string)(nil), &cbsa, &cbsaName))
+
+	got, err := AssignPoint(context.Background(), mock, 32.7767, -96.797)
+	require.NoError(t, err)
+	assert.Equal(t, &PointAssignment{
+		CountyFIPS: county, TractGEOID: tract, CBSACode: cbsa, CBSAName: cbsaName,
+	}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignBatchSQL(t *testing.T) {
+	assert.Contains(t, assignBatchSQL, "spatial_assigned_at IS NULL")
+	assert.Contains(t, assignBatchSQL, "cbsa_code = COALESCE(a.cbsa_code, l.cbsa_code)")
+	assert.Contains(t, assignBoundariesSQL, "m.lsad IN ('M1', 'M2')")
+}
@@ -0,0 +1,233 @@
+package geospatial
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// MaxExportTiles caps the tiles a single MVT export may write.
+const MaxExportTiles = 20000
+
+// exportLayerSQL finds a geometry column of a geo schema table in the
+// PostGIS catalog, preferring one named geom.
+const exportLayerSQL = `
+SELECT f_table_name, f_geometry_column, srid
+FROM public.geometry_columns
+WHERE f_table_schema = 'geo' AND f_table_name = $1
+ORDER BY f_geometry_column <> 'geom', f_geometry_column
+LIMIT 1`
+
+// ExportLayer is a geo schema table resolved for export.
+type ExportLayer struct {
+	Name       string // table name without the geo schema
+	GeomColumn string
+	SRID       int // 0 when the column has no declared SRID
+}
+
+// ResolveExportLayer looks up a geo schema table with a geometry column.
+// name may be bare ("flood_zones") or schema-qualified ("geo.flood_zones").
+// Only tables registered in geometry_columns resolve, so the returned
+// identifiers are safe to quote into SQL.
+func ResolveExportLayer(ctx context.Context, pool db.Pool, name string) (*ExportLayer, error) {
+	table := strings.TrimPrefix(strings.TrimSpace(name), "geo.")
+	var l ExportLayer
+	err := pool.QueryRow(ctx, exportLayerSQL, table).Scan(&l.Name, &l.GeomColumn, &l.SRID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, eris.Errorf("geo export: no geometry layer geo.%s", table)
+		}
+		return nil, eris.Wrap(err, "geo export: resolve layer")
+	}
+	return &l, nil
+}
+
+// ParseBBox parses "min_lng,min_lat,max_lng,max_lat" (the QGIS and GDAL
+// order) in WGS84 degrees.
+func ParseBBox(s string) (BBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return BBox{}, eris.Errorf("geo: bbox %q must be min_lng,min_lat,max_lng,max_lat", s)
+	}
+	var v [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return BBox{}, eris.Wrapf(err, "geo: bbox %q", s)
+		}
+		v[i] = f
+	}
+	b := BBox{MinLng: v[0], MinLat: v[1], MaxLng: v[2], MaxLat: v[3]}
+	if b.MinLng >= b.MaxLng || b.MinLat >= b.MaxLat ||
+		b.MinLng < -180 || b.MaxLng > 180 || b.MinLat < -90 || b.MaxLat > 90 {
+		return BBox{}, eris.Errorf("geo: bbox %q is not a valid WGS84 extent", s)
+	}
+	return b, nil
+}
+
+// envelopeSQL returns the bbox envelope ($1-$4) in the layer's SRID.
+func (l *ExportLayer) envelopeSQL() string {
+	switch l.SRID {
+	case 0:
+		return "ST_MakeEnvelope($1, $2, $3, $4)"
+	case 4326:
+		return "ST_MakeEnvelope($1, $2, $3, $4, 4326)"
+	default:
+		return fmt.Sprintf("ST_Transform(ST_MakeEnvelope($1, $2, $3, $4, 4326), %d)", l.SRID)
+	}
+}
+
+// wgs84SQL returns expr transformed to WGS84 when the layer has another
+// declared SRID.
+func (l *ExportLayer) wgs84SQL(expr string) string {
+	if l.SRID == 0 || l.SRID == 4326 {
+		return expr
+	}
+	return "ST_Transform(" + expr + ", 4326)"
+}
+
+// ExportGeoJSON streams the layer's features intersecting bbox to w as a
+// GeoJSON FeatureCollection. Lines and polygons are clipped to the bbox;
+// every non-geometry column becomes a property. Returns the feature count.
+func ExportGeoJSON(ctx context.Context, pool db.Pool, layer *ExportLayer, bbox BBox, w io.Writer) (int, error) {
+	table := pgx.Identifier{"geo", layer.Name}.Sanitize()
+	geom := "t." + pgx.Identifier{layer.GeomColumn}.Sanitize()
+	clipped := fmt.Sprintf("CASE WHEN ST_Dimension(%[1]s) = 0 THEN %[1]s ELSE ST_Intersection(%[1]s, e.env) END", geom)
+	sql := fmt.Sprintf(`
+		SELECT json_build_object(
+			'type', 'Feature',
+			'geometry', ST_AsGeoJSON(%s, 6)::json,
+			'properties', to_jsonb(t) - $5::text
+		)::text
+		FROM %s t, (SELECT %s AS env) e
+		WHERE %s && e.env AND ST_Intersects(%s, e.env)`,
+		layer.wgs84SQL(clipped), table, layer.envelopeSQL(), geom, geom)
+
+	rows, err := pool.Query(ctx, sql, bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat, layer.GeomColumn)
+	if err != nil {
+		return 0, eris.Wrap(err, "geo export: query features")
+	}
+	defer rows.Close()
+
+	if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`+"\n"); err != nil {
+		return 0, eris.Wrap(err, "geo export: write")
+	}
+	n := 0
+	for rows.Next() {
+		var feature string
+		if err := rows.Scan(&feature); err != nil {
+			return n, eris.Wrap(err, "geo export: scan feature")
+		}
+		sep := ""
+		if n > 0 {
+			sep = ",\n"
+		}
+		if _, err := io.WriteString(w, sep+feature); err != nil {
+			return n, eris.Wrap(err, "geo export: write")
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, eris.Wrap(err, "geo export: iterate features")
+	}
+	if _, err := io.WriteString(w, "\n]}\n"); err != nil {
+		return n, eris.Wrap(err, "geo export: write")
+	}
+	return n, nil
+}
+
+// ExportMVT writes the layer's vector tiles covering bbox at zooms minZoom
+// through maxZoom to dir as {z}/{x}/{y}.pbf, which QGIS loads as a local
+// XYZ vector tile source. Each tile holds one layer named after the table;
+// empty tiles are skipped. Returns the tiles written.
+func ExportMVT(ctx context.Context, pool db.Pool, layer *ExportLayer, bbox BBox, minZoom, maxZoom int, dir string) (int, error) {
+	if minZoom < 0 || maxZoom > 22 || minZoom > maxZoom {
+		return 0, eris.Errorf("geo export: invalid zoom range %d-%d", minZoom, maxZoom)
+	}
+	total := 0
+	for z := minZoom; z <= maxZoom; z++ {
+		x0, y0, x1, y1 := tileRange(bbox, z)
+		total += (x1 - x0 + 1) * (y1 - y0 + 1)
+	}
+	if total > MaxExportTiles {
+		return 0, eris.Errorf("geo export: %d tiles exceeds the %d tile limit; narrow the bbox or zoom range", total, MaxExportTiles)
+	}
+
+	// Geometries without a declared SRID are taken to be WGS84.
+	table := pgx.Identifier{"geo", layer.Name}.Sanitize()
+	geom := "t." + pgx.Identifier{layer.GeomColumn}.Sanitize()
+	srid := layer.SRID
+	if srid == 0 {
+		srid = 4326
+		geom = fmt.Sprintf("ST_SetSRID(%s, 4326)", geom)
+	}
+	env := "ST_TileEnvelope($1, $2, $3)"
+	mvtGeom := geom
+	if srid != 3857 {
+		env = fmt.Sprintf("ST_Transform(%s, %d)", env, srid)
+		mvtGeom = fmt.Sprintf("ST_Transform(%s, 3857)", geom)
+	}
+	sql := fmt.Sprintf(`
+		SELECT ST_AsMVT(q, $4, 4096, 'mvt_geom') FROM (
+			SELECT to_jsonb(t) - $5::text AS properties,
+				ST_AsMVTGeom(%s, ST_TileEnvelope($1, $2, $3), 4096, 256, true) AS mvt_geom
+			FROM %s t
+			WHERE %s && %s
+		) q`, mvtGeom, table, geom, env)
+
+	written := 0
+	for z := minZoom; z <= maxZoom; z++ {
+		x0, y0, x1, y1 := tileRange(bbox, z)
+		for x := x0; x <= x1; x++ {
+			for y := y0; y <= y1; y++ {
+				if err := ctx.Err(); err != nil {
+					return written, eris.Wrap(err, "geo export: canceled")
+				}
+				var tile []byte
+				if err := pool.QueryRow(ctx, sql, z, x, y, layer.Name, layer.GeomColumn).Scan(&tile); err != nil {
+					return written, eris.Wrapf(err, "geo export: tile %d/%d/%d", z, x, y)
+				}
+				if len(tile) == 0 {
+					continue
+				}
+				path := filepath.Join(dir, strconv.Itoa(z), strconv.Itoa(x), strconv.Itoa(y)+".pbf")
+				if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+					return written, eris.Wrap(err, "geo export: create tile dir")
+				}
+				if err := os.WriteFile(path, tile, 0o600); err != nil {
+					return written, eris.Wrap(err, "geo export: write tile")
+				}
+				written++
+			}
+		}
+	}
+	return written, nil
+}
+
+// tileRange returns the XYZ tile columns and rows covering bbox at zoom z.
+func tileRange(b BBox, z int) (x0, y0, x1, y1 int) {
+	x0, y1 = lngLatToTile(b.MinLng, b.MinLat, z)
+	x1, y0 = lngLatToTile(b.MaxLng, b.MaxLat, z)
+	return x0, y0, x1, y1
+}
+
+// lngLatToTile returns the web mercator tile containing (lng, lat) at
+// zoom z.
+func lngLatToTile(lng, lat float64, z int) (x, y int) {
+	n := 1 << z
+	lat = math.Max(-85.05112878, math.Min(85.05112878, lat))
+	latRad := lat * math.Pi / 180
+	x = int(math.Floor((lng + 180) / 360 * float64(n)))
+	y = int(math.Floor((1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2 * float64(n)))
+	return min(max(x, 0), n-1), min(max(y, 0), n-1)
+}
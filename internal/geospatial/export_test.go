@@ -0,0 +1,144 @@
+package geospatial
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dallas = BBox{MinLng: -96.9, MinLat: 32.7, MaxLng: -96.7, MaxLat: 32.9}
+
+func TestResolveExportLayer(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM public\.geometry_columns`).
+		WithArgs("flood_zones").
+		WillReturnRows(pgxmock.NewRows([]string{"f_table_name", "f_geometry_column", "srid"}).
+			AddRow("flood_zones", "geom", 4326))
+	mock.ExpectQuery(`FROM public\.geometry_columns`).
+		WithArgs("companies").
+		WillReturnError(pgx.ErrNoRows)
+
+	l, err := ResolveExportLayer(context.Background(), mock, "geo.flood_zones")
+	require.NoError(t, err)
+	assert.Equal(t, &ExportLayer{Name: "flood_zones", GeomColumn: "geom", SRID: 4326}, l)
+
+	_, err = ResolveExportLayer(context.Background(), mock, "companies")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no geometry layer geo.companies")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParseBBox(t *testing.T) {
+	b, err := ParseBBox("-96.9, 32.7,-96.7,32.9")
+	require.NoError(t, err)
+	assert.Equal(t, dallas, b)
+
+	for _, bad := range []string{"", "-96.9,32.7,-96.7", "-96.9,32.7,x,32.9", "-96.7,32.7,-96.9,32.9", "-200,32.7,-96.7,32.9"} {
+		_, err := ParseBBox(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestExportGeoJSON(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM "geo"\."flood_zones" t, \(SELECT ST_MakeEnvelope\(\$1, \$2, \$3, \$4, 4326\) AS env\) e`).
+		WithArgs(-96.9, 32.7, -96.7, 32.9, "geom").
+		WillReturnRows(pgxmock.NewRows([]string{"feature"}).
+			AddRow(`{"type":"Feature","geometry":{"type":"Point","coordinates":[-96.8,32.8]},"properties":{"zone_code":"AE"}}`).
+			AddRow(`{"type":"Feature","geometry":{"type":"Point","coordinates":[-96.75,32.85]},"properties":{"zone_code":"X"}}`))
+
+	var buf bytes.Buffer
+	n, err := ExportGeoJSON(context.Background(), mock, &ExportLayer{Name: "flood_zones", GeomColumn: "geom", SRID: 4326}, dallas, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	var fc struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	assert.Len(t, fc.Features, 2)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportGeoJSON_Empty(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`ST_Transform\(ST_MakeEnvelope\(\$1, \$2, \$3, \$4, 4326\), 5070\)`).
+		WithArgs(-96.9, 32.7, -96.7, 32.9, "shape").
+		WillReturnRows(pgxmock.NewRows([]string{"feature"}))
+
+	var buf bytes.Buffer
+	n, err := ExportGeoJSON(context.Background(), mock, &ExportLayer{Name: "parcels", GeomColumn: "shape", SRID: 5070}, dallas, &buf)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, buf.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportMVT(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// The Dallas bbox spans two tile rows at zoom 10.
+	layer := &ExportLayer{Name: "flood_zones", GeomColumn: "geom", SRID: 4326}
+	mock.ExpectQuery(`SELECT ST_AsMVT\(q, \$4, 4096, 'mvt_geom'\)`).
+		WithArgs(10, 236, 412, "flood_zones", "geom").
+		WillReturnRows(pgxmock.NewRows([]string{"tile"}).AddRow([]byte{0x1a, 0x01}))
+	mock.ExpectQuery(`SELECT ST_AsMVT`).
+		WithArgs(10, 236, 413, "flood_zones", "geom").
+		WillReturnRows(pgxmock.NewRows([]string{"tile"}).AddRow([]byte{}))
+
+	dir := t.TempDir()
+	n, err := ExportMVT(context.Background(), mock, layer, dallas, 10, 10, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	tile, err := os.ReadFile(filepath.Join(dir, "10", "236", "412.pbf"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x1a, 0x01}, tile)
+	assert.NoFileExists(t, filepath.Join(dir, "10", "236", "413.pbf"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportMVT_Limits(t *testing.T) {
+	layer := &ExportLayer{Name: "flood_zones", GeomColumn: "geom", SRID: 4326}
+	_, err := ExportMVT(context.Background(), nil, layer, dallas, 12, 10, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid zoom range")
+
+	conus := BBox{MinLng: -125, MinLat: 24.4, MaxLng: -66.9, MaxLat: 49.4}
+	_, err = ExportMVT(context.Background(), nil, layer, conus, 0, 12, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tile limit")
+}
+
+func TestLngLatToTile(t *testing.T) {
+	x, y := lngLatToTile(0, 0, 1)
+	assert.Equal(t, 1, x)
+	assert.Equal(t, 1, y)
+	x, y = lngLatToTile(-180, 90, 3)
+	assert.Zero(t, x)
+	assert.Zero(t, y)
+	x, y = lngLatToTile(180, -90, 3)
+	assert.Equal(t, 7, x)
+	assert.Equal(t, 7, y)
+}
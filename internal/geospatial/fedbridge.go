@@ -56,12 +56,13 @@ func (b *FedBridge) EnqueueADVFirms(ctx context.Context, limit int) (int, error)
 		return 0, nil
 	}
 
-	if err := b.queue.EnqueueBatch(ctx, "fed_data.adv_firms", items); err != nil {
-		return 0, eris.Wrap(err, "fedbridge: enqueue adv firms")
+	n, err := b.queue.EnqueueBatch(ctx, "fed_data.adv_firms", items)
+	if err != nil {
+		return n, eris.Wrap(err, "fedbridge: enqueue adv firms")
 	}
 
-	zap.L().Info("fedbridge: enqueued ADV firms for geocoding", zap.Int("count", len(items)))
-	return len(items), nil
+	zap.L().Info("fedbridge: enqueued ADV firms for geocoding", zap.Int("count", n), zap.Int("found", len(items)))
+	return n, nil
 }
 
 // EnqueueEPAFacilities finds EPA facilities that have addresses but no coordinates
@@ -102,10 +103,11 @@ func (b *FedBridge) EnqueueEPAFacilities(ctx context.Context, limit int) (int, e
 		return 0, nil
 	}
 
-	if err := b.queue.EnqueueBatch(ctx, "fed_data.epa_facilities", items); err != nil {
-		return 0, eris.Wrap(err, "fedbridge: enqueue epa facilities")
+	n, err := b.queue.EnqueueBatch(ctx, "fed_data.epa_facilities", items)
+	if err != nil {
+		return n, eris.Wrap(err, "fedbridge: enqueue epa facilities")
 	}
 
-	zap.L().Info("fedbridge: enqueued EPA facilities for geocoding", zap.Int("count", len(items)))
-	return len(items), nil
+	zap.L().Info("fedbridge: enqueued EPA facilities for geocoding", zap.Int("count", n), zap.Int("found", len(items)))
+	return n, nil
 }
@@ -24,15 +24,14 @@ func TestEnqueueADVFirms_Success(t *testing.T) {
 				AddRow("67890", "200 Broadway, New York, NY 10001"),
 		)
 
-	// Batch enqueue transaction.
-	mock.ExpectBegin()
+	// Batch enqueue statement.
 	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("fed_data.adv_firms", "12345", "100 Main St, Miami, FL 33131").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("fed_data.adv_firms", "67890", "200 Broadway, New York, NY 10001").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectCommit()
+		WithArgs("fed_data.adv_firms",
+			[]string{"12345", "67890"},
+			[]string{"100 Main St, Miami, FL 33131", "200 Broadway, New York, NY 10001"},
+			[]string{"100 MAIN ST, MIAMI, FL 33131", "200 BROADWAY, NEW YORK, NY 10001"},
+			pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
 
 	queue := NewGeocodeQueue(mock, nil, 100)
 	bridge := NewFedBridge(mock, queue)
@@ -92,12 +91,11 @@ func TestEnqueueEPAFacilities_Success(t *testing.T) {
 				AddRow("TXD000001234", "Acme Corp, Houston, TX 77001"),
 		)
 
-	// Batch enqueue transaction.
-	mock.ExpectBegin()
+	// Batch enqueue statement.
 	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("fed_data.epa_facilities", "TXD000001234", "Acme Corp, Houston, TX 77001").
+		WithArgs("fed_data.epa_facilities", []string{"TXD000001234"}, []string{"Acme Corp, Houston, TX 77001"},
+			pgxmock.AnyArg(), pgxmock.AnyArg()).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectCommit()
 
 	queue := NewGeocodeQueue(mock, nil, 100)
 	bridge := NewFedBridge(mock, queue)
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/pkg/address"
 	"github.com/sells-group/research-cli/pkg/geocode"
 )
 
@@ -19,11 +20,30 @@ type QueueItem struct {
 	Address  string
 }
 
+// normalizeAddress parses a raw queue address, returning its normalized
+// one-line form and JSON components, or empty strings when nothing parses.
+func normalizeAddress(raw string) (norm, components string) {
+	c := address.Parse(raw)
+	if c.IsZero() {
+		return "", ""
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return c.String(), ""
+	}
+	return c.String(), string(b)
+}
+
+// enqueueChunkSize is the number of items inserted per EnqueueBatch
+// statement; queue depth is rechecked between chunks.
+const enqueueChunkSize = 1000
+
 // GeocodeQueue manages a geocoding work queue backed by geo.geocode_queue.
 type GeocodeQueue struct {
 	pool      db.Pool
 	geocoder  geocode.Client
 	batchSize int
+	maxDepth  int
 }
 
 // NewGeocodeQueue creates a GeocodeQueue with the given pool, geocoder, and batch size.
@@ -38,52 +58,140 @@ func NewGeocodeQueue(pool db.Pool, geocoder geocode.Client, batchSize int) *Geoc
 	}
 }
 
+// WithMaxDepth sets the backpressure limit: EnqueueBatch stops adding items
+// once this many are pending or processing. Zero disables the limit.
+func (q *GeocodeQueue) WithMaxDepth(n int) *GeocodeQueue {
+	q.maxDepth = n
+	return q
+}
+
+// Depth returns the number of queue items pending or processing.
+func (q *GeocodeQueue) Depth(ctx context.Context) (int, error) {
+	var n int
+	err := q.pool.QueryRow(ctx,
+		`SELECT count(*) FROM geo.geocode_queue WHERE status IN ('pending', 'processing')`,
+	).Scan(&n)
+	return n, eris.Wrap(err, "geocode queue: depth")
+}
+
 // Enqueue inserts or updates a single address in the geocode queue.
 func (q *GeocodeQueue) Enqueue(ctx context.Context, sourceTable, sourceID, address string) error {
+	norm, components := normalizeAddress(address)
 	_, err := q.pool.Exec(ctx, `
-		INSERT INTO geo.geocode_queue (source_table, source_id, address, status, attempts, created_at, updated_at)
-		VALUES ($1, $2, $3, 'pending', 0, now(), now())
+		INSERT INTO geo.geocode_queue (source_table, source_id, address, address_norm, address_components,
+			status, attempts, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')::jsonb, 'pending', 0, now(), now())
 		ON CONFLICT (source_table, source_id) DO UPDATE SET
 			address = EXCLUDED.address,
+			address_norm = EXCLUDED.address_norm,
+			address_components = EXCLUDED.address_components,
 			status = 'pending',
 			attempts = 0,
 			error = NULL,
 			updated_at = now()`,
-		sourceTable, sourceID, address,
+		sourceTable, sourceID, address, norm, components,
 	)
 	return eris.Wrap(err, "geocode queue: enqueue")
 }
 
-// EnqueueBatch inserts multiple items into the geocode queue for a given source table.
-func (q *GeocodeQueue) EnqueueBatch(ctx context.Context, sourceTable string, items []QueueItem) error {
-	if len(items) == 0 {
-		return nil
-	}
+// EnqueueBatch inserts items into the geocode queue for a given source table
+// in multi-row chunks. Items already queued or geocoded at the same address
+// are left alone, so re-enqueueing the same rows is cheap; failed items get
+// a fresh set of attempts. When a
+// max depth is set, enqueueing pauses once the queue is that deep; the
+// remaining items are left for a later call, whose source query finds them
+// again since those rows are still ungeocoded. Returns the number of queue
+// rows inserted or reset.
+func (q *GeocodeQueue) EnqueueBatch(ctx context.Context, sourceTable string, items []QueueItem) (int, error) {
+	items = dedupeQueueItems(items)
 
-	tx, err := q.pool.Begin(ctx)
-	if err != nil {
-		return eris.Wrap(err, "geocode queue: begin tx for batch enqueue")
-	}
-	defer func() { _ = tx.Rollback(ctx) }()
+	var enqueued int
+	for start := 0; start < len(items); start += enqueueChunkSize {
+		chunk := items[start:min(start+enqueueChunkSize, len(items))]
 
-	for _, item := range items {
-		_, err := tx.Exec(ctx, `
-			INSERT INTO geo.geocode_queue (source_table, source_id, address, status, attempts, created_at, updated_at)
-			VALUES ($1, $2, $3, 'pending', 0, now(), now())
+		// Backpressure: only fill the room left under maxDepth.
+		full := false
+		if q.maxDepth > 0 {
+			depth, err := q.Depth(ctx)
+			if err != nil {
+				return enqueued, err
+			}
+			room := q.maxDepth - depth
+			if room <= 0 {
+				q.logPaused(sourceTable, depth, len(items)-start)
+				return enqueued, nil
+			}
+			if room < len(chunk) {
+				chunk = chunk[:room]
+				full = true
+			}
+		}
+
+		ids := make([]string, len(chunk))
+		addresses := make([]string, len(chunk))
+		norms := make([]string, len(chunk))
+		components := make([]string, len(chunk))
+		for i, item := range chunk {
+			ids[i] = item.SourceID
+			addresses[i] = item.Address
+			norms[i], components[i] = normalizeAddress(item.Address)
+		}
+		tag, err := q.pool.Exec(ctx, `
+			INSERT INTO geo.geocode_queue (source_table, source_id, address, address_norm, address_components,
+				status, attempts, created_at, updated_at)
+			SELECT $1, s.source_id, s.address, NULLIF(s.address_norm, ''), NULLIF(s.address_components, '')::jsonb,
+				'pending', 0, now(), now()
+			FROM unnest($2::text[], $3::text[], $4::text[], $5::text[])
+				AS s(source_id, address, address_norm, address_components)
 			ON CONFLICT (source_table, source_id) DO UPDATE SET
 				address = EXCLUDED.address,
+				address_norm = EXCLUDED.address_norm,
+				address_components = EXCLUDED.address_components,
 				status = 'pending',
 				attempts = 0,
 				error = NULL,
-				updated_at = now()`,
-			sourceTable, item.SourceID, item.Address,
+				updated_at = now()
+			WHERE geo.geocode_queue.status = 'failed'
+			   OR geo.geocode_queue.address IS DISTINCT FROM EXCLUDED.address`,
+			sourceTable, ids, addresses, norms, components,
 		)
 		if err != nil {
-			return eris.Wrapf(err, "geocode queue: enqueue batch item %s", item.SourceID)
+			return enqueued, eris.Wrapf(err, "geocode queue: enqueue batch of %d for %s", len(chunk), sourceTable)
+		}
+		enqueued += int(tag.RowsAffected())
+
+		if full {
+			q.logPaused(sourceTable, q.maxDepth, len(items)-start-len(chunk))
+			return enqueued, nil
 		}
 	}
+	return enqueued, nil
+}
+
+// logPaused records that EnqueueBatch stopped at the depth limit.
+func (q *GeocodeQueue) logPaused(sourceTable string, depth, deferred int) {
+	zap.L().Warn("geocode queue: max depth reached, pausing enqueue",
+		zap.String("source_table", sourceTable),
+		zap.Int("depth", depth),
+		zap.Int("max_depth", q.maxDepth),
+		zap.Int("deferred", deferred),
+	)
+}
 
-	return eris.Wrap(tx.Commit(ctx), "geocode queue: commit batch enqueue")
+// dedupeQueueItems drops repeated source IDs, keeping the last address, since
+// one INSERT ... ON CONFLICT statement cannot touch the same row twice.
+func dedupeQueueItems(items []QueueItem) []QueueItem {
+	idx := make(map[string]int, len(items))
+	out := make([]QueueItem, 0, len(items))
+	for _, item := range items {
+		if i, ok := idx[item.SourceID]; ok {
+			out[i] = item
+			continue
+		}
+		idx[item.SourceID] = len(out)
+		out = append(out, item)
+	}
+	return out
 }
 
 // queueRow holds a row claimed from the geocode queue.
@@ -95,26 +203,71 @@ type queueRow struct {
 }
 
 // ProcessBatch claims up to batchSize pending items, geocodes them, and updates results.
-// Returns the number of items processed.
+// Returns the number of items processed. A queue built without a geocoder
+// leaves pending items for the geocode worker.
 func (q *GeocodeQueue) ProcessBatch(ctx context.Context) (int, error) {
-	tx, err := q.pool.Begin(ctx)
+	if q.geocoder == nil {
+		return 0, nil
+	}
+	claimed, err := claimQueueRows(ctx, q.pool, q.batchSize)
+	if err != nil || len(claimed) == 0 {
+		return 0, err
+	}
+
+	// Geocode each claimed row outside the transaction.
+	processed := 0
+	for _, row := range claimed {
+		result, gcErr := q.geocoder.Geocode(ctx, geocode.AddressInput{
+			ID:     row.SourceID,
+			Street: row.Address,
+		})
+
+		if gcErr != nil {
+			zap.L().Warn("geocode queue: geocode failed",
+				zap.Int("queue_id", row.ID),
+				zap.String("source_id", row.SourceID),
+				zap.Error(gcErr),
+			)
+			q.markFailed(ctx, row.ID, gcErr.Error())
+			processed++
+			continue
+		}
+
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			q.markFailed(ctx, row.ID, fmt.Sprintf("marshal result: %v", err))
+			processed++
+			continue
+		}
+
+		q.markComplete(ctx, row.ID, resultJSON)
+		processed++
+	}
+
+	return processed, nil
+}
+
+// claimQueueRows claims up to limit pending rows that are due, marking them
+// processing and counting the attempt. FOR UPDATE SKIP LOCKED lets
+// concurrent workers claim disjoint rows.
+func claimQueueRows(ctx context.Context, pool db.Pool, limit int) ([]queueRow, error) {
+	tx, err := pool.Begin(ctx)
 	if err != nil {
-		return 0, eris.Wrap(err, "geocode queue: begin tx")
+		return nil, eris.Wrap(err, "geocode queue: begin tx")
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	// Claim pending rows with FOR UPDATE SKIP LOCKED to allow concurrent workers.
 	rows, err := tx.Query(ctx, `
 		SELECT id, source_table, source_id, address
 		FROM geo.geocode_queue
-		WHERE status = 'pending'
+		WHERE status = 'pending' AND next_attempt_at <= now()
 		ORDER BY created_at
 		LIMIT $1
 		FOR UPDATE SKIP LOCKED`,
-		q.batchSize,
+		limit,
 	)
 	if err != nil {
-		return 0, eris.Wrap(err, "geocode queue: claim rows")
+		return nil, eris.Wrap(err, "geocode queue: claim rows")
 	}
 
 	var claimed []queueRow
@@ -122,21 +275,20 @@ func (q *GeocodeQueue) ProcessBatch(ctx context.Context) (int, error) {
 		var r queueRow
 		if err := rows.Scan(&r.ID, &r.SourceTable, &r.SourceID, &r.Address); err != nil {
 			rows.Close()
-			return 0, eris.Wrap(err, "geocode queue: scan row")
+			return nil, eris.Wrap(err, "geocode queue: scan row")
 		}
 		claimed = append(claimed, r)
 	}
 	rows.Close()
 	if err := rows.Err(); err != nil {
-		return 0, eris.Wrap(err, "geocode queue: iterate rows")
+		return nil, eris.Wrap(err, "geocode queue: iterate rows")
 	}
 
 	if len(claimed) == 0 {
 		_ = tx.Commit(ctx)
-		return 0, nil
+		return nil, nil
 	}
 
-	// Mark claimed rows as processing.
 	ids := make([]int, len(claimed))
 	for i, r := range claimed {
 		ids[i] = r.ID
@@ -148,44 +300,13 @@ func (q *GeocodeQueue) ProcessBatch(ctx context.Context) (int, error) {
 		ids,
 	)
 	if err != nil {
-		return 0, eris.Wrap(err, "geocode queue: mark processing")
+		return nil, eris.Wrap(err, "geocode queue: mark processing")
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return 0, eris.Wrap(err, "geocode queue: commit claim")
+		return nil, eris.Wrap(err, "geocode queue: commit claim")
 	}
-
-	// Geocode each claimed row outside the transaction.
-	processed := 0
-	for _, row := range claimed {
-		result, gcErr := q.geocoder.Geocode(ctx, geocode.AddressInput{
-			ID:     row.SourceID,
-			Street: row.Address,
-		})
-
-		if gcErr != nil {
-			zap.L().Warn("geocode queue: geocode failed",
-				zap.Int("queue_id", row.ID),
-				zap.String("source_id", row.SourceID),
-				zap.Error(gcErr),
-			)
-			q.markFailed(ctx, row.ID, gcErr.Error())
-			processed++
-			continue
-		}
-
-		resultJSON, err := json.Marshal(result)
-		if err != nil {
-			q.markFailed(ctx, row.ID, fmt.Sprintf("marshal result: %v", err))
-			processed++
-			continue
-		}
-
-		q.markComplete(ctx, row.ID, resultJSON)
-		processed++
-	}
-
-	return processed, nil
+	return claimed, nil
 }
 
 // markComplete updates a queue row to complete with the geocode result.
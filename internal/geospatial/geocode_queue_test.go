@@ -46,7 +46,8 @@ func TestEnqueue_Success(t *testing.T) {
 	defer mock.Close()
 
 	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("geo.poi", "123", "100 Main St, Miami, FL").
+		WithArgs("geo.poi", "123", "100 Main St, Miami, FL", "100 MAIN ST, MIAMI, FL",
+			`{"number":"100","street_name":"MAIN","suffix":"ST","city":"MIAMI","state":"FL"}`).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	q := NewGeocodeQueue(mock, nil, 100)
@@ -62,7 +63,7 @@ func TestEnqueue_Error(t *testing.T) {
 	defer mock.Close()
 
 	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("geo.poi", "123", "100 Main St").
+		WithArgs("geo.poi", "123", "100 Main St", pgxmock.AnyArg(), pgxmock.AnyArg()).
 		WillReturnError(fmt.Errorf("connection refused"))
 
 	q := NewGeocodeQueue(mock, nil, 100)
@@ -78,49 +79,110 @@ func TestEnqueueBatch_Success(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectBegin()
-	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("geo.poi", "1", "100 Main St").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("geo.poi", "2", "200 Main St").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-	mock.ExpectCommit()
+	// One multi-row statement; the repeated source ID keeps its last address.
+	mock.ExpectExec(`INSERT INTO geo.geocode_queue .* unnest`).
+		WithArgs("geo.poi", []string{"1", "2"}, []string{"101 Main St", "200 Main St"},
+			[]string{"101 MAIN ST", "200 MAIN ST"}, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
 
 	q := NewGeocodeQueue(mock, nil, 100)
-	err = q.EnqueueBatch(context.Background(), "geo.poi", []QueueItem{
+	n, err := q.EnqueueBatch(context.Background(), "geo.poi", []QueueItem{
 		{SourceID: "1", Address: "100 Main St"},
 		{SourceID: "2", Address: "200 Main St"},
+		{SourceID: "1", Address: "101 Main St"},
 	})
 
 	require.NoError(t, err)
+	assert.Equal(t, 2, n)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestEnqueueBatch_Empty(t *testing.T) {
 	q := NewGeocodeQueue(nil, nil, 100)
-	err := q.EnqueueBatch(context.Background(), "geo.poi", nil)
+	n, err := q.EnqueueBatch(context.Background(), "geo.poi", nil)
 	require.NoError(t, err)
+	assert.Zero(t, n)
 }
 
-func TestEnqueueBatch_ItemError(t *testing.T) {
+func TestEnqueueBatch_ExecError(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
-		WithArgs("geo.poi", "1", "100 Main St").
-		WillReturnError(fmt.Errorf("duplicate key"))
-	mock.ExpectRollback()
+		WithArgs("geo.poi", []string{"1"}, []string{"100 Main St"}, pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(fmt.Errorf("connection reset"))
 
 	q := NewGeocodeQueue(mock, nil, 100)
-	err = q.EnqueueBatch(context.Background(), "geo.poi", []QueueItem{
+	_, err = q.EnqueueBatch(context.Background(), "geo.poi", []QueueItem{
 		{SourceID: "1", Address: "100 Main St"},
 	})
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "enqueue batch item")
+	assert.Contains(t, err.Error(), "enqueue batch of 1 for geo.poi")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnqueueBatch_Chunks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	items := make([]QueueItem, enqueueChunkSize+5)
+	for i := range items {
+		items[i] = QueueItem{SourceID: fmt.Sprint(i), Address: fmt.Sprintf("%d Main St", i)}
+	}
+	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
+		WithArgs("geo.poi", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", enqueueChunkSize))
+	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
+		WithArgs("geo.poi", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 3))
+
+	q := NewGeocodeQueue(mock, nil, 100)
+	n, err := q.EnqueueBatch(context.Background(), "geo.poi", items)
+
+	require.NoError(t, err)
+	assert.Equal(t, enqueueChunkSize+3, n, "already-pending items are not counted")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnqueueBatch_Backpressure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	items := []QueueItem{
+		{SourceID: "1", Address: "100 Main St"},
+		{SourceID: "2", Address: "200 Main St"},
+		{SourceID: "3", Address: "300 Main St"},
+	}
+
+	// Room for two more: the chunk is trimmed and the rest deferred.
+	mock.ExpectQuery(`SELECT count\(\*\) FROM geo.geocode_queue`).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(8))
+	mock.ExpectExec(`INSERT INTO geo.geocode_queue`).
+		WithArgs("geo.poi", []string{"1", "2"}, []string{"100 Main St", "200 Main St"}, pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	q := NewGeocodeQueue(mock, nil, 100).WithMaxDepth(10)
+	n, err := q.EnqueueBatch(context.Background(), "geo.poi", items)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// Full queue: nothing is inserted.
+	mock.ExpectQuery(`SELECT count\(\*\) FROM geo.geocode_queue`).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(12))
+	n, err = q.EnqueueBatch(context.Background(), "geo.poi", items)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// Depth query failure surfaces.
+	mock.ExpectQuery(`SELECT count\(\*\) FROM geo.geocode_queue`).
+		WillReturnError(fmt.Errorf("timeout"))
+	_, err = q.EnqueueBatch(context.Background(), "geo.poi", items)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "geocode queue: depth")
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -254,6 +316,20 @@ func TestProcessBatch_MultipleItems(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestProcessBatch_NoGeocoder(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// Without a geocoder nothing is claimed; the geocode worker drains the queue.
+	q := NewGeocodeQueue(mock, nil, 100)
+	n, err := q.ProcessBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestProcessBatch_BeginError(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -261,7 +337,7 @@ func TestProcessBatch_BeginError(t *testing.T) {
 
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("connection refused"))
 
-	q := NewGeocodeQueue(mock, nil, 100)
+	q := NewGeocodeQueue(mock, &mockGeocodeClient{}, 100)
 	_, err = q.ProcessBatch(context.Background())
 
 	require.Error(t, err)
@@ -280,7 +356,7 @@ func TestProcessBatch_ClaimQueryError(t *testing.T) {
 		WillReturnError(fmt.Errorf("query error"))
 	mock.ExpectRollback()
 
-	q := NewGeocodeQueue(mock, nil, 100)
+	q := NewGeocodeQueue(mock, &mockGeocodeClient{}, 100)
 	_, err = q.ProcessBatch(context.Background())
 
 	require.Error(t, err)
@@ -395,7 +471,7 @@ func TestProcessBatch_ScanError(t *testing.T) {
 		)
 	mock.ExpectRollback()
 
-	q := NewGeocodeQueue(mock, nil, 100)
+	q := NewGeocodeQueue(mock, &mockGeocodeClient{}, 100)
 	_, err = q.ProcessBatch(context.Background())
 
 	require.Error(t, err)
@@ -420,7 +496,7 @@ func TestProcessBatch_MarkProcessingError(t *testing.T) {
 		WillReturnError(fmt.Errorf("mark processing failed"))
 	mock.ExpectRollback()
 
-	q := NewGeocodeQueue(mock, nil, 100)
+	q := NewGeocodeQueue(mock, &mockGeocodeClient{}, 100)
 	_, err = q.ProcessBatch(context.Background())
 
 	require.Error(t, err)
@@ -445,7 +521,7 @@ func TestProcessBatch_CommitClaimError(t *testing.T) {
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 	mock.ExpectCommit().WillReturnError(fmt.Errorf("commit failed"))
 
-	q := NewGeocodeQueue(mock, nil, 100)
+	q := NewGeocodeQueue(mock, &mockGeocodeClient{}, 100)
 	_, err = q.ProcessBatch(context.Background())
 
 	require.Error(t, err)
@@ -453,23 +529,6 @@ func TestProcessBatch_CommitClaimError(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestEnqueueBatch_BeginError(t *testing.T) {
-	mock, err := pgxmock.NewPool()
-	require.NoError(t, err)
-	defer mock.Close()
-
-	mock.ExpectBegin().WillReturnError(fmt.Errorf("connection refused"))
-
-	q := NewGeocodeQueue(mock, nil, 100)
-	err = q.EnqueueBatch(context.Background(), "geo.poi", []QueueItem{
-		{SourceID: "1", Address: "100 Main St"},
-	})
-
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "begin tx")
-	require.NoError(t, mock.ExpectationsWereMet())
-}
-
 func TestProcessBatch_MarkFailedError(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
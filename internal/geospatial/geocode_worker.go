@@ -0,0 +1,675 @@
+package geospatial
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+// BatchGeocoder geocodes many addresses in one request, correlating
+// results by AddressInput.ID.
+type BatchGeocoder interface {
+	GeocodeBatch(ctx context.Context, addrs []geocode.AddressInput) ([]geocode.BatchMatch, error)
+}
+
+// DefaultGeocodeMaxAttempts is how many times a queue item is tried
+// before it is marked failed.
+const DefaultGeocodeMaxAttempts = 3
+
+// geocodeRetryBase is the delay before the first retry; each later retry
+// doubles it.
+const geocodeRetryBase = time.Minute
+
+// GeocodeWorkerStats counts the outcomes of a worker run.
+type GeocodeWorkerStats struct {
+	Batches int `json:"batches"`
+	Claimed int `json:"claimed"`
+	Matched int `json:"matched"`
+	NoMatch int `json:"no_match"`
+	Retried int `json:"retried"`
+	Failed  int `json:"failed"`
+	// Fallback counts matches made by the fallback provider chain.
+	Fallback int `json:"fallback"`
+	// CacheHits counts items resolved from geo.geocode_cache.
+	CacheHits int `json:"cache_hits"`
+	// Deduped counts items that shared a geocode with an earlier item in
+	// the same batch.
+	Deduped int `json:"deduped"`
+	// Assigned counts locations given county, tract, place, and CBSA by
+	// the spatial assigner after the run.
+	Assigned int `json:"assigned"`
+}
+
+func (s *GeocodeWorkerStats) add(o *GeocodeWorkerStats) {
+	s.Batches += o.Batches
+	s.Claimed += o.Claimed
+	s.Matched += o.Matched
+	s.NoMatch += o.NoMatch
+	s.Retried += o.Retried
+	s.Failed += o.Failed
+	s.Fallback += o.Fallback
+	s.CacheHits += o.CacheHits
+	s.Deduped += o.Deduped
+	s.Assigned += o.Assigned
+}
+
+// GeocodeWorker drains geo.geocode_queue through a batch geocoder, writing
+// matches to geo.locations. Unmatched and tied addresses are marked
+// no_match unless a fallback client matches them; transient errors return
+// items to pending with exponential backoff until maxAttempts is reached,
+// after which they are marked failed.
+type GeocodeWorker struct {
+	pool         db.Pool
+	geocoder     BatchGeocoder
+	fallback     geocode.Client
+	batchSize    int
+	maxAttempts  int
+	cache        bool
+	cacheTTLDays int
+	assigner     *SpatialAssigner
+}
+
+// NewGeocodeWorker creates a GeocodeWorker. batchSize is capped at the
+// Census batch limit; zero values use the defaults.
+func NewGeocodeWorker(pool db.Pool, geocoder BatchGeocoder, batchSize, maxAttempts int) *GeocodeWorker {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	if batchSize > geocode.CensusBatchMaxRecords {
+		batchSize = geocode.CensusBatchMaxRecords
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultGeocodeMaxAttempts
+	}
+	return &GeocodeWorker{
+		pool:        pool,
+		geocoder:    geocoder,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// WithFallback sets a single-address client (typically a cost-ordered
+// CascadeClient) tried for addresses the batch geocoder could not match.
+func (w *GeocodeWorker) WithFallback(c geocode.Client) *GeocodeWorker {
+	w.fallback = c
+	return w
+}
+
+// WithCache resolves addresses from geo.geocode_cache before calling the
+// batch geocoder and stores new results there, so an address queued from
+// several sources is geocoded once. Entries older than ttlDays are ignored
+// (0 = no expiry).
+func (w *GeocodeWorker) WithCache(ttlDays int) *GeocodeWorker {
+	w.cache = true
+	w.cacheTTLDays = ttlDays
+	return w
+}
+
+// WithSpatialAssign runs an incremental spatial assignment after Run matches
+// any addresses, so new locations get county, tract, place, and CBSA from
+// the synced boundaries rather than only what the geocoder returned.
+func (w *GeocodeWorker) WithSpatialAssign(a *SpatialAssigner) *GeocodeWorker {
+	w.assigner = a
+	return w
+}
+
+// Run requeues items stranded in processing by a crashed worker, then
+// processes batches until the queue has nothing due or maxBatches batches
+// have run (0 = no limit).
+func (w *GeocodeWorker) Run(ctx context.Context, maxBatches int) (*GeocodeWorkerStats, error) {
+	total := &GeocodeWorkerStats{}
+	if _, err := w.RequeueStale(ctx, time.Hour); err != nil {
+		return total, err
+	}
+	for maxBatches <= 0 || total.Batches < maxBatches {
+		if err := ctx.Err(); err != nil {
+			return total, eris.Wrap(err, "geocode worker: canceled")
+		}
+		stats, err := w.RunOnce(ctx)
+		if stats != nil {
+			total.add(stats)
+		}
+		if err != nil {
+			return total, err
+		}
+		if stats.Claimed == 0 {
+			break
+		}
+		zap.L().Info("geocode worker: batch complete",
+			zap.Int("claimed", stats.Claimed),
+			zap.Int("matched", stats.Matched),
+			zap.Int("no_match", stats.NoMatch),
+			zap.Int("retried", stats.Retried),
+			zap.Int("failed", stats.Failed),
+			zap.Int("fallback", stats.Fallback),
+			zap.Int("cache_hits", stats.CacheHits),
+			zap.Int("deduped", stats.Deduped),
+		)
+	}
+	if w.assigner != nil && total.Matched > 0 {
+		assigned, err := w.assigner.Run(ctx, SpatialAssignOpts{})
+		if err != nil {
+			zap.L().Warn("geocode worker: spatial assignment failed", zap.Error(err))
+		} else {
+			total.Assigned = assigned.Scanned
+		}
+	}
+	return total, nil
+}
+
+// RequeueStale returns items that have been processing for longer than
+// olderThan to pending. Returns the number of items requeued.
+func (w *GeocodeWorker) RequeueStale(ctx context.Context, olderThan time.Duration) (int, error) {
+	tag, err := w.pool.Exec(ctx, `
+		UPDATE geo.geocode_queue
+		SET status = 'pending', updated_at = now()
+		WHERE status = 'processing' AND updated_at < now() - make_interval(secs => $1)`,
+		olderThan.Seconds(),
+	)
+	if err != nil {
+		return 0, eris.Wrap(err, "geocode worker: requeue stale")
+	}
+	if n := tag.RowsAffected(); n > 0 {
+		zap.L().Warn("geocode worker: requeued stale items", zap.Int64("count", n))
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// RunOnce claims one batch of due items and geocodes it. A geocoder error
+// reschedules the whole batch and is returned.
+func (w *GeocodeWorker) RunOnce(ctx context.Context) (*GeocodeWorkerStats, error) {
+	claimed, err := claimQueueRows(ctx, w.pool, w.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	stats := &GeocodeWorkerStats{Claimed: len(claimed)}
+	if len(claimed) == 0 {
+		return stats, nil
+	}
+	stats.Batches = 1
+
+	// Rows with the same normalized address share one geocode.
+	keys := make([]string, len(claimed))
+	var (
+		distinct []geocode.AddressInput
+		norms    = make(map[string]string, len(claimed))
+	)
+	for i, row := range claimed {
+		in := geocode.ParseOneLine(strconv.Itoa(row.ID), row.Address)
+		keys[i] = geocode.CacheKey(in)
+		if _, ok := norms[keys[i]]; !ok {
+			norms[keys[i]] = geocode.CacheAddress(geocode.NormalizeInput(in))
+			distinct = append(distinct, in)
+		}
+	}
+	stats.Deduped = len(claimed) - len(distinct)
+
+	outcomes, err := w.cached(ctx, distinct)
+	if err != nil {
+		return stats, err
+	}
+	var inputs []geocode.AddressInput
+	for _, in := range distinct {
+		if _, ok := outcomes[geocode.CacheKey(in)]; !ok {
+			inputs = append(inputs, in)
+		}
+	}
+
+	var (
+		fresh       []string
+		missing     = make(map[string]bool)
+		fallbackErr = make(map[string]bool)
+		lastErr     error
+	)
+	if len(inputs) > 0 {
+		matches, gcErr := w.geocoder.GeocodeBatch(ctx, inputs)
+		if gcErr != nil {
+			ids := make([]int, len(claimed))
+			for i, row := range claimed {
+				ids[i] = row.ID
+			}
+			if err := w.retry(ctx, ids, gcErr.Error(), stats); err != nil {
+				return stats, err
+			}
+			return stats, eris.Wrap(gcErr, "geocode worker: batch geocode")
+		}
+
+		byID := make(map[string]geocode.BatchMatch, len(matches))
+		for _, m := range matches {
+			byID[m.ID] = m
+		}
+		for _, in := range inputs {
+			key := geocode.CacheKey(in)
+			m, ok := byID[in.ID]
+			if !ok {
+				missing[key] = true
+				continue
+			}
+			o, err := w.resolve(ctx, in, m)
+			if err != nil {
+				fallbackErr[key] = true
+				lastErr = err
+				continue
+			}
+			outcomes[key] = o
+			fresh = append(fresh, key)
+		}
+	}
+
+	var (
+		loc         locationRows
+		done        queueResults
+		missingIDs  []int
+		fallbackIDs []int
+	)
+	for i, row := range claimed {
+		switch {
+		case missing[keys[i]]:
+			missingIDs = append(missingIDs, row.ID)
+			continue
+		case fallbackErr[keys[i]]:
+			fallbackIDs = append(fallbackIDs, row.ID)
+			continue
+		}
+		o := outcomes[keys[i]]
+		if o.cached {
+			stats.CacheHits++
+		}
+		if !o.matched {
+			done.add(row.ID, "no_match", o.result, o.status)
+			stats.NoMatch++
+			continue
+		}
+		loc.add(row, o)
+		done.add(row.ID, "complete", o.result, "")
+		stats.Matched++
+		if o.fallback {
+			stats.Fallback++
+		}
+	}
+
+	if err := w.record(ctx, loc, done); err != nil {
+		return stats, err
+	}
+	w.storeCache(ctx, outcomes, fresh, norms)
+	if len(missingIDs) > 0 {
+		if err := w.retry(ctx, missingIDs, "missing from geocoder response", stats); err != nil {
+			return stats, err
+		}
+	}
+	if len(fallbackIDs) > 0 {
+		if err := w.retry(ctx, fallbackIDs, "fallback: "+lastErr.Error(), stats); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// resolve turns a batch response into an outcome, trying the fallback
+// client for addresses the batch geocoder could not match. A fallback
+// error is returned so the address is retried.
+func (w *GeocodeWorker) resolve(ctx context.Context, in geocode.AddressInput, m geocode.BatchMatch) (*geocodeOutcome, error) {
+	if m.Matched() {
+		return batchOutcome(m)
+	}
+	if w.fallback != nil {
+		r, err := w.fallback.Geocode(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil && r.Matched {
+			o, err := resultOutcome(r)
+			if err != nil {
+				return nil, err
+			}
+			o.fallback = true
+			return o, nil
+		}
+	}
+	return batchOutcome(m)
+}
+
+// record writes matched locations and final queue statuses in one
+// transaction.
+func (w *GeocodeWorker) record(ctx context.Context, loc locationRows, done queueResults) error {
+	if len(done.ids) == 0 {
+		return nil
+	}
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return eris.Wrap(err, "geocode worker: begin tx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if len(loc.sourceIDs) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO geo.locations (source_table, source_id, address, address_norm, address_components,
+				matched_address, match_type, latitude, longitude, geom, state_fips, county_fips, tract_geoid,
+				source, confidence, geocoded_at)
+			SELECT s.source_table, s.source_id, s.address, q.address_norm, q.address_components,
+				NULLIF(s.matched_address, ''), NULLIF(s.match_type, ''),
+				s.latitude, s.longitude, ST_SetSRID(ST_MakePoint(s.longitude, s.latitude), 4326),
+				NULLIF(s.state_fips, ''), NULLIF(s.county_fips, ''), NULLIF(s.tract_geoid, ''),
+				s.source, s.confidence, now()
+			FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[],
+				$6::float8[], $7::float8[], $8::text[], $9::text[], $10::text[], $11::text[], $12::float8[])
+				AS s(source_table, source_id, address, matched_address, match_type,
+					latitude, longitude, state_fips, county_fips, tract_geoid, source, confidence)
+			LEFT JOIN geo.geocode_queue q ON q.source_table = s.source_table AND q.source_id = s.source_id
+			ON CONFLICT (source_table, source_id) DO UPDATE SET
+				address = EXCLUDED.address,
+				address_norm = EXCLUDED.address_norm,
+				address_components = EXCLUDED.address_components,
+				matched_address = EXCLUDED.matched_address,
+				match_type = EXCLUDED.match_type,
+				latitude = EXCLUDED.latitude,
+				longitude = EXCLUDED.longitude,
+				geom = EXCLUDED.geom,
+				state_fips = EXCLUDED.state_fips,
+				county_fips = EXCLUDED.county_fips,
+				tract_geoid = EXCLUDED.tract_geoid,
+				source = EXCLUDED.source,
+				confidence = EXCLUDED.confidence,
+				geocoded_at = EXCLUDED.geocoded_at,
+				spatial_assigned_at = NULL`,
+			loc.sourceTables, loc.sourceIDs, loc.addresses, loc.matchedAddresses, loc.matchTypes,
+			loc.latitudes, loc.longitudes, loc.stateFIPS, loc.countyFIPS, loc.tractGEOIDs,
+			loc.sources, loc.confidences,
+		)
+		if err != nil {
+			return eris.Wrapf(err, "geocode worker: upsert %d locations", len(loc.sourceIDs))
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE geo.geocode_queue q
+		SET status = r.status, result = r.result, error = NULLIF(r.error, ''), updated_at = now()
+		FROM unnest($1::bigint[], $2::text[], $3::jsonb[], $4::text[]) AS r(id, status, result, error)
+		WHERE q.id = r.id`,
+		done.ids, done.statuses, done.results, done.errors,
+	)
+	if err != nil {
+		return eris.Wrapf(err, "geocode worker: update %d queue items", len(done.ids))
+	}
+	return eris.Wrap(tx.Commit(ctx), "geocode worker: commit")
+}
+
+// cached returns outcomes from geo.geocode_cache for addrs, keyed by
+// geocode.CacheKey. The map is empty when the cache is disabled. The tiger
+// and cascade clients share the cache, but their non-matches say nothing
+// about what the Census batch geocoder would return, so only non-matches
+// the worker itself stored (source census) are final.
+func (w *GeocodeWorker) cached(ctx context.Context, addrs []geocode.AddressInput) (map[string]*geocodeOutcome, error) {
+	outcomes := make(map[string]*geocodeOutcome, len(addrs))
+	if !w.cache || len(addrs) == 0 {
+		return outcomes, nil
+	}
+	keys := make([]string, len(addrs))
+	for i, in := range addrs {
+		keys[i] = geocode.CacheKey(in)
+	}
+
+	rows, err := w.pool.Query(ctx, `
+		SELECT address_hash, matched, latitude, longitude, quality, county_fips,
+			source, confidence, matched_address, tract_geoid
+		FROM geo.geocode_cache
+		WHERE address_hash = ANY($1)
+			AND (matched OR source = 'census')
+			AND ($2 <= 0 OR cached_at > now() - make_interval(days => $2))`,
+		keys, w.cacheTTLDays,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "geocode worker: read cache")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key                                            string
+			r                                              geocode.Result
+			countyFIPS, source, matchedAddress, tractGEOID *string
+			confidence                                     *float64
+		)
+		if err := rows.Scan(&key, &r.Matched, &r.Latitude, &r.Longitude, &r.Quality, &countyFIPS,
+			&source, &confidence, &matchedAddress, &tractGEOID); err != nil {
+			return nil, eris.Wrap(err, "geocode worker: scan cache")
+		}
+		r.CountyFIPS = derefString(countyFIPS)
+		r.Source = cmp.Or(derefString(source), "cache")
+		if confidence != nil {
+			r.Confidence = *confidence
+		}
+		o, err := resultOutcome(&r)
+		if err != nil {
+			return nil, err
+		}
+		o.matchedAddress, o.tractGEOID = derefString(matchedAddress), derefString(tractGEOID)
+		if !r.Matched {
+			o.status = "cached no_match"
+		}
+		o.cached = true
+		outcomes[key] = o
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "geocode worker: read cache")
+	}
+	for _, key := range keys {
+		if _, ok := outcomes[key]; ok {
+			opsmetrics.RecordCacheEvent("hit", "geocode", "postgres")
+		} else {
+			opsmetrics.RecordCacheEvent("miss", "geocode", "postgres")
+		}
+	}
+	return outcomes, nil
+}
+
+// storeCache writes newly geocoded outcomes to geo.geocode_cache. The cache
+// is best-effort: a failed write is logged, not returned.
+func (w *GeocodeWorker) storeCache(ctx context.Context, outcomes map[string]*geocodeOutcome, keys []string, norms map[string]string) {
+	if !w.cache || len(keys) == 0 {
+		return
+	}
+	var c cacheRows
+	for _, key := range keys {
+		c.add(key, norms[key], outcomes[key])
+	}
+	_, err := w.pool.Exec(ctx, `
+		INSERT INTO geo.geocode_cache (address_hash, address_norm, matched, latitude, longitude, quality, county_fips,
+			source, confidence, matched_address, tract_geoid, cached_at)
+		SELECT s.address_hash, NULLIF(s.address_norm, ''), s.matched, s.latitude, s.longitude, s.quality, NULLIF(s.county_fips, ''),
+			s.source, s.confidence, NULLIF(s.matched_address, ''), NULLIF(s.tract_geoid, ''), now()
+		FROM unnest($1::text[], $2::bool[], $3::float8[], $4::float8[], $5::text[], $6::text[],
+			$7::text[], $8::float8[], $9::text[], $10::text[], $11::text[])
+			AS s(address_hash, matched, latitude, longitude, quality, county_fips,
+				source, confidence, matched_address, tract_geoid, address_norm)
+		ON CONFLICT (address_hash) DO UPDATE SET
+			address_norm = EXCLUDED.address_norm,
+			matched = EXCLUDED.matched,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			quality = EXCLUDED.quality,
+			county_fips = EXCLUDED.county_fips,
+			source = EXCLUDED.source,
+			confidence = EXCLUDED.confidence,
+			matched_address = EXCLUDED.matched_address,
+			tract_geoid = EXCLUDED.tract_geoid,
+			cached_at = EXCLUDED.cached_at`,
+		c.keys, c.matched, c.latitudes, c.longitudes, c.qualities, c.countyFIPS,
+		c.sources, c.confidences, c.matchedAddresses, c.tractGEOIDs, c.norms,
+	)
+	if err != nil {
+		zap.L().Warn("geocode worker: store cache", zap.Int("count", len(keys)), zap.Error(err))
+		return
+	}
+	for range keys {
+		opsmetrics.RecordCacheEvent("set", "geocode", "postgres")
+	}
+}
+
+// retry returns items to pending with exponential backoff, or marks them
+// failed once they have used maxAttempts.
+func (w *GeocodeWorker) retry(ctx context.Context, ids []int, errMsg string, stats *GeocodeWorkerStats) error {
+	var failed int
+	err := w.pool.QueryRow(ctx, `
+		WITH u AS (
+			UPDATE geo.geocode_queue
+			SET status = CASE WHEN attempts >= $2 THEN 'failed' ELSE 'pending' END,
+				error = $3,
+				next_attempt_at = now() + make_interval(secs => $4 * power(2, GREATEST(attempts - 1, 0))),
+				updated_at = now()
+			WHERE id = ANY($1)
+			RETURNING status
+		)
+		SELECT count(*) FILTER (WHERE status = 'failed') FROM u`,
+		ids, w.maxAttempts, errMsg, geocodeRetryBase.Seconds(),
+	).Scan(&failed)
+	if err != nil {
+		return eris.Wrap(err, "geocode worker: reschedule")
+	}
+	stats.Failed += failed
+	stats.Retried += len(ids) - failed
+	return nil
+}
+
+// locationRows holds column arrays for the geo.locations upsert.
+type locationRows struct {
+	sourceTables, sourceIDs, addresses, matchedAddresses, matchTypes []string
+	latitudes, longitudes                                            []float64
+	stateFIPS, countyFIPS, tractGEOIDs, sources                      []string
+	confidences                                                      []float64
+}
+
+// censusBatchConfidence maps Census batch match types to 0-1 confidence.
+var censusBatchConfidence = map[string]float64{
+	"Exact":     1.0,
+	"Non_Exact": 0.8,
+}
+
+func (l *locationRows) add(row queueRow, o *geocodeOutcome) {
+	l.sourceTables = append(l.sourceTables, row.SourceTable)
+	l.sourceIDs = append(l.sourceIDs, row.SourceID)
+	l.addresses = append(l.addresses, row.Address)
+	l.matchedAddresses = append(l.matchedAddresses, o.matchedAddress)
+	l.matchTypes = append(l.matchTypes, o.matchType)
+	l.latitudes = append(l.latitudes, o.latitude)
+	l.longitudes = append(l.longitudes, o.longitude)
+	l.stateFIPS = append(l.stateFIPS, o.stateFIPS)
+	l.countyFIPS = append(l.countyFIPS, o.countyFIPS)
+	l.tractGEOIDs = append(l.tractGEOIDs, o.tractGEOID)
+	l.sources = append(l.sources, o.source)
+	l.confidences = append(l.confidences, o.confidence)
+}
+
+// geocodeOutcome is the geocode result for one distinct address, shared by
+// every queue item with that address.
+type geocodeOutcome struct {
+	matched                           bool
+	matchedAddress                    string
+	matchType                         string // Census match type or provider quality
+	latitude, longitude               float64
+	stateFIPS, countyFIPS, tractGEOID string
+	source                            string
+	confidence                        float64
+	status                            string // Census status of an unmatched address
+	result                            []byte // JSON stored on the queue item
+	cached, fallback                  bool
+}
+
+// batchOutcome converts a Census batch response row.
+func batchOutcome(m geocode.BatchMatch) (*geocodeOutcome, error) {
+	result, err := json.Marshal(m)
+	if err != nil {
+		return nil, eris.Wrapf(err, "geocode worker: marshal result %s", m.ID)
+	}
+	o := &geocodeOutcome{matched: m.Matched(), status: m.Status, result: result}
+	if o.matched {
+		o.matchedAddress, o.matchType = m.MatchedAddress, m.MatchType
+		o.latitude, o.longitude = m.Latitude, m.Longitude
+		o.stateFIPS, o.countyFIPS, o.tractGEOID = m.StateFIPS, m.CountyFIPS, m.TractGEOID
+		o.source, o.confidence = "census", censusBatchConfidence[m.MatchType]
+	}
+	return o, nil
+}
+
+// resultOutcome converts a single-address client result.
+func resultOutcome(r *geocode.Result) (*geocodeOutcome, error) {
+	result, err := json.Marshal(r)
+	if err != nil {
+		return nil, eris.Wrap(err, "geocode worker: marshal result")
+	}
+	o := &geocodeOutcome{
+		matched:    r.Matched,
+		matchType:  r.Quality,
+		latitude:   r.Latitude,
+		longitude:  r.Longitude,
+		countyFIPS: r.CountyFIPS,
+		source:     r.Source,
+		confidence: r.Confidence,
+		result:     result,
+	}
+	if len(r.CountyFIPS) == 5 {
+		o.stateFIPS = r.CountyFIPS[:2]
+	}
+	return o, nil
+}
+
+// cacheRows holds column arrays for the geo.geocode_cache upsert.
+type cacheRows struct {
+	keys                           []string
+	matched                        []bool
+	latitudes, longitudes          []float64
+	qualities, countyFIPS, sources []string
+	confidences                    []float64
+	matchedAddresses, tractGEOIDs  []string
+	norms                          []string
+}
+
+func (c *cacheRows) add(key, norm string, o *geocodeOutcome) {
+	quality := o.matchType
+	if !o.matched {
+		quality = o.status
+	}
+	c.keys = append(c.keys, key)
+	c.matched = append(c.matched, o.matched)
+	c.latitudes = append(c.latitudes, o.latitude)
+	c.longitudes = append(c.longitudes, o.longitude)
+	c.qualities = append(c.qualities, quality)
+	c.countyFIPS = append(c.countyFIPS, o.countyFIPS)
+	c.sources = append(c.sources, cmp.Or(o.source, "census"))
+	c.confidences = append(c.confidences, o.confidence)
+	c.matchedAddresses = append(c.matchedAddresses, o.matchedAddress)
+	c.tractGEOIDs = append(c.tractGEOIDs, o.tractGEOID)
+	c.norms = append(c.norms, norm)
+}
+
+// queueResults holds column arrays for the final queue status update.
+type queueResults struct {
+	ids      []int
+	statuses []string
+	results  []string
+	errors   []string
+}
+
+func (r *queueResults) add(id int, status string, result []byte, errMsg string) {
+	r.ids = append(r.ids, id)
+	r.statuses = append(r.statuses, status)
+	r.results = append(r.results, string(result))
+	r.errors = append(r.errors, errMsg)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
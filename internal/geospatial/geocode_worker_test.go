@@ -0,0 +1,371 @@
+package geospatial
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+// mockBatchGeocoder implements BatchGeocoder for testing.
+type mockBatchGeocoder struct {
+	matches []geocode.BatchMatch
+	err     error
+	got     []geocode.AddressInput
+}
+
+func (m *mockBatchGeocoder) GeocodeBatch(_ context.Context, addrs []geocode.AddressInput) ([]geocode.BatchMatch, error) {
+	m.got = addrs
+	return m.matches, m.err
+}
+
+var queueCols = []string{"id", "source_table", "source_id", "address"}
+
+func expectClaim(mock pgxmock.PgxPoolIface, limit int, rows *pgxmock.Rows, ids []int) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, source_table, source_id, address FROM geo.geocode_queue WHERE status = 'pending' AND next_attempt_at <= now\(\)`).
+		WithArgs(limit).
+		WillReturnRows(rows)
+	if len(ids) == 0 {
+		mock.ExpectCommit()
+		return
+	}
+	mock.ExpectExec(`SET status = 'processing'`).
+		WithArgs(ids).
+		WillReturnResult(pgxmock.NewResult("UPDATE", int64(len(ids))))
+	mock.ExpectCommit()
+}
+
+func TestNewGeocodeWorker_Defaults(t *testing.T) {
+	w := NewGeocodeWorker(nil, nil, 0, 0)
+	assert.Equal(t, 1000, w.batchSize)
+	assert.Equal(t, DefaultGeocodeMaxAttempts, w.maxAttempts)
+
+	w = NewGeocodeWorker(nil, nil, 50000, 5)
+	assert.Equal(t, geocode.CensusBatchMaxRecords, w.batchSize)
+	assert.Equal(t, 5, w.maxAttempts)
+}
+
+func TestGeocodeWorker_RunOnce(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "100 Main St, Miami, FL 33101").
+		AddRow(2, "geo.poi", "b", "1 Nowhere Rd, Nowhere, ZZ").
+		AddRow(3, "fed_data.adv_firms", "c", "5 Elm St, Austin, TX 78701"), []int{1, 2, 3})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs([]string{"geo.poi"}, []string{"a"}, []string{"100 Main St, Miami, FL 33101"},
+			[]string{"100 MAIN ST, MIAMI, FL, 33101"}, []string{"Exact"},
+			[]float64{25.77}, []float64{-80.19}, []string{"12"}, []string{"12086"}, []string{"12086003001"},
+			[]string{"census"}, []float64{1.0}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs([]int{1, 2}, []string{"complete", "no_match"}, pgxmock.AnyArg(), []string{"", "No_Match"}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectCommit()
+
+	// Item 3 is missing from the response and is rescheduled.
+	mock.ExpectQuery(`WITH u AS`).
+		WithArgs([]int{3}, 3, "missing from geocoder response", geocodeRetryBase.Seconds()).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+
+	gc := &mockBatchGeocoder{matches: []geocode.BatchMatch{
+		{ID: "1", Status: geocode.CensusMatch, MatchType: "Exact", MatchedAddress: "100 MAIN ST, MIAMI, FL, 33101",
+			Latitude: 25.77, Longitude: -80.19, StateFIPS: "12", CountyFIPS: "12086", TractGEOID: "12086003001"},
+		{ID: "2", Status: geocode.CensusNoMatch},
+	}}
+	w := NewGeocodeWorker(mock, gc, 10, 3)
+	stats, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, GeocodeWorkerStats{Batches: 1, Claimed: 3, Matched: 1, NoMatch: 1, Retried: 1}, *stats)
+	require.Len(t, gc.got, 3)
+	assert.Equal(t, geocode.AddressInput{ID: "1", Street: "100 MAIN ST", City: "MIAMI", State: "FL", ZipCode: "33101"}, gc.got[0])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_RunOnce_CacheAndDedup(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// Items 1 and 2 are the same address spelled two ways; item 3 is cached.
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "fed_data.adv_firms", "a", "100 Main St, Miami, FL 33101").
+		AddRow(2, "fed_data.osha_inspections", "b", "100 Main Street, Miami, Florida 33101").
+		AddRow(3, "geo.epa_sites", "c", "5 Elm St, Austin, TX 78701"), []int{1, 2, 3})
+
+	mainKey := geocode.CacheKey(geocode.AddressInput{Street: "100 Main St", City: "Miami", State: "FL", ZipCode: "33101"})
+	elmKey := geocode.CacheKey(geocode.AddressInput{Street: "5 Elm St", City: "Austin", State: "TX", ZipCode: "78701"})
+
+	// Non-matches stored by the tiger and cascade clients are not final.
+	mock.ExpectQuery(`SELECT address_hash, matched, latitude, longitude, quality, county_fips, source, confidence, matched_address, tract_geoid FROM geo.geocode_cache WHERE address_hash = ANY\(\$1\) AND \(matched OR source = 'census'\)`).
+		WithArgs([]string{mainKey, elmKey}, 90).
+		WillReturnRows(pgxmock.NewRows([]string{"address_hash", "matched", "latitude", "longitude", "quality",
+			"county_fips", "source", "confidence", "matched_address", "tract_geoid"}).
+			AddRow(elmKey, true, 30.27, -97.74, "Exact", ptr("48453"), ptr("census"), ptr(1.0),
+				ptr("5 ELM ST, AUSTIN, TX, 78701"), ptr("48453001100")))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs([]string{"fed_data.adv_firms", "fed_data.osha_inspections", "geo.epa_sites"}, []string{"a", "b", "c"},
+			[]string{"100 Main St, Miami, FL 33101", "100 Main Street, Miami, Florida 33101", "5 Elm St, Austin, TX 78701"},
+			[]string{"100 MAIN ST, MIAMI, FL, 33101", "100 MAIN ST, MIAMI, FL, 33101", "5 ELM ST, AUSTIN, TX, 78701"},
+			[]string{"Exact", "Exact", "Exact"},
+			[]float64{25.77, 25.77, 30.27}, []float64{-80.19, -80.19, -97.74}, []string{"12", "12", "48"},
+			[]string{"12086", "12086", "48453"}, []string{"12086003001", "12086003001", "48453001100"},
+			[]string{"census", "census", "census"}, []float64{1.0, 1.0, 1.0}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs([]int{1, 2, 3}, []string{"complete", "complete", "complete"}, pgxmock.AnyArg(), []string{"", "", ""}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+	mock.ExpectCommit()
+
+	// Only the newly geocoded address is cached.
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs([]string{mainKey}, []bool{true}, []float64{25.77}, []float64{-80.19}, []string{"Exact"},
+			[]string{"12086"}, []string{"census"}, []float64{1.0},
+			[]string{"100 MAIN ST, MIAMI, FL, 33101"}, []string{"12086003001"},
+			[]string{geocode.CacheAddress(geocode.NormalizeInput(geocode.AddressInput{Street: "100 Main St", City: "Miami", State: "FL", ZipCode: "33101"}))}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	gc := &mockBatchGeocoder{matches: []geocode.BatchMatch{
+		{ID: "1", Status: geocode.CensusMatch, MatchType: "Exact", MatchedAddress: "100 MAIN ST, MIAMI, FL, 33101",
+			Latitude: 25.77, Longitude: -80.19, StateFIPS: "12", CountyFIPS: "12086", TractGEOID: "12086003001"},
+	}}
+	w := NewGeocodeWorker(mock, gc, 10, 3).WithCache(90)
+	stats, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, GeocodeWorkerStats{Batches: 1, Claimed: 3, Matched: 3, CacheHits: 1, Deduped: 1}, *stats)
+	require.Len(t, gc.got, 1)
+	assert.Equal(t, "1", gc.got[0].ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// mockFallbackClient implements geocode.Client for fallback tests.
+type mockFallbackClient struct {
+	results map[string]*geocode.Result
+	err     error
+}
+
+func (m *mockFallbackClient) Geocode(_ context.Context, addr geocode.AddressInput) (*geocode.Result, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if r, ok := m.results[addr.ID]; ok {
+		return r, nil
+	}
+	return &geocode.Result{Matched: false}, nil
+}
+
+func (m *mockFallbackClient) BatchGeocode(context.Context, []geocode.AddressInput) ([]geocode.Result, error) {
+	return nil, nil
+}
+
+func (m *mockFallbackClient) ReverseGeocode(context.Context, float64, float64) (*geocode.ReverseResult, error) {
+	return nil, nil
+}
+
+func TestGeocodeWorker_RunOnce_Fallback(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "100 Rural Rte 2, Marfa, TX 79843").
+		AddRow(2, "geo.poi", "b", "1 Nowhere Rd, Nowhere, ZZ"), []int{1, 2})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs([]string{"geo.poi"}, []string{"a"}, []string{"100 Rural Rte 2, Marfa, TX 79843"},
+			[]string{""}, []string{"range"}, []float64{30.31}, []float64{-104.02},
+			[]string{"48"}, []string{"48377"}, []string{""}, []string{"google"}, []float64{0.8}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs([]int{1, 2}, []string{"complete", "no_match"}, pgxmock.AnyArg(), []string{"", "No_Match"}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectCommit()
+
+	fb := &mockFallbackClient{results: map[string]*geocode.Result{
+		"1": {Matched: true, Source: "google", Quality: "range", Confidence: 0.8,
+			Latitude: 30.31, Longitude: -104.02, CountyFIPS: "48377"},
+	}}
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{matches: []geocode.BatchMatch{
+		{ID: "1", Status: geocode.CensusNoMatch},
+		{ID: "2", Status: geocode.CensusNoMatch},
+	}}, 10, 3).WithFallback(fb)
+	stats, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, GeocodeWorkerStats{Batches: 1, Claimed: 2, Matched: 1, NoMatch: 1, Fallback: 1}, *stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_RunOnce_FallbackError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "1 Nowhere Rd, Nowhere, ZZ"), []int{1})
+	mock.ExpectQuery(`WITH u AS`).
+		WithArgs([]int{1}, 3, "fallback: google: status OVER_QUERY_LIMIT", geocodeRetryBase.Seconds()).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{matches: []geocode.BatchMatch{{ID: "1", Status: geocode.CensusTie}}}, 10, 3).
+		WithFallback(&mockFallbackClient{err: errors.New("google: status OVER_QUERY_LIMIT")})
+	stats, err := w.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Retried)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_RunOnce_GeocoderError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "100 Main St, Miami, FL").
+		AddRow(2, "geo.poi", "b", "200 Main St, Miami, FL"), []int{1, 2})
+	mock.ExpectQuery(`WITH u AS`).
+		WithArgs([]int{1, 2}, 3, "census batch: unexpected status 503", geocodeRetryBase.Seconds()).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{err: errors.New("census batch: unexpected status 503")}, 10, 3)
+	stats, err := w.RunOnce(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "geocode worker: batch geocode")
+	assert.Equal(t, 1, stats.Retried)
+	assert.Equal(t, 1, stats.Failed, "items out of attempts are marked failed")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_RunOnce_RecordError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "1 Nowhere Rd, Nowhere, ZZ"), []int{1})
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(errors.New("deadlock"))
+	mock.ExpectRollback()
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{matches: []geocode.BatchMatch{{ID: "1", Status: geocode.CensusTie}}}, 10, 3)
+	_, err = w.RunOnce(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "geocode worker: update 1 queue items")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_Run(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`SET status = 'pending'`).
+		WithArgs(float64(3600)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "1 Nowhere Rd, Nowhere, ZZ"), []int{1})
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols), nil)
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{matches: []geocode.BatchMatch{{ID: "1", Status: geocode.CensusNoMatch}}}, 10, 3)
+	stats, err := w.Run(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, GeocodeWorkerStats{Batches: 1, Claimed: 1, NoMatch: 1}, *stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_Run_MaxBatches(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`SET status = 'pending'`).WithArgs(pgxmock.AnyArg()).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "1 Nowhere Rd, Nowhere, ZZ"), []int{1})
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{matches: []geocode.BatchMatch{{ID: "1", Status: geocode.CensusNoMatch}}}, 10, 3)
+	stats, err := w.Run(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Batches)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_Run_RequeueError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`SET status = 'pending'`).WithArgs(pgxmock.AnyArg()).WillReturnError(errors.New("connection refused"))
+
+	w := NewGeocodeWorker(mock, &mockBatchGeocoder{}, 10, 3)
+	_, err = w.Run(context.Background(), 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requeue stale")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGeocodeWorker_Run_SpatialAssign(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`SET status = 'pending'`).WithArgs(pgxmock.AnyArg()).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols).
+		AddRow(1, "geo.poi", "a", "100 Main St, Miami, FL 33101"), []int{1})
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE geo.geocode_queue q`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+	expectClaim(mock, 10, pgxmock.NewRows(queueCols), nil)
+
+	// Incremental assignment of the new location.
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(0), false, "", 5000).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(1, int64(8), 1, 1, 1, 1))
+	mock.ExpectQuery(`UPDATE geo\.locations l SET`).
+		WithArgs(int64(8), false, "", 5000).
+		WillReturnRows(pgxmock.NewRows(assignCols).AddRow(0, int64(0), 0, 0, 0, 0))
+
+	gc := &mockBatchGeocoder{matches: []geocode.BatchMatch{
+		{ID: "1", Status: geocode.CensusMatch, MatchType: "Exact", Latitude: 25.77, Longitude: -80.19},
+	}}
+	w := NewGeocodeWorker(mock, gc, 10, 3).WithSpatialAssign(NewSpatialAssigner(mock, 0))
+	stats, err := w.Run(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Matched)
+	assert.Equal(t, 1, stats.Assigned)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -21,6 +21,7 @@ type TableStats struct {
 
 // geoTables lists all tables in the geo schema that maintenance commands operate on.
 var geoTables = []string{
+	"geo.boundaries",
 	"geo.counties",
 	"geo.places",
 	"geo.zcta",
@@ -34,6 +35,7 @@ var geoTables = []string{
 	"geo.demographics",
 	"geo.geocode_cache",
 	"geo.geocode_queue",
+	"geo.locations",
 }
 
 // VacuumAnalyze runs VACUUM ANALYZE on all geo.* tables to update planner
@@ -53,6 +55,7 @@ func VacuumAnalyze(ctx context.Context, pool db.Pool) error {
 // rows by their GIST index, improving spatial query performance.
 func ClusterSpatialIndexes(ctx context.Context, pool db.Pool) error {
 	spatialIndexes := map[string]string{
+		"geo.boundaries":              "idx_boundaries_geom",
 		"geo.counties":                "idx_counties_geom",
 		"geo.places":                  "idx_places_geom",
 		"geo.zcta":                    "idx_zcta_geom",
@@ -53,7 +53,7 @@ func TestClusterSpatialIndexes_Success(t *testing.T) {
 	// Expect CLUSTER for each spatial table — order is non-deterministic (map iteration).
 	mock.MatchExpectationsInOrder(false)
 	tables := []string{
-		"geo.counties", "geo.places", "geo.zcta", "geo.cbsa",
+		"geo.boundaries", "geo.counties", "geo.places", "geo.zcta", "geo.cbsa",
 		"geo.census_tracts", "geo.congressional_districts",
 		"geo.poi", "geo.infrastructure", "geo.epa_sites",
 		"geo.flood_zones", "geo.demographics",
@@ -0,0 +1,262 @@
+package geospatial
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// nearestAmenitiesSQL finds the nearest airport, hospital, competitor POI
+// ($1 subcategories), and interstate to each location l. Only public-use
+// airports count: FAA NASR rows with site type A and use code PU, and HIFLD
+// aerodromes not flagged private use. Heliports, seaplane bases,
+// gliderports, ultralight fields, and private strips are skipped. The
+// airport rows keep their site type in fuel_type. Competitor POIs within
+// 50 m are taken to be the company's own office. TIGER primary roads carry
+// no ramps, so the interstate distance is to the nearest limited-access
+// highway.
+const nearestAmenitiesSQL = `
+	LEFT JOIN LATERAL (
+		SELECT i.name, ST_Distance(i.geom::geography, l.geom::geography) / 1000 AS km
+		FROM geo.infrastructure i
+		WHERE i.type = 'airport' AND (
+			(i.source = 'faa' AND i.fuel_type = 'A'
+				AND i.properties->>'FACILITY_USE_CODE' = 'PU')
+			OR (i.source = 'hifld' AND i.fuel_type IN ('AD', 'AIRPORT')
+				AND COALESCE(i.properties->>'PRIVATEUSE', '0') IN ('0', 'N', 'false')))
+		ORDER BY i.geom <-> l.geom LIMIT 1
+	) ap ON true
+	LEFT JOIN LATERAL (
+		SELECT i.name, ST_Distance(i.geom::geography, l.geom::geography) / 1000 AS km
+		FROM geo.infrastructure i
+		WHERE i.type = 'hospital'
+		ORDER BY i.geom <-> l.geom LIMIT 1
+	) ho ON true
+	LEFT JOIN LATERAL (
+		SELECT p.name, ST_Distance(p.geom::geography, l.geom::geography) / 1000 AS km
+		FROM geo.osm_pois p
+		WHERE p.subcategory = ANY($1::text[])
+			AND NOT ST_DWithin(p.geom::geography, l.geom::geography, 50)
+		ORDER BY p.geom <-> l.geom LIMIT 1
+	) cp ON true
+	LEFT JOIN LATERAL (
+		SELECT r.name, ST_Distance(r.geom::geography, l.geom::geography) / 1000 AS km
+		FROM geo.roads r
+		WHERE r.route_type = 'interstate'
+		ORDER BY r.geom <-> l.geom LIMIT 1
+	) rd ON true`
+
+// metricsBatchSQL computes metrics for one batch of geocoded company
+// addresses after id $2 and reports how many were written, the last id,
+// and how many found each amenity. $3 recomputes current metrics, $4 is
+// the refresh age in days, and $5 the batch size. Addresses are pending
+// when never computed, older than $4 days, or re-geocoded since.
+const metricsBatchSQL = `
+WITH computed AS (
+	SELECT l.id, l.geom, ap.km AS airport_km, ap.name AS airport_name,
+		ho.km AS hospital_km, ho.name AS hospital_name,
+		cp.km AS competitor_km, cp.name AS competitor_name,
+		rd.km AS interstate_km, rd.name AS interstate_name
+	FROM (
+		SELECT a.id, a.geom FROM public.company_addresses a
+		LEFT JOIN geo.location_metrics m
+			ON m.source_table = 'public.company_addresses' AND m.source_id = a.id::text
+		WHERE a.id > $2
+			AND a.geom IS NOT NULL
+			AND ($3 OR m.source_id IS NULL OR NOT ST_Equals(m.origin, a.geom)
+				OR m.computed_at < now() - make_interval(days => $4))
+		ORDER BY a.id
+		LIMIT $5
+	) l` + nearestAmenitiesSQL + `
+), upserted AS (
+	INSERT INTO geo.location_metrics (source_table, source_id, origin,
+		airport_km, airport_name, hospital_km, hospital_name,
+		competitor_km, competitor_name, interstate_km, interstate_name, computed_at)
+	SELECT 'public.company_addresses', c.id::text, c.geom,
+		c.airport_km, c.airport_name, c.hospital_km, c.hospital_name,
+		c.competitor_km, c.competitor_name, c.interstate_km, c.interstate_name, now()
+	FROM computed c
+	ON CONFLICT (source_table, source_id) DO UPDATE SET
+		origin = EXCLUDED.origin,
+		airport_km = EXCLUDED.airport_km,
+		airport_name = EXCLUDED.airport_name,
+		hospital_km = EXCLUDED.hospital_km,
+		hospital_name = EXCLUDED.hospital_name,
+		competitor_km = EXCLUDED.competitor_km,
+		competitor_name = EXCLUDED.competitor_name,
+		interstate_km = EXCLUDED.interstate_km,
+		interstate_name = EXCLUDED.interstate_name,
+		computed_at = EXCLUDED.computed_at
+	RETURNING source_id::bigint AS id, airport_km IS NOT NULL AS airport,
+		hospital_km IS NOT NULL AS hospital, competitor_km IS NOT NULL AS competitor,
+		interstate_km IS NOT NULL AS interstate
+)
+SELECT count(*), COALESCE(max(id), 0),
+	count(*) FILTER (WHERE airport), count(*) FILTER (WHERE hospital),
+	count(*) FILTER (WHERE competitor), count(*) FILTER (WHERE interstate)
+FROM upserted`
+
+// storedMetricsSQL reads the stored metrics measured from the point ($1
+// longitude, $2 latitude).
+const storedMetricsSQL = `
+SELECT airport_km, airport_name, hospital_km, hospital_name,
+	competitor_km, competitor_name, interstate_km, interstate_name
+FROM geo.location_metrics
+WHERE ST_DWithin(origin, ST_SetSRID(ST_MakePoint($1, $2), 4326), 0.000001)
+ORDER BY computed_at DESC
+LIMIT 1`
+
+// pointMetricsSQL computes metrics for a single point ($2 longitude, $3
+// latitude).
+const pointMetricsSQL = `
+SELECT ap.km, ap.name, ho.km, ho.name, cp.km, cp.name, rd.km, rd.name
+FROM (SELECT ST_SetSRID(ST_MakePoint($2, $3), 4326) AS geom) l` + nearestAmenitiesSQL
+
+// LocationMetrics holds the distances in kilometers from a location to the
+// nearest amenity of each kind. Distances are 0 and names empty when the
+// amenity layer has no rows.
+type LocationMetrics struct {
+	AirportKM      float64 `json:"airport_km,omitempty"`
+	AirportName    string  `json:"airport_name,omitempty"`
+	HospitalKM     float64 `json:"hospital_km,omitempty"`
+	HospitalName   string  `json:"hospital_name,omitempty"`
+	CompetitorKM   float64 `json:"competitor_km,omitempty"`
+	CompetitorName string  `json:"competitor_name,omitempty"`
+	InterstateKM   float64 `json:"interstate_km,omitempty"`
+	InterstateName string  `json:"interstate_name,omitempty"`
+}
+
+// MetricsOpts configures a MetricsComputer run.
+type MetricsOpts struct {
+	Force       bool // recompute current metrics
+	RefreshDays int  // recompute metrics older than this (default 90)
+	Limit       int  // max addresses (0 = all)
+}
+
+// MetricsStats counts the addresses a run computed and how many found
+// each amenity.
+type MetricsStats struct {
+	Computed    int `json:"computed"`
+	Airports    int `json:"airports"`
+	Hospitals   int `json:"hospitals"`
+	Competitors int `json:"competitors"`
+	Interstates int `json:"interstates"`
+}
+
+// MetricsComputer computes nearest-amenity distances for geocoded company
+// addresses into geo.location_metrics: the nearest airport and hospital
+// (geo.infrastructure), competitor POI (geo.osm_pois), and interstate
+// (geo.roads). Incremental runs pick up addresses never computed,
+// re-geocoded, or past the refresh age.
+type MetricsComputer struct {
+	pool        db.Pool
+	batchSize   int
+	competitors []string
+}
+
+// NewMetricsComputer creates a MetricsComputer. competitors are the
+// geo.osm_pois subcategories counted as competitors; batchSize is the
+// number of addresses computed per statement (default 1000).
+func NewMetricsComputer(pool db.Pool, batchSize int, competitors []string) *MetricsComputer {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return &MetricsComputer{pool: pool, batchSize: batchSize, competitors: nonNil(competitors)}
+}
+
+// Run computes metrics for pending addresses, or every geocoded address
+// when opts.Force is set, in keyset batches until none remain or
+// opts.Limit is reached.
+func (c *MetricsComputer) Run(ctx context.Context, opts MetricsOpts) (*MetricsStats, error) {
+	refresh := opts.RefreshDays
+	if refresh <= 0 {
+		refresh = 90
+	}
+	stats := &MetricsStats{}
+	var lastID int64
+	for opts.Limit <= 0 || stats.Computed < opts.Limit {
+		if err := ctx.Err(); err != nil {
+			return stats, eris.Wrap(err, "location metrics: canceled")
+		}
+		n := c.batchSize
+		if opts.Limit > 0 {
+			n = min(n, opts.Limit-stats.Computed)
+		}
+		var computed, airports, hospitals, competitors, interstates int
+		err := c.pool.QueryRow(ctx, metricsBatchSQL, c.competitors, lastID, opts.Force, refresh, n).
+			Scan(&computed, &lastID, &airports, &hospitals, &competitors, &interstates)
+		if err != nil {
+			return stats, eris.Wrap(err, "location metrics: compute batch")
+		}
+		if computed == 0 {
+			break
+		}
+		stats.Computed += computed
+		stats.Airports += airports
+		stats.Hospitals += hospitals
+		stats.Competitors += competitors
+		stats.Interstates += interstates
+		zap.L().Debug("location metrics: batch complete",
+			zap.Int("computed", computed), zap.Int64("last_id", lastID))
+	}
+	return stats, nil
+}
+
+// LocationMetricsAt returns the metrics stored in geo.location_metrics for
+// the point (lat, lng), computing them with the same joins as
+// MetricsComputer when the point has none yet.
+func LocationMetricsAt(ctx context.Context, pool db.Pool, lat, lng float64, competitors []string) (*LocationMetrics, error) {
+	m, err := scanMetrics(pool.QueryRow(ctx, storedMetricsSQL, lng, lat))
+	if err == nil {
+		return m, nil
+	}
+	if !eris.Is(err, pgx.ErrNoRows) {
+		return nil, eris.Wrap(err, "location metrics: read stored")
+	}
+	m, err = scanMetrics(pool.QueryRow(ctx, pointMetricsSQL, nonNil(competitors), lng, lat))
+	if err != nil {
+		return nil, eris.Wrap(err, "location metrics: compute point")
+	}
+	return m, nil
+}
+
+// scanMetrics scans the eight metric columns shared by storedMetricsSQL
+// and pointMetricsSQL.
+func scanMetrics(row pgx.Row) (*LocationMetrics, error) {
+	var airportKM, hospitalKM, competitorKM, interstateKM *float64
+	var airport, hospital, competitor, interstate *string
+	if err := row.Scan(&airportKM, &airport, &hospitalKM, &hospital,
+		&competitorKM, &competitor, &interstateKM, &interstate); err != nil {
+		return nil, err
+	}
+	return &LocationMetrics{
+		AirportKM:      derefFloat(airportKM),
+		AirportName:    derefString(airport),
+		HospitalKM:     derefFloat(hospitalKM),
+		HospitalName:   derefString(hospital),
+		CompetitorKM:   derefFloat(competitorKM),
+		CompetitorName: derefString(competitor),
+		InterstateKM:   derefFloat(interstateKM),
+		InterstateName: derefString(interstate),
+	}, nil
+}
+
+// nonNil returns s, or an empty slice so the ANY($1) filter matches
+// nothing rather than binding NULL.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func derefFloat(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
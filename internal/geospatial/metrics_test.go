@@ -0,0 +1,137 @@
+package geospatial
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	metricsBatchCols = []string{"computed", "last_id", "airports", "hospitals", "competitors", "interstates"}
+	metricsCols      = []string{"airport_km", "airport_name", "hospital_km", "hospital_name",
+		"competitor_km", "competitor_name", "interstate_km", "interstate_name"}
+)
+
+func TestMetricsComputer_Run(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	competitors := []string{"accountant"}
+	mock.ExpectQuery(`INSERT INTO geo\.location_metrics`).
+		WithArgs(competitors, int64(0), false, 90, 2).
+		WillReturnRows(pgxmock.NewRows(metricsBatchCols).AddRow(2, int64(7), 2, 2, 1, 2))
+	mock.ExpectQuery(`INSERT INTO geo\.location_metrics`).
+		WithArgs(competitors, int64(7), false, 90, 2).
+		WillReturnRows(pgxmock.NewRows(metricsBatchCols).AddRow(0, int64(0), 0, 0, 0, 0))
+
+	stats, err := NewMetricsComputer(mock, 2, competitors).Run(context.Background(), MetricsOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, &MetricsStats{Computed: 2, Airports: 2, Hospitals: 2, Competitors: 1, Interstates: 2}, stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMetricsComputer_Run_ForceLimit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`INSERT INTO geo\.location_metrics`).
+		WithArgs([]string{}, int64(0), true, 30, 3).
+		WillReturnRows(pgxmock.NewRows(metricsBatchCols).AddRow(3, int64(12), 3, 3, 0, 3))
+
+	stats, err := NewMetricsComputer(mock, 0, nil).Run(context.Background(), MetricsOpts{
+		Force: true, RefreshDays: 30, Limit: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Computed)
+	assert.Zero(t, stats.Competitors)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMetricsComputer_Run_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`INSERT INTO geo\.location_metrics`).
+		WithArgs([]string{}, int64(0), false, 90, 1000).
+		WillReturnError(errors.New("relation geo.roads does not exist"))
+
+	_, err = NewMetricsComputer(mock, 0, nil).Run(context.Background(), MetricsOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "location metrics: compute batch")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLocationMetricsAt_Stored(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	airportKM, airport := 12.4, "DALLAS LOVE FIELD"
+	interstateKM, interstate := 1.8, "I- 35E"
+	mock.ExpectQuery(`FROM geo\.location_metrics`).
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(metricsCols).AddRow(&airportKM, &airport,
+			(*float64)(nil), (*string)(nil), (*float64)(nil), (*string)(nil), &interstateKM, &interstate))
+
+	got, err := LocationMetricsAt(context.Background(), mock, 32.7767, -96.797, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &LocationMetrics{
+		AirportKM: airportKM, AirportName: airport, InterstateKM: interstateKM, InterstateName: interstate,
+	}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLocationMetricsAt_Computed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	hospitalKM, hospital := 3.2, "BAYLOR UNIVERSITY MEDICAL CENTER"
+	competitorKM, competitor := 0.4, "Smith & Co CPAs"
+	mock.ExpectQuery(`FROM geo\.location_metrics`).
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`SELECT ap\.km, ap\.name, ho\.km`).
+		WithArgs([]string{"accountant"}, -96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(metricsCols).AddRow((*float64)(nil), (*string)(nil),
+			&hospitalKM, &hospital, &competitorKM, &competitor, (*float64)(nil), (*string)(nil)))
+
+	got, err := LocationMetricsAt(context.Background(), mock, 32.7767, -96.797, []string{"accountant"})
+	require.NoError(t, err)
+	assert.Equal(t, &LocationMetrics{
+		HospitalKM: hospitalKM, HospitalName: hospital, CompetitorKM: competitorKM, CompetitorName: competitor,
+	}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLocationMetricsAt_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM geo\.location_metrics`).
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(errors.New("relation geo.location_metrics does not exist"))
+
+	_, err = LocationMetricsAt(context.Background(), mock, 32.7767, -96.797, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "location metrics: read stored")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMetricsBatchSQL(t *testing.T) {
+	assert.Contains(t, metricsBatchSQL, "NOT ST_Equals(m.origin, a.geom)")
+	assert.Contains(t, metricsBatchSQL, "ON CONFLICT (source_table, source_id) DO UPDATE")
+	assert.Contains(t, nearestAmenitiesSQL, "i.source = 'faa' AND i.fuel_type = 'A'")
+	assert.Contains(t, nearestAmenitiesSQL, "i.properties->>'FACILITY_USE_CODE' = 'PU'")
+	assert.Contains(t, nearestAmenitiesSQL, "COALESCE(i.properties->>'PRIVATEUSE', '0')")
+	assert.Contains(t, nearestAmenitiesSQL, "r.route_type = 'interstate'")
+}
@@ -0,0 +1,221 @@
+package geospatial
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+// ReverseGeocoder converts coordinates to an address.
+type ReverseGeocoder interface {
+	ReverseGeocode(ctx context.Context, lat, lng float64) (*geocode.ReverseResult, error)
+}
+
+// defaultReverseTables are the coordinate-only tables reverse geocoded when
+// no tables are given. ReverseTables adds the geo.hifld_* layer tables.
+var defaultReverseTables = []string{"geo.epa_sites", "geo.infrastructure"}
+
+// reverseTableName restricts reverse backfill to geo tables.
+var reverseTableName = regexp.MustCompile(`^geo\.[a-z][a-z0-9_]*$`)
+
+// ReverseTables returns the default reverse geocode targets: EPA
+// facilities, legacy infrastructure, and every geo.hifld_* layer table.
+func ReverseTables(ctx context.Context, pool db.Pool) ([]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT table_schema || '.' || table_name
+		FROM information_schema.tables
+		WHERE table_schema = 'geo' AND table_name LIKE 'hifld\_%'
+		ORDER BY table_name`)
+	if err != nil {
+		return nil, eris.Wrap(err, "reverse backfill: list hifld tables")
+	}
+	defer rows.Close()
+
+	tables := append([]string(nil), defaultReverseTables...)
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return nil, eris.Wrap(err, "reverse backfill: scan table")
+		}
+		tables = append(tables, t)
+	}
+	return tables, eris.Wrap(rows.Err(), "reverse backfill: list hifld tables")
+}
+
+// ReverseBackfillStats counts the outcomes of a reverse backfill.
+type ReverseBackfillStats struct {
+	Scanned int `json:"scanned"`
+	Matched int `json:"matched"`
+	NoMatch int `json:"no_match"`
+}
+
+// ReverseBackfill reverse geocodes rows that have coordinates but no
+// geo.locations entry, writing the standardized address, county FIPS, and
+// Census place with match_type 'reverse'.
+type ReverseBackfill struct {
+	pool      db.Pool
+	geocoder  ReverseGeocoder
+	batchSize int
+}
+
+// NewReverseBackfill creates a ReverseBackfill. batchSize is the number of
+// rows read and written per round trip (default 500).
+func NewReverseBackfill(pool db.Pool, geocoder ReverseGeocoder, batchSize int) *ReverseBackfill {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &ReverseBackfill{pool: pool, geocoder: geocoder, batchSize: batchSize}
+}
+
+// Run reverse geocodes up to limit rows of table (0 = all). Rows with no
+// result are counted and left for a later run.
+func (b *ReverseBackfill) Run(ctx context.Context, table string, limit int) (*ReverseBackfillStats, error) {
+	if !reverseTableName.MatchString(table) {
+		return nil, eris.Errorf("reverse backfill: invalid table %q", table)
+	}
+	ident := pgx.Identifier(strings.SplitN(table, ".", 2)).Sanitize()
+	log := zap.L().With(zap.String("table", table))
+
+	stats := &ReverseBackfillStats{}
+	var lastID int64
+	for limit <= 0 || stats.Scanned < limit {
+		n := b.batchSize
+		if limit > 0 {
+			n = min(n, limit-stats.Scanned)
+		}
+		batch, err := b.pending(ctx, ident, table, lastID, n)
+		if err != nil {
+			return stats, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastID = batch[len(batch)-1].id
+
+		var loc reverseRows
+		for _, row := range batch {
+			if err := ctx.Err(); err != nil {
+				return stats, eris.Wrap(err, "reverse backfill: canceled")
+			}
+			stats.Scanned++
+			r, err := b.geocoder.ReverseGeocode(ctx, row.lat, row.lng)
+			if err != nil || r == nil || r.Street == "" {
+				log.Debug("reverse backfill: no result", zap.Int64("id", row.id), zap.Error(err))
+				stats.NoMatch++
+				continue
+			}
+			loc.add(table, row, r)
+			stats.Matched++
+		}
+		if err := b.record(ctx, loc); err != nil {
+			return stats, err
+		}
+		log.Info("reverse backfill: batch complete",
+			zap.Int("scanned", stats.Scanned),
+			zap.Int("matched", stats.Matched),
+		)
+	}
+	return stats, nil
+}
+
+// reverseRow is a coordinate-only row awaiting reverse geocoding.
+type reverseRow struct {
+	id       int64
+	lat, lng float64
+}
+
+// pending returns up to n rows of table after lastID that have coordinates
+// and no geo.locations entry.
+func (b *ReverseBackfill) pending(ctx context.Context, ident, table string, lastID int64, n int) ([]reverseRow, error) {
+	rows, err := b.pool.Query(ctx, fmt.Sprintf(`
+		SELECT t.id, t.latitude, t.longitude
+		FROM %s t
+		WHERE t.id > $1
+			AND t.latitude IS NOT NULL AND t.longitude IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM geo.locations l
+				WHERE l.source_table = $2 AND l.source_id = t.id::text
+			)
+		ORDER BY t.id
+		LIMIT $3`, ident),
+		lastID, table, n,
+	)
+	if err != nil {
+		return nil, eris.Wrapf(err, "reverse backfill: query %s", table)
+	}
+	defer rows.Close()
+
+	var out []reverseRow
+	for rows.Next() {
+		var r reverseRow
+		if err := rows.Scan(&r.id, &r.lat, &r.lng); err != nil {
+			return nil, eris.Wrapf(err, "reverse backfill: scan %s", table)
+		}
+		out = append(out, r)
+	}
+	return out, eris.Wrapf(rows.Err(), "reverse backfill: query %s", table)
+}
+
+// record upserts reverse geocoded locations.
+func (b *ReverseBackfill) record(ctx context.Context, loc reverseRows) error {
+	if len(loc.sourceIDs) == 0 {
+		return nil
+	}
+	_, err := b.pool.Exec(ctx, `
+		INSERT INTO geo.locations (source_table, source_id, address, matched_address, match_type,
+			latitude, longitude, geom, state_fips, county_fips, place_name, place_geoid, source, geocoded_at)
+		SELECT s.source_table, s.source_id, s.address, s.address, 'reverse',
+			s.latitude, s.longitude, ST_SetSRID(ST_MakePoint(s.longitude, s.latitude), 4326),
+			NULLIF(left(s.county_fips, 2), ''), NULLIF(s.county_fips, ''),
+			NULLIF(s.place_name, ''), NULLIF(s.place_geoid, ''), COALESCE(NULLIF(s.source, ''), 'tiger'), now()
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::float8[], $5::float8[],
+			$6::text[], $7::text[], $8::text[], $9::text[])
+			AS s(source_table, source_id, address, latitude, longitude,
+				county_fips, place_name, place_geoid, source)
+		ON CONFLICT (source_table, source_id) DO UPDATE SET
+			address = EXCLUDED.address,
+			matched_address = EXCLUDED.matched_address,
+			match_type = EXCLUDED.match_type,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			geom = EXCLUDED.geom,
+			state_fips = EXCLUDED.state_fips,
+			county_fips = EXCLUDED.county_fips,
+			place_name = EXCLUDED.place_name,
+			place_geoid = EXCLUDED.place_geoid,
+			source = EXCLUDED.source,
+			geocoded_at = EXCLUDED.geocoded_at,
+			spatial_assigned_at = NULL`,
+		loc.sourceTables, loc.sourceIDs, loc.addresses, loc.latitudes, loc.longitudes,
+		loc.countyFIPS, loc.placeNames, loc.placeGEOIDs, loc.sources,
+	)
+	return eris.Wrapf(err, "reverse backfill: upsert %d locations", len(loc.sourceIDs))
+}
+
+// reverseRows holds column arrays for the reverse geocode upsert.
+type reverseRows struct {
+	sourceTables, sourceIDs, addresses           []string
+	latitudes, longitudes                        []float64
+	countyFIPS, placeNames, placeGEOIDs, sources []string
+}
+
+func (l *reverseRows) add(table string, row reverseRow, r *geocode.ReverseResult) {
+	l.sourceTables = append(l.sourceTables, table)
+	l.sourceIDs = append(l.sourceIDs, fmt.Sprint(row.id))
+	l.addresses = append(l.addresses, r.Street)
+	l.latitudes = append(l.latitudes, row.lat)
+	l.longitudes = append(l.longitudes, row.lng)
+	l.countyFIPS = append(l.countyFIPS, r.CountyFIPS)
+	l.placeNames = append(l.placeNames, cmp.Or(r.PlaceName, r.City))
+	l.placeGEOIDs = append(l.placeGEOIDs, r.PlaceGEOID)
+	l.sources = append(l.sources, r.Source)
+}
@@ -0,0 +1,110 @@
+package geospatial
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/pkg/geocode"
+)
+
+// mockReverseGeocoder implements ReverseGeocoder for testing.
+type mockReverseGeocoder struct {
+	results map[float64]*geocode.ReverseResult
+}
+
+func (m *mockReverseGeocoder) ReverseGeocode(_ context.Context, lat, _ float64) (*geocode.ReverseResult, error) {
+	if r, ok := m.results[lat]; ok {
+		return r, nil
+	}
+	return nil, errors.New("no rows in result set")
+}
+
+var reverseCols = []string{"id", "latitude", "longitude"}
+
+func TestReverseTables(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM information_schema.tables`).
+		WillReturnRows(pgxmock.NewRows([]string{"table"}).AddRow("geo.hifld_hospitals").AddRow("geo.hifld_prisons"))
+
+	tables, err := ReverseTables(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"geo.epa_sites", "geo.infrastructure", "geo.hifld_hospitals", "geo.hifld_prisons"}, tables)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReverseBackfill_Run(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM "geo"."epa_sites" t`).
+		WithArgs(int64(0), "geo.epa_sites", 2).
+		WillReturnRows(pgxmock.NewRows(reverseCols).AddRow(int64(7), 25.77, -80.19).AddRow(int64(9), 0.0, 0.0))
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs([]string{"geo.epa_sites"}, []string{"7"}, []string{"100 Main St, Miami, FL 33131"},
+			[]float64{25.77}, []float64{-80.19}, []string{"12086"}, []string{"Miami"}, []string{"1245000"}, []string{"tiger"}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(`FROM "geo"."epa_sites" t`).
+		WithArgs(int64(9), "geo.epa_sites", 2).
+		WillReturnRows(pgxmock.NewRows(reverseCols))
+
+	gc := &mockReverseGeocoder{results: map[float64]*geocode.ReverseResult{
+		25.77: {Street: "100 Main St, Miami, FL 33131", City: "Miami", CountyFIPS: "12086",
+			PlaceName: "Miami", PlaceGEOID: "1245000", Source: "tiger"},
+	}}
+	stats, err := NewReverseBackfill(mock, gc, 2).Run(context.Background(), "geo.epa_sites", 0)
+	require.NoError(t, err)
+	assert.Equal(t, ReverseBackfillStats{Scanned: 2, Matched: 1, NoMatch: 1}, *stats)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReverseBackfill_Run_Limit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM "geo"."hifld_hospitals" t`).
+		WithArgs(int64(0), "geo.hifld_hospitals", 1).
+		WillReturnRows(pgxmock.NewRows(reverseCols).AddRow(int64(3), 30.27, -97.74))
+	mock.ExpectExec(`INSERT INTO geo.locations`).
+		WithArgs(pgxmock.AnyArg(), []string{"3"}, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), []string{"Austin"}, pgxmock.AnyArg(), []string{"nominatim"}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	gc := &mockReverseGeocoder{results: map[float64]*geocode.ReverseResult{
+		30.27: {Street: "1 Congress Ave, Austin, TX", City: "Austin", Source: "nominatim"},
+	}}
+	stats, err := NewReverseBackfill(mock, gc, 500).Run(context.Background(), "geo.hifld_hospitals", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Matched, "place name falls back to the city")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReverseBackfill_Run_InvalidTable(t *testing.T) {
+	_, err := NewReverseBackfill(nil, &mockReverseGeocoder{}, 0).Run(context.Background(), "public.users; DROP", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid table")
+}
+
+func TestReverseBackfill_Run_QueryError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM "geo"."epa_sites" t`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(errors.New("relation does not exist"))
+
+	_, err = NewReverseBackfill(mock, &mockReverseGeocoder{}, 0).Run(context.Background(), "geo.epa_sites", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reverse backfill: query geo.epa_sites")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -24,6 +24,11 @@ var validTables = map[string]bool{
 	"geo.epa_sites":               true,
 	"geo.flood_zones":             true,
 	"geo.demographics":            true,
+	"geo.usgs_hazards":            true,
+	"geo.school_districts":        true,
+	"geo.transit_stops":           true,
+	"geo.incentive_tracts":        true,
+	"geo.parcels":                 true,
 }
 
 // BBox represents a geographic bounding box.
@@ -0,0 +1,182 @@
+// Package manifest builds per-run reproducibility manifests recording the
+// binary, config, question pack, models, and dataset vintages behind a result.
+package manifest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// Version is the release version, set at build time with
+// -ldflags "-X github.com/sells-group/research-cli/internal/manifest.Version=v1.2.3".
+var Version = "dev"
+
+// readBuildInfo is swapped in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// New builds a manifest for the given config and question pack. Dataset
+// vintages are attached separately with DatasetVintages.
+func New(cfg *config.Config, questions []model.Question) *model.RunManifest {
+	m := &model.RunManifest{
+		BinaryVersion: Version,
+		ConfigHash:    ConfigHash(cfg),
+		Models:        Models(cfg),
+		CreatedAt:     time.Now().UTC(),
+	}
+	if len(questions) > 0 {
+		m.QuestionPack = QuestionPackVersion(questions)
+		m.QuestionCount = len(questions)
+	}
+	if info, ok := readBuildInfo(); ok {
+		m.GoVersion = info.GoVersion
+		if m.BinaryVersion == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			m.BinaryVersion = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				m.VCSRevision = s.Value
+			case "vcs.modified":
+				m.VCSModified = s.Value == "true"
+			}
+		}
+	}
+	return m
+}
+
+// ConfigHash returns a stable SHA-256 hash of the effective config with
+// credentials and connection URLs removed, so rotating a key or pointing at
+// a different database does not change the hash.
+func ConfigHash(cfg *config.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return ""
+	}
+	redact(tree)
+	// encoding/json sorts map keys, so the output is deterministic.
+	data, err = json.Marshal(tree)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(data)
+	return fmt.Sprintf("%x", h[:16])
+}
+
+// redact drops secret-bearing and environment-specific keys in place.
+func redact(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if isSensitiveKey(k) {
+				delete(t, k)
+				continue
+			}
+			redact(child)
+		}
+	case []any:
+		for _, child := range t {
+			redact(child)
+		}
+	}
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range []string{"secret", "token", "password"} {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return strings.HasSuffix(k, "key") || strings.HasSuffix(k, "keypath") || strings.HasSuffix(k, "url")
+}
+
+// QuestionPackVersion returns a content hash of the question pack. Questions
+// are sorted by ID so load order does not affect the version.
+func QuestionPackVersion(questions []model.Question) string {
+	sorted := append([]model.Question(nil), questions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(data)
+	return fmt.Sprintf("%x", h[:8])
+}
+
+// Models returns the configured model IDs keyed by role.
+func Models(cfg *config.Config) map[string]string {
+	if cfg == nil {
+		return nil
+	}
+	models := map[string]string{
+		"haiku":      cfg.Anthropic.HaikuModel,
+		"sonnet":     cfg.Anthropic.SonnetModel,
+		"opus":       cfg.Anthropic.OpusModel,
+		"perplexity": cfg.Perplexity.Model,
+	}
+	if cfg.Pipeline.Shadow.Enabled {
+		models["shadow_haiku"] = cfg.Pipeline.Shadow.HaikuModel
+		models["shadow_sonnet"] = cfg.Pipeline.Shadow.SonnetModel
+	}
+	for k, v := range models {
+		if v == "" {
+			delete(models, k)
+		}
+	}
+	return models
+}
+
+// DatasetVintages returns the most recent successful fedsync load of every
+// dataset, keyed by dataset name.
+func DatasetVintages(ctx context.Context, pool db.Pool) (map[string]model.DatasetVintage, error) {
+	rows, err := pool.Query(ctx,
+		`SELECT DISTINCT ON (dataset) dataset, id, started_at, metadata
+		 FROM fed_data.sync_log
+		 WHERE status = 'complete'
+		 ORDER BY dataset, started_at DESC`)
+	if err != nil {
+		return nil, eris.Wrap(err, "manifest: query dataset vintages")
+	}
+	defer rows.Close()
+
+	out := make(map[string]model.DatasetVintage)
+	for rows.Next() {
+		var (
+			name     string
+			v        model.DatasetVintage
+			metaJSON []byte
+		)
+		if err := rows.Scan(&name, &v.SyncID, &v.SyncedAt, &metaJSON); err != nil {
+			return nil, eris.Wrap(err, "manifest: scan dataset vintage")
+		}
+		if metaJSON != nil {
+			_ = json.Unmarshal(metaJSON, &v.Metadata)
+			// Drop nested manifests stamped by fedsync runs.
+			delete(v.Metadata, "manifest")
+		}
+		out[name] = v
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "manifest: iterate dataset vintages")
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestNew(t *testing.T) {
+	orig := readBuildInfo
+	t.Cleanup(func() { readBuildInfo = orig })
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.25.1",
+			Main:      debug.Module{Version: "v1.4.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+
+	cfg := &config.Config{}
+	cfg.Anthropic.HaikuModel = "haiku-x"
+	cfg.Anthropic.SonnetModel = "sonnet-x"
+	questions := []model.Question{{ID: "q1", Text: "Revenue?"}}
+
+	m := New(cfg, questions)
+	assert.Equal(t, "v1.4.0", m.BinaryVersion)
+	assert.Equal(t, "go1.25.1", m.GoVersion)
+	assert.Equal(t, "abc123", m.VCSRevision)
+	assert.True(t, m.VCSModified)
+	assert.Len(t, m.ConfigHash, 32)
+	assert.Equal(t, QuestionPackVersion(questions), m.QuestionPack)
+	assert.Equal(t, 1, m.QuestionCount)
+	assert.Equal(t, map[string]string{"haiku": "haiku-x", "sonnet": "sonnet-x"}, m.Models)
+	assert.False(t, m.CreatedAt.IsZero())
+}
+
+func TestConfigHash(t *testing.T) {
+	a := &config.Config{}
+	a.Anthropic.SonnetModel = "sonnet-x"
+	a.Anthropic.Key = "sk-one"
+	a.Store.DatabaseURL = "postgres://a"
+
+	b := &config.Config{}
+	b.Anthropic.SonnetModel = "sonnet-x"
+	b.Anthropic.Key = "sk-two"
+	b.Store.DatabaseURL = "postgres://b"
+
+	// Credentials and connection URLs do not affect the hash.
+	assert.Equal(t, ConfigHash(a), ConfigHash(b))
+
+	// Behavioral settings do.
+	b.Anthropic.SonnetModel = "sonnet-y"
+	assert.NotEqual(t, ConfigHash(a), ConfigHash(b))
+
+	// Keyword lists are not treated as secrets.
+	c := &config.Config{}
+	c.Anthropic.SonnetModel = "sonnet-x"
+	c.Anthropic.Key = "sk-one"
+	c.Scorer.IndustryKeywords = []string{"hvac"}
+	assert.NotEqual(t, ConfigHash(a), ConfigHash(c))
+
+	assert.Empty(t, ConfigHash(nil))
+}
+
+func TestQuestionPackVersion_OrderIndependent(t *testing.T) {
+	q1 := model.Question{ID: "q1", Text: "Revenue?"}
+	q2 := model.Question{ID: "q2", Text: "Employees?"}
+	assert.Equal(t, QuestionPackVersion([]model.Question{q1, q2}), QuestionPackVersion([]model.Question{q2, q1}))
+
+	q2.Instructions = "Count full-time staff."
+	assert.NotEqual(t, QuestionPackVersion([]model.Question{q1}), QuestionPackVersion([]model.Question{q1, q2}))
+}
+
+func TestDatasetVintages(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	synced := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	pool.ExpectQuery("SELECT DISTINCT ON \\(dataset\\)").
+		WillReturnRows(pgxmock.NewRows([]string{"dataset", "id", "started_at", "metadata"}).
+			AddRow("bds", int64(42), synced, []byte(`{"to_year":2023,"manifest":{"binary_version":"dev"}}`)).
+			AddRow("cbp", int64(7), synced, nil))
+
+	v, err := DatasetVintages(context.Background(), pool)
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+	assert.Equal(t, int64(42), v["bds"].SyncID)
+	assert.Equal(t, map[string]any{"to_year": float64(2023)}, v["bds"].Metadata)
+	assert.Nil(t, v["cbp"].Metadata)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestDatasetVintages_QueryError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("SELECT DISTINCT ON").WillReturnError(errors.New("relation does not exist"))
+
+	_, err = DatasetVintages(context.Background(), pool)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "manifest: query dataset vintages")
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package migrate
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBEAGeoLevelBackfill(t *testing.T) {
+	dbURL := os.Getenv("RESEARCH_INTEGRATION_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("RESEARCH_INTEGRATION_DATABASE_URL is not set")
+	}
+	ctx := t.Context()
+
+	db, err := sql.Open("pgx", dbURL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.ExecContext(ctx, `
+		DROP SCHEMA IF EXISTS fed_data CASCADE;
+		DROP SCHEMA IF EXISTS public CASCADE;
+		CREATE SCHEMA public;
+	`)
+	require.NoError(t, err)
+
+	goose.SetBaseFS(migrationsFS)
+	require.NoError(t, goose.SetDialect("postgres"))
+	require.NoError(t, goose.UpToContext(ctx, db, "migrations", 12))
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO fed_data.bea_regional (table_name, geo_fips, line_code, year, value) VALUES
+			('CAINC1', '00000', 1, 2023, 1),
+			('CAINC1', '06000', 1, 2023, 2),
+			('CAINC1', '06', 1, 2023, 3),
+			('CAINC1', '06037', 1, 2023, 4)
+	`)
+	require.NoError(t, err)
+	require.NoError(t, goose.UpToContext(ctx, db, "migrations", 13))
+
+	rows, err := db.QueryContext(ctx, `SELECT geo_fips, geo_level FROM fed_data.bea_regional`)
+	require.NoError(t, err)
+	defer rows.Close() //nolint:errcheck
+	got := map[string]string{}
+	for rows.Next() {
+		var fips, level string
+		require.NoError(t, rows.Scan(&fips, &level))
+		got[fips] = level
+	}
+	require.NoError(t, rows.Err())
+
+	assert.Equal(t, map[string]string{
+		"00000": "national",
+		"06000": "state",
+		"06":    "state",
+		"06037": "county",
+	}, got)
+}
@@ -84,6 +84,55 @@ func Status(ctx context.Context, dbURL string) error {
 	return nil
 }
 
+// Down rolls back applied migrations. With to < 0 only the latest
+// migration is rolled back; otherwise every migration above version to is.
+// The baseline (version 1) is never rolled back.
+func Down(ctx context.Context, dbURL string, to int64) error {
+	if dbURL == "" {
+		return eris.New("migrate: database URL is required")
+	}
+	if to == 0 {
+		return eris.New("migrate: cannot roll back the baseline (version 1)")
+	}
+
+	db, err := sql.Open("pgx", dbURL)
+	if err != nil {
+		return eris.Wrap(err, "migrate: open database")
+	}
+	defer db.Close() //nolint:errcheck
+
+	goose.SetBaseFS(migrationsFS)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return eris.Wrap(err, "migrate: set dialect")
+	}
+
+	current, err := goose.GetDBVersionContext(ctx, db)
+	if err != nil {
+		return eris.Wrap(err, "migrate: get current version")
+	}
+	if to < 0 {
+		if current <= 1 {
+			return eris.New("migrate: cannot roll back the baseline (version 1)")
+		}
+		zap.L().Info("rolling back latest migration", zap.Int64("version", current))
+		if err := goose.DownContext(ctx, db, "migrations"); err != nil {
+			return eris.Wrap(err, "migrate: roll back")
+		}
+		return nil
+	}
+
+	if to >= current {
+		zap.L().Info("nothing to roll back", zap.Int64("version", current), zap.Int64("to", to))
+		return nil
+	}
+	zap.L().Info("rolling back migrations", zap.Int64("from", current), zap.Int64("to", to))
+	if err := goose.DownToContext(ctx, db, "migrations", to); err != nil {
+		return eris.Wrapf(err, "migrate: roll back to %d", to)
+	}
+	return nil
+}
+
 // Baseline explicitly marks version 1 as applied without running its SQL.
 // Use this for manually baselining an existing database.
 func Baseline(ctx context.Context, dbURL string) error {
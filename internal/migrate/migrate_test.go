@@ -29,6 +29,18 @@ func TestStatus_EmptyURL(t *testing.T) {
 	}
 }
 
+func TestDown_EmptyURL(t *testing.T) {
+	err := Down(t.Context(), "", -1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "database URL is required")
+}
+
+func TestDown_RefusesBaseline(t *testing.T) {
+	err := Down(t.Context(), "postgres://unused", 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot roll back the baseline")
+}
+
 func TestBaseline_EmptyURL(t *testing.T) {
 	err := Baseline(t.Context(), "")
 	if err == nil {
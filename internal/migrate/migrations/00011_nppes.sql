@@ -0,0 +1,48 @@
+-- +goose Up
+
+-- CMS NPPES NPI provider registry (monthly full file + weekly deltas).
+CREATE TABLE IF NOT EXISTS fed_data.npi_providers (
+    npi                BIGINT PRIMARY KEY,
+    entity_type        SMALLINT,
+    org_name           TEXT,
+    last_name          TEXT,
+    first_name         TEXT,
+    middle_name        TEXT,
+    credential         TEXT,
+    other_org_name     TEXT,
+    practice_address1  TEXT,
+    practice_address2  TEXT,
+    practice_city      TEXT,
+    practice_state     TEXT,
+    practice_zip       TEXT,
+    practice_country   TEXT,
+    practice_phone     TEXT,
+    primary_taxonomy   TEXT,
+    taxonomy_codes     TEXT[],
+    sole_proprietor    BOOLEAN,
+    enumeration_date   DATE,
+    last_update_date   DATE,
+    deactivation_date  DATE,
+    reactivation_date  DATE,
+    source_file        TEXT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_npi_providers_state ON fed_data.npi_providers (practice_state);
+CREATE INDEX IF NOT EXISTS idx_npi_providers_zip ON fed_data.npi_providers (LEFT(practice_zip, 5));
+CREATE INDEX IF NOT EXISTS idx_npi_providers_taxonomy ON fed_data.npi_providers (primary_taxonomy);
+CREATE INDEX IF NOT EXISTS idx_npi_providers_source_file ON fed_data.npi_providers (source_file);
+CREATE INDEX IF NOT EXISTS idx_npi_providers_org_trgm ON fed_data.npi_providers USING GIN (org_name public.gin_trgm_ops);
+
+-- Dissemination files already applied to fed_data.npi_providers.
+CREATE TABLE IF NOT EXISTS fed_data.npi_file_loads (
+    file_name    TEXT PRIMARY KEY,
+    file_type    TEXT NOT NULL,
+    file_date    DATE NOT NULL,
+    rows_loaded  BIGINT NOT NULL DEFAULT 0,
+    loaded_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.npi_file_loads;
+DROP TABLE IF EXISTS fed_data.npi_providers;
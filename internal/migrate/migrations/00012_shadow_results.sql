@@ -0,0 +1,19 @@
+-- +goose Up
+-- Shadow-mode answers from candidate question packs/models, stored apart
+-- from production results so they never reach the gate or CRM.
+CREATE TABLE IF NOT EXISTS public.shadow_results (
+    id           BIGSERIAL PRIMARY KEY,
+    run_id       TEXT NOT NULL REFERENCES public.runs (id),
+    company_url  TEXT NOT NULL,
+    variant      TEXT NOT NULL,
+    answers      JSONB NOT NULL DEFAULT '[]'::jsonb,
+    comparison   JSONB NOT NULL DEFAULT '{}'::jsonb,
+    token_usage  JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_results_variant_created ON public.shadow_results (variant, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_shadow_results_run ON public.shadow_results (run_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS public.shadow_results;
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- BEA regional rows now cover MSAs as well as states and counties; CBSA codes
+-- share the 5-digit space with county FIPS, so the geography level becomes part
+-- of the natural key.
+ALTER TABLE fed_data.bea_regional ADD COLUMN IF NOT EXISTS geo_level TEXT NOT NULL DEFAULT 'county';
+-- Mirrors beaGeoLevelForFIPS so backfilled rows key the same way new syncs do.
+UPDATE fed_data.bea_regional SET geo_level = CASE
+    WHEN geo_fips IN ('00', '00000') THEN 'national'
+    WHEN length(geo_fips) = 2 OR geo_fips LIKE '%000' THEN 'state'
+    ELSE 'county'
+END;
+ALTER TABLE fed_data.bea_regional DROP CONSTRAINT IF EXISTS bea_regional_table_name_geo_fips_line_code_year_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bea_regional_key
+    ON fed_data.bea_regional (table_name, geo_level, geo_fips, line_code, year);
+
+-- +goose Down
+DROP INDEX IF EXISTS fed_data.idx_bea_regional_key;
+DELETE FROM fed_data.bea_regional WHERE geo_level = 'msa';
+ALTER TABLE fed_data.bea_regional ADD CONSTRAINT bea_regional_table_name_geo_fips_line_code_year_key
+    UNIQUE (table_name, geo_fips, line_code, year);
+ALTER TABLE fed_data.bea_regional DROP COLUMN IF EXISTS geo_level;
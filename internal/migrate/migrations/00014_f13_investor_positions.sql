@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Advisor → issuer relationship graph derived from 13F holdings. One row per
+-- filer, quarter, and CUSIP with the position's weight in the filer's book and
+-- the quarter-over-quarter change.
+CREATE TABLE IF NOT EXISTS fed_data.f13_investor_positions (
+    cik               VARCHAR(10) NOT NULL,
+    period            DATE NOT NULL,
+    cusip             CHAR(9) NOT NULL,
+    filer_name        TEXT,
+    crd_number        INT,
+    issuer_name       TEXT,
+    value             BIGINT,
+    shares            BIGINT,
+    portfolio_weight  DOUBLE PRECISION,
+    position_rank     INT,
+    prior_value       BIGINT,
+    value_change_pct  DOUBLE PRECISION,
+    first_period      DATE,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (cik, period, cusip)
+);
+CREATE INDEX IF NOT EXISTS idx_f13_positions_cusip ON fed_data.f13_investor_positions (cusip, period DESC);
+CREATE INDEX IF NOT EXISTS idx_f13_positions_issuer ON fed_data.f13_investor_positions USING GIN (issuer_name public.gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_f13_positions_crd ON fed_data.f13_investor_positions (crd_number) WHERE crd_number IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.f13_investor_positions;
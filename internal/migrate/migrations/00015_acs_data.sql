@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- American Community Survey 5-year estimates in long form: one row per
+-- vintage, geography, and variable. geo_id is the 5-digit county FIPS or the
+-- 11-digit state+county+tract code.
+CREATE TABLE IF NOT EXISTS fed_data.acs_data (
+    year         SMALLINT NOT NULL,
+    geo_level    TEXT NOT NULL,
+    geo_id       VARCHAR(11) NOT NULL,
+    state_fips   CHAR(2) NOT NULL,
+    county_fips  CHAR(3) NOT NULL,
+    tract        CHAR(6),
+    name         TEXT,
+    variable     VARCHAR(20) NOT NULL,
+    value        DOUBLE PRECISION,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (year, geo_level, geo_id, variable)
+);
+CREATE INDEX IF NOT EXISTS idx_acs_data_county ON fed_data.acs_data (state_fips, county_fips, variable);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.acs_data;
@@ -0,0 +1,37 @@
+-- +goose Up
+
+-- Per-fund service providers from Form ADV Schedule D 7.B.(1)
+-- (item 23 auditors, item 24 prime brokers, item 26 administrators).
+CREATE TABLE IF NOT EXISTS fed_data.adv_fund_service_providers (
+    crd_number     INTEGER NOT NULL,
+    fund_id        VARCHAR(50) NOT NULL,
+    provider_type  VARCHAR(50) NOT NULL,
+    provider_name  VARCHAR(300) NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (crd_number, fund_id, provider_type, provider_name)
+);
+CREATE INDEX IF NOT EXISTS idx_fund_svc_providers_type ON fed_data.adv_fund_service_providers (provider_type, provider_name);
+
+-- Fund auditor/administrator/prime broker network: one row per normalized
+-- provider with the number of advisers and funds using it. is_mainstream is
+-- set for auditors only.
+CREATE TABLE IF NOT EXISTS fed_data.fund_provider_network (
+    provider_type          VARCHAR(50) NOT NULL,
+    provider_name          VARCHAR(300) NOT NULL,
+    firm_count             INTEGER NOT NULL DEFAULT 0,
+    fund_count             INTEGER NOT NULL DEFAULT 0,
+    answer_firm_count      INTEGER NOT NULL DEFAULT 0,
+    schedule_d_firm_count  INTEGER NOT NULL DEFAULT 0,
+    is_mainstream          BOOLEAN,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider_type, provider_name)
+);
+CREATE INDEX IF NOT EXISTS idx_fund_provider_network_firms ON fed_data.fund_provider_network (provider_type, firm_count DESC);
+
+ALTER TABLE fed_data.adv_computed_metrics
+    ADD COLUMN IF NOT EXISTS non_mainstream_auditor BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE fed_data.adv_computed_metrics DROP COLUMN IF EXISTS non_mainstream_auditor;
+DROP TABLE IF EXISTS fed_data.fund_provider_network;
+DROP TABLE IF EXISTS fed_data.adv_fund_service_providers;
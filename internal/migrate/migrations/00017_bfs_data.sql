@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Census Business Formation Statistics. frequency is 'monthly' (EITS API,
+-- by state and NAICS sector) or 'weekly' (state business applications, not
+-- seasonally adjusted). period is the first of the month or the week-ending
+-- Saturday. series is the Census data_type_code (e.g. BA_BA, BA_HBA, BF_BF4Q).
+CREATE TABLE IF NOT EXISTS fed_data.bfs_data (
+    frequency       VARCHAR(10) NOT NULL,
+    period          DATE NOT NULL,
+    geo             VARCHAR(5) NOT NULL,
+    naics_sector    VARCHAR(10) NOT NULL,
+    series          VARCHAR(20) NOT NULL,
+    seasonally_adj  BOOLEAN NOT NULL,
+    value           DOUBLE PRECISION,
+    PRIMARY KEY (frequency, period, geo, naics_sector, series, seasonally_adj)
+);
+CREATE INDEX IF NOT EXISTS idx_bfs_data_series ON fed_data.bfs_data (series, geo, naics_sector, period DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.bfs_data;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Supports the bounding-box prefilter used by the enrichment pipeline's
+-- EPA facility proximity check (Phase 7E).
+CREATE INDEX IF NOT EXISTS idx_epa_location ON fed_data.epa_facilities (fac_lat, fac_long)
+    WHERE fac_lat IS NOT NULL AND fac_long IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS fed_data.idx_epa_location;
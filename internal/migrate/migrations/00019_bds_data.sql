@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Census Business Dynamics Statistics. geo_level is 'us', 'state', 'county',
+-- or 'msa' (CBSA); naics is the sector code ('00' = all industries). Rates
+-- are percentages as published; suppressed cells are NULL.
+CREATE TABLE IF NOT EXISTS fed_data.bds_data (
+    year                  SMALLINT NOT NULL,
+    geo_level             VARCHAR(10) NOT NULL,
+    geo_id                VARCHAR(10) NOT NULL,
+    naics                 VARCHAR(10) NOT NULL,
+    name                  TEXT,
+    firms                 DOUBLE PRECISION,
+    estabs                DOUBLE PRECISION,
+    emp                   DOUBLE PRECISION,
+    estabs_entry          DOUBLE PRECISION,
+    estabs_entry_rate     DOUBLE PRECISION,
+    estabs_exit           DOUBLE PRECISION,
+    estabs_exit_rate      DOUBLE PRECISION,
+    job_creation          DOUBLE PRECISION,
+    job_creation_rate     DOUBLE PRECISION,
+    job_destruction       DOUBLE PRECISION,
+    job_destruction_rate  DOUBLE PRECISION,
+    net_job_creation      DOUBLE PRECISION,
+    firm_deaths           DOUBLE PRECISION,
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (year, geo_level, geo_id, naics)
+);
+CREATE INDEX IF NOT EXISTS idx_bds_data_sector ON fed_data.bds_data (naics, geo_level, year DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.bds_data;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- County market opportunity scores, rebuilt from CBP, SUSB, ACS, and
+-- Salesforce account coverage. *_pct columns are percentile ranks (0-1)
+-- across scored counties; score is the weighted sum scaled to 0-100.
+CREATE TABLE IF NOT EXISTS fed_data.county_opportunity_scores (
+    county_fips       CHAR(5) PRIMARY KEY,
+    state_fips        CHAR(2) NOT NULL,
+    name              TEXT,
+    cbp_year          SMALLINT,
+    target_estabs     BIGINT,
+    target_emp        BIGINT,
+    small_firm_share  DOUBLE PRECISION,
+    median_hh_income  DOUBLE PRECISION,
+    population        DOUBLE PRECISION,
+    sf_accounts       INTEGER NOT NULL DEFAULT 0,
+    coverage_ratio    DOUBLE PRECISION,
+    estab_pct         DOUBLE PRECISION,
+    small_firm_pct    DOUBLE PRECISION,
+    wealth_pct        DOUBLE PRECISION,
+    whitespace_pct    DOUBLE PRECISION,
+    score             NUMERIC(5,2),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_county_opportunity_score ON fed_data.county_opportunity_scores (score DESC);
+CREATE INDEX IF NOT EXISTS idx_county_opportunity_state ON fed_data.county_opportunity_scores (state_fips, score DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.county_opportunity_scores;
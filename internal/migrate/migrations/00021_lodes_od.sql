@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- LEHD LODES origin-destination flows aggregated to tract pairs (11-digit
+-- GEOIDs). state is the workplace state file the row came from; out-of-state
+-- residents come from the aux files.
+CREATE TABLE IF NOT EXISTS fed_data.lodes_od (
+    year                    SMALLINT NOT NULL,
+    state                   CHAR(2) NOT NULL,
+    w_tract                 CHAR(11) NOT NULL,
+    h_tract                 CHAR(11) NOT NULL,
+    total_jobs              INTEGER NOT NULL DEFAULT 0,
+    jobs_age_29_or_younger  INTEGER,
+    jobs_age_30_to_54       INTEGER,
+    jobs_age_55_plus        INTEGER,
+    jobs_earn_1250_or_less  INTEGER,
+    jobs_earn_1251_to_3333  INTEGER,
+    jobs_earn_3334_or_more  INTEGER,
+    PRIMARY KEY (year, w_tract, h_tract)
+);
+CREATE INDEX IF NOT EXISTS idx_lodes_od_work ON fed_data.lodes_od (w_tract, year);
+CREATE INDEX IF NOT EXISTS idx_lodes_od_home ON fed_data.lodes_od (h_tract, year);
+
+-- Workforce catchment (labor shed) per geocoded company address, rebuilt
+-- after each lodes_od sync. Shares are fractions of total_workers.
+CREATE TABLE IF NOT EXISTS fed_data.lodes_catchments (
+    address_id         BIGINT PRIMARY KEY,
+    company_id         BIGINT NOT NULL,
+    year               SMALLINT NOT NULL,
+    work_tract         CHAR(11) NOT NULL,
+    total_workers      BIGINT,
+    home_tracts        INTEGER,
+    home_counties      INTEGER,
+    same_county_share  DOUBLE PRECISION,
+    low_wage_share     DOUBLE PRECISION,
+    high_wage_share    DOUBLE PRECISION,
+    top_home_counties  JSONB,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_lodes_catchments_company ON fed_data.lodes_catchments (company_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.lodes_catchments;
+DROP TABLE IF EXISTS fed_data.lodes_od;
@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- BLS Job Openings and Labor Turnover Survey series. Components are parsed
+-- from the series ID: seasonal ('S'/'U'), industry_code (e.g. '000000' total
+-- nonfarm), state_code ('00' national), size_class, data_element ('JO'
+-- openings, 'HI' hires, 'QU' quits, 'LD' layoffs, 'TS' separations), and
+-- rate_level ('L' thousands, 'R' percent).
+CREATE TABLE IF NOT EXISTS fed_data.jolts_data (
+    series_id      VARCHAR(21) NOT NULL,
+    year           SMALLINT NOT NULL,
+    period         VARCHAR(3) NOT NULL,
+    value          DOUBLE PRECISION,
+    seasonal       CHAR(1),
+    industry_code  VARCHAR(6),
+    state_code     VARCHAR(2),
+    size_class     VARCHAR(2),
+    data_element   VARCHAR(2),
+    rate_level     CHAR(1),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (series_id, year, period)
+);
+CREATE INDEX IF NOT EXISTS idx_jolts_data_element ON fed_data.jolts_data (data_element, industry_code, state_code, year DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.jolts_data;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- BLS Producer Price Index series (WP* commodity, PCU* industry). Values are
+-- index levels as published.
+CREATE TABLE IF NOT EXISTS fed_data.ppi_data (
+    series_id   VARCHAR(30) NOT NULL,
+    year        SMALLINT NOT NULL,
+    period      VARCHAR(3) NOT NULL,
+    value       DOUBLE PRECISION,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (series_id, year, period)
+);
+
+-- BLS Consumer Price Index series (CU* all urban consumers). Values are index
+-- levels as published.
+CREATE TABLE IF NOT EXISTS fed_data.cpi_data (
+    series_id   VARCHAR(30) NOT NULL,
+    year        SMALLINT NOT NULL,
+    period      VARCHAR(3) NOT NULL,
+    value       DOUBLE PRECISION,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (series_id, year, period)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.cpi_data;
+DROP TABLE IF EXISTS fed_data.ppi_data;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- FCC Broadband Data Collection fixed availability aggregated from
+-- per-location provider filings. geo_level is 'block' (15-digit GEOID) or
+-- 'county' (5-digit FIPS). Location counts are distinct broadband-serviceable
+-- locations with at least one fixed offer; 25/3 and 100/20 require low
+-- latency.
+CREATE TABLE IF NOT EXISTS fed_data.fcc_broadband (
+    as_of_date          DATE NOT NULL,
+    geo_level           VARCHAR(6) NOT NULL,
+    geoid               VARCHAR(15) NOT NULL,
+    state_fips          CHAR(2) NOT NULL,
+    county_fips         CHAR(5) NOT NULL,
+    locations           INTEGER NOT NULL,
+    locations_25_3      INTEGER NOT NULL,
+    locations_100_20    INTEGER NOT NULL,
+    locations_1000_100  INTEGER NOT NULL,
+    fiber_locations     INTEGER NOT NULL,
+    providers           INTEGER NOT NULL,
+    max_download        DOUBLE PRECISION,
+    max_upload          DOUBLE PRECISION,
+    pct_100_20          DOUBLE PRECISION,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (as_of_date, geo_level, geoid)
+);
+CREATE INDEX IF NOT EXISTS idx_fcc_broadband_geoid ON fed_data.fcc_broadband (geoid, as_of_date DESC);
+CREATE INDEX IF NOT EXISTS idx_fcc_broadband_county ON fed_data.fcc_broadband (county_fips, geo_level);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.fcc_broadband;
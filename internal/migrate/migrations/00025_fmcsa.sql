@@ -0,0 +1,49 @@
+-- +goose Up
+
+-- FMCSA Motor Carrier Census joined with SMS (Safety Measurement System)
+-- BASIC percentiles, one row per USDOT number. name_norm, street_norm, and
+-- zip5 hold normalized values for entity matching.
+CREATE TABLE IF NOT EXISTS fed_data.fmcsa_carriers (
+    dot_number              INTEGER PRIMARY KEY,
+    legal_name              TEXT NOT NULL,
+    dba_name                TEXT,
+    name_norm               TEXT,
+    carrier_operation       VARCHAR(4),
+    hm_flag                 BOOLEAN NOT NULL DEFAULT false,
+    pc_flag                 BOOLEAN NOT NULL DEFAULT false,
+    phy_street              TEXT,
+    phy_city                TEXT,
+    phy_state               VARCHAR(2),
+    phy_zip                 VARCHAR(10),
+    phy_country             VARCHAR(2),
+    street_norm             TEXT,
+    zip5                    CHAR(5),
+    mailing_street          TEXT,
+    mailing_city            TEXT,
+    mailing_state           VARCHAR(2),
+    mailing_zip             VARCHAR(10),
+    telephone               VARCHAR(20),
+    email                   TEXT,
+    mcs150_date             DATE,
+    mcs150_mileage          BIGINT,
+    mcs150_mileage_year     SMALLINT,
+    add_date                DATE,
+    power_units             INTEGER,
+    drivers                 INTEGER,
+    inspections             INTEGER,
+    driver_oos_inspections  INTEGER,
+    vehicle_oos_inspections INTEGER,
+    unsafe_driving_pct      NUMERIC(5,1),
+    hos_pct                 NUMERIC(5,1),
+    driver_fitness_pct      NUMERIC(5,1),
+    drugs_alcohol_pct       NUMERIC(5,1),
+    vehicle_maint_pct       NUMERIC(5,1),
+    basic_alerts            SMALLINT,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_fmcsa_carriers_name_trgm ON fed_data.fmcsa_carriers USING gin (name_norm gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_fmcsa_carriers_state_zip ON fed_data.fmcsa_carriers (phy_state, zip5);
+CREATE INDEX IF NOT EXISTS idx_fmcsa_carriers_street ON fed_data.fmcsa_carriers (zip5, street_norm);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.fmcsa_carriers;
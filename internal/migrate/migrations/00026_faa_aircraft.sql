@@ -0,0 +1,58 @@
+-- +goose Up
+
+-- FAA Releasable Aircraft Database: one row per registered N-number (stored
+-- without the "N" prefix) with manufacturer/model from ACFTREF. name_norm,
+-- street_norm, and zip5 hold normalized registrant values for entity
+-- matching. registrant_type: 1 individual, 2 partnership, 3 corporation,
+-- 4 co-owned, 5 government, 7 LLC, 8 non-citizen corp, 9 non-citizen co-owned.
+CREATE TABLE IF NOT EXISTS fed_data.faa_aircraft (
+    n_number          VARCHAR(6) PRIMARY KEY,
+    serial_number     TEXT,
+    mfr_mdl_code      VARCHAR(7),
+    aircraft_mfr      TEXT,
+    aircraft_model    TEXT,
+    year_mfr          SMALLINT,
+    type_aircraft     VARCHAR(1),
+    type_engine       SMALLINT,
+    num_engines       SMALLINT,
+    num_seats         SMALLINT,
+    weight_class      VARCHAR(10),
+    registrant_type   SMALLINT,
+    registrant_name   TEXT,
+    name_norm         TEXT,
+    street            TEXT,
+    street2           TEXT,
+    city              TEXT,
+    state             VARCHAR(2),
+    zip               VARCHAR(10),
+    country           VARCHAR(2),
+    street_norm       TEXT,
+    zip5              CHAR(5),
+    cert_issue_date   DATE,
+    last_action_date  DATE,
+    expiration_date   DATE,
+    status_code       VARCHAR(2),
+    fractional_owner  BOOLEAN NOT NULL DEFAULT false,
+    mode_s_hex        VARCHAR(10),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_faa_aircraft_name_trgm ON fed_data.faa_aircraft USING gin (name_norm gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_faa_aircraft_state_zip ON fed_data.faa_aircraft (state, zip5);
+
+-- Business registrants matched to enrichment companies (entity_key =
+-- companies.id) and ADV firms (entity_key = crd_number). Rebuilt after each
+-- faa_registry sync.
+CREATE TABLE IF NOT EXISTS fed_data.faa_aircraft_matches (
+    n_number     VARCHAR(6) NOT NULL REFERENCES fed_data.faa_aircraft (n_number) ON DELETE CASCADE,
+    entity_type  VARCHAR(10) NOT NULL,
+    entity_key   TEXT NOT NULL,
+    match_type   VARCHAR(20) NOT NULL,
+    confidence   NUMERIC(3,2) NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (n_number, entity_type, entity_key)
+);
+CREATE INDEX IF NOT EXISTS idx_faa_aircraft_matches_entity ON fed_data.faa_aircraft_matches (entity_type, entity_key);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.faa_aircraft_matches;
+DROP TABLE IF EXISTS fed_data.faa_aircraft;
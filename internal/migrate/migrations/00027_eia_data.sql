@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- EIA v2 API observations: state retail electricity prices (keyed by the
+-- legacy ELEC.PRICE.<state>-<sector>.M series ID) and configured fuel
+-- series. period is the EIA period string (YYYY, YYYY-MM, or YYYY-MM-DD).
+-- state_code and sector are set for electricity price rows only.
+CREATE TABLE IF NOT EXISTS fed_data.eia_data (
+    series_id   VARCHAR(60) NOT NULL,
+    period      VARCHAR(10) NOT NULL,
+    state_code  VARCHAR(2),
+    sector      VARCHAR(5),
+    value       DOUBLE PRECISION,
+    units       VARCHAR(40),
+    description TEXT,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (series_id, period)
+);
+
+CREATE INDEX IF NOT EXISTS idx_eia_data_state_sector
+    ON fed_data.eia_data (state_code, sector, period)
+    WHERE state_code IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.eia_data;
@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- MSRB EMMA municipal securities: one row per EMMA issue with primary
+-- market details from the issues extract and continuing disclosure
+-- rollups from the disclosures extract. advisor_name_norm is the normalized
+-- name of the first listed municipal advisor; advisor_crd links it to an
+-- ADV firm and is rebuilt after each emma sync.
+CREATE TABLE IF NOT EXISTS fed_data.emma_issues (
+    issue_id                   VARCHAR(20) PRIMARY KEY,
+    issuer_name                TEXT,
+    issuer_cusip6              VARCHAR(6),
+    state                      VARCHAR(2),
+    issue_description          TEXT,
+    security_type              VARCHAR(60),
+    tax_status                 VARCHAR(40),
+    offering_type              VARCHAR(40),
+    dated_date                 DATE,
+    sale_date                  DATE,
+    closing_date               DATE,
+    first_maturity_date        DATE,
+    final_maturity_date        DATE,
+    par_amount                 NUMERIC(18,2),
+    municipal_advisor          TEXT,
+    advisor_name_norm          TEXT,
+    advisor_crd                INTEGER,
+    underwriter                TEXT,
+    bond_counsel               TEXT,
+    official_statement_url     TEXT,
+    cd_filing_count            INTEGER NOT NULL DEFAULT 0,
+    last_cd_filing_date        DATE,
+    last_financial_filing_date DATE,
+    last_event_notice_date     DATE,
+    failure_to_file_count      INTEGER NOT NULL DEFAULT 0,
+    updated_at                 TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_emma_issues_cusip6 ON fed_data.emma_issues (issuer_cusip6);
+CREATE INDEX IF NOT EXISTS idx_emma_issues_state ON fed_data.emma_issues (state, sale_date);
+CREATE INDEX IF NOT EXISTS idx_emma_issues_advisor_crd ON fed_data.emma_issues (advisor_crd)
+    WHERE advisor_crd IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_emma_issues_advisor_norm ON fed_data.emma_issues (advisor_name_norm);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.emma_issues;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- Secretary of State business entities from state bulk extracts, one row
+-- per (state, entity_id). status is normalized (active, inactive,
+-- dissolved, withdrawn, delinquent) with the state's wording in status_raw.
+-- first_seen_at is set on insert only, so new rows between syncs surface
+-- formations even when a state omits the formation date.
+CREATE TABLE IF NOT EXISTS fed_data.sos_entities (
+    state            VARCHAR(2) NOT NULL,
+    entity_id        VARCHAR(30) NOT NULL,
+    entity_name      TEXT NOT NULL,
+    name_norm        TEXT,
+    entity_type      TEXT,
+    status           VARCHAR(12),
+    status_raw       TEXT,
+    jurisdiction     TEXT,
+    registered_agent TEXT,
+    principal_street TEXT,
+    principal_city   TEXT,
+    principal_state  VARCHAR(2),
+    principal_zip    VARCHAR(10),
+    formation_date   DATE,
+    dissolution_date DATE,
+    first_seen_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (state, entity_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sos_entities_name_trgm ON fed_data.sos_entities USING gin (name_norm gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_sos_entities_status ON fed_data.sos_entities (state, status);
+CREATE INDEX IF NOT EXISTS idx_sos_entities_formed ON fed_data.sos_entities (formation_date);
+CREATE INDEX IF NOT EXISTS idx_sos_entities_dissolved ON fed_data.sos_entities (dissolution_date)
+    WHERE dissolution_date IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.sos_entities;
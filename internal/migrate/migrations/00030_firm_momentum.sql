@@ -0,0 +1,50 @@
+-- +goose Up
+
+-- Per-firm rate-of-change tables rebuilt by the firm_momentum dataset.
+--
+-- firm_trends holds one point per firm, metric, and year: AUM and headcount
+-- from the last ADV filing of the year, disclosure events dated in the year,
+-- and website content changes observed in the year.
+CREATE TABLE IF NOT EXISTS fed_data.firm_trends (
+    crd_number  INTEGER NOT NULL,
+    metric      VARCHAR(20) NOT NULL,
+    period      DATE NOT NULL,
+    value       NUMERIC,
+    prior_value NUMERIC,
+    change_pct  DOUBLE PRECISION,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (crd_number, metric, period)
+);
+CREATE INDEX IF NOT EXISTS idx_firm_trends_metric ON fed_data.firm_trends (metric, period DESC);
+
+-- firm_website_hashes records each distinct crawl content hash per firm
+-- website; a new row is a website change.
+CREATE TABLE IF NOT EXISTS fed_data.firm_website_hashes (
+    crd_number   INTEGER NOT NULL,
+    content_hash VARCHAR(32) NOT NULL,
+    crawled_at   TIMESTAMPTZ NOT NULL,
+    observed_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (crd_number, crawled_at)
+);
+
+-- firm_momentum is the latest rate-of-change summary per firm. momentum is
+-- a 0-1 blend of the component changes (0.5 = flat), NULL without inputs.
+CREATE TABLE IF NOT EXISTS fed_data.firm_momentum (
+    crd_number              INTEGER PRIMARY KEY,
+    aum                     BIGINT,
+    aum_change_pct          DOUBLE PRECISION,
+    employees               INTEGER,
+    employees_change_pct    DOUBLE PRECISION,
+    disclosures_12m         INTEGER NOT NULL DEFAULT 0,
+    disclosures_prior_12m   INTEGER NOT NULL DEFAULT 0,
+    website_changes_12m     INTEGER NOT NULL DEFAULT 0,
+    website_last_changed_at TIMESTAMPTZ,
+    momentum                NUMERIC(4,3),
+    computed_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_firm_momentum_score ON fed_data.firm_momentum (momentum DESC NULLS LAST);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.firm_momentum;
+DROP TABLE IF EXISTS fed_data.firm_website_hashes;
+DROP TABLE IF EXISTS fed_data.firm_trends;
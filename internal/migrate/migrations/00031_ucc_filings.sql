@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- UCC financing statements from state bulk extracts, one row per (state,
+-- filing_number, debtor_name) since a filing can name several debtors.
+-- filing_type is normalized (initial, amendment, continuation, termination,
+-- assignment) and collateral_type is classified from the extract's type
+-- code or collateral description (all_assets, equipment, inventory,
+-- receivables, vehicles, fixtures, farm_products, securities, other).
+CREATE TABLE IF NOT EXISTS fed_data.ucc_filings (
+    state                  VARCHAR(2) NOT NULL,
+    filing_number          VARCHAR(30) NOT NULL,
+    debtor_name            TEXT NOT NULL,
+    debtor_name_norm       TEXT,
+    debtor_city            TEXT,
+    debtor_state           VARCHAR(2),
+    debtor_zip             VARCHAR(10),
+    secured_party_name     TEXT,
+    secured_party_norm     TEXT,
+    filing_type            VARCHAR(12),
+    filing_date            DATE,
+    lapse_date             DATE,
+    original_filing_number VARCHAR(30),
+    collateral_type        VARCHAR(16),
+    collateral_description TEXT,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (state, filing_number, debtor_name)
+);
+CREATE INDEX IF NOT EXISTS idx_ucc_filings_debtor_trgm ON fed_data.ucc_filings USING gin (debtor_name_norm gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_ucc_filings_secured_party ON fed_data.ucc_filings (secured_party_norm);
+CREATE INDEX IF NOT EXISTS idx_ucc_filings_date ON fed_data.ucc_filings (filing_date);
+CREATE INDEX IF NOT EXISTS idx_ucc_filings_original ON fed_data.ucc_filings (state, original_filing_number)
+    WHERE original_filing_number IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.ucc_filings;
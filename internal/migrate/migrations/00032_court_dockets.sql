@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- Federal court dockets from CourtListener RECAP search, kept when a
+-- defendant matches a known company. defendant_norms holds the normalized
+-- names of matched defendants for lookups by company name.
+CREATE TABLE IF NOT EXISTS fed_data.court_dockets (
+    docket_id        BIGINT PRIMARY KEY,
+    court_id         VARCHAR(20) NOT NULL,
+    docket_number    TEXT,
+    case_name        TEXT NOT NULL,
+    nature_of_suit   TEXT,
+    cause            TEXT,
+    date_filed       DATE,
+    date_terminated  DATE,
+    defendants       TEXT[] NOT NULL,
+    defendant_norms  TEXT[] NOT NULL,
+    parties          TEXT[],
+    absolute_url     TEXT,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_court_dockets_defendants ON fed_data.court_dockets USING gin (defendant_norms);
+CREATE INDEX IF NOT EXISTS idx_court_dockets_filed ON fed_data.court_dockets (date_filed);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.court_dockets;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Litigation releases and administrative proceedings are now synced from the
+-- SEC index feeds. respondent_norm holds the normalized respondent name used
+-- to link actions to entity_xref and adv_firms.
+ALTER TABLE fed_data.sec_enforcement_actions ADD COLUMN IF NOT EXISTS respondent_norm TEXT;
+CREATE INDEX IF NOT EXISTS idx_enforcement_respondent_norm ON fed_data.sec_enforcement_actions (respondent_norm);
+CREATE INDEX IF NOT EXISTS idx_enforcement_cik ON fed_data.sec_enforcement_actions (cik);
+
+-- +goose Down
+DROP INDEX IF EXISTS fed_data.idx_enforcement_cik;
+DROP INDEX IF EXISTS fed_data.idx_enforcement_respondent_norm;
+ALTER TABLE fed_data.sec_enforcement_actions DROP COLUMN IF EXISTS respondent_norm;
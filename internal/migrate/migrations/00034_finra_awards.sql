@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- FINRA arbitration awards from the Arbitration Awards Online metadata
+-- export. award_text and award_amount are filled from the award PDF;
+-- respondent_crds is rebuilt after each sync by matching respondent_norms
+-- against adv_firms and form_bd firm names.
+CREATE TABLE IF NOT EXISTS fed_data.finra_awards (
+    case_id          VARCHAR(30) PRIMARY KEY,
+    forum            VARCHAR(30),
+    document_type    VARCHAR(50),
+    award_date       DATE,
+    claimants        TEXT[],
+    respondents      TEXT[] NOT NULL,
+    respondent_norms TEXT[] NOT NULL,
+    respondent_crds  INTEGER[],
+    pdf_url          TEXT,
+    award_amount     BIGINT,
+    award_text       TEXT,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_finra_awards_respondents ON fed_data.finra_awards USING gin (respondent_norms);
+CREATE INDEX IF NOT EXISTS idx_finra_awards_crds ON fed_data.finra_awards USING gin (respondent_crds);
+CREATE INDEX IF NOT EXISTS idx_finra_awards_date ON fed_data.finra_awards (award_date);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.finra_awards;
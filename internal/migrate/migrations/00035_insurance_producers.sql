@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- Insurance producer licenses from state Department of Insurance extracts,
+-- one row per (state, license_number). entity_type is individual or agency;
+-- lines_of_authority is normalized (life, accident_health, property,
+-- casualty, variable, personal_lines, title, surplus_lines, other).
+-- crd_number is rebuilt after each sync for agencies, and for individuals
+-- through their affiliated agency, whose normalized name identifies exactly
+-- one adv_firms or form_bd firm.
+CREATE TABLE IF NOT EXISTS fed_data.insurance_producers (
+    state              VARCHAR(2) NOT NULL,
+    license_number     VARCHAR(30) NOT NULL,
+    npn                VARCHAR(12),
+    entity_type        VARCHAR(10) NOT NULL,
+    name               TEXT NOT NULL,
+    name_norm          TEXT,
+    agency_name        TEXT,
+    agency_norm        TEXT,
+    city               TEXT,
+    address_state      VARCHAR(2),
+    zip                VARCHAR(10),
+    resident           BOOLEAN,
+    status             VARCHAR(10),
+    lines_of_authority TEXT[],
+    issue_date         DATE,
+    expiration_date    DATE,
+    crd_number         INTEGER,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (state, license_number)
+);
+CREATE INDEX IF NOT EXISTS idx_insurance_producers_npn ON fed_data.insurance_producers (npn);
+CREATE INDEX IF NOT EXISTS idx_insurance_producers_name ON fed_data.insurance_producers (name_norm);
+CREATE INDEX IF NOT EXISTS idx_insurance_producers_agency ON fed_data.insurance_producers (agency_norm);
+CREATE INDEX IF NOT EXISTS idx_insurance_producers_crd ON fed_data.insurance_producers (crd_number)
+    WHERE crd_number IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.insurance_producers;
@@ -0,0 +1,64 @@
+-- +goose Up
+
+-- SAM.gov entity registrations from the monthly public entity extract,
+-- keyed by Unique Entity ID. extract_date is the month of the extract the
+-- row was last loaded from; naics_codes and business_types hold the codes
+-- from the extract's "~"-separated lists.
+CREATE TABLE IF NOT EXISTS fed_data.sam_entities (
+    uei                    VARCHAR(12) PRIMARY KEY,
+    cage_code              VARCHAR(5),
+    registration_status    VARCHAR(8),
+    purpose                VARCHAR(2),
+    legal_name             TEXT NOT NULL,
+    legal_name_norm        TEXT,
+    dba_name               TEXT,
+    address_line1          TEXT,
+    city                   TEXT,
+    state                  VARCHAR(2),
+    zip                    VARCHAR(10),
+    country                VARCHAR(3),
+    entity_structure       VARCHAR(2),
+    state_of_incorporation VARCHAR(2),
+    primary_naics          VARCHAR(6),
+    naics_codes            TEXT[],
+    business_types         TEXT[],
+    entity_url             TEXT,
+    registration_date      DATE,
+    expiration_date        DATE,
+    last_update_date       DATE,
+    entity_start_date      DATE,
+    extract_date           DATE NOT NULL,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_sam_entities_name ON fed_data.sam_entities (legal_name_norm);
+CREATE INDEX IF NOT EXISTS idx_sam_entities_cage ON fed_data.sam_entities (cage_code);
+CREATE INDEX IF NOT EXISTS idx_sam_entities_naics ON fed_data.sam_entities (primary_naics);
+
+-- SAM.gov exclusions (debarments and suspensions) from the daily public
+-- exclusions extract. The extract lists every current record, so rows
+-- missing from the latest extract are deleted after each sync.
+-- termination_date is NULL for indefinite exclusions.
+CREATE TABLE IF NOT EXISTS fed_data.sam_exclusions (
+    sam_number        VARCHAR(20) PRIMARY KEY,
+    classification    VARCHAR(30),
+    name              TEXT NOT NULL,
+    name_norm         TEXT,
+    uei               VARCHAR(12),
+    cage_code         VARCHAR(5),
+    city              TEXT,
+    state             VARCHAR(2),
+    country           VARCHAR(3),
+    exclusion_program VARCHAR(30),
+    excluding_agency  TEXT,
+    exclusion_type    TEXT,
+    active_date       DATE,
+    termination_date  DATE,
+    comments          TEXT,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_sam_exclusions_uei ON fed_data.sam_exclusions (uei) WHERE uei IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_sam_exclusions_name ON fed_data.sam_exclusions (name_norm);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.sam_exclusions;
+DROP TABLE IF EXISTS fed_data.sam_entities;
@@ -0,0 +1,53 @@
+-- +goose Up
+
+-- OSHA Injury Tracking Application Form 300A summaries, one row per
+-- establishment and filing year. DART (days away, restricted, or
+-- transferred) and TRIR (total recordable incident rate) are cases per
+-- 200,000 hours worked, i.e. per 100 full-time workers.
+CREATE TABLE IF NOT EXISTS fed_data.osha_ita_300a (
+    year_filing_for              SMALLINT NOT NULL,
+    establishment_id             VARCHAR(40) NOT NULL,
+    establishment_name           TEXT NOT NULL,
+    establishment_name_norm      TEXT,
+    company_name                 TEXT,
+    ein                          VARCHAR(9),
+    street_address               TEXT,
+    city                         TEXT,
+    state                        VARCHAR(2),
+    zip                          VARCHAR(10),
+    naics_code                   VARCHAR(6),
+    industry_description         TEXT,
+    establishment_type           SMALLINT,
+    size                         SMALLINT,
+    annual_average_employees     INTEGER,
+    total_hours_worked           BIGINT,
+    total_deaths                 INTEGER,
+    total_dafw_cases             INTEGER,
+    total_djtr_cases             INTEGER,
+    total_other_cases            INTEGER,
+    total_dafw_days              INTEGER,
+    total_djtr_days              INTEGER,
+    total_injuries               INTEGER,
+    total_skin_disorders         INTEGER,
+    total_respiratory_conditions INTEGER,
+    total_poisonings             INTEGER,
+    total_hearing_loss           INTEGER,
+    total_other_illnesses        INTEGER,
+    dart_rate                    NUMERIC(10,2) GENERATED ALWAYS AS (
+        CASE WHEN total_hours_worked > 0 THEN
+            (COALESCE(total_dafw_cases, 0) + COALESCE(total_djtr_cases, 0)) * 200000.0 / total_hours_worked
+        END) STORED,
+    trir                         NUMERIC(10,2) GENERATED ALWAYS AS (
+        CASE WHEN total_hours_worked > 0 THEN
+            (COALESCE(total_dafw_cases, 0) + COALESCE(total_djtr_cases, 0) + COALESCE(total_other_cases, 0))
+                * 200000.0 / total_hours_worked
+        END) STORED,
+    updated_at                   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (year_filing_for, establishment_id)
+);
+CREATE INDEX IF NOT EXISTS idx_osha_ita_300a_name ON fed_data.osha_ita_300a (establishment_name_norm);
+CREATE INDEX IF NOT EXISTS idx_osha_ita_300a_ein ON fed_data.osha_ita_300a (ein) WHERE ein IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_osha_ita_300a_naics ON fed_data.osha_ita_300a (naics_code);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.osha_ita_300a;
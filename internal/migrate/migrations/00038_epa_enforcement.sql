@@ -0,0 +1,37 @@
+-- +goose Up
+
+-- EPA ECHO enforcement and compliance history, one row per FRS registry_id
+-- (joinable to fed_data.epa_facilities). Action, penalty, and noncompliance
+-- summaries come from the ECHO Exporter; case_* columns aggregate the ICIS
+-- civil enforcement cases naming the facility. Only facilities with an
+-- enforcement action, penalty, case, or quarter of noncompliance are kept.
+CREATE TABLE IF NOT EXISTS fed_data.epa_enforcement (
+    registry_id               VARCHAR(50) NOT NULL PRIMARY KEY,
+    fac_name                  TEXT,
+    fac_state                 VARCHAR(2),
+    compliance_status         TEXT,
+    snc_flag                  BOOLEAN NOT NULL DEFAULT false,
+    qtrs_with_nc              SMALLINT NOT NULL DEFAULT 0,
+    programs_with_snc         TEXT[],
+    compliance_history_3yr    TEXT,
+    inspection_count          INTEGER NOT NULL DEFAULT 0,
+    last_inspection_date      DATE,
+    informal_action_count     INTEGER NOT NULL DEFAULT 0,
+    last_informal_action_date DATE,
+    formal_action_count       INTEGER NOT NULL DEFAULT 0,
+    last_formal_action_date   DATE,
+    penalty_count             INTEGER NOT NULL DEFAULT 0,
+    total_penalties           NUMERIC(14,2),
+    last_penalty_date         DATE,
+    last_penalty_amount       NUMERIC(14,2),
+    case_count                INTEGER,
+    case_numbers              TEXT[],
+    case_penalties            NUMERIC(14,2),
+    last_case_date            DATE,
+    updated_at                TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_epa_enforcement_state ON fed_data.epa_enforcement (fac_state);
+CREATE INDEX IF NOT EXISTS idx_epa_enforcement_snc ON fed_data.epa_enforcement (registry_id) WHERE snc_flag;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.epa_enforcement;
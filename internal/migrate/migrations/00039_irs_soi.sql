@@ -0,0 +1,33 @@
+-- +goose Up
+
+-- IRS SOI county migration: the name and state of the other county of each
+-- flow (the origin for inflows, the destination for outflows).
+ALTER TABLE fed_data.irs_soi_migration
+    ADD COLUMN IF NOT EXISTS counterpart_state VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS counterpart_name  TEXT;
+
+-- IRS SOI individual income by ZIP code and AGI size class (agi_stub 1-6;
+-- 6 is $200,000 or more). Amounts are in thousands of dollars.
+CREATE TABLE IF NOT EXISTS fed_data.soi_zip_income (
+    tax_year        SMALLINT NOT NULL,
+    zipcode         VARCHAR(5) NOT NULL,
+    agi_stub        SMALLINT NOT NULL,
+    state           VARCHAR(2),
+    returns         BIGINT,
+    individuals     BIGINT,
+    agi             BIGINT,
+    wages           BIGINT,
+    dividends       BIGINT,
+    capital_gains   BIGINT,
+    business_income BIGINT,
+    total_income    BIGINT,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tax_year, zipcode, agi_stub)
+);
+CREATE INDEX IF NOT EXISTS idx_soi_zip_income_zip ON fed_data.soi_zip_income (zipcode);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.soi_zip_income;
+ALTER TABLE fed_data.irs_soi_migration
+    DROP COLUMN IF EXISTS counterpart_name,
+    DROP COLUMN IF EXISTS counterpart_state;
@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Census ZIP Code Business Patterns detail: establishment counts by ZIP,
+-- NAICS, and employment size class (n1_4 = 1-4 employees ... n1000 = 1,000
+-- or more). naics 000000 is the all-industries total. Loaded by the cbp
+-- dataset when fedsync.cbp.zip_level is set.
+CREATE TABLE IF NOT EXISTS fed_data.zbp_data (
+    year     SMALLINT NOT NULL,
+    zip      VARCHAR(5) NOT NULL,
+    naics    VARCHAR(6) NOT NULL,
+    state    VARCHAR(2),
+    city     TEXT,
+    est      INTEGER,
+    n1_4     INTEGER,
+    n5_9     INTEGER,
+    n10_19   INTEGER,
+    n20_49   INTEGER,
+    n50_99   INTEGER,
+    n100_249 INTEGER,
+    n250_499 INTEGER,
+    n500_999 INTEGER,
+    n1000    INTEGER,
+    PRIMARY KEY (year, zip, naics)
+);
+CREATE INDEX IF NOT EXISTS idx_zbp_data_naics ON fed_data.zbp_data (naics, year);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.zbp_data;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- SEC ticker-to-CIK map (company_tickers.json) with each ticker's exchange
+-- (company_tickers_exchange.json). Covers currently listed registrants only;
+-- delisted tickers are removed on each sync. cik is zero-padded to 10 digits
+-- like fed_data.edgar_entities.
+CREATE TABLE IF NOT EXISTS fed_data.cik_tickers (
+    cik        VARCHAR(10) NOT NULL,
+    ticker     VARCHAR(20) NOT NULL,
+    title      TEXT,
+    exchange   VARCHAR(20),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (cik, ticker)
+);
+CREATE INDEX IF NOT EXISTS idx_cik_tickers_ticker ON fed_data.cik_tickers (ticker);
+CREATE INDEX IF NOT EXISTS idx_cik_tickers_title ON fed_data.cik_tickers (UPPER(TRIM(title)));
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.cik_tickers;
@@ -0,0 +1,57 @@
+-- +goose Up
+
+-- 13F cover page fields from each filer's latest primary_doc.xml: business
+-- address, 13F file number, signer, and the count of other included managers.
+ALTER TABLE fed_data.f13_filers
+    ADD COLUMN IF NOT EXISTS street1              VARCHAR(200),
+    ADD COLUMN IF NOT EXISTS street2              VARCHAR(200),
+    ADD COLUMN IF NOT EXISTS city                 VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS state                VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS zip                  VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS report_type          TEXT,
+    ADD COLUMN IF NOT EXISTS file_number          VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS crd_number           VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS is_amendment         BOOLEAN,
+    ADD COLUMN IF NOT EXISTS signer_name          TEXT,
+    ADD COLUMN IF NOT EXISTS signer_title         TEXT,
+    ADD COLUMN IF NOT EXISTS signer_phone         VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS signature_date       DATE,
+    ADD COLUMN IF NOT EXISTS other_managers_count INTEGER;
+CREATE INDEX IF NOT EXISTS idx_f13_filers_state ON fed_data.f13_filers (state);
+
+-- Other managers named on a 13F filing. role 'included' lists managers whose
+-- holdings the filer reports (summary page); 'reporting' lists managers that
+-- report this filer's holdings (cover page of a notice or combination
+-- report). manager_cik is unpadded, like f13_filers.cik.
+CREATE TABLE IF NOT EXISTS fed_data.f13_other_managers (
+    cik          VARCHAR(10) NOT NULL,
+    period       DATE NOT NULL,
+    role         VARCHAR(10) NOT NULL,
+    seq          INTEGER NOT NULL,
+    manager_cik  VARCHAR(10),
+    manager_name TEXT,
+    file_number  VARCHAR(20),
+    crd_number   VARCHAR(20),
+    PRIMARY KEY (cik, period, role, seq)
+);
+CREATE INDEX IF NOT EXISTS idx_f13_other_managers_manager ON fed_data.f13_other_managers (manager_cik);
+CREATE INDEX IF NOT EXISTS idx_f13_other_managers_file ON fed_data.f13_other_managers (file_number);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.f13_other_managers;
+DROP INDEX IF EXISTS fed_data.idx_f13_filers_state;
+ALTER TABLE fed_data.f13_filers
+    DROP COLUMN IF EXISTS street1,
+    DROP COLUMN IF EXISTS street2,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS state,
+    DROP COLUMN IF EXISTS zip,
+    DROP COLUMN IF EXISTS report_type,
+    DROP COLUMN IF EXISTS file_number,
+    DROP COLUMN IF EXISTS crd_number,
+    DROP COLUMN IF EXISTS is_amendment,
+    DROP COLUMN IF EXISTS signer_name,
+    DROP COLUMN IF EXISTS signer_title,
+    DROP COLUMN IF EXISTS signer_phone,
+    DROP COLUMN IF EXISTS signature_date,
+    DROP COLUMN IF EXISTS other_managers_count;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- EDGAR full-text search hits for the configured keyword queries, one row per
+-- query, filing, and filer. accession_number joins to fed_data.edgar_filings
+-- and cik (zero-padded) to fed_data.edgar_entities. first_seen_at records when
+-- a hit first appeared, for "new this week" monitoring.
+CREATE TABLE IF NOT EXISTS fed_data.edgar_fts_hits (
+    query            TEXT NOT NULL,
+    accession_number VARCHAR(25) NOT NULL,
+    cik              VARCHAR(10) NOT NULL,
+    entity_name      TEXT,
+    form_type        VARCHAR(20),
+    file_name        TEXT,
+    filing_date      DATE,
+    period_ending    DATE,
+    state            VARCHAR(2),
+    sic              VARCHAR(4),
+    url              TEXT,
+    first_seen_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (query, accession_number, cik)
+);
+CREATE INDEX IF NOT EXISTS idx_edgar_fts_hits_cik ON fed_data.edgar_fts_hits (cik);
+CREATE INDEX IF NOT EXISTS idx_edgar_fts_hits_date ON fed_data.edgar_fts_hits (query, filing_date DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.edgar_fts_hits;
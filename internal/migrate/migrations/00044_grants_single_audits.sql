@@ -0,0 +1,63 @@
+-- +goose Up
+
+-- Federal grant awards (USAspending assistance types 02-05), keyed by the
+-- USAspending generated award ID. recipient_uei joins to
+-- fed_data.single_audits.auditee_uei and fed_data.sam_entities.
+CREATE TABLE IF NOT EXISTS fed_data.grants (
+    award_id            TEXT PRIMARY KEY,
+    fain                TEXT,
+    award_type          TEXT,
+    recipient_uei       VARCHAR(12),
+    recipient_name      TEXT,
+    recipient_city      TEXT,
+    recipient_state     VARCHAR(2),
+    recipient_zip       VARCHAR(5),
+    awarding_agency     TEXT,
+    awarding_sub_agency TEXT,
+    funding_agency      TEXT,
+    cfda_number         VARCHAR(10),
+    cfda_title          TEXT,
+    award_amount        NUMERIC(18,2),
+    total_outlays       NUMERIC(18,2),
+    start_date          DATE,
+    end_date            DATE,
+    last_modified_date  DATE,
+    description         TEXT,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_grants_recipient_uei ON fed_data.grants (recipient_uei);
+CREATE INDEX IF NOT EXISTS idx_grants_state ON fed_data.grants (recipient_state);
+CREATE INDEX IF NOT EXISTS idx_grants_modified ON fed_data.grants (last_modified_date);
+
+-- Single Audit submissions from the Federal Audit Clearinghouse general
+-- view, one row per report. Auditee EINs join to fed_data.eo_bmf; auditor
+-- fields identify the audit firm.
+CREATE TABLE IF NOT EXISTS fed_data.single_audits (
+    report_id             TEXT PRIMARY KEY,
+    auditee_uei           VARCHAR(12),
+    auditee_ein           VARCHAR(9),
+    auditee_name          TEXT,
+    auditee_city          TEXT,
+    auditee_state         VARCHAR(2),
+    auditee_zip           VARCHAR(10),
+    entity_type           TEXT,
+    audit_year            SMALLINT,
+    fy_end_date           DATE,
+    fac_accepted_date     DATE,
+    auditor_firm_name     TEXT,
+    auditor_ein           VARCHAR(9),
+    auditor_city          TEXT,
+    auditor_state         VARCHAR(2),
+    total_amount_expended NUMERIC(18,2),
+    is_going_concern      BOOLEAN,
+    is_material_weakness  BOOLEAN,
+    is_low_risk_auditee   BOOLEAN,
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_single_audits_uei ON fed_data.single_audits (auditee_uei);
+CREATE INDEX IF NOT EXISTS idx_single_audits_ein ON fed_data.single_audits (auditee_ein);
+CREATE INDEX IF NOT EXISTS idx_single_audits_auditor ON fed_data.single_audits (auditor_ein);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.single_audits;
+DROP TABLE IF EXISTS fed_data.grants;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- High-water marks for incremental dataset syncs. The fedsync engine reads a
+-- dataset's mark before calling SyncIncremental and advances it after each
+-- successful sync; a dataset without a row runs its default window.
+CREATE TABLE IF NOT EXISTS fed_data.sync_state (
+    dataset    TEXT PRIMARY KEY,
+    high_water TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.sync_state;
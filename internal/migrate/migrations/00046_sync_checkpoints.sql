@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Progress of in-flight long-running dataset syncs (xbrl_facts,
+-- edgar_submissions). A Resumable dataset saves the last key it committed
+-- (a CIK or file name) and skips past it on the next run; the row is
+-- deleted when the sync completes.
+CREATE TABLE IF NOT EXISTS fed_data.sync_checkpoints (
+    dataset    TEXT PRIMARY KEY,
+    last_key   TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.sync_checkpoints;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Run history for fedsync datasets. Every run is already a row in
+-- fed_data.sync_log; these columns add what the engine measures while the
+-- run executes, and fed_data.sync_runs is the read surface for run history
+-- and `fedsync status`.
+ALTER TABLE fed_data.sync_log
+    ADD COLUMN IF NOT EXISTS bytes_downloaded BIGINT,
+    ADD COLUMN IF NOT EXISTS source_versions  JSONB;
+
+CREATE OR REPLACE VIEW fed_data.sync_runs AS
+SELECT
+    id,
+    dataset,
+    status,
+    started_at,
+    completed_at,
+    completed_at - started_at AS duration,
+    rows_synced,
+    bytes_downloaded,
+    error,
+    source_versions,
+    metadata
+FROM fed_data.sync_log;
+
+-- +goose Down
+DROP VIEW IF EXISTS fed_data.sync_runs;
+ALTER TABLE fed_data.sync_log
+    DROP COLUMN IF EXISTS source_versions,
+    DROP COLUMN IF EXISTS bytes_downloaded;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Results of the post-sync validation rules (row-count deltas, null rates,
+-- referential checks) the fedsync engine runs after each dataset sync.
+CREATE TABLE IF NOT EXISTS fed_data.sync_validations (
+    id         BIGSERIAL PRIMARY KEY,
+    sync_id    BIGINT NOT NULL REFERENCES fed_data.sync_log (id) ON DELETE CASCADE,
+    dataset    TEXT NOT NULL,
+    rule       TEXT NOT NULL,
+    passed     BOOLEAN NOT NULL,
+    observed   DOUBLE PRECISION,
+    threshold  DOUBLE PRECISION,
+    message    TEXT,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_validations_dataset
+    ON fed_data.sync_validations (dataset, checked_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_sync_validations_sync
+    ON fed_data.sync_validations (sync_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.sync_validations;
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Last downloaded version of each fedsync source file: the HTTP validators
+-- the server sent and the SHA256 of the body. Datasets send the validators
+-- as a conditional request and skip parsing when the server answers 304 or
+-- the body hashes the same as last time.
+CREATE TABLE IF NOT EXISTS fed_data.source_files (
+    dataset       TEXT NOT NULL,
+    url           TEXT NOT NULL,
+    etag          TEXT,
+    last_modified TEXT,
+    sha256        TEXT,
+    size_bytes    BIGINT,
+    checked_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    changed_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (dataset, url)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.source_files;
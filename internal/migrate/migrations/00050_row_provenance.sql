@@ -0,0 +1,68 @@
+-- +goose Up
+
+-- Row-level provenance for fed_data tables: the sync run (fed_data.sync_log
+-- id) that last wrote each row, the upstream file it came from, and when.
+-- The engine stamps these through db.BulkUpsert / db.CopyFrom; rows written
+-- outside a sync keep NULLs and the ingested_at default.
+--
+-- Partitions inherit the columns from their parent. Sync bookkeeping tables
+-- and tables that already carry their own source_url (pe_answers) are left
+-- alone.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    t RECORD;
+BEGIN
+    FOR t IN
+        SELECT c.relname
+        FROM pg_class c
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        WHERE n.nspname = 'fed_data'
+          AND c.relkind IN ('r', 'p')
+          AND NOT c.relispartition
+          AND c.relname NOT IN ('sync_log', 'sync_state', 'sync_checkpoints', 'sync_validations', 'source_files')
+          AND NOT EXISTS (
+              SELECT 1 FROM pg_attribute a
+              WHERE a.attrelid = c.oid AND a.attname = 'source_url' AND NOT a.attisdropped
+          )
+    LOOP
+        EXECUTE format(
+            'ALTER TABLE fed_data.%I
+                 ADD COLUMN IF NOT EXISTS source_url       TEXT,
+                 ADD COLUMN IF NOT EXISTS source_file_hash TEXT,
+                 ADD COLUMN IF NOT EXISTS ingested_at      TIMESTAMPTZ DEFAULT now(),
+                 ADD COLUMN IF NOT EXISTS sync_run_id      BIGINT',
+            t.relname);
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+DECLARE
+    t RECORD;
+BEGIN
+    FOR t IN
+        SELECT c.relname
+        FROM pg_class c
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        JOIN pg_attribute a ON a.attrelid = c.oid
+        WHERE n.nspname = 'fed_data'
+          AND c.relkind IN ('r', 'p')
+          AND NOT c.relispartition
+          AND a.attname = 'sync_run_id'
+          AND NOT a.attisdropped
+    LOOP
+        EXECUTE format(
+            'ALTER TABLE fed_data.%I
+                 DROP COLUMN IF EXISTS sync_run_id,
+                 DROP COLUMN IF EXISTS ingested_at,
+                 DROP COLUMN IF EXISTS source_file_hash,
+                 DROP COLUMN IF EXISTS source_url',
+            t.relname);
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
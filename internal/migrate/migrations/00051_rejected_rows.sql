@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Dead-letter table for rows fedsync parsers reject (bad CUSIP, zero CRD,
+-- unparsable dates, ...): the raw record, why it was rejected, and the run
+-- and source file it came from. Each run keeps a capped sample; the full
+-- per-reason counts are in sync_log.metadata (rejected_rows,
+-- rejected_reasons).
+CREATE TABLE IF NOT EXISTS fed_data.rejected_rows (
+    id          BIGSERIAL PRIMARY KEY,
+    sync_run_id BIGINT NOT NULL,
+    dataset     TEXT NOT NULL,
+    reason      TEXT NOT NULL,
+    record      JSONB,
+    source_url  TEXT,
+    rejected_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rejected_rows_run ON fed_data.rejected_rows (sync_run_id);
+CREATE INDEX IF NOT EXISTS idx_rejected_rows_dataset ON fed_data.rejected_rows (dataset, rejected_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.rejected_rows;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Heartbeat of each running `fedsync daemon`: when it started, its last
+-- and next schedule check, the datasets that check found due, and the
+-- error it hit, if any. One row per host.
+CREATE TABLE IF NOT EXISTS fed_data.daemon_state (
+    host         TEXT PRIMARY KEY,
+    started_at   TIMESTAMPTZ NOT NULL,
+    last_tick_at TIMESTAMPTZ NOT NULL,
+    next_tick_at TIMESTAMPTZ,
+    last_due     TEXT[] NOT NULL DEFAULT '{}',
+    synced       INT NOT NULL DEFAULT 0,
+    failed       INT NOT NULL DEFAULT 0,
+    last_error   TEXT
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.daemon_state;
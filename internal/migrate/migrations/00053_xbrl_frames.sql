@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- EDGAR XBRL frames: one concept's value for every filer in a calendar
+-- period (e.g. us-gaap Assets in USD at CY2024Q4I), as published by the
+-- frames API. frame is SEC's period code: CY2024 (annual duration),
+-- CY2024Q2 (quarterly duration), or CY2024Q4I (instant).
+CREATE TABLE IF NOT EXISTS fed_data.xbrl_frames (
+    taxonomy     VARCHAR(20)  NOT NULL,
+    tag          VARCHAR(150) NOT NULL,
+    unit         VARCHAR(30)  NOT NULL,
+    frame        VARCHAR(12)  NOT NULL,
+    cik          VARCHAR(10)  NOT NULL,
+    entity_name  TEXT,
+    loc          VARCHAR(10),
+    period_start DATE,
+    period_end   DATE NOT NULL,
+    value        NUMERIC,
+    accession    VARCHAR(25),
+    -- Row provenance, as added to existing tables by 00050.
+    source_url       TEXT,
+    source_file_hash TEXT,
+    ingested_at      TIMESTAMPTZ DEFAULT now(),
+    sync_run_id      BIGINT,
+    PRIMARY KEY (taxonomy, tag, unit, frame, cik)
+);
+
+CREATE INDEX IF NOT EXISTS idx_xbrl_frames_cik ON fed_data.xbrl_frames (cik);
+CREATE INDEX IF NOT EXISTS idx_xbrl_frames_tag_frame ON fed_data.xbrl_frames (tag, frame);
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.xbrl_frames;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- Every 13F-HR and 13F-HR/A loaded, with amendment lineage. For a CIK and
+-- period, the effective holdings come from the latest original or
+-- RESTATEMENT filing plus any NEW HOLDINGS amendments filed after it;
+-- earlier filings are marked superseded_by the filing that replaced them
+-- and their holdings are deleted.
+CREATE TABLE IF NOT EXISTS fed_data.f13_filings (
+    accession_number VARCHAR(25) PRIMARY KEY,
+    cik              VARCHAR(10) NOT NULL,
+    period           DATE,
+    form_type        VARCHAR(10),
+    filing_date      DATE,
+    amendment_no     INTEGER NOT NULL DEFAULT 0,
+    amendment_type   VARCHAR(20),          -- NULL for originals; RESTATEMENT or NEW HOLDINGS
+    superseded_by    VARCHAR(25),
+    holdings         INTEGER,
+    total_value      BIGINT,
+    loaded_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- Row provenance, as added to existing tables by 00050.
+    source_url       TEXT,
+    source_file_hash TEXT,
+    ingested_at      TIMESTAMPTZ DEFAULT now(),
+    sync_run_id      BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_f13_filings_cik_period ON fed_data.f13_filings (cik, period);
+
+-- The filing each holding was loaded from. NULL for holdings loaded before
+-- lineage was tracked; the next original or restatement for the period
+-- replaces them.
+ALTER TABLE fed_data.f13_holdings ADD COLUMN IF NOT EXISTS accession_number VARCHAR(25);
+
+-- +goose Down
+ALTER TABLE fed_data.f13_holdings DROP COLUMN IF EXISTS accession_number;
+DROP TABLE IF EXISTS fed_data.f13_filings;
@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- How each CRD-CIK link was made. match_method is 'deterministic' for the
+-- identifier and exact-name passes and 'probabilistic' for the scored
+-- pass; match_score is the scored pass's weighted score (deterministic
+-- links repeat their fixed confidence), and match_detail holds its
+-- per-signal components so reviewers can audit low-confidence links.
+ALTER TABLE fed_data.entity_xref ADD COLUMN IF NOT EXISTS match_method VARCHAR(20);
+ALTER TABLE fed_data.entity_xref ADD COLUMN IF NOT EXISTS match_score NUMERIC(4,3);
+ALTER TABLE fed_data.entity_xref ADD COLUMN IF NOT EXISTS match_detail JSONB;
+CREATE INDEX IF NOT EXISTS idx_entity_xref_method_score ON fed_data.entity_xref (match_method, match_score);
+
+-- EDGAR business address, an address signal for the scored pass.
+ALTER TABLE fed_data.edgar_entities ADD COLUMN IF NOT EXISTS business_street VARCHAR(200);
+ALTER TABLE fed_data.edgar_entities ADD COLUMN IF NOT EXISTS business_city VARCHAR(100);
+ALTER TABLE fed_data.edgar_entities ADD COLUMN IF NOT EXISTS business_zip VARCHAR(10);
+
+-- +goose Down
+ALTER TABLE fed_data.edgar_entities DROP COLUMN IF EXISTS business_zip;
+ALTER TABLE fed_data.edgar_entities DROP COLUMN IF EXISTS business_city;
+ALTER TABLE fed_data.edgar_entities DROP COLUMN IF EXISTS business_street;
+DROP INDEX IF EXISTS fed_data.idx_entity_xref_method_score;
+ALTER TABLE fed_data.entity_xref DROP COLUMN IF EXISTS match_detail;
+ALTER TABLE fed_data.entity_xref DROP COLUMN IF EXISTS match_score;
+ALTER TABLE fed_data.entity_xref DROP COLUMN IF EXISTS match_method;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- The identifier value a link was joined on (sec_number, LEI, UEI, DUNS)
+-- for identifier passes; match_type names its kind. NULL for name-based
+-- links.
+ALTER TABLE fed_data.entity_xref ADD COLUMN IF NOT EXISTS match_key TEXT;
+ALTER TABLE fed_data.entity_xref_multi ADD COLUMN IF NOT EXISTS match_key TEXT;
+CREATE INDEX IF NOT EXISTS idx_xref_multi_match_key ON fed_data.entity_xref_multi (match_key) WHERE match_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS fed_data.idx_xref_multi_match_key;
+ALTER TABLE fed_data.entity_xref_multi DROP COLUMN IF EXISTS match_key;
+ALTER TABLE fed_data.entity_xref DROP COLUMN IF EXISTS match_key;
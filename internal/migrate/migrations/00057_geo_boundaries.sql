@@ -0,0 +1,48 @@
+-- +goose Up
+
+-- Unified TIGER/Line boundary layers (state, county, tract, block_group,
+-- place), one row per feature per vintage so a new TIGER release loads
+-- alongside the previous one. Geometries are stored in WGS84.
+CREATE TABLE IF NOT EXISTS geo.boundaries (
+    layer         TEXT NOT NULL,
+    geoid         TEXT NOT NULL,
+    vintage       SMALLINT NOT NULL,
+    state_fips    TEXT,
+    name          TEXT,
+    aland         BIGINT,
+    awater        BIGINT,
+    geom          geometry(MultiPolygon, 4326),
+    latitude      DOUBLE PRECISION,
+    longitude     DOUBLE PRECISION,
+    source        TEXT NOT NULL DEFAULT 'tiger',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (layer, geoid, vintage)
+);
+CREATE INDEX IF NOT EXISTS idx_boundaries_layer_state ON geo.boundaries (layer, vintage, state_fips);
+CREATE INDEX IF NOT EXISTS idx_boundaries_geom ON geo.boundaries USING GIST (geom);
+
+-- One row per completed layer load; a vintage becomes current for its
+-- layer only once recorded here, so a half-loaded release is never served.
+CREATE TABLE IF NOT EXISTS geo.boundary_vintages (
+    layer         TEXT NOT NULL,
+    vintage       SMALLINT NOT NULL,
+    row_count     BIGINT NOT NULL,
+    loaded_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (layer, vintage)
+);
+
+-- Latest recorded vintage of each layer, for point-in-polygon lookups.
+CREATE OR REPLACE VIEW geo.current_boundaries AS
+SELECT b.*
+FROM geo.boundaries b
+JOIN (
+    SELECT layer, MAX(vintage) AS vintage
+    FROM geo.boundary_vintages
+    GROUP BY layer
+) v ON v.layer = b.layer AND v.vintage = b.vintage;
+
+-- +goose Down
+DROP VIEW IF EXISTS geo.current_boundaries;
+DROP TABLE IF EXISTS geo.boundary_vintages;
+DROP TABLE IF EXISTS geo.boundaries;
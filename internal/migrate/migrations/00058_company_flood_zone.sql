@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- FEMA NFHL flood zone at each geocoded company address, refreshed after
+-- every flood zone sync. flood_zone is the FLD_ZONE code (AE, VE, X, ...)
+-- and flood_type its risk class; both stay NULL when the point falls
+-- outside mapped NFHL polygons. flood_zone_checked_at records the last
+-- lookup so unmapped and untagged addresses can be told apart.
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS flood_zone VARCHAR(20);
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS flood_type VARCHAR(20);
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS flood_zone_checked_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_company_addresses_flood_type ON public.company_addresses (flood_type) WHERE flood_type IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS public.idx_company_addresses_flood_type;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS flood_zone_checked_at;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS flood_type;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS flood_zone;
@@ -0,0 +1,60 @@
+-- +goose Up
+
+-- OpenFEMA disaster declarations, one row per designated area (county,
+-- statewide "000", or tribal area) of each declaration, with that county's
+-- public assistance project count and federal share obligated.
+CREATE TABLE IF NOT EXISTS geo.fema_disasters (
+    id                   BIGSERIAL PRIMARY KEY,
+    disaster_number      INT NOT NULL,
+    declaration_string   TEXT,
+    declaration_type     TEXT,
+    declaration_date     DATE,
+    state                TEXT,
+    state_fips           TEXT NOT NULL,
+    county_fips          TEXT NOT NULL,
+    designated_area      TEXT,
+    place_code           TEXT,
+    incident_type        TEXT,
+    title                TEXT,
+    incident_begin       DATE,
+    incident_end         DATE,
+    closeout_date        DATE,
+    ih_declared          BOOLEAN NOT NULL DEFAULT false,
+    ia_declared          BOOLEAN NOT NULL DEFAULT false,
+    pa_declared          BOOLEAN NOT NULL DEFAULT false,
+    hm_declared          BOOLEAN NOT NULL DEFAULT false,
+    pa_projects          INT,
+    pa_federal_obligated NUMERIC(16,2),
+    source               TEXT NOT NULL DEFAULT 'openfema',
+    source_id            TEXT NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (source, source_id)
+);
+CREATE INDEX IF NOT EXISTS idx_fema_disasters_county_date ON geo.fema_disasters (county_fips, declaration_date DESC);
+CREATE INDEX IF NOT EXISTS idx_fema_disasters_state_date ON geo.fema_disasters (state_fips, declaration_date DESC);
+CREATE INDEX IF NOT EXISTS idx_fema_disasters_number ON geo.fema_disasters (disaster_number);
+
+-- Five-year disaster exposure per county. Statewide declarations count
+-- toward every county in the state.
+CREATE OR REPLACE VIEW geo.county_disaster_exposure AS
+SELECT c.county_fips,
+       COUNT(DISTINCT d.disaster_number) AS declarations_5y,
+       COUNT(DISTINCT d.disaster_number) FILTER (WHERE d.declaration_type = 'DR') AS major_disasters_5y,
+       MAX(d.declaration_date) AS last_declaration_date,
+       SUM(d.pa_federal_obligated) FILTER (WHERE d.county_fips = c.county_fips) AS pa_federal_obligated_5y,
+       ARRAY_AGG(DISTINCT d.incident_type) FILTER (WHERE d.incident_type IS NOT NULL) AS incident_types
+FROM (
+    SELECT DISTINCT county_fips, state_fips
+    FROM geo.fema_disasters
+    WHERE county_fips NOT LIKE '__000'
+) c
+JOIN geo.fema_disasters d
+  ON d.county_fips = c.county_fips
+  OR (d.state_fips = c.state_fips AND d.county_fips = c.state_fips || '000')
+WHERE d.declaration_date >= CURRENT_DATE - INTERVAL '5 years'
+GROUP BY c.county_fips;
+
+-- +goose Down
+DROP VIEW IF EXISTS geo.county_disaster_exposure;
+DROP TABLE IF EXISTS geo.fema_disasters;
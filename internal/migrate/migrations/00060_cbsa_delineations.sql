@@ -0,0 +1,62 @@
+-- +goose Up
+
+-- CBSA boundaries, loaded from TIGER/Line by the cbsa_delineations scraper.
+-- Created here for databases where the geo baseline predates it.
+CREATE TABLE IF NOT EXISTS geo.cbsa (
+    id            BIGSERIAL PRIMARY KEY,
+    cbsa_code     TEXT NOT NULL UNIQUE,
+    name          TEXT,
+    lsad          TEXT,
+    geom          geometry(MultiPolygon, 4326),
+    latitude      DOUBLE PRECISION,
+    longitude     DOUBLE PRECISION,
+    source        TEXT NOT NULL DEFAULT 'tiger',
+    source_id     TEXT NOT NULL,
+    properties    JSONB DEFAULT '{}'::jsonb,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_cbsa_geom ON geo.cbsa USING GIST (geom);
+
+-- OMB delineation summary per CBSA: metro or micro, the enclosing CSA, and
+-- the number of member counties in the delineation vintage.
+ALTER TABLE geo.cbsa ADD COLUMN IF NOT EXISTS metro_micro TEXT;
+ALTER TABLE geo.cbsa ADD COLUMN IF NOT EXISTS csa_code TEXT;
+ALTER TABLE geo.cbsa ADD COLUMN IF NOT EXISTS csa_name TEXT;
+ALTER TABLE geo.cbsa ADD COLUMN IF NOT EXISTS county_count INTEGER;
+ALTER TABLE geo.cbsa ADD COLUMN IF NOT EXISTS delineation_vintage SMALLINT;
+
+-- OMB CBSA delineation (Census list 1): one row per member county. A county
+-- belongs to at most one CBSA per vintage; counties dropped from every CBSA
+-- are removed when a new vintage loads.
+CREATE TABLE IF NOT EXISTS geo.cbsa_counties (
+    county_fips           TEXT PRIMARY KEY,
+    state_fips            TEXT NOT NULL,
+    cbsa_code             TEXT NOT NULL,
+    cbsa_title            TEXT,
+    metro_micro           TEXT,
+    metro_division_code   TEXT,
+    metro_division_title  TEXT,
+    csa_code              TEXT,
+    csa_title             TEXT,
+    county_name           TEXT,
+    state_name            TEXT,
+    central_outlying      TEXT,
+    vintage               SMALLINT NOT NULL,
+    source                TEXT NOT NULL DEFAULT 'omb',
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_cbsa_counties_cbsa ON geo.cbsa_counties (cbsa_code);
+CREATE INDEX IF NOT EXISTS idx_cbsa_counties_csa ON geo.cbsa_counties (csa_code) WHERE csa_code IS NOT NULL;
+
+-- MSA associations now come from geo.cbsa, whose codes need not exist in
+-- the static public.cbsa_areas import.
+ALTER TABLE public.address_msa DROP CONSTRAINT IF EXISTS address_msa_cbsa_code_fkey;
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.cbsa_counties;
+ALTER TABLE geo.cbsa DROP COLUMN IF EXISTS delineation_vintage;
+ALTER TABLE geo.cbsa DROP COLUMN IF EXISTS county_count;
+ALTER TABLE geo.cbsa DROP COLUMN IF EXISTS csa_name;
+ALTER TABLE geo.cbsa DROP COLUMN IF EXISTS csa_code;
+ALTER TABLE geo.cbsa DROP COLUMN IF EXISTS metro_micro;
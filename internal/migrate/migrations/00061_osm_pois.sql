@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Business-relevant OpenStreetMap POIs (offices, banks, professional and
+-- trade services) from Geofabrik state extracts or Overpass. Ways are
+-- reduced to the centroid of their nodes. state_fips is the extract the row
+-- was last loaded from; rows not seen in a state's latest extract are
+-- removed by comparing synced_at.
+CREATE TABLE IF NOT EXISTS geo.osm_pois (
+    id           BIGSERIAL PRIMARY KEY,
+    osm_type     TEXT NOT NULL,
+    osm_id       BIGINT NOT NULL,
+    name         TEXT,
+    brand        TEXT,
+    category     TEXT NOT NULL,
+    subcategory  TEXT,
+    latitude     DOUBLE PRECISION NOT NULL,
+    longitude    DOUBLE PRECISION NOT NULL,
+    geom         geometry(Point, 4326) GENERATED ALWAYS AS
+                 (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)) STORED,
+    state_fips   TEXT,
+    tags         JSONB NOT NULL DEFAULT '{}'::jsonb,
+    source       TEXT NOT NULL DEFAULT 'osm',
+    synced_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (osm_type, osm_id)
+);
+CREATE INDEX IF NOT EXISTS idx_osm_pois_geom ON geo.osm_pois USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_osm_pois_category ON geo.osm_pois (category, subcategory);
+CREATE INDEX IF NOT EXISTS idx_osm_pois_state ON geo.osm_pois (state_fips, synced_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.osm_pois;
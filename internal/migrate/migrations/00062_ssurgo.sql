@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- SSURGO soil map unit polygons with key map unit attributes, loaded per
+-- soil survey area from NRCS Soil Data Access. Each survey area is
+-- replaced as a whole when its published version changes.
+CREATE TABLE IF NOT EXISTS geo.ssurgo (
+    id                   BIGSERIAL PRIMARY KEY,
+    areasymbol           TEXT NOT NULL,
+    mukey                TEXT NOT NULL,
+    polygon_key          TEXT NOT NULL UNIQUE,
+    musym                TEXT,
+    muname               TEXT,
+    mukind               TEXT,
+    farmland_class       TEXT,
+    drainage_class       TEXT,
+    hydrologic_group     TEXT,
+    hydric_percent       SMALLINT,
+    slope_percent        DOUBLE PRECISION,
+    capability_class     TEXT,
+    available_water_cm   DOUBLE PRECISION,
+    geom                 geometry(MultiPolygon, 4326),
+    source               TEXT NOT NULL DEFAULT 'nrcs',
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_ssurgo_geom ON geo.ssurgo USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_ssurgo_area ON geo.ssurgo (areasymbol);
+CREATE INDEX IF NOT EXISTS idx_ssurgo_mukey ON geo.ssurgo (mukey);
+CREATE INDEX IF NOT EXISTS idx_ssurgo_farmland ON geo.ssurgo (farmland_class);
+
+-- Loaded survey areas and the SSURGO version of each, so unchanged areas
+-- are skipped on later syncs.
+CREATE TABLE IF NOT EXISTS geo.ssurgo_areas (
+    areasymbol      TEXT PRIMARY KEY,
+    areaname        TEXT,
+    survey_version  TEXT NOT NULL,
+    map_units       INTEGER NOT NULL DEFAULT 0,
+    polygons        INTEGER NOT NULL DEFAULT 0,
+    loaded_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.ssurgo_areas;
+DROP TABLE IF EXISTS geo.ssurgo;
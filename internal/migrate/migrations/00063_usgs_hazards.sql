@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- USGS hazard polygons: seismic design category and landslide
+-- susceptibility. hazard_class is the normalized class (a design category
+-- letter, or low/moderate/high) and severity its rank within the hazard,
+-- higher being worse. Each hazard is replaced as a whole on sync.
+CREATE TABLE IF NOT EXISTS geo.usgs_hazards (
+    id            BIGSERIAL PRIMARY KEY,
+    hazard        TEXT NOT NULL,
+    hazard_class  TEXT NOT NULL,
+    severity      SMALLINT NOT NULL,
+    label         TEXT,
+    geom          geometry(MultiPolygon, 4326),
+    source        TEXT NOT NULL DEFAULT 'usgs',
+    source_id     TEXT NOT NULL,
+    properties    JSONB DEFAULT '{}'::jsonb,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (source, source_id)
+);
+CREATE INDEX IF NOT EXISTS idx_usgs_hazards_geom ON geo.usgs_hazards USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_usgs_hazards_hazard ON geo.usgs_hazards (hazard, severity);
+
+-- Hazard classes at each geocoded company address, refreshed after every
+-- hazard sync alongside the flood zone tags. Both stay NULL outside mapped
+-- polygons; hazards_checked_at records the last lookup.
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS seismic_design_category VARCHAR(4);
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS landslide_susceptibility VARCHAR(20);
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS hazards_checked_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS hazards_checked_at;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS landslide_susceptibility;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS seismic_design_category;
+DROP TABLE IF EXISTS geo.usgs_hazards;
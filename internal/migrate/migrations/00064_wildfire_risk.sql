@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- USFS Wildfire Risk to Communities, aggregated by county and census tract.
+-- Percentiles are national and scaled 0-100. Rows join to geo.counties and
+-- geo.census_tracts by geoid; the source rasters are not stored.
+CREATE TABLE IF NOT EXISTS geo.wildfire_risk (
+    id                          BIGSERIAL PRIMARY KEY,
+    geo_level                   TEXT NOT NULL,
+    geoid                       TEXT NOT NULL,
+    state_fips                  CHAR(2),
+    name                        TEXT,
+    risk_to_homes               DOUBLE PRECISION,
+    risk_to_homes_pctile        DOUBLE PRECISION,
+    wildfire_likelihood         DOUBLE PRECISION,
+    wildfire_likelihood_pctile  DOUBLE PRECISION,
+    vintage                     SMALLINT NOT NULL,
+    source                      TEXT NOT NULL DEFAULT 'usfs',
+    properties                  JSONB DEFAULT '{}'::jsonb,
+    updated_at                  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (geo_level, geoid)
+);
+CREATE INDEX IF NOT EXISTS idx_wildfire_risk_geoid ON geo.wildfire_risk (geoid);
+CREATE INDEX IF NOT EXISTS idx_wildfire_risk_state ON geo.wildfire_risk (state_fips);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.wildfire_risk;
@@ -0,0 +1,73 @@
+-- +goose Up
+
+-- NOAA Storm Events details, one row per event. county_fips is set only for
+-- county-coded events (cz_type 'C'); zone and marine events keep the NWS
+-- zone code in cz_fips. Damages are in dollars.
+CREATE TABLE IF NOT EXISTS geo.storm_events (
+    event_id         BIGINT PRIMARY KEY,
+    episode_id       BIGINT,
+    year             SMALLINT NOT NULL,
+    begin_date       DATE,
+    state            TEXT,
+    state_fips       CHAR(2),
+    cz_type          CHAR(1),
+    cz_fips          VARCHAR(3),
+    cz_name          TEXT,
+    county_fips      CHAR(5),
+    event_type       TEXT NOT NULL,
+    injuries         INTEGER NOT NULL DEFAULT 0,
+    deaths           INTEGER NOT NULL DEFAULT 0,
+    damage_property  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    damage_crops     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    magnitude        DOUBLE PRECISION,
+    magnitude_type   TEXT,
+    latitude         DOUBLE PRECISION,
+    longitude        DOUBLE PRECISION,
+    geom             GEOMETRY(Point, 4326) GENERATED ALWAYS AS
+                     (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)) STORED,
+    source           TEXT NOT NULL DEFAULT 'noaa',
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_storm_events_county ON geo.storm_events (county_fips, event_type);
+CREATE INDEX IF NOT EXISTS idx_storm_events_year ON geo.storm_events (year);
+CREATE INDEX IF NOT EXISTS idx_storm_events_geom ON geo.storm_events USING GIST (geom);
+
+-- Loaded Storm Events yearly files, so years whose file has not been
+-- republished are skipped on later syncs.
+CREATE TABLE IF NOT EXISTS geo.storm_event_files (
+    year        SMALLINT PRIMARY KEY,
+    file_name   TEXT NOT NULL,
+    events      INTEGER NOT NULL DEFAULT 0,
+    loaded_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- NOAA 1991-2020 annual climate normals by station, tagged with the county
+-- containing the station. Temperatures in degrees F, precipitation and
+-- snowfall in inches.
+CREATE TABLE IF NOT EXISTS geo.climate_normals (
+    station_id    TEXT PRIMARY KEY,
+    name          TEXT,
+    county_fips   CHAR(5),
+    elevation_m   DOUBLE PRECISION,
+    tavg_f        DOUBLE PRECISION,
+    tmax_f        DOUBLE PRECISION,
+    tmin_f        DOUBLE PRECISION,
+    prcp_in       DOUBLE PRECISION,
+    snow_in       DOUBLE PRECISION,
+    heating_dd    DOUBLE PRECISION,
+    cooling_dd    DOUBLE PRECISION,
+    period        TEXT NOT NULL,
+    latitude      DOUBLE PRECISION NOT NULL,
+    longitude     DOUBLE PRECISION NOT NULL,
+    geom          GEOMETRY(Point, 4326) GENERATED ALWAYS AS
+                  (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)) STORED,
+    source        TEXT NOT NULL DEFAULT 'noaa',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_climate_normals_county ON geo.climate_normals (county_fips);
+CREATE INDEX IF NOT EXISTS idx_climate_normals_geom ON geo.climate_normals USING GIST (geom);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.climate_normals;
+DROP TABLE IF EXISTS geo.storm_event_files;
+DROP TABLE IF EXISTS geo.storm_events;
@@ -0,0 +1,37 @@
+-- +goose Up
+
+-- NCES EDGE school district boundaries (unified, elementary, and secondary)
+-- with district totals rolled up from the NCES public school
+-- characteristics. geoid is the seven-digit NCES LEA id. The table is
+-- replaced as a whole on sync.
+CREATE TABLE IF NOT EXISTS geo.school_districts (
+    id                     BIGSERIAL PRIMARY KEY,
+    geoid                  VARCHAR(7) NOT NULL UNIQUE,
+    name                   TEXT NOT NULL,
+    state_fips             CHAR(2),
+    district_type          TEXT,
+    lowest_grade           VARCHAR(2),
+    highest_grade          VARCHAR(2),
+    school_year            TEXT,
+    schools                INTEGER NOT NULL DEFAULT 0,
+    enrollment             INTEGER,
+    teachers               DOUBLE PRECISION,
+    student_teacher_ratio  DOUBLE PRECISION,
+    frl_pct                DOUBLE PRECISION,
+    geom                   geometry(MultiPolygon, 4326),
+    source                 TEXT NOT NULL DEFAULT 'nces',
+    properties             JSONB DEFAULT '{}'::jsonb,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_school_districts_geom ON geo.school_districts USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_school_districts_state ON geo.school_districts (state_fips);
+
+-- School district at each geocoded company address, refreshed after every
+-- district sync. Stays NULL outside mapped districts.
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS school_district_geoid VARCHAR(7);
+ALTER TABLE public.company_addresses ADD COLUMN IF NOT EXISTS school_district_checked_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS school_district_checked_at;
+ALTER TABLE public.company_addresses DROP COLUMN IF EXISTS school_district_geoid;
+DROP TABLE IF EXISTS geo.school_districts;
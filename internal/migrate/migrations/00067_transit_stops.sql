@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Transit stops from configured GTFS static feeds with weekday service
+-- levels. Departures count trips running on a representative Wednesday;
+-- the peak window is 07:00-09:00. Stops of a feed are replaced on every
+-- sync and feeds dropped from config are pruned.
+CREATE TABLE IF NOT EXISTS geo.transit_stops (
+    id                BIGSERIAL PRIMARY KEY,
+    feed_id           TEXT NOT NULL,
+    stop_id           TEXT NOT NULL,
+    name              TEXT,
+    route_count       INTEGER NOT NULL DEFAULT 0,
+    route_types       SMALLINT[],
+    daily_departures  INTEGER NOT NULL DEFAULT 0,
+    peak_departures   INTEGER NOT NULL DEFAULT 0,
+    peak_headway_min  DOUBLE PRECISION,
+    latitude          DOUBLE PRECISION NOT NULL,
+    longitude         DOUBLE PRECISION NOT NULL,
+    geom              GEOMETRY(Point, 4326) GENERATED ALWAYS AS
+                      (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)) STORED,
+    source            TEXT NOT NULL DEFAULT 'gtfs',
+    synced_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (feed_id, stop_id)
+);
+CREATE INDEX IF NOT EXISTS idx_transit_stops_geom ON geo.transit_stops USING GIST (geom);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.transit_stops;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Census tracts eligible for federal place-based incentives from the CDFI
+-- Fund: designated Qualified Opportunity Zones (program 'qoz', 2010 tracts)
+-- and New Markets Tax Credit low-income community tracts (program 'nmtc').
+-- category holds the QOZ tract type or the NMTC distress level. Rows of a
+-- program are replaced on every sync.
+CREATE TABLE IF NOT EXISTS geo.incentive_tracts (
+    id             BIGSERIAL PRIMARY KEY,
+    program        TEXT NOT NULL,
+    geoid          CHAR(11) NOT NULL,
+    state_fips     CHAR(2) NOT NULL,
+    county_fips    CHAR(5) NOT NULL,
+    category       TEXT,
+    poverty_rate   DOUBLE PRECISION,
+    mfi_pct        DOUBLE PRECISION,
+    tract_vintage  SMALLINT NOT NULL,
+    source         TEXT NOT NULL DEFAULT 'cdfi',
+    properties     JSONB DEFAULT '{}'::jsonb,
+    synced_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (program, geoid)
+);
+CREATE INDEX IF NOT EXISTS idx_incentive_tracts_geoid ON geo.incentive_tracts (geoid);
+CREATE INDEX IF NOT EXISTS idx_incentive_tracts_county ON geo.incentive_tracts (county_fips);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.incentive_tracts;
@@ -0,0 +1,41 @@
+-- +goose Up
+
+-- HUD Fair Market Rents move to geo.hud_fmr, which adds ZIP-level Small
+-- Area FMRs (geo_level 'zip', fips holds the ZIP) and Section 8 income
+-- limits for a four-person household on county rows. County rows keep
+-- HUD's ten-digit FIPS code; county_fips is its five-digit prefix.
+ALTER TABLE geo.fair_market_rents RENAME TO hud_fmr;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS geo_level TEXT NOT NULL DEFAULT 'county';
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS county_fips CHAR(5);
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS hud_area_code TEXT;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS area_name TEXT;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS median_family_income INT;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS il_extremely_low_4p INT;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS il_very_low_4p INT;
+ALTER TABLE geo.hud_fmr ADD COLUMN IF NOT EXISTS il_low_4p INT;
+UPDATE geo.hud_fmr SET county_fips = LEFT(fips, 5) WHERE length(fips) >= 5;
+
+ALTER TABLE geo.hud_fmr DROP CONSTRAINT IF EXISTS fair_market_rents_fips_year_key;
+ALTER TABLE geo.hud_fmr ADD CONSTRAINT hud_fmr_geo_level_fips_year_key UNIQUE (geo_level, fips, year);
+ALTER INDEX IF EXISTS geo.idx_fmr_fips RENAME TO idx_hud_fmr_fips;
+ALTER INDEX IF EXISTS geo.idx_fmr_state RENAME TO idx_hud_fmr_state;
+ALTER INDEX IF EXISTS geo.idx_fmr_year RENAME TO idx_hud_fmr_year;
+CREATE INDEX IF NOT EXISTS idx_hud_fmr_county ON geo.hud_fmr (county_fips, year);
+
+-- +goose Down
+DELETE FROM geo.hud_fmr WHERE geo_level <> 'county';
+DROP INDEX IF EXISTS geo.idx_hud_fmr_county;
+ALTER INDEX IF EXISTS geo.idx_hud_fmr_fips RENAME TO idx_fmr_fips;
+ALTER INDEX IF EXISTS geo.idx_hud_fmr_state RENAME TO idx_fmr_state;
+ALTER INDEX IF EXISTS geo.idx_hud_fmr_year RENAME TO idx_fmr_year;
+ALTER TABLE geo.hud_fmr DROP CONSTRAINT IF EXISTS hud_fmr_geo_level_fips_year_key;
+ALTER TABLE geo.hud_fmr ADD CONSTRAINT fair_market_rents_fips_year_key UNIQUE (fips, year);
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS il_low_4p;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS il_very_low_4p;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS il_extremely_low_4p;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS median_family_income;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS area_name;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS hud_area_code;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS county_fips;
+ALTER TABLE geo.hud_fmr DROP COLUMN IF EXISTS geo_level;
+ALTER TABLE geo.hud_fmr RENAME TO fair_market_rents;
@@ -0,0 +1,39 @@
+-- +goose Up
+
+-- County assessor parcels loaded by the per-county parcels_* scrapers
+-- (parcel_counties manifest). parcel_id is the county's own APN/PIN;
+-- owner fields hold the owner of record and mailing address. land_use is
+-- the normalized class mapped from the county's land_use_code. Values are
+-- the latest assessed dollars. A county's rows are replaced on every sync.
+CREATE TABLE IF NOT EXISTS geo.parcels (
+    id                 BIGSERIAL PRIMARY KEY,
+    county_fips        CHAR(5) NOT NULL,
+    state_fips         CHAR(2) NOT NULL,
+    parcel_id          TEXT NOT NULL,
+    owner_name         TEXT,
+    owner_address      TEXT,
+    owner_city         TEXT,
+    owner_state        TEXT,
+    owner_zip          TEXT,
+    site_address       TEXT,
+    site_city          TEXT,
+    site_zip           TEXT,
+    land_use_code      TEXT,
+    land_use           TEXT,
+    assessed_value     NUMERIC(14,0),
+    land_value         NUMERIC(14,0),
+    improvement_value  NUMERIC(14,0),
+    acres              DOUBLE PRECISION,
+    geom               GEOMETRY(MultiPolygon, 4326),
+    adapter            TEXT NOT NULL,
+    source             TEXT NOT NULL DEFAULT 'county_assessor',
+    properties         JSONB DEFAULT '{}'::jsonb,
+    synced_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (county_fips, parcel_id)
+);
+CREATE INDEX IF NOT EXISTS idx_parcels_geom ON geo.parcels USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_parcels_owner ON geo.parcels (owner_name);
+CREATE INDEX IF NOT EXISTS idx_parcels_owner_zip ON geo.parcels (owner_zip);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.parcels;
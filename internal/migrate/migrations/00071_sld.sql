@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- EPA Smart Location Database moves to geo.sld and gains street
+-- intersection density (D3B), which was previously loaded into
+-- transit_freq. transit_freq now holds D4C, peak-hour transit service
+-- frequency, and is cleared until the next sync.
+ALTER TABLE geo.smart_location RENAME TO sld;
+ALTER TABLE geo.sld ADD COLUMN IF NOT EXISTS intersection_density DOUBLE PRECISION;
+UPDATE geo.sld SET intersection_density = transit_freq, transit_freq = NULL;
+ALTER INDEX IF EXISTS geo.idx_smart_location_state RENAME TO idx_sld_state;
+ALTER INDEX IF EXISTS geo.idx_smart_location_county RENAME TO idx_sld_county;
+
+-- +goose Down
+UPDATE geo.sld SET transit_freq = intersection_density;
+ALTER INDEX IF EXISTS geo.idx_sld_state RENAME TO idx_smart_location_state;
+ALTER INDEX IF EXISTS geo.idx_sld_county RENAME TO idx_smart_location_county;
+ALTER TABLE geo.sld DROP COLUMN IF EXISTS intersection_density;
+ALTER TABLE geo.sld RENAME TO smart_location;
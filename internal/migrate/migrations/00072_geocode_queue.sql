@@ -0,0 +1,53 @@
+-- +goose Up
+
+-- Geocoding work queue. PostSync hooks and the fed_data bridge enqueue
+-- addresses; `research-cli geocode run` drains them through the Census
+-- Bureau batch geocoder. Rows move pending -> processing -> complete,
+-- no_match, or failed; transient errors return a row to pending with a
+-- backoff in next_attempt_at until max attempts are used up.
+CREATE TABLE IF NOT EXISTS geo.geocode_queue (
+    id              BIGSERIAL PRIMARY KEY,
+    source_table    TEXT NOT NULL,
+    source_id       TEXT NOT NULL,
+    address         TEXT NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    error           TEXT,
+    result          JSONB,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (source_table, source_id)
+);
+ALTER TABLE geo.geocode_queue ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE geo.geocode_queue DROP CONSTRAINT IF EXISTS geocode_queue_status_check;
+ALTER TABLE geo.geocode_queue ADD CONSTRAINT geocode_queue_status_check
+    CHECK (status IN ('pending', 'processing', 'complete', 'no_match', 'failed'));
+CREATE INDEX IF NOT EXISTS idx_geocode_queue_claim ON geo.geocode_queue (status, next_attempt_at, created_at);
+
+-- Geocoded locations keyed by queue source row.
+CREATE TABLE IF NOT EXISTS geo.locations (
+    id              BIGSERIAL PRIMARY KEY,
+    source_table    TEXT NOT NULL,
+    source_id       TEXT NOT NULL,
+    address         TEXT NOT NULL,
+    matched_address TEXT,
+    match_type      TEXT,
+    latitude        DOUBLE PRECISION NOT NULL,
+    longitude       DOUBLE PRECISION NOT NULL,
+    geom            geometry(Point, 4326),
+    state_fips      CHAR(2),
+    county_fips     CHAR(5),
+    tract_geoid     CHAR(11),
+    source          TEXT NOT NULL DEFAULT 'census',
+    geocoded_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (source_table, source_id)
+);
+CREATE INDEX IF NOT EXISTS idx_locations_geom ON geo.locations USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_locations_county ON geo.locations (county_fips);
+CREATE INDEX IF NOT EXISTS idx_locations_tract ON geo.locations (tract_geoid);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.locations;
+DROP INDEX IF EXISTS geo.idx_geocode_queue_claim;
+ALTER TABLE geo.geocode_queue DROP CONSTRAINT IF EXISTS geocode_queue_status_check;
+ALTER TABLE geo.geocode_queue DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Record which provider produced each geocode and how confident it was, so
+-- addresses that needed a paid provider (mapbox, google) can be audited.
+ALTER TABLE public.geocode_cache ADD COLUMN IF NOT EXISTS source TEXT;
+ALTER TABLE public.geocode_cache ADD COLUMN IF NOT EXISTS confidence DOUBLE PRECISION;
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS confidence DOUBLE PRECISION;
+CREATE INDEX IF NOT EXISTS idx_locations_source ON geo.locations (source);
+
+-- +goose Down
+DROP INDEX IF EXISTS geo.idx_locations_source;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS confidence;
+ALTER TABLE public.geocode_cache DROP COLUMN IF EXISTS confidence;
+ALTER TABLE public.geocode_cache DROP COLUMN IF EXISTS source;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Reverse geocoding backfills standardized addresses for coordinate-only
+-- rows (EPA facilities, HIFLD layers) into geo.locations with
+-- match_type = 'reverse' and the containing Census place.
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS place_name TEXT;
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS place_geoid CHAR(7);
+
+-- +goose Down
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS place_geoid;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS place_name;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Normalized addresses (pkg/address: USPS abbreviations, uppercase, unit
+-- and ZIP+4 split) stored alongside the raw address. address_norm is the
+-- one-line form; address_components holds the parsed parts as JSON.
+ALTER TABLE geo.geocode_queue ADD COLUMN IF NOT EXISTS address_norm TEXT;
+ALTER TABLE geo.geocode_queue ADD COLUMN IF NOT EXISTS address_components JSONB;
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS address_norm TEXT;
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS address_components JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_locations_address_norm ON geo.locations (address_norm);
+
+-- +goose Down
+DROP INDEX IF EXISTS geo.idx_locations_address_norm;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS address_components;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS address_norm;
+ALTER TABLE geo.geocode_queue DROP COLUMN IF EXISTS address_components;
+ALTER TABLE geo.geocode_queue DROP COLUMN IF EXISTS address_norm;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Move the geocode cache into the geo schema and key it by the hash of the
+-- normalized one-line address (address_norm), so the same address queued
+-- from several sources (ADV, OSHA, EPA, SBA) is geocoded once. The batch
+-- worker also stores the Census matched address and tract.
+ALTER TABLE IF EXISTS public.geocode_cache SET SCHEMA geo;
+ALTER TABLE geo.geocode_cache ADD COLUMN IF NOT EXISTS address_norm TEXT;
+ALTER TABLE geo.geocode_cache ADD COLUMN IF NOT EXISTS matched_address TEXT;
+ALTER TABLE geo.geocode_cache ADD COLUMN IF NOT EXISTS tract_geoid VARCHAR(11);
+COMMENT ON TABLE geo.geocode_cache IS 'Caches geocode results keyed by SHA-256 of the normalized address';
+
+-- +goose Down
+COMMENT ON TABLE geo.geocode_cache IS 'Caches PostGIS geocode() results keyed by SHA-256 of normalized address';
+ALTER TABLE geo.geocode_cache DROP COLUMN IF EXISTS tract_geoid;
+ALTER TABLE geo.geocode_cache DROP COLUMN IF EXISTS matched_address;
+ALTER TABLE geo.geocode_cache DROP COLUMN IF EXISTS address_norm;
+ALTER TABLE IF EXISTS geo.geocode_cache SET SCHEMA public;
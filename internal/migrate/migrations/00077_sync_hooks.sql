@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Executions of the named post-sync hooks (enqueue_geocode, assign_msa,
+-- flood_tag, notify) the fedsync and geo scraper engines run after each
+-- sync. Hooks per dataset come from the postsync.hooks config.
+CREATE TABLE IF NOT EXISTS fed_data.sync_hooks (
+    id            BIGSERIAL PRIMARY KEY,
+    sync_id       BIGINT NOT NULL REFERENCES fed_data.sync_log (id) ON DELETE CASCADE,
+    dataset       TEXT NOT NULL,
+    hook          TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    rows_affected BIGINT,
+    duration_ms   BIGINT,
+    error         TEXT,
+    ran_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_hooks_dataset
+    ON fed_data.sync_hooks (dataset, ran_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_sync_hooks_sync
+    ON fed_data.sync_hooks (sync_id);
+
+-- The assign_msa hook stores the metro/micro CBSA containing each geocoded
+-- location.
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS cbsa_code CHAR(5);
+CREATE INDEX IF NOT EXISTS idx_locations_cbsa ON geo.locations (cbsa_code);
+
+-- +goose Down
+DROP INDEX IF EXISTS geo.idx_locations_cbsa;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS cbsa_code;
+DROP TABLE IF EXISTS fed_data.sync_hooks;
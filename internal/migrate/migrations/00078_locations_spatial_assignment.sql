@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Point-in-polygon assignment of county, tract, place, and CBSA from the
+-- synced TIGER and CBSA boundaries. Locations geocoded or moved since their
+-- last assignment are picked up by the next incremental run.
+ALTER TABLE geo.locations ADD COLUMN IF NOT EXISTS spatial_assigned_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_locations_spatial_pending
+    ON geo.locations (id)
+    WHERE spatial_assigned_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS geo.idx_locations_spatial_pending;
+ALTER TABLE geo.locations DROP COLUMN IF EXISTS spatial_assigned_at;
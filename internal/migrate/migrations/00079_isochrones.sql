@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Drive-time isochrones around target office locations, computed by the
+-- isochrones geo scraper through Valhalla or OSRM. Population is the ACS
+-- tract total and establishments/employees the CBP county all-industry
+-- totals, each weighted by the share of the tract or county area inside
+-- the isochrone.
+CREATE TABLE IF NOT EXISTS geo.isochrones (
+    id             BIGSERIAL PRIMARY KEY,
+    source_table   TEXT NOT NULL,
+    source_id      TEXT NOT NULL,
+    minutes        SMALLINT NOT NULL,
+    provider       TEXT NOT NULL,
+    origin         geometry(Point, 4326) NOT NULL,
+    geom           geometry(MultiPolygon, 4326) NOT NULL,
+    population     BIGINT,
+    establishments BIGINT,
+    employees      BIGINT,
+    acs_year       SMALLINT,
+    cbp_year       SMALLINT,
+    computed_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    stats_at       TIMESTAMPTZ,
+    UNIQUE (source_table, source_id, minutes)
+);
+CREATE INDEX IF NOT EXISTS idx_isochrones_geom ON geo.isochrones USING GIST (geom);
+CREATE INDEX IF NOT EXISTS idx_isochrones_stats_pending
+    ON geo.isochrones (id)
+    WHERE stats_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.isochrones;
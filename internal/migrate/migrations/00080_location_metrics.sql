@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- Nearest-amenity distances for geocoded company addresses: the nearest
+-- airport and hospital (geo.infrastructure), competitor POI
+-- (geo.osm_pois), and interstate (geo.roads), computed by geo metrics and
+-- read by the pipeline for Salesforce fields. origin is the point the
+-- distances were measured from, so a re-geocoded address is recomputed.
+CREATE TABLE IF NOT EXISTS geo.location_metrics (
+    source_table    TEXT NOT NULL,
+    source_id       TEXT NOT NULL,
+    origin          geometry(Point, 4326) NOT NULL,
+    airport_km      DOUBLE PRECISION,
+    airport_name    TEXT,
+    hospital_km     DOUBLE PRECISION,
+    hospital_name   TEXT,
+    competitor_km   DOUBLE PRECISION,
+    competitor_name TEXT,
+    interstate_km   DOUBLE PRECISION,
+    interstate_name TEXT,
+    computed_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (source_table, source_id)
+);
+CREATE INDEX IF NOT EXISTS idx_location_metrics_origin ON geo.location_metrics USING GIST (origin);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.location_metrics;
@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- USDA ERS Rural-Urban Commuting Area codes by census tract, loaded by the
+-- usda_ruca geo scraper. primary_ruca is 1-10 (1-3 metropolitan, 4-6
+-- micropolitan, 7-9 small town, 10 rural; 99 = no population);
+-- secondary_ruca refines it by commuting flow (e.g. 4.1). The pipeline
+-- uses it to refine the urban classification. Rows are replaced on every
+-- sync.
+CREATE TABLE IF NOT EXISTS geo.ruca (
+    geoid           CHAR(11) PRIMARY KEY,
+    state_fips      CHAR(2) NOT NULL,
+    county_fips     CHAR(5) NOT NULL,
+    primary_ruca    SMALLINT NOT NULL,
+    secondary_ruca  NUMERIC(4, 1),
+    population      INTEGER,
+    land_area_sqmi  DOUBLE PRECISION,
+    pop_density     DOUBLE PRECISION,
+    tract_vintage   SMALLINT NOT NULL,
+    source          TEXT NOT NULL DEFAULT 'usda_ers',
+    synced_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_ruca_county ON geo.ruca (county_fips);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.ruca;
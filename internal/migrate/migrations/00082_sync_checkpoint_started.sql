@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- When the run that owns a checkpoint started. A resumed run keeps the
+-- interrupted run's start as its watermark, so changes made while the
+-- earlier files were being loaded are picked up by the next incremental run.
+ALTER TABLE fed_data.sync_checkpoints
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- +goose Down
+ALTER TABLE fed_data.sync_checkpoints DROP COLUMN IF EXISTS started_at;
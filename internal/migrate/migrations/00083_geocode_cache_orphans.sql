@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- 00076 re-keyed geo.geocode_cache by the hash of the normalized one-line
+-- address (address_norm). Entries written under the old street|city|state|zip
+-- hash can never be looked up again; every entry written since carries
+-- address_norm, so drop the ones without it. Dropped addresses are simply
+-- geocoded again on their next lookup.
+DELETE FROM geo.geocode_cache WHERE address_norm IS NULL;
+
+-- +goose Down
+-- Deleted cache entries are not restored; they are rebuilt on lookup.
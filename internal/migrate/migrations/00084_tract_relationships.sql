@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Census 2020-to-2010 census tract relationship file, loaded by the
+-- tract_relationships geo scraper. Each row is one overlap of a 2020 tract
+-- with a 2010 tract; arealand_part is the overlap's land area in square
+-- meters. Lookups against 2010-vintage tract lists (QOZ designations, RUCA
+-- codes) go from the 2020 tract containing a point to the 2010 tract it
+-- shares the most land with. Rows are replaced on every sync.
+CREATE TABLE IF NOT EXISTS geo.tract_relationships (
+    tract_2020     CHAR(11) NOT NULL,
+    tract_2010     CHAR(11) NOT NULL,
+    arealand_part  BIGINT NOT NULL DEFAULT 0,
+    arealand_2020  BIGINT,
+    synced_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tract_2020, tract_2010)
+);
+CREATE INDEX IF NOT EXISTS idx_tract_relationships_2010 ON geo.tract_relationships (tract_2010);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.tract_relationships;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- The built-in HIFLD layer manifest no longer loads hospitals, schools, or
+-- fire stations: the hifld_hospitals, hifld_schools, and hifld_fire_ems
+-- scrapers already load them into geo.infrastructure. Drop the per-layer
+-- tables earlier syncs created.
+DROP TABLE IF EXISTS geo.hifld_hospitals;
+DROP TABLE IF EXISTS geo.hifld_schools;
+DROP TABLE IF EXISTS geo.hifld_fire_stations;
+
+-- +goose Down
+-- The tables are created on the first sync of a manifest layer.
+SELECT 1;
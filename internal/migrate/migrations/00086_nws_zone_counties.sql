@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- NWS public forecast zone to county correlation, loaded by the noaa_storms
+-- geo scraper from the NWS zone-county file. Each row is one county a zone
+-- covers; zones spanning several counties have several rows. Storm Events
+-- coded to a zone (cz_type 'Z') take their county_fips from here. Rows are
+-- replaced on every sync.
+CREATE TABLE IF NOT EXISTS geo.nws_zone_counties (
+    state_fips   CHAR(2) NOT NULL,
+    zone         CHAR(3) NOT NULL,
+    county_fips  CHAR(5) NOT NULL,
+    state_zone   TEXT,
+    zone_name    TEXT,
+    synced_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (state_fips, zone, county_fips)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS geo.nws_zone_counties;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Normalized street and ZIP keys for the fed_data rows the entity
+-- cross-reference passes compare by address. The resolve package fills
+-- them before each xref build with pkg/address, so the passes join on
+-- street_norm and zip5 instead of the raw columns. street_raw and zip_raw
+-- hold the values the keys were computed from; a row is recomputed when
+-- its source values change. source_id is the source table's key as text,
+-- joined with ':' for composite keys.
+CREATE TABLE IF NOT EXISTS fed_data.address_keys (
+    source_table TEXT NOT NULL,
+    source_id    TEXT NOT NULL,
+    street_raw   TEXT,
+    zip_raw      TEXT,
+    street_norm  TEXT,
+    zip5         CHAR(5),
+    PRIMARY KEY (source_table, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_address_keys_zip5
+    ON fed_data.address_keys (source_table, zip5) WHERE zip5 IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS fed_data.address_keys;
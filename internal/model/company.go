@@ -88,6 +88,7 @@ type RunResult struct {
 	Report         string             `json:"report"`
 	SalesforceSync bool               `json:"salesforce_sync"`
 	Error          string             `json:"error,omitempty"`
+	Manifest       *RunManifest       `json:"manifest,omitempty"`
 }
 
 // RunPhase represents a phase within a run.
@@ -123,14 +124,107 @@ type PhaseResult struct {
 
 // GeoData holds geographic enrichment data from Phase 7D for Salesforce write.
 type GeoData struct {
-	Latitude       float64 `json:"latitude,omitempty"`
-	Longitude      float64 `json:"longitude,omitempty"`
-	MSAName        string  `json:"msa_name,omitempty"`
-	CBSACode       string  `json:"cbsa_code,omitempty"`
-	Classification string  `json:"classification,omitempty"` // urban_core, suburban, exurban, rural
-	CentroidKM     float64 `json:"centroid_km,omitempty"`
-	EdgeKM         float64 `json:"edge_km,omitempty"`
-	CountyFIPS     string  `json:"county_fips,omitempty"`
+	Latitude            float64 `json:"latitude,omitempty"`
+	Longitude           float64 `json:"longitude,omitempty"`
+	MSAName             string  `json:"msa_name,omitempty"`
+	CBSACode            string  `json:"cbsa_code,omitempty"`
+	Classification      string  `json:"classification,omitempty"` // urban_core, suburban, exurban, rural
+	CentroidKM          float64 `json:"centroid_km,omitempty"`
+	EdgeKM              float64 `json:"edge_km,omitempty"`
+	CountyFIPS          string  `json:"county_fips,omitempty"`
+	WildfireScore       float64 `json:"wildfire_score,omitempty"`       // national risk to homes percentile, 0-100
+	TransitScore        float64 `json:"transit_score,omitempty"`        // peak-hour transit departures within 800 m, capped at 100
+	OpportunityZone     bool    `json:"opportunity_zone,omitempty"`     // tract is a designated Qualified Opportunity Zone
+	NMTCEligible        bool    `json:"nmtc_eligible,omitempty"`        // tract is an NMTC low-income community
+	WalkabilityIndex    float64 `json:"walkability_index,omitempty"`    // EPA SLD national walkability index, 1-20
+	IntersectionDensity float64 `json:"intersection_density,omitempty"` // street intersections per square mile (EPA SLD D3B)
+	EmploymentDensity   float64 `json:"employment_density,omitempty"`   // jobs per acre (EPA SLD D1C)
+	AirportKM           float64 `json:"airport_km,omitempty"`           // km to the nearest airport (geo.location_metrics)
+	NearestAirport      string  `json:"nearest_airport,omitempty"`      // name of that airport
+	HospitalKM          float64 `json:"hospital_km,omitempty"`          // km to the nearest hospital
+	CompetitorKM        float64 `json:"competitor_km,omitempty"`        // km to the nearest competitor POI (geo.osm_pois)
+	NearestCompetitor   string  `json:"nearest_competitor,omitempty"`   // name of that competitor
+	InterstateKM        float64 `json:"interstate_km,omitempty"`        // km to the nearest interstate
+	RUCACode            int     `json:"ruca_code,omitempty"`            // USDA primary RUCA code of the tract, 1-10 (refines Classification)
+}
+
+// EnvRisk holds the EPA/OSHA environmental and safety risk check from Phase 7E.
+type EnvRisk struct {
+	NearbyEPAFacilities []EnvRiskMatch `json:"nearby_epa_facilities,omitempty"`
+	EPANameMatches      []EnvRiskMatch `json:"epa_name_matches,omitempty"`
+	OSHAInspections     []EnvRiskMatch `json:"osha_inspections,omitempty"`
+	RadiusKM            float64        `json:"radius_km,omitempty"`
+	Note                string         `json:"note,omitempty"`
+}
+
+// EnvRiskMatch is a single EPA facility or OSHA inspection tied to a company.
+type EnvRiskMatch struct {
+	ID         string     `json:"id"` // EPA registry_id or OSHA activity_nr
+	Name       string     `json:"name"`
+	City       string     `json:"city,omitempty"`
+	State      string     `json:"state,omitempty"`
+	DistanceKM float64    `json:"distance_km,omitempty"` // proximity matches only
+	Similarity float64    `json:"similarity,omitempty"`  // name matches only
+	Date       *time.Time `json:"date,omitempty"`        // OSHA inspection open date
+	Penalty    float64    `json:"penalty,omitempty"`     // OSHA total penalty or EPA enforcement penalties
+
+	// EPA name matches only, from fed_data.epa_enforcement.
+	FormalActions    int    `json:"formal_actions,omitempty"`
+	ComplianceStatus string `json:"compliance_status,omitempty"`
+	SNC              bool   `json:"snc,omitempty"` // significant noncompliance
+}
+
+// Flagged reports whether any EPA or OSHA record matched.
+func (r *EnvRisk) Flagged() bool {
+	return r != nil && (len(r.NearbyEPAFacilities)+len(r.EPANameMatches)+len(r.OSHAInspections)) > 0
+}
+
+// Litigation holds the federal court docket check from Phase 7F.
+type Litigation struct {
+	Dockets []LitigationDocket `json:"dockets,omitempty"`
+	Open    int                `json:"open"` // dockets without a termination date
+	Note    string             `json:"note,omitempty"`
+}
+
+// LitigationDocket is a single federal docket naming the company as defendant.
+type LitigationDocket struct {
+	DocketID       int64      `json:"docket_id"`
+	Court          string     `json:"court"`
+	DocketNumber   string     `json:"docket_number,omitempty"`
+	CaseName       string     `json:"case_name"`
+	NatureOfSuit   string     `json:"nature_of_suit,omitempty"`
+	DateFiled      *time.Time `json:"date_filed,omitempty"`
+	DateTerminated *time.Time `json:"date_terminated,omitempty"`
+	URL            string     `json:"url,omitempty"`
+}
+
+// Flagged reports whether any docket matched.
+func (l *Litigation) Flagged() bool {
+	return l != nil && len(l.Dockets) > 0
+}
+
+// Exclusions holds the SAM.gov exclusions check from Phase 7G.
+type Exclusions struct {
+	Records []ExclusionRecord `json:"records,omitempty"`
+	Note    string            `json:"note,omitempty"`
+}
+
+// ExclusionRecord is a single active SAM.gov exclusion matching the company.
+type ExclusionRecord struct {
+	SAMNumber       string     `json:"sam_number"`
+	Name            string     `json:"name"`
+	UEI             string     `json:"uei,omitempty"`
+	Classification  string     `json:"classification,omitempty"`
+	Program         string     `json:"program,omitempty"`
+	Agency          string     `json:"agency,omitempty"`
+	Type            string     `json:"type,omitempty"`
+	ActiveDate      *time.Time `json:"active_date,omitempty"`
+	TerminationDate *time.Time `json:"termination_date,omitempty"` // nil = indefinite
+}
+
+// Flagged reports whether any active exclusion matched.
+func (e *Exclusions) Flagged() bool {
+	return e != nil && len(e.Records) > 0
 }
 
 // EnrichmentResult is the final output of the pipeline.
@@ -142,6 +236,9 @@ type EnrichmentResult struct {
 	FieldValues    map[string]FieldValue `json:"field_values"`
 	PPPMatches     []ppp.LoanMatch       `json:"ppp_matches,omitempty"`
 	GeoData        *GeoData              `json:"geo_data,omitempty"`
+	EnvRisk        *EnvRisk              `json:"env_risk,omitempty"`
+	Litigation     *Litigation           `json:"litigation,omitempty"`
+	Exclusions     *Exclusions           `json:"exclusions,omitempty"`
 	FederalContext any                   `json:"federal_context,omitempty"` // *pipeline.FederalContext (typed as any to avoid import cycle)
 	Report         string                `json:"report"`
 	Phases         []PhaseResult         `json:"phases"`
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// RunManifest records everything needed to reproduce a pipeline or fedsync
+// run: the binary that ran, the effective config, the question pack, the
+// models called, and the federal dataset vintages read.
+type RunManifest struct {
+	BinaryVersion   string                    `json:"binary_version"`
+	VCSRevision     string                    `json:"vcs_revision,omitempty"`
+	VCSModified     bool                      `json:"vcs_modified,omitempty"`
+	GoVersion       string                    `json:"go_version,omitempty"`
+	ConfigHash      string                    `json:"config_hash"`
+	QuestionPack    string                    `json:"question_pack,omitempty"`
+	QuestionCount   int                       `json:"question_count,omitempty"`
+	Models          map[string]string         `json:"models,omitempty"`
+	DatasetVintages map[string]DatasetVintage `json:"dataset_vintages,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+// DatasetVintage identifies the fedsync load a run read from.
+type DatasetVintage struct {
+	SyncID   int64          `json:"sync_id"`
+	SyncedAt time.Time      `json:"synced_at"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// ShadowFieldComparison compares one field between the production answers
+// and a shadow variant's answers for the same run.
+type ShadowFieldComparison struct {
+	FieldKey         string  `json:"field_key"`
+	ProdValue        any     `json:"prod_value,omitempty"`
+	ShadowValue      any     `json:"shadow_value,omitempty"`
+	ProdConfidence   float64 `json:"prod_confidence"`
+	ShadowConfidence float64 `json:"shadow_confidence"`
+	Status           string  `json:"status"` // "agree", "disagree", "prod_only", "shadow_only"
+}
+
+// Shadow comparison statuses.
+const (
+	ShadowAgree      = "agree"
+	ShadowDisagree   = "disagree"
+	ShadowProdOnly   = "prod_only"
+	ShadowShadowOnly = "shadow_only"
+)
+
+// ShadowComparison summarizes agreement between production and shadow answers.
+type ShadowComparison struct {
+	Fields        []ShadowFieldComparison `json:"fields"`
+	Agreed        int                     `json:"agreed"`
+	Disagreed     int                     `json:"disagreed"`
+	ProdOnly      int                     `json:"prod_only"`
+	ShadowOnly    int                     `json:"shadow_only"`
+	AgreementRate float64                 `json:"agreement_rate"` // agreed / (agreed + disagreed)
+}
+
+// ShadowResult stores a shadow variant's answers for one run. Shadow answers
+// never feed the quality gate or CRM writes.
+type ShadowResult struct {
+	ID         int64              `json:"id,omitempty"`
+	RunID      string             `json:"run_id"`
+	CompanyURL string             `json:"company_url"`
+	Variant    string             `json:"variant"`
+	Answers    []ExtractionAnswer `json:"answers"`
+	Comparison ShadowComparison   `json:"comparison"`
+	TokenUsage TokenUsage         `json:"token_usage"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
@@ -23,6 +23,7 @@ const (
 	AlertPipelineFailureRate AlertType = "pipeline_failure_rate"
 	AlertFedsyncFailure      AlertType = "fedsync_failure"
 	AlertCostOverrun         AlertType = "cost_overrun"
+	AlertContractDrift       AlertType = "contract_drift"
 )
 
 // Alert represents a single alert to be sent.
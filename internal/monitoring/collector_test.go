@@ -145,6 +145,12 @@ func (m *mockStore) GetProvenance(context.Context, string) ([]model.FieldProvena
 func (m *mockStore) GetLatestProvenance(context.Context, string) ([]model.FieldProvenance, error) {
 	return nil, nil
 }
+func (m *mockStore) SaveShadowResult(context.Context, *model.ShadowResult) error {
+	return nil
+}
+func (m *mockStore) ListShadowResults(context.Context, string, time.Time) ([]model.ShadowResult, error) {
+	return nil, nil
+}
 func (m *mockStore) ListStaleCompanies(context.Context, store.StaleCompanyFilter) ([]store.StaleCompany, error) {
 	return nil, nil
 }
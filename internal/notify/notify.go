@@ -0,0 +1,166 @@
+// Package notify posts run summaries (fedsync syncs, pipeline batches) to
+// Slack incoming webhooks and generic HTTP endpoints.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+// Status values for a summary Item.
+const (
+	StatusComplete  = "complete"
+	StatusFailed    = "failed"
+	StatusUnchanged = "unchanged"
+)
+
+// Item is the outcome of one unit of a run: a dataset sync or a company
+// enrichment.
+type Item struct {
+	Name      string        `json:"name"`
+	Status    string        `json:"status"`
+	Rows      int64         `json:"rows,omitempty"`
+	Duration  time.Duration `json:"duration_ns,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Anomalies []string      `json:"anomalies,omitempty"`
+}
+
+// Summary describes a finished run.
+type Summary struct {
+	Source    string        `json:"source"` // "fedsync", "batch", ...
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration_ns"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Rows      int64         `json:"rows"`
+	Items     []Item        `json:"items,omitempty"`
+	Anomalies []string      `json:"anomalies,omitempty"`
+}
+
+// NewSummary builds a summary from items, counting successes, failures,
+// and rows. Unchanged items count as successes.
+func NewSummary(source string, startedAt time.Time, items []Item) Summary {
+	s := Summary{
+		Source:    source,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+		Items:     items,
+	}
+	for _, it := range items {
+		if it.Status == StatusFailed {
+			s.Failed++
+		} else {
+			s.Succeeded++
+		}
+		s.Rows += it.Rows
+	}
+	return s
+}
+
+// Title returns a one-line headline for the summary.
+func (s Summary) Title() string {
+	status := "succeeded"
+	if s.Failed > 0 {
+		status = "had failures"
+	}
+	return fmt.Sprintf("%s run %s: %d succeeded, %d failed, %d rows in %s",
+		s.Source, status, s.Succeeded, s.Failed, s.Rows, s.Duration.Round(time.Second))
+}
+
+// Text renders the summary as plain text: the title, failures with their
+// errors, and anomalies.
+func (s Summary) Text() string {
+	var b strings.Builder
+	b.WriteString(s.Title())
+	for _, it := range s.Items {
+		if it.Status == StatusFailed {
+			fmt.Fprintf(&b, "\n- %s failed: %s", it.Name, truncate(it.Error, 200))
+		}
+	}
+	for _, it := range s.Items {
+		for _, a := range it.Anomalies {
+			fmt.Fprintf(&b, "\n- %s: %s", it.Name, a)
+		}
+	}
+	for _, a := range s.Anomalies {
+		fmt.Fprintf(&b, "\n- %s", a)
+	}
+	return b.String()
+}
+
+// Notifier delivers a run summary.
+type Notifier interface {
+	Notify(ctx context.Context, s Summary) error
+}
+
+// Dispatcher sends summaries to every configured notifier. A nil
+// Dispatcher is valid and sends nothing.
+type Dispatcher struct {
+	notifiers    []Notifier
+	onlyFailures bool
+}
+
+// New builds a Dispatcher from cfg, or returns nil when no endpoint is
+// configured.
+func New(cfg config.NotifyConfig) *Dispatcher {
+	client := &http.Client{Timeout: 10 * time.Second}
+	var ns []Notifier
+	if cfg.SlackWebhookURL != "" {
+		ns = append(ns, &SlackNotifier{URL: cfg.SlackWebhookURL, client: client})
+	}
+	for _, u := range cfg.WebhookURLs {
+		if u != "" {
+			ns = append(ns, &WebhookNotifier{URL: u, client: client})
+		}
+	}
+	if len(ns) == 0 {
+		return nil
+	}
+	return &Dispatcher{notifiers: ns, onlyFailures: cfg.OnlyFailures}
+}
+
+// NewDispatcher creates a Dispatcher over the given notifiers.
+func NewDispatcher(onlyFailures bool, notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{notifiers: notifiers, onlyFailures: onlyFailures}
+}
+
+// Notify sends s to every notifier, skipping clean runs when configured
+// for failures only. Every notifier is tried; their errors are joined.
+func (d *Dispatcher) Notify(ctx context.Context, s Summary) error {
+	if d == nil || (d.onlyFailures && s.Failed == 0 && !s.hasAnomalies()) {
+		return nil
+	}
+	var errs []error
+	for _, n := range d.notifiers {
+		if err := n.Notify(ctx, s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hasAnomalies reports whether the summary or any item flagged an anomaly.
+func (s Summary) hasAnomalies() bool {
+	if len(s.Anomalies) > 0 {
+		return true
+	}
+	for _, it := range s.Items {
+		if len(it.Anomalies) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+)
+
+func sampleSummary() Summary {
+	return NewSummary("fedsync", time.Now().Add(-time.Minute), []Item{
+		{Name: "cbp", Status: StatusComplete, Rows: 100},
+		{Name: "qcew", Status: StatusUnchanged},
+		{Name: "fpds", Status: StatusFailed, Error: "download: 503", Anomalies: []string{"succeeded after 3 attempts"}},
+	})
+}
+
+func TestNewSummary_Counts(t *testing.T) {
+	s := sampleSummary()
+	assert.Equal(t, 2, s.Succeeded)
+	assert.Equal(t, 1, s.Failed)
+	assert.Equal(t, int64(100), s.Rows)
+	assert.Contains(t, s.Title(), "fedsync run had failures: 2 succeeded, 1 failed, 100 rows")
+}
+
+func TestSummary_Text(t *testing.T) {
+	text := sampleSummary().Text()
+	assert.Contains(t, text, "- fpds failed: download: 503")
+	assert.Contains(t, text, "- fpds: succeeded after 3 attempts")
+	assert.NotContains(t, text, "- cbp")
+}
+
+func TestNew_NoEndpoints(t *testing.T) {
+	d := New(config.NotifyConfig{})
+	assert.Nil(t, d)
+	assert.NoError(t, d.Notify(context.Background(), sampleSummary()))
+}
+
+func TestDispatcher_SlackAndWebhook(t *testing.T) {
+	var slackBody map[string]string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&slackBody))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slack.Close()
+
+	var hookBody Summary
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&hookBody))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hook.Close()
+
+	d := New(config.NotifyConfig{SlackWebhookURL: slack.URL, WebhookURLs: []string{hook.URL}})
+	require.NotNil(t, d)
+	require.NoError(t, d.Notify(context.Background(), sampleSummary()))
+
+	assert.Contains(t, slackBody["text"], ":x: fedsync run had failures")
+	assert.Equal(t, "fedsync", hookBody.Source)
+	assert.Equal(t, 1, hookBody.Failed)
+	assert.Len(t, hookBody.Items, 3)
+}
+
+func TestDispatcher_OnlyFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := New(config.NotifyConfig{WebhookURLs: []string{srv.URL}, OnlyFailures: true})
+	clean := NewSummary("batch", time.Now(), []Item{{Name: "acme.com", Status: StatusComplete}})
+	require.NoError(t, d.Notify(context.Background(), clean))
+	assert.Equal(t, int32(0), calls.Load())
+
+	require.NoError(t, d.Notify(context.Background(), sampleSummary()))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDispatcher_JoinsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var ok atomic.Int32
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ok.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer good.Close()
+
+	d := NewDispatcher(false, NewWebhookNotifier(srv.URL, nil), NewSlackNotifier(good.URL, nil))
+	err := d.Notify(context.Background(), sampleSummary())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notify: webhook returned 500")
+	assert.Equal(t, int32(1), ok.Load(), "later notifiers still run after a failure")
+}
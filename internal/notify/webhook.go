@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rotisserie/eris"
+)
+
+// SlackNotifier posts summaries to a Slack incoming webhook.
+type SlackNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// NewSlackNotifier creates a SlackNotifier posting to url.
+func NewSlackNotifier(url string, client *http.Client) *SlackNotifier {
+	return &SlackNotifier{URL: url, client: client}
+}
+
+// Notify implements Notifier.
+func (n *SlackNotifier) Notify(ctx context.Context, s Summary) error {
+	icon := ":white_check_mark:"
+	if s.Failed > 0 {
+		icon = ":x:"
+	} else if s.hasAnomalies() {
+		icon = ":warning:"
+	}
+	return post(ctx, n.client, n.URL, map[string]string{"text": icon + " " + s.Text()})
+}
+
+// WebhookNotifier posts summaries as JSON to an HTTP endpoint.
+type WebhookNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier posting to url.
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, client: client}
+}
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(ctx context.Context, s Summary) error {
+	return post(ctx, n.client, n.URL, s)
+}
+
+// post sends payload as JSON to url.
+func post(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return eris.Wrap(err, "notify: marshal payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return eris.Wrap(err, "notify: create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return eris.Wrap(err, "notify: send webhook")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 400 {
+		return eris.Errorf("notify: webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
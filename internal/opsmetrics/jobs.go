@@ -0,0 +1,258 @@
+package opsmetrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// syncDurationBuckets spans fedsync runs, from API pulls taking seconds to
+// bulk loads approaching the engine's 60 minute timeout.
+var syncDurationBuckets = []float64{1, 5, 15, 60, 300, 900, 1800, 3600}
+
+// phaseDurationBuckets spans pipeline phases, from cache hits to batch
+// extraction waits.
+var phaseDurationBuckets = []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900}
+
+// LLM token types recorded by RecordLLMUsage.
+const (
+	TokensInput         = "input"
+	TokensOutput        = "output"
+	TokensCacheCreation = "cache_creation"
+	TokensCacheRead     = "cache_read"
+)
+
+// histogram is a fixed-bucket duration histogram.
+type histogram struct {
+	bins  []uint64
+	count uint64
+	sum   float64
+}
+
+// observe records seconds into the histogram at key, creating it on first
+// use.
+func observe(m map[string]*histogram, key string, buckets []float64, seconds float64) {
+	h, ok := m[key]
+	if !ok {
+		h = &histogram{bins: make([]uint64, len(buckets))}
+		m[key] = h
+	}
+	h.count++
+	h.sum += seconds
+	for i, bucket := range buckets {
+		if seconds <= bucket {
+			h.bins[i]++
+		}
+	}
+}
+
+// SyncRun describes one finished fedsync dataset run.
+type SyncRun struct {
+	Dataset  string
+	Status   string // "complete" or "failed"
+	Rows     int64
+	Bytes    int64
+	Requests int64
+	Duration time.Duration
+	Finished time.Time
+}
+
+// RecordSync records a finished fedsync dataset run.
+func RecordSync(run SyncRun) {
+	defaultCollector.RecordSync(run)
+}
+
+// RecordPhase records a finished pipeline phase.
+func RecordPhase(phase string, status string, duration time.Duration) {
+	defaultCollector.RecordPhase(phase, status, duration)
+}
+
+// RecordLLMUsage records LLM tokens by type and cost in USD for a phase.
+func RecordLLMUsage(phase string, tokens map[string]int, costUSD float64) {
+	defaultCollector.RecordLLMUsage(phase, tokens, costUSD)
+}
+
+// RecordSalesforceWrite records n Salesforce record writes.
+func RecordSalesforceWrite(object string, operation string, status string, n int) {
+	defaultCollector.RecordSalesforceWrite(object, operation, status, n)
+}
+
+// RecordSync records a finished fedsync dataset run.
+func (c *Collector) RecordSync(run SyncRun) {
+	dataset := orUnknown(run.Dataset)
+	status := orUnknown(run.Status)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncRuns[dataset+"|"+status]++
+	observe(c.syncDuration, dataset, syncDurationBuckets, run.Duration.Seconds())
+	if run.Bytes > 0 {
+		c.syncBytes[dataset] += uint64(run.Bytes)
+	}
+	if run.Requests > 0 {
+		c.syncRequests[dataset] += uint64(run.Requests)
+	}
+	if status != "complete" {
+		return
+	}
+	if run.Rows > 0 {
+		c.syncRows[dataset] += uint64(run.Rows)
+	}
+	finished := run.Finished
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+	c.syncLastSuccess[dataset] = float64(finished.Unix())
+}
+
+// RecordPhase records a finished pipeline phase.
+func (c *Collector) RecordPhase(phase string, status string, duration time.Duration) {
+	key := orUnknown(phase) + "|" + orUnknown(status)
+
+	c.mu.Lock()
+	observe(c.phaseDuration, key, phaseDurationBuckets, duration.Seconds())
+	c.mu.Unlock()
+}
+
+// RecordLLMUsage records LLM tokens by type and cost in USD for a phase.
+func (c *Collector) RecordLLMUsage(phase string, tokens map[string]int, costUSD float64) {
+	phase = orUnknown(phase)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for typ, n := range tokens {
+		if n > 0 {
+			c.llmTokens[phase+"|"+typ] += uint64(n)
+		}
+	}
+	if costUSD > 0 {
+		c.llmCost[phase] += costUSD
+	}
+}
+
+// RecordSalesforceWrite records n Salesforce record writes.
+func (c *Collector) RecordSalesforceWrite(object string, operation string, status string, n int) {
+	if n <= 0 {
+		return
+	}
+	key := orUnknown(object) + "|" + orUnknown(operation) + "|" + orUnknown(status)
+
+	c.mu.Lock()
+	c.sfWrites[key] += uint64(n)
+	c.mu.Unlock()
+}
+
+// appendSyncLines renders the fedsync metrics. Callers hold c.mu.
+func (c *Collector) appendSyncLines(lines []string) []string {
+	lines = append(lines,
+		"# HELP research_fedsync_runs_total Fedsync dataset runs by dataset and status.",
+		"# TYPE research_fedsync_runs_total counter",
+	)
+	for _, key := range sortedKeys(c.syncRuns) {
+		dataset, status, _ := split3(key)
+		lines = append(lines, fmt.Sprintf(
+			`research_fedsync_runs_total{dataset=%q,status=%q} %d`, dataset, status, c.syncRuns[key]))
+	}
+
+	lines = appendCounter(lines, "research_fedsync_rows_synced_total",
+		"Rows written by successful fedsync runs.", c.syncRows)
+	lines = appendCounter(lines, "research_fedsync_download_bytes_total",
+		"Bytes downloaded by fedsync runs.", c.syncBytes)
+	lines = appendCounter(lines, "research_fedsync_download_requests_total",
+		"Download requests made by fedsync runs.", c.syncRequests)
+
+	lines = append(lines,
+		"# HELP research_fedsync_last_success_timestamp_seconds Unix time of each dataset's last successful run.",
+		"# TYPE research_fedsync_last_success_timestamp_seconds gauge",
+	)
+	for _, dataset := range sortedKeys(c.syncLastSuccess) {
+		lines = append(lines, fmt.Sprintf(
+			`research_fedsync_last_success_timestamp_seconds{dataset=%q} %s`, dataset, trimFloat(c.syncLastSuccess[dataset])))
+	}
+
+	lines = append(lines,
+		"# HELP research_fedsync_sync_duration_seconds Fedsync dataset run duration buckets.",
+		"# TYPE research_fedsync_sync_duration_seconds histogram",
+	)
+	for _, dataset := range sortedKeys(c.syncDuration) {
+		lines = appendHistogram(lines, "research_fedsync_sync_duration_seconds",
+			fmt.Sprintf(`dataset=%q`, dataset), syncDurationBuckets, c.syncDuration[dataset])
+	}
+	return lines
+}
+
+// appendPipelineLines renders the pipeline, LLM, and Salesforce metrics.
+// Callers hold c.mu.
+func (c *Collector) appendPipelineLines(lines []string) []string {
+	lines = append(lines,
+		"# HELP research_pipeline_phase_duration_seconds Pipeline phase duration buckets by phase and status.",
+		"# TYPE research_pipeline_phase_duration_seconds histogram",
+	)
+	for _, key := range sortedKeys(c.phaseDuration) {
+		phase, status, _ := split3(key)
+		lines = appendHistogram(lines, "research_pipeline_phase_duration_seconds",
+			fmt.Sprintf(`phase=%q,status=%q`, phase, status), phaseDurationBuckets, c.phaseDuration[key])
+	}
+
+	lines = append(lines,
+		"# HELP research_llm_tokens_total LLM tokens by pipeline phase and token type.",
+		"# TYPE research_llm_tokens_total counter",
+	)
+	for _, key := range sortedKeys(c.llmTokens) {
+		phase, typ, _ := split3(key)
+		lines = append(lines, fmt.Sprintf(
+			`research_llm_tokens_total{phase=%q,type=%q} %d`, phase, typ, c.llmTokens[key]))
+	}
+
+	lines = append(lines,
+		"# HELP research_llm_cost_usd_total Estimated LLM spend in USD by pipeline phase.",
+		"# TYPE research_llm_cost_usd_total counter",
+	)
+	for _, phase := range sortedKeys(c.llmCost) {
+		lines = append(lines, fmt.Sprintf(
+			`research_llm_cost_usd_total{phase=%q} %s`, phase, trimFloat(c.llmCost[phase])))
+	}
+
+	lines = append(lines,
+		"# HELP research_salesforce_writes_total Salesforce record writes by object, operation, and status.",
+		"# TYPE research_salesforce_writes_total counter",
+	)
+	for _, key := range sortedKeys(c.sfWrites) {
+		object, operation, status := split3(key)
+		lines = append(lines, fmt.Sprintf(
+			`research_salesforce_writes_total{object=%q,operation=%q,status=%q} %d`,
+			object, operation, status, c.sfWrites[key]))
+	}
+	return lines
+}
+
+// appendCounter renders a counter labeled by dataset.
+func appendCounter(lines []string, name string, help string, values map[string]uint64) []string {
+	lines = append(lines, "# HELP "+name+" "+help, "# TYPE "+name+" counter")
+	for _, dataset := range sortedKeys(values) {
+		lines = append(lines, fmt.Sprintf(`%s{dataset=%q} %d`, name, dataset, values[dataset]))
+	}
+	return lines
+}
+
+// appendHistogram renders one labeled histogram series. observe already
+// counts each observation into every bucket it fits, so bins are cumulative.
+func appendHistogram(lines []string, name string, labels string, buckets []float64, h *histogram) []string {
+	for i, bucket := range buckets {
+		lines = append(lines, fmt.Sprintf(`%s_bucket{%s,le=%q} %d`, name, labels, trimFloat(bucket), h.bins[i]))
+	}
+	lines = append(lines,
+		fmt.Sprintf(`%s_bucket{%s,le="+Inf"} %d`, name, labels, h.count),
+		fmt.Sprintf(`%s_sum{%s} %s`, name, labels, trimFloat(h.sum)),
+		fmt.Sprintf(`%s_count{%s} %d`, name, labels, h.count),
+	)
+	return lines
+}
+
+func orUnknown(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
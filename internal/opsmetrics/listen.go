@@ -0,0 +1,32 @@
+package opsmetrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+)
+
+// Listen serves the default collector at /metrics on addr (e.g. ":9090")
+// so short-lived CLI runs can be scraped. The returned function shuts the
+// listener down.
+func Listen(addr string) (func(context.Context) error, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, eris.Wrapf(err, "opsmetrics: listen on %s", addr)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", Handler)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Warn("opsmetrics: metrics listener stopped", zap.String("addr", addr), zap.Error(err))
+		}
+	}()
+	return srv.Shutdown, nil
+}
@@ -15,7 +15,8 @@ var defaultCollector = New()
 
 var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
 
-// Collector stores operational metrics for HTTP and cache activity.
+// Collector stores operational metrics for HTTP and cache activity, fedsync
+// runs, pipeline phases, and Salesforce writes.
 type Collector struct {
 	mu           sync.RWMutex
 	requests     map[string]uint64
@@ -24,6 +25,17 @@ type Collector struct {
 	latencyCount map[string]uint64
 	latencySum   map[string]float64
 	latencyBins  map[string][]uint64
+
+	syncRuns        map[string]uint64
+	syncRows        map[string]uint64
+	syncBytes       map[string]uint64
+	syncRequests    map[string]uint64
+	syncLastSuccess map[string]float64
+	syncDuration    map[string]*histogram
+	phaseDuration   map[string]*histogram
+	llmTokens       map[string]uint64
+	llmCost         map[string]float64
+	sfWrites        map[string]uint64
 }
 
 // New creates a new Collector.
@@ -35,6 +47,17 @@ func New() *Collector {
 		latencyCount: make(map[string]uint64),
 		latencySum:   make(map[string]float64),
 		latencyBins:  make(map[string][]uint64),
+
+		syncRuns:        make(map[string]uint64),
+		syncRows:        make(map[string]uint64),
+		syncBytes:       make(map[string]uint64),
+		syncRequests:    make(map[string]uint64),
+		syncLastSuccess: make(map[string]float64),
+		syncDuration:    make(map[string]*histogram),
+		phaseDuration:   make(map[string]*histogram),
+		llmTokens:       make(map[string]uint64),
+		llmCost:         make(map[string]float64),
+		sfWrites:        make(map[string]uint64),
 	}
 }
 
@@ -180,6 +203,9 @@ func (c *Collector) ServeHTTP(w http.ResponseWriter) {
 		))
 	}
 
+	lines = c.appendSyncLines(lines)
+	lines = c.appendPipelineLines(lines)
+
 	_, _ = w.Write([]byte(strings.Join(lines, "\n") + "\n"))
 }
 
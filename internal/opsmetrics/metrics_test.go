@@ -24,3 +24,34 @@ func TestCollector_ServeHTTP(t *testing.T) {
 	assert.Contains(t, body, `research_api_cache_events_total`)
 	assert.Contains(t, body, `queue_status`)
 }
+
+func TestCollector_SyncAndPipelineMetrics(t *testing.T) {
+	collector := New()
+	finished := time.Unix(1700000000, 0)
+	collector.RecordSync(SyncRun{Dataset: "cbp", Status: "complete", Rows: 120, Bytes: 2048, Requests: 3, Duration: 90 * time.Second, Finished: finished})
+	collector.RecordSync(SyncRun{Dataset: "cbp", Status: "failed", Rows: 50, Bytes: 10, Duration: 2 * time.Second})
+	collector.RecordPhase("2_classify", "complete", 3*time.Second)
+	collector.RecordLLMUsage("2_classify", map[string]int{TokensInput: 1000, TokensOutput: 200, TokensCacheRead: 0}, 0.25)
+	collector.RecordSalesforceWrite("Account", "update", "success", 4)
+	collector.RecordSalesforceWrite("Account", "update", "error", 0)
+
+	rr := httptest.NewRecorder()
+	collector.ServeHTTP(rr)
+
+	body := rr.Body.String()
+	assert.Contains(t, body, `research_fedsync_runs_total{dataset="cbp",status="complete"} 1`)
+	assert.Contains(t, body, `research_fedsync_runs_total{dataset="cbp",status="failed"} 1`)
+	assert.Contains(t, body, `research_fedsync_rows_synced_total{dataset="cbp"} 120`, "failed runs add no rows")
+	assert.Contains(t, body, `research_fedsync_download_bytes_total{dataset="cbp"} 2058`)
+	assert.Contains(t, body, `research_fedsync_download_requests_total{dataset="cbp"} 3`)
+	assert.Contains(t, body, `research_fedsync_last_success_timestamp_seconds{dataset="cbp"} 1700000000`)
+	assert.Contains(t, body, `research_fedsync_sync_duration_seconds_bucket{dataset="cbp",le="5"} 1`)
+	assert.Contains(t, body, `research_fedsync_sync_duration_seconds_bucket{dataset="cbp",le="300"} 2`)
+	assert.Contains(t, body, `research_fedsync_sync_duration_seconds_count{dataset="cbp"} 2`)
+	assert.Contains(t, body, `research_pipeline_phase_duration_seconds_bucket{phase="2_classify",status="complete",le="5"} 1`)
+	assert.Contains(t, body, `research_llm_tokens_total{phase="2_classify",type="input"} 1000`)
+	assert.NotContains(t, body, `type="cache_read"`)
+	assert.Contains(t, body, `research_llm_cost_usd_total{phase="2_classify"} 0.25`)
+	assert.Contains(t, body, `research_salesforce_writes_total{object="Account",operation="update",status="success"} 4`)
+	assert.NotContains(t, body, `status="error"} 0`)
+}
@@ -0,0 +1,73 @@
+package opsmetrics
+
+import (
+	"context"
+
+	"github.com/sells-group/research-cli/pkg/salesforce"
+)
+
+// WrapSalesforce returns c with its record writes counted in
+// research_salesforce_writes_total. A nil client is returned unchanged.
+func WrapSalesforce(c salesforce.Client) salesforce.Client {
+	if c == nil {
+		return nil
+	}
+	return &meteredSalesforce{Client: c}
+}
+
+// meteredSalesforce counts writes; reads pass through the embedded client.
+type meteredSalesforce struct {
+	salesforce.Client
+}
+
+// InsertOne implements salesforce.Client.
+func (m *meteredSalesforce) InsertOne(ctx context.Context, sObjectName string, record map[string]any) (string, error) {
+	id, err := m.Client.InsertOne(ctx, sObjectName, record)
+	RecordSalesforceWrite(sObjectName, "insert", writeStatus(err == nil), 1)
+	return id, err
+}
+
+// InsertCollection implements salesforce.Client.
+func (m *meteredSalesforce) InsertCollection(ctx context.Context, sObjectName string, records []map[string]any) ([]salesforce.CollectionResult, error) {
+	results, err := m.Client.InsertCollection(ctx, sObjectName, records)
+	recordCollection(sObjectName, "insert", len(records), results, err)
+	return results, err
+}
+
+// UpdateOne implements salesforce.Client.
+func (m *meteredSalesforce) UpdateOne(ctx context.Context, sObjectName string, id string, fields map[string]any) error {
+	err := m.Client.UpdateOne(ctx, sObjectName, id, fields)
+	RecordSalesforceWrite(sObjectName, "update", writeStatus(err == nil), 1)
+	return err
+}
+
+// UpdateCollection implements salesforce.Client.
+func (m *meteredSalesforce) UpdateCollection(ctx context.Context, sObjectName string, records []salesforce.CollectionRecord) ([]salesforce.CollectionResult, error) {
+	results, err := m.Client.UpdateCollection(ctx, sObjectName, records)
+	recordCollection(sObjectName, "update", len(records), results, err)
+	return results, err
+}
+
+// recordCollection counts a collection write per record. A request-level
+// error counts every record as failed.
+func recordCollection(object string, operation string, n int, results []salesforce.CollectionResult, err error) {
+	if err != nil {
+		RecordSalesforceWrite(object, operation, writeStatus(false), n)
+		return
+	}
+	var ok int
+	for _, r := range results {
+		if r.Success {
+			ok++
+		}
+	}
+	RecordSalesforceWrite(object, operation, writeStatus(true), ok)
+	RecordSalesforceWrite(object, operation, writeStatus(false), len(results)-ok)
+}
+
+func writeStatus(ok bool) string {
+	if ok {
+		return "success"
+	}
+	return "error"
+}
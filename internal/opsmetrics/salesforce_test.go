@@ -0,0 +1,64 @@
+package opsmetrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/pkg/salesforce"
+	sfmocks "github.com/sells-group/research-cli/pkg/salesforce/mocks"
+)
+
+func TestWrapSalesforce(t *testing.T) {
+	assert.Nil(t, WrapSalesforce(nil))
+
+	sf := sfmocks.NewMockClient(t)
+	sf.EXPECT().UpdateOne(mock.Anything, "Test_Metrics__c", "001", mock.Anything).Return(nil)
+	sf.EXPECT().InsertOne(mock.Anything, "Test_Metrics__c", mock.Anything).Return("", errors.New("boom"))
+	sf.EXPECT().InsertCollection(mock.Anything, "Test_Metrics__c", mock.Anything).
+		Return([]salesforce.CollectionResult{{Success: true}, {Success: false}, {Success: true}}, nil)
+
+	c := WrapSalesforce(sf)
+	ctx := context.Background()
+	require.NoError(t, c.UpdateOne(ctx, "Test_Metrics__c", "001", map[string]any{"Name": "x"}))
+	_, err := c.InsertOne(ctx, "Test_Metrics__c", map[string]any{})
+	require.Error(t, err)
+	_, err = c.InsertCollection(ctx, "Test_Metrics__c", make([]map[string]any, 3))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	defaultCollector.ServeHTTP(rr)
+	body := rr.Body.String()
+	assert.Contains(t, body, `research_salesforce_writes_total{object="Test_Metrics__c",operation="update",status="success"} 1`)
+	assert.Contains(t, body, `research_salesforce_writes_total{object="Test_Metrics__c",operation="insert",status="error"} 2`)
+	assert.Contains(t, body, `research_salesforce_writes_total{object="Test_Metrics__c",operation="insert",status="success"} 2`)
+}
+
+func TestListen(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	stop, err := Listen(addr)
+	require.NoError(t, err)
+	defer stop(context.Background()) //nolint:errcheck
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "# TYPE research_fedsync_runs_total counter")
+
+	_, err = Listen(addr)
+	require.Error(t, err, "address in use")
+}
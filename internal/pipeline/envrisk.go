@@ -0,0 +1,282 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// envRiskNoteKey is the field registry key written by Phase 7E.
+const envRiskNoteKey = "env_safety_risk_note"
+
+// envRiskMaxMatches caps the records returned per EPA/OSHA check.
+const envRiskMaxMatches = 10
+
+// kmPerDegreeLat is the approximate length of one degree of latitude.
+const kmPerDegreeLat = 111.0
+
+// epaNearbySQL finds EPA-regulated facilities within $3 km of ($1, $2). The
+// lat/long bounding box ($4, $5 degrees) lets the location index prefilter
+// before the haversine distance is computed.
+const epaNearbySQL = `
+	SELECT registry_id, name, city, state, distance_km FROM (
+		SELECT registry_id, COALESCE(fac_name, '') AS name, COALESCE(fac_city, '') AS city,
+			COALESCE(fac_state, '') AS state,
+			6371.0 * 2 * asin(sqrt(
+				power(sin(radians(fac_lat::float8 - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(fac_lat::float8)) *
+				power(sin(radians(fac_long::float8 - $2) / 2), 2)
+			)) AS distance_km
+		FROM fed_data.epa_facilities
+		WHERE fac_lat BETWEEN $1 - $4 AND $1 + $4
+		  AND fac_long BETWEEN $2 - $5 AND $2 + $5
+	) f
+	WHERE distance_km <= $3
+	ORDER BY distance_km
+	LIMIT $6`
+
+// epaNameSQL finds EPA facilities in the company's state whose name is
+// similar to the company name, with their enforcement and compliance
+// history when the facility has one.
+const epaNameSQL = `
+	SELECT f.registry_id, COALESCE(f.fac_name, ''), COALESCE(f.fac_city, ''), COALESCE(f.fac_state, ''),
+		similarity(f.fac_name, $1) AS sim,
+		COALESCE(e.formal_action_count, 0), COALESCE(e.total_penalties, 0)::float8,
+		COALESCE(e.compliance_status, ''), COALESCE(e.snc_flag, false)
+	FROM fed_data.epa_facilities f
+	LEFT JOIN fed_data.epa_enforcement e ON e.registry_id = f.registry_id
+	WHERE f.fac_state = $2 AND f.fac_name % $1 AND similarity(f.fac_name, $1) >= $3
+	ORDER BY sim DESC
+	LIMIT $4`
+
+// oshaNameSQL finds OSHA inspections opened since $3 at establishments in the
+// company's state whose name is similar to the company name.
+const oshaNameSQL = `
+	SELECT activity_nr::text, COALESCE(estab_name, ''), COALESCE(site_city, ''), COALESCE(site_state, ''),
+		similarity(estab_name, $1) AS sim, open_date, COALESCE(total_penalty, 0)::float8
+	FROM fed_data.osha_inspections
+	WHERE site_state = $2 AND estab_name % $1 AND similarity(estab_name, $1) >= $4
+	  AND open_date >= $3
+	ORDER BY open_date DESC
+	LIMIT $5`
+
+// LookupEnvRisk checks a company against EPA facility and OSHA inspection
+// records: EPA facilities within the configured radius of the geocoded
+// location, EPA facilities with a similar name in the same state, and OSHA
+// inspections of a similarly named establishment within the lookback window.
+// Proximity is skipped without geo data; name checks are skipped without a
+// company name and state.
+func LookupEnvRisk(ctx context.Context, pool db.Pool, company model.Company, geoData *model.GeoData, cfg config.EnvRiskConfig) (*model.EnvRisk, error) {
+	if pool == nil {
+		return nil, nil
+	}
+
+	risk := &model.EnvRisk{RadiusKM: cfg.RadiusKM}
+
+	if geoData != nil && (geoData.Latitude != 0 || geoData.Longitude != 0) && cfg.RadiusKM > 0 {
+		nearby, err := queryEPANearby(ctx, pool, geoData.Latitude, geoData.Longitude, cfg.RadiusKM)
+		if err != nil {
+			return nil, err
+		}
+		risk.NearbyEPAFacilities = nearby
+	}
+
+	name := strings.TrimSpace(company.Name)
+	state := strings.ToUpper(strings.TrimSpace(company.State))
+	if name != "" && len(state) == 2 {
+		epaMatches, err := queryEnvRiskMatches(ctx, pool, "EPA facility", epaNameSQL,
+			func(m *model.EnvRiskMatch) []any {
+				return []any{&m.FormalActions, &m.Penalty, &m.ComplianceStatus, &m.SNC}
+			},
+			name, state, cfg.NameSimilarity, envRiskMaxMatches)
+		if err != nil {
+			return nil, err
+		}
+		risk.EPANameMatches = epaMatches
+
+		since := time.Now().AddDate(-cfg.LookbackYears, 0, 0)
+		oshaMatches, err := queryEnvRiskMatches(ctx, pool, "OSHA inspection", oshaNameSQL,
+			func(m *model.EnvRiskMatch) []any { return []any{&m.Date, &m.Penalty} },
+			name, state, since, cfg.NameSimilarity, envRiskMaxMatches)
+		if err != nil {
+			return nil, err
+		}
+		risk.OSHAInspections = oshaMatches
+	}
+
+	risk.Note = FormatEnvRiskNote(risk, cfg.LookbackYears)
+	return risk, nil
+}
+
+func queryEPANearby(ctx context.Context, pool db.Pool, lat, lon, radiusKM float64) ([]model.EnvRiskMatch, error) {
+	latDelta := radiusKM / kmPerDegreeLat
+	lonDelta := latDelta
+	if c := math.Cos(lat * math.Pi / 180); c > 0.01 {
+		lonDelta = radiusKM / (kmPerDegreeLat * c)
+	}
+
+	rows, err := pool.Query(ctx, epaNearbySQL, lat, lon, radiusKM, latDelta, lonDelta, envRiskMaxMatches)
+	if err != nil {
+		return nil, eris.Wrap(err, "env_risk: query nearby EPA facilities")
+	}
+	defer rows.Close()
+
+	var out []model.EnvRiskMatch
+	for rows.Next() {
+		var m model.EnvRiskMatch
+		if err := rows.Scan(&m.ID, &m.Name, &m.City, &m.State, &m.DistanceKM); err != nil {
+			return nil, eris.Wrap(err, "env_risk: scan nearby EPA facility")
+		}
+		out = append(out, m)
+	}
+	return out, eris.Wrap(rows.Err(), "env_risk: iterate nearby EPA facilities")
+}
+
+// queryEnvRiskMatches runs a name-similarity query. extra returns the scan
+// destinations for the columns after similarity (EPA enforcement history or
+// OSHA open date and penalty).
+func queryEnvRiskMatches(ctx context.Context, pool db.Pool, label, sql string, extra func(*model.EnvRiskMatch) []any, args ...any) ([]model.EnvRiskMatch, error) {
+	rows, err := pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, eris.Wrapf(err, "env_risk: query %s matches", label)
+	}
+	defer rows.Close()
+
+	var out []model.EnvRiskMatch
+	for rows.Next() {
+		var m model.EnvRiskMatch
+		dest := append([]any{&m.ID, &m.Name, &m.City, &m.State, &m.Similarity}, extra(&m)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, eris.Wrapf(err, "env_risk: scan %s match", label)
+		}
+		out = append(out, m)
+	}
+	return out, eris.Wrapf(rows.Err(), "env_risk: iterate %s matches", label)
+}
+
+// FormatEnvRiskNote builds the environmental/safety risk note. It returns ""
+// when nothing matched.
+func FormatEnvRiskNote(risk *model.EnvRisk, lookbackYears int) string {
+	if !risk.Flagged() {
+		return ""
+	}
+
+	var parts []string
+	if n := len(risk.NearbyEPAFacilities); n > 0 {
+		closest := risk.NearbyEPAFacilities[0]
+		parts = append(parts, fmt.Sprintf("%d EPA-regulated %s within %.1f km (closest: %s, %.2f km)",
+			n, plural(n, "facility", "facilities"), risk.RadiusKM, closest.Name, closest.DistanceKM))
+	}
+	if n := len(risk.EPANameMatches); n > 0 {
+		top := risk.EPANameMatches[0]
+		s := fmt.Sprintf("%d EPA %s matching company name (%s, %s %s)",
+			n, plural(n, "facility", "facilities"), top.Name, top.City, top.State)
+		var actions int
+		var penalties float64
+		var snc bool
+		for _, m := range risk.EPANameMatches {
+			actions += m.FormalActions
+			penalties += m.Penalty
+			snc = snc || m.SNC
+		}
+		if actions > 0 {
+			s += fmt.Sprintf(", %d formal EPA enforcement %s", actions, plural(actions, "action", "actions"))
+		}
+		if penalties > 0 {
+			s += fmt.Sprintf(", $%.0f EPA penalties", penalties)
+		}
+		if snc {
+			s += ", in significant noncompliance"
+		}
+		parts = append(parts, s)
+	}
+	if n := len(risk.OSHAInspections); n > 0 {
+		var penalties float64
+		var latest *time.Time
+		for _, m := range risk.OSHAInspections {
+			penalties += m.Penalty
+			if m.Date != nil && (latest == nil || m.Date.After(*latest)) {
+				latest = m.Date
+			}
+		}
+		s := fmt.Sprintf("%d OSHA %s in the last %d years", n, plural(n, "inspection", "inspections"), lookbackYears)
+		if latest != nil {
+			s += ", latest " + latest.Format("2006-01-02")
+		}
+		if penalties > 0 {
+			s += fmt.Sprintf(", $%.0f total penalties", penalties)
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "; ") + "."
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// ApplyEnvRiskNote writes the risk note into fieldValues when the field
+// registry maps env_safety_risk_note. It reports whether a value was set.
+func ApplyEnvRiskNote(fieldValues map[string]model.FieldValue, fields *model.FieldRegistry, risk *model.EnvRisk) bool {
+	if risk == nil || risk.Note == "" || fields == nil {
+		return false
+	}
+	fm := fields.ByKey(envRiskNoteKey)
+	if fm == nil {
+		return false
+	}
+	fieldValues[envRiskNoteKey] = model.FieldValue{
+		FieldKey:   envRiskNoteKey,
+		SFField:    fm.SFField,
+		Value:      risk.Note,
+		Confidence: 0.7,
+		Source:     "fed_data.epa_facilities+epa_enforcement+osha_inspections",
+		Reasoning:  "EPA facility proximity, EPA/OSHA name matches, and EPA enforcement history",
+	}
+	return true
+}
+
+// Phase7EEnvRisk runs the EPA/OSHA environmental and safety risk check and
+// records the note on fieldValues.
+func (p *Pipeline) Phase7EEnvRisk(ctx context.Context, company model.Company, geoData *model.GeoData, fieldValues map[string]model.FieldValue) (*model.EnvRisk, *model.PhaseResult, error) {
+	risk, err := LookupEnvRisk(ctx, p.fedsyncPool, company, geoData, p.cfg.Pipeline.EnvRisk)
+	if err != nil {
+		return nil, nil, err
+	}
+	if risk == nil {
+		return nil, &model.PhaseResult{
+			Status:   model.PhaseStatusSkipped,
+			Metadata: map[string]any{"reason": "fedsync_pool_not_available"},
+		}, nil
+	}
+
+	written := ApplyEnvRiskNote(fieldValues, p.fields, risk)
+	if risk.Flagged() {
+		zap.L().Info("pipeline: environmental/safety risk flagged",
+			zap.String("company", company.Name),
+			zap.Int("epa_nearby", len(risk.NearbyEPAFacilities)),
+			zap.Int("epa_name_matches", len(risk.EPANameMatches)),
+			zap.Int("osha_inspections", len(risk.OSHAInspections)),
+		)
+	}
+	return risk, &model.PhaseResult{
+		Metadata: map[string]any{
+			"epa_nearby":       len(risk.NearbyEPAFacilities),
+			"epa_name_matches": len(risk.EPANameMatches),
+			"osha_inspections": len(risk.OSHAInspections),
+			"note_written":     written,
+		},
+	}, nil
+}
@@ -0,0 +1,132 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func testEnvRiskConfig() config.EnvRiskConfig {
+	return config.EnvRiskConfig{Enabled: true, RadiusKM: 1.0, NameSimilarity: 0.6, LookbackYears: 5}
+}
+
+var (
+	epaNearbyCols = []string{"registry_id", "name", "city", "state", "distance_km"}
+	epaNameCols   = []string{"registry_id", "fac_name", "fac_city", "fac_state", "sim",
+		"formal_action_count", "total_penalties", "compliance_status", "snc_flag"}
+	oshaNameCols = []string{"activity_nr", "estab_name", "site_city", "site_state", "sim", "open_date", "total_penalty"}
+)
+
+func TestLookupEnvRisk_NilPool(t *testing.T) {
+	t.Parallel()
+	risk, err := LookupEnvRisk(context.Background(), nil, model.Company{Name: "Acme"}, nil, testEnvRiskConfig())
+	assert.NoError(t, err)
+	assert.Nil(t, risk)
+}
+
+func TestLookupEnvRisk_AllChecks(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	opened := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.epa_facilities\n\t\tWHERE fac_lat BETWEEN")).
+		WithArgs(32.78, -96.8, 1.0, pgxmock.AnyArg(), pgxmock.AnyArg(), envRiskMaxMatches).
+		WillReturnRows(pgxmock.NewRows(epaNearbyCols).
+			AddRow("110000001", "DALLAS PLATING", "DALLAS", "TX", 0.42).
+			AddRow("110000002", "ACME FABRICATION", "DALLAS", "TX", 0.87))
+	pool.ExpectQuery(regexp.QuoteMeta("LEFT JOIN fed_data.epa_enforcement")).
+		WithArgs("Acme Fabrication", "TX", 0.6, envRiskMaxMatches).
+		WillReturnRows(pgxmock.NewRows(epaNameCols).
+			AddRow("110000002", "ACME FABRICATION", "DALLAS", "TX", 0.91,
+				2, 40000.0, "Significant Violation", true))
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.osha_inspections")).
+		WithArgs("Acme Fabrication", "TX", pgxmock.AnyArg(), 0.6, envRiskMaxMatches).
+		WillReturnRows(pgxmock.NewRows(oshaNameCols).
+			AddRow("1234567", "ACME FABRICATION INC", "DALLAS", "TX", 0.8, &opened, 12500.0))
+
+	risk, err := LookupEnvRisk(context.Background(), pool,
+		model.Company{Name: "Acme Fabrication", State: "tx"},
+		&model.GeoData{Latitude: 32.78, Longitude: -96.8},
+		testEnvRiskConfig())
+	require.NoError(t, err)
+	require.NotNil(t, risk)
+
+	assert.True(t, risk.Flagged())
+	assert.Len(t, risk.NearbyEPAFacilities, 2)
+	assert.Len(t, risk.EPANameMatches, 1)
+	require.Len(t, risk.OSHAInspections, 1)
+	assert.Equal(t, 12500.0, risk.OSHAInspections[0].Penalty)
+	assert.Equal(t, "Significant Violation", risk.EPANameMatches[0].ComplianceStatus)
+	assert.Equal(t,
+		"2 EPA-regulated facilities within 1.0 km (closest: DALLAS PLATING, 0.42 km); "+
+			"1 EPA facility matching company name (ACME FABRICATION, DALLAS TX), "+
+			"2 formal EPA enforcement actions, $40000 EPA penalties, in significant noncompliance; "+
+			"1 OSHA inspection in the last 5 years, latest 2024-03-01, $12500 total penalties.",
+		risk.Note)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLookupEnvRisk_NoGeoNoState(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	risk, err := LookupEnvRisk(context.Background(), pool, model.Company{Name: "Acme"}, nil, testEnvRiskConfig())
+	require.NoError(t, err)
+	require.NotNil(t, risk)
+	assert.False(t, risk.Flagged())
+	assert.Empty(t, risk.Note)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLookupEnvRisk_QueryError(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery(regexp.QuoteMeta("LEFT JOIN fed_data.epa_enforcement")).
+		WillReturnError(errors.New("connection reset"))
+
+	_, err = LookupEnvRisk(context.Background(), pool, model.Company{Name: "Acme", State: "TX"}, nil, testEnvRiskConfig())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "env_risk: query EPA facility matches")
+}
+
+func TestApplyEnvRiskNote(t *testing.T) {
+	t.Parallel()
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "env_safety_risk_note", SFField: "Env_Safety_Risk_Note__c"},
+	})
+	risk := &model.EnvRisk{
+		OSHAInspections: []model.EnvRiskMatch{{ID: "1", Name: "ACME"}},
+		Note:            "1 OSHA inspection in the last 5 years.",
+	}
+
+	fv := map[string]model.FieldValue{}
+	assert.True(t, ApplyEnvRiskNote(fv, fields, risk))
+	assert.Equal(t, "Env_Safety_Risk_Note__c", fv["env_safety_risk_note"].SFField)
+	assert.Equal(t, risk.Note, fv["env_safety_risk_note"].Value)
+
+	// No registry mapping: nothing written.
+	fv = map[string]model.FieldValue{}
+	assert.False(t, ApplyEnvRiskNote(fv, model.NewFieldRegistry(nil), risk))
+	assert.Empty(t, fv)
+
+	// No findings: nothing written.
+	assert.False(t, ApplyEnvRiskNote(fv, fields, &model.EnvRisk{}))
+	assert.Empty(t, fv)
+}
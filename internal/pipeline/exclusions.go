@@ -0,0 +1,122 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// exclusionsMaxRecords caps the exclusion records attached to a result.
+const exclusionsMaxRecords = 10
+
+// exclusionsSQL finds active SAM.gov exclusions for the normalized company
+// name $1: firm exclusions under that name, and exclusions on the UEI of any
+// SAM registration with that legal name. $2 restricts name matches to the
+// company's state when known.
+const exclusionsSQL = `
+	SELECT x.sam_number, x.name, COALESCE(x.uei, ''), COALESCE(x.classification, ''),
+		COALESCE(x.exclusion_program, ''), COALESCE(x.excluding_agency, ''),
+		COALESCE(x.exclusion_type, ''), x.active_date, x.termination_date
+	FROM fed_data.sam_exclusions x
+	WHERE (x.termination_date IS NULL OR x.termination_date >= CURRENT_DATE)
+		AND (
+			(x.name_norm = $1 AND x.classification IS DISTINCT FROM 'Individual'
+				AND ($2 = '' OR x.state IS NULL OR x.state = $2))
+			OR x.uei IN (SELECT uei FROM fed_data.sam_entities WHERE legal_name_norm = $1)
+		)
+	ORDER BY x.active_date DESC NULLS LAST
+	LIMIT $3`
+
+// LookupExclusions finds active SAM.gov exclusions (fed_data.sam_exclusions)
+// matching the company. Returns nil when no pool is available and an empty
+// result when the company has no name.
+func LookupExclusions(ctx context.Context, pool db.Pool, company model.Company) (*model.Exclusions, error) {
+	if pool == nil {
+		return nil, nil
+	}
+
+	ex := &model.Exclusions{}
+	norm := resolve.NormalizeName(company.Name)
+	if norm == "" {
+		return ex, nil
+	}
+
+	state := strings.ToUpper(strings.TrimSpace(company.State))
+	if len(state) != 2 {
+		state = ""
+	}
+	rows, err := pool.Query(ctx, exclusionsSQL, norm, state, exclusionsMaxRecords)
+	if err != nil {
+		return nil, eris.Wrap(err, "exclusions: query sam exclusions")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r model.ExclusionRecord
+		if err := rows.Scan(&r.SAMNumber, &r.Name, &r.UEI, &r.Classification,
+			&r.Program, &r.Agency, &r.Type, &r.ActiveDate, &r.TerminationDate); err != nil {
+			return nil, eris.Wrap(err, "exclusions: scan sam exclusion")
+		}
+		ex.Records = append(ex.Records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "exclusions: iterate sam exclusions")
+	}
+
+	ex.Note = FormatExclusionsNote(ex)
+	return ex, nil
+}
+
+// FormatExclusionsNote summarizes matched exclusions. It returns "" when
+// nothing matched.
+func FormatExclusionsNote(ex *model.Exclusions) string {
+	if !ex.Flagged() {
+		return ""
+	}
+	n := len(ex.Records)
+	latest := ex.Records[0]
+	s := fmt.Sprintf("%d active SAM.gov %s", n, plural(n, "exclusion", "exclusions"))
+	if latest.Agency != "" {
+		s += " (latest by " + latest.Agency
+		if latest.Type != "" {
+			s += ": " + latest.Type
+		}
+		s += ")"
+	}
+	return s + "; e.g. " + latest.Name + "."
+}
+
+// Phase7GExclusions checks the company against the SAM.gov exclusions list
+// so the quality gate can hold debarred or suspended companies for review.
+// It runs for every company whenever the fedsync database is available.
+func (p *Pipeline) Phase7GExclusions(ctx context.Context, company model.Company) (*model.Exclusions, *model.PhaseResult, error) {
+	ex, err := LookupExclusions(ctx, p.fedsyncPool, company)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ex == nil {
+		return nil, &model.PhaseResult{
+			Status:   model.PhaseStatusSkipped,
+			Metadata: map[string]any{"reason": "fedsync_pool_not_available"},
+		}, nil
+	}
+
+	if ex.Flagged() {
+		zap.L().Warn("pipeline: sam exclusion matched",
+			zap.String("company", company.Name),
+			zap.Int("exclusions", len(ex.Records)),
+		)
+	}
+	return ex, &model.PhaseResult{
+		Metadata: map[string]any{
+			"exclusions": len(ex.Records),
+		},
+	}, nil
+}
@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+var exclusionCols = []string{
+	"sam_number", "name", "uei", "classification", "exclusion_program",
+	"excluding_agency", "exclusion_type", "active_date", "termination_date",
+}
+
+func TestLookupExclusions_NilPool(t *testing.T) {
+	t.Parallel()
+	ex, err := LookupExclusions(context.Background(), nil, model.Company{Name: "Acme"})
+	assert.NoError(t, err)
+	assert.Nil(t, ex)
+}
+
+func TestLookupExclusions(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	active := time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.sam_exclusions")).
+		WithArgs("ACME FEDERAL SERVICES", "VA", exclusionsMaxRecords).
+		WillReturnRows(pgxmock.NewRows(exclusionCols).
+			AddRow("S4MR3ABC123", "Acme Federal Services, LLC", "ABCDEF123456", "Firm", "Reciprocal",
+				"HHS", "Prohibition/Restriction", &active, (*time.Time)(nil)))
+
+	ex, err := LookupExclusions(context.Background(), pool, model.Company{Name: "Acme Federal Services, LLC", State: "va"})
+	require.NoError(t, err)
+	require.Len(t, ex.Records, 1)
+	assert.True(t, ex.Flagged())
+	assert.Nil(t, ex.Records[0].TerminationDate)
+	assert.Equal(t, "1 active SAM.gov exclusion (latest by HHS: Prohibition/Restriction); e.g. Acme Federal Services, LLC.", ex.Note)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLookupExclusions_NoNameAndError(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	ex, err := LookupExclusions(context.Background(), pool, model.Company{})
+	require.NoError(t, err)
+	assert.False(t, ex.Flagged())
+	assert.Empty(t, FormatExclusionsNote(ex))
+
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.sam_exclusions")).
+		WithArgs("ACME", "", exclusionsMaxRecords).
+		WillReturnError(errors.New("relation does not exist"))
+	_, err = LookupExclusions(context.Background(), pool, model.Company{Name: "Acme", State: "Texas"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exclusions: query sam exclusions")
+}
+
+func TestComputeGateResult_Exclusions(t *testing.T) {
+	cfg := &config.Config{Pipeline: config.PipelineConfig{
+		QualityScoreThreshold: 0.3,
+		QualityWeights:        config.QualityWeights{Confidence: 1.0},
+	}}
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme"},
+		Exclusions: &model.Exclusions{
+			Records: []model.ExclusionRecord{{SAMNumber: "S4MR3ABC123", Name: "Acme"}},
+		},
+	}
+
+	gate := ComputeGateResult(result, nil, nil, cfg)
+	require.NotNil(t, gate.Exclusions)
+	assert.True(t, gate.ManualReview)
+
+	result.Exclusions = &model.Exclusions{}
+	gate = ComputeGateResult(result, nil, nil, cfg)
+	assert.Nil(t, gate.Exclusions)
+	assert.False(t, gate.ManualReview)
+}
@@ -45,6 +45,15 @@ func (e *NotionExporter) ExportResult(ctx context.Context, result *model.Enrichm
 			)
 		}
 	}
+
+	if len(gate.SFDiff) > 0 {
+		if err := writeNotionPendingChanges(ctx, e.client, result.Company.NotionPageID, gate.SFDiff); err != nil {
+			zap.L().Warn("exporter: notion pending changes update failed",
+				zap.String("company", result.Company.Name),
+				zap.Error(err),
+			)
+		}
+	}
 	return nil
 }
 
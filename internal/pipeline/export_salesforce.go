@@ -48,16 +48,13 @@ func (e *SalesforceExporter) ExportResult(ctx context.Context, result *model.Enr
 		return nil
 	}
 
-	accountFields, contactFields := buildSFFieldsByObject(result.FieldValues, e.fields)
-	if result.Report != "" {
-		accountFields["Enrichment_Report__c"] = result.Report
-	}
-	ensureMinimumSFFields(accountFields, result.Company, result.FieldValues)
-	injectGeoFields(accountFields, result.GeoData)
+	accountFields := buildSFAccountPayload(result, e.fields)
 
 	contacts := extractContactsForSF(result.FieldValues, e.fields)
-	if contacts == nil && len(contactFields) > 0 {
-		contacts = []map[string]any{contactFields}
+	if contacts == nil {
+		if _, contactFields := buildSFFieldsByObject(result.FieldValues, e.fields); len(contactFields) > 0 {
+			contacts = []map[string]any{contactFields}
+		}
 	}
 
 	if e.deferred {
@@ -0,0 +1,352 @@
+package pipeline
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// Sheet export formats.
+const (
+	SheetFormatCSV  = "csv"
+	SheetFormatXLSX = "xlsx"
+)
+
+// ExportRow is one company's latest completed enrichment, flattened for
+// spreadsheet export.
+type ExportRow struct {
+	Company     model.Company
+	RunID       string
+	EnrichedAt  time.Time
+	Score       float64
+	FieldValues map[string]model.FieldValue
+}
+
+// BuildExportRows keeps the most recent completed run per company URL and
+// rebuilds its field values against the registry. Rows are sorted by score,
+// highest first.
+func BuildExportRows(runs []model.Run, fields *model.FieldRegistry) []ExportRow {
+	latest := make(map[string]model.Run)
+	for _, r := range runs {
+		if r.Status != model.RunStatusComplete || r.Result == nil {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSuffix(r.Company.URL, "/"))
+		if prev, ok := latest[key]; !ok || r.CreatedAt.After(prev.CreatedAt) {
+			latest[key] = r
+		}
+	}
+
+	rows := make([]ExportRow, 0, len(latest))
+	for _, r := range latest {
+		rows = append(rows, ExportRow{
+			Company:     r.Company,
+			RunID:       r.ID,
+			EnrichedAt:  r.CreatedAt,
+			Score:       r.Result.Score,
+			FieldValues: BuildFieldValues(r.Result.Answers, fields, r.Company),
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Score != rows[j].Score {
+			return rows[i].Score > rows[j].Score
+		}
+		return rows[i].Company.Name < rows[j].Company.Name
+	})
+	return rows
+}
+
+// segmentTerm is one "key<op>value" filter condition.
+type segmentTerm struct {
+	key, op, value string
+}
+
+// Segment selects export rows. All terms must match.
+type Segment struct {
+	terms []segmentTerm
+}
+
+// ParseSegment parses a comma-separated filter such as
+// "state=TX,score>=0.6,naics_code~238". Keys are score, name, url, state,
+// city, location, salesforce_id, or any field key. Operators are =, !=, >,
+// >=, <, <= (numeric when both sides parse as numbers, otherwise
+// case-insensitive) and ~ (contains). An empty string matches everything.
+func ParseSegment(s string) (Segment, error) {
+	var seg Segment
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		term, ok := parseSegmentTerm(part)
+		if !ok {
+			return Segment{}, eris.Errorf("segment: invalid term %q (want key<op>value)", part)
+		}
+		seg.terms = append(seg.terms, term)
+	}
+	return seg, nil
+}
+
+func parseSegmentTerm(part string) (segmentTerm, bool) {
+	i := strings.IndexAny(part, "!=<>~")
+	if i <= 0 {
+		return segmentTerm{}, false
+	}
+	op := part[i : i+1]
+	if two := part[i:min(i+2, len(part))]; two == "!=" || two == ">=" || two == "<=" {
+		op = two
+	} else if op == "!" {
+		return segmentTerm{}, false
+	}
+	key := strings.ToLower(strings.TrimSpace(part[:i]))
+	return segmentTerm{key: key, op: op, value: strings.TrimSpace(part[i+len(op):])}, key != ""
+}
+
+// Match reports whether row satisfies every term.
+func (s Segment) Match(row ExportRow) bool {
+	for _, t := range s.terms {
+		if !t.match(row.lookup(t.key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the rows matching the segment.
+func (s Segment) Filter(rows []ExportRow) []ExportRow {
+	var out []ExportRow
+	for _, r := range rows {
+		if s.Match(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (t segmentTerm) match(got string) bool {
+	if t.op == "~" {
+		return strings.Contains(strings.ToLower(got), strings.ToLower(t.value))
+	}
+
+	var cmp int
+	a, aErr := strconv.ParseFloat(got, 64)
+	b, bErr := strconv.ParseFloat(t.value, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	case t.op == "=" || t.op == "!=":
+		if strings.EqualFold(got, t.value) {
+			cmp = 0
+		} else {
+			cmp = 1
+		}
+	case got == "":
+		return false // ordering against a missing value never matches
+	default:
+		cmp = strings.Compare(strings.ToLower(got), strings.ToLower(t.value))
+	}
+
+	switch t.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	default: // "<="
+		return cmp <= 0
+	}
+}
+
+// lookup returns the value of a segment key as a string.
+func (r ExportRow) lookup(key string) string {
+	switch key {
+	case "score":
+		return strconv.FormatFloat(r.Score, 'f', -1, 64)
+	case "name":
+		return r.Company.Name
+	case "url":
+		return r.Company.URL
+	case "state":
+		if r.Company.State != "" {
+			return r.Company.State
+		}
+		return fieldStr(r.FieldValues, "hq_state")
+	case "city":
+		if r.Company.City != "" {
+			return r.Company.City
+		}
+		return fieldStr(r.FieldValues, "hq_city")
+	case "location":
+		return r.Company.Location
+	case "salesforce_id":
+		return r.Company.SalesforceID
+	default:
+		return fieldStr(r.FieldValues, key)
+	}
+}
+
+// ExportColumns resolves the field columns to export. With no keys every
+// registry field is exported in registry order; unknown keys are an error.
+func ExportColumns(fields *model.FieldRegistry, keys []string) ([]model.FieldMapping, error) {
+	if len(keys) == 0 {
+		return fields.Fields, nil
+	}
+	cols := make([]model.FieldMapping, 0, len(keys))
+	var unknown []string
+	for _, k := range keys {
+		f := fields.ByKey(strings.TrimSpace(k))
+		if f == nil {
+			unknown = append(unknown, k)
+			continue
+		}
+		cols = append(cols, *f)
+	}
+	if len(unknown) > 0 {
+		return nil, eris.Errorf("export: unknown field keys: %s", strings.Join(unknown, ", "))
+	}
+	return cols, nil
+}
+
+// exportBaseHeader are the company columns that precede field columns.
+var exportBaseHeader = []string{"Company", "URL", "Salesforce ID", "Location", "Score", "Enriched At"}
+
+// sheetHeader builds the header row. With confidence each field is followed
+// by a "<key> confidence" column.
+func sheetHeader(cols []model.FieldMapping, confidence bool) []string {
+	header := append([]string(nil), exportBaseHeader...)
+	for _, c := range cols {
+		header = append(header, c.Key)
+		if confidence {
+			header = append(header, c.Key+" confidence")
+		}
+	}
+	return header
+}
+
+// sheetCell is one exported value; num is set for numeric cells so xlsx
+// output keeps them sortable.
+type sheetCell struct {
+	text string
+	num  *float64
+}
+
+func textCell(s string) sheetCell { return sheetCell{text: s} }
+
+func numCell(f float64) sheetCell {
+	return sheetCell{text: strconv.FormatFloat(f, 'f', -1, 64), num: &f}
+}
+
+// sheetRow flattens an export row in header order.
+func sheetRow(r ExportRow, cols []model.FieldMapping, confidence bool) []sheetCell {
+	cells := []sheetCell{
+		textCell(r.Company.Name),
+		textCell(r.Company.URL),
+		textCell(r.Company.SalesforceID),
+		textCell(r.Company.Location),
+		numCell(r.Score),
+		textCell(r.EnrichedAt.UTC().Format("2006-01-02")),
+	}
+	for _, c := range cols {
+		fv, ok := r.FieldValues[c.Key]
+		if !ok || fv.Value == nil {
+			cells = append(cells, textCell(""))
+			if confidence {
+				cells = append(cells, textCell(""))
+			}
+			continue
+		}
+		if n, isNum := toFloat(fv.Value); isNum && (c.DataType == "integer" || c.DataType == "float") {
+			cells = append(cells, numCell(n))
+		} else {
+			cells = append(cells, textCell(formatSheetValue(fv.Value)))
+		}
+		if confidence {
+			cells = append(cells, numCell(fv.Confidence))
+		}
+	}
+	return cells
+}
+
+// formatSheetValue renders lists as "; "-joined text and everything else
+// with %v.
+func formatSheetValue(v any) string {
+	switch x := v.(type) {
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			parts = append(parts, fmt.Sprintf("%v", e))
+		}
+		return strings.Join(parts, "; ")
+	case []string:
+		return strings.Join(x, "; ")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// WriteSheet writes rows to w as CSV or XLSX with the given field columns.
+func WriteSheet(w io.Writer, format string, rows []ExportRow, cols []model.FieldMapping, confidence bool) error {
+	header := sheetHeader(cols, confidence)
+	switch format {
+	case SheetFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return eris.Wrap(err, "export: write header")
+		}
+		for _, r := range rows {
+			cells := sheetRow(r, cols, confidence)
+			rec := make([]string, len(cells))
+			for i, c := range cells {
+				rec[i] = c.text
+			}
+			if err := cw.Write(rec); err != nil {
+				return eris.Wrap(err, "export: write row")
+			}
+		}
+		cw.Flush()
+		return eris.Wrap(cw.Error(), "export: flush csv")
+	case SheetFormatXLSX:
+		file := xlsx.NewFile()
+		sheet, err := file.AddSheet("Companies")
+		if err != nil {
+			return eris.Wrap(err, "export: add sheet")
+		}
+		hr := sheet.AddRow()
+		for _, h := range header {
+			hr.AddCell().SetString(h)
+		}
+		for _, r := range rows {
+			xr := sheet.AddRow()
+			for _, c := range sheetRow(r, cols, confidence) {
+				cell := xr.AddCell()
+				if c.num != nil {
+					cell.SetFloat(*c.num)
+				} else {
+					cell.SetString(c.text)
+				}
+			}
+		}
+		return eris.Wrap(file.Write(w), "export: write xlsx")
+	default:
+		return eris.Errorf("export: unknown format %q (want csv or xlsx)", format)
+	}
+}
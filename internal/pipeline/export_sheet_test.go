@@ -0,0 +1,144 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx/v2"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func exportTestRegistry() *model.FieldRegistry {
+	return model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "review_count", SFField: "Review_Count__c", DataType: "integer"},
+		{Key: "naics_code", SFField: "NAICS__c", DataType: "string"},
+		{Key: "hq_state", SFField: "BillingState", DataType: "string"},
+	})
+}
+
+func exportTestRuns() []model.Run {
+	t0 := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	answers := func(emp float64, naics, state string) []model.ExtractionAnswer {
+		return []model.ExtractionAnswer{
+			{FieldKey: "review_count", Value: emp, Confidence: 0.8},
+			{FieldKey: "naics_code", Value: naics, Confidence: 0.7},
+			{FieldKey: "hq_state", Value: state, Confidence: 0.9},
+		}
+	}
+	return []model.Run{
+		{ID: "old", Company: model.Company{Name: "Acme", URL: "https://acme.com"}, Status: model.RunStatusComplete,
+			CreatedAt: t0, Result: &model.RunResult{Score: 0.4, Answers: answers(10, "238220", "TX")}},
+		{ID: "new", Company: model.Company{Name: "Acme", URL: "https://acme.com/"}, Status: model.RunStatusComplete,
+			CreatedAt: t0.Add(24 * time.Hour), Result: &model.RunResult{Score: 0.8, Answers: answers(45, "238220", "TX")}},
+		{ID: "beta", Company: model.Company{Name: "Beta", URL: "https://beta.com", State: "OK"}, Status: model.RunStatusComplete,
+			CreatedAt: t0, Result: &model.RunResult{Score: 0.6, Answers: answers(12, "541511", "TX")}},
+		{ID: "failed", Company: model.Company{Name: "Gamma", URL: "https://gamma.com"}, Status: model.RunStatusFailed, CreatedAt: t0},
+	}
+}
+
+func TestBuildExportRows(t *testing.T) {
+	rows := BuildExportRows(exportTestRuns(), exportTestRegistry())
+	require.Len(t, rows, 2)
+	assert.Equal(t, "new", rows[0].RunID, "latest run per URL wins")
+	assert.Equal(t, "beta", rows[1].RunID)
+	assert.InDelta(t, 45.0, rows[0].FieldValues["review_count"].Value, 1e-9)
+}
+
+func TestParseSegment(t *testing.T) {
+	seg, err := ParseSegment(" state=TX, score>=0.5 ,naics_code~2382,review_count!=0")
+	require.NoError(t, err)
+	require.Len(t, seg.terms, 4)
+	assert.Equal(t, segmentTerm{key: "score", op: ">=", value: "0.5"}, seg.terms[1])
+	assert.Equal(t, segmentTerm{key: "review_count", op: "!=", value: "0"}, seg.terms[3])
+
+	for _, bad := range []string{"state", "=TX", "score!0.5"} {
+		_, err := ParseSegment(bad)
+		assert.Error(t, err, bad)
+	}
+
+	empty, err := ParseSegment("")
+	require.NoError(t, err)
+	assert.True(t, empty.Match(ExportRow{}))
+}
+
+func TestSegment_Filter(t *testing.T) {
+	rows := BuildExportRows(exportTestRuns(), exportTestRegistry())
+	filter := func(s string) []string {
+		seg, err := ParseSegment(s)
+		require.NoError(t, err)
+		var ids []string
+		for _, r := range seg.Filter(rows) {
+			ids = append(ids, r.RunID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"new"}, filter("state=tx"), "company state wins over hq_state")
+	assert.Equal(t, []string{"new"}, filter("score>0.6"))
+	assert.Equal(t, []string{"new", "beta"}, filter("review_count>=12"))
+	assert.Equal(t, []string{"beta"}, filter("naics_code~5415"))
+	assert.Equal(t, []string{"beta"}, filter("name!=acme"))
+	assert.Empty(t, filter("revenue_range>A"), "ordering against a missing value")
+}
+
+func TestExportColumns(t *testing.T) {
+	fields := exportTestRegistry()
+	cols, err := ExportColumns(fields, nil)
+	require.NoError(t, err)
+	assert.Len(t, cols, 3)
+
+	cols, err = ExportColumns(fields, []string{"naics_code"})
+	require.NoError(t, err)
+	require.Len(t, cols, 1)
+	assert.Equal(t, "naics_code", cols[0].Key)
+
+	_, err = ExportColumns(fields, []string{"naics_code", "nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope")
+}
+
+func TestWriteSheet_CSV(t *testing.T) {
+	fields := exportTestRegistry()
+	rows := BuildExportRows(exportTestRuns(), fields)
+	cols, err := ExportColumns(fields, []string{"review_count", "naics_code"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSheet(&buf, SheetFormatCSV, rows, cols, true))
+
+	recs, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, recs, 3)
+	assert.Equal(t, []string{"Company", "URL", "Salesforce ID", "Location", "Score", "Enriched At",
+		"review_count", "review_count confidence", "naics_code", "naics_code confidence"}, recs[0])
+	assert.Equal(t, []string{"Acme", "https://acme.com/", "", "", "0.8", "2026-09-02", "45", "0.8", "238220", "0.7"}, recs[1])
+}
+
+func TestWriteSheet_XLSX(t *testing.T) {
+	fields := exportTestRegistry()
+	rows := BuildExportRows(exportTestRuns(), fields)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSheet(&buf, SheetFormatXLSX, rows, fields.Fields, false))
+
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, f.Sheets, 1)
+	sheet := f.Sheets[0]
+	require.Len(t, sheet.Rows, 3)
+	assert.Len(t, sheet.Rows[0].Cells, len(exportBaseHeader)+3)
+	n, err := sheet.Rows[1].Cells[6].Int()
+	require.NoError(t, err)
+	assert.Equal(t, 45, n)
+	assert.Equal(t, "Beta", sheet.Rows[2].Cells[0].String())
+}
+
+func TestWriteSheet_UnknownFormat(t *testing.T) {
+	err := WriteSheet(&bytes.Buffer{}, "pdf", nil, nil, false)
+	require.Error(t, err)
+}
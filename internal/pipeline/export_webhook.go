@@ -27,7 +27,7 @@ func (e *WebhookExporter) ExportResult(ctx context.Context, result *model.Enrich
 	if gate.Passed || e.webhookURL == "" {
 		return nil
 	}
-	if err := sendToToolJet(ctx, result, e.webhookURL); err != nil {
+	if err := sendToToolJet(ctx, result, gate.SFDiff, e.webhookURL); err != nil {
 		zap.L().Warn("exporter: webhook failed",
 			zap.String("company", result.Company.Name),
 			zap.Error(err),
@@ -511,6 +511,28 @@ func TestNotionExporter_BothCallsFail(t *testing.T) {
 	notionClient.AssertExpectations(t)
 }
 
+func TestNotionExporter_WritesPendingChanges(t *testing.T) {
+	ctx := context.Background()
+
+	notionClient := notionmocks.NewMockClient(t)
+	// Status update + pending changes update.
+	notionClient.On("UpdatePage", mock.Anything, "page-123", mock.Anything).Return(nil, nil).Twice()
+
+	exp := NewNotionExporter(notionClient)
+
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme", NotionPageID: "page-123"},
+	}
+	gate := &GateResult{
+		Passed: false,
+		SFDiff: []FieldChange{{SFField: "Phone", Old: "555-0000", New: "555-1234"}},
+	}
+
+	err := exp.ExportResult(ctx, result, gate)
+	assert.NoError(t, err)
+	notionClient.AssertExpectations(t)
+}
+
 func TestNotionExporter_Flush(t *testing.T) {
 	ctx := context.Background()
 	exp := NewNotionExporter(nil)
@@ -575,6 +597,34 @@ func TestWebhookExporter_FiresOnFailedGate(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWebhookExporter_IncludesSFDiff(t *testing.T) {
+	ctx := context.Background()
+
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	exp := NewWebhookExporter(ts.URL)
+
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme"},
+	}
+	gate := &GateResult{
+		Passed: false,
+		SFDiff: []FieldChange{{SFField: "Phone", Old: nil, New: "555-1234"}},
+	}
+
+	err := exp.ExportResult(ctx, result, gate)
+	require.NoError(t, err)
+	require.Contains(t, body, "sf_diff")
+	diff := body["sf_diff"].([]any)
+	require.Len(t, diff, 1)
+	assert.Equal(t, "Phone", diff[0].(map[string]any)["sf_field"])
+}
+
 func TestWebhookExporter_WebhookError(t *testing.T) {
 	ctx := context.Background()
 
@@ -23,13 +23,25 @@ var webhookClient = &http.Client{Timeout: 10 * time.Second}
 
 // GateResult holds the outcome of the quality gate phase.
 type GateResult struct {
-	Score           float64        `json:"score"`
-	ScoreBreakdown  ScoreBreakdown `json:"score_breakdown"`
-	Passed          bool           `json:"passed"`
-	SFUpdated       bool           `json:"sf_updated"`
-	DedupMatch      bool           `json:"dedup_match"`
-	ManualReview    bool           `json:"manual_review"`
-	MissingRequired []string       `json:"missing_required,omitempty"`
+	Score           float64           `json:"score"`
+	ScoreBreakdown  ScoreBreakdown    `json:"score_breakdown"`
+	Passed          bool              `json:"passed"`
+	SFUpdated       bool              `json:"sf_updated"`
+	DedupMatch      bool              `json:"dedup_match"`
+	ManualReview    bool              `json:"manual_review"`
+	MissingRequired []string          `json:"missing_required,omitempty"`
+	SFDiff          []FieldChange     `json:"sf_diff,omitempty"`
+	Litigation      *model.Litigation `json:"litigation,omitempty"`
+	Exclusions      *model.Exclusions `json:"exclusions,omitempty"`
+}
+
+// LitigationOpen returns the number of open federal dockets naming the
+// company as a defendant.
+func (g *GateResult) LitigationOpen() int {
+	if g.Litigation == nil {
+		return 0
+	}
+	return g.Litigation.Open
 }
 
 // ComputeGateResult evaluates the quality gate as a pure scoring function with
@@ -64,6 +76,30 @@ func ComputeGateResult(result *model.EnrichmentResult, fields *model.FieldRegist
 		)
 	}
 
+	// Open federal litigation does not fail the gate but routes the
+	// company to manual review.
+	if result.Litigation.Flagged() {
+		gate.Litigation = result.Litigation
+		if result.Litigation.Open > 0 {
+			gate.ManualReview = true
+			zap.L().Warn("gate: open litigation, flagging for manual review",
+				zap.Int("open", result.Litigation.Open),
+				zap.String("company", result.Company.Name),
+			)
+		}
+	}
+
+	// An active SAM.gov exclusion (debarment or suspension) routes the
+	// company to manual review.
+	if result.Exclusions.Flagged() {
+		gate.Exclusions = result.Exclusions
+		gate.ManualReview = true
+		zap.L().Warn("gate: active SAM.gov exclusion, flagging for manual review",
+			zap.Int("exclusions", len(result.Exclusions.Records)),
+			zap.String("company", result.Company.Name),
+		)
+	}
+
 	return gate
 }
 
@@ -142,6 +178,45 @@ func injectGeoFields(fields map[string]any, gd *model.GeoData) {
 	if gd.CountyFIPS != "" {
 		fields["County_FIPS__c"] = gd.CountyFIPS
 	}
+	if gd.WildfireScore != 0 {
+		fields["Wildfire_Risk_Score__c"] = gd.WildfireScore
+	}
+	if gd.TransitScore != 0 {
+		fields["Transit_Access_Score__c"] = gd.TransitScore
+	}
+	if gd.OpportunityZone {
+		fields["Opportunity_Zone__c"] = true
+	}
+	if gd.NMTCEligible {
+		fields["NMTC_Eligible__c"] = true
+	}
+	if gd.WalkabilityIndex != 0 {
+		fields["Walkability_Index__c"] = gd.WalkabilityIndex
+	}
+	if gd.IntersectionDensity != 0 {
+		fields["Intersection_Density__c"] = gd.IntersectionDensity
+	}
+	if gd.EmploymentDensity != 0 {
+		fields["Employment_Density__c"] = gd.EmploymentDensity
+	}
+	if gd.AirportKM != 0 {
+		fields["Distance_to_Airport_km__c"] = gd.AirportKM
+	}
+	if gd.NearestAirport != "" {
+		fields["Nearest_Airport__c"] = gd.NearestAirport
+	}
+	if gd.HospitalKM != 0 {
+		fields["Distance_to_Hospital_km__c"] = gd.HospitalKM
+	}
+	if gd.CompetitorKM != 0 {
+		fields["Distance_to_Competitor_km__c"] = gd.CompetitorKM
+	}
+	if gd.NearestCompetitor != "" {
+		fields["Nearest_Competitor__c"] = gd.NearestCompetitor
+	}
+	if gd.InterstateKM != 0 {
+		fields["Distance_to_Interstate_km__c"] = gd.InterstateKM
+	}
 }
 
 // ensureMinimumSFFields sets Name and Website from the Company if not already
@@ -195,8 +270,15 @@ func writeNotionSalesforceID(ctx context.Context, client notion.Client, pageID,
 	return nil
 }
 
-func sendToToolJet(ctx context.Context, result *model.EnrichmentResult, webhookURL string) error {
-	payload, err := json.Marshal(result)
+// toolJetPayload is the manual-review webhook body: the full enrichment
+// result plus the field-level diff against current Salesforce values.
+type toolJetPayload struct {
+	*model.EnrichmentResult
+	SFDiff []FieldChange `json:"sf_diff,omitempty"`
+}
+
+func sendToToolJet(ctx context.Context, result *model.EnrichmentResult, changes []FieldChange, webhookURL string) error {
+	payload, err := json.Marshal(toolJetPayload{EnrichmentResult: result, SFDiff: changes})
 	if err != nil {
 		return eris.Wrap(err, "gate: marshal tooljet payload")
 	}
@@ -251,6 +333,32 @@ func updateNotionStatus(ctx context.Context, client notion.Client, pageID, statu
 	return nil
 }
 
+// writeNotionPendingChanges records the proposed Salesforce diff on the Lead
+// Tracker page so reviewers can see what the gate will change.
+func writeNotionPendingChanges(ctx context.Context, client notion.Client, pageID string, changes []FieldChange) error {
+	// Notion caps a single rich text object at 2000 characters.
+	const maxLen = 2000
+	text := FormatSFDiff(changes)
+	if r := []rune(text); len(r) > maxLen {
+		text = string(r[:maxLen-1]) + "…"
+	}
+
+	_, err := client.UpdatePage(ctx, pageID, &notionapi.PageUpdateRequest{
+		Properties: notionapi.Properties{
+			"Pending Changes": notionapi.RichTextProperty{
+				Type: notionapi.PropertyTypeRichText,
+				RichText: []notionapi.RichText{
+					{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: text}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return eris.Wrap(err, fmt.Sprintf("gate: write pending changes to notion page %s", pageID))
+	}
+	return nil
+}
+
 // extractContactsForSF builds up to 3 SF Contact field maps from the contacts
 // FieldValue. Returns nil if no contacts field is found or it's empty.
 func extractContactsForSF(fieldValues map[string]model.FieldValue, _ *model.FieldRegistry) []map[string]any {
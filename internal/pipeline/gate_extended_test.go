@@ -22,7 +22,7 @@ func TestSendToToolJet_ErrorStatus(t *testing.T) {
 		Company: model.Company{Name: "Test"},
 	}
 
-	err := sendToToolJet(context.Background(), result, ts.URL)
+	err := sendToToolJet(context.Background(), result, nil, ts.URL)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tooljet returned status 500")
 }
@@ -38,7 +38,7 @@ func TestSendToToolJet_Success(t *testing.T) {
 		Company: model.Company{Name: "Test"},
 	}
 
-	err := sendToToolJet(context.Background(), result, ts.URL)
+	err := sendToToolJet(context.Background(), result, nil, ts.URL)
 	assert.NoError(t, err)
 }
 
@@ -47,7 +47,7 @@ func TestSendToToolJet_ConnectionError(t *testing.T) {
 		Company: model.Company{Name: "Test"},
 	}
 
-	err := sendToToolJet(context.Background(), result, "http://localhost:1/bad")
+	err := sendToToolJet(context.Background(), result, nil, "http://localhost:1/bad")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "tooljet request failed")
 }
@@ -95,7 +95,7 @@ func TestSendToToolJet_Timeout(t *testing.T) {
 	}
 
 	start := time.Now()
-	err := sendToToolJet(context.Background(), result, ts.URL)
+	err := sendToToolJet(context.Background(), result, nil, ts.URL)
 	elapsed := time.Since(start)
 
 	assert.Error(t, err)
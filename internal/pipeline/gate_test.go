@@ -1366,14 +1366,27 @@ func TestInjectGeoFields_Nil(t *testing.T) {
 func TestInjectGeoFields_AllFields(t *testing.T) {
 	fields := make(map[string]any)
 	gd := &model.GeoData{
-		Latitude:       32.7767,
-		Longitude:      -96.797,
-		MSAName:        "Dallas-Fort Worth",
-		CBSACode:       "19100",
-		Classification: "Metropolitan",
-		CentroidKM:     5.2,
-		EdgeKM:         12.8,
-		CountyFIPS:     "48113",
+		Latitude:            32.7767,
+		Longitude:           -96.797,
+		MSAName:             "Dallas-Fort Worth",
+		CBSACode:            "19100",
+		Classification:      "Metropolitan",
+		CentroidKM:          5.2,
+		EdgeKM:              12.8,
+		CountyFIPS:          "48113",
+		WildfireScore:       41.5,
+		TransitScore:        22.5,
+		OpportunityZone:     true,
+		NMTCEligible:        true,
+		WalkabilityIndex:    15.2,
+		IntersectionDensity: 42.5,
+		EmploymentDensity:   8.3,
+		AirportKM:           12.4,
+		NearestAirport:      "DALLAS LOVE FIELD",
+		HospitalKM:          3.2,
+		CompetitorKM:        0.4,
+		NearestCompetitor:   "Smith & Co CPAs",
+		InterstateKM:        1.8,
 	}
 
 	injectGeoFields(fields, gd)
@@ -1386,6 +1399,19 @@ func TestInjectGeoFields_AllFields(t *testing.T) {
 	assert.Equal(t, 5.2, fields["Distance_to_MSA_Center_km__c"])
 	assert.Equal(t, 12.8, fields["Distance_to_MSA_Edge_km__c"])
 	assert.Equal(t, "48113", fields["County_FIPS__c"])
+	assert.Equal(t, 41.5, fields["Wildfire_Risk_Score__c"])
+	assert.Equal(t, 22.5, fields["Transit_Access_Score__c"])
+	assert.Equal(t, true, fields["Opportunity_Zone__c"])
+	assert.Equal(t, true, fields["NMTC_Eligible__c"])
+	assert.Equal(t, 15.2, fields["Walkability_Index__c"])
+	assert.Equal(t, 42.5, fields["Intersection_Density__c"])
+	assert.Equal(t, 8.3, fields["Employment_Density__c"])
+	assert.Equal(t, 12.4, fields["Distance_to_Airport_km__c"])
+	assert.Equal(t, "DALLAS LOVE FIELD", fields["Nearest_Airport__c"])
+	assert.Equal(t, 3.2, fields["Distance_to_Hospital_km__c"])
+	assert.Equal(t, 0.4, fields["Distance_to_Competitor_km__c"])
+	assert.Equal(t, "Smith & Co CPAs", fields["Nearest_Competitor__c"])
+	assert.Equal(t, 1.8, fields["Distance_to_Interstate_km__c"])
 }
 
 func TestInjectGeoFields_PartialData(t *testing.T) {
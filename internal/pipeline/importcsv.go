@@ -0,0 +1,184 @@
+package pipeline
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// importColumnAliases maps the accepted header spellings (lower-cased) for
+// each import column. Conference and purchased lists rarely agree on names.
+var importColumnAliases = map[string][]string{
+	"name":     {"name", "company", "company name", "account name"},
+	"url":      {"url", "website", "domain", "company website"},
+	"sf_id":    {"sf id", "sf_id", "salesforce id", "salesforceid", "account id"},
+	"location": {"location", "hq location", "city, state"},
+}
+
+// sfIDPattern matches 15- or 18-character Salesforce record IDs.
+var sfIDPattern = regexp.MustCompile(`^[a-zA-Z0-9]{15}([a-zA-Z0-9]{3})?$`)
+
+// ImportIssue describes a CSV row rejected during import validation. Line
+// is the 1-based line number in the file (the header is line 1).
+type ImportIssue struct {
+	Line   int    `json:"line"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ParseImportCSV reads a company list for batch import. Rows need a name
+// and a website URL; a Salesforce ID and location are optional. Invalid
+// rows and duplicate URLs are returned as issues rather than failing the
+// import.
+func ParseImportCSV(csvPath string) ([]model.Company, []ImportIssue, error) {
+	f, err := os.Open(csvPath) // #nosec G304 -- path from CLI flag
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "import csv: open")
+	}
+	defer f.Close() //nolint:errcheck
+
+	return parseImportCSV(f)
+}
+
+func parseImportCSV(r io.Reader) ([]model.Company, []ImportIssue, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, eris.New("import csv: file is empty")
+	}
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "import csv: read header")
+	}
+
+	colIdx := importColumns(header)
+	for _, col := range []string{"name", "url"} {
+		if _, ok := colIdx[col]; !ok {
+			return nil, nil, eris.Errorf("import csv: missing %s column (accepted headers: %s)",
+				col, strings.Join(importColumnAliases[col], ", "))
+		}
+	}
+
+	var (
+		companies []model.Company
+		issues    []ImportIssue
+		seen      = make(map[string]int)
+	)
+	line := 1
+	for {
+		row, rErr := reader.Read()
+		if rErr == io.EOF {
+			break
+		}
+		line++
+		if rErr != nil {
+			return nil, nil, eris.Wrapf(rErr, "import csv: read line %d", line)
+		}
+
+		get := func(col string) string {
+			i, ok := colIdx[col]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+		name := strings.Trim(get("name"), `"`)
+		website := get("url")
+		sfID := get("sf_id")
+
+		if name == "" && website == "" && sfID == "" {
+			continue // blank line
+		}
+
+		reject := func(reason string) {
+			issues = append(issues, ImportIssue{Line: line, Name: name, Reason: reason})
+		}
+		if name == "" {
+			reject("missing name")
+			continue
+		}
+		u, ok := validImportURL(website)
+		if !ok {
+			if website == "" {
+				reject("missing URL")
+			} else {
+				reject(fmt.Sprintf("invalid URL %q", website))
+			}
+			continue
+		}
+		if sfID != "" && !sfIDPattern.MatchString(sfID) {
+			reject(fmt.Sprintf("invalid Salesforce ID %q (want 15 or 18 alphanumeric characters)", sfID))
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(u.Hostname(), "www."))
+		if first, dup := seen[key]; dup {
+			reject(fmt.Sprintf("duplicate of line %d (%s)", first, key))
+			continue
+		}
+		seen[key] = line
+
+		c := model.Company{
+			Name:         name,
+			URL:          u.String(),
+			SalesforceID: sfID,
+			Location:     get("location"),
+			InputMode:    model.InputModeMinimal,
+		}
+		if c.Location != "" {
+			c.InputMode = model.InputModeStandard
+		}
+		companies = append(companies, c)
+	}
+
+	return companies, issues, nil
+}
+
+// importColumns resolves header positions for each import column, first
+// matching alias wins.
+func importColumns(header []string) map[string]int {
+	idx := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		for col, aliases := range importColumnAliases {
+			if _, done := idx[col]; done {
+				continue
+			}
+			for _, a := range aliases {
+				if h == a {
+					idx[col] = i
+					break
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// validImportURL normalizes a website and checks it looks like a public
+// http(s) URL with a dotted host.
+func validImportURL(website string) (*url.URL, bool) {
+	if website == "" || strings.ContainsAny(website, " \t") {
+		return nil, false
+	}
+	u, err := url.Parse(normalizeWebsite(website))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, false
+	}
+	host := u.Hostname()
+	if !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") {
+		return nil, false
+	}
+	return u, true
+}
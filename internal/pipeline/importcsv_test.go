@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	in := "\ufeffCompany Name,Website,Salesforce ID,Location\n" +
+		"Acme Corp,acme.com,001000000000001AAA,\"Austin, TX\"\n" +
+		"Beta LLC,https://www.beta.io/about,,\n" +
+		",gamma.com,,\n" +
+		"Delta,not a url,,\n" +
+		"Epsilon,epsilon.com,BAD,\n" +
+		"Acme Again,https://www.acme.com,,\n" +
+		",,,\n" +
+		"Zeta,localhost,,\n"
+
+	companies, issues, err := parseImportCSV(strings.NewReader(in))
+	require.NoError(t, err)
+
+	require.Len(t, companies, 2)
+	assert.Equal(t, model.Company{
+		Name:         "Acme Corp",
+		URL:          "https://acme.com",
+		SalesforceID: "001000000000001AAA",
+		Location:     "Austin, TX",
+		InputMode:    model.InputModeStandard,
+	}, companies[0])
+	assert.Equal(t, "https://www.beta.io/about", companies[1].URL)
+	assert.Equal(t, model.InputModeMinimal, companies[1].InputMode)
+
+	require.Len(t, issues, 5)
+	assert.Equal(t, ImportIssue{Line: 4, Reason: "missing name"}, issues[0])
+	assert.Contains(t, issues[1].Reason, "invalid URL")
+	assert.Contains(t, issues[2].Reason, "invalid Salesforce ID")
+	assert.Equal(t, 7, issues[3].Line)
+	assert.Equal(t, "duplicate of line 2 (acme.com)", issues[3].Reason)
+	assert.Equal(t, 9, issues[4].Line)
+}
+
+func TestParseImportCSV_MissingColumn(t *testing.T) {
+	_, _, err := parseImportCSV(strings.NewReader("Name,City\nAcme,Austin\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing url column")
+
+	_, _, err = parseImportCSV(strings.NewReader(""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "empty")
+}
+
+func TestParseImportCSV_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "companies.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,url\nAcme,acme.com\n"), 0o600))
+
+	companies, issues, err := ParseImportCSV(path)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	require.Len(t, companies, 1)
+	assert.Equal(t, "https://acme.com", companies[0].URL)
+
+	_, _, err = ParseImportCSV(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
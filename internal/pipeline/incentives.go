@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// pointTractsCTE defines tracts(geoid, vintage): the 2020 census tract
+// containing ($1, $2) from geo.census_tracts, and the 2010 tract it shares
+// the most land with in geo.tract_relationships. Tract lists keyed by 2010
+// geoids join on the 2010 row.
+const pointTractsCTE = `
+	WITH tract AS (
+		SELECT t.geoid FROM geo.census_tracts t
+		WHERE ST_Contains(t.geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+		LIMIT 1
+	), tracts AS (
+		SELECT geoid, 2020 AS vintage FROM tract
+		UNION ALL
+		(SELECT r.tract_2010, 2010 FROM geo.tract_relationships r JOIN tract ON r.tract_2020 = tract.geoid
+		 ORDER BY r.arealand_part DESC LIMIT 1)
+	)`
+
+// incentiveTractsSQL reports whether the census tract containing ($1, $2)
+// is a designated Opportunity Zone and whether it is NMTC-eligible. Each
+// program's list is matched in its own tract vintage: 2010 for QOZ, 2020
+// for NMTC.
+const incentiveTractsSQL = pointTractsCTE + `
+	SELECT COALESCE(bool_or(i.program = 'qoz'), false), COALESCE(bool_or(i.program = 'nmtc'), false)
+	FROM geo.incentive_tracts i
+	JOIN tracts t ON t.geoid = i.geoid AND t.vintage = i.tract_vintage`
+
+// LookupIncentiveTracts reports whether the geocoded location falls in a
+// Qualified Opportunity Zone or an NMTC-eligible tract, from
+// geo.incentive_tracts. Both are false when geo data is missing.
+func LookupIncentiveTracts(ctx context.Context, pool db.Pool, gd *model.GeoData) (qoz, nmtc bool, err error) {
+	if pool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return false, false, nil
+	}
+	if err := pool.QueryRow(ctx, incentiveTractsSQL, gd.Longitude, gd.Latitude).Scan(&qoz, &nmtc); err != nil {
+		return false, false, eris.Wrap(err, "incentives: query incentive_tracts")
+	}
+	return qoz, nmtc, nil
+}
+
+// applyIncentiveTracts sets the Opportunity Zone and NMTC flags on geo
+// data collected in Phase 7D. Lookup failures are logged and leave the
+// flags unset.
+func (p *Pipeline) applyIncentiveTracts(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil {
+		return
+	}
+	qoz, nmtc, err := LookupIncentiveTracts(ctx, p.fedsyncPool, gd)
+	if err != nil {
+		zap.L().Warn("pipeline: incentive tract lookup failed", zap.Error(err))
+		return
+	}
+	gd.OpportunityZone = qoz
+	gd.NMTCEligible = nmtc
+}
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupIncentiveTracts(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	gd := &model.GeoData{Latitude: 30.2672, Longitude: -97.7431}
+	// QOZ tracts are 2010 geoids, reached through the relationship file.
+	pool.ExpectQuery(`geo.tract_relationships r JOIN tract .*FROM geo.incentive_tracts i\s+JOIN tracts t ON t.geoid = i.geoid AND t.vintage = i.tract_vintage`).
+		WithArgs(-97.7431, 30.2672).
+		WillReturnRows(pgxmock.NewRows([]string{"qoz", "nmtc"}).AddRow(true, true))
+	pool.ExpectQuery("FROM geo.incentive_tracts").
+		WithArgs(-97.7431, 30.2672).
+		WillReturnRows(pgxmock.NewRows([]string{"qoz", "nmtc"}).AddRow(false, true))
+	pool.ExpectQuery("FROM geo.incentive_tracts").
+		WithArgs(-97.7431, 30.2672).
+		WillReturnError(errors.New("connection reset"))
+
+	qoz, nmtc, err := LookupIncentiveTracts(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.True(t, qoz)
+	assert.True(t, nmtc)
+
+	qoz, nmtc, err = LookupIncentiveTracts(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.False(t, qoz)
+	assert.True(t, nmtc)
+
+	_, _, err = LookupIncentiveTracts(context.Background(), pool, gd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "incentives: query incentive_tracts")
+
+	// No location: no query.
+	qoz, nmtc, err = LookupIncentiveTracts(context.Background(), pool, &model.GeoData{CountyFIPS: "48453"})
+	require.NoError(t, err)
+	assert.False(t, qoz)
+	assert.False(t, nmtc)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync/resolve"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// litigationMaxDockets caps the dockets attached to a result.
+const litigationMaxDockets = 10
+
+// litigationSQL finds dockets filed since $2 naming any of the normalized
+// company names $1 as a defendant. Open dockets sort first.
+const litigationSQL = `
+	SELECT docket_id, court_id, COALESCE(docket_number, ''), case_name,
+		COALESCE(nature_of_suit, ''), date_filed, date_terminated, COALESCE(absolute_url, '')
+	FROM fed_data.court_dockets
+	WHERE defendant_norms && $1::text[] AND date_filed >= $2
+	ORDER BY date_terminated IS NOT NULL, date_filed DESC
+	LIMIT $3`
+
+// LookupLitigation finds federal court dockets (fed_data.court_dockets)
+// filed within the lookback window that name the company, by its name or
+// legalName, as a defendant. Returns nil when no pool is available and an
+// empty result when the company has no name.
+func LookupLitigation(ctx context.Context, pool db.Pool, company model.Company, legalName string, cfg config.LitigationConfig) (*model.Litigation, error) {
+	if pool == nil {
+		return nil, nil
+	}
+
+	lit := &model.Litigation{}
+	var norms []string
+	for _, n := range []string{company.Name, legalName} {
+		if norm := resolve.NormalizeName(n); norm != "" && !slices.Contains(norms, norm) {
+			norms = append(norms, norm)
+		}
+	}
+	if len(norms) == 0 {
+		return lit, nil
+	}
+
+	since := time.Now().AddDate(-cfg.LookbackYears, 0, 0)
+	rows, err := pool.Query(ctx, litigationSQL, norms, since, litigationMaxDockets)
+	if err != nil {
+		return nil, eris.Wrap(err, "litigation: query court dockets")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d model.LitigationDocket
+		if err := rows.Scan(&d.DocketID, &d.Court, &d.DocketNumber, &d.CaseName,
+			&d.NatureOfSuit, &d.DateFiled, &d.DateTerminated, &d.URL); err != nil {
+			return nil, eris.Wrap(err, "litigation: scan court docket")
+		}
+		if d.DateTerminated == nil {
+			lit.Open++
+		}
+		lit.Dockets = append(lit.Dockets, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "litigation: iterate court dockets")
+	}
+
+	lit.Note = FormatLitigationNote(lit, cfg.LookbackYears)
+	return lit, nil
+}
+
+// FormatLitigationNote summarizes matched dockets. It returns "" when
+// nothing matched.
+func FormatLitigationNote(lit *model.Litigation, lookbackYears int) string {
+	if !lit.Flagged() {
+		return ""
+	}
+	n := len(lit.Dockets)
+	latest := lit.Dockets[0]
+	s := fmt.Sprintf("%d federal %s naming the company as defendant in the last %d years (%d open)",
+		n, plural(n, "case", "cases"), lookbackYears, lit.Open)
+
+	var natures []string
+	seen := make(map[string]bool)
+	for _, d := range lit.Dockets {
+		if d.NatureOfSuit != "" && !seen[d.NatureOfSuit] {
+			seen[d.NatureOfSuit] = true
+			natures = append(natures, d.NatureOfSuit)
+		}
+	}
+	if len(natures) > 0 {
+		s += "; " + strings.Join(natures, ", ")
+	}
+	return s + "; e.g. " + latest.CaseName + "."
+}
+
+// Phase7FLitigation attaches federal court dockets naming the company (or
+// its extracted legal name) as a defendant so the quality gate can route
+// exposed companies to review.
+func (p *Pipeline) Phase7FLitigation(ctx context.Context, company model.Company, fieldValues map[string]model.FieldValue) (*model.Litigation, *model.PhaseResult, error) {
+	legalName := fieldStr(fieldValues, "company_legal_name")
+	lit, err := LookupLitigation(ctx, p.fedsyncPool, company, legalName, p.cfg.Pipeline.Litigation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lit == nil {
+		return nil, &model.PhaseResult{
+			Status:   model.PhaseStatusSkipped,
+			Metadata: map[string]any{"reason": "fedsync_pool_not_available"},
+		}, nil
+	}
+
+	if lit.Flagged() {
+		zap.L().Info("pipeline: litigation exposure flagged",
+			zap.String("company", company.Name),
+			zap.Int("dockets", len(lit.Dockets)),
+			zap.Int("open", lit.Open),
+		)
+	}
+	return lit, &model.PhaseResult{
+		Metadata: map[string]any{
+			"dockets": len(lit.Dockets),
+			"open":    lit.Open,
+		},
+	}, nil
+}
@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+var litigationCols = []string{
+	"docket_id", "court_id", "docket_number", "case_name", "nature_of_suit",
+	"date_filed", "date_terminated", "absolute_url",
+}
+
+func testLitigationConfig() config.LitigationConfig {
+	return config.LitigationConfig{Enabled: true, LookbackYears: 5}
+}
+
+func TestLookupLitigation_NilPool(t *testing.T) {
+	t.Parallel()
+	lit, err := LookupLitigation(context.Background(), nil, model.Company{Name: "Acme"}, "", testLitigationConfig())
+	assert.NoError(t, err)
+	assert.Nil(t, lit)
+}
+
+func TestLookupLitigation(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	filed := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	older := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	closed := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.court_dockets")).
+		WithArgs([]string{"ACME PLUMBING", "ACME PLUMBING SERVICES OF HOUSTON"}, pgxmock.AnyArg(), litigationMaxDockets).
+		WillReturnRows(pgxmock.NewRows(litigationCols).
+			AddRow(int64(101), "txsd", "4:26-cv-00101", "Smith v. Acme Plumbing LLC", "Labor: FLSA",
+				&filed, (*time.Time)(nil), "https://www.courtlistener.com/docket/101/").
+			AddRow(int64(55), "txsd", "4:23-cv-00055", "Jones v. Acme Plumbing LLC", "Labor: FLSA",
+				&older, &closed, ""))
+
+	lit, err := LookupLitigation(context.Background(), pool, model.Company{Name: "Acme Plumbing, LLC"}, "Acme Plumbing Services of Houston LLC", testLitigationConfig())
+	require.NoError(t, err)
+	require.Len(t, lit.Dockets, 2)
+	assert.True(t, lit.Flagged())
+	assert.Equal(t, 1, lit.Open)
+	assert.Equal(t, "2 federal cases naming the company as defendant in the last 5 years (1 open); Labor: FLSA; e.g. Smith v. Acme Plumbing LLC.", lit.Note)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestLookupLitigation_NoNameAndError(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	lit, err := LookupLitigation(context.Background(), pool, model.Company{}, "", testLitigationConfig())
+	require.NoError(t, err)
+	assert.False(t, lit.Flagged())
+	assert.Empty(t, FormatLitigationNote(lit, 5))
+
+	pool.ExpectQuery(regexp.QuoteMeta("FROM fed_data.court_dockets")).
+		WillReturnError(errors.New("relation does not exist"))
+	_, err = LookupLitigation(context.Background(), pool, model.Company{Name: "Acme"}, "", testLitigationConfig())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "litigation: query court dockets")
+}
+
+func TestComputeGateResult_Litigation(t *testing.T) {
+	cfg := &config.Config{Pipeline: config.PipelineConfig{
+		QualityScoreThreshold: 0.3,
+		QualityWeights:        config.QualityWeights{Confidence: 1.0},
+	}}
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme"},
+		Litigation: &model.Litigation{
+			Dockets: []model.LitigationDocket{{DocketID: 101, CaseName: "Smith v. Acme"}},
+			Open:    1,
+		},
+	}
+
+	gate := ComputeGateResult(result, nil, nil, cfg)
+	require.NotNil(t, gate.Litigation)
+	assert.True(t, gate.ManualReview)
+	assert.Equal(t, 1, gate.LitigationOpen())
+
+	result.Litigation.Open = 0
+	gate = ComputeGateResult(result, nil, nil, cfg)
+	assert.NotNil(t, gate.Litigation)
+	assert.False(t, gate.ManualReview, "closed cases are context only")
+
+	result.Litigation = nil
+	gate = ComputeGateResult(result, nil, nil, cfg)
+	assert.Nil(t, gate.Litigation)
+	assert.Zero(t, gate.LitigationOpen())
+}
@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// applyLocationMetrics sets the nearest airport, hospital, competitor, and
+// interstate distances on geo data collected in Phase 7D from
+// geo.location_metrics, computing them for the point when geo metrics has
+// not reached it yet. Lookup failures are logged and leave the distances
+// unset.
+func (p *Pipeline) applyLocationMetrics(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return
+	}
+	var competitors []string
+	if p.cfg != nil {
+		competitors = p.cfg.Geo.LocationMetrics.CompetitorSubcategories
+	}
+	m, err := geospatial.LocationMetricsAt(ctx, p.fedsyncPool, gd.Latitude, gd.Longitude, competitors)
+	if err != nil {
+		zap.L().Warn("pipeline: location metrics lookup failed", zap.Error(err))
+		return
+	}
+	gd.AirportKM = m.AirportKM
+	gd.NearestAirport = m.AirportName
+	gd.HospitalKM = m.HospitalKM
+	gd.CompetitorKM = m.CompetitorKM
+	gd.NearestCompetitor = m.CompetitorName
+	gd.InterstateKM = m.InterstateKM
+}
@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestApplyLocationMetrics(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cols := []string{"airport_km", "airport_name", "hospital_km", "hospital_name",
+		"competitor_km", "competitor_name", "interstate_km", "interstate_name"}
+	airportKM, airport := 12.4, "DALLAS LOVE FIELD"
+	competitorKM, competitor := 0.4, "Smith & Co CPAs"
+	interstateKM := 1.8
+	pool.ExpectQuery("FROM geo.location_metrics").
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(&airportKM, &airport, (*float64)(nil), (*string)(nil),
+			&competitorKM, &competitor, &interstateKM, (*string)(nil)))
+	pool.ExpectQuery("FROM geo.location_metrics").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(errors.New("connection reset"))
+
+	cfg := &config.Config{}
+	cfg.Geo.LocationMetrics.CompetitorSubcategories = []string{"accountant"}
+	p := &Pipeline{fedsyncPool: pool, cfg: cfg}
+
+	gd := &model.GeoData{Latitude: 32.7767, Longitude: -96.797}
+	p.applyLocationMetrics(context.Background(), gd)
+	assert.Equal(t, 12.4, gd.AirportKM)
+	assert.Equal(t, airport, gd.NearestAirport)
+	assert.Zero(t, gd.HospitalKM)
+	assert.Equal(t, 0.4, gd.CompetitorKM)
+	assert.Equal(t, competitor, gd.NearestCompetitor)
+	assert.Equal(t, 1.8, gd.InterstateKM)
+
+	// Lookup errors leave the distances unset.
+	gd = &model.GeoData{Latitude: 32.7767, Longitude: -96.797}
+	p.applyLocationMetrics(context.Background(), gd)
+	assert.Zero(t, gd.AirportKM)
+
+	// No location: no query.
+	p.applyLocationMetrics(context.Background(), &model.GeoData{})
+	p.applyLocationMetrics(context.Background(), nil)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,27 @@
+package pipeline
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/manifest"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// buildManifest stamps a run with the binary, config hash, question pack,
+// models, and — when a fedsync pool is set — the federal dataset vintages
+// the run could read. Vintage lookup failures are logged, not fatal.
+func (p *Pipeline) buildManifest(ctx context.Context, log *zap.Logger) *model.RunManifest {
+	m := manifest.New(p.cfg, p.questions)
+	if p.fedsyncPool == nil {
+		return m
+	}
+	vintages, err := manifest.DatasetVintages(ctx, p.fedsyncPool)
+	if err != nil {
+		log.Warn("pipeline: failed to load dataset vintages", zap.Error(err))
+		return m
+	}
+	m.DatasetVintages = vintages
+	return m
+}
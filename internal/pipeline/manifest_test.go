@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestBuildManifest(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("FROM fed_data.sync_log").
+		WillReturnRows(pgxmock.NewRows([]string{"dataset", "id", "started_at", "metadata"}).
+			AddRow("adv_part1", int64(9), time.Now(), []byte(`{"month":"2026-09"}`)))
+
+	cfg := &config.Config{}
+	cfg.Anthropic.SonnetModel = "sonnet-x"
+	p := &Pipeline{
+		cfg:         cfg,
+		questions:   []model.Question{{ID: "q1"}, {ID: "q2"}},
+		fedsyncPool: pool,
+	}
+
+	m := p.buildManifest(context.Background(), zap.NewNop())
+	require.NotNil(t, m)
+	assert.NotEmpty(t, m.ConfigHash)
+	assert.NotEmpty(t, m.QuestionPack)
+	assert.Equal(t, 2, m.QuestionCount)
+	assert.Equal(t, "sonnet-x", m.Models["sonnet"])
+	require.Contains(t, m.DatasetVintages, "adv_part1")
+	assert.Equal(t, int64(9), m.DatasetVintages["adv_part1"].SyncID)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBuildManifest_VintageError(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectQuery("FROM fed_data.sync_log").WillReturnError(errors.New("connection refused"))
+
+	p := &Pipeline{cfg: &config.Config{}, fedsyncPool: pool}
+	m := p.buildManifest(context.Background(), zap.NewNop())
+	require.NotNil(t, m)
+	assert.Nil(t, m.DatasetVintages)
+}
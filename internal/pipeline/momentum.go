@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// momentumFieldKey is the field registry key that carries the firm momentum
+// score to Salesforce.
+const momentumFieldKey = "momentum"
+
+// LookupMomentum returns the firm's momentum score from
+// fed_data.firm_momentum. Returns nil when the firm has no score.
+func LookupMomentum(ctx context.Context, pool db.Pool, crdNumber int) (*float64, error) {
+	var momentum *float64
+	err := pool.QueryRow(ctx,
+		`SELECT momentum::double precision FROM fed_data.firm_momentum WHERE crd_number = $1`,
+		crdNumber,
+	).Scan(&momentum)
+	if err != nil {
+		// pgx returns no rows as an error; treat as "not found".
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "momentum: query firm_momentum")
+	}
+	return momentum, nil
+}
+
+// ApplyMomentum writes the momentum score into fieldValues when the field
+// registry maps momentum. It reports whether a value was set.
+func ApplyMomentum(fieldValues map[string]model.FieldValue, fields *model.FieldRegistry, momentum *float64) bool {
+	if momentum == nil || fields == nil {
+		return false
+	}
+	fm := fields.ByKey(momentumFieldKey)
+	if fm == nil {
+		return false
+	}
+	fieldValues[momentumFieldKey] = model.FieldValue{
+		FieldKey:   momentumFieldKey,
+		SFField:    fm.SFField,
+		Value:      *momentum,
+		Confidence: 0.8,
+		Source:     "fed_data.firm_momentum",
+		Reasoning:  fmt.Sprintf("Momentum %.3f from AUM, headcount, disclosure, and website change trends", *momentum),
+	}
+	return true
+}
+
+// preSeededCRD returns the CRD number from the company's pre-seeded data,
+// or 0 when absent.
+func preSeededCRD(company model.Company) int {
+	switch n := company.PreSeeded["crd_number"].(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupMomentum(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	score := 0.734
+	pool.ExpectQuery("FROM fed_data.firm_momentum").
+		WithArgs(1001).
+		WillReturnRows(pgxmock.NewRows([]string{"momentum"}).AddRow(&score))
+	pool.ExpectQuery("FROM fed_data.firm_momentum").
+		WithArgs(1002).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectQuery("FROM fed_data.firm_momentum").
+		WithArgs(1003).
+		WillReturnError(errors.New("connection reset"))
+
+	got, err := LookupMomentum(context.Background(), pool, 1001)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.InDelta(t, 0.734, *got, 0.0001)
+
+	got, err = LookupMomentum(context.Background(), pool, 1002)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = LookupMomentum(context.Background(), pool, 1003)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "momentum: query firm_momentum")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestApplyMomentum(t *testing.T) {
+	t.Parallel()
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "momentum", SFField: "Momentum__c"},
+	})
+	score := 0.61
+
+	fv := map[string]model.FieldValue{}
+	assert.True(t, ApplyMomentum(fv, fields, &score))
+	assert.Equal(t, "Momentum__c", fv["momentum"].SFField)
+	assert.Equal(t, 0.61, fv["momentum"].Value)
+
+	// No registry mapping: nothing written.
+	fv = map[string]model.FieldValue{}
+	assert.False(t, ApplyMomentum(fv, model.NewFieldRegistry(nil), &score))
+	assert.Empty(t, fv)
+
+	// No score: nothing written.
+	assert.False(t, ApplyMomentum(fv, fields, nil))
+	assert.Empty(t, fv)
+}
+
+func TestPreSeededCRD(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 123, preSeededCRD(model.Company{PreSeeded: map[string]any{"crd_number": 123}}))
+	assert.Equal(t, 456, preSeededCRD(model.Company{PreSeeded: map[string]any{"crd_number": float64(456)}}))
+	assert.Equal(t, 0, preSeededCRD(model.Company{}))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sells-group/research-cli/internal/estimate"
 	"github.com/sells-group/research-cli/internal/geo"
 	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
 	"github.com/sells-group/research-cli/internal/resilience"
 	"github.com/sells-group/research-cli/internal/scrape"
 	"github.com/sells-group/research-cli/internal/store"
@@ -62,6 +63,10 @@ type Pipeline struct {
 
 	forceReExtract bool
 
+	// shadowQuestions is the candidate question pack for shadow mode
+	// (pipeline.shadow). Empty means shadow re-runs the production pack.
+	shadowQuestions []model.Question
+
 	// Company golden record importer. When set, enrichment results are
 	// persisted to the companies table after Phase 9.
 	companyImporter *companypkg.Importer
@@ -684,16 +689,7 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 	// pool is connected, pre-fill answers from ADV filing data.
 	var advPrefilled []model.ExtractionAnswer
 	if p.fedsyncPool != nil {
-		var crdNumber int
-		if v, ok := company.PreSeeded["crd_number"]; ok {
-			switch n := v.(type) {
-			case int:
-				crdNumber = n
-			case float64:
-				crdNumber = int(n)
-			}
-		}
-		if crdNumber > 0 {
+		if crdNumber := preSeededCRD(company); crdNumber > 0 {
 			prefilled, prefillErr := prefillFromADV(ctx, p.fedsyncPool, crdNumber, questionsForRouting)
 			if prefillErr != nil {
 				log.Warn("pipeline: ADV pre-fill failed", zap.Error(prefillErr))
@@ -762,6 +758,17 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 	}
 	var cumulativeCost float64
 
+	// --- Shadow mode ---
+	// Run the candidate pack/models in parallel with production extraction.
+	// Shadow answers are stored separately and never reach gates or exports.
+	var shadowCh chan shadowOutput
+	if p.shadowEnabled() && !isSourcing {
+		shadowCh = make(chan shadowOutput, 1)
+		go func() {
+			shadowCh <- p.runShadow(ctx, company, pageIndex, pppMatches)
+		}()
+	}
+
 	// ===== Phases 4+5: T1, T2-native, and T2-escalated with max overlap =====
 	// T1 and T2-native start in parallel. Once T1 completes, T2-escalated
 	// starts immediately (overlapping with the still-running T2-native).
@@ -934,6 +941,23 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 		shouldRunT3 = false
 	}
 
+	// Sampling gate: spend Opus only on companies whose pre-score from
+	// T1/T2 answers and federal matches clears the threshold. An explicit
+	// tier3_gate: always (or --with-t3) overrides sampling.
+	var preScore *PreScore
+	if shouldRunT3 && p.cfg.Pipeline.Tier3Gate != "always" && p.cfg.Pipeline.Sampling.Enabled {
+		ps := ComputePreScore(MergeAnswers(t1Answers, t2Answers, nil), company, fedCtx, p.fedsyncPool != nil, p.cfg.Pipeline.Sampling)
+		preScore = &ps
+		if ps.Score < p.cfg.Pipeline.Sampling.Tier3MinScore {
+			shouldRunT3 = false
+			t3SkipReason = "prescore_below_threshold"
+			log.Info("pipeline: skipping T3 due to pre-score",
+				zap.Float64("prescore", ps.Score),
+				zap.Float64("min_score", p.cfg.Pipeline.Sampling.Tier3MinScore),
+			)
+		}
+	}
+
 	// Cost budget gate: skip T3 if cumulative cost exceeds budget.
 	if shouldRunT3 && cumulativeCost >= maxCost {
 		shouldRunT3 = false
@@ -952,11 +976,15 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 			}
 			t3Answers = t3Result.Answers
 			totalUsage.Add(t3Result.TokenUsage)
+			meta := map[string]any{
+				"answers": len(t3Result.Answers),
+			}
+			if preScore != nil {
+				meta["prescore"] = preScore
+			}
 			return &model.PhaseResult{
 				TokenUsage: t3Result.TokenUsage,
-				Metadata: map[string]any{
-					"answers": len(t3Result.Answers),
-				},
+				Metadata:   meta,
 			}, nil
 		})
 	} else {
@@ -967,11 +995,15 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 			}
 		}
 		trackPhase("6_extract_t3", func() (*model.PhaseResult, error) {
+			meta := map[string]any{
+				"reason": t3SkipReason,
+			}
+			if preScore != nil {
+				meta["prescore"] = preScore
+			}
 			return &model.PhaseResult{
-				Status: model.PhaseStatusSkipped,
-				Metadata: map[string]any{
-					"reason": t3SkipReason,
-				},
+				Status:   model.PhaseStatusSkipped,
+				Metadata: meta,
 			}, nil
 		})
 	}
@@ -1044,6 +1076,10 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 		}, nil
 	})
 
+	if shadowCh != nil {
+		p.saveShadowResult(ctx, run.ID, company, MergeAnswers(t1Answers, t2Answers, t3Answers), <-shadowCh, log)
+	}
+
 	result.Answers = allAnswers
 	result.FieldValues = fieldValues
 	if fedCtx != nil {
@@ -1118,11 +1154,66 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 			phaseRes, phaseErr := p.Phase7DGeocode(ctx, company, run.ID)
 			if phaseErr == nil && phaseRes != nil {
 				result.GeoData = p.collectGeoData(ctx, company)
+				p.applySpatialAssignment(ctx, result.GeoData)
+				p.applyRUCA(ctx, result.GeoData)
+				p.applyWildfireScore(ctx, result.GeoData)
+				p.applyTransitScore(ctx, result.GeoData)
+				p.applyIncentiveTracts(ctx, result.GeoData)
+				p.applySmartLocation(ctx, result.GeoData)
+				p.applyLocationMetrics(ctx, result.GeoData)
 			}
 			return phaseRes, phaseErr
 		})
 	}
 
+	// ===== Phase 7E: Environmental/Safety Risk =====
+	if p.cfg.Pipeline.EnvRisk.Enabled && p.fedsyncPool != nil {
+		trackPhase("7e_env_risk", func() (*model.PhaseResult, error) {
+			risk, phaseRes, phaseErr := p.Phase7EEnvRisk(ctx, result.Company, result.GeoData, fieldValues)
+			if phaseErr == nil {
+				result.EnvRisk = risk
+			}
+			return phaseRes, phaseErr
+		})
+	}
+
+	// ===== Phase 7F: Litigation =====
+	if p.cfg.Pipeline.Litigation.Enabled && p.fedsyncPool != nil {
+		trackPhase("7f_litigation", func() (*model.PhaseResult, error) {
+			lit, phaseRes, phaseErr := p.Phase7FLitigation(ctx, result.Company, fieldValues)
+			if phaseErr == nil {
+				result.Litigation = lit
+			}
+			return phaseRes, phaseErr
+		})
+	}
+
+	// ===== Phase 7G: SAM.gov Exclusions =====
+	// Runs for every company headed to the quality gate.
+	if p.fedsyncPool != nil {
+		trackPhase("7g_exclusions", func() (*model.PhaseResult, error) {
+			ex, phaseRes, phaseErr := p.Phase7GExclusions(ctx, result.Company)
+			if phaseErr == nil {
+				result.Exclusions = ex
+			}
+			return phaseRes, phaseErr
+		})
+	}
+
+	// ===== Momentum =====
+	// Copy the firm's momentum score (fed_data.firm_momentum) onto the
+	// Salesforce field when the registry maps it.
+	if p.fedsyncPool != nil && p.fields != nil && p.fields.ByKey(momentumFieldKey) != nil {
+		if crdNumber := preSeededCRD(result.Company); crdNumber > 0 {
+			momentum, momentumErr := LookupMomentum(ctx, p.fedsyncPool, crdNumber)
+			if momentumErr != nil {
+				log.Warn("pipeline: momentum lookup failed", zap.Error(momentumErr))
+			} else {
+				ApplyMomentum(fieldValues, p.fields, momentum)
+			}
+		}
+	}
+
 	// ===== Phase 8: Report =====
 	// Set totalUsage.Cost from per-phase costs so the report shows the correct total.
 	var reportCost float64
@@ -1143,6 +1234,16 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 	trackPhaseWithRetry("9_gate", "salesforce", func() (*model.PhaseResult, error) {
 		gate := ComputeGateResult(result, p.fields, p.questions, p.cfg)
 
+		// Diff the outgoing Account payload against current CRM values so
+		// reviewers see exactly what the write will change.
+		if p.cfg.Pipeline.SFDiff {
+			changes, diffErr := FetchSFDiff(ctx, p.salesforce, result, p.fields)
+			if diffErr != nil {
+				log.Warn("pipeline: salesforce diff failed", zap.Error(diffErr))
+			}
+			gate.SFDiff = changes
+		}
+
 		for _, exp := range p.exporters {
 			if exportErr := exp.ExportResult(ctx, result, gate); exportErr != nil {
 				log.Warn("pipeline: exporter failed",
@@ -1159,6 +1260,9 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 				"passed":           gate.Passed,
 				"missing_required": gate.MissingRequired,
 				"manual_review":    gate.ManualReview,
+				"sf_changes":       len(gate.SFDiff),
+				"litigation_open":  gate.LitigationOpen(),
+				"sam_excluded":     gate.Exclusions.Flagged(),
 			},
 		}, nil
 	})
@@ -1197,6 +1301,7 @@ func (p *Pipeline) Run(ctx context.Context, company model.Company) (*model.Enric
 		Answers:        allAnswers,
 		Report:         result.Report,
 		SalesforceSync: true,
+		Manifest:       p.buildManifest(ctx, log),
 	}
 	if saveErr := p.store.UpdateRunResult(ctx, run.ID, runResult); saveErr != nil {
 		log.Warn("pipeline: failed to save run result", zap.Error(saveErr))
@@ -1254,6 +1359,7 @@ func (p *Pipeline) executePhase(ctx context.Context, runID, name string, fn func
 	if phaseResult.Status != model.PhaseStatusSkipped {
 		phaseResult.TokenUsage.Cost = p.computePhaseCost(name, phaseResult.TokenUsage)
 	}
+	recordPhaseMetrics(phaseResult)
 
 	if phase != nil {
 		if cpErr := p.store.CompletePhase(ctx, phase.ID, phaseResult); cpErr != nil {
@@ -1270,6 +1376,19 @@ func (p *Pipeline) executePhase(ctx context.Context, runID, name string, fn func
 	return phaseResult
 }
 
+// recordPhaseMetrics records a phase's duration, token usage, and cost in
+// the Prometheus metrics.
+func recordPhaseMetrics(ph *model.PhaseResult) {
+	opsmetrics.RecordPhase(ph.Name, string(ph.Status), time.Duration(ph.Duration)*time.Millisecond)
+	u := ph.TokenUsage
+	opsmetrics.RecordLLMUsage(ph.Name, map[string]int{
+		opsmetrics.TokensInput:         u.InputTokens,
+		opsmetrics.TokensOutput:        u.OutputTokens,
+		opsmetrics.TokensCacheCreation: u.CacheCreationTokens,
+		opsmetrics.TokensCacheRead:     u.CacheReadTokens,
+	}, u.Cost)
+}
+
 // computePhaseCost maps a phase name to the correct model and computes cost.
 func (p *Pipeline) computePhaseCost(phase string, usage model.TokenUsage) float64 {
 	var modelName string
@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"strings"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// PreScore is the Tier 3 sampling score computed before Opus runs. Skipped
+// components (no targets configured or no fedsync connection) are absent
+// from Components and excluded from the weighted average.
+type PreScore struct {
+	Score      float64            `json:"score"`
+	Components map[string]float64 `json:"components"`
+}
+
+// employeeFieldKeys are the answer field keys checked, in order, for a
+// headcount.
+var employeeFieldKeys = []string{"employees", "employee_count"}
+
+// ComputePreScore scores a company from its Tier 1/2 answers and federal
+// context. fedAvailable reports whether the fedsync database was consulted;
+// when false the federal component is skipped rather than scored zero.
+func ComputePreScore(answers []model.ExtractionAnswer, company model.Company, fedCtx *FederalContext, fedAvailable bool, cfg config.SamplingConfig) PreScore {
+	components := make(map[string]float64)
+	weights := make(map[string]float64)
+
+	if cfg.ConfidenceWeight > 0 {
+		var sum float64
+		for _, a := range answers {
+			sum += a.Confidence
+		}
+		if len(answers) > 0 {
+			components["confidence"] = sum / float64(len(answers))
+		} else {
+			components["confidence"] = 0
+		}
+		weights["confidence"] = cfg.ConfidenceWeight
+	}
+
+	if cfg.SizeWeight > 0 && (cfg.MinEmployees > 0 || cfg.MaxEmployees > 0) {
+		components["size"] = sizeFit(answers, company, cfg.MinEmployees, cfg.MaxEmployees)
+		weights["size"] = cfg.SizeWeight
+	}
+
+	if cfg.GeoWeight > 0 && len(cfg.TargetStates) > 0 {
+		components["geo"] = geoFit(answers, company, cfg.TargetStates)
+		weights["geo"] = cfg.GeoWeight
+	}
+
+	if cfg.FederalWeight > 0 && fedAvailable {
+		var best float64
+		if fedCtx != nil {
+			for _, m := range fedCtx.EntityMatches {
+				best = max(best, m.Confidence)
+			}
+		}
+		components["federal"] = best
+		weights["federal"] = cfg.FederalWeight
+	}
+
+	var total, weighted float64
+	for k, w := range weights {
+		total += w
+		weighted += w * components[k]
+	}
+	ps := PreScore{Components: components}
+	if total > 0 {
+		ps.Score = weighted / total
+	}
+	return ps
+}
+
+// sizeFit returns 1 when the extracted (or pre-seeded) headcount is within
+// [minEmp, maxEmp], 0 when outside, and 0.5 when unknown.
+func sizeFit(answers []model.ExtractionAnswer, company model.Company, minEmp, maxEmp int) float64 {
+	n, ok := answerNumber(answers, employeeFieldKeys)
+	if !ok {
+		n, ok = toFloat(company.PreSeeded["employee_count"])
+	}
+	if !ok {
+		return 0.5
+	}
+	if n < float64(minEmp) || (maxEmp > 0 && n > float64(maxEmp)) {
+		return 0
+	}
+	return 1
+}
+
+// geoFit returns 1 when the HQ state is a target state, 0 when it is not,
+// and 0.5 when unknown.
+func geoFit(answers []model.ExtractionAnswer, company model.Company, targets []string) float64 {
+	state := company.State
+	for _, a := range answers {
+		if a.FieldKey == "hq_state" {
+			if s, ok := a.Value.(string); ok && s != "" {
+				state = s
+			}
+			break
+		}
+	}
+	state = stateAbbreviation(state)
+	if state == "" {
+		return 0.5
+	}
+	for _, t := range targets {
+		if strings.EqualFold(strings.TrimSpace(t), state) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// answerNumber returns the highest-confidence numeric answer for any of
+// keys.
+func answerNumber(answers []model.ExtractionAnswer, keys []string) (float64, bool) {
+	var (
+		best     float64
+		bestConf = -1.0
+	)
+	for _, a := range answers {
+		for _, k := range keys {
+			if a.FieldKey != k {
+				continue
+			}
+			if n, ok := toFloat(a.Value); ok && a.Confidence > bestConf {
+				best, bestConf = n, a.Confidence
+			}
+		}
+	}
+	return best, bestConf >= 0
+}
@@ -0,0 +1,217 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/scrape"
+	scrapemocks "github.com/sells-group/research-cli/internal/scrape/mocks"
+	storemocks "github.com/sells-group/research-cli/internal/store/mocks"
+	"github.com/sells-group/research-cli/pkg/anthropic"
+	anthropicmocks "github.com/sells-group/research-cli/pkg/anthropic/mocks"
+	notionmocks "github.com/sells-group/research-cli/pkg/notion/mocks"
+	"github.com/sells-group/research-cli/pkg/perplexity"
+	perplexitymocks "github.com/sells-group/research-cli/pkg/perplexity/mocks"
+	pppmocks "github.com/sells-group/research-cli/pkg/ppp/mocks"
+	salesforcemocks "github.com/sells-group/research-cli/pkg/salesforce/mocks"
+)
+
+func testSamplingConfig() config.SamplingConfig {
+	return config.SamplingConfig{
+		Enabled:          true,
+		Tier3MinScore:    0.5,
+		MinEmployees:     20,
+		MaxEmployees:     500,
+		TargetStates:     []string{"TX", "OK"},
+		ConfidenceWeight: 0.3,
+		SizeWeight:       0.3,
+		GeoWeight:        0.2,
+		FederalWeight:    0.2,
+	}
+}
+
+func TestComputePreScore(t *testing.T) {
+	t.Parallel()
+	answers := []model.ExtractionAnswer{
+		{FieldKey: "industry", Value: "Accounting", Confidence: 0.9},
+		{FieldKey: "employees", Value: "1,200", Confidence: 0.4},
+		{FieldKey: "employees", Value: 150.0, Confidence: 0.7},
+		{FieldKey: "hq_state", Value: "Texas", Confidence: 0.8},
+	}
+	fed := &FederalContext{EntityMatches: []FedEntityMatch{{Confidence: 0.6}, {Confidence: 0.95}}}
+
+	ps := ComputePreScore(answers, model.Company{}, fed, true, testSamplingConfig())
+	assert.InDelta(t, 0.7, ps.Components["confidence"], 1e-9)
+	assert.Equal(t, 1.0, ps.Components["size"]) // highest-confidence headcount wins
+	assert.Equal(t, 1.0, ps.Components["geo"])
+	assert.Equal(t, 0.95, ps.Components["federal"])
+	assert.InDelta(t, 0.3*0.7+0.3+0.2+0.2*0.95, ps.Score, 1e-9)
+}
+
+func TestComputePreScore_SkippedComponents(t *testing.T) {
+	t.Parallel()
+	cfg := testSamplingConfig()
+	cfg.MinEmployees, cfg.MaxEmployees = 0, 0
+	cfg.TargetStates = nil
+
+	// Only confidence remains: no size range, no target states, no fedsync.
+	ps := ComputePreScore([]model.ExtractionAnswer{{Confidence: 0.8}}, model.Company{}, nil, false, cfg)
+	assert.Equal(t, map[string]float64{"confidence": 0.8}, ps.Components)
+	assert.InDelta(t, 0.8, ps.Score, 1e-9)
+
+	// Fedsync connected but no matches scores zero.
+	ps = ComputePreScore(nil, model.Company{}, nil, true, cfg)
+	assert.Equal(t, 0.0, ps.Components["federal"])
+	assert.Equal(t, 0.0, ps.Score)
+}
+
+func TestComputePreScore_FitFallbacks(t *testing.T) {
+	t.Parallel()
+	cfg := testSamplingConfig()
+
+	// Unknown size and state score 0.5.
+	ps := ComputePreScore(nil, model.Company{}, nil, false, cfg)
+	assert.Equal(t, 0.5, ps.Components["size"])
+	assert.Equal(t, 0.5, ps.Components["geo"])
+
+	// Pre-seeded headcount and company state are used when not extracted.
+	ps = ComputePreScore(nil, model.Company{State: "ca", PreSeeded: map[string]any{"employee_count": "8"}}, nil, false, cfg)
+	assert.Equal(t, 0.0, ps.Components["size"])
+	assert.Equal(t, 0.0, ps.Components["geo"])
+}
+
+// runSampledT3 runs the pipeline with sampling enabled at a 0.8 minimum
+// pre-score, every answer at confidence, and returns the T3 phase.
+func runSampledT3(t *testing.T, tier3Gate string, confidence float64) *model.PhaseResult {
+	t.Helper()
+	ctx := context.Background()
+
+	// No name: skips the external search phases like the URL-only test.
+	company := model.Company{
+		URL:          "https://acme.com",
+		SalesforceID: "001ABC",
+		State:        "CA",
+	}
+	questions := []model.Question{
+		{ID: "q1", Text: "What industry?", Tier: 1, FieldKey: "industry",
+			PageTypes:    []model.PageType{model.PageTypeAbout},
+			OutputFormat: "string"},
+		{ID: "q3", Text: "Assess exit readiness", Tier: 3, FieldKey: "exit_readiness",
+			PageTypes:    []model.PageType{model.PageTypeAbout},
+			OutputFormat: "string"},
+	}
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "industry", SFField: "Industry", DataType: "string"},
+		{Key: "exit_readiness", SFField: "Exit_Readiness__c", DataType: "string"},
+	})
+
+	sampling := testSamplingConfig()
+	sampling.Tier3MinScore = 0.8
+	cfg := &config.Config{
+		Crawl: config.CrawlConfig{MaxPages: 50, MaxDepth: 2, CacheTTLHours: 24},
+		Pipeline: config.PipelineConfig{
+			ConfidenceEscalationThreshold: 0.4,
+			Tier3Gate:                     tier3Gate,
+			QualityScoreThreshold:         0.5,
+			Sampling:                      sampling,
+		},
+		Anthropic: config.AnthropicConfig{
+			HaikuModel:  "claude-haiku-4-5-20251001",
+			SonnetModel: "claude-sonnet-4-5-20250929",
+			OpusModel:   "claude-opus-4-6",
+		},
+	}
+
+	st := storemocks.NewMockStore(t)
+	st.On("CreateRun", mock.Anything, mock.AnythingOfType("model.Company")).Return(&model.Run{
+		ID: "run-ps", Company: company, Status: model.RunStatusQueued,
+	}, nil)
+	st.On("UpdateRunStatus", mock.Anything, "run-ps", mock.AnythingOfType("model.RunStatus")).Return(nil)
+	st.On("CreatePhase", mock.Anything, "run-ps", mock.AnythingOfType("string")).Return(&model.RunPhase{ID: "phase-ps"}, nil)
+	st.On("CompletePhase", mock.Anything, "phase-ps", mock.AnythingOfType("*model.PhaseResult")).Return(nil)
+	st.On("GetCachedCrawl", mock.Anything, "https://acme.com").Return(&model.CrawlCache{
+		CompanyURL: "https://acme.com",
+		Pages: []model.CrawledPage{
+			{URL: "https://acme.com", Title: "Home", Markdown: "Welcome to Acme Corporation, a technology company."},
+			{URL: "https://acme.com/about", Title: "About", Markdown: "Acme Corp industry details and employee info."},
+		},
+		CrawledAt: time.Now(),
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}, nil)
+	st.On("UpdateRunResult", mock.Anything, "run-ps", mock.AnythingOfType("*model.RunResult")).Return(nil)
+	st.On("GetCachedLinkedIn", mock.Anything, mock.AnythingOfType("string")).Return(nil, nil).Maybe()
+	st.On("SetCachedLinkedIn", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return(nil).Maybe()
+	st.On("GetHighConfidenceAnswers", mock.Anything, "https://acme.com", mock.AnythingOfType("float64"), mock.AnythingOfType("time.Duration")).Return(nil, nil)
+	st.On("LoadCheckpoint", mock.Anything, "https://acme.com").Return(nil, nil)
+	st.On("SaveCheckpoint", mock.Anything, "https://acme.com", mock.AnythingOfType("string"), mock.Anything).Return(nil).Maybe()
+	st.On("DeleteCheckpoint", mock.Anything, "https://acme.com").Return(nil)
+	st.On("GetLatestProvenance", mock.Anything, "https://acme.com").Return(nil, nil)
+	st.On("SaveProvenance", mock.Anything, mock.AnythingOfType("[]model.FieldProvenance")).Return(nil)
+
+	s := scrapemocks.NewMockScraper(t)
+	s.On("Name").Return("mock").Maybe()
+	s.On("Supports", mock.Anything).Return(true).Maybe()
+	s.On("Scrape", mock.Anything, mock.Anything).Return(&scrape.Result{
+		Page:   model.CrawledPage{URL: "https://example.com", Title: "External", Markdown: "Acme Corporation information."},
+		Source: "mock",
+	}, nil).Maybe()
+	chain := scrape.NewChain(scrape.NewPathMatcher(nil), s)
+
+	pplxClient := perplexitymocks.NewMockClient(t)
+	pplxClient.On("ChatCompletion", mock.Anything, mock.AnythingOfType("perplexity.ChatCompletionRequest")).
+		Return(&perplexity.ChatCompletionResponse{
+			Choices: []perplexity.Choice{{Message: perplexity.Message{Content: "Acme Corp LinkedIn info."}}},
+			Usage:   perplexity.Usage{PromptTokens: 100, CompletionTokens: 50},
+		}, nil).Maybe()
+
+	aiClient := anthropicmocks.NewMockClient(t)
+	aiClient.On("CreateMessage", mock.Anything, mock.AnythingOfType("anthropic.MessageRequest")).
+		Return(&anthropic.MessageResponse{
+			Content: []anthropic.ContentBlock{{Text: fmt.Sprintf(`{"page_type": "about", "confidence": %g, "value": "Technology", "reasoning": "from page", "source_url": "https://acme.com/about"}`, confidence)}},
+			Usage:   anthropic.TokenUsage{InputTokens: 100, OutputTokens: 20},
+		}, nil)
+
+	sfClient := salesforcemocks.NewMockClient(t)
+	sfClient.On("UpdateOne", mock.Anything, "Account", "001ABC", mock.AnythingOfType("map[string]interface {}")).Return(nil).Maybe()
+	notionClient := notionmocks.NewMockClient(t)
+	pppClient := pppmocks.NewMockQuerier(t)
+	pppClient.On("FindLoans", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, nil).Maybe()
+
+	p := New(cfg, st, chain, nil, nil, pplxClient, aiClient, sfClient, notionClient, nil, pppClient, nil, nil, questions, fields)
+
+	result, err := p.Run(ctx, company)
+	require.NoError(t, err)
+
+	var t3 *model.PhaseResult
+	for i := range result.Phases {
+		if result.Phases[i].Name == "6_extract_t3" {
+			t3 = &result.Phases[i]
+		}
+	}
+	require.NotNil(t, t3)
+	return t3
+}
+
+func TestPipeline_Run_T3SkippedByPreScore(t *testing.T) {
+	t3 := runSampledT3(t, "ambiguity_only", 0.5)
+	assert.Equal(t, "prescore_below_threshold", t3.Metadata["reason"])
+	ps, ok := t3.Metadata["prescore"].(*PreScore)
+	require.True(t, ok)
+	assert.Equal(t, 0.0, ps.Components["geo"]) // CA is not a target state
+	assert.Less(t, ps.Score, 0.8)
+}
+
+func TestPipeline_Run_T3AlwaysBypassesSampling(t *testing.T) {
+	// tier3_gate: always (or --with-t3) runs T3 whatever the pre-score.
+	t3 := runSampledT3(t, "always", 0.9)
+	assert.NotEqual(t, model.PhaseStatusSkipped, t3.Status)
+	assert.NotContains(t, t3.Metadata, "reason")
+}
@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/geo"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// rucaSQL returns the USDA RUCA code and population density of the census
+// tract containing ($1, $2), matched in the codes' tract vintage (2010
+// codes through geo.tract_relationships). The newest vintage wins if both
+// are loaded.
+const rucaSQL = pointTractsCTE + `
+	SELECT r.primary_ruca, COALESCE(r.pop_density, 0)
+	FROM geo.ruca r
+	JOIN tracts t ON t.geoid = r.geoid AND t.vintage = r.tract_vintage
+	ORDER BY r.tract_vintage DESC
+	LIMIT 1`
+
+// LookupRUCA returns the primary RUCA code and population density (people
+// per square mile) of the tract containing the geocoded location, from
+// geo.ruca. code is 0 when geo data is missing or the tract has no code.
+func LookupRUCA(ctx context.Context, pool db.Pool, gd *model.GeoData) (code int, density float64, err error) {
+	if pool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return 0, 0, nil
+	}
+	var primary int16
+	if err := pool.QueryRow(ctx, rucaSQL, gd.Longitude, gd.Latitude).Scan(&primary, &density); err != nil {
+		// pgx returns no rows as an error; treat as "not found".
+		if strings.Contains(err.Error(), "no rows") {
+			return 0, 0, nil
+		}
+		return 0, 0, eris.Wrap(err, "ruca: query ruca")
+	}
+	return int(primary), density, nil
+}
+
+// applyRUCA refines the centroid-distance urban classification on geo
+// data collected in Phase 7D with the tract's RUCA code (see
+// geo.RefineWithRUCA) and records the code. Lookup failures are logged and
+// leave the classification unchanged.
+func (p *Pipeline) applyRUCA(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil {
+		return
+	}
+	code, density, err := LookupRUCA(ctx, p.fedsyncPool, gd)
+	if err != nil {
+		zap.L().Warn("pipeline: ruca lookup failed", zap.Error(err))
+		return
+	}
+	if code == 0 {
+		return
+	}
+	gd.RUCACode = code
+	gd.Classification = geo.RefineWithRUCA(gd.Classification, code, density)
+}
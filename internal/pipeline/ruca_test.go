@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupRUCA(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cols := []string{"primary_ruca", "pop_density"}
+	gd := &model.GeoData{Latitude: 32.7767, Longitude: -96.797}
+	// 2010 codes are matched through the tract relationship file.
+	pool.ExpectQuery(`geo.tract_relationships r JOIN tract .*FROM geo.ruca r\s+JOIN tracts t ON t.geoid = r.geoid AND t.vintage = r.tract_vintage`).
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(int16(1), 6791.9))
+	pool.ExpectQuery("FROM geo.ruca").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectQuery("FROM geo.ruca").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(errors.New("connection reset"))
+
+	code, density, err := LookupRUCA(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.Equal(t, 1, code)
+	assert.InDelta(t, 6791.9, density, 0.01)
+
+	code, _, err = LookupRUCA(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.Zero(t, code)
+
+	_, _, err = LookupRUCA(context.Background(), pool, gd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ruca: query ruca")
+
+	// No location: no query.
+	code, _, err = LookupRUCA(context.Background(), pool, &model.GeoData{CountyFIPS: "48113"})
+	require.NoError(t, err)
+	assert.Zero(t, code)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestApplyRUCA(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cols := []string{"primary_ruca", "pop_density"}
+	pool.ExpectQuery("FROM geo.ruca").
+		WithArgs(-97.3308, 32.7555).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(int16(1), 5200.0))
+	pool.ExpectQuery("FROM geo.ruca").
+		WithArgs(-97.3308, 32.7555).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(int16(2), 180.0))
+	pool.ExpectQuery("FROM geo.ruca").
+		WithArgs(-97.3308, 32.7555).
+		WillReturnError(errors.New("connection reset"))
+
+	p := &Pipeline{fedsyncPool: pool}
+
+	// Downtown Fort Worth: a dense metro core tract far from the DFW centroid.
+	gd := &model.GeoData{Latitude: 32.7555, Longitude: -97.3308, Classification: "suburban"}
+	p.applyRUCA(context.Background(), gd)
+	assert.Equal(t, 1, gd.RUCACode)
+	assert.Equal(t, "urban_core", gd.Classification)
+
+	gd = &model.GeoData{Latitude: 32.7555, Longitude: -97.3308, Classification: "suburban"}
+	p.applyRUCA(context.Background(), gd)
+	assert.Equal(t, 2, gd.RUCACode)
+	assert.Equal(t, "exurban", gd.Classification)
+
+	// Lookup errors keep the heuristic classification.
+	gd = &model.GeoData{Latitude: 32.7555, Longitude: -97.3308, Classification: "suburban"}
+	p.applyRUCA(context.Background(), gd)
+	assert.Zero(t, gd.RUCACode)
+	assert.Equal(t, "suburban", gd.Classification)
+
+	p.applyRUCA(context.Background(), nil)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
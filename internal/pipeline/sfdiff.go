@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/pkg/salesforce"
+)
+
+// FieldChange describes a single Salesforce Account field that the gate
+// would write, with the value currently stored in the CRM.
+type FieldChange struct {
+	SFField  string `json:"sf_field"`
+	FieldKey string `json:"field_key,omitempty"`
+	Old      any    `json:"old"`
+	New      any    `json:"new"`
+}
+
+// sfDiffSkipFields lists fields that change on every run and would only add
+// noise to the reviewer-facing diff.
+var sfDiffSkipFields = map[string]bool{
+	"Enrichment_Report__c": true,
+}
+
+// buildSFAccountPayload assembles the Account field map the Salesforce
+// exporter writes. The exporter and the diff both call it so they cannot
+// drift apart.
+func buildSFAccountPayload(result *model.EnrichmentResult, fields *model.FieldRegistry) map[string]any {
+	accountFields, _ := buildSFFieldsByObject(result.FieldValues, fields)
+	if result.Report != "" {
+		accountFields["Enrichment_Report__c"] = result.Report
+	}
+	ensureMinimumSFFields(accountFields, result.Company, result.FieldValues)
+	injectGeoFields(accountFields, result.GeoData)
+	return accountFields
+}
+
+// ComputeSFDiff compares proposed Account fields against the current CRM
+// values and returns the fields whose values would change, sorted by SF
+// field name. A nil current map means the Account does not exist yet, so
+// every proposed field is reported as new.
+func ComputeSFDiff(current, proposed map[string]any, fieldValues map[string]model.FieldValue) []FieldChange {
+	keyBySF := make(map[string]string, len(fieldValues))
+	for _, fv := range fieldValues {
+		if fv.SFField != "" {
+			keyBySF[fv.SFField] = fv.FieldKey
+		}
+	}
+
+	var changes []FieldChange
+	for sfField, newVal := range proposed {
+		if sfDiffSkipFields[sfField] {
+			continue
+		}
+		oldVal := current[sfField]
+		if sfValueString(oldVal) == sfValueString(newVal) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			SFField:  sfField,
+			FieldKey: keyBySF[sfField],
+			Old:      oldVal,
+			New:      newVal,
+		})
+	}
+
+	slices.SortFunc(changes, func(a, b FieldChange) int {
+		return strings.Compare(a.SFField, b.SFField)
+	})
+	return changes
+}
+
+// FetchSFDiff loads the current Account values from Salesforce (when the
+// company already has an SF ID) and diffs them against the payload the
+// exporter would write.
+func FetchSFDiff(ctx context.Context, sfClient salesforce.Client, result *model.EnrichmentResult, fields *model.FieldRegistry) ([]FieldChange, error) {
+	proposed := buildSFAccountPayload(result, fields)
+
+	var current map[string]any
+	if accountID := result.Company.SalesforceID; accountID != "" && sfClient != nil {
+		names := make([]string, 0, len(proposed))
+		for k := range proposed {
+			if !sfDiffSkipFields[k] {
+				names = append(names, k)
+			}
+		}
+		slices.Sort(names)
+
+		var err error
+		current, err = salesforce.FindAccountFields(ctx, sfClient, accountID, names)
+		if err != nil {
+			return nil, eris.Wrap(err, "sfdiff: load current account values")
+		}
+	}
+
+	return ComputeSFDiff(current, proposed, result.FieldValues), nil
+}
+
+// FormatSFDiff renders changes as one "Field: old → new" line per field.
+func FormatSFDiff(changes []FieldChange) string {
+	if len(changes) == 0 {
+		return "No changes."
+	}
+	var b strings.Builder
+	for _, c := range changes {
+		old := sfValueString(c.Old)
+		if old == "" {
+			old = "(empty)"
+		}
+		fmt.Fprintf(&b, "- %s: %s → %s\n", c.SFField, truncateDiffValue(old), truncateDiffValue(sfValueString(c.New)))
+	}
+	return b.String()
+}
+
+// sfValueString normalizes a field value for comparison and display so that
+// SF's float64 numbers match the ints and strings produced by extraction.
+func sfValueString(v any) string {
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(n)
+	case float64:
+		if n == math.Trunc(n) && math.Abs(n) < 1e15 {
+			return strconv.FormatInt(int64(n), 10)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case float32:
+		return sfValueString(float64(n))
+	case bool:
+		return strconv.FormatBool(n)
+	default:
+		return strings.TrimSpace(fmt.Sprintf("%v", v))
+	}
+}
+
+// truncateDiffValue shortens long values (descriptions, reports) for display.
+func truncateDiffValue(s string) string {
+	const maxLen = 120
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len([]rune(s)) <= maxLen {
+		return s
+	}
+	return string([]rune(s)[:maxLen]) + "…"
+}
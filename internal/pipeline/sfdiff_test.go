@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+	salesforcemocks "github.com/sells-group/research-cli/pkg/salesforce/mocks"
+)
+
+func TestComputeSFDiff(t *testing.T) {
+	current := map[string]any{
+		"Id":                "001ABC",
+		"Name":              "Acme Corp",
+		"NumberOfEmployees": float64(50),
+		"Phone":             "555-0000",
+	}
+	proposed := map[string]any{
+		"Name":                 "Acme Corp",
+		"NumberOfEmployees":    50,
+		"Phone":                "555-1234",
+		"Website":              "https://acme.com",
+		"Enrichment_Report__c": "report",
+	}
+	fieldValues := map[string]model.FieldValue{
+		"phone": {FieldKey: "phone", SFField: "Phone", Value: "555-1234"},
+	}
+
+	changes := ComputeSFDiff(current, proposed, fieldValues)
+	require.Len(t, changes, 2)
+	assert.Equal(t, FieldChange{SFField: "Phone", FieldKey: "phone", Old: "555-0000", New: "555-1234"}, changes[0])
+	assert.Equal(t, "Website", changes[1].SFField)
+	assert.Nil(t, changes[1].Old)
+}
+
+func TestComputeSFDiff_NewAccount(t *testing.T) {
+	proposed := map[string]any{"Name": "Acme", "Phone": "555-1234"}
+
+	changes := ComputeSFDiff(nil, proposed, nil)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "Name", changes[0].SFField)
+	assert.Equal(t, "Phone", changes[1].SFField)
+}
+
+func TestFormatSFDiff(t *testing.T) {
+	assert.Equal(t, "No changes.", FormatSFDiff(nil))
+
+	out := FormatSFDiff([]FieldChange{
+		{SFField: "Phone", Old: "555-0000", New: "555-1234"},
+		{SFField: "Website", Old: nil, New: "https://acme.com"},
+	})
+	assert.Equal(t, "- Phone: 555-0000 → 555-1234\n- Website: (empty) → https://acme.com\n", out)
+}
+
+func TestSFValueString(t *testing.T) {
+	assert.Equal(t, "", sfValueString(nil))
+	assert.Equal(t, "50", sfValueString(float64(50)))
+	assert.Equal(t, "1.5", sfValueString(1.5))
+	assert.Equal(t, "50", sfValueString(50))
+	assert.Equal(t, "true", sfValueString(true))
+	assert.Equal(t, "x", sfValueString("  x "))
+}
+
+func TestFetchSFDiff_ExistingAccount(t *testing.T) {
+	ctx := context.Background()
+
+	sfClient := salesforcemocks.NewMockClient(t)
+	sfClient.On("Query", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			out := args.Get(2).(*[]map[string]any)
+			*out = []map[string]any{{"Id": "001ABC", "Name": "Acme Corp", "Website": "https://old.com"}}
+		}).
+		Return(nil)
+
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme Corp", URL: "https://acme.com", SalesforceID: "001ABC"},
+		FieldValues: map[string]model.FieldValue{
+			"website": {FieldKey: "website", SFField: "Website", Value: "https://acme.com"},
+		},
+	}
+	fields := model.NewFieldRegistry([]model.FieldMapping{
+		{Key: "website", SFField: "Website", SFObject: "Account", DataType: "url"},
+	})
+
+	changes, err := FetchSFDiff(ctx, sfClient, result, fields)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "Website", changes[0].SFField)
+	assert.Equal(t, "https://old.com", changes[0].Old)
+}
+
+func TestFetchSFDiff_NoSalesforceID(t *testing.T) {
+	ctx := context.Background()
+
+	// Strict mock: any Query call fails the test.
+	sfClient := salesforcemocks.NewMockClient(t)
+
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme Corp"},
+	}
+
+	changes, err := FetchSFDiff(ctx, sfClient, result, model.NewFieldRegistry(nil))
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "Name", changes[0].SFField)
+}
+
+func TestFetchSFDiff_QueryError(t *testing.T) {
+	ctx := context.Background()
+
+	sfClient := salesforcemocks.NewMockClient(t)
+	sfClient.On("Query", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("sf down"))
+
+	result := &model.EnrichmentResult{
+		Company: model.Company{Name: "Acme Corp", SalesforceID: "001ABC"},
+	}
+
+	_, err := FetchSFDiff(ctx, sfClient, result, model.NewFieldRegistry(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,307 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/pkg/ppp"
+)
+
+// shadowNumericTolerance is the relative difference under which two numeric
+// answers are considered to agree (e.g. 102 vs 100 employees).
+const shadowNumericTolerance = 0.05
+
+// shadowOutput carries the shadow variant's extraction back to Run.
+type shadowOutput struct {
+	answers []model.ExtractionAnswer
+	usage   model.TokenUsage
+	err     error
+}
+
+// SetShadowQuestions sets the candidate question pack used by shadow mode.
+// When unset, shadow mode re-runs the production questions with the shadow
+// model overrides.
+func (p *Pipeline) SetShadowQuestions(questions []model.Question) {
+	p.shadowQuestions = questions
+}
+
+// shadowEnabled reports whether a shadow variant should run for this pipeline.
+func (p *Pipeline) shadowEnabled() bool {
+	return p.cfg.Pipeline.Shadow.Enabled
+}
+
+// shadowAnthropicConfig returns the production Anthropic config with the
+// shadow model overrides applied.
+func shadowAnthropicConfig(base config.AnthropicConfig, sc config.ShadowConfig) config.AnthropicConfig {
+	out := base
+	if sc.HaikuModel != "" {
+		out.HaikuModel = sc.HaikuModel
+	}
+	if sc.SonnetModel != "" {
+		out.SonnetModel = sc.SonnetModel
+	}
+	return out
+}
+
+// runShadow routes the shadow question pack against the classified pages and
+// runs T1 + T2 extraction with the shadow models. Results never touch the
+// production answer set.
+func (p *Pipeline) runShadow(ctx context.Context, company model.Company, pageIndex model.PageIndex, pppMatches []ppp.LoanMatch) shadowOutput {
+	questions := p.shadowQuestions
+	if len(questions) == 0 {
+		questions = p.questions
+	}
+	aiCfg := shadowAnthropicConfig(p.cfg.Anthropic, p.cfg.Pipeline.Shadow)
+	isBatch := !aiCfg.NoBatch
+
+	batches := RouteQuestions(questions, pageIndex)
+
+	var out shadowOutput
+	t1, err := ExtractTier1(ctx, batches.Tier1, company, pppMatches, p.anthropic, aiCfg)
+	if err != nil {
+		out.err = eris.Wrap(err, "shadow: tier 1")
+		return out
+	}
+	t1Usage := t1.TokenUsage
+	t1Usage.Cost = p.costCalc.Claude(aiCfg.HaikuModel, isBatch,
+		t1Usage.InputTokens, t1Usage.OutputTokens, t1Usage.CacheCreationTokens, t1Usage.CacheReadTokens)
+	out.usage.Add(t1Usage)
+
+	var t2Answers []model.ExtractionAnswer
+	if len(batches.Tier2) > 0 {
+		t2, t2Err := ExtractTier2(ctx, batches.Tier2, t1.Answers, company, pppMatches, p.anthropic, aiCfg)
+		if t2Err != nil {
+			out.err = eris.Wrap(t2Err, "shadow: tier 2")
+			return out
+		}
+		t2Answers = t2.Answers
+		t2Usage := t2.TokenUsage
+		t2Usage.Cost = p.costCalc.Claude(aiCfg.SonnetModel, isBatch,
+			t2Usage.InputTokens, t2Usage.OutputTokens, t2Usage.CacheCreationTokens, t2Usage.CacheReadTokens)
+		out.usage.Add(t2Usage)
+	}
+
+	out.answers = MergeAnswers(t1.Answers, t2Answers, nil)
+	return out
+}
+
+// saveShadowResult compares a finished shadow run against the production
+// extraction answers and persists it. Failures are logged, never returned.
+func (p *Pipeline) saveShadowResult(ctx context.Context, runID string, company model.Company, prodAnswers []model.ExtractionAnswer, out shadowOutput, log *zap.Logger) {
+	variant := p.cfg.Pipeline.Shadow.Variant
+	if out.err != nil {
+		log.Warn("pipeline: shadow run failed", zap.String("variant", variant), zap.Error(out.err))
+		return
+	}
+
+	sr := &model.ShadowResult{
+		RunID:      runID,
+		CompanyURL: company.URL,
+		Variant:    variant,
+		Answers:    out.answers,
+		Comparison: CompareShadowAnswers(prodAnswers, out.answers),
+		TokenUsage: out.usage,
+	}
+	if err := p.store.SaveShadowResult(ctx, sr); err != nil {
+		log.Warn("pipeline: failed to save shadow result", zap.String("variant", variant), zap.Error(err))
+		return
+	}
+
+	log.Info("pipeline: shadow run complete",
+		zap.String("variant", variant),
+		zap.Float64("agreement_rate", sr.Comparison.AgreementRate),
+		zap.Int("disagreed", sr.Comparison.Disagreed),
+		zap.Float64("shadow_cost_usd", sr.TokenUsage.Cost),
+	)
+}
+
+// CompareShadowAnswers compares production and shadow answers field by field.
+// Fields are sorted by key; agreement rate only counts fields both sides answered.
+func CompareShadowAnswers(prod, shadow []model.ExtractionAnswer) model.ShadowComparison {
+	prodByKey := answersByField(prod)
+	shadowByKey := answersByField(shadow)
+
+	keys := make([]string, 0, len(prodByKey)+len(shadowByKey))
+	for k := range prodByKey {
+		keys = append(keys, k)
+	}
+	for k := range shadowByKey {
+		if _, ok := prodByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var cmp model.ShadowComparison
+	for _, key := range keys {
+		pa, hasProd := prodByKey[key]
+		sa, hasShadow := shadowByKey[key]
+
+		fc := model.ShadowFieldComparison{FieldKey: key}
+		if hasProd {
+			fc.ProdValue = pa.Value
+			fc.ProdConfidence = pa.Confidence
+		}
+		if hasShadow {
+			fc.ShadowValue = sa.Value
+			fc.ShadowConfidence = sa.Confidence
+		}
+
+		switch {
+		case hasProd && !hasShadow:
+			fc.Status = model.ShadowProdOnly
+			cmp.ProdOnly++
+		case hasShadow && !hasProd:
+			fc.Status = model.ShadowShadowOnly
+			cmp.ShadowOnly++
+		case shadowValuesAgree(pa.Value, sa.Value):
+			fc.Status = model.ShadowAgree
+			cmp.Agreed++
+		default:
+			fc.Status = model.ShadowDisagree
+			cmp.Disagreed++
+		}
+		cmp.Fields = append(cmp.Fields, fc)
+	}
+
+	if compared := cmp.Agreed + cmp.Disagreed; compared > 0 {
+		cmp.AgreementRate = float64(cmp.Agreed) / float64(compared)
+	}
+	return cmp
+}
+
+// answersByField indexes non-empty answers by field key, keeping the
+// highest-confidence answer per key.
+func answersByField(answers []model.ExtractionAnswer) map[string]model.ExtractionAnswer {
+	out := make(map[string]model.ExtractionAnswer, len(answers))
+	for _, a := range answers {
+		if a.FieldKey == "" || a.Value == nil || sfValueString(a.Value) == "" {
+			continue
+		}
+		if cur, ok := out[a.FieldKey]; !ok || a.Confidence > cur.Confidence {
+			out[a.FieldKey] = a
+		}
+	}
+	return out
+}
+
+// shadowValuesAgree compares two answer values. Numbers agree within
+// shadowNumericTolerance; everything else compares case-insensitively.
+func shadowValuesAgree(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			if fa == fb {
+				return true
+			}
+			denom := math.Max(math.Abs(fa), math.Abs(fb))
+			return math.Abs(fa-fb)/denom <= shadowNumericTolerance
+		}
+	}
+	return strings.EqualFold(sfValueString(a), sfValueString(b))
+}
+
+// ShadowFieldStats aggregates agreement for one field across shadow runs.
+type ShadowFieldStats struct {
+	FieldKey      string  `json:"field_key"`
+	Agreed        int     `json:"agreed"`
+	Disagreed     int     `json:"disagreed"`
+	ProdOnly      int     `json:"prod_only"`
+	ShadowOnly    int     `json:"shadow_only"`
+	AgreementRate float64 `json:"agreement_rate"`
+}
+
+// ShadowReport quantifies agreement between a shadow variant and production
+// across many runs, for deciding whether to cut over.
+type ShadowReport struct {
+	Variant       string             `json:"variant"`
+	Runs          int                `json:"runs"`
+	Agreed        int                `json:"agreed"`
+	Disagreed     int                `json:"disagreed"`
+	ProdOnly      int                `json:"prod_only"`
+	ShadowOnly    int                `json:"shadow_only"`
+	AgreementRate float64            `json:"agreement_rate"`
+	ShadowCostUSD float64            `json:"shadow_cost_usd"`
+	Fields        []ShadowFieldStats `json:"fields"`
+}
+
+// BuildShadowReport aggregates stored shadow results into a report. Fields
+// are sorted by agreement rate ascending so the worst fields come first.
+func BuildShadowReport(variant string, results []model.ShadowResult) *ShadowReport {
+	report := &ShadowReport{Variant: variant, Runs: len(results)}
+	byField := make(map[string]*ShadowFieldStats)
+
+	for _, r := range results {
+		report.ShadowCostUSD += r.TokenUsage.Cost
+		for _, fc := range r.Comparison.Fields {
+			st, ok := byField[fc.FieldKey]
+			if !ok {
+				st = &ShadowFieldStats{FieldKey: fc.FieldKey}
+				byField[fc.FieldKey] = st
+			}
+			switch fc.Status {
+			case model.ShadowAgree:
+				st.Agreed++
+				report.Agreed++
+			case model.ShadowDisagree:
+				st.Disagreed++
+				report.Disagreed++
+			case model.ShadowProdOnly:
+				st.ProdOnly++
+				report.ProdOnly++
+			case model.ShadowShadowOnly:
+				st.ShadowOnly++
+				report.ShadowOnly++
+			}
+		}
+	}
+
+	if compared := report.Agreed + report.Disagreed; compared > 0 {
+		report.AgreementRate = float64(report.Agreed) / float64(compared)
+	}
+
+	for _, st := range byField {
+		if compared := st.Agreed + st.Disagreed; compared > 0 {
+			st.AgreementRate = float64(st.Agreed) / float64(compared)
+		}
+		report.Fields = append(report.Fields, *st)
+	}
+	slices.SortFunc(report.Fields, func(a, b ShadowFieldStats) int {
+		if a.AgreementRate != b.AgreementRate {
+			if a.AgreementRate < b.AgreementRate {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.FieldKey, b.FieldKey)
+	})
+
+	return report
+}
+
+// FormatShadowReport renders a report as a plain-text summary and field table.
+func FormatShadowReport(r *ShadowReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Shadow variant: %s\n", r.Variant)
+	fmt.Fprintf(&b, "Runs: %d\n", r.Runs)
+	fmt.Fprintf(&b, "Agreement: %.1f%% (%d agree, %d disagree)\n", r.AgreementRate*100, r.Agreed, r.Disagreed)
+	fmt.Fprintf(&b, "Coverage: %d prod-only, %d shadow-only\n", r.ProdOnly, r.ShadowOnly)
+	fmt.Fprintf(&b, "Shadow cost: $%.2f\n\n", r.ShadowCostUSD)
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tAGREE\tDISAGREE\tPROD_ONLY\tSHADOW_ONLY\tRATE")
+	for _, f := range r.Fields {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.1f%%\n",
+			f.FieldKey, f.Agreed, f.Disagreed, f.ProdOnly, f.ShadowOnly, f.AgreementRate*100)
+	}
+	_ = tw.Flush()
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/model"
+	storemocks "github.com/sells-group/research-cli/internal/store/mocks"
+)
+
+func TestCompareShadowAnswers(t *testing.T) {
+	prod := []model.ExtractionAnswer{
+		{FieldKey: "employees", Value: 100, Confidence: 0.8},
+		{FieldKey: "industry", Value: "HVAC", Confidence: 0.9},
+		{FieldKey: "locations", Value: 3, Confidence: 0.7},
+		{FieldKey: "phone", Value: "555-1234", Confidence: 0.6},
+		{FieldKey: "empty", Value: "", Confidence: 0.9},
+	}
+	shadow := []model.ExtractionAnswer{
+		{FieldKey: "employees", Value: float64(103), Confidence: 0.85},
+		{FieldKey: "industry", Value: "hvac", Confidence: 0.9},
+		{FieldKey: "locations", Value: 5, Confidence: 0.6},
+		{FieldKey: "revenue", Value: "5000000", Confidence: 0.5},
+	}
+
+	cmp := CompareShadowAnswers(prod, shadow)
+	require.Len(t, cmp.Fields, 5)
+
+	byKey := make(map[string]model.ShadowFieldComparison)
+	for _, f := range cmp.Fields {
+		byKey[f.FieldKey] = f
+	}
+	assert.Equal(t, model.ShadowAgree, byKey["employees"].Status, "within numeric tolerance")
+	assert.Equal(t, model.ShadowAgree, byKey["industry"].Status, "case-insensitive")
+	assert.Equal(t, model.ShadowDisagree, byKey["locations"].Status)
+	assert.Equal(t, model.ShadowProdOnly, byKey["phone"].Status)
+	assert.Equal(t, model.ShadowShadowOnly, byKey["revenue"].Status)
+	assert.NotContains(t, byKey, "empty")
+
+	assert.Equal(t, "employees", cmp.Fields[0].FieldKey, "fields sorted by key")
+	assert.Equal(t, 2, cmp.Agreed)
+	assert.Equal(t, 1, cmp.Disagreed)
+	assert.Equal(t, 1, cmp.ProdOnly)
+	assert.Equal(t, 1, cmp.ShadowOnly)
+	assert.InDelta(t, 2.0/3.0, cmp.AgreementRate, 1e-9)
+}
+
+func TestCompareShadowAnswers_KeepsHighestConfidence(t *testing.T) {
+	prod := []model.ExtractionAnswer{
+		{FieldKey: "state", Value: "TX", Confidence: 0.4},
+		{FieldKey: "state", Value: "CA", Confidence: 0.9},
+	}
+	shadow := []model.ExtractionAnswer{{FieldKey: "state", Value: "CA", Confidence: 0.8}}
+
+	cmp := CompareShadowAnswers(prod, shadow)
+	require.Len(t, cmp.Fields, 1)
+	assert.Equal(t, model.ShadowAgree, cmp.Fields[0].Status)
+	assert.Equal(t, "CA", cmp.Fields[0].ProdValue)
+	assert.InDelta(t, 1.0, cmp.AgreementRate, 1e-9)
+}
+
+func TestCompareShadowAnswers_Empty(t *testing.T) {
+	cmp := CompareShadowAnswers(nil, nil)
+	assert.Empty(t, cmp.Fields)
+	assert.Zero(t, cmp.AgreementRate)
+}
+
+func TestShadowValuesAgree(t *testing.T) {
+	assert.True(t, shadowValuesAgree(100, 104))
+	assert.False(t, shadowValuesAgree(100, 110))
+	assert.True(t, shadowValuesAgree(0, 0))
+	assert.True(t, shadowValuesAgree("Acme ", "acme"))
+	assert.False(t, shadowValuesAgree("Acme", "Beta"))
+	assert.True(t, shadowValuesAgree(true, "true"))
+}
+
+func TestShadowAnthropicConfig(t *testing.T) {
+	base := config.AnthropicConfig{HaikuModel: "haiku-prod", SonnetModel: "sonnet-prod", OpusModel: "opus-prod"}
+
+	out := shadowAnthropicConfig(base, config.ShadowConfig{SonnetModel: "sonnet-next"})
+	assert.Equal(t, "haiku-prod", out.HaikuModel)
+	assert.Equal(t, "sonnet-next", out.SonnetModel)
+	assert.Equal(t, "opus-prod", out.OpusModel)
+	assert.Equal(t, "sonnet-prod", base.SonnetModel, "base config untouched")
+}
+
+func TestBuildShadowReport(t *testing.T) {
+	results := []model.ShadowResult{
+		{
+			Variant:    "v2",
+			TokenUsage: model.TokenUsage{Cost: 0.25},
+			Comparison: model.ShadowComparison{Fields: []model.ShadowFieldComparison{
+				{FieldKey: "employees", Status: model.ShadowAgree},
+				{FieldKey: "industry", Status: model.ShadowDisagree},
+				{FieldKey: "phone", Status: model.ShadowProdOnly},
+			}},
+		},
+		{
+			Variant:    "v2",
+			TokenUsage: model.TokenUsage{Cost: 0.5},
+			Comparison: model.ShadowComparison{Fields: []model.ShadowFieldComparison{
+				{FieldKey: "employees", Status: model.ShadowAgree},
+				{FieldKey: "industry", Status: model.ShadowAgree},
+				{FieldKey: "revenue", Status: model.ShadowShadowOnly},
+			}},
+		},
+	}
+
+	r := BuildShadowReport("v2", results)
+	assert.Equal(t, "v2", r.Variant)
+	assert.Equal(t, 2, r.Runs)
+	assert.Equal(t, 3, r.Agreed)
+	assert.Equal(t, 1, r.Disagreed)
+	assert.Equal(t, 1, r.ProdOnly)
+	assert.Equal(t, 1, r.ShadowOnly)
+	assert.InDelta(t, 0.75, r.AgreementRate, 1e-9)
+	assert.InDelta(t, 0.75, r.ShadowCostUSD, 1e-9)
+
+	require.Len(t, r.Fields, 4)
+	// Zero-rate coverage-only fields first, then by ascending agreement.
+	assert.Equal(t, "phone", r.Fields[0].FieldKey)
+	assert.Equal(t, "revenue", r.Fields[1].FieldKey)
+	assert.Equal(t, "industry", r.Fields[2].FieldKey)
+	assert.InDelta(t, 0.5, r.Fields[2].AgreementRate, 1e-9)
+	assert.Equal(t, "employees", r.Fields[3].FieldKey)
+}
+
+func TestFormatShadowReport(t *testing.T) {
+	r := &ShadowReport{
+		Variant:       "v2",
+		Runs:          4,
+		Agreed:        9,
+		Disagreed:     1,
+		AgreementRate: 0.9,
+		ShadowCostUSD: 1.234,
+		Fields:        []ShadowFieldStats{{FieldKey: "industry", Agreed: 9, Disagreed: 1, AgreementRate: 0.9}},
+	}
+
+	out := FormatShadowReport(r)
+	assert.Contains(t, out, "Shadow variant: v2")
+	assert.Contains(t, out, "Agreement: 90.0% (9 agree, 1 disagree)")
+	assert.Contains(t, out, "Shadow cost: $1.23")
+	assert.Contains(t, out, "FIELD")
+	assert.Contains(t, out, "industry")
+}
+
+func TestSaveShadowResult(t *testing.T) {
+	ctx := context.Background()
+	st := storemocks.NewMockStore(t)
+	st.EXPECT().SaveShadowResult(ctx, mock.MatchedBy(func(sr *model.ShadowResult) bool {
+		return sr.RunID == "run-1" && sr.Variant == "v2" &&
+			sr.CompanyURL == "https://acme.com" && sr.Comparison.Agreed == 1
+	})).Return(nil)
+
+	cfg := &config.Config{}
+	cfg.Pipeline.Shadow = config.ShadowConfig{Enabled: true, Variant: "v2"}
+	p := &Pipeline{cfg: cfg, store: st}
+
+	answers := []model.ExtractionAnswer{{FieldKey: "industry", Value: "HVAC"}}
+	p.saveShadowResult(ctx, "run-1", model.Company{URL: "https://acme.com"}, answers,
+		shadowOutput{answers: answers}, zap.NewNop())
+}
+
+func TestSaveShadowResult_ShadowErrorSkipsSave(t *testing.T) {
+	st := storemocks.NewMockStore(t)
+
+	cfg := &config.Config{}
+	cfg.Pipeline.Shadow = config.ShadowConfig{Enabled: true, Variant: "v2"}
+	p := &Pipeline{cfg: cfg, store: st}
+
+	p.saveShadowResult(context.Background(), "run-1", model.Company{URL: "https://acme.com"}, nil,
+		shadowOutput{err: assert.AnError}, zap.NewNop())
+}
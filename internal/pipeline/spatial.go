@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// applySpatialAssignment fills the county FIPS and CBSA on geo data
+// collected in Phase 7D from the synced TIGER and CBSA boundaries when the
+// geocoder and MSA association left them empty, so the later tract and
+// county lookups and the Salesforce fields have them. Lookup failures are
+// logged and leave the fields unset.
+func (p *Pipeline) applySpatialAssignment(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return
+	}
+	if gd.CountyFIPS != "" && gd.CBSACode != "" {
+		return
+	}
+	a, err := geospatial.AssignPoint(ctx, p.fedsyncPool, gd.Latitude, gd.Longitude)
+	if err != nil {
+		zap.L().Warn("pipeline: spatial assignment failed", zap.Error(err))
+		return
+	}
+	if gd.CountyFIPS == "" {
+		gd.CountyFIPS = a.CountyFIPS
+	}
+	if gd.CBSACode == "" && a.CBSACode != "" {
+		gd.CBSACode = a.CBSACode
+		gd.MSAName = a.CBSAName
+	}
+}
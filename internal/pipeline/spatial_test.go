@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestApplySpatialAssignment(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cols := []string{"county", "tract", "place_geoid", "place_name", "cbsa", "cbsa_name"}
+	county, cbsa, name := "48113", "19100", "Dallas-Fort Worth-Arlington, TX"
+	pool.ExpectQuery("FROM geo.counties").
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(&county, (*string)(nil), (*string)(nil), (*string)(nil), &cbsa, &name))
+	pool.ExpectQuery("FROM geo.counties").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(errors.New("connection reset"))
+
+	p := &Pipeline{fedsyncPool: pool}
+
+	gd := &model.GeoData{Latitude: 32.7767, Longitude: -96.797}
+	p.applySpatialAssignment(context.Background(), gd)
+	assert.Equal(t, "48113", gd.CountyFIPS)
+	assert.Equal(t, "19100", gd.CBSACode)
+	assert.Equal(t, name, gd.MSAName)
+
+	// Lookup errors leave the fields unset.
+	gd = &model.GeoData{Latitude: 32.7767, Longitude: -96.797, CountyFIPS: "48113"}
+	p.applySpatialAssignment(context.Background(), gd)
+	assert.Empty(t, gd.CBSACode)
+
+	// Already assigned or no location: no query.
+	p.applySpatialAssignment(context.Background(), &model.GeoData{Latitude: 1, Longitude: 1, CountyFIPS: "48113", CBSACode: "19100"})
+	p.applySpatialAssignment(context.Background(), &model.GeoData{})
+	p.applySpatialAssignment(context.Background(), nil)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -304,6 +304,11 @@ func (s *StubNotionClient) UpdatePage(_ context.Context, _ string, _ *notionapi.
 	return &notionapi.Page{}, nil
 }
 
+// GetDatabase implements notion.Client.
+func (s *StubNotionClient) GetDatabase(_ context.Context, dbID string) (*notionapi.Database, error) {
+	return &notionapi.Database{ID: notionapi.ObjectID(dbID)}, nil
+}
+
 // --- PPP Stub ---
 
 // StubPPPClient implements ppp.Querier as a no-op.
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"math"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// transitWalkMeters is the walking distance (about half a mile) within
+// which transit stops count toward a location's access.
+const transitWalkMeters = 800
+
+// transitAccessSQL sums weekday 07:00-09:00 departures at GTFS stops
+// within walking distance of ($1, $2).
+const transitAccessSQL = `
+	SELECT COALESCE(SUM(s.peak_departures), 0)
+	FROM geo.transit_stops s
+	WHERE ST_DWithin(s.geom::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)`
+
+// LookupTransitScore returns the transit access score (0-100) at the
+// geocoded location: peak-hour departures at stops within 800 m, capped at
+// 100. Returns nil when geo data is missing.
+func LookupTransitScore(ctx context.Context, pool db.Pool, gd *model.GeoData) (*float64, error) {
+	if pool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return nil, nil
+	}
+	var departures int64
+	if err := pool.QueryRow(ctx, transitAccessSQL, gd.Longitude, gd.Latitude, transitWalkMeters).Scan(&departures); err != nil {
+		return nil, eris.Wrap(err, "transit: query transit_stops")
+	}
+	score := math.Min(100, math.Round(float64(departures)/2*10)/10)
+	return &score, nil
+}
+
+// applyTransitScore sets the transit access score on geo data collected in
+// Phase 7D. Lookup failures are logged and leave the score unset.
+func (p *Pipeline) applyTransitScore(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil {
+		return
+	}
+	score, err := LookupTransitScore(ctx, p.fedsyncPool, gd)
+	if err != nil {
+		zap.L().Warn("pipeline: transit score lookup failed", zap.Error(err))
+		return
+	}
+	if score != nil {
+		gd.TransitScore = *score
+	}
+}
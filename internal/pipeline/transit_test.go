@@ -0,0 +1,50 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupTransitScore(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	gd := &model.GeoData{Latitude: 30.2672, Longitude: -97.7431}
+	pool.ExpectQuery("FROM geo.transit_stops").
+		WithArgs(-97.7431, 30.2672, transitWalkMeters).
+		WillReturnRows(pgxmock.NewRows([]string{"sum"}).AddRow(int64(45)))
+	pool.ExpectQuery("FROM geo.transit_stops").
+		WithArgs(-97.7431, 30.2672, transitWalkMeters).
+		WillReturnRows(pgxmock.NewRows([]string{"sum"}).AddRow(int64(640)))
+	pool.ExpectQuery("FROM geo.transit_stops").
+		WithArgs(-97.7431, 30.2672, transitWalkMeters).
+		WillReturnError(errors.New("connection reset"))
+
+	got, err := LookupTransitScore(context.Background(), pool, gd)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.InDelta(t, 22.5, *got, 0.0001)
+
+	got, err = LookupTransitScore(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.InDelta(t, 100, *got, 0.0001, "capped at 100")
+
+	_, err = LookupTransitScore(context.Background(), pool, gd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transit: query transit_stops")
+
+	// No location: no query.
+	got, err = LookupTransitScore(context.Background(), pool, &model.GeoData{CountyFIPS: "48453"})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
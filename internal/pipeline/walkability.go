@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// smartLocationSQL returns the EPA Smart Location Database measures for the
+// census block group containing ($1, $2).
+const smartLocationSQL = `
+	SELECT s.walkability_index, s.intersection_density, s.emp_density
+	FROM geo.sld s
+	WHERE s.geoid = (
+		SELECT b.geoid FROM geo.block_groups b
+		WHERE ST_Contains(b.geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+		LIMIT 1
+	)`
+
+// SmartLocation holds the EPA Smart Location Database measures for a
+// block group.
+type SmartLocation struct {
+	WalkabilityIndex    float64 // national walkability index, 1-20
+	IntersectionDensity float64 // street intersections per square mile
+	EmploymentDensity   float64 // jobs per acre
+}
+
+// LookupSmartLocation returns the EPA Smart Location Database measures for
+// the block group containing the geocoded location. Returns nil when geo
+// data is missing or no block group matches.
+func LookupSmartLocation(ctx context.Context, pool db.Pool, gd *model.GeoData) (*SmartLocation, error) {
+	if pool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return nil, nil
+	}
+	var walk, intersections, emp *float64
+	err := pool.QueryRow(ctx, smartLocationSQL, gd.Longitude, gd.Latitude).Scan(&walk, &intersections, &emp)
+	if err != nil {
+		// pgx returns no rows as an error; treat as "not found".
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "walkability: query sld")
+	}
+	sl := &SmartLocation{}
+	if walk != nil {
+		sl.WalkabilityIndex = *walk
+	}
+	if intersections != nil {
+		sl.IntersectionDensity = *intersections
+	}
+	if emp != nil {
+		sl.EmploymentDensity = *emp
+	}
+	return sl, nil
+}
+
+// applySmartLocation sets the walkability, intersection density, and
+// employment density scores on geo data collected in Phase 7D alongside
+// the urban classification. Lookup failures are logged and leave the
+// scores unset.
+func (p *Pipeline) applySmartLocation(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil {
+		return
+	}
+	sl, err := LookupSmartLocation(ctx, p.fedsyncPool, gd)
+	if err != nil {
+		zap.L().Warn("pipeline: smart location lookup failed", zap.Error(err))
+		return
+	}
+	if sl != nil {
+		gd.WalkabilityIndex = sl.WalkabilityIndex
+		gd.IntersectionDensity = sl.IntersectionDensity
+		gd.EmploymentDensity = sl.EmploymentDensity
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupSmartLocation(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	gd := &model.GeoData{Latitude: 32.7767, Longitude: -96.797}
+	walk, intersections := 15.2, 42.5
+	cols := []string{"walkability_index", "intersection_density", "emp_density"}
+	pool.ExpectQuery("FROM geo.sld").
+		WithArgs(-96.797, 32.7767).
+		WillReturnRows(pgxmock.NewRows(cols).AddRow(&walk, &intersections, (*float64)(nil)))
+	pool.ExpectQuery("FROM geo.sld").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectQuery("FROM geo.sld").
+		WithArgs(-96.797, 32.7767).
+		WillReturnError(errors.New("connection reset"))
+
+	got, err := LookupSmartLocation(context.Background(), pool, gd)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.InDelta(t, 15.2, got.WalkabilityIndex, 0.0001)
+	assert.InDelta(t, 42.5, got.IntersectionDensity, 0.0001)
+	assert.Zero(t, got.EmploymentDensity)
+
+	got, err = LookupSmartLocation(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = LookupSmartLocation(context.Background(), pool, gd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "walkability: query sld")
+
+	// No location: no query.
+	got, err = LookupSmartLocation(context.Background(), pool, &model.GeoData{CountyFIPS: "48113"})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+// wildfireScoreSQL reads the Wildfire Risk to Communities risk to homes
+// national percentile for the census tract containing ($1, $2), falling
+// back to the county ($3) when the tract has no score.
+const wildfireScoreSQL = `
+	SELECT w.risk_to_homes_pctile
+	FROM geo.wildfire_risk w
+	WHERE w.risk_to_homes_pctile IS NOT NULL AND (
+		(w.geo_level = 'tract' AND w.geoid = (
+			SELECT t.geoid FROM geo.census_tracts t
+			WHERE ST_Contains(t.geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+			LIMIT 1
+		))
+		OR (w.geo_level = 'county' AND w.geoid = $3)
+	)
+	ORDER BY w.geo_level = 'tract' DESC
+	LIMIT 1`
+
+// LookupWildfireScore returns the wildfire score (0-100) at the geocoded
+// location from geo.wildfire_risk. Returns nil when the location has no
+// score or geo data is missing.
+func LookupWildfireScore(ctx context.Context, pool db.Pool, gd *model.GeoData) (*float64, error) {
+	if pool == nil || gd == nil || (gd.Latitude == 0 && gd.Longitude == 0) {
+		return nil, nil
+	}
+	var score *float64
+	err := pool.QueryRow(ctx, wildfireScoreSQL, gd.Longitude, gd.Latitude, gd.CountyFIPS).Scan(&score)
+	if err != nil {
+		// pgx returns no rows as an error; treat as "not found".
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "wildfire: query wildfire_risk")
+	}
+	return score, nil
+}
+
+// applyWildfireScore sets the wildfire score on geo data collected in
+// Phase 7D. Lookup failures are logged and leave the score unset.
+func (p *Pipeline) applyWildfireScore(ctx context.Context, gd *model.GeoData) {
+	if p.fedsyncPool == nil || gd == nil {
+		return
+	}
+	score, err := LookupWildfireScore(ctx, p.fedsyncPool, gd)
+	if err != nil {
+		zap.L().Warn("pipeline: wildfire score lookup failed", zap.Error(err))
+		return
+	}
+	if score != nil {
+		gd.WildfireScore = *score
+	}
+}
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/model"
+)
+
+func TestLookupWildfireScore(t *testing.T) {
+	t.Parallel()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	gd := &model.GeoData{Latitude: 34.05, Longitude: -118.24, CountyFIPS: "06037"}
+	score := 97.0
+	pool.ExpectQuery("FROM geo.wildfire_risk").
+		WithArgs(-118.24, 34.05, "06037").
+		WillReturnRows(pgxmock.NewRows([]string{"risk_to_homes_pctile"}).AddRow(&score))
+	pool.ExpectQuery("FROM geo.wildfire_risk").
+		WithArgs(-118.24, 34.05, "06037").
+		WillReturnError(pgx.ErrNoRows)
+	pool.ExpectQuery("FROM geo.wildfire_risk").
+		WithArgs(-118.24, 34.05, "06037").
+		WillReturnError(errors.New("connection reset"))
+
+	got, err := LookupWildfireScore(context.Background(), pool, gd)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.InDelta(t, 97.0, *got, 0.0001)
+
+	got, err = LookupWildfireScore(context.Background(), pool, gd)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = LookupWildfireScore(context.Background(), pool, gd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wildfire: query wildfire_risk")
+
+	// No location: no query.
+	got, err = LookupWildfireScore(context.Background(), pool, &model.GeoData{CountyFIPS: "06037"})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,274 @@
+package postsync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/notify"
+)
+
+// Built-in hook names.
+const (
+	EnqueueGeocode    = "enqueue_geocode"
+	AssignMSA         = "assign_msa"
+	FloodTag          = "flood_tag"
+	HazardTag         = "hazard_tag"
+	SchoolDistrictTag = "school_district_tag"
+	Notify            = "notify"
+)
+
+// enqueueLimit caps the rows enqueue_geocode reads per sync; the rest are
+// picked up by later syncs.
+const enqueueLimit = 10000
+
+// Builtins returns a Registry with the built-in hooks. queue backs
+// enqueue_geocode and notifier backs notify; either may be nil, in which
+// case that hook fails when run.
+func Builtins(queue *geospatial.GeocodeQueue, notifier notify.Notifier) *Registry {
+	return NewRegistry(
+		NewHook(EnqueueGeocode, func(ctx context.Context, pool db.Pool, t Target) (int64, error) {
+			if queue == nil {
+				return 0, eris.New("enqueue_geocode: no geocode queue configured")
+			}
+			n, err := EnqueueTable(ctx, pool, queue, t.Table)
+			return int64(n), err
+		}),
+		NewHook(AssignMSA, assignMSA),
+		NewHook(FloodTag, func(ctx context.Context, pool db.Pool, _ Target) (int64, error) {
+			return TagCompanyFloodZones(ctx, pool)
+		}),
+		NewHook(HazardTag, func(ctx context.Context, pool db.Pool, _ Target) (int64, error) {
+			return TagCompanyHazards(ctx, pool)
+		}),
+		NewHook(SchoolDistrictTag, func(ctx context.Context, pool db.Pool, _ Target) (int64, error) {
+			return TagCompanySchoolDistricts(ctx, pool)
+		}),
+		NewHook(Notify, func(ctx context.Context, _ db.Pool, t Target) (int64, error) {
+			if notifier == nil {
+				return 0, eris.New("notify: no notifier configured")
+			}
+			s := notify.NewSummary(t.Source, time.Now(), []notify.Item{
+				{Name: t.Dataset, Status: notify.StatusComplete, Rows: t.Rows},
+			})
+			return 0, eris.Wrap(notifier.Notify(ctx, s), "notify: send summary")
+		}),
+	)
+}
+
+// fedBridgeEnqueuers enqueue fed_data tables whose addresses span several
+// columns through the FedBridge queries.
+var fedBridgeEnqueuers = map[string]func(b *geospatial.FedBridge, ctx context.Context, limit int) (int, error){
+	"fed_data.adv_firms":      (*geospatial.FedBridge).EnqueueADVFirms,
+	"fed_data.epa_facilities": (*geospatial.FedBridge).EnqueueEPAFacilities,
+}
+
+// EnqueueTable enqueues addresses from table's rows missing coordinates
+// into geo.geocode_queue and returns the number enqueued. Geo tables are
+// read through their source_id, address, latitude, and longitude columns;
+// fed_data tables with a FedBridge query use it. When the queue is at its
+// max depth the rest are left for a later sync. Small batches are geocoded
+// immediately when the queue has a geocoder.
+func EnqueueTable(ctx context.Context, pool db.Pool, queue *geospatial.GeocodeQueue, table string) (int, error) {
+	log := zap.L().With(zap.String("component", "postsync"), zap.String("table", table))
+
+	var (
+		enqueued int
+		err      error
+	)
+	if enqueue, ok := fedBridgeEnqueuers[table]; ok {
+		enqueued, err = enqueue(geospatial.NewFedBridge(pool, queue), ctx, enqueueLimit)
+		if err != nil {
+			return enqueued, eris.Wrapf(err, "postsync: enqueue %s", table)
+		}
+	} else {
+		items, err := ungeocodedRows(ctx, pool, table)
+		if err != nil {
+			return 0, err
+		}
+		if len(items) == 0 {
+			log.Debug("no ungeocoded rows found")
+			return 0, nil
+		}
+		enqueued, err = queue.EnqueueBatch(ctx, table, items)
+		if err != nil {
+			return enqueued, eris.Wrapf(err, "postsync: enqueue batch for %s", table)
+		}
+		log.Info("enqueued addresses for geocoding", zap.Int("found", len(items)), zap.Int("enqueued", enqueued))
+	}
+
+	// For small batches, process immediately.
+	if enqueued > 0 && enqueued <= 100 {
+		processed, err := queue.ProcessBatch(ctx)
+		if err != nil {
+			log.Warn("postsync: immediate geocode failed", zap.Error(err))
+		} else {
+			log.Info("postsync: immediate geocode complete", zap.Int("processed", processed))
+		}
+	}
+	return enqueued, nil
+}
+
+// ungeocodedRows returns queue items for table rows that have an address
+// but no coordinates.
+func ungeocodedRows(ctx context.Context, pool db.Pool, table string) ([]geospatial.QueueItem, error) {
+	rows, err := pool.Query(ctx, fmt.Sprintf(
+		`SELECT source_id, address FROM %s
+		 WHERE address IS NOT NULL AND address != ''
+		   AND (latitude IS NULL OR longitude IS NULL)
+		 LIMIT %d`,
+		pgx.Identifier(strings.SplitN(table, ".", 2)).Sanitize(), enqueueLimit,
+	))
+	if err != nil {
+		return nil, eris.Wrapf(err, "postsync: query ungeocoded rows from %s", table)
+	}
+	defer rows.Close()
+
+	var items []geospatial.QueueItem
+	for rows.Next() {
+		var item geospatial.QueueItem
+		if err := rows.Scan(&item.SourceID, &item.Address); err != nil {
+			return nil, eris.Wrap(err, "postsync: scan row")
+		}
+		items = append(items, item)
+	}
+	return items, eris.Wrap(rows.Err(), "postsync: iterate rows")
+}
+
+// assignMSASQL sets the metropolitan or micropolitan CBSA containing each
+// geocoded location of a source table.
+const assignMSASQL = `UPDATE geo.locations l
+SET cbsa_code = c.cbsa_code
+FROM geo.cbsa c
+WHERE l.source_table = $1
+	AND c.lsad IN ('M1', 'M2')
+	AND ST_Contains(c.geom, l.geom)
+	AND l.cbsa_code IS DISTINCT FROM c.cbsa_code`
+
+func assignMSA(ctx context.Context, pool db.Pool, t Target) (int64, error) {
+	if t.Table == "" {
+		return 0, eris.New("assign_msa: no target table")
+	}
+	tag, err := pool.Exec(ctx, assignMSASQL, t.Table)
+	if err != nil {
+		return 0, eris.Wrapf(err, "assign_msa: %s", t.Table)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// tagCompanyFloodZonesSQL sets the flood zone of every geocoded company
+// address from the flood polygon containing it, preferring the riskiest
+// zone where polygons overlap. Addresses outside every polygon are cleared.
+const tagCompanyFloodZonesSQL = `WITH tagged AS (
+	SELECT a.id, fz.zone_code, fz.flood_type
+	FROM public.company_addresses a
+	LEFT JOIN LATERAL (
+		SELECT f.zone_code, f.flood_type
+		FROM geo.flood_zones f
+		WHERE ST_Intersects(f.geom, a.geom)
+		ORDER BY CASE f.flood_type
+			WHEN 'high_risk' THEN 1
+			WHEN 'moderate_risk' THEN 2
+			WHEN 'low_risk' THEN 3
+			ELSE 4
+		END, f.zone_code
+		LIMIT 1
+	) fz ON true
+	WHERE a.geom IS NOT NULL
+)
+UPDATE public.company_addresses a
+SET flood_zone = t.zone_code,
+	flood_type = t.flood_type,
+	flood_zone_checked_at = now()
+FROM tagged t
+WHERE a.id = t.id`
+
+// TagCompanyFloodZones refreshes company_addresses flood zone tags after a
+// flood zone sync and returns the number of addresses checked.
+func TagCompanyFloodZones(ctx context.Context, pool db.Pool) (int64, error) {
+	tag, err := pool.Exec(ctx, tagCompanyFloodZonesSQL)
+	if err != nil {
+		return 0, eris.Wrap(err, "flood_zones: tag company addresses")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// tagCompanyHazardsSQL sets the seismic design category and landslide
+// susceptibility of every geocoded company address from the hazard
+// polygons containing it, preferring the most severe class where polygons
+// overlap. Addresses outside every polygon are cleared.
+const tagCompanyHazardsSQL = `WITH tagged AS (
+	SELECT a.id, sdc.hazard_class AS sdc, ls.hazard_class AS landslide
+	FROM public.company_addresses a
+	LEFT JOIN LATERAL (
+		SELECT h.hazard_class
+		FROM geo.usgs_hazards h
+		WHERE h.hazard = 'seismic_design_category' AND ST_Intersects(h.geom, a.geom)
+		ORDER BY h.severity DESC, h.hazard_class DESC
+		LIMIT 1
+	) sdc ON true
+	LEFT JOIN LATERAL (
+		SELECT h.hazard_class
+		FROM geo.usgs_hazards h
+		WHERE h.hazard = 'landslide_susceptibility' AND ST_Intersects(h.geom, a.geom)
+		ORDER BY h.severity DESC
+		LIMIT 1
+	) ls ON true
+	WHERE a.geom IS NOT NULL
+)
+UPDATE public.company_addresses a
+SET seismic_design_category = t.sdc,
+	landslide_susceptibility = t.landslide,
+	hazards_checked_at = now()
+FROM tagged t
+WHERE a.id = t.id`
+
+// TagCompanyHazards refreshes company_addresses seismic design category
+// and landslide susceptibility tags after a USGS hazards sync and returns
+// the number of addresses checked.
+func TagCompanyHazards(ctx context.Context, pool db.Pool) (int64, error) {
+	tag, err := pool.Exec(ctx, tagCompanyHazardsSQL)
+	if err != nil {
+		return 0, eris.Wrap(err, "usgs_hazards: tag company addresses")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// tagCompanySchoolDistrictsSQL sets the school district of every geocoded
+// company address. Where an elementary and a secondary district overlap,
+// a unified district wins, then the elementary district. Addresses outside
+// every district are cleared.
+const tagCompanySchoolDistrictsSQL = `WITH tagged AS (
+	SELECT a.id, sd.geoid
+	FROM public.company_addresses a
+	LEFT JOIN LATERAL (
+		SELECT d.geoid
+		FROM geo.school_districts d
+		WHERE ST_Intersects(d.geom, a.geom)
+		ORDER BY CASE d.district_type WHEN 'unified' THEN 0 WHEN 'elementary' THEN 1 ELSE 2 END, d.geoid
+		LIMIT 1
+	) sd ON true
+	WHERE a.geom IS NOT NULL
+)
+UPDATE public.company_addresses a
+SET school_district_geoid = t.geoid,
+	school_district_checked_at = now()
+FROM tagged t
+WHERE a.id = t.id`
+
+// TagCompanySchoolDistricts refreshes company_addresses school district
+// tags after an NCES sync and returns the number of addresses checked.
+func TagCompanySchoolDistricts(ctx context.Context, pool db.Pool) (int64, error) {
+	tag, err := pool.Exec(ctx, tagCompanySchoolDistrictsSQL)
+	if err != nil {
+		return 0, eris.Wrap(err, "nces: tag company addresses")
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,161 @@
+package postsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/notify"
+)
+
+type captureNotifier struct {
+	got []notify.Summary
+	err error
+}
+
+func (c *captureNotifier) Notify(_ context.Context, s notify.Summary) error {
+	c.got = append(c.got, s)
+	return c.err
+}
+
+func runBuiltin(t *testing.T, reg *Registry, name string, pool pgxmock.PgxPoolIface, tg Target) (int64, error) {
+	t.Helper()
+	h, err := reg.Get(name)
+	require.NoError(t, err)
+	return h.Run(context.Background(), pool, tg)
+}
+
+func TestAssignMSA(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`UPDATE geo\.locations l\s+SET cbsa_code = c\.cbsa_code`).
+		WithArgs("fed_data.adv_firms").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 25))
+
+	n, err := runBuiltin(t, Builtins(nil, nil), AssignMSA, mock, Target{Table: "fed_data.adv_firms"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), n)
+
+	_, err = runBuiltin(t, Builtins(nil, nil), AssignMSA, mock, Target{})
+	require.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagCompanyFloodZones(t *testing.T) {
+	assert.Contains(t, tagCompanyFloodZonesSQL, "ST_Intersects(f.geom, a.geom)")
+	assert.Contains(t, tagCompanyFloodZonesSQL, "WHERE a.geom IS NOT NULL")
+	assert.Contains(t, tagCompanyFloodZonesSQL, "WHEN 'high_risk' THEN 1")
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`UPDATE public\.company_addresses a\s+SET flood_zone = t\.zone_code`).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 12))
+	n, err := runBuiltin(t, Builtins(nil, nil), FloodTag, mock, Target{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+
+	mock.ExpectExec(`UPDATE public\.company_addresses`).WillReturnError(assert.AnError)
+	_, err = TagCompanyFloodZones(context.Background(), mock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "flood_zones: tag company addresses")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagCompanyHazards(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`UPDATE public\.company_addresses a\s+SET seismic_design_category = t\.sdc`).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 9))
+	n, err := runBuiltin(t, Builtins(nil, nil), HazardTag, mock, Target{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), n)
+
+	mock.ExpectExec(`UPDATE public\.company_addresses`).WillReturnError(assert.AnError)
+	_, err = TagCompanyHazards(context.Background(), mock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "usgs_hazards: tag company addresses")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagCompanySchoolDistricts(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(`UPDATE public\.company_addresses a\s+SET school_district_geoid = t\.geoid`).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 4))
+	n, err := runBuiltin(t, Builtins(nil, nil), SchoolDistrictTag, mock, Target{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+
+	mock.ExpectExec(`UPDATE public\.company_addresses`).WillReturnError(assert.AnError)
+	_, err = TagCompanySchoolDistricts(context.Background(), mock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nces: tag company addresses")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotifyHook(t *testing.T) {
+	_, err := runBuiltin(t, Builtins(nil, nil), Notify, nil, Target{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no notifier configured")
+
+	n := &captureNotifier{}
+	_, err = runBuiltin(t, Builtins(nil, n), Notify, nil, Target{Source: "fedsync", Dataset: "cbp", Rows: 80})
+	require.NoError(t, err)
+	require.Len(t, n.got, 1)
+	assert.Equal(t, "fedsync", n.got[0].Source)
+	assert.Equal(t, "cbp", n.got[0].Items[0].Name)
+	assert.Equal(t, int64(80), n.got[0].Items[0].Rows)
+
+	n.err = errors.New("webhook: 500")
+	_, err = runBuiltin(t, Builtins(nil, n), Notify, nil, Target{Dataset: "cbp"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notify: send summary")
+}
+
+func TestEnqueueGeocodeHook_NoQueue(t *testing.T) {
+	_, err := runBuiltin(t, Builtins(nil, nil), EnqueueGeocode, nil, Target{Table: "geo.hospitals"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no geocode queue configured")
+}
+
+func TestEnqueueTable_FedBridge(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`FROM fed_data\.adv_firms`).
+		WithArgs(enqueueLimit).
+		WillReturnRows(pgxmock.NewRows([]string{"crd_number", "address"}))
+
+	n, err := EnqueueTable(context.Background(), mock, geospatial.NewGeocodeQueue(mock, nil, 10), "fed_data.adv_firms")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnqueueTable_NoRows(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT source_id, address FROM "geo"\."hospitals"`).
+		WillReturnRows(pgxmock.NewRows([]string{"source_id", "address"}))
+
+	n, err := EnqueueTable(context.Background(), mock, geospatial.NewGeocodeQueue(mock, nil, 10), "geo.hospitals")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
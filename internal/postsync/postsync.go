@@ -0,0 +1,174 @@
+// Package postsync runs named follow-up hooks after a dataset sync. Hooks
+// such as enqueue_geocode, assign_msa, flood_tag, and notify live in a
+// Registry. Each fedsync dataset or geo scraper gets its hook list from the
+// postsync.hooks config, falling back to the hooks it declares, and every
+// execution is recorded in fed_data.sync_hooks.
+package postsync
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/notify"
+)
+
+// Target describes the synced dataset a hook runs for.
+type Target struct {
+	Source  string // "fedsync" or "geoscraper"
+	Dataset string // dataset or scraper name
+	Table   string // primary target table
+	SyncID  int64  // fed_data.sync_log id; 0 when the run is not logged
+	Rows    int64  // rows synced
+}
+
+// Hook is a named post-sync action. Run returns the number of rows it
+// affected.
+type Hook interface {
+	Name() string
+	Run(ctx context.Context, pool db.Pool, t Target) (int64, error)
+}
+
+// Declarer is an optional interface datasets and scrapers implement to
+// declare their default hooks. The postsync.hooks config overrides it.
+type Declarer interface {
+	PostSyncHooks() []string
+}
+
+// Declared returns the hooks v declares, or nil if it is not a Declarer.
+func Declared(v any) []string {
+	if d, ok := v.(Declarer); ok {
+		return d.PostSyncHooks()
+	}
+	return nil
+}
+
+// NewHook returns a Hook that calls fn.
+func NewHook(name string, fn func(ctx context.Context, pool db.Pool, t Target) (int64, error)) Hook {
+	return &hookFunc{name: name, fn: fn}
+}
+
+type hookFunc struct {
+	name string
+	fn   func(ctx context.Context, pool db.Pool, t Target) (int64, error)
+}
+
+func (h *hookFunc) Name() string { return h.name }
+
+func (h *hookFunc) Run(ctx context.Context, pool db.Pool, t Target) (int64, error) {
+	return h.fn(ctx, pool, t)
+}
+
+// Registry holds hooks by name.
+type Registry struct {
+	hooks map[string]Hook
+}
+
+// NewRegistry creates a Registry holding hooks.
+func NewRegistry(hooks ...Hook) *Registry {
+	r := &Registry{hooks: make(map[string]Hook, len(hooks))}
+	for _, h := range hooks {
+		r.Register(h)
+	}
+	return r
+}
+
+// Register adds h, replacing any hook with the same name.
+func (r *Registry) Register(h Hook) {
+	r.hooks[h.Name()] = h
+}
+
+// Get returns the named hook.
+func (r *Registry) Get(name string) (Hook, error) {
+	h, ok := r.hooks[name]
+	if !ok {
+		return nil, eris.Errorf("postsync: unknown hook %q", name)
+	}
+	return h, nil
+}
+
+// Names returns the registered hook names, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.hooks))
+	for name := range r.hooks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Runner runs each dataset's hooks after a sync and records the results.
+type Runner struct {
+	pool    db.Pool
+	reg     *Registry
+	syncLog *fedsync.SyncLog
+	hooks   map[string][]string
+}
+
+// NewRunner creates a Runner. hooks maps dataset names to hook lists from
+// config; syncLog, when set, records each execution.
+func NewRunner(pool db.Pool, reg *Registry, syncLog *fedsync.SyncLog, hooks map[string][]string) *Runner {
+	return &Runner{pool: pool, reg: reg, syncLog: syncLog, hooks: hooks}
+}
+
+// RunnerFromConfig creates a Runner with the built-in hooks, notifying
+// through cfg.Notify and taking hook lists from cfg.PostSync.
+func RunnerFromConfig(pool db.Pool, syncLog *fedsync.SyncLog, queue *geospatial.GeocodeQueue, cfg *config.Config) *Runner {
+	var notifier notify.Notifier
+	if d := notify.New(cfg.Notify); d != nil {
+		notifier = d
+	}
+	return NewRunner(pool, Builtins(queue, notifier), syncLog, cfg.PostSync.Hooks)
+}
+
+// Hooks returns the hook names for dataset: its configured list when the
+// config names the dataset (an empty list disables hooks), else defaults.
+func (r *Runner) Hooks(dataset string, defaults []string) []string {
+	if configured, ok := r.hooks[dataset]; ok {
+		return configured
+	}
+	return defaults
+}
+
+// Run runs the dataset's hooks in order. A failing hook is logged and
+// recorded but does not stop later hooks or fail the sync.
+func (r *Runner) Run(ctx context.Context, t Target, defaults []string) []fedsync.HookResult {
+	names := r.Hooks(t.Dataset, defaults)
+	if len(names) == 0 {
+		return nil
+	}
+	log := zap.L().With(zap.String("component", "postsync"), zap.String("dataset", t.Dataset))
+
+	results := make([]fedsync.HookResult, 0, len(names))
+	for _, name := range names {
+		start := time.Now()
+		res := fedsync.HookResult{Hook: name, Status: "complete"}
+		h, err := r.reg.Get(name)
+		if err == nil {
+			res.Rows, err = h.Run(ctx, r.pool, t)
+		}
+		res.Duration = time.Since(start)
+		if err != nil {
+			res.Status, res.Error = "failed", err.Error()
+			log.Warn("post-sync hook failed", zap.String("hook", name), zap.Error(err))
+		} else {
+			log.Info("post-sync hook complete", zap.String("hook", name),
+				zap.Int64("rows", res.Rows), zap.Duration("elapsed", res.Duration))
+		}
+		results = append(results, res)
+	}
+
+	if r.syncLog != nil && t.SyncID > 0 {
+		if err := r.syncLog.RecordHooks(ctx, t.SyncID, t.Dataset, results); err != nil {
+			log.Error("failed to record post-sync hooks", zap.Error(err))
+		}
+	}
+	return results
+}
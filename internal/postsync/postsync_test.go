@@ -0,0 +1,100 @@
+package postsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/fedsync"
+)
+
+type declaring struct{ hooks []string }
+
+func (d declaring) PostSyncHooks() []string { return d.hooks }
+
+func TestDeclared(t *testing.T) {
+	assert.Equal(t, []string{FloodTag}, Declared(declaring{hooks: []string{FloodTag}}))
+	assert.Nil(t, Declared(struct{}{}))
+}
+
+func TestRegistry(t *testing.T) {
+	reg := Builtins(nil, nil)
+	assert.Equal(t, []string{AssignMSA, EnqueueGeocode, FloodTag, HazardTag, Notify, SchoolDistrictTag}, reg.Names())
+
+	_, err := reg.Get("nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown hook "nope"`)
+
+	reg.Register(NewHook(Notify, func(context.Context, db.Pool, Target) (int64, error) { return 3, nil }))
+	h, err := reg.Get(Notify)
+	require.NoError(t, err)
+	n, err := h.Run(context.Background(), nil, Target{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
+
+func TestRunner_Hooks(t *testing.T) {
+	r := NewRunner(nil, NewRegistry(), nil, map[string][]string{
+		"fema_flood": {Notify},
+		"cbp":        {},
+	})
+	assert.Equal(t, []string{Notify}, r.Hooks("fema_flood", []string{FloodTag}))
+	assert.Empty(t, r.Hooks("cbp", []string{AssignMSA}))
+	assert.Equal(t, []string{AssignMSA}, r.Hooks("hifld", []string{AssignMSA}))
+}
+
+func TestRunner_Run(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	var ran []string
+	reg := NewRegistry(
+		NewHook("first", func(_ context.Context, _ db.Pool, tg Target) (int64, error) {
+			ran = append(ran, "first:"+tg.Table)
+			return 0, errors.New("boom")
+		}),
+		NewHook("second", func(context.Context, db.Pool, Target) (int64, error) {
+			ran = append(ran, "second")
+			return 42, nil
+		}),
+	)
+
+	mock.ExpectExec("INSERT INTO fed_data.sync_hooks").
+		WithArgs(int64(9), "hifld", "first", "failed", int64(0), pgxmock.AnyArg(), "boom").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_hooks").
+		WithArgs(int64(9), "hifld", "missing", "failed", int64(0), pgxmock.AnyArg(), `postsync: unknown hook "missing"`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO fed_data.sync_hooks").
+		WithArgs(int64(9), "hifld", "second", "complete", int64(42), pgxmock.AnyArg(), "").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	r := NewRunner(mock, reg, fedsync.NewSyncLog(mock), map[string][]string{
+		"hifld": {"first", "missing", "second"},
+	})
+	results := r.Run(context.Background(), Target{Source: "geoscraper", Dataset: "hifld", Table: "geo.infrastructure", SyncID: 9}, nil)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{"first:geo.infrastructure", "second"}, ran)
+	assert.Equal(t, "failed", results[0].Status)
+	assert.Equal(t, "failed", results[1].Status)
+	assert.Equal(t, "complete", results[2].Status)
+	assert.Equal(t, int64(42), results[2].Rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunner_Run_NoHooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	r := NewRunner(mock, Builtins(nil, nil), fedsync.NewSyncLog(mock), nil)
+	assert.Nil(t, r.Run(context.Background(), Target{Dataset: "cbp", SyncID: 1}, nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
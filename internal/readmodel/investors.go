@@ -0,0 +1,104 @@
+package readmodel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+type postgresInvestors struct {
+	pool db.Pool
+}
+
+// investorPositionSelect lists positions with the pipeline company (if any)
+// linked to the filer by CIK or CRD identifier.
+const investorPositionSelect = `
+	SELECT
+		p.cik, COALESCE(p.filer_name, ''), p.crd_number, c.company_id,
+		p.cusip, COALESCE(p.issuer_name, ''), p.period,
+		COALESCE(p.value, 0), COALESCE(p.shares, 0),
+		COALESCE(p.portfolio_weight, 0), COALESCE(p.position_rank, 0),
+		p.prior_value, p.value_change_pct, p.first_period
+	FROM fed_data.f13_investor_positions p
+	LEFT JOIN LATERAL (
+		SELECT ci.company_id
+		FROM company_identifiers ci
+		WHERE (ci.system = 'cik' AND ltrim(ci.identifier, '0') = ltrim(p.cik, '0'))
+		   OR (ci.system = 'crd' AND p.crd_number IS NOT NULL AND ci.identifier = p.crd_number::text)
+		ORDER BY ci.company_id
+		LIMIT 1
+	) c ON true`
+
+// IssuerHolders implements InvestorsReader.
+func (p *postgresInvestors) IssuerHolders(ctx context.Context, q IssuerHoldersQuery) ([]InvestorPosition, error) {
+	issuer := strings.TrimSpace(q.Issuer)
+	if issuer == "" {
+		return nil, eris.New("readmodel: issuer is required")
+	}
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	rows, err := p.pool.Query(ctx, investorPositionSelect+`
+		WHERE (p.cusip = upper($1) OR p.issuer_name ILIKE '%' || $1 || '%')
+		  AND p.period = COALESCE($2::date, (
+			SELECT MAX(period) FROM fed_data.f13_investor_positions
+			WHERE cusip = upper($1) OR issuer_name ILIKE '%' || $1 || '%'))
+		  AND COALESCE(p.portfolio_weight, 0) >= $3
+		  AND (NOT $4::bool OR p.crd_number IS NOT NULL)
+		  AND (NOT $5::bool OR c.company_id IS NOT NULL)
+		ORDER BY p.portfolio_weight DESC NULLS LAST, p.value DESC NULLS LAST
+		LIMIT $6`,
+		issuer, q.Period, q.MinWeight, q.RIAsOnly, q.PipelineOnly, q.Limit,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "readmodel: issuer holders")
+	}
+	return scanInvestorPositions(rows)
+}
+
+// FilerPositions implements InvestorsReader.
+func (p *postgresInvestors) FilerPositions(ctx context.Context, cik string, period *time.Time, limit int) ([]InvestorPosition, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := p.pool.Query(ctx, investorPositionSelect+`
+		WHERE p.cik = $1
+		  AND p.period = COALESCE($2::date, (
+			SELECT MAX(period) FROM fed_data.f13_investor_positions WHERE cik = $1))
+		ORDER BY p.position_rank, p.cusip
+		LIMIT $3`,
+		cik, period, limit,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "readmodel: filer positions")
+	}
+	return scanInvestorPositions(rows)
+}
+
+func scanInvestorPositions(rows pgx.Rows) ([]InvestorPosition, error) {
+	defer rows.Close()
+
+	positions := []InvestorPosition{}
+	for rows.Next() {
+		var pos InvestorPosition
+		if err := rows.Scan(
+			&pos.CIK, &pos.FilerName, &pos.CRDNumber, &pos.CompanyID,
+			&pos.CUSIP, &pos.IssuerName, &pos.Period,
+			&pos.Value, &pos.Shares,
+			&pos.PortfolioWeight, &pos.PositionRank,
+			&pos.PriorValue, &pos.ValueChangePct, &pos.FirstPeriod,
+		); err != nil {
+			return nil, eris.Wrap(err, "readmodel: scan investor position")
+		}
+		pos.CUSIP = strings.TrimSpace(pos.CUSIP)
+		positions = append(positions, pos)
+	}
+	return positions, eris.Wrap(rows.Err(), "readmodel: iterate investor positions")
+}
@@ -0,0 +1,244 @@
+package readmodel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/sells-group/research-cli/internal/company"
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+const (
+	// profileFedDataRowLimit caps the fed_data rows returned per match;
+	// sources like form_5500 and usaspending_awards hold one row per filing.
+	profileFedDataRowLimit = 25
+	// profileRunLimit caps the runs listed in a profile.
+	profileRunLimit = 50
+)
+
+// profileFedDataSources maps company_matches.matched_source (also the
+// fed_data table name) to the predicate selecting rows for a matched key
+// and the order in which they are listed.
+var profileFedDataSources = map[string]struct{ where, order string }{
+	"adv_firms":          {"crd_number::text = $1", "crd_number"},
+	"edgar_entities":     {"cik = $1", "cik"},
+	"eo_bmf":             {"ein = $1", "ein"},
+	"fdic_institutions":  {"cert::text = $1", "cert"},
+	"form_5500":          {"spons_dfe_ein = $1", "ack_id DESC"},
+	"ncua_call_reports":  {"cu_number::text = $1", "cycle_date DESC"},
+	"sba_loans":          {"l2locid::text = $1", "approvalfiscalyear DESC NULLS LAST"},
+	"usaspending_awards": {"(recipient_uei = $1 OR recipient_duns = $1)", "award_latest_action_date DESC NULLS LAST"},
+}
+
+type postgresProfiles struct {
+	pool         db.Pool
+	companyStore company.CompanyStore
+}
+
+// CompanyProfile implements ProfilesReader. Returns nil when no company
+// matches the lookup.
+func (p *postgresProfiles) CompanyProfile(ctx context.Context, lookup ProfileLookup) (*CompanyProfile, error) {
+	comp, err := p.findCompany(ctx, lookup)
+	if err != nil || comp == nil {
+		return nil, err
+	}
+	id := comp.ID
+
+	profile := &CompanyProfile{Company: *comp, GeneratedAt: time.Now().UTC()}
+	if profile.Identifiers, err = p.companyStore.GetIdentifiers(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.Addresses, err = p.companyStore.GetAddresses(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.MSAs, err = p.companyStore.GetCompanyMSAs(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.Contacts, err = p.companyStore.GetContacts(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.Licenses, err = p.companyStore.GetLicenses(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.Financials, err = p.companyStore.GetFinancials(ctx, id, ""); err != nil {
+		return nil, err
+	}
+	if profile.Tags, err = p.companyStore.GetTags(ctx, id); err != nil {
+		return nil, err
+	}
+	if profile.Sources, err = p.companyStore.GetSources(ctx, id); err != nil {
+		return nil, err
+	}
+
+	matches, err := p.companyStore.GetMatches(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.FedData, err = p.fedData(ctx, matches); err != nil {
+		return nil, err
+	}
+
+	urls := profileCompanyURLs(comp)
+	if profile.Answers, err = p.answers(ctx, urls); err != nil {
+		return nil, err
+	}
+	if profile.Runs, err = p.runs(ctx, urls); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (p *postgresProfiles) findCompany(ctx context.Context, lookup ProfileLookup) (*company.CompanyRecord, error) {
+	switch {
+	case lookup.CompanyID > 0:
+		return p.companyStore.GetCompany(ctx, lookup.CompanyID)
+	case strings.TrimSpace(lookup.Domain) != "":
+		return p.companyStore.GetCompanyByDomain(ctx, strings.ToLower(strings.TrimSpace(lookup.Domain)))
+	case lookup.System != "" && lookup.Identifier != "":
+		return p.companyStore.FindByIdentifier(ctx, lookup.System, lookup.Identifier)
+	default:
+		return nil, eris.New("readmodel: company id, domain, or system and identifier is required")
+	}
+}
+
+// fedData loads the fed_data rows behind each company match. Matches from
+// sources without a known key mapping are returned without rows.
+func (p *postgresProfiles) fedData(ctx context.Context, matches []company.Match) ([]ProfileFedData, error) {
+	out := make([]ProfileFedData, 0, len(matches))
+	for _, m := range matches {
+		fd := ProfileFedData{
+			Source:     m.MatchedSource,
+			Key:        m.MatchedKey,
+			MatchType:  m.MatchType,
+			Confidence: m.Confidence,
+			MatchedAt:  m.CreatedAt,
+			Rows:       []map[string]any{},
+		}
+		if src, ok := profileFedDataSources[m.MatchedSource]; ok {
+			query := fmt.Sprintf(`SELECT row_to_json(t) FROM fed_data.%s t WHERE %s ORDER BY %s LIMIT $2`,
+				m.MatchedSource, src.where, src.order)
+			rows, err := p.pool.Query(ctx, query, m.MatchedKey, profileFedDataRowLimit)
+			if err != nil {
+				return nil, eris.Wrapf(err, "readmodel: query %s rows", m.MatchedSource)
+			}
+			for rows.Next() {
+				var payload []byte
+				if err := rows.Scan(&payload); err != nil {
+					rows.Close()
+					return nil, eris.Wrapf(err, "readmodel: scan %s row", m.MatchedSource)
+				}
+				var row map[string]any
+				if err := json.Unmarshal(payload, &row); err != nil {
+					rows.Close()
+					return nil, eris.Wrapf(err, "readmodel: unmarshal %s row", m.MatchedSource)
+				}
+				fd.Rows = append(fd.Rows, row)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, eris.Wrapf(err, "readmodel: iterate %s rows", m.MatchedSource)
+			}
+		}
+		out = append(out, fd)
+	}
+	return out, nil
+}
+
+// answers returns the most recent provenance record for each field
+// extracted for any of the company's URLs.
+func (p *postgresProfiles) answers(ctx context.Context, urls []string) ([]ProfileAnswer, error) {
+	answers := []ProfileAnswer{}
+	if len(urls) == 0 {
+		return answers, nil
+	}
+
+	rows, err := p.pool.Query(ctx, `
+		SELECT DISTINCT ON (field_key)
+			field_key, COALESCE(winner_value, ''), COALESCE(winner_source, ''),
+			COALESCE(effective_confidence, 0)::float8, threshold_met, data_as_of,
+			COALESCE(run_id, ''), created_at
+		FROM field_provenance
+		WHERE company_url = ANY($1)
+		ORDER BY field_key, created_at DESC`,
+		urls,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "readmodel: query field provenance")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a ProfileAnswer
+		if err := rows.Scan(&a.FieldKey, &a.Value, &a.Source, &a.Confidence,
+			&a.ThresholdMet, &a.DataAsOf, &a.RunID, &a.ExtractedAt); err != nil {
+			return nil, eris.Wrap(err, "readmodel: scan field provenance")
+		}
+		answers = append(answers, a)
+	}
+	return answers, eris.Wrap(rows.Err(), "readmodel: iterate field provenance")
+}
+
+// runs lists the company's enrichment runs, newest first.
+func (p *postgresProfiles) runs(ctx context.Context, urls []string) ([]ProfileRun, error) {
+	runs := []ProfileRun{}
+	if len(urls) == 0 {
+		return runs, nil
+	}
+
+	rows, err := p.pool.Query(ctx, `
+		SELECT id, status, (result->>'score')::float8, COALESCE(error->>'message', ''),
+			created_at, updated_at
+		FROM runs
+		WHERE company->>'url' = ANY($1)
+		ORDER BY created_at DESC
+		LIMIT $2`,
+		urls, profileRunLimit,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "readmodel: query company runs")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r ProfileRun
+		if err := rows.Scan(&r.ID, &r.Status, &r.Score, &r.Error, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, eris.Wrap(err, "readmodel: scan company run")
+		}
+		runs = append(runs, r)
+	}
+	return runs, eris.Wrap(rows.Err(), "readmodel: iterate company runs")
+}
+
+// profileCompanyURLs returns the URL spellings runs and provenance may be
+// keyed by for the company's website and domain.
+func profileCompanyURLs(c *company.CompanyRecord) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	add := func(u string) {
+		if u != "" && !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	for _, raw := range []string{c.Website, c.Domain} {
+		raw = strings.TrimSpace(strings.TrimRight(raw, "/"))
+		if raw == "" {
+			continue
+		}
+		if strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://") {
+			add(raw)
+			add(raw + "/")
+			continue
+		}
+		for _, scheme := range []string{"https://", "http://"} {
+			add(scheme + raw)
+			add(scheme + raw + "/")
+		}
+	}
+	return urls
+}
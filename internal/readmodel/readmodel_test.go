@@ -2,6 +2,7 @@ package readmodel
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sells-group/research-cli/internal/company"
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 )
@@ -154,7 +156,7 @@ func TestPostgresFedsync_ListDatasetStatuses(t *testing.T) {
 
 	statuses, err := reader.ListDatasetStatuses(context.Background())
 	require.NoError(t, err)
-	require.Len(t, statuses, 42)
+	require.Len(t, statuses, 81)
 
 	var cbpStatus *DatasetStatus
 	for i := range statuses {
@@ -172,3 +174,183 @@ func TestPostgresFedsync_ListDatasetStatuses(t *testing.T) {
 	assert.Equal(t, now.AddDate(1, 0, 0), *cbpStatus.NextDue)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPostgresInvestors_IssuerHolders(t *testing.T) {
+	mock := newMockPool(t)
+	reader := &postgresInvestors{pool: mock}
+
+	period := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+	first := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	crd := 123456
+	companyID := int64(42)
+	prior := int64(800)
+	change := 0.25
+
+	mock.ExpectQuery(`FROM fed_data.f13_investor_positions p`).
+		WithArgs("nvidia", (*time.Time)(nil), 0.05, true, true, 100).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"cik", "filer_name", "crd_number", "company_id", "cusip", "issuer_name", "period",
+			"value", "shares", "portfolio_weight", "position_rank", "prior_value", "value_change_pct", "first_period",
+		}).AddRow("0001234567", "Acme Wealth", &crd, &companyID, "67066G104", "NVIDIA CORP", period,
+			int64(1000), int64(50), 0.12, 1, &prior, &change, first))
+
+	holders, err := reader.IssuerHolders(context.Background(), IssuerHoldersQuery{
+		Issuer:       "nvidia",
+		MinWeight:    0.05,
+		RIAsOnly:     true,
+		PipelineOnly: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	assert.Equal(t, "Acme Wealth", holders[0].FilerName)
+	assert.Equal(t, 123456, *holders[0].CRDNumber)
+	assert.Equal(t, int64(42), *holders[0].CompanyID)
+	assert.InDelta(t, 0.12, holders[0].PortfolioWeight, 0.0001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresInvestors_IssuerHolders_RequiresIssuer(t *testing.T) {
+	reader := &postgresInvestors{pool: newMockPool(t)}
+	_, err := reader.IssuerHolders(context.Background(), IssuerHoldersQuery{Issuer: "  "})
+	require.Error(t, err)
+}
+
+func TestPostgresInvestors_FilerPositions(t *testing.T) {
+	mock := newMockPool(t)
+	reader := &postgresInvestors{pool: mock}
+
+	mock.ExpectQuery(`WHERE p.cik = \$1`).
+		WithArgs("0001234567", (*time.Time)(nil), 25).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"cik", "filer_name", "crd_number", "company_id", "cusip", "issuer_name", "period",
+			"value", "shares", "portfolio_weight", "position_rank", "prior_value", "value_change_pct", "first_period",
+		}))
+
+	positions, err := reader.FilerPositions(context.Background(), "0001234567", nil, 25)
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+	assert.NotNil(t, positions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// fakeProfileCompanyStore serves the company-store reads used by profiles.
+type fakeProfileCompanyStore struct {
+	company.CompanyStore
+	record  *company.CompanyRecord
+	matches []company.Match
+	lookups []string
+}
+
+func (f *fakeProfileCompanyStore) GetCompany(_ context.Context, id int64) (*company.CompanyRecord, error) {
+	f.lookups = append(f.lookups, fmt.Sprintf("id:%d", id))
+	return f.record, nil
+}
+
+func (f *fakeProfileCompanyStore) GetCompanyByDomain(_ context.Context, domain string) (*company.CompanyRecord, error) {
+	f.lookups = append(f.lookups, "domain:"+domain)
+	return f.record, nil
+}
+
+func (f *fakeProfileCompanyStore) FindByIdentifier(_ context.Context, system, identifier string) (*company.CompanyRecord, error) {
+	f.lookups = append(f.lookups, system+":"+identifier)
+	return f.record, nil
+}
+
+func (f *fakeProfileCompanyStore) GetIdentifiers(context.Context, int64) ([]company.Identifier, error) {
+	return []company.Identifier{{System: company.SystemCRD, Identifier: "123456"}}, nil
+}
+
+func (f *fakeProfileCompanyStore) GetAddresses(context.Context, int64) ([]company.Address, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetCompanyMSAs(context.Context, int64) ([]company.AddressMSA, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetContacts(context.Context, int64) ([]company.Contact, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetLicenses(context.Context, int64) ([]company.License, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetFinancials(context.Context, int64, string) ([]company.Financial, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetTags(context.Context, int64) ([]company.Tag, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetSources(context.Context, int64) ([]company.Source, error) {
+	return nil, nil
+}
+
+func (f *fakeProfileCompanyStore) GetMatches(context.Context, int64) ([]company.Match, error) {
+	return f.matches, nil
+}
+
+func TestPostgresProfiles_CompanyProfile(t *testing.T) {
+	mock := newMockPool(t)
+	store := &fakeProfileCompanyStore{
+		record: &company.CompanyRecord{ID: 42, Name: "Acme Advisors", Domain: "acme.com"},
+		matches: []company.Match{
+			{MatchedSource: "adv_firms", MatchedKey: "123456", MatchType: "direct_crd"},
+			{MatchedSource: "unknown_source", MatchedKey: "x"},
+		},
+	}
+	reader := &postgresProfiles{pool: mock, companyStore: store}
+
+	urls := []string{"https://acme.com", "https://acme.com/", "http://acme.com", "http://acme.com/"}
+	extracted := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	score := 0.82
+
+	mock.ExpectQuery(`FROM fed_data.adv_firms t WHERE crd_number::text = \$1`).
+		WithArgs("123456", profileFedDataRowLimit).
+		WillReturnRows(pgxmock.NewRows([]string{"row_to_json"}).
+			AddRow([]byte(`{"crd_number":123456,"firm_name":"ACME ADVISORS LLC"}`)))
+	mock.ExpectQuery(`FROM field_provenance`).
+		WithArgs(urls).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"field_key", "winner_value", "winner_source", "effective_confidence",
+			"threshold_met", "data_as_of", "run_id", "created_at",
+		}).AddRow("employees", "45", "linkedin", 0.9, true, (*time.Time)(nil), "run-1", extracted))
+	mock.ExpectQuery(`FROM runs`).
+		WithArgs(urls, profileRunLimit).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "status", "score", "error", "created_at", "updated_at"}).
+			AddRow("run-1", "complete", &score, "", extracted, extracted))
+
+	profile, err := reader.CompanyProfile(context.Background(), ProfileLookup{Domain: " ACME.com "})
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	assert.Equal(t, []string{"domain:acme.com"}, store.lookups)
+	assert.Equal(t, "Acme Advisors", profile.Company.Name)
+	require.Len(t, profile.Identifiers, 1)
+
+	require.Len(t, profile.FedData, 2)
+	assert.Equal(t, "adv_firms", profile.FedData[0].Source)
+	require.Len(t, profile.FedData[0].Rows, 1)
+	assert.Equal(t, "ACME ADVISORS LLC", profile.FedData[0].Rows[0]["firm_name"])
+	assert.Empty(t, profile.FedData[1].Rows)
+
+	require.Len(t, profile.Answers, 1)
+	assert.Equal(t, "linkedin", profile.Answers[0].Source)
+	require.Len(t, profile.Runs, 1)
+	assert.InDelta(t, 0.82, *profile.Runs[0].Score, 0.0001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresProfiles_CompanyProfile_Lookup(t *testing.T) {
+	store := &fakeProfileCompanyStore{}
+	reader := &postgresProfiles{pool: newMockPool(t), companyStore: store}
+
+	profile, err := reader.CompanyProfile(context.Background(), ProfileLookup{System: "crd", Identifier: "123456"})
+	require.NoError(t, err)
+	assert.Nil(t, profile, "unknown company")
+	assert.Equal(t, []string{"crd:123456"}, store.lookups)
+
+	_, err = reader.CompanyProfile(context.Background(), ProfileLookup{System: "crd"})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package readmodel
 
 import (
 	"context"
+	"time"
 
 	"github.com/sells-group/research-cli/internal/company"
 	"github.com/sells-group/research-cli/internal/config"
@@ -48,12 +49,25 @@ type FedsyncReader interface {
 	ListSyncEntries(ctx context.Context) ([]fedsync.SyncEntry, error)
 }
 
+// InvestorsReader serves 13F investor relationship graph queries.
+type InvestorsReader interface {
+	IssuerHolders(ctx context.Context, q IssuerHoldersQuery) ([]InvestorPosition, error)
+	FilerPositions(ctx context.Context, cik string, period *time.Time, limit int) ([]InvestorPosition, error)
+}
+
+// ProfilesReader assembles the unified company profile used by the review UI.
+type ProfilesReader interface {
+	CompanyProfile(ctx context.Context, lookup ProfileLookup) (*CompanyProfile, error)
+}
+
 // Service groups the read-side query services used by the API.
 type Service struct {
 	Companies CompaniesReader
 	Data      DataReader
 	Analytics AnalyticsReader
 	Fedsync   FedsyncReader
+	Investors InvestorsReader
+	Profiles  ProfilesReader
 }
 
 // NewPostgresService creates a Postgres-backed readmodel service bundle.
@@ -80,5 +94,12 @@ func NewPostgresService(pool db.Pool, cfg *config.Config) *Service {
 			registry: newRegistry(cfg),
 			syncLog:  fedsync.NewSyncLog(pool),
 		},
+		Investors: &postgresInvestors{
+			pool: pool,
+		},
+		Profiles: &postgresProfiles{
+			pool:         pool,
+			companyStore: companyStore,
+		},
 	}
 }
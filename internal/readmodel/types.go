@@ -1,6 +1,10 @@
 package readmodel
 
-import "time"
+import (
+	"time"
+
+	"github.com/sells-group/research-cli/internal/company"
+)
 
 // CompaniesFilter defines list companies pagination and search criteria.
 type CompaniesFilter struct {
@@ -162,3 +166,90 @@ type CostBreakdownRow struct {
 	Cost   float64 `json:"cost"`
 	Tokens int64   `json:"tokens"`
 }
+
+// IssuerHoldersQuery selects 13F filers holding an issuer in one period.
+type IssuerHoldersQuery struct {
+	Issuer       string     // CUSIP or issuer name fragment
+	Period       *time.Time // nil = latest period with holdings of the issuer
+	MinWeight    float64    // minimum share of the filer's 13F book (0-1)
+	RIAsOnly     bool       // only filers cross-referenced to an ADV CRD
+	PipelineOnly bool       // only filers linked to a company in our pipeline
+	Limit        int
+}
+
+// InvestorPosition is one filer's 13F position in an issuer for a period.
+type InvestorPosition struct {
+	CIK             string    `json:"cik"`
+	FilerName       string    `json:"filer_name"`
+	CRDNumber       *int      `json:"crd_number,omitempty"`
+	CompanyID       *int64    `json:"company_id,omitempty"`
+	CUSIP           string    `json:"cusip"`
+	IssuerName      string    `json:"issuer_name"`
+	Period          time.Time `json:"period"`
+	Value           int64     `json:"value"`
+	Shares          int64     `json:"shares"`
+	PortfolioWeight float64   `json:"portfolio_weight"`
+	PositionRank    int       `json:"position_rank"`
+	PriorValue      *int64    `json:"prior_value,omitempty"`
+	ValueChangePct  *float64  `json:"value_change_pct,omitempty"`
+	FirstPeriod     time.Time `json:"first_period"`
+}
+
+// ProfileLookup identifies the company whose profile is requested. The first
+// non-empty of CompanyID, Domain, or System+Identifier is used.
+type ProfileLookup struct {
+	CompanyID  int64
+	Domain     string
+	System     string // identifier system, e.g. "crd", "cik", "ein"
+	Identifier string
+}
+
+// CompanyProfile is everything stored about one company: the golden record,
+// geo data, linked fed_data rows, extracted answers, and pipeline runs.
+type CompanyProfile struct {
+	Company     company.CompanyRecord `json:"company"`
+	Identifiers []company.Identifier  `json:"identifiers"`
+	Addresses   []company.Address     `json:"addresses"`
+	MSAs        []company.AddressMSA  `json:"msas"`
+	Contacts    []company.Contact     `json:"contacts"`
+	Licenses    []company.License     `json:"licenses"`
+	Financials  []company.Financial   `json:"financials"`
+	Tags        []company.Tag         `json:"tags"`
+	Sources     []company.Source      `json:"sources"`
+	FedData     []ProfileFedData      `json:"fed_data"`
+	Answers     []ProfileAnswer       `json:"answers"`
+	Runs        []ProfileRun          `json:"runs"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// ProfileFedData is a company_matches link with the fed_data rows it points to.
+type ProfileFedData struct {
+	Source     string           `json:"source"`
+	Key        string           `json:"key"`
+	MatchType  string           `json:"match_type"`
+	Confidence *float64         `json:"confidence,omitempty"`
+	MatchedAt  time.Time        `json:"matched_at"`
+	Rows       []map[string]any `json:"rows"`
+}
+
+// ProfileAnswer is the latest extracted value for one field.
+type ProfileAnswer struct {
+	FieldKey     string     `json:"field_key"`
+	Value        string     `json:"value"`
+	Source       string     `json:"source"`
+	Confidence   float64    `json:"confidence"`
+	ThresholdMet bool       `json:"threshold_met"`
+	DataAsOf     *time.Time `json:"data_as_of,omitempty"`
+	RunID        string     `json:"run_id"`
+	ExtractedAt  time.Time  `json:"extracted_at"`
+}
+
+// ProfileRun summarizes one enrichment run for the company.
+type ProfileRun struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	Score     *float64  `json:"score,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -84,6 +84,7 @@ type scoringRow struct {
 	HNWRevenuePct        *float64
 	InstitutionalRevPct  *float64
 	AcquisitionReadiness *int16
+	Momentum             *float64 // fed_data.firm_momentum, 0-1
 
 	// Document text for keyword search.
 	BrochureText string
@@ -200,6 +201,7 @@ func computeScore(row *scoringRow, cfg config.ScorerConfig, acquirerCentroids []
 		"industry_match":    scoreIndustryMatch(cfg.IndustryKeywords, row.BrochureText, row.CRSText),
 		"regulatory_clean":  scoreRegulatoryClean(row.HasAnyDRP, row.DRPCriminalFirm, row.DRPRegulatoryFirm),
 		"succession_signal": scoreSuccessionSignal(cfg.SuccessionKeywords, row.BrochureText, row.CRSText),
+		"momentum":          scoreMomentum(row.Momentum),
 	}
 
 	weights := map[string]float64{
@@ -211,6 +213,7 @@ func computeScore(row *scoringRow, cfg config.ScorerConfig, acquirerCentroids []
 		"industry_match":    cfg.IndustryMatchWeight,
 		"regulatory_clean":  cfg.RegulatoryCleanWeight,
 		"succession_signal": cfg.SuccessionSignalWeight,
+		"momentum":          cfg.MomentumWeight,
 	}
 
 	weightSum := WeightSum(cfg)
@@ -307,6 +310,14 @@ func scoreGrowth(cagr *float64) float64 {
 	}
 }
 
+// scoreMomentum returns the firm's 0.0-1.0 momentum from the trend tables.
+func scoreMomentum(momentum *float64) float64 {
+	if momentum == nil {
+		return 0.5 // neutral when trends are unavailable
+	}
+	return math.Max(0, math.Min(*momentum, 1))
+}
+
 // scoreClientQuality returns 0.0-1.0 based on HNW concentration and client mix.
 func scoreClientQuality(hnwPct, instPct *float64, clientTypes []string) float64 {
 	var score float64
@@ -674,6 +685,7 @@ SELECT
     cm.hnw_revenue_pct,
     cm.institutional_revenue_pct,
     cm.acquisition_readiness,
+    mo.momentum::double precision,
     COALESCE(lb.text_content, ''),
     COALESCE(lc.text_content, ''),
     fm.latitude,
@@ -696,6 +708,7 @@ SELECT
     AND cm2.matched_source = 'adv_firms') AS all_msas
 FROM fed_data.mv_firm_combined fc
 LEFT JOIN fed_data.adv_computed_metrics cm ON cm.crd_number = fc.crd_number
+LEFT JOIN fed_data.firm_momentum mo ON mo.crd_number = fc.crd_number
 LEFT JOIN latest_brochure lb ON lb.crd_number = fc.crd_number
 LEFT JOIN latest_crs lc ON lc.crd_number = fc.crd_number
 LEFT JOIN public.v_firm_msa fm ON fm.crd_number = fc.crd_number
@@ -811,6 +824,7 @@ SELECT
     cm.hnw_revenue_pct,
     cm.institutional_revenue_pct,
     cm.acquisition_readiness,
+    mo.momentum::double precision,
     COALESCE((SELECT text_content FROM latest_brochure), ''),
     COALESCE((SELECT text_content FROM latest_crs), ''),
     fm.latitude,
@@ -833,6 +847,7 @@ SELECT
     AND cm2.matched_source = 'adv_firms') AS all_msas
 FROM fed_data.mv_firm_combined fc
 LEFT JOIN fed_data.adv_computed_metrics cm ON cm.crd_number = fc.crd_number
+LEFT JOIN fed_data.firm_momentum mo ON mo.crd_number = fc.crd_number
 LEFT JOIN public.v_firm_msa fm ON fm.crd_number = fc.crd_number
 WHERE fc.crd_number = $1`
 
@@ -883,6 +898,7 @@ func scanScoringRows(rows pgx.Rows) ([]scoringRow, error) {
 			&sr.HNWRevenuePct,
 			&sr.InstitutionalRevPct,
 			&sr.AcquisitionReadiness,
+			&sr.Momentum,
 			&sr.BrochureText,
 			&sr.CRSText,
 			&sr.Latitude,
@@ -942,6 +958,7 @@ func scanOneRow(row pgx.Row, sr *scoringRow) error {
 		&sr.HNWRevenuePct,
 		&sr.InstitutionalRevPct,
 		&sr.AcquisitionReadiness,
+		&sr.Momentum,
 		&sr.BrochureText,
 		&sr.CRSText,
 		&sr.Latitude,
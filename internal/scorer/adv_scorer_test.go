@@ -65,6 +65,13 @@ func TestScoreGrowth(t *testing.T) {
 	}
 }
 
+func TestScoreMomentum(t *testing.T) {
+	assert.Equal(t, 0.5, scoreMomentum(nil))
+	assert.InDelta(t, 0.82, scoreMomentum(ptrFloat64(0.82)), 0.001)
+	assert.Equal(t, 1.0, scoreMomentum(ptrFloat64(1.4)))
+	assert.Equal(t, 0.0, scoreMomentum(ptrFloat64(-0.1)))
+}
+
 func TestScoreClientQuality(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -17,13 +17,14 @@ func DefaultScorerConfig() config.ScorerConfig {
 	return config.ScorerConfig{
 		// Weights (sum = 100).
 		AUMFitWeight:           25,
-		GrowthWeight:           10,
+		GrowthWeight:           5,
 		ClientQualityWeight:    15,
 		ServiceFitWeight:       10,
 		GeoMatchWeight:         10,
 		IndustryMatchWeight:    10,
 		RegulatoryCleanWeight:  10,
 		SuccessionSignalWeight: 10,
+		MomentumWeight:         5,
 
 		// Target ranges.
 		MinAUM:       100_000_000,   // $100M
@@ -51,7 +52,7 @@ func DefaultScorerConfig() config.ScorerConfig {
 func WeightSum(c config.ScorerConfig) float64 {
 	return c.AUMFitWeight + c.GrowthWeight + c.ClientQualityWeight +
 		c.ServiceFitWeight + c.GeoMatchWeight + c.IndustryMatchWeight +
-		c.RegulatoryCleanWeight + c.SuccessionSignalWeight
+		c.RegulatoryCleanWeight + c.SuccessionSignalWeight + c.MomentumWeight
 }
 
 // ValidateConfig checks that a ScorerConfig is internally consistent.
@@ -68,6 +69,7 @@ func ValidateConfig(c config.ScorerConfig) error {
 		"industry_match_weight":    c.IndustryMatchWeight,
 		"regulatory_clean_weight":  c.RegulatoryCleanWeight,
 		"succession_signal_weight": c.SuccessionSignalWeight,
+		"momentum_weight":          c.MomentumWeight,
 	}
 	for name, w := range weights {
 		if w < 0 {
@@ -177,6 +177,121 @@ func (_c *MockStore_CountDLQ_Call) RunAndReturn(run func(context.Context) (int,
 	return _c
 }
 
+// CountRuns provides a mock function with given fields: ctx, filter
+func (_m *MockStore) CountRuns(ctx context.Context, filter store.RunFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRuns")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.RunFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.RunFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.RunFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRuns'
+type MockStore_CountRuns_Call struct {
+	*mock.Call
+}
+
+// CountRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter store.RunFilter
+func (_e *MockStore_Expecter) CountRuns(ctx interface{}, filter interface{}) *MockStore_CountRuns_Call {
+	return &MockStore_CountRuns_Call{Call: _e.mock.On("CountRuns", ctx, filter)}
+}
+
+func (_c *MockStore_CountRuns_Call) Run(run func(ctx context.Context, filter store.RunFilter)) *MockStore_CountRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(store.RunFilter))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountRuns_Call) Return(_a0 int, _a1 error) *MockStore_CountRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountRuns_Call) RunAndReturn(run func(context.Context, store.RunFilter) (int, error)) *MockStore_CountRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountRunsByStatus provides a mock function with given fields: ctx
+func (_m *MockStore) CountRunsByStatus(ctx context.Context) (map[string]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRunsByStatus")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountRunsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRunsByStatus'
+type MockStore_CountRunsByStatus_Call struct {
+	*mock.Call
+}
+
+// CountRunsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) CountRunsByStatus(ctx interface{}) *MockStore_CountRunsByStatus_Call {
+	return &MockStore_CountRunsByStatus_Call{Call: _e.mock.On("CountRunsByStatus", ctx)}
+}
+
+func (_c *MockStore_CountRunsByStatus_Call) Run(run func(ctx context.Context)) *MockStore_CountRunsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountRunsByStatus_Call) Return(_a0 map[string]int, _a1 error) *MockStore_CountRunsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountRunsByStatus_Call) RunAndReturn(run func(context.Context) (map[string]int, error)) *MockStore_CountRunsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePhase provides a mock function with given fields: ctx, runID, name
 func (_m *MockStore) CreatePhase(ctx context.Context, runID string, name string) (*model.RunPhase, error) {
 	ret := _m.Called(ctx, runID, name)
@@ -842,7 +957,7 @@ func (_c *MockStore_GetCachedScrape_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetHighConfidenceAnswers provides a mock function with given fields: ctx, companyURL, minConfidence
+// GetHighConfidenceAnswers provides a mock function with given fields: ctx, companyURL, minConfidence, maxAge
 func (_m *MockStore) GetHighConfidenceAnswers(ctx context.Context, companyURL string, minConfidence float64, maxAge time.Duration) ([]model.ExtractionAnswer, error) {
 	ret := _m.Called(ctx, companyURL, minConfidence, maxAge)
 
@@ -962,65 +1077,6 @@ func (_c *MockStore_GetLatestProvenance_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// ListStaleCompanies provides a mock function with given fields: ctx, filter
-func (_m *MockStore) ListStaleCompanies(ctx context.Context, filter store.StaleCompanyFilter) ([]store.StaleCompany, error) {
-	ret := _m.Called(ctx, filter)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ListStaleCompanies")
-	}
-
-	var r0 []store.StaleCompany
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.StaleCompanyFilter) ([]store.StaleCompany, error)); ok {
-		return rf(ctx, filter)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.StaleCompanyFilter) []store.StaleCompany); ok {
-		r0 = rf(ctx, filter)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]store.StaleCompany)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, store.StaleCompanyFilter) error); ok {
-		r1 = rf(ctx, filter)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockStore_ListStaleCompanies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStaleCompanies'
-type MockStore_ListStaleCompanies_Call struct {
-	*mock.Call
-}
-
-// ListStaleCompanies is a helper method to define mock.On call
-//   - ctx context.Context
-//   - filter store.StaleCompanyFilter
-func (_e *MockStore_Expecter) ListStaleCompanies(ctx interface{}, filter interface{}) *MockStore_ListStaleCompanies_Call {
-	return &MockStore_ListStaleCompanies_Call{Call: _e.mock.On("ListStaleCompanies", ctx, filter)}
-}
-
-func (_c *MockStore_ListStaleCompanies_Call) Run(run func(ctx context.Context, filter store.StaleCompanyFilter)) *MockStore_ListStaleCompanies_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.StaleCompanyFilter))
-	})
-	return _c
-}
-
-func (_c *MockStore_ListStaleCompanies_Call) Return(_a0 []store.StaleCompany, _a1 error) *MockStore_ListStaleCompanies_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *MockStore_ListStaleCompanies_Call) RunAndReturn(run func(context.Context, store.StaleCompanyFilter) ([]store.StaleCompany, error)) *MockStore_ListStaleCompanies_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // GetProvenance provides a mock function with given fields: ctx, runID
 func (_m *MockStore) GetProvenance(ctx context.Context, runID string) ([]model.FieldProvenance, error) {
 	ret := _m.Called(ctx, runID)
@@ -1247,86 +1303,29 @@ func (_c *MockStore_ListRuns_Call) RunAndReturn(run func(context.Context, store.
 	return _c
 }
 
-// CountRuns provides a mock function with given fields: ctx, filter
-func (_m *MockStore) CountRuns(ctx context.Context, filter store.RunFilter) (int, error) {
-	ret := _m.Called(ctx, filter)
+// ListShadowResults provides a mock function with given fields: ctx, variant, since
+func (_m *MockStore) ListShadowResults(ctx context.Context, variant string, since time.Time) ([]model.ShadowResult, error) {
+	ret := _m.Called(ctx, variant, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountRuns")
+		panic("no return value specified for ListShadowResults")
 	}
 
-	var r0 int
+	var r0 []model.ShadowResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, store.RunFilter) (int, error)); ok {
-		return rf(ctx, filter)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, store.RunFilter) int); ok {
-		r0 = rf(ctx, filter)
-	} else {
-		r0 = ret.Get(0).(int)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]model.ShadowResult, error)); ok {
+		return rf(ctx, variant, since)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, store.RunFilter) error); ok {
-		r1 = rf(ctx, filter)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockStore_CountRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRuns'
-type MockStore_CountRuns_Call struct {
-	*mock.Call
-}
-
-// CountRuns is a helper method to define mock.On call
-//   - ctx context.Context
-//   - filter store.RunFilter
-func (_e *MockStore_Expecter) CountRuns(ctx interface{}, filter interface{}) *MockStore_CountRuns_Call {
-	return &MockStore_CountRuns_Call{Call: _e.mock.On("CountRuns", ctx, filter)}
-}
-
-func (_c *MockStore_CountRuns_Call) Run(run func(ctx context.Context, filter store.RunFilter)) *MockStore_CountRuns_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(store.RunFilter))
-	})
-	return _c
-}
-
-func (_c *MockStore_CountRuns_Call) Return(_a0 int, _a1 error) *MockStore_CountRuns_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *MockStore_CountRuns_Call) RunAndReturn(run func(context.Context, store.RunFilter) (int, error)) *MockStore_CountRuns_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// CountRunsByStatus provides a mock function with given fields: ctx
-func (_m *MockStore) CountRunsByStatus(ctx context.Context) (map[string]int, error) {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CountRunsByStatus")
-	}
-
-	var r0 map[string]int
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []model.ShadowResult); ok {
+		r0 = rf(ctx, variant, since)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]int)
+			r0 = ret.Get(0).([]model.ShadowResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, variant, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1334,57 +1333,59 @@ func (_m *MockStore) CountRunsByStatus(ctx context.Context) (map[string]int, err
 	return r0, r1
 }
 
-// MockStore_CountRunsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRunsByStatus'
-type MockStore_CountRunsByStatus_Call struct {
+// MockStore_ListShadowResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListShadowResults'
+type MockStore_ListShadowResults_Call struct {
 	*mock.Call
 }
 
-// CountRunsByStatus is a helper method to define mock.On call
+// ListShadowResults is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) CountRunsByStatus(ctx interface{}) *MockStore_CountRunsByStatus_Call {
-	return &MockStore_CountRunsByStatus_Call{Call: _e.mock.On("CountRunsByStatus", ctx)}
+//   - variant string
+//   - since time.Time
+func (_e *MockStore_Expecter) ListShadowResults(ctx interface{}, variant interface{}, since interface{}) *MockStore_ListShadowResults_Call {
+	return &MockStore_ListShadowResults_Call{Call: _e.mock.On("ListShadowResults", ctx, variant, since)}
 }
 
-func (_c *MockStore_CountRunsByStatus_Call) Run(run func(ctx context.Context)) *MockStore_CountRunsByStatus_Call {
+func (_c *MockStore_ListShadowResults_Call) Run(run func(ctx context.Context, variant string, since time.Time)) *MockStore_ListShadowResults_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_CountRunsByStatus_Call) Return(_a0 map[string]int, _a1 error) *MockStore_CountRunsByStatus_Call {
+func (_c *MockStore_ListShadowResults_Call) Return(_a0 []model.ShadowResult, _a1 error) *MockStore_ListShadowResults_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_CountRunsByStatus_Call) RunAndReturn(run func(context.Context) (map[string]int, error)) *MockStore_CountRunsByStatus_Call {
+func (_c *MockStore_ListShadowResults_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]model.ShadowResult, error)) *MockStore_ListShadowResults_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SummarizeRuns provides a mock function with given fields: ctx, since
-func (_m *MockStore) SummarizeRuns(ctx context.Context, since time.Time) (*store.RunSummary, error) {
-	ret := _m.Called(ctx, since)
+// ListStaleCompanies provides a mock function with given fields: ctx, filter
+func (_m *MockStore) ListStaleCompanies(ctx context.Context, filter store.StaleCompanyFilter) ([]store.StaleCompany, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SummarizeRuns")
+		panic("no return value specified for ListStaleCompanies")
 	}
 
-	var r0 *store.RunSummary
+	var r0 []store.StaleCompany
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*store.RunSummary, error)); ok {
-		return rf(ctx, since)
+	if rf, ok := ret.Get(0).(func(context.Context, store.StaleCompanyFilter) ([]store.StaleCompany, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *store.RunSummary); ok {
-		r0 = rf(ctx, since)
+	if rf, ok := ret.Get(0).(func(context.Context, store.StaleCompanyFilter) []store.StaleCompany); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*store.RunSummary)
+			r0 = ret.Get(0).([]store.StaleCompany)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = rf(ctx, since)
+	if rf, ok := ret.Get(1).(func(context.Context, store.StaleCompanyFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1392,31 +1393,31 @@ func (_m *MockStore) SummarizeRuns(ctx context.Context, since time.Time) (*store
 	return r0, r1
 }
 
-// MockStore_SummarizeRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeRuns'
-type MockStore_SummarizeRuns_Call struct {
+// MockStore_ListStaleCompanies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStaleCompanies'
+type MockStore_ListStaleCompanies_Call struct {
 	*mock.Call
 }
 
-// SummarizeRuns is a helper method to define mock.On call
+// ListStaleCompanies is a helper method to define mock.On call
 //   - ctx context.Context
-//   - since time.Time
-func (_e *MockStore_Expecter) SummarizeRuns(ctx interface{}, since interface{}) *MockStore_SummarizeRuns_Call {
-	return &MockStore_SummarizeRuns_Call{Call: _e.mock.On("SummarizeRuns", ctx, since)}
+//   - filter store.StaleCompanyFilter
+func (_e *MockStore_Expecter) ListStaleCompanies(ctx interface{}, filter interface{}) *MockStore_ListStaleCompanies_Call {
+	return &MockStore_ListStaleCompanies_Call{Call: _e.mock.On("ListStaleCompanies", ctx, filter)}
 }
 
-func (_c *MockStore_SummarizeRuns_Call) Run(run func(ctx context.Context, since time.Time)) *MockStore_SummarizeRuns_Call {
+func (_c *MockStore_ListStaleCompanies_Call) Run(run func(ctx context.Context, filter store.StaleCompanyFilter)) *MockStore_ListStaleCompanies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(store.StaleCompanyFilter))
 	})
 	return _c
 }
 
-func (_c *MockStore_SummarizeRuns_Call) Return(_a0 *store.RunSummary, _a1 error) *MockStore_SummarizeRuns_Call {
+func (_c *MockStore_ListStaleCompanies_Call) Return(_a0 []store.StaleCompany, _a1 error) *MockStore_ListStaleCompanies_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_SummarizeRuns_Call) RunAndReturn(run func(context.Context, time.Time) (*store.RunSummary, error)) *MockStore_SummarizeRuns_Call {
+func (_c *MockStore_ListStaleCompanies_Call) RunAndReturn(run func(context.Context, store.StaleCompanyFilter) ([]store.StaleCompany, error)) *MockStore_ListStaleCompanies_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1715,6 +1716,53 @@ func (_c *MockStore_SaveProvenance_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SaveShadowResult provides a mock function with given fields: ctx, result
+func (_m *MockStore) SaveShadowResult(ctx context.Context, result *model.ShadowResult) error {
+	ret := _m.Called(ctx, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveShadowResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ShadowResult) error); ok {
+		r0 = rf(ctx, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_SaveShadowResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveShadowResult'
+type MockStore_SaveShadowResult_Call struct {
+	*mock.Call
+}
+
+// SaveShadowResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - result *model.ShadowResult
+func (_e *MockStore_Expecter) SaveShadowResult(ctx interface{}, result interface{}) *MockStore_SaveShadowResult_Call {
+	return &MockStore_SaveShadowResult_Call{Call: _e.mock.On("SaveShadowResult", ctx, result)}
+}
+
+func (_c *MockStore_SaveShadowResult_Call) Run(run func(ctx context.Context, result *model.ShadowResult)) *MockStore_SaveShadowResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ShadowResult))
+	})
+	return _c
+}
+
+func (_c *MockStore_SaveShadowResult_Call) Return(_a0 error) *MockStore_SaveShadowResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_SaveShadowResult_Call) RunAndReturn(run func(context.Context, *model.ShadowResult) error) *MockStore_SaveShadowResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetCachedCrawl provides a mock function with given fields: ctx, companyURL, pages, ttl
 func (_m *MockStore) SetCachedCrawl(ctx context.Context, companyURL string, pages []model.CrawledPage, ttl time.Duration) error {
 	ret := _m.Called(ctx, companyURL, pages, ttl)
@@ -1862,6 +1910,65 @@ func (_c *MockStore_SetCachedScrape_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SummarizeRuns provides a mock function with given fields: ctx, since
+func (_m *MockStore) SummarizeRuns(ctx context.Context, since time.Time) (*store.RunSummary, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeRuns")
+	}
+
+	var r0 *store.RunSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*store.RunSummary, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *store.RunSummary); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.RunSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_SummarizeRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeRuns'
+type MockStore_SummarizeRuns_Call struct {
+	*mock.Call
+}
+
+// SummarizeRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+func (_e *MockStore_Expecter) SummarizeRuns(ctx interface{}, since interface{}) *MockStore_SummarizeRuns_Call {
+	return &MockStore_SummarizeRuns_Call{Call: _e.mock.On("SummarizeRuns", ctx, since)}
+}
+
+func (_c *MockStore_SummarizeRuns_Call) Run(run func(ctx context.Context, since time.Time)) *MockStore_SummarizeRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_SummarizeRuns_Call) Return(_a0 *store.RunSummary, _a1 error) *MockStore_SummarizeRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_SummarizeRuns_Call) RunAndReturn(run func(context.Context, time.Time) (*store.RunSummary, error)) *MockStore_SummarizeRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRunResult provides a mock function with given fields: ctx, runID, result
 func (_m *MockStore) UpdateRunResult(ctx context.Context, runID string, result *model.RunResult) error {
 	ret := _m.Called(ctx, runID, result)
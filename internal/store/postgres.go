@@ -851,6 +851,63 @@ func (s *PostgresStore) GetLatestProvenance(ctx context.Context, companyURL stri
 	return scanPgProvenanceRows(rows)
 }
 
+// SaveShadowResult implements Store.
+func (s *PostgresStore) SaveShadowResult(ctx context.Context, result *model.ShadowResult) error {
+	answersJSON, err := json.Marshal(result.Answers)
+	if err != nil {
+		return eris.Wrap(err, "postgres: marshal shadow answers")
+	}
+	comparisonJSON, err := json.Marshal(result.Comparison)
+	if err != nil {
+		return eris.Wrap(err, "postgres: marshal shadow comparison")
+	}
+	usageJSON, err := json.Marshal(result.TokenUsage)
+	if err != nil {
+		return eris.Wrap(err, "postgres: marshal shadow usage")
+	}
+
+	_, err = s.pool.Exec(ctx,
+		`INSERT INTO shadow_results (run_id, company_url, variant, answers, comparison, token_usage)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		result.RunID, result.CompanyURL, result.Variant, answersJSON, comparisonJSON, usageJSON,
+	)
+	return eris.Wrap(err, "postgres: insert shadow result")
+}
+
+// ListShadowResults implements Store.
+func (s *PostgresStore) ListShadowResults(ctx context.Context, variant string, since time.Time) ([]model.ShadowResult, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, run_id, company_url, variant, answers, comparison, token_usage, created_at
+		 FROM shadow_results
+		 WHERE variant = $1 AND created_at >= $2
+		 ORDER BY created_at DESC`, variant, since.UTC())
+	if err != nil {
+		return nil, eris.Wrap(err, "postgres: list shadow results")
+	}
+	defer rows.Close()
+
+	var results []model.ShadowResult
+	for rows.Next() {
+		var r model.ShadowResult
+		var answersJSON, comparisonJSON, usageJSON []byte
+		if err := rows.Scan(&r.ID, &r.RunID, &r.CompanyURL, &r.Variant,
+			&answersJSON, &comparisonJSON, &usageJSON, &r.CreatedAt); err != nil {
+			return nil, eris.Wrap(err, "postgres: scan shadow result")
+		}
+		if err := json.Unmarshal(answersJSON, &r.Answers); err != nil {
+			return nil, eris.Wrap(err, "postgres: unmarshal shadow answers")
+		}
+		if err := json.Unmarshal(comparisonJSON, &r.Comparison); err != nil {
+			return nil, eris.Wrap(err, "postgres: unmarshal shadow comparison")
+		}
+		if err := json.Unmarshal(usageJSON, &r.TokenUsage); err != nil {
+			return nil, eris.Wrap(err, "postgres: unmarshal shadow usage")
+		}
+		results = append(results, r)
+	}
+	return results, eris.Wrap(rows.Err(), "postgres: shadow rows iterate")
+}
+
 // scanPgProvenanceRows scans pgx rows into FieldProvenance records.
 func scanPgProvenanceRows(rows pgx.Rows) ([]model.FieldProvenance, error) {
 	var results []model.FieldProvenance
@@ -146,6 +146,19 @@ CREATE TABLE IF NOT EXISTS field_provenance (
 
 CREATE INDEX IF NOT EXISTS idx_field_provenance_run ON field_provenance(run_id);
 CREATE INDEX IF NOT EXISTS idx_field_provenance_company ON field_provenance(company_url, field_key);
+
+CREATE TABLE IF NOT EXISTS shadow_results (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id      TEXT NOT NULL REFERENCES runs(id),
+	company_url TEXT NOT NULL,
+	variant     TEXT NOT NULL,
+	answers     TEXT NOT NULL DEFAULT '[]',
+	comparison  TEXT NOT NULL DEFAULT '{}',
+	token_usage TEXT NOT NULL DEFAULT '{}',
+	created_at  DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_results_variant ON shadow_results(variant, created_at);
 `
 
 // Ping implements Store.
@@ -927,6 +940,64 @@ func scanProvenanceRows(rows *sql.Rows) ([]model.FieldProvenance, error) {
 	return results, eris.Wrap(rows.Err(), "sqlite: provenance rows iterate")
 }
 
+// SaveShadowResult implements Store.
+func (s *SQLiteStore) SaveShadowResult(ctx context.Context, result *model.ShadowResult) error {
+	answersJSON, err := json.Marshal(result.Answers)
+	if err != nil {
+		return eris.Wrap(err, "sqlite: marshal shadow answers")
+	}
+	comparisonJSON, err := json.Marshal(result.Comparison)
+	if err != nil {
+		return eris.Wrap(err, "sqlite: marshal shadow comparison")
+	}
+	usageJSON, err := json.Marshal(result.TokenUsage)
+	if err != nil {
+		return eris.Wrap(err, "sqlite: marshal shadow usage")
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO shadow_results (run_id, company_url, variant, answers, comparison, token_usage, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		result.RunID, result.CompanyURL, result.Variant,
+		string(answersJSON), string(comparisonJSON), string(usageJSON), time.Now().UTC(),
+	)
+	return eris.Wrap(err, "sqlite: insert shadow result")
+}
+
+// ListShadowResults implements Store.
+func (s *SQLiteStore) ListShadowResults(ctx context.Context, variant string, since time.Time) ([]model.ShadowResult, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, run_id, company_url, variant, answers, comparison, token_usage, created_at
+		 FROM shadow_results
+		 WHERE variant = ? AND created_at >= ?
+		 ORDER BY created_at DESC`, variant, since.UTC())
+	if err != nil {
+		return nil, eris.Wrap(err, "sqlite: list shadow results")
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var results []model.ShadowResult
+	for rows.Next() {
+		var r model.ShadowResult
+		var answersJSON, comparisonJSON, usageJSON string
+		if err := rows.Scan(&r.ID, &r.RunID, &r.CompanyURL, &r.Variant,
+			&answersJSON, &comparisonJSON, &usageJSON, &r.CreatedAt); err != nil {
+			return nil, eris.Wrap(err, "sqlite: scan shadow result")
+		}
+		if err := json.Unmarshal([]byte(answersJSON), &r.Answers); err != nil {
+			return nil, eris.Wrap(err, "sqlite: unmarshal shadow answers")
+		}
+		if err := json.Unmarshal([]byte(comparisonJSON), &r.Comparison); err != nil {
+			return nil, eris.Wrap(err, "sqlite: unmarshal shadow comparison")
+		}
+		if err := json.Unmarshal([]byte(usageJSON), &r.TokenUsage); err != nil {
+			return nil, eris.Wrap(err, "sqlite: unmarshal shadow usage")
+		}
+		results = append(results, r)
+	}
+	return results, eris.Wrap(rows.Err(), "sqlite: shadow rows iterate")
+}
+
 // ListStaleCompanies implements Store.
 func (s *SQLiteStore) ListStaleCompanies(ctx context.Context, filter StaleCompanyFilter) ([]StaleCompany, error) {
 	query := `SELECT id, company, result, created_at FROM runs
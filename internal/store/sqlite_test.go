@@ -561,3 +561,43 @@ func TestSQLite_Migrate_Idempotent(t *testing.T) {
 	err := st.Migrate(ctx)
 	require.NoError(t, err)
 }
+
+// --- Shadow Results ---
+
+func TestSQLite_ShadowResults_SaveAndList(t *testing.T) {
+	st := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	run, err := st.CreateRun(ctx, model.Company{URL: "https://acme.com", Name: "Acme"})
+	require.NoError(t, err)
+
+	sr := &model.ShadowResult{
+		RunID:      run.ID,
+		CompanyURL: "https://acme.com",
+		Variant:    "v2",
+		Answers:    []model.ExtractionAnswer{{FieldKey: "industry", Value: "HVAC", Confidence: 0.9}},
+		Comparison: model.ShadowComparison{
+			Fields:        []model.ShadowFieldComparison{{FieldKey: "industry", Status: model.ShadowAgree}},
+			Agreed:        1,
+			AgreementRate: 1,
+		},
+		TokenUsage: model.TokenUsage{InputTokens: 100, OutputTokens: 20, Cost: 0.01},
+	}
+	require.NoError(t, st.SaveShadowResult(ctx, sr))
+	require.NoError(t, st.SaveShadowResult(ctx, &model.ShadowResult{RunID: run.ID, CompanyURL: "https://acme.com", Variant: "other"}))
+
+	results, err := st.ListShadowResults(ctx, "v2", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, run.ID, results[0].RunID)
+	assert.Equal(t, "v2", results[0].Variant)
+	require.Len(t, results[0].Answers, 1)
+	assert.Equal(t, "industry", results[0].Answers[0].FieldKey)
+	assert.Equal(t, 1, results[0].Comparison.Agreed)
+	assert.InDelta(t, 0.01, results[0].TokenUsage.Cost, 1e-9)
+	assert.False(t, results[0].CreatedAt.IsZero())
+
+	results, err = st.ListShadowResults(ctx, "v2", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
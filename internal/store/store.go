@@ -102,6 +102,10 @@ type Store interface {
 	GetProvenance(ctx context.Context, runID string) ([]model.FieldProvenance, error)
 	GetLatestProvenance(ctx context.Context, companyURL string) ([]model.FieldProvenance, error)
 
+	// Shadow mode
+	SaveShadowResult(ctx context.Context, result *model.ShadowResult) error
+	ListShadowResults(ctx context.Context, variant string, since time.Time) ([]model.ShadowResult, error)
+
 	// Lifecycle
 	Ping(ctx context.Context) error
 	Migrate(ctx context.Context) error
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sells-group/research-cli/internal/docling"
+	"github.com/sells-group/research-cli/internal/fetcher"
 )
 
 // --- mocks ---
@@ -38,6 +39,10 @@ func (m *mockFetcher) DownloadIfChanged(_ context.Context, _ string, _ string) (
 	return nil, "", false, fmt.Errorf("not implemented")
 }
 
+func (m *mockFetcher) DownloadToFileIfChanged(_ context.Context, _ string, _ string, _ fetcher.FileVersion) (fetcher.FileVersion, bool, error) {
+	return fetcher.FileVersion{}, false, fmt.Errorf("not implemented")
+}
+
 type mockDocling struct {
 	convertFn func(ctx context.Context, pdfData []byte, opts docling.ConvertOpts) (*docling.Document, error)
 }
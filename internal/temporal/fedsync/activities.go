@@ -15,6 +15,10 @@ import (
 	"github.com/sells-group/research-cli/internal/fedsync"
 	"github.com/sells-group/research-cli/internal/fedsync/dataset"
 	"github.com/sells-group/research-cli/internal/fetcher"
+	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/manifest"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
+	"github.com/sells-group/research-cli/internal/postsync"
 	"github.com/sells-group/research-cli/internal/temporal/sdk"
 )
 
@@ -23,15 +27,16 @@ type Activities struct {
 	sdk.SyncLogActivities
 	sdk.NotifyActivities
 
-	pool    db.Pool
-	fetcher fetcher.Fetcher
-	reg     *dataset.Registry
-	tempDir string
-	cfg     *config.Config
+	pool     db.Pool
+	fetcher  fetcher.Fetcher
+	reg      *dataset.Registry
+	tempDir  string
+	cfg      *config.Config
+	postSync *postsync.Runner
 }
 
 // NewActivities creates a new fedsync Activities instance.
-func NewActivities(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, reg *dataset.Registry, tempDir string, cfg *config.Config) *Activities {
+func NewActivities(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, reg *dataset.Registry, queue *geospatial.GeocodeQueue, tempDir string, cfg *config.Config) *Activities {
 	return &Activities{
 		SyncLogActivities: sdk.SyncLogActivities{SyncLog: syncLog},
 		NotifyActivities:  sdk.NotifyActivities{WebhookURL: cfg.Fedsync.N8NWebhook},
@@ -40,6 +45,7 @@ func NewActivities(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, re
 		reg:               reg,
 		tempDir:           tempDir,
 		cfg:               cfg,
+		postSync:          postsync.RunnerFromConfig(pool, syncLog, queue, cfg),
 	}
 }
 
@@ -95,6 +101,7 @@ func (a *Activities) SelectDatasets(ctx context.Context, params SelectDatasetsPa
 type SyncDatasetParams struct {
 	Dataset string `json:"dataset"`
 	Full    bool   `json:"full"`
+	SyncID  int64  `json:"sync_id,omitempty"` // workflow's sync log entry; hook results are recorded against it
 }
 
 // SyncDatasetResult is the output of SyncDataset.
@@ -112,26 +119,68 @@ func (a *Activities) SyncDataset(ctx context.Context, params SyncDatasetParams)
 			"UnknownDataset", lookupErr)
 	}
 
+	f := fetcher.NewMeteredFetcher(dataset.FetcherFor(a.fetcher, ds, dataset.MirrorsFromConfig(a.cfg)))
+	start := time.Now()
 	var result *dataset.SyncResult
 	syncErr := sdk.RunWithHeartbeat(ctx, fmt.Sprintf("syncing %s", params.Dataset), 30*time.Second, func(ctx context.Context) error {
 		var err error
 		if params.Full {
 			if fs, ok := ds.(dataset.FullSyncer); ok {
 				log.Info("running full sync via Temporal")
-				result, err = fs.SyncFull(ctx, a.pool, a.fetcher, a.tempDir)
+				result, err = fs.SyncFull(ctx, a.pool, f, a.tempDir)
 				return err
 			}
 		}
-		result, err = ds.Sync(ctx, a.pool, a.fetcher, a.tempDir)
+		result, err = ds.Sync(ctx, a.pool, f, a.tempDir)
 		return err
 	})
+	elapsed := time.Since(start)
 
 	if syncErr != nil {
+		recordSyncMetrics(ds, "failed", 0, f, elapsed)
 		return nil, eris.Wrapf(syncErr, "sync dataset %s", params.Dataset)
 	}
+	recordSyncMetrics(ds, "complete", result.RowsSynced, f, elapsed)
+
+	if ps, ok := ds.(dataset.PostSyncer); ok {
+		if err := ps.PostSync(ctx, a.pool, result); err != nil {
+			log.Warn("post-sync hook failed", zap.Error(err))
+		}
+	}
+	a.postSync.Run(ctx, postsync.Target{
+		Source:  "fedsync",
+		Dataset: ds.Name(),
+		Table:   ds.Table(),
+		SyncID:  params.SyncID,
+		Rows:    result.RowsSynced,
+	}, postsync.Declared(ds))
 
 	return &SyncDatasetResult{
 		RowsSynced: result.RowsSynced,
-		Metadata:   result.Metadata,
+		Metadata:   a.syncMetadata(result.Metadata),
 	}, nil
 }
+
+// recordSyncMetrics records a finished run in the Prometheus metrics, as
+// Engine.Run does for CLI syncs.
+func recordSyncMetrics(ds dataset.Dataset, status string, rows int64, f *fetcher.MeteredFetcher, elapsed time.Duration) {
+	opsmetrics.RecordSync(opsmetrics.SyncRun{
+		Dataset:  ds.Name(),
+		Status:   status,
+		Rows:     rows,
+		Bytes:    f.Bytes(),
+		Requests: f.Requests(),
+		Duration: elapsed,
+	})
+}
+
+// syncMetadata returns the dataset's metadata with the run manifest added,
+// as Engine.Run records it, so the workflow's sync log entry carries it.
+func (a *Activities) syncMetadata(meta map[string]any) map[string]any {
+	out := make(map[string]any, len(meta)+1)
+	for k, v := range meta {
+		out[k] = v
+	}
+	out["manifest"] = manifest.New(a.cfg, nil)
+	return out
+}
@@ -0,0 +1,101 @@
+package fedsync
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/sells-group/research-cli/internal/config"
+	"github.com/sells-group/research-cli/internal/fedsync/dataset"
+	datasetmocks "github.com/sells-group/research-cli/internal/fedsync/dataset/mocks"
+	"github.com/sells-group/research-cli/internal/manifest"
+	"github.com/sells-group/research-cli/internal/model"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
+)
+
+// newTestActivities returns Activities whose registry also holds ds.
+func newTestActivities(t *testing.T, ds dataset.Dataset) *Activities {
+	t.Helper()
+	cfg := &config.Config{}
+	reg := dataset.NewRegistry(cfg)
+	reg.Register(ds)
+	return NewActivities(nil, nil, nil, reg, nil, t.TempDir(), cfg)
+}
+
+func TestSyncDataset_StampsManifest(t *testing.T) {
+	ds := datasetmocks.NewMockDataset(t)
+	ds.EXPECT().Name().Return("test_ds").Maybe()
+	ds.EXPECT().Table().Return("fed_data.test_ds").Maybe()
+	ds.EXPECT().Sync(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&dataset.SyncResult{RowsSynced: 7, Metadata: map[string]any{"files": 2}}, nil)
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	a := newTestActivities(t, ds)
+	env.RegisterActivity(a)
+
+	val, err := env.ExecuteActivity(a.SyncDataset, SyncDatasetParams{Dataset: "test_ds"})
+	require.NoError(t, err)
+
+	var result struct {
+		Metadata struct {
+			Files    int               `json:"files"`
+			Manifest model.RunManifest `json:"manifest"`
+		} `json:"metadata"`
+	}
+	require.NoError(t, val.Get(&result))
+	assert.Equal(t, 2, result.Metadata.Files)
+	assert.Equal(t, manifest.ConfigHash(&config.Config{}), result.Metadata.Manifest.ConfigHash)
+	assert.NotEmpty(t, result.Metadata.Manifest.BinaryVersion)
+}
+
+// syncMetrics returns the Prometheus exposition of the default collector.
+func syncMetrics(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	opsmetrics.Handler(rec, httptest.NewRequest("GET", "/metrics", nil))
+	return rec.Body.String()
+}
+
+func TestSyncDataset_RecordsSyncMetrics(t *testing.T) {
+	ds := datasetmocks.NewMockDataset(t)
+	ds.EXPECT().Name().Return("metrics_ok_ds").Maybe()
+	ds.EXPECT().Table().Return("fed_data.metrics_ok_ds").Maybe()
+	ds.EXPECT().Sync(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&dataset.SyncResult{RowsSynced: 42}, nil)
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	a := newTestActivities(t, ds)
+	env.RegisterActivity(a)
+
+	_, err := env.ExecuteActivity(a.SyncDataset, SyncDatasetParams{Dataset: "metrics_ok_ds"})
+	require.NoError(t, err)
+
+	body := syncMetrics(t)
+	assert.Contains(t, body, `research_fedsync_runs_total{dataset="metrics_ok_ds",status="complete"} 1`)
+	assert.Contains(t, body, `research_fedsync_rows_synced_total{dataset="metrics_ok_ds"} 42`)
+}
+
+func TestSyncDataset_RecordsFailedSyncMetrics(t *testing.T) {
+	ds := datasetmocks.NewMockDataset(t)
+	ds.EXPECT().Name().Return("metrics_fail_ds").Maybe()
+	ds.EXPECT().Table().Return("fed_data.metrics_fail_ds").Maybe()
+	ds.EXPECT().Sync(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("download failed"))
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	a := newTestActivities(t, ds)
+	env.RegisterActivity(a)
+
+	_, err := env.ExecuteActivity(a.SyncDataset, SyncDatasetParams{Dataset: "metrics_fail_ds"})
+	require.Error(t, err)
+
+	assert.Contains(t, syncMetrics(t), `research_fedsync_runs_total{dataset="metrics_fail_ds",status="failed"} 1`)
+}
@@ -42,7 +42,7 @@ func (a *Activities) CheckSyncLag(ctx context.Context) (*SyncLagResult, error) {
 
 		// If ShouldRun is true but we have a recent sync, it's just due.
 		// Only flag as overdue if the lag exceeds 2x the cadence.
-		if lastSync != nil && !isSignificantlyOverdue(ds, now, *lastSync) {
+		if lastSync != nil && !dataset.SignificantlyOverdue(ds.Cadence(), now.Sub(*lastSync)) {
 			continue
 		}
 
@@ -62,22 +62,3 @@ func (a *Activities) CheckSyncLag(ctx context.Context) (*SyncLagResult, error) {
 	log.Info("sync lag check complete", zap.Int("overdue", len(overdue)))
 	return &SyncLagResult{Overdue: overdue}, nil
 }
-
-// isSignificantlyOverdue returns true if a dataset is more than 2x overdue.
-func isSignificantlyOverdue(ds dataset.Dataset, now time.Time, lastSync time.Time) bool {
-	elapsed := now.Sub(lastSync)
-	switch ds.Cadence() {
-	case dataset.Daily:
-		return elapsed > 48*time.Hour
-	case dataset.Weekly:
-		return elapsed > 14*24*time.Hour
-	case dataset.Monthly:
-		return elapsed > 60*24*time.Hour
-	case dataset.Quarterly:
-		return elapsed > 180*24*time.Hour
-	case dataset.Annual:
-		return elapsed > 730*24*time.Hour
-	default:
-		return false
-	}
-}
@@ -163,6 +163,7 @@ func DatasetSyncWorkflow(ctx workflow.Context, params DatasetSyncParams) (*Datas
 	syncErr := workflow.ExecuteActivity(syncCtx, (*Activities).SyncDataset, SyncDatasetParams{
 		Dataset: params.Name,
 		Full:    params.Full,
+		SyncID:  startResult.SyncID,
 	}).Get(ctx, &syncResult)
 
 	// 3. Complete or fail the sync log.
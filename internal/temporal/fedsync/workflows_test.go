@@ -86,8 +86,12 @@ func TestDatasetSyncWorkflow_Success(t *testing.T) {
 
 	env.OnActivity((*Activities).StartSyncLog, mock.Anything, mock.Anything, mock.Anything).
 		Return(&sdk.StartSyncLogResult{SyncID: 42}, nil)
-	env.OnActivity((*Activities).SyncDataset, mock.Anything, mock.Anything, mock.Anything).
-		Return(&SyncDatasetResult{RowsSynced: 150}, nil)
+	// Mocked by name so the matcher sees the decoded params; hooks are
+	// recorded against the workflow's sync log entry.
+	env.RegisterActivity(&Activities{})
+	env.OnActivity("SyncDataset", mock.Anything, mock.MatchedBy(func(p SyncDatasetParams) bool {
+		return p.SyncID == 42
+	})).Return(&SyncDatasetResult{RowsSynced: 150}, nil)
 	env.OnActivity((*Activities).CompleteSyncLog, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/sells-group/research-cli/internal/fetcher"
 	"github.com/sells-group/research-cli/internal/geoscraper"
 	"github.com/sells-group/research-cli/internal/geospatial"
+	"github.com/sells-group/research-cli/internal/postsync"
 	"github.com/sells-group/research-cli/internal/temporal/sdk"
 )
 
@@ -22,12 +23,13 @@ import (
 type Activities struct {
 	sdk.SyncLogActivities
 
-	pool    db.Pool
-	fetcher fetcher.Fetcher
-	reg     *geoscraper.Registry
-	queue   *geospatial.GeocodeQueue
-	tempDir string
-	cfg     *config.Config
+	pool     db.Pool
+	fetcher  fetcher.Fetcher
+	reg      *geoscraper.Registry
+	queue    *geospatial.GeocodeQueue
+	tempDir  string
+	cfg      *config.Config
+	postSync *postsync.Runner
 }
 
 // NewActivities creates a new geo scraper Activities instance.
@@ -40,6 +42,7 @@ func NewActivities(pool db.Pool, f fetcher.Fetcher, syncLog *fedsync.SyncLog, re
 		queue:             queue,
 		tempDir:           tempDir,
 		cfg:               cfg,
+		postSync:          postsync.RunnerFromConfig(pool, syncLog, queue, cfg),
 	}
 }
 
@@ -95,6 +98,7 @@ func (a *Activities) SelectScrapers(ctx context.Context, params SelectScrapersPa
 // SyncScraperParams is the input for SyncScraper.
 type SyncScraperParams struct {
 	Scraper string `json:"scraper"`
+	SyncID  int64  `json:"sync_id,omitempty"` // workflow's sync log entry; hook results are recorded against it
 }
 
 // SyncScraperResult is the output of SyncScraper.
@@ -124,12 +128,16 @@ func (a *Activities) SyncScraper(ctx context.Context, params SyncScraperParams)
 		return nil, eris.Wrapf(syncErr, "sync scraper %s", params.Scraper)
 	}
 
-	// PostSync: enqueue addresses for geocoding if applicable.
-	if a.queue != nil {
-		if ap, ok := s.(geoscraper.AddressProducer); ok && ap.HasAddresses() {
-			if psErr := geoscraper.PostSyncGeocode(ctx, a.pool, a.queue, s.Table(), result); psErr != nil {
-				log.Warn("postsync geocode failed", zap.Error(psErr))
-			}
+	a.postSync.Run(ctx, postsync.Target{
+		Source:  "geoscraper",
+		Dataset: s.Name(),
+		Table:   s.Table(),
+		SyncID:  params.SyncID,
+		Rows:    result.RowsSynced,
+	}, geoscraper.DefaultHooks(s, a.queue != nil))
+	if ps, ok := s.(geoscraper.PostSyncer); ok {
+		if psErr := ps.PostSync(ctx, a.pool, result); psErr != nil {
+			log.Warn("postsync failed", zap.Error(psErr))
 		}
 	}
 
@@ -125,7 +125,7 @@ func ScrapeSingleWorkflow(ctx workflow.Context, params ScrapeSingleParams) (*Scr
 	// 2. Run the actual scraper sync.
 	var syncResult SyncScraperResult
 	syncErr := workflow.ExecuteActivity(syncCtx, (*Activities).SyncScraper,
-		SyncScraperParams{Scraper: params.Name}).Get(ctx, &syncResult)
+		SyncScraperParams{Scraper: params.Name, SyncID: startResult.SyncID}).Get(ctx, &syncResult)
 
 	// 3. Complete or fail the sync log.
 	if syncErr != nil {
@@ -76,8 +76,12 @@ func TestScrapeSingleWorkflow_Success(t *testing.T) {
 
 	env.OnActivity((*Activities).StartSyncLog, mock.Anything, mock.Anything, mock.Anything).
 		Return(&sdk.StartSyncLogResult{SyncID: 42}, nil)
-	env.OnActivity((*Activities).SyncScraper, mock.Anything, mock.Anything, mock.Anything).
-		Return(&SyncScraperResult{RowsSynced: 1500}, nil)
+	// Mocked by name so the matcher sees the decoded params; hooks are
+	// recorded against the workflow's sync log entry.
+	env.RegisterActivity(&Activities{})
+	env.OnActivity("SyncScraper", mock.Anything, mock.MatchedBy(func(p SyncScraperParams) bool {
+		return p.SyncID == 42
+	})).Return(&SyncScraperResult{RowsSynced: 1500}, nil)
 	env.OnActivity((*Activities).CompleteSyncLog, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
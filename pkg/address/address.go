@@ -0,0 +1,326 @@
+// Package address parses and normalizes US postal addresses into USPS
+// Publication 28 form: uppercase, punctuation stripped, street suffixes,
+// directionals, and unit designators abbreviated, and ZIP+4 split. It is
+// applied before geocoding and when building entity-xref address keys, so
+// "123 North Main Street, Suite 4" and "123 N. Main St #4" compare equal.
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Components holds the parsed parts of an address. All values are
+// normalized; empty fields were not present in the input.
+type Components struct {
+	Number          string `json:"number,omitempty"`
+	PreDirectional  string `json:"pre_directional,omitempty"`
+	StreetName      string `json:"street_name,omitempty"`
+	Suffix          string `json:"suffix,omitempty"`
+	PostDirectional string `json:"post_directional,omitempty"`
+	UnitType        string `json:"unit_type,omitempty"`
+	Unit            string `json:"unit,omitempty"`
+	POBox           string `json:"po_box,omitempty"`
+	City            string `json:"city,omitempty"`
+	State           string `json:"state,omitempty"`
+	ZIP5            string `json:"zip5,omitempty"`
+	ZIP4            string `json:"zip4,omitempty"`
+}
+
+// StreetLine returns the normalized delivery line ("123 N MAIN ST STE 4").
+func (c Components) StreetLine() string {
+	if c.POBox != "" {
+		return "PO BOX " + c.POBox
+	}
+	return joinNonEmpty(" ", c.Number, c.PreDirectional, c.StreetName, c.Suffix,
+		c.PostDirectional, c.UnitType, c.Unit)
+}
+
+// ZIP returns the ZIP code, with the +4 extension when known.
+func (c Components) ZIP() string {
+	if c.ZIP4 != "" {
+		return c.ZIP5 + "-" + c.ZIP4
+	}
+	return c.ZIP5
+}
+
+// String returns the normalized one-line address
+// ("123 N MAIN ST STE 4, AUSTIN, TX 78701-1234").
+func (c Components) String() string {
+	return joinNonEmpty(", ", c.StreetLine(), c.City, joinNonEmpty(" ", c.State, c.ZIP()))
+}
+
+// IsZero reports whether no component was parsed.
+func (c Components) IsZero() bool { return c == Components{} }
+
+// Parse parses a one-line address ("street[, unit], city, state zip").
+// Without commas the whole input is treated as the street line, except a
+// trailing state and ZIP.
+func Parse(raw string) Components {
+	var parts []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = clean(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	// Drop a trailing country.
+	if n := len(parts); n > 1 && (parts[n-1] == "USA" || parts[n-1] == "US" || parts[n-1] == "UNITED STATES") {
+		parts = parts[:n-1]
+	}
+	if len(parts) == 0 {
+		return Components{}
+	}
+	if len(parts) == 1 {
+		return parseSingle(parts[0])
+	}
+
+	n := len(parts)
+	city, state, zip5, zip4 := parseLocality(parts[n-1])
+	streetParts := parts[:n-1]
+	switch {
+	case state == "" && zip5 == "" && isUnit(parts[n-1]):
+		// "street, unit" with no locality.
+		streetParts = parts
+	case state == "" && zip5 == "":
+		// "street, city" with no state or ZIP.
+		city = parts[n-1]
+	case city == "" && n >= 3:
+		city = parts[n-2]
+		streetParts = parts[:n-2]
+	}
+
+	c := ParseStreet(strings.Join(streetParts, " "))
+	c.City, c.State, c.ZIP5, c.ZIP4 = city, state, zip5, zip4
+	return c
+}
+
+// parseSingle parses an address with no commas, splitting off a trailing
+// state and ZIP but leaving any city in the street line.
+func parseSingle(s string) Components {
+	fields := strings.Fields(s)
+	var zip5, zip4 string
+	if n := len(fields); n > 1 {
+		if z5, z4, ok := splitZIPToken(fields[n-1]); ok {
+			zip5, zip4 = z5, z4
+			fields = fields[:n-1]
+		}
+	}
+	var state string
+	if zip5 != "" && len(fields) > 1 {
+		if st, used := trailingState(fields); used > 0 && used < len(fields) {
+			state = st
+			fields = fields[:len(fields)-used]
+		}
+	}
+	c := ParseStreet(strings.Join(fields, " "))
+	c.State, c.ZIP5, c.ZIP4 = state, zip5, zip4
+	return c
+}
+
+// parseLocality splits "CITY ST 12345-6789", "ST 12345", "ST", or "12345".
+func parseLocality(s string) (city, state, zip5, zip4 string) {
+	fields := strings.Fields(s)
+	if n := len(fields); n > 0 {
+		if z5, z4, ok := splitZIPToken(fields[n-1]); ok {
+			zip5, zip4 = z5, z4
+			fields = fields[:n-1]
+		}
+	}
+	if st, used := trailingState(fields); used > 0 {
+		state = st
+		fields = fields[:len(fields)-used]
+	} else if zip5 == "" {
+		return "", "", "", ""
+	}
+	return strings.Join(fields, " "), state, zip5, zip4
+}
+
+// trailingState matches a state abbreviation or full state name at the end
+// of fields, returning the abbreviation and the number of fields used.
+func trailingState(fields []string) (string, int) {
+	for used := min(3, len(fields)); used >= 1; used-- {
+		if st := NormalizeState(strings.Join(fields[len(fields)-used:], " ")); st != "" {
+			return st, used
+		}
+	}
+	return "", 0
+}
+
+// ParseStreet parses a delivery line ("123 North Main Street Suite 4").
+func ParseStreet(line string) Components {
+	fields := strings.Fields(strings.ReplaceAll(clean(line), "#", " # "))
+	if len(fields) == 0 {
+		return Components{}
+	}
+
+	if box, ok := parsePOBox(fields); ok {
+		return Components{POBox: box}
+	}
+
+	var c Components
+	fields = c.takeUnit(fields)
+
+	if isHouseNumber(fields[0]) && len(fields) > 1 {
+		c.Number = fields[0]
+		fields = fields[1:]
+	}
+
+	// Post-directional after a suffix ("MAIN ST NW").
+	if n := len(fields); n >= 3 {
+		if dir, ok := directionals[fields[n-1]]; ok {
+			if _, isSuffix := suffixes[fields[n-2]]; isSuffix {
+				c.PostDirectional = dir
+				fields = fields[:n-1]
+			}
+		}
+	}
+	// Suffix, when a street name precedes it.
+	if n := len(fields); n >= 2 {
+		if sfx, ok := suffixes[fields[n-1]]; ok {
+			c.Suffix = sfx
+			fields = fields[:n-1]
+		}
+	}
+	// Post-directional without a suffix ("BROADWAY E").
+	if n := len(fields); n >= 2 && c.PostDirectional == "" && c.Suffix == "" {
+		if dir, ok := directionals[fields[n-1]]; ok {
+			c.PostDirectional = dir
+			fields = fields[:n-1]
+		}
+	}
+	// Pre-directional, when a street name follows it.
+	if len(fields) >= 2 {
+		if dir, ok := directionals[fields[0]]; ok {
+			c.PreDirectional = dir
+			fields = fields[1:]
+		}
+	}
+
+	c.StreetName = strings.Join(fields, " ")
+	return c
+}
+
+// takeUnit removes a trailing unit designator and value ("STE 4", "# 12",
+// "APT 3B") from fields. Designators other than "#" must follow at least a
+// number and street name, so "12 LOT RD" keeps LOT in the name.
+func (c *Components) takeUnit(fields []string) []string {
+	for i := 1; i < len(fields); i++ {
+		ut, ok := unitTypes[fields[i]]
+		if !ok || (i < 2 && fields[i] != "#") {
+			continue
+		}
+		// A designator needs a value unless it stands alone (REAR, LOWR).
+		rest := fields[i+1:]
+		if len(rest) == 0 && !unitNoValue[ut] {
+			continue
+		}
+		if len(rest) > 0 && unitNoValue[ut] {
+			continue
+		}
+		c.UnitType = ut
+		c.Unit = strings.Join(rest, " ")
+		return fields[:i]
+	}
+	return fields
+}
+
+// isUnit reports whether part starts with a unit designator ("STE 4").
+func isUnit(part string) bool {
+	first, _, _ := strings.Cut(part, " ")
+	_, ok := unitTypes[first]
+	return ok || strings.HasPrefix(part, "#")
+}
+
+// parsePOBox recognizes "PO BOX 12", "P O BOX 12", and "POST OFFICE BOX 12".
+func parsePOBox(fields []string) (string, bool) {
+	line := strings.Join(fields, " ")
+	for _, prefix := range []string{"PO BOX ", "P O BOX ", "POST OFFICE BOX ", "POB "} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix), true
+		}
+	}
+	return "", false
+}
+
+// isHouseNumber reports whether s looks like a primary number ("123",
+// "123A", "12-14", "N123W456").
+func isHouseNumber(s string) bool {
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeStreet returns the normalized delivery line for street.
+func NormalizeStreet(street string) string {
+	return ParseStreet(street).StreetLine()
+}
+
+// SplitZIP splits a ZIP or ZIP+4 ("12345-6789", "123456789") into its
+// 5-digit ZIP and 4-digit extension. Both are empty when there are fewer
+// than 5 digits.
+func SplitZIP(zip string) (zip5, zip4 string) {
+	var digits strings.Builder
+	for _, r := range zip {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	d := digits.String()
+	if len(d) < 5 {
+		return "", ""
+	}
+	if len(d) >= 9 {
+		return d[:5], d[5:9]
+	}
+	return d[:5], ""
+}
+
+var zipTokenRe = regexp.MustCompile(`^(\d{5})(?:-?(\d{4}))?$`)
+
+// splitZIPToken matches a single ZIP or ZIP+4 token.
+func splitZIPToken(s string) (zip5, zip4 string, ok bool) {
+	m := zipTokenRe.FindStringSubmatch(s)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// NormalizeState returns the two-letter abbreviation for a state, DC, or
+// territory name or abbreviation, or "" when unrecognized.
+func NormalizeState(s string) string {
+	s = strings.Join(strings.Fields(clean(s)), " ")
+	if _, ok := stateNames[s]; ok {
+		return s
+	}
+	for abbr, name := range stateNames {
+		if name == s {
+			return abbr
+		}
+	}
+	return ""
+}
+
+var (
+	dropPunctRe  = regexp.MustCompile(`['"’.]`)
+	spacePunctRe = regexp.MustCompile(`[,;:()]`)
+)
+
+// clean uppercases s, strips punctuation, and collapses whitespace.
+func clean(s string) string {
+	s = spacePunctRe.ReplaceAllString(dropPunctRe.ReplaceAllString(strings.ToUpper(s), ""), " ")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	out := parts[:0:0]
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,105 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeStreet(t *testing.T) {
+	tests := map[string]string{
+		"":                               "",
+		"123 North Main Street, Suite 4": "123 N MAIN ST STE 4",
+		"123 N. Main St #4":              "123 N MAIN ST UNIT 4",
+		"  45 w. elm ave. ":              "45 W ELM AVE",
+		"P.O. Box 100":                   "PO BOX 100",
+		"P O BOX 100":                    "PO BOX 100",
+		"Post Office Box 7":              "PO BOX 7",
+		"9 Industrial Parkway #12":       "9 INDUSTRIAL PKWY UNIT 12",
+		"1 Southwest Commerce Boulevard": "1 SW COMMERCE BLVD",
+		"1600 Pennsylvania Avenue NW":    "1600 PENNSYLVANIA AVE NW",
+		"500 North Street":               "500 NORTH ST",
+		"12 Lot Road":                    "12 LOT RD",
+		"77 Avenue of the Americas":      "77 AVENUE OF THE AMERICAS",
+		"200 Main Str Apartment 3B":      "200 MAIN ST APT 3B",
+		"10 Broadway East":               "10 BROADWAY E",
+		"8 O'Neil Cir Rear":              "8 ONEIL CIR REAR",
+		"4000 US Highway 1":              "4000 US HIGHWAY 1",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizeStreet(in), in)
+	}
+}
+
+func TestParseStreet_Components(t *testing.T) {
+	c := ParseStreet("123 North Main Street Northwest, Suite 400")
+	assert.Equal(t, Components{
+		Number: "123", PreDirectional: "N", StreetName: "MAIN", Suffix: "ST",
+		PostDirectional: "NW", UnitType: "STE", Unit: "400",
+	}, c)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Components
+	}{
+		{"100 Main Street, Miami, FL 33101", Components{
+			Number: "100", StreetName: "MAIN", Suffix: "ST", City: "MIAMI", State: "FL", ZIP5: "33101",
+		}},
+		{"123 N. Main St., Suite 4, Austin, Texas 78701-1234, USA", Components{
+			Number: "123", PreDirectional: "N", StreetName: "MAIN", Suffix: "ST", UnitType: "STE", Unit: "4",
+			City: "AUSTIN", State: "TX", ZIP5: "78701", ZIP4: "1234",
+		}},
+		{"5 Elm Ave, Salt Lake City UT 841011234", Components{
+			Number: "5", StreetName: "ELM", Suffix: "AVE", City: "SALT LAKE CITY", State: "UT", ZIP5: "84101", ZIP4: "1234",
+		}},
+		{"1 Park Pl, New York, New York 10007", Components{
+			Number: "1", StreetName: "PARK", Suffix: "PL", City: "NEW YORK", State: "NY", ZIP5: "10007",
+		}},
+		{"1 Nowhere Rd, Nowhere", Components{
+			Number: "1", StreetName: "NOWHERE", Suffix: "RD", City: "NOWHERE",
+		}},
+		{"1 Main St, Suite 4", Components{
+			Number: "1", StreetName: "MAIN", Suffix: "ST", UnitType: "STE", Unit: "4",
+		}},
+		{"100 Main St FL 33101", Components{
+			Number: "100", StreetName: "MAIN", Suffix: "ST", State: "FL", ZIP5: "33101",
+		}},
+		{"", Components{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Parse(tt.in), tt.in)
+	}
+}
+
+func TestComponents_String(t *testing.T) {
+	c := Parse("123 north main street suite 4, austin, tx 78701-1234")
+	assert.Equal(t, "123 N MAIN ST STE 4, AUSTIN, TX 78701-1234", c.String())
+	assert.Equal(t, "78701-1234", c.ZIP())
+	assert.False(t, c.IsZero())
+	assert.True(t, Parse(" , ").IsZero())
+}
+
+func TestSplitZIP(t *testing.T) {
+	tests := map[string][2]string{
+		"12345":      {"12345", ""},
+		"12345-6789": {"12345", "6789"},
+		"123456789":  {"12345", "6789"},
+		"02134":      {"02134", ""},
+		"1234":       {"", ""},
+		"":           {"", ""},
+	}
+	for in, want := range tests {
+		zip5, zip4 := SplitZIP(in)
+		assert.Equal(t, want, [2]string{zip5, zip4}, in)
+	}
+}
+
+func TestNormalizeState(t *testing.T) {
+	assert.Equal(t, "TX", NormalizeState("Texas"))
+	assert.Equal(t, "TX", NormalizeState("tx"))
+	assert.Equal(t, "DC", NormalizeState("District of Columbia"))
+	assert.Equal(t, "PR", NormalizeState("Puerto Rico"))
+	assert.Equal(t, "", NormalizeState("Ontario"))
+}
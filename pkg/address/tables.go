@@ -0,0 +1,136 @@
+package address
+
+// suffixes maps USPS Publication 28 street suffix names and common
+// variants to their standard abbreviations.
+var suffixes = map[string]string{
+	"ALLEY": "ALY", "ALLEE": "ALY", "ALLY": "ALY", "ALY": "ALY",
+	"ANNEX": "ANX", "ANNX": "ANX", "ANX": "ANX",
+	"AVENUE": "AVE", "AV": "AVE", "AVE": "AVE", "AVEN": "AVE", "AVENU": "AVE", "AVN": "AVE", "AVNUE": "AVE",
+	"BAYOU": "BYU", "BYU": "BYU",
+	"BEND": "BND", "BND": "BND",
+	"BLUFF": "BLF", "BLF": "BLF",
+	"BOULEVARD": "BLVD", "BLVD": "BLVD", "BOUL": "BLVD", "BOULV": "BLVD",
+	"BRANCH": "BR", "BR": "BR",
+	"BRIDGE": "BRG", "BRDGE": "BRG", "BRG": "BRG",
+	"BYPASS": "BYP", "BYP": "BYP", "BYPS": "BYP",
+	"CAUSEWAY": "CSWY", "CAUSWA": "CSWY", "CSWY": "CSWY",
+	"CENTER": "CTR", "CEN": "CTR", "CENT": "CTR", "CENTR": "CTR", "CENTRE": "CTR", "CNTER": "CTR", "CNTR": "CTR", "CTR": "CTR",
+	"CIRCLE": "CIR", "CIR": "CIR", "CIRC": "CIR", "CIRCL": "CIR", "CRCL": "CIR", "CRCLE": "CIR",
+	"COURT": "CT", "CT": "CT", "CRT": "CT",
+	"COVE": "CV", "CV": "CV",
+	"CREEK": "CRK", "CRK": "CRK",
+	"CROSSING": "XING", "CRSSNG": "XING", "XING": "XING",
+	"DRIVE": "DR", "DR": "DR", "DRIV": "DR", "DRV": "DR",
+	"EXPRESSWAY": "EXPY", "EXP": "EXPY", "EXPR": "EXPY", "EXPRESS": "EXPY", "EXPW": "EXPY", "EXPY": "EXPY",
+	"EXTENSION": "EXT", "EXT": "EXT", "EXTN": "EXT", "EXTNSN": "EXT",
+	"FREEWAY": "FWY", "FREEWY": "FWY", "FRWAY": "FWY", "FRWY": "FWY", "FWY": "FWY",
+	"GARDENS": "GDNS", "GDNS": "GDNS",
+	"GATEWAY": "GTWY", "GATEWY": "GTWY", "GATWAY": "GTWY", "GTWAY": "GTWY", "GTWY": "GTWY",
+	"GROVE": "GRV", "GROV": "GRV", "GRV": "GRV",
+	"HEIGHTS": "HTS", "HT": "HTS", "HTS": "HTS",
+	"HIGHWAY": "HWY", "HIGHWY": "HWY", "HIWAY": "HWY", "HIWY": "HWY", "HWAY": "HWY", "HWY": "HWY",
+	"HILL": "HL", "HL": "HL",
+	"HOLLOW": "HOLW", "HOLLOWS": "HOLW", "HLLW": "HOLW", "HOLW": "HOLW",
+	"ISLAND": "IS", "IS": "IS",
+	"JUNCTION": "JCT", "JCT": "JCT", "JCTION": "JCT", "JUNCTN": "JCT",
+	"LAKE": "LK", "LK": "LK",
+	"LANDING": "LNDG", "LNDG": "LNDG",
+	"LANE": "LN", "LN": "LN",
+	"LOOP": "LOOP", "LOOPS": "LOOP",
+	"MALL":  "MALL",
+	"MANOR": "MNR", "MNR": "MNR",
+	"MEADOWS": "MDWS", "MDWS": "MDWS",
+	"MILL": "ML", "ML": "ML",
+	"MOTORWAY": "MTWY", "MTWY": "MTWY",
+	"MOUNTAIN": "MTN", "MTN": "MTN", "MTIN": "MTN",
+	"PARK": "PARK", "PRK": "PARK",
+	"PARKWAY": "PKWY", "PARKWY": "PKWY", "PKWAY": "PKWY", "PKWY": "PKWY", "PKY": "PKWY",
+	"PASS": "PASS",
+	"PATH": "PATH",
+	"PIKE": "PIKE", "PIKES": "PIKE",
+	"PLACE": "PL", "PL": "PL",
+	"PLAZA": "PLZ", "PLZ": "PLZ", "PLZA": "PLZ",
+	"POINT": "PT", "PT": "PT",
+	"PORT": "PRT", "PRT": "PRT",
+	"RANCH": "RNCH", "RNCH": "RNCH",
+	"RIDGE": "RDG", "RDG": "RDG", "RDGE": "RDG",
+	"ROAD": "RD", "RD": "RD",
+	"ROUTE": "RTE", "RTE": "RTE",
+	"ROW":    "ROW",
+	"RUN":    "RUN",
+	"SKYWAY": "SKWY", "SKWY": "SKWY",
+	"SQUARE": "SQ", "SQ": "SQ", "SQR": "SQ", "SQRE": "SQ", "SQU": "SQ",
+	"STATION": "STA", "STA": "STA", "STATN": "STA", "STN": "STA",
+	"STREET": "ST", "ST": "ST", "STR": "ST", "STRT": "ST",
+	"SUMMIT": "SMT", "SMT": "SMT", "SUMIT": "SMT",
+	"TERRACE": "TER", "TER": "TER", "TERR": "TER",
+	"THROUGHWAY": "TRWY", "TRWY": "TRWY",
+	"TRACE": "TRCE", "TRCE": "TRCE",
+	"TRAIL": "TRL", "TRL": "TRL", "TRAILS": "TRL", "TRLS": "TRL",
+	"TURNPIKE": "TPKE", "TPKE": "TPKE", "TRNPK": "TPKE", "TURNPK": "TPKE",
+	"VALLEY": "VLY", "VLY": "VLY", "VALLY": "VLY",
+	"VIEW": "VW", "VW": "VW",
+	"VILLAGE": "VLG", "VLG": "VLG", "VILL": "VLG",
+	"VISTA": "VIS", "VIS": "VIS", "VIST": "VIS",
+	"WALK": "WALK",
+	"WAY":  "WAY", "WY": "WAY",
+}
+
+// directionals maps directional words to their abbreviations.
+var directionals = map[string]string{
+	"NORTH": "N", "N": "N", "SOUTH": "S", "S": "S", "EAST": "E", "E": "E", "WEST": "W", "W": "W",
+	"NORTHEAST": "NE", "NE": "NE", "NORTHWEST": "NW", "NW": "NW",
+	"SOUTHEAST": "SE", "SE": "SE", "SOUTHWEST": "SW", "SW": "SW",
+}
+
+// unitTypes maps secondary unit designators to their abbreviations. A bare
+// "#" becomes UNIT.
+var unitTypes = map[string]string{
+	"APARTMENT": "APT", "APT": "APT",
+	"BASEMENT": "BSMT", "BSMT": "BSMT",
+	"BUILDING": "BLDG", "BLDG": "BLDG",
+	"DEPARTMENT": "DEPT", "DEPT": "DEPT",
+	"FLOOR": "FL", "FL": "FL", "FLR": "FL",
+	"FRONT": "FRNT", "FRNT": "FRNT",
+	"HANGAR": "HNGR", "HNGR": "HNGR",
+	"LOBBY": "LBBY", "LBBY": "LBBY",
+	"LOT":   "LOT",
+	"LOWER": "LOWR", "LOWR": "LOWR",
+	"OFFICE": "OFC", "OFC": "OFC",
+	"PENTHOUSE": "PH", "PH": "PH",
+	"PIER": "PIER",
+	"REAR": "REAR",
+	"ROOM": "RM", "RM": "RM",
+	"SLIP":  "SLIP",
+	"SPACE": "SPC", "SPC": "SPC",
+	"STOP":  "STOP",
+	"SUITE": "STE", "STE": "STE", "SUIT": "STE",
+	"TRAILER": "TRLR", "TRLR": "TRLR",
+	"UNIT": "UNIT", "#": "UNIT",
+	"UPPER": "UPPR", "UPPR": "UPPR",
+}
+
+// unitNoValue lists designators that take no unit value.
+var unitNoValue = map[string]bool{
+	"BSMT": true, "FRNT": true, "LBBY": true, "LOWR": true, "OFC": true,
+	"PH": true, "REAR": true, "UPPR": true,
+}
+
+// stateNames maps USPS state, DC, and territory abbreviations to names.
+var stateNames = map[string]string{
+	"AL": "ALABAMA", "AK": "ALASKA", "AZ": "ARIZONA", "AR": "ARKANSAS",
+	"CA": "CALIFORNIA", "CO": "COLORADO", "CT": "CONNECTICUT", "DE": "DELAWARE",
+	"DC": "DISTRICT OF COLUMBIA", "FL": "FLORIDA", "GA": "GEORGIA", "HI": "HAWAII",
+	"ID": "IDAHO", "IL": "ILLINOIS", "IN": "INDIANA", "IA": "IOWA",
+	"KS": "KANSAS", "KY": "KENTUCKY", "LA": "LOUISIANA", "ME": "MAINE",
+	"MD": "MARYLAND", "MA": "MASSACHUSETTS", "MI": "MICHIGAN", "MN": "MINNESOTA",
+	"MS": "MISSISSIPPI", "MO": "MISSOURI", "MT": "MONTANA", "NE": "NEBRASKA",
+	"NV": "NEVADA", "NH": "NEW HAMPSHIRE", "NJ": "NEW JERSEY", "NM": "NEW MEXICO",
+	"NY": "NEW YORK", "NC": "NORTH CAROLINA", "ND": "NORTH DAKOTA", "OH": "OHIO",
+	"OK": "OKLAHOMA", "OR": "OREGON", "PA": "PENNSYLVANIA", "RI": "RHODE ISLAND",
+	"SC": "SOUTH CAROLINA", "SD": "SOUTH DAKOTA", "TN": "TENNESSEE", "TX": "TEXAS",
+	"UT": "UTAH", "VT": "VERMONT", "VA": "VIRGINIA", "WA": "WASHINGTON",
+	"WV": "WEST VIRGINIA", "WI": "WISCONSIN", "WY": "WYOMING",
+	"AS": "AMERICAN SAMOA", "GU": "GUAM", "MP": "NORTHERN MARIANA ISLANDS",
+	"PR": "PUERTO RICO", "VI": "VIRGIN ISLANDS",
+}
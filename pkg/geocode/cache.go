@@ -1,6 +1,7 @@
 package geocode
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"fmt"
@@ -8,17 +9,30 @@ import (
 
 	"github.com/rotisserie/eris"
 	"go.uber.org/zap"
+
+	"github.com/sells-group/research-cli/internal/opsmetrics"
 )
 
-// cacheKey returns SHA-256 hex of the normalized address for cache lookup.
+// DefaultCacheTable is the geocode cache shared by the geocode clients and
+// the geocode queue worker.
+const DefaultCacheTable = "geo.geocode_cache"
+
+// CacheKey returns the cache key for addr: the SHA-256 hex of its
+// normalized one-line form, so spellings of the same address queued from
+// different sources share one entry.
+func CacheKey(addr AddressInput) string {
+	return cacheKey(NormalizeInput(addr))
+}
+
+// CacheAddress returns the normalized one-line address stored with a cache
+// entry as address_norm.
+func CacheAddress(addr AddressInput) string {
+	return strings.ToUpper(formatOneLine(addr))
+}
+
+// cacheKey returns SHA-256 hex of an already-normalized address.
 func cacheKey(addr AddressInput) string {
-	normalized := fmt.Sprintf("%s|%s|%s|%s",
-		strings.ToLower(strings.TrimSpace(addr.Street)),
-		strings.ToLower(strings.TrimSpace(addr.City)),
-		strings.ToLower(strings.TrimSpace(addr.State)),
-		strings.TrimSpace(addr.ZipCode),
-	)
-	h := sha256.Sum256([]byte(normalized))
+	h := sha256.Sum256([]byte(CacheAddress(addr)))
 	return fmt.Sprintf("%x", h)
 }
 
@@ -31,10 +45,7 @@ func (g *geocoder) checkCache(ctx context.Context, key string) (*Result, error)
 	var matched bool
 	var countyFIPS *string
 
-	table := g.cacheTable
-	if table == "" {
-		table = "public.geocode_cache"
-	}
+	table := cmp.Or(g.cacheTable, DefaultCacheTable)
 	query := fmt.Sprintf("SELECT latitude, longitude, quality, rating, matched, county_fips FROM %s WHERE address_hash = $1", table)
 	args := []any{key}
 
@@ -44,8 +55,10 @@ func (g *geocoder) checkCache(ctx context.Context, key string) (*Result, error)
 
 	row := g.pool.QueryRow(ctx, query, args...)
 	if err := row.Scan(&lat, &lon, &quality, &rating, &matched, &countyFIPS); err != nil {
+		opsmetrics.RecordCacheEvent("miss", "geocode", "postgres")
 		return nil, err // no row or scan error — caller handles
 	}
+	opsmetrics.RecordCacheEvent("hit", "geocode", "postgres")
 
 	r := &Result{
 		Latitude:  lat,
@@ -70,15 +83,13 @@ func (g *geocoder) checkCache(ctx context.Context, key string) (*Result, error)
 }
 
 // storeCache inserts a geocode result (match or non-match) into the cache.
-func (g *geocoder) storeCache(ctx context.Context, key string, result *Result) error {
-	table := g.cacheTable
-	if table == "" {
-		table = "public.geocode_cache"
-	}
+func (g *geocoder) storeCache(ctx context.Context, key, norm string, result *Result) error {
+	table := cmp.Or(g.cacheTable, DefaultCacheTable)
 	_, err := g.pool.Exec(ctx, fmt.Sprintf(`
-		INSERT INTO %s (address_hash, latitude, longitude, quality, rating, matched, county_fips, cached_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+		INSERT INTO %s (address_hash, address_norm, latitude, longitude, quality, rating, matched, county_fips, cached_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
 		ON CONFLICT (address_hash) DO UPDATE SET
+			address_norm = EXCLUDED.address_norm,
 			latitude = EXCLUDED.latitude,
 			longitude = EXCLUDED.longitude,
 			quality = EXCLUDED.quality,
@@ -86,11 +97,12 @@ func (g *geocoder) storeCache(ctx context.Context, key string, result *Result) e
 			matched = EXCLUDED.matched,
 			county_fips = EXCLUDED.county_fips,
 			cached_at = now()`, table),
-		key, result.Latitude, result.Longitude, result.Quality, result.Rating, result.Matched, nilIfEmpty(result.CountyFIPS),
+		key, nilIfEmpty(norm), result.Latitude, result.Longitude, result.Quality, result.Rating, result.Matched, nilIfEmpty(result.CountyFIPS),
 	)
 	if err != nil {
 		return eris.Wrap(err, "geocode: store cache")
 	}
+	opsmetrics.RecordCacheEvent("set", "geocode", "postgres")
 	return nil
 }
 
@@ -44,7 +44,7 @@ func TestCheckCache_Hit(t *testing.T) {
 
 	rating := 5
 	countyFIPS := "12086"
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs("abc123").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips"}).
@@ -72,7 +72,7 @@ func TestCheckCache_Miss(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs("missing-key").
 		WillReturnError(assert.AnError)
 
@@ -90,7 +90,7 @@ func TestCheckCache_NegativeHit(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs("neg-key").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips"}).
@@ -114,7 +114,7 @@ func TestCheckCache_TTLFilter(t *testing.T) {
 	defer mock.Close()
 
 	// With TTL configured, the query should include the TTL clause.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache WHERE address_hash = .+ AND cached_at > now\(\) - interval '90 days'`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache WHERE address_hash = .+ AND cached_at > now\(\) - interval '90 days'`).
 		WithArgs("ttl-key").
 		WillReturnError(assert.AnError)
 
@@ -132,12 +132,12 @@ func TestStoreCache(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs("hashkey", 25.77, -80.19, "rooftop", 5, true, "12086").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs("hashkey", "100 S BISCAYNE BLVD, MIAMI, FL, 33131", 25.77, -80.19, "rooftop", 5, true, "12086").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := &geocoder{pool: mock, cacheEnabled: true}
-	err = g.storeCache(context.Background(), "hashkey", &Result{
+	err = g.storeCache(context.Background(), "hashkey", "100 S BISCAYNE BLVD, MIAMI, FL, 33131", &Result{
 		Latitude:   25.77,
 		Longitude:  -80.19,
 		Quality:    "rooftop",
@@ -155,12 +155,12 @@ func TestStoreCache_NegativeEntry(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs("neg-hashkey", 0.0, 0.0, "", 0, false, nil).
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs("neg-hashkey", nil, 0.0, 0.0, "", 0, false, nil).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := &geocoder{pool: mock, cacheEnabled: true}
-	err = g.storeCache(context.Background(), "neg-hashkey", &Result{
+	err = g.storeCache(context.Background(), "neg-hashkey", "", &Result{
 		Matched: false,
 		Source:  "tiger",
 	})
@@ -175,12 +175,12 @@ func TestCacheRoundTrip_CountyFIPS(t *testing.T) {
 	defer mock.Close()
 
 	// Store a result with CountyFIPS.
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs("fips-key", 25.77, -80.19, "rooftop", 5, true, "12086").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs("fips-key", nil, 25.77, -80.19, "rooftop", 5, true, "12086").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := &geocoder{pool: mock, cacheEnabled: true}
-	err = g.storeCache(context.Background(), "fips-key", &Result{
+	err = g.storeCache(context.Background(), "fips-key", "", &Result{
 		Latitude:   25.77,
 		Longitude:  -80.19,
 		Quality:    "rooftop",
@@ -193,7 +193,7 @@ func TestCacheRoundTrip_CountyFIPS(t *testing.T) {
 	// Retrieve from cache — county_fips should round-trip.
 	rating := 5
 	countyFIPS := "12086"
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs("fips-key").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips"}).
@@ -217,7 +217,7 @@ func TestGeocode_CacheHitSkipsPostGIS(t *testing.T) {
 
 	rating := 3
 	countyFIPS := "12086"
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnRows(
 			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips"}).
@@ -246,7 +246,7 @@ func TestGeocode_NegativeCacheHitSkipsPostGIS(t *testing.T) {
 	defer mock.Close()
 
 	// Negative cache entry: Matched=false.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnRows(
 			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips"}).
@@ -271,12 +271,12 @@ func TestStoreCache_Error(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs("hashkey", 25.77, -80.19, "rooftop", 5, true, "12086").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs("hashkey", "100 S BISCAYNE BLVD, MIAMI, FL, 33131", 25.77, -80.19, "rooftop", 5, true, "12086").
 		WillReturnError(assert.AnError)
 
 	g := &geocoder{pool: mock, cacheEnabled: true}
-	err = g.storeCache(context.Background(), "hashkey", &Result{
+	err = g.storeCache(context.Background(), "hashkey", "100 S BISCAYNE BLVD, MIAMI, FL, 33131", &Result{
 		Latitude:   25.77,
 		Longitude:  -80.19,
 		Quality:    "rooftop",
@@ -298,7 +298,7 @@ func TestGeocode_CacheDisabled(t *testing.T) {
 
 	// No cache query expected when cache is disabled.
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("100 Main St, Miami, FL, 33131").
+		WithArgs("100 MAIN ST, MIAMI, FL, 33131").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"lat", "lon", "rating", "matched_address", "county_fips"}).
 				AddRow(25.77, -80.19, 3, "100 Main St, Miami, FL 33131", "12086"),
@@ -354,3 +354,11 @@ func TestWithCacheTTLDays(t *testing.T) {
 	g := NewClient(mock, WithCacheTTLDays(30)).(*geocoder)
 	assert.Equal(t, 30, g.cacheTTLDays)
 }
+
+func TestCacheKey_NormalizedVariants(t *testing.T) {
+	oneLine := AddressInput{Street: "100 North Main Street, Suite 4, Miami, Florida 33131"}
+	parts := AddressInput{Street: "100 N. Main St. Ste. 4", City: "miami", State: "FL", ZipCode: "33131-1234"}
+
+	assert.Equal(t, CacheKey(oneLine), CacheKey(parts))
+	assert.Equal(t, "100 N MAIN ST STE 4, MIAMI, FL, 33131", CacheAddress(NormalizeInput(parts)))
+}
@@ -102,13 +102,16 @@ func (p *CensusProvider) Geocode(ctx context.Context, addr AddressInput) (*Resul
 		return &Result{Matched: false, Source: "census"}, nil
 	}
 
+	// The single-address API does not report exact vs. non-exact matches,
+	// so matches get a flat confidence just under an exact batch match.
 	match := cr.Result.AddressMatches[0]
 	result := &Result{
-		Latitude:  match.Coordinates.Y,
-		Longitude: match.Coordinates.X,
-		Source:    "census",
-		Quality:   "rooftop",
-		Matched:   true,
+		Latitude:   match.Coordinates.Y,
+		Longitude:  match.Coordinates.X,
+		Source:     "census",
+		Quality:    "rooftop",
+		Confidence: 0.9,
+		Matched:    true,
 	}
 	// NOTE: Census geocoding API does not return county FIPS directly.
 	// State FIPS would require a separate lookup.
@@ -0,0 +1,205 @@
+package geocode
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+const defaultCensusBatchURL = "https://geocoding.geo.census.gov/geocoder/geographies/addressbatch"
+
+// CensusBatchMaxRecords is the most addresses the Census Bureau batch
+// geocoder accepts in one request.
+const CensusBatchMaxRecords = 10000
+
+// Census batch geocoder match statuses.
+const (
+	CensusMatch   = "Match"
+	CensusNoMatch = "No_Match"
+	CensusTie     = "Tie"
+)
+
+// BatchMatch is one record of a Census Bureau batch geocoder response.
+type BatchMatch struct {
+	ID             string
+	Status         string // Match, No_Match, or Tie
+	MatchType      string // Exact or Non_Exact; empty unless matched
+	MatchedAddress string
+	Latitude       float64
+	Longitude      float64
+	StateFIPS      string // 2-digit state FIPS
+	CountyFIPS     string // 5-digit state+county FIPS
+	TractGEOID     string // 11-digit census tract GEOID
+}
+
+// Matched reports whether the address matched a single location.
+func (m BatchMatch) Matched() bool { return m.Status == CensusMatch }
+
+// CensusBatchClient geocodes up to CensusBatchMaxRecords addresses per
+// request via the Census Bureau batch geocoder, returning coordinates and
+// census geographies (state, county, tract) for each match.
+type CensusBatchClient struct {
+	client    *http.Client
+	baseURL   string
+	benchmark string
+	vintage   string
+}
+
+// CensusBatchOption configures the CensusBatchClient.
+type CensusBatchOption func(*CensusBatchClient)
+
+// WithCensusBatchHTTPClient sets a custom HTTP client for the batch geocoder.
+func WithCensusBatchHTTPClient(c *http.Client) CensusBatchOption {
+	return func(b *CensusBatchClient) {
+		b.client = c
+	}
+}
+
+// WithCensusBatchBaseURL overrides the batch geocoder URL (for testing).
+func WithCensusBatchBaseURL(u string) CensusBatchOption {
+	return func(b *CensusBatchClient) {
+		b.baseURL = u
+	}
+}
+
+// NewCensusBatchClient creates a CensusBatchClient using the current
+// benchmark and vintage.
+func NewCensusBatchClient(opts ...CensusBatchOption) *CensusBatchClient {
+	b := &CensusBatchClient{
+		// Full 10,000-record batches take several minutes to return.
+		client:    &http.Client{Timeout: 15 * time.Minute},
+		baseURL:   defaultCensusBatchURL,
+		benchmark: "Public_AR_Current",
+		vintage:   "Current_Current",
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// GeocodeBatch submits addrs to the batch geocoder. Addresses are
+// correlated by AddressInput.ID, which must be unique within the batch.
+// Results come back in the geocoder's order, not the input order.
+func (b *CensusBatchClient) GeocodeBatch(ctx context.Context, addrs []AddressInput) ([]BatchMatch, error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	if len(addrs) > CensusBatchMaxRecords {
+		return nil, eris.Errorf("census batch: %d addresses exceeds limit of %d", len(addrs), CensusBatchMaxRecords)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("benchmark", b.benchmark)
+	_ = mw.WriteField("vintage", b.vintage)
+	part, err := mw.CreateFormFile("addressFile", "addresses.csv")
+	if err != nil {
+		return nil, eris.Wrap(err, "census batch: create form file")
+	}
+	cw := csv.NewWriter(part)
+	for _, a := range addrs {
+		if err := cw.Write([]string{a.ID, a.Street, a.City, a.State, a.ZipCode}); err != nil {
+			return nil, eris.Wrap(err, "census batch: write address")
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, eris.Wrap(err, "census batch: write addresses")
+	}
+	if err := mw.Close(); err != nil {
+		return nil, eris.Wrap(err, "census batch: close form")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL, &body)
+	if err != nil {
+		return nil, eris.Wrap(err, "census batch: build request")
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "census batch: http request")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, eris.Errorf("census batch: unexpected status %d", resp.StatusCode)
+	}
+	return parseCensusBatch(resp.Body)
+}
+
+// parseCensusBatch parses the batch geocoder's headerless CSV response:
+// id, input address, status, match type, matched address, "lon,lat",
+// TIGER line id, side, state, county, tract, block. Unmatched records
+// stop after the status column.
+func parseCensusBatch(r io.Reader) ([]BatchMatch, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var out []BatchMatch
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, eris.Wrap(err, "census batch: parse response")
+		}
+		if len(rec) < 3 {
+			continue
+		}
+		m := BatchMatch{ID: strings.TrimSpace(rec[0]), Status: strings.TrimSpace(rec[2])}
+		if m.Matched() && len(rec) >= 6 {
+			m.MatchType = batchField(rec, 3)
+			m.MatchedAddress = batchField(rec, 4)
+			lon, lat, ok := strings.Cut(rec[5], ",")
+			if !ok {
+				return nil, eris.Errorf("census batch: bad coordinates %q for id %s", rec[5], m.ID)
+			}
+			if m.Longitude, err = strconv.ParseFloat(strings.TrimSpace(lon), 64); err != nil {
+				return nil, eris.Wrapf(err, "census batch: parse longitude for id %s", m.ID)
+			}
+			if m.Latitude, err = strconv.ParseFloat(strings.TrimSpace(lat), 64); err != nil {
+				return nil, eris.Wrapf(err, "census batch: parse latitude for id %s", m.ID)
+			}
+			state, county, tract := batchField(rec, 8), batchField(rec, 9), batchField(rec, 10)
+			if len(state) == 2 {
+				m.StateFIPS = state
+				if len(county) == 3 {
+					m.CountyFIPS = state + county
+					if len(tract) == 6 {
+						m.TractGEOID = m.CountyFIPS + tract
+					}
+				}
+			}
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// batchField returns the trimmed i'th column of rec, or "" when absent.
+func batchField(rec []string, i int) string {
+	if i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+// ParseOneLine parses and normalizes a one-line "street, city, ST zip"
+// address (see NormalizeInput). Addresses without a recognizable city,
+// state, or ZIP keep their normalized street line in Street, which the
+// geocoders still accept.
+func ParseOneLine(id, addr string) AddressInput {
+	return NormalizeInput(AddressInput{ID: id, Street: addr})
+}
@@ -0,0 +1,104 @@
+package geocode
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCensusBatchResponse = `"1","1600 Pennsylvania Ave NW, Washington, DC, 20500","Match","Exact","1600 PENNSYLVANIA AVE NW, WASHINGTON, DC, 20500","-77.03518753691,38.89869893252","76225813","L","11","001","980000","1034"
+"2","1 Nowhere Rd, Nowhere, ZZ, 00000","No_Match"
+"3","100 Main St, Springfield, ","Tie"
+`
+
+func TestCensusBatchClient_GeocodeBatch(t *testing.T) {
+	var gotAddrs [][]string
+	var gotBenchmark string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		gotBenchmark = r.FormValue("benchmark")
+		f, _, err := r.FormFile("addressFile")
+		require.NoError(t, err)
+		gotAddrs, err = csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(testCensusBatchResponse))
+	}))
+	defer srv.Close()
+
+	c := NewCensusBatchClient(WithCensusBatchBaseURL(srv.URL))
+	got, err := c.GeocodeBatch(context.Background(), []AddressInput{
+		{ID: "1", Street: "1600 Pennsylvania Ave NW", City: "Washington", State: "DC", ZipCode: "20500"},
+		{ID: "2", Street: "1 Nowhere Rd", City: "Nowhere", State: "ZZ", ZipCode: "00000"},
+		{ID: "3", Street: "100 Main St", City: "Springfield"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Public_AR_Current", gotBenchmark)
+	require.Len(t, gotAddrs, 3)
+	assert.Equal(t, []string{"1", "1600 Pennsylvania Ave NW", "Washington", "DC", "20500"}, gotAddrs[0])
+
+	require.Len(t, got, 3)
+	m := got[0]
+	assert.True(t, m.Matched())
+	assert.Equal(t, "Exact", m.MatchType)
+	assert.Equal(t, "1600 PENNSYLVANIA AVE NW, WASHINGTON, DC, 20500", m.MatchedAddress)
+	assert.InDelta(t, 38.8987, m.Latitude, 0.0001)
+	assert.InDelta(t, -77.0352, m.Longitude, 0.0001)
+	assert.Equal(t, "11", m.StateFIPS)
+	assert.Equal(t, "11001", m.CountyFIPS)
+	assert.Equal(t, "11001980000", m.TractGEOID)
+
+	assert.Equal(t, CensusNoMatch, got[1].Status)
+	assert.False(t, got[1].Matched())
+	assert.Equal(t, CensusTie, got[2].Status)
+	assert.Empty(t, got[2].CountyFIPS)
+}
+
+func TestCensusBatchClient_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c := NewCensusBatchClient(WithCensusBatchBaseURL(srv.URL))
+
+	got, err := c.GeocodeBatch(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = c.GeocodeBatch(context.Background(), []AddressInput{{ID: "1", Street: "1 Main St"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 503")
+
+	_, err = c.GeocodeBatch(context.Background(), make([]AddressInput, CensusBatchMaxRecords+1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds limit")
+}
+
+func TestParseCensusBatch_BadCoordinates(t *testing.T) {
+	_, err := parseCensusBatch(strings.NewReader(`"1","x","Match","Exact","X","bad"` + "\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad coordinates")
+}
+
+func TestParseOneLine(t *testing.T) {
+	tests := []struct {
+		addr string
+		want AddressInput
+	}{
+		{"100 Main St, Miami, FL 33101", AddressInput{ID: "7", Street: "100 MAIN ST", City: "MIAMI", State: "FL", ZipCode: "33101"}},
+		{"100 Main St, Suite 200, Austin, tx 78701-1234, USA", AddressInput{ID: "7", Street: "100 MAIN ST STE 200", City: "AUSTIN", State: "TX", ZipCode: "78701"}},
+		{"100 Main St, Miami, FL", AddressInput{ID: "7", Street: "100 MAIN ST", City: "MIAMI", State: "FL"}},
+		{"100 Main St Miami FL 33101", AddressInput{ID: "7", Street: "100 MAIN ST MIAMI", State: "FL", ZipCode: "33101"}},
+		{"100 Main St, Miami, Florida", AddressInput{ID: "7", Street: "100 MAIN ST", City: "MIAMI", State: "FL"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ParseOneLine("7", tt.addr), tt.addr)
+	}
+}
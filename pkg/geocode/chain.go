@@ -0,0 +1,129 @@
+package geocode
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rotisserie/eris"
+	"golang.org/x/time/rate"
+
+	"github.com/sells-group/research-cli/internal/db"
+)
+
+// ProviderCosts is the approximate list price in USD per 1,000 requests for
+// each provider. The fallback chain tries cheaper providers first. Mapbox
+// is priced for permanent geocoding, which storing its results requires.
+var ProviderCosts = map[string]float64{
+	"tiger":     0,
+	"census":    0,
+	"nominatim": 0,
+	"mapbox":    5.00,
+	"google":    5.00,
+}
+
+// DefaultRateLimits is each provider's requests-per-second limit when the
+// chain config does not set one. Nominatim's public usage policy allows one
+// request per second; tiger runs locally and is unlimited.
+var DefaultRateLimits = map[string]float64{
+	"census":    10,
+	"nominatim": 1,
+	"mapbox":    10,
+	"google":    50,
+}
+
+// Paid reports whether the named provider charges per request.
+func Paid(provider string) bool { return ProviderCosts[provider] > 0 }
+
+// ChainConfig configures the provider fallback chain.
+type ChainConfig struct {
+	Providers    []string           // provider names; ordered by cost when built
+	RateLimits   map[string]float64 // provider -> requests/sec; 0 = DefaultRateLimits
+	MaxRating    int                // tiger rating threshold
+	MapboxToken  string
+	GoogleAPIKey string
+}
+
+// BuildProviders constructs the named providers, ordered by ProviderCosts
+// (config order breaks ties) and wrapped with their rate limits. Providers
+// missing credentials are kept but skipped by the cascade via Available.
+func BuildProviders(pool db.Pool, cfg ChainConfig) ([]Provider, error) {
+	names := append([]string(nil), cfg.Providers...)
+	for _, name := range names {
+		if _, ok := ProviderCosts[name]; !ok {
+			return nil, eris.Errorf("geocode: unknown provider %q", name)
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return ProviderCosts[names[i]] < ProviderCosts[names[j]]
+	})
+
+	providers := make([]Provider, 0, len(names))
+	for _, name := range names {
+		var p Provider
+		switch name {
+		case "tiger":
+			p = NewTigerProvider(pool, cfg.MaxRating)
+		case "census":
+			p = NewCensusProvider()
+		case "nominatim":
+			p = NewNominatimProvider()
+		case "mapbox":
+			p = NewMapboxProvider(cfg.MapboxToken)
+		case "google":
+			p = NewGoogleProvider(cfg.GoogleAPIKey)
+		}
+		rps, ok := cfg.RateLimits[name]
+		if !ok || rps <= 0 {
+			rps = DefaultRateLimits[name]
+		}
+		providers = append(providers, WithRateLimit(p, rps))
+	}
+	return providers, nil
+}
+
+// WithRateLimit wraps p so Geocode waits for a token from a
+// requests-per-second limiter. A non-positive rps returns p unchanged.
+func WithRateLimit(p Provider, rps float64) Provider {
+	if rps <= 0 {
+		return p
+	}
+	return &rateLimitedProvider{Provider: p, limiter: rate.NewLimiter(rate.Limit(rps), 1)}
+}
+
+// rateLimitedProvider throttles calls to the wrapped Provider.
+type rateLimitedProvider struct {
+	Provider
+	limiter *rate.Limiter
+}
+
+// Geocode implements Provider.
+func (r *rateLimitedProvider) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, eris.Wrapf(err, "%s: rate limit", r.Name())
+	}
+	return r.Provider.Geocode(ctx, addr)
+}
+
+// reverse rate-limits a reverse geocode through the wrapped ReverseProvider.
+func (r *rateLimitedProvider) reverse(ctx context.Context, lat, lng float64) (*ReverseResult, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, eris.Wrapf(err, "%s: rate limit", r.Name())
+	}
+	return r.Provider.(ReverseProvider).ReverseGeocode(ctx, lat, lng)
+}
+
+// reverseFunc returns p's reverse geocode function, looking through the
+// rate limiter. ok is false when p cannot reverse geocode.
+func reverseFunc(p Provider) (fn func(ctx context.Context, lat, lng float64) (*ReverseResult, error), ok bool) {
+	if rl, isLimited := p.(*rateLimitedProvider); isLimited {
+		if _, ok := rl.Provider.(ReverseProvider); !ok {
+			return nil, false
+		}
+		return rl.reverse, true
+	}
+	rp, ok := p.(ReverseProvider)
+	if !ok {
+		return nil, false
+	}
+	return rp.ReverseGeocode, true
+}
@@ -0,0 +1,100 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProviders_OrderedByCost(t *testing.T) {
+	providers, err := BuildProviders(nil, ChainConfig{
+		Providers:   []string{"google", "mapbox", "census", "tiger", "nominatim"},
+		RateLimits:  map[string]float64{"google": 5},
+		MapboxToken: "tok",
+	})
+	require.NoError(t, err)
+
+	names := make([]string, len(providers))
+	for i, p := range providers {
+		names[i] = p.Name()
+	}
+	assert.Equal(t, []string{"census", "tiger", "nominatim", "google", "mapbox"}, names, "equal-cost paid providers keep config order")
+
+	assert.IsType(t, &TigerProvider{}, providers[1], "tiger is not rate limited")
+	require.IsType(t, &rateLimitedProvider{}, providers[3])
+	assert.InDelta(t, 5, float64(providers[3].(*rateLimitedProvider).limiter.Limit()), 1e-9)
+	assert.InDelta(t, 1, float64(providers[2].(*rateLimitedProvider).limiter.Limit()), 1e-9, "nominatim default")
+	assert.False(t, providers[3].Available(), "google has no key")
+	assert.True(t, providers[4].Available())
+}
+
+func TestBuildProviders_Unknown(t *testing.T) {
+	_, err := BuildProviders(nil, ChainConfig{Providers: []string{"census", "here"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown provider "here"`)
+}
+
+func TestPaid(t *testing.T) {
+	assert.False(t, Paid("census"))
+	assert.False(t, Paid("nominatim"))
+	assert.True(t, Paid("mapbox"))
+	assert.True(t, Paid("google"))
+}
+
+func TestWithRateLimit(t *testing.T) {
+	p := &mockProvider{name: "m", available: true, result: &Result{Matched: true}}
+	assert.Same(t, p, WithRateLimit(p, 0))
+
+	limited := WithRateLimit(p, 1)
+	_, err := limited.Geocode(context.Background(), AddressInput{})
+	require.NoError(t, err, "first call uses the burst token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limited.Geocode(ctx, AddressInput{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "m: rate limit")
+}
+
+func TestCascadeClient_MinConfidence(t *testing.T) {
+	free := &mockProvider{name: "census", available: true,
+		result: &Result{Matched: true, Source: "census", Latitude: 1, Confidence: 0.5}}
+	paid := &mockProvider{name: "google", available: true,
+		result: &Result{Matched: true, Source: "google", Latitude: 2, Confidence: 0.95}}
+
+	c := NewCascadeClient(nil, []Provider{free, paid}, WithCascadeCacheEnabled(false), WithCascadeMinConfidence(0.8))
+	result, err := c.Geocode(context.Background(), AddressInput{Street: "1 Main St"})
+	require.NoError(t, err)
+	assert.Equal(t, "google", result.Source, "low-confidence free match escalates")
+
+	paid.result = &Result{Matched: true, Source: "google", Latitude: 2, Confidence: 0.4}
+	result, err = c.Geocode(context.Background(), AddressInput{Street: "1 Main St"})
+	require.NoError(t, err)
+	assert.Equal(t, "census", result.Source, "most confident match wins when none reach the threshold")
+	assert.True(t, result.Matched)
+}
+
+func TestCascadeClient_ReverseGeocodeFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"address":{"road":"County Rd 12","village":"Marfa","ISO3166-2-lvl4":"US-TX"}}`))
+	}))
+	defer srv.Close()
+
+	providers := []Provider{
+		&mockProvider{name: "census", available: true},
+		WithRateLimit(NewNominatimProvider(WithNominatimBaseURL(srv.URL)), 100),
+	}
+	c := NewCascadeClient(nil, providers, WithCascadeCacheEnabled(false))
+	result, err := c.ReverseGeocode(context.Background(), 30.31, -104.02)
+	require.NoError(t, err)
+	assert.Equal(t, "County Rd 12, Marfa, TX", result.Street)
+	assert.Equal(t, "nominatim", result.Source)
+
+	_, err = NewCascadeClient(nil, providers[:1], WithCascadeCacheEnabled(false)).ReverseGeocode(context.Background(), 30.31, -104.02)
+	require.Error(t, err)
+}
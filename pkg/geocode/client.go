@@ -2,6 +2,7 @@
 package geocode
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/pkg/address"
 )
 
 // Client geocodes addresses using PostGIS tiger geocoder.
@@ -36,8 +38,9 @@ type AddressInput struct {
 type Result struct {
 	Latitude   float64
 	Longitude  float64
-	Source     string // "tiger"
-	Quality    string // "rooftop", "range", "centroid", "approximate"
+	Source     string  // provider name: "tiger", "census", "nominatim", "mapbox", "google"
+	Quality    string  // "rooftop", "range", "centroid", "approximate"
+	Confidence float64 // provider match confidence, 0-1
 	Matched    bool
 	Rating     int    // PostGIS geocoder rating (0=best)
 	CountyFIPS string // 5-digit state+county FIPS (e.g., "48453" for Travis County TX)
@@ -80,7 +83,7 @@ func WithBatchConcurrency(n int) Option {
 	}
 }
 
-// WithCacheTable sets the cache table name. Default is "public.geocode_cache".
+// WithCacheTable sets the cache table name. Default is DefaultCacheTable.
 func WithCacheTable(table string) Option {
 	return func(g *geocoder) {
 		g.cacheTable = table
@@ -112,7 +115,8 @@ func NewClient(pool db.Pool, opts ...Option) Client {
 
 // Geocode geocodes a single address using PostGIS tiger geocoder.
 func (g *geocoder) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
-	key := cacheKey(addr)
+	addr = NormalizeInput(addr)
+	key, norm := cacheKey(addr), CacheAddress(addr)
 
 	// Check cache first.
 	if g.cacheEnabled {
@@ -130,7 +134,7 @@ func (g *geocoder) Geocode(ctx context.Context, addr AddressInput) (*Result, err
 
 	// Store in cache (both matches and non-matches for negative caching).
 	if g.cacheEnabled {
-		_ = g.storeCache(ctx, key, result)
+		_ = g.storeCache(ctx, key, norm, result)
 	}
 
 	return result, nil
@@ -175,6 +179,25 @@ func (g *geocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*Rever
 	return ReverseGeocode(ctx, g.pool, lat, lng)
 }
 
+// NormalizeInput normalizes addr with package address before geocoding:
+// uppercase, USPS suffix, directional, and unit abbreviations, state
+// abbreviations, and 5-digit ZIPs. A one-line address in Street with no
+// city, state, or ZIP is parsed into its components.
+func NormalizeInput(addr AddressInput) AddressInput {
+	if addr.City == "" && addr.State == "" && addr.ZipCode == "" {
+		c := address.Parse(addr.Street)
+		return AddressInput{ID: addr.ID, Street: c.StreetLine(), City: c.City, State: c.State, ZipCode: c.ZIP5}
+	}
+	zip5, _ := address.SplitZIP(addr.ZipCode)
+	return AddressInput{
+		ID:      addr.ID,
+		Street:  address.NormalizeStreet(addr.Street),
+		City:    strings.Join(strings.Fields(strings.ToUpper(addr.City)), " "),
+		State:   cmp.Or(address.NormalizeState(addr.State), strings.ToUpper(strings.TrimSpace(addr.State))),
+		ZipCode: zip5,
+	}
+}
+
 // formatOneLine formats an address as a single line for the geocoder.
 func formatOneLine(addr AddressInput) string {
 	parts := []string{addr.Street, addr.City, addr.State, addr.ZipCode}
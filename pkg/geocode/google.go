@@ -0,0 +1,143 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+const defaultGoogleBaseURL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+// GoogleCacheTTLDays is the longest Google's terms allow its geocodes to be
+// cached. Cached Google results older than this are ignored whatever the
+// configured cache TTL.
+const GoogleCacheTTLDays = 30
+
+// GoogleProvider geocodes via the Google Maps Geocoding API (paid per
+// request).
+type GoogleProvider struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// GoogleOption configures the GoogleProvider.
+type GoogleOption func(*GoogleProvider)
+
+// WithGoogleBaseURL overrides the Google geocoding URL (for testing).
+func WithGoogleBaseURL(u string) GoogleOption {
+	return func(p *GoogleProvider) {
+		p.baseURL = u
+	}
+}
+
+// NewGoogleProvider creates a GoogleProvider with the given API key.
+func NewGoogleProvider(apiKey string, opts ...GoogleOption) *GoogleProvider {
+	p := &GoogleProvider{
+		client:  http.DefaultClient,
+		baseURL: defaultGoogleBaseURL,
+		apiKey:  apiKey,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name implements Provider.
+func (p *GoogleProvider) Name() string { return "google" }
+
+// Available implements Provider. Requires an API key.
+func (p *GoogleProvider) Available() bool { return p.apiKey != "" }
+
+// googleLocationTypes maps Google location_type to quality and confidence.
+var googleLocationTypes = map[string]struct {
+	quality    string
+	confidence float64
+}{
+	"ROOFTOP":            {"rooftop", 1.0},
+	"RANGE_INTERPOLATED": {"range", 0.8},
+	"GEOMETRIC_CENTER":   {"centroid", 0.6},
+	"APPROXIMATE":        {"approximate", 0.4},
+}
+
+// Geocode implements Provider.
+func (p *GoogleProvider) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
+	oneLine := formatOneLine(addr)
+	if oneLine == "" {
+		return &Result{Matched: false, Source: "google"}, nil
+	}
+
+	params := url.Values{
+		"address":    {oneLine},
+		"components": {"country:US"},
+		"key":        {p.apiKey},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", p.baseURL, params.Encode()), nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "google: build request")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "google: http request")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, eris.Errorf("google: unexpected status %d", resp.StatusCode)
+	}
+
+	var gr struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+		Results      []struct {
+			Geometry struct {
+				Location struct {
+					Lat float64 `json:"lat"`
+					Lng float64 `json:"lng"`
+				} `json:"location"`
+				LocationType string `json:"location_type"`
+			} `json:"geometry"`
+			PartialMatch bool `json:"partial_match"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&gr); err != nil {
+		return nil, eris.Wrap(err, "google: parse response")
+	}
+
+	switch gr.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return &Result{Matched: false, Source: "google"}, nil
+	default:
+		// OVER_QUERY_LIMIT, REQUEST_DENIED, INVALID_REQUEST, UNKNOWN_ERROR.
+		return nil, eris.Errorf("google: status %s: %s", gr.Status, strings.TrimSpace(gr.ErrorMessage))
+	}
+	if len(gr.Results) == 0 {
+		return &Result{Matched: false, Source: "google"}, nil
+	}
+
+	r := gr.Results[0]
+	lt, ok := googleLocationTypes[r.Geometry.LocationType]
+	if !ok {
+		lt = googleLocationTypes["APPROXIMATE"]
+	}
+	confidence := lt.confidence
+	if r.PartialMatch {
+		confidence *= 0.8
+	}
+	return &Result{
+		Latitude:   r.Geometry.Location.Lat,
+		Longitude:  r.Geometry.Location.Lng,
+		Source:     "google",
+		Quality:    lt.quality,
+		Confidence: confidence,
+		Matched:    true,
+	}, nil
+}
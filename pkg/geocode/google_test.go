@@ -0,0 +1,52 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoogleProvider_Match(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.URL.Query().Get("key"))
+		assert.Equal(t, "country:US", r.URL.Query().Get("components"))
+		_, _ = w.Write([]byte(`{"status":"OK","results":[{"geometry":{"location":{"lat":30.27,"lng":-97.74},
+			"location_type":"RANGE_INTERPOLATED"},"partial_match":true}]}`))
+	}))
+	defer srv.Close()
+
+	p := NewGoogleProvider("key", WithGoogleBaseURL(srv.URL))
+	assert.Equal(t, "google", p.Name())
+	assert.True(t, p.Available())
+	assert.False(t, NewGoogleProvider("").Available())
+
+	result, err := p.Geocode(context.Background(), AddressInput{Street: "100 Congress Ave", City: "Austin", State: "TX"})
+	require.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, "google", result.Source)
+	assert.Equal(t, "range", result.Quality)
+	assert.InDelta(t, 0.64, result.Confidence, 1e-9, "partial match discounts confidence")
+	assert.InDelta(t, 30.27, result.Latitude, 1e-9)
+}
+
+func TestGoogleProvider_Statuses(t *testing.T) {
+	body := `{"status":"ZERO_RESULTS","results":[]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	p := NewGoogleProvider("key", WithGoogleBaseURL(srv.URL))
+
+	result, err := p.Geocode(context.Background(), AddressInput{Street: "1 Nowhere Rd"})
+	require.NoError(t, err)
+	assert.False(t, result.Matched)
+
+	body = `{"status":"OVER_QUERY_LIMIT","error_message":"quota exceeded"}`
+	_, err = p.Geocode(context.Background(), AddressInput{Street: "1 Nowhere Rd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OVER_QUERY_LIMIT: quota exceeded")
+}
@@ -0,0 +1,136 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rotisserie/eris"
+)
+
+const defaultMapboxBaseURL = "https://api.mapbox.com/search/geocode/v6/forward"
+
+// MapboxProvider geocodes via the Mapbox Geocoding v6 API. Requests are
+// permanent geocodes (permanent=true), billed per request, because results
+// are stored in the geocode cache and geo.locations, which Mapbox's terms
+// do not allow for temporary geocodes.
+type MapboxProvider struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// MapboxOption configures the MapboxProvider.
+type MapboxOption func(*MapboxProvider)
+
+// WithMapboxBaseURL overrides the Mapbox forward geocoding URL (for testing).
+func WithMapboxBaseURL(u string) MapboxOption {
+	return func(p *MapboxProvider) {
+		p.baseURL = u
+	}
+}
+
+// NewMapboxProvider creates a MapboxProvider with the given access token.
+func NewMapboxProvider(token string, opts ...MapboxOption) *MapboxProvider {
+	p := &MapboxProvider{
+		client:  http.DefaultClient,
+		baseURL: defaultMapboxBaseURL,
+		token:   token,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name implements Provider.
+func (p *MapboxProvider) Name() string { return "mapbox" }
+
+// Available implements Provider. Requires an access token.
+func (p *MapboxProvider) Available() bool { return p.token != "" }
+
+// mapboxConfidence maps Mapbox match_code confidence to 0-1.
+var mapboxConfidence = map[string]float64{
+	"exact":  1.0,
+	"high":   0.9,
+	"medium": 0.7,
+	"low":    0.4,
+}
+
+// mapboxQuality maps Mapbox feature types to the quality taxonomy.
+var mapboxQuality = map[string]string{
+	"address":  "rooftop",
+	"street":   "range",
+	"postcode": "centroid",
+	"place":    "centroid",
+}
+
+// Geocode implements Provider.
+func (p *MapboxProvider) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
+	oneLine := formatOneLine(addr)
+	if oneLine == "" {
+		return &Result{Matched: false, Source: "mapbox"}, nil
+	}
+
+	params := url.Values{
+		"q":            {oneLine},
+		"country":      {"us"},
+		"limit":        {"1"},
+		"permanent":    {"true"},
+		"access_token": {p.token},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", p.baseURL, params.Encode()), nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "mapbox: build request")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "mapbox: http request")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, eris.Errorf("mapbox: unexpected status %d", resp.StatusCode)
+	}
+
+	var fc struct {
+		Features []struct {
+			Geometry struct {
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties struct {
+				FeatureType string `json:"feature_type"`
+				MatchCode   struct {
+					Confidence string `json:"confidence"`
+				} `json:"match_code"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&fc); err != nil {
+		return nil, eris.Wrap(err, "mapbox: parse response")
+	}
+	if len(fc.Features) == 0 || len(fc.Features[0].Geometry.Coordinates) < 2 {
+		return &Result{Matched: false, Source: "mapbox"}, nil
+	}
+
+	f := fc.Features[0]
+	quality, ok := mapboxQuality[f.Properties.FeatureType]
+	if !ok {
+		quality = "approximate"
+	}
+	confidence, ok := mapboxConfidence[f.Properties.MatchCode.Confidence]
+	if !ok {
+		confidence = 0.5
+	}
+	return &Result{
+		Latitude:   f.Geometry.Coordinates[1],
+		Longitude:  f.Geometry.Coordinates[0],
+		Source:     "mapbox",
+		Quality:    quality,
+		Confidence: confidence,
+		Matched:    true,
+	}, nil
+}
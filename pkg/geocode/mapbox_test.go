@@ -0,0 +1,55 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapboxProvider_Match(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tok", r.URL.Query().Get("access_token"))
+		assert.Equal(t, "100 Main St, Miami, FL", r.URL.Query().Get("q"))
+		assert.Equal(t, "true", r.URL.Query().Get("permanent"))
+		_, _ = w.Write([]byte(`{"features":[{"geometry":{"coordinates":[-80.19,25.77]},
+			"properties":{"feature_type":"address","match_code":{"confidence":"high"}}}]}`))
+	}))
+	defer srv.Close()
+
+	p := NewMapboxProvider("tok", WithMapboxBaseURL(srv.URL))
+	assert.Equal(t, "mapbox", p.Name())
+	assert.True(t, p.Available())
+	assert.False(t, NewMapboxProvider("").Available())
+
+	result, err := p.Geocode(context.Background(), AddressInput{Street: "100 Main St", City: "Miami", State: "FL"})
+	require.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, "mapbox", result.Source)
+	assert.Equal(t, "rooftop", result.Quality)
+	assert.InDelta(t, 0.9, result.Confidence, 1e-9)
+	assert.InDelta(t, 25.77, result.Latitude, 1e-9)
+	assert.InDelta(t, -80.19, result.Longitude, 1e-9)
+}
+
+func TestMapboxProvider_NoMatchAndError(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"features":[]}`))
+	}))
+	defer srv.Close()
+	p := NewMapboxProvider("tok", WithMapboxBaseURL(srv.URL))
+
+	result, err := p.Geocode(context.Background(), AddressInput{Street: "1 Nowhere Rd"})
+	require.NoError(t, err)
+	assert.False(t, result.Matched)
+
+	status = http.StatusUnauthorized
+	_, err = p.Geocode(context.Background(), AddressInput{Street: "1 Nowhere Rd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 401")
+}
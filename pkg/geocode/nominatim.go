@@ -0,0 +1,187 @@
+package geocode
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+const defaultNominatimBaseURL = "https://nominatim.openstreetmap.org"
+
+// NominatimProvider geocodes via the OpenStreetMap Nominatim API. The
+// public instance allows one request per second and requires an
+// identifying User-Agent.
+type NominatimProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NominatimOption configures the NominatimProvider.
+type NominatimOption func(*NominatimProvider)
+
+// WithNominatimBaseURL overrides the Nominatim API root (for testing or a
+// self-hosted instance).
+func WithNominatimBaseURL(u string) NominatimOption {
+	return func(p *NominatimProvider) {
+		p.baseURL = u
+	}
+}
+
+// NewNominatimProvider creates a NominatimProvider.
+func NewNominatimProvider(opts ...NominatimOption) *NominatimProvider {
+	p := &NominatimProvider{
+		client:  http.DefaultClient,
+		baseURL: defaultNominatimBaseURL,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name implements Provider.
+func (p *NominatimProvider) Name() string { return "nominatim" }
+
+// Available implements Provider.
+func (p *NominatimProvider) Available() bool { return true }
+
+// Geocode implements Provider using a structured US address search.
+func (p *NominatimProvider) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
+	if formatOneLine(addr) == "" {
+		return &Result{Matched: false, Source: "nominatim"}, nil
+	}
+
+	params := url.Values{
+		"format":       {"jsonv2"},
+		"limit":        {"1"},
+		"countrycodes": {"us"},
+	}
+	if addr.City == "" && addr.State == "" && addr.ZipCode == "" {
+		params.Set("q", addr.Street)
+	} else {
+		params.Set("street", addr.Street)
+		params.Set("city", addr.City)
+		params.Set("state", addr.State)
+		params.Set("postalcode", addr.ZipCode)
+	}
+
+	var places []struct {
+		Lat       string `json:"lat"`
+		Lon       string `json:"lon"`
+		PlaceRank int    `json:"place_rank"`
+	}
+	if err := p.get(ctx, "/search", params, &places); err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return &Result{Matched: false, Source: "nominatim"}, nil
+	}
+
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, eris.Wrap(err, "nominatim: parse lat")
+	}
+	lon, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, eris.Wrap(err, "nominatim: parse lon")
+	}
+
+	// Place rank 30 is a building or house number, 26-27 a street, and
+	// anything coarser a locality or region.
+	quality, confidence := "approximate", 0.3
+	switch rank := places[0].PlaceRank; {
+	case rank >= 30:
+		quality, confidence = "rooftop", 0.9
+	case rank >= 26:
+		quality, confidence = "range", 0.6
+	case rank >= 16:
+		quality, confidence = "centroid", 0.4
+	}
+	return &Result{
+		Latitude:   lat,
+		Longitude:  lon,
+		Source:     "nominatim",
+		Quality:    quality,
+		Confidence: confidence,
+		Matched:    true,
+	}, nil
+}
+
+// ReverseGeocode implements ReverseProvider.
+func (p *NominatimProvider) ReverseGeocode(ctx context.Context, lat, lng float64) (*ReverseResult, error) {
+	params := url.Values{
+		"format":         {"jsonv2"},
+		"lat":            {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"lon":            {strconv.FormatFloat(lng, 'f', -1, 64)},
+		"addressdetails": {"1"},
+	}
+	var place struct {
+		Error   string `json:"error"`
+		Address struct {
+			HouseNumber string `json:"house_number"`
+			Road        string `json:"road"`
+			City        string `json:"city"`
+			Town        string `json:"town"`
+			Village     string `json:"village"`
+			Hamlet      string `json:"hamlet"`
+			State       string `json:"state"`
+			ISO3166     string `json:"ISO3166-2-lvl4"`
+			Postcode    string `json:"postcode"`
+		} `json:"address"`
+	}
+	if err := p.get(ctx, "/reverse", params, &place); err != nil {
+		return nil, err
+	}
+	if place.Error != "" {
+		return nil, eris.Errorf("nominatim: reverse: %s", place.Error)
+	}
+
+	a := place.Address
+	result := &ReverseResult{
+		City:    cmp.Or(a.City, a.Town, a.Village, a.Hamlet),
+		State:   strings.TrimPrefix(a.ISO3166, "US-"),
+		ZipCode: a.Postcode,
+		Source:  "nominatim",
+	}
+	// Like tiger's pprint_addy, Street is the full one-line address; it
+	// stays empty when the point is not near a named road.
+	if a.Road != "" {
+		result.Street = formatOneLine(AddressInput{
+			Street:  strings.TrimSpace(a.HouseNumber + " " + a.Road),
+			City:    result.City,
+			State:   result.State,
+			ZipCode: result.ZipCode,
+		})
+	}
+	return result, nil
+}
+
+// get issues a Nominatim API request and decodes the JSON response into v.
+func (p *NominatimProvider) get(ctx context.Context, path string, params url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", p.baseURL, path, params.Encode()), nil)
+	if err != nil {
+		return eris.Wrap(err, "nominatim: build request")
+	}
+	req.Header.Set("User-Agent", "research-cli (sells-group)")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return eris.Wrap(err, "nominatim: http request")
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return eris.Errorf("nominatim: unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return eris.Wrap(err, "nominatim: parse response")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNominatimProvider_Match(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1600 Pennsylvania Ave NW", r.URL.Query().Get("street"))
+		assert.Equal(t, "DC", r.URL.Query().Get("state"))
+		assert.Equal(t, "us", r.URL.Query().Get("countrycodes"))
+		assert.NotEmpty(t, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`[{"lat":"38.8976","lon":"-77.0365","place_rank":30}]`))
+	}))
+	defer srv.Close()
+
+	p := NewNominatimProvider(WithNominatimBaseURL(srv.URL))
+	assert.Equal(t, "nominatim", p.Name())
+	assert.True(t, p.Available())
+
+	result, err := p.Geocode(context.Background(), AddressInput{
+		Street: "1600 Pennsylvania Ave NW", City: "Washington", State: "DC", ZipCode: "20500",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, "nominatim", result.Source)
+	assert.Equal(t, "rooftop", result.Quality)
+	assert.InDelta(t, 0.9, result.Confidence, 1e-9)
+	assert.InDelta(t, 38.8976, result.Latitude, 1e-6)
+	assert.InDelta(t, -77.0365, result.Longitude, 1e-6)
+}
+
+func TestNominatimProvider_StreetLevelAndNoMatch(t *testing.T) {
+	body := `[{"lat":"30.27","lon":"-97.74","place_rank":26}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Congress Ave Austin TX", r.URL.Query().Get("q"), "one-line input uses free-form q")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	p := NewNominatimProvider(WithNominatimBaseURL(srv.URL))
+
+	result, err := p.Geocode(context.Background(), AddressInput{Street: "Congress Ave Austin TX"})
+	require.NoError(t, err)
+	assert.Equal(t, "range", result.Quality)
+	assert.InDelta(t, 0.6, result.Confidence, 1e-9)
+
+	body = `[]`
+	result, err = p.Geocode(context.Background(), AddressInput{Street: "Congress Ave Austin TX"})
+	require.NoError(t, err)
+	assert.False(t, result.Matched)
+}
+
+func TestNominatimProvider_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewNominatimProvider(WithNominatimBaseURL(srv.URL)).Geocode(context.Background(), AddressInput{Street: "1 Main St"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 429")
+}
+
+func TestNominatimProvider_ReverseGeocode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reverse", r.URL.Path)
+		assert.Equal(t, "25.77", r.URL.Query().Get("lat"))
+		assert.Equal(t, "-80.19", r.URL.Query().Get("lon"))
+		_, _ = w.Write([]byte(`{"address":{"house_number":"100","road":"Main St","town":"Miami",
+			"ISO3166-2-lvl4":"US-FL","postcode":"33131"}}`))
+	}))
+	defer srv.Close()
+
+	var p ReverseProvider = NewNominatimProvider(WithNominatimBaseURL(srv.URL))
+	result, err := p.ReverseGeocode(context.Background(), 25.77, -80.19)
+	require.NoError(t, err)
+	assert.Equal(t, "100 Main St, Miami, FL, 33131", result.Street)
+	assert.Equal(t, "Miami", result.City)
+	assert.Equal(t, "FL", result.State)
+	assert.Equal(t, "nominatim", result.Source)
+}
+
+func TestNominatimProvider_ReverseGeocode_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error":"Unable to geocode"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewNominatimProvider(WithNominatimBaseURL(srv.URL)).ReverseGeocode(context.Background(), 0, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unable to geocode")
+}
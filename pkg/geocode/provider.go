@@ -1,6 +1,7 @@
 package geocode
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sells-group/research-cli/internal/db"
+	"github.com/sells-group/research-cli/internal/opsmetrics"
 )
 
 // Provider represents a single geocoding backend.
@@ -80,12 +82,13 @@ func (p *TigerProvider) Geocode(ctx context.Context, addr AddressInput) (*Result
 	}
 
 	result := &Result{
-		Latitude:  lat,
-		Longitude: lon,
-		Source:    "tiger",
-		Quality:   ratingToQuality(rating),
-		Matched:   true,
-		Rating:    rating,
+		Latitude:   lat,
+		Longitude:  lon,
+		Source:     "tiger",
+		Quality:    ratingToQuality(rating),
+		Confidence: ratingToConfidence(rating),
+		Matched:    true,
+		Rating:     rating,
 	}
 	if countyFIPS.Valid {
 		result.CountyFIPS = countyFIPS.String
@@ -101,6 +104,7 @@ type CascadeClient struct {
 	cacheTTLDays     int
 	cacheTable       string
 	batchConcurrency int
+	minConfidence    float64
 }
 
 // CascadeOption configures the CascadeClient.
@@ -136,13 +140,22 @@ func WithCascadeBatchConcurrency(n int) CascadeOption {
 	}
 }
 
+// WithCascadeMinConfidence sets the confidence a match needs to stop the
+// cascade. Lower-confidence matches fall through to the next (costlier)
+// provider; if none reaches the threshold the most confident match wins.
+func WithCascadeMinConfidence(c float64) CascadeOption {
+	return func(cc *CascadeClient) {
+		cc.minConfidence = c
+	}
+}
+
 // NewCascadeClient creates a CascadeClient that tries providers in order.
 func NewCascadeClient(pool db.Pool, providers []Provider, opts ...CascadeOption) *CascadeClient {
 	c := &CascadeClient{
 		providers:        providers,
 		pool:             pool,
 		cacheEnabled:     true,
-		cacheTable:       "public.geocode_cache",
+		cacheTable:       DefaultCacheTable,
 		batchConcurrency: 10,
 	}
 	for _, opt := range opts {
@@ -153,7 +166,8 @@ func NewCascadeClient(pool db.Pool, providers []Provider, opts ...CascadeOption)
 
 // Geocode implements Client by trying each provider in order.
 func (c *CascadeClient) Geocode(ctx context.Context, addr AddressInput) (*Result, error) {
-	key := cacheKey(addr)
+	addr = NormalizeInput(addr)
+	key, norm := cacheKey(addr), CacheAddress(addr)
 
 	if c.cacheEnabled {
 		cached, err := c.checkCache(ctx, key)
@@ -162,7 +176,7 @@ func (c *CascadeClient) Geocode(ctx context.Context, addr AddressInput) (*Result
 		}
 	}
 
-	var lastResult *Result
+	var lastResult, best *Result
 	for _, p := range c.providers {
 		if !p.Available() {
 			continue
@@ -176,16 +190,33 @@ func (c *CascadeClient) Geocode(ctx context.Context, addr AddressInput) (*Result
 			continue
 		}
 		if result != nil && result.Matched {
-			if c.cacheEnabled {
-				_ = c.storeCache(ctx, key, result)
+			if result.Confidence >= c.minConfidence {
+				if c.cacheEnabled {
+					_ = c.storeCache(ctx, key, norm, result)
+				}
+				return result, nil
 			}
-			return result, nil
+			zap.L().Debug("cascade: low-confidence match, trying next",
+				zap.String("provider", p.Name()),
+				zap.Float64("confidence", result.Confidence),
+			)
+			if best == nil || result.Confidence > best.Confidence {
+				best = result
+			}
+			continue
 		}
 		if result != nil {
 			lastResult = result
 		}
 	}
 
+	if best != nil {
+		if c.cacheEnabled {
+			_ = c.storeCache(ctx, key, norm, best)
+		}
+		return best, nil
+	}
+
 	// All providers missed — cache negative result and return unmatched.
 	noMatch := &Result{Matched: false, Source: "cascade"}
 	if lastResult != nil {
@@ -193,7 +224,7 @@ func (c *CascadeClient) Geocode(ctx context.Context, addr AddressInput) (*Result
 		noMatch.Rating = lastResult.Rating
 	}
 	if c.cacheEnabled {
-		_ = c.storeCache(ctx, key, noMatch)
+		_ = c.storeCache(ctx, key, norm, noMatch)
 	}
 	return noMatch, nil
 }
@@ -231,9 +262,49 @@ func (c *CascadeClient) BatchGeocode(ctx context.Context, addrs []AddressInput)
 	return results, nil
 }
 
-// ReverseGeocode implements Client by delegating to the package-level function.
+// ReverseGeocode implements Client. It tries the PostGIS tiger reverse
+// geocoder, then each available provider that implements ReverseProvider,
+// returning the first result with a street address.
 func (c *CascadeClient) ReverseGeocode(ctx context.Context, lat, lng float64) (*ReverseResult, error) {
-	return ReverseGeocode(ctx, c.pool, lat, lng)
+	var (
+		result   *ReverseResult
+		tigerErr = eris.New("geocode: reverse geocode: no result")
+	)
+	if c.pool != nil {
+		result, tigerErr = ReverseGeocode(ctx, c.pool, lat, lng)
+		if tigerErr == nil && result.Street != "" {
+			return result, nil
+		}
+	}
+
+	for _, p := range c.providers {
+		reverse, ok := reverseFunc(p)
+		if !ok || !p.Available() {
+			continue
+		}
+		r, err := reverse(ctx, lat, lng)
+		if err != nil {
+			zap.L().Debug("geocode: reverse provider error",
+				zap.String("provider", p.Name()),
+				zap.Error(err),
+			)
+			continue
+		}
+		if r != nil && r.Street != "" {
+			// Keep tiger's county and place when the provider lacks them.
+			if result != nil {
+				r.CountyFIPS = cmp.Or(r.CountyFIPS, result.CountyFIPS)
+				r.PlaceName = cmp.Or(r.PlaceName, result.PlaceName)
+				r.PlaceGEOID = cmp.Or(r.PlaceGEOID, result.PlaceGEOID)
+			}
+			return r, nil
+		}
+	}
+
+	if tigerErr != nil {
+		return nil, tigerErr
+	}
+	return result, nil
 }
 
 // checkCache looks up a cached geocode result for the cascade client.
@@ -244,18 +315,24 @@ func (c *CascadeClient) checkCache(ctx context.Context, key string) (*Result, er
 	var matched bool
 	var countyFIPS *string
 	var source *string
+	var confidence *float64
 
-	query := fmt.Sprintf("SELECT latitude, longitude, quality, rating, matched, county_fips, source FROM %s WHERE address_hash = $1", c.cacheTable)
+	query := fmt.Sprintf("SELECT latitude, longitude, quality, rating, matched, county_fips, source, confidence FROM %s WHERE address_hash = $1", c.cacheTable)
 	args := []any{key}
 
 	if c.cacheTTLDays > 0 {
 		query += fmt.Sprintf(" AND cached_at > now() - interval '%d days'", c.cacheTTLDays)
 	}
+	if c.cacheTTLDays <= 0 || c.cacheTTLDays > GoogleCacheTTLDays {
+		query += fmt.Sprintf(" AND (source IS DISTINCT FROM 'google' OR cached_at > now() - interval '%d days')", GoogleCacheTTLDays)
+	}
 
 	row := c.pool.QueryRow(ctx, query, args...)
-	if err := row.Scan(&lat, &lon, &quality, &rating, &matched, &countyFIPS, &source); err != nil {
+	if err := row.Scan(&lat, &lon, &quality, &rating, &matched, &countyFIPS, &source, &confidence); err != nil {
+		opsmetrics.RecordCacheEvent("miss", "geocode", "postgres")
 		return nil, err
 	}
+	opsmetrics.RecordCacheEvent("hit", "geocode", "postgres")
 
 	r := &Result{
 		Latitude:  lat,
@@ -273,6 +350,9 @@ func (c *CascadeClient) checkCache(ctx context.Context, key string) (*Result, er
 	if source != nil {
 		r.Source = *source
 	}
+	if confidence != nil {
+		r.Confidence = *confidence
+	}
 
 	keyPrefix := key
 	if len(keyPrefix) > 12 {
@@ -283,11 +363,12 @@ func (c *CascadeClient) checkCache(ctx context.Context, key string) (*Result, er
 }
 
 // storeCache inserts a geocode result into the cascade cache.
-func (c *CascadeClient) storeCache(ctx context.Context, key string, result *Result) error {
+func (c *CascadeClient) storeCache(ctx context.Context, key, norm string, result *Result) error {
 	query := fmt.Sprintf(`
-		INSERT INTO %s (address_hash, latitude, longitude, quality, rating, matched, county_fips, source, cached_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+		INSERT INTO %s (address_hash, address_norm, latitude, longitude, quality, rating, matched, county_fips, source, confidence, cached_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())
 		ON CONFLICT (address_hash) DO UPDATE SET
+			address_norm = EXCLUDED.address_norm,
 			latitude = EXCLUDED.latitude,
 			longitude = EXCLUDED.longitude,
 			quality = EXCLUDED.quality,
@@ -295,13 +376,15 @@ func (c *CascadeClient) storeCache(ctx context.Context, key string, result *Resu
 			matched = EXCLUDED.matched,
 			county_fips = EXCLUDED.county_fips,
 			source = EXCLUDED.source,
+			confidence = EXCLUDED.confidence,
 			cached_at = now()`, c.cacheTable)
 
 	_, err := c.pool.Exec(ctx, query,
-		key, result.Latitude, result.Longitude, result.Quality, result.Rating, result.Matched, nilIfEmpty(result.CountyFIPS), result.Source,
+		key, nilIfEmpty(norm), result.Latitude, result.Longitude, result.Quality, result.Rating, result.Matched, nilIfEmpty(result.CountyFIPS), result.Source, result.Confidence,
 	)
 	if err != nil {
 		return eris.Wrap(err, "cascade: store cache")
 	}
+	opsmetrics.RecordCacheEvent("set", "geocode", "postgres")
 	return nil
 }
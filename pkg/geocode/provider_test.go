@@ -269,11 +269,11 @@ func TestCascadeClient_CacheHitSkipsProviders(t *testing.T) {
 	rating := 3
 	countyFIPS := "12086"
 	source := "tiger"
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source, confidence FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips", "source"}).
-				AddRow(25.77, -80.19, "rooftop", &rating, true, &countyFIPS, &source),
+			pgxmock.NewRows([]string{"latitude", "longitude", "quality", "rating", "matched", "county_fips", "source", "confidence"}).
+				AddRow(25.77, -80.19, "rooftop", &rating, true, &countyFIPS, &source, (*float64)(nil)),
 		)
 
 	// Provider should NOT be called — cache hit short-circuits.
@@ -336,7 +336,7 @@ func TestCascadeClient_CacheTableOption(t *testing.T) {
 	defer mock.Close()
 
 	// Expect query against custom table.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source FROM geo.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source, confidence FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError) // cache miss
 
@@ -348,7 +348,7 @@ func TestCascadeClient_CacheTableOption(t *testing.T) {
 
 	// Expect negative cache store to custom table.
 	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil, "census").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil, "census", 0.0).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	c := NewCascadeClient(mock, []Provider{p},
@@ -382,7 +382,7 @@ func TestCascadeClient_CacheTTLDaysOption(t *testing.T) {
 	defer mock.Close()
 
 	// Expect cache query with TTL clause.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source FROM public.geocode_cache WHERE address_hash = .+ AND cached_at > now\(\) - interval '30 days'`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source, confidence FROM geo.geocode_cache WHERE address_hash = .+ AND cached_at > now\(\) - interval '30 days'`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError) // cache miss
 
@@ -393,8 +393,8 @@ func TestCascadeClient_CacheTTLDaysOption(t *testing.T) {
 	}
 
 	// Expect cache store after provider match.
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 38.899, -77.016, "rooftop", 0, true, nil, "census").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 38.899, -77.016, "rooftop", 0, true, nil, "census", 0.0).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	c := NewCascadeClient(mock, []Provider{p},
@@ -415,6 +415,22 @@ func TestCascadeClient_CacheTTLDaysOption(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCascadeClient_GoogleCacheTTLCap(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// A 90-day TTL still expires cached Google results after 30 days.
+	mock.ExpectQuery(`FROM geo.geocode_cache WHERE address_hash = .+ AND cached_at > now\(\) - interval '90 days' AND \(source IS DISTINCT FROM 'google' OR cached_at > now\(\) - interval '30 days'\)`).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnError(assert.AnError) // cache miss
+
+	c := NewCascadeClient(mock, nil, WithCascadeCacheEnabled(true), WithCascadeCacheTTLDays(90))
+	_, err = c.checkCache(context.Background(), "key")
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCascadeClient_ReverseGeocode(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -428,8 +444,8 @@ func TestCascadeClient_ReverseGeocode(t *testing.T) {
 	mock.ExpectQuery(`SELECT\s+pprint_addy`).
 		WithArgs(-80.19, 25.77).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"pprint_addy", "stateabbrev", "zip", "county_fips", "rating"}).
-				AddRow(fullAddr, state, zip, countyFIPS, 3),
+			pgxmock.NewRows([]string{"pprint_addy", "location", "stateabbrev", "zip", "county_fips", "place_name", "place_geoid", "rating"}).
+				AddRow(fullAddr, sql.NullString{}, state, zip, countyFIPS, sql.NullString{}, sql.NullString{}, 3),
 		)
 
 	c := NewCascadeClient(mock, nil)
@@ -452,7 +468,7 @@ func TestCascadeClient_StoreCacheError(t *testing.T) {
 	defer mock.Close()
 
 	// Cache miss.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips, source, confidence FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 
@@ -463,8 +479,8 @@ func TestCascadeClient_StoreCacheError(t *testing.T) {
 	}
 
 	// Cache store fails — should not crash, error is swallowed.
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 38.899, -77.016, "rooftop", 0, true, nil, "census").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 38.899, -77.016, "rooftop", 0, true, nil, "census", 0.0).
 		WillReturnError(assert.AnError)
 
 	c := NewCascadeClient(mock, []Provider{p}, WithCascadeCacheEnabled(true))
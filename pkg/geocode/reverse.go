@@ -17,15 +17,30 @@ type ReverseResult struct {
 	State      string `json:"state"`
 	ZipCode    string `json:"zip_code"`
 	CountyFIPS string `json:"county_fips"`
+	PlaceName  string `json:"place_name,omitempty"`  // Census place containing the point
+	PlaceGEOID string `json:"place_geoid,omitempty"` // 7-digit state+place FIPS
+	Source     string `json:"source,omitempty"`      // provider name
 	Rating     int    `json:"rating"`
 }
 
-// ReverseGeocode converts a lat/lng to a street address using PostGIS TIGER data.
+// ReverseProvider is implemented by providers that can reverse geocode.
+// The cascade tries them in order when the PostGIS tiger reverse geocoder
+// finds nothing.
+type ReverseProvider interface {
+	Provider
+	ReverseGeocode(ctx context.Context, lat, lng float64) (*ReverseResult, error)
+}
+
+// ReverseGeocode converts a lat/lng to a street address using PostGIS TIGER
+// data, with the containing county and Census place.
 func ReverseGeocode(ctx context.Context, pool db.Pool, lat, lng float64) (*ReverseResult, error) {
 	var fullAddr sql.NullString
+	var city sql.NullString
 	var state sql.NullString
 	var zip sql.NullString
 	var countyFIPS sql.NullString
+	var placeName sql.NullString
+	var placeGEOID sql.NullString
 	var rating int
 
 	err := pool.QueryRow(ctx, `
@@ -35,15 +50,20 @@ func ReverseGeocode(ctx context.Context, pool db.Pool, lat, lng float64) (*Rever
 		)
 		SELECT
 			pprint_addy(r.addy),
+			(r.addy).location,
 			(r.addy).stateabbrev,
 			(r.addy).zip,
 			c.statefp || c.countyfp,
+			p.name,
+			p.geoid,
 			0 AS rating
 		FROM r
 		LEFT JOIN tiger_data.county_all c
-			ON ST_Within(ST_SetSRID(ST_MakePoint($1, $2), 4326), c.the_geom)`,
+			ON ST_Within(ST_SetSRID(ST_MakePoint($1, $2), 4326), c.the_geom)
+		LEFT JOIN geo.places p
+			ON ST_Contains(p.geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))`,
 		lng, lat,
-	).Scan(&fullAddr, &state, &zip, &countyFIPS, &rating)
+	).Scan(&fullAddr, &city, &state, &zip, &countyFIPS, &placeName, &placeGEOID, &rating)
 	if err != nil {
 		zap.L().Debug("reverse geocode: no result",
 			zap.Float64("lat", lat),
@@ -54,11 +74,21 @@ func ReverseGeocode(ctx context.Context, pool db.Pool, lat, lng float64) (*Rever
 	}
 
 	result := &ReverseResult{
+		Source: "tiger",
 		Rating: rating,
 	}
 	if fullAddr.Valid {
 		result.Street = fullAddr.String
 	}
+	if city.Valid {
+		result.City = city.String
+	}
+	if placeName.Valid {
+		result.PlaceName = placeName.String
+	}
+	if placeGEOID.Valid {
+		result.PlaceGEOID = placeGEOID.String
+	}
 	if state.Valid {
 		result.State = state.String
 	}
@@ -20,11 +20,15 @@ func TestReverseGeocode_Success(t *testing.T) {
 	zip := sql.NullString{String: "33131", Valid: true}
 	countyFIPS := sql.NullString{String: "12086", Valid: true}
 
+	city := sql.NullString{String: "Miami", Valid: true}
+	placeName := sql.NullString{String: "Miami", Valid: true}
+	placeGEOID := sql.NullString{String: "1245000", Valid: true}
+
 	mock.ExpectQuery(`SELECT\s+pprint_addy`).
 		WithArgs(-80.19, 25.77).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"pprint_addy", "stateabbrev", "zip", "county_fips", "rating"}).
-				AddRow(fullAddr, state, zip, countyFIPS, 3),
+			pgxmock.NewRows([]string{"pprint_addy", "location", "stateabbrev", "zip", "county_fips", "place_name", "place_geoid", "rating"}).
+				AddRow(fullAddr, city, state, zip, countyFIPS, placeName, placeGEOID, 3),
 		)
 
 	result, err := ReverseGeocode(context.Background(), mock, 25.77, -80.19)
@@ -35,6 +39,10 @@ func TestReverseGeocode_Success(t *testing.T) {
 	assert.Equal(t, "FL", result.State)
 	assert.Equal(t, "33131", result.ZipCode)
 	assert.Equal(t, "12086", result.CountyFIPS)
+	assert.Equal(t, "Miami", result.City)
+	assert.Equal(t, "Miami", result.PlaceName)
+	assert.Equal(t, "1245000", result.PlaceGEOID)
+	assert.Equal(t, "tiger", result.Source)
 	assert.Equal(t, 3, result.Rating)
 
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -84,8 +92,9 @@ func TestReverseGeocode_NullFields(t *testing.T) {
 	mock.ExpectQuery(`SELECT\s+pprint_addy`).
 		WithArgs(-80.19, 25.77).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"pprint_addy", "stateabbrev", "zip", "county_fips", "rating"}).
-				AddRow(sql.NullString{}, sql.NullString{String: "FL", Valid: true}, sql.NullString{}, sql.NullString{}, 50),
+			pgxmock.NewRows([]string{"pprint_addy", "location", "stateabbrev", "zip", "county_fips", "place_name", "place_geoid", "rating"}).
+				AddRow(sql.NullString{}, sql.NullString{}, sql.NullString{String: "FL", Valid: true}, sql.NullString{}, sql.NullString{},
+					sql.NullString{}, sql.NullString{}, 50),
 		)
 
 	result, err := ReverseGeocode(context.Background(), mock, 25.77, -80.19)
@@ -96,6 +105,7 @@ func TestReverseGeocode_NullFields(t *testing.T) {
 	assert.Equal(t, "FL", result.State)
 	assert.Equal(t, "", result.ZipCode)
 	assert.Equal(t, "", result.CountyFIPS)
+	assert.Equal(t, "", result.PlaceName)
 	assert.Equal(t, 50, result.Rating)
 
 	require.NoError(t, mock.ExpectationsWereMet())
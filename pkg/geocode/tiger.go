@@ -53,12 +53,13 @@ func (g *geocoder) tigerGeocode(ctx context.Context, addr AddressInput) (*Result
 	}
 
 	result := &Result{
-		Latitude:  lat,
-		Longitude: lon,
-		Source:    "tiger",
-		Quality:   ratingToQuality(rating),
-		Matched:   true,
-		Rating:    rating,
+		Latitude:   lat,
+		Longitude:  lon,
+		Source:     "tiger",
+		Quality:    ratingToQuality(rating),
+		Confidence: ratingToConfidence(rating),
+		Matched:    true,
+		Rating:     rating,
 	}
 	if countyFIPS.Valid {
 		result.CountyFIPS = countyFIPS.String
@@ -80,3 +81,9 @@ func ratingToQuality(rating int) string {
 		return "approximate"
 	}
 }
+
+// ratingToConfidence maps a PostGIS geocoder rating (0 = exact, 100+ =
+// poor) to a 0-1 confidence.
+func ratingToConfidence(rating int) float64 {
+	return max(0, 1-float64(rating)/100)
+}
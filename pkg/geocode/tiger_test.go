@@ -15,19 +15,19 @@ func TestTigerGeocode_Match(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("100 S Biscayne Blvd, Miami, FL, 33131").
+		WithArgs("100 S BISCAYNE BLVD, MIAMI, FL, 33131").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"lat", "lon", "rating", "matched_address", "county_fips"}).
 				AddRow(25.772320, -80.189370, 5, "100 S Biscayne Blvd, Miami, FL 33131", "12086"),
 		)
 
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 25.772320, -80.189370, "rooftop", 5, true, "12086").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), "100 S BISCAYNE BLVD, MIAMI, FL, 33131", 25.772320, -80.189370, "rooftop", 5, true, "12086").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := NewClient(mock, WithCacheEnabled(true))
@@ -56,17 +56,17 @@ func TestTigerGeocode_NoMatch(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("123 Nonexistent St, Nowhere, XX, 00000").
+		WithArgs("123 NONEXISTENT ST, NOWHERE, XX, 00000").
 		WillReturnError(assert.AnError)
 
 	// Non-match is now cached (negative caching).
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := NewClient(mock, WithCacheEnabled(true))
@@ -90,20 +90,20 @@ func TestTigerGeocode_ExceedsMaxRating(t *testing.T) {
 	require.NoError(t, err)
 	defer mock.Close()
 
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("123 Main St, Anytown, FL, 33101").
+		WithArgs("123 MAIN ST, ANYTOWN, FL, 33101").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"lat", "lon", "rating", "matched_address", "county_fips"}).
 				AddRow(25.0, -80.0, 60, "123 Main St, Anytown, FL 33101", "12086"),
 		)
 
 	// Exceeds max rating → Matched=false, still cached (negative caching).
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 0.0, 0.0, "", 60, false, nil).
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 0.0, 0.0, "", 60, false, nil).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := NewClient(mock, WithMaxRating(50))
@@ -128,13 +128,13 @@ func TestTigerGeocode_EmptyAddress(t *testing.T) {
 	defer mock.Close()
 
 	// Empty address: cache miss, then tigerGeocode returns early (empty oneLine).
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 
 	// Non-match cached.
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := NewClient(mock)
@@ -153,28 +153,28 @@ func TestBatchGeocode_Sequential(t *testing.T) {
 	defer mock.Close()
 
 	// First address: cache miss, then geocode match.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("100 Main St, Miami, FL, 33131").
+		WithArgs("100 MAIN ST, MIAMI, FL, 33131").
 		WillReturnRows(
 			pgxmock.NewRows([]string{"lat", "lon", "rating", "matched_address", "county_fips"}).
 				AddRow(25.77, -80.19, 3, "100 Main St, Miami, FL 33131", "12086"),
 		)
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 25.77, -80.19, "rooftop", 3, true, "12086").
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 25.77, -80.19, "rooftop", 3, true, "12086").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	// Second address: cache miss, no geocode match.
-	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM public.geocode_cache`).
+	mock.ExpectQuery(`SELECT latitude, longitude, quality, rating, matched, county_fips FROM geo.geocode_cache`).
 		WithArgs(pgxmock.AnyArg()).
 		WillReturnError(assert.AnError)
 	mock.ExpectQuery(`SELECT\s+ST_Y`).
-		WithArgs("999 Fake Ave, Nowhere, XX, 00000").
+		WithArgs("999 FAKE AVE, NOWHERE, XX, 00000").
 		WillReturnError(assert.AnError)
-	mock.ExpectExec(`INSERT INTO public.geocode_cache`).
-		WithArgs(pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
+	mock.ExpectExec(`INSERT INTO geo.geocode_cache`).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), 0.0, 0.0, "", 0, false, nil).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	g := NewClient(mock, WithCacheEnabled(true), WithBatchConcurrency(1))
@@ -205,8 +205,8 @@ func TestGeocoderClient_ReverseGeocode(t *testing.T) {
 	mock.ExpectQuery(`SELECT\s+pprint_addy`).
 		WithArgs(-82.46, 27.95).
 		WillReturnRows(
-			pgxmock.NewRows([]string{"pprint_addy", "stateabbrev", "zip", "county_fips", "rating"}).
-				AddRow(fullAddr, state, zip, countyFIPS, 5),
+			pgxmock.NewRows([]string{"pprint_addy", "location", "stateabbrev", "zip", "county_fips", "place_name", "place_geoid", "rating"}).
+				AddRow(fullAddr, sql.NullString{}, state, zip, countyFIPS, sql.NullString{}, sql.NullString{}, 5),
 		)
 
 	g := NewClient(mock, WithCacheEnabled(false))
@@ -244,3 +244,26 @@ func TestRatingToQuality(t *testing.T) {
 		assert.Equal(t, tt.quality, ratingToQuality(tt.rating), "rating %d", tt.rating)
 	}
 }
+
+func TestNormalizeInput(t *testing.T) {
+	tests := []struct {
+		in   AddressInput
+		want AddressInput
+	}{
+		{
+			AddressInput{ID: "1", Street: "100 North Main Street, Suite 4", City: " miami ", State: "Florida", ZipCode: "33131-1234"},
+			AddressInput{ID: "1", Street: "100 N MAIN ST STE 4", City: "MIAMI", State: "FL", ZipCode: "33131"},
+		},
+		{
+			AddressInput{ID: "2", Street: "200 Broadway Ave., Apt 3B, Austin, Texas 78701"},
+			AddressInput{ID: "2", Street: "200 BROADWAY AVE APT 3B", City: "AUSTIN", State: "TX", ZipCode: "78701"},
+		},
+		{
+			AddressInput{ID: "3", Street: "123 Nonexistent St", City: "Nowhere", State: "xx", ZipCode: "00000"},
+			AddressInput{ID: "3", Street: "123 NONEXISTENT ST", City: "NOWHERE", State: "XX", ZipCode: "00000"},
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeInput(tt.in), tt.in.Street)
+	}
+}
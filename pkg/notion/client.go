@@ -15,6 +15,7 @@ type Client interface {
 	QueryDatabase(ctx context.Context, dbID string, req *notionapi.DatabaseQueryRequest) (*notionapi.DatabaseQueryResponse, error)
 	CreatePage(ctx context.Context, req *notionapi.PageCreateRequest) (*notionapi.Page, error)
 	UpdatePage(ctx context.Context, pageID string, req *notionapi.PageUpdateRequest) (*notionapi.Page, error)
+	GetDatabase(ctx context.Context, dbID string) (*notionapi.Database, error)
 }
 
 // ClientOption configures the Notion client.
@@ -90,3 +91,14 @@ func (c *notionClient) UpdatePage(ctx context.Context, pageID string, req *notio
 	}
 	return page, nil
 }
+
+func (c *notionClient) GetDatabase(ctx context.Context, dbID string) (*notionapi.Database, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, eris.Wrap(err, "notion: rate limit")
+	}
+	db, err := c.inner.Database.Get(ctx, notionapi.DatabaseID(dbID))
+	if err != nil {
+		return nil, eris.Wrap(err, fmt.Sprintf("notion: get database %s", dbID))
+	}
+	return db, nil
+}
@@ -40,6 +40,14 @@ func (m *MockClient) UpdatePage(ctx context.Context, pageID string, req *notiona
 	return args.Get(0).(*notionapi.Page), args.Error(1)
 }
 
+func (m *MockClient) GetDatabase(ctx context.Context, dbID string) (*notionapi.Database, error) {
+	args := m.Called(ctx, dbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notionapi.Database), args.Error(1)
+}
+
 func TestMockClientSatisfiesInterface(t *testing.T) {
 	t.Parallel()
 	var _ Client = (*MockClient)(nil)
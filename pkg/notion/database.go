@@ -87,3 +87,54 @@ func QueryQueuedLeads(ctx context.Context, c Client, dbID string) ([]notionapi.P
 	}
 	return pages, nil
 }
+
+// Lead holds the intake fields of a lead page.
+type Lead struct {
+	Name         string
+	URL          string
+	SalesforceID string
+	Location     string
+}
+
+// CreateQueuedLead creates a lead page with Status = "Queued" so the next
+// batch run picks it up. Empty optional fields are omitted.
+func CreateQueuedLead(ctx context.Context, c Client, dbID string, lead Lead) (*notionapi.Page, error) {
+	props := notionapi.Properties{
+		"Name": notionapi.TitleProperty{
+			Type: notionapi.PropertyTypeTitle,
+			Title: []notionapi.RichText{
+				{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: lead.Name}},
+			},
+		},
+		"URL": notionapi.URLProperty{
+			Type: notionapi.PropertyTypeURL,
+			URL:  lead.URL,
+		},
+		"Status": notionapi.StatusProperty{
+			Status: notionapi.Status{Name: "Queued"},
+		},
+	}
+	for k, v := range map[string]string{"SalesforceID": lead.SalesforceID, "Location": lead.Location} {
+		if v == "" {
+			continue
+		}
+		props[k] = notionapi.RichTextProperty{
+			Type: notionapi.PropertyTypeRichText,
+			RichText: []notionapi.RichText{
+				{Type: notionapi.ObjectTypeText, Text: &notionapi.Text{Content: v}},
+			},
+		}
+	}
+
+	page, err := c.CreatePage(ctx, &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: notionapi.DatabaseID(dbID),
+		},
+		Properties: props,
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "notion: create lead %s", lead.Name)
+	}
+	return page, nil
+}
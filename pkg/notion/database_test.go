@@ -198,3 +198,31 @@ func TestQueryQueuedLeads(t *testing.T) {
 	assert.Len(t, pages, 2)
 	mc.AssertExpectations(t)
 }
+
+func TestCreateQueuedLead(t *testing.T) {
+	mc := new(MockClient)
+	ctx := context.Background()
+
+	mc.On("CreatePage", ctx, mock.MatchedBy(func(req *notionapi.PageCreateRequest) bool {
+		status, ok := req.Properties["Status"].(notionapi.StatusProperty)
+		if !ok || status.Status.Name != "Queued" {
+			return false
+		}
+		u, ok := req.Properties["URL"].(notionapi.URLProperty)
+		if !ok || u.URL != "https://acme.com" {
+			return false
+		}
+		_, hasLoc := req.Properties["Location"]
+		_, hasSF := req.Properties["SalesforceID"]
+		return string(req.Parent.DatabaseID) == "db-leads" && hasSF && !hasLoc
+	})).Return(&notionapi.Page{ID: "page-1"}, nil).Once()
+
+	page, err := CreateQueuedLead(ctx, mc, "db-leads", Lead{
+		Name:         "Acme",
+		URL:          "https://acme.com",
+		SalesforceID: "001000000000001",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, notionapi.ObjectID("page-1"), page.ID)
+	mc.AssertExpectations(t)
+}
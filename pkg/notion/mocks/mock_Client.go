@@ -82,6 +82,65 @@ func (_c *MockClient_CreatePage_Call) RunAndReturn(run func(context.Context, *no
 	return _c
 }
 
+// GetDatabase provides a mock function with given fields: ctx, dbID
+func (_m *MockClient) GetDatabase(ctx context.Context, dbID string) (*notionapi.Database, error) {
+	ret := _m.Called(ctx, dbID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDatabase")
+	}
+
+	var r0 *notionapi.Database
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*notionapi.Database, error)); ok {
+		return rf(ctx, dbID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *notionapi.Database); ok {
+		r0 = rf(ctx, dbID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*notionapi.Database)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dbID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetDatabase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDatabase'
+type MockClient_GetDatabase_Call struct {
+	*mock.Call
+}
+
+// GetDatabase is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbID string
+func (_e *MockClient_Expecter) GetDatabase(ctx interface{}, dbID interface{}) *MockClient_GetDatabase_Call {
+	return &MockClient_GetDatabase_Call{Call: _e.mock.On("GetDatabase", ctx, dbID)}
+}
+
+func (_c *MockClient_GetDatabase_Call) Run(run func(ctx context.Context, dbID string)) *MockClient_GetDatabase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetDatabase_Call) Return(_a0 *notionapi.Database, _a1 error) *MockClient_GetDatabase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetDatabase_Call) RunAndReturn(run func(context.Context, string) (*notionapi.Database, error)) *MockClient_GetDatabase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // QueryDatabase provides a mock function with given fields: ctx, dbID, req
 func (_m *MockClient) QueryDatabase(ctx context.Context, dbID string, req *notionapi.DatabaseQueryRequest) (*notionapi.DatabaseQueryResponse, error) {
 	ret := _m.Called(ctx, dbID, req)
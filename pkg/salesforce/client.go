@@ -42,11 +42,20 @@ type CollectionResult struct {
 
 // SObjectField describes a single field on a Salesforce SObject.
 type SObjectField struct {
-	Name       string `json:"name"`
-	Label      string `json:"label"`
-	Type       string `json:"type"`
-	Length     int    `json:"length"`
-	Updateable bool   `json:"updateable"`
+	Name               string          `json:"name"`
+	Label              string          `json:"label"`
+	Type               string          `json:"type"`
+	Length             int             `json:"length"`
+	Updateable         bool            `json:"updateable"`
+	RestrictedPicklist bool            `json:"restrictedPicklist"`
+	PicklistValues     []PicklistValue `json:"picklistValues"`
+}
+
+// PicklistValue is one entry of a picklist or multipicklist field.
+type PicklistValue struct {
+	Value  string `json:"value"`
+	Label  string `json:"label"`
+	Active bool   `json:"active"`
 }
 
 // SObjectDescription holds metadata about a Salesforce SObject.
@@ -70,6 +70,37 @@ func FindAccountByID(ctx context.Context, c Client, id string) (*Account, error)
 	return &accounts[0], nil
 }
 
+// FindAccountFields queries Salesforce for the current values of the given
+// fields on an Account. Returns nil if no account is found.
+func FindAccountFields(ctx context.Context, c Client, id string, fields []string) (map[string]any, error) {
+	if len(fields) == 0 {
+		return map[string]any{}, nil
+	}
+
+	selectFields := make([]string, 0, len(fields)+1)
+	selectFields = append(selectFields, "Id")
+	for _, f := range fields {
+		if f != "Id" {
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	soql := fmt.Sprintf(
+		"SELECT %s FROM Account WHERE Id = '%s' LIMIT 1",
+		strings.Join(selectFields, ", "),
+		escapeSoql(id),
+	)
+
+	var records []map[string]any
+	if err := c.Query(ctx, soql, &records); err != nil {
+		return nil, eris.Wrap(err, fmt.Sprintf("sf: find account fields %s", id))
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
 // Contact represents a Salesforce Contact record.
 type Contact struct {
 	ID        string `json:"Id" salesforce:"Id"`
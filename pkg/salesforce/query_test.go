@@ -179,3 +179,59 @@ func TestFindContactsByAccountID(t *testing.T) {
 		assert.Contains(t, err.Error(), "find contacts for account")
 	})
 }
+
+func TestFindAccountFields(t *testing.T) {
+	t.Run("returns field values when found", func(t *testing.T) {
+		mock := &mockClient{
+			queryFn: func(_ context.Context, soql string, out any) error {
+				assert.Contains(t, soql, "SELECT Id, Name, Industry FROM Account")
+				assert.Contains(t, soql, "Id = '001xx'")
+
+				records := out.(*[]map[string]any)
+				*records = []map[string]any{
+					{"Id": "001xx", "Name": "Acme Corp", "Industry": nil},
+				}
+				return nil
+			},
+		}
+
+		got, err := FindAccountFields(context.Background(), mock, "001xx", []string{"Name", "Id", "Industry"})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Corp", got["Name"])
+		assert.Nil(t, got["Industry"])
+	})
+
+	t.Run("returns nil when not found", func(t *testing.T) {
+		mock := &mockClient{}
+
+		got, err := FindAccountFields(context.Background(), mock, "001missing", []string{"Name"})
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("skips query when no fields requested", func(t *testing.T) {
+		mock := &mockClient{
+			queryFn: func(_ context.Context, _ string, _ any) error {
+				t.Fatal("query should not be called")
+				return nil
+			},
+		}
+
+		got, err := FindAccountFields(context.Background(), mock, "001xx", nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("returns error on query failure", func(t *testing.T) {
+		mock := &mockClient{
+			queryFn: func(_ context.Context, _ string, _ any) error {
+				return errors.New("timeout")
+			},
+		}
+
+		got, err := FindAccountFields(context.Background(), mock, "001fail", []string{"Name"})
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "find account fields")
+	})
+}